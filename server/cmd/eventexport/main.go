@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"cqrs/cqrsx"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// eventexport MongoDB 이벤트 저장소의 이벤트를 분석용 파일로 내보내는 CLI
+//
+// 사용 예:
+//
+//	go run ./cmd/eventexport -database defense_allies -event-type GuildCreated,GuildJoined \
+//	    -from 2025-01-01T00:00:00Z -to 2025-02-01T00:00:00Z -format csv -out guild-events.csv
+func main() {
+	mongoURI := flag.String("mongo-uri", getEnv("MONGODB_URI", "mongodb://localhost:27017"), "MongoDB 연결 URI")
+	database := flag.String("database", getEnv("MONGODB_DATABASE", "defense_allies"), "MongoDB 데이터베이스 이름")
+	collection := flag.String("collection", "events", "이벤트 컬렉션 이름")
+	format := flag.String("format", "jsonl", fmt.Sprintf("출력 형식 (%s)", strings.Join(cqrsx.EventExportFormats(), ", ")))
+	eventTypes := flag.String("event-type", "", "내보낼 이벤트 타입 (쉼표 구분, 비우면 전체)")
+	aggregateType := flag.String("aggregate-type", "", "내보낼 집합체 타입 (비우면 전체)")
+	from := flag.String("from", "", "시작 시각 (RFC3339, 포함)")
+	to := flag.String("to", "", "종료 시각 (RFC3339, 미포함)")
+	limit := flag.String("limit", "", "최대 이벤트 수 (비우면 무제한)")
+	out := flag.String("out", "", "출력 파일 경로 (비우면 표준 출력)")
+	flag.Parse()
+
+	filter, err := cqrsx.ParseEventExportFilter(url.Values{
+		"event_type":     {*eventTypes},
+		"aggregate_type": {*aggregateType},
+		"from":           {*from},
+		"to":             {*to},
+		"limit":          {*limit},
+	})
+	if err != nil {
+		log.Fatalf("Invalid export filter: %v", err)
+	}
+
+	exportFormat, err := cqrsx.GetEventExportFormat(*format)
+	if err != nil {
+		log.Fatalf("Invalid export format: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := cqrsx.NewMongoClientManager(&cqrsx.MongoConfig{
+		URI:            *mongoURI,
+		Database:       *database,
+		ConnectTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer client.Close(context.Background())
+
+	output := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	store := cqrsx.NewMongoEventStore(client, *collection)
+	started := time.Now()
+
+	count, err := cqrsx.ExportEvents(ctx, store, filter, exportFormat.NewWriter(output))
+	if err != nil {
+		log.Fatalf("Export failed after %d events: %v", count, err)
+	}
+
+	log.Printf("Exported %d events as %s in %v", count, exportFormat.Name, time.Since(started).Round(time.Millisecond))
+}
+
+// getEnv 환경변수 조회 (없으면 기본값)
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
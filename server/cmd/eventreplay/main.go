@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"flag"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// eventreplay 운영 이벤트 스트림 일부를 샘플링해 개인정보를 가린 뒤 스테이징 환경에 재생하는 CLI
+//
+// 집합체 스트림 단위로 샘플링하므로 재생된 집합체는 버전이 끊기지 않습니다.
+// 이미 스테이징에 있는 이벤트는 건너뛰므로 같은 시드로 여러 번 실행해도 안전합니다.
+//
+// 사용 예:
+//
+//	go run ./cmd/eventreplay -source-uri mongodb://prod:27017 -target-uri mongodb://staging:27017 \
+//	    -sample 5 -seed 2025-06 -speed 10 -from 2025-06-01T00:00:00Z -to 2025-06-02T00:00:00Z \
+//	    -redact email=email,phone=phone,nickname=full
+//
+//	# 스테이징에 쓰지 않고 샘플 결과만 JSONL 파일로 확인
+//	go run ./cmd/eventreplay -source-uri mongodb://prod:27017 -sample 1 -dry-run sample.jsonl
+func main() {
+	sourceURI := flag.String("source-uri", getEnv("MONGODB_URI", "mongodb://localhost:27017"), "운영(원본) MongoDB 연결 URI")
+	sourceDatabase := flag.String("source-database", getEnv("MONGODB_DATABASE", "defense_allies"), "원본 데이터베이스 이름")
+	targetURI := flag.String("target-uri", getEnv("STAGING_MONGODB_URI", ""), "스테이징 MongoDB 연결 URI")
+	targetDatabase := flag.String("target-database", getEnv("STAGING_MONGODB_DATABASE", "defense_allies_staging"), "스테이징 데이터베이스 이름")
+	collection := flag.String("collection", "events", "이벤트 컬렉션 이름 (원본/스테이징 공통)")
+	sample := flag.Float64("sample", 1, "샘플링할 집합체 스트림 비율 (퍼센트, 0 초과 100 이하)")
+	seed := flag.String("seed", "", "샘플링 시드 (같은 시드는 같은 스트림을 선택)")
+	speed := flag.Float64("speed", 0, "재생 속도 배율 (1=실시간, 10=10배속, 0=최대 속도)")
+	maxDelay := flag.Duration("max-delay", 5*time.Second, "이벤트 사이 최대 대기 시간")
+	redact := flag.String("redact", "email=email,phone=phone", "가릴 필드=마스커 목록 (마스커: full, email, phone)")
+	eventTypes := flag.String("event-type", "", "재생할 이벤트 타입 (쉼표 구분, 비우면 전체)")
+	aggregateType := flag.String("aggregate-type", "", "재생할 집합체 타입 (비우면 전체)")
+	from := flag.String("from", "", "시작 시각 (RFC3339, 포함)")
+	to := flag.String("to", "", "종료 시각 (RFC3339, 미포함)")
+	limit := flag.String("limit", "", "원본에서 읽을 최대 이벤트 수 (비우면 무제한)")
+	dryRun := flag.String("dry-run", "", "스테이징 대신 JSONL로 기록할 파일 경로 (- 는 표준 출력)")
+	flag.Parse()
+
+	filter, err := cqrsx.ParseEventExportFilter(url.Values{
+		"event_type":     {*eventTypes},
+		"aggregate_type": {*aggregateType},
+		"from":           {*from},
+		"to":             {*to},
+		"limit":          {*limit},
+	})
+	if err != nil {
+		log.Fatalf("Invalid replay filter: %v", err)
+	}
+
+	policy, err := parseRedaction(*redact)
+	if err != nil {
+		log.Fatalf("Invalid redaction rules: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	source, err := connect(*sourceURI, *sourceDatabase)
+	if err != nil {
+		log.Fatalf("Failed to connect to source MongoDB: %v", err)
+	}
+	defer source.Close(context.Background())
+
+	// 재생 대상: dry-run 이면 JSONL 파일, 아니면 스테이징 이벤트 컬렉션
+	var target cqrsx.EventReplayTarget
+	var writer cqrsx.EventExportWriter
+	switch {
+	case *dryRun != "":
+		output := os.Stdout
+		if *dryRun != "-" {
+			file, err := os.Create(*dryRun)
+			if err != nil {
+				log.Fatalf("Failed to create dry-run file: %v", err)
+			}
+			defer file.Close()
+			output = file
+		}
+		writer = cqrsx.NewJSONLExportWriter(output)
+		target = cqrsx.ExportWriterReplayTarget{Writer: writer}
+	case *targetURI == "":
+		log.Fatal("Either -target-uri or -dry-run is required")
+	default:
+		if *targetURI == *sourceURI && *targetDatabase == *sourceDatabase {
+			log.Fatal("Refusing to replay into the source database")
+		}
+		staging, err := connect(*targetURI, *targetDatabase)
+		if err != nil {
+			log.Fatalf("Failed to connect to staging MongoDB: %v", err)
+		}
+		defer staging.Close(context.Background())
+		target = cqrsx.NewMongoEventReplayTarget(staging, *collection)
+	}
+
+	replayer, err := cqrsx.NewEventReplayer(cqrsx.NewMongoEventStore(source, *collection), target, cqrsx.EventReplayConfig{
+		SamplePercent: *sample,
+		Seed:          *seed,
+		Redaction:     policy,
+		Speed:         *speed,
+		MaxDelay:      *maxDelay,
+	})
+	if err != nil {
+		log.Fatalf("Invalid replay config: %v", err)
+	}
+
+	report, err := replayer.Run(ctx, filter)
+	if writer != nil {
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		if report == nil {
+			log.Fatalf("Replay failed: %v", err)
+		}
+		log.Fatalf("Replay failed after %d of %d sampled events: %v", report.Replayed, report.Sampled, err)
+	}
+
+	log.Printf("Replayed %d events from %d streams (%d scanned, %d already present) in %v",
+		report.Replayed, report.Streams, report.Scanned, report.Duplicates, report.Duration.Round(time.Millisecond))
+}
+
+// connect MongoDB 연결 생성
+func connect(uri, database string) (*cqrsx.MongoClientManager, error) {
+	return cqrsx.NewMongoClientManager(&cqrsx.MongoConfig{
+		URI:            uri,
+		Database:       database,
+		ConnectTimeout: 10 * time.Second,
+	})
+}
+
+// parseRedaction "필드=마스커" 목록으로 마스킹 정책 생성 (빈 문자열이면 마스킹 없음)
+func parseRedaction(rules string) (*cqrs.RedactionPolicy, error) {
+	if strings.TrimSpace(rules) == "" {
+		return nil, nil
+	}
+
+	policy := cqrs.NewRedactionPolicy()
+	for _, rule := range strings.Split(rules, ",") {
+		field, masker, found := strings.Cut(strings.TrimSpace(rule), "=")
+		if !found {
+			masker = "full"
+		}
+		if err := policy.RedactField(field, masker); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// getEnv 환경변수 조회 (없으면 기본값)
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
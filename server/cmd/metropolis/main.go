@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"defense-allies-server/configs"
+	"defense-allies-server/serverapp"
 	"defense-allies-server/serverapp/timesquare"
 )
 
@@ -36,36 +37,38 @@ func main() {
 		log.Fatalf("Failed to create TimeSquareApp: %v", err)
 	}
 
-	// HTTP Mux 생성
-	mux := http.NewServeMux()
+	// ServerApp 매니저 생성 및 앱 등록
+	manager := serverapp.NewManager()
+	mux := manager.GetMux()
 
 	// 기본 라우트 추가
 	mux.HandleFunc("/", homeHandler)
 
-	// TimeSquareApp 라우트 등록
-	timeSquareApp.RegisterRoutes(mux)
-
-	// TimeSquareApp 시작
-	ctx := context.Background()
-	if err := timeSquareApp.Start(ctx); err != nil {
-		log.Fatalf("Failed to start TimeSquareApp: %v", err)
+	// TimeSquareApp 등록 (라우트 등록 포함)
+	if err := manager.Register(timeSquareApp); err != nil {
+		log.Fatalf("Failed to register TimeSquareApp: %v", err)
 	}
 
-	// HTTP 서버 설정
+	// HTTP 서버 설정 (모든 앱이 준비되기 전까지 503 응답)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", globalConfig.Server.Host, globalConfig.Server.Port),
-		Handler: mux,
+		Handler: manager.ReadinessGate(mux),
 	}
 
 	// 서버 시작
 	go func() {
 		fmt.Printf("🚀 Metropolis TimeSquare Server starting on port %d\n", globalConfig.Server.Port)
-		fmt.Println("🎮 Ready to welcome players to the square!")
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
 		}
 	}()
 
+	// 의존성 순서대로 서버앱 시작
+	if err := manager.StartAll(context.Background()); err != nil {
+		log.Fatalf("Failed to start server apps: %v", err)
+	}
+	fmt.Println("🎮 Ready to welcome players to the square!")
+
 	// Graceful shutdown 설정
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -83,9 +86,9 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
-	// TimeSquareApp 종료
-	if err := timeSquareApp.Stop(ctx); err != nil {
-		log.Printf("Error stopping TimeSquareApp: %v", err)
+	// 서버앱 역순 종료
+	if err := manager.StopAll(ctx); err != nil {
+		log.Printf("Error stopping server apps: %v", err)
 	}
 
 	fmt.Println("🌙 Metropolis has gone to sleep. Good night!")
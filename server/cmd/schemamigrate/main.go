@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"cqrs/cqrsx"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// schemamigrate 이벤트 저장소 스키마(인덱스, 컬렉션 옵션) 마이그레이션을 배포 단계에서 적용하는 CLI
+// 적용한 버전은 schema_migrations 컬렉션에 기록되어 서버 시작 시 임의로 스키마를 만들 필요가 없습니다
+//
+// 사용 예:
+//
+//	go run ./cmd/schemamigrate -database defense_allies -dry-run   # 적용할 마이그레이션만 출력
+//	go run ./cmd/schemamigrate -database defense_allies            # 최신 버전까지 적용
+//	go run ./cmd/schemamigrate -database defense_allies -target 1  # 1번까지만 적용
+func main() {
+	mongoURI := flag.String("mongo-uri", getEnv("MONGODB_URI", "mongodb://localhost:27017"), "MongoDB 연결 URI")
+	database := flag.String("database", getEnv("MONGODB_DATABASE", "defense_allies"), "MongoDB 데이터베이스 이름")
+	prefix := flag.String("collection-prefix", getEnv("MONGODB_COLLECTION_PREFIX", ""), "컬렉션 이름 접두사")
+	recordCollection := flag.String("record-collection", "", "적용 기록 컬렉션 (비우면 schema_migrations)")
+	dryRun := flag.Bool("dry-run", false, "적용하지 않고 대기 중인 마이그레이션과 단계만 출력")
+	target := flag.Int("target", 0, "적용할 최고 버전 (0이면 최신)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := cqrsx.NewMongoClientManagerWithPrefix(&cqrsx.MongoConfig{
+		URI:            *mongoURI,
+		Database:       *database,
+		ConnectTimeout: 10 * time.Second,
+	}, *prefix)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer client.Close(context.Background())
+
+	runner, err := cqrsx.NewSchemaMigrationRunner(
+		cqrsx.NewMongoSchemaMigrationStore(client, *recordCollection),
+		cqrsx.DefaultMongoSchemaMigrations(client)...,
+	)
+	if err != nil {
+		log.Fatalf("Invalid schema migrations: %v", err)
+	}
+
+	report, err := runner.Run(ctx, cqrsx.SchemaMigrationOptions{DryRun: *dryRun, Target: *target})
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	}
+	if err != nil {
+		log.Fatalf("Schema migration failed: %v", err)
+	}
+
+	if *dryRun {
+		log.Printf("Dry run: schema at version %d, %d migration(s) pending (latest %d)", report.CurrentVersion, len(report.Planned), runner.LatestVersion())
+		return
+	}
+	log.Printf("Schema at version %d, applied %d migration(s)", report.CurrentVersion, len(report.Applied))
+}
+
+// getEnv 환경변수 조회 (없으면 기본값)
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -1,11 +1,14 @@
 package configs
 
 import (
+	"cqrs"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+
+	"defense-allies-server/serverapp/i18n"
 )
 
 // Config 전체 애플리케이션 설정
@@ -15,6 +18,12 @@ type Config struct {
 	TimeSquare TimeSquareConfig `json:"timesquare"`
 	Guardian   GuardianConfig   `json:"guardian"`
 	Logging    LoggingConfig    `json:"logging"`
+	// CommandThrottles 애그리게이트별 명령 빈도 제한 (cqrs.ThrottlingDispatcher 규칙)
+	CommandThrottles []cqrs.CommandThrottleRule `json:"command_throttles"`
+	// DailyResets 지역 시간대별 일일 초기화 일정 (cqrs.DailyResetScheduler 설정)
+	DailyResets []cqrs.DailyResetSchedule `json:"daily_resets"`
+	// Localization 서버 메시지 카탈로그 (i18n.NewCatalog 설정, catalog_dir의 <로케일>.json)
+	Localization i18n.CatalogConfig `json:"localization"`
 }
 
 // ServerConfig 공용 서버 설정
@@ -97,6 +106,16 @@ func loadFromJSON(configPath string) (*Config, error) {
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config JSON: %w", err)
 	}
+	for _, rule := range config.CommandThrottles {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid command throttle: %w", err)
+		}
+	}
+	for _, schedule := range config.DailyResets {
+		if err := schedule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid daily reset: %w", err)
+		}
+	}
 
 	return &config, nil
 }
@@ -170,6 +189,16 @@ func (c *Config) GetRedisURL(topic string) string {
 	return c.Redis.Default
 }
 
+// NewCommandDispatcher CommandThrottles 규칙을 적용한 커맨드 디스패처를 생성합니다.
+// 서버앱에 주입하는 디스패처는 이 함수로 감싸야 설정 파일의 명령 빈도 제한이 적용됩니다.
+// counter가 nil이면 프로세스 메모리 카운터를 사용합니다 (여러 인스턴스는 cqrsx.RedisThrottleCounter 사용)
+func (c *Config) NewCommandDispatcher(dispatcher cqrs.CommandDispatcher, counter cqrs.ThrottleCounter) (*cqrs.ThrottlingDispatcher, error) {
+	return cqrs.NewThrottlingDispatcher(dispatcher, cqrs.CommandThrottleConfig{
+		Rules:   c.CommandThrottles,
+		Counter: counter,
+	})
+}
+
 // getEnv 환경변수를 가져오거나 기본값을 반환합니다
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
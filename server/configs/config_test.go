@@ -0,0 +1,55 @@
+package configs
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptingHandler 모든 명령을 성공시키는 테스트 핸들러
+type acceptingHandler struct{}
+
+func (acceptingHandler) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	return &cqrs.CommandResult{Success: true, AggregateID: command.ID()}, nil
+}
+
+func (acceptingHandler) CanHandle(commandType string) bool { return true }
+
+func (acceptingHandler) GetHandlerName() string { return "accepting" }
+
+func TestConfig_NewCommandDispatcherAppliesCommandThrottles(t *testing.T) {
+	// Arrange - config.json은 길드당 InviteMember를 분당 5회로 제한
+	config, err := LoadConfigFromPath("config.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, config.CommandThrottles)
+
+	inner := cqrs.NewInMemoryCommandDispatcher()
+	require.NoError(t, inner.RegisterHandler("InviteMember", acceptingHandler{}))
+	dispatcher, err := config.NewCommandDispatcher(inner, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	invite := func(guildID string) *cqrs.CommandResult {
+		result, err := dispatcher.Dispatch(ctx, cqrs.NewBaseCommand("InviteMember", guildID, "Guild", nil))
+		require.NoError(t, err)
+		return result
+	}
+
+	// Act
+	for i := 0; i < 5; i++ {
+		require.True(t, invite("guild-1").Success)
+	}
+	throttled := invite("guild-1")
+	otherGuild := invite("guild-2")
+
+	// Assert
+	assert.Equal(t, config.CommandThrottles, dispatcher.Rules())
+	require.False(t, throttled.Success)
+	assert.True(t, cqrs.IsRateLimitedError(throttled.Error))
+	_, ok := cqrs.ThrottleRetryAfter(throttled.Error)
+	assert.True(t, ok)
+	assert.True(t, otherGuild.Success)
+}
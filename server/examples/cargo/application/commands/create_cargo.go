@@ -5,17 +5,18 @@ import (
 	"time"
 
 	"cqrs"
+	"cqrs/validation"
 
 	"github.com/google/uuid"
 )
 
 // CreateCargoCommandData contains the data for creating a cargo
 type CreateCargoCommandData struct {
-	CargoID     string  `json:"cargo_id"`
-	Origin      string  `json:"origin"`
-	Destination string  `json:"destination"`
-	MaxWeight   float64 `json:"max_weight"`
-	MaxVolume   float64 `json:"max_volume"`
+	CargoID     string  `json:"cargo_id" validate:"required"`
+	Origin      string  `json:"origin" validate:"required"`
+	Destination string  `json:"destination" validate:"required"`
+	MaxWeight   float64 `json:"max_weight" validate:"gt=0"`
+	MaxVolume   float64 `json:"max_volume" validate:"gt=0"`
 }
 
 // CreateCargoCommand represents a command to create a new cargo
@@ -103,23 +104,11 @@ func (c *CreateCargoCommand) Validate() error {
 		return err
 	}
 
-	if c.Data.CargoID == "" {
-		return fmt.Errorf("cargo ID cannot be empty")
-	}
-	if c.Data.Origin == "" {
-		return fmt.Errorf("origin cannot be empty")
-	}
-	if c.Data.Destination == "" {
-		return fmt.Errorf("destination cannot be empty")
+	if err := validation.Struct(c); err != nil {
+		return err
 	}
 	if c.Data.Origin == c.Data.Destination {
-		return fmt.Errorf("origin and destination cannot be the same")
-	}
-	if c.Data.MaxWeight <= 0 {
-		return fmt.Errorf("max weight must be positive")
-	}
-	if c.Data.MaxVolume <= 0 {
-		return fmt.Errorf("max volume must be positive")
+		return cqrs.NewValidationError("origin and destination cannot be the same", nil)
 	}
 
 	return nil
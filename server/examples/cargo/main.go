@@ -21,23 +21,34 @@ func main() {
 	// Initialize CQRS infrastructure
 	ctx := context.Background()
 
-	// Create in-memory repository for this example
-	repository := NewInMemoryCargoRepository()
-
-	// Create command dispatcher
-	commandDispatcher := cqrs.NewInMemoryCommandDispatcher()
-
-	// Create and register command handler
-	cargoHandler := handlers.NewCargoCommandHandler(repository)
-	if err := commandDispatcher.RegisterHandler("CreateCargo", cargoHandler); err != nil {
-		log.Fatalf("Failed to register CreateCargo handler: %v", err)
+	// Register components with their dependencies; Bootstrap resolves them
+	// and registers the handlers in the command dispatcher
+	container := cqrs.NewContainer()
+	if err := cqrs.ProvideValue[cqrs.CommandDispatcher](container, cqrs.NewInMemoryCommandDispatcher()); err != nil {
+		log.Fatalf("Failed to provide command dispatcher: %v", err)
+	}
+	if err := cqrs.ProvideValue[cqrs.EventBus](container, cqrs.NewInMemoryEventBus()); err != nil {
+		log.Fatalf("Failed to provide event bus: %v", err)
+	}
+	if err := cqrs.ProvideValue[cqrs.EventSourcedRepository](container, NewInMemoryCargoRepository()); err != nil {
+		log.Fatalf("Failed to provide cargo repository: %v", err)
+	}
+	err := cqrs.ProvideCommandHandler(container, func(r cqrs.Resolver) (*handlers.CargoCommandHandler, error) {
+		repository, err := cqrs.Resolve[cqrs.EventSourcedRepository](r)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewCargoCommandHandler(repository), nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to provide cargo command handler: %v", err)
 	}
-	if err := commandDispatcher.RegisterHandler("LoadShipment", cargoHandler); err != nil {
-		log.Fatalf("Failed to register LoadShipment handler: %v", err)
+	if err := container.Bootstrap(); err != nil {
+		log.Fatalf("Failed to bootstrap container: %v", err)
 	}
 
-	// Create event bus for projections
-	eventBus := cqrs.NewInMemoryEventBus()
+	// Start event bus for projections
+	eventBus := cqrs.MustResolve[cqrs.EventBus](container)
 	if err := eventBus.Start(ctx); err != nil {
 		log.Fatalf("Failed to start event bus: %v", err)
 	}
@@ -46,7 +57,7 @@ func main() {
 	fmt.Println("\n✅ CQRS Infrastructure initialized successfully")
 
 	// Run the cargo transport example
-	if err := runCargoExample(ctx, commandDispatcher, repository); err != nil {
+	if err := runCargoExample(ctx, cqrs.MustResolve[cqrs.CommandDispatcher](container), cqrs.MustResolve[cqrs.EventSourcedRepository](container)); err != nil {
 		log.Fatalf("Example failed: %v", err)
 	}
 
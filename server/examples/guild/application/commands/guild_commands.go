@@ -2,8 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"time"
 
 	"cqrs"
+	"cqrs/validation"
 )
 
 // Command type constants
@@ -13,6 +15,8 @@ const (
 	UpdateGuildInfoCommandType     = "UpdateGuildInfo"
 	UpdateGuildSettingsCommandType = "UpdateGuildSettings"
 	DisbandGuildCommandType        = "DisbandGuild"
+	UpdateGuildEmblemCommandType   = "UpdateGuildEmblem"
+	UpdateGuildLocaleCommandType   = "UpdateGuildLocale"
 
 	// Member management commands
 	InviteMemberCommandType     = "InviteMember"
@@ -22,6 +26,15 @@ const (
 	KickMemberCommandType       = "KickMember"
 	PromoteMemberCommandType    = "PromoteMember"
 	DemoteMemberCommandType     = "DemoteMember"
+
+	// Treasury commands
+	DonateToTreasuryCommandType = "DonateToTreasury"
+
+	// Transport commands
+	ProcessExpiredRecruitmentCommandType = "ProcessExpiredRecruitment"
+
+	// Chat commands
+	PostChatMessageCommandType = "PostChatMessage"
 )
 
 // Guild Management Commands
@@ -29,10 +42,10 @@ const (
 // CreateGuildCommand represents a command to create a new guild
 type CreateGuildCommand struct {
 	*cqrs.BaseCommand
-	Name            string `json:"name"`
+	Name            string `json:"name" validate:"required,min=3,max=50"`
 	Description     string `json:"description"`
-	FounderID       string `json:"founder_id"`
-	FounderUsername string `json:"founder_username"`
+	FounderID       string `json:"founder_id" validate:"required"`
+	FounderUsername string `json:"founder_username" validate:"required"`
 }
 
 // NewCreateGuildCommand creates a new CreateGuildCommand
@@ -58,19 +71,7 @@ func NewCreateGuildCommand(guildID, name, description, founderID, founderUsernam
 
 // Validate validates the create guild command
 func (c *CreateGuildCommand) Validate() error {
-	if c.Name == "" {
-		return fmt.Errorf("guild name cannot be empty")
-	}
-	if len(c.Name) < 3 || len(c.Name) > 50 {
-		return fmt.Errorf("guild name must be between 3 and 50 characters")
-	}
-	if c.FounderID == "" {
-		return fmt.Errorf("founder ID cannot be empty")
-	}
-	if c.FounderUsername == "" {
-		return fmt.Errorf("founder username cannot be empty")
-	}
-	return nil
+	return validation.Struct(c)
 }
 
 // UpdateGuildInfoCommand represents a command to update guild information
@@ -123,6 +124,85 @@ func (c *UpdateGuildInfoCommand) Validate() error {
 	return nil
 }
 
+// UpdateGuildEmblemCommand represents a command to link an uploaded emblem to the guild
+type UpdateGuildEmblemCommand struct {
+	*cqrs.BaseCommand
+	ObjectKey string `json:"object_key"` // Returned by the asset upload ticket
+	UpdatedBy string `json:"updated_by"`
+}
+
+// NewUpdateGuildEmblemCommand creates a new UpdateGuildEmblemCommand
+func NewUpdateGuildEmblemCommand(guildID, objectKey, updatedBy string) *UpdateGuildEmblemCommand {
+	return &UpdateGuildEmblemCommand{
+		BaseCommand: cqrs.NewBaseCommand(
+			UpdateGuildEmblemCommandType,
+			guildID,
+			"Guild",
+			map[string]interface{}{
+				"object_key": objectKey,
+				"updated_by": updatedBy,
+			},
+		),
+		ObjectKey: objectKey,
+		UpdatedBy: updatedBy,
+	}
+}
+
+// Validate validates the update guild emblem command
+func (c *UpdateGuildEmblemCommand) Validate() error {
+	if c.ObjectKey == "" {
+		return fmt.Errorf("emblem object key cannot be empty")
+	}
+	if c.UpdatedBy == "" {
+		return fmt.Errorf("updated by cannot be empty")
+	}
+	return nil
+}
+
+// UpdateGuildLocaleCommand represents a command to set the region and language of a guild
+type UpdateGuildLocaleCommand struct {
+	*cqrs.BaseCommand
+	Region    string `json:"region"`   // e.g. "eu-west"
+	Language  string `json:"language"` // ISO 639-1 code, e.g. "ko"
+	UpdatedBy string `json:"updated_by"`
+}
+
+// NewUpdateGuildLocaleCommand creates a new UpdateGuildLocaleCommand
+func NewUpdateGuildLocaleCommand(guildID, region, language, updatedBy string) *UpdateGuildLocaleCommand {
+	return &UpdateGuildLocaleCommand{
+		BaseCommand: cqrs.NewBaseCommand(
+			UpdateGuildLocaleCommandType,
+			guildID,
+			"Guild",
+			map[string]interface{}{
+				"region":     region,
+				"language":   language,
+				"updated_by": updatedBy,
+			},
+		),
+		Region:    region,
+		Language:  language,
+		UpdatedBy: updatedBy,
+	}
+}
+
+// Validate validates the update guild locale command
+func (c *UpdateGuildLocaleCommand) Validate() error {
+	if c.Region == "" && c.Language == "" {
+		return fmt.Errorf("region or language must be set")
+	}
+	if len(c.Region) > 32 {
+		return fmt.Errorf("region cannot be longer than 32 characters")
+	}
+	if c.Language != "" && len(c.Language) != 2 {
+		return fmt.Errorf("language must be a two-letter ISO 639-1 code")
+	}
+	if c.UpdatedBy == "" {
+		return fmt.Errorf("updated by cannot be empty")
+	}
+	return nil
+}
+
 // UpdateGuildSettingsCommand represents a command to update guild settings
 type UpdateGuildSettingsCommand struct {
 	*cqrs.BaseCommand
@@ -217,6 +297,12 @@ func (c *InviteMemberCommand) Validate() error {
 	return nil
 }
 
+// References declares the invited user, which must exist when a
+// cqrs.ReferenceValidatingDispatcher with a "User" checker is in front of the handler
+func (c *InviteMemberCommand) References() []cqrs.Reference {
+	return []cqrs.Reference{cqrs.NewReference("User", c.UserID())}
+}
+
 // AcceptInvitationCommand represents a command to accept a guild invitation
 type AcceptInvitationCommand struct {
 	*cqrs.BaseCommand
@@ -332,3 +418,134 @@ func (c *PromoteMemberCommand) Validate() error {
 	}
 	return nil
 }
+
+// Treasury Commands
+
+// DonateToTreasuryCommand represents a command to donate to the guild treasury
+type DonateToTreasuryCommand struct {
+	*cqrs.BaseCommand
+	Amount int64 `json:"amount"`
+}
+
+// NewDonateToTreasuryCommand creates a new DonateToTreasuryCommand
+func NewDonateToTreasuryCommand(guildID, userID string, amount int64) *DonateToTreasuryCommand {
+	cmd := &DonateToTreasuryCommand{
+		BaseCommand: cqrs.NewBaseCommand(
+			DonateToTreasuryCommandType,
+			guildID,
+			"Guild",
+			map[string]interface{}{
+				"user_id": userID,
+				"amount":  amount,
+			},
+		),
+		Amount: amount,
+	}
+
+	cmd.SetUserID(userID)
+	return cmd
+}
+
+// Validate validates the donate to treasury command
+func (c *DonateToTreasuryCommand) Validate() error {
+	if c.UserID() == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+	if c.Amount <= 0 {
+		return fmt.Errorf("donation amount must be positive")
+	}
+	return nil
+}
+
+// Transport Commands
+
+// ProcessExpiredRecruitmentCommand represents a command to start or cancel a recruitment whose window has closed
+type ProcessExpiredRecruitmentCommand struct {
+	*cqrs.BaseCommand
+	RecruitmentID string    `json:"recruitment_id"`
+	TransportID   string    `json:"transport_id"` // Used if the transport starts
+	ProcessedAt   time.Time `json:"processed_at"`
+}
+
+// NewProcessExpiredRecruitmentCommand creates a new ProcessExpiredRecruitmentCommand
+func NewProcessExpiredRecruitmentCommand(guildID, recruitmentID, transportID string, processedAt time.Time) *ProcessExpiredRecruitmentCommand {
+	return &ProcessExpiredRecruitmentCommand{
+		BaseCommand: cqrs.NewBaseCommand(
+			ProcessExpiredRecruitmentCommandType,
+			guildID,
+			"Guild",
+			map[string]interface{}{
+				"recruitment_id": recruitmentID,
+				"transport_id":   transportID,
+				"processed_at":   processedAt,
+			},
+		),
+		RecruitmentID: recruitmentID,
+		TransportID:   transportID,
+		ProcessedAt:   processedAt,
+	}
+}
+
+// Validate validates the process expired recruitment command
+func (c *ProcessExpiredRecruitmentCommand) Validate() error {
+	if c.RecruitmentID == "" {
+		return fmt.Errorf("recruitment ID cannot be empty")
+	}
+	if c.TransportID == "" {
+		return fmt.Errorf("transport ID cannot be empty")
+	}
+	if c.ProcessedAt.IsZero() {
+		return fmt.Errorf("processed time cannot be empty")
+	}
+	return nil
+}
+
+// Chat Commands
+
+// maxChatMessageLength is the longest chat message accepted, in characters
+const maxChatMessageLength = 500
+
+// PostChatMessageCommand represents a command to post a message to the guild chat
+type PostChatMessageCommand struct {
+	*cqrs.BaseCommand
+	MessageID string `json:"message_id"`
+	Text      string `json:"text"`
+}
+
+// NewPostChatMessageCommand creates a new PostChatMessageCommand
+func NewPostChatMessageCommand(guildID, messageID, userID, text string) *PostChatMessageCommand {
+	cmd := &PostChatMessageCommand{
+		BaseCommand: cqrs.NewBaseCommand(
+			PostChatMessageCommandType,
+			guildID,
+			"Guild",
+			map[string]interface{}{
+				"message_id": messageID,
+				"user_id":    userID,
+				"text":       text,
+			},
+		),
+		MessageID: messageID,
+		Text:      text,
+	}
+
+	cmd.SetUserID(userID)
+	return cmd
+}
+
+// Validate validates the post chat message command
+func (c *PostChatMessageCommand) Validate() error {
+	if c.MessageID == "" {
+		return fmt.Errorf("message ID cannot be empty")
+	}
+	if c.UserID() == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+	if c.Text == "" {
+		return fmt.Errorf("message text cannot be empty")
+	}
+	if len([]rune(c.Text)) > maxChatMessageLength {
+		return fmt.Errorf("message text cannot be longer than %d characters", maxChatMessageLength)
+	}
+	return nil
+}
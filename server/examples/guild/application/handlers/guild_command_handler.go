@@ -7,12 +7,17 @@ import (
 	"cqrs"
 	"defense-allies-server/examples/guild/application/commands"
 	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/moderation"
 )
 
 // GuildCommandHandler handles guild-related commands
 type GuildCommandHandler struct {
 	*cqrs.BaseCommandHandler
-	repository cqrs.EventSourcedRepository
+	repository  cqrs.EventSourcedRepository
+	moderation  moderation.Filter              // optional; nil skips moderation
+	economy     domain.EconomyConfigProvider   // optional; nil uses the default economy
+	progression *domain.GuildProgressionConfig // optional; nil uses the default level rules
+	emblems     domain.EmblemVerifier          // optional; nil rejects emblem updates
 }
 
 // NewGuildCommandHandler creates a new GuildCommandHandler
@@ -21,10 +26,15 @@ func NewGuildCommandHandler(repository cqrs.EventSourcedRepository) *GuildComman
 		commands.CreateGuildCommandType,
 		commands.UpdateGuildInfoCommandType,
 		commands.UpdateGuildSettingsCommandType,
+		commands.UpdateGuildEmblemCommandType,
+		commands.UpdateGuildLocaleCommandType,
 		commands.InviteMemberCommandType,
 		commands.AcceptInvitationCommandType,
 		commands.KickMemberCommandType,
 		commands.PromoteMemberCommandType,
+		commands.DonateToTreasuryCommandType,
+		commands.ProcessExpiredRecruitmentCommandType,
+		commands.PostChatMessageCommandType,
 	}
 
 	return &GuildCommandHandler{
@@ -33,6 +43,26 @@ func NewGuildCommandHandler(repository cqrs.EventSourcedRepository) *GuildComman
 	}
 }
 
+// SetModerationFilter installs the filter applied to guild info updates and chat messages
+func (h *GuildCommandHandler) SetModerationFilter(filter moderation.Filter) {
+	h.moderation = filter
+}
+
+// SetEconomyConfigProvider installs the economy config used by mining and transport commands
+func (h *GuildCommandHandler) SetEconomyConfigProvider(provider domain.EconomyConfigProvider) {
+	h.economy = provider
+}
+
+// SetProgressionConfig installs the experience and level-up rules
+func (h *GuildCommandHandler) SetProgressionConfig(config *domain.GuildProgressionConfig) {
+	h.progression = config
+}
+
+// SetEmblemVerifier installs the asset check used before an uploaded emblem is linked
+func (h *GuildCommandHandler) SetEmblemVerifier(verifier domain.EmblemVerifier) {
+	h.emblems = verifier
+}
+
 // Handle handles the incoming command
 func (h *GuildCommandHandler) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
 	// Validate command
@@ -47,6 +77,10 @@ func (h *GuildCommandHandler) Handle(ctx context.Context, command cqrs.Command)
 		return h.handleUpdateGuildInfo(ctx, cmd)
 	case *commands.UpdateGuildSettingsCommand:
 		return h.handleUpdateGuildSettings(ctx, cmd)
+	case *commands.UpdateGuildEmblemCommand:
+		return h.handleUpdateGuildEmblem(ctx, cmd)
+	case *commands.UpdateGuildLocaleCommand:
+		return h.handleUpdateGuildLocale(ctx, cmd)
 	case *commands.InviteMemberCommand:
 		return h.handleInviteMember(ctx, cmd)
 	case *commands.AcceptInvitationCommand:
@@ -55,6 +89,12 @@ func (h *GuildCommandHandler) Handle(ctx context.Context, command cqrs.Command)
 		return h.handleKickMember(ctx, cmd)
 	case *commands.PromoteMemberCommand:
 		return h.handlePromoteMember(ctx, cmd)
+	case *commands.DonateToTreasuryCommand:
+		return h.handleDonateToTreasury(ctx, cmd)
+	case *commands.ProcessExpiredRecruitmentCommand:
+		return h.handleProcessExpiredRecruitment(ctx, cmd)
+	case *commands.PostChatMessageCommand:
+		return h.handlePostChatMessage(ctx, cmd)
 	default:
 		return nil, fmt.Errorf("unsupported command type: %s", command.CommandType())
 	}
@@ -93,8 +133,8 @@ func (h *GuildCommandHandler) handleCreateGuild(ctx context.Context, cmd *comman
 		Data: map[string]interface{}{
 			"guild_id": cmd.ID(),
 			"name":     cmd.Name,
-			"message":  "Guild created successfully",
 		},
+		Message: "Guild created successfully",
 	}, nil
 }
 
@@ -106,11 +146,42 @@ func (h *GuildCommandHandler) handleUpdateGuildInfo(ctx context.Context, cmd *co
 		return nil, err
 	}
 
+	// Moderate user-visible text before changing anything
+	fields := []struct {
+		key  string
+		kind domain.ContentKind
+		text string
+	}{
+		{"name", domain.ContentGuildName, cmd.Name},
+		{"description", domain.ContentGuildDescription, cmd.Description},
+		{"notice", domain.ContentGuildNotice, cmd.Notice},
+	}
+	flagged := make(map[string]moderation.Result)
+	for _, field := range fields {
+		if field.text == "" {
+			continue
+		}
+		result, err := h.moderate(ctx, cmd.ID(), cmd.UpdatedBy, field.kind, field.text)
+		if err != nil {
+			return nil, err
+		}
+		if result.Verdict == moderation.VerdictFlag {
+			flagged[field.key] = result
+		}
+	}
+
 	// Update guild info
 	if err := guild.UpdateInfo(cmd.Name, cmd.Description, cmd.Notice, cmd.Tag, cmd.UpdatedBy); err != nil {
 		return nil, fmt.Errorf("failed to update guild info: %w", err)
 	}
 
+	// Queue flagged fields for review
+	for _, field := range fields {
+		if result, ok := flagged[field.key]; ok {
+			guild.FlagContent(field.key, field.kind, cmd.UpdatedBy, field.text, result.Reasons)
+		}
+	}
+
 	// Save the guild
 	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
 		return nil, fmt.Errorf("failed to save guild: %w", err)
@@ -119,9 +190,7 @@ func (h *GuildCommandHandler) handleUpdateGuildInfo(ctx context.Context, cmd *co
 	return &cqrs.CommandResult{
 		AggregateID: cmd.ID(),
 		Success:     true,
-		Data: map[string]interface{}{
-			"message": "Guild info updated successfully",
-		},
+		Message:     "Guild info updated successfully",
 	}, nil
 }
 
@@ -146,9 +215,7 @@ func (h *GuildCommandHandler) handleUpdateGuildSettings(ctx context.Context, cmd
 	return &cqrs.CommandResult{
 		AggregateID: cmd.ID(),
 		Success:     true,
-		Data: map[string]interface{}{
-			"message": "Guild settings updated successfully",
-		},
+		Message:     "Guild settings updated successfully",
 	}, nil
 }
 
@@ -177,8 +244,8 @@ func (h *GuildCommandHandler) handleInviteMember(ctx context.Context, cmd *comma
 			"user_id":    cmd.UserID(),
 			"username":   cmd.Username,
 			"invited_by": cmd.InvitedBy,
-			"message":    "Member invited successfully",
 		},
+		Message: "Member invited successfully",
 	}, nil
 }
 
@@ -205,8 +272,8 @@ func (h *GuildCommandHandler) handleAcceptInvitation(ctx context.Context, cmd *c
 		Success:     true,
 		Data: map[string]interface{}{
 			"user_id": cmd.UserID(),
-			"message": "Invitation accepted successfully",
 		},
+		Message: "Invitation accepted successfully",
 	}, nil
 }
 
@@ -235,8 +302,8 @@ func (h *GuildCommandHandler) handleKickMember(ctx context.Context, cmd *command
 			"user_id":   cmd.UserID(),
 			"kicked_by": cmd.KickedBy,
 			"reason":    cmd.Reason,
-			"message":   "Member kicked successfully",
 		},
+		Message: "Member kicked successfully",
 	}, nil
 }
 
@@ -271,11 +338,189 @@ func (h *GuildCommandHandler) handlePromoteMember(ctx context.Context, cmd *comm
 			"user_id":     cmd.UserID(),
 			"new_role":    cmd.NewRole,
 			"promoted_by": cmd.PromotedBy,
-			"message":     "Member promoted successfully",
 		},
+		Message: "Member promoted successfully",
 	}, nil
 }
 
+// handleDonateToTreasury handles the DonateToTreasuryCommand
+func (h *GuildCommandHandler) handleDonateToTreasury(ctx context.Context, cmd *commands.DonateToTreasuryCommand) (*cqrs.CommandResult, error) {
+	// Load guild aggregate
+	guild, err := h.loadGuild(ctx, cmd.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Donate
+	if err := guild.DonateToTreasury(cmd.UserID(), cmd.Amount); err != nil {
+		return nil, fmt.Errorf("failed to donate to treasury: %w", err)
+	}
+
+	// Save the guild
+	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
+		return nil, fmt.Errorf("failed to save guild: %w", err)
+	}
+
+	return &cqrs.CommandResult{
+		AggregateID: cmd.ID(),
+		Success:     true,
+		Data: map[string]interface{}{
+			"user_id":  cmd.UserID(),
+			"amount":   cmd.Amount,
+			"treasury": guild.GetTreasury(),
+		},
+		Message: "Donation received successfully",
+	}, nil
+}
+
+// handleProcessExpiredRecruitment handles the ProcessExpiredRecruitmentCommand
+func (h *GuildCommandHandler) handleProcessExpiredRecruitment(ctx context.Context, cmd *commands.ProcessExpiredRecruitmentCommand) (*cqrs.CommandResult, error) {
+	// Load guild aggregate
+	guild, err := h.loadGuild(ctx, cmd.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Start or cancel the recruitment
+	started, err := guild.ProcessExpiredRecruitment(cmd.RecruitmentID, cmd.TransportID, cmd.ProcessedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process expired recruitment: %w", err)
+	}
+
+	// Save the guild
+	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
+		return nil, fmt.Errorf("failed to save guild: %w", err)
+	}
+
+	message := "Recruitment cancelled for lack of participants"
+	if started {
+		message = "Transport started from expired recruitment"
+	}
+
+	return &cqrs.CommandResult{
+		AggregateID: cmd.ID(),
+		Success:     true,
+		Data: map[string]interface{}{
+			"recruitment_id": cmd.RecruitmentID,
+			"transport_id":   cmd.TransportID,
+			"started":        started,
+		},
+		Message: message,
+	}, nil
+}
+
+// handlePostChatMessage handles the PostChatMessageCommand
+func (h *GuildCommandHandler) handlePostChatMessage(ctx context.Context, cmd *commands.PostChatMessageCommand) (*cqrs.CommandResult, error) {
+	// Load guild aggregate
+	guild, err := h.loadGuild(ctx, cmd.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Moderate the message
+	result, err := h.moderate(ctx, cmd.ID(), cmd.UserID(), domain.ContentChatMessage, cmd.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Post message
+	if err := guild.PostChatMessage(cmd.MessageID, cmd.UserID(), cmd.Text); err != nil {
+		return nil, fmt.Errorf("failed to post chat message: %w", err)
+	}
+	if result.Verdict == moderation.VerdictFlag {
+		guild.FlagContent(cmd.MessageID, domain.ContentChatMessage, cmd.UserID(), cmd.Text, result.Reasons)
+	}
+
+	// Save the guild
+	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
+		return nil, fmt.Errorf("failed to save guild: %w", err)
+	}
+
+	return &cqrs.CommandResult{
+		AggregateID: cmd.ID(),
+		Success:     true,
+		Data: map[string]interface{}{
+			"message_id": cmd.MessageID,
+			"flagged":    result.Verdict == moderation.VerdictFlag,
+		},
+		Message: "Chat message posted successfully",
+	}, nil
+}
+
+// handleUpdateGuildLocale handles the UpdateGuildLocaleCommand
+func (h *GuildCommandHandler) handleUpdateGuildLocale(ctx context.Context, cmd *commands.UpdateGuildLocaleCommand) (*cqrs.CommandResult, error) {
+	// Load guild aggregate
+	guild, err := h.loadGuild(ctx, cmd.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Update region and language
+	if err := guild.UpdateLocale(cmd.Region, cmd.Language, cmd.UpdatedBy); err != nil {
+		return nil, fmt.Errorf("failed to update guild locale: %w", err)
+	}
+
+	// Save the guild
+	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
+		return nil, fmt.Errorf("failed to save guild: %w", err)
+	}
+
+	return &cqrs.CommandResult{
+		AggregateID: cmd.ID(),
+		Success:     true,
+		Message:     "Guild locale updated successfully",
+	}, nil
+}
+
+// handleUpdateGuildEmblem handles the UpdateGuildEmblemCommand
+func (h *GuildCommandHandler) handleUpdateGuildEmblem(ctx context.Context, cmd *commands.UpdateGuildEmblemCommand) (*cqrs.CommandResult, error) {
+	if h.emblems == nil {
+		return nil, fmt.Errorf("emblem uploads are not configured")
+	}
+
+	// Load guild aggregate
+	guild, err := h.loadGuild(ctx, cmd.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the uploaded object before linking it
+	emblem, err := h.emblems.VerifyEmblem(ctx, cmd.ID(), cmd.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("emblem upload rejected: %w", err)
+	}
+
+	// Update emblem
+	if err := guild.UpdateEmblem(emblem, cmd.UpdatedBy); err != nil {
+		return nil, fmt.Errorf("failed to update guild emblem: %w", err)
+	}
+
+	// Save the guild
+	if err := h.repository.Save(ctx, guild, guild.OriginalVersion()); err != nil {
+		return nil, fmt.Errorf("failed to save guild: %w", err)
+	}
+
+	return &cqrs.CommandResult{
+		AggregateID: cmd.ID(),
+		Success:     true,
+		Data: map[string]interface{}{
+			"object_key": emblem.ObjectKey,
+			"url":        emblem.URL,
+		},
+		Message: "Guild emblem updated successfully",
+	}, nil
+}
+
+// moderate runs the moderation filter; rejected content is returned as a *moderation.RejectedError
+func (h *GuildCommandHandler) moderate(ctx context.Context, guildID, authorID string, kind domain.ContentKind, text string) (moderation.Result, error) {
+	return moderation.Check(ctx, h.moderation, moderation.Content{
+		ScopeID:  guildID,
+		AuthorID: authorID,
+		Kind:     kind.String(),
+		Text:     text,
+	})
+}
+
 // loadGuild loads a guild aggregate from the repository
 func (h *GuildCommandHandler) loadGuild(ctx context.Context, guildID string) (*domain.GuildAggregate, error) {
 	// Check if guild exists
@@ -295,6 +540,12 @@ func (h *GuildCommandHandler) loadGuild(ctx context.Context, guildID string) (*d
 	if err != nil {
 		return nil, fmt.Errorf("failed to load guild aggregate: %w", err)
 	}
+	if h.economy != nil {
+		guild.SetEconomyConfigProvider(h.economy)
+	}
+	if h.progression != nil {
+		guild.SetProgressionConfig(h.progression)
+	}
 
 	return guild, nil
 }
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+)
+
+// TreasuryDonationsRuleName is the name of the rule built by NewTreasuryDonationsRule
+const TreasuryDonationsRuleName = "guild_donations_match_contributions"
+
+// NewTreasuryDonationsRule checks that every member's donations recorded in the
+// contribution views add up to the TreasuryDonated events of their guild.
+// Violations reference the donation events the expected total was built from.
+func NewTreasuryDonationsRule(repository cqrs.EventSourcedRepository, readStore cqrs.ReadStore) cqrs.InvariantRule {
+	return cqrs.NewInvariantRule(TreasuryDonationsRuleName, func(ctx context.Context) ([]cqrs.InvariantViolation, error) {
+		guilds, err := readStore.Query(ctx, cqrs.QueryCriteria{
+			Filters: map[string]interface{}{"type": "GuildView"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query guilds: %w", err)
+		}
+
+		recorded, err := recordedDonations(ctx, readStore)
+		if err != nil {
+			return nil, err
+		}
+
+		violations := make([]cqrs.InvariantViolation, 0)
+		for _, readModel := range guilds {
+			guildView, ok := readModel.(*projections.GuildView)
+			if !ok {
+				continue
+			}
+
+			events, err := repository.GetEventHistory(ctx, guildView.GuildID, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load events of guild %s: %w", guildView.GuildID, err)
+			}
+
+			donated := make(map[string]int64)
+			refs := make(map[string][]cqrs.EventReference)
+			for _, event := range events {
+				donation, ok := event.(*domain.TreasuryDonatedEvent)
+				if !ok {
+					continue
+				}
+				donated[donation.UserID] += donation.Amount
+				refs[donation.UserID] = append(refs[donation.UserID], cqrs.EventReferenceOf(donation))
+			}
+
+			userIDs := make([]string, 0, len(donated))
+			for userID := range donated {
+				userIDs = append(userIDs, userID)
+			}
+			for userID := range recorded[guildView.GuildID] {
+				if _, exists := donated[userID]; !exists {
+					userIDs = append(userIDs, userID)
+				}
+			}
+			sort.Strings(userIDs)
+
+			for _, userID := range userIDs {
+				actual := recorded[guildView.GuildID][userID]
+				if donated[userID] == actual {
+					continue
+				}
+				violations = append(violations, cqrs.InvariantViolation{
+					AggregateID: guildView.GuildID,
+					Message:     fmt.Sprintf("contributions of member %s do not match treasury donations", userID),
+					Expected:    donated[userID],
+					Actual:      actual,
+					Events:      refs[userID],
+				})
+			}
+		}
+		return violations, nil
+	})
+}
+
+// recordedDonations sums the donations in every contribution view by guild and member
+func recordedDonations(ctx context.Context, readStore cqrs.ReadStore) (map[string]map[string]int64, error) {
+	readModels, err := readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "MemberContributionView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contribution views: %w", err)
+	}
+
+	recorded := make(map[string]map[string]int64)
+	for _, readModel := range readModels {
+		view, ok := readModel.(*projections.MemberContributionView)
+		if !ok {
+			continue
+		}
+		if recorded[view.GuildID] == nil {
+			recorded[view.GuildID] = make(map[string]int64)
+		}
+		recorded[view.GuildID][view.UserID] += view.TreasuryDonated
+	}
+	return recorded, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+	"defense-allies-server/examples/guild/infrastructure/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreasuryDonationsRule(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	repository := repositories.NewInMemoryGuildRepository([]cqrs.Projection{
+		projections.NewGuildViewProjection(store),
+		projections.NewMemberContributionProjection(store),
+	})
+
+	guild := domain.NewGuildAggregate("guild-1", "Knights", "", "leader", "Leader")
+	require.NoError(t, guild.InviteMember("bob", "Bob", "leader"))
+	require.NoError(t, guild.AcceptInvitation("bob"))
+	require.NoError(t, guild.DonateToTreasury("bob", 100))
+	require.NoError(t, guild.DonateToTreasury("bob", 20))
+	require.NoError(t, guild.DonateToTreasury("leader", 300))
+	require.NoError(t, repository.Save(ctx, guild, 0))
+
+	other := domain.NewGuildAggregate("guild-2", "Mages", "", "merlin", "Merlin")
+	require.NoError(t, other.DonateToTreasury("merlin", 70))
+	require.NoError(t, repository.Save(ctx, other, 0))
+
+	checker, err := cqrs.NewInvariantChecker(NewTreasuryDonationsRule(repository, store))
+	require.NoError(t, err)
+
+	// Act: projections are in sync
+	consistent := checker.Check(ctx)
+
+	// Drift: bob's view over-counts and a view exists for a member who never donated
+	views, err := projections.GetWeeklyContributions(ctx, store, "guild-1", bobWeek(t, store))
+	require.NoError(t, err)
+	for _, view := range views {
+		if view.UserID == "bob" {
+			view.TreasuryDonated += 5
+			require.NoError(t, store.Save(ctx, view))
+		}
+	}
+	stray := projections.NewMemberContributionView("guild-1", "mallory", bobWeek(t, store))
+	stray.TreasuryDonated = 50
+	require.NoError(t, store.Save(ctx, stray))
+
+	drifted := checker.Check(ctx)
+
+	// Assert
+	assert.True(t, consistent.Passed(), "%+v", consistent.Violations)
+
+	violations := drifted.ViolationsOf(TreasuryDonationsRuleName)
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, "guild-1", violations[0].AggregateID)
+	assert.Contains(t, violations[0].Message, "bob")
+	assert.Equal(t, int64(120), violations[0].Expected)
+	assert.Equal(t, int64(125), violations[0].Actual)
+	require.Len(t, violations[0].Events, 2)
+	for _, ref := range violations[0].Events {
+		assert.Equal(t, domain.TreasuryDonatedEventType, ref.EventType)
+		assert.Equal(t, "guild-1", ref.AggregateID)
+		assert.NotZero(t, ref.Version)
+	}
+
+	assert.Contains(t, violations[1].Message, "mallory")
+	assert.Equal(t, int64(0), violations[1].Expected)
+	assert.Equal(t, int64(50), violations[1].Actual)
+	assert.Empty(t, violations[1].Events)
+}
+
+// bobWeek returns the week of bob's contribution view
+func bobWeek(t *testing.T, store cqrs.ReadStore) (week time.Time) {
+	t.Helper()
+	readModels, err := store.Query(context.Background(), cqrs.QueryCriteria{Filters: map[string]interface{}{"type": "MemberContributionView"}})
+	require.NoError(t, err)
+	for _, readModel := range readModels {
+		if view := readModel.(*projections.MemberContributionView); view.UserID == "bob" {
+			return view.WeekStart
+		}
+	}
+	t.Fatal("no contribution view for bob")
+	return week
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+)
+
+// ContributionSummaryService publishes a MemberContributionWeeklySummary event
+// for every guild once a contribution week is over
+type ContributionSummaryService struct {
+	readStore cqrs.ReadStore
+	eventBus  cqrs.EventBus
+	now       func() time.Time
+
+	lastSummarized time.Time // start of the last week summarized
+}
+
+// NewContributionSummaryService creates a new ContributionSummaryService
+func NewContributionSummaryService(readStore cqrs.ReadStore, eventBus cqrs.EventBus) *ContributionSummaryService {
+	return &ContributionSummaryService{
+		readStore: readStore,
+		eventBus:  eventBus,
+		now:       time.Now,
+	}
+}
+
+// PublishWeeklySummary publishes the summary of guildID's week starting at weekStart
+func (s *ContributionSummaryService) PublishWeeklySummary(ctx context.Context, guildID string, weekStart time.Time) error {
+	views, err := projections.GetWeeklyContributions(ctx, s.readStore, guildID, weekStart)
+	if err != nil {
+		return err
+	}
+
+	contributions := make([]domain.MemberContribution, len(views))
+	for i, view := range views {
+		contributions[i] = view.Contribution()
+	}
+	sort.Slice(contributions, func(i, j int) bool {
+		return contributions[i].Points() > contributions[j].Points()
+	})
+
+	event := domain.NewMemberContributionWeeklySummaryEvent(guildID, weekStart, contributions)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish weekly summary for guild %s: %w", guildID, err)
+	}
+	return nil
+}
+
+// PublishDueSummaries summarizes the previous week for every guild, once per week.
+// The last summarized week is kept in memory, so consumers should tolerate a repeat after a restart.
+func (s *ContributionSummaryService) PublishDueSummaries(ctx context.Context) error {
+	previousWeek := domain.ContributionWeekStart(s.now()).AddDate(0, 0, -7)
+	if !previousWeek.After(s.lastSummarized) {
+		return nil
+	}
+
+	readModels, err := s.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "GuildView"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query guilds: %w", err)
+	}
+
+	for _, readModel := range readModels {
+		guildView, ok := readModel.(*projections.GuildView)
+		if !ok {
+			continue
+		}
+		if err := s.PublishWeeklySummary(ctx, guildView.GuildID, previousWeek); err != nil {
+			return err
+		}
+	}
+
+	s.lastSummarized = previousWeek
+	return nil
+}
+
+// Start checks for finished weeks every interval until ctx is cancelled
+func (s *ContributionSummaryService) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.PublishDueSummaries(ctx); err != nil {
+					log.Printf("weekly contribution summary failed: %v", err)
+				}
+			}
+		}
+	}()
+}
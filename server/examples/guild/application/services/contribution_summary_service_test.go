@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveContribution(t *testing.T, store cqrs.ReadStore, guildID, userID string, weekStart time.Time, mined, donated int64) {
+	t.Helper()
+	view := projections.NewMemberContributionView(guildID, userID, weekStart)
+	view.MineralsMined = mined
+	view.TreasuryDonated = donated
+	require.NoError(t, store.Save(context.Background(), view))
+}
+
+func TestContributionSummaryService_PublishDueSummaries(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	bus := &recordingEventBus{}
+	lastWeek := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	for _, guildID := range []string{"guild-1", "guild-2"} {
+		require.NoError(t, store.Save(ctx, projections.NewGuildView(guildID)))
+	}
+	saveContribution(t, store, "guild-1", "alice", lastWeek, 30, 0)
+	saveContribution(t, store, "guild-1", "bob", lastWeek, 0, 500)
+	saveContribution(t, store, "guild-1", "carol", lastWeek.AddDate(0, 0, 7), 900, 0) // current week
+
+	service := NewContributionSummaryService(store, bus)
+	service.now = func() time.Time { return time.Date(2024, 3, 13, 9, 0, 0, 0, time.UTC) }
+
+	// Act
+	require.NoError(t, service.PublishDueSummaries(ctx))
+	require.NoError(t, service.PublishDueSummaries(ctx)) // same week: nothing new
+
+	// Assert
+	require.Len(t, bus.events, 2)
+	summaries := make(map[string]*domain.MemberContributionWeeklySummaryEvent)
+	for _, event := range bus.events {
+		summary, ok := event.(*domain.MemberContributionWeeklySummaryEvent)
+		require.True(t, ok)
+		assert.Equal(t, lastWeek, summary.WeekStart)
+		assert.Equal(t, summary.GuildID, summary.AggregateID())
+		summaries[summary.GuildID] = summary
+	}
+
+	require.Contains(t, summaries, "guild-1")
+	assert.Equal(t, []domain.MemberContribution{
+		{UserID: "bob", TreasuryDonated: 500},
+		{UserID: "alice", MineralsMined: 30},
+	}, summaries["guild-1"].Contributions) // highest points first, current week excluded
+	require.Contains(t, summaries, "guild-2")
+	assert.Empty(t, summaries["guild-2"].Contributions)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+)
+
+// EconomyConfigService holds the economy config in effect and lets operators change it
+// at runtime. Every change is validated, versioned and published as EconomyConfigChanged.
+// Live-ops modifiers from SetModifierSource are applied on top of the operator's config
+// when it is read, so a scheduled event takes effect the moment it starts or ends.
+type EconomyConfigService struct {
+	current   atomic.Pointer[domain.EconomyConfig]
+	updateMu  sync.Mutex // serializes updates so versions stay sequential
+	eventBus  cqrs.EventBus
+	modifiers ModifierSource // optional; nil applies no live-ops modifiers
+}
+
+// NewEconomyConfigService creates a service starting from initial (DefaultEconomyConfig when nil)
+func NewEconomyConfigService(initial *domain.EconomyConfig, eventBus cqrs.EventBus) (*EconomyConfigService, error) {
+	if initial == nil {
+		initial = domain.DefaultEconomyConfig()
+	}
+	if err := initial.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid economy config: %w", err)
+	}
+
+	service := &EconomyConfigService{eventBus: eventBus}
+	service.current.Store(initial.Clone())
+	return service, nil
+}
+
+// SetModifierSource installs the live-ops modifiers applied to the config in effect
+func (s *EconomyConfigService) SetModifierSource(modifiers ModifierSource) {
+	s.modifiers = modifiers
+}
+
+// EconomyConfig returns the config in effect, with active live-ops modifiers applied;
+// it implements domain.EconomyConfigProvider. The returned config must not be modified.
+func (s *EconomyConfigService) EconomyConfig() *domain.EconomyConfig {
+	config := s.current.Load()
+	if s.modifiers == nil {
+		return config
+	}
+	return applyModifiers(config, s.modifiers.ActiveModifiers())
+}
+
+// BaseConfig returns the config set by operators, without live-ops modifiers.
+// Updates start from it so temporary modifiers are never saved into a new version.
+func (s *EconomyConfigService) BaseConfig() *domain.EconomyConfig {
+	return s.current.Load()
+}
+
+// Update replaces the config with a new version and publishes EconomyConfigChanged
+func (s *EconomyConfigService) Update(ctx context.Context, config *domain.EconomyConfig, changedBy, reason string) (*domain.EconomyConfig, error) {
+	if config == nil {
+		return nil, fmt.Errorf("economy config cannot be nil")
+	}
+	if changedBy == "" {
+		return nil, fmt.Errorf("changed by cannot be empty")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid economy config: %w", err)
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	previous := s.current.Load()
+	next := config.Clone()
+	next.Version = previous.Version + 1
+
+	if s.eventBus != nil {
+		event := domain.NewEconomyConfigChangedEvent(previous.Version, next, changedBy, reason)
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to publish economy config change: %w", err)
+		}
+	}
+
+	s.current.Store(next)
+	return next, nil
+}
+
+// economyConfigFile is the on-disk format, keyed by mineral names
+type economyConfigFile struct {
+	MineralValues       map[string]int64   `json:"mineral_values"`
+	YieldMultipliers    map[string]float64 `json:"yield_multipliers"`
+	TransportRewardRate *float64           `json:"transport_reward_rate"`
+}
+
+// LoadFile reads a JSON config file and applies it; fields missing from the file keep their current values
+func (s *EconomyConfigService) LoadFile(ctx context.Context, path, changedBy string) (*domain.EconomyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read economy config: %w", err)
+	}
+
+	var file economyConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse economy config: %w", err)
+	}
+
+	config := s.BaseConfig().Clone()
+	for name, value := range file.MineralValues {
+		mineral, err := domain.ParseMineralType(name)
+		if err != nil {
+			return nil, err
+		}
+		config.MineralValues[mineral] = value
+	}
+	for name, multiplier := range file.YieldMultipliers {
+		mineral, err := domain.ParseMineralType(name)
+		if err != nil {
+			return nil, err
+		}
+		config.YieldMultipliers[mineral] = multiplier
+	}
+	if file.TransportRewardRate != nil {
+		config.TransportRewardRate = *file.TransportRewardRate
+	}
+
+	return s.Update(ctx, config, changedBy, fmt.Sprintf("reloaded from %s", path))
+}
+
+// WatchFile reloads path whenever its modification time changes, until ctx is cancelled.
+// A file that fails to load is logged and the previous config stays in effect.
+func (s *EconomyConfigService) WatchFile(ctx context.Context, path string, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastModified time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil || !info.ModTime().After(lastModified) {
+					continue
+				}
+				lastModified = info.ModTime()
+
+				if _, err := s.LoadFile(ctx, path, "config-watcher"); err != nil {
+					log.Printf("economy config reload failed: %v", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventBus records published events; the other EventBus methods are not used
+type recordingEventBus struct {
+	cqrs.EventBus
+	events []cqrs.EventMessage
+}
+
+func (b *recordingEventBus) Publish(ctx context.Context, event cqrs.EventMessage, options ...cqrs.EventPublishOptions) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func TestEconomyConfigService_UpdatePublishesConfigVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := &recordingEventBus{}
+	service, err := NewEconomyConfigService(nil, bus)
+	require.NoError(t, err)
+	config := service.EconomyConfig().Clone()
+	config.TransportRewardRate = 0.8
+
+	// Act
+	updated, err := service.Update(ctx, config, "operator", "weekend event")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Same(t, updated, service.EconomyConfig())
+
+	require.Len(t, bus.events, 1)
+	event, ok := bus.events[0].(*domain.EconomyConfigChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, domain.EconomyConfigChangedEventType, event.EventType())
+	assert.Equal(t, 1, event.PreviousVersion)
+	assert.Equal(t, 2, event.ConfigVersion)
+	assert.Equal(t, "operator", event.ChangedBy)
+
+	// The config version must not shadow the envelope's Version method
+	var message cqrs.EventMessage = event
+	assert.Equal(t, 0, message.Version())
+
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"config_version":2`)
+	assert.Contains(t, string(payload), `"previous_version":1`)
+}
+
+func TestEconomyConfigService_RejectsInvalidUpdates(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := &recordingEventBus{}
+	service, err := NewEconomyConfigService(nil, bus)
+	require.NoError(t, err)
+	invalid := service.EconomyConfig().Clone()
+	invalid.TransportRewardRate = 1.5
+
+	// Act
+	_, invalidErr := service.Update(ctx, invalid, "operator", "typo")
+	_, anonymousErr := service.Update(ctx, service.EconomyConfig().Clone(), "", "no author")
+
+	// Assert
+	assert.Error(t, invalidErr)
+	assert.Error(t, anonymousErr)
+	assert.Empty(t, bus.events)
+	assert.Equal(t, 1, service.EconomyConfig().Version)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"math"
+	"strings"
+
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/liveops"
+)
+
+// ModifierSource supplies the live-ops modifiers in effect; liveops.Service implements it
+type ModifierSource interface {
+	ActiveModifiers() liveops.Modifiers
+}
+
+// applyModifiers returns config with live-ops modifiers applied, e.g. a double-yield mining
+// weekend scheduled as {"mining.yield": 2}. Mineral-specific keys use the lower-case mineral
+// name ("mining.yield.gold", "mineral.value.mithril"). The adjusted config keeps the version
+// of config and is clamped to the ranges EconomyConfig.Validate allows. Mining results are
+// recorded in events, so replay is unaffected by modifiers.
+func applyModifiers(config *domain.EconomyConfig, modifiers liveops.Modifiers) *domain.EconomyConfig {
+	if len(modifiers) == 0 {
+		return config
+	}
+
+	adjusted := config.Clone()
+	for _, mineral := range domain.AllMineralTypes() {
+		name := strings.ToLower(mineral.String())
+		yield := config.YieldMultiplier(mineral) * modifiers.For(liveops.ModifierMiningYield, name)
+		adjusted.YieldMultipliers[mineral] = math.Min(yield, 10)
+		value := float64(config.MineralValue(mineral)) * modifiers.For(liveops.ModifierMineralValue, name)
+		adjusted.MineralValues[mineral] = int64(math.Round(value))
+	}
+	rate := config.TransportRewardRate * modifiers.Get(liveops.ModifierTransportRewardRate)
+	adjusted.TransportRewardRate = math.Min(rate, 1)
+	return adjusted
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/liveops"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEconomyConfigService_AppliesLiveOpsModifiers(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	start := time.Date(2024, 3, 23, 0, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	bus := &recordingEventBus{}
+	schedule, err := liveops.NewService(liveops.ServiceConfig{
+		EventBus: bus,
+		Now:      func() time.Time { return now },
+		Events: []liveops.ScheduledEvent{
+			{ID: "double-yield", Name: "Double Yield Weekend", StartsAt: start, EndsAt: start.Add(48 * time.Hour),
+				Modifiers: map[string]float64{liveops.ModifierMiningYield: 2, "mineral.value.gold": 1.5}},
+			{ID: "convoy-tax", Name: "Convoy Tax", StartsAt: start, EndsAt: start.Add(24 * time.Hour),
+				Modifiers: map[string]float64{liveops.ModifierTransportRewardRate: 0.5}},
+		},
+	})
+	require.NoError(t, err)
+
+	service, err := NewEconomyConfigService(nil, bus)
+	require.NoError(t, err)
+	service.SetModifierSource(schedule)
+	guild := domain.NewGuildAggregate("guild-1", "Miners", "", "leader", "Leader")
+	guild.SetEconomyConfigProvider(service)
+	base := domain.DefaultEconomyConfig()
+
+	// Act & Assert: before the events start the operator's config is used as is
+	assert.Same(t, service.BaseConfig(), service.EconomyConfig())
+
+	// During both events
+	now = start.Add(time.Hour)
+	during := guild.GetEconomyConfig()
+	assert.Equal(t, 2.0, during.YieldMultiplier(domain.MineralIron))
+	assert.Equal(t, 2.0, during.YieldMultiplier(domain.MineralGold))
+	assert.Equal(t, base.MineralValue(domain.MineralIron), during.MineralValue(domain.MineralIron))
+	assert.Equal(t, int64(float64(base.MineralValue(domain.MineralGold))*1.5), during.MineralValue(domain.MineralGold))
+	assert.Equal(t, 0.5, during.TransportRewardRate)
+	assert.Equal(t, 1, during.Version) // modifiers do not create config versions
+	require.NoError(t, during.Validate())
+
+	// An operator update during the event starts from the base config, not the modified one
+	next := service.BaseConfig().Clone()
+	next.MineralValues[domain.MineralIron] = 7
+	updated, err := service.Update(ctx, next, "operator", "iron rebalance")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, 1.0, updated.YieldMultiplier(domain.MineralIron))
+	assert.Same(t, updated, service.BaseConfig())
+
+	changed, ok := bus.events[len(bus.events)-1].(*domain.EconomyConfigChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, 2, changed.ConfigVersion)
+	assert.Equal(t, 1, changed.PreviousVersion)
+
+	rebalanced := service.EconomyConfig()
+	assert.Equal(t, 2, rebalanced.Version)
+	assert.Equal(t, int64(7), rebalanced.MineralValue(domain.MineralIron))
+	assert.Equal(t, 2.0, rebalanced.YieldMultiplier(domain.MineralIron))
+
+	// The shorter event ends first, then the weekend
+	now = start.Add(30 * time.Hour)
+	assert.Equal(t, 1.0, service.EconomyConfig().TransportRewardRate)
+	assert.Equal(t, 2.0, service.EconomyConfig().YieldMultiplier(domain.MineralIron))
+
+	now = start.Add(48 * time.Hour)
+	assert.Same(t, service.BaseConfig(), guild.GetEconomyConfig())
+}
+
+func TestApplyModifiers_ClampsToValidRanges(t *testing.T) {
+	// Arrange
+	config := domain.DefaultEconomyConfig()
+	modifiers := liveops.Modifiers{
+		liveops.ModifierMiningYield:         4,
+		"mining.yield.mithril":              5, // 20x overall
+		liveops.ModifierTransportRewardRate: 3,
+	}
+
+	// Act
+	adjusted := applyModifiers(config, modifiers)
+
+	// Assert
+	assert.Equal(t, 4.0, adjusted.YieldMultiplier(domain.MineralIron))
+	assert.Equal(t, 10.0, adjusted.YieldMultiplier(domain.MineralMithril))
+	assert.Equal(t, 1.0, adjusted.TransportRewardRate)
+	assert.NoError(t, adjusted.Validate())
+	assert.Equal(t, 1.0, config.YieldMultiplier(domain.MineralIron)) // the base config is not modified
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/application/commands"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/google/uuid"
+)
+
+// RecruitmentExpiryProcessor settles transport recruitments once their recruiting
+// window closes: recruitments with enough participants are started, the rest are
+// cancelled
+type RecruitmentExpiryProcessor struct {
+	readStore  cqrs.ReadStore
+	dispatcher cqrs.CommandDispatcher
+	now        func() time.Time
+	newID      func() string
+
+	mutex  sync.Mutex
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewRecruitmentExpiryProcessor creates a new RecruitmentExpiryProcessor
+func NewRecruitmentExpiryProcessor(readStore cqrs.ReadStore, dispatcher cqrs.CommandDispatcher) *RecruitmentExpiryProcessor {
+	return &RecruitmentExpiryProcessor{
+		readStore:  readStore,
+		dispatcher: dispatcher,
+		now:        time.Now,
+		newID:      func() string { return uuid.New().String() },
+	}
+}
+
+// ProcessDue starts or cancels every recruitment whose window has closed and
+// returns how many were processed. A recruitment whose command fails or is rejected
+// is logged, not counted, and retried on the next run.
+func (p *RecruitmentExpiryProcessor) ProcessDue(ctx context.Context) (int, error) {
+	readModels, err := p.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "TransportRecruitmentView"},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query recruitment views: %w", err)
+	}
+
+	now := p.now()
+	processed := 0
+	for _, readModel := range readModels {
+		view, ok := readModel.(*projections.TransportRecruitmentView)
+		if !ok || !view.IsDue(now) {
+			continue
+		}
+
+		cmd := commands.NewProcessExpiredRecruitmentCommand(view.GuildID, view.RecruitmentID, p.newID(), now)
+		result, err := p.dispatcher.Dispatch(ctx, cmd)
+		if err != nil {
+			log.Printf("failed to process expired recruitment %s: %v", view.RecruitmentID, err)
+			continue
+		}
+		if result == nil || !result.Success {
+			var reason error
+			if result != nil {
+				reason = result.Error
+			}
+			log.Printf("expired recruitment %s was not processed: %v", view.RecruitmentID, reason)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// Start checks for expired recruitments every interval until Stop is called or ctx is cancelled
+func (p *RecruitmentExpiryProcessor) Start(ctx context.Context, interval time.Duration) {
+	p.mutex.Lock()
+	if p.stopCh != nil {
+		p.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	p.stopCh = stopCh
+	p.mutex.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if _, err := p.ProcessDue(ctx); err != nil {
+					log.Printf("recruitment expiry processing failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the loop started by Start and waits for a run in progress to finish
+func (p *RecruitmentExpiryProcessor) Stop() {
+	p.mutex.Lock()
+	stopCh := p.stopCh
+	p.stopCh = nil
+	p.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		p.wg.Wait()
+	}
+}
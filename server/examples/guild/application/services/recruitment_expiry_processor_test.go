@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/application/commands"
+	"defense-allies-server/examples/guild/application/handlers"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+	"defense-allies-server/examples/guild/infrastructure/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedRecruitment saves a guild with one recruitment needing two participants, joined by the given members
+func seedRecruitment(t *testing.T, repository *repositories.InMemoryGuildRepository, guildID string, cargo map[domain.MineralType]int64, participants ...string) {
+	t.Helper()
+	ctx := context.Background()
+	guild := domain.NewGuildAggregate(guildID, guildID, "", "leader", "Leader")
+	for _, userID := range participants {
+		require.NoError(t, guild.InviteMember(userID, userID, "leader"))
+		require.NoError(t, guild.AcceptInvitation(userID))
+	}
+	require.NoError(t, guild.CreateTransportRecruitment("rec-"+guildID, "Cargo run", "", 3, 2, time.Hour, 30*time.Minute, cargo, "leader"))
+	for _, userID := range participants {
+		require.NoError(t, guild.JoinTransportRecruitment("rec-"+guildID, userID))
+	}
+	require.NoError(t, repository.Save(ctx, guild, 0))
+}
+
+func TestRecruitmentExpiryProcessor_StartsOrCancels(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	repository := repositories.NewInMemoryGuildRepository([]cqrs.Projection{projections.NewTransportRecruitmentProjection(store, nil)})
+	dispatcher := cqrs.NewInMemoryCommandDispatcher()
+	_, err := cqrs.AutoRegisterCommandHandler(dispatcher, handlers.NewGuildCommandHandler(repository))
+	require.NoError(t, err)
+
+	cargo := map[domain.MineralType]int64{domain.MineralGold: 12, domain.MineralIron: 40}
+	seedRecruitment(t, repository, "guild-full", cargo, "alice", "bob")
+	seedRecruitment(t, repository, "guild-short", cargo, "carol")
+
+	processor := NewRecruitmentExpiryProcessor(store, dispatcher)
+	ids := 0
+	processor.newID = func() string { ids++; return fmt.Sprintf("transport-%d", ids) }
+	processor.now = func() time.Time { return time.Now().Add(-time.Minute) }
+
+	// Act: nothing is due before the window closes
+	beforeExpiry, err := processor.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	processor.now = func() time.Time { return time.Now().Add(time.Hour) }
+	processed, err := processor.ProcessDue(ctx)
+	require.NoError(t, err)
+	again, err := processor.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 0, beforeExpiry)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, 0, again) // settled recruitments are no longer due
+
+	startedView, err := cqrs.LoadReadModel[*projections.TransportRecruitmentView](ctx, store, "rec-guild-full", "TransportRecruitmentView")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RecruitmentStatusStarted.String(), startedView.Status)
+	assert.NotEmpty(t, startedView.TransportID)
+
+	cancelledView, err := cqrs.LoadReadModel[*projections.TransportRecruitmentView](ctx, store, "rec-guild-short", "TransportRecruitmentView")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RecruitmentStatusCancelled.String(), cancelledView.Status)
+
+	// The start path records the system as the actor
+	fullHistory, err := repository.GetEventHistory(ctx, "guild-full", 0)
+	require.NoError(t, err)
+	started, ok := fullHistory[len(fullHistory)-1].(*domain.TransportRecruitmentStartedEvent)
+	require.True(t, ok)
+	assert.Equal(t, domain.SystemActorID, started.StartedBy)
+	assert.Equal(t, startedView.TransportID, started.TransportID)
+
+	// The cancel path names the participants to notify
+	shortHistory, err := repository.GetEventHistory(ctx, "guild-short", 0)
+	require.NoError(t, err)
+	cancelled, ok := shortHistory[len(shortHistory)-1].(*domain.TransportRecruitmentCancelledEvent)
+	require.True(t, ok)
+	assert.Equal(t, domain.RecruitmentCancelReasonUnderSubscribed, cancelled.Reason)
+	assert.Equal(t, []string{"carol"}, cancelled.Participants)
+	assert.Equal(t, domain.SystemActorID, cancelled.CancelledBy)
+}
+
+func TestRecruitmentExpiryProcessor_DoesNotCountRejectedCommands(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	repository := repositories.NewInMemoryGuildRepository([]cqrs.Projection{projections.NewTransportRecruitmentProjection(store, nil)})
+	seedRecruitment(t, repository, "guild-1", map[domain.MineralType]int64{domain.MineralGold: 1}, "alice")
+	dispatcher := cqrs.NewInMemoryCommandDispatcher()
+	var attempts atomic.Int32
+	require.NoError(t, cqrs.RegisterCommandHandler(dispatcher, commands.ProcessExpiredRecruitmentCommandType,
+		func(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+			attempts.Add(1)
+			return &cqrs.CommandResult{Success: false, Error: errors.New("guild is locked")}, nil
+		}))
+	processor := NewRecruitmentExpiryProcessor(store, dispatcher)
+	processor.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	// Act
+	processed, err := processor.ProcessDue(ctx)
+	retried, retryErr := processor.ProcessDue(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, retryErr)
+	assert.Equal(t, 0, processed)
+	assert.Equal(t, 0, retried)
+	assert.Equal(t, int32(2), attempts.Load()) // the rejected recruitment stays due
+}
+
+func TestRecruitmentExpiryProcessor_StopWaitsForTheLoop(t *testing.T) {
+	// Arrange
+	store := cqrs.NewInMemoryReadStore()
+	processor := NewRecruitmentExpiryProcessor(store, cqrs.NewInMemoryCommandDispatcher())
+	var runs atomic.Int32
+	processor.now = func() time.Time { runs.Add(1); return time.Now() }
+	processor.Start(context.Background(), time.Millisecond)
+	require.Eventually(t, func() bool { return runs.Load() > 0 }, time.Second, time.Millisecond)
+
+	// Act
+	processor.Stop()
+	stopped := runs.Load()
+	time.Sleep(10 * time.Millisecond)
+
+	// Assert
+	assert.Equal(t, stopped, runs.Load())
+	processor.Stop() // stopping twice is a no-op
+}
+
+func TestGuildAggregate_ProcessExpiredRecruitmentRejectsEarlyOrSettled(t *testing.T) {
+	// Arrange
+	guild := domain.NewGuildAggregate("guild-1", "Knights", "", "leader", "Leader")
+	require.NoError(t, guild.CreateTransportRecruitment("rec-1", "Cargo run", "", 3, 1, time.Hour, time.Minute, map[domain.MineralType]int64{domain.MineralGold: 1}, "leader"))
+	recruitment, _ := guild.GetTransportRecruitment("rec-1")
+
+	// Act
+	_, earlyErr := guild.ProcessExpiredRecruitment("rec-1", "transport-1", recruitment.ExpiresAt.Add(-time.Second))
+	started, expiredErr := guild.ProcessExpiredRecruitment("rec-1", "transport-1", recruitment.ExpiresAt)
+	_, settledErr := guild.ProcessExpiredRecruitment("rec-1", "transport-2", recruitment.ExpiresAt)
+	_, missingErr := guild.ProcessExpiredRecruitment("missing", "transport-3", recruitment.ExpiresAt)
+
+	// Assert
+	assert.Error(t, earlyErr)
+	require.NoError(t, expiredErr)
+	assert.False(t, started) // no participants: cancelled
+	assert.Error(t, settledErr)
+	assert.Error(t, missingErr)
+}
@@ -13,6 +13,7 @@ import (
 	"defense-allies-server/examples/guild/infrastructure/projections"
 	"defense-allies-server/examples/guild/infrastructure/queries"
 	"defense-allies-server/examples/guild/infrastructure/repositories"
+	"defense-allies-server/serverapp/moderation"
 )
 
 func main() {
@@ -37,31 +38,25 @@ func main() {
 	// Create projections
 	guildViewProjection := projections.NewGuildViewProjection(readStore)
 	memberViewProjection := projections.NewMemberViewProjection(readStore)
-	allProjections := []cqrs.Projection{guildViewProjection, memberViewProjection}
+
+	// Flagged guild text lands in the moderation review queue
+	reviewQueue := moderation.NewReviewQueue(moderation.ReviewQueueConfig{ReadStore: readStore})
+	moderationProjection := projections.NewModerationReviewProjection(reviewQueue)
+	allProjections := []cqrs.Projection{guildViewProjection, memberViewProjection, moderationProjection}
 
 	// Create in-memory repository for this example (with projections)
 	repository := repositories.NewInMemoryGuildRepository(allProjections)
 
 	// Create and register command handler
 	guildHandler := handlers.NewGuildCommandHandler(repository)
+	guildHandler.SetModerationFilter(moderation.NewWordListFilter([]string{"cheat", "exploit"}, []string{"trade", "sell"}))
 
 	// Create and register query handler
 	guildQueryHandler := queries.NewGuildQueryHandler(readStore)
 
-	// Register command handlers
-	commandTypes := []string{
-		commands.CreateGuildCommandType,
-		commands.UpdateGuildInfoCommandType,
-		commands.UpdateGuildSettingsCommandType,
-		commands.InviteMemberCommandType,
-		commands.AcceptInvitationCommandType,
-		commands.KickMemberCommandType,
-		commands.PromoteMemberCommandType,
-	}
-	for _, commandType := range commandTypes {
-		if err := commandDispatcher.RegisterHandler(commandType, guildHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", commandType, err)
-		}
+	// Register the command handler for every command type it declares
+	if _, err := cqrs.AutoRegisterCommandHandler(commandDispatcher, guildHandler); err != nil {
+		log.Fatalf("Failed to register guild command handler: %v", err)
 	}
 
 	// Create event bus for projections
@@ -85,16 +80,9 @@ func main() {
 	}
 	defer projectionManager.Stop(ctx)
 
-	// Register query handlers
-	queryTypes := []string{
-		queries.GetGuildQueryType,
-		queries.GetGuildMembersQueryType,
-		queries.SearchGuildsQueryType,
-	}
-	for _, queryType := range queryTypes {
-		if err := queryDispatcher.RegisterHandler(queryType, guildQueryHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", queryType, err)
-		}
+	// Register the query handler for every query type it declares
+	if _, err := cqrs.AutoRegisterQueryHandler(queryDispatcher, guildQueryHandler); err != nil {
+		log.Fatalf("Failed to register guild query handler: %v", err)
 	}
 
 	fmt.Println("\n✅ CQRS Infrastructure initialized successfully")
@@ -219,16 +207,10 @@ func runGuildExample(ctx context.Context, dispatcher cqrs.CommandDispatcher, que
 	return nil
 }
 
-// getMessageFromResult extracts message from CommandResult
+// getMessageFromResult returns the result message, falling back to defaultMessage
 func getMessageFromResult(result *cqrs.CommandResult, defaultMessage string) string {
-	if result.Data != nil {
-		if data, ok := result.Data.(map[string]interface{}); ok {
-			if msg, exists := data["message"]; exists {
-				if msgStr, ok := msg.(string); ok {
-					return msgStr
-				}
-			}
-		}
+	if result.Message != "" {
+		return result.Message
 	}
 	return defaultMessage
 }
@@ -11,10 +11,12 @@ import (
 	"cqrs"
 	"defense-allies-server/examples/guild/application/commands"
 	"defense-allies-server/examples/guild/application/handlers"
+	"defense-allies-server/examples/guild/application/services"
 	"defense-allies-server/examples/guild/domain"
 	"defense-allies-server/examples/guild/infrastructure/projections"
 	"defense-allies-server/examples/guild/infrastructure/queries"
 	"defense-allies-server/examples/guild/infrastructure/repositories"
+	"defense-allies-server/serverapp/liveops"
 )
 
 func main() {
@@ -50,20 +52,9 @@ func main() {
 	// Create and register query handler
 	guildQueryHandler := queries.NewGuildQueryHandler(readStore)
 
-	// Register command handlers
-	commandTypes := []string{
-		commands.CreateGuildCommandType,
-		commands.UpdateGuildInfoCommandType,
-		commands.UpdateGuildSettingsCommandType,
-		commands.InviteMemberCommandType,
-		commands.AcceptInvitationCommandType,
-		commands.KickMemberCommandType,
-		commands.PromoteMemberCommandType,
-	}
-	for _, commandType := range commandTypes {
-		if err := commandDispatcher.RegisterHandler(commandType, guildHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", commandType, err)
-		}
+	// Register the command handler for every command type it declares
+	if _, err := cqrs.AutoRegisterCommandHandler(commandDispatcher, guildHandler); err != nil {
+		log.Fatalf("Failed to register guild command handler: %v", err)
 	}
 
 	// Create event bus for projections
@@ -73,6 +64,13 @@ func main() {
 	}
 	defer eventBus.Stop(ctx)
 
+	// Economy config with a live-ops double-yield event running for this session
+	economy, err := newLiveOpsEconomy(eventBus)
+	if err != nil {
+		log.Fatalf("Failed to set up economy config: %v", err)
+	}
+	guildHandler.SetEconomyConfigProvider(economy)
+
 	// Register projections with projection manager
 	if err := projectionManager.RegisterProjection(guildViewProjection); err != nil {
 		log.Fatalf("Failed to register guild view projection: %v", err)
@@ -87,29 +85,48 @@ func main() {
 	}
 	defer projectionManager.Stop(ctx)
 
-	// Register query handlers
-	queryTypes := []string{
-		queries.GetGuildQueryType,
-		queries.GetGuildMembersQueryType,
-		queries.SearchGuildsQueryType,
-	}
-	for _, queryType := range queryTypes {
-		if err := queryDispatcher.RegisterHandler(queryType, guildQueryHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", queryType, err)
-		}
+	// Register the query handler for every query type it declares
+	if _, err := cqrs.AutoRegisterQueryHandler(queryDispatcher, guildQueryHandler); err != nil {
+		log.Fatalf("Failed to register guild query handler: %v", err)
 	}
 
 	fmt.Println("\n✅ CQRS Infrastructure initialized successfully")
 
 	// Run the guild mining example
-	if err := runGuildMiningExample(ctx, commandDispatcher, queryDispatcher, repository); err != nil {
+	if err := runGuildMiningExample(ctx, commandDispatcher, queryDispatcher, repository, economy); err != nil {
 		log.Fatalf("Example failed: %v", err)
 	}
 
 	fmt.Println("\n🎉 Guild mining example completed successfully!")
 }
 
-func runGuildMiningExample(ctx context.Context, dispatcher cqrs.CommandDispatcher, queryDispatcher cqrs.QueryDispatcher, repository cqrs.EventSourcedRepository) error {
+// newLiveOpsEconomy creates the economy config service with a live-ops schedule whose
+// Double Yield Weekend is running now, so harvests below yield twice the base amount
+func newLiveOpsEconomy(eventBus cqrs.EventBus) (*services.EconomyConfigService, error) {
+	now := time.Now()
+	schedule, err := liveops.NewService(liveops.ServiceConfig{
+		EventBus: eventBus,
+		Events: []liveops.ScheduledEvent{{
+			ID:        "double-yield-weekend",
+			Name:      "Double Yield Weekend",
+			StartsAt:  now.Add(-time.Hour),
+			EndsAt:    now.Add(47 * time.Hour),
+			Modifiers: map[string]float64{liveops.ModifierMiningYield: 2},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	economy, err := services.NewEconomyConfigService(nil, eventBus)
+	if err != nil {
+		return nil, err
+	}
+	economy.SetModifierSource(schedule)
+	return economy, nil
+}
+
+func runGuildMiningExample(ctx context.Context, dispatcher cqrs.CommandDispatcher, queryDispatcher cqrs.QueryDispatcher, repository cqrs.EventSourcedRepository, economy domain.EconomyConfigProvider) error {
 	// Generate IDs
 	guildID := uuid.New().String()
 	founderID := "founder123"
@@ -187,6 +204,8 @@ func runGuildMiningExample(ctx context.Context, dispatcher cqrs.CommandDispatche
 	if !ok {
 		return fmt.Errorf("invalid aggregate type")
 	}
+	guild.SetEconomyConfigProvider(economy)
+	fmt.Printf("   🎉 Live-ops: iron yield x%.1f during the Double Yield Weekend\n", guild.GetEconomyConfig().YieldMultiplier(domain.MineralIron))
 
 	// Initialize mining system and add mining nodes
 	mining := guild.GetMining()
@@ -309,7 +328,7 @@ func displayMiningStatus(guild *domain.GuildAggregate) {
 	mining := guild.GetMining()
 
 	fmt.Printf("   🏭 Mining Level: %d (Experience: %d)\n", mining.MiningLevel, mining.MiningExperience)
-	fmt.Printf("   💎 Total Mineral Value: %d gold\n", mining.GetTotalMineralValue())
+	fmt.Printf("   💎 Total Mineral Value: %d gold\n", mining.GetTotalMineralValue(guild.GetEconomyConfig()))
 	fmt.Printf("   🔄 Active Operations: %d\n", mining.GetActiveOperationsCount())
 
 	fmt.Println("   📦 Mineral Inventory:")
@@ -50,20 +50,9 @@ func main() {
 	// Create and register query handler
 	guildQueryHandler := queries.NewGuildQueryHandler(readStore)
 
-	// Register command handlers
-	commandTypes := []string{
-		commands.CreateGuildCommandType,
-		commands.UpdateGuildInfoCommandType,
-		commands.UpdateGuildSettingsCommandType,
-		commands.InviteMemberCommandType,
-		commands.AcceptInvitationCommandType,
-		commands.KickMemberCommandType,
-		commands.PromoteMemberCommandType,
-	}
-	for _, commandType := range commandTypes {
-		if err := commandDispatcher.RegisterHandler(commandType, guildHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", commandType, err)
-		}
+	// Register the command handler for every command type it declares
+	if _, err := cqrs.AutoRegisterCommandHandler(commandDispatcher, guildHandler); err != nil {
+		log.Fatalf("Failed to register guild command handler: %v", err)
 	}
 
 	// Create event bus for projections
@@ -87,16 +76,9 @@ func main() {
 	}
 	defer projectionManager.Stop(ctx)
 
-	// Register query handlers
-	queryTypes := []string{
-		queries.GetGuildQueryType,
-		queries.GetGuildMembersQueryType,
-		queries.SearchGuildsQueryType,
-	}
-	for _, queryType := range queryTypes {
-		if err := queryDispatcher.RegisterHandler(queryType, guildQueryHandler); err != nil {
-			log.Fatalf("Failed to register %s handler: %v", queryType, err)
-		}
+	// Register the query handler for every query type it declares
+	if _, err := cqrs.AutoRegisterQueryHandler(queryDispatcher, guildQueryHandler); err != nil {
+		log.Fatalf("Failed to register guild query handler: %v", err)
 	}
 
 	fmt.Println("\n✅ CQRS Infrastructure initialized successfully")
@@ -370,16 +352,10 @@ func calculateCargoValue(cargo map[domain.MineralType]int64) int64 {
 	return total
 }
 
-// getMessageFromResult extracts message from CommandResult
+// getMessageFromResult returns the result message, falling back to defaultMessage
 func getMessageFromResult(result *cqrs.CommandResult, defaultMessage string) string {
-	if result.Data != nil {
-		if data, ok := result.Data.(map[string]interface{}); ok {
-			if msg, exists := data["message"]; exists {
-				if msgStr, ok := msg.(string); ok {
-					return msgStr
-				}
-			}
-		}
+	if result.Message != "" {
+		return result.Message
 	}
 	return defaultMessage
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// MemberContribution is what a member contributed to the guild during a period
+type MemberContribution struct {
+	UserID              string `json:"user_id"`
+	MineralsMined       int64  `json:"minerals_mined"`
+	TransportsCompleted int    `json:"transports_completed"`
+	TreasuryDonated     int64  `json:"treasury_donated"`
+}
+
+// Points returns a single score used to rank members on the management screen
+func (c MemberContribution) Points() int64 {
+	return c.MineralsMined + int64(c.TransportsCompleted)*100 + c.TreasuryDonated
+}
+
+// ContributionWeekStart returns the start of the contribution week containing t (Monday 00:00 UTC)
+func ContributionWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// splitHarvest divides harvested units evenly between workers; the remainder goes
+// to the first workers in user ID order so the split is deterministic
+func splitHarvest(total int64, workerIDs []string) map[string]int64 {
+	shares := make(map[string]int64, len(workerIDs))
+	if len(workerIDs) == 0 || total <= 0 {
+		return shares
+	}
+
+	sorted := append([]string(nil), workerIDs...)
+	sort.Strings(sorted)
+
+	each := total / int64(len(sorted))
+	remainder := total % int64(len(sorted))
+	for i, userID := range sorted {
+		shares[userID] = each
+		if int64(i) < remainder {
+			shares[userID]++
+		}
+	}
+	return shares
+}
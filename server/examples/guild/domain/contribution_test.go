@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContributionWeekStart(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	seoul := time.FixedZone("KST", 9*60*60)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{"monday midnight", monday, monday},
+		{"midweek", time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC), monday},
+		{"sunday night", time.Date(2024, 3, 10, 23, 59, 59, 0, time.UTC), monday},
+		{"next monday", time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), monday.AddDate(0, 0, 7)},
+		{"local monday morning is still the previous UTC week", time.Date(2024, 3, 11, 8, 0, 0, 0, seoul), monday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ContributionWeekStart(tt.at))
+		})
+	}
+}
+
+func TestMemberContribution_Points(t *testing.T) {
+	contribution := MemberContribution{MineralsMined: 30, TransportsCompleted: 2, TreasuryDonated: 15}
+
+	assert.Equal(t, int64(245), contribution.Points())
+}
+
+func TestSplitHarvest(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		workers []string
+		want    map[string]int64
+	}{
+		{"even split", 30, []string{"b", "a", "c"}, map[string]int64{"a": 10, "b": 10, "c": 10}},
+		{"remainder goes to the first workers by ID", 11, []string{"c", "b", "a"}, map[string]int64{"a": 4, "b": 4, "c": 3}},
+		{"no workers", 10, nil, map[string]int64{}},
+		{"nothing harvested", 0, []string{"a"}, map[string]int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitHarvest(tt.total, tt.workers))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// EconomyConfig holds the balancing values used by mining and transport.
+// Values are read when a command runs; the results are recorded in events,
+// so replaying old events is not affected by later config changes.
+type EconomyConfig struct {
+	Version int `json:"version"`
+
+	// MineralValues is the treasury value of one unit of each mineral
+	MineralValues map[MineralType]int64 `json:"mineral_values"`
+
+	// YieldMultipliers scales mining yield per mineral (1.0 = unchanged)
+	YieldMultipliers map[MineralType]float64 `json:"yield_multipliers"`
+
+	// TransportRewardRate is the share of the cargo distributed to transport participants (0.0 - 1.0)
+	TransportRewardRate float64 `json:"transport_reward_rate"`
+}
+
+// EconomyConfigProvider supplies the economy config currently in effect
+type EconomyConfigProvider interface {
+	EconomyConfig() *EconomyConfig
+}
+
+// DefaultEconomyConfig returns the built-in balancing values
+func DefaultEconomyConfig() *EconomyConfig {
+	config := &EconomyConfig{
+		Version:             1,
+		MineralValues:       make(map[MineralType]int64),
+		YieldMultipliers:    make(map[MineralType]float64),
+		TransportRewardRate: 1.0,
+	}
+	for _, mineral := range AllMineralTypes() {
+		config.MineralValues[mineral] = mineral.GetValue()
+		config.YieldMultipliers[mineral] = 1.0
+	}
+	return config
+}
+
+// EconomyConfig implements EconomyConfigProvider for a fixed config
+func (c *EconomyConfig) EconomyConfig() *EconomyConfig {
+	return c
+}
+
+// MineralValue returns the value of one unit of mineral, falling back to its base value
+func (c *EconomyConfig) MineralValue(mineral MineralType) int64 {
+	if value, exists := c.MineralValues[mineral]; exists {
+		return value
+	}
+	return mineral.GetValue()
+}
+
+// YieldMultiplier returns the yield multiplier for mineral (1.0 when not configured)
+func (c *EconomyConfig) YieldMultiplier(mineral MineralType) float64 {
+	if multiplier, exists := c.YieldMultipliers[mineral]; exists {
+		return multiplier
+	}
+	return 1.0
+}
+
+// MineralsValue returns the total value of a set of minerals
+func (c *EconomyConfig) MineralsValue(minerals map[MineralType]int64) int64 {
+	total := int64(0)
+	for mineral, amount := range minerals {
+		total += amount * c.MineralValue(mineral)
+	}
+	return total
+}
+
+// TransportRewardPerPerson splits the rewarded share of the cargo between maxParticipants
+func (c *EconomyConfig) TransportRewardPerPerson(totalCargo map[MineralType]int64, maxParticipants int) map[MineralType]int64 {
+	rewardPerPerson := make(map[MineralType]int64)
+	if maxParticipants <= 0 {
+		return rewardPerPerson
+	}
+	for mineral, amount := range totalCargo {
+		rewarded := int64(float64(amount) * c.TransportRewardRate)
+		rewardPerPerson[mineral] = rewarded / int64(maxParticipants)
+	}
+	return rewardPerPerson
+}
+
+// Validate validates the economy config
+func (c *EconomyConfig) Validate() error {
+	for mineral, value := range c.MineralValues {
+		if value < 0 {
+			return fmt.Errorf("value of %s cannot be negative", mineral.String())
+		}
+	}
+	for mineral, multiplier := range c.YieldMultipliers {
+		if multiplier < 0 || multiplier > 10 {
+			return fmt.Errorf("yield multiplier of %s must be between 0 and 10", mineral.String())
+		}
+	}
+	if c.TransportRewardRate < 0 || c.TransportRewardRate > 1 {
+		return fmt.Errorf("transport reward rate must be between 0 and 1")
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the config
+func (c *EconomyConfig) Clone() *EconomyConfig {
+	clone := &EconomyConfig{
+		Version:             c.Version,
+		MineralValues:       make(map[MineralType]int64, len(c.MineralValues)),
+		YieldMultipliers:    make(map[MineralType]float64, len(c.YieldMultipliers)),
+		TransportRewardRate: c.TransportRewardRate,
+	}
+	for mineral, value := range c.MineralValues {
+		clone.MineralValues[mineral] = value
+	}
+	for mineral, multiplier := range c.YieldMultipliers {
+		clone.YieldMultipliers[mineral] = multiplier
+	}
+	return clone
+}
+
+// ToMap converts the config to a serializable map keyed by mineral names
+func (c *EconomyConfig) ToMap() map[string]interface{} {
+	values := make(map[string]interface{}, len(c.MineralValues))
+	for mineral, value := range c.MineralValues {
+		values[mineral.String()] = value
+	}
+	multipliers := make(map[string]interface{}, len(c.YieldMultipliers))
+	for mineral, multiplier := range c.YieldMultipliers {
+		multipliers[mineral.String()] = multiplier
+	}
+	return map[string]interface{}{
+		"version":               c.Version,
+		"mineral_values":        values,
+		"yield_multipliers":     multipliers,
+		"transport_reward_rate": c.TransportRewardRate,
+	}
+}
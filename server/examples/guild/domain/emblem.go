@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EmblemPerkID is the perk that unlocks custom guild emblems
+const EmblemPerkID = "guild_emblem"
+
+// GuildEmblem is an uploaded emblem image stored in the asset blob store
+type GuildEmblem struct {
+	ObjectKey   string    `json:"object_key"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// EmblemVerifier checks that an uploaded object belongs to the guild and satisfies
+// the emblem content-type and size limits before it is linked to the aggregate
+type EmblemVerifier interface {
+	VerifyEmblem(ctx context.Context, guildID, objectKey string) (GuildEmblem, error)
+}
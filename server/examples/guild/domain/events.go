@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"cqrs"
 )
 
@@ -11,6 +13,20 @@ const (
 	GuildInfoUpdatedEventType     = "GuildInfoUpdated"
 	GuildSettingsUpdatedEventType = "GuildSettingsUpdated"
 	GuildDisbandedEventType       = "GuildDisbanded"
+	GuildEmblemUpdatedEventType   = "EmblemUpdated"
+	GuildLocaleUpdatedEventType   = "GuildLocaleUpdated"
+
+	// Contribution events
+	TreasuryDonatedEventType                 = "TreasuryDonated"
+	MemberContributionWeeklySummaryEventType = "MemberContributionWeeklySummary"
+
+	// Progression events
+	GuildExperienceGainedEventType = "GuildExperienceGained"
+	GuildLeveledUpEventType        = "GuildLeveledUp"
+
+	// Chat and moderation events
+	GuildChatMessagePostedEventType = "GuildChatMessagePosted"
+	GuildContentFlaggedEventType    = "GuildContentFlagged"
 
 	// Member events
 	MemberInvitedEventType  = "MemberInvited"
@@ -36,6 +52,7 @@ const (
 	TransportRecruitmentLeftEventType      = "TransportRecruitmentLeft"
 	TransportRecruitmentStartedEventType   = "TransportRecruitmentStarted"
 	TransportRecruitmentCompletedEventType = "TransportRecruitmentCompleted"
+	TransportRecruitmentCancelledEventType = "TransportRecruitmentCancelled"
 
 	// Transport events
 	TransportStartedEventType   = "TransportStarted"
@@ -44,8 +61,20 @@ const (
 	TransportCompletedEventType = "TransportCompleted"
 	TransportRaidedEventType    = "TransportRaided"
 	TransportCancelledEventType = "TransportCancelled"
+
+	// Economy events
+	EconomyConfigChangedEventType = "EconomyConfigChanged"
 )
 
+// newEventMessage creates the envelope of a domain event.
+// The aggregate assigns the version when the event is applied.
+func newEventMessage(eventType, aggregateID, aggregateType string) *cqrs.BaseEventMessage {
+	message := cqrs.NewBaseEventMessage(eventType)
+	message.AggregateID_ = aggregateID
+	message.AggregateType_ = aggregateType
+	return message
+}
+
 // Guild Events
 
 // GuildCreatedEvent represents a guild creation event
@@ -61,24 +90,12 @@ type GuildCreatedEvent struct {
 // NewGuildCreatedEvent creates a new guild created event
 func NewGuildCreatedEvent(guildID, name, description, founderID, founderUsername string) *GuildCreatedEvent {
 	return &GuildCreatedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			GuildCreatedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":         guildID,
-				"name":             name,
-				"description":      description,
-				"founder_id":       founderID,
-				"founder_username": founderUsername,
-			},
-		),
-		GuildID:         guildID,
-		Name:            name,
-		Description:     description,
-		FounderID:       founderID,
-		FounderUsername: founderUsername,
+		BaseEventMessage: newEventMessage(GuildCreatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		Name:             name,
+		Description:      description,
+		FounderID:        founderID,
+		FounderUsername:  founderUsername,
 	}
 }
 
@@ -96,26 +113,13 @@ type GuildInfoUpdatedEvent struct {
 // NewGuildInfoUpdatedEvent creates a new guild info updated event
 func NewGuildInfoUpdatedEvent(guildID, name, description, notice, tag, updatedBy string) *GuildInfoUpdatedEvent {
 	return &GuildInfoUpdatedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			GuildInfoUpdatedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":    guildID,
-				"name":        name,
-				"description": description,
-				"notice":      notice,
-				"tag":         tag,
-				"updated_by":  updatedBy,
-			},
-		),
-		GuildID:     guildID,
-		Name:        name,
-		Description: description,
-		Notice:      notice,
-		Tag:         tag,
-		UpdatedBy:   updatedBy,
+		BaseEventMessage: newEventMessage(GuildInfoUpdatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		Name:             name,
+		Description:      description,
+		Notice:           notice,
+		Tag:              tag,
+		UpdatedBy:        updatedBy,
 	}
 }
 
@@ -133,26 +137,57 @@ type GuildSettingsUpdatedEvent struct {
 // NewGuildSettingsUpdatedEvent creates a new guild settings updated event
 func NewGuildSettingsUpdatedEvent(guildID string, maxMembers, minLevel int, isPublic, requireApproval bool, updatedBy string) *GuildSettingsUpdatedEvent {
 	return &GuildSettingsUpdatedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			GuildSettingsUpdatedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":         guildID,
-				"max_members":      maxMembers,
-				"min_level":        minLevel,
-				"is_public":        isPublic,
-				"require_approval": requireApproval,
-				"updated_by":       updatedBy,
-			},
-		),
-		GuildID:         guildID,
-		MaxMembers:      maxMembers,
-		MinLevel:        minLevel,
-		IsPublic:        isPublic,
-		RequireApproval: requireApproval,
-		UpdatedBy:       updatedBy,
+		BaseEventMessage: newEventMessage(GuildSettingsUpdatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		MaxMembers:       maxMembers,
+		MinLevel:         minLevel,
+		IsPublic:         isPublic,
+		RequireApproval:  requireApproval,
+		UpdatedBy:        updatedBy,
+	}
+}
+
+// GuildEmblemUpdatedEvent links an uploaded emblem object to the guild
+type GuildEmblemUpdatedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID     string `json:"guild_id"`
+	ObjectKey   string `json:"object_key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	UpdatedBy   string `json:"updated_by"`
+}
+
+// NewGuildEmblemUpdatedEvent creates a new emblem updated event
+func NewGuildEmblemUpdatedEvent(guildID string, emblem GuildEmblem, updatedBy string) *GuildEmblemUpdatedEvent {
+	return &GuildEmblemUpdatedEvent{
+		BaseEventMessage: newEventMessage(GuildEmblemUpdatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		ObjectKey:        emblem.ObjectKey,
+		ContentType:      emblem.ContentType,
+		Size:             emblem.Size,
+		URL:              emblem.URL,
+		UpdatedBy:        updatedBy,
+	}
+}
+
+// GuildLocaleUpdatedEvent sets the region and language players use to find the guild
+type GuildLocaleUpdatedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID   string `json:"guild_id"`
+	Region    string `json:"region"`
+	Language  string `json:"language"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// NewGuildLocaleUpdatedEvent creates a new guild locale updated event
+func NewGuildLocaleUpdatedEvent(guildID, region, language, updatedBy string) *GuildLocaleUpdatedEvent {
+	return &GuildLocaleUpdatedEvent{
+		BaseEventMessage: newEventMessage(GuildLocaleUpdatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		Region:           region,
+		Language:         language,
+		UpdatedBy:        updatedBy,
 	}
 }
 
@@ -170,22 +205,11 @@ type MemberInvitedEvent struct {
 // NewMemberInvitedEvent creates a new member invited event
 func NewMemberInvitedEvent(guildID, userID, username, invitedBy string) *MemberInvitedEvent {
 	return &MemberInvitedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MemberInvitedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":   guildID,
-				"user_id":    userID,
-				"username":   username,
-				"invited_by": invitedBy,
-			},
-		),
-		GuildID:   guildID,
-		UserID:    userID,
-		Username:  username,
-		InvitedBy: invitedBy,
+		BaseEventMessage: newEventMessage(MemberInvitedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		UserID:           userID,
+		Username:         username,
+		InvitedBy:        invitedBy,
 	}
 }
 
@@ -199,18 +223,9 @@ type MemberJoinedEvent struct {
 // NewMemberJoinedEvent creates a new member joined event
 func NewMemberJoinedEvent(guildID, userID string) *MemberJoinedEvent {
 	return &MemberJoinedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MemberJoinedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id": guildID,
-				"user_id":  userID,
-			},
-		),
-		GuildID: guildID,
-		UserID:  userID,
+		BaseEventMessage: newEventMessage(MemberJoinedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		UserID:           userID,
 	}
 }
 
@@ -226,22 +241,11 @@ type MemberKickedEvent struct {
 // NewMemberKickedEvent creates a new member kicked event
 func NewMemberKickedEvent(guildID, userID, kickedBy, reason string) *MemberKickedEvent {
 	return &MemberKickedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MemberKickedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":  guildID,
-				"user_id":   userID,
-				"kicked_by": kickedBy,
-				"reason":    reason,
-			},
-		),
-		GuildID:  guildID,
-		UserID:   userID,
-		KickedBy: kickedBy,
-		Reason:   reason,
+		BaseEventMessage: newEventMessage(MemberKickedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		UserID:           userID,
+		KickedBy:         kickedBy,
+		Reason:           reason,
 	}
 }
 
@@ -258,24 +262,12 @@ type MemberPromotedEvent struct {
 // NewMemberPromotedEvent creates a new member promoted event
 func NewMemberPromotedEvent(guildID, userID, promotedBy string, oldRole, newRole GuildRole) *MemberPromotedEvent {
 	return &MemberPromotedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MemberPromotedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":    guildID,
-				"user_id":     userID,
-				"promoted_by": promotedBy,
-				"old_role":    oldRole.String(),
-				"new_role":    newRole.String(),
-			},
-		),
-		GuildID:    guildID,
-		UserID:     userID,
-		PromotedBy: promotedBy,
-		OldRole:    oldRole,
-		NewRole:    newRole,
+		BaseEventMessage: newEventMessage(MemberPromotedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		UserID:           userID,
+		PromotedBy:       promotedBy,
+		OldRole:          oldRole,
+		NewRole:          newRole,
 	}
 }
 
@@ -294,24 +286,12 @@ type MiningOperationStartedEvent struct {
 // NewMiningOperationStartedEvent creates a new mining operation started event
 func NewMiningOperationStartedEvent(guildID, operationID, nodeID string, workerIDs []string, startedBy string) *MiningOperationStartedEvent {
 	return &MiningOperationStartedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MiningOperationStartedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":     guildID,
-				"operation_id": operationID,
-				"node_id":      nodeID,
-				"worker_ids":   workerIDs,
-				"started_by":   startedBy,
-			},
-		),
-		GuildID:     guildID,
-		OperationID: operationID,
-		NodeID:      nodeID,
-		WorkerIDs:   workerIDs,
-		StartedBy:   startedBy,
+		BaseEventMessage: newEventMessage(MiningOperationStartedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		OperationID:      operationID,
+		NodeID:           nodeID,
+		WorkerIDs:        workerIDs,
+		StartedBy:        startedBy,
 	}
 }
 
@@ -323,10 +303,11 @@ type MineralsHarvestedEvent struct {
 	Harvested        map[MineralType]int64 `json:"harvested"`
 	TreasuryIncrease int64                 `json:"treasury_increase"`
 	HarvestedBy      string                `json:"harvested_by"`
+	WorkerShares     map[string]int64      `json:"worker_shares"` // userID -> minerals mined
 }
 
 // NewMineralsHarvestedEvent creates a new minerals harvested event
-func NewMineralsHarvestedEvent(guildID, operationID string, harvested map[MineralType]int64, treasuryIncrease int64, harvestedBy string) *MineralsHarvestedEvent {
+func NewMineralsHarvestedEvent(guildID, operationID string, harvested map[MineralType]int64, treasuryIncrease int64, harvestedBy string, workerShares map[string]int64) *MineralsHarvestedEvent {
 	// Convert map for serialization
 	harvestedData := make(map[string]interface{})
 	for mineralType, amount := range harvested {
@@ -334,24 +315,13 @@ func NewMineralsHarvestedEvent(guildID, operationID string, harvested map[Minera
 	}
 
 	return &MineralsHarvestedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MineralsHarvestedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":          guildID,
-				"operation_id":      operationID,
-				"harvested":         harvestedData,
-				"treasury_increase": treasuryIncrease,
-				"harvested_by":      harvestedBy,
-			},
-		),
+		BaseEventMessage: newEventMessage(MineralsHarvestedEventType, guildID, "Guild"),
 		GuildID:          guildID,
 		OperationID:      operationID,
 		Harvested:        harvested,
 		TreasuryIncrease: treasuryIncrease,
 		HarvestedBy:      harvestedBy,
+		WorkerShares:     workerShares,
 	}
 }
 
@@ -366,20 +336,10 @@ type MiningOperationStoppedEvent struct {
 // NewMiningOperationStoppedEvent creates a new mining operation stopped event
 func NewMiningOperationStoppedEvent(guildID, operationID, stoppedBy string) *MiningOperationStoppedEvent {
 	return &MiningOperationStoppedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			MiningOperationStoppedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":     guildID,
-				"operation_id": operationID,
-				"stopped_by":   stoppedBy,
-			},
-		),
-		GuildID:     guildID,
-		OperationID: operationID,
-		StoppedBy:   stoppedBy,
+		BaseEventMessage: newEventMessage(MiningOperationStoppedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		OperationID:      operationID,
+		StoppedBy:        stoppedBy,
 	}
 }
 
@@ -397,6 +357,7 @@ type TransportRecruitmentCreatedEvent struct {
 	Duration          int64                 `json:"duration"`       // Duration in nanoseconds
 	TransportTime     int64                 `json:"transport_time"` // Transport time in nanoseconds
 	TotalCargo        map[MineralType]int64 `json:"total_cargo"`
+	RewardPerPerson   map[MineralType]int64 `json:"reward_per_person"`
 	CreatedBy         string                `json:"created_by"`
 	CreatedByUsername string                `json:"created_by_username"`
 }
@@ -404,34 +365,20 @@ type TransportRecruitmentCreatedEvent struct {
 // NewTransportRecruitmentCreatedEvent creates a new transport recruitment created event
 func NewTransportRecruitmentCreatedEvent(guildID, recruitmentID, title, description string,
 	maxParticipants, minParticipants int, duration, transportTime int64,
-	totalCargo map[MineralType]int64, createdBy, createdByUsername string) *TransportRecruitmentCreatedEvent {
+	totalCargo, rewardPerPerson map[MineralType]int64, createdBy, createdByUsername string) *TransportRecruitmentCreatedEvent {
 
-	// Convert map for serialization
+	// Convert maps for serialization
 	cargoData := make(map[string]interface{})
 	for mineralType, amount := range totalCargo {
 		cargoData[mineralType.String()] = amount
 	}
+	rewardData := make(map[string]interface{})
+	for mineralType, amount := range rewardPerPerson {
+		rewardData[mineralType.String()] = amount
+	}
 
 	return &TransportRecruitmentCreatedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			TransportRecruitmentCreatedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":            guildID,
-				"recruitment_id":      recruitmentID,
-				"title":               title,
-				"description":         description,
-				"max_participants":    maxParticipants,
-				"min_participants":    minParticipants,
-				"duration":            duration,
-				"transport_time":      transportTime,
-				"total_cargo":         cargoData,
-				"created_by":          createdBy,
-				"created_by_username": createdByUsername,
-			},
-		),
+		BaseEventMessage:  newEventMessage(TransportRecruitmentCreatedEventType, guildID, "Guild"),
 		GuildID:           guildID,
 		RecruitmentID:     recruitmentID,
 		Title:             title,
@@ -441,6 +388,7 @@ func NewTransportRecruitmentCreatedEvent(guildID, recruitmentID, title, descript
 		Duration:          duration,
 		TransportTime:     transportTime,
 		TotalCargo:        totalCargo,
+		RewardPerPerson:   rewardPerPerson,
 		CreatedBy:         createdBy,
 		CreatedByUsername: createdByUsername,
 	}
@@ -458,22 +406,11 @@ type TransportRecruitmentJoinedEvent struct {
 // NewTransportRecruitmentJoinedEvent creates a new transport recruitment joined event
 func NewTransportRecruitmentJoinedEvent(guildID, recruitmentID, userID, username string) *TransportRecruitmentJoinedEvent {
 	return &TransportRecruitmentJoinedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			TransportRecruitmentJoinedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":       guildID,
-				"recruitment_id": recruitmentID,
-				"user_id":        userID,
-				"username":       username,
-			},
-		),
-		GuildID:       guildID,
-		RecruitmentID: recruitmentID,
-		UserID:        userID,
-		Username:      username,
+		BaseEventMessage: newEventMessage(TransportRecruitmentJoinedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		RecruitmentID:    recruitmentID,
+		UserID:           userID,
+		Username:         username,
 	}
 }
 
@@ -489,22 +426,11 @@ type TransportRecruitmentLeftEvent struct {
 // NewTransportRecruitmentLeftEvent creates a new transport recruitment left event
 func NewTransportRecruitmentLeftEvent(guildID, recruitmentID, userID, username string) *TransportRecruitmentLeftEvent {
 	return &TransportRecruitmentLeftEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			TransportRecruitmentLeftEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":       guildID,
-				"recruitment_id": recruitmentID,
-				"user_id":        userID,
-				"username":       username,
-			},
-		),
-		GuildID:       guildID,
-		RecruitmentID: recruitmentID,
-		UserID:        userID,
-		Username:      username,
+		BaseEventMessage: newEventMessage(TransportRecruitmentLeftEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		RecruitmentID:    recruitmentID,
+		UserID:           userID,
+		Username:         username,
 	}
 }
 
@@ -520,22 +446,11 @@ type TransportRecruitmentStartedEvent struct {
 // NewTransportRecruitmentStartedEvent creates a new transport recruitment started event
 func NewTransportRecruitmentStartedEvent(guildID, recruitmentID, transportID, startedBy string) *TransportRecruitmentStartedEvent {
 	return &TransportRecruitmentStartedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			TransportRecruitmentStartedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":       guildID,
-				"recruitment_id": recruitmentID,
-				"transport_id":   transportID,
-				"started_by":     startedBy,
-			},
-		),
-		GuildID:       guildID,
-		RecruitmentID: recruitmentID,
-		TransportID:   transportID,
-		StartedBy:     startedBy,
+		BaseEventMessage: newEventMessage(TransportRecruitmentStartedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		RecruitmentID:    recruitmentID,
+		TransportID:      transportID,
+		StartedBy:        startedBy,
 	}
 }
 
@@ -561,21 +476,189 @@ func NewTransportRecruitmentCompletedEvent(guildID, recruitmentID string, reward
 	}
 
 	return &TransportRecruitmentCompletedEvent{
-		BaseEventMessage: cqrs.NewBaseEventMessage(
-			TransportRecruitmentCompletedEventType,
-			guildID,
-			"Guild",
-			1, // version
-			map[string]interface{}{
-				"guild_id":       guildID,
-				"recruitment_id": recruitmentID,
-				"rewards":        rewardsData,
-				"completed_by":   completedBy,
-			},
-		),
-		GuildID:       guildID,
-		RecruitmentID: recruitmentID,
-		Rewards:       rewards,
-		CompletedBy:   completedBy,
+		BaseEventMessage: newEventMessage(TransportRecruitmentCompletedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		RecruitmentID:    recruitmentID,
+		Rewards:          rewards,
+		CompletedBy:      completedBy,
+	}
+}
+
+// TransportRecruitmentCancelledEvent represents a transport recruitment cancellation event.
+// Recruitments do not reserve guild minerals, so cancelling one returns nothing.
+type TransportRecruitmentCancelledEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID       string   `json:"guild_id"`
+	RecruitmentID string   `json:"recruitment_id"`
+	Reason        string   `json:"reason"`
+	Participants  []string `json:"participants"`
+	CancelledBy   string   `json:"cancelled_by"`
+}
+
+// NewTransportRecruitmentCancelledEvent creates a new transport recruitment cancelled event
+func NewTransportRecruitmentCancelledEvent(guildID, recruitmentID, reason string, participants []string, cancelledBy string) *TransportRecruitmentCancelledEvent {
+	return &TransportRecruitmentCancelledEvent{
+		BaseEventMessage: newEventMessage(TransportRecruitmentCancelledEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		RecruitmentID:    recruitmentID,
+		Reason:           reason,
+		Participants:     participants,
+		CancelledBy:      cancelledBy,
+	}
+}
+
+// Contribution Events
+
+// TreasuryDonatedEvent represents a member donating to the guild treasury
+type TreasuryDonatedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID string `json:"guild_id"`
+	UserID  string `json:"user_id"`
+	Amount  int64  `json:"amount"`
+}
+
+// NewTreasuryDonatedEvent creates a new treasury donated event
+func NewTreasuryDonatedEvent(guildID, userID string, amount int64) *TreasuryDonatedEvent {
+	return &TreasuryDonatedEvent{
+		BaseEventMessage: newEventMessage(TreasuryDonatedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		UserID:           userID,
+		Amount:           amount,
+	}
+}
+
+// MemberContributionWeeklySummaryEvent summarizes member contributions for a finished week
+type MemberContributionWeeklySummaryEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID       string               `json:"guild_id"`
+	WeekStart     time.Time            `json:"week_start"`
+	Contributions []MemberContribution `json:"contributions"` // Ordered by points, highest first
+}
+
+// NewMemberContributionWeeklySummaryEvent creates a new weekly contribution summary event
+func NewMemberContributionWeeklySummaryEvent(guildID string, weekStart time.Time, contributions []MemberContribution) *MemberContributionWeeklySummaryEvent {
+	return &MemberContributionWeeklySummaryEvent{
+		BaseEventMessage: newEventMessage(MemberContributionWeeklySummaryEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		WeekStart:        weekStart,
+		Contributions:    contributions,
+	}
+}
+
+// Progression Events
+
+// GuildExperienceGainedEvent represents experience awarded to the guild
+type GuildExperienceGainedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID string           `json:"guild_id"`
+	Source  ExperienceSource `json:"source"`
+	Amount  int64            `json:"amount"`
+	Reason  string           `json:"reason"`
+}
+
+// NewGuildExperienceGainedEvent creates a new guild experience gained event
+func NewGuildExperienceGainedEvent(guildID string, source ExperienceSource, amount int64, reason string) *GuildExperienceGainedEvent {
+	return &GuildExperienceGainedEvent{
+		BaseEventMessage: newEventMessage(GuildExperienceGainedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		Source:           source,
+		Amount:           amount,
+		Reason:           reason,
+	}
+}
+
+// GuildLeveledUpEvent represents the guild reaching a new level
+type GuildLeveledUpEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID       string      `json:"guild_id"`
+	PreviousLevel int         `json:"previous_level"`
+	NewLevel      int         `json:"new_level"`
+	UnlockedPerks []GuildPerk `json:"unlocked_perks"`
+}
+
+// NewGuildLeveledUpEvent creates a new guild leveled up event
+func NewGuildLeveledUpEvent(guildID string, previousLevel, newLevel int, unlockedPerks []GuildPerk) *GuildLeveledUpEvent {
+	perkIDs := make([]string, len(unlockedPerks))
+	for i, perk := range unlockedPerks {
+		perkIDs[i] = perk.ID
+	}
+
+	return &GuildLeveledUpEvent{
+		BaseEventMessage: newEventMessage(GuildLeveledUpEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		PreviousLevel:    previousLevel,
+		NewLevel:         newLevel,
+		UnlockedPerks:    unlockedPerks,
+	}
+}
+
+// Chat and Moderation Events
+
+// GuildChatMessagePostedEvent represents a message posted to the guild chat
+type GuildChatMessagePostedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID   string `json:"guild_id"`
+	MessageID string `json:"message_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+}
+
+// NewGuildChatMessagePostedEvent creates a new guild chat message posted event
+func NewGuildChatMessagePostedEvent(guildID, messageID, userID, text string) *GuildChatMessagePostedEvent {
+	return &GuildChatMessagePostedEvent{
+		BaseEventMessage: newEventMessage(GuildChatMessagePostedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		MessageID:        messageID,
+		UserID:           userID,
+		Text:             text,
+	}
+}
+
+// GuildContentFlaggedEvent represents content that passed moderation but needs human review
+type GuildContentFlaggedEvent struct {
+	*cqrs.BaseEventMessage
+	GuildID    string   `json:"guild_id"`
+	ContentKey string   `json:"content_key"` // message ID for chat, field name for guild info
+	Kind       string   `json:"kind"`
+	AuthorID   string   `json:"author_id"`
+	Text       string   `json:"text"`
+	Reasons    []string `json:"reasons"`
+}
+
+// NewGuildContentFlaggedEvent creates a new guild content flagged event
+func NewGuildContentFlaggedEvent(guildID, contentKey string, kind ContentKind, authorID, text string, reasons []string) *GuildContentFlaggedEvent {
+	return &GuildContentFlaggedEvent{
+		BaseEventMessage: newEventMessage(GuildContentFlaggedEventType, guildID, "Guild"),
+		GuildID:          guildID,
+		ContentKey:       contentKey,
+		Kind:             kind.String(),
+		AuthorID:         authorID,
+		Text:             text,
+		Reasons:          reasons,
+	}
+}
+
+// Economy Events
+
+// EconomyConfigChangedEvent records a change of the economy balancing values for audit
+type EconomyConfigChangedEvent struct {
+	*cqrs.BaseEventMessage
+	PreviousVersion int                    `json:"previous_version"`
+	ConfigVersion   int                    `json:"config_version"`
+	Config          map[string]interface{} `json:"config"`
+	ChangedBy       string                 `json:"changed_by"`
+	Reason          string                 `json:"reason"`
+}
+
+// NewEconomyConfigChangedEvent creates a new economy config changed event
+func NewEconomyConfigChangedEvent(previousVersion int, config *EconomyConfig, changedBy, reason string) *EconomyConfigChangedEvent {
+	configData := config.ToMap()
+	return &EconomyConfigChangedEvent{
+		BaseEventMessage: newEventMessage(EconomyConfigChangedEventType, "economy", "EconomyConfig"),
+		PreviousVersion:  previousVersion,
+		ConfigVersion:    config.Version,
+		Config:           configData,
+		ChangedBy:        changedBy,
+		Reason:           reason,
 	}
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"cqrs"
@@ -49,6 +50,8 @@ type GuildAggregate struct {
 	isPublic        bool
 	requireApproval bool
 	minLevel        int
+	region          string // Matchmaking region, e.g. "eu-west"
+	language        string // Primary chat language, e.g. "ko"
 
 	// Guild members
 	members map[string]*GuildMember // userID -> member
@@ -65,14 +68,27 @@ type GuildAggregate struct {
 	experience        int64
 	ranking           int
 
+	// Progression; nil uses DefaultGuildProgressionConfig
+	progression *GuildProgressionConfig
+	perks       map[string]GuildPerk // perkID -> unlocked perk
+
 	// Mining system
 	mining *GuildMining
 
+	// Economy balancing; nil uses DefaultEconomyConfig
+	economy EconomyConfigProvider
+
+	// Custom emblem; nil until one is uploaded
+	emblem *GuildEmblem
+
 	// Timestamps
 	foundedAt    time.Time
 	lastActiveAt time.Time
 }
 
+// defaultMaxMembers is the member capacity of a new guild before settings and perks
+const defaultMaxMembers = 50
+
 // NewGuildAggregate creates a new guild aggregate
 func NewGuildAggregate(id, name, description, founderID, founderUsername string) *GuildAggregate {
 	now := time.Now()
@@ -84,7 +100,7 @@ func NewGuildAggregate(id, name, description, founderID, founderUsername string)
 		notice:                "",
 		tag:                   "",
 		status:                GuildStatusActive,
-		maxMembers:            50, // Default max members
+		maxMembers:            defaultMaxMembers,
 		isPublic:              true,
 		requireApproval:       false,
 		minLevel:              1,
@@ -93,6 +109,7 @@ func NewGuildAggregate(id, name, description, founderID, founderUsername string)
 		mines:                 make(map[string]*Mine),
 		transports:            make(map[string]*Transport),
 		transportRecruitments: make(map[string]*TransportRecruitment),
+		perks:                 make(map[string]GuildPerk),
 		totalContribution:     0,
 		level:                 1,
 		experience:            0,
@@ -122,15 +139,18 @@ func LoadGuildAggregate(id string, events []cqrs.EventMessage) (*GuildAggregate,
 		mines:                 make(map[string]*Mine),
 		transports:            make(map[string]*Transport),
 		transportRecruitments: make(map[string]*TransportRecruitment),
+		perks:                 make(map[string]GuildPerk),
 	}
 
 	for _, event := range events {
-		if err := guild.ApplyEvent(event); err != nil {
+		if err := guild.BaseAggregate.ReplayEvent(event); err != nil {
+			return nil, fmt.Errorf("failed to replay event %s: %w", event.EventType(), err)
+		}
+		if err := guild.applyDomainEvent(event); err != nil {
 			return nil, fmt.Errorf("failed to apply event %s: %w", event.EventType(), err)
 		}
 	}
 
-	guild.ClearChanges()
 	return guild, nil
 }
 
@@ -172,6 +192,50 @@ func (g *GuildAggregate) UpdateSettings(maxMembers, minLevel int, isPublic, requ
 	return nil
 }
 
+// UpdateLocale sets the region and language the guild is listed under in guild search
+func (g *GuildAggregate) UpdateLocale(region, language, updatedBy string) error {
+	member, exists := g.members[updatedBy]
+	if !exists {
+		return fmt.Errorf("user %s is not a member of the guild", updatedBy)
+	}
+
+	if !member.HasPermission(PermissionManageGuild) {
+		return fmt.Errorf("user %s does not have permission to manage guild", updatedBy)
+	}
+
+	event := NewGuildLocaleUpdatedEvent(g.ID(), region, language, updatedBy)
+	g.Apply(event, true)
+	return nil
+}
+
+// UpdateEmblem links a verified emblem upload to the guild
+func (g *GuildAggregate) UpdateEmblem(emblem GuildEmblem, updatedBy string) error {
+	if g.status != GuildStatusActive {
+		return fmt.Errorf("guild is not active")
+	}
+
+	member, exists := g.members[updatedBy]
+	if !exists {
+		return fmt.Errorf("user %s is not a member of the guild", updatedBy)
+	}
+
+	if !member.HasPermission(PermissionManageGuild) {
+		return fmt.Errorf("user %s does not have permission to manage guild", updatedBy)
+	}
+
+	if !g.HasPerk(EmblemPerkID) {
+		return fmt.Errorf("guild has not unlocked custom emblems")
+	}
+
+	if emblem.ObjectKey == "" {
+		return fmt.Errorf("emblem object key cannot be empty")
+	}
+
+	event := NewGuildEmblemUpdatedEvent(g.ID(), emblem, updatedBy)
+	g.Apply(event, true)
+	return nil
+}
+
 // Member management operations
 
 // InviteMember invites a new member to the guild
@@ -268,6 +332,98 @@ func (g *GuildAggregate) PromoteMember(userID, promotedBy string, newRole GuildR
 	return nil
 }
 
+// Chat and moderation operations
+
+// PostChatMessage posts a message to the guild chat
+func (g *GuildAggregate) PostChatMessage(messageID, userID, text string) error {
+	if g.status != GuildStatusActive {
+		return fmt.Errorf("guild is not active")
+	}
+
+	member, exists := g.members[userID]
+	if !exists || member.Status != StatusActive {
+		return fmt.Errorf("user %s is not an active member of the guild", userID)
+	}
+
+	if !member.HasPermission(PermissionChat) {
+		return fmt.Errorf("user %s does not have permission to chat", userID)
+	}
+
+	event := NewGuildChatMessagePostedEvent(g.ID(), messageID, userID, text)
+	g.Apply(event, true)
+	return nil
+}
+
+// FlagContent records that accepted content was flagged by moderation and needs review
+func (g *GuildAggregate) FlagContent(contentKey string, kind ContentKind, authorID, text string, reasons []string) {
+	event := NewGuildContentFlaggedEvent(g.ID(), contentKey, kind, authorID, text, reasons)
+	g.Apply(event, true)
+}
+
+// DonateToTreasury records a member's donation to the guild treasury
+func (g *GuildAggregate) DonateToTreasury(userID string, amount int64) error {
+	if g.status != GuildStatusActive {
+		return fmt.Errorf("guild is not active")
+	}
+
+	member, exists := g.members[userID]
+	if !exists || member.Status != StatusActive {
+		return fmt.Errorf("user %s is not an active member of the guild", userID)
+	}
+
+	if amount <= 0 {
+		return fmt.Errorf("donation amount must be positive")
+	}
+
+	event := NewTreasuryDonatedEvent(g.ID(), userID, amount)
+	g.Apply(event, true)
+	return nil
+}
+
+// Progression operations
+
+// SetProgressionConfig sets the experience and level-up rules for new commands
+func (g *GuildAggregate) SetProgressionConfig(config *GuildProgressionConfig) {
+	g.progression = config
+}
+
+// GetProgressionConfig returns the progression rules in effect
+func (g *GuildAggregate) GetProgressionConfig() *GuildProgressionConfig {
+	if g.progression != nil {
+		return g.progression
+	}
+	return DefaultGuildProgressionConfig()
+}
+
+// AwardExperience grants experience from an external source such as a guild war
+func (g *GuildAggregate) AwardExperience(source ExperienceSource, amount int64, reason string) error {
+	if g.status != GuildStatusActive {
+		return fmt.Errorf("guild is not active")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("experience amount must be positive")
+	}
+
+	g.grantExperience(source, amount, reason)
+	return nil
+}
+
+// grantExperience records gained experience and levels the guild up when thresholds are crossed
+func (g *GuildAggregate) grantExperience(source ExperienceSource, amount int64, reason string) {
+	if amount <= 0 {
+		return
+	}
+
+	g.Apply(NewGuildExperienceGainedEvent(g.ID(), source, amount, reason), true)
+
+	config := g.GetProgressionConfig()
+	newLevel := config.LevelFor(g.experience)
+	if newLevel > g.level {
+		event := NewGuildLeveledUpEvent(g.ID(), g.level, newLevel, config.PerksBetween(g.level, newLevel))
+		g.Apply(event, true)
+	}
+}
+
 // Getters
 
 // GetName returns the guild name
@@ -285,6 +441,16 @@ func (g *GuildAggregate) GetStatus() GuildStatus {
 	return g.status
 }
 
+// GetRegion returns the guild's matchmaking region
+func (g *GuildAggregate) GetRegion() string {
+	return g.region
+}
+
+// GetLanguage returns the guild's primary language
+func (g *GuildAggregate) GetLanguage() string {
+	return g.language
+}
+
 // GetMember returns a guild member by user ID
 func (g *GuildAggregate) GetMember(userID string) (*GuildMember, bool) {
 	member, exists := g.members[userID]
@@ -331,6 +497,34 @@ func (g *GuildAggregate) GetLevel() int {
 	return g.level
 }
 
+// GetExperience returns the guild's total experience
+func (g *GuildAggregate) GetExperience() int64 {
+	return g.experience
+}
+
+// GetPerks returns the perks unlocked so far
+func (g *GuildAggregate) GetPerks() []GuildPerk {
+	perks := make([]GuildPerk, 0, len(g.perks))
+	for _, perk := range g.perks {
+		perks = append(perks, perk)
+	}
+	return perks
+}
+
+// HasPerk checks if the guild has unlocked a perk
+func (g *GuildAggregate) HasPerk(perkID string) bool {
+	_, exists := g.perks[perkID]
+	return exists
+}
+
+// GetEmblem returns the current custom emblem, if any
+func (g *GuildAggregate) GetEmblem() (GuildEmblem, bool) {
+	if g.emblem == nil {
+		return GuildEmblem{}, false
+	}
+	return *g.emblem, true
+}
+
 // Mining operations
 
 // GetMining returns the guild mining state
@@ -341,6 +535,21 @@ func (g *GuildAggregate) GetMining() *GuildMining {
 	return g.mining
 }
 
+// SetEconomyConfigProvider sets the source of economy balancing values for new commands
+func (g *GuildAggregate) SetEconomyConfigProvider(provider EconomyConfigProvider) {
+	g.economy = provider
+}
+
+// GetEconomyConfig returns the economy config currently in effect
+func (g *GuildAggregate) GetEconomyConfig() *EconomyConfig {
+	if g.economy != nil {
+		if config := g.economy.EconomyConfig(); config != nil {
+			return config
+		}
+	}
+	return DefaultEconomyConfig()
+}
+
 // StartMiningOperation starts a new mining operation
 func (g *GuildAggregate) StartMiningOperation(operationID, nodeID string, workerUserIDs []string, startedBy string) error {
 	member, exists := g.members[startedBy]
@@ -391,22 +600,35 @@ func (g *GuildAggregate) HarvestMinerals(operationID string, harvestedBy string)
 		return nil, fmt.Errorf("user %s does not have permission to manage mining", harvestedBy)
 	}
 
+	economy := g.GetEconomyConfig()
 	mining := g.GetMining()
-	harvested, err := mining.HarvestMinerals(operationID)
+	harvested, err := mining.HarvestMinerals(operationID, economy)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(harvested) > 0 {
 		// Calculate treasury value from harvested minerals
-		treasuryIncrease := int64(0)
-		for mineralType, amount := range harvested {
-			treasuryIncrease += amount * mineralType.GetValue()
-		}
+		treasuryIncrease := economy.MineralsValue(harvested)
 		g.treasury += treasuryIncrease
 
-		event := NewMineralsHarvestedEvent(g.ID(), operationID, harvested, treasuryIncrease, harvestedBy)
+		// Credit the operation's workers with the mined minerals
+		total := int64(0)
+		for _, amount := range harvested {
+			total += amount
+		}
+		workerIDs := make([]string, 0)
+		if operation, exists := mining.ActiveOperations[operationID]; exists {
+			for userID := range operation.Workers {
+				workerIDs = append(workerIDs, userID)
+			}
+		}
+
+		event := NewMineralsHarvestedEvent(g.ID(), operationID, harvested, treasuryIncrease, harvestedBy, splitHarvest(total, workerIDs))
 		g.Apply(event, true)
+
+		xp := g.GetProgressionConfig().MiningExperience(treasuryIncrease)
+		g.grantExperience(ExperienceFromMining, xp, fmt.Sprintf("mining operation %s", operationID))
 	}
 
 	return harvested, nil
@@ -435,13 +657,21 @@ func (g *GuildAggregate) StopMiningOperation(operationID string, stoppedBy strin
 
 // Event application methods
 
-// Apply applies an event to the aggregate (overrides BaseAggregate.Apply)
+// Apply applies an event to the aggregate; new events are tracked as uncommitted changes
 func (g *GuildAggregate) Apply(event cqrs.EventMessage, isNew bool) {
-	// Call base implementation for infrastructure concerns
-	g.BaseAggregate.Apply(event, isNew)
+	// Call base implementation for infrastructure concerns (version, change tracking)
+	var err error
+	if isNew {
+		err = g.BaseAggregate.ApplyEvent(event)
+	} else {
+		err = g.BaseAggregate.ReplayEvent(event)
+	}
 
 	// Apply domain-specific logic
-	if err := g.applyDomainEvent(event); err != nil {
+	if err == nil {
+		err = g.applyDomainEvent(event)
+	}
+	if err != nil {
 		// In a real implementation, you might want to handle this differently
 		panic(fmt.Sprintf("failed to apply event: %v", err))
 	}
@@ -461,6 +691,10 @@ func (g *GuildAggregate) applyDomainEvent(event cqrs.EventMessage) error {
 		return g.applyGuildInfoUpdatedEvent(e)
 	case *GuildSettingsUpdatedEvent:
 		return g.applyGuildSettingsUpdatedEvent(e)
+	case *GuildEmblemUpdatedEvent:
+		return g.applyGuildEmblemUpdatedEvent(e)
+	case *GuildLocaleUpdatedEvent:
+		return g.applyGuildLocaleUpdatedEvent(e)
 	case *MemberInvitedEvent:
 		return g.applyMemberInvitedEvent(e)
 	case *MemberJoinedEvent:
@@ -469,6 +703,17 @@ func (g *GuildAggregate) applyDomainEvent(event cqrs.EventMessage) error {
 		return g.applyMemberKickedEvent(e)
 	case *MemberPromotedEvent:
 		return g.applyMemberPromotedEvent(e)
+	case *TreasuryDonatedEvent:
+		return g.applyTreasuryDonatedEvent(e)
+	case *GuildExperienceGainedEvent:
+		return g.applyGuildExperienceGainedEvent(e)
+	case *GuildLeveledUpEvent:
+		return g.applyGuildLeveledUpEvent(e)
+	case *GuildChatMessagePostedEvent:
+		return g.applyGuildChatMessagePostedEvent(e)
+	case *GuildContentFlaggedEvent:
+		// Flags only feed the review queue read model
+		return nil
 	case *MiningOperationStartedEvent:
 		return g.applyMiningOperationStartedEvent(e)
 	case *MineralsHarvestedEvent:
@@ -485,6 +730,8 @@ func (g *GuildAggregate) applyDomainEvent(event cqrs.EventMessage) error {
 		return g.applyTransportRecruitmentStartedEvent(e)
 	case *TransportRecruitmentCompletedEvent:
 		return g.applyTransportRecruitmentCompletedEvent(e)
+	case *TransportRecruitmentCancelledEvent:
+		return g.applyTransportRecruitmentCancelledEvent(e)
 	default:
 		return fmt.Errorf("unknown event type: %s", event.EventType())
 	}
@@ -493,6 +740,11 @@ func (g *GuildAggregate) applyDomainEvent(event cqrs.EventMessage) error {
 func (g *GuildAggregate) applyGuildCreatedEvent(event *GuildCreatedEvent) error {
 	g.name = event.Name
 	g.description = event.Description
+	g.status = GuildStatusActive
+	g.maxMembers = defaultMaxMembers
+	g.isPublic = true
+	g.minLevel = 1
+	g.level = 1
 	g.foundedAt = event.Timestamp()
 	g.lastActiveAt = event.Timestamp()
 
@@ -525,6 +777,28 @@ func (g *GuildAggregate) applyGuildSettingsUpdatedEvent(event *GuildSettingsUpda
 	return nil
 }
 
+func (g *GuildAggregate) applyGuildLocaleUpdatedEvent(event *GuildLocaleUpdatedEvent) error {
+	g.region = event.Region
+	g.language = event.Language
+	g.lastActiveAt = event.Timestamp()
+
+	return nil
+}
+
+func (g *GuildAggregate) applyGuildEmblemUpdatedEvent(event *GuildEmblemUpdatedEvent) error {
+	g.emblem = &GuildEmblem{
+		ObjectKey:   event.ObjectKey,
+		ContentType: event.ContentType,
+		Size:        event.Size,
+		URL:         event.URL,
+		UpdatedBy:   event.UpdatedBy,
+		UpdatedAt:   event.Timestamp(),
+	}
+	g.lastActiveAt = event.Timestamp()
+
+	return nil
+}
+
 func (g *GuildAggregate) applyMemberInvitedEvent(event *MemberInvitedEvent) error {
 	member := NewGuildMember(event.UserID, event.Username, event.InvitedBy)
 	g.members[event.UserID] = member
@@ -560,6 +834,47 @@ func (g *GuildAggregate) applyMemberPromotedEvent(event *MemberPromotedEvent) er
 	return nil
 }
 
+func (g *GuildAggregate) applyTreasuryDonatedEvent(event *TreasuryDonatedEvent) error {
+	g.treasury += event.Amount
+	g.totalContribution += event.Amount
+	if member, exists := g.members[event.UserID]; exists {
+		member.Contribution += event.Amount
+		member.LastActiveAt = event.Timestamp()
+	}
+	g.lastActiveAt = event.Timestamp()
+
+	return nil
+}
+
+func (g *GuildAggregate) applyGuildExperienceGainedEvent(event *GuildExperienceGainedEvent) error {
+	g.experience += event.Amount
+	g.lastActiveAt = event.Timestamp()
+
+	return nil
+}
+
+func (g *GuildAggregate) applyGuildLeveledUpEvent(event *GuildLeveledUpEvent) error {
+	g.level = event.NewLevel
+	for _, perk := range event.UnlockedPerks {
+		if _, exists := g.perks[perk.ID]; exists {
+			continue
+		}
+		g.perks[perk.ID] = perk
+		g.maxMembers += perk.MaxMembersBonus
+	}
+
+	return nil
+}
+
+func (g *GuildAggregate) applyGuildChatMessagePostedEvent(event *GuildChatMessagePostedEvent) error {
+	if member, exists := g.members[event.UserID]; exists {
+		member.LastActiveAt = event.Timestamp()
+	}
+	g.lastActiveAt = event.Timestamp()
+
+	return nil
+}
+
 // Validation
 
 // Validate validates the guild aggregate
@@ -614,6 +929,7 @@ func (g *GuildAggregate) CreateTransportRecruitment(recruitmentID, title, descri
 
 	recruitment := NewTransportRecruitment(recruitmentID, g.ID(), createdBy, member.Username,
 		title, description, maxParticipants, minParticipants, duration, transportTime, totalCargo)
+	recruitment.RewardPerPerson = g.GetEconomyConfig().TransportRewardPerPerson(totalCargo, maxParticipants)
 
 	if err := recruitment.Validate(); err != nil {
 		return fmt.Errorf("invalid recruitment data: %w", err)
@@ -623,7 +939,7 @@ func (g *GuildAggregate) CreateTransportRecruitment(recruitmentID, title, descri
 
 	// Apply event
 	event := NewTransportRecruitmentCreatedEvent(g.ID(), recruitmentID, title, description,
-		maxParticipants, minParticipants, int64(duration), int64(transportTime), totalCargo, createdBy, member.Username)
+		maxParticipants, minParticipants, int64(duration), int64(transportTime), totalCargo, recruitment.RewardPerPerson, createdBy, member.Username)
 	g.Apply(event, true)
 
 	return nil
@@ -711,6 +1027,51 @@ func (g *GuildAggregate) StartTransportFromRecruitment(recruitmentID, transportI
 	return nil
 }
 
+// ProcessExpiredRecruitment settles a recruitment whose recruiting window has closed.
+// The transport starts if enough members joined; otherwise the recruitment is
+// cancelled. Returns true if the transport was started.
+func (g *GuildAggregate) ProcessExpiredRecruitment(recruitmentID, transportID string, now time.Time) (bool, error) {
+	recruitment, exists := g.transportRecruitments[recruitmentID]
+	if !exists {
+		return false, fmt.Errorf("transport recruitment %s not found", recruitmentID)
+	}
+
+	if recruitment.Status != RecruitmentStatusOpen && recruitment.Status != RecruitmentStatusFull {
+		return false, fmt.Errorf("recruitment is not active: status=%s", recruitment.Status.String())
+	}
+
+	if now.Before(recruitment.ExpiresAt) {
+		return false, fmt.Errorf("recruitment %s has not expired yet", recruitmentID)
+	}
+
+	if recruitment.CanStart() {
+		if err := recruitment.StartTransport(transportID); err != nil {
+			return false, err
+		}
+
+		// Apply event
+		event := NewTransportRecruitmentStartedEvent(g.ID(), recruitmentID, transportID, SystemActorID)
+		g.Apply(event, true)
+		return true, nil
+	}
+
+	participants := make([]string, 0, len(recruitment.Participants))
+	for userID := range recruitment.Participants {
+		participants = append(participants, userID)
+	}
+	sort.Strings(participants)
+
+	if err := recruitment.CancelRecruitment(); err != nil {
+		return false, err
+	}
+
+	// Apply event
+	event := NewTransportRecruitmentCancelledEvent(g.ID(), recruitmentID, RecruitmentCancelReasonUnderSubscribed,
+		participants, SystemActorID)
+	g.Apply(event, true)
+	return false, nil
+}
+
 // CompleteTransportRecruitment completes a transport recruitment and distributes rewards
 func (g *GuildAggregate) CompleteTransportRecruitment(recruitmentID string, completedBy string) (map[string]map[MineralType]int64, error) {
 	member, exists := g.members[completedBy]
@@ -741,6 +1102,9 @@ func (g *GuildAggregate) CompleteTransportRecruitment(recruitmentID string, comp
 	event := NewTransportRecruitmentCompletedEvent(g.ID(), recruitmentID, rewards, completedBy)
 	g.Apply(event, true)
 
+	xp := g.GetProgressionConfig().TransportExperience(len(recruitment.Participants))
+	g.grantExperience(ExperienceFromTransport, xp, fmt.Sprintf("transport recruitment %s", recruitmentID))
+
 	return rewards, nil
 }
 
@@ -774,6 +1138,9 @@ func (g *GuildAggregate) ForceCompleteTransportRecruitment(recruitmentID string,
 	event := NewTransportRecruitmentCompletedEvent(g.ID(), recruitmentID, rewards, completedBy)
 	g.Apply(event, true)
 
+	xp := g.GetProgressionConfig().TransportExperience(len(recruitment.Participants))
+	g.grantExperience(ExperienceFromTransport, xp, fmt.Sprintf("transport recruitment %s", recruitmentID))
+
 	return rewards, nil
 }
 
@@ -828,6 +1195,11 @@ func (g *GuildAggregate) applyTransportRecruitmentCreatedEvent(event *TransportR
 	recruitment := NewTransportRecruitment(event.RecruitmentID, g.ID(), event.CreatedBy, event.CreatedByUsername,
 		event.Title, event.Description, event.MaxParticipants, event.MinParticipants, duration, transportTime, event.TotalCargo)
 
+	// Rewards are fixed at creation; events without them used the default formula
+	if event.RewardPerPerson != nil {
+		recruitment.RewardPerPerson = event.RewardPerPerson
+	}
+
 	// Set created time from event
 	recruitment.CreatedAt = event.Timestamp()
 	recruitment.ExpiresAt = event.Timestamp().Add(duration)
@@ -892,3 +1264,12 @@ func (g *GuildAggregate) applyTransportRecruitmentCompletedEvent(event *Transpor
 	g.lastActiveAt = event.Timestamp()
 	return nil
 }
+
+func (g *GuildAggregate) applyTransportRecruitmentCancelledEvent(event *TransportRecruitmentCancelledEvent) error {
+	if recruitment, exists := g.transportRecruitments[event.RecruitmentID]; exists {
+		recruitment.Status = RecruitmentStatusCancelled
+	}
+
+	g.lastActiveAt = event.Timestamp()
+	return nil
+}
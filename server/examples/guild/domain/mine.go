@@ -43,7 +43,8 @@ func (m MineralType) String() string {
 	}
 }
 
-// GetValue returns the base value of the mineral per unit
+// GetValue returns the base value of the mineral per unit.
+// The value in effect is EconomyConfig.MineralValue.
 func (m MineralType) GetValue() int64 {
 	switch m {
 	case MineralIron:
@@ -63,6 +64,21 @@ func (m MineralType) GetValue() int64 {
 	}
 }
 
+// AllMineralTypes returns every mineral type
+func AllMineralTypes() []MineralType {
+	return []MineralType{MineralIron, MineralGold, MineralSilver, MineralCopper, MineralDiamond, MineralMithril}
+}
+
+// ParseMineralType parses a mineral type from its string representation
+func ParseMineralType(s string) (MineralType, error) {
+	for _, mineral := range AllMineralTypes() {
+		if mineral.String() == s {
+			return mineral, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid mineral type: %s", s)
+}
+
 // MineralDeposit represents a deposit of a specific mineral in a mine
 type MineralDeposit struct {
 	Type      MineralType `json:"type"`
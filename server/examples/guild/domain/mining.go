@@ -159,8 +159,8 @@ func (gm *GuildMining) StartMiningOperation(operationID, nodeID string, workers
 	return nil
 }
 
-// HarvestMinerals harvests minerals from an active operation
-func (gm *GuildMining) HarvestMinerals(operationID string) (map[MineralType]int64, error) {
+// HarvestMinerals harvests minerals from an active operation, scaled by the economy's yield multiplier
+func (gm *GuildMining) HarvestMinerals(operationID string, economy *EconomyConfig) (map[MineralType]int64, error) {
 	operation, exists := gm.ActiveOperations[operationID]
 	if !exists {
 		return nil, fmt.Errorf("mining operation %s not found", operationID)
@@ -177,7 +177,7 @@ func (gm *GuildMining) HarvestMinerals(operationID string) (map[MineralType]int6
 
 	// Calculate yield since last harvest
 	duration := time.Since(operation.LastHarvestAt)
-	yield := operation.CalculateYield(node, duration)
+	yield := int64(float64(operation.CalculateYield(node, duration)) * economy.YieldMultiplier(node.MineralType))
 
 	if yield <= 0 {
 		return map[MineralType]int64{}, nil
@@ -223,12 +223,8 @@ func (gm *GuildMining) StopMiningOperation(operationID string) error {
 }
 
 // GetTotalMineralValue calculates the total value of all minerals in inventory
-func (gm *GuildMining) GetTotalMineralValue() int64 {
-	totalValue := int64(0)
-	for mineralType, amount := range gm.MineralInventory {
-		totalValue += amount * mineralType.GetValue()
-	}
-	return totalValue
+func (gm *GuildMining) GetTotalMineralValue(economy *EconomyConfig) int64 {
+	return economy.MineralsValue(gm.MineralInventory)
 }
 
 // GetActiveOperationsCount returns the number of active mining operations
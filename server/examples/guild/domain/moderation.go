@@ -0,0 +1,32 @@
+package domain
+
+// ContentKind identifies which guild text is being moderated.
+// Its String form is the moderation.Content kind.
+type ContentKind int
+
+const (
+	// ContentGuildName is the guild name
+	ContentGuildName ContentKind = iota
+	// ContentGuildDescription is the guild description
+	ContentGuildDescription
+	// ContentGuildNotice is the guild notice board text
+	ContentGuildNotice
+	// ContentChatMessage is a guild chat message
+	ContentChatMessage
+)
+
+// String returns the string representation of the content kind
+func (k ContentKind) String() string {
+	switch k {
+	case ContentGuildName:
+		return "GuildName"
+	case ContentGuildDescription:
+		return "GuildDescription"
+	case ContentGuildNotice:
+		return "GuildNotice"
+	case ContentChatMessage:
+		return "ChatMessage"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// ExperienceSource identifies the activity that granted guild experience
+type ExperienceSource string
+
+const (
+	// ExperienceFromMining is granted when minerals are harvested
+	ExperienceFromMining ExperienceSource = "Mining"
+	// ExperienceFromTransport is granted when a transport is completed
+	ExperienceFromTransport ExperienceSource = "Transport"
+	// ExperienceFromWar is granted for guild war results
+	ExperienceFromWar ExperienceSource = "War"
+)
+
+// GuildPerk is a bonus unlocked when the guild reaches a level
+type GuildPerk struct {
+	ID              string `json:"id"`
+	Description     string `json:"description"`
+	MaxMembersBonus int    `json:"max_members_bonus"`
+}
+
+// GuildProgressionConfig holds the experience and level-up rules
+type GuildProgressionConfig struct {
+	// LevelThresholds[i] is the total experience needed to reach level i+2.
+	// The guild cannot level past len(LevelThresholds)+1.
+	LevelThresholds []int64 `json:"level_thresholds"`
+
+	// MiningXPPerValue grants one experience per this much harvested treasury value
+	MiningXPPerValue int64 `json:"mining_xp_per_value"`
+
+	// TransportXPPerParticipant is granted per participant of a completed transport
+	TransportXPPerParticipant int64 `json:"transport_xp_per_participant"`
+
+	// Perks unlocked when reaching a level
+	Perks map[int][]GuildPerk `json:"perks"`
+}
+
+// DefaultGuildProgressionConfig returns the built-in progression rules
+func DefaultGuildProgressionConfig() *GuildProgressionConfig {
+	return &GuildProgressionConfig{
+		LevelThresholds:           []int64{1000, 3000, 7000, 15000, 30000, 60000, 100000, 160000, 250000},
+		MiningXPPerValue:          100,
+		TransportXPPerParticipant: 50,
+		Perks: map[int][]GuildPerk{
+			2:  {{ID: "roster_1", Description: "+5 member slots", MaxMembersBonus: 5}},
+			4:  {{ID: "roster_2", Description: "+5 member slots", MaxMembersBonus: 5}},
+			5:  {{ID: "guild_emblem", Description: "Custom guild emblem"}},
+			7:  {{ID: "roster_3", Description: "+10 member slots", MaxMembersBonus: 10}},
+			10: {{ID: "legendary_banner", Description: "Legendary guild banner"}},
+		},
+	}
+}
+
+// MaxLevel returns the highest reachable level
+func (c *GuildProgressionConfig) MaxLevel() int {
+	return len(c.LevelThresholds) + 1
+}
+
+// LevelFor returns the level reached with the given total experience
+func (c *GuildProgressionConfig) LevelFor(experience int64) int {
+	level := 1
+	for _, threshold := range c.LevelThresholds {
+		if experience < threshold {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// ExperienceToNextLevel returns the experience still needed for the next level (0 at max level)
+func (c *GuildProgressionConfig) ExperienceToNextLevel(level int, experience int64) int64 {
+	if level < 1 || level > len(c.LevelThresholds) {
+		return 0
+	}
+	remaining := c.LevelThresholds[level-1] - experience
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// MiningExperience returns the experience granted for harvesting minerals worth value
+func (c *GuildProgressionConfig) MiningExperience(value int64) int64 {
+	if c.MiningXPPerValue <= 0 {
+		return 0
+	}
+	return value / c.MiningXPPerValue
+}
+
+// TransportExperience returns the experience granted for completing a transport
+func (c *GuildProgressionConfig) TransportExperience(participants int) int64 {
+	return int64(participants) * c.TransportXPPerParticipant
+}
+
+// PerksBetween returns the perks unlocked by levels fromLevel+1 through toLevel
+func (c *GuildProgressionConfig) PerksBetween(fromLevel, toLevel int) []GuildPerk {
+	perks := make([]GuildPerk, 0)
+	for level := fromLevel + 1; level <= toLevel; level++ {
+		perks = append(perks, c.Perks[level]...)
+	}
+	return perks
+}
+
+// Validate validates the progression config
+func (c *GuildProgressionConfig) Validate() error {
+	previous := int64(0)
+	for i, threshold := range c.LevelThresholds {
+		if threshold <= previous {
+			return fmt.Errorf("level %d threshold must be greater than the previous one", i+2)
+		}
+		previous = threshold
+	}
+	if c.MiningXPPerValue < 0 || c.TransportXPPerParticipant < 0 {
+		return fmt.Errorf("experience rates cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProgressionConfig() *GuildProgressionConfig {
+	return &GuildProgressionConfig{
+		LevelThresholds:           []int64{100, 300, 600},
+		MiningXPPerValue:          10,
+		TransportXPPerParticipant: 25,
+		Perks: map[int][]GuildPerk{
+			2: {{ID: "roster_1", MaxMembersBonus: 5}},
+			3: {{ID: "banner"}},
+			4: {{ID: "roster_2", MaxMembersBonus: 10}},
+		},
+	}
+}
+
+func TestGuildProgressionConfig_LevelThresholds(t *testing.T) {
+	config := testProgressionConfig()
+
+	tests := []struct {
+		experience int64
+		level      int
+		toNext     int64
+	}{
+		{0, 1, 100},
+		{99, 1, 1},
+		{100, 2, 200}, // reaching a threshold exactly levels up
+		{299, 2, 1},
+		{600, 4, 0}, // max level
+		{10000, 4, 0},
+	}
+
+	for _, tt := range tests {
+		level := config.LevelFor(tt.experience)
+		assert.Equal(t, tt.level, level, "level for %d", tt.experience)
+		assert.Equal(t, tt.toNext, config.ExperienceToNextLevel(level, tt.experience), "to next for %d", tt.experience)
+	}
+	assert.Equal(t, 4, config.MaxLevel())
+}
+
+func TestGuildProgressionConfig_ExperienceRates(t *testing.T) {
+	config := testProgressionConfig()
+
+	assert.Equal(t, int64(12), config.MiningExperience(129)) // rounds down
+	assert.Equal(t, int64(75), config.TransportExperience(3))
+
+	config.MiningXPPerValue = 0
+	assert.Equal(t, int64(0), config.MiningExperience(1000))
+}
+
+func TestGuildProgressionConfig_PerksBetween(t *testing.T) {
+	config := testProgressionConfig()
+
+	assert.Equal(t, []GuildPerk{{ID: "roster_1", MaxMembersBonus: 5}}, config.PerksBetween(1, 2))
+	assert.Equal(t, []string{"roster_1", "banner", "roster_2"}, perkIDs(config.PerksBetween(1, 4))) // skipping levels unlocks every perk in between
+	assert.Empty(t, config.PerksBetween(2, 2))
+}
+
+func TestGuildProgressionConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultGuildProgressionConfig().Validate())
+
+	unordered := testProgressionConfig()
+	unordered.LevelThresholds = []int64{100, 100}
+	assert.Error(t, unordered.Validate())
+
+	negative := testProgressionConfig()
+	negative.TransportXPPerParticipant = -1
+	assert.Error(t, negative.Validate())
+}
+
+func TestGuildAggregate_AwardExperienceLevelsUpWithPerks(t *testing.T) {
+	// Arrange
+	guild := NewGuildAggregate("guild-1", "Knights", "", "founder", "Founder")
+	guild.SetProgressionConfig(testProgressionConfig())
+
+	// Act
+	require.NoError(t, guild.AwardExperience(ExperienceFromWar, 50, "skirmish"))
+	require.NoError(t, guild.AwardExperience(ExperienceFromWar, 600, "war victory"))
+
+	// Assert
+	assert.Equal(t, int64(650), guild.GetExperience())
+	assert.Equal(t, 4, guild.GetLevel())
+	assert.True(t, guild.HasPerk("roster_1"))
+	assert.True(t, guild.HasPerk("roster_2"))
+
+	changes := guild.Changes()
+	require.Len(t, changes, 4) // created, gained, gained, one level-up spanning three levels
+	levelUp, ok := changes[3].(*GuildLeveledUpEvent)
+	require.True(t, ok)
+	assert.Equal(t, 1, levelUp.PreviousLevel)
+	assert.Equal(t, 4, levelUp.NewLevel)
+	assert.Equal(t, []string{"roster_1", "banner", "roster_2"}, perkIDs(levelUp.UnlockedPerks))
+
+	// Rebuilding from events yields the same level, perks and member capacity
+	reloaded, err := LoadGuildAggregate("guild-1", changes)
+	require.NoError(t, err)
+	assert.Equal(t, 4, reloaded.GetLevel())
+	assert.Equal(t, guild.maxMembers, reloaded.maxMembers)
+	assert.Equal(t, 65, reloaded.maxMembers) // 50 + 5 + 10
+}
+
+func TestGuildAggregate_AwardExperienceRejectsInvalidAmounts(t *testing.T) {
+	guild := NewGuildAggregate("guild-1", "Knights", "", "founder", "Founder")
+
+	assert.Error(t, guild.AwardExperience(ExperienceFromWar, 0, "nothing"))
+	assert.Error(t, guild.AwardExperience(ExperienceFromWar, -10, "negative"))
+	assert.Equal(t, int64(0), guild.GetExperience())
+}
+
+func perkIDs(perks []GuildPerk) []string {
+	ids := make([]string, len(perks))
+	for i, perk := range perks {
+		ids[i] = perk.ID
+	}
+	return ids
+}
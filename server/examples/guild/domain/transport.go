@@ -21,6 +21,13 @@ const (
 	RecruitmentStatusCancelled
 )
 
+// SystemActorID is recorded as the actor of changes made by background processors
+const SystemActorID = "system"
+
+// RecruitmentCancelReasonUnderSubscribed is recorded when a recruitment expires
+// without reaching its minimum participants
+const RecruitmentCancelReasonUnderSubscribed = "under_subscribed"
+
 // String returns the string representation of the recruitment status
 func (s TransportRecruitmentStatus) String() string {
 	switch s {
@@ -0,0 +1,34 @@
+package assets
+
+import (
+	"context"
+
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/asset"
+)
+
+// EmblemVerifier adapts the asset service to domain.EmblemVerifier. Clients request an
+// upload ticket (kind "guild_emblem", owner = guild ID) from the asset app, upload the
+// image, then send UpdateGuildEmblem with the ticket's object key.
+type EmblemVerifier struct {
+	service *asset.Service
+}
+
+// NewEmblemVerifier creates a new EmblemVerifier
+func NewEmblemVerifier(service *asset.Service) *EmblemVerifier {
+	return &EmblemVerifier{service: service}
+}
+
+// VerifyEmblem checks the uploaded object against the guild emblem policy
+func (v *EmblemVerifier) VerifyEmblem(ctx context.Context, guildID, objectKey string) (domain.GuildEmblem, error) {
+	stored, err := v.service.Verify(ctx, asset.KindGuildEmblem, guildID, objectKey)
+	if err != nil {
+		return domain.GuildEmblem{}, err
+	}
+	return domain.GuildEmblem{
+		ObjectKey:   stored.ObjectKey,
+		ContentType: stored.ContentType,
+		Size:        stored.Size,
+		URL:         stored.URL,
+	}, nil
+}
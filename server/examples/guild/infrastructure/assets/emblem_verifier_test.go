@@ -0,0 +1,108 @@
+package assets
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/application/commands"
+	"defense-allies-server/examples/guild/application/handlers"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+	"defense-allies-server/examples/guild/infrastructure/repositories"
+	"defense-allies-server/serverapp/asset"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngBytes is a test image starting with the PNG signature
+var pngBytes = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+// uploadEmblem requests an upload ticket for the guild and stores the image through the signed URL
+func uploadEmblem(t *testing.T, service *asset.Service, store *asset.LocalBlobStore, guildID string) asset.UploadTicket {
+	t.Helper()
+	ticket, err := service.RequestUpload(context.Background(), asset.UploadRequest{
+		Kind:        asset.KindGuildEmblem,
+		OwnerID:     guildID,
+		ContentType: "image/png",
+		Size:        int64(len(pngBytes)),
+	})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(ticket.Upload.Method, ticket.Upload.URL, bytes.NewReader(pngBytes))
+	request.Header.Set("Content-Type", "image/png")
+	recorder := httptest.NewRecorder()
+	store.ServeUpload(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	return ticket
+}
+
+func TestEmblemVerifier_LinksUploadedEmblemToGuild(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store, err := asset.NewLocalBlobStore(t.TempDir(), "http://assets.test/assets", []byte("secret"))
+	require.NoError(t, err)
+	service, err := asset.NewService(asset.ServiceConfig{Store: store})
+	require.NoError(t, err)
+
+	readStore := cqrs.NewInMemoryReadStore()
+	repository := repositories.NewInMemoryGuildRepository([]cqrs.Projection{projections.NewGuildViewProjection(readStore)})
+	handler := handlers.NewGuildCommandHandler(repository)
+	handler.SetEmblemVerifier(NewEmblemVerifier(service))
+
+	veteran := domain.NewGuildAggregate("guild-1", "Knights", "", "leader", "Leader")
+	require.NoError(t, veteran.AwardExperience(domain.ExperienceFromWar, 15000, "season reward")) // level 5 unlocks emblems
+	require.NoError(t, repository.Save(ctx, veteran, 0))
+	rookie := domain.NewGuildAggregate("guild-2", "Squires", "", "squire", "Squire")
+	require.NoError(t, repository.Save(ctx, rookie, 0))
+
+	ticket := uploadEmblem(t, service, store, "guild-1")
+	rookieTicket := uploadEmblem(t, service, store, "guild-2")
+	pending, err := service.RequestUpload(ctx, asset.UploadRequest{Kind: asset.KindGuildEmblem, OwnerID: "guild-1", ContentType: "image/png", Size: 10})
+	require.NoError(t, err)
+
+	// Act
+	result, linkErr := handler.Handle(ctx, commands.NewUpdateGuildEmblemCommand("guild-1", ticket.ObjectKey, "leader"))
+	_, notUploadedErr := handler.Handle(ctx, commands.NewUpdateGuildEmblemCommand("guild-1", pending.ObjectKey, "leader"))
+	_, otherGuildErr := handler.Handle(ctx, commands.NewUpdateGuildEmblemCommand("guild-1", rookieTicket.ObjectKey, "leader"))
+	_, lockedErr := handler.Handle(ctx, commands.NewUpdateGuildEmblemCommand("guild-2", rookieTicket.ObjectKey, "squire"))
+
+	// Assert
+	require.NoError(t, linkErr)
+	assert.True(t, result.Success)
+	data, ok := result.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, ticket.ObjectKey, data["object_key"])
+	assert.Equal(t, store.URL(ticket.ObjectKey), data["url"])
+
+	assert.ErrorIs(t, notUploadedErr, asset.ErrAssetNotUploaded)
+	assert.ErrorIs(t, otherGuildErr, asset.ErrInvalidUpload)
+	require.Error(t, lockedErr)
+	assert.Contains(t, lockedErr.Error(), "has not unlocked custom emblems")
+
+	events, err := repository.GetEventHistory(ctx, "guild-1", 0)
+	require.NoError(t, err)
+	reloaded, err := domain.LoadGuildAggregate("guild-1", events)
+	require.NoError(t, err)
+	emblem, hasEmblem := reloaded.GetEmblem()
+	require.True(t, hasEmblem)
+	assert.Equal(t, ticket.ObjectKey, emblem.ObjectKey)
+	assert.Equal(t, "image/png", emblem.ContentType)
+	assert.Equal(t, int64(len(pngBytes)), emblem.Size)
+	assert.Equal(t, "leader", emblem.UpdatedBy)
+
+	view, err := cqrs.LoadReadModel[*projections.GuildView](ctx, readStore, "guild-1", "GuildView")
+	require.NoError(t, err)
+	assert.Equal(t, store.URL(ticket.ObjectKey), view.EmblemURL)
+
+	rookieEvents, err := repository.GetEventHistory(ctx, "guild-2", 0)
+	require.NoError(t, err)
+	rookieReloaded, err := domain.LoadGuildAggregate("guild-2", rookieEvents)
+	require.NoError(t, err)
+	_, rookieHasEmblem := rookieReloaded.GetEmblem()
+	assert.False(t, rookieHasEmblem)
+}
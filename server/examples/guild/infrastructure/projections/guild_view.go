@@ -18,6 +18,7 @@ type GuildView struct {
 	Description string    `json:"description"`
 	Notice      string    `json:"notice"`
 	Tag         string    `json:"tag"`
+	EmblemURL   string    `json:"emblem_url,omitempty"`
 	Status      string    `json:"status"`
 	FoundedAt   time.Time `json:"founded_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -28,6 +29,10 @@ type GuildView struct {
 	IsPublic        bool `json:"is_public"`
 	RequireApproval bool `json:"require_approval"`
 
+	// Locale used by guild search
+	Region   string `json:"region,omitempty"`
+	Language string `json:"language,omitempty"`
+
 	// Guild statistics
 	MemberCount       int   `json:"member_count"`
 	ActiveMemberCount int   `json:"active_member_count"`
@@ -36,6 +41,9 @@ type GuildView struct {
 	Experience        int64 `json:"experience"`
 	TotalContribution int64 `json:"total_contribution"`
 
+	// Last member or progression activity, used to sort guild search by recent activity
+	LastActivityAt time.Time `json:"last_activity_at"`
+
 	// Founder information
 	FounderID       string `json:"founder_id"`
 	FounderUsername string `json:"founder_username"`
@@ -74,12 +82,16 @@ func (gv *GuildView) GetData() interface{} {
 		"min_level":           gv.MinLevel,
 		"is_public":           gv.IsPublic,
 		"require_approval":    gv.RequireApproval,
+		"region":              gv.Region,
+		"language":            gv.Language,
+		"open_slots":          gv.OpenSlots(),
 		"member_count":        gv.MemberCount,
 		"active_member_count": gv.ActiveMemberCount,
 		"treasury":            gv.Treasury,
 		"level":               gv.Level,
 		"experience":          gv.Experience,
 		"total_contribution":  gv.TotalContribution,
+		"last_activity_at":    gv.LastActivityAt,
 		"founder_id":          gv.FounderID,
 		"founder_username":    gv.FounderUsername,
 		"searchable_text":     gv.SearchableText,
@@ -122,6 +134,14 @@ func (gv *GuildView) IsActive() bool {
 	return gv.Status == "Active"
 }
 
+// OpenSlots returns how many more members the guild can take
+func (gv *GuildView) OpenSlots() int {
+	if gv.MemberCount >= gv.MaxMembers {
+		return 0
+	}
+	return gv.MaxMembers - gv.MemberCount
+}
+
 // GetMemberCapacityPercentage returns the member capacity as a percentage
 func (gv *GuildView) GetMemberCapacityPercentage() float64 {
 	if gv.MaxMembers == 0 {
@@ -130,62 +150,60 @@ func (gv *GuildView) GetMemberCapacityPercentage() float64 {
 	return float64(gv.MemberCount) / float64(gv.MaxMembers) * 100
 }
 
+// guildSearchIndexes are the GuildView fields guild search filters and sorts on
+var guildSearchIndexes = [][]string{
+	{"region", "language"},
+	{"min_level"},
+	{"open_slots"},
+	{"last_activity_at"},
+	{"level", "experience"},
+}
+
+// EnsureGuildSearchIndexes creates the GuildView indexes used by guild search; call it at startup
+func EnsureGuildSearchIndexes(ctx context.Context, readStore cqrs.ReadStore) error {
+	for _, fields := range guildSearchIndexes {
+		if err := readStore.CreateIndex(ctx, "GuildView", fields); err != nil {
+			return fmt.Errorf("failed to create guild search index %v: %w", fields, err)
+		}
+	}
+	return nil
+}
+
 // GuildViewProjection handles guild events and updates the GuildView read model
 type GuildViewProjection struct {
-	*cqrs.BaseProjection
+	*cqrs.DeclarativeProjection
 	readStore cqrs.ReadStore
 }
 
 // NewGuildViewProjection creates a new GuildViewProjection
 func NewGuildViewProjection(readStore cqrs.ReadStore) *GuildViewProjection {
-	supportedEvents := []string{
-		domain.GuildCreatedEventType,
-		domain.GuildInfoUpdatedEventType,
-		domain.GuildSettingsUpdatedEventType,
-		domain.MemberInvitedEventType,
-		domain.MemberJoinedEventType,
-		domain.MemberKickedEventType,
-		domain.MemberPromotedEventType,
-	}
-
-	return &GuildViewProjection{
-		BaseProjection: cqrs.NewBaseProjection("GuildViewProjection", "1.0.0", supportedEvents),
-		readStore:      readStore,
-	}
-}
-
-// Project processes the event and updates the read model
-func (p *GuildViewProjection) Project(ctx context.Context, event cqrs.EventMessage) error {
-	// Call base implementation first
-	if err := p.BaseProjection.Project(ctx, event); err != nil {
-		return err
-	}
-
-	switch e := event.(type) {
-	case *domain.GuildCreatedEvent:
-		return p.handleGuildCreated(ctx, e)
-	case *domain.GuildInfoUpdatedEvent:
-		return p.handleGuildInfoUpdated(ctx, e)
-	case *domain.GuildSettingsUpdatedEvent:
-		return p.handleGuildSettingsUpdated(ctx, e)
-	case *domain.MemberInvitedEvent:
-		return p.handleMemberInvited(ctx, e)
-	case *domain.MemberJoinedEvent:
-		return p.handleMemberJoined(ctx, e)
-	case *domain.MemberKickedEvent:
-		return p.handleMemberKicked(ctx, e)
-	case *domain.MemberPromotedEvent:
-		return p.handleMemberPromoted(ctx, e)
-	default:
-		return fmt.Errorf("unsupported event type: %T", event)
-	}
+	p := &GuildViewProjection{readStore: readStore}
+	p.DeclarativeProjection = cqrs.MustNewDeclarativeProjection(cqrs.ProjectionDefinition{
+		Name:        "GuildViewProjection",
+		Version:     "1.0.0",
+		Description: "Guild profile, settings and member statistics.",
+		Rules: []cqrs.ProjectionRule{
+			cqrs.ProjectionRuleFor(domain.GuildCreatedEventType, "GuildView", "Creates the guild view with the founder as first member", p.handleGuildCreated),
+			cqrs.ProjectionRuleFor(domain.GuildInfoUpdatedEventType, "GuildView", "Updates name, description, notice and tag", p.handleGuildInfoUpdated),
+			cqrs.ProjectionRuleFor(domain.GuildSettingsUpdatedEventType, "GuildView", "Updates membership settings", p.handleGuildSettingsUpdated),
+			cqrs.ProjectionRuleFor(domain.GuildEmblemUpdatedEventType, "GuildView", "Updates the emblem URL", p.handleGuildEmblemUpdated),
+			cqrs.ProjectionRuleFor(domain.GuildLocaleUpdatedEventType, "GuildView", "Updates region and language", p.handleGuildLocaleUpdated),
+			cqrs.ProjectionRuleFor(domain.MemberInvitedEventType, "GuildView", "Counts the pending member", p.handleMemberInvited),
+			cqrs.ProjectionRuleFor(domain.MemberJoinedEventType, "GuildView", "Counts the active member", p.handleMemberJoined),
+			cqrs.ProjectionRuleFor(domain.MemberKickedEventType, "GuildView", "Removes the member from both counts", p.handleMemberKicked),
+			cqrs.ProjectionRuleFor(domain.MemberPromotedEventType, "GuildView", "Records guild activity", p.handleMemberPromoted),
+			cqrs.ProjectionRuleFor(domain.GuildExperienceGainedEventType, "GuildView", "Adds experience", p.handleExperienceGained),
+			cqrs.ProjectionRuleFor(domain.GuildLeveledUpEventType, "GuildView", "Sets the level and applies member capacity perks", p.handleLeveledUp),
+		},
+	})
+	return p
 }
 
 // Event handlers
 
 // handleGuildCreated handles GuildCreatedEvent
 func (p *GuildViewProjection) handleGuildCreated(ctx context.Context, event *domain.GuildCreatedEvent) error {
-	guildView := NewGuildView(event.ID())
+	guildView := NewGuildView(event.AggregateID())
 	guildView.Name = event.Name
 	guildView.Description = event.Description
 	guildView.Status = "Active"
@@ -195,6 +213,7 @@ func (p *GuildViewProjection) handleGuildCreated(ctx context.Context, event *dom
 	guildView.FounderUsername = event.FounderUsername
 	guildView.MemberCount = 1 // Founder is the first member
 	guildView.ActiveMemberCount = 1
+	guildView.LastActivityAt = event.Timestamp()
 	guildView.SetVersion(event.Version())
 
 	guildView.UpdateSearchableText()
@@ -204,134 +223,104 @@ func (p *GuildViewProjection) handleGuildCreated(ctx context.Context, event *dom
 
 // handleGuildInfoUpdated handles GuildInfoUpdatedEvent
 func (p *GuildViewProjection) handleGuildInfoUpdated(ctx context.Context, event *domain.GuildInfoUpdatedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
-
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
-
-	// Update guild info
-	guildView.Name = event.Name
-	guildView.Description = event.Description
-	guildView.Notice = event.Notice
-	guildView.Tag = event.Tag
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
-
-	guildView.UpdateSearchableText()
-
-	return p.readStore.Save(ctx, guildView)
+	return p.update(ctx, event, func(guildView *GuildView) {
+		guildView.Name = event.Name
+		guildView.Description = event.Description
+		guildView.Notice = event.Notice
+		guildView.Tag = event.Tag
+		guildView.UpdateSearchableText()
+	})
 }
 
 // handleGuildSettingsUpdated handles GuildSettingsUpdatedEvent
 func (p *GuildViewProjection) handleGuildSettingsUpdated(ctx context.Context, event *domain.GuildSettingsUpdatedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
-
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
+	return p.update(ctx, event, func(guildView *GuildView) {
+		guildView.MaxMembers = event.MaxMembers
+		guildView.MinLevel = event.MinLevel
+		guildView.IsPublic = event.IsPublic
+		guildView.RequireApproval = event.RequireApproval
+	})
+}
 
-	// Update guild settings
-	guildView.MaxMembers = event.MaxMembers
-	guildView.MinLevel = event.MinLevel
-	guildView.IsPublic = event.IsPublic
-	guildView.RequireApproval = event.RequireApproval
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
+// handleGuildEmblemUpdated handles GuildEmblemUpdatedEvent
+func (p *GuildViewProjection) handleGuildEmblemUpdated(ctx context.Context, event *domain.GuildEmblemUpdatedEvent) error {
+	return p.update(ctx, event, func(guildView *GuildView) {
+		guildView.EmblemURL = event.URL
+	})
+}
 
-	return p.readStore.Save(ctx, guildView)
+// handleGuildLocaleUpdated handles GuildLocaleUpdatedEvent
+func (p *GuildViewProjection) handleGuildLocaleUpdated(ctx context.Context, event *domain.GuildLocaleUpdatedEvent) error {
+	return p.update(ctx, event, func(guildView *GuildView) {
+		guildView.Region = event.Region
+		guildView.Language = event.Language
+	})
 }
 
 // handleMemberInvited handles MemberInvitedEvent
 func (p *GuildViewProjection) handleMemberInvited(ctx context.Context, event *domain.MemberInvitedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
-
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
-
-	// Update member count (invited member is pending)
-	guildView.MemberCount++
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
-
-	return p.readStore.Save(ctx, guildView)
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {
+		// Invited member is pending
+		cqrs.AdjustCount(&guildView.MemberCount, 1)
+	})
 }
 
 // handleMemberJoined handles MemberJoinedEvent
 func (p *GuildViewProjection) handleMemberJoined(ctx context.Context, event *domain.MemberJoinedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
-
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
-
-	// Update active member count (member accepted invitation)
-	guildView.ActiveMemberCount++
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
-
-	return p.readStore.Save(ctx, guildView)
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {
+		// Member accepted invitation
+		cqrs.AdjustCount(&guildView.ActiveMemberCount, 1)
+	})
 }
 
 // handleMemberKicked handles MemberKickedEvent
 func (p *GuildViewProjection) handleMemberKicked(ctx context.Context, event *domain.MemberKickedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
-
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
-
-	// Update member counts (member was kicked)
-	guildView.MemberCount--
-	guildView.ActiveMemberCount--
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
-
-	return p.readStore.Save(ctx, guildView)
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {
+		cqrs.AdjustCount(&guildView.MemberCount, -1)
+		cqrs.AdjustCount(&guildView.ActiveMemberCount, -1)
+	})
 }
 
 // handleMemberPromoted handles MemberPromotedEvent
 func (p *GuildViewProjection) handleMemberPromoted(ctx context.Context, event *domain.MemberPromotedEvent) error {
-	// Load existing guild view
-	readModel, err := p.readStore.GetByID(ctx, event.ID(), "GuildView")
-	if err != nil {
-		return fmt.Errorf("failed to load guild view: %w", err)
-	}
+	// Promotion doesn't change counts but is an activity
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {})
+}
 
-	guildView, ok := readModel.(*GuildView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *GuildView, got %T", readModel)
-	}
+// handleExperienceGained handles GuildExperienceGainedEvent
+func (p *GuildViewProjection) handleExperienceGained(ctx context.Context, event *domain.GuildExperienceGainedEvent) error {
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {
+		guildView.Experience += event.Amount
+	})
+}
 
-	// Update timestamp (promotion doesn't change counts but is an activity)
-	guildView.UpdatedAt = event.Timestamp()
-	guildView.SetVersion(event.Version())
+// handleLeveledUp handles GuildLeveledUpEvent
+func (p *GuildViewProjection) handleLeveledUp(ctx context.Context, event *domain.GuildLeveledUpEvent) error {
+	return p.updateActivity(ctx, event, func(guildView *GuildView) {
+		guildView.Level = event.NewLevel
+		for _, perk := range event.UnlockedPerks {
+			guildView.MaxMembers += perk.MaxMembersBonus
+		}
+	})
+}
 
-	return p.readStore.Save(ctx, guildView)
+// updateActivity is update for member and progression events, which also count as guild activity
+func (p *GuildViewProjection) updateActivity(ctx context.Context, event cqrs.EventMessage, fn func(guildView *GuildView)) error {
+	return p.update(ctx, event, func(guildView *GuildView) {
+		fn(guildView)
+		guildView.LastActivityAt = event.Timestamp()
+	})
+}
+
+// update applies fn to the guild's view unless the event was already applied,
+// then stamps the event time and version and saves the view
+func (p *GuildViewProjection) update(ctx context.Context, event cqrs.EventMessage, fn func(guildView *GuildView)) error {
+	err := cqrs.ProjectEvent(ctx, p.readStore, event, event.AggregateID(), "GuildView", nil, func(guildView *GuildView) {
+		fn(guildView)
+		guildView.UpdatedAt = event.Timestamp()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update guild view: %w", err)
+	}
+	return nil
 }
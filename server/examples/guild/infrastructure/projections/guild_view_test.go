@@ -0,0 +1,185 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// guildHistory runs a typical guild lifecycle and returns the recorded events in order
+func guildHistory(t *testing.T) []cqrs.EventMessage {
+	t.Helper()
+	guild := domain.NewGuildAggregate("guild-1", "Knights", "Brave knights", "founder", "Founder")
+	require.NoError(t, guild.UpdateInfo("Knights", "Braver knights", "Raid at 8", "KNT", "founder"))
+	require.NoError(t, guild.UpdateSettings(30, 10, true, false, "founder"))
+	require.NoError(t, guild.InviteMember("warrior", "Warrior", "founder"))
+	require.NoError(t, guild.InviteMember("mage", "Mage", "founder"))
+	require.NoError(t, guild.AcceptInvitation("warrior"))
+	require.NoError(t, guild.AcceptInvitation("mage"))
+	require.NoError(t, guild.PromoteMember("warrior", "founder", domain.RoleOfficer))
+	require.NoError(t, guild.KickMember("mage", "founder", "inactive"))
+	require.NoError(t, guild.AwardExperience(domain.ExperienceFromWar, 1200, "war victory"))
+	return guild.Changes()
+}
+
+func project(t *testing.T, projection cqrs.Projection, events []cqrs.EventMessage) {
+	t.Helper()
+	for _, event := range events {
+		if projection.CanHandle(event.EventType()) {
+			require.NoError(t, projection.Project(context.Background(), event), event.EventType())
+		}
+	}
+}
+
+func TestGuildViewProjection_Lifecycle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewGuildViewProjection(store)
+	events := guildHistory(t)
+	last := events[len(events)-1]
+
+	// Act
+	project(t, projection, events)
+
+	// Assert
+	view, err := cqrs.LoadReadModel[*GuildView](ctx, store, "guild-1", "GuildView")
+	require.NoError(t, err)
+	assert.Equal(t, "guild-1", view.GuildID)
+	assert.Equal(t, "Braver knights", view.Description)
+	assert.Equal(t, "Raid at 8", view.Notice)
+	assert.Equal(t, "KNT", view.Tag)
+	assert.Equal(t, 35, view.MaxMembers) // 30 + roster_1 perk at level 2
+	assert.Equal(t, 10, view.MinLevel)
+	assert.True(t, view.IsPublic)
+	assert.Equal(t, 2, view.MemberCount)       // founder + 2 invites - 1 kick
+	assert.Equal(t, 2, view.ActiveMemberCount) // founder + 2 joins - 1 kick
+	assert.Equal(t, int64(1200), view.Experience)
+	assert.Equal(t, 2, view.Level)
+	assert.Equal(t, "Founder", view.FounderUsername)
+	assert.Contains(t, view.SearchableText, "KNT")
+	assert.Equal(t, last.Version(), view.GetVersion())
+	assert.Equal(t, last.Timestamp(), view.UpdatedAt)
+	assert.Equal(t, last.Timestamp(), view.LastActivityAt)
+}
+
+func TestGuildViewProjection_SkipsRedeliveredEvents(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewGuildViewProjection(store)
+	events := guildHistory(t)
+	project(t, projection, events)
+
+	// Act: at-least-once delivery replays the member events
+	project(t, projection, events[3:])
+
+	// Assert
+	view, err := cqrs.LoadReadModel[*GuildView](ctx, store, "guild-1", "GuildView")
+	require.NoError(t, err)
+	assert.Equal(t, 2, view.MemberCount)
+	assert.Equal(t, 2, view.ActiveMemberCount)
+	assert.Equal(t, int64(1200), view.Experience)
+}
+
+func TestMemberViewProjection_Lifecycle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewMemberViewProjection(store)
+	events := guildHistory(t)
+
+	// Act
+	project(t, projection, events)
+
+	// Assert
+	founder, err := cqrs.LoadReadModel[*MemberView](ctx, store, "guild-1:founder", "MemberView")
+	require.NoError(t, err)
+	assert.Equal(t, "Leader", founder.Role)
+	assert.True(t, founder.IsActive())
+
+	warrior, err := cqrs.LoadReadModel[*MemberView](ctx, store, "guild-1:warrior", "MemberView")
+	require.NoError(t, err)
+	assert.Equal(t, "guild-1", warrior.GuildID)
+	assert.Equal(t, "Warrior", warrior.Username)
+	assert.Equal(t, "Officer", warrior.Role)
+	assert.Equal(t, "founder", warrior.InvitedBy)
+	assert.True(t, warrior.IsActive())
+	assert.True(t, warrior.HasPermission(domain.PermissionInviteMembers.String()))
+
+	mage, err := cqrs.LoadReadModel[*MemberView](ctx, store, "guild-1:mage", "MemberView")
+	require.NoError(t, err)
+	assert.Equal(t, "Kicked", mage.Status)
+	assert.Equal(t, "founder", mage.KickedBy)
+	assert.Equal(t, "inactive", mage.KickedReason)
+	assert.Equal(t, "Member", mage.Role)
+}
+
+func TestGuildViewProjection_ReloadedAggregateContinuesVersions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewGuildViewProjection(store)
+	events := guildHistory(t)
+	project(t, projection, events)
+
+	reloaded, err := domain.LoadGuildAggregate("guild-1", events)
+	require.NoError(t, err)
+	require.NoError(t, reloaded.InviteMember("rogue", "Rogue", "founder"))
+
+	// Act
+	project(t, projection, reloaded.Changes())
+
+	// Assert
+	view, err := cqrs.LoadReadModel[*GuildView](ctx, store, "guild-1", "GuildView")
+	require.NoError(t, err)
+	assert.Equal(t, 3, view.MemberCount)
+	assert.Equal(t, len(events)+1, view.GetVersion())
+}
+
+func TestGuildViewProjection_LocaleAndActivity(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewGuildViewProjection(store)
+	start := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	guild := domain.NewGuildAggregate("guild-1", "Knights", "", "founder", "Founder")
+	require.NoError(t, guild.InviteMember("warrior", "Warrior", "founder"))
+	require.NoError(t, guild.UpdateLocale("EU", "de", "founder"))
+	require.NoError(t, guild.UpdateSettings(40, 5, true, false, "founder"))
+	events := guild.Changes()
+	require.Len(t, events, 4)
+	for i, event := range events {
+		at := start.Add(time.Duration(i) * time.Hour)
+		switch e := event.(type) {
+		case *domain.GuildCreatedEvent:
+			stamp(e.BaseEventMessage, e.Version(), at)
+		case *domain.MemberInvitedEvent:
+			stamp(e.BaseEventMessage, e.Version(), at)
+		case *domain.GuildLocaleUpdatedEvent:
+			stamp(e.BaseEventMessage, e.Version(), at)
+		case *domain.GuildSettingsUpdatedEvent:
+			stamp(e.BaseEventMessage, e.Version(), at)
+		}
+	}
+
+	// Act
+	project(t, projection, events)
+
+	// Assert
+	view, err := cqrs.LoadReadModel[*GuildView](ctx, store, "guild-1", "GuildView")
+	require.NoError(t, err)
+	assert.Equal(t, "EU", view.Region)
+	assert.Equal(t, "de", view.Language)
+	assert.Equal(t, 5, view.MinLevel)
+	assert.Equal(t, 38, view.OpenSlots())                      // 40 - founder - pending invite
+	assert.Equal(t, start.Add(time.Hour), view.LastActivityAt) // only member and progression events count as activity
+	assert.Equal(t, start.Add(3*time.Hour), view.UpdatedAt)
+}
@@ -0,0 +1,170 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+)
+
+// MemberContributionView represents a member's contribution to a guild during one week
+type MemberContributionView struct {
+	*cqrs.BaseReadModel
+	GuildID             string    `json:"guild_id"`
+	UserID              string    `json:"user_id"`
+	WeekStart           time.Time `json:"week_start"`
+	MineralsMined       int64     `json:"minerals_mined"`
+	TransportsCompleted int       `json:"transports_completed"`
+	TreasuryDonated     int64     `json:"treasury_donated"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// MemberContributionViewID returns the read model ID for a member's week
+func MemberContributionViewID(guildID, userID string, weekStart time.Time) string {
+	return fmt.Sprintf("%s:%s:%s", guildID, userID, weekStart.Format("2006-01-02"))
+}
+
+// NewMemberContributionView creates a new MemberContributionView
+func NewMemberContributionView(guildID, userID string, weekStart time.Time) *MemberContributionView {
+	return &MemberContributionView{
+		BaseReadModel: cqrs.NewBaseReadModel(MemberContributionViewID(guildID, userID, weekStart), "MemberContributionView", map[string]interface{}{}),
+		GuildID:       guildID,
+		UserID:        userID,
+		WeekStart:     weekStart,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// GetData returns the MemberContributionView data as a map for serialization
+func (cv *MemberContributionView) GetData() interface{} {
+	return map[string]interface{}{
+		"guild_id":             cv.GuildID,
+		"user_id":              cv.UserID,
+		"week_start":           cv.WeekStart,
+		"minerals_mined":       cv.MineralsMined,
+		"transports_completed": cv.TransportsCompleted,
+		"treasury_donated":     cv.TreasuryDonated,
+		"points":               cv.Contribution().Points(),
+		"updated_at":           cv.UpdatedAt,
+	}
+}
+
+// Contribution returns the view as a domain contribution
+func (cv *MemberContributionView) Contribution() domain.MemberContribution {
+	return domain.MemberContribution{
+		UserID:              cv.UserID,
+		MineralsMined:       cv.MineralsMined,
+		TransportsCompleted: cv.TransportsCompleted,
+		TreasuryDonated:     cv.TreasuryDonated,
+	}
+}
+
+// MemberContributionProjection accumulates weekly member contributions from
+// mining, transport and treasury events
+type MemberContributionProjection struct {
+	*cqrs.BaseProjection
+	readStore cqrs.ReadStore
+}
+
+// NewMemberContributionProjection creates a new MemberContributionProjection
+func NewMemberContributionProjection(readStore cqrs.ReadStore) *MemberContributionProjection {
+	supportedEvents := []string{
+		domain.MineralsHarvestedEventType,
+		domain.TransportRecruitmentCompletedEventType,
+		domain.TreasuryDonatedEventType,
+	}
+
+	return &MemberContributionProjection{
+		BaseProjection: cqrs.NewBaseProjection("MemberContributionProjection", "1.0.0", supportedEvents),
+		readStore:      readStore,
+	}
+}
+
+// Project processes the event and updates the read model
+func (p *MemberContributionProjection) Project(ctx context.Context, event cqrs.EventMessage) error {
+	// Call base implementation first
+	if err := p.BaseProjection.Project(ctx, event); err != nil {
+		return err
+	}
+
+	switch e := event.(type) {
+	case *domain.MineralsHarvestedEvent:
+		return p.handleMineralsHarvested(ctx, e)
+	case *domain.TransportRecruitmentCompletedEvent:
+		return p.handleTransportCompleted(ctx, e)
+	case *domain.TreasuryDonatedEvent:
+		return p.handleTreasuryDonated(ctx, e)
+	default:
+		return fmt.Errorf("unsupported event type: %T", event)
+	}
+}
+
+// Event handlers
+
+// handleMineralsHarvested handles MineralsHarvestedEvent
+func (p *MemberContributionProjection) handleMineralsHarvested(ctx context.Context, event *domain.MineralsHarvestedEvent) error {
+	for userID, amount := range event.WorkerShares {
+		err := p.update(ctx, event, userID, func(view *MemberContributionView) {
+			view.MineralsMined += amount
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleTransportCompleted handles TransportRecruitmentCompletedEvent
+func (p *MemberContributionProjection) handleTransportCompleted(ctx context.Context, event *domain.TransportRecruitmentCompletedEvent) error {
+	for userID := range event.Rewards {
+		err := p.update(ctx, event, userID, func(view *MemberContributionView) {
+			view.TransportsCompleted++
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleTreasuryDonated handles TreasuryDonatedEvent
+func (p *MemberContributionProjection) handleTreasuryDonated(ctx context.Context, event *domain.TreasuryDonatedEvent) error {
+	return p.update(ctx, event, event.UserID, func(view *MemberContributionView) {
+		view.TreasuryDonated += event.Amount
+	})
+}
+
+// update loads (or creates) the member's view for the event's week and applies fn once per event
+func (p *MemberContributionProjection) update(ctx context.Context, event cqrs.EventMessage, userID string, fn func(view *MemberContributionView)) error {
+	guildID := event.AggregateID()
+	weekStart := domain.ContributionWeekStart(event.Timestamp())
+
+	return cqrs.ProjectEvent(ctx, p.readStore, event, MemberContributionViewID(guildID, userID, weekStart), "MemberContributionView",
+		func() *MemberContributionView { return NewMemberContributionView(guildID, userID, weekStart) },
+		func(view *MemberContributionView) {
+			fn(view)
+			view.UpdatedAt = event.Timestamp()
+		})
+}
+
+// GetWeeklyContributions returns every member's contribution to guildID for the week starting at weekStart
+func GetWeeklyContributions(ctx context.Context, readStore cqrs.ReadStore, guildID string, weekStart time.Time) ([]*MemberContributionView, error) {
+	readModels, err := readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "MemberContributionView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contribution views: %w", err)
+	}
+
+	views := make([]*MemberContributionView, 0)
+	for _, readModel := range readModels {
+		view, ok := readModel.(*MemberContributionView)
+		if !ok || view.GuildID != guildID || !view.WeekStart.Equal(weekStart) {
+			continue
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
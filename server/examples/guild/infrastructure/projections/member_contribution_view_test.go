@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stamp sets the version and time the aggregate would have assigned to the event
+func stamp(base *cqrs.BaseEventMessage, version int, at time.Time) {
+	base.Version_ = version
+	base.Timestamp_ = at
+}
+
+// contributionHistory returns contribution events of guild-1 over two weeks (starting 2024-03-04 and 2024-03-11)
+// and one donation to guild-2
+func contributionHistory() []cqrs.EventMessage {
+	harvested := domain.NewMineralsHarvestedEvent("guild-1", "op-1", map[domain.MineralType]int64{}, 50, "alice", map[string]int64{"alice": 30, "bob": 20})
+	completed := domain.NewTransportRecruitmentCompletedEvent("guild-1", "rec-1", map[string]map[domain.MineralType]int64{"alice": {}, "carol": {}}, "alice")
+	donated := domain.NewTreasuryDonatedEvent("guild-1", "bob", 500)
+	nextWeek := domain.NewTreasuryDonatedEvent("guild-1", "alice", 40)
+	otherGuild := domain.NewTreasuryDonatedEvent("guild-2", "bob", 999)
+
+	stamp(harvested.BaseEventMessage, 1, time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC))
+	stamp(completed.BaseEventMessage, 2, time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC))
+	stamp(donated.BaseEventMessage, 3, time.Date(2024, 3, 10, 23, 59, 0, 0, time.UTC))
+	stamp(nextWeek.BaseEventMessage, 4, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC))
+	stamp(otherGuild.BaseEventMessage, 1, time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC))
+
+	return []cqrs.EventMessage{harvested, completed, donated, nextWeek, otherGuild}
+}
+
+func contributionsByUser(views []*MemberContributionView) map[string]domain.MemberContribution {
+	byUser := make(map[string]domain.MemberContribution, len(views))
+	for _, view := range views {
+		byUser[view.UserID] = view.Contribution()
+	}
+	return byUser
+}
+
+func TestMemberContributionProjection_WeeklyBuckets(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	projection := NewMemberContributionProjection(store)
+	events := contributionHistory()
+	week := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	project(t, projection, events)
+	project(t, projection, events[2:3]) // redelivered donation
+
+	// Assert
+	thisWeek, err := GetWeeklyContributions(ctx, store, "guild-1", week)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.MemberContribution{
+		"alice": {UserID: "alice", MineralsMined: 30, TransportsCompleted: 1},
+		"bob":   {UserID: "bob", MineralsMined: 20, TreasuryDonated: 500},
+		"carol": {UserID: "carol", TransportsCompleted: 1},
+	}, contributionsByUser(thisWeek))
+
+	nextWeek, err := GetWeeklyContributions(ctx, store, "guild-1", week.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.MemberContribution{
+		"alice": {UserID: "alice", TreasuryDonated: 40},
+	}, contributionsByUser(nextWeek))
+
+	otherGuild, err := GetWeeklyContributions(ctx, store, "guild-2", week)
+	require.NoError(t, err)
+	require.Len(t, otherGuild, 1)
+	assert.Equal(t, int64(999), otherGuild[0].TreasuryDonated)
+}
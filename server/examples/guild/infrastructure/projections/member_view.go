@@ -109,54 +109,33 @@ func (mv *MemberView) HasPermission(permission string) bool {
 
 // MemberViewProjection handles guild member events and updates the MemberView read model
 type MemberViewProjection struct {
-	*cqrs.BaseProjection
+	*cqrs.DeclarativeProjection
 	readStore cqrs.ReadStore
 }
 
 // NewMemberViewProjection creates a new MemberViewProjection
 func NewMemberViewProjection(readStore cqrs.ReadStore) *MemberViewProjection {
-	supportedEvents := []string{
-		domain.GuildCreatedEventType,
-		domain.MemberInvitedEventType,
-		domain.MemberJoinedEventType,
-		domain.MemberKickedEventType,
-		domain.MemberPromotedEventType,
-	}
-
-	return &MemberViewProjection{
-		BaseProjection: cqrs.NewBaseProjection("MemberViewProjection", "1.0.0", supportedEvents),
-		readStore:      readStore,
-	}
-}
-
-// Project processes the event and updates the read model
-func (p *MemberViewProjection) Project(ctx context.Context, event cqrs.EventMessage) error {
-	// Call base implementation first
-	if err := p.BaseProjection.Project(ctx, event); err != nil {
-		return err
-	}
-
-	switch e := event.(type) {
-	case *domain.GuildCreatedEvent:
-		return p.handleGuildCreated(ctx, e)
-	case *domain.MemberInvitedEvent:
-		return p.handleMemberInvited(ctx, e)
-	case *domain.MemberJoinedEvent:
-		return p.handleMemberJoined(ctx, e)
-	case *domain.MemberKickedEvent:
-		return p.handleMemberKicked(ctx, e)
-	case *domain.MemberPromotedEvent:
-		return p.handleMemberPromoted(ctx, e)
-	default:
-		return fmt.Errorf("unsupported event type: %T", event)
-	}
+	p := &MemberViewProjection{readStore: readStore}
+	p.DeclarativeProjection = cqrs.MustNewDeclarativeProjection(cqrs.ProjectionDefinition{
+		Name:        "MemberViewProjection",
+		Version:     "1.0.0",
+		Description: "One view per guild member with role, status and permissions.",
+		Rules: []cqrs.ProjectionRule{
+			cqrs.ProjectionRuleFor(domain.GuildCreatedEventType, "MemberView", "Creates the founder as leader", p.handleGuildCreated),
+			cqrs.ProjectionRuleFor(domain.MemberInvitedEventType, "MemberView", "Creates the pending member", p.handleMemberInvited),
+			cqrs.ProjectionRuleFor(domain.MemberJoinedEventType, "MemberView", "Activates the member", p.handleMemberJoined),
+			cqrs.ProjectionRuleFor(domain.MemberKickedEventType, "MemberView", "Marks the member as kicked", p.handleMemberKicked),
+			cqrs.ProjectionRuleFor(domain.MemberPromotedEventType, "MemberView", "Changes the member's role", p.handleMemberPromoted),
+		},
+	})
+	return p
 }
 
 // Event handlers
 
 // handleGuildCreated handles GuildCreatedEvent (creates founder member)
 func (p *MemberViewProjection) handleGuildCreated(ctx context.Context, event *domain.GuildCreatedEvent) error {
-	guildID := event.ID()
+	guildID := event.AggregateID()
 	founderID := event.FounderID
 	founderUsername := event.FounderUsername
 
@@ -177,7 +156,7 @@ func (p *MemberViewProjection) handleGuildCreated(ctx context.Context, event *do
 
 // handleMemberInvited handles MemberInvitedEvent
 func (p *MemberViewProjection) handleMemberInvited(ctx context.Context, event *domain.MemberInvitedEvent) error {
-	guildID := event.ID()
+	guildID := event.AggregateID()
 	userID := event.UserID
 	username := event.Username
 	invitedBy := event.InvitedBy
@@ -200,91 +179,41 @@ func (p *MemberViewProjection) handleMemberInvited(ctx context.Context, event *d
 
 // handleMemberJoined handles MemberJoinedEvent
 func (p *MemberViewProjection) handleMemberJoined(ctx context.Context, event *domain.MemberJoinedEvent) error {
-	guildID := event.ID()
-	userID := event.UserID
-	memberID := fmt.Sprintf("%s:%s", guildID, userID)
-
-	// Load existing member view
-	readModel, err := p.readStore.GetByID(ctx, memberID, "MemberView")
-	if err != nil {
-		return fmt.Errorf("failed to load member view: %w", err)
-	}
-
-	memberView, ok := readModel.(*MemberView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *MemberView, got %T", readModel)
-	}
-
-	// Update status to active
-	memberView.Status = "Active"
-	memberView.LastActiveAt = event.Timestamp()
-	memberView.UpdatedAt = event.Timestamp()
-	memberView.SetVersion(event.Version())
-
-	memberView.UpdatePermissions()
-	memberView.UpdateDaysInGuild()
-
-	return p.readStore.Save(ctx, memberView)
+	return p.update(ctx, event, event.UserID, func(memberView *MemberView) {
+		memberView.Status = "Active"
+		memberView.LastActiveAt = event.Timestamp()
+	})
 }
 
 // handleMemberKicked handles MemberKickedEvent
 func (p *MemberViewProjection) handleMemberKicked(ctx context.Context, event *domain.MemberKickedEvent) error {
-	guildID := event.ID()
-	userID := event.UserID
-	kickedBy := event.KickedBy
-	reason := event.Reason
-	memberID := fmt.Sprintf("%s:%s", guildID, userID)
-
-	// Load existing member view
-	readModel, err := p.readStore.GetByID(ctx, memberID, "MemberView")
-	if err != nil {
-		return fmt.Errorf("failed to load member view: %w", err)
-	}
-
-	memberView, ok := readModel.(*MemberView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *MemberView, got %T", readModel)
-	}
-
-	// Update status to kicked
-	memberView.Status = "Kicked"
-	memberView.KickedBy = kickedBy
-	memberView.KickedReason = reason
-	memberView.UpdatedAt = event.Timestamp()
-	memberView.SetVersion(event.Version())
-
-	memberView.UpdatePermissions()
-	memberView.UpdateDaysInGuild()
-
-	return p.readStore.Save(ctx, memberView)
+	return p.update(ctx, event, event.UserID, func(memberView *MemberView) {
+		memberView.Status = "Kicked"
+		memberView.KickedBy = event.KickedBy
+		memberView.KickedReason = event.Reason
+	})
 }
 
 // handleMemberPromoted handles MemberPromotedEvent
 func (p *MemberViewProjection) handleMemberPromoted(ctx context.Context, event *domain.MemberPromotedEvent) error {
-	guildID := event.ID()
-	userID := event.UserID
-	newRole := event.NewRole.String()
-	memberID := fmt.Sprintf("%s:%s", guildID, userID)
+	return p.update(ctx, event, event.UserID, func(memberView *MemberView) {
+		memberView.Role = event.NewRole.String()
+		memberView.LastActiveAt = event.Timestamp()
+	})
+}
 
-	// Load existing member view
-	readModel, err := p.readStore.GetByID(ctx, memberID, "MemberView")
+// update applies fn to the member's view unless the event was already applied,
+// then refreshes the derived fields, stamps the event time and version and saves the view
+func (p *MemberViewProjection) update(ctx context.Context, event cqrs.EventMessage, userID string, fn func(memberView *MemberView)) error {
+	memberID := fmt.Sprintf("%s:%s", event.AggregateID(), userID)
+	err := cqrs.ProjectEvent(ctx, p.readStore, event, memberID, "MemberView", nil, func(memberView *MemberView) {
+		fn(memberView)
+		memberView.UpdatedAt = event.Timestamp()
+		memberView.UpdatePermissions()
+		memberView.UpdateDaysInGuild()
+	})
 	if err != nil {
-		return fmt.Errorf("failed to load member view: %w", err)
-	}
-
-	memberView, ok := readModel.(*MemberView)
-	if !ok {
-		return fmt.Errorf("invalid read model type: expected *MemberView, got %T", readModel)
+		return fmt.Errorf("failed to update member view: %w", err)
 	}
-
-	// Update role
-	memberView.Role = newRole
-	memberView.LastActiveAt = event.Timestamp()
-	memberView.UpdatedAt = event.Timestamp()
-	memberView.SetVersion(event.Version())
-
-	memberView.UpdatePermissions()
-	memberView.UpdateDaysInGuild()
-
-	return p.readStore.Save(ctx, memberView)
+	return nil
 }
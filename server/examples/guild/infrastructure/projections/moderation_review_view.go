@@ -0,0 +1,45 @@
+package projections
+
+import (
+	"context"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/moderation"
+)
+
+// ModerationReviewProjection feeds GuildContentFlagged events into the moderation review queue.
+// It runs asynchronously from the command side, so flagged content is visible to
+// players immediately and reaches moderators once the projection catches up.
+type ModerationReviewProjection struct {
+	*cqrs.DeclarativeProjection
+	queue *moderation.ReviewQueue
+}
+
+// NewModerationReviewProjection creates a new ModerationReviewProjection
+func NewModerationReviewProjection(queue *moderation.ReviewQueue) *ModerationReviewProjection {
+	p := &ModerationReviewProjection{queue: queue}
+	p.DeclarativeProjection = cqrs.MustNewDeclarativeProjection(cqrs.ProjectionDefinition{
+		Name:        "ModerationReviewProjection",
+		Version:     "1.0.0",
+		Description: "Queue of flagged guild content awaiting moderator review.",
+		Rules: []cqrs.ProjectionRule{
+			cqrs.ProjectionRuleFor(domain.GuildContentFlaggedEventType, moderation.ReviewItemModelType, "Adds a pending review item", p.handleContentFlagged),
+		},
+	})
+	return p
+}
+
+// handleContentFlagged handles GuildContentFlaggedEvent
+func (p *ModerationReviewProjection) handleContentFlagged(ctx context.Context, event *domain.GuildContentFlaggedEvent) error {
+	// The event ID keeps repeated flags of the same content as separate review items
+	item := moderation.NewReviewItem(event.EventID(), event.ContentKey, moderation.Content{
+		ScopeID:  event.GuildID,
+		AuthorID: event.AuthorID,
+		Kind:     event.Kind,
+		Text:     event.Text,
+	}, event.Reasons, event.Timestamp())
+	item.SetVersion(event.Version())
+
+	return p.queue.Enqueue(ctx, item)
+}
@@ -0,0 +1,131 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+	"defense-allies-server/serverapp/moderation"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allGuildEventTypes lists every event type the guild domain publishes
+var allGuildEventTypes = []string{
+	domain.GuildCreatedEventType,
+	domain.GuildInfoUpdatedEventType,
+	domain.GuildSettingsUpdatedEventType,
+	domain.GuildDisbandedEventType,
+	domain.GuildEmblemUpdatedEventType,
+	domain.GuildLocaleUpdatedEventType,
+	domain.TreasuryDonatedEventType,
+	domain.MemberContributionWeeklySummaryEventType,
+	domain.GuildExperienceGainedEventType,
+	domain.GuildLeveledUpEventType,
+	domain.GuildChatMessagePostedEventType,
+	domain.GuildContentFlaggedEventType,
+	domain.MemberInvitedEventType,
+	domain.MemberJoinedEventType,
+	domain.MemberLeftEventType,
+	domain.MemberKickedEventType,
+	domain.MemberPromotedEventType,
+	domain.MemberDemotedEventType,
+	domain.MineDiscoveredEventType,
+	domain.MiningStartedEventType,
+	domain.WorkerAssignedEventType,
+	domain.WorkerRemovedEventType,
+	domain.MineralsExtractedEventType,
+	domain.MiningOperationStartedEventType,
+	domain.MineralsHarvestedEventType,
+	domain.MiningOperationStoppedEventType,
+	domain.TransportRecruitmentCreatedEventType,
+	domain.TransportRecruitmentJoinedEventType,
+	domain.TransportRecruitmentLeftEventType,
+	domain.TransportRecruitmentStartedEventType,
+	domain.TransportRecruitmentCompletedEventType,
+	domain.TransportRecruitmentCancelledEventType,
+	domain.TransportStartedEventType,
+	domain.TransportAttackedEventType,
+	domain.TransportDefendedEventType,
+	domain.TransportCompletedEventType,
+	domain.TransportRaidedEventType,
+	domain.TransportCancelledEventType,
+	domain.EconomyConfigChangedEventType,
+}
+
+// The rule tables must accept exactly the event types the hand-written
+// supportedEvents lists and Project type switches handled before.
+func TestDeclarativeProjections_MatchLegacyEventSwitches(t *testing.T) {
+	store := cqrs.NewInMemoryReadStore()
+
+	tests := []struct {
+		name       string
+		projection *cqrs.DeclarativeProjection
+		legacy     []string
+	}{
+		{
+			name:       "GuildViewProjection",
+			projection: NewGuildViewProjection(store).DeclarativeProjection,
+			legacy: []string{
+				domain.GuildCreatedEventType,
+				domain.GuildInfoUpdatedEventType,
+				domain.GuildSettingsUpdatedEventType,
+				domain.GuildEmblemUpdatedEventType,
+				domain.GuildLocaleUpdatedEventType, // added with guild locales after the conversion
+				domain.MemberInvitedEventType,
+				domain.MemberJoinedEventType,
+				domain.MemberKickedEventType,
+				domain.MemberPromotedEventType,
+				domain.GuildExperienceGainedEventType,
+				domain.GuildLeveledUpEventType,
+			},
+		},
+		{
+			name:       "MemberViewProjection",
+			projection: NewMemberViewProjection(store).DeclarativeProjection,
+			legacy: []string{
+				domain.GuildCreatedEventType,
+				domain.MemberInvitedEventType,
+				domain.MemberJoinedEventType,
+				domain.MemberKickedEventType,
+				domain.MemberPromotedEventType,
+			},
+		},
+		{
+			name:       "ModerationReviewProjection",
+			projection: NewModerationReviewProjection(moderation.NewReviewQueue(moderation.ReviewQueueConfig{ReadStore: store})).DeclarativeProjection,
+			legacy:     []string{domain.GuildContentFlaggedEventType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handled := make(map[string]bool, len(tt.legacy))
+			for _, eventType := range tt.legacy {
+				handled[eventType] = true
+			}
+
+			// Act & Assert
+			assert.Equal(t, tt.name, tt.projection.GetProjectionName())
+			assert.ElementsMatch(t, tt.legacy, tt.projection.Definition().EventTypes())
+			for _, eventType := range allGuildEventTypes {
+				assert.Equal(t, handled[eventType], tt.projection.CanHandle(eventType), eventType)
+			}
+		})
+	}
+}
+
+func TestDeclarativeProjections_RejectUnhandledEvents(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	projection := NewMemberViewProjection(cqrs.NewInMemoryReadStore())
+	event := domain.NewTreasuryDonatedEvent("guild-1", "user-1", 100)
+
+	// Act
+	err := projection.Project(ctx, event)
+
+	// Assert: like the old default branch, an unsupported event is an error
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+)
+
+// TransportRecruitmentView represents a transport recruitment on the cross-guild recruitment board
+type TransportRecruitmentView struct {
+	*cqrs.BaseReadModel
+	RecruitmentID     string        `json:"recruitment_id"`
+	GuildID           string        `json:"guild_id"`
+	GuildName         string        `json:"guild_name"`
+	Title             string        `json:"title"`
+	Description       string        `json:"description"`
+	CreatedBy         string        `json:"created_by"`
+	CreatedByUsername string        `json:"created_by_username"`
+	Status            string        `json:"status"`
+	CreatedAt         time.Time     `json:"created_at"`
+	ExpiresAt         time.Time     `json:"expires_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+	TransportTime     time.Duration `json:"transport_time"`
+	TransportID       string        `json:"transport_id,omitempty"`
+
+	// Participants
+	MaxParticipants  int      `json:"max_participants"`
+	MinParticipants  int      `json:"min_participants"`
+	ParticipantIDs   []string `json:"participant_ids"`
+	ParticipantCount int      `json:"participant_count"`
+
+	// Cargo, keyed by mineral name
+	TotalCargo      map[string]int64 `json:"total_cargo"`
+	RewardPerPerson map[string]int64 `json:"reward_per_person"`
+	CargoValue      int64            `json:"cargo_value"` // Value at creation time
+}
+
+// NewTransportRecruitmentView creates a new TransportRecruitmentView
+func NewTransportRecruitmentView(recruitmentID string) *TransportRecruitmentView {
+	return &TransportRecruitmentView{
+		BaseReadModel:   cqrs.NewBaseReadModel(recruitmentID, "TransportRecruitmentView", map[string]interface{}{}),
+		RecruitmentID:   recruitmentID,
+		ParticipantIDs:  make([]string, 0),
+		TotalCargo:      make(map[string]int64),
+		RewardPerPerson: make(map[string]int64),
+	}
+}
+
+// GetData returns the TransportRecruitmentView data as a map for serialization
+func (rv *TransportRecruitmentView) GetData() interface{} {
+	return map[string]interface{}{
+		"recruitment_id":      rv.RecruitmentID,
+		"guild_id":            rv.GuildID,
+		"guild_name":          rv.GuildName,
+		"title":               rv.Title,
+		"description":         rv.Description,
+		"created_by":          rv.CreatedBy,
+		"created_by_username": rv.CreatedByUsername,
+		"status":              rv.Status,
+		"created_at":          rv.CreatedAt,
+		"expires_at":          rv.ExpiresAt,
+		"updated_at":          rv.UpdatedAt,
+		"transport_time":      rv.TransportTime,
+		"transport_id":        rv.TransportID,
+		"max_participants":    rv.MaxParticipants,
+		"min_participants":    rv.MinParticipants,
+		"participant_ids":     rv.ParticipantIDs,
+		"participant_count":   rv.ParticipantCount,
+		"total_cargo":         rv.TotalCargo,
+		"reward_per_person":   rv.RewardPerPerson,
+		"cargo_value":         rv.CargoValue,
+	}
+}
+
+// IsDue returns true if the recruitment is still active but its window closed at or before the given time
+func (rv *TransportRecruitmentView) IsDue(at time.Time) bool {
+	active := rv.Status == domain.RecruitmentStatusOpen.String() || rv.Status == domain.RecruitmentStatusFull.String()
+	return active && !at.Before(rv.ExpiresAt)
+}
+
+// IsOpen returns true if the recruitment accepts participants at the given time
+func (rv *TransportRecruitmentView) IsOpen(at time.Time) bool {
+	return rv.Status == domain.RecruitmentStatusOpen.String() && at.Before(rv.ExpiresAt)
+}
+
+// TimeRemaining returns how long the recruitment stays open after the given time
+func (rv *TransportRecruitmentView) TimeRemaining(at time.Time) time.Duration {
+	if remaining := rv.ExpiresAt.Sub(at); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (rv *TransportRecruitmentView) updateParticipantStatus() {
+	rv.ParticipantCount = len(rv.ParticipantIDs)
+	switch {
+	case rv.Status != domain.RecruitmentStatusOpen.String() && rv.Status != domain.RecruitmentStatusFull.String():
+		// Started or finished recruitments keep their status
+	case rv.ParticipantCount >= rv.MaxParticipants:
+		rv.Status = domain.RecruitmentStatusFull.String()
+	default:
+		rv.Status = domain.RecruitmentStatusOpen.String()
+	}
+}
+
+// TransportRecruitmentProjection maintains TransportRecruitmentView for every guild's recruitments
+type TransportRecruitmentProjection struct {
+	*cqrs.BaseProjection
+	readStore cqrs.ReadStore
+	economy   domain.EconomyConfigProvider
+}
+
+// NewTransportRecruitmentProjection creates a new TransportRecruitmentProjection.
+// economy prices the cargo; nil uses the default economy.
+func NewTransportRecruitmentProjection(readStore cqrs.ReadStore, economy domain.EconomyConfigProvider) *TransportRecruitmentProjection {
+	supportedEvents := []string{
+		domain.TransportRecruitmentCreatedEventType,
+		domain.TransportRecruitmentJoinedEventType,
+		domain.TransportRecruitmentLeftEventType,
+		domain.TransportRecruitmentStartedEventType,
+		domain.TransportRecruitmentCompletedEventType,
+		domain.TransportRecruitmentCancelledEventType,
+	}
+
+	if economy == nil {
+		economy = domain.DefaultEconomyConfig()
+	}
+
+	return &TransportRecruitmentProjection{
+		BaseProjection: cqrs.NewBaseProjection("TransportRecruitmentProjection", "1.0.0", supportedEvents),
+		readStore:      readStore,
+		economy:        economy,
+	}
+}
+
+// Project processes the event and updates the read model
+func (p *TransportRecruitmentProjection) Project(ctx context.Context, event cqrs.EventMessage) error {
+	// Call base implementation first
+	if err := p.BaseProjection.Project(ctx, event); err != nil {
+		return err
+	}
+
+	switch e := event.(type) {
+	case *domain.TransportRecruitmentCreatedEvent:
+		return p.handleRecruitmentCreated(ctx, e)
+	case *domain.TransportRecruitmentJoinedEvent:
+		return p.handleRecruitmentJoined(ctx, e)
+	case *domain.TransportRecruitmentLeftEvent:
+		return p.handleRecruitmentLeft(ctx, e)
+	case *domain.TransportRecruitmentStartedEvent:
+		return p.handleRecruitmentStarted(ctx, e)
+	case *domain.TransportRecruitmentCompletedEvent:
+		return p.handleRecruitmentCompleted(ctx, e)
+	case *domain.TransportRecruitmentCancelledEvent:
+		return p.handleRecruitmentCancelled(ctx, e)
+	default:
+		return fmt.Errorf("unsupported event type: %T", event)
+	}
+}
+
+// Event handlers
+
+// handleRecruitmentCreated handles TransportRecruitmentCreatedEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentCreated(ctx context.Context, event *domain.TransportRecruitmentCreatedEvent) error {
+	view := NewTransportRecruitmentView(event.RecruitmentID)
+	view.GuildID = event.GuildID
+	view.Title = event.Title
+	view.Description = event.Description
+	view.CreatedBy = event.CreatedBy
+	view.CreatedByUsername = event.CreatedByUsername
+	view.Status = domain.RecruitmentStatusOpen.String()
+	view.CreatedAt = event.Timestamp()
+	view.ExpiresAt = event.Timestamp().Add(time.Duration(event.Duration))
+	view.UpdatedAt = event.Timestamp()
+	view.TransportTime = time.Duration(event.TransportTime)
+	view.MaxParticipants = event.MaxParticipants
+	view.MinParticipants = event.MinParticipants
+
+	for mineralType, amount := range event.TotalCargo {
+		view.TotalCargo[mineralType.String()] = amount
+	}
+	for mineralType, amount := range event.RewardPerPerson {
+		view.RewardPerPerson[mineralType.String()] = amount
+	}
+	view.CargoValue = p.economy.EconomyConfig().MineralsValue(event.TotalCargo)
+
+	// Guild name is display-only; the board still works without it
+	if readModel, err := p.readStore.GetByID(ctx, event.GuildID, "GuildView"); err == nil {
+		if guildView, ok := readModel.(*GuildView); ok {
+			view.GuildName = guildView.Name
+		}
+	}
+
+	view.SetVersion(event.Version())
+	return p.readStore.Save(ctx, view)
+}
+
+// handleRecruitmentJoined handles TransportRecruitmentJoinedEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentJoined(ctx context.Context, event *domain.TransportRecruitmentJoinedEvent) error {
+	return p.update(ctx, event.RecruitmentID, event, func(view *TransportRecruitmentView) {
+		for _, userID := range view.ParticipantIDs {
+			if userID == event.UserID {
+				return
+			}
+		}
+		view.ParticipantIDs = append(view.ParticipantIDs, event.UserID)
+		view.updateParticipantStatus()
+	})
+}
+
+// handleRecruitmentLeft handles TransportRecruitmentLeftEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentLeft(ctx context.Context, event *domain.TransportRecruitmentLeftEvent) error {
+	return p.update(ctx, event.RecruitmentID, event, func(view *TransportRecruitmentView) {
+		remaining := make([]string, 0, len(view.ParticipantIDs))
+		for _, userID := range view.ParticipantIDs {
+			if userID != event.UserID {
+				remaining = append(remaining, userID)
+			}
+		}
+		view.ParticipantIDs = remaining
+		view.updateParticipantStatus()
+	})
+}
+
+// handleRecruitmentStarted handles TransportRecruitmentStartedEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentStarted(ctx context.Context, event *domain.TransportRecruitmentStartedEvent) error {
+	return p.update(ctx, event.RecruitmentID, event, func(view *TransportRecruitmentView) {
+		view.Status = domain.RecruitmentStatusStarted.String()
+		view.TransportID = event.TransportID
+	})
+}
+
+// handleRecruitmentCompleted handles TransportRecruitmentCompletedEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentCompleted(ctx context.Context, event *domain.TransportRecruitmentCompletedEvent) error {
+	return p.update(ctx, event.RecruitmentID, event, func(view *TransportRecruitmentView) {
+		view.Status = "Completed"
+	})
+}
+
+// handleRecruitmentCancelled handles TransportRecruitmentCancelledEvent
+func (p *TransportRecruitmentProjection) handleRecruitmentCancelled(ctx context.Context, event *domain.TransportRecruitmentCancelledEvent) error {
+	return p.update(ctx, event.RecruitmentID, event, func(view *TransportRecruitmentView) {
+		view.Status = domain.RecruitmentStatusCancelled.String()
+	})
+}
+
+// update loads the recruitment view, applies fn and saves it
+func (p *TransportRecruitmentProjection) update(ctx context.Context, recruitmentID string, event cqrs.EventMessage, fn func(view *TransportRecruitmentView)) error {
+	readModel, err := p.readStore.GetByID(ctx, recruitmentID, "TransportRecruitmentView")
+	if err != nil {
+		return fmt.Errorf("failed to load recruitment view: %w", err)
+	}
+
+	view, ok := readModel.(*TransportRecruitmentView)
+	if !ok {
+		return fmt.Errorf("invalid read model type: expected *TransportRecruitmentView, got %T", readModel)
+	}
+
+	fn(view)
+	view.UpdatedAt = event.Timestamp()
+	view.SetVersion(event.Version())
+
+	return p.readStore.Save(ctx, view)
+}
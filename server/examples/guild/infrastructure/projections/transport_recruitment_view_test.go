@@ -0,0 +1,93 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportRecruitmentProjection_Lifecycle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	guildView := NewGuildView("guild-1")
+	guildView.Name = "Knights"
+	require.NoError(t, store.Save(ctx, guildView))
+
+	economy := domain.DefaultEconomyConfig()
+	economy.MineralValues[domain.MineralGold] = 10
+	economy.MineralValues[domain.MineralIron] = 1
+	projection := NewTransportRecruitmentProjection(store, economy)
+
+	createdAt := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	cargo := map[domain.MineralType]int64{domain.MineralGold: 5, domain.MineralIron: 20}
+	created := domain.NewTransportRecruitmentCreatedEvent("guild-1", "rec-1", "Gold run", "", 2, 1,
+		int64(time.Hour), int64(30*time.Minute), cargo, map[domain.MineralType]int64{domain.MineralGold: 2}, "alice", "Alice")
+	joinedAlice := domain.NewTransportRecruitmentJoinedEvent("guild-1", "rec-1", "alice", "Alice")
+	joinedBob := domain.NewTransportRecruitmentJoinedEvent("guild-1", "rec-1", "bob", "Bob")
+	leftAlice := domain.NewTransportRecruitmentLeftEvent("guild-1", "rec-1", "alice", "Alice")
+	started := domain.NewTransportRecruitmentStartedEvent("guild-1", "rec-1", "transport-1", "bob")
+	for i, base := range []*cqrs.BaseEventMessage{created.BaseEventMessage, joinedAlice.BaseEventMessage, joinedBob.BaseEventMessage, leftAlice.BaseEventMessage, started.BaseEventMessage} {
+		stamp(base, i+1, createdAt.Add(time.Duration(i)*time.Minute))
+	}
+	load := func() *TransportRecruitmentView {
+		view, err := cqrs.LoadReadModel[*TransportRecruitmentView](ctx, store, "rec-1", "TransportRecruitmentView")
+		require.NoError(t, err)
+		return view
+	}
+
+	// Act & Assert: created
+	project(t, projection, []cqrs.EventMessage{created})
+	view := load()
+	assert.Equal(t, "Knights", view.GuildName)
+	assert.Equal(t, int64(70), view.CargoValue) // 5*10 + 20*1
+	assert.Equal(t, map[string]int64{"Gold": 5, "Iron": 20}, view.TotalCargo)
+	assert.Equal(t, createdAt.Add(time.Hour), view.ExpiresAt)
+	assert.Equal(t, 30*time.Minute, view.TransportTime)
+	assert.True(t, view.IsOpen(createdAt))
+
+	// Act & Assert: joins fill the recruitment; a repeated join is counted once
+	project(t, projection, []cqrs.EventMessage{joinedAlice, joinedAlice, joinedBob})
+	view = load()
+	assert.Equal(t, []string{"alice", "bob"}, view.ParticipantIDs)
+	assert.Equal(t, domain.RecruitmentStatusFull.String(), view.Status)
+	assert.False(t, view.IsOpen(createdAt))
+
+	// Act & Assert: leaving reopens it
+	project(t, projection, []cqrs.EventMessage{leftAlice})
+	view = load()
+	assert.Equal(t, 1, view.ParticipantCount)
+	assert.Equal(t, domain.RecruitmentStatusOpen.String(), view.Status)
+
+	// Act & Assert: started recruitments leave the board
+	project(t, projection, []cqrs.EventMessage{started})
+	view = load()
+	assert.Equal(t, domain.RecruitmentStatusStarted.String(), view.Status)
+	assert.Equal(t, "transport-1", view.TransportID)
+	assert.Equal(t, 5, view.GetVersion())
+	assert.False(t, view.IsOpen(createdAt))
+	assert.False(t, view.IsDue(createdAt.Add(2*time.Hour)))
+}
+
+func TestTransportRecruitmentView_Window(t *testing.T) {
+	expiresAt := time.Date(2024, 3, 4, 13, 0, 0, 0, time.UTC)
+	view := NewTransportRecruitmentView("rec-1")
+	view.Status = domain.RecruitmentStatusOpen.String()
+	view.ExpiresAt = expiresAt
+
+	assert.True(t, view.IsOpen(expiresAt.Add(-time.Second)))
+	assert.False(t, view.IsOpen(expiresAt))
+	assert.False(t, view.IsDue(expiresAt.Add(-time.Second)))
+	assert.True(t, view.IsDue(expiresAt))
+	assert.Equal(t, 10*time.Minute, view.TimeRemaining(expiresAt.Add(-10*time.Minute)))
+	assert.Equal(t, time.Duration(0), view.TimeRemaining(expiresAt.Add(time.Minute)))
+
+	view.Status = domain.RecruitmentStatusFull.String()
+	assert.True(t, view.IsDue(expiresAt)) // full recruitments are processed at expiry too
+}
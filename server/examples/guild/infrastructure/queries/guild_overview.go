@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"fmt"
+
+	"cqrs"
+)
+
+// GetGuildOverviewQueryType is the composite query behind the guild screen
+const GetGuildOverviewQueryType = "GetGuildOverview"
+
+// GetGuildOverviewQuery loads the guild, its members and its ranking in one round trip
+type GetGuildOverviewQuery struct {
+	*cqrs.BaseQuery
+	GuildID string `json:"guild_id"`
+}
+
+// NewGetGuildOverviewQuery creates a new GetGuildOverviewQuery
+func NewGetGuildOverviewQuery(guildID string) *GetGuildOverviewQuery {
+	return &GetGuildOverviewQuery{
+		BaseQuery: cqrs.NewBaseQuery(
+			GetGuildOverviewQueryType,
+			map[string]interface{}{
+				"guild_id": guildID,
+			},
+		),
+		GuildID: guildID,
+	}
+}
+
+// Validate validates the get guild overview query
+func (q *GetGuildOverviewQuery) Validate() error {
+	if q.GuildID == "" {
+		return fmt.Errorf("guild ID cannot be empty")
+	}
+	return nil
+}
+
+// RegisterGuildOverviewQuery registers GetGuildOverview as a composite of the guild,
+// member and ranking queries already served by dispatcher. The guild is required;
+// members and ranking are optional, so the screen still renders when one of them fails.
+func RegisterGuildOverviewQuery(dispatcher cqrs.QueryDispatcher) error {
+	guildID := func(query cqrs.Query) string {
+		return query.(*GetGuildOverviewQuery).GuildID
+	}
+
+	return cqrs.RegisterCompositeQuery(dispatcher, GetGuildOverviewQueryType,
+		cqrs.QueryPart("guild", dispatcher, func(query cqrs.Query) cqrs.Query {
+			return NewGetGuildQuery(guildID(query))
+		}),
+		cqrs.QueryPart("members", dispatcher, func(query cqrs.Query) cqrs.Query {
+			return NewGetGuildMembersQuery(guildID(query)).WithStatus("Active")
+		}).AsOptional(),
+		cqrs.QueryPart("ranking", dispatcher, func(query cqrs.Query) cqrs.Query {
+			return cqrs.NewBaseQuery(GetGuildRankingQueryType, map[string]interface{}{"guild_id": guildID(query)})
+		}).AsOptional(),
+	)
+}
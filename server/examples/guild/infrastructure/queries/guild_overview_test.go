@@ -0,0 +1,83 @@
+package queries
+
+import (
+	"context"
+	"testing"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOverviewDispatcher serves GetGuild and GetGuildMembers from the store, but not
+// GetGuildRanking, so the optional ranking part always fails
+func newOverviewDispatcher(t *testing.T) cqrs.QueryDispatcher {
+	t.Helper()
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+
+	guild := projections.NewGuildView("guild-1")
+	guild.Name = "Knights"
+	guild.Status = "Active"
+	require.NoError(t, store.Save(ctx, guild))
+	for userID, status := range map[string]string{"alice": "Active", "bob": "Active", "carol": "Pending"} {
+		member := projections.NewMemberView("guild-1", userID)
+		member.Status = status
+		require.NoError(t, store.Save(ctx, member))
+	}
+
+	dispatcher := cqrs.NewInMemoryQueryDispatcher()
+	handler := NewGuildQueryHandler(store)
+	require.NoError(t, dispatcher.RegisterHandler(GetGuildQueryType, handler))
+	require.NoError(t, dispatcher.RegisterHandler(GetGuildMembersQueryType, handler))
+	require.NoError(t, RegisterGuildOverviewQuery(dispatcher))
+	return dispatcher
+}
+
+func TestGuildOverviewQuery_OptionalPartFailureIsPartial(t *testing.T) {
+	// Arrange
+	dispatcher := newOverviewDispatcher(t)
+
+	// Act
+	result, err := dispatcher.Dispatch(context.Background(), NewGetGuildOverviewQuery("guild-1"))
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, result.Success, "%v", result.Error)
+	assert.Equal(t, true, result.Metadata[cqrs.QueryMetadataPartial])
+
+	overview, ok := result.Data.(*cqrs.CompositeResult)
+	require.True(t, ok)
+	guild, ok := cqrs.CompositeValue[*GuildQueryResult](overview, "guild")
+	require.True(t, ok)
+	assert.Equal(t, "Knights", guild.Guild.Name)
+
+	members, ok := cqrs.CompositeValue[*GuildQueryResult](overview, "members")
+	require.True(t, ok)
+	assert.Equal(t, 2, members.Total) // active members only
+
+	assert.NotContains(t, overview.Parts, "ranking")
+	assert.Contains(t, overview.Errors, "ranking")
+}
+
+func TestGuildOverviewQuery_RequiredPartFailureFailsQuery(t *testing.T) {
+	// Arrange
+	dispatcher := newOverviewDispatcher(t)
+
+	// Act
+	missing, err := dispatcher.Dispatch(context.Background(), NewGetGuildOverviewQuery("guild-9"))
+	require.NoError(t, err)
+	invalid, err := dispatcher.Dispatch(context.Background(), NewGetGuildOverviewQuery(""))
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, missing.Success)
+	require.Error(t, missing.Error)
+	assert.Contains(t, missing.Error.Error(), "failed to load guild view")
+	assert.Nil(t, missing.Data)
+
+	assert.False(t, invalid.Success)
+	assert.Error(t, invalid.Error)
+}
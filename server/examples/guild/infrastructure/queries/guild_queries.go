@@ -3,9 +3,12 @@ package queries
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"cqrs"
+	"defense-allies-server/examples/guild/domain"
 	"defense-allies-server/examples/guild/infrastructure/projections"
 )
 
@@ -15,6 +18,8 @@ const (
 	GetGuildMembersQueryType = "GetGuildMembers"
 	SearchGuildsQueryType    = "SearchGuilds"
 	GetGuildRankingQueryType = "GetGuildRanking"
+
+	GetMemberContributionsQueryType = "GetMemberContributions"
 )
 
 // GetGuildQuery represents a query to get a specific guild
@@ -121,10 +126,15 @@ type SearchGuildsQuery struct {
 	IsPublic   *bool  `json:"is_public,omitempty"`   // Filter by public/private
 	MinLevel   int    `json:"min_level,omitempty"`   // Filter by minimum level
 	MaxLevel   int    `json:"max_level,omitempty"`   // Filter by maximum level
-	Limit      int    `json:"limit,omitempty"`       // Limit number of results
-	Offset     int    `json:"offset,omitempty"`      // Offset for pagination
-	SortBy     string `json:"sort_by,omitempty"`     // Sort field (name, level, member_count, founded_at)
-	SortOrder  string `json:"sort_order,omitempty"`  // Sort order (asc, desc)
+	Region     string `json:"region,omitempty"`      // Filter by region
+	Language   string `json:"language,omitempty"`    // Filter by language
+	// Only guilds whose minimum level requirement a player of this level meets
+	PlayerLevel int    `json:"player_level,omitempty"`
+	OpenSlots   int    `json:"open_slots,omitempty"` // Only guilds with at least this many open slots
+	Limit       int    `json:"limit,omitempty"`      // Limit number of results
+	Offset      int    `json:"offset,omitempty"`     // Offset for pagination
+	SortBy      string `json:"sort_by,omitempty"`    // Sort field (name, level, member_count, founded_at, activity, ranking)
+	SortOrder   string `json:"sort_order,omitempty"` // Sort order (asc, desc)
 }
 
 // NewSearchGuildsQuery creates a new SearchGuildsQuery
@@ -166,6 +176,25 @@ func (q *SearchGuildsQuery) WithLevelRange(minLevel, maxLevel int) *SearchGuilds
 	return q
 }
 
+// WithLocale adds region and language filters; empty values are not filtered
+func (q *SearchGuildsQuery) WithLocale(region, language string) *SearchGuildsQuery {
+	q.Region = region
+	q.Language = language
+	return q
+}
+
+// WithPlayerLevel limits results to guilds a player of the given level can join
+func (q *SearchGuildsQuery) WithPlayerLevel(playerLevel int) *SearchGuildsQuery {
+	q.PlayerLevel = playerLevel
+	return q
+}
+
+// WithOpenSlots limits results to guilds with at least the given number of open slots
+func (q *SearchGuildsQuery) WithOpenSlots(openSlots int) *SearchGuildsQuery {
+	q.OpenSlots = openSlots
+	return q
+}
+
 // WithPagination adds pagination
 func (q *SearchGuildsQuery) WithPagination(limit, offset int) *SearchGuildsQuery {
 	q.Limit = limit
@@ -194,17 +223,58 @@ func (q *SearchGuildsQuery) Validate() error {
 	if q.MaxLevel > 0 && q.MaxLevel < q.MinLevel {
 		return fmt.Errorf("max level cannot be less than min level")
 	}
+	if q.PlayerLevel < 0 {
+		return fmt.Errorf("player level cannot be negative")
+	}
+	if q.OpenSlots < 0 {
+		return fmt.Errorf("open slots cannot be negative")
+	}
+	return nil
+}
+
+// GetMemberContributionsQuery represents a query for weekly member contributions
+type GetMemberContributionsQuery struct {
+	*cqrs.BaseQuery
+	GuildID   string    `json:"guild_id"`
+	WeekStart time.Time `json:"week_start"` // Any time within the week; normalized to the week start
+}
+
+// NewGetMemberContributionsQuery creates a new GetMemberContributionsQuery for the week containing at
+func NewGetMemberContributionsQuery(guildID string, at time.Time) *GetMemberContributionsQuery {
+	weekStart := domain.ContributionWeekStart(at)
+	return &GetMemberContributionsQuery{
+		BaseQuery: cqrs.NewBaseQuery(
+			GetMemberContributionsQueryType,
+			map[string]interface{}{
+				"guild_id":   guildID,
+				"week_start": weekStart,
+			},
+		),
+		GuildID:   guildID,
+		WeekStart: weekStart,
+	}
+}
+
+// Validate validates the get member contributions query
+func (q *GetMemberContributionsQuery) Validate() error {
+	if q.GuildID == "" {
+		return fmt.Errorf("guild ID cannot be empty")
+	}
+	if q.WeekStart.IsZero() {
+		return fmt.Errorf("week start cannot be empty")
+	}
 	return nil
 }
 
 // GuildQueryResult represents the result of a guild query
 type GuildQueryResult struct {
-	Guild   *projections.GuildView    `json:"guild,omitempty"`
-	Guilds  []*projections.GuildView  `json:"guilds,omitempty"`
-	Members []*projections.MemberView `json:"members,omitempty"`
-	Total   int                       `json:"total,omitempty"`
-	Limit   int                       `json:"limit,omitempty"`
-	Offset  int                       `json:"offset,omitempty"`
+	Guild         *projections.GuildView                `json:"guild,omitempty"`
+	Guilds        []*projections.GuildView              `json:"guilds,omitempty"`
+	Members       []*projections.MemberView             `json:"members,omitempty"`
+	Contributions []*projections.MemberContributionView `json:"contributions,omitempty"`
+	Total         int                                   `json:"total,omitempty"`
+	Limit         int                                   `json:"limit,omitempty"`
+	Offset        int                                   `json:"offset,omitempty"`
 }
 
 // GuildQueryHandler handles guild-related queries
@@ -219,6 +289,7 @@ func NewGuildQueryHandler(readStore cqrs.ReadStore) *GuildQueryHandler {
 		GetGuildQueryType,
 		GetGuildMembersQueryType,
 		SearchGuildsQueryType,
+		GetMemberContributionsQueryType,
 	}
 
 	return &GuildQueryHandler{
@@ -247,6 +318,8 @@ func (h *GuildQueryHandler) Handle(ctx context.Context, query cqrs.Query) (*cqrs
 		result, err = h.handleGetGuildMembers(ctx, q)
 	case *SearchGuildsQuery:
 		result, err = h.handleSearchGuilds(ctx, q)
+	case *GetMemberContributionsQuery:
+		result, err = h.handleGetMemberContributions(ctx, q)
 	default:
 		return &cqrs.QueryResult{
 			Success: false,
@@ -366,30 +439,38 @@ func (h *GuildQueryHandler) handleSearchGuilds(ctx context.Context, query *Searc
 	}, nil
 }
 
+// handleGetMemberContributions handles GetMemberContributionsQuery
+func (h *GuildQueryHandler) handleGetMemberContributions(ctx context.Context, query *GetMemberContributionsQuery) (*GuildQueryResult, error) {
+	contributions, err := projections.GetWeeklyContributions(ctx, h.readStore, query.GuildID, query.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	// Highest contributors first
+	sort.Slice(contributions, func(i, j int) bool {
+		return contributions[i].Contribution().Points() > contributions[j].Contribution().Points()
+	})
+
+	return &GuildQueryResult{
+		Contributions: contributions,
+		Total:         len(contributions),
+	}, nil
+}
+
 // Helper methods for data retrieval and filtering
 
 // getAllMembersForGuild retrieves all member views for a specific guild
 func (h *GuildQueryHandler) getAllMembersForGuild(ctx context.Context, guildID string) ([]*projections.MemberView, error) {
-	// In a real implementation, this would use a proper query to get members by guild ID
-	// For this example, we'll simulate by checking if we can get any member views
-	// Since we don't have a proper query mechanism in the in-memory store, we'll return empty for now
-	// In production, you'd implement proper indexing and querying
+	readModels, err := h.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "MemberView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query member views: %w", err)
+	}
 
 	members := make([]*projections.MemberView, 0)
-
-	// Try to get some sample member IDs (this is a simplified approach)
-	// In a real system, you'd have proper indexing by guild ID
-	sampleUserIDs := []string{"founder123", "member001", "member002"}
-
-	for _, userID := range sampleUserIDs {
-		memberID := fmt.Sprintf("%s:%s", guildID, userID)
-		readModel, err := h.readStore.GetByID(ctx, memberID, "MemberView")
-		if err != nil {
-			// Member doesn't exist, continue
-			continue
-		}
-
-		if memberView, ok := readModel.(*projections.MemberView); ok {
+	for _, readModel := range readModels {
+		if memberView, ok := readModel.(*projections.MemberView); ok && memberView.GuildID == guildID {
 			members = append(members, memberView)
 		}
 	}
@@ -399,14 +480,21 @@ func (h *GuildQueryHandler) getAllMembersForGuild(ctx context.Context, guildID s
 
 // getAllGuilds retrieves all guild views
 func (h *GuildQueryHandler) getAllGuilds(ctx context.Context) ([]*projections.GuildView, error) {
-	// In a real implementation, this would use a proper query to get all guilds
-	// For this example, we'll return empty since we don't have a way to list all items in the in-memory store
-	// In production, you'd implement proper indexing and querying
-
-	guilds := make([]*projections.GuildView, 0)
+	// Stores with the indexes from projections.EnsureGuildSearchIndexes serve this from an index;
+	// the in-memory store scans all read models
+	readModels, err := h.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "GuildView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query guild views: %w", err)
+	}
 
-	// This is a limitation of our simple in-memory implementation
-	// In a real system, you'd have proper indexing and be able to list all guilds
+	guilds := make([]*projections.GuildView, 0, len(readModels))
+	for _, readModel := range readModels {
+		if guildView, ok := readModel.(*projections.GuildView); ok {
+			guilds = append(guilds, guildView)
+		}
+	}
 
 	return guilds, nil
 }
@@ -497,6 +585,22 @@ func (h *GuildQueryHandler) filterGuilds(guilds []*projections.GuildView, query
 			continue
 		}
 
+		// Apply locale filters
+		if query.Region != "" && !strings.EqualFold(guild.Region, query.Region) {
+			continue
+		}
+		if query.Language != "" && !strings.EqualFold(guild.Language, query.Language) {
+			continue
+		}
+
+		// Apply joinability filters
+		if query.PlayerLevel > 0 && guild.MinLevel > query.PlayerLevel {
+			continue
+		}
+		if query.OpenSlots > 0 && guild.OpenSlots() < query.OpenSlots {
+			continue
+		}
+
 		filtered = append(filtered, guild)
 	}
 
@@ -575,6 +679,25 @@ func (h *GuildQueryHandler) sortGuilds(guilds []*projections.GuildView, sortBy,
 				}
 			}
 		}
+	case "activity":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sortOrder == "desc" {
+				return sorted[i].LastActivityAt.After(sorted[j].LastActivityAt)
+			}
+			return sorted[i].LastActivityAt.Before(sorted[j].LastActivityAt)
+		})
+	case "ranking":
+		// Guild ranking is level first, then experience within a level
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if a.Level != b.Level {
+				return (a.Level > b.Level) == (sortOrder == "desc")
+			}
+			if a.Experience != b.Experience {
+				return (a.Experience > b.Experience) == (sortOrder == "desc")
+			}
+			return false
+		})
 	}
 
 	return sorted
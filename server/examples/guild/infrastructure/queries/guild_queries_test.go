@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func handleGuildQuery(t *testing.T, handler *GuildQueryHandler, query cqrs.Query) *GuildQueryResult {
+	t.Helper()
+	result, err := handler.Handle(context.Background(), query)
+	require.NoError(t, err)
+	require.True(t, result.Success, "%v", result.Error)
+	data, ok := result.Data.(*GuildQueryResult)
+	require.True(t, ok)
+	return data
+}
+
+func TestGuildQueryHandler_GetMemberContributions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	week := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		guildID, userID string
+		weekStart       time.Time
+		mined           int64
+		transports      int
+	}{
+		{"guild-1", "alice", week, 30, 0},
+		{"guild-1", "bob", week, 0, 2},
+		{"guild-1", "carol", week, 150, 0},
+		{"guild-1", "dave", week.AddDate(0, 0, -7), 999, 0}, // previous week
+		{"guild-2", "erin", week, 999, 0},                   // other guild
+	} {
+		view := projections.NewMemberContributionView(c.guildID, c.userID, c.weekStart)
+		view.MineralsMined = c.mined
+		view.TransportsCompleted = c.transports
+		require.NoError(t, store.Save(ctx, view))
+	}
+	handler := NewGuildQueryHandler(store)
+
+	// Act: any time within the week selects that week
+	result := handleGuildQuery(t, handler, NewGetMemberContributionsQuery("guild-1", week.Add(80*time.Hour)))
+
+	// Assert
+	require.Len(t, result.Contributions, 3)
+	assert.Equal(t, "bob", result.Contributions[0].UserID) // 2 transports = 200 points
+	assert.Equal(t, "carol", result.Contributions[1].UserID)
+	assert.Equal(t, "alice", result.Contributions[2].UserID)
+	assert.Equal(t, 3, result.Total)
+}
@@ -0,0 +1,338 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+)
+
+// Recommendation query type constants
+const (
+	GetRecommendedGuildsQueryType = "GetRecommendedGuilds"
+)
+
+// GetRecommendedGuildsQuery represents a query for guilds suggested to a player
+type GetRecommendedGuildsQuery struct {
+	*cqrs.BaseQuery
+	PlayerID string `json:"player_id"`
+	Limit    int    `json:"limit,omitempty"` // Maximum number of suggestions
+}
+
+// NewGetRecommendedGuildsQuery creates a new GetRecommendedGuildsQuery
+func NewGetRecommendedGuildsQuery(playerID string) *GetRecommendedGuildsQuery {
+	return &GetRecommendedGuildsQuery{
+		BaseQuery: cqrs.NewBaseQuery(
+			GetRecommendedGuildsQueryType,
+			map[string]interface{}{"player_id": playerID},
+		),
+		PlayerID: playerID,
+		Limit:    10, // Default limit
+	}
+}
+
+// WithLimit sets the maximum number of suggestions
+func (q *GetRecommendedGuildsQuery) WithLimit(limit int) *GetRecommendedGuildsQuery {
+	q.Limit = limit
+	return q
+}
+
+// Validate validates the get recommended guilds query
+func (q *GetRecommendedGuildsQuery) Validate() error {
+	if q.PlayerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	if q.Limit < 0 || q.Limit > 50 {
+		return fmt.Errorf("limit must be between 0 and 50")
+	}
+	return nil
+}
+
+// RecommendationPlayer is the player profile used to score guilds
+type RecommendationPlayer struct {
+	PlayerID    string   `json:"player_id"`
+	Level       int      `json:"level"`
+	PeakHourUTC int      `json:"peak_hour_utc"` // Hour of day the player is usually online, -1 if unknown
+	FriendIDs   []string `json:"friend_ids"`
+}
+
+// RecommendationPlayerSource provides player profiles for recommendations
+type RecommendationPlayerSource interface {
+	GetRecommendationPlayer(ctx context.Context, playerID string) (*RecommendationPlayer, error)
+}
+
+// GuildPresenceSource provides presence data aggregated per guild
+type GuildPresenceSource interface {
+	// GuildPeakHourUTC returns the hour of day most guild members are online
+	GuildPeakHourUTC(ctx context.Context, guildID string) (int, bool)
+}
+
+// RecommendationWeights controls how much each signal contributes to the score
+type RecommendationWeights struct {
+	Level    float64 `json:"level"`
+	Timezone float64 `json:"timezone"`
+	Friends  float64 `json:"friends"`
+	Activity float64 `json:"activity"`
+}
+
+// DefaultRecommendationWeights returns the default recommendation weights
+func DefaultRecommendationWeights() RecommendationWeights {
+	return RecommendationWeights{
+		Level:    0.3,
+		Timezone: 0.2,
+		Friends:  0.3,
+		Activity: 0.2,
+	}
+}
+
+// RecommendedGuild is a scored guild suggestion
+type RecommendedGuild struct {
+	Guild       *projections.GuildView `json:"guild"`
+	Score       float64                `json:"score"`
+	FriendCount int                    `json:"friend_count"`
+	Reasons     []string               `json:"reasons"`
+}
+
+// RecommendedGuildsQueryResult represents the result of a recommendation query
+type RecommendedGuildsQueryResult struct {
+	PlayerID    string              `json:"player_id"`
+	Suggestions []*RecommendedGuild `json:"suggestions"`
+}
+
+const (
+	// friendsForFullScore is the number of friends in a guild that gives the maximum friend score
+	friendsForFullScore = 3
+	// levelGapForZeroScore is the level gap above the guild minimum at which level proximity scores zero
+	levelGapForZeroScore = 30
+)
+
+// GuildRecommendationHandler scores guilds for a player from read models and presence data
+type GuildRecommendationHandler struct {
+	*cqrs.BaseQueryHandler
+	readStore cqrs.ReadStore
+	players   RecommendationPlayerSource
+	presence  GuildPresenceSource
+	weights   RecommendationWeights
+	now       func() time.Time
+}
+
+// NewGuildRecommendationHandler creates a new GuildRecommendationHandler.
+// presence may be nil, in which case timezone scores are neutral.
+func NewGuildRecommendationHandler(readStore cqrs.ReadStore, players RecommendationPlayerSource, presence GuildPresenceSource) *GuildRecommendationHandler {
+	supportedQueries := []string{
+		GetRecommendedGuildsQueryType,
+	}
+
+	return &GuildRecommendationHandler{
+		BaseQueryHandler: cqrs.NewBaseQueryHandler("GuildRecommendationHandler", supportedQueries),
+		readStore:        readStore,
+		players:          players,
+		presence:         presence,
+		weights:          DefaultRecommendationWeights(),
+		now:              time.Now,
+	}
+}
+
+// WithWeights overrides the default recommendation weights
+func (h *GuildRecommendationHandler) WithWeights(weights RecommendationWeights) *GuildRecommendationHandler {
+	h.weights = weights
+	return h
+}
+
+// Handle handles the incoming query
+func (h *GuildRecommendationHandler) Handle(ctx context.Context, query cqrs.Query) (*cqrs.QueryResult, error) {
+	// Validate query
+	if err := query.Validate(); err != nil {
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   fmt.Errorf("query validation failed: %w", err),
+		}, nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch q := query.(type) {
+	case *GetRecommendedGuildsQuery:
+		result, err = h.handleGetRecommendedGuilds(ctx, q)
+	default:
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   fmt.Errorf("unsupported query type: %T", query),
+		}, nil
+	}
+
+	if err != nil {
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   err,
+		}, nil
+	}
+
+	return &cqrs.QueryResult{
+		Success: true,
+		Data:    result,
+	}, nil
+}
+
+// handleGetRecommendedGuilds handles GetRecommendedGuildsQuery
+func (h *GuildRecommendationHandler) handleGetRecommendedGuilds(ctx context.Context, query *GetRecommendedGuildsQuery) (*RecommendedGuildsQueryResult, error) {
+	player, err := h.players.GetRecommendationPlayer(ctx, query.PlayerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load player %s: %w", query.PlayerID, err)
+	}
+
+	guilds, err := h.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "GuildView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query guild views: %w", err)
+	}
+
+	friendCounts, joined, err := h.scanMembers(ctx, player)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.now()
+	suggestions := make([]*RecommendedGuild, 0)
+	for _, readModel := range guilds {
+		guild, ok := readModel.(*projections.GuildView)
+		if !ok || !h.isEligible(guild, player, joined) {
+			continue
+		}
+		suggestions = append(suggestions, h.score(ctx, guild, player, friendCounts[guild.GuildID], now))
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if query.Limit > 0 && len(suggestions) > query.Limit {
+		suggestions = suggestions[:query.Limit]
+	}
+
+	return &RecommendedGuildsQueryResult{
+		PlayerID:    player.PlayerID,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// scanMembers counts the player's friends per guild and collects the guilds the player already belongs to
+func (h *GuildRecommendationHandler) scanMembers(ctx context.Context, player *RecommendationPlayer) (map[string]int, map[string]bool, error) {
+	members, err := h.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "MemberView"},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query member views: %w", err)
+	}
+
+	friends := make(map[string]bool, len(player.FriendIDs))
+	for _, friendID := range player.FriendIDs {
+		friends[friendID] = true
+	}
+
+	friendCounts := make(map[string]int)
+	joined := make(map[string]bool)
+	for _, readModel := range members {
+		member, ok := readModel.(*projections.MemberView)
+		if !ok || !member.IsActive() {
+			continue
+		}
+		if member.UserID == player.PlayerID {
+			joined[member.GuildID] = true
+			continue
+		}
+		if friends[member.UserID] {
+			friendCounts[member.GuildID]++
+		}
+	}
+	return friendCounts, joined, nil
+}
+
+// isEligible reports whether the player could join the guild
+func (h *GuildRecommendationHandler) isEligible(guild *projections.GuildView, player *RecommendationPlayer, joined map[string]bool) bool {
+	if !guild.IsActive() || !guild.IsPublic || guild.OpenSlots() == 0 {
+		return false
+	}
+	if guild.MinLevel > player.Level {
+		return false
+	}
+	return !joined[guild.GuildID]
+}
+
+// score combines the weighted signals into a single recommendation score
+func (h *GuildRecommendationHandler) score(ctx context.Context, guild *projections.GuildView, player *RecommendationPlayer, friendCount int, now time.Time) *RecommendedGuild {
+	reasons := make([]string, 0)
+
+	levelScore := levelProximityScore(player.Level, guild.MinLevel)
+	if levelScore >= 0.8 {
+		reasons = append(reasons, "level_match")
+	}
+
+	timezoneScore := 0.5
+	if h.presence != nil && player.PeakHourUTC >= 0 {
+		if peakHour, ok := h.presence.GuildPeakHourUTC(ctx, guild.GuildID); ok {
+			timezoneScore = timezoneScoreFor(player.PeakHourUTC, peakHour)
+			if timezoneScore >= 0.75 {
+				reasons = append(reasons, "same_timezone")
+			}
+		}
+	}
+
+	friendScore := float64(friendCount) / friendsForFullScore
+	if friendScore > 1 {
+		friendScore = 1
+	}
+	if friendCount > 0 {
+		reasons = append(reasons, "friends_in_guild")
+	}
+
+	activityScore := 0.0
+	if !guild.LastActivityAt.IsZero() {
+		days := now.Sub(guild.LastActivityAt).Hours() / 24
+		if days < 0 {
+			days = 0
+		}
+		activityScore = 1 / (1 + days)
+		if days < 1 {
+			reasons = append(reasons, "recently_active")
+		}
+	}
+
+	score := h.weights.Level*levelScore +
+		h.weights.Timezone*timezoneScore +
+		h.weights.Friends*friendScore +
+		h.weights.Activity*activityScore
+
+	return &RecommendedGuild{
+		Guild:       guild,
+		Score:       score,
+		FriendCount: friendCount,
+		Reasons:     reasons,
+	}
+}
+
+// levelProximityScore scores how close the player's level is to the guild's minimum level
+func levelProximityScore(playerLevel, minLevel int) float64 {
+	gap := playerLevel - minLevel
+	if gap < 0 || gap >= levelGapForZeroScore {
+		return 0
+	}
+	return 1 - float64(gap)/levelGapForZeroScore
+}
+
+// timezoneScoreFor scores the circular distance between two peak hours
+func timezoneScoreFor(playerHour, guildHour int) float64 {
+	diff := playerHour - guildHour
+	if diff < 0 {
+		diff = -diff
+	}
+	diff %= 24
+	if diff > 12 {
+		diff = 24 - diff
+	}
+	return 1 - float64(diff)/12
+}
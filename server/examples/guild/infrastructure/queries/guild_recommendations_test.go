@@ -0,0 +1,204 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlayers map[string]*RecommendationPlayer
+
+func (p fakePlayers) GetRecommendationPlayer(ctx context.Context, playerID string) (*RecommendationPlayer, error) {
+	player, ok := p[playerID]
+	if !ok {
+		return nil, errors.New("player not found")
+	}
+	return player, nil
+}
+
+type fakePresence map[string]int
+
+func (p fakePresence) GuildPeakHourUTC(ctx context.Context, guildID string) (int, bool) {
+	hour, ok := p[guildID]
+	return hour, ok
+}
+
+func TestLevelProximityScore(t *testing.T) {
+	tests := []struct {
+		name                  string
+		playerLevel, minLevel int
+		want                  float64
+	}{
+		{"exact minimum", 10, 10, 1},
+		{"small gap", 16, 10, 0.8},
+		{"half way", 25, 10, 0.5},
+		{"gap at the cutoff", 40, 10, 0},
+		{"gap beyond the cutoff", 70, 10, 0},
+		{"below the minimum", 9, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, levelProximityScore(tt.playerLevel, tt.minLevel), 1e-9)
+		})
+	}
+}
+
+func TestTimezoneScoreFor(t *testing.T) {
+	tests := []struct {
+		name                  string
+		playerHour, guildHour int
+		want                  float64
+	}{
+		{"same hour", 20, 20, 1},
+		{"three hours apart", 20, 17, 0.75},
+		{"wraps around midnight", 23, 2, 0.75},
+		{"wraps the other way", 1, 22, 0.75},
+		{"opposite side of the world", 0, 12, 0},
+		{"six hours apart", 6, 12, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, timezoneScoreFor(tt.playerHour, tt.guildHour), 1e-9)
+		})
+	}
+}
+
+func TestGuildRecommendationHandler_Score(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	player := &RecommendationPlayer{PlayerID: "p1", Level: 10, PeakHourUTC: 20}
+
+	tests := []struct {
+		name        string
+		minLevel    int
+		peakHour    int // -1 leaves the guild without presence data
+		friends     int
+		lastActive  time.Duration // zero leaves LastActivityAt unset
+		wantScore   float64
+		wantReasons []string
+	}{
+		{
+			name: "perfect match", minLevel: 10, peakHour: 20, friends: 3, lastActive: time.Hour,
+			// 0.3*1 + 0.2*1 + 0.3*1 + 0.2*(1/(1+1/24))
+			wantScore:   0.8 + 0.2/(1+1.0/24),
+			wantReasons: []string{"level_match", "same_timezone", "friends_in_guild", "recently_active"},
+		},
+		{
+			name: "friend score is capped", minLevel: 10, peakHour: 20, friends: 7, lastActive: time.Hour,
+			wantScore:   0.8 + 0.2/(1+1.0/24),
+			wantReasons: []string{"level_match", "same_timezone", "friends_in_guild", "recently_active"},
+		},
+		{
+			name: "no presence data is neutral", minLevel: 10, peakHour: -1, friends: 0, lastActive: 0,
+			// 0.3*1 + 0.2*0.5
+			wantScore:   0.4,
+			wantReasons: []string{"level_match"},
+		},
+		{
+			name: "far timezone and stale guild", minLevel: 1, peakHour: 8, friends: 1, lastActive: 72 * time.Hour,
+			// 0.3*(1-9/30) + 0.2*0 + 0.3*(1/3) + 0.2*(1/4)
+			wantScore:   0.21 + 0.1 + 0.05,
+			wantReasons: []string{"friends_in_guild"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			presence := fakePresence{}
+			if tt.peakHour >= 0 {
+				presence["guild-1"] = tt.peakHour
+			}
+			handler := NewGuildRecommendationHandler(cqrs.NewInMemoryReadStore(), fakePlayers{}, presence)
+			guild := projections.NewGuildView("guild-1")
+			guild.MinLevel = tt.minLevel
+			if tt.lastActive > 0 {
+				guild.LastActivityAt = now.Add(-tt.lastActive)
+			}
+
+			// Act
+			suggestion := handler.score(context.Background(), guild, player, tt.friends, now)
+
+			// Assert
+			assert.InDelta(t, tt.wantScore, suggestion.Score, 1e-9)
+			assert.Equal(t, tt.wantReasons, suggestion.Reasons)
+			assert.Equal(t, tt.friends, suggestion.FriendCount)
+		})
+	}
+}
+
+func TestGuildRecommendationHandler_RanksEligibleGuilds(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	store := cqrs.NewInMemoryReadStore()
+	for _, g := range []struct {
+		id                string
+		minLevel, members int
+		public            bool
+		status            string
+		lastActive        time.Duration
+	}{
+		{"guild-friends", 10, 10, true, "Active", time.Hour},
+		{"guild-quiet", 10, 10, true, "Active", 240 * time.Hour},
+		{"guild-mine", 1, 10, true, "Active", time.Hour},      // player is already a member
+		{"guild-private", 10, 10, false, "Active", time.Hour}, // not public
+		{"guild-full", 10, 50, true, "Active", time.Hour},     // no open slots
+		{"guild-veteran", 20, 10, true, "Active", time.Hour},  // above the player's level
+		{"guild-disbanded", 10, 10, true, "Disbanded", time.Hour},
+	} {
+		view := projections.NewGuildView(g.id)
+		view.MinLevel = g.minLevel
+		view.MemberCount = g.members
+		view.IsPublic = g.public
+		view.Status = g.status
+		view.LastActivityAt = now.Add(-g.lastActive)
+		require.NoError(t, store.Save(ctx, view))
+	}
+	for _, m := range []struct{ guildID, userID, status string }{
+		{"guild-friends", "f1", "Active"},
+		{"guild-friends", "f2", "Active"},
+		{"guild-quiet", "f3", "Kicked"}, // former members don't count
+		{"guild-mine", "p1", "Active"},
+	} {
+		member := projections.NewMemberView(m.guildID, m.userID)
+		member.Status = m.status
+		require.NoError(t, store.Save(ctx, member))
+	}
+
+	players := fakePlayers{"p1": {PlayerID: "p1", Level: 12, PeakHourUTC: -1, FriendIDs: []string{"f1", "f2", "f3"}}}
+	handler := NewGuildRecommendationHandler(store, players, nil)
+	handler.now = func() time.Time { return now }
+
+	// Act
+	result, err := handler.Handle(ctx, NewGetRecommendedGuildsQuery("p1"))
+	require.NoError(t, err)
+	limited, err := handler.Handle(ctx, NewGetRecommendedGuildsQuery("p1").WithLimit(1))
+	require.NoError(t, err)
+	unknown, err := handler.Handle(ctx, NewGetRecommendedGuildsQuery("nobody"))
+	require.NoError(t, err)
+
+	// Assert
+	require.True(t, result.Success, "%v", result.Error)
+	suggestions := result.Data.(*RecommendedGuildsQueryResult).Suggestions
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "guild-friends", suggestions[0].Guild.GuildID)
+	assert.Equal(t, 2, suggestions[0].FriendCount)
+	assert.Equal(t, "guild-quiet", suggestions[1].Guild.GuildID)
+	assert.Equal(t, 0, suggestions[1].FriendCount)
+	assert.Greater(t, suggestions[0].Score, suggestions[1].Score)
+
+	require.True(t, limited.Success)
+	assert.Len(t, limited.Data.(*RecommendedGuildsQueryResult).Suggestions, 1)
+
+	assert.False(t, unknown.Success)
+	assert.Error(t, unknown.Error)
+}
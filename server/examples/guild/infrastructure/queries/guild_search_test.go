@@ -0,0 +1,144 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSearchHandler stores four guilds with distinct locales, levels, capacity and activity
+func newSearchHandler(t *testing.T) *GuildQueryHandler {
+	t.Helper()
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	for _, g := range []struct {
+		id, name, tag, region, language string
+		level, minLevel                 int
+		experience                      int64
+		members, maxMembers             int
+		public                          bool
+		lastActive                      time.Duration
+	}{
+		{"guild-a", "Alpha Wolves", "WOLF", "EU", "de", 5, 10, 16000, 48, 50, true, -2 * time.Hour},
+		{"guild-b", "Bravo Knights", "KNT", "EU", "en", 5, 1, 20000, 10, 50, true, -10 * time.Minute},
+		{"guild-c", "Charlie Mages", "MAGE", "NA", "en", 2, 1, 1500, 5, 50, false, -48 * time.Hour},
+		{"guild-d", "Delta Knights", "DLT", "na", "EN", 8, 30, 70000, 60, 60, true, -time.Hour},
+	} {
+		view := projections.NewGuildView(g.id)
+		view.Name = g.name
+		view.Tag = g.tag
+		view.Status = "Active"
+		view.Region = g.region
+		view.Language = g.language
+		view.Level = g.level
+		view.MinLevel = g.minLevel
+		view.Experience = g.experience
+		view.MemberCount = g.members
+		view.ActiveMemberCount = g.members
+		view.MaxMembers = g.maxMembers
+		view.IsPublic = g.public
+		view.LastActivityAt = now.Add(g.lastActive)
+		view.UpdateSearchableText()
+		require.NoError(t, store.Save(ctx, view))
+	}
+
+	return NewGuildQueryHandler(store)
+}
+
+func guildIDs(guilds []*projections.GuildView) []string {
+	ids := make([]string, 0, len(guilds))
+	for _, guild := range guilds {
+		ids = append(ids, guild.GuildID)
+	}
+	return ids
+}
+
+func TestGuildQueryHandler_SearchGuildsFilters(t *testing.T) {
+	handler := newSearchHandler(t)
+
+	tests := []struct {
+		name  string
+		query *SearchGuildsQuery
+		want  []string
+	}{
+		{"no filters", NewSearchGuildsQuery(), []string{"guild-a", "guild-b", "guild-c", "guild-d"}},
+		{"search text matches name or tag", NewSearchGuildsQuery().WithSearchText("knights"), []string{"guild-b", "guild-d"}},
+		{"search text is case insensitive", NewSearchGuildsQuery().WithSearchText("wolf"), []string{"guild-a"}},
+		{"public only", NewSearchGuildsQuery().WithPublicFilter(true), []string{"guild-a", "guild-b", "guild-d"}},
+		{"level range", NewSearchGuildsQuery().WithLevelRange(3, 6), []string{"guild-a", "guild-b"}},
+		{"region ignores case", NewSearchGuildsQuery().WithLocale("NA", ""), []string{"guild-c", "guild-d"}},
+		{"region and language", NewSearchGuildsQuery().WithLocale("EU", "en"), []string{"guild-b"}},
+		{"language only", NewSearchGuildsQuery().WithLocale("", "en"), []string{"guild-b", "guild-c", "guild-d"}},
+		{"player level meets minimum", NewSearchGuildsQuery().WithPlayerLevel(10), []string{"guild-a", "guild-b", "guild-c"}},
+		{"open slots", NewSearchGuildsQuery().WithOpenSlots(3), []string{"guild-b", "guild-c"}},
+		{"joinable for a level 5 player in EU", NewSearchGuildsQuery().WithLocale("EU", "").WithPlayerLevel(5).WithOpenSlots(1), []string{"guild-b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := handleGuildQuery(t, handler, tt.query)
+
+			// Assert
+			assert.Equal(t, tt.want, guildIDs(result.Guilds))
+			assert.Equal(t, len(tt.want), result.Total)
+		})
+	}
+}
+
+func TestGuildQueryHandler_SearchGuildsSorting(t *testing.T) {
+	handler := newSearchHandler(t)
+
+	tests := []struct {
+		sortBy, sortOrder string
+		want              []string
+	}{
+		{"name", "desc", []string{"guild-d", "guild-c", "guild-b", "guild-a"}},
+		{"member_count", "desc", []string{"guild-d", "guild-a", "guild-b", "guild-c"}},
+		{"activity", "desc", []string{"guild-b", "guild-d", "guild-a", "guild-c"}},
+		{"activity", "asc", []string{"guild-c", "guild-a", "guild-d", "guild-b"}},
+		{"ranking", "desc", []string{"guild-d", "guild-b", "guild-a", "guild-c"}}, // level, then experience
+		{"ranking", "asc", []string{"guild-c", "guild-a", "guild-b", "guild-d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy+" "+tt.sortOrder, func(t *testing.T) {
+			// Act
+			result := handleGuildQuery(t, handler, NewSearchGuildsQuery().WithSorting(tt.sortBy, tt.sortOrder))
+
+			// Assert
+			assert.Equal(t, tt.want, guildIDs(result.Guilds))
+		})
+	}
+}
+
+func TestGuildQueryHandler_SearchGuildsPaginationAndValidation(t *testing.T) {
+	// Arrange
+	handler := newSearchHandler(t)
+
+	// Act
+	page := handleGuildQuery(t, handler, NewSearchGuildsQuery().WithSorting("ranking", "desc").WithPagination(2, 1))
+	beyond := handleGuildQuery(t, handler, NewSearchGuildsQuery().WithPagination(2, 10))
+	invalid, err := handler.Handle(context.Background(), NewSearchGuildsQuery().WithOpenSlots(-1))
+
+	// Assert
+	assert.Equal(t, []string{"guild-b", "guild-a"}, guildIDs(page.Guilds))
+	assert.Equal(t, 4, page.Total)
+	assert.Equal(t, 2, page.Limit)
+	assert.Equal(t, 1, page.Offset)
+
+	assert.Empty(t, beyond.Guilds)
+	assert.Equal(t, 4, beyond.Total)
+
+	require.NoError(t, err)
+	assert.False(t, invalid.Success)
+	assert.Error(t, invalid.Error)
+}
@@ -0,0 +1,227 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+)
+
+// Transport query type constants
+const (
+	ListOpenRecruitmentsQueryType = "ListOpenRecruitments"
+)
+
+// ListOpenRecruitmentsQuery represents a query for the cross-guild transport recruitment board
+type ListOpenRecruitmentsQuery struct {
+	*cqrs.BaseQuery
+	GuildID          string        `json:"guild_id,omitempty"`           // Only recruitments of this guild
+	MinCargoValue    int64         `json:"min_cargo_value,omitempty"`    // Minimum total cargo value
+	MinTimeRemaining time.Duration `json:"min_time_remaining,omitempty"` // Skip recruitments closing sooner than this
+	Limit            int           `json:"limit,omitempty"`              // Limit number of results
+	Offset           int           `json:"offset,omitempty"`             // Offset for pagination
+	SortBy           string        `json:"sort_by,omitempty"`            // Sort field (expires_at, cargo_value, created_at)
+	SortOrder        string        `json:"sort_order,omitempty"`         // Sort order (asc, desc)
+}
+
+// NewListOpenRecruitmentsQuery creates a new ListOpenRecruitmentsQuery
+func NewListOpenRecruitmentsQuery() *ListOpenRecruitmentsQuery {
+	return &ListOpenRecruitmentsQuery{
+		BaseQuery: cqrs.NewBaseQuery(
+			ListOpenRecruitmentsQueryType,
+			map[string]interface{}{},
+		),
+		Limit:     20, // Default limit
+		Offset:    0,  // Default offset
+		SortBy:    "expires_at",
+		SortOrder: "asc",
+	}
+}
+
+// WithGuild adds guild filter
+func (q *ListOpenRecruitmentsQuery) WithGuild(guildID string) *ListOpenRecruitmentsQuery {
+	q.GuildID = guildID
+	return q
+}
+
+// WithMinCargoValue adds minimum cargo value filter
+func (q *ListOpenRecruitmentsQuery) WithMinCargoValue(value int64) *ListOpenRecruitmentsQuery {
+	q.MinCargoValue = value
+	return q
+}
+
+// WithMinTimeRemaining adds minimum time remaining filter
+func (q *ListOpenRecruitmentsQuery) WithMinTimeRemaining(remaining time.Duration) *ListOpenRecruitmentsQuery {
+	q.MinTimeRemaining = remaining
+	return q
+}
+
+// WithPagination sets pagination parameters
+func (q *ListOpenRecruitmentsQuery) WithPagination(limit, offset int) *ListOpenRecruitmentsQuery {
+	q.Limit = limit
+	q.Offset = offset
+	return q
+}
+
+// WithSorting sets sorting parameters
+func (q *ListOpenRecruitmentsQuery) WithSorting(sortBy, sortOrder string) *ListOpenRecruitmentsQuery {
+	q.SortBy = sortBy
+	q.SortOrder = sortOrder
+	return q
+}
+
+// Validate validates the list open recruitments query
+func (q *ListOpenRecruitmentsQuery) Validate() error {
+	if q.Limit < 0 || q.Limit > 100 {
+		return fmt.Errorf("limit must be between 0 and 100")
+	}
+	if q.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	if q.MinCargoValue < 0 || q.MinTimeRemaining < 0 {
+		return fmt.Errorf("filters cannot be negative")
+	}
+	switch q.SortBy {
+	case "", "expires_at", "cargo_value", "created_at":
+	default:
+		return fmt.Errorf("unsupported sort field: %s", q.SortBy)
+	}
+	return nil
+}
+
+// TransportRecruitmentQueryResult represents the result of a recruitment board query
+type TransportRecruitmentQueryResult struct {
+	Recruitments []*projections.TransportRecruitmentView `json:"recruitments"`
+	Total        int                                     `json:"total"`
+	Limit        int                                     `json:"limit"`
+	Offset       int                                     `json:"offset"`
+}
+
+// TransportRecruitmentQueryHandler serves the transport recruitment board
+type TransportRecruitmentQueryHandler struct {
+	*cqrs.BaseQueryHandler
+	readStore cqrs.ReadStore
+	now       func() time.Time
+}
+
+// NewTransportRecruitmentQueryHandler creates a new TransportRecruitmentQueryHandler
+func NewTransportRecruitmentQueryHandler(readStore cqrs.ReadStore) *TransportRecruitmentQueryHandler {
+	supportedQueries := []string{
+		ListOpenRecruitmentsQueryType,
+	}
+
+	return &TransportRecruitmentQueryHandler{
+		BaseQueryHandler: cqrs.NewBaseQueryHandler("TransportRecruitmentQueryHandler", supportedQueries),
+		readStore:        readStore,
+		now:              time.Now,
+	}
+}
+
+// Handle handles the incoming query
+func (h *TransportRecruitmentQueryHandler) Handle(ctx context.Context, query cqrs.Query) (*cqrs.QueryResult, error) {
+	// Validate query
+	if err := query.Validate(); err != nil {
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   fmt.Errorf("query validation failed: %w", err),
+		}, nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch q := query.(type) {
+	case *ListOpenRecruitmentsQuery:
+		result, err = h.handleListOpenRecruitments(ctx, q)
+	default:
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   fmt.Errorf("unsupported query type: %T", query),
+		}, nil
+	}
+
+	if err != nil {
+		return &cqrs.QueryResult{
+			Success: false,
+			Error:   err,
+		}, nil
+	}
+
+	return &cqrs.QueryResult{
+		Success: true,
+		Data:    result,
+	}, nil
+}
+
+// handleListOpenRecruitments handles ListOpenRecruitmentsQuery
+func (h *TransportRecruitmentQueryHandler) handleListOpenRecruitments(ctx context.Context, query *ListOpenRecruitmentsQuery) (*TransportRecruitmentQueryResult, error) {
+	readModels, err := h.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "TransportRecruitmentView"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recruitment views: %w", err)
+	}
+
+	now := h.now()
+	recruitments := make([]*projections.TransportRecruitmentView, 0)
+	for _, readModel := range readModels {
+		view, ok := readModel.(*projections.TransportRecruitmentView)
+		if !ok || !view.IsOpen(now) {
+			continue
+		}
+		if query.GuildID != "" && view.GuildID != query.GuildID {
+			continue
+		}
+		if view.CargoValue < query.MinCargoValue {
+			continue
+		}
+		if view.TimeRemaining(now) < query.MinTimeRemaining {
+			continue
+		}
+		recruitments = append(recruitments, view)
+	}
+
+	h.sortRecruitments(recruitments, query.SortBy, query.SortOrder)
+
+	// Apply pagination
+	total := len(recruitments)
+	start := query.Offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if query.Limit > 0 && start+query.Limit < total {
+		end = start + query.Limit
+	}
+
+	return &TransportRecruitmentQueryResult{
+		Recruitments: recruitments[start:end],
+		Total:        total,
+		Limit:        query.Limit,
+		Offset:       query.Offset,
+	}, nil
+}
+
+// sortRecruitments sorts recruitments by the given field and order
+func (h *TransportRecruitmentQueryHandler) sortRecruitments(recruitments []*projections.TransportRecruitmentView, sortBy, sortOrder string) {
+	less := func(a, b *projections.TransportRecruitmentView) bool {
+		switch sortBy {
+		case "cargo_value":
+			return a.CargoValue < b.CargoValue
+		case "created_at":
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			return a.ExpiresAt.Before(b.ExpiresAt)
+		}
+	}
+
+	sort.SliceStable(recruitments, func(i, j int) bool {
+		if sortOrder == "desc" {
+			return less(recruitments[j], recruitments[i])
+		}
+		return less(recruitments[i], recruitments[j])
+	})
+}
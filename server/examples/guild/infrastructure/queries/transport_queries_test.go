@@ -0,0 +1,110 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cqrs"
+	"defense-allies-server/examples/guild/infrastructure/projections"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecruitmentBoard(t *testing.T, now time.Time) *TransportRecruitmentQueryHandler {
+	t.Helper()
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	for _, r := range []struct {
+		id, guildID, status   string
+		createdAgo, expiresIn time.Duration
+		cargoValue            int64
+	}{
+		{"rec-a", "guild-1", "Open", 50 * time.Minute, 10 * time.Minute, 300},
+		{"rec-b", "guild-1", "Open", 10 * time.Minute, 50 * time.Minute, 100},
+		{"rec-c", "guild-2", "Open", 30 * time.Minute, 30 * time.Minute, 500},
+		{"rec-full", "guild-1", "Full", time.Minute, time.Hour, 900},
+		{"rec-expired", "guild-2", "Open", 2 * time.Hour, -time.Minute, 900},
+		{"rec-started", "guild-2", "Started", time.Hour, time.Hour, 900},
+	} {
+		view := projections.NewTransportRecruitmentView(r.id)
+		view.GuildID = r.guildID
+		view.Status = r.status
+		view.CreatedAt = now.Add(-r.createdAgo)
+		view.ExpiresAt = now.Add(r.expiresIn)
+		view.CargoValue = r.cargoValue
+		require.NoError(t, store.Save(ctx, view))
+	}
+
+	handler := NewTransportRecruitmentQueryHandler(store)
+	handler.now = func() time.Time { return now }
+	return handler
+}
+
+func listRecruitments(t *testing.T, handler *TransportRecruitmentQueryHandler, query *ListOpenRecruitmentsQuery) ([]string, int) {
+	t.Helper()
+	result, err := handler.Handle(context.Background(), query)
+	require.NoError(t, err)
+	require.True(t, result.Success, "%v", result.Error)
+	board := result.Data.(*TransportRecruitmentQueryResult)
+	ids := make([]string, len(board.Recruitments))
+	for i, view := range board.Recruitments {
+		ids[i] = view.RecruitmentID
+	}
+	return ids, board.Total
+}
+
+func TestListOpenRecruitments_FiltersAndSorts(t *testing.T) {
+	handler := newRecruitmentBoard(t, time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		query *ListOpenRecruitmentsQuery
+		want  []string
+	}{
+		{"default closes soonest first", NewListOpenRecruitmentsQuery(), []string{"rec-a", "rec-c", "rec-b"}},
+		{"guild filter", NewListOpenRecruitmentsQuery().WithGuild("guild-1"), []string{"rec-a", "rec-b"}},
+		{"minimum cargo value", NewListOpenRecruitmentsQuery().WithMinCargoValue(300), []string{"rec-a", "rec-c"}},
+		{"minimum time remaining", NewListOpenRecruitmentsQuery().WithMinTimeRemaining(30 * time.Minute), []string{"rec-c", "rec-b"}},
+		{"cargo value descending", NewListOpenRecruitmentsQuery().WithSorting("cargo_value", "desc"), []string{"rec-c", "rec-a", "rec-b"}},
+		{"cargo value ascending", NewListOpenRecruitmentsQuery().WithSorting("cargo_value", "asc"), []string{"rec-b", "rec-a", "rec-c"}},
+		{"newest first", NewListOpenRecruitmentsQuery().WithSorting("created_at", "desc"), []string{"rec-b", "rec-c", "rec-a"}},
+		{"expiring last first", NewListOpenRecruitmentsQuery().WithSorting("expires_at", "desc"), []string{"rec-b", "rec-c", "rec-a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, total := listRecruitments(t, handler, tt.query)
+
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, len(tt.want), total)
+		})
+	}
+}
+
+func TestListOpenRecruitments_Pagination(t *testing.T) {
+	handler := newRecruitmentBoard(t, time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	page, total := listRecruitments(t, handler, NewListOpenRecruitmentsQuery().WithPagination(2, 1))
+	assert.Equal(t, []string{"rec-c", "rec-b"}, page)
+	assert.Equal(t, 3, total)
+
+	beyond, total := listRecruitments(t, handler, NewListOpenRecruitmentsQuery().WithPagination(2, 5))
+	assert.Empty(t, beyond)
+	assert.Equal(t, 3, total)
+}
+
+func TestListOpenRecruitments_Validation(t *testing.T) {
+	handler := newRecruitmentBoard(t, time.Now())
+
+	for _, query := range []*ListOpenRecruitmentsQuery{
+		NewListOpenRecruitmentsQuery().WithSorting("guild_name", "asc"),
+		NewListOpenRecruitmentsQuery().WithPagination(101, 0),
+		NewListOpenRecruitmentsQuery().WithMinCargoValue(-1),
+	} {
+		result, err := handler.Handle(context.Background(), query)
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+	}
+}
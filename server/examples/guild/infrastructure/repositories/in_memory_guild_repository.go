@@ -37,7 +37,7 @@ func (r *InMemoryGuildRepository) Save(ctx context.Context, aggregate cqrs.Aggre
 	r.guilds[aggregate.ID()] = &guildCopy
 
 	// Get uncommitted events
-	events := aggregate.GetChanges()
+	events := aggregate.Changes()
 	fmt.Printf("   🔧 Saving aggregate %s with %d events\n", aggregate.ID(), len(events))
 
 	if len(events) > 0 {
@@ -128,9 +128,8 @@ func runRedisExample(ctx context.Context) error {
 	// Create Redis-based implementations
 	stateStore := cqrsx.NewRedisStateStore(client, "user_example")
 
-	// Create factory and serializer for UserView read models
-	factory := projections.NewUserReadModelFactory()
-	serializer := cqrsx.NewJSONReadModelSerializer(factory)
+	// UserView is registered with the read model type registry by the projections package
+	serializer := &cqrsx.JSONReadModelSerializer{}
 	readStore := cqrsx.NewRedisReadStore(client, "user_example", serializer)
 
 	// Create User-specific repository
@@ -207,30 +206,22 @@ func runUserScenario(ctx context.Context, commandHandler *handlers.UserCommandHa
 	fmt.Println("\n2️⃣  Querying users...")
 
 	getUserQuery := handlers.CreateGetUserQuery(userID1)
-	userResult, err := queryDispatcher.Dispatch(ctx, getUserQuery)
+	queryStart := time.Now()
+	userView, err := cqrs.DispatchQuery[*projections.UserView](ctx, queryDispatcher, getUserQuery)
+	queryUserTime := time.Since(queryStart)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get user")
 	}
-
-	if !userResult.Success {
-		return errors.Wrapf(userResult.Error, "failed to get user")
-	}
-
-	userView := userResult.Data.(*projections.UserView)
 	fmt.Printf("   📋 User Details: %s - %s (%s)\n", userView.Name, userView.Email, userView.Status)
 
 	// 3. List all users
 	listQuery := handlers.CreateListUsersQuery("", 1, 10)
-	listResult, err := queryDispatcher.Dispatch(ctx, listQuery)
+	listStart := time.Now()
+	userViews, err := cqrs.DispatchQuery[[]*projections.UserView](ctx, queryDispatcher, listQuery)
+	listUsersTime := time.Since(listStart)
 	if err != nil {
 		return errors.Wrapf(err, "failed to list users")
 	}
-
-	if !listResult.Success {
-		return fmt.Errorf("failed to list users: %v", listResult.Error)
-	}
-
-	userViews := listResult.Data.([]*projections.UserView)
 	fmt.Printf("   📋 Total users: %d\n", len(userViews))
 	for _, view := range userViews {
 		fmt.Printf("      - %s: %s (%s)\n", view.Name, view.Email, view.Status)
@@ -255,16 +246,10 @@ func runUserScenario(ctx context.Context, commandHandler *handlers.UserCommandHa
 
 	// 5. Verify email change
 	getUserQuery2 := handlers.CreateGetUserQuery(userID1)
-	userResult2, err := queryDispatcher.Dispatch(ctx, getUserQuery2)
+	userView2, err := cqrs.DispatchQuery[*projections.UserView](ctx, queryDispatcher, getUserQuery2)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get user after email change")
 	}
-
-	if !userResult2.Success {
-		return errors.Wrapf(userResult2.Error, "failed to get user after email change")
-	}
-
-	userView2 := userResult2.Data.(*projections.UserView)
 	fmt.Printf("   📋 Updated email: %s\n", userView2.Email)
 
 	// 6. Deactivate user
@@ -288,16 +273,10 @@ func runUserScenario(ctx context.Context, commandHandler *handlers.UserCommandHa
 	fmt.Println("\n5️⃣  Listing active users...")
 
 	activeQuery := handlers.CreateListActiveUsersQuery(1, 10)
-	activeResult, err := queryDispatcher.Dispatch(ctx, activeQuery)
+	activeViews, err := cqrs.DispatchQuery[[]*projections.UserView](ctx, queryDispatcher, activeQuery)
 	if err != nil {
 		return errors.Wrapf(err, "failed to list active users")
 	}
-
-	if !activeResult.Success {
-		return errors.Wrapf(activeResult.Error, "failed to list active users")
-	}
-
-	activeViews := activeResult.Data.([]*projections.UserView)
 	fmt.Printf("   📋 Active users: %d\n", len(activeViews))
 	for _, view := range activeViews {
 		fmt.Printf("      - %s: %s (%s)\n", view.Name, view.Email, view.Status)
@@ -309,8 +288,8 @@ func runUserScenario(ctx context.Context, commandHandler *handlers.UserCommandHa
 	fmt.Printf("   ⚡ Create User 2: %v\n", result2.ExecutionTime)
 	fmt.Printf("   ⚡ Change Email: %v\n", emailResult.ExecutionTime)
 	fmt.Printf("   ⚡ Deactivate User: %v\n", deactivateResult.ExecutionTime)
-	fmt.Printf("   ⚡ Query User: %v\n", userResult.ExecutionTime)
-	fmt.Printf("   ⚡ List Users: %v\n", listResult.ExecutionTime)
+	fmt.Printf("   ⚡ Query User: %v\n", queryUserTime)
+	fmt.Printf("   ⚡ List Users: %v\n", listUsersTime)
 
 	fmt.Printf("\n✅ %s scenario completed successfully!\n", implementation)
 	return nil
@@ -3,6 +3,7 @@ package projections
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -10,6 +11,11 @@ import (
 	"defense-allies-server/examples/user/domain"
 )
 
+func init() {
+	// JSONReadModelSerializer resolves read model types through the cqrs registry
+	cqrs.RegisterReadModelType("UserView", reflect.TypeOf(&UserView{}))
+}
+
 // UserView represents a read model for user data
 type UserView struct {
 	*cqrs.BaseReadModel
@@ -368,90 +374,3 @@ func (q *UserViewQuery) Validate() error {
 
 	return nil
 }
-
-// UserReadModelFactory creates UserView read models
-type UserReadModelFactory struct{}
-
-// NewUserReadModelFactory creates a new UserReadModelFactory
-func NewUserReadModelFactory() *UserReadModelFactory {
-	return &UserReadModelFactory{}
-}
-
-// CreateReadModel creates a read model based on type
-func (f *UserReadModelFactory) CreateReadModel(modelType string, id string, data interface{}) (cqrs.ReadModel, error) {
-	switch modelType {
-	case "UserView":
-		return f.createUserView(id, data)
-	default:
-		return nil, fmt.Errorf("unsupported read model type: %s", modelType)
-	}
-}
-
-// createUserView creates a UserView from data
-func (f *UserReadModelFactory) createUserView(id string, data interface{}) (*UserView, error) {
-	// Try to convert data to UserView directly if it's already the right type
-	if userView, ok := data.(*UserView); ok {
-		return userView, nil
-	}
-
-	// Create a new UserView and populate it from data
-	userView := NewUserView(id)
-
-	// If data is a map, extract the fields
-	if dataMap, ok := data.(map[string]interface{}); ok {
-		// Extract fields from the map
-		if email, exists := dataMap["email"]; exists {
-			if emailStr, ok := email.(string); ok {
-				userView.Email = emailStr
-			}
-		}
-		if name, exists := dataMap["name"]; exists {
-			if nameStr, ok := name.(string); ok {
-				userView.Name = nameStr
-			}
-		}
-		if status, exists := dataMap["status"]; exists {
-			if statusStr, ok := status.(string); ok {
-				userView.Status = statusStr
-			}
-		}
-
-		// Handle time fields
-		if createdAt, exists := dataMap["created_at"]; exists {
-			if createdAtStr, ok := createdAt.(string); ok {
-				if parsedTime, err := time.Parse(time.RFC3339, createdAtStr); err == nil {
-					userView.CreatedAt = parsedTime
-				}
-			}
-		}
-		if updatedAt, exists := dataMap["updated_at"]; exists {
-			if updatedAtStr, ok := updatedAt.(string); ok {
-				if parsedTime, err := time.Parse(time.RFC3339, updatedAtStr); err == nil {
-					userView.UpdatedAt = parsedTime
-				}
-			}
-		}
-
-		// Handle optional time fields
-		if lastLoginAt, exists := dataMap["last_login_at"]; exists && lastLoginAt != nil {
-			if lastLoginAtStr, ok := lastLoginAt.(string); ok {
-				if parsedTime, err := time.Parse(time.RFC3339, lastLoginAtStr); err == nil {
-					userView.LastLoginAt = &parsedTime
-				}
-			}
-		}
-		if deactivatedAt, exists := dataMap["deactivated_at"]; exists && deactivatedAt != nil {
-			if deactivatedAtStr, ok := deactivatedAt.(string); ok {
-				if parsedTime, err := time.Parse(time.RFC3339, deactivatedAtStr); err == nil {
-					userView.DeactivatedAt = &parsedTime
-				}
-			}
-		}
-		if deactivationReason, exists := dataMap["deactivation_reason"]; exists {
-			if reasonStr, ok := deactivationReason.(string); ok {
-				userView.DeactivationReason = reasonStr
-			}
-		}
-	}
-	return userView, nil
-}
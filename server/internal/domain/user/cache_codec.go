@@ -0,0 +1,41 @@
+package user
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// CacheCodec encodes cached values for a CacheStorage
+type CacheCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCacheCodec encodes cached values as JSON (readable with redis-cli)
+type JSONCacheCodec struct{}
+
+func (JSONCacheCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCacheCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobCacheCodec encodes cached values with encoding/gob.
+// It is smaller and faster than JSON for the nested user data structures; concrete
+// types stored in interface{} fields beyond the basic types must be registered with gob.Register.
+type GobCacheCodec struct{}
+
+func (GobCacheCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCacheCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
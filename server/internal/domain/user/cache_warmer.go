@@ -0,0 +1,244 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrWarmupInProgress is returned when a warmup is triggered while another one is running
+var ErrWarmupInProgress = errors.New("cache warmup already in progress")
+
+// maxWarmupErrors caps the per-user errors kept in WarmupProgress
+const maxWarmupErrors = 100
+
+// WarmupSelector returns the IDs of the users a warmup source wants preloaded
+type WarmupSelector func(ctx context.Context) ([]string, error)
+
+// WarmupPreloadFunc loads one user's data into the cache
+type WarmupPreloadFunc func(ctx context.Context, userID string) error
+
+// WarmupProgress reports the state of the current or last warmup
+type WarmupProgress struct {
+	Running    bool              `json:"running"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Total      int               `json:"total"`
+	Completed  int               `json:"completed"`
+	Failed     int               `json:"failed"`
+	Sources    map[string]int    `json:"sources"`          // Source name -> selected users
+	Errors     map[string]string `json:"errors,omitempty"` // User ID or "source:<name>" -> error, capped at maxWarmupErrors
+}
+
+// Percent returns the completed share of the warmup from 0 to 100
+func (p WarmupProgress) Percent() float64 {
+	if p.Total == 0 {
+		if p.Running {
+			return 0
+		}
+		return 100
+	}
+	return float64(p.Completed+p.Failed) * 100 / float64(p.Total)
+}
+
+// CacheWarmerConfig contains configuration for CacheWarmer
+type CacheWarmerConfig struct {
+	Preload     WarmupPreloadFunc    // Required, e.g. MultiTypeLazyLoader.PreloadUser
+	Concurrency int                  // Parallel preloads; defaults to 8
+	OnProgress  func(WarmupProgress) // Called after every preloaded user, possibly from several goroutines
+}
+
+type warmupSource struct {
+	name     string
+	selector WarmupSelector
+}
+
+// CacheWarmer preloads hot users (top active players, guild rosters about to go to war)
+// into the lazy loader cache on server start or when an admin triggers it
+type CacheWarmer struct {
+	preload     WarmupPreloadFunc
+	concurrency int
+	onProgress  func(WarmupProgress)
+
+	mu       sync.Mutex
+	sources  []warmupSource
+	progress WarmupProgress
+}
+
+// NewCacheWarmer creates a new CacheWarmer
+func NewCacheWarmer(config CacheWarmerConfig) (*CacheWarmer, error) {
+	if config.Preload == nil {
+		return nil, errors.New("preload function is required")
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 8
+	}
+
+	return &CacheWarmer{
+		preload:     config.Preload,
+		concurrency: config.Concurrency,
+		onProgress:  config.OnProgress,
+	}, nil
+}
+
+// AddSource registers a named selector of users to preload; sources run in registration order
+func (w *CacheWarmer) AddSource(name string, selector WarmupSelector) error {
+	if name == "" || selector == nil {
+		return errors.New("source name and selector are required")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, source := range w.sources {
+		if source.name == name {
+			return fmt.Errorf("warmup source already registered: %s", name)
+		}
+	}
+	w.sources = append(w.sources, warmupSource{name: name, selector: selector})
+	return nil
+}
+
+// Progress returns a copy of the current or last warmup progress
+func (w *CacheWarmer) Progress() WarmupProgress {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.copyProgress()
+}
+
+// Warm runs every source and preloads the selected users, blocking until done.
+// A user selected by several sources is preloaded once. Failed sources and users are
+// recorded in the returned progress instead of aborting the warmup.
+func (w *CacheWarmer) Warm(ctx context.Context) (WarmupProgress, error) {
+	sources, err := w.begin()
+	if err != nil {
+		return WarmupProgress{}, err
+	}
+	w.run(ctx, sources)
+	return w.Progress(), nil
+}
+
+// WarmAsync starts a warmup in the background for an admin trigger; poll Progress for its state
+func (w *CacheWarmer) WarmAsync(ctx context.Context) error {
+	sources, err := w.begin()
+	if err != nil {
+		return err
+	}
+	go w.run(ctx, sources)
+	return nil
+}
+
+// begin resets the progress and marks the warmup as running
+func (w *CacheWarmer) begin() ([]warmupSource, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.progress.Running {
+		return nil, ErrWarmupInProgress
+	}
+	w.progress = WarmupProgress{
+		Running:   true,
+		StartedAt: time.Now(),
+		Sources:   make(map[string]int),
+		Errors:    make(map[string]string),
+	}
+	return append([]warmupSource(nil), w.sources...), nil
+}
+
+func (w *CacheWarmer) run(ctx context.Context, sources []warmupSource) {
+	defer func() {
+		w.mu.Lock()
+		w.progress.Running = false
+		w.progress.FinishedAt = time.Now()
+		w.mu.Unlock()
+		w.notify()
+	}()
+
+	// Collect users from every source, keeping the first source's order
+	seen := make(map[string]bool)
+	userIDs := make([]string, 0)
+	for _, source := range sources {
+		ids, err := source.selector(ctx)
+
+		w.mu.Lock()
+		if err != nil {
+			w.recordError("source:"+source.name, err)
+		} else {
+			w.progress.Sources[source.name] = len(ids)
+		}
+		w.mu.Unlock()
+
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+
+	w.mu.Lock()
+	w.progress.Total = len(userIDs)
+	w.mu.Unlock()
+	w.notify()
+
+	// Preload with at most concurrency users in flight
+	semaphore := make(chan struct{}, w.concurrency)
+	var wg sync.WaitGroup
+	for _, userID := range userIDs {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			err := w.preload(ctx, userID)
+
+			w.mu.Lock()
+			if err != nil {
+				w.progress.Failed++
+				w.recordError(userID, err)
+			} else {
+				w.progress.Completed++
+			}
+			w.mu.Unlock()
+			w.notify()
+		}(userID)
+	}
+	wg.Wait()
+}
+
+// recordError must be called with w.mu held
+func (w *CacheWarmer) recordError(key string, err error) {
+	if len(w.progress.Errors) < maxWarmupErrors {
+		w.progress.Errors[key] = err.Error()
+	}
+}
+
+// copyProgress must be called with w.mu held
+func (w *CacheWarmer) copyProgress() WarmupProgress {
+	progress := w.progress
+	progress.Sources = make(map[string]int, len(w.progress.Sources))
+	for name, count := range w.progress.Sources {
+		progress.Sources[name] = count
+	}
+	progress.Errors = make(map[string]string, len(w.progress.Errors))
+	for key, message := range w.progress.Errors {
+		progress.Errors[key] = message
+	}
+	return progress
+}
+
+func (w *CacheWarmer) notify() {
+	if w.onProgress == nil {
+		return
+	}
+	w.onProgress(w.Progress())
+}
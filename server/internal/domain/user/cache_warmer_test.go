@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheWarmer_PreloadsEverySourceOnce(t *testing.T) {
+	storage := NewInMemoryStorage()
+	defer storage.Close()
+
+	multiLoader := NewMultiTypeLazyLoader(MultiTypeLazyLoaderConfig{
+		CacheStorage:   storage,
+		CacheKeyPrefix: "test_warmup",
+	})
+
+	var progressCalls int32
+	warmer, err := NewCacheWarmer(CacheWarmerConfig{
+		Preload:     multiLoader.PreloadUser,
+		Concurrency: 2,
+		OnProgress: func(progress WarmupProgress) {
+			atomic.AddInt32(&progressCalls, 1)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create warmer: %v", err)
+	}
+
+	warmer.AddSource("top_active_players", func(ctx context.Context) ([]string, error) {
+		return []string{"player_1", "player_2", "player_3"}, nil
+	})
+	warmer.AddSource("guild_war_rosters", func(ctx context.Context) ([]string, error) {
+		return []string{"player_2", "player_4"}, nil
+	})
+
+	progress, err := warmer.Warm(context.Background())
+	if err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+
+	if progress.Running {
+		t.Error("Expected warmup to be finished")
+	}
+	if progress.Total != 4 || progress.Completed != 4 || progress.Failed != 0 {
+		t.Errorf("Expected 4 users preloaded once each, got %+v", progress)
+	}
+	if progress.Sources["top_active_players"] != 3 || progress.Sources["guild_war_rosters"] != 2 {
+		t.Errorf("Unexpected source counts: %v", progress.Sources)
+	}
+	if progress.Percent() != 100 {
+		t.Errorf("Expected 100%% progress, got %.1f", progress.Percent())
+	}
+	if atomic.LoadInt32(&progressCalls) < 4 {
+		t.Errorf("Expected progress reports for every user, got %d", progressCalls)
+	}
+
+	// Warmed users are served from the cache
+	info, err := multiLoader.inventoryLoader.GetCacheInfo(context.Background(), "player_4")
+	if err != nil || !info.Exists {
+		t.Errorf("Expected player_4 inventory to be cached, err=%v", err)
+	}
+}
+
+func TestCacheWarmer_LimitsConcurrencyAndRecordsFailures(t *testing.T) {
+	var inFlight, maxInFlight int32
+	warmer, _ := NewCacheWarmer(CacheWarmerConfig{
+		Concurrency: 3,
+		Preload: func(ctx context.Context, userID string) error {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if userID == "broken_user" {
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	})
+
+	warmer.AddSource("top_active_players", func(ctx context.Context) ([]string, error) {
+		ids := []string{"broken_user"}
+		for i := 0; i < 10; i++ {
+			ids = append(ids, "player_"+string(rune('a'+i)))
+		}
+		return ids, nil
+	})
+	warmer.AddSource("guild_war_rosters", func(ctx context.Context) ([]string, error) {
+		return nil, errors.New("guild service unavailable")
+	})
+
+	progress, err := warmer.Warm(context.Background())
+	if err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+
+	if max := atomic.LoadInt32(&maxInFlight); max > 3 {
+		t.Errorf("Expected at most 3 concurrent preloads, got %d", max)
+	}
+	if progress.Completed != 10 || progress.Failed != 1 {
+		t.Errorf("Expected 10 completed and 1 failed, got %+v", progress)
+	}
+	if _, exists := progress.Errors["broken_user"]; !exists {
+		t.Error("Expected broken_user error to be recorded")
+	}
+	if _, exists := progress.Errors["source:guild_war_rosters"]; !exists {
+		t.Error("Expected failed source to be recorded")
+	}
+}
+
+func TestCacheWarmer_RejectsConcurrentTrigger(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var once sync.Once
+
+	warmer, _ := NewCacheWarmer(CacheWarmerConfig{
+		Preload: func(ctx context.Context, userID string) error {
+			once.Do(func() { close(started) })
+			<-release
+			return nil
+		},
+	})
+	warmer.AddSource("top_active_players", func(ctx context.Context) ([]string, error) {
+		return []string{"player_1"}, nil
+	})
+
+	if err := warmer.WarmAsync(context.Background()); err != nil {
+		t.Fatalf("Failed to trigger warmup: %v", err)
+	}
+	<-started
+
+	if !warmer.Progress().Running {
+		t.Error("Expected warmup to be running")
+	}
+	if err := warmer.WarmAsync(context.Background()); !errors.Is(err, ErrWarmupInProgress) {
+		t.Errorf("Expected ErrWarmupInProgress, got %v", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for warmer.Progress().Running && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if progress := warmer.Progress(); progress.Running || progress.Completed != 1 {
+		t.Errorf("Expected warmup to finish with 1 user, got %+v", progress)
+	}
+}
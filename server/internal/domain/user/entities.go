@@ -469,6 +469,7 @@ var (
 	ErrUserBlocked                = NewDomainError("USER_BLOCKED", "User is blocked")
 	ErrFriendNotFound             = NewDomainError("FRIEND_NOT_FOUND", "Friend not found")
 	ErrInvalidFriendStatus        = NewDomainError("INVALID_FRIEND_STATUS", "Invalid friend status")
+	ErrUserNotFound               = NewDomainError("USER_NOT_FOUND", "User not found")
 )
 
 // DomainError represents a domain-specific error
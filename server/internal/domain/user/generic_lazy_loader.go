@@ -1,8 +1,8 @@
 package user
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -15,9 +15,17 @@ type GenericLazyLoader[T any] struct {
 	defaultTTL     time.Duration
 	cacheKeyPrefix string
 	loaderFunc     GenericLoaderFunc[T]
+	codec          CacheCodec
+	negativeTTL    time.Duration
+	isNotFound     func(error) bool
+	flights        loadGroup[T]
 	mu             sync.RWMutex
 }
 
+// negativeCacheMarker is stored in place of a value for keys the loader reported as missing.
+// A single zero byte is neither valid JSON nor a valid gob stream, so it cannot collide with a value.
+var negativeCacheMarker = []byte{0}
+
 // GenericLoaderFunc is a type-safe function type that loads data for a specific key
 type GenericLoaderFunc[T any] func(ctx context.Context, key string) (T, error)
 
@@ -27,6 +35,15 @@ type GenericLazyLoaderConfig[T any] struct {
 	DefaultTTL     time.Duration
 	CacheKeyPrefix string
 	LoaderFunc     GenericLoaderFunc[T]
+
+	// Codec encodes cached values; defaults to JSONCacheCodec
+	Codec CacheCodec
+	// NegativeTTL caches "not found" results so lookups of missing users do not reach
+	// the loader on every request; 0 disables negative caching
+	NegativeTTL time.Duration
+	// IsNotFound reports whether a loader error means the key does not exist;
+	// defaults to errors.Is(err, ErrUserNotFound)
+	IsNotFound func(error) bool
 }
 
 // NewGenericLazyLoader creates a new type-safe GenericLazyLoader
@@ -37,12 +54,21 @@ func NewGenericLazyLoader[T any](config GenericLazyLoaderConfig[T]) *GenericLazy
 	if config.CacheKeyPrefix == "" {
 		config.CacheKeyPrefix = "generic_lazy_load"
 	}
+	if config.Codec == nil {
+		config.Codec = JSONCacheCodec{}
+	}
+	if config.IsNotFound == nil {
+		config.IsNotFound = func(err error) bool { return errors.Is(err, ErrUserNotFound) }
+	}
 
 	return &GenericLazyLoader[T]{
 		cacheStorage:   config.CacheStorage,
 		defaultTTL:     config.DefaultTTL,
 		cacheKeyPrefix: config.CacheKeyPrefix,
 		loaderFunc:     config.LoaderFunc,
+		codec:          config.Codec,
+		negativeTTL:    config.NegativeTTL,
+		isNotFound:     config.IsNotFound,
 	}
 }
 
@@ -51,17 +77,36 @@ func (l *GenericLazyLoader[T]) Load(ctx context.Context, key string) (T, error)
 	var zero T
 	
 	// Try to get from cache first
-	if cached, err := l.getFromCache(ctx, key); err == nil {
+	cached, err := l.getFromCache(ctx, key)
+	if err == nil {
 		return cached, nil
 	}
+	if errors.Is(err, ErrUserNotFound) {
+		return zero, err // Negative cache hit
+	}
 
 	// Load data using the registered loader
 	if l.loaderFunc == nil {
 		return zero, errors.New("no loader function registered")
 	}
 
+	data, err, _ := l.flights.Do(ctx, key, func(ctx context.Context) (T, error) {
+		return l.loadAndCache(ctx, key)
+	})
+	return data, err
+}
+
+// loadAndCache calls the loader and caches its result, or the absence of one
+func (l *GenericLazyLoader[T]) loadAndCache(ctx context.Context, key string) (T, error) {
+	var zero T
+
 	data, err := l.loaderFunc(ctx, key)
 	if err != nil {
+		if l.negativeTTL > 0 && l.isNotFound(err) {
+			if cacheErr := l.cacheStorage.Set(ctx, l.buildCacheKey(key), negativeCacheMarker, l.negativeTTL); cacheErr != nil {
+				fmt.Printf("Failed to cache missing key %s: %v\n", key, cacheErr)
+			}
+		}
 		return zero, fmt.Errorf("failed to load data: %w", err)
 	}
 
@@ -81,9 +126,13 @@ func (l *GenericLazyLoader[T]) LoadMultiple(ctx context.Context, keys []string)
 
 	// First, try to get all keys from cache
 	for _, key := range keys {
-		if cached, err := l.getFromCache(ctx, key); err == nil {
+		cached, err := l.getFromCache(ctx, key)
+		switch {
+		case err == nil:
 			results[key] = cached
-		} else {
+		case errors.Is(err, ErrUserNotFound):
+			return nil, fmt.Errorf("failed to load data for key %s: %w", key, err)
+		default:
 			uncachedKeys = append(uncachedKeys, key)
 		}
 	}
@@ -99,17 +148,14 @@ func (l *GenericLazyLoader[T]) LoadMultiple(ctx context.Context, keys []string)
 	}
 
 	for _, key := range uncachedKeys {
-		data, err := l.loaderFunc(ctx, key)
+		data, err, _ := l.flights.Do(ctx, key, func(ctx context.Context) (T, error) {
+			return l.loadAndCache(ctx, key)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to load data for key %s: %w", key, err)
 		}
 
 		results[key] = data
-
-		// Cache the loaded data
-		if err := l.setToCache(ctx, key, data); err != nil {
-			fmt.Printf("Failed to cache data for key %s: %v\n", key, err)
-		}
 	}
 
 	return results, nil
@@ -183,9 +229,12 @@ func (l *GenericLazyLoader[T]) getFromCache(ctx context.Context, key string) (T,
 	if err != nil {
 		return zero, err
 	}
+	if bytes.Equal(data, negativeCacheMarker) {
+		return zero, fmt.Errorf("failed to load data: %w", ErrUserNotFound)
+	}
 
 	var result T
-	if err := json.Unmarshal(data, &result); err != nil {
+	if err := l.codec.Unmarshal(data, &result); err != nil {
 		return zero, fmt.Errorf("failed to unmarshal cached data: %w", err)
 	}
 
@@ -196,12 +245,12 @@ func (l *GenericLazyLoader[T]) getFromCache(ctx context.Context, key string) (T,
 func (l *GenericLazyLoader[T]) setToCache(ctx context.Context, key string, data T) error {
 	cacheKey := l.buildCacheKey(key)
 	
-	jsonData, err := json.Marshal(data)
+	encoded, err := l.codec.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal data for caching: %w", err)
 	}
 
-	return l.cacheStorage.Set(ctx, cacheKey, jsonData, l.defaultTTL)
+	return l.cacheStorage.Set(ctx, cacheKey, encoded, l.defaultTTL)
 }
 
 // Multi-type lazy loader for handling different data types
@@ -218,6 +267,8 @@ type MultiTypeLazyLoaderConfig struct {
 	CacheStorage   CacheStorage
 	DefaultTTL     time.Duration
 	CacheKeyPrefix string
+	Codec          CacheCodec    // Shared by every data type loader; defaults to JSONCacheCodec
+	NegativeTTL    time.Duration // How long missing users stay cached; 0 disables negative caching
 }
 
 // NewMultiTypeLazyLoader creates a new multi-type lazy loader
@@ -231,30 +282,40 @@ func NewMultiTypeLazyLoader(config MultiTypeLazyLoaderConfig) *MultiTypeLazyLoad
 			CacheStorage:   config.CacheStorage,
 			DefaultTTL:     config.DefaultTTL,
 			CacheKeyPrefix: config.CacheKeyPrefix + ":inventory",
+			Codec:          config.Codec,
+			NegativeTTL:    config.NegativeTTL,
 			LoaderFunc:     loadUserInventory,
 		}),
 		achievementsLoader: NewGenericLazyLoader(GenericLazyLoaderConfig[*UserAchievements]{
 			CacheStorage:   config.CacheStorage,
 			DefaultTTL:     config.DefaultTTL,
 			CacheKeyPrefix: config.CacheKeyPrefix + ":achievements",
+			Codec:          config.Codec,
+			NegativeTTL:    config.NegativeTTL,
 			LoaderFunc:     loadUserAchievements,
 		}),
 		statsLoader: NewGenericLazyLoader(GenericLazyLoaderConfig[*UserStats]{
 			CacheStorage:   config.CacheStorage,
 			DefaultTTL:     config.DefaultTTL,
 			CacheKeyPrefix: config.CacheKeyPrefix + ":stats",
+			Codec:          config.Codec,
+			NegativeTTL:    config.NegativeTTL,
 			LoaderFunc:     loadUserStats,
 		}),
 		preferencesLoader: NewGenericLazyLoader(GenericLazyLoaderConfig[*UserPreferences]{
 			CacheStorage:   config.CacheStorage,
 			DefaultTTL:     config.DefaultTTL,
 			CacheKeyPrefix: config.CacheKeyPrefix + ":preferences",
+			Codec:          config.Codec,
+			NegativeTTL:    config.NegativeTTL,
 			LoaderFunc:     loadUserPreferences,
 		}),
 		socialLoader: NewGenericLazyLoader(GenericLazyLoaderConfig[*UserSocialData]{
 			CacheStorage:   config.CacheStorage,
 			DefaultTTL:     config.DefaultTTL,
 			CacheKeyPrefix: config.CacheKeyPrefix + ":social",
+			Codec:          config.Codec,
+			NegativeTTL:    config.NegativeTTL,
 			LoaderFunc:     loadUserSocialData,
 		}),
 	}
@@ -360,6 +421,12 @@ func (m *MultiTypeLazyLoader) LoadAll(ctx context.Context, userID string) (*User
 	}, nil
 }
 
+// PreloadUser loads every data type of a user into the cache; usable as a CacheWarmer preload function
+func (m *MultiTypeLazyLoader) PreloadUser(ctx context.Context, userID string) error {
+	_, err := m.LoadAll(ctx, userID)
+	return err
+}
+
 // InvalidateUserCache invalidates all cache for a user
 func (m *MultiTypeLazyLoader) InvalidateUserCache(ctx context.Context, userID string) error {
 	var errs []error
@@ -2,6 +2,9 @@ package user
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -343,4 +346,123 @@ func TestMultiTypeLazyLoader_CacheInvalidation(t *testing.T) {
 	if invCacheInfo.Exists {
 		t.Error("Inventory cache should not exist after invalidation")
 	}
-}
\ No newline at end of file
+}
+
+func TestGenericLazyLoader_ConcurrentMissesShareOneLoad(t *testing.T) {
+	storage := NewInMemoryStorage()
+	defer storage.Close()
+
+	var loadCount int32
+	release := make(chan struct{})
+
+	statsLoader := NewGenericLazyLoader(GenericLazyLoaderConfig[*UserStats]{
+		CacheStorage:   storage,
+		CacheKeyPrefix: "test_flight",
+		LoaderFunc: func(ctx context.Context, userID string) (*UserStats, error) {
+			atomic.AddInt32(&loadCount, 1)
+			<-release
+			return NewUserStats(userID), nil
+		},
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := statsLoader.Load(ctx, "hot_user"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("Failed to load stats: %v", err)
+	}
+
+	// Callers that arrive after the shared load finishes are served from the cache
+	if count := atomic.LoadInt32(&loadCount); count != 1 {
+		t.Errorf("Expected loader to be called once, got %d", count)
+	}
+}
+
+func TestGenericLazyLoader_NegativeCaching(t *testing.T) {
+	storage := NewInMemoryStorage()
+	defer storage.Close()
+
+	loadCount := 0
+	preferencesLoader := NewGenericLazyLoader(GenericLazyLoaderConfig[*UserPreferences]{
+		CacheStorage:   storage,
+		CacheKeyPrefix: "test_negative",
+		NegativeTTL:    time.Minute,
+		LoaderFunc: func(ctx context.Context, userID string) (*UserPreferences, error) {
+			loadCount++
+			return nil, ErrUserNotFound
+		},
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := preferencesLoader.Load(ctx, "missing_user")
+		if !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("Expected ErrUserNotFound, got %v", err)
+		}
+	}
+
+	if loadCount != 1 {
+		t.Errorf("Expected missing user to be loaded once, got %d", loadCount)
+	}
+
+	// Invalidating the key lets a newly created user be loaded
+	if err := preferencesLoader.InvalidateCache(ctx, "missing_user"); err != nil {
+		t.Fatalf("Failed to invalidate cache: %v", err)
+	}
+	preferencesLoader.Load(ctx, "missing_user")
+	if loadCount != 2 {
+		t.Errorf("Expected loader to be called again after invalidation, got %d", loadCount)
+	}
+}
+
+func TestGenericLazyLoader_GobCodec(t *testing.T) {
+	storage := NewInMemoryStorage()
+	defer storage.Close()
+
+	loadCount := 0
+	statsLoader := NewGenericLazyLoader(GenericLazyLoaderConfig[*UserStats]{
+		CacheStorage:   storage,
+		CacheKeyPrefix: "test_gob",
+		Codec:          GobCacheCodec{},
+		LoaderFunc: func(ctx context.Context, userID string) (*UserStats, error) {
+			loadCount++
+			stats := NewUserStats(userID)
+			stats.GlobalStats["level"] = 12
+			stats.GlobalStats["title"] = "Defender"
+			return stats, nil
+		},
+	})
+
+	ctx := context.Background()
+	if _, err := statsLoader.Load(ctx, "gob_user"); err != nil {
+		t.Fatalf("Failed to load stats: %v", err)
+	}
+
+	cached, err := statsLoader.Load(ctx, "gob_user")
+	if err != nil {
+		t.Fatalf("Failed to load cached stats: %v", err)
+	}
+
+	if loadCount != 1 {
+		t.Errorf("Expected second load to hit the cache, loader called %d times", loadCount)
+	}
+	if cached.GlobalStats["level"] != 12 || cached.GlobalStats["title"] != "Defender" {
+		t.Errorf("Unexpected decoded global stats: %v", cached.GlobalStats)
+	}
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// loadGroup collapses concurrent loads of the same key into a single call,
+// so a cache miss on a hot key does not stampede the backing store
+type loadGroup[T any] struct {
+	group singleflight.Group
+}
+
+// Do runs fn once for all concurrent callers of key; shared reports whether
+// the result came from another caller's load.
+// fn gets a context that ignores the first caller's cancellation, so one caller
+// giving up does not fail the load for the others; each caller still stops
+// waiting when its own ctx is done. A panic in fn is returned as an error to
+// every caller instead of a zero value.
+func (g *loadGroup[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (val T, err error, shared bool) {
+	loadCtx := context.WithoutCancel(ctx)
+	results := g.group.DoChan(key, func() (result interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("load of %s panicked: %v", key, r)
+			}
+		}()
+		return fn(loadCtx)
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return val, result.Err, result.Shared
+		}
+		return result.Val.(T), nil, result.Shared
+	case <-ctx.Done():
+		return val, ctx.Err(), false
+	}
+}
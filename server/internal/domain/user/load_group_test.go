@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadGroup_PanicIsReturnedToEveryCaller(t *testing.T) {
+	var group loadGroup[*UserStats]
+	started := make(chan struct{})
+	var startOnce sync.Once
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i], _ = group.Do(context.Background(), "hot_user", func(ctx context.Context) (*UserStats, error) {
+				startOnce.Do(func() { close(started) })
+				<-release
+				panic("storage driver bug")
+			})
+		}(i)
+		if i == 0 {
+			<-started
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "storage driver bug") {
+			t.Errorf("caller %d: expected panic to be returned as an error, got %v", i, err)
+		}
+	}
+}
+
+func TestLoadGroup_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	var group loadGroup[*UserStats]
+	started := make(chan struct{})
+	var startOnce sync.Once
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*UserStats, error) {
+		startOnce.Do(func() { close(started) })
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return NewUserStats("hot_user"), nil
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err, _ := group.Do(leaderCtx, "hot_user", load)
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan *UserStats, 1)
+	followerErr := make(chan error, 1)
+	go func() {
+		stats, err, _ := group.Do(context.Background(), "hot_user", load)
+		followerResult <- stats
+		followerErr <- err
+	}()
+
+	// The first caller gives up while the shared load is still running
+	cancel()
+	if err := <-leaderErr; err != context.Canceled {
+		t.Fatalf("Expected cancelled caller to stop waiting with context.Canceled, got %v", err)
+	}
+	close(release)
+
+	if err := <-followerErr; err != nil {
+		t.Fatalf("Expected waiting caller to get the shared result, got %v", err)
+	}
+	if stats := <-followerResult; stats == nil || stats.UserID != "hot_user" {
+		t.Errorf("Expected stats for hot_user, got %+v", stats)
+	}
+}
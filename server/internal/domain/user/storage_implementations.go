@@ -11,7 +11,15 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-// RedisStorage implements CacheStorage interface using Redis
+// ErrCacheMiss is returned by RedisStorage when a key is not cached
+var ErrCacheMiss = errors.New("cache miss")
+
+// redisScanBatch is the SCAN page size used when deleting keys by pattern
+const redisScanBatch = 500
+
+// RedisStorage implements CacheStorage interface using Redis.
+// Values are stored as raw bytes, so binary codecs such as GobCacheCodec work unchanged,
+// and every server instance sharing the Redis sees the same cache.
 type RedisStorage struct {
 	client *redis.Client
 }
@@ -22,11 +30,14 @@ func NewRedisStorage(client *redis.Client) *RedisStorage {
 }
 
 func (r *RedisStorage) Get(ctx context.Context, key string) ([]byte, error) {
-	result, err := r.client.Get(ctx, key).Result()
+	result, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
 	if err != nil {
 		return nil, err
 	}
-	return []byte(result), nil
+	return result, nil
 }
 
 func (r *RedisStorage) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
@@ -37,15 +48,25 @@ func (r *RedisStorage) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// DeletePattern deletes matching keys page by page with SCAN, which unlike KEYS
+// does not block a shared Redis while it walks the keyspace
 func (r *RedisStorage) DeletePattern(ctx context.Context, pattern string) error {
-	keys, err := r.client.Keys(ctx, pattern).Result()
-	if err != nil {
-		return err
-	}
-	if len(keys) > 0 {
-		return r.client.Del(ctx, keys...).Err()
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, redisScanBatch).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
 	}
-	return nil
 }
 
 func (r *RedisStorage) Exists(ctx context.Context, key string) (bool, error) {
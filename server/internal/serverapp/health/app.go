@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"defense-allies-server/internal/serverapp"
@@ -11,26 +13,47 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// CheckFunc 외부 의존성(MongoDB 등)의 상태를 확인하는 함수
+type CheckFunc func(ctx context.Context) serverapp.HealthStatus
+
 // HealthApp 헬스체크 기능을 제공하는 ServerApp
 type HealthApp struct {
 	*serverapp.BaseApp
-	redisClient *redis.Client
+	redisClient  *redis.Client
+	checks       map[string]CheckFunc
+	checksMutex  sync.RWMutex
+	checkTimeout time.Duration
 }
 
 // NewHealthApp 새로운 HealthApp을 생성합니다
 func NewHealthApp(redisClient *redis.Client) *HealthApp {
 	app := &HealthApp{
-		BaseApp:     serverapp.NewBaseApp("health"),
-		redisClient: redisClient,
+		BaseApp:      serverapp.NewBaseApp("health"),
+		redisClient:  redisClient,
+		checks:       make(map[string]CheckFunc),
+		checkTimeout: 5 * time.Second,
 	}
 	return app
 }
 
+// AddCheck 상세 헬스체크에 포함될 의존성 체크를 등록합니다.
+// cqrsx.MongoClientManager는 다음과 같이 연결할 수 있습니다:
+//
+//	app.AddCheck("mongodb", func(ctx context.Context) serverapp.HealthStatus {
+//		return serverapp.HealthStatus(mongoManager.HealthCheck(ctx))
+//	})
+func (h *HealthApp) AddCheck(name string, check CheckFunc) {
+	h.checksMutex.Lock()
+	defer h.checksMutex.Unlock()
+	h.checks[name] = check
+}
+
 // RegisterRoutes HTTP Mux에 라우트를 등록합니다
 func (h *HealthApp) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/health", h.handleHealth)
 	mux.HandleFunc("/health/detailed", h.handleDetailedHealth)
 	mux.HandleFunc("/health/redis", h.handleRedisHealth)
+	mux.HandleFunc("/health/checks/", h.handleCheckHealth)
 }
 
 // handleHealth 기본 헬스체크 핸들러
@@ -109,6 +132,44 @@ func (h *HealthApp) handleRedisHealth(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(redisHealth)
 }
 
+// handleCheckHealth 등록된 개별 의존성 체크 핸들러 (/health/checks/{name})
+func (h *HealthApp) handleCheckHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/health/checks/")
+
+	h.checksMutex.RLock()
+	check, exists := h.checks[name]
+	h.checksMutex.RUnlock()
+
+	if !exists {
+		http.Error(w, "Health check not found", http.StatusNotFound)
+		return
+	}
+
+	status := h.runCheck(r.Context(), check)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if status.Status == serverapp.HealthStatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	json.NewEncoder(w).Encode(status)
+}
+
+// runCheck 타임아웃을 적용해 의존성 체크를 실행합니다
+func (h *HealthApp) runCheck(ctx context.Context, check CheckFunc) serverapp.HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+	return check(ctx)
+}
+
 // DetailedHealthResponse 상세 헬스체크 응답
 type DetailedHealthResponse struct {
 	Status    string                            `json:"status"`
@@ -130,6 +191,13 @@ func (h *HealthApp) performDetailedHealthCheck() DetailedHealthResponse {
 	// 메모리 상태 (간단한 체크)
 	checks["memory"] = h.checkMemoryHealth()
 
+	// 등록된 의존성 체크
+	h.checksMutex.RLock()
+	for name, check := range h.checks {
+		checks[name] = h.runCheck(context.Background(), check)
+	}
+	h.checksMutex.RUnlock()
+
 	// 전체 상태 결정
 	overallStatus := serverapp.HealthStatusHealthy
 	for _, check := range checks {
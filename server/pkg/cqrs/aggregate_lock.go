@@ -0,0 +1,177 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AggregateLock is an exclusive, expiring hold on one aggregate.
+// Token is a fencing token that increases with every acquisition of the same aggregate;
+// stores that receive it can reject writes carrying an older token, which protects
+// against a holder that paused past its TTL and lost the lock without noticing.
+type AggregateLock interface {
+	AggregateType() string
+	AggregateID() string
+	Token() int64
+	// Refresh extends the lock to ttl from now; it fails if the lock was lost
+	Refresh(ctx context.Context, ttl time.Duration) error
+	// Release gives up the lock; releasing a lost lock is not an error
+	Release(ctx context.Context) error
+}
+
+// AggregateLocker acquires aggregate locks for multi-step operations
+// (e.g. StartTransportFromRecruitment followed by reward distribution)
+type AggregateLocker interface {
+	// Acquire waits until the lock is obtained or ctx is done
+	Acquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (AggregateLock, error)
+	// TryAcquire returns ErrAggregateLocked immediately if another holder owns the lock
+	TryAcquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (AggregateLock, error)
+}
+
+type aggregateLockContextKey struct{}
+
+// AggregateLockFromContext returns the lock held by WithAggregateLock, if any
+func AggregateLockFromContext(ctx context.Context) (AggregateLock, bool) {
+	lock, ok := ctx.Value(aggregateLockContextKey{}).(AggregateLock)
+	return lock, ok
+}
+
+// WithAggregateLock runs fn while holding the aggregate lock, refreshing it every ttl/3.
+// fn's context is cancelled if a refresh fails, since the lock may then belong to someone else.
+func WithAggregateLock(ctx context.Context, locker AggregateLocker, aggregateType, aggregateID string, ttl time.Duration, fn func(ctx context.Context, lock AggregateLock) error) error {
+	lock, err := locker.Acquire(ctx, aggregateType, aggregateID, ttl)
+	if err != nil {
+		return err
+	}
+	defer lock.Release(context.WithoutCancel(ctx))
+
+	lockCtx, cancel := context.WithCancelCause(context.WithValue(ctx, aggregateLockContextKey{}, lock))
+	defer cancel(nil)
+
+	refreshDone := make(chan struct{})
+	go func() {
+		defer close(refreshDone)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-lockCtx.Done():
+				return
+			case <-ticker.C:
+				if err := lock.Refresh(lockCtx, ttl); err != nil {
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(lockCtx, lock)
+	cancel(nil)
+	<-refreshDone
+
+	if err == nil && context.Cause(lockCtx) != nil && context.Cause(lockCtx) != context.Canceled {
+		return context.Cause(lockCtx)
+	}
+	return err
+}
+
+// InMemoryAggregateLocker is a process-local AggregateLocker for tests and single-instance servers
+type InMemoryAggregateLocker struct {
+	mutex  sync.Mutex
+	locks  map[string]*inMemoryLockState
+	tokens map[string]int64
+	retry  time.Duration
+}
+
+type inMemoryLockState struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// NewInMemoryAggregateLocker creates an in-memory locker
+func NewInMemoryAggregateLocker() *InMemoryAggregateLocker {
+	return &InMemoryAggregateLocker{
+		locks:  make(map[string]*inMemoryLockState),
+		tokens: make(map[string]int64),
+		retry:  10 * time.Millisecond,
+	}
+}
+
+func aggregateLockKey(aggregateType, aggregateID string) string {
+	return aggregateType + ":" + aggregateID
+}
+
+func (l *InMemoryAggregateLocker) Acquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (AggregateLock, error) {
+	for {
+		lock, err := l.TryAcquire(ctx, aggregateType, aggregateID, ttl)
+		if err == nil || !IsConcurrencyError(err) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(l.retry):
+		}
+	}
+}
+
+func (l *InMemoryAggregateLocker) TryAcquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (AggregateLock, error) {
+	if aggregateType == "" || aggregateID == "" {
+		return nil, NewValidationError("aggregate type and id are required", nil)
+	}
+	key := aggregateLockKey(aggregateType, aggregateID)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if state, exists := l.locks[key]; exists && now.Before(state.expiresAt) {
+		return nil, NewConcurrencyError(fmt.Sprintf("aggregate %s is locked", key), ErrAggregateLocked)
+	}
+
+	owner := uuid.NewString()
+	l.tokens[key]++
+	l.locks[key] = &inMemoryLockState{owner: owner, expiresAt: now.Add(ttl)}
+	return &inMemoryAggregateLock{locker: l, key: key, aggregateType: aggregateType, aggregateID: aggregateID, owner: owner, token: l.tokens[key]}, nil
+}
+
+type inMemoryAggregateLock struct {
+	locker        *InMemoryAggregateLocker
+	key           string
+	aggregateType string
+	aggregateID   string
+	owner         string
+	token         int64
+}
+
+func (l *inMemoryAggregateLock) AggregateType() string { return l.aggregateType }
+func (l *inMemoryAggregateLock) AggregateID() string   { return l.aggregateID }
+func (l *inMemoryAggregateLock) Token() int64          { return l.token }
+
+func (l *inMemoryAggregateLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	l.locker.mutex.Lock()
+	defer l.locker.mutex.Unlock()
+
+	state, exists := l.locker.locks[l.key]
+	if !exists || state.owner != l.owner || time.Now().After(state.expiresAt) {
+		return NewConcurrencyError(fmt.Sprintf("lock on aggregate %s was lost", l.key), ErrAggregateLockLost)
+	}
+	state.expiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+func (l *inMemoryAggregateLock) Release(ctx context.Context) error {
+	l.locker.mutex.Lock()
+	defer l.locker.mutex.Unlock()
+
+	if state, exists := l.locker.locks[l.key]; exists && state.owner == l.owner {
+		delete(l.locker.locks, l.key)
+	}
+	return nil
+}
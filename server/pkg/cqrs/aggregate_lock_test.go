@@ -0,0 +1,56 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAggregateLock_HoldsLockDuringOperation(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	locker := NewInMemoryAggregateLocker()
+
+	// Act
+	var contended error
+	var token int64
+	err := WithAggregateLock(ctx, locker, "Guild", "guild-1", 30*time.Millisecond, func(ctx context.Context, lock AggregateLock) error {
+		time.Sleep(50 * time.Millisecond) // TTL보다 길게 잡아도 갱신으로 유지된다
+		_, contended = locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+		held, ok := AggregateLockFromContext(ctx)
+		require.True(t, ok)
+		token = held.Token()
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, contended, ErrAggregateLocked)
+	assert.Equal(t, int64(1), token)
+
+	next, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), next.Token())
+}
+
+func TestInMemoryAggregateLocker_ExpiredLockCannotRefresh(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	locker := NewInMemoryAggregateLocker()
+	stale, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	current, acquireErr := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+	refreshErr := stale.Refresh(ctx, time.Second)
+	stale.Release(ctx)
+
+	// Assert
+	require.NoError(t, acquireErr)
+	assert.ErrorIs(t, refreshErr, ErrAggregateLockLost)
+	assert.NoError(t, current.Refresh(ctx, time.Second))
+}
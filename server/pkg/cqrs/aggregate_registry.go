@@ -0,0 +1,264 @@
+package cqrs
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// AggregateApplyFunc applies a historical event to a concrete aggregate's state.
+// It is called after ReplayEvent has advanced the aggregate version.
+type AggregateApplyFunc func(aggregate AggregateRoot, event EventMessage) error
+
+// AggregateSnapshotFunc restores a concrete aggregate's state from a snapshot
+type AggregateSnapshotFunc func(aggregate AggregateRoot, snapshot SnapshotData) error
+
+// AggregateRegistration describes how to construct and rehydrate one aggregate type
+type AggregateRegistration struct {
+	AggregateType   string
+	Factory         AggregateFactory
+	Apply           AggregateApplyFunc    // Optional; without it only the version is replayed
+	RestoreSnapshot AggregateSnapshotFunc // Optional; falls back to EventSourcedAggregate.LoadFromSnapshot
+}
+
+// AggregateRegistrationOption configures an AggregateRegistration
+type AggregateRegistrationOption func(*AggregateRegistration)
+
+// WithApplyFunc sets the event-apply function used during rehydration
+func WithApplyFunc(apply AggregateApplyFunc) AggregateRegistrationOption {
+	return func(r *AggregateRegistration) {
+		r.Apply = apply
+	}
+}
+
+// WithSnapshotRestore sets the function used to restore state from snapshots.
+// The function is responsible for restoring the aggregate version as well.
+func WithSnapshotRestore(restore AggregateSnapshotFunc) AggregateRegistrationOption {
+	return func(r *AggregateRegistration) {
+		r.RestoreSnapshot = restore
+	}
+}
+
+// AggregateRegistry maps aggregate type names to constructors and event-apply functions
+// so repositories can rehydrate real domain types instead of BaseAggregate.
+// It is safe for concurrent use.
+type AggregateRegistry struct {
+	registrations map[string]AggregateRegistration
+	mu            sync.RWMutex
+}
+
+// NewAggregateRegistry creates an empty aggregate registry
+func NewAggregateRegistry() *AggregateRegistry {
+	return &AggregateRegistry{
+		registrations: make(map[string]AggregateRegistration),
+	}
+}
+
+var defaultAggregateRegistry = NewAggregateRegistry()
+
+// DefaultAggregateRegistry returns the process-wide registry used by RegisterAggregateType
+func DefaultAggregateRegistry() *AggregateRegistry {
+	return defaultAggregateRegistry
+}
+
+// newAggregateRegistration validates a registration and applies its options
+func newAggregateRegistration(aggregateType string, factory AggregateFactory, options []AggregateRegistrationOption) (AggregateRegistration, error) {
+	if aggregateType == "" {
+		return AggregateRegistration{}, NewCQRSError(ErrCodeValidationError.String(), "aggregate type cannot be empty", nil)
+	}
+	if factory == nil {
+		return AggregateRegistration{}, NewCQRSError(ErrCodeValidationError.String(), "aggregate factory cannot be nil", nil)
+	}
+
+	registration := AggregateRegistration{AggregateType: aggregateType, Factory: factory}
+	for _, option := range options {
+		option(&registration)
+	}
+	return registration, nil
+}
+
+// Register adds an aggregate type. Registering the same type twice is an error.
+func (r *AggregateRegistry) Register(aggregateType string, factory AggregateFactory, options ...AggregateRegistrationOption) error {
+	registration, err := newAggregateRegistration(aggregateType, factory, options)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.registrations[aggregateType]; exists {
+		return NewCQRSError(ErrCodeValidationError.String(),
+			fmt.Sprintf("aggregate type already registered: %s", aggregateType), nil)
+	}
+	r.registrations[aggregateType] = registration
+	return nil
+}
+
+// MustRegister is like Register but panics on error; intended for init() registration
+func (r *AggregateRegistry) MustRegister(aggregateType string, factory AggregateFactory, options ...AggregateRegistrationOption) {
+	if err := r.Register(aggregateType, factory, options...); err != nil {
+		panic(err)
+	}
+}
+
+// Replace registers an aggregate type, atomically replacing any existing registration.
+// The new registration is built only from the given options; nothing is kept from the old one.
+func (r *AggregateRegistry) Replace(aggregateType string, factory AggregateFactory, options ...AggregateRegistrationOption) error {
+	registration, err := newAggregateRegistration(aggregateType, factory, options)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.registrations[aggregateType] = registration
+	return nil
+}
+
+// replaceFactory swaps the factory of an aggregate type, keeping the apply and snapshot
+// functions of an existing registration
+func (r *AggregateRegistry) replaceFactory(aggregateType string, factory AggregateFactory) error {
+	registration, err := newAggregateRegistration(aggregateType, factory, nil)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, exists := r.registrations[aggregateType]; exists {
+		existing.Factory = factory
+		registration = existing
+	}
+	r.registrations[aggregateType] = registration
+	return nil
+}
+
+// Unregister removes an aggregate type
+func (r *AggregateRegistry) Unregister(aggregateType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.registrations, aggregateType)
+}
+
+// IsRegistered checks if an aggregate type is registered
+func (r *AggregateRegistry) IsRegistered(aggregateType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.registrations[aggregateType]
+	return exists
+}
+
+// RegisteredTypes returns all registered aggregate types in sorted order
+func (r *AggregateRegistry) RegisteredTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.registrations))
+	for aggregateType := range r.registrations {
+		types = append(types, aggregateType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (r *AggregateRegistry) get(aggregateType string) (AggregateRegistration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registration, exists := r.registrations[aggregateType]
+	if !exists {
+		return AggregateRegistration{}, NewCQRSError(ErrCodeNotFoundError.String(),
+			fmt.Sprintf("aggregate type not registered: %s", aggregateType), nil)
+	}
+	return registration, nil
+}
+
+// Create constructs an empty aggregate of the given type
+func (r *AggregateRegistry) Create(aggregateType, id string) (AggregateRoot, error) {
+	registration, err := r.get(aggregateType)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate, err := registration.Factory(id)
+	if err != nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to create aggregate instance: %v", err), err)
+	}
+	if aggregate == nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(),
+			fmt.Sprintf("factory for %s returned nil aggregate", aggregateType), nil)
+	}
+	return aggregate, nil
+}
+
+// Rehydrate constructs an aggregate, restores the optional snapshot and replays events on top of it
+func (r *AggregateRegistry) Rehydrate(aggregateType, id string, snapshot SnapshotData, events []EventMessage) (AggregateRoot, error) {
+	registration, err := r.get(aggregateType)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate, err := r.Create(aggregateType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot != nil {
+		if err := restoreSnapshot(registration, aggregate, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := replayEvents(registration, aggregate, events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// Apply replays events onto an existing aggregate using the registered apply function
+func (r *AggregateRegistry) Apply(aggregate AggregateRoot, events []EventMessage) error {
+	registration, err := r.get(aggregate.Type())
+	if err != nil {
+		return err
+	}
+	return replayEvents(registration, aggregate, events)
+}
+
+// replayEvents advances the aggregate version and applies each event's state change
+func replayEvents(registration AggregateRegistration, aggregate AggregateRoot, events []EventMessage) error {
+	for _, event := range events {
+		if err := aggregate.ReplayEvent(event); err != nil {
+			return NewCQRSError(ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to replay event %s", event.EventType()), err)
+		}
+		if registration.Apply == nil {
+			continue
+		}
+		if err := registration.Apply(aggregate, event); err != nil {
+			return NewCQRSError(ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to apply event %s to %s", event.EventType(), registration.AggregateType), err)
+		}
+	}
+	return nil
+}
+
+func restoreSnapshot(registration AggregateRegistration, aggregate AggregateRoot, snapshot SnapshotData) error {
+	if registration.RestoreSnapshot != nil {
+		if err := registration.RestoreSnapshot(aggregate, snapshot); err != nil {
+			return NewCQRSError(ErrCodeSnapshotStoreError.String(), "failed to restore snapshot", err)
+		}
+		return nil
+	}
+
+	if eventSourced, ok := aggregate.(EventSourcedAggregate); ok {
+		if err := eventSourced.LoadFromSnapshot(snapshot); err != nil {
+			return NewCQRSError(ErrCodeSnapshotStoreError.String(), "failed to restore snapshot", err)
+		}
+		return nil
+	}
+
+	return NewCQRSError(ErrCodeSnapshotStoreError.String(),
+		fmt.Sprintf("aggregate type %s cannot be restored from snapshot", registration.AggregateType), nil)
+}
@@ -0,0 +1,125 @@
+package cqrs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test aggregate with state rebuilt from events
+type counterAggregate struct {
+	*BaseAggregate
+	Count int
+}
+
+func newCounterAggregate(id string) (AggregateRoot, error) {
+	return &counterAggregate{BaseAggregate: NewBaseAggregate(id, "Counter")}, nil
+}
+
+func applyCounterEvent(aggregate AggregateRoot, event EventMessage) error {
+	counter := aggregate.(*counterAggregate)
+	if event.EventType() == "Incremented" {
+		counter.Count++
+	}
+	return nil
+}
+
+func TestAggregateRegistry_Register(t *testing.T) {
+	// Arrange
+	registry := NewAggregateRegistry()
+
+	// Act
+	err := registry.Register("Counter", newCounterAggregate, WithApplyFunc(applyCounterEvent))
+	duplicateErr := registry.Register("Counter", newCounterAggregate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, duplicateErr)
+	assert.True(t, registry.IsRegistered("Counter"))
+	assert.Equal(t, []string{"Counter"}, registry.RegisteredTypes())
+	assert.Error(t, registry.Register("", newCounterAggregate))
+	assert.Error(t, registry.Register("Other", nil))
+	assert.Panics(t, func() { registry.MustRegister("Counter", newCounterAggregate) })
+}
+
+func TestAggregateRegistry_Rehydrate(t *testing.T) {
+	// Arrange
+	registry := NewAggregateRegistry()
+	registry.MustRegister("Counter", newCounterAggregate, WithApplyFunc(applyCounterEvent))
+	events := []EventMessage{
+		NewBaseEventMessage("Incremented"),
+		NewBaseEventMessage("Incremented"),
+		NewBaseEventMessage("Renamed"),
+	}
+
+	// Act
+	aggregate, err := registry.Rehydrate("Counter", "counter-1", nil, events)
+
+	// Assert
+	require.NoError(t, err)
+	counter, ok := aggregate.(*counterAggregate)
+	require.True(t, ok)
+	assert.Equal(t, 2, counter.Count)
+	assert.Equal(t, 3, counter.Version())
+	assert.Equal(t, "counter-1", counter.ID())
+	assert.Empty(t, counter.Changes())
+}
+
+func TestAggregateRegistry_UnknownType(t *testing.T) {
+	// Arrange
+	registry := NewAggregateRegistry()
+
+	// Act
+	_, err := registry.Create("Missing", "id-1")
+
+	// Assert
+	assert.True(t, IsNotFoundError(err))
+}
+
+func TestCreateAggregateInstance_DefaultRegistry(t *testing.T) {
+	// Arrange
+	require.NoError(t, DefaultAggregateRegistry().Register("DefaultCounter", newCounterAggregate, WithApplyFunc(applyCounterEvent)))
+	defer DefaultAggregateRegistry().Unregister("DefaultCounter")
+	created := 0
+	countingFactory := func(id string) (AggregateRoot, error) {
+		created++
+		return newCounterAggregate(id)
+	}
+
+	// Act
+	err := RegisterAggregateType("DefaultCounter", countingFactory)
+	aggregate, createErr := CreateAggregateInstance("DefaultCounter", "counter-2")
+	rehydrated, rehydrateErr := DefaultAggregateRegistry().Rehydrate("DefaultCounter", "counter-3", nil, []EventMessage{NewBaseEventMessage("Incremented")})
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, createErr)
+	assert.IsType(t, &counterAggregate{}, aggregate)
+	require.NoError(t, rehydrateErr)
+	assert.Equal(t, 1, rehydrated.(*counterAggregate).Count) // 앞서 등록한 apply 함수 유지
+	assert.Equal(t, 2, created)
+	assert.Error(t, RegisterAggregateType("", newCounterAggregate)) // 패닉 대신 에러
+	assert.Error(t, RegisterAggregateType("DefaultCounter", nil))
+}
+
+func TestAggregateRegistry_Replace(t *testing.T) {
+	// Arrange
+	registry := NewAggregateRegistry()
+	registry.MustRegister("Counter", newCounterAggregate)
+	events := []EventMessage{NewBaseEventMessage("Incremented")}
+
+	// Act
+	err := registry.Replace("Counter", newCounterAggregate, WithApplyFunc(applyCounterEvent))
+	aggregate, rehydrateErr := registry.Rehydrate("Counter", "counter-1", nil, events)
+	addErr := registry.Replace("Gauge", newCounterAggregate)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, rehydrateErr)
+	assert.Equal(t, 1, aggregate.(*counterAggregate).Count)
+	assert.NoError(t, addErr) // 등록되지 않은 타입은 새로 등록
+	assert.Equal(t, []string{"Counter", "Gauge"}, registry.RegisteredTypes())
+	assert.Error(t, registry.Replace("Counter", nil))
+	assert.True(t, registry.IsRegistered("Counter")) // 실패한 교체는 기존 등록을 지우지 않음
+}
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"context"
+	"cqrs"
+)
+
+// Standard context keys copied from event metadata by DefaultMetadataEnricher.
+// They are the cqrs.EventMetadata keys, so enriched events need no translation.
+const (
+	ContextSessionID     = cqrs.MetadataSessionID
+	ContextDeviceID      = cqrs.MetadataDeviceID
+	ContextPlatform      = cqrs.MetadataPlatform
+	ContextAppVersion    = cqrs.MetadataAppVersion
+	ContextUserID        = cqrs.MetadataUserID
+	ContextServerVersion = cqrs.MetadataServerVersion
+	ContextRegion        = cqrs.MetadataRegion
+)
+
+// Enricher adds context to an analytics record before sampling-approved events are queued
+type Enricher interface {
+	Enrich(ctx context.Context, source cqrs.EventMessage, event *Event) error
+}
+
+// EnricherFunc adapts a function to Enricher
+type EnricherFunc func(ctx context.Context, source cqrs.EventMessage, event *Event) error
+
+func (f EnricherFunc) Enrich(ctx context.Context, source cqrs.EventMessage, event *Event) error {
+	return f(ctx, source, event)
+}
+
+// MetadataEnricher copies the given metadata keys of the domain event into the record context
+func MetadataEnricher(keys ...string) Enricher {
+	return EnricherFunc(func(ctx context.Context, source cqrs.EventMessage, event *Event) error {
+		metadata := source.Metadata()
+		for _, key := range keys {
+			if value, exists := metadata[key]; exists {
+				event.SetContext(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// DefaultMetadataEnricher copies the standard event metadata keys
+func DefaultMetadataEnricher() Enricher {
+	return MetadataEnricher(ContextSessionID, ContextDeviceID, ContextPlatform, ContextAppVersion, ContextUserID,
+		ContextServerVersion, ContextRegion)
+}
+
+// StaticEnricher adds fixed values such as server region or build to every record
+func StaticEnricher(values map[string]interface{}) Enricher {
+	return EnricherFunc(func(ctx context.Context, source cqrs.EventMessage, event *Event) error {
+		for key, value := range values {
+			event.SetContext(key, value)
+		}
+		return nil
+	})
+}
+
+// SessionInfo describes the client session that caused an event
+type SessionInfo struct {
+	SessionID  string
+	DeviceID   string
+	Platform   string
+	AppVersion string
+}
+
+type sessionInfoKey struct{}
+
+// WithSessionInfo attaches session information to a context for SessionContextEnricher
+func WithSessionInfo(ctx context.Context, info SessionInfo) context.Context {
+	return context.WithValue(ctx, sessionInfoKey{}, info)
+}
+
+// SessionInfoFromContext returns session information attached with WithSessionInfo
+func SessionInfoFromContext(ctx context.Context) (SessionInfo, bool) {
+	info, ok := ctx.Value(sessionInfoKey{}).(SessionInfo)
+	return info, ok
+}
+
+// SessionContextEnricher copies SessionInfo from the handling context.
+// Values already taken from event metadata are not overwritten.
+func SessionContextEnricher() Enricher {
+	return EnricherFunc(func(ctx context.Context, source cqrs.EventMessage, event *Event) error {
+		info, ok := SessionInfoFromContext(ctx)
+		if !ok {
+			return nil
+		}
+
+		for key, value := range map[string]string{
+			ContextSessionID:  info.SessionID,
+			ContextDeviceID:   info.DeviceID,
+			ContextPlatform:   info.Platform,
+			ContextAppVersion: info.AppVersion,
+		} {
+			if _, exists := event.Context[key]; value != "" && !exists {
+				event.SetContext(key, value)
+			}
+		}
+		return nil
+	})
+}
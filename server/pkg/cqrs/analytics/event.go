@@ -0,0 +1,60 @@
+// Package analytics forwards domain events to analytics sinks.
+//
+// The pipeline subscribes to the event bus like any other handler, but runs
+// independently of gameplay projections: events are sampled, enriched with
+// session/device context and shipped in batches from a background worker, so a
+// slow or failing collector never blocks event processing.
+package analytics
+
+import (
+	"cqrs"
+	"encoding/json"
+	"time"
+)
+
+// Event is the analytics record shipped to sinks
+type Event struct {
+	EventID       string                 `json:"event_id"`
+	EventType     string                 `json:"event_type"`
+	AggregateID   string                 `json:"aggregate_id,omitempty"`
+	AggregateType string                 `json:"aggregate_type,omitempty"`
+	Version       int                    `json:"version,omitempty"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	SampleRate    float64                `json:"sample_rate"`
+	Properties    map[string]interface{} `json:"properties,omitempty"`
+	Context       map[string]interface{} `json:"context,omitempty"`
+}
+
+// SetContext sets a context value (session, device, region, ...)
+func (e *Event) SetContext(key string, value interface{}) {
+	if e.Context == nil {
+		e.Context = make(map[string]interface{})
+	}
+	e.Context[key] = value
+}
+
+// newEvent converts a domain event into an analytics record.
+// The payload is flattened through JSON so sinks receive plain maps.
+func newEvent(event cqrs.EventMessage, sampleRate float64) *Event {
+	record := &Event{
+		EventID:       event.EventID(),
+		EventType:     event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		Version:       event.Version(),
+		OccurredAt:    event.Timestamp(),
+		SampleRate:    sampleRate,
+	}
+
+	if data := event.EventData(); data != nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			var properties map[string]interface{}
+			if json.Unmarshal(encoded, &properties) == nil {
+				record.Properties = properties
+			} else {
+				record.Properties = map[string]interface{}{"value": data}
+			}
+		}
+	}
+	return record
+}
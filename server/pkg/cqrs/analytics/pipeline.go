@@ -0,0 +1,278 @@
+package analytics
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config configures an analytics Pipeline
+type Config struct {
+	Name          string         // Handler name (default "AnalyticsPipeline")
+	EventTypes    []string       // Event types to forward; empty forwards every event
+	Sink          Sink           // Required destination
+	Enrichers     []Enricher     // Applied in order to every sampled event
+	Sampling      []SamplingRule // Per-type sampling rules; "*" sets the default rate
+	BufferSize    int            // Queued events before new events are dropped (default 10000)
+	BatchSize     int            // Events per sink call (default 100)
+	FlushInterval time.Duration  // Maximum delay before a partial batch is sent (default 5s)
+	SendTimeout   time.Duration  // Timeout for a single sink call (default 10s)
+}
+
+// DefaultConfig returns the default pipeline settings for a sink
+func DefaultConfig(sink Sink) Config {
+	return Config{
+		Name:          "AnalyticsPipeline",
+		Sink:          sink,
+		Enrichers:     []Enricher{DefaultMetadataEnricher(), SessionContextEnricher()},
+		BufferSize:    10000,
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+		SendTimeout:   10 * time.Second,
+	}
+}
+
+// Stats reports pipeline counters
+type Stats struct {
+	Received       int64 `json:"received"`
+	Sampled        int64 `json:"sampled"` // Dropped by sampling rules
+	Dropped        int64 `json:"dropped"` // Dropped because the buffer was full
+	Sent           int64 `json:"sent"`    // Delivered to the sink
+	Failed         int64 `json:"failed"`  // Lost because the sink returned an error
+	Batches        int64 `json:"batches"`
+	EnricherErrors int64 `json:"enricher_errors"`
+}
+
+// Pipeline is an event handler that forwards sampled, enriched events to a sink.
+// Handle never blocks on the sink; delivery happens on a background worker started by Start.
+type Pipeline struct {
+	*cqrs.BaseEventHandler
+	config   Config
+	sampler  *Sampler
+	allTypes bool
+
+	queue     chan *Event
+	flushCh   chan chan struct{}
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	lifecycle sync.Mutex // Serializes Start and Stop
+	running   atomic.Bool
+	stopped   bool // Set by Stop; the sink is closed, so the pipeline cannot be restarted
+
+	received, sampled, dropped, sent, failed, batches, enricherErrors atomic.Int64
+}
+
+// NewPipeline creates a pipeline; zero config values fall back to DefaultConfig
+func NewPipeline(config Config) (*Pipeline, error) {
+	if config.Sink == nil {
+		return nil, fmt.Errorf("analytics sink is required")
+	}
+
+	defaults := DefaultConfig(config.Sink)
+	if config.Name == "" {
+		config.Name = defaults.Name
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaults.BufferSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = defaults.SendTimeout
+	}
+
+	sampler, err := NewSampler(config.Sampling...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{
+		BaseEventHandler: cqrs.NewBaseEventHandler(config.Name, cqrs.AnalyticsHandler, config.EventTypes),
+		config:           config,
+		sampler:          sampler,
+		allTypes:         len(config.EventTypes) == 0,
+		queue:            make(chan *Event, config.BufferSize),
+		flushCh:          make(chan chan struct{}),
+		stopCh:           make(chan struct{}),
+	}, nil
+}
+
+// CanHandle accepts every event when no event types are configured
+func (p *Pipeline) CanHandle(eventType string) bool {
+	return p.allTypes || p.BaseEventHandler.CanHandle(eventType)
+}
+
+// Handle samples, enriches and queues an event. It never returns sink errors,
+// so analytics failures cannot cause event bus retries for gameplay events.
+func (p *Pipeline) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	if !p.CanHandle(event.EventType()) {
+		return nil
+	}
+	p.received.Add(1)
+
+	keep, rate := p.sampler.Sample(event.EventType(), event.EventID())
+	if !keep {
+		p.sampled.Add(1)
+		return nil
+	}
+
+	record := newEvent(event, rate)
+	for _, enricher := range p.config.Enrichers {
+		if err := enricher.Enrich(ctx, event, record); err != nil {
+			p.enricherErrors.Add(1)
+			log.Printf("[Analytics] enricher failed for %s: %v", event.EventType(), err)
+		}
+	}
+
+	select {
+	case p.queue <- record:
+	default:
+		p.dropped.Add(1)
+	}
+	return nil
+}
+
+// Start launches the delivery worker. A stopped pipeline cannot be started again
+// because Stop closes its sink; create a new pipeline instead.
+func (p *Pipeline) Start(ctx context.Context) error {
+	p.lifecycle.Lock()
+	defer p.lifecycle.Unlock()
+
+	if p.stopped {
+		return fmt.Errorf("analytics pipeline %s was stopped and cannot be restarted", p.config.Name)
+	}
+	if !p.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("analytics pipeline %s is already running", p.config.Name)
+	}
+
+	p.wg.Add(1)
+	go p.run()
+	return nil
+}
+
+// Flush delivers all queued events and waits until the sink has been called
+func (p *Pipeline) Flush(ctx context.Context) error {
+	if !p.running.Load() {
+		return fmt.Errorf("analytics pipeline %s is not running", p.config.Name)
+	}
+
+	done := make(chan struct{})
+	select {
+	case p.flushCh <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop flushes queued events, stops the worker and closes the sink
+func (p *Pipeline) Stop(ctx context.Context) error {
+	p.lifecycle.Lock()
+	if !p.running.CompareAndSwap(true, false) {
+		p.lifecycle.Unlock()
+		return nil
+	}
+	p.stopped = true
+	close(p.stopCh)
+	p.lifecycle.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.config.Sink.Close()
+}
+
+// Stats returns a snapshot of the pipeline counters
+func (p *Pipeline) Stats() Stats {
+	return Stats{
+		Received:       p.received.Load(),
+		Sampled:        p.sampled.Load(),
+		Dropped:        p.dropped.Load(),
+		Sent:           p.sent.Load(),
+		Failed:         p.failed.Load(),
+		Batches:        p.batches.Load(),
+		EnricherErrors: p.enricherErrors.Load(),
+	}
+}
+
+func (p *Pipeline) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Event, 0, p.config.BatchSize)
+	send := func() {
+		if len(batch) > 0 {
+			p.send(batch)
+			batch = make([]*Event, 0, p.config.BatchSize)
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case event := <-p.queue:
+				batch = append(batch, event)
+				if len(batch) >= p.config.BatchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case event := <-p.queue:
+			batch = append(batch, event)
+			if len(batch) >= p.config.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case done := <-p.flushCh:
+			drain()
+			close(done)
+		case <-p.stopCh:
+			drain()
+			return
+		}
+	}
+}
+
+func (p *Pipeline) send(batch []*Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.SendTimeout)
+	defer cancel()
+
+	p.batches.Add(1)
+	if err := p.config.Sink.Send(ctx, batch); err != nil {
+		p.failed.Add(int64(len(batch)))
+		log.Printf("[Analytics] failed to send %d events: %v", len(batch), err)
+		return
+	}
+	p.sent.Add(int64(len(batch)))
+}
@@ -0,0 +1,220 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPayloadEvent struct {
+	cqrs.BaseEventMessage
+	Gold int `json:"gold"`
+}
+
+func (e *testPayloadEvent) EventData() interface{} {
+	return struct {
+		Gold int `json:"gold"`
+	}{e.Gold}
+}
+
+func newTestEvent(eventType string, gold int) *testPayloadEvent {
+	event := &testPayloadEvent{BaseEventMessage: *cqrs.NewBaseEventMessage(eventType), Gold: gold}
+	event.AddMetadata(ContextSessionID, "s-1")
+	event.AddMetadata(ContextDeviceID, "d-1")
+	return event
+}
+
+// recordingSink 전송된 배치를 기록하는 테스트용 싱크
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]*Event
+	err     error
+}
+
+func (s *recordingSink) Send(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+func (s *recordingSink) events() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var all []*Event
+	for _, batch := range s.batches {
+		all = append(all, batch...)
+	}
+	return all
+}
+
+func TestPipeline_EnrichesAndForwards(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sink := &recordingSink{}
+	config := DefaultConfig(sink)
+	config.Enrichers = append(config.Enrichers, StaticEnricher(map[string]interface{}{"region": "kr"}))
+	pipeline, err := NewPipeline(config)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Start(ctx))
+
+	// Act
+	require.NoError(t, pipeline.Handle(WithSessionInfo(ctx, SessionInfo{SessionID: "other", Platform: "ios"}), newTestEvent("GoldEarned", 50)))
+	require.NoError(t, pipeline.Flush(ctx))
+	require.NoError(t, pipeline.Stop(ctx))
+
+	// Assert
+	events := sink.events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "GoldEarned", events[0].EventType)
+	assert.Equal(t, float64(50), events[0].Properties["gold"])
+	assert.Equal(t, "s-1", events[0].Context[ContextSessionID])
+	assert.Equal(t, "ios", events[0].Context[ContextPlatform])
+	assert.Equal(t, "kr", events[0].Context["region"])
+	assert.Equal(t, cqrs.AnalyticsHandler, pipeline.GetHandlerType())
+}
+
+func TestPipeline_SamplingAndEventTypes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sink := &recordingSink{}
+	pipeline, err := NewPipeline(Config{
+		Sink:       sink,
+		EventTypes: []string{"GoldEarned", "TowerBuilt"},
+		Sampling:   []SamplingRule{{EventType: "TowerBuilt", Rate: 0}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Start(ctx))
+
+	// Act
+	pipeline.Handle(ctx, newTestEvent("GoldEarned", 1))
+	pipeline.Handle(ctx, newTestEvent("TowerBuilt", 1))
+	pipeline.Handle(ctx, newTestEvent("GuildCreated", 1))
+	require.NoError(t, pipeline.Stop(ctx))
+
+	// Assert
+	assert.Len(t, sink.events(), 1)
+	stats := pipeline.Stats()
+	assert.Equal(t, int64(2), stats.Received)
+	assert.Equal(t, int64(1), stats.Sampled)
+	assert.Equal(t, int64(1), stats.Sent)
+}
+
+func TestPipeline_SinkFailureDoesNotFailHandle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sink := &recordingSink{err: errors.New("collector down")}
+	pipeline, err := NewPipeline(Config{Sink: sink})
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Start(ctx))
+
+	// Act
+	handleErr := pipeline.Handle(ctx, newTestEvent("GoldEarned", 1))
+	require.NoError(t, pipeline.Stop(ctx))
+
+	// Assert
+	assert.NoError(t, handleErr)
+	assert.Equal(t, int64(1), pipeline.Stats().Failed)
+}
+
+func TestPipeline_RejectsRestartAfterStop(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pipeline, err := NewPipeline(Config{Sink: &recordingSink{}})
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Start(ctx))
+	require.NoError(t, pipeline.Stop(ctx))
+
+	// Act
+	restartErr := pipeline.Start(ctx)
+
+	// Assert - 싱크가 이미 닫혔으므로 재시작을 거부하고, 다시 Stop해도 패닉이 없어야 함
+	assert.ErrorContains(t, restartErr, "cannot be restarted")
+	assert.NotPanics(t, func() { assert.NoError(t, pipeline.Stop(ctx)) })
+	assert.Error(t, pipeline.Flush(ctx))
+}
+
+func TestPipeline_DropsWhenBufferFull(t *testing.T) {
+	// Arrange
+	pipeline, err := NewPipeline(Config{Sink: &recordingSink{}, BufferSize: 1})
+	require.NoError(t, err)
+
+	// Act (worker not started, so the queue is never drained)
+	pipeline.Handle(context.Background(), newTestEvent("GoldEarned", 1))
+	pipeline.Handle(context.Background(), newTestEvent("GoldEarned", 2))
+
+	// Assert
+	assert.Equal(t, int64(1), pipeline.Stats().Dropped)
+}
+
+func TestSampler_Deterministic(t *testing.T) {
+	// Arrange
+	sampler, err := NewSampler(SamplingRule{EventType: "*", Rate: 0.5})
+	require.NoError(t, err)
+
+	// Act
+	kept := 0
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("event-%d", i)
+		first, _ := sampler.Sample("Any", id)
+		second, _ := sampler.Sample("Any", id)
+		require.Equal(t, first, second)
+		if first {
+			kept++
+		}
+	}
+
+	// Assert
+	assert.InDelta(t, 500, kept, 100)
+	_, err = NewSampler(SamplingRule{EventType: "X", Rate: 1.5})
+	assert.Error(t, err)
+}
+
+func TestHTTPSink_Send(t *testing.T) {
+	// Arrange
+	var received []*Event
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-Api-Key")
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+	sink := NewHTTPSink(server.URL, map[string]string{"X-Api-Key": "k"})
+
+	// Act
+	err := sink.Send(context.Background(), []*Event{{EventID: "e1", EventType: "GoldEarned"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "k", apiKey)
+	require.Len(t, received, 1)
+	assert.Equal(t, "e1", received[0].EventID)
+}
+
+func TestWriterSink_Send(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+
+	// Act
+	err := sink.Send(context.Background(), []*Event{{EventID: "e1"}, {EventID: "e2"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("\n")))
+}
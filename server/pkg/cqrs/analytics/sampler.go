@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// WildcardEventType matches every event type in a SamplingRule
+const WildcardEventType = "*"
+
+// SamplingRule keeps the given fraction of events of a type (0 = drop all, 1 = keep all)
+type SamplingRule struct {
+	EventType string  `json:"event_type"`
+	Rate      float64 `json:"rate"`
+}
+
+// Sampler decides which events are forwarded.
+// Decisions are deterministic per event ID, so replays and retries sample the same events.
+type Sampler struct {
+	rates       map[string]float64
+	defaultRate float64
+}
+
+// NewSampler creates a sampler; a "*" rule overrides the default rate of 1
+func NewSampler(rules ...SamplingRule) (*Sampler, error) {
+	sampler := &Sampler{rates: make(map[string]float64), defaultRate: 1}
+
+	for _, rule := range rules {
+		if rule.Rate < 0 || rule.Rate > 1 || math.IsNaN(rule.Rate) {
+			return nil, fmt.Errorf("sampling rate for %q must be between 0 and 1: %v", rule.EventType, rule.Rate)
+		}
+		if rule.EventType == "" || rule.EventType == WildcardEventType {
+			sampler.defaultRate = rule.Rate
+			continue
+		}
+		sampler.rates[rule.EventType] = rule.Rate
+	}
+	return sampler, nil
+}
+
+// Rate returns the sampling rate applied to an event type
+func (s *Sampler) Rate(eventType string) float64 {
+	if rate, exists := s.rates[eventType]; exists {
+		return rate
+	}
+	return s.defaultRate
+}
+
+// Sample reports whether the event is kept, along with the applied rate
+func (s *Sampler) Sample(eventType, eventID string) (bool, float64) {
+	rate := s.Rate(eventType)
+	switch {
+	case rate >= 1:
+		return true, rate
+	case rate <= 0:
+		return false, rate
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(eventID))
+	return float64(mix64(hash.Sum64()))/float64(math.MaxUint64) < rate, rate
+}
+
+// mix64 spreads FNV output over the full range (splitmix64 finalizer);
+// raw FNV of similar IDs such as sequential UUIDs clusters badly
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// Sink receives batches of analytics events
+type Sink interface {
+	Send(ctx context.Context, events []*Event) error
+	Close() error
+}
+
+// SinkFunc adapts a function to Sink
+type SinkFunc func(ctx context.Context, events []*Event) error
+
+func (f SinkFunc) Send(ctx context.Context, events []*Event) error {
+	return f(ctx, events)
+}
+
+func (f SinkFunc) Close() error {
+	return nil
+}
+
+// HTTPSink posts each batch as a JSON array to an analytics collector
+type HTTPSink struct {
+	url     string
+	client  *http.Client
+	headers map[string]string
+}
+
+// NewHTTPSink creates an HTTP collector sink; headers are added to every request (e.g. API keys)
+func NewHTTPSink(url string, headers map[string]string) *HTTPSink {
+	return &HTTPSink{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		headers: headers,
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used by the sink
+func (s *HTTPSink) WithHTTPClient(client *http.Client) *HTTPSink {
+	s.client = client
+	return s
+}
+
+func (s *HTTPSink) Send(ctx context.Context, events []*Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode analytics batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send analytics batch: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("analytics collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	return nil
+}
+
+// WriterSink writes events as JSON Lines to an io.Writer
+type WriterSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewWriterSink creates a JSON Lines sink; the writer is not closed by the sink
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(w)}
+}
+
+// NewFileSink appends JSON Lines to a file, creating it if needed
+func NewFileSink(path string) (*WriterSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics file: %w", err)
+	}
+	return &WriterSink{encoder: json.NewEncoder(file), closer: file}, nil
+}
+
+func (s *WriterSink) Send(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		if err := s.encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to write analytics event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *WriterSink) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+// PublisherSink publishes each event to a watermill publisher topic.
+// Use it with watermill-kafka (or any other watermill Pub/Sub) to feed Kafka.
+type PublisherSink struct {
+	publisher message.Publisher
+	topic     string
+}
+
+// NewPublisherSink creates a watermill publisher sink
+func NewPublisherSink(publisher message.Publisher, topic string) *PublisherSink {
+	return &PublisherSink{publisher: publisher, topic: topic}
+}
+
+func (s *PublisherSink) Send(ctx context.Context, events []*Event) error {
+	messages := make([]*message.Message, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode analytics event: %w", err)
+		}
+
+		msg := message.NewMessage(watermill.NewUUID(), payload)
+		msg.Metadata.Set("event_type", event.EventType)
+		msg.Metadata.Set("event_id", event.EventID)
+		msg.SetContext(ctx)
+		messages = append(messages, msg)
+	}
+	return s.publisher.Publish(s.topic, messages...)
+}
+
+func (s *PublisherSink) Close() error {
+	return s.publisher.Close()
+}
@@ -0,0 +1,313 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommandStatus is the lifecycle state of an asynchronously dispatched command
+type CommandStatus string
+
+const (
+	CommandStatusPending   CommandStatus = "pending"
+	CommandStatusRunning   CommandStatus = "running"
+	CommandStatusCompleted CommandStatus = "completed"
+	CommandStatusFailed    CommandStatus = "failed"
+)
+
+// IsTerminal reports whether the command has finished
+func (s CommandStatus) IsTerminal() bool {
+	return s == CommandStatusCompleted || s == CommandStatusFailed
+}
+
+// CommandTicket tracks an asynchronously dispatched command until its result is ready
+type CommandTicket struct {
+	TicketID    string         `json:"ticket_id"`
+	CommandID   string         `json:"command_id"`
+	CommandType string         `json:"command_type"`
+	AggregateID string         `json:"aggregate_id"`
+	Status      CommandStatus  `json:"status"`
+	Result      *CommandResult `json:"result,omitempty"`
+	Error       string         `json:"error,omitempty"` // Error message, since CommandResult.Error does not survive serialization
+	SubmittedAt time.Time      `json:"submitted_at"`
+	StartedAt   time.Time      `json:"started_at,omitempty"`
+	CompletedAt time.Time      `json:"completed_at,omitempty"`
+}
+
+// CommandStatusStore persists command tickets so clients can poll for results
+type CommandStatusStore interface {
+	Save(ctx context.Context, ticket *CommandTicket) error
+	Get(ctx context.Context, ticketID string) (*CommandTicket, error)
+	Delete(ctx context.Context, ticketID string) error
+}
+
+// CommandCompletionListener is notified when an async command reaches a terminal status
+type CommandCompletionListener func(ticket *CommandTicket)
+
+// AsyncCommandDispatcherConfig configures an AsyncCommandDispatcher
+type AsyncCommandDispatcherConfig struct {
+	MaxConcurrent int           // Commands executed at the same time, default 8
+	Timeout       time.Duration // Per-command execution timeout, 0 means none
+}
+
+// AsyncCommandDispatcher runs heavy commands (guild merge, mass mail) in the background.
+// DispatchAsync validates the command and returns a ticket ID immediately; clients poll
+// GetStatus, block on Wait, or register a completion listener.
+// The wrapped dispatcher still serves synchronous Dispatch calls.
+type AsyncCommandDispatcher struct {
+	CommandDispatcher
+
+	store     CommandStatusStore
+	config    AsyncCommandDispatcherConfig
+	semaphore chan struct{}
+
+	mutex     sync.Mutex
+	listeners []CommandCompletionListener
+	waiters   map[string][]chan *CommandTicket
+	wg        sync.WaitGroup
+}
+
+// NewAsyncCommandDispatcher wraps dispatcher with asynchronous execution backed by store
+func NewAsyncCommandDispatcher(dispatcher CommandDispatcher, store CommandStatusStore, config AsyncCommandDispatcherConfig) *AsyncCommandDispatcher {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = 8
+	}
+	return &AsyncCommandDispatcher{
+		CommandDispatcher: dispatcher,
+		store:             store,
+		config:            config,
+		semaphore:         make(chan struct{}, config.MaxConcurrent),
+		waiters:           make(map[string][]chan *CommandTicket),
+	}
+}
+
+// OnCompleted registers a listener called after every async command finishes
+func (d *AsyncCommandDispatcher) OnCompleted(listener CommandCompletionListener) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.listeners = append(d.listeners, listener)
+}
+
+// DispatchAsync validates and enqueues the command, returning its ticket ID.
+// The command runs detached from ctx's cancellation but keeps its values.
+func (d *AsyncCommandDispatcher) DispatchAsync(ctx context.Context, command Command) (string, error) {
+	if command == nil {
+		return "", NewCQRSError(ErrCodeCommandValidation.String(), "command cannot be nil", nil)
+	}
+	if err := command.Validate(); err != nil {
+		return "", NewCQRSError(ErrCodeCommandValidation.String(), "command validation failed", err)
+	}
+
+	ticket := &CommandTicket{
+		TicketID:    uuid.NewString(),
+		CommandID:   command.CommandID(),
+		CommandType: command.CommandType(),
+		AggregateID: command.ID(),
+		Status:      CommandStatusPending,
+		SubmittedAt: time.Now(),
+	}
+	if err := d.store.Save(ctx, ticket); err != nil {
+		return "", err
+	}
+
+	d.wg.Add(1)
+	go d.execute(context.WithoutCancel(ctx), command, *ticket)
+	return ticket.TicketID, nil
+}
+
+// GetStatus returns the current ticket for polling clients
+func (d *AsyncCommandDispatcher) GetStatus(ctx context.Context, ticketID string) (*CommandTicket, error) {
+	return d.store.Get(ctx, ticketID)
+}
+
+// Wait blocks until the command finishes or ctx is done
+func (d *AsyncCommandDispatcher) Wait(ctx context.Context, ticketID string) (*CommandTicket, error) {
+	done := make(chan *CommandTicket, 1)
+	d.mutex.Lock()
+	d.waiters[ticketID] = append(d.waiters[ticketID], done)
+	d.mutex.Unlock()
+
+	// 등록 전에 이미 끝났을 수 있으므로 저장소를 한 번 확인한다
+	ticket, err := d.store.Get(ctx, ticketID)
+	if err != nil {
+		d.removeWaiter(ticketID, done)
+		return nil, err
+	}
+	if ticket.Status.IsTerminal() {
+		d.removeWaiter(ticketID, done)
+		return ticket, nil
+	}
+
+	select {
+	case ticket := <-done:
+		return ticket, nil
+	case <-ctx.Done():
+		d.removeWaiter(ticketID, done)
+		return nil, ctx.Err()
+	}
+}
+
+// Shutdown waits for in-flight commands to finish or ctx to be done
+func (d *AsyncCommandDispatcher) Shutdown(ctx context.Context) error {
+	finished := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *AsyncCommandDispatcher) execute(ctx context.Context, command Command, ticket CommandTicket) {
+	defer d.wg.Done()
+
+	d.semaphore <- struct{}{}
+	defer func() { <-d.semaphore }()
+
+	ticket.Status = CommandStatusRunning
+	ticket.StartedAt = time.Now()
+	d.store.Save(ctx, &ticket)
+
+	if d.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+		defer cancel()
+	}
+
+	result, err := d.dispatch(ctx, command)
+	ticket.Result = result
+	ticket.CompletedAt = time.Now()
+	switch {
+	case err != nil:
+		ticket.Status = CommandStatusFailed
+		ticket.Error = err.Error()
+	case result == nil:
+		ticket.Status = CommandStatusFailed
+		ticket.Error = "command handler returned no result"
+	case !result.Success || result.Error != nil:
+		ticket.Status = CommandStatusFailed
+		if result.Error != nil {
+			ticket.Error = result.Error.Error()
+		}
+	default:
+		ticket.Status = CommandStatusCompleted
+	}
+
+	d.store.Save(context.WithoutCancel(ctx), &ticket)
+	d.notify(&ticket)
+}
+
+// dispatch converts handler panics into failures so a ticket never stays running
+func (d *AsyncCommandDispatcher) dispatch(ctx context.Context, command Command) (result *CommandResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("command handler panicked: %v", r)
+		}
+	}()
+	return d.CommandDispatcher.Dispatch(ctx, command)
+}
+
+func (d *AsyncCommandDispatcher) notify(ticket *CommandTicket) {
+	d.mutex.Lock()
+	listeners := append([]CommandCompletionListener(nil), d.listeners...)
+	waiters := d.waiters[ticket.TicketID]
+	delete(d.waiters, ticket.TicketID)
+	d.mutex.Unlock()
+
+	for _, waiter := range waiters {
+		waiter <- ticket
+	}
+	for _, listener := range listeners {
+		listener(ticket)
+	}
+}
+
+func (d *AsyncCommandDispatcher) removeWaiter(ticketID string, target chan *CommandTicket) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	waiters := d.waiters[ticketID]
+	for i, waiter := range waiters {
+		if waiter == target {
+			d.waiters[ticketID] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(d.waiters[ticketID]) == 0 {
+		delete(d.waiters, ticketID)
+	}
+}
+
+// InMemoryCommandStatusStore keeps tickets in memory and expires finished ones after a TTL
+type InMemoryCommandStatusStore struct {
+	tickets map[string]*CommandTicket
+	ttl     time.Duration
+	mutex   sync.RWMutex
+}
+
+// NewInMemoryCommandStatusStore creates a store; ttl 0 keeps finished tickets forever
+func NewInMemoryCommandStatusStore(ttl time.Duration) *InMemoryCommandStatusStore {
+	return &InMemoryCommandStatusStore{
+		tickets: make(map[string]*CommandTicket),
+		ttl:     ttl,
+	}
+}
+
+func (s *InMemoryCommandStatusStore) Save(ctx context.Context, ticket *CommandTicket) error {
+	if ticket == nil || ticket.TicketID == "" {
+		return NewValidationError("ticket id is required", nil)
+	}
+
+	copied := *ticket
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tickets[ticket.TicketID] = &copied
+	return nil
+}
+
+func (s *InMemoryCommandStatusStore) Get(ctx context.Context, ticketID string) (*CommandTicket, error) {
+	s.mutex.RLock()
+	ticket, exists := s.tickets[ticketID]
+	s.mutex.RUnlock()
+
+	if !exists || s.expired(ticket) {
+		return nil, NewNotFoundError(fmt.Sprintf("command ticket not found: %s", ticketID), nil)
+	}
+	copied := *ticket
+	return &copied, nil
+}
+
+func (s *InMemoryCommandStatusStore) Delete(ctx context.Context, ticketID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.tickets, ticketID)
+	return nil
+}
+
+// PurgeExpired removes finished tickets older than the TTL and returns how many were removed
+func (s *InMemoryCommandStatusStore) PurgeExpired() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	removed := 0
+	for id, ticket := range s.tickets {
+		if s.expired(ticket) {
+			delete(s.tickets, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *InMemoryCommandStatusStore) expired(ticket *CommandTicket) bool {
+	return s.ttl > 0 && ticket.Status.IsTerminal() && time.Since(ticket.CompletedAt) > s.ttl
+}
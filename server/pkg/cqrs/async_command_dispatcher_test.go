@@ -0,0 +1,101 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAsyncTestDispatcher(t *testing.T, handle func(ctx context.Context, command Command) (*CommandResult, error)) *AsyncCommandDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = handle
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	return NewAsyncCommandDispatcher(dispatcher, NewInMemoryCommandStatusStore(0), AsyncCommandDispatcherConfig{})
+}
+
+func TestAsyncCommandDispatcher_DispatchAsync_ReturnsBeforeCompletion(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	async := newAsyncTestDispatcher(t, func(ctx context.Context, command Command) (*CommandResult, error) {
+		<-release
+		return &CommandResult{Success: true, AggregateID: command.ID(), Version: 3}, nil
+	})
+	ctx := context.Background()
+
+	// Act
+	ticketID, err := async.DispatchAsync(ctx, NewTestCommand("guild-1", "merge"))
+	require.NoError(t, err)
+	pending, err := async.GetStatus(ctx, ticketID)
+	require.NoError(t, err)
+	close(release)
+	finished, err := async.Wait(ctx, ticketID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, pending.Status.IsTerminal())
+	assert.Equal(t, CommandStatusCompleted, finished.Status)
+	assert.Equal(t, 3, finished.Result.Version)
+	assert.Equal(t, "guild-1", finished.AggregateID)
+
+	polled, err := async.GetStatus(ctx, ticketID)
+	require.NoError(t, err)
+	assert.Equal(t, CommandStatusCompleted, polled.Status)
+}
+
+func TestAsyncCommandDispatcher_DispatchAsync_RecordsFailure(t *testing.T) {
+	// Arrange
+	async := newAsyncTestDispatcher(t, func(ctx context.Context, command Command) (*CommandResult, error) {
+		return nil, errors.New("mail server unavailable")
+	})
+	notified := make(chan *CommandTicket, 1)
+	async.OnCompleted(func(ticket *CommandTicket) { notified <- ticket })
+
+	// Act
+	ticketID, err := async.DispatchAsync(context.Background(), NewTestCommand("guild-1", "mass-mail"))
+	require.NoError(t, err)
+
+	// Assert
+	select {
+	case ticket := <-notified:
+		assert.Equal(t, ticketID, ticket.TicketID)
+		assert.Equal(t, CommandStatusFailed, ticket.Status)
+		assert.Contains(t, ticket.Error, "mail server unavailable")
+	case <-time.After(time.Second):
+		t.Fatal("completion listener was not called")
+	}
+}
+
+func TestAsyncCommandDispatcher_DispatchAsync_RejectsInvalidCommand(t *testing.T) {
+	// Arrange
+	async := newAsyncTestDispatcher(t, nil)
+
+	// Act
+	_, err := async.DispatchAsync(context.Background(), NewTestCommand("", "merge"))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestInMemoryCommandStatusStore_ExpiresFinishedTickets(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryCommandStatusStore(time.Minute)
+	require.NoError(t, store.Save(ctx, &CommandTicket{TicketID: "old", Status: CommandStatusCompleted, CompletedAt: time.Now().Add(-time.Hour)}))
+	require.NoError(t, store.Save(ctx, &CommandTicket{TicketID: "running", Status: CommandStatusRunning}))
+
+	// Act
+	removed := store.PurgeExpired()
+
+	// Assert
+	assert.Equal(t, 1, removed)
+	_, err := store.Get(ctx, "old")
+	assert.True(t, IsNotFoundError(err))
+	_, err = store.Get(ctx, "running")
+	assert.NoError(t, err)
+}
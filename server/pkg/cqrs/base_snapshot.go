@@ -58,7 +58,7 @@ func (s *InMemorySnapshotStore) Load(ctx context.Context, aggregateID string) (S
 		}
 	}
 
-	return nil, NewCQRSError(ErrCodeSnapshotValidationFailed.String(), fmt.Sprintf("snapshot not found for aggregate: %s", aggregateID), ErrSnapshotNotFound)
+	return nil, NewCQRSError(ErrCodeSnapshotNotFound.String(), fmt.Sprintf("snapshot not found for aggregate: %s", aggregateID), ErrSnapshotNotFound)
 }
 
 func (s *InMemorySnapshotStore) Delete(ctx context.Context, aggregateID string) error {
@@ -77,7 +77,7 @@ func (s *InMemorySnapshotStore) Delete(ctx context.Context, aggregateID string)
 		}
 	}
 
-	return NewCQRSError(ErrCodeSnapshotValidationFailed.String(), fmt.Sprintf("snapshot not found for aggregate: %s", aggregateID), ErrSnapshotNotFound)
+	return NewCQRSError(ErrCodeSnapshotNotFound.String(), fmt.Sprintf("snapshot not found for aggregate: %s", aggregateID), ErrSnapshotNotFound)
 }
 
 func (s *InMemorySnapshotStore) Exists(ctx context.Context, aggregateID string) bool {
@@ -148,7 +148,7 @@ func (s *InMemorySnapshotStore) LoadByType(ctx context.Context, aggregateID, agg
 		return snapshot, nil
 	}
 
-	return nil, NewCQRSError(ErrCodeSnapshotValidationFailed.String(), fmt.Sprintf("snapshot not found for aggregate: %s:%s", aggregateType, aggregateID), ErrSnapshotNotFound)
+	return nil, NewCQRSError(ErrCodeSnapshotNotFound.String(), fmt.Sprintf("snapshot not found for aggregate: %s:%s", aggregateType, aggregateID), ErrSnapshotNotFound)
 }
 
 // GetSnapshotsByType returns all snapshots of a specific aggregate type
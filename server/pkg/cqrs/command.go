@@ -107,16 +107,114 @@ type Command interface {
 // Usage patterns:
 //   - Check Success field first to determine outcome
 //   - Use Events for event sourcing and projection updates
+//   - Use ProducedEvents when the result crosses a process boundary (JSON-friendly)
+//   - Use Message/Warnings for user-facing feedback instead of encoding them in Data
+//   - Read Data through CommandPayload[T] to avoid unchecked type assertions
 //   - Monitor ExecutionTime for performance analysis
 //   - Use Version for optimistic concurrency control
 type CommandResult struct {
-	Success       bool           `json:"success"`        // Indicates if command executed successfully
-	Error         error          `json:"error"`          // Error details if execution failed
-	Events        []EventMessage `json:"events"`         // Events generated during command execution
-	string        string         `json:"aggregate_id"`   // ID of the aggregate that was processed
-	Version       int            `json:"version"`        // Aggregate version after command execution
-	Data          interface{}    `json:"data"`           // Optional response data (e.g., created entity ID)
-	ExecutionTime time.Duration  `json:"execution_time"` // Time taken to execute the command
+	Success        bool            `json:"success"`                   // Indicates if command executed successfully
+	Error          error           `json:"error"`                     // Error details if execution failed
+	Events         []EventMessage  `json:"events"`                    // Events generated during command execution
+	ProducedEvents []ProducedEvent `json:"produced_events,omitempty"` // Serializable summary of generated events
+	AggregateID    string          `json:"aggregate_id"`              // ID of the aggregate that was processed
+	Version        int             `json:"version"`                   // Aggregate version after command execution
+	Data           interface{}     `json:"data"`                      // Optional typed response payload (e.g., created entity view)
+	Message        string          `json:"message,omitempty"`         // Human-readable outcome message
+	Warnings       []string        `json:"warnings,omitempty"`        // Non-fatal issues detected while executing the command
+	ExecutionTime  time.Duration   `json:"execution_time"`            // Time taken to execute the command
+}
+
+// ProducedEvent identifies an event generated by a command
+type ProducedEvent struct {
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	Version   int    `json:"version"`
+}
+
+// NewCommandResult creates a successful result for the aggregate and records the produced events
+func NewCommandResult(aggregateID string, version int, events ...EventMessage) *CommandResult {
+	result := &CommandResult{
+		Success:     true,
+		AggregateID: aggregateID,
+		Version:     version,
+	}
+	result.AddEvents(events...)
+	return result
+}
+
+// NewFailedCommandResult creates a failed result carrying the given error
+func NewFailedCommandResult(err error) *CommandResult {
+	return &CommandResult{
+		Success: false,
+		Error:   err,
+	}
+}
+
+// AddEvents appends events to the result and their identifiers to ProducedEvents
+func (r *CommandResult) AddEvents(events ...EventMessage) *CommandResult {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		r.Events = append(r.Events, event)
+		r.ProducedEvents = append(r.ProducedEvents, ProducedEvent{
+			EventID:   event.EventID(),
+			EventType: event.EventType(),
+			Version:   event.Version(),
+		})
+	}
+	return r
+}
+
+// WithData sets the response payload
+func (r *CommandResult) WithData(data interface{}) *CommandResult {
+	r.Data = data
+	return r
+}
+
+// WithMessage sets the human-readable outcome message
+func (r *CommandResult) WithMessage(message string) *CommandResult {
+	r.Message = message
+	return r
+}
+
+// AddWarning records a non-fatal issue
+func (r *CommandResult) AddWarning(warning string) *CommandResult {
+	r.Warnings = append(r.Warnings, warning)
+	return r
+}
+
+// HasWarnings reports whether any warnings were recorded
+func (r *CommandResult) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// EventIDs returns the IDs of the produced events
+func (r *CommandResult) EventIDs() []string {
+	ids := make([]string, 0, len(r.ProducedEvents))
+	for _, produced := range r.ProducedEvents {
+		ids = append(ids, produced.EventID)
+	}
+	return ids
+}
+
+// EventTypes returns the types of the produced events in order
+func (r *CommandResult) EventTypes() []string {
+	types := make([]string, 0, len(r.ProducedEvents))
+	for _, produced := range r.ProducedEvents {
+		types = append(types, produced.EventType)
+	}
+	return types
+}
+
+// CommandPayload returns result.Data as T, or ErrResultTypeMismatch if it has a different type
+func CommandPayload[T any](result *CommandResult) (T, error) {
+	if result == nil {
+		var zero T
+		return zero, NewCQRSError(ErrCodeCommandValidation.String(), "command result is nil", nil)
+	}
+	return castResult[T](result.Data)
 }
 
 // CommandHandler interface for handling commands
@@ -0,0 +1,118 @@
+package cqrs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CommandDeduplicationRecord tracks one deduplication key and, once the command
+// finished, its recorded outcome
+type CommandDeduplicationRecord struct {
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"` // Hash of the command payload; reusing a key with another payload is a client error
+	Completed   bool      `json:"completed"`
+	Result      []byte    `json:"result,omitempty"` // Encoded outcome, opaque to the store
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+}
+
+// CommandDeduplicationStore remembers command keys for a limited time so retried
+// submissions are detected instead of being executed twice
+type CommandDeduplicationStore interface {
+	// Reserve claims key for ttl. If the key is already claimed it returns the
+	// existing record and false; otherwise it returns the new record and true.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*CommandDeduplicationRecord, bool, error)
+	// Complete records the outcome of a reserved key and keeps it for ttl
+	Complete(ctx context.Context, key string, result []byte, ttl time.Duration) error
+	// Release drops a reservation so the command can be submitted again
+	Release(ctx context.Context, key string) error
+}
+
+// InMemoryCommandDeduplicationStore is a process-local CommandDeduplicationStore
+// for tests and single-instance servers
+type InMemoryCommandDeduplicationStore struct {
+	mutex   sync.Mutex
+	records map[string]*inMemoryDeduplicationEntry
+	now     func() time.Time
+}
+
+type inMemoryDeduplicationEntry struct {
+	record    CommandDeduplicationRecord
+	expiresAt time.Time
+}
+
+// NewInMemoryCommandDeduplicationStore creates an in-memory deduplication store
+func NewInMemoryCommandDeduplicationStore() *InMemoryCommandDeduplicationStore {
+	return &InMemoryCommandDeduplicationStore{
+		records: make(map[string]*inMemoryDeduplicationEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *InMemoryCommandDeduplicationStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*CommandDeduplicationRecord, bool, error) {
+	if key == "" {
+		return nil, false, NewValidationError("deduplication key is required", nil)
+	}
+	if ttl <= 0 {
+		return nil, false, NewValidationError("deduplication ttl must be positive", nil)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.purgeExpired(now)
+
+	if entry, exists := s.records[key]; exists {
+		record := entry.record
+		return &record, false, nil
+	}
+
+	entry := &inMemoryDeduplicationEntry{
+		record:    CommandDeduplicationRecord{Key: key, Fingerprint: fingerprint, CreatedAt: now},
+		expiresAt: now.Add(ttl),
+	}
+	s.records[key] = entry
+	record := entry.record
+	return &record, true, nil
+}
+
+func (s *InMemoryCommandDeduplicationStore) Complete(ctx context.Context, key string, result []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return NewValidationError("deduplication ttl must be positive", nil)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	entry, exists := s.records[key]
+	if !exists || !now.Before(entry.expiresAt) {
+		// The reservation expired while the command ran; record the outcome anyway
+		entry = &inMemoryDeduplicationEntry{record: CommandDeduplicationRecord{Key: key, CreatedAt: now}}
+		s.records[key] = entry
+	}
+
+	entry.record.Completed = true
+	entry.record.Result = append([]byte(nil), result...)
+	entry.record.CompletedAt = now
+	entry.expiresAt = now.Add(ttl)
+	return nil
+}
+
+func (s *InMemoryCommandDeduplicationStore) Release(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// purgeExpired drops expired records; callers must hold the mutex
+func (s *InMemoryCommandDeduplicationStore) purgeExpired(now time.Time) {
+	for key, entry := range s.records {
+		if !now.Before(entry.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryCommandDeduplicationStore_ReserveCompleteRelease(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryCommandDeduplicationStore()
+
+	// Act
+	first, reserved, err := store.Reserve(ctx, "key-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+	pending, again, err := store.Reserve(ctx, "key-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, store.Complete(ctx, "key-1", []byte("result"), time.Hour))
+	completed, _, err := store.Reserve(ctx, "key-1", "fp-2", time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, reserved)
+	assert.Equal(t, "fp-1", first.Fingerprint)
+	assert.False(t, again)
+	assert.False(t, pending.Completed)
+	assert.True(t, completed.Completed)
+	assert.Equal(t, "fp-1", completed.Fingerprint)
+	assert.Equal(t, []byte("result"), completed.Result)
+
+	require.NoError(t, store.Release(ctx, "key-1"))
+	_, reserved, err = store.Reserve(ctx, "key-1", "fp-2", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestInMemoryCommandDeduplicationStore_Expiry(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewInMemoryCommandDeduplicationStore()
+	store.now = func() time.Time { return now }
+
+	_, _, err := store.Reserve(ctx, "key-1", "fp", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, store.Complete(ctx, "key-1", []byte("ok"), time.Hour))
+
+	// Act
+	now = now.Add(30 * time.Minute)
+	_, withinTTL, err := store.Reserve(ctx, "key-1", "fp", time.Minute)
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, afterTTL, err := store.Reserve(ctx, "key-1", "fp", time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, withinTTL)
+	assert.True(t, afterTTL)
+}
+
+func TestInMemoryCommandDeduplicationStore_Validation(t *testing.T) {
+	store := NewInMemoryCommandDeduplicationStore()
+
+	_, _, err := store.Reserve(context.Background(), "", "fp", time.Minute)
+	assert.True(t, IsValidationError(err))
+	_, _, err = store.Reserve(context.Background(), "key", "fp", 0)
+	assert.True(t, IsValidationError(err))
+}
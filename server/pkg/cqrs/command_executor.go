@@ -0,0 +1,185 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// CommandExecutorConfig configures a PartitionedCommandExecutor
+type CommandExecutorConfig struct {
+	Workers   int // Worker goroutines, default 16
+	QueueSize int // Pending commands per worker, default 256
+}
+
+// CommandExecutorMetrics reports executor activity
+type CommandExecutorMetrics struct {
+	Workers        int   `json:"workers"`
+	QueuedByWorker []int `json:"queued_by_worker"`
+	Executed       int64 `json:"executed"`
+	Panics         int64 `json:"panics"`
+}
+
+type commandJob struct {
+	ctx     context.Context
+	command Command
+	done    chan commandOutcome
+}
+
+type commandOutcome struct {
+	result *CommandResult
+	err    error
+}
+
+// PartitionedCommandExecutor runs command handlers on a worker pool.
+// Commands are partitioned by aggregate ID, so commands for the same aggregate
+// execute one at a time in arrival order while different aggregates run in parallel.
+// This removes optimistic concurrency conflicts between commands handled by the same
+// process (e.g. many players acting on one popular guild); conflicts with other
+// processes still surface as ErrConcurrencyConflict.
+//
+// It implements CommandDispatcher, so it can replace the wrapped dispatcher directly.
+type PartitionedCommandExecutor struct {
+	CommandDispatcher
+
+	queues   []chan commandJob
+	executed atomic.Int64
+	panics   atomic.Int64
+
+	mutex   sync.RWMutex
+	running bool
+	wg      sync.WaitGroup
+}
+
+// NewPartitionedCommandExecutor creates an executor around dispatcher; call Start before Dispatch
+func NewPartitionedCommandExecutor(dispatcher CommandDispatcher, config CommandExecutorConfig) *PartitionedCommandExecutor {
+	if config.Workers <= 0 {
+		config.Workers = 16
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 256
+	}
+
+	queues := make([]chan commandJob, config.Workers)
+	for i := range queues {
+		queues[i] = make(chan commandJob, config.QueueSize)
+	}
+	return &PartitionedCommandExecutor{
+		CommandDispatcher: dispatcher,
+		queues:            queues,
+	}
+}
+
+// Start launches the workers
+func (e *PartitionedCommandExecutor) Start() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.running {
+		return
+	}
+	e.running = true
+	for _, queue := range e.queues {
+		e.wg.Add(1)
+		go e.work(queue)
+	}
+}
+
+// Stop rejects new commands and waits until queued commands finish
+func (e *PartitionedCommandExecutor) Stop() {
+	e.mutex.Lock()
+	if !e.running {
+		e.mutex.Unlock()
+		return
+	}
+	e.running = false
+	for i, queue := range e.queues {
+		close(queue)
+		e.queues[i] = make(chan commandJob, cap(queue))
+	}
+	e.mutex.Unlock()
+
+	e.wg.Wait()
+}
+
+// Dispatch queues the command on its aggregate's worker and waits for the result.
+// If ctx is done while the command is still queued, the command is skipped.
+func (e *PartitionedCommandExecutor) Dispatch(ctx context.Context, command Command) (*CommandResult, error) {
+	if command == nil {
+		return e.CommandDispatcher.Dispatch(ctx, command)
+	}
+
+	job := commandJob{ctx: ctx, command: command, done: make(chan commandOutcome, 1)}
+
+	e.mutex.RLock()
+	if !e.running {
+		e.mutex.RUnlock()
+		return nil, NewCQRSError(ErrCodeCommandValidation.String(), "command executor is not running", nil)
+	}
+	queue := e.queues[e.partition(command.ID())]
+	select {
+	case queue <- job:
+	case <-ctx.Done():
+		e.mutex.RUnlock()
+		return nil, ctx.Err()
+	}
+	e.mutex.RUnlock()
+
+	select {
+	case outcome := <-job.done:
+		return outcome.result, outcome.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetMetrics returns worker and queue statistics
+func (e *PartitionedCommandExecutor) GetMetrics() CommandExecutorMetrics {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	queued := make([]int, len(e.queues))
+	for i, queue := range e.queues {
+		queued[i] = len(queue)
+	}
+	return CommandExecutorMetrics{
+		Workers:        len(e.queues),
+		QueuedByWorker: queued,
+		Executed:       e.executed.Load(),
+		Panics:         e.panics.Load(),
+	}
+}
+
+func (e *PartitionedCommandExecutor) partition(aggregateID string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(aggregateID))
+	return int(hash.Sum32() % uint32(len(e.queues)))
+}
+
+func (e *PartitionedCommandExecutor) work(queue chan commandJob) {
+	defer e.wg.Done()
+
+	for job := range queue {
+		if err := job.ctx.Err(); err != nil {
+			job.done <- commandOutcome{err: err}
+			continue
+		}
+		job.done <- e.execute(job)
+	}
+}
+
+// execute runs one command, converting a handler panic into an error so the worker survives
+func (e *PartitionedCommandExecutor) execute(job commandJob) (outcome commandOutcome) {
+	defer func() {
+		e.executed.Add(1)
+		if r := recover(); r != nil {
+			e.panics.Add(1)
+			outcome = commandOutcome{err: fmt.Errorf("command handler panicked: %v", r)}
+		}
+	}()
+
+	result, err := e.CommandDispatcher.Dispatch(job.ctx, job.command)
+	return commandOutcome{result: result, err: err}
+}
@@ -0,0 +1,98 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExecutorTestDispatcher(t *testing.T, handle func(ctx context.Context, command Command) (*CommandResult, error)) CommandDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = handle
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	return dispatcher
+}
+
+func TestPartitionedCommandExecutor_SerializesSameAggregate(t *testing.T) {
+	// Arrange
+	var mutex sync.Mutex
+	active := make(map[string]int)
+	var overlaps atomic.Int32
+	dispatcher := newExecutorTestDispatcher(t, func(ctx context.Context, command Command) (*CommandResult, error) {
+		mutex.Lock()
+		active[command.ID()]++
+		if active[command.ID()] > 1 {
+			overlaps.Add(1)
+		}
+		mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mutex.Lock()
+		active[command.ID()]--
+		mutex.Unlock()
+		return &CommandResult{Success: true, AggregateID: command.ID()}, nil
+	})
+	executor := NewPartitionedCommandExecutor(dispatcher, CommandExecutorConfig{Workers: 4})
+	executor.Start()
+	defer executor.Stop()
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := executor.Dispatch(context.Background(), NewTestCommand(fmt.Sprintf("guild-%d", i%3), "join"))
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Zero(t, overlaps.Load())
+	assert.Equal(t, int64(40), executor.GetMetrics().Executed)
+}
+
+func TestPartitionedCommandExecutor_RecoversHandlerPanic(t *testing.T) {
+	// Arrange
+	dispatcher := newExecutorTestDispatcher(t, func(ctx context.Context, command Command) (*CommandResult, error) {
+		if command.GetData() == "boom" {
+			panic("corrupted guild state")
+		}
+		return &CommandResult{Success: true}, nil
+	})
+	executor := NewPartitionedCommandExecutor(dispatcher, CommandExecutorConfig{Workers: 1})
+	executor.Start()
+	defer executor.Stop()
+
+	// Act
+	_, panicErr := executor.Dispatch(context.Background(), NewTestCommand("guild-1", "boom"))
+	result, err := executor.Dispatch(context.Background(), NewTestCommand("guild-1", "ok"))
+
+	// Assert
+	assert.ErrorContains(t, panicErr, "corrupted guild state")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(1), executor.GetMetrics().Panics)
+}
+
+func TestPartitionedCommandExecutor_RejectsWhenStopped(t *testing.T) {
+	// Arrange
+	executor := NewPartitionedCommandExecutor(newExecutorTestDispatcher(t, nil), CommandExecutorConfig{})
+
+	// Act
+	_, err := executor.Dispatch(context.Background(), NewTestCommand("guild-1", "join"))
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultCommandHistoryRetention is how long dispatched commands are kept by default
+const DefaultCommandHistoryRetention = 30 * 24 * time.Hour
+
+// DefaultCommandHistoryLimit caps query results when no limit is given
+const DefaultCommandHistoryLimit = 100
+
+// CommandRecord is the stored outcome of one dispatched command
+type CommandRecord struct {
+	CommandID     string        `json:"command_id" bson:"command_id"`
+	CommandType   string        `json:"command_type" bson:"command_type"`
+	UserID        string        `json:"user_id,omitempty" bson:"user_id,omitempty"` // Issuer
+	AggregateID   string        `json:"aggregate_id,omitempty" bson:"aggregate_id,omitempty"`
+	AggregateType string        `json:"aggregate_type,omitempty" bson:"aggregate_type,omitempty"`
+	CorrelationID string        `json:"correlation_id,omitempty" bson:"correlation_id,omitempty"`
+	Success       bool          `json:"success" bson:"success"`
+	ErrorCode     string        `json:"error_code,omitempty" bson:"error_code,omitempty"`
+	Error         string        `json:"error,omitempty" bson:"error,omitempty"`
+	Version       int           `json:"version,omitempty" bson:"version,omitempty"` // Aggregate version after the command
+	EventTypes    []string      `json:"event_types,omitempty" bson:"event_types,omitempty"`
+	IssuedAt      time.Time     `json:"issued_at" bson:"issued_at"`
+	Latency       time.Duration `json:"latency" bson:"latency"`
+}
+
+// NewCommandRecord describes a dispatched command and its outcome
+func NewCommandRecord(command Command, result *CommandResult, err error, issuedAt time.Time, latency time.Duration) CommandRecord {
+	record := CommandRecord{
+		CommandID:     command.CommandID(),
+		CommandType:   command.CommandType(),
+		UserID:        command.UserID(),
+		AggregateID:   command.ID(),
+		AggregateType: command.Type(),
+		CorrelationID: command.CorrelationID(),
+		IssuedAt:      issuedAt,
+		Latency:       latency,
+	}
+	if err == nil && result != nil {
+		record.Success = result.Success
+		record.Version = result.Version
+		record.EventTypes = result.EventTypes()
+		if result.AggregateID != "" {
+			record.AggregateID = result.AggregateID
+		}
+		err = result.Error
+	}
+	if err != nil {
+		record.Success = false
+		record.Error = err.Error()
+		var cqrsErr *CQRSError
+		if errors.As(err, &cqrsErr) {
+			record.ErrorCode = cqrsErr.Code
+		}
+	}
+	return record
+}
+
+// CommandHistoryQuery selects recorded commands; empty fields match everything
+type CommandHistoryQuery struct {
+	UserID        string    `json:"user_id,omitempty"`
+	AggregateID   string    `json:"aggregate_id,omitempty"`
+	AggregateType string    `json:"aggregate_type,omitempty"`
+	CommandType   string    `json:"command_type,omitempty"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	FailedOnly    bool      `json:"failed_only,omitempty"`
+	From          time.Time `json:"from"` // Inclusive; zero means no lower bound
+	To            time.Time `json:"to"`   // Inclusive; zero means no upper bound
+	Limit         int       `json:"limit,omitempty"`
+}
+
+// Validate validates the command history query
+func (q CommandHistoryQuery) Validate() error {
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return NewValidationError("to must not be before from", nil)
+	}
+	if q.Limit < 0 {
+		return NewValidationError("limit cannot be negative", nil)
+	}
+	return nil
+}
+
+// Matches reports whether the record satisfies every filter of the query
+func (q CommandHistoryQuery) Matches(record CommandRecord) bool {
+	switch {
+	case q.UserID != "" && record.UserID != q.UserID:
+		return false
+	case q.AggregateID != "" && record.AggregateID != q.AggregateID:
+		return false
+	case q.AggregateType != "" && record.AggregateType != q.AggregateType:
+		return false
+	case q.CommandType != "" && record.CommandType != q.CommandType:
+		return false
+	case q.CorrelationID != "" && record.CorrelationID != q.CorrelationID:
+		return false
+	case q.FailedOnly && record.Success:
+		return false
+	case !q.From.IsZero() && record.IssuedAt.Before(q.From):
+		return false
+	}
+	return q.To.IsZero() || !record.IssuedAt.After(q.To)
+}
+
+// EffectiveLimit returns the limit applied to the query
+func (q CommandHistoryQuery) EffectiveLimit() int {
+	if q.Limit <= 0 {
+		return DefaultCommandHistoryLimit
+	}
+	return q.Limit
+}
+
+// CommandStore persists dispatched commands for investigations such as "what did this
+// player do in the last hour", independently of the events the commands produced
+type CommandStore interface {
+	// Append records a dispatched command
+	Append(ctx context.Context, record CommandRecord) error
+	// Query returns matching records, newest first, up to the query's effective limit
+	Query(ctx context.Context, query CommandHistoryQuery) ([]CommandRecord, error)
+	// DeleteBefore removes records issued before cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// CommandHistoryConfig configures a CommandHistoryDispatcher
+type CommandHistoryConfig struct {
+	Store     CommandStore  // Default NewInMemoryCommandStore
+	Retention time.Duration // Records older than this are purged (default DefaultCommandHistoryRetention)
+	// FailClosed fails the dispatch when the record cannot be stored; by default the command
+	// result carries a warning so a store outage does not stop gameplay
+	FailClosed bool
+}
+
+// CommandHistoryDispatcher records every dispatched command with its issuer, target
+// aggregate, outcome and latency in a CommandStore. Dry runs (see DryRunDispatcher) are
+// previews, not player actions, and are not recorded.
+type CommandHistoryDispatcher struct {
+	CommandDispatcher
+	store      CommandStore
+	retention  time.Duration
+	failClosed bool
+	now        func() time.Time
+
+	mutex  sync.Mutex
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewCommandHistoryDispatcher wraps dispatcher with command history recording
+func NewCommandHistoryDispatcher(dispatcher CommandDispatcher, config CommandHistoryConfig) *CommandHistoryDispatcher {
+	if config.Store == nil {
+		config.Store = NewInMemoryCommandStore()
+	}
+	if config.Retention <= 0 {
+		config.Retention = DefaultCommandHistoryRetention
+	}
+	return &CommandHistoryDispatcher{
+		CommandDispatcher: dispatcher,
+		store:             config.Store,
+		retention:         config.Retention,
+		failClosed:        config.FailClosed,
+		now:               time.Now,
+	}
+}
+
+func (d *CommandHistoryDispatcher) Dispatch(ctx context.Context, command Command) (*CommandResult, error) {
+	if command == nil || IsDryRun(ctx) {
+		return d.CommandDispatcher.Dispatch(ctx, command)
+	}
+
+	issuedAt := d.now()
+	result, err := d.CommandDispatcher.Dispatch(ctx, command)
+	record := NewCommandRecord(command, result, err, issuedAt.UTC(), d.now().Sub(issuedAt))
+
+	if storeErr := d.store.Append(ctx, record); storeErr != nil {
+		if d.failClosed && err == nil {
+			return result, NewInfrastructureError(ErrCodeRepositoryError, "failed to record command history", storeErr)
+		}
+		if result != nil {
+			result.AddWarning("command history not recorded: " + storeErr.Error())
+		}
+	}
+	return result, err
+}
+
+// History returns the recorded commands matching the query, newest first
+func (d *CommandHistoryDispatcher) History(ctx context.Context, query CommandHistoryQuery) ([]CommandRecord, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return d.store.Query(ctx, query)
+}
+
+// PurgeExpired removes records older than the retention and returns how many were removed
+func (d *CommandHistoryDispatcher) PurgeExpired(ctx context.Context) (int64, error) {
+	removed, err := d.store.DeleteBefore(ctx, d.now().Add(-d.retention))
+	if err != nil {
+		return removed, NewInfrastructureError(ErrCodeRepositoryError, "failed to purge command history", err)
+	}
+	return removed, nil
+}
+
+// Start purges expired records every interval until ctx is cancelled or Stop is called
+func (d *CommandHistoryDispatcher) Start(ctx context.Context, interval time.Duration) {
+	d.mutex.Lock()
+	if d.stopCh != nil {
+		d.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	d.stopCh = stopCh
+	d.mutex.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				d.PurgeExpired(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the purge loop started by Start
+func (d *CommandHistoryDispatcher) Stop() {
+	d.mutex.Lock()
+	stopCh := d.stopCh
+	d.stopCh = nil
+	d.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		d.wg.Wait()
+	}
+}
+
+// InMemoryCommandStore keeps command records in memory, for tests and single-node development
+type InMemoryCommandStore struct {
+	mutex   sync.RWMutex
+	records []CommandRecord // In issue order
+}
+
+// NewInMemoryCommandStore creates an empty in-memory command store
+func NewInMemoryCommandStore() *InMemoryCommandStore {
+	return &InMemoryCommandStore{}
+}
+
+func (s *InMemoryCommandStore) Append(ctx context.Context, record CommandRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.records = append(s.records, record)
+	// Keep issue order when records arrive late
+	for i := len(s.records) - 1; i > 0 && s.records[i].IssuedAt.Before(s.records[i-1].IssuedAt); i-- {
+		s.records[i], s.records[i-1] = s.records[i-1], s.records[i]
+	}
+	return nil
+}
+
+func (s *InMemoryCommandStore) Query(ctx context.Context, query CommandHistoryQuery) ([]CommandRecord, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	limit := query.EffectiveLimit()
+	var result []CommandRecord
+	for i := len(s.records) - 1; i >= 0 && len(result) < limit; i-- {
+		if query.Matches(s.records[i]) {
+			result = append(result, s.records[i])
+		}
+	}
+	return result, nil
+}
+
+func (s *InMemoryCommandStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	keep := sort.Search(len(s.records), func(i int) bool { return !s.records[i].IssuedAt.Before(cutoff) })
+	s.records = append([]CommandRecord(nil), s.records[keep:]...)
+	return int64(keep), nil
+}
@@ -0,0 +1,128 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCommandStore 기록에 항상 실패하는 커맨드 저장소
+type failingCommandStore struct {
+	*InMemoryCommandStore
+}
+
+func (failingCommandStore) Append(ctx context.Context, record CommandRecord) error {
+	return errors.New("mongo down")
+}
+
+func newCommandHistoryTestDispatcher(t *testing.T, config CommandHistoryConfig) *CommandHistoryDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = func(ctx context.Context, command Command) (*CommandResult, error) {
+		if command.(*TestCommand).TestData == "reject" {
+			return NewFailedCommandResult(NewValidationError("guild is full", nil)), nil
+		}
+		return NewCommandResult(command.ID(), 2, NewBaseEventMessage("MemberInvited")), nil
+	}
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	return NewCommandHistoryDispatcher(dispatcher, config)
+}
+
+func playerCommand(userID, aggregateID, data string) *TestCommand {
+	command := NewTestCommand(aggregateID, data)
+	command.SetUserID(userID)
+	return command
+}
+
+func TestCommandHistoryDispatcher_RecordsAndQueries(t *testing.T) {
+	// Arrange
+	dispatcher := newCommandHistoryTestDispatcher(t, CommandHistoryConfig{})
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time {
+		now = now.Add(10 * time.Millisecond) // 호출마다 10ms 경과
+		return now
+	}
+	ctx := context.Background()
+	_, err := dispatcher.Dispatch(ctx, playerCommand("alice", "guild-1", "invite"))
+	require.NoError(t, err)
+	_, err = dispatcher.Dispatch(ctx, playerCommand("bob", "guild-1", "invite"))
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	_, err = dispatcher.Dispatch(ctx, playerCommand("alice", "guild-2", "reject"))
+	require.NoError(t, err)
+
+	// Act
+	lastHour, lastHourErr := dispatcher.History(ctx, CommandHistoryQuery{UserID: "alice", From: now.Add(-time.Hour)})
+	alice, aliceErr := dispatcher.History(ctx, CommandHistoryQuery{UserID: "alice"})
+	guild, guildErr := dispatcher.History(ctx, CommandHistoryQuery{AggregateID: "guild-1", Limit: 1})
+	failed, failedErr := dispatcher.History(ctx, CommandHistoryQuery{FailedOnly: true})
+
+	// Assert
+	require.NoError(t, lastHourErr)
+	require.Len(t, lastHour, 1)
+	assert.Equal(t, "guild-2", lastHour[0].AggregateID)
+	assert.False(t, lastHour[0].Success)
+	assert.Equal(t, ErrCodeValidationError.String(), lastHour[0].ErrorCode)
+	assert.Equal(t, 10*time.Millisecond, lastHour[0].Latency)
+	require.NoError(t, aliceErr)
+	require.Len(t, alice, 2) // 최신순
+	assert.Equal(t, "guild-1", alice[1].AggregateID)
+	assert.Equal(t, "TestAggregate", alice[1].AggregateType)
+	assert.True(t, alice[1].Success)
+	assert.Equal(t, 2, alice[1].Version)
+	assert.Equal(t, []string{"MemberInvited"}, alice[1].EventTypes)
+	require.NoError(t, guildErr)
+	require.Len(t, guild, 1)
+	assert.Equal(t, "bob", guild[0].UserID)
+	require.NoError(t, failedErr)
+	assert.Len(t, failed, 1)
+}
+
+func TestCommandHistoryDispatcher_PurgeExpired(t *testing.T) {
+	// Arrange
+	store := NewInMemoryCommandStore()
+	dispatcher := newCommandHistoryTestDispatcher(t, CommandHistoryConfig{Store: store, Retention: 24 * time.Hour})
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+	ctx := context.Background()
+	_, err := dispatcher.Dispatch(ctx, playerCommand("alice", "guild-1", "invite"))
+	require.NoError(t, err)
+	now = now.Add(20 * time.Hour)
+	_, err = dispatcher.Dispatch(ctx, playerCommand("alice", "guild-1", "invite"))
+	require.NoError(t, err)
+	now = now.Add(10 * time.Hour)
+
+	// Act
+	removed, purgeErr := dispatcher.PurgeExpired(ctx)
+	remaining, _ := store.Query(ctx, CommandHistoryQuery{})
+
+	// Assert
+	require.NoError(t, purgeErr)
+	assert.Equal(t, int64(1), removed)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 0, 0, 0, time.UTC), remaining[0].IssuedAt)
+}
+
+func TestCommandHistoryDispatcher_StoreFailure(t *testing.T) {
+	// Arrange
+	failing := failingCommandStore{NewInMemoryCommandStore()}
+	openDispatcher := newCommandHistoryTestDispatcher(t, CommandHistoryConfig{Store: failing})
+	closedDispatcher := newCommandHistoryTestDispatcher(t, CommandHistoryConfig{Store: failing, FailClosed: true})
+	ctx := context.Background()
+
+	// Act
+	openResult, openErr := openDispatcher.Dispatch(ctx, playerCommand("alice", "guild-1", "invite"))
+	_, closedErr := closedDispatcher.Dispatch(ctx, playerCommand("alice", "guild-1", "invite"))
+
+	// Assert
+	require.NoError(t, openErr)
+	assert.True(t, openResult.Success)
+	require.Len(t, openResult.Warnings, 1)
+	assert.Contains(t, openResult.Warnings[0], "mongo down")
+	assert.True(t, IsInfrastructureError(closedErr))
+}
@@ -0,0 +1,259 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Throttle scopes: whose commands share a counter
+const (
+	ThrottlePerAggregate = "aggregate" // One counter per target aggregate (e.g. per guild)
+	ThrottlePerUser      = "user"      // One counter per issuing user across aggregates
+)
+
+// CommandThrottleRule limits how often a command type may hit one aggregate (or be sent
+// by one user) within a fixed window, e.g. at most 5 InviteMember per guild per minute.
+// Rules are JSON-configurable: {"command_type":"InviteMember","aggregate_type":"Guild","limit":5,"window":"1m"}
+type CommandThrottleRule struct {
+	CommandType   string        `json:"command_type"`
+	AggregateType string        `json:"aggregate_type,omitempty"` // Optional; empty matches every aggregate type
+	Limit         int           `json:"limit"`
+	Window        time.Duration `json:"-"`
+	Per           string        `json:"per,omitempty"` // ThrottlePerAggregate (default) or ThrottlePerUser
+}
+
+type commandThrottleRuleJSON struct {
+	CommandType   string `json:"command_type"`
+	AggregateType string `json:"aggregate_type,omitempty"`
+	Limit         int    `json:"limit"`
+	Window        string `json:"window"`
+	Per           string `json:"per,omitempty"`
+}
+
+// MarshalJSON writes the window as a duration string
+func (r CommandThrottleRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(commandThrottleRuleJSON{
+		CommandType:   r.CommandType,
+		AggregateType: r.AggregateType,
+		Limit:         r.Limit,
+		Window:        r.Window.String(),
+		Per:           r.Per,
+	})
+}
+
+// UnmarshalJSON reads the window as a duration string ("30s", "1m")
+func (r *CommandThrottleRule) UnmarshalJSON(data []byte) error {
+	var raw commandThrottleRuleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	window, err := time.ParseDuration(raw.Window)
+	if err != nil {
+		return fmt.Errorf("invalid throttle window %q: %w", raw.Window, err)
+	}
+	*r = CommandThrottleRule{
+		CommandType:   raw.CommandType,
+		AggregateType: raw.AggregateType,
+		Limit:         raw.Limit,
+		Window:        window,
+		Per:           raw.Per,
+	}
+	return nil
+}
+
+// Validate checks the rule
+func (r CommandThrottleRule) Validate() error {
+	if r.CommandType == "" {
+		return NewValidationError("throttle command type is required", nil)
+	}
+	if r.Limit <= 0 || r.Window <= 0 {
+		return NewValidationError(fmt.Sprintf("throttle for %s needs a positive limit and window", r.CommandType), nil)
+	}
+	if r.Per != "" && r.Per != ThrottlePerAggregate && r.Per != ThrottlePerUser {
+		return NewValidationError(fmt.Sprintf("unknown throttle scope %q", r.Per), nil)
+	}
+	return nil
+}
+
+func (r CommandThrottleRule) matches(command Command) bool {
+	return r.CommandType == command.CommandType() && (r.AggregateType == "" || r.AggregateType == command.Type())
+}
+
+// key names the counter the command increments, or "" when the command has no subject
+func (r CommandThrottleRule) key(command Command) string {
+	scope, subject := ThrottlePerAggregate, command.ID()
+	if r.Per == ThrottlePerUser {
+		scope, subject = ThrottlePerUser, command.UserID()
+	}
+	if subject == "" {
+		return ""
+	}
+	return strings.Join([]string{r.CommandType, r.AggregateType, scope, subject}, ":")
+}
+
+// ParseCommandThrottleRules parses and validates a JSON array of rules
+func ParseCommandThrottleRules(data []byte) ([]CommandThrottleRule, error) {
+	var rules []CommandThrottleRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, NewValidationError("invalid throttle rules", err)
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// ThrottleCounter counts hits in fixed windows; shared implementations (see cqrsx.RedisThrottleCounter)
+// make limits hold across server instances
+type ThrottleCounter interface {
+	// Increment counts one hit on key and returns the count in the current window and the
+	// time until the window resets
+	Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+}
+
+// InMemoryThrottleCounter is a process-local ThrottleCounter for tests and single-instance servers
+type InMemoryThrottleCounter struct {
+	mutex   sync.Mutex
+	windows map[string]*throttleWindow
+	now     func() time.Time
+}
+
+type throttleWindow struct {
+	count   int64
+	resetAt time.Time
+}
+
+// NewInMemoryThrottleCounter creates an empty counter
+func NewInMemoryThrottleCounter() *InMemoryThrottleCounter {
+	return &InMemoryThrottleCounter{windows: make(map[string]*throttleWindow), now: time.Now}
+}
+
+func (c *InMemoryThrottleCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	current, exists := c.windows[key]
+	if !exists || !now.Before(current.resetAt) {
+		c.evictExpired(now)
+		current = &throttleWindow{resetAt: now.Add(window)}
+		c.windows[key] = current
+	}
+	current.count++
+	return current.count, current.resetAt.Sub(now), nil
+}
+
+// evictExpired drops finished windows so idle keys do not accumulate
+func (c *InMemoryThrottleCounter) evictExpired(now time.Time) {
+	for key, window := range c.windows {
+		if !now.Before(window.resetAt) {
+			delete(c.windows, key)
+		}
+	}
+}
+
+// CommandThrottleConfig configures a ThrottlingDispatcher
+type CommandThrottleConfig struct {
+	Rules   []CommandThrottleRule
+	Counter ThrottleCounter // Default NewInMemoryThrottleCounter
+	// FailClosed rejects commands when the counter is unavailable; by default they are let
+	// through with a warning so a Redis outage does not stop gameplay
+	FailClosed bool
+}
+
+// ThrottlingDispatcher rejects commands that exceed their throttle rule with a
+// COMMAND_THROTTLED error carrying "retry_after". Commands without a matching rule, and
+// contexts marked with WithMaintenanceBypass (operator requests), are not counted.
+type ThrottlingDispatcher struct {
+	CommandDispatcher
+	counter    ThrottleCounter
+	failClosed bool
+
+	mutex sync.RWMutex
+	rules []CommandThrottleRule
+}
+
+// NewThrottlingDispatcher wraps dispatcher with command throttles
+func NewThrottlingDispatcher(dispatcher CommandDispatcher, config CommandThrottleConfig) (*ThrottlingDispatcher, error) {
+	if config.Counter == nil {
+		config.Counter = NewInMemoryThrottleCounter()
+	}
+	throttled := &ThrottlingDispatcher{CommandDispatcher: dispatcher, counter: config.Counter, failClosed: config.FailClosed}
+	if err := throttled.SetRules(config.Rules); err != nil {
+		return nil, err
+	}
+	return throttled, nil
+}
+
+// SetRules replaces the rules, e.g. after the configuration is reloaded
+func (d *ThrottlingDispatcher) SetRules(rules []CommandThrottleRule) error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.rules = append([]CommandThrottleRule(nil), rules...)
+	return nil
+}
+
+// Rules returns the active rules
+func (d *ThrottlingDispatcher) Rules() []CommandThrottleRule {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return append([]CommandThrottleRule(nil), d.rules...)
+}
+
+func (d *ThrottlingDispatcher) Dispatch(ctx context.Context, command Command) (*CommandResult, error) {
+	if command == nil || IsMaintenanceBypass(ctx) {
+		return d.CommandDispatcher.Dispatch(ctx, command)
+	}
+
+	var warnings []string
+	for _, rule := range d.Rules() {
+		if !rule.matches(command) {
+			continue
+		}
+		key := rule.key(command)
+		if key == "" {
+			continue
+		}
+		count, retryAfter, err := d.counter.Increment(ctx, key, rule.Window)
+		if err != nil {
+			if d.failClosed {
+				return nil, NewInfrastructureError(ErrCodeRepositoryError, "failed to check command throttle", err)
+			}
+			warnings = append(warnings, fmt.Sprintf("throttle check for %s skipped: %v", command.CommandType(), err))
+			continue
+		}
+		if count > int64(rule.Limit) {
+			return NewFailedCommandResult(NewThrottledError(
+				fmt.Sprintf("%s is limited to %d per %s; retry in %s", command.CommandType(), rule.Limit, rule.Window, retryAfter.Round(time.Second)),
+				retryAfter)), nil
+		}
+	}
+
+	result, err := d.CommandDispatcher.Dispatch(ctx, command)
+	if result != nil && len(warnings) > 0 {
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+	return result, err
+}
+
+// ThrottleRetryAfter returns how long the caller should wait before retrying a throttled command
+func ThrottleRetryAfter(err error) (time.Duration, bool) {
+	var cqrsErr *CQRSError
+	if !errors.As(err, &cqrsErr) || cqrsErr.Code != ErrCodeCommandThrottled.String() {
+		return 0, false
+	}
+	retryAfter, ok := cqrsErr.Context["retry_after"].(time.Duration)
+	return retryAfter, ok
+}
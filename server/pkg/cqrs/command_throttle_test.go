@@ -0,0 +1,88 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingThrottleCounter struct{}
+
+func (failingThrottleCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	return 0, 0, errors.New("redis down")
+}
+
+func newThrottleTestDispatcher(t *testing.T, config CommandThrottleConfig) *ThrottlingDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = succeedMaintenanceTest
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	throttled, err := NewThrottlingDispatcher(dispatcher, config)
+	require.NoError(t, err)
+	return throttled
+}
+
+func TestThrottlingDispatcher_LimitsPerAggregate(t *testing.T) {
+	// Arrange
+	rules, err := ParseCommandThrottleRules([]byte(`[{"command_type":"TestCommand","aggregate_type":"TestAggregate","limit":2,"window":"1m"}]`))
+	require.NoError(t, err)
+	counter := NewInMemoryThrottleCounter()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+	dispatcher := newThrottleTestDispatcher(t, CommandThrottleConfig{Rules: rules, Counter: counter})
+	ctx := context.Background()
+
+	// Act
+	var results []*CommandResult
+	for i := 0; i < 3; i++ {
+		result, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "invite"))
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+	otherGuild, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-2", "invite"))
+	require.NoError(t, err)
+	bypassed, err := dispatcher.Dispatch(WithMaintenanceBypass(ctx), NewTestCommand("guild-1", "invite"))
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	nextWindow, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "invite"))
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, results[0].Success)
+	assert.True(t, results[1].Success)
+	require.False(t, results[2].Success)
+	assert.True(t, errors.Is(results[2].Error, ErrCommandThrottled))
+	assert.True(t, IsRateLimitedError(results[2].Error))
+	retryAfter, ok := ThrottleRetryAfter(results[2].Error)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+	assert.True(t, otherGuild.Success)
+	assert.True(t, bypassed.Success)
+	assert.True(t, nextWindow.Success)
+}
+
+func TestThrottlingDispatcher_CounterFailure(t *testing.T) {
+	// Arrange
+	rules := []CommandThrottleRule{{CommandType: "TestCommand", Limit: 1, Window: time.Minute, Per: ThrottlePerUser}}
+	open := newThrottleTestDispatcher(t, CommandThrottleConfig{Rules: rules, Counter: failingThrottleCounter{}})
+	closed := newThrottleTestDispatcher(t, CommandThrottleConfig{Rules: rules, Counter: failingThrottleCounter{}, FailClosed: true})
+	command := NewTestCommand("guild-1", "invite")
+	command.SetUserID("alice")
+
+	// Act
+	allowed, openErr := open.Dispatch(context.Background(), command)
+	_, closedErr := closed.Dispatch(context.Background(), command)
+	_, invalidErr := NewThrottlingDispatcher(NewInMemoryCommandDispatcher(), CommandThrottleConfig{Rules: []CommandThrottleRule{{CommandType: "TestCommand"}}})
+
+	// Assert
+	require.NoError(t, openErr)
+	assert.True(t, allowed.Success)
+	assert.Len(t, allowed.Warnings, 1)
+	assert.True(t, IsInfrastructureError(closedErr))
+	assert.True(t, IsValidationError(invalidErr))
+}
@@ -0,0 +1,199 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// QueryMetadataPartial is set to true on composite results where optional parts failed
+const QueryMetadataPartial = "partial"
+
+// CompositeFetchFunc loads one part of a composite query result
+type CompositeFetchFunc func(ctx context.Context, query Query) (interface{}, error)
+
+// CompositePart is one section of a composite query, e.g. the guild, its members or its rank
+type CompositePart struct {
+	Name     string
+	Fetch    CompositeFetchFunc
+	Optional bool          // A failing optional part is reported in Errors instead of failing the query
+	Timeout  time.Duration // Optional deadline for this part only
+}
+
+// CompositeResult is the data of a composite query result
+type CompositeResult struct {
+	Parts  map[string]interface{} `json:"parts"`
+	Errors map[string]string      `json:"errors,omitempty"` // Failed optional parts by name
+}
+
+// Partial reports whether any optional part failed
+func (r *CompositeResult) Partial() bool {
+	return len(r.Errors) > 0
+}
+
+// CompositeValue returns a part of the result as T
+func CompositeValue[T any](result *CompositeResult, name string) (T, bool) {
+	var zero T
+	if result == nil {
+		return zero, false
+	}
+	value, ok := result.Parts[name].(T)
+	return value, ok
+}
+
+// CompositeQueryHandler answers one query by fetching several read models in parallel and
+// returning them together, so a client screen needs one round trip instead of one per model.
+// A failing required part cancels the others and fails the query; failing optional parts
+// are left out and listed in CompositeResult.Errors.
+type CompositeQueryHandler struct {
+	*BaseQueryHandler
+	parts []CompositePart
+}
+
+// NewCompositeQueryHandler creates a handler for queryType composed of parts
+func NewCompositeQueryHandler(name, queryType string, parts ...CompositePart) (*CompositeQueryHandler, error) {
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		if part.Name == "" || part.Fetch == nil {
+			return nil, NewCQRSError(ErrCodeQueryValidation.String(), "composite part needs a name and a fetch function", nil)
+		}
+		if seen[part.Name] {
+			return nil, NewCQRSError(ErrCodeQueryValidation.String(), fmt.Sprintf("composite part %s declared twice", part.Name), nil)
+		}
+		seen[part.Name] = true
+	}
+	return &CompositeQueryHandler{
+		BaseQueryHandler: NewBaseQueryHandler(name, []string{queryType}),
+		parts:            parts,
+	}, nil
+}
+
+// RegisterCompositeQuery registers a composite handler for queryType with the dispatcher
+func RegisterCompositeQuery(dispatcher QueryDispatcher, queryType string, parts ...CompositePart) error {
+	handler, err := NewCompositeQueryHandler(queryType+"Handler", queryType, parts...)
+	if err != nil {
+		return err
+	}
+	return dispatcher.RegisterHandler(queryType, handler)
+}
+
+type compositeOutcome struct {
+	part  CompositePart
+	value interface{}
+	err   error
+}
+
+// Handle fetches every part concurrently
+func (h *CompositeQueryHandler) Handle(ctx context.Context, query Query) (*QueryResult, error) {
+	if err := query.Validate(); err != nil {
+		return &QueryResult{Success: false, Error: err}, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan compositeOutcome, len(h.parts))
+	var wg sync.WaitGroup
+	for _, part := range h.parts {
+		wg.Add(1)
+		go func(part CompositePart) {
+			defer wg.Done()
+			value, err := h.fetch(ctx, query, part)
+			if err != nil && !part.Optional {
+				cancel() // The query fails anyway; stop the other parts
+			}
+			outcomes <- compositeOutcome{part: part, value: value, err: err}
+		}(part)
+	}
+	wg.Wait()
+	close(outcomes)
+
+	result := &CompositeResult{Parts: make(map[string]interface{}, len(h.parts))}
+	var requiredErr error
+	for outcome := range outcomes {
+		switch {
+		case outcome.err == nil:
+			result.Parts[outcome.part.Name] = outcome.value
+		case outcome.part.Optional:
+			if result.Errors == nil {
+				result.Errors = make(map[string]string)
+			}
+			result.Errors[outcome.part.Name] = outcome.err.Error()
+		case requiredErr == nil || (isContextError(requiredErr) && !isContextError(outcome.err)):
+			// Prefer the error that caused the cancellation over the cancellations it caused
+			requiredErr = outcome.err
+		}
+	}
+	if requiredErr != nil {
+		return &QueryResult{Success: false, Error: requiredErr}, nil
+	}
+
+	queryResult := &QueryResult{Success: true, Data: result}
+	if result.Partial() {
+		queryResult.SetMetadata(QueryMetadataPartial, true)
+	}
+	return queryResult, nil
+}
+
+func (h *CompositeQueryHandler) fetch(ctx context.Context, query Query, part CompositePart) (value interface{}, err error) {
+	if part.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, part.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("composite part %s panicked: %v", part.Name, recovered)
+		}
+	}()
+	return part.Fetch(ctx, query)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// ReadModelPart fetches one read model whose ID is derived from the query
+func ReadModelPart(name string, store ReadStore, modelType string, id func(query Query) string) CompositePart {
+	return CompositePart{
+		Name: name,
+		Fetch: func(ctx context.Context, query Query) (interface{}, error) {
+			return store.GetByID(ctx, id(query), modelType)
+		},
+	}
+}
+
+// QueryPart fetches the data of another query dispatched through dispatcher, e.g. a
+// leaderboard rank served by a different handler
+func QueryPart(name string, dispatcher QueryDispatcher, build func(query Query) Query) CompositePart {
+	return CompositePart{
+		Name: name,
+		Fetch: func(ctx context.Context, query Query) (interface{}, error) {
+			result, err := dispatcher.Dispatch(ctx, build(query))
+			if err != nil {
+				return nil, err
+			}
+			if !result.Success {
+				if result.Error != nil {
+					return nil, result.Error
+				}
+				return nil, fmt.Errorf("query for composite part %s failed", name)
+			}
+			return result.Data, nil
+		},
+	}
+}
+
+// AsOptional marks the part as optional
+func (p CompositePart) AsOptional() CompositePart {
+	p.Optional = true
+	return p
+}
+
+// WithTimeout sets a deadline for this part only
+func (p CompositePart) WithTimeout(timeout time.Duration) CompositePart {
+	p.Timeout = timeout
+	return p
+}
@@ -0,0 +1,131 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func guildIDCriteria(query Query) string {
+	return query.GetCriteria().(map[string]interface{})["guild_id"].(string)
+}
+
+func newGuildOverviewTestStore(t *testing.T) *InMemoryReadStore {
+	t.Helper()
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	require.NoError(t, store.Save(ctx, NewBaseReadModel("guild-1", "GuildView", map[string]interface{}{"name": "Allies"})))
+	require.NoError(t, store.Save(ctx, NewBaseReadModel("guild-1", "MemberListView", map[string]interface{}{"members": []string{"alice", "bob"}})))
+	return store
+}
+
+func TestCompositeQueryHandler_ReportsFailedOptionalParts(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newGuildOverviewTestStore(t)
+	dispatcher := NewInMemoryQueryDispatcher()
+	// 순위 쿼리 핸들러는 등록하지 않아 선택 파트가 실패함
+	require.NoError(t, RegisterCompositeQuery(dispatcher, "GetGuildOverview",
+		ReadModelPart("guild", store, "GuildView", guildIDCriteria),
+		ReadModelPart("members", store, "MemberListView", guildIDCriteria),
+		QueryPart("rank", dispatcher, func(query Query) Query {
+			return NewBaseQuery("GetGuildRank", query.GetCriteria())
+		}).AsOptional(),
+	))
+
+	// Act
+	result, err := dispatcher.Dispatch(ctx, NewBaseQuery("GetGuildOverview", map[string]interface{}{"guild_id": "guild-1"}))
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	overview := result.Data.(*CompositeResult)
+	guild, ok := CompositeValue[ReadModel](overview, "guild")
+	require.True(t, ok)
+	assert.Equal(t, "GuildView", guild.GetType())
+	assert.Contains(t, overview.Parts, "members")
+	assert.NotContains(t, overview.Parts, "rank")
+	assert.Contains(t, overview.Errors, "rank")
+	assert.Equal(t, true, result.Metadata[QueryMetadataPartial])
+}
+
+func TestCompositeQueryHandler_RequiredFailureCancelsOtherParts(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newGuildOverviewTestStore(t)
+	cancelled := make(chan struct{})
+	handler, err := NewCompositeQueryHandler("GuildOverviewHandler", "GetGuildOverview",
+		ReadModelPart("guild", store, "GuildView", guildIDCriteria),
+		CompositePart{Name: "rank", Fetch: func(ctx context.Context, query Query) (interface{}, error) {
+			// 필수 파트 실패로 취소될 때까지 대기
+			select {
+			case <-ctx.Done():
+				close(cancelled)
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return 1, nil
+			}
+		}},
+	)
+	require.NoError(t, err)
+
+	// Act
+	result, err := handler.Handle(ctx, NewBaseQuery("GetGuildOverview", map[string]interface{}{"guild_id": "guild-9"}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, IsNotFoundError(result.Error))
+	select {
+	case <-cancelled:
+	default:
+		t.Fatal("rank part was not cancelled")
+	}
+}
+
+func TestCompositeQueryHandler_OptionalTimeoutAndPanicAreReported(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newGuildOverviewTestStore(t)
+	handler, err := NewCompositeQueryHandler("GuildOverviewHandler", "GetGuildOverview",
+		ReadModelPart("guild", store, "GuildView", guildIDCriteria),
+		CompositePart{Name: "rank", Fetch: func(ctx context.Context, query Query) (interface{}, error) {
+			<-ctx.Done() // 파트 제한 시간까지 응답하지 않음
+			return nil, ctx.Err()
+		}}.AsOptional().WithTimeout(10*time.Millisecond),
+		CompositePart{Name: "badges", Fetch: func(ctx context.Context, query Query) (interface{}, error) {
+			panic("badge service exploded")
+		}}.AsOptional(),
+	)
+	require.NoError(t, err)
+
+	// Act
+	result, err := handler.Handle(ctx, NewBaseQuery("GetGuildOverview", map[string]interface{}{"guild_id": "guild-1"}))
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	overview := result.Data.(*CompositeResult)
+	assert.Contains(t, overview.Parts, "guild")
+	assert.Contains(t, overview.Errors["rank"], context.DeadlineExceeded.Error())
+	assert.Contains(t, overview.Errors["badges"], "panicked: badge service exploded")
+	assert.Equal(t, true, result.Metadata[QueryMetadataPartial])
+}
+
+func TestNewCompositeQueryHandler_RejectsInvalidParts(t *testing.T) {
+	// Arrange
+	fetch := func(ctx context.Context, query Query) (interface{}, error) { return nil, nil }
+
+	// Act
+	_, unnamedErr := NewCompositeQueryHandler("h", "q", CompositePart{Fetch: fetch})
+	_, noFetchErr := NewCompositeQueryHandler("h", "q", CompositePart{Name: "guild"})
+	_, duplicateErr := NewCompositeQueryHandler("h", "q", CompositePart{Name: "guild", Fetch: fetch}, CompositePart{Name: "guild", Fetch: fetch})
+
+	// Assert
+	assert.Error(t, unnamedErr)
+	assert.Error(t, noFetchErr)
+	assert.Error(t, duplicateErr)
+}
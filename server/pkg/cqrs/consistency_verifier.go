@@ -0,0 +1,298 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConsistencyViolationEventType is published for every inconsistency the verifier finds
+const ConsistencyViolationEventType = "ConsistencyViolation"
+
+// DefaultConsistencyPageSize is how many state-store aggregates are verified per FindBy page
+const DefaultConsistencyPageSize = 100
+
+// ConsistencyViolationKind classifies a mismatch between the event stream, the snapshot
+// and the state store of one aggregate
+type ConsistencyViolationKind string
+
+const (
+	// ViolationSnapshotAhead: the snapshot claims a version the event stream never reached
+	ViolationSnapshotAhead ConsistencyViolationKind = "snapshot_ahead"
+	// ViolationStateAhead: the state store holds changes without matching events
+	ViolationStateAhead ConsistencyViolationKind = "state_ahead"
+	// ViolationStateBehind: events were stored but the state store missed them
+	ViolationStateBehind ConsistencyViolationKind = "state_behind"
+	// ViolationStateMismatch: versions agree but ValidateConsistency found different state
+	ViolationStateMismatch ConsistencyViolationKind = "state_mismatch"
+)
+
+// ConsistencyViolation reports one inconsistent aggregate. Versions are -1 when the
+// storage has nothing for the aggregate.
+type ConsistencyViolation struct {
+	AggregateID     string                   `json:"aggregate_id"`
+	AggregateType   string                   `json:"aggregate_type"`
+	Kind            ConsistencyViolationKind `json:"kind"`
+	EventVersion    int                      `json:"event_version"`
+	SnapshotVersion int                      `json:"snapshot_version"`
+	StateVersion    int                      `json:"state_version"`
+	Detail          string                   `json:"detail,omitempty"`
+	DetectedAt      time.Time                `json:"detected_at"`
+	Repaired        bool                     `json:"repaired"`
+	RepairError     string                   `json:"repair_error,omitempty"`
+}
+
+// ConsistencyViolationEvent carries a ConsistencyViolation on the event bus for alerting
+type ConsistencyViolationEvent struct {
+	*BaseEventMessage
+	Violation ConsistencyViolation `json:"violation"`
+}
+
+// NewConsistencyViolationEvent creates a ConsistencyViolation event for the inconsistent aggregate
+func NewConsistencyViolationEvent(violation ConsistencyViolation) *ConsistencyViolationEvent {
+	event := &ConsistencyViolationEvent{BaseEventMessage: NewBaseEventMessage(ConsistencyViolationEventType), Violation: violation}
+	event.setAggregateInfo(violation.AggregateID, violation.AggregateType, 1)
+	return event
+}
+
+func (e *ConsistencyViolationEvent) EventData() interface{} {
+	return e.Violation
+}
+
+// ConsistencyTarget is one hybrid repository to verify
+type ConsistencyTarget struct {
+	AggregateType string
+	Repository    HybridRepository
+	// PageSize for walking the state store with FindBy (default DefaultConsistencyPageSize)
+	PageSize int
+	// Deep also calls ValidateConsistency when all versions agree; it replays events, so it is
+	// much more expensive than the version comparison
+	Deep bool
+}
+
+// ConsistencyReport summarizes one verification run
+type ConsistencyReport struct {
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Checked    int                    `json:"checked"`
+	Violations []ConsistencyViolation `json:"violations"`
+	Errors     []string               `json:"errors,omitempty"` // Aggregates or pages that could not be checked
+}
+
+// ConsistencyVerifier periodically compares, per aggregate, the last event version, the
+// snapshot version and the state-store version of hybrid repositories. Each mismatch is
+// returned in the report and published as a ConsistencyViolation event; with AutoRepair
+// the verifier resyncs the state store from the events (SyncStateFromEvents) and drops
+// snapshots that are ahead of the stream.
+type ConsistencyVerifier struct {
+	targets  []ConsistencyTarget
+	eventBus EventBus // Optional; violations are only reported when nil
+
+	// AutoRepair runs SyncStateFromEvents / DeleteSnapshot for detected violations
+	AutoRepair bool
+
+	now func() time.Time
+
+	mutex      sync.Mutex
+	lastReport *ConsistencyReport
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewConsistencyVerifier creates a verifier for the given targets
+func NewConsistencyVerifier(targets []ConsistencyTarget, eventBus EventBus) (*ConsistencyVerifier, error) {
+	for _, target := range targets {
+		if target.AggregateType == "" || target.Repository == nil {
+			return nil, NewValidationError("consistency target requires an aggregate type and a hybrid repository", nil)
+		}
+	}
+	return &ConsistencyVerifier{targets: targets, eventBus: eventBus, now: time.Now}, nil
+}
+
+// LastReport returns the report of the most recent run (nil before the first run)
+func (v *ConsistencyVerifier) LastReport() *ConsistencyReport {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.lastReport
+}
+
+// Verify checks every aggregate in the targets' state stores and returns the report.
+// It only fails when the context is cancelled or a violation cannot be published;
+// per-aggregate failures are collected in the report.
+func (v *ConsistencyVerifier) Verify(ctx context.Context) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{StartedAt: v.now()}
+	for _, target := range v.targets {
+		if err := v.verifyTarget(ctx, target, report); err != nil {
+			return report, err
+		}
+	}
+	report.FinishedAt = v.now()
+
+	v.mutex.Lock()
+	v.lastReport = report
+	v.mutex.Unlock()
+	return report, nil
+}
+
+func (v *ConsistencyVerifier) verifyTarget(ctx context.Context, target ConsistencyTarget, report *ConsistencyReport) error {
+	pageSize := target.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultConsistencyPageSize
+	}
+
+	for offset := 0; ; offset += pageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := target.Repository.FindBy(ctx, QueryCriteria{SortBy: "id", SortOrder: Ascending, Limit: pageSize, Offset: offset})
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: failed to list state store at offset %d: %v", target.AggregateType, offset, err))
+			return nil
+		}
+		for _, aggregate := range page {
+			report.Checked++
+			violation, err := v.verifyAggregate(ctx, target, aggregate)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s/%s: %v", target.AggregateType, aggregate.ID(), err))
+				continue
+			}
+			if violation == nil {
+				continue
+			}
+			if v.AutoRepair {
+				v.repair(ctx, target, violation)
+			}
+			report.Violations = append(report.Violations, *violation)
+			if err := v.publish(ctx, *violation); err != nil {
+				return err
+			}
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
+
+// verifyAggregate returns the violation of one aggregate, or nil when it is consistent
+func (v *ConsistencyVerifier) verifyAggregate(ctx context.Context, target ConsistencyTarget, aggregate AggregateRoot) (*ConsistencyViolation, error) {
+	id := aggregate.ID()
+	eventVersion, err := target.Repository.GetLastEventVersion(ctx, id)
+	if IsNotFoundError(err) {
+		eventVersion, err = -1, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last event version: %w", err)
+	}
+
+	snapshotVersion := -1
+	snapshot, err := target.Repository.GetSnapshot(ctx, id)
+	switch {
+	case err == nil && snapshot != nil:
+		snapshotVersion = snapshot.Version()
+	case err != nil && !IsNotFoundError(err):
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	violation := &ConsistencyViolation{
+		AggregateID:     id,
+		AggregateType:   target.AggregateType,
+		EventVersion:    eventVersion,
+		SnapshotVersion: snapshotVersion,
+		StateVersion:    aggregate.Version(),
+		DetectedAt:      v.now(),
+	}
+	switch {
+	case snapshotVersion > eventVersion:
+		violation.Kind = ViolationSnapshotAhead
+		violation.Detail = fmt.Sprintf("snapshot version %d is beyond the last event version %d", snapshotVersion, eventVersion)
+	case violation.StateVersion > eventVersion:
+		violation.Kind = ViolationStateAhead
+		violation.Detail = fmt.Sprintf("state version %d is beyond the last event version %d", violation.StateVersion, eventVersion)
+	case violation.StateVersion < eventVersion:
+		violation.Kind = ViolationStateBehind
+		violation.Detail = fmt.Sprintf("state version %d is behind the last event version %d", violation.StateVersion, eventVersion)
+	case target.Deep:
+		if err := target.Repository.ValidateConsistency(ctx, id); err != nil {
+			violation.Kind = ViolationStateMismatch
+			violation.Detail = err.Error()
+			break
+		}
+		return nil, nil
+	default:
+		return nil, nil
+	}
+	return violation, nil
+}
+
+// repair resolves a violation: snapshots ahead of the stream are deleted (the aggregate
+// reloads from events), and the state store is rebuilt from the events otherwise
+func (v *ConsistencyVerifier) repair(ctx context.Context, target ConsistencyTarget, violation *ConsistencyViolation) {
+	var err error
+	switch violation.Kind {
+	case ViolationSnapshotAhead:
+		err = target.Repository.DeleteSnapshot(ctx, violation.AggregateID)
+		if err == nil && violation.StateVersion != violation.EventVersion {
+			err = target.Repository.SyncStateFromEvents(ctx, violation.AggregateID)
+		}
+	default:
+		err = target.Repository.SyncStateFromEvents(ctx, violation.AggregateID)
+	}
+	if err != nil {
+		violation.RepairError = err.Error()
+		return
+	}
+	violation.Repaired = true
+}
+
+func (v *ConsistencyVerifier) publish(ctx context.Context, violation ConsistencyViolation) error {
+	if v.eventBus == nil {
+		return nil
+	}
+	if err := v.eventBus.Publish(ctx, NewConsistencyViolationEvent(violation)); err != nil {
+		return NewInfrastructureError(ErrCodeEventBusError, fmt.Sprintf("failed to publish consistency violation for %s", violation.AggregateID), err)
+	}
+	return nil
+}
+
+// Start runs Verify every interval until Stop is called or ctx is cancelled
+func (v *ConsistencyVerifier) Start(ctx context.Context, interval time.Duration) {
+	v.mutex.Lock()
+	if v.stopCh != nil {
+		v.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	v.stopCh = stopCh
+	v.mutex.Unlock()
+
+	v.wg.Add(1)
+	go func() {
+		defer v.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				_, _ = v.Verify(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the verification loop started by Start
+func (v *ConsistencyVerifier) Stop() {
+	v.mutex.Lock()
+	stopCh := v.stopCh
+	v.stopCh = nil
+	v.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		v.wg.Wait()
+	}
+}
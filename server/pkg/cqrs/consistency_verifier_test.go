@@ -0,0 +1,137 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHybridRepository 애그리게이트별 이벤트/스냅샷/상태 버전을 따로 가진 하이브리드 저장소
+type fakeHybridRepository struct {
+	HybridRepository
+	ids       []string
+	events    map[string]int
+	snapshots map[string]int
+	states    map[string]int
+	mismatch  map[string]bool
+	synced    []string
+}
+
+func (r *fakeHybridRepository) FindBy(ctx context.Context, criteria QueryCriteria) ([]AggregateRoot, error) {
+	var page []AggregateRoot
+	for i := criteria.Offset; i < len(r.ids) && len(page) < criteria.Limit; i++ {
+		id := r.ids[i]
+		page = append(page, NewBaseAggregate(id, "Guild", WithOriginalVersion(r.states[id])))
+	}
+	return page, nil
+}
+
+func (r *fakeHybridRepository) GetLastEventVersion(ctx context.Context, aggregateID string) (int, error) {
+	return r.events[aggregateID], nil
+}
+
+func (r *fakeHybridRepository) GetSnapshot(ctx context.Context, aggregateID string) (SnapshotData, error) {
+	version, exists := r.snapshots[aggregateID]
+	if !exists {
+		return nil, NewNotFoundError("snapshot not found", ErrSnapshotNotFound)
+	}
+	return NewBaseSnapshotData(aggregateID, "Guild", version, map[string]interface{}{}), nil
+}
+
+func (r *fakeHybridRepository) DeleteSnapshot(ctx context.Context, aggregateID string) error {
+	delete(r.snapshots, aggregateID)
+	return nil
+}
+
+func (r *fakeHybridRepository) ValidateConsistency(ctx context.Context, aggregateID string) error {
+	if r.mismatch[aggregateID] {
+		return errors.New("member count differs from replayed state")
+	}
+	return nil
+}
+
+func (r *fakeHybridRepository) SyncStateFromEvents(ctx context.Context, aggregateID string) error {
+	r.synced = append(r.synced, aggregateID)
+	r.states[aggregateID] = r.events[aggregateID]
+	delete(r.mismatch, aggregateID)
+	return nil
+}
+
+func newFakeHybridRepository() *fakeHybridRepository {
+	return &fakeHybridRepository{
+		ids:       []string{"guild-ok", "guild-behind", "guild-ahead", "guild-snapshot", "guild-mismatch"},
+		events:    map[string]int{"guild-ok": 5, "guild-behind": 7, "guild-ahead": 3, "guild-snapshot": 4, "guild-mismatch": 2},
+		snapshots: map[string]int{"guild-ok": 5, "guild-snapshot": 6},
+		states:    map[string]int{"guild-ok": 5, "guild-behind": 6, "guild-ahead": 4, "guild-snapshot": 4, "guild-mismatch": 2},
+		mismatch:  map[string]bool{"guild-mismatch": true},
+	}
+}
+
+func TestConsistencyVerifier_ReportsViolations(t *testing.T) {
+	// Arrange
+	repository := newFakeHybridRepository()
+	bus := NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	handler := NewTestEventHandler("consistency_alerts", []string{ConsistencyViolationEventType})
+	_, err := bus.Subscribe(ConsistencyViolationEventType, handler)
+	require.NoError(t, err)
+	verifier, err := NewConsistencyVerifier([]ConsistencyTarget{
+		{AggregateType: "Guild", Repository: repository, PageSize: 2, Deep: true},
+	}, bus)
+	require.NoError(t, err)
+
+	// Act
+	report, verifyErr := verifier.Verify(context.Background())
+
+	// Assert
+	require.NoError(t, verifyErr)
+	assert.Equal(t, 5, report.Checked)
+	assert.Empty(t, report.Errors)
+	kinds := make(map[string]ConsistencyViolationKind)
+	for _, violation := range report.Violations {
+		kinds[violation.AggregateID] = violation.Kind
+		assert.False(t, violation.Repaired)
+	}
+	assert.Equal(t, map[string]ConsistencyViolationKind{
+		"guild-behind":   ViolationStateBehind,
+		"guild-ahead":    ViolationStateAhead,
+		"guild-snapshot": ViolationSnapshotAhead,
+		"guild-mismatch": ViolationStateMismatch,
+	}, kinds)
+	assert.Equal(t, 4, handler.GetHandledEventCount())
+	assert.Empty(t, repository.synced)
+	assert.Same(t, report, verifier.LastReport())
+}
+
+func TestConsistencyVerifier_AutoRepair(t *testing.T) {
+	// Arrange
+	repository := newFakeHybridRepository()
+	verifier, err := NewConsistencyVerifier([]ConsistencyTarget{
+		{AggregateType: "Guild", Repository: repository, Deep: true},
+	}, nil)
+	require.NoError(t, err)
+	verifier.AutoRepair = true
+
+	// Act
+	first, firstErr := verifier.Verify(context.Background())
+	second, secondErr := verifier.Verify(context.Background())
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.Len(t, first.Violations, 4)
+	for _, violation := range first.Violations {
+		assert.True(t, violation.Repaired, violation.AggregateID)
+	}
+	assert.ElementsMatch(t, []string{"guild-behind", "guild-ahead", "guild-mismatch"}, repository.synced) // 스냅샷만 앞선 경우는 스냅샷 삭제
+	assert.NotContains(t, repository.snapshots, "guild-snapshot")
+	require.NoError(t, secondErr)
+	assert.Empty(t, second.Violations)
+}
+
+func TestNewConsistencyVerifier_RequiresRepository(t *testing.T) {
+	_, err := NewConsistencyVerifier([]ConsistencyTarget{{AggregateType: "Guild"}}, nil)
+	assert.Error(t, err)
+}
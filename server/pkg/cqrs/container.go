@@ -0,0 +1,439 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Resolver resolves dependencies. Constructors receive one so that they can resolve
+// their own dependencies; use it with Resolve.
+type Resolver interface {
+	resolve(t reflect.Type) (interface{}, error)
+}
+
+// ComponentConstructor builds a component from its dependencies
+type ComponentConstructor[T any] func(r Resolver) (T, error)
+
+type componentProvider struct {
+	build    func(r Resolver) (interface{}, error)
+	instance interface{}
+	built    bool
+}
+
+type commandHandlerBinding struct {
+	handlerType  reflect.Type
+	commandTypes []string
+}
+
+type queryHandlerBinding struct {
+	handlerType reflect.Type
+	queryTypes  []string
+}
+
+// Container wires CQRS components from constructors instead of hand-written bootstrap code.
+// Components are singletons keyed by their declared type and built lazily, on first Resolve,
+// so registration order does not matter. Command handlers, query handlers and projections
+// are additionally bound to their dispatchers by Bootstrap.
+//
+// Usage:
+//
+//	container := cqrs.NewContainer()
+//	cqrs.ProvideValue[cqrs.EventBus](container, cqrs.NewInMemoryEventBus())
+//	cqrs.ProvideValue[cqrs.CommandDispatcher](container, cqrs.NewInMemoryCommandDispatcher())
+//	cqrs.Provide(container, func(r cqrs.Resolver) (cqrs.EventSourcedRepository, error) {
+//		return NewCargoRepository(), nil
+//	})
+//	cqrs.ProvideCommandHandler(container, func(r cqrs.Resolver) (*CargoCommandHandler, error) {
+//		repository, err := cqrs.Resolve[cqrs.EventSourcedRepository](r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return NewCargoCommandHandler(repository), nil
+//	})
+//	err := container.Bootstrap()
+type Container struct {
+	mu        sync.RWMutex
+	providers map[reflect.Type]*componentProvider
+
+	commandHandlers []commandHandlerBinding
+	queryHandlers   []queryHandlerBinding
+	projections     []reflect.Type
+	bootstrapped    bool
+
+	// buildMu serializes construction so a component is built once even under concurrent Resolve
+	buildMu sync.Mutex
+}
+
+// NewContainer creates an empty container
+func NewContainer() *Container {
+	return &Container{providers: make(map[reflect.Type]*componentProvider)}
+}
+
+func componentType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Provide registers the constructor of T. Interfaces are resolved by the exact type they
+// were provided as, so provide a repository as cqrs.EventSourcedRepository to inject it as one.
+func Provide[T any](c *Container, constructor ComponentConstructor[T]) error {
+	if constructor == nil {
+		return NewValidationError(fmt.Sprintf("constructor for %s cannot be nil", componentType[T]()), nil)
+	}
+	return c.provide(componentType[T](), func(r Resolver) (interface{}, error) {
+		return constructor(r)
+	})
+}
+
+// ProvideValue registers an already built component
+func ProvideValue[T any](c *Container, value T) error {
+	return Provide(c, func(r Resolver) (T, error) {
+		return value, nil
+	})
+}
+
+// ProvideCommandHandler registers a command handler constructor and the command types
+// Bootstrap registers it for in the CommandDispatcher. Without explicit command types,
+// the types the handler declares are used (see HandledCommandTypes).
+func ProvideCommandHandler[T CommandHandler](c *Container, constructor ComponentConstructor[T], commandTypes ...string) error {
+	if err := validateHandledTypes("command", componentType[T](), commandTypes); err != nil {
+		return err
+	}
+	if err := Provide(c, constructor); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commandHandlers = append(c.commandHandlers, commandHandlerBinding{handlerType: componentType[T](), commandTypes: commandTypes})
+	return nil
+}
+
+// ProvideQueryHandler registers a query handler constructor and the query types
+// Bootstrap registers it for in the QueryDispatcher. Without explicit query types,
+// the types the handler declares are used (see HandledQueryTypes).
+func ProvideQueryHandler[T QueryHandler](c *Container, constructor ComponentConstructor[T], queryTypes ...string) error {
+	if err := validateHandledTypes("query", componentType[T](), queryTypes); err != nil {
+		return err
+	}
+	if err := Provide(c, constructor); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queryHandlers = append(c.queryHandlers, queryHandlerBinding{handlerType: componentType[T](), queryTypes: queryTypes})
+	return nil
+}
+
+// ProvideProjection registers a projection constructor. Bootstrap registers the projection in
+// the ProjectionManager and subscribes it to the EventBus, whichever of the two are provided.
+func ProvideProjection[T Projection](c *Container, constructor ComponentConstructor[T]) error {
+	if err := Provide(c, constructor); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.projections = append(c.projections, componentType[T]())
+	return nil
+}
+
+func validateHandledTypes(kind string, handlerType reflect.Type, handledTypes []string) error {
+	for _, handledType := range handledTypes {
+		if handledType == "" {
+			return NewValidationError(fmt.Sprintf("%s handler %s has an empty %s type", kind, handlerType, kind), nil)
+		}
+	}
+	return nil
+}
+
+func (c *Container) provide(t reflect.Type, build func(r Resolver) (interface{}, error)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.bootstrapped {
+		return NewValidationError(fmt.Sprintf("cannot provide %s after bootstrap", t), nil)
+	}
+	if _, exists := c.providers[t]; exists {
+		return NewValidationError(fmt.Sprintf("%s is already provided", t), nil)
+	}
+	c.providers[t] = &componentProvider{build: build}
+	return nil
+}
+
+// Resolve returns the component provided as T, building it and its dependencies on first use
+func Resolve[T any](r Resolver) (T, error) {
+	var zero T
+	instance, err := r.resolve(componentType[T]())
+	if err != nil {
+		return zero, err
+	}
+	if instance == nil {
+		return zero, nil
+	}
+	return instance.(T), nil
+}
+
+// MustResolve is like Resolve but panics on error; intended for bootstrap code
+func MustResolve[T any](r Resolver) T {
+	instance, err := Resolve[T](r)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// Has reports whether T was provided
+func Has[T any](c *Container) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, exists := c.providers[componentType[T]()]
+	return exists
+}
+
+func (c *Container) resolve(t reflect.Type) (interface{}, error) {
+	if instance, built := c.built(t); built {
+		return instance, nil
+	}
+	c.buildMu.Lock()
+	defer c.buildMu.Unlock()
+	return (&containerResolution{container: c}).resolve(t)
+}
+
+// built returns the instance of t when it has been built
+func (c *Container) built(t reflect.Type) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	provider, exists := c.providers[t]
+	if !exists || !provider.built {
+		return nil, false
+	}
+	return provider.instance, true
+}
+
+// containerResolution is the Resolver passed to constructors; it tracks the dependency path
+// of the build in progress to report cycles
+type containerResolution struct {
+	container *Container
+	path      []reflect.Type
+}
+
+func (r *containerResolution) resolve(t reflect.Type) (interface{}, error) {
+	if instance, built := r.container.built(t); built {
+		return instance, nil
+	}
+	for i, resolving := range r.path {
+		if resolving == t {
+			return nil, NewValidationError(fmt.Sprintf("dependency cycle: %s", formatDependencyPath(append(r.path[i:], t))), nil)
+		}
+	}
+
+	r.container.mu.RLock()
+	provider, exists := r.container.providers[t]
+	r.container.mu.RUnlock()
+	if !exists {
+		message := fmt.Sprintf("%s is not provided", t)
+		if len(r.path) > 0 {
+			message += fmt.Sprintf(" (required by %s)", formatDependencyPath(r.path))
+		}
+		return nil, NewNotFoundError(message, nil)
+	}
+
+	r.path = append(r.path, t)
+	instance, err := provider.build(r)
+	r.path = r.path[:len(r.path)-1]
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s: %w", t, err)
+	}
+
+	r.container.mu.Lock()
+	provider.instance, provider.built = instance, true
+	r.container.mu.Unlock()
+	return instance, nil
+}
+
+func formatDependencyPath(path []reflect.Type) string {
+	names := make([]string, len(path))
+	for i, t := range path {
+		names[i] = t.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Bootstrap builds every handler and projection, then registers them: command handlers in the
+// provided CommandDispatcher, query handlers in the QueryDispatcher, and projections in the
+// ProjectionManager and on the EventBus. Everything is built before anything is registered,
+// so a missing dependency leaves the dispatchers untouched and Bootstrap can be retried.
+func (c *Container) Bootstrap() error {
+	c.mu.Lock()
+	if c.bootstrapped {
+		c.mu.Unlock()
+		return NewValidationError("container is already bootstrapped", nil)
+	}
+	c.bootstrapped = true
+	commandHandlers := append([]commandHandlerBinding(nil), c.commandHandlers...)
+	queryHandlers := append([]queryHandlerBinding(nil), c.queryHandlers...)
+	projectionTypes := append([]reflect.Type(nil), c.projections...)
+	c.mu.Unlock()
+
+	wiring, err := c.build(commandHandlers, queryHandlers, projectionTypes)
+	if err != nil {
+		c.mu.Lock()
+		c.bootstrapped = false
+		c.mu.Unlock()
+		return err
+	}
+	return wiring.register()
+}
+
+// containerWiring is everything Bootstrap registers, built up front
+type containerWiring struct {
+	commandDispatcher CommandDispatcher
+	commandHandlers   map[string]CommandHandler
+	commandTypes      []string // Registration order
+	queryDispatcher   QueryDispatcher
+	queryHandlers     map[string]QueryHandler
+	queryTypes        []string
+	projectionManager ProjectionManager
+	eventBus          EventBus
+	projections       []Projection
+}
+
+func (c *Container) build(commandHandlers []commandHandlerBinding, queryHandlers []queryHandlerBinding, projectionTypes []reflect.Type) (*containerWiring, error) {
+	wiring := &containerWiring{
+		commandHandlers: make(map[string]CommandHandler),
+		queryHandlers:   make(map[string]QueryHandler),
+	}
+
+	if len(commandHandlers) > 0 {
+		dispatcher, err := Resolve[CommandDispatcher](c)
+		if err != nil {
+			return nil, err
+		}
+		wiring.commandDispatcher = dispatcher
+	}
+	for _, binding := range commandHandlers {
+		instance, err := c.resolve(binding.handlerType)
+		if err != nil {
+			return nil, err
+		}
+		commandTypes := binding.commandTypes
+		if len(commandTypes) == 0 {
+			if commandTypes, err = HandledCommandTypes(instance.(CommandHandler)); err != nil {
+				return nil, err
+			}
+		}
+		for _, commandType := range commandTypes {
+			if _, exists := wiring.commandHandlers[commandType]; exists {
+				return nil, NewValidationError(fmt.Sprintf("command type %s is bound to more than one handler", commandType), nil)
+			}
+			wiring.commandHandlers[commandType] = instance.(CommandHandler)
+			wiring.commandTypes = append(wiring.commandTypes, commandType)
+		}
+	}
+
+	if len(queryHandlers) > 0 {
+		dispatcher, err := Resolve[QueryDispatcher](c)
+		if err != nil {
+			return nil, err
+		}
+		wiring.queryDispatcher = dispatcher
+	}
+	for _, binding := range queryHandlers {
+		instance, err := c.resolve(binding.handlerType)
+		if err != nil {
+			return nil, err
+		}
+		queryTypes := binding.queryTypes
+		if len(queryTypes) == 0 {
+			if queryTypes, err = HandledQueryTypes(instance.(QueryHandler)); err != nil {
+				return nil, err
+			}
+		}
+		for _, queryType := range queryTypes {
+			if _, exists := wiring.queryHandlers[queryType]; exists {
+				return nil, NewValidationError(fmt.Sprintf("query type %s is bound to more than one handler", queryType), nil)
+			}
+			wiring.queryHandlers[queryType] = instance.(QueryHandler)
+			wiring.queryTypes = append(wiring.queryTypes, queryType)
+		}
+	}
+
+	if len(projectionTypes) == 0 {
+		return wiring, nil
+	}
+	if Has[ProjectionManager](c) {
+		manager, err := Resolve[ProjectionManager](c)
+		if err != nil {
+			return nil, err
+		}
+		wiring.projectionManager = manager
+	}
+	if Has[EventBus](c) {
+		eventBus, err := Resolve[EventBus](c)
+		if err != nil {
+			return nil, err
+		}
+		wiring.eventBus = eventBus
+	}
+	if wiring.projectionManager == nil && wiring.eventBus == nil {
+		return nil, NewValidationError("projections need a ProjectionManager or an EventBus to be provided", nil)
+	}
+	for _, projectionType := range projectionTypes {
+		instance, err := c.resolve(projectionType)
+		if err != nil {
+			return nil, err
+		}
+		wiring.projections = append(wiring.projections, instance.(Projection))
+	}
+	return wiring, nil
+}
+
+func (w *containerWiring) register() error {
+	for _, commandType := range w.commandTypes {
+		handler := w.commandHandlers[commandType]
+		if err := w.commandDispatcher.RegisterHandler(commandType, handler); err != nil {
+			return fmt.Errorf("failed to register %s for %s: %w", handler.GetHandlerName(), commandType, err)
+		}
+	}
+	for _, queryType := range w.queryTypes {
+		handler := w.queryHandlers[queryType]
+		if err := w.queryDispatcher.RegisterHandler(queryType, handler); err != nil {
+			return fmt.Errorf("failed to register %s for %s: %w", handler.GetHandlerName(), queryType, err)
+		}
+	}
+	for _, projection := range w.projections {
+		if w.projectionManager != nil {
+			if err := w.projectionManager.RegisterProjection(projection); err != nil {
+				return fmt.Errorf("failed to register projection %s: %w", projection.GetProjectionName(), err)
+			}
+		}
+		if w.eventBus != nil {
+			if _, err := w.eventBus.SubscribeAll(&projectionSubscriber{projection: projection}); err != nil {
+				return fmt.Errorf("failed to subscribe projection %s: %w", projection.GetProjectionName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// projectionSubscriber feeds the events a projection can handle from the event bus
+type projectionSubscriber struct {
+	projection Projection
+}
+
+func (s *projectionSubscriber) Handle(ctx context.Context, event EventMessage) error {
+	return s.projection.Project(ctx, event)
+}
+
+func (s *projectionSubscriber) CanHandle(eventType string) bool {
+	return s.projection.CanHandle(eventType)
+}
+
+func (s *projectionSubscriber) GetHandlerName() string {
+	return s.projection.GetProjectionName()
+}
+
+func (s *projectionSubscriber) GetHandlerType() HandlerType {
+	return ProjectionHandler
+}
@@ -0,0 +1,160 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// containerCounter 핸들러들이 공유하는 의존성
+type containerCounter struct {
+	commands map[string]int
+}
+
+// containerCommandHandler 카운터에 명령을 기록하는 명령 핸들러
+type containerCommandHandler struct {
+	*BaseCommandHandler
+	counter *containerCounter
+}
+
+func (h *containerCommandHandler) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	h.counter.commands[command.CommandType()]++
+	return &CommandResult{Success: true}, nil
+}
+
+// containerQueryHandler 카운터를 돌려주는 쿼리 핸들러
+type containerQueryHandler struct {
+	*BaseQueryHandler
+	counter *containerCounter
+}
+
+func (h *containerQueryHandler) Handle(ctx context.Context, query Query) (*QueryResult, error) {
+	return &QueryResult{Success: true, Data: h.counter.commands}, nil
+}
+
+// containerProjection 받은 이벤트 타입을 기록하는 프로젝션
+type containerProjection struct {
+	*BaseProjection
+	projected []string
+}
+
+func (p *containerProjection) Project(ctx context.Context, event EventMessage) error {
+	p.projected = append(p.projected, event.EventType())
+	return nil
+}
+
+func provideContainerHandlers(t *testing.T, container *Container) {
+	t.Helper()
+	require.NoError(t, ProvideCommandHandler(container, func(r Resolver) (*containerCommandHandler, error) {
+		counter, err := Resolve[*containerCounter](r)
+		if err != nil {
+			return nil, err
+		}
+		return &containerCommandHandler{BaseCommandHandler: NewBaseCommandHandler("CounterCommands", []string{"Increment", "Decrement"}), counter: counter}, nil
+	}, "Increment", "Decrement"))
+	require.NoError(t, ProvideQueryHandler(container, func(r Resolver) (*containerQueryHandler, error) {
+		return &containerQueryHandler{BaseQueryHandler: NewBaseQueryHandler("CounterQueries", []string{"GetCounts"}), counter: MustResolve[*containerCounter](r)}, nil
+	}, "GetCounts"))
+}
+
+func TestContainer_BootstrapRegistersHandlersAndProjections(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	container := NewContainer()
+	commands := NewInMemoryCommandDispatcher()
+	queries := NewInMemoryQueryDispatcher()
+	projections := NewInMemoryProjectionManager()
+	require.NoError(t, ProvideValue[CommandDispatcher](container, commands))
+	require.NoError(t, ProvideValue[QueryDispatcher](container, queries))
+	require.NoError(t, ProvideValue[ProjectionManager](container, projections))
+	require.NoError(t, ProvideValue[EventBus](container, NewInMemoryEventBus()))
+	provideContainerHandlers(t, container)
+	require.NoError(t, ProvideProjection(container, func(r Resolver) (*containerProjection, error) {
+		return &containerProjection{BaseProjection: NewBaseProjection("CounterView", "1", []string{"Incremented"})}, nil
+	}))
+	// 핸들러보다 늦게 등록해도 처음 Resolve할 때 만들어야 합니다
+	require.NoError(t, Provide(container, func(r Resolver) (*containerCounter, error) {
+		return &containerCounter{commands: make(map[string]int)}, nil
+	}))
+
+	// Act
+	err := container.Bootstrap()
+	require.NoError(t, err)
+	_, incrementErr := commands.Dispatch(ctx, NewBaseCommand("Increment", "counter-1", "Counter", nil))
+	_, decrementErr := commands.Dispatch(ctx, NewBaseCommand("Decrement", "counter-1", "Counter", nil))
+	counts, queryErr := DispatchQuery[map[string]int](ctx, queries, NewBaseQuery("GetCounts", nil))
+	bus := MustResolve[EventBus](container)
+	require.NoError(t, bus.Publish(ctx, NewBaseEventMessage("Incremented")))
+	require.NoError(t, bus.Publish(ctx, NewBaseEventMessage("Ignored")))
+	projection := MustResolve[*containerProjection](container)
+	provideAfterErr := ProvideValue(container, "late")
+	secondBootstrapErr := container.Bootstrap()
+
+	// Assert
+	assert.NoError(t, incrementErr)
+	assert.NoError(t, decrementErr)
+	require.NoError(t, queryErr)
+	assert.Equal(t, map[string]int{"Increment": 1, "Decrement": 1}, counts)
+	assert.Same(t, MustResolve[*containerCounter](container), MustResolve[*containerCommandHandler](container).counter, "싱글톤이어야 합니다")
+	assert.Equal(t, []string{"Incremented"}, projection.projected)
+	registered, ok := projections.GetProjection("CounterView")
+	assert.True(t, ok)
+	assert.Same(t, projection, registered)
+	assert.True(t, IsValidationError(provideAfterErr))
+	assert.True(t, IsValidationError(secondBootstrapErr))
+}
+
+func TestContainer_ReportsMissingAndCyclicDependencies(t *testing.T) {
+	// Arrange
+	type serviceA struct{}
+	type serviceB struct{}
+	container := NewContainer()
+	require.NoError(t, Provide(container, func(r Resolver) (*serviceA, error) {
+		_, err := Resolve[*serviceB](r)
+		return &serviceA{}, err
+	}))
+	require.NoError(t, Provide(container, func(r Resolver) (*serviceB, error) {
+		_, err := Resolve[*serviceA](r)
+		return &serviceB{}, err
+	}))
+
+	// Act
+	_, cycleErr := Resolve[*serviceA](container)
+	_, missingErr := Resolve[*containerCounter](container)
+	duplicateErr := ProvideValue(container, &serviceA{})
+	emptyTypeErr := ProvideCommandHandler(container, func(r Resolver) (*containerCommandHandler, error) {
+		return nil, nil
+	}, "Increment", "")
+
+	// Assert
+	require.Error(t, cycleErr)
+	assert.True(t, IsValidationError(cycleErr))
+	assert.Contains(t, cycleErr.Error(), "*cqrs.serviceA -> *cqrs.serviceB -> *cqrs.serviceA")
+	assert.True(t, IsNotFoundError(missingErr))
+	assert.True(t, IsValidationError(duplicateErr))
+	assert.True(t, IsValidationError(emptyTypeErr))
+}
+
+func TestContainer_FailedBootstrapLeavesDispatchersUntouched(t *testing.T) {
+	// Arrange
+	container := NewContainer()
+	commands := NewInMemoryCommandDispatcher()
+	require.NoError(t, ProvideValue[CommandDispatcher](container, commands))
+	require.NoError(t, ProvideValue[QueryDispatcher](container, NewInMemoryQueryDispatcher()))
+	provideContainerHandlers(t, container)
+
+	// Act
+	missingErr := container.Bootstrap()
+	handlersAfterFailure := commands.GetHandlerCount()
+	require.NoError(t, ProvideValue(container, &containerCounter{commands: make(map[string]int)}))
+	retryErr := container.Bootstrap()
+
+	// Assert
+	assert.True(t, IsNotFoundError(missingErr))
+	assert.Contains(t, missingErr.Error(), "required by *cqrs.containerCommandHandler")
+	assert.Equal(t, 0, handlersAfterFailure)
+	assert.NoError(t, retryErr)
+	assert.Equal(t, 2, commands.GetHandlerCount())
+}
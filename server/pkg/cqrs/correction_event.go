@@ -0,0 +1,273 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Metadata keys written by NewBaseCorrectionEvent so generic tooling (exports, audit views)
+// can recognize corrections without knowing their Go types
+const (
+	MetadataCorrectsEventID  = "corrects_event_id"
+	MetadataCorrectionReason = "correction_reason"
+)
+
+// CorrectionEvent is an event that semantically overrides an earlier event of the same
+// aggregate (e.g. CorrectHarvestAmount fixing a HarvestRecorded), leaving history untouched.
+type CorrectionEvent interface {
+	EventMessage
+	// CorrectsEventID returns the ID of the original event being corrected
+	CorrectsEventID() string
+	// Correct returns the event as it should have been, given the event as currently in
+	// effect (the original, or the result of an earlier correction). It must return a copy
+	// and keep the event ID, type and version of its input.
+	Correct(effective EventMessage) (EventMessage, error)
+}
+
+// BaseCorrectionEvent provides the reference to the corrected event; embed it by value
+// and implement Correct on the concrete correction type
+type BaseCorrectionEvent struct {
+	BaseEventMessage
+	CorrectsEventID_ string `json:"correctsEventId" bson:"correctsEventId"`
+	Reason_          string `json:"correctionReason,omitempty" bson:"correctionReason,omitempty"`
+}
+
+// NewBaseCorrectionEvent creates a correction of the event with correctsEventID
+func NewBaseCorrectionEvent(eventType, correctsEventID, reason string) *BaseCorrectionEvent {
+	event := &BaseCorrectionEvent{
+		BaseEventMessage: *NewBaseEventMessage(eventType),
+		CorrectsEventID_: correctsEventID,
+		Reason_:          reason,
+	}
+	event.AddMetadata(MetadataCorrectsEventID, correctsEventID)
+	if reason != "" {
+		event.AddMetadata(MetadataCorrectionReason, reason)
+	}
+	return event
+}
+
+func (e BaseCorrectionEvent) CorrectsEventID() string {
+	return e.CorrectsEventID_
+}
+
+// CorrectionReason returns the operator's explanation of the correction
+func (e BaseCorrectionEvent) CorrectionReason() string {
+	return e.Reason_
+}
+
+type correctionContextKey struct{}
+
+// WithCorrection marks ctx as projecting the corrected version of an event
+func WithCorrection(ctx context.Context, correction CorrectionEvent) context.Context {
+	return context.WithValue(ctx, correctionContextKey{}, correction)
+}
+
+// CorrectionFromContext returns the correction being applied, if any, so projections can
+// record it (e.g. for an audit column) while handling the corrected event
+func CorrectionFromContext(ctx context.Context) (CorrectionEvent, bool) {
+	correction, ok := ctx.Value(correctionContextKey{}).(CorrectionEvent)
+	return correction, ok
+}
+
+// ApplyCorrections returns events as they should have been: each corrected event is replaced
+// in place by its latest correction and the correction events themselves are dropped.
+// Use it for projection rebuilds; aggregates should handle corrections in their apply
+// functions instead, since stream versions count the correction events.
+func ApplyCorrections(events []EventMessage) ([]EventMessage, error) {
+	effective := make([]EventMessage, 0, len(events))
+	positions := make(map[string]int, len(events))
+
+	for _, event := range events {
+		correction, ok := event.(CorrectionEvent)
+		if !ok {
+			positions[event.EventID()] = len(effective)
+			effective = append(effective, event)
+			continue
+		}
+
+		position, exists := positions[correction.CorrectsEventID()]
+		if !exists {
+			return nil, NewNotFoundError(fmt.Sprintf("event %s corrected by %s not found",
+				correction.CorrectsEventID(), correction.EventID()), nil)
+		}
+		corrected, err := correction.Correct(effective[position])
+		if err != nil {
+			return nil, NewCQRSError(ErrCodeEventValidation.String(),
+				fmt.Sprintf("failed to apply correction %s", correction.EventID()), err)
+		}
+		effective[position] = corrected
+	}
+	return effective, nil
+}
+
+// CorrectionSource finds the event a correction overrides, as currently in effect
+type CorrectionSource interface {
+	Effective(ctx context.Context, correction CorrectionEvent) (EventMessage, error)
+}
+
+// HistoryLoader loads the event stream of an aggregate
+type HistoryLoader func(ctx context.Context, aggregateType, aggregateID string) ([]EventMessage, error)
+
+// HistoryCorrectionSource resolves corrected events from the aggregate's stream, so it needs
+// no state of its own and works after restarts and during rebuilds
+type HistoryCorrectionSource struct {
+	load HistoryLoader
+}
+
+// NewHistoryCorrectionSource creates a source reading streams with load
+func NewHistoryCorrectionSource(load HistoryLoader) *HistoryCorrectionSource {
+	return &HistoryCorrectionSource{load: load}
+}
+
+// Effective replays the corrections that precede correction onto the original event
+func (s *HistoryCorrectionSource) Effective(ctx context.Context, correction CorrectionEvent) (EventMessage, error) {
+	history, err := s.load(ctx, correction.AggregateType(), correction.AggregateID())
+	if err != nil {
+		return nil, err
+	}
+
+	var earlier []EventMessage
+	for _, event := range history {
+		if event.EventID() == correction.EventID() || (correction.Version() > 0 && event.Version() >= correction.Version()) {
+			break
+		}
+		earlier = append(earlier, event)
+	}
+
+	effective, err := ApplyCorrections(earlier)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range effective {
+		if event.EventID() == correction.CorrectsEventID() {
+			return event, nil
+		}
+	}
+	return nil, NewNotFoundError(fmt.Sprintf("event %s corrected by %s not found in %s %s",
+		correction.CorrectsEventID(), correction.EventID(), correction.AggregateType(), correction.AggregateID()), nil)
+}
+
+// InMemoryCorrectionSource remembers the events a CorrectingProjection projected. It is
+// meant for tests and in-memory projections; its memory is lost on restart.
+type InMemoryCorrectionSource struct {
+	mutex  sync.RWMutex
+	events map[string]EventMessage
+}
+
+// NewInMemoryCorrectionSource creates an empty source
+func NewInMemoryCorrectionSource() *InMemoryCorrectionSource {
+	return &InMemoryCorrectionSource{events: make(map[string]EventMessage)}
+}
+
+func (s *InMemoryCorrectionSource) Effective(ctx context.Context, correction CorrectionEvent) (EventMessage, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	event, exists := s.events[correction.CorrectsEventID()]
+	if !exists {
+		return nil, NewNotFoundError(fmt.Sprintf("event %s corrected by %s was not projected",
+			correction.CorrectsEventID(), correction.EventID()), nil)
+	}
+	return event, nil
+}
+
+// Record remembers the event now in effect under its ID
+func (s *InMemoryCorrectionSource) Record(event EventMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.events[event.EventID()] = event
+}
+
+// Clear forgets every event, e.g. when the projection is reset
+func (s *InMemoryCorrectionSource) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.events = make(map[string]EventMessage)
+}
+
+// CorrectingProjectionConfig configures a CorrectingProjection
+type CorrectingProjectionConfig struct {
+	// CorrectionTypes are the event types implementing CorrectionEvent
+	CorrectionTypes []string
+	// Source finds corrected events (default NewInMemoryCorrectionSource)
+	Source CorrectionSource
+	// Revert undoes the effect of an event before its corrected version is projected.
+	// Leave it nil for projections whose handlers overwrite values instead of accumulating them.
+	Revert func(ctx context.Context, event EventMessage) error
+}
+
+// CorrectingProjection applies correction events to a projection automatically: it reverts
+// the event currently in effect and projects the corrected version in its place, so the
+// wrapped projection only ever handles its ordinary event types.
+type CorrectingProjection struct {
+	Projection
+	config          CorrectingProjectionConfig
+	correctionTypes map[string]bool
+}
+
+// NewCorrectingProjection wraps a projection
+func NewCorrectingProjection(projection Projection, config CorrectingProjectionConfig) *CorrectingProjection {
+	if config.Source == nil {
+		config.Source = NewInMemoryCorrectionSource()
+	}
+	types := make(map[string]bool, len(config.CorrectionTypes))
+	for _, eventType := range config.CorrectionTypes {
+		types[eventType] = true
+	}
+	return &CorrectingProjection{Projection: projection, config: config, correctionTypes: types}
+}
+
+func (p *CorrectingProjection) CanHandle(eventType string) bool {
+	return p.correctionTypes[eventType] || p.Projection.CanHandle(eventType)
+}
+
+// Project projects ordinary events unchanged and turns corrections into revert + reproject
+func (p *CorrectingProjection) Project(ctx context.Context, event EventMessage) error {
+	correction, ok := event.(CorrectionEvent)
+	if !ok || !p.correctionTypes[event.EventType()] {
+		if err := p.Projection.Project(ctx, event); err != nil {
+			return err
+		}
+		p.record(event)
+		return nil
+	}
+
+	current, err := p.config.Source.Effective(ctx, correction)
+	if err != nil {
+		return err
+	}
+	if !p.Projection.CanHandle(current.EventType()) {
+		return nil
+	}
+
+	corrected, err := correction.Correct(current)
+	if err != nil {
+		return NewCQRSError(ErrCodeEventValidation.String(),
+			fmt.Sprintf("failed to apply correction %s", correction.EventID()), err)
+	}
+
+	if p.config.Revert != nil {
+		if err := p.config.Revert(ctx, current); err != nil {
+			return fmt.Errorf("failed to revert event %s: %w", current.EventID(), err)
+		}
+	}
+	if err := p.Projection.Project(WithCorrection(ctx, correction), corrected); err != nil {
+		return err
+	}
+	p.record(corrected)
+	return nil
+}
+
+// Reset resets the wrapped projection and forgets recorded events
+func (p *CorrectingProjection) Reset(ctx context.Context) error {
+	if clearer, ok := p.config.Source.(interface{ Clear() }); ok {
+		clearer.Clear()
+	}
+	return p.Projection.Reset(ctx)
+}
+
+func (p *CorrectingProjection) record(event EventMessage) {
+	if recorder, ok := p.config.Source.(interface{ Record(EventMessage) }); ok {
+		recorder.Record(event)
+	}
+}
@@ -0,0 +1,130 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type harvestRecorded struct {
+	BaseEventMessage
+	Amount int
+}
+
+type correctHarvestAmount struct {
+	BaseCorrectionEvent
+	Amount int
+}
+
+func (c *correctHarvestAmount) Correct(effective EventMessage) (EventMessage, error) {
+	harvest, ok := effective.(*harvestRecorded)
+	if !ok {
+		return nil, errors.New("only harvests can be corrected")
+	}
+	corrected := *harvest
+	corrected.Amount = c.Amount
+	return &corrected, nil
+}
+
+// harvestStream builds a stream with versions assigned as an aggregate would
+func harvestStream(events ...EventMessage) []EventMessage {
+	for i, event := range events {
+		event.setAggregateInfo("farm-1", "Farm", i+1)
+	}
+	return events
+}
+
+func newHarvest(amount int) *harvestRecorded {
+	return &harvestRecorded{BaseEventMessage: *NewBaseEventMessage("HarvestRecorded"), Amount: amount}
+}
+
+func newHarvestCorrection(original EventMessage, amount int) *correctHarvestAmount {
+	return &correctHarvestAmount{
+		BaseCorrectionEvent: *NewBaseCorrectionEvent("CorrectHarvestAmount", original.EventID(), "scale misread"),
+		Amount:              amount,
+	}
+}
+
+// harvestTotalProjection accumulates harvested amounts
+type harvestTotalProjection struct {
+	*BaseProjection
+	total     int
+	corrected []string
+}
+
+func (p *harvestTotalProjection) Project(ctx context.Context, event EventMessage) error {
+	p.total += event.(*harvestRecorded).Amount
+	if correction, ok := CorrectionFromContext(ctx); ok {
+		p.corrected = append(p.corrected, correction.EventID())
+	}
+	return nil
+}
+
+func TestApplyCorrections_ReplacesCorrectedEvents(t *testing.T) {
+	// Arrange
+	first, second := newHarvest(100), newHarvest(40)
+	fix := newHarvestCorrection(first, 10)
+	refix := newHarvestCorrection(first, 12)
+	events := harvestStream(first, second, fix, refix)
+
+	// Act
+	effective, err := ApplyCorrections(events)
+	_, missingErr := ApplyCorrections(harvestStream(newHarvestCorrection(newHarvest(1), 2)))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, effective, 2)
+	assert.Equal(t, first.EventID(), effective[0].EventID())
+	assert.Equal(t, 12, effective[0].(*harvestRecorded).Amount)
+	assert.Equal(t, 100, first.Amount) // History is untouched
+	assert.Equal(t, "scale misread", fix.Metadata()[MetadataCorrectionReason])
+	assert.True(t, IsNotFoundError(missingErr))
+}
+
+func TestCorrectingProjection_RevertsAndReprojects(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	first, second := newHarvest(100), newHarvest(40)
+	fix := newHarvestCorrection(first, 10)
+	refix := newHarvestCorrection(first, 12)
+	stream := harvestStream(first, second, fix, refix)
+
+	inMemory := &harvestTotalProjection{BaseProjection: NewBaseProjection("HarvestTotals", "1", []string{"HarvestRecorded"})}
+	fromHistory := &harvestTotalProjection{BaseProjection: NewBaseProjection("HarvestTotals", "1", []string{"HarvestRecorded"})}
+	revert := func(p *harvestTotalProjection) func(ctx context.Context, event EventMessage) error {
+		return func(ctx context.Context, event EventMessage) error {
+			p.total -= event.(*harvestRecorded).Amount
+			return nil
+		}
+	}
+	projections := []*CorrectingProjection{
+		NewCorrectingProjection(inMemory, CorrectingProjectionConfig{
+			CorrectionTypes: []string{"CorrectHarvestAmount"},
+			Revert:          revert(inMemory),
+		}),
+		NewCorrectingProjection(fromHistory, CorrectingProjectionConfig{
+			CorrectionTypes: []string{"CorrectHarvestAmount"},
+			Revert:          revert(fromHistory),
+			Source: NewHistoryCorrectionSource(func(ctx context.Context, aggregateType, aggregateID string) ([]EventMessage, error) {
+				return stream, nil
+			}),
+		}),
+	}
+
+	// Act
+	for _, projection := range projections {
+		for _, event := range stream {
+			require.True(t, projection.CanHandle(event.EventType()))
+			require.NoError(t, projection.Project(ctx, event))
+		}
+	}
+
+	// Assert
+	for _, projected := range []*harvestTotalProjection{inMemory, fromHistory} {
+		assert.Equal(t, 52, projected.total)
+		assert.Equal(t, []string{fix.EventID(), refix.EventID()}, projected.corrected)
+	}
+}
@@ -0,0 +1,457 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Link event types recorded by aggregate merge and split operations. Apply functions
+// registered with cqrs.AggregateRegistry must ignore (or handle) them; IsAggregateLinkEvent
+// helps with that.
+const (
+	AggregateMergedIntoEventType = "AggregateMergedInto" // Last event of a merged-away source
+	AggregateAbsorbedEventType   = "AggregateAbsorbed"   // First event of a merge on the target
+	AggregateSplitEventType      = "AggregateSplit"      // Last event of a split on the source
+	AggregateSplitFromEventType  = "AggregateSplitFrom"  // First event of an aggregate carved out of a source
+)
+
+// Restructure operations
+const (
+	RestructureMerge = "merge"
+	RestructureSplit = "split"
+)
+
+// MetadataRestructureOperation is the event metadata key carrying the operation ID on
+// every event written by a merge or split, so projections can group them
+const MetadataRestructureOperation = "restructure_operation"
+
+// IsAggregateLinkEvent reports whether the event type is a merge/split link event
+func IsAggregateLinkEvent(eventType string) bool {
+	switch eventType {
+	case AggregateMergedIntoEventType, AggregateAbsorbedEventType, AggregateSplitEventType, AggregateSplitFromEventType:
+		return true
+	}
+	return false
+}
+
+// AggregateLink is the payload of the link events
+type AggregateLink struct {
+	OperationID string    `json:"operation_id" bson:"operation_id"`
+	Operation   string    `json:"operation" bson:"operation"`
+	SourceID    string    `json:"source_id" bson:"source_id"`
+	TargetIDs   []string  `json:"target_ids" bson:"target_ids"`
+	Reason      string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty" bson:"requested_by,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at" bson:"occurred_at"`
+}
+
+// AggregateLinkEvent records that aggregates were merged or split
+type AggregateLinkEvent struct {
+	cqrs.BaseEventMessage
+	Link AggregateLink `json:"link" bson:"link"`
+}
+
+// EventData returns the link
+func (e *AggregateLinkEvent) EventData() interface{} {
+	return e.Link
+}
+
+// RegisterAggregateLinkEvents registers the link event types so event stores can load them
+func RegisterAggregateLinkEvents(registry *VersionedEventRegistry) error {
+	for _, eventType := range []string{AggregateMergedIntoEventType, AggregateAbsorbedEventType, AggregateSplitEventType, AggregateSplitFromEventType} {
+		if err := RegisterEvent[AggregateLinkEvent](registry, eventType, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AggregateMergeFunc moves the source's state into the target by calling domain methods
+// that apply events on them (e.g. target.AbsorbAccount(source)). Both are fully rehydrated.
+type AggregateMergeFunc func(ctx context.Context, source, target cqrs.AggregateRoot) error
+
+// AggregateSplitFunc moves part of the source's state into the new, empty parts by
+// applying events on all of them (e.g. moving half of a guild's members)
+type AggregateSplitFunc func(ctx context.Context, source cqrs.AggregateRoot, parts []cqrs.AggregateRoot) error
+
+// AggregateHistoryReader loads event streams (MongoEventStore, RedisEventStore)
+type AggregateHistoryReader interface {
+	GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+}
+
+// MergeRequest merges SourceID into TargetID; the source is retired afterwards
+type MergeRequest struct {
+	AggregateType string `json:"aggregate_type"`
+	SourceID      string `json:"source_id"`
+	TargetID      string `json:"target_id"`
+	Reason        string `json:"reason,omitempty"`
+	RequestedBy   string `json:"requested_by,omitempty"`
+	DryRun        bool   `json:"dry_run,omitempty"`
+}
+
+// SplitRequest carves new aggregates with PartIDs out of SourceID; the source stays alive
+type SplitRequest struct {
+	AggregateType string   `json:"aggregate_type"`
+	SourceID      string   `json:"source_id"`
+	PartIDs       []string `json:"part_ids"`
+	Reason        string   `json:"reason,omitempty"`
+	RequestedBy   string   `json:"requested_by,omitempty"`
+	DryRun        bool     `json:"dry_run,omitempty"`
+}
+
+// RestructuredEvent is a preview of one event an operation writes
+type RestructuredEvent struct {
+	EventID   string      `json:"event_id"`
+	EventType string      `json:"event_type"`
+	Version   int         `json:"version"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// RestructuredStream is the outcome of an operation for one aggregate
+type RestructuredStream struct {
+	AggregateID     string              `json:"aggregate_id"`
+	AggregateType   string              `json:"aggregate_type"`
+	ExpectedVersion int                 `json:"expected_version"` // Version before the operation
+	Events          []RestructuredEvent `json:"events"`
+	// Aggregate is the resulting aggregate, with the new events applied
+	Aggregate cqrs.AggregateRoot `json:"state"`
+}
+
+// RestructureResult describes a merge or split; with DryRun nothing was written
+type RestructureResult struct {
+	OperationID string               `json:"operation_id"`
+	Operation   string               `json:"operation"`
+	DryRun      bool                 `json:"dry_run"`
+	Streams     []RestructuredStream `json:"streams"`
+}
+
+// AggregateRestructurerConfig wires an AggregateRestructurer
+type AggregateRestructurerConfig struct {
+	History  AggregateHistoryReader
+	Writer   EventStreamWriter       // Writes all affected streams in one transaction
+	Registry *cqrs.AggregateRegistry // Rehydrates aggregates (default cqrs.DefaultAggregateRegistry)
+	EventBus cqrs.EventBus           // Optional; receives the written events so projections follow
+}
+
+// AggregateRestructurer runs administrative merges and splits of event-sourced aggregates.
+// The domain decides how state moves (RegisterMerge/RegisterSplit); the restructurer adds
+// link events, checks versions and writes every affected stream atomically.
+type AggregateRestructurer struct {
+	config AggregateRestructurerConfig
+	mutex  sync.RWMutex
+	merges map[string]AggregateMergeFunc
+	splits map[string]AggregateSplitFunc
+}
+
+// NewAggregateRestructurer creates a restructurer
+func NewAggregateRestructurer(config AggregateRestructurerConfig) (*AggregateRestructurer, error) {
+	if config.History == nil || config.Writer == nil {
+		return nil, cqrs.NewValidationError("history reader and stream writer are required", nil)
+	}
+	if config.Registry == nil {
+		config.Registry = cqrs.DefaultAggregateRegistry()
+	}
+	return &AggregateRestructurer{
+		config: config,
+		merges: make(map[string]AggregateMergeFunc),
+		splits: make(map[string]AggregateSplitFunc),
+	}, nil
+}
+
+// RegisterMerge enables merges of an aggregate type
+func (r *AggregateRestructurer) RegisterMerge(aggregateType string, merge AggregateMergeFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.merges[aggregateType] = merge
+}
+
+// RegisterSplit enables splits of an aggregate type
+func (r *AggregateRestructurer) RegisterSplit(aggregateType string, split AggregateSplitFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.splits[aggregateType] = split
+}
+
+// Merge merges the source aggregate into the target
+func (r *AggregateRestructurer) Merge(ctx context.Context, request MergeRequest) (*RestructureResult, error) {
+	r.mutex.RLock()
+	merge, exists := r.merges[request.AggregateType]
+	r.mutex.RUnlock()
+	if !exists {
+		return nil, cqrs.NewValidationError(fmt.Sprintf("merge is not supported for %s", request.AggregateType), nil)
+	}
+	if request.SourceID == "" || request.TargetID == "" || request.SourceID == request.TargetID {
+		return nil, cqrs.NewValidationError("merge needs two different aggregate IDs", nil)
+	}
+
+	source, err := r.load(ctx, request.AggregateType, request.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := r.load(ctx, request.AggregateType, request.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	link := AggregateLink{
+		OperationID: uuid.NewString(),
+		Operation:   RestructureMerge,
+		SourceID:    request.SourceID,
+		TargetIDs:   []string{request.TargetID},
+		Reason:      request.Reason,
+		RequestedBy: request.RequestedBy,
+		OccurredAt:  time.Now(),
+	}
+	if err := target.ApplyEvent(newLinkEvent(AggregateAbsorbedEventType, link)); err != nil {
+		return nil, err
+	}
+	if err := merge(ctx, source, target); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeCommandValidation.String(), "merge rejected", err)
+	}
+	if err := source.ApplyEvent(newLinkEvent(AggregateMergedIntoEventType, link)); err != nil {
+		return nil, err
+	}
+
+	return r.commit(ctx, link, request.DryRun, source, target)
+}
+
+// Split carves new aggregates out of the source
+func (r *AggregateRestructurer) Split(ctx context.Context, request SplitRequest) (*RestructureResult, error) {
+	r.mutex.RLock()
+	split, exists := r.splits[request.AggregateType]
+	r.mutex.RUnlock()
+	if !exists {
+		return nil, cqrs.NewValidationError(fmt.Sprintf("split is not supported for %s", request.AggregateType), nil)
+	}
+	if request.SourceID == "" || len(request.PartIDs) == 0 {
+		return nil, cqrs.NewValidationError("split needs a source and at least one part ID", nil)
+	}
+
+	source, err := r.load(ctx, request.AggregateType, request.SourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	link := AggregateLink{
+		OperationID: uuid.NewString(),
+		Operation:   RestructureSplit,
+		SourceID:    request.SourceID,
+		TargetIDs:   request.PartIDs,
+		Reason:      request.Reason,
+		RequestedBy: request.RequestedBy,
+		OccurredAt:  time.Now(),
+	}
+
+	seen := map[string]bool{request.SourceID: true}
+	parts := make([]cqrs.AggregateRoot, 0, len(request.PartIDs))
+	for _, partID := range request.PartIDs {
+		if partID == "" || seen[partID] {
+			return nil, cqrs.NewValidationError(fmt.Sprintf("invalid or duplicate part ID %q", partID), nil)
+		}
+		seen[partID] = true
+
+		history, err := r.config.History.GetEventHistory(ctx, partID, request.AggregateType, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(history) > 0 {
+			return nil, cqrs.NewValidationError(fmt.Sprintf("%s %s already exists", request.AggregateType, partID), nil)
+		}
+		part, err := r.config.Registry.Create(request.AggregateType, partID)
+		if err != nil {
+			return nil, err
+		}
+		if err := part.ApplyEvent(newLinkEvent(AggregateSplitFromEventType, link)); err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	if err := split(ctx, source, parts); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeCommandValidation.String(), "split rejected", err)
+	}
+	if err := source.ApplyEvent(newLinkEvent(AggregateSplitEventType, link)); err != nil {
+		return nil, err
+	}
+
+	return r.commit(ctx, link, request.DryRun, append([]cqrs.AggregateRoot{source}, parts...)...)
+}
+
+// Resolve follows merge links from an aggregate ID to the aggregate it was merged into,
+// e.g. to redirect a login to the surviving account. Unmerged IDs resolve to themselves.
+func (r *AggregateRestructurer) Resolve(ctx context.Context, aggregateType, aggregateID string) (string, error) {
+	current := aggregateID
+	for hops := 0; hops < 16; hops++ {
+		history, err := r.config.History.GetEventHistory(ctx, current, aggregateType, 0)
+		if err != nil {
+			return "", err
+		}
+		if len(history) == 0 {
+			return current, nil
+		}
+		link, ok := mergedInto(history[len(history)-1])
+		if !ok {
+			return current, nil
+		}
+		current = link.TargetIDs[0]
+	}
+	return "", cqrs.NewCQRSError(cqrs.ErrCodeInvalidAggregate.String(),
+		fmt.Sprintf("merge chain of %s %s is too long", aggregateType, aggregateID), nil)
+}
+
+// load rehydrates an existing aggregate and rejects aggregates retired by an earlier merge
+func (r *AggregateRestructurer) load(ctx context.Context, aggregateType, aggregateID string) (cqrs.AggregateRoot, error) {
+	history, err := r.config.History.GetEventHistory(ctx, aggregateID, aggregateType, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, cqrs.NewNotFoundError(fmt.Sprintf("%s %s not found", aggregateType, aggregateID), nil)
+	}
+	if link, ok := mergedInto(history[len(history)-1]); ok {
+		return nil, cqrs.NewValidationError(fmt.Sprintf("%s %s was merged into %s", aggregateType, aggregateID, link.TargetIDs[0]), nil)
+	}
+
+	aggregate, err := r.config.Registry.Rehydrate(aggregateType, aggregateID, nil, history)
+	if err != nil {
+		return nil, err
+	}
+	if setter, ok := aggregate.(interface{ SetOriginalVersion(int) }); ok {
+		setter.SetOriginalVersion(aggregate.Version())
+	}
+	return aggregate, nil
+}
+
+// commit tags the new events with the operation, then writes and publishes them unless dry-running
+func (r *AggregateRestructurer) commit(ctx context.Context, link AggregateLink, dryRun bool, aggregates ...cqrs.AggregateRoot) (*RestructureResult, error) {
+	result := &RestructureResult{OperationID: link.OperationID, Operation: link.Operation, DryRun: dryRun}
+	streams := make([]EventStreamAppend, 0, len(aggregates))
+	var published []cqrs.EventMessage
+
+	for _, aggregate := range aggregates {
+		changes := aggregate.Changes()
+		expectedVersion := aggregate.Version() - len(changes)
+
+		stream := RestructuredStream{
+			AggregateID:     aggregate.ID(),
+			AggregateType:   aggregate.Type(),
+			ExpectedVersion: expectedVersion,
+			Aggregate:       aggregate,
+		}
+		for _, event := range changes {
+			tagOperation(event, link.OperationID)
+			stream.Events = append(stream.Events, RestructuredEvent{
+				EventID:   event.EventID(),
+				EventType: event.EventType(),
+				Version:   event.Version(),
+				Data:      event.EventData(),
+			})
+		}
+		result.Streams = append(result.Streams, stream)
+		streams = append(streams, EventStreamAppend{AggregateID: aggregate.ID(), Events: changes, ExpectedVersion: expectedVersion})
+		published = append(published, changes...)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := r.config.Writer.SaveEventStreams(ctx, streams); err != nil {
+		return nil, err
+	}
+	for _, aggregate := range aggregates {
+		aggregate.ClearChanges()
+	}
+
+	if r.config.EventBus != nil && len(published) > 0 {
+		if err := r.config.EventBus.PublishBatch(ctx, published); err != nil {
+			return result, cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(),
+				fmt.Sprintf("%s %s was saved but its events were not published", link.Operation, link.OperationID), err)
+		}
+	}
+	return result, nil
+}
+
+// ServeHTTP exposes merges and splits as an admin endpoint: POST ?operation=merge|split
+// with a MergeRequest or SplitRequest body. Set "dry_run" to preview the result.
+func (r *AggregateRestructurer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var (
+		result *RestructureResult
+		err    error
+	)
+	switch req.URL.Query().Get("operation") {
+	case RestructureMerge:
+		var request MergeRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid merge request", http.StatusBadRequest)
+			return
+		}
+		result, err = r.Merge(req.Context(), request)
+	case RestructureSplit:
+		var request SplitRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid split request", http.StatusBadRequest)
+			return
+		}
+		result, err = r.Split(req.Context(), request)
+	default:
+		http.Error(w, "operation must be merge or split", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case cqrs.IsValidationError(err):
+			status = http.StatusBadRequest
+		case cqrs.IsNotFoundError(err):
+			status = http.StatusNotFound
+		case cqrs.IsConcurrencyError(err):
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func newLinkEvent(eventType string, link AggregateLink) *AggregateLinkEvent {
+	return &AggregateLinkEvent{BaseEventMessage: *cqrs.NewBaseEventMessage(eventType), Link: link}
+}
+
+// mergedInto returns the link of a MergedInto event, which may have been loaded as a
+// registered AggregateLinkEvent or as generic event data
+func mergedInto(event cqrs.EventMessage) (AggregateLink, bool) {
+	if event.EventType() != AggregateMergedIntoEventType {
+		return AggregateLink{}, false
+	}
+	var link AggregateLink
+	if typed, ok := event.(*AggregateLinkEvent); ok {
+		link = typed.Link
+	} else if encoded, err := json.Marshal(event.EventData()); err == nil {
+		json.Unmarshal(encoded, &link)
+	}
+	return link, len(link.TargetIDs) > 0
+}
+
+func tagOperation(event cqrs.EventMessage, operationID string) {
+	if adder, ok := event.(interface{ AddMetadata(key string, value interface{}) }); ok {
+		adder.AddMetadata(MetadataRestructureOperation, operationID)
+	} else if metadata := event.Metadata(); metadata != nil {
+		metadata[MetadataRestructureOperation] = operationID
+	}
+}
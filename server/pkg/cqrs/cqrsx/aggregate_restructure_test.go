@@ -0,0 +1,202 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (w *memoryStreamWriter) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]cqrs.EventMessage(nil), w.streams[aggregateID]...), nil
+}
+
+type memberEvent struct {
+	cqrs.BaseEventMessage
+	Member string `json:"member"`
+}
+
+func (e *memberEvent) EventData() interface{} { return map[string]string{"member": e.Member} }
+
+type restructureGuild struct {
+	*cqrs.BaseAggregate
+	members map[string]bool
+}
+
+func (g *restructureGuild) join(member string) error {
+	g.members[member] = true
+	return g.ApplyEvent(&memberEvent{BaseEventMessage: *cqrs.NewBaseEventMessage("MemberJoined"), Member: member})
+}
+
+func (g *restructureGuild) leave(member string) error {
+	delete(g.members, member)
+	return g.ApplyEvent(&memberEvent{BaseEventMessage: *cqrs.NewBaseEventMessage("MemberLeft"), Member: member})
+}
+
+func (g *restructureGuild) memberList() []string {
+	var members []string
+	for member := range g.members {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+func newRestructureTestRestructurer(t *testing.T, bus cqrs.EventBus) (*AggregateRestructurer, *memoryStreamWriter) {
+	registry := cqrs.NewAggregateRegistry()
+	require.NoError(t, registry.Register("Guild", func(id string) (cqrs.AggregateRoot, error) {
+		return &restructureGuild{BaseAggregate: cqrs.NewBaseAggregate(id, "Guild"), members: map[string]bool{}}, nil
+	}, cqrs.WithApplyFunc(func(aggregate cqrs.AggregateRoot, event cqrs.EventMessage) error {
+		guild := aggregate.(*restructureGuild)
+		switch typed := event.(type) {
+		case *memberEvent:
+			if typed.EventType() == "MemberJoined" {
+				guild.members[typed.Member] = true
+			} else {
+				delete(guild.members, typed.Member)
+			}
+		case *AggregateLinkEvent:
+		default:
+			return errors.New("unexpected event " + event.EventType())
+		}
+		return nil
+	})))
+
+	store := &memoryStreamWriter{}
+	restructurer, err := NewAggregateRestructurer(AggregateRestructurerConfig{History: store, Writer: store, Registry: registry, EventBus: bus})
+	require.NoError(t, err)
+
+	restructurer.RegisterMerge("Guild", func(ctx context.Context, source, target cqrs.AggregateRoot) error {
+		from, to := source.(*restructureGuild), target.(*restructureGuild)
+		for _, member := range from.memberList() {
+			if err := from.leave(member); err != nil {
+				return err
+			}
+			if !to.members[member] {
+				if err := to.join(member); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	restructurer.RegisterSplit("Guild", func(ctx context.Context, source cqrs.AggregateRoot, parts []cqrs.AggregateRoot) error {
+		from := source.(*restructureGuild)
+		members := from.memberList()
+		for i, member := range members[len(members)/2:] {
+			if err := from.leave(member); err != nil {
+				return err
+			}
+			if err := parts[i%len(parts)].(*restructureGuild).join(member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return restructurer, store
+}
+
+func seedGuild(t *testing.T, store *memoryStreamWriter, guildID string, members ...string) {
+	guild := &restructureGuild{BaseAggregate: cqrs.NewBaseAggregate(guildID, "Guild"), members: map[string]bool{}}
+	for _, member := range members {
+		require.NoError(t, guild.join(member))
+	}
+	require.NoError(t, store.SaveEventStreams(context.Background(), []EventStreamAppend{{AggregateID: guildID, Events: guild.Changes()}}))
+}
+
+type publishedRecorder struct {
+	cqrs.EventBus
+	events []cqrs.EventMessage
+}
+
+func (p *publishedRecorder) PublishBatch(ctx context.Context, events []cqrs.EventMessage, options ...cqrs.EventPublishOptions) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func TestAggregateRestructurer_MergePreviewAndCommit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := &publishedRecorder{}
+	restructurer, store := newRestructureTestRestructurer(t, bus)
+	seedGuild(t, store, "g1", "alice", "bob")
+	seedGuild(t, store, "g2", "bob", "carol")
+	request := MergeRequest{AggregateType: "Guild", SourceID: "g1", TargetID: "g2", Reason: "duplicate", DryRun: true}
+
+	// Act
+	preview, err := restructurer.Merge(ctx, request)
+	require.NoError(t, err)
+	request.DryRun = false
+	result, err := restructurer.Merge(ctx, request)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, preview.DryRun)
+	require.Len(t, preview.Streams, 2)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, preview.Streams[1].Aggregate.(*restructureGuild).memberList())
+	assert.Empty(t, preview.Streams[0].Aggregate.(*restructureGuild).memberList())
+
+	source, target := result.Streams[0], result.Streams[1]
+	assert.Equal(t, 2, source.ExpectedVersion)
+	assert.Equal(t, []string{"MemberLeft", "MemberLeft", AggregateMergedIntoEventType}, restructuredTypes(source.Events))
+	assert.Equal(t, []string{AggregateAbsorbedEventType, "MemberJoined"}, restructuredTypes(target.Events))
+	assert.Equal(t, 3, target.Events[0].Version)
+
+	assert.Len(t, store.streams["g1"], 5)
+	assert.Len(t, store.streams["g2"], 4)
+	assert.Len(t, bus.events, 5)
+	for _, event := range bus.events {
+		assert.Equal(t, result.OperationID, event.Metadata()[MetadataRestructureOperation])
+	}
+
+	resolved, err := restructurer.Resolve(ctx, "Guild", "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "g2", resolved)
+
+	_, err = restructurer.Merge(ctx, request)
+	assert.True(t, cqrs.IsValidationError(err)) // The source is retired
+}
+
+func TestAggregateRestructurer_Split(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	restructurer, store := newRestructureTestRestructurer(t, nil)
+	seedGuild(t, store, "g1", "alice", "bob", "carol", "dave")
+	seedGuild(t, store, "taken", "erin")
+
+	// Act
+	result, err := restructurer.Split(ctx, SplitRequest{AggregateType: "Guild", SourceID: "g1", PartIDs: []string{"g1-east", "g1-west"}})
+	_, takenErr := restructurer.Split(ctx, SplitRequest{AggregateType: "Guild", SourceID: "g1", PartIDs: []string{"taken"}})
+	_, unsupportedErr := restructurer.Merge(ctx, MergeRequest{AggregateType: "Player", SourceID: "p1", TargetID: "p2"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Streams, 3)
+	assert.Equal(t, []string{"alice", "bob"}, result.Streams[0].Aggregate.(*restructureGuild).memberList())
+	assert.Equal(t, []string{"carol"}, result.Streams[1].Aggregate.(*restructureGuild).memberList())
+	assert.Equal(t, []string{"dave"}, result.Streams[2].Aggregate.(*restructureGuild).memberList())
+	assert.Equal(t, []string{AggregateSplitFromEventType, "MemberJoined"}, restructuredTypes(result.Streams[1].Events))
+	assert.Equal(t, AggregateSplitEventType, store.streams["g1"][len(store.streams["g1"])-1].EventType())
+	assert.Len(t, store.streams["g1-west"], 2)
+
+	resolved, err := restructurer.Resolve(ctx, "Guild", "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "g1", resolved) // Splits do not retire the source
+
+	assert.True(t, cqrs.IsValidationError(takenErr))
+	assert.True(t, cqrs.IsValidationError(unsupportedErr))
+}
+
+func restructuredTypes(events []RestructuredEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.EventType
+	}
+	return types
+}
@@ -0,0 +1,54 @@
+package cqrsx
+
+import (
+	"bytes"
+	"cqrs"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize keeps unusually large buffers from pinning memory in the pool
+const maxPooledBufferSize = 64 * 1024
+
+// serializerBufferPool reuses byte buffers for event serialization on hot paths
+var serializerBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, 0, 1024))
+	},
+}
+
+// AcquireBuffer returns an empty pooled buffer
+func AcquireBuffer() *bytes.Buffer {
+	buf := serializerBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// ReleaseBuffer returns the buffer to the pool.
+// Slices obtained from buf.Bytes() must not be used after release.
+func ReleaseBuffer(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	serializerBufferPool.Put(buf)
+}
+
+// BufferedEventMarshaler is implemented by marshalers that can serialize into a caller-provided buffer
+type BufferedEventMarshaler interface {
+	EventMarshaler
+	MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error
+}
+
+// MarshalEventJSONTo serializes an event as JSON into buf without the trailing newline added by json.Encoder
+func MarshalEventJSONTo(buf *bytes.Buffer, event cqrs.EventMessage) error {
+	if err := json.NewEncoder(buf).Encode(event); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// MarshalTo serializes an event to JSON into a caller-provided buffer
+func (s *JSONEventMarshaler) MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error {
+	return MarshalEventJSONTo(buf, event)
+}
@@ -0,0 +1,56 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalEventJSONTo_MatchesMarshalEventJSON(t *testing.T) {
+	event := cqrs.NewBaseEventMessage("PooledBufferEvent")
+	event.AddMetadata("tick", 42)
+
+	expected, err := MarshalEventJSON(event)
+	require.NoError(t, err)
+
+	buf := AcquireBuffer()
+	defer ReleaseBuffer(buf)
+
+	require.NoError(t, MarshalEventJSONTo(buf, event))
+	assert.Equal(t, expected, buf.Bytes())
+}
+
+func TestAcquireBuffer_ReturnsEmptyBuffer(t *testing.T) {
+	buf := AcquireBuffer()
+	buf.WriteString("stale")
+	ReleaseBuffer(buf)
+
+	reused := AcquireBuffer()
+	defer ReleaseBuffer(reused)
+	assert.Equal(t, 0, reused.Len())
+}
+
+// 10k events/sec 수준의 직렬화 경로를 가정한 할당 비교 벤치마크
+func BenchmarkMarshalEventJSON(b *testing.B) {
+	event := cqrs.NewBaseEventMessage("PlayerMoved")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = MarshalEventJSON(event)
+		}
+	})
+}
+
+func BenchmarkMarshalEventJSONTo_Pooled(b *testing.B) {
+	event := cqrs.NewBaseEventMessage("PlayerMoved")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := AcquireBuffer()
+			_ = MarshalEventJSONTo(buf, event)
+			ReleaseBuffer(buf)
+		}
+	})
+}
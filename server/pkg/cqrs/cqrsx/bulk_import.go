@@ -0,0 +1,546 @@
+package cqrsx
+
+import (
+	"bufio"
+	"context"
+	"cqrs"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkImportMode selects how converted legacy rows are written
+type BulkImportMode string
+
+const (
+	ImportAsEvents BulkImportMode = "events" // One synthetic "<Type>Imported" event (version 1) per row
+	ImportAsState  BulkImportMode = "state"  // Direct StateStore write per row
+)
+
+// BulkImportRow is one legacy record read by a BulkImportReader
+type BulkImportRow struct {
+	Line   int // 1-based record number in the source (header excluded)
+	Fields map[string]interface{}
+}
+
+// BulkImportReader reads legacy records; Next returns io.EOF after the last row
+type BulkImportReader interface {
+	Next() (*BulkImportRow, error)
+}
+
+// csvImportReader reads rows keyed by the header line
+type csvImportReader struct {
+	reader *csv.Reader
+	header []string
+	line   int
+}
+
+// NewCSVImportReader reads CSV with a header row; every value is a string
+func NewCSVImportReader(r io.Reader) (BulkImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, cqrs.NewValidationError("failed to read CSV header", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\uFEFF"))
+	}
+	return &csvImportReader{reader: reader, header: header}, nil
+}
+
+func (r *csvImportReader) Next() (*BulkImportRow, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.line++
+
+	fields := make(map[string]interface{}, len(r.header))
+	for i, column := range r.header {
+		if i < len(record) {
+			fields[column] = record[i]
+		}
+	}
+	return &BulkImportRow{Line: r.line, Fields: fields}, nil
+}
+
+// jsonImportReader reads a JSON array of objects or JSON Lines
+type jsonImportReader struct {
+	decoder *json.Decoder
+	array   bool
+	line    int
+}
+
+// NewJSONImportReader reads either a JSON array of objects or one object per line.
+// Numbers are kept as json.Number so large legacy IDs are not rounded.
+func NewJSONImportReader(r io.Reader) (BulkImportReader, error) {
+	buffered := bufio.NewReader(r)
+	array := false
+	for {
+		b, err := buffered.Peek(1)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if b[0] == ' ' || b[0] == '\n' || b[0] == '\r' || b[0] == '\t' {
+			buffered.ReadByte()
+			continue
+		}
+		array = b[0] == '['
+		break
+	}
+
+	decoder := json.NewDecoder(buffered)
+	decoder.UseNumber()
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return nil, cqrs.NewValidationError("failed to read JSON array", err)
+		}
+	}
+	return &jsonImportReader{decoder: decoder, array: array}, nil
+}
+
+func (r *jsonImportReader) Next() (*BulkImportRow, error) {
+	if r.array && !r.decoder.More() {
+		return nil, io.EOF
+	}
+	var fields map[string]interface{}
+	if err := r.decoder.Decode(&fields); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, cqrs.NewValidationError(fmt.Sprintf("invalid JSON record %d", r.line+1), err)
+	}
+	r.line++
+	return &BulkImportRow{Line: r.line, Fields: fields}, nil
+}
+
+// BulkImportMapping converts legacy rows of one aggregate type
+type BulkImportMapping struct {
+	AggregateType string
+	Mode          BulkImportMode    // Defaults to ImportAsEvents
+	IDField       string            // Legacy column holding the aggregate ID (after renaming); required
+	EventType     string            // Event type for ImportAsEvents (default "<AggregateType>Imported")
+	Rename        map[string]string // Legacy column -> field name; other columns keep their names
+	Drop          []string          // Columns not carried over
+	Required      []string          // Fields (after renaming) that must be present and non-empty
+	// Transform validates and reshapes a row (types, units, defaults); an error rejects the row
+	Transform func(fields map[string]interface{}) (map[string]interface{}, error)
+	// StateKey builds the StateStore key for ImportAsState (default "<AggregateType>:<id>")
+	StateKey func(aggregateID string) string
+}
+
+// Validate checks the mapping
+func (m BulkImportMapping) Validate() error {
+	if m.AggregateType == "" {
+		return cqrs.NewValidationError("import mapping needs an aggregate type", nil)
+	}
+	if m.IDField == "" {
+		return cqrs.NewValidationError("import mapping needs an ID field", nil)
+	}
+	switch m.Mode {
+	case "", ImportAsEvents, ImportAsState:
+	default:
+		return cqrs.NewValidationError(fmt.Sprintf("unsupported import mode: %s", m.Mode), nil)
+	}
+	return nil
+}
+
+func (m BulkImportMapping) eventType() string {
+	if m.EventType != "" {
+		return m.EventType
+	}
+	return m.AggregateType + "Imported"
+}
+
+func (m BulkImportMapping) stateKey(aggregateID string) string {
+	if m.StateKey != nil {
+		return m.StateKey(aggregateID)
+	}
+	return m.AggregateType + ":" + aggregateID
+}
+
+// convert applies renaming, dropping, transformation and required-field checks
+func (m BulkImportMapping) convert(row *BulkImportRow) (string, map[string]interface{}, *BulkImportRowError) {
+	dropped := make(map[string]bool, len(m.Drop))
+	for _, column := range m.Drop {
+		dropped[column] = true
+	}
+
+	fields := make(map[string]interface{}, len(row.Fields))
+	for column, value := range row.Fields {
+		if dropped[column] {
+			continue
+		}
+		if renamed, ok := m.Rename[column]; ok {
+			column = renamed
+		}
+		fields[column] = value
+	}
+
+	if m.Transform != nil {
+		transformed, err := m.Transform(fields)
+		if err != nil {
+			return "", nil, &BulkImportRowError{Line: row.Line, Message: err.Error()}
+		}
+		fields = transformed
+	}
+
+	for _, field := range append([]string{m.IDField}, m.Required...) {
+		if value, ok := fields[field]; !ok || value == nil || fmt.Sprint(value) == "" {
+			return "", nil, &BulkImportRowError{Line: row.Line, Field: field, Message: "required field is missing"}
+		}
+	}
+	return fmt.Sprint(fields[m.IDField]), fields, nil
+}
+
+// ImportedEvent is the synthetic event recording the legacy state of an aggregate.
+// Register its event type with a payload struct (or map) so event stores can load it.
+type ImportedEvent struct {
+	cqrs.BaseEventMessage
+	Data map[string]interface{} `json:"data" bson:"data"`
+}
+
+// EventData returns the imported fields
+func (e *ImportedEvent) EventData() interface{} {
+	return e.Data
+}
+
+// BulkImportRowError describes a rejected row
+type BulkImportRowError struct {
+	Line        int    `json:"line"`
+	AggregateID string `json:"aggregate_id,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Message     string `json:"message"`
+}
+
+// BulkImportCheckpoint records how far a job got so it can resume after a crash
+type BulkImportCheckpoint struct {
+	JobID     string    `json:"job_id" bson:"_id"`
+	Line      int       `json:"line" bson:"line"` // Last source line whose batch was committed
+	Imported  int       `json:"imported" bson:"imported"`
+	Rejected  int       `json:"rejected" bson:"rejected"`
+	Skipped   int       `json:"skipped" bson:"skipped"`
+	Completed bool      `json:"completed" bson:"completed"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// BulkImportCheckpointStore persists import checkpoints
+type BulkImportCheckpointStore interface {
+	// LoadCheckpoint returns nil when the job has no checkpoint
+	LoadCheckpoint(ctx context.Context, jobID string) (*BulkImportCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint BulkImportCheckpoint) error
+}
+
+// BulkImportReport is the validation and progress report of one Import call
+type BulkImportReport struct {
+	JobID       string               `json:"job_id"`
+	ResumedFrom int                  `json:"resumed_from"` // Lines skipped because a previous run committed them
+	Read        int                  `json:"read"`         // Rows read in this run
+	Imported    int                  `json:"imported"`     // Aggregates written in this run
+	Skipped     int                  `json:"skipped"`      // Aggregates that already existed
+	Rejected    int                  `json:"rejected"`     // Rows failing validation in this run
+	Batches     int                  `json:"batches"`
+	Errors      []BulkImportRowError `json:"errors,omitempty"` // First MaxErrors rejected rows
+	Duration    time.Duration        `json:"duration"`
+}
+
+// EventStreamWriter is implemented by event stores that append several aggregate streams at once (MongoEventStore)
+type EventStreamWriter interface {
+	SaveEventStreams(ctx context.Context, streams []EventStreamAppend) error
+}
+
+// BulkImportConfig configures a BulkImporter
+type BulkImportConfig struct {
+	Events      EventStreamWriter         // Required for ImportAsEvents mappings
+	State       StateStore                // Required for ImportAsState mappings
+	Checkpoints BulkImportCheckpointStore // Defaults to an in-memory store (no resume across processes)
+	BatchSize   int                       // Rows per write (default 500)
+	MaxErrors   int                       // Rejected rows kept in the report (default 1000)
+	// StopOnError aborts on the first invalid row instead of reporting it and continuing
+	StopOnError bool
+}
+
+// BulkImporter converts legacy rows into imported events or state writes in batches
+type BulkImporter struct {
+	config BulkImportConfig
+	now    func() time.Time
+}
+
+// NewBulkImporter creates an importer
+func NewBulkImporter(config BulkImportConfig) *BulkImporter {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+	if config.MaxErrors <= 0 {
+		config.MaxErrors = 1000
+	}
+	if config.Checkpoints == nil {
+		config.Checkpoints = NewInMemoryBulkImportCheckpointStore()
+	}
+	return &BulkImporter{config: config, now: time.Now}
+}
+
+type pendingImport struct {
+	line        int
+	aggregateID string
+	fields      map[string]interface{}
+}
+
+// Import reads every row and writes it with the mapping. Running the same jobID again
+// resumes after the last committed batch; a completed job is a no-op.
+func (i *BulkImporter) Import(ctx context.Context, jobID string, mapping BulkImportMapping, reader BulkImportReader) (*BulkImportReport, error) {
+	if jobID == "" {
+		return nil, cqrs.NewValidationError("import job ID cannot be empty", nil)
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	if mapping.Mode == ImportAsState && i.config.State == nil || mapping.Mode != ImportAsState && i.config.Events == nil {
+		return nil, cqrs.NewValidationError(fmt.Sprintf("no store configured for %s import", mapping.AggregateType), nil)
+	}
+
+	started := time.Now()
+	report := &BulkImportReport{JobID: jobID}
+	checkpoint, err := i.config.Checkpoints.LoadCheckpoint(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		checkpoint = &BulkImportCheckpoint{JobID: jobID}
+	}
+	if checkpoint.Completed {
+		report.ResumedFrom = checkpoint.Line
+		return report, nil
+	}
+	report.ResumedFrom = checkpoint.Line
+
+	var batch []pendingImport
+	lastLine := checkpoint.Line
+	pendingRejected := 0 // Rejections are checkpointed with the batch that follows them
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		imported, skipped, err := i.write(ctx, jobID, mapping, batch)
+		if err != nil {
+			return err
+		}
+		report.Imported += imported
+		report.Skipped += skipped
+		report.Batches++
+		batch = batch[:0]
+
+		checkpoint.Line = lastLine
+		checkpoint.Imported += imported
+		checkpoint.Skipped += skipped
+		checkpoint.Rejected += pendingRejected
+		pendingRejected = 0
+		checkpoint.UpdatedAt = i.now().UTC()
+		return i.config.Checkpoints.SaveCheckpoint(ctx, *checkpoint)
+	}
+
+	seen := make(map[string]int) // aggregate ID -> line, for duplicate detection within the run
+	for {
+		if err := ctx.Err(); err != nil {
+			report.Duration = time.Since(started)
+			return report, err
+		}
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			report.Duration = time.Since(started)
+			return report, err
+		}
+		if row.Line <= checkpoint.Line {
+			continue // Committed by a previous run
+		}
+		report.Read++
+		lastLine = row.Line
+
+		aggregateID, fields, rowErr := mapping.convert(row)
+		if rowErr == nil {
+			if first, duplicate := seen[aggregateID]; duplicate {
+				rowErr = &BulkImportRowError{Line: row.Line, Field: mapping.IDField, Message: fmt.Sprintf("duplicate aggregate ID (first seen on line %d)", first)}
+			}
+		}
+		if rowErr != nil {
+			rowErr.AggregateID = aggregateID
+			report.Rejected++
+			pendingRejected++
+			if len(report.Errors) < i.config.MaxErrors {
+				report.Errors = append(report.Errors, *rowErr)
+			}
+			if i.config.StopOnError {
+				report.Duration = time.Since(started)
+				return report, cqrs.NewValidationError(fmt.Sprintf("line %d: %s", rowErr.Line, rowErr.Message), nil)
+			}
+			continue
+		}
+		seen[aggregateID] = row.Line
+
+		batch = append(batch, pendingImport{line: row.Line, aggregateID: aggregateID, fields: fields})
+		if len(batch) >= i.config.BatchSize {
+			if err := flush(); err != nil {
+				report.Duration = time.Since(started)
+				return report, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		report.Duration = time.Since(started)
+		return report, err
+	}
+	checkpoint.Line = lastLine
+	checkpoint.Rejected += pendingRejected
+	checkpoint.Completed = true
+	checkpoint.UpdatedAt = i.now().UTC()
+	report.Duration = time.Since(started)
+	return report, i.config.Checkpoints.SaveCheckpoint(ctx, *checkpoint)
+}
+
+// write stores one batch and returns how many aggregates were written and skipped as existing
+func (i *BulkImporter) write(ctx context.Context, jobID string, mapping BulkImportMapping, batch []pendingImport) (int, int, error) {
+	if mapping.Mode == ImportAsState {
+		for _, item := range batch {
+			if err := i.config.State.SaveState(ctx, mapping.stateKey(item.aggregateID), item.fields); err != nil {
+				return 0, 0, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+					fmt.Sprintf("failed to import %s %s (line %d)", mapping.AggregateType, item.aggregateID, item.line), err)
+			}
+		}
+		return len(batch), 0, nil
+	}
+
+	streams := make([]EventStreamAppend, len(batch))
+	for index, item := range batch {
+		streams[index] = EventStreamAppend{
+			AggregateID:     item.aggregateID,
+			Events:          []cqrs.EventMessage{i.importedEvent(jobID, mapping, item)},
+			ExpectedVersion: 0, // Imported aggregates must not exist yet
+		}
+	}
+
+	err := i.config.Events.SaveEventStreams(ctx, streams)
+	if err == nil {
+		return len(batch), 0, nil
+	}
+	if !errors.Is(err, cqrs.ErrConcurrencyConflict) {
+		return 0, 0, err
+	}
+
+	// Some aggregates already exist, typically because the batch was committed before the
+	// checkpoint was saved. Retry stream by stream and skip the existing ones.
+	imported, skipped := 0, 0
+	for _, stream := range streams {
+		err := i.config.Events.SaveEventStreams(ctx, []EventStreamAppend{stream})
+		switch {
+		case err == nil:
+			imported++
+		case errors.Is(err, cqrs.ErrConcurrencyConflict):
+			skipped++
+		default:
+			return imported, skipped, err
+		}
+	}
+	return imported, skipped, nil
+}
+
+func (i *BulkImporter) importedEvent(jobID string, mapping BulkImportMapping, item pendingImport) *ImportedEvent {
+	return &ImportedEvent{
+		BaseEventMessage: cqrs.BaseEventMessage{
+			EventID_:       uuid.NewString(),
+			EventType_:     mapping.eventType(),
+			AggregateID_:   item.aggregateID,
+			AggregateType_: mapping.AggregateType,
+			Version_:       1,
+			Timestamp_:     i.now().UTC(),
+			Metadata_: map[string]interface{}{
+				"import_job":  jobID,
+				"import_line": item.line,
+			},
+		},
+		Data: item.fields,
+	}
+}
+
+// InMemoryBulkImportCheckpointStore keeps checkpoints in memory
+type InMemoryBulkImportCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]BulkImportCheckpoint
+}
+
+// NewInMemoryBulkImportCheckpointStore creates an empty checkpoint store
+func NewInMemoryBulkImportCheckpointStore() *InMemoryBulkImportCheckpointStore {
+	return &InMemoryBulkImportCheckpointStore{checkpoints: make(map[string]BulkImportCheckpoint)}
+}
+
+func (s *InMemoryBulkImportCheckpointStore) LoadCheckpoint(ctx context.Context, jobID string) (*BulkImportCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoint, exists := s.checkpoints[jobID]
+	if !exists {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (s *InMemoryBulkImportCheckpointStore) SaveCheckpoint(ctx context.Context, checkpoint BulkImportCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[checkpoint.JobID] = checkpoint
+	return nil
+}
+
+// MongoBulkImportCheckpointStore keeps checkpoints in a Mongo collection (default "bulk_import_checkpoints")
+type MongoBulkImportCheckpointStore struct {
+	client     *MongoClientManager
+	collection string
+}
+
+// NewMongoBulkImportCheckpointStore creates a Mongo checkpoint store
+func NewMongoBulkImportCheckpointStore(client *MongoClientManager, collection string) *MongoBulkImportCheckpointStore {
+	if collection == "" {
+		collection = "bulk_import_checkpoints"
+	}
+	return &MongoBulkImportCheckpointStore{client: client, collection: collection}
+}
+
+func (s *MongoBulkImportCheckpointStore) LoadCheckpoint(ctx context.Context, jobID string) (*BulkImportCheckpoint, error) {
+	var checkpoint BulkImportCheckpoint
+	err := s.client.ExecuteCommand(ctx, func() error {
+		return s.client.GetCollection(s.collection).FindOne(ctx, bson.M{"_id": jobID}).Decode(&checkpoint)
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to load import checkpoint", err)
+	}
+	return &checkpoint, nil
+}
+
+func (s *MongoBulkImportCheckpointStore) SaveCheckpoint(ctx context.Context, checkpoint BulkImportCheckpoint) error {
+	return s.client.ExecuteCommand(ctx, func() error {
+		_, err := s.client.GetCollection(s.collection).ReplaceOne(ctx, bson.M{"_id": checkpoint.JobID}, checkpoint, options.Replace().SetUpsert(true))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to save import checkpoint", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,203 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStreamWriter appends event streams transactionally like MongoEventStore.SaveEventStreams
+type memoryStreamWriter struct {
+	mu        sync.Mutex
+	streams   map[string][]cqrs.EventMessage
+	calls     int
+	failAfter int // Commit this many calls, then fail the next one before returning success
+}
+
+func (w *memoryStreamWriter) SaveEventStreams(ctx context.Context, streams []EventStreamAppend) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.streams == nil {
+		w.streams = make(map[string][]cqrs.EventMessage)
+	}
+	for _, stream := range streams {
+		if len(w.streams[stream.AggregateID]) != stream.ExpectedVersion {
+			return cqrs.NewCQRSError(cqrs.ErrCodeConcurrencyConflict.String(), "conflict", cqrs.ErrConcurrencyConflict)
+		}
+	}
+	for _, stream := range streams {
+		w.streams[stream.AggregateID] = append(w.streams[stream.AggregateID], stream.Events...)
+	}
+	w.calls++
+	if w.failAfter > 0 && w.calls > w.failAfter {
+		return errors.New("connection reset") // Committed, but the caller never learns it
+	}
+	return nil
+}
+
+type memoryStateStore struct {
+	states map[string]interface{}
+}
+
+func (s *memoryStateStore) SaveState(ctx context.Context, key string, state interface{}) error {
+	if s.states == nil {
+		s.states = make(map[string]interface{})
+	}
+	s.states[key] = state
+	return nil
+}
+
+func (s *memoryStateStore) GetState(ctx context.Context, key string, result interface{}) error {
+	return nil
+}
+
+func (s *memoryStateStore) DeleteState(ctx context.Context, key string) error {
+	delete(s.states, key)
+	return nil
+}
+
+func (s *memoryStateStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, exists := s.states[key]
+	return exists, nil
+}
+
+func legacyPlayersCSV(rows int) string {
+	var builder strings.Builder
+	builder.WriteString("user_no,nick,gold,password_hash\n")
+	for i := 1; i <= rows; i++ {
+		fmt.Fprintf(&builder, "%d,player%d,%d,secret\n", i, i, i*100)
+	}
+	return builder.String()
+}
+
+func playerMapping() BulkImportMapping {
+	return BulkImportMapping{
+		AggregateType: "Player",
+		IDField:       "player_id",
+		Rename:        map[string]string{"user_no": "player_id", "nick": "nickname"},
+		Drop:          []string{"password_hash"},
+		Required:      []string{"nickname"},
+		Transform: func(fields map[string]interface{}) (map[string]interface{}, error) {
+			gold, err := strconv.Atoi(fmt.Sprint(fields["gold"]))
+			if err != nil || gold < 0 {
+				return nil, fmt.Errorf("invalid gold %q", fields["gold"])
+			}
+			fields["gold"] = gold
+			return fields, nil
+		},
+	}
+}
+
+func TestBulkImporter_ImportsEventsWithValidationReport(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	csvData := "user_no,nick,gold,password_hash\n" +
+		"1,alice,100,x\n" +
+		"2,,50,x\n" + // Missing nickname
+		"3,carol,lots,x\n" + // Invalid gold
+		"1,alice2,10,x\n" + // Duplicate ID
+		"4,dave,0,x\n"
+	reader, err := NewCSVImportReader(strings.NewReader(csvData))
+	require.NoError(t, err)
+	writer := &memoryStreamWriter{}
+	importer := NewBulkImporter(BulkImportConfig{Events: writer, BatchSize: 2})
+
+	// Act
+	report, err := importer.Import(ctx, "players-2025", playerMapping(), reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Read)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 3, report.Rejected)
+	require.Len(t, report.Errors, 3)
+	assert.Equal(t, BulkImportRowError{Line: 2, Field: "nickname", AggregateID: "", Message: "required field is missing"}, report.Errors[0])
+	assert.Contains(t, report.Errors[1].Message, "invalid gold")
+	assert.Contains(t, report.Errors[2].Message, "duplicate aggregate ID (first seen on line 1)")
+
+	require.Len(t, writer.streams["1"], 1)
+	event := writer.streams["1"][0].(*ImportedEvent)
+	assert.Equal(t, "PlayerImported", event.EventType())
+	assert.Equal(t, "Player", event.AggregateType())
+	assert.Equal(t, 1, event.Version())
+	assert.Equal(t, map[string]interface{}{"player_id": "1", "nickname": "alice", "gold": 100}, event.EventData())
+	assert.Equal(t, "players-2025", event.Metadata()["import_job"])
+
+	// A completed job is not imported twice
+	again, err := importer.Import(ctx, "players-2025", playerMapping(), reader)
+	require.NoError(t, err)
+	assert.Zero(t, again.Read)
+	assert.Equal(t, 5, again.ResumedFrom)
+}
+
+func TestBulkImporter_ResumesFromCheckpoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	checkpoints := NewInMemoryBulkImportCheckpointStore()
+	writer := &memoryStreamWriter{failAfter: 2}
+	importer := NewBulkImporter(BulkImportConfig{Events: writer, Checkpoints: checkpoints, BatchSize: 3})
+	first, err := NewCSVImportReader(strings.NewReader(legacyPlayersCSV(10)))
+	require.NoError(t, err)
+
+	// Act
+	_, crashErr := importer.Import(ctx, "players", playerMapping(), first)
+	writer.failAfter = 0
+	second, err := NewCSVImportReader(strings.NewReader(legacyPlayersCSV(10)))
+	require.NoError(t, err)
+	report, err := importer.Import(ctx, "players", playerMapping(), second)
+
+	// Assert
+	require.Error(t, crashErr)
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.ResumedFrom) // Two batches were checkpointed
+	assert.Equal(t, 4, report.Read)
+	assert.Equal(t, 3, report.Skipped) // Third batch committed before the crash
+	assert.Equal(t, 1, report.Imported)
+	assert.Len(t, writer.streams, 10)
+	for id, events := range writer.streams {
+		assert.Len(t, events, 1, id)
+	}
+
+	checkpoint, err := checkpoints.LoadCheckpoint(ctx, "players")
+	require.NoError(t, err)
+	assert.True(t, checkpoint.Completed)
+	assert.Equal(t, 7, checkpoint.Imported)
+	assert.Equal(t, 3, checkpoint.Skipped)
+}
+
+func TestBulkImporter_StateModeFromJSON(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	state := &memoryStateStore{}
+	importer := NewBulkImporter(BulkImportConfig{State: state})
+	reader, err := NewJSONImportReader(strings.NewReader(`
+		[{"guild_no": 9007199254740993, "name": "Allies"}, {"guild_no": 2, "name": "Axis"}]`))
+	require.NoError(t, err)
+	mapping := BulkImportMapping{AggregateType: "Guild", Mode: ImportAsState, IDField: "guild_no"}
+
+	// Act
+	report, err := importer.Import(ctx, "guilds", mapping, reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Contains(t, state.states, "Guild:9007199254740993") // Large IDs are not rounded
+	assert.Contains(t, state.states, "Guild:2")
+
+	lines, err := NewJSONImportReader(strings.NewReader("{\"id\":1}\n{\"id\":2}\n"))
+	require.NoError(t, err)
+	row, err := lines.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, row.Line)
+
+	_, err = importer.Import(ctx, "events", playerMapping(), lines)
+	assert.Error(t, err) // No event store configured
+}
@@ -0,0 +1,439 @@
+package cqrsx
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// CloudEvents 1.0 (https://github.com/cloudevents/spec) encoding for events leaving the system.
+//
+// Mapping:
+//
+//	id               <- EventID
+//	type             <- TypePrefix + EventType
+//	source           <- codec source (e.g. "/defense-allies/game-server")
+//	subject          <- AggregateID
+//	time             <- Timestamp
+//	aggregatetype    <- AggregateType (extension)
+//	aggregateversion <- Version (extension)
+//	data             <- event payload without envelope fields
+//
+// Metadata entries whose key is a valid extension name and whose value is a
+// scalar become extensions of the same name; everything else is kept in the
+// JSON-encoded "eventmetadata" extension, so decoding restores the metadata.
+const (
+	CloudEventsSpecVersion         = "1.0"
+	CloudEventsContentType         = "application/cloudevents+json"
+	CloudEventAggregateTypeExt     = "aggregatetype"
+	CloudEventAggregateVersionExt  = "aggregateversion"
+	CloudEventMetadataExt          = "eventmetadata"
+	cloudEventsKafkaHeaderPrefix   = "ce_"
+	cloudEventsDataContentTypeJSON = "application/json"
+)
+
+// cloudEventExtensionName matches the attribute naming rule of the spec
+var cloudEventExtensionName = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// Context attributes that can never be used as extension names
+var cloudEventReservedAttributes = map[string]bool{
+	"id": true, "source": true, "specversion": true, "type": true,
+	"datacontenttype": true, "dataschema": true, "subject": true, "time": true,
+	"data": true, "data_base64": true,
+	CloudEventAggregateTypeExt: true, CloudEventAggregateVersionExt: true, CloudEventMetadataExt: true,
+}
+
+// Envelope fields of the flat event JSON that are carried as attributes instead of data
+var cloudEventEnvelopeFields = []string{"eventId", "eventType", "aggregateId", "aggregateType", "version", "metadata", "timestamp"}
+
+// CloudEvent is a CloudEvents 1.0 event in structured JSON form
+type CloudEvent struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	Data            json.RawMessage
+	Extensions      map[string]interface{}
+}
+
+// Validate checks the required attributes
+func (e *CloudEvent) Validate() error {
+	switch {
+	case e.ID == "":
+		return cqrs.NewValidationError("cloud event id is required", nil)
+	case e.Source == "":
+		return cqrs.NewValidationError("cloud event source is required", nil)
+	case e.Type == "":
+		return cqrs.NewValidationError("cloud event type is required", nil)
+	case e.SpecVersion != CloudEventsSpecVersion:
+		return cqrs.NewValidationError(fmt.Sprintf("unsupported cloud event specversion %q", e.SpecVersion), nil)
+	}
+	return nil
+}
+
+// MarshalJSON writes the structured content mode representation
+func (e CloudEvent) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(e.Extensions)+9)
+	for name, value := range e.Extensions {
+		fields[name] = value
+	}
+
+	fields["specversion"] = e.SpecVersion
+	fields["id"] = e.ID
+	fields["source"] = e.Source
+	fields["type"] = e.Type
+	if e.Subject != "" {
+		fields["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		fields["time"] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if e.DataContentType != "" {
+		fields["datacontenttype"] = e.DataContentType
+	}
+	if e.DataSchema != "" {
+		fields["dataschema"] = e.DataSchema
+	}
+	if len(e.Data) > 0 {
+		fields["data"] = e.Data
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON reads the structured content mode representation
+func (e *CloudEvent) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*e = CloudEvent{Extensions: make(map[string]interface{})}
+	for name, raw := range fields {
+		var err error
+		switch name {
+		case "specversion":
+			err = json.Unmarshal(raw, &e.SpecVersion)
+		case "id":
+			err = json.Unmarshal(raw, &e.ID)
+		case "source":
+			err = json.Unmarshal(raw, &e.Source)
+		case "type":
+			err = json.Unmarshal(raw, &e.Type)
+		case "subject":
+			err = json.Unmarshal(raw, &e.Subject)
+		case "time":
+			var value string
+			if err = json.Unmarshal(raw, &value); err == nil {
+				e.Time, err = time.Parse(time.RFC3339Nano, value)
+			}
+		case "datacontenttype":
+			err = json.Unmarshal(raw, &e.DataContentType)
+		case "dataschema":
+			err = json.Unmarshal(raw, &e.DataSchema)
+		case "data":
+			e.Data = append(json.RawMessage(nil), raw...)
+		case "data_base64":
+			return fmt.Errorf("binary cloud event data is not supported")
+		default:
+			var value interface{}
+			err = json.Unmarshal(raw, &value)
+			e.Extensions[name] = value
+		}
+		if err != nil {
+			return fmt.Errorf("invalid cloud event attribute %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CloudEventCodec converts domain events to and from CloudEvents
+type CloudEventCodec struct {
+	source     string
+	typePrefix string
+	registry   EventRegistry
+}
+
+// CloudEventCodecOption configures a CloudEventCodec
+type CloudEventCodecOption func(*CloudEventCodec)
+
+// WithCloudEventTypePrefix prefixes event types, e.g. "com.defenseallies." -> "com.defenseallies.GuildCreated"
+func WithCloudEventTypePrefix(prefix string) CloudEventCodecOption {
+	return func(c *CloudEventCodec) {
+		c.typePrefix = prefix
+	}
+}
+
+// NewCloudEventCodec creates a codec.
+// registry resolves concrete event types on decode; with nil, events decode to *UnknownEvent.
+func NewCloudEventCodec(source string, registry EventRegistry, options ...CloudEventCodecOption) *CloudEventCodec {
+	codec := &CloudEventCodec{source: source, registry: registry}
+	for _, option := range options {
+		option(codec)
+	}
+	return codec
+}
+
+// Encode maps a domain event to a CloudEvent
+func (c *CloudEventCodec) Encode(event cqrs.EventMessage) (*CloudEvent, error) {
+	if event == nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "event cannot be nil", nil)
+	}
+
+	flat, err := MarshalEventJSON(event)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to encode event payload", err)
+	}
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(flat, &payload); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "event payload is not a JSON object", err)
+	}
+	for _, field := range cloudEventEnvelopeFields {
+		delete(payload, field)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to encode event data", err)
+	}
+
+	ce := &CloudEvent{
+		ID:              event.EventID(),
+		Source:          c.source,
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            c.typePrefix + event.EventType(),
+		Subject:         event.AggregateID(),
+		Time:            event.Timestamp(),
+		DataContentType: cloudEventsDataContentTypeJSON,
+		Data:            data,
+		Extensions:      make(map[string]interface{}),
+	}
+	if event.AggregateType() != "" {
+		ce.Extensions[CloudEventAggregateTypeExt] = event.AggregateType()
+	}
+	ce.Extensions[CloudEventAggregateVersionExt] = event.Version()
+
+	rest := make(map[string]interface{})
+	for key, value := range event.Metadata() {
+		if cloudEventExtensionName.MatchString(key) && !cloudEventReservedAttributes[key] && isCloudEventScalar(value) {
+			ce.Extensions[key] = value
+		} else {
+			rest[key] = value
+		}
+	}
+	if len(rest) > 0 {
+		encoded, err := json.Marshal(rest)
+		if err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to encode event metadata", err)
+		}
+		ce.Extensions[CloudEventMetadataExt] = string(encoded)
+	}
+
+	return ce, ce.Validate()
+}
+
+func isCloudEventScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int32, int64, float64:
+		return true
+	}
+	return false
+}
+
+// Decode maps a CloudEvent back to a domain event
+func (c *CloudEventCodec) Decode(ce *CloudEvent) (cqrs.EventMessage, error) {
+	if err := ce.Validate(); err != nil {
+		return nil, err
+	}
+	if c.typePrefix != "" && !strings.HasPrefix(ce.Type, c.typePrefix) {
+		return nil, cqrs.NewValidationError(fmt.Sprintf("cloud event type %q does not have prefix %q", ce.Type, c.typePrefix), nil)
+	}
+
+	metadata := make(map[string]interface{})
+	if encoded, ok := ce.Extensions[CloudEventMetadataExt].(string); ok && encoded != "" {
+		if err := json.Unmarshal([]byte(encoded), &metadata); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "invalid eventmetadata extension", err)
+		}
+	}
+	for name, value := range ce.Extensions {
+		if !cloudEventReservedAttributes[name] {
+			metadata[name] = value
+		}
+	}
+
+	flat := make(map[string]interface{})
+	if len(ce.Data) > 0 {
+		if err := json.Unmarshal(ce.Data, &flat); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "cloud event data is not a JSON object", err)
+		}
+	}
+	aggregateType, _ := ce.Extensions[CloudEventAggregateTypeExt].(string)
+	flat["eventId"] = ce.ID
+	flat["eventType"] = strings.TrimPrefix(ce.Type, c.typePrefix)
+	flat["aggregateId"] = ce.Subject
+	flat["aggregateType"] = aggregateType
+	flat["version"] = cloudEventInt(ce.Extensions[CloudEventAggregateVersionExt])
+	flat["metadata"] = metadata
+	flat["timestamp"] = ce.Time
+
+	encoded, err := json.Marshal(flat)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to rebuild event", err)
+	}
+
+	if c.registry == nil {
+		unknown := &UnknownEvent{}
+		if err := json.Unmarshal(encoded, unknown); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to decode cloud event", err)
+		}
+		return unknown, nil
+	}
+	return UnmarshalEventJSON(encoded, c.registry)
+}
+
+// cloudEventInt accepts integers from JSON numbers and binary-mode header strings
+func cloudEventInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+// MarshalStructured encodes an event as application/cloudevents+json
+func (c *CloudEventCodec) MarshalStructured(event cqrs.EventMessage) ([]byte, error) {
+	ce, err := c.Encode(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ce)
+}
+
+// UnmarshalStructured decodes an application/cloudevents+json body
+func (c *CloudEventCodec) UnmarshalStructured(data []byte) (cqrs.EventMessage, error) {
+	var ce CloudEvent
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "invalid cloud event", err)
+	}
+	return c.Decode(&ce)
+}
+
+// NewHTTPRequest builds a structured-mode webhook request
+func (c *CloudEventCodec) NewHTTPRequest(ctx context.Context, url string, event cqrs.EventMessage) (*http.Request, error) {
+	body, err := c.MarshalStructured(event)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", CloudEventsContentType)
+	return req, nil
+}
+
+// ToMessage encodes an event as a binary-mode watermill message (Kafka protocol binding):
+// attributes become "ce_" metadata, which watermill-kafka writes as record headers.
+// Header values are strings, so scalar metadata extensions decode as strings.
+func (c *CloudEventCodec) ToMessage(event cqrs.EventMessage) (*message.Message, error) {
+	ce, err := c.Encode(event)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := message.NewMessage(ce.ID, message.Payload(ce.Data))
+	for name, value := range binaryAttributes(ce) {
+		msg.Metadata.Set(cloudEventsKafkaHeaderPrefix+name, value)
+	}
+	msg.Metadata.Set("content-type", ce.DataContentType)
+	return msg, nil
+}
+
+// FromMessage decodes a binary-mode watermill message produced by ToMessage
+func (c *CloudEventCodec) FromMessage(msg *message.Message) (cqrs.EventMessage, error) {
+	attributes := make(map[string]string)
+	for key, value := range msg.Metadata {
+		if strings.HasPrefix(key, cloudEventsKafkaHeaderPrefix) {
+			attributes[strings.TrimPrefix(key, cloudEventsKafkaHeaderPrefix)] = value
+		}
+	}
+
+	ce, err := fromBinaryAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+	ce.DataContentType = msg.Metadata.Get("content-type")
+	ce.Data = json.RawMessage(msg.Payload)
+	return c.Decode(ce)
+}
+
+// binaryAttributes renders context attributes and extensions as header strings
+func binaryAttributes(ce *CloudEvent) map[string]string {
+	attributes := map[string]string{
+		"specversion": ce.SpecVersion,
+		"id":          ce.ID,
+		"source":      ce.Source,
+		"type":        ce.Type,
+	}
+	if ce.Subject != "" {
+		attributes["subject"] = ce.Subject
+	}
+	if !ce.Time.IsZero() {
+		attributes["time"] = ce.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if ce.DataSchema != "" {
+		attributes["dataschema"] = ce.DataSchema
+	}
+	for name, value := range ce.Extensions {
+		attributes[name] = fmt.Sprint(value)
+	}
+	return attributes
+}
+
+func fromBinaryAttributes(attributes map[string]string) (*CloudEvent, error) {
+	ce := &CloudEvent{Extensions: make(map[string]interface{})}
+	for name, value := range attributes {
+		switch name {
+		case "specversion":
+			ce.SpecVersion = value
+		case "id":
+			ce.ID = value
+		case "source":
+			ce.Source = value
+		case "type":
+			ce.Type = value
+		case "subject":
+			ce.Subject = value
+		case "dataschema":
+			ce.DataSchema = value
+		case "time":
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, cqrs.NewValidationError("invalid cloud event time", err)
+			}
+			ce.Time = parsed
+		default:
+			ce.Extensions[name] = value
+		}
+	}
+	return ce, nil
+}
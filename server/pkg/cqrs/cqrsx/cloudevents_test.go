@@ -0,0 +1,140 @@
+package cqrsx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCloudEventTestEvent() *guildCreatedV2 {
+	event := newGuildCreated(2)
+	event.AggregateID_ = "guild-1"
+	event.AggregateType_ = "Guild"
+	event.Version_ = 3
+	event.AddMetadata("correlationid", "corr-1")
+	event.AddMetadata("userId", "user-7")
+	return event
+}
+
+func newCloudEventTestCodec(t *testing.T) *CloudEventCodec {
+	registry := NewVersionedEventRegistry()
+	require.NoError(t, RegisterEvent[guildCreatedV2](registry, "GuildCreated", 2))
+	return NewCloudEventCodec("/defense-allies/game-server", registry, WithCloudEventTypePrefix("com.defenseallies."))
+}
+
+func TestCloudEventCodec_EncodeMapsAttributes(t *testing.T) {
+	// Arrange
+	codec := newCloudEventTestCodec(t)
+	event := newCloudEventTestEvent()
+
+	// Act
+	body, err := codec.MarshalStructured(event)
+	require.NoError(t, err)
+
+	// Assert
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	assert.Equal(t, "1.0", fields["specversion"])
+	assert.Equal(t, event.EventID(), fields["id"])
+	assert.Equal(t, "com.defenseallies.GuildCreated", fields["type"])
+	assert.Equal(t, "/defense-allies/game-server", fields["source"])
+	assert.Equal(t, "guild-1", fields["subject"])
+	assert.Equal(t, "Guild", fields["aggregatetype"])
+	assert.Equal(t, float64(3), fields["aggregateversion"])
+	assert.Equal(t, "corr-1", fields["correlationid"])
+	assert.Equal(t, map[string]interface{}{"name": "Allies", "tag": "ALY"}, fields["data"])
+	assert.Contains(t, fields["eventmetadata"], `"userId":"user-7"`)
+}
+
+func TestCloudEventCodec_StructuredRoundTrip(t *testing.T) {
+	// Arrange
+	codec := newCloudEventTestCodec(t)
+	event := newCloudEventTestEvent()
+	body, err := codec.MarshalStructured(event)
+	require.NoError(t, err)
+
+	// Act
+	decoded, err := codec.UnmarshalStructured(body)
+
+	// Assert
+	require.NoError(t, err)
+	guild, ok := decoded.(*guildCreatedV2)
+	require.True(t, ok)
+	assert.Equal(t, event.EventID(), guild.EventID())
+	assert.Equal(t, "GuildCreated", guild.EventType())
+	assert.Equal(t, "guild-1", guild.AggregateID())
+	assert.Equal(t, "Guild", guild.AggregateType())
+	assert.Equal(t, 3, guild.Version())
+	assert.True(t, event.Timestamp().Equal(guild.Timestamp()))
+	assert.Equal(t, "ALY", guild.Tag)
+	assert.Equal(t, "corr-1", guild.Metadata()["correlationid"])
+	assert.Equal(t, "user-7", guild.Metadata()["userId"])
+}
+
+func TestCloudEventCodec_BinaryMessageRoundTrip(t *testing.T) {
+	// Arrange
+	codec := newCloudEventTestCodec(t)
+	event := newCloudEventTestEvent()
+
+	// Act
+	msg, err := codec.ToMessage(event)
+	require.NoError(t, err)
+	decoded, err := codec.FromMessage(msg)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "com.defenseallies.GuildCreated", msg.Metadata.Get("ce_type"))
+	assert.Equal(t, "guild-1", msg.Metadata.Get("ce_subject"))
+	assert.Equal(t, "application/json", msg.Metadata.Get("content-type"))
+	assert.JSONEq(t, `{"name":"Allies","tag":"ALY"}`, string(msg.Payload))
+	assert.Equal(t, 3, decoded.Version())
+	assert.Equal(t, "Allies", decoded.(*guildCreatedV2).Name)
+}
+
+func TestCloudEventCodec_DecodeWithoutRegistry(t *testing.T) {
+	// Arrange
+	body := []byte(`{"specversion":"1.0","id":"e-1","source":"/partner","type":"ScoreGained","subject":"p-1","data":{"score":10}}`)
+	codec := NewCloudEventCodec("/defense-allies", nil)
+
+	// Act
+	decoded, err := codec.UnmarshalStructured(body)
+
+	// Assert
+	require.NoError(t, err)
+	unknown, ok := decoded.(*UnknownEvent)
+	require.True(t, ok)
+	assert.Equal(t, "ScoreGained", unknown.EventType())
+	assert.Equal(t, "p-1", unknown.AggregateID())
+	assert.Equal(t, float64(10), unknown.Payload["score"])
+}
+
+func TestCloudEventCodec_RejectsInvalidEvents(t *testing.T) {
+	// Arrange
+	codec := newCloudEventTestCodec(t)
+
+	// Act
+	_, missingSource := codec.UnmarshalStructured([]byte(`{"specversion":"1.0","id":"e-1","type":"com.defenseallies.GuildCreated"}`))
+	_, wrongVersion := codec.UnmarshalStructured([]byte(`{"specversion":"0.3","id":"e-1","source":"/x","type":"com.defenseallies.GuildCreated"}`))
+	_, wrongPrefix := codec.UnmarshalStructured([]byte(`{"specversion":"1.0","id":"e-1","source":"/x","type":"GuildCreated"}`))
+
+	// Assert
+	assert.Error(t, missingSource)
+	assert.Error(t, wrongVersion)
+	assert.Error(t, wrongPrefix)
+}
+
+func TestCloudEventCodec_NewHTTPRequest(t *testing.T) {
+	// Arrange
+	codec := newCloudEventTestCodec(t)
+
+	// Act
+	req, err := codec.NewHTTPRequest(context.Background(), "http://partner.example/hooks", newCloudEventTestEvent())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, CloudEventsContentType, req.Header.Get("Content-Type"))
+	assert.Equal(t, "POST", req.Method)
+}
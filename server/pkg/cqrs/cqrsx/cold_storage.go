@@ -0,0 +1,418 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ColdStorage keeps archived aggregates outside the hot store (object storage, disk, a cheaper database)
+type ColdStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns a not found CQRSError (cqrs.IsNotFoundError) when key is not archived
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FileColdStorage stores each archive as a file under a directory
+type FileColdStorage struct {
+	dir string
+}
+
+// NewFileColdStorage creates a file cold storage, creating dir if needed
+func NewFileColdStorage(dir string) (*FileColdStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to create cold storage directory", err)
+	}
+	return &FileColdStorage{dir: dir}, nil
+}
+
+func (s *FileColdStorage) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".archive")
+}
+
+// Put writes the archive atomically so a crash never leaves a partial file behind
+func (s *FileColdStorage) Put(ctx context.Context, key string, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".archive-*")
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to create cold archive", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to write cold archive", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to write cold archive", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to store cold archive", err)
+	}
+	return nil
+}
+
+func (s *FileColdStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, cqrs.NewNotFoundError("cold archive not found: "+key, nil)
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read cold archive", err)
+	}
+	return data, nil
+}
+
+func (s *FileColdStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := os.Stat(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to check cold archive", err)
+	}
+	return true, nil
+}
+
+func (s *FileColdStorage) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to delete cold archive", err)
+	}
+	return nil
+}
+
+// TierableEventStore is an event store whose streams can be moved out and back in
+type TierableEventStore interface {
+	SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error
+	GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+	DeleteEvents(ctx context.Context, aggregateID string, aggregateType string) error
+}
+
+// TieringPolicy decides which aggregates move to cold storage
+type TieringPolicy struct {
+	InactiveAfter  time.Duration // Aggregates not accessed for this long are evicted
+	MaxPerRun      int           // Evictions per RunPolicy call; 0 is unlimited
+	ReadModelTypes []string      // Read models stored under the aggregate ID that move with it
+}
+
+// ColdStorageTieringConfig configures ColdStorageTiering
+type ColdStorageTieringConfig struct {
+	Policy              TieringPolicy
+	Events              TierableEventStore            // Required: hot event store
+	Cold                ColdStorage                   // Required: archive destination
+	Metrics             *cqrs.StorageMetricsCollector // Required by RunPolicy: source of LastAccessed
+	EventMarshaler      EventMarshaler                // Defaults to JSONEventMarshaler
+	ReadStore           cqrs.ReadStore                // Optional: hot read models to move
+	ReadModelSerializer ReadModelSerializer           // Defaults to JSONReadModelSerializer
+	Snapshots           cqrs.SnapshotStore            // Optional: hot snapshots dropped on eviction
+	Locker              cqrs.AggregateLocker          // Optional: serializes eviction and restore with writers
+	LockTTL             time.Duration                 // Defaults to 30 seconds
+}
+
+// coldArchive is the stored form of an evicted aggregate
+type coldArchive struct {
+	AggregateID   string            `json:"aggregate_id"`
+	AggregateType string            `json:"aggregate_type"`
+	Version       int               `json:"version"`
+	ArchivedAt    time.Time         `json:"archived_at"`
+	Events        [][]byte          `json:"events"`
+	ReadModels    []coldArchiveView `json:"read_models,omitempty"`
+}
+
+type coldArchiveView struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// TieringReport is the outcome of one RunPolicy call
+type TieringReport struct {
+	StartedAt time.Time         `json:"started_at"`
+	Evicted   []string          `json:"evicted"`          // "type/id" of evicted aggregates
+	Errors    map[string]string `json:"errors,omitempty"` // "type/id" -> eviction error
+}
+
+// ColdStorageTiering moves aggregates that have not been accessed for a while from the
+// hot stores to cold storage and restores them on demand
+type ColdStorageTiering struct {
+	config ColdStorageTieringConfig
+
+	mutex     sync.Mutex
+	firstSeen map[string]time.Time // Aggregates without a recorded access, by when a run first saw them
+	now       func() time.Time
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewColdStorageTiering creates a tiering manager
+func NewColdStorageTiering(config ColdStorageTieringConfig) (*ColdStorageTiering, error) {
+	if config.Events == nil || config.Cold == nil {
+		return nil, cqrs.NewValidationError("event store and cold storage are required", nil)
+	}
+	if config.Policy.InactiveAfter <= 0 {
+		return nil, cqrs.NewValidationError("inactive period must be positive", nil)
+	}
+	if len(config.Policy.ReadModelTypes) > 0 && config.ReadStore == nil {
+		return nil, cqrs.NewValidationError("read model types need a read store", nil)
+	}
+	if config.EventMarshaler == nil {
+		config.EventMarshaler = &JSONEventMarshaler{}
+	}
+	if config.ReadModelSerializer == nil {
+		config.ReadModelSerializer = &JSONReadModelSerializer{}
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = 30 * time.Second
+	}
+
+	return &ColdStorageTiering{
+		config:    config,
+		firstSeen: make(map[string]time.Time),
+		now:       time.Now,
+	}, nil
+}
+
+func coldArchiveKey(aggregateType, aggregateID string) string {
+	return aggregateType + "/" + aggregateID
+}
+
+// withLock runs fn while holding the aggregate lock when a locker is configured
+func (t *ColdStorageTiering) withLock(ctx context.Context, aggregateType, aggregateID string, fn func(ctx context.Context) error) error {
+	if t.config.Locker == nil {
+		return fn(ctx)
+	}
+	return cqrs.WithAggregateLock(ctx, t.config.Locker, aggregateType, aggregateID, t.config.LockTTL,
+		func(ctx context.Context, lock cqrs.AggregateLock) error {
+			return fn(ctx)
+		})
+}
+
+// IsArchived reports whether the aggregate is in cold storage
+func (t *ColdStorageTiering) IsArchived(ctx context.Context, aggregateType, aggregateID string) (bool, error) {
+	return t.config.Cold.Exists(ctx, coldArchiveKey(aggregateType, aggregateID))
+}
+
+// Evict archives the aggregate's events and read models, then removes them from the hot stores.
+// The archive is written first, so a failure part way leaves the aggregate hot, never lost.
+func (t *ColdStorageTiering) Evict(ctx context.Context, aggregateType, aggregateID string) error {
+	return t.withLock(ctx, aggregateType, aggregateID, func(ctx context.Context) error {
+		events, err := t.config.Events.GetEventHistory(ctx, aggregateID, aggregateType, 0)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return cqrs.NewNotFoundError(fmt.Sprintf("no events to archive for %s", coldArchiveKey(aggregateType, aggregateID)), nil)
+		}
+
+		archive := coldArchive{
+			AggregateID:   aggregateID,
+			AggregateType: aggregateType,
+			Version:       events[len(events)-1].Version(),
+			ArchivedAt:    t.now(),
+			Events:        make([][]byte, 0, len(events)),
+		}
+		for _, event := range events {
+			data, err := t.config.EventMarshaler.Marshal(event)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize event for archive", err)
+			}
+			archive.Events = append(archive.Events, data)
+		}
+
+		for _, modelType := range t.config.Policy.ReadModelTypes {
+			readModel, err := t.config.ReadStore.GetByID(ctx, aggregateID, modelType)
+			if cqrs.IsNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			data, err := t.config.ReadModelSerializer.SerializeReadModel(readModel)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize read model for archive", err)
+			}
+			archive.ReadModels = append(archive.ReadModels, coldArchiveView{Type: modelType, Data: data})
+		}
+
+		data, err := json.Marshal(archive)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize archive", err)
+		}
+		if err := t.config.Cold.Put(ctx, coldArchiveKey(aggregateType, aggregateID), data); err != nil {
+			return err
+		}
+
+		for _, view := range archive.ReadModels {
+			if err := t.config.ReadStore.Delete(ctx, aggregateID, view.Type); err != nil {
+				return err
+			}
+		}
+		if t.config.Snapshots != nil {
+			if err := t.config.Snapshots.Delete(ctx, aggregateID); err != nil && !cqrs.IsNotFoundError(err) {
+				return err
+			}
+		}
+		return t.config.Events.DeleteEvents(ctx, aggregateID, aggregateType)
+	})
+}
+
+// Restore moves an archived aggregate back to the hot stores.
+// It returns false when the aggregate is not archived.
+func (t *ColdStorageTiering) Restore(ctx context.Context, aggregateType, aggregateID string) (bool, error) {
+	restored := false
+	err := t.withLock(ctx, aggregateType, aggregateID, func(ctx context.Context) error {
+		key := coldArchiveKey(aggregateType, aggregateID)
+		data, err := t.config.Cold.Get(ctx, key)
+		if cqrs.IsNotFoundError(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var archive coldArchive
+		if err := json.Unmarshal(data, &archive); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to read archive", err)
+		}
+
+		events := make([]cqrs.EventMessage, 0, len(archive.Events))
+		for _, eventData := range archive.Events {
+			event, err := t.config.EventMarshaler.Unmarshal(eventData)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to read archived event", err)
+			}
+			events = append(events, event)
+		}
+		if err := t.config.Events.SaveEvents(ctx, aggregateID, events, 0); err != nil {
+			return err
+		}
+
+		for _, view := range archive.ReadModels {
+			readModel, err := t.config.ReadModelSerializer.DeserializeReadModel(view.Data, view.Type)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to read archived read model", err)
+			}
+			if err := t.config.ReadStore.Save(ctx, readModel); err != nil {
+				return err
+			}
+		}
+
+		restored = true
+		return t.config.Cold.Delete(ctx, key)
+	})
+	return restored, err
+}
+
+// RunPolicy evicts every aggregate whose last access is older than the policy's inactive period.
+// LastAccessed is kept in memory by the repositories, so aggregates without a recorded access
+// count as inactive from the first run that saw them.
+func (t *ColdStorageTiering) RunPolicy(ctx context.Context) (*TieringReport, error) {
+	if t.config.Metrics == nil {
+		return nil, cqrs.NewValidationError("storage metrics collector is required to run the tiering policy", nil)
+	}
+
+	now := t.now()
+	summary, err := t.config.Metrics.Collect(ctx, cqrs.StorageMetricsQuery{SortBy: cqrs.SortByStaleness})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TieringReport{
+		StartedAt: now,
+		Evicted:   make([]string, 0),
+		Errors:    make(map[string]string),
+	}
+
+	t.mutex.Lock()
+	candidates := make([]cqrs.AggregateStorageMetrics, 0)
+	seen := make(map[string]time.Time, len(t.firstSeen))
+	for _, entry := range summary.Top {
+		if entry.EventCount == 0 {
+			continue // Already evicted or never stored
+		}
+		lastAccessed := entry.LastAccessed
+		if lastAccessed.IsZero() {
+			key := coldArchiveKey(entry.AggregateType, entry.AggregateID)
+			first, exists := t.firstSeen[key]
+			if !exists {
+				first = now
+			}
+			seen[key] = first
+			lastAccessed = first
+		}
+		if now.Sub(lastAccessed) >= t.config.Policy.InactiveAfter {
+			candidates = append(candidates, entry)
+		}
+	}
+	t.firstSeen = seen
+	t.mutex.Unlock()
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastAccessed.Before(candidates[j].LastAccessed)
+	})
+
+	for _, entry := range candidates {
+		if t.config.Policy.MaxPerRun > 0 && len(report.Evicted) >= t.config.Policy.MaxPerRun {
+			break
+		}
+		key := coldArchiveKey(entry.AggregateType, entry.AggregateID)
+		if err := t.Evict(ctx, entry.AggregateType, entry.AggregateID); err != nil {
+			report.Errors[key] = err.Error()
+			continue
+		}
+		report.Evicted = append(report.Evicted, key)
+	}
+
+	return report, nil
+}
+
+// Start runs the tiering policy every interval until ctx is cancelled or Stop is called
+func (t *ColdStorageTiering) Start(ctx context.Context, interval time.Duration) {
+	t.mutex.Lock()
+	if t.stopCh != nil {
+		t.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	t.stopCh = stopCh
+	t.mutex.Unlock()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				// Failed evictions stay hot and are retried on the next tick
+				_, _ = t.RunPolicy(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the policy loop started by Start
+func (t *ColdStorageTiering) Stop() {
+	t.mutex.Lock()
+	stopCh := t.stopCh
+	t.stopCh = nil
+	t.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		t.wg.Wait()
+	}
+}
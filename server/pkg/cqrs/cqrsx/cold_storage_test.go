@@ -0,0 +1,205 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type coldTestView struct {
+	ID        string    `json:"id"`
+	Members   int       `json:"members"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (v *coldTestView) GetID() string             { return v.ID }
+func (v *coldTestView) GetType() string           { return "ColdTestView" }
+func (v *coldTestView) GetVersion() int           { return 1 }
+func (v *coldTestView) GetData() interface{}      { return v }
+func (v *coldTestView) GetLastUpdated() time.Time { return v.UpdatedAt }
+func (v *coldTestView) Validate() error           { return nil }
+
+// fakeTierableEvents 집합체별 이벤트와 마지막 접근 시각을 메모리에 보관
+type fakeTierableEvents struct {
+	streams      map[string][]cqrs.EventMessage
+	lastAccessed map[string]time.Time
+}
+
+func newFakeTierableEvents() *fakeTierableEvents {
+	return &fakeTierableEvents{
+		streams:      make(map[string][]cqrs.EventMessage),
+		lastAccessed: make(map[string]time.Time),
+	}
+}
+
+func (f *fakeTierableEvents) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	f.streams[aggregateID] = append(f.streams[aggregateID], events...)
+	return nil
+}
+
+func (f *fakeTierableEvents) GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	return f.streams[aggregateID], nil
+}
+
+func (f *fakeTierableEvents) DeleteEvents(ctx context.Context, aggregateID string, aggregateType string) error {
+	delete(f.streams, aggregateID)
+	return nil
+}
+
+func (f *fakeTierableEvents) GetStorageMetrics(ctx context.Context, aggregateID string) (*cqrs.StorageMetrics, error) {
+	return &cqrs.StorageMetrics{
+		EventCount:   int64(len(f.streams[aggregateID])),
+		LastAccessed: f.lastAccessed[aggregateID],
+	}, nil
+}
+
+func (f *fakeTierableEvents) listIDs(ctx context.Context, aggregateType string) ([]string, error) {
+	ids := make([]string, 0, len(f.streams))
+	for id := range f.streams {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (f *fakeTierableEvents) addGuild(t *testing.T, id string, eventCount int) {
+	t.Helper()
+	aggregate := cqrs.NewBaseAggregate(id, "Guild")
+	for i := 0; i < eventCount; i++ {
+		require.NoError(t, aggregate.ApplyEvent(cqrs.NewBaseEventMessage("GuildUpdated")))
+	}
+	f.streams[id] = aggregate.Changes()
+}
+
+func newColdStorageFixture(t *testing.T) (*ColdStorageTiering, *fakeTierableEvents, *cqrs.InMemoryReadStore, *time.Time) {
+	t.Helper()
+	cqrs.RegisterReadModelType("ColdTestView", reflect.TypeOf(&coldTestView{}))
+
+	cold, err := NewFileColdStorage(t.TempDir())
+	require.NoError(t, err)
+
+	events := newFakeTierableEvents()
+	readStore := cqrs.NewInMemoryReadStore()
+	collector := cqrs.NewStorageMetricsCollector()
+	require.NoError(t, collector.Register("Guild", events, events.listIDs))
+
+	tiering, err := NewColdStorageTiering(ColdStorageTieringConfig{
+		Policy: TieringPolicy{
+			InactiveAfter:  30 * 24 * time.Hour,
+			ReadModelTypes: []string{"ColdTestView"},
+		},
+		Events:         events,
+		Cold:           cold,
+		Metrics:        collector,
+		EventMarshaler: NewJSONEventMarshaler(NewVersionedEventRegistry(WithStrictMode(false))),
+		ReadStore:      readStore,
+		Locker:         cqrs.NewInMemoryAggregateLocker(),
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	tiering.now = func() time.Time { return now }
+	return tiering, events, readStore, &now
+}
+
+func TestColdStorageTiering_EvictAndRestore_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	tiering, events, readStore, _ := newColdStorageFixture(t)
+	events.addGuild(t, "guild-1", 3)
+	require.NoError(t, readStore.Save(ctx, &coldTestView{ID: "guild-1", Members: 12}))
+
+	// Act
+	evictErr := tiering.Evict(ctx, "Guild", "guild-1")
+	archived, _ := tiering.IsArchived(ctx, "Guild", "guild-1")
+	_, hotViewErr := readStore.GetByID(ctx, "guild-1", "ColdTestView")
+	restored, restoreErr := tiering.Restore(ctx, "Guild", "guild-1")
+
+	// Assert
+	require.NoError(t, evictErr)
+	assert.True(t, archived)
+	assert.True(t, cqrs.IsNotFoundError(hotViewErr))
+
+	require.NoError(t, restoreErr)
+	assert.True(t, restored)
+	require.Len(t, events.streams["guild-1"], 3)
+	assert.Equal(t, 3, events.streams["guild-1"][2].Version())
+	assert.Equal(t, "GuildUpdated", events.streams["guild-1"][0].EventType())
+
+	view, err := readStore.GetByID(ctx, "guild-1", "ColdTestView")
+	require.NoError(t, err)
+	assert.Equal(t, 12, view.(*coldTestView).Members)
+
+	stillArchived, _ := tiering.IsArchived(ctx, "Guild", "guild-1")
+	assert.False(t, stillArchived)
+}
+
+func TestColdStorageTiering_Restore_NotArchived(t *testing.T) {
+	// Arrange
+	tiering, _, _, _ := newColdStorageFixture(t)
+
+	// Act
+	restored, err := tiering.Restore(context.Background(), "Guild", "guild-unknown")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, restored)
+}
+
+func TestColdStorageTiering_RunPolicy_EvictsOnlyInactiveAggregates(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	tiering, events, _, now := newColdStorageFixture(t)
+	events.addGuild(t, "guild-active", 2)
+	events.addGuild(t, "guild-dormant", 2)
+	events.addGuild(t, "guild-untracked", 2)
+	events.lastAccessed["guild-active"] = now.Add(-24 * time.Hour)
+	events.lastAccessed["guild-dormant"] = now.Add(-45 * 24 * time.Hour)
+
+	// Act
+	first, firstErr := tiering.RunPolicy(ctx)
+	*now = now.Add(31 * 24 * time.Hour)
+	events.lastAccessed["guild-active"] = *now
+	second, secondErr := tiering.RunPolicy(ctx)
+
+	// Assert
+	require.NoError(t, firstErr)
+	assert.Equal(t, []string{"Guild/guild-dormant"}, first.Evicted)
+
+	// Aggregates without a recorded access are only evicted once they stay unaccessed for the inactive period
+	require.NoError(t, secondErr)
+	assert.Equal(t, []string{"Guild/guild-untracked"}, second.Evicted)
+	assert.Empty(t, second.Errors)
+	assert.Contains(t, events.streams, "guild-active")
+}
+
+func TestColdStorageTiering_RunPolicy_RespectsMaxPerRun(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	tiering, events, _, now := newColdStorageFixture(t)
+	tiering.config.Policy.MaxPerRun = 1
+	events.addGuild(t, "guild-1", 1)
+	events.addGuild(t, "guild-2", 1)
+	events.lastAccessed["guild-1"] = now.Add(-40 * 24 * time.Hour)
+	events.lastAccessed["guild-2"] = now.Add(-60 * 24 * time.Hour)
+
+	// Act
+	report, err := tiering.RunPolicy(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Guild/guild-2"}, report.Evicted)
+	assert.Contains(t, events.streams, "guild-1")
+}
+
+func TestNewColdStorageTiering_RequiresStores(t *testing.T) {
+	// Act
+	_, err := NewColdStorageTiering(ColdStorageTieringConfig{Policy: TieringPolicy{InactiveAfter: time.Hour}})
+
+	// Assert
+	assert.True(t, cqrs.IsValidationError(err))
+}
@@ -0,0 +1,368 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventExportFilter selects the events to export.
+// Zero values mean "no restriction" for every field.
+type EventExportFilter struct {
+	EventTypes    []string  `json:"event_types,omitempty"`
+	AggregateType string    `json:"aggregate_type,omitempty"`
+	From          time.Time `json:"from,omitempty"` // Inclusive
+	To            time.Time `json:"to,omitempty"`   // Exclusive
+	Limit         int       `json:"limit,omitempty"`
+}
+
+// Validate checks the filter for inconsistent ranges
+func (f EventExportFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.To.After(f.From) {
+		return cqrs.NewValidationError("export range end must be after start", nil)
+	}
+	if f.Limit < 0 {
+		return cqrs.NewValidationError("export limit cannot be negative", nil)
+	}
+	return nil
+}
+
+// ExportedEvent is the flat, storage-independent representation written by export writers
+type ExportedEvent struct {
+	EventID       string                 `json:"event_id"`
+	EventType     string                 `json:"event_type"`
+	AggregateID   string                 `json:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type"`
+	Version       int                    `json:"version"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
+
+// EventExportSource streams events matching a filter in timestamp order
+type EventExportSource interface {
+	ExportEvents(ctx context.Context, filter EventExportFilter, fn func(*ExportedEvent) error) error
+}
+
+// EventExportWriter writes exported events in a specific file format
+type EventExportWriter interface {
+	WriteEvent(event *ExportedEvent) error
+	// Close flushes buffered output; it does not close the underlying io.Writer
+	Close() error
+}
+
+// EventExportWriterFactory creates a writer for an output stream
+type EventExportWriterFactory func(w io.Writer) EventExportWriter
+
+// EventExportFormat describes a registered export format
+type EventExportFormat struct {
+	Name        string
+	ContentType string
+	Extension   string
+	NewWriter   EventExportWriterFactory
+}
+
+var (
+	exportFormatsMu sync.RWMutex
+	exportFormats   = map[string]EventExportFormat{
+		"jsonl": {Name: "jsonl", ContentType: "application/x-ndjson", Extension: ".jsonl", NewWriter: NewJSONLExportWriter},
+		"csv":   {Name: "csv", ContentType: "text/csv", Extension: ".csv", NewWriter: NewCSVExportWriter},
+	}
+)
+
+// RegisterEventExportFormat adds or replaces an export format (e.g. a Parquet writer)
+func RegisterEventExportFormat(format EventExportFormat) error {
+	if format.Name == "" {
+		return cqrs.NewValidationError("export format name cannot be empty", nil)
+	}
+	if format.NewWriter == nil {
+		return cqrs.NewValidationError("export format writer cannot be nil", nil)
+	}
+
+	exportFormatsMu.Lock()
+	defer exportFormatsMu.Unlock()
+	exportFormats[format.Name] = format
+	return nil
+}
+
+// GetEventExportFormat looks up a registered export format
+func GetEventExportFormat(name string) (EventExportFormat, error) {
+	exportFormatsMu.RLock()
+	defer exportFormatsMu.RUnlock()
+
+	format, exists := exportFormats[name]
+	if !exists {
+		return EventExportFormat{}, cqrs.NewValidationError(fmt.Sprintf("unsupported export format: %s", name), nil)
+	}
+	return format, nil
+}
+
+// EventExportFormats returns the names of all registered export formats
+func EventExportFormats() []string {
+	exportFormatsMu.RLock()
+	defer exportFormatsMu.RUnlock()
+
+	names := make([]string, 0, len(exportFormats))
+	for name := range exportFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExportEvents streams events from the source into the writer and returns the number written.
+// The writer is closed (flushed) even when the export fails part way.
+func ExportEvents(ctx context.Context, source EventExportSource, filter EventExportFilter, writer EventExportWriter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	err := source.ExportEvents(ctx, filter, func(event *ExportedEvent) error {
+		if err := writer.WriteEvent(event); err != nil {
+			return err
+		}
+		written++
+		return nil
+	})
+
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return written, err
+}
+
+// JSONLExportWriter writes one JSON object per line
+type JSONLExportWriter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONLExportWriter creates a JSON Lines writer
+func NewJSONLExportWriter(w io.Writer) EventExportWriter {
+	return &JSONLExportWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *JSONLExportWriter) WriteEvent(event *ExportedEvent) error {
+	return w.encoder.Encode(event)
+}
+
+func (w *JSONLExportWriter) Close() error {
+	return nil
+}
+
+// CSVExportHeader is the column order written by CSVExportWriter.
+// Metadata and data are written as JSON-encoded strings.
+var CSVExportHeader = []string{"event_id", "event_type", "aggregate_id", "aggregate_type", "version", "timestamp", "metadata", "data"}
+
+// CSVExportWriter writes events as CSV rows with a header line
+type CSVExportWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVExportWriter creates a CSV writer
+func NewCSVExportWriter(w io.Writer) EventExportWriter {
+	return &CSVExportWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *CSVExportWriter) WriteEvent(event *ExportedEvent) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(CSVExportHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	metadata, err := marshalExportField(event.Metadata)
+	if err != nil {
+		return err
+	}
+	data, err := marshalExportField(event.Data)
+	if err != nil {
+		return err
+	}
+
+	return w.writer.Write([]string{
+		event.EventID,
+		event.EventType,
+		event.AggregateID,
+		event.AggregateType,
+		strconv.Itoa(event.Version),
+		event.Timestamp.UTC().Format(time.RFC3339Nano),
+		metadata,
+		data,
+	})
+}
+
+func (w *CSVExportWriter) Close() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(CSVExportHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func marshalExportField(value map[string]interface{}) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// ParseEventExportFilter builds a filter from query parameters:
+// event_type (comma separated, repeatable), aggregate_type, from/to (RFC3339) and limit.
+func ParseEventExportFilter(values map[string][]string) (EventExportFilter, error) {
+	var filter EventExportFilter
+
+	for _, raw := range values["event_type"] {
+		for _, eventType := range strings.Split(raw, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.EventTypes = append(filter.EventTypes, eventType)
+			}
+		}
+	}
+	if len(values["aggregate_type"]) > 0 {
+		filter.AggregateType = values["aggregate_type"][0]
+	}
+
+	var err error
+	if filter.From, err = parseExportTime(values["from"]); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseExportTime(values["to"]); err != nil {
+		return filter, err
+	}
+	if len(values["limit"]) > 0 && values["limit"][0] != "" {
+		if filter.Limit, err = strconv.Atoi(values["limit"][0]); err != nil {
+			return filter, cqrs.NewValidationError("invalid export limit", err)
+		}
+	}
+
+	return filter, filter.Validate()
+}
+
+func parseExportTime(values []string) (time.Time, error) {
+	if len(values) == 0 || values[0] == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, values[0])
+	if err != nil {
+		return time.Time{}, cqrs.NewValidationError(fmt.Sprintf("invalid export time %q (expected RFC3339)", values[0]), err)
+	}
+	return parsed, nil
+}
+
+// HTTP trailers set by EventExportHandler after the body has been streamed
+const (
+	EventExportErrorTrailer = "X-Export-Error"
+	EventExportCountTrailer = "X-Export-Count"
+)
+
+// EventExportHandler serves event exports over HTTP:
+// GET ?format=jsonl|csv&event_type=A,B&aggregate_type=&from=&to=&limit=
+// The response is streamed, so large exports do not need to fit in memory.
+type EventExportHandler struct {
+	source EventExportSource
+}
+
+// NewEventExportHandler creates an export HTTP handler
+func NewEventExportHandler(source EventExportSource) *EventExportHandler {
+	return &EventExportHandler{source: source}
+}
+
+func (h *EventExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	formatName := query.Get("format")
+	if formatName == "" {
+		formatName = "jsonl"
+	}
+	format, err := GetEventExportFormat(formatName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter, err := ParseEventExportFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if authorizer, ok := h.source.(exportAuthorizer); ok {
+		if err := authorizer.AuthorizeExport(r.Context(), filter); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="events-%s%s"`, time.Now().UTC().Format("20060102T150405Z"), format.Extension))
+
+	// Headers are already sent once streaming starts, so failures are reported in a trailer
+	w.Header().Set("Trailer", EventExportErrorTrailer+", "+EventExportCountTrailer)
+
+	count, err := ExportEvents(r.Context(), h.source, filter, format.NewWriter(w))
+	w.Header().Set(EventExportCountTrailer, strconv.Itoa(count))
+	if err != nil {
+		w.Header().Set(EventExportErrorTrailer, err.Error())
+	}
+}
+
+// exportAuthorizer is implemented by sources that can reject an export before streaming starts
+type exportAuthorizer interface {
+	AuthorizeExport(ctx context.Context, filter EventExportFilter) error
+}
+
+// AccessControlledExportSource applies a stream access policy to an export source: exports
+// naming a hidden aggregate type are rejected, and unfiltered exports skip hidden streams.
+// The reader comes from the context (cqrs.WithStreamReader).
+type AccessControlledExportSource struct {
+	source EventExportSource
+	policy *cqrs.StreamAccessPolicy
+}
+
+// NewAccessControlledExportSource wraps an export source
+func NewAccessControlledExportSource(source EventExportSource, policy *cqrs.StreamAccessPolicy) *AccessControlledExportSource {
+	return &AccessControlledExportSource{source: source, policy: policy}
+}
+
+// AuthorizeExport checks an explicitly requested aggregate type
+func (s *AccessControlledExportSource) AuthorizeExport(ctx context.Context, filter EventExportFilter) error {
+	if filter.AggregateType == "" {
+		return nil
+	}
+	return s.policy.Authorize(ctx, filter.AggregateType)
+}
+
+func (s *AccessControlledExportSource) ExportEvents(ctx context.Context, filter EventExportFilter, fn func(*ExportedEvent) error) error {
+	if err := s.AuthorizeExport(ctx, filter); err != nil {
+		return err
+	}
+	return s.source.ExportEvents(ctx, filter, func(event *ExportedEvent) error {
+		if !s.policy.Allows(ctx, event.AggregateType) {
+			return nil
+		}
+		return fn(event)
+	})
+}
@@ -0,0 +1,159 @@
+package cqrsx
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sliceExportSource filters a fixed slice of events like a real store would
+type sliceExportSource struct {
+	events []*ExportedEvent
+}
+
+func (s *sliceExportSource) ExportEvents(ctx context.Context, filter EventExportFilter, fn func(*ExportedEvent) error) error {
+	written := 0
+	for _, event := range s.events {
+		if len(filter.EventTypes) > 0 && !containsString(filter.EventTypes, event.EventType) {
+			continue
+		}
+		if !filter.From.IsZero() && event.Timestamp.Before(filter.From) {
+			continue
+		}
+		if filter.Limit > 0 && written >= filter.Limit {
+			break
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+		written++
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func newExportTestSource() *sliceExportSource {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &sliceExportSource{events: []*ExportedEvent{
+		{EventID: "e1", EventType: "GuildCreated", AggregateID: "g1", AggregateType: "Guild", Version: 1, Timestamp: base,
+			Data: map[string]interface{}{"name": "Allies"}},
+		{EventID: "e2", EventType: "MemberJoined", AggregateID: "g1", AggregateType: "Guild", Version: 2, Timestamp: base.Add(time.Hour),
+			Metadata: map[string]interface{}{"user": "u1"}},
+		{EventID: "e3", EventType: "GuildCreated", AggregateID: "g2", AggregateType: "Guild", Version: 1, Timestamp: base.Add(2 * time.Hour)},
+	}}
+}
+
+func TestExportEvents_JSONL(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	filter := EventExportFilter{EventTypes: []string{"GuildCreated"}}
+
+	// Act
+	count, err := ExportEvents(context.Background(), newExportTestSource(), filter, NewJSONLExportWriter(&buf))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var first ExportedEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "e1", first.EventID)
+	assert.Equal(t, "Allies", first.Data["name"])
+}
+
+func TestExportEvents_CSV(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	count, err := ExportEvents(context.Background(), newExportTestSource(), EventExportFilter{Limit: 2}, NewCSVExportWriter(&buf))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, CSVExportHeader, rows[0])
+	assert.Equal(t, "MemberJoined", rows[2][1])
+	assert.JSONEq(t, `{"user":"u1"}`, rows[2][6])
+}
+
+func TestParseEventExportFilter(t *testing.T) {
+	// Act
+	filter, err := ParseEventExportFilter(map[string][]string{
+		"event_type": {"A, B", "C"},
+		"from":       {"2025-01-01T00:00:00Z"},
+		"to":         {"2025-02-01T00:00:00Z"},
+		"limit":      {"10"},
+	})
+	_, badRange := ParseEventExportFilter(map[string][]string{
+		"from": {"2025-02-01T00:00:00Z"},
+		"to":   {"2025-01-01T00:00:00Z"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, filter.EventTypes)
+	assert.Equal(t, 10, filter.Limit)
+	assert.Error(t, badRange)
+}
+
+func TestEventExportHandler_ServeHTTP(t *testing.T) {
+	// Arrange
+	handler := NewEventExportHandler(newExportTestSource())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/export?format=csv&from=2025-01-01T00:30:00Z", nil))
+	bad := httptest.NewRecorder()
+	handler.ServeHTTP(bad, httptest.NewRequest(http.MethodGet, "/admin/export?format=xml", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "2", rec.Result().Trailer.Get(EventExportCountTrailer))
+	assert.Equal(t, http.StatusBadRequest, bad.Code)
+}
+
+func TestAccessControlledExportSource(t *testing.T) {
+	// Arrange
+	source := newExportTestSource()
+	source.events = append(source.events, &ExportedEvent{EventID: "e4", EventType: "PasswordChanged",
+		AggregateID: "u1", AggregateType: "UserCredential", Version: 1, Timestamp: source.events[2].Timestamp})
+	policy := cqrs.NewStreamAccessPolicy()
+	require.NoError(t, policy.Restrict("UserCredential", "auth-service"))
+	handler := NewEventExportHandler(NewAccessControlledExportSource(source, policy))
+	gameplay := cqrs.WithStreamReader(context.Background(), cqrs.StreamReader{Name: "battle", Roles: []string{"gameplay"}})
+
+	// Act
+	var buf bytes.Buffer
+	count, err := ExportEvents(gameplay, NewAccessControlledExportSource(source, policy), EventExportFilter{}, NewJSONLExportWriter(&buf))
+	denied := httptest.NewRecorder()
+	handler.ServeHTTP(denied, httptest.NewRequest(http.MethodGet, "/admin/export?aggregate_type=UserCredential", nil).WithContext(gameplay))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NotContains(t, buf.String(), "PasswordChanged")
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+}
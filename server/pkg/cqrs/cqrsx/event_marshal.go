@@ -107,15 +107,11 @@ func MarshalEventJSON(event cqrs.EventMessage) ([]byte, error) {
 	return json.Marshal(event)
 }
 
-var typeExtractor struct {
-	EventType string `json:"eventType" bson:"eventType"`
-}
-
 // UnmarshalEventJSON은 JSON 바이트를 올바른 타입의 이벤트 객체로 역직렬화합니다.
 // BucketDataRegistry를 사용하여 동적으로 타입을 결정합니다.
 func UnmarshalEventJSON(data []byte, registry EventRegistry) (cqrs.EventMessage, error) {
-	// 1. eventType 필드만 추출
-
+	// 1. eventType 필드와 스키마 버전(metadata)만 추출
+	var typeExtractor metadata
 	if err := json.Unmarshal(data, &typeExtractor); err != nil {
 		return nil, fmt.Errorf("failed to extract eventType from JSON: %w", err)
 	}
@@ -124,7 +120,7 @@ func UnmarshalEventJSON(data []byte, registry EventRegistry) (cqrs.EventMessage,
 	}
 
 	// 2. 레지스트리를 사용하여 해당 eventType에 맞는 빈 이벤트 객체(포인터)를 생성
-	instance, err := registry.CreateDataInstance(typeExtractor.EventType)
+	instance, err := createEventInstance(registry, typeExtractor.EventType, typeExtractor.Metadata)
 	if err != nil {
 		return nil, err // 레지스트리에 등록되지 않은 이벤트 타입
 	}
@@ -212,7 +208,7 @@ func UnmarshalEventBSON(data []byte, registry EventRegistry) (cqrs.EventMessage,
 	}
 
 	// 레지스트리를 사용하여 인스턴스 생성
-	instance, err := registry.CreateDataInstance(meta.EventType)
+	instance, err := createEventInstance(registry, meta.EventType, meta.Metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -227,5 +223,5 @@ func UnmarshalEventBSON(data []byte, registry EventRegistry) (cqrs.EventMessage,
 		return eventMessage, nil
 	}
 
-	return nil, fmt.Errorf("unmarshaled BSON event of type '%s' does not implement EventMessage interface", typeExtractor.EventType)
+	return nil, fmt.Errorf("unmarshaled BSON event of type '%s' does not implement EventMessage interface", meta.EventType)
 }
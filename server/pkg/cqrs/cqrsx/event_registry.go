@@ -0,0 +1,281 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EventSchemaVersionKey is the metadata key carrying an event's payload schema version
+const EventSchemaVersionKey = "schemaVersion"
+
+// ErrUnknownEventType is returned in strict mode when deserializing an unregistered event type
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// EventFactory creates an empty event instance (a pointer) to deserialize into
+type EventFactory func() interface{}
+
+// VersionedEventResolver is implemented by registries that can resolve a specific schema version
+type VersionedEventResolver interface {
+	CreateVersionedInstance(eventType string, schemaVersion int) (interface{}, error)
+}
+
+// eventRegistryKey identifies an event payload schema
+type eventRegistryKey struct {
+	eventType string
+	version   int
+}
+
+// VersionedEventRegistry maps (event type, schema version) to factories.
+// It implements EventRegistry, so it can be passed to the JSON/BSON event marshalers.
+//
+// Usage:
+//
+//	var Events = cqrsx.NewVersionedEventRegistry()
+//
+//	func init() {
+//		cqrsx.MustRegisterEvent[GuildCreatedEvent](Events, "GuildCreated", 1)
+//	}
+type VersionedEventRegistry struct {
+	mu        sync.RWMutex
+	factories map[eventRegistryKey]EventFactory
+	types     map[eventRegistryKey]reflect.Type
+	latest    map[string]int
+	strict    bool
+}
+
+// VersionedEventRegistryOption configures a VersionedEventRegistry
+type VersionedEventRegistryOption func(*VersionedEventRegistry)
+
+// WithStrictMode controls whether unknown event types fail deserialization (default true).
+// When disabled, unknown events are decoded into *UnknownEvent.
+func WithStrictMode(strict bool) VersionedEventRegistryOption {
+	return func(r *VersionedEventRegistry) {
+		r.strict = strict
+	}
+}
+
+// NewVersionedEventRegistry creates an empty registry in strict mode
+func NewVersionedEventRegistry(options ...VersionedEventRegistryOption) *VersionedEventRegistry {
+	r := &VersionedEventRegistry{
+		factories: make(map[eventRegistryKey]EventFactory),
+		types:     make(map[eventRegistryKey]reflect.Type),
+		latest:    make(map[string]int),
+		strict:    true,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+var _ EventRegistry = (*VersionedEventRegistry)(nil)
+var _ VersionedEventResolver = (*VersionedEventRegistry)(nil)
+
+// Register adds a factory for an event type and schema version (1-based).
+// Re-registering the same key is an error.
+func (r *VersionedEventRegistry) Register(eventType string, version int, factory EventFactory) error {
+	if eventType == "" {
+		return cqrs.NewValidationError("event type cannot be empty", nil)
+	}
+	if version < 1 {
+		return cqrs.NewValidationError(fmt.Sprintf("event schema version must be positive: %s v%d", eventType, version), nil)
+	}
+	if factory == nil {
+		return cqrs.NewValidationError("event factory cannot be nil", nil)
+	}
+
+	sample := factory()
+	if sample == nil || reflect.TypeOf(sample).Kind() != reflect.Ptr {
+		return cqrs.NewValidationError(fmt.Sprintf("event factory for %s v%d must return a pointer", eventType, version), nil)
+	}
+
+	key := eventRegistryKey{eventType: eventType, version: version}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[key]; exists {
+		return cqrs.NewValidationError(fmt.Sprintf("event type already registered: %s v%d", eventType, version), nil)
+	}
+
+	r.factories[key] = factory
+	r.types[key] = reflect.TypeOf(sample).Elem()
+	if version > r.latest[eventType] {
+		r.latest[eventType] = version
+	}
+	return nil
+}
+
+// MustRegister is like Register but panics on error; intended for init() registration
+func (r *VersionedEventRegistry) MustRegister(eventType string, version int, factory EventFactory) {
+	if err := r.Register(eventType, version, factory); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterEvent registers T (used as *T) for an event type and schema version
+func RegisterEvent[T any](r *VersionedEventRegistry, eventType string, version int) error {
+	return r.Register(eventType, version, func() interface{} { return new(T) })
+}
+
+// MustRegisterEvent is like RegisterEvent but panics on error
+func MustRegisterEvent[T any](r *VersionedEventRegistry, eventType string, version int) {
+	if err := RegisterEvent[T](r, eventType, version); err != nil {
+		panic(err)
+	}
+}
+
+// CreateVersionedInstance creates an instance for a specific schema version.
+// A version <= 0 resolves to the latest registered version.
+func (r *VersionedEventRegistry) CreateVersionedInstance(eventType string, schemaVersion int) (interface{}, error) {
+	r.mu.RLock()
+	if schemaVersion <= 0 {
+		schemaVersion = r.latest[eventType]
+	}
+	factory, exists := r.factories[eventRegistryKey{eventType: eventType, version: schemaVersion}]
+	strict := r.strict
+	r.mu.RUnlock()
+
+	if exists {
+		return factory(), nil
+	}
+	if !strict {
+		return &UnknownEvent{}, nil
+	}
+	return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+		fmt.Sprintf("event type not registered: %s v%d", eventType, schemaVersion), ErrUnknownEventType)
+}
+
+// RegisterEventDataType registers a struct type as version 1 of an event type (EventRegistry)
+func (r *VersionedEventRegistry) RegisterEventDataType(eventType string, dataType reflect.Type) error {
+	if dataType == nil {
+		return cqrs.NewValidationError("data type cannot be nil", nil)
+	}
+	if dataType.Kind() == reflect.Ptr {
+		dataType = dataType.Elem()
+	}
+	if dataType.Kind() != reflect.Struct {
+		return cqrs.NewValidationError(fmt.Sprintf("data type must be a struct, got %v", dataType.Kind()), nil)
+	}
+	return r.Register(eventType, 1, func() interface{} { return reflect.New(dataType).Interface() })
+}
+
+// CreateDataInstance creates an instance of the latest schema version (EventRegistry)
+func (r *VersionedEventRegistry) CreateDataInstance(eventType string) (interface{}, error) {
+	return r.CreateVersionedInstance(eventType, 0)
+}
+
+// GetDataType returns the struct type of the latest schema version (EventRegistry)
+func (r *VersionedEventRegistry) GetDataType(eventType string) (reflect.Type, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dataType, exists := r.types[eventRegistryKey{eventType: eventType, version: r.latest[eventType]}]
+	if !exists {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("event type not registered: %s", eventType), ErrUnknownEventType)
+	}
+	return dataType, nil
+}
+
+// GetRegisteredEventTypes returns all registered event types in sorted order (EventRegistry)
+func (r *VersionedEventRegistry) GetRegisteredEventTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	eventTypes := make([]string, 0, len(r.latest))
+	for eventType := range r.latest {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
+}
+
+// IsRegistered checks if any schema version of the event type is registered (EventRegistry)
+func (r *VersionedEventRegistry) IsRegistered(eventType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.latest[eventType]
+	return exists
+}
+
+// LatestVersion returns the latest registered schema version, or 0 if unknown
+func (r *VersionedEventRegistry) LatestVersion(eventType string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.latest[eventType]
+}
+
+// UnknownEvent holds an event whose type is not registered (non-strict mode only).
+// The envelope is decoded into BaseEventMessage and the remaining fields are kept in Payload.
+type UnknownEvent struct {
+	cqrs.BaseEventMessage `bson:",inline"`
+	Payload               map[string]interface{} `json:"-" bson:"-"`
+}
+
+// UnmarshalJSON decodes both the envelope and the raw payload
+func (e *UnknownEvent) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.BaseEventMessage); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &e.Payload)
+}
+
+// UnmarshalBSON decodes both the envelope and the raw payload
+func (e *UnknownEvent) UnmarshalBSON(data []byte) error {
+	if err := bson.Unmarshal(data, &e.BaseEventMessage); err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, &e.Payload)
+}
+
+// MarshalJSON writes the raw payload back with the envelope fields on top
+func (e *UnknownEvent) MarshalJSON() ([]byte, error) {
+	envelope, err := json.Marshal(&e.BaseEventMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]interface{}, len(e.Payload))
+	for k, v := range e.Payload {
+		merged[k] = v
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(envelope, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return json.Marshal(merged)
+}
+
+// schemaVersionOf reads the payload schema version from event metadata
+func schemaVersionOf(metadata map[string]interface{}) int {
+	switch v := metadata[EventSchemaVersionKey].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// createEventInstance resolves the schema version when the registry supports it
+func createEventInstance(registry EventRegistry, eventType string, metadata map[string]interface{}) (interface{}, error) {
+	if resolver, ok := registry.(VersionedEventResolver); ok {
+		return resolver.CreateVersionedInstance(eventType, schemaVersionOf(metadata))
+	}
+	return registry.CreateDataInstance(eventType)
+}
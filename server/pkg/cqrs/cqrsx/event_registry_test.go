@@ -0,0 +1,88 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type guildCreatedV1 struct {
+	cqrs.BaseEventMessage `bson:",inline"`
+	Name                  string `json:"name" bson:"name"`
+}
+
+type guildCreatedV2 struct {
+	cqrs.BaseEventMessage `bson:",inline"`
+	Name                  string `json:"name" bson:"name"`
+	Tag                   string `json:"tag" bson:"tag"`
+}
+
+func newGuildCreated(version int) *guildCreatedV2 {
+	event := &guildCreatedV2{
+		BaseEventMessage: *cqrs.NewBaseEventMessage("GuildCreated"),
+		Name:             "Allies",
+		Tag:              "ALY",
+	}
+	event.AddMetadata(EventSchemaVersionKey, version)
+	return event
+}
+
+func TestVersionedEventRegistry_Register(t *testing.T) {
+	// Arrange
+	registry := NewVersionedEventRegistry()
+
+	// Act
+	err := RegisterEvent[guildCreatedV1](registry, "GuildCreated", 1)
+	duplicateErr := RegisterEvent[guildCreatedV1](registry, "GuildCreated", 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, duplicateErr)
+	assert.Error(t, RegisterEvent[guildCreatedV1](registry, "", 1))
+	assert.Error(t, RegisterEvent[guildCreatedV1](registry, "GuildCreated", 0))
+	assert.True(t, registry.IsRegistered("GuildCreated"))
+	assert.Panics(t, func() { MustRegisterEvent[guildCreatedV1](registry, "GuildCreated", 1) })
+}
+
+func TestVersionedEventRegistry_ResolvesSchemaVersion(t *testing.T) {
+	// Arrange
+	registry := NewVersionedEventRegistry()
+	MustRegisterEvent[guildCreatedV1](registry, "GuildCreated", 1)
+	MustRegisterEvent[guildCreatedV2](registry, "GuildCreated", 2)
+
+	data, err := MarshalEventJSON(newGuildCreated(1))
+	require.NoError(t, err)
+
+	// Act
+	event, err := UnmarshalEventJSON(data, registry)
+
+	// Assert
+	require.NoError(t, err)
+	assert.IsType(t, &guildCreatedV1{}, event)
+	assert.Equal(t, 2, registry.LatestVersion("GuildCreated"))
+
+	latest, err := registry.CreateDataInstance("GuildCreated")
+	require.NoError(t, err)
+	assert.IsType(t, &guildCreatedV2{}, latest)
+}
+
+func TestVersionedEventRegistry_StrictMode(t *testing.T) {
+	// Arrange
+	data, err := MarshalEventJSON(newGuildCreated(2))
+	require.NoError(t, err)
+
+	// Act
+	_, strictErr := UnmarshalEventJSON(data, NewVersionedEventRegistry())
+	event, lenientErr := UnmarshalEventJSON(data, NewVersionedEventRegistry(WithStrictMode(false)))
+
+	// Assert
+	assert.True(t, errors.Is(strictErr, ErrUnknownEventType))
+	require.NoError(t, lenientErr)
+	unknown, ok := event.(*UnknownEvent)
+	require.True(t, ok)
+	assert.Equal(t, "GuildCreated", unknown.EventType())
+	assert.Equal(t, "ALY", unknown.Payload["tag"])
+}
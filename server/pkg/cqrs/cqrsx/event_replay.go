@@ -0,0 +1,258 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EventReplayTarget receives sampled events, e.g. a staging environment's event store
+type EventReplayTarget interface {
+	// ReplayEvent stores one event; it reports false when the event already existed
+	ReplayEvent(ctx context.Context, event *ExportedEvent) (bool, error)
+}
+
+// EventReplayConfig configures sampling, redaction and pacing of a replay
+type EventReplayConfig struct {
+	// SamplePercent selects this percentage (0-100] of aggregate streams. Whole streams are
+	// sampled, never single events, so replayed aggregates keep contiguous versions.
+	SamplePercent float64
+	// Seed changes which streams are sampled; the same seed always samples the same streams
+	Seed string
+	// Redaction masks personal data in event data and metadata before replay (nil disables)
+	Redaction *cqrs.RedactionPolicy
+	// Speed scales the original gaps between events: 1 replays in real time, 10 is ten times
+	// faster, 0 replays as fast as the target accepts events
+	Speed float64
+	// MaxDelay caps a single pause so quiet periods in production do not stall the replay (default 5s)
+	MaxDelay time.Duration
+}
+
+// Validate checks the replay configuration
+func (c EventReplayConfig) Validate() error {
+	if c.SamplePercent <= 0 || c.SamplePercent > 100 {
+		return cqrs.NewValidationError("sample percent must be in (0, 100]", nil)
+	}
+	if c.Speed < 0 {
+		return cqrs.NewValidationError("replay speed cannot be negative", nil)
+	}
+	return nil
+}
+
+// EventReplayReport summarizes a replay run
+type EventReplayReport struct {
+	Scanned    int           `json:"scanned"`    // Events read from the source
+	Sampled    int           `json:"sampled"`    // Events belonging to sampled streams
+	Replayed   int           `json:"replayed"`   // Events written to the target
+	Duplicates int           `json:"duplicates"` // Sampled events the target already had
+	Streams    int           `json:"streams"`    // Distinct sampled aggregate streams
+	Duration   time.Duration `json:"duration"`
+}
+
+// EventReplayer samples events from a production source and replays them into a target
+type EventReplayer struct {
+	source EventExportSource
+	target EventReplayTarget
+	config EventReplayConfig
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewEventReplayer creates a replayer
+func NewEventReplayer(source EventExportSource, target EventReplayTarget, config EventReplayConfig) (*EventReplayer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.MaxDelay <= 0 {
+		config.MaxDelay = 5 * time.Second
+	}
+	return &EventReplayer{source: source, target: target, config: config, sleep: sleepContext}, nil
+}
+
+// Sampled reports whether the aggregate stream is part of the sample
+func (r *EventReplayer) Sampled(aggregateType, aggregateID string) bool {
+	if r.config.SamplePercent >= 100 {
+		return true
+	}
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s", r.config.Seed, aggregateType, aggregateID)
+	return float64(hash.Sum64()%10000) < r.config.SamplePercent*100
+}
+
+// Run replays the events matching filter and stops at the first target error
+func (r *EventReplayer) Run(ctx context.Context, filter EventExportFilter) (*EventReplayReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	report := &EventReplayReport{}
+	streams := make(map[string]bool)
+	var previous time.Time
+
+	err := r.source.ExportEvents(ctx, filter, func(event *ExportedEvent) error {
+		report.Scanned++
+		if !r.Sampled(event.AggregateType, event.AggregateID) {
+			return nil
+		}
+		report.Sampled++
+		streams[event.AggregateType+"/"+event.AggregateID] = true
+
+		if err := r.pace(ctx, previous, event.Timestamp); err != nil {
+			return err
+		}
+		previous = event.Timestamp
+
+		inserted, err := r.target.ReplayEvent(ctx, r.redact(event))
+		if err != nil {
+			return fmt.Errorf("failed to replay event %s: %w", event.EventID, err)
+		}
+		if inserted {
+			report.Replayed++
+		} else {
+			report.Duplicates++
+		}
+		return nil
+	})
+
+	report.Streams = len(streams)
+	report.Duration = time.Since(started)
+	return report, err
+}
+
+// pace waits for the original gap between two events scaled by Speed
+func (r *EventReplayer) pace(ctx context.Context, previous, current time.Time) error {
+	if r.config.Speed == 0 || previous.IsZero() || !current.After(previous) {
+		return nil
+	}
+	delay := time.Duration(float64(current.Sub(previous)) / r.config.Speed)
+	if delay > r.config.MaxDelay {
+		delay = r.config.MaxDelay
+	}
+	return r.sleep(ctx, delay)
+}
+
+// redact returns a copy of the event with personal data masked
+func (r *EventReplayer) redact(event *ExportedEvent) *ExportedEvent {
+	if r.config.Redaction == nil {
+		return event
+	}
+	redacted := *event
+	redacted.Data = r.config.Redaction.RedactMap(normalizeBSONMap(event.Data))
+	redacted.Metadata = r.config.Redaction.RedactMap(normalizeBSONMap(event.Metadata))
+	return &redacted
+}
+
+// normalizeBSONMap converts nested BSON documents and arrays decoded from Mongo into
+// plain maps and slices, so JSON-oriented tooling such as redaction can walk them
+func normalizeBSONMap(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	normalized, _ := normalizeBSONValue(data).(map[string]interface{})
+	return normalized
+}
+
+func normalizeBSONValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			result[key] = normalizeBSONValue(child)
+		}
+		return result
+	case bson.M:
+		return normalizeBSONValue(map[string]interface{}(typed))
+	case bson.D:
+		result := make(map[string]interface{}, len(typed))
+		for _, element := range typed {
+			result[element.Key] = normalizeBSONValue(element.Value)
+		}
+		return result
+	case bson.A:
+		return normalizeBSONValue([]interface{}(typed))
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, child := range typed {
+			result[i] = normalizeBSONValue(child)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// MongoEventReplayTarget writes replayed events into a Mongo event collection in the
+// MongoEventStore document format. Events already present (same aggregate version) are skipped.
+type MongoEventReplayTarget struct {
+	client     *MongoClientManager
+	collection string
+}
+
+// NewMongoEventReplayTarget creates a target writing into collection (default "events")
+func NewMongoEventReplayTarget(client *MongoClientManager, collection string) *MongoEventReplayTarget {
+	if collection == "" {
+		collection = "events"
+	}
+	return &MongoEventReplayTarget{client: client, collection: collection}
+}
+
+func (t *MongoEventReplayTarget) ReplayEvent(ctx context.Context, event *ExportedEvent) (bool, error) {
+	document := MongoEventDocument{
+		ID:            primitive.NewObjectID(),
+		AggregateID:   event.AggregateID,
+		AggregateType: event.AggregateType,
+		EventID:       event.EventID,
+		EventType:     event.EventType,
+		EventVersion:  event.Version,
+		Timestamp:     event.Timestamp,
+		Metadata:      event.Metadata,
+	}
+	if event.Data != nil {
+		raw, err := bson.Marshal(event.Data)
+		if err != nil {
+			return false, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to encode replayed event data", err)
+		}
+		document.EventData = raw
+	}
+
+	inserted := true
+	err := t.client.ExecuteCommand(ctx, func() error {
+		_, err := t.client.GetCollection(t.collection).InsertOne(ctx, document)
+		if mongo.IsDuplicateKeyError(err) {
+			inserted = false
+			return nil
+		}
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to insert replayed event", err)
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+// ExportWriterReplayTarget replays into an export writer, e.g. a JSONL file for a dry run.
+// The caller closes the writer when the replay finishes.
+type ExportWriterReplayTarget struct {
+	Writer EventExportWriter
+}
+
+func (t ExportWriterReplayTarget) ReplayEvent(ctx context.Context, event *ExportedEvent) (bool, error) {
+	return true, t.Writer.WriteEvent(event)
+}
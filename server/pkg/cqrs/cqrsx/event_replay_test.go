@@ -0,0 +1,138 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// recordingReplayTarget records replayed events and reports repeated event IDs as duplicates
+type recordingReplayTarget struct {
+	events []*ExportedEvent
+	seen   map[string]bool
+	err    error
+}
+
+func (t *recordingReplayTarget) ReplayEvent(ctx context.Context, event *ExportedEvent) (bool, error) {
+	if t.err != nil {
+		return false, t.err
+	}
+	if t.seen == nil {
+		t.seen = make(map[string]bool)
+	}
+	if t.seen[event.EventID] {
+		return false, nil
+	}
+	t.seen[event.EventID] = true
+	t.events = append(t.events, event)
+	return true, nil
+}
+
+func newReplayTestSource(streams, eventsPerStream int) *sliceExportSource {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &sliceExportSource{}
+	for version := 1; version <= eventsPerStream; version++ {
+		for stream := 0; stream < streams; stream++ {
+			source.events = append(source.events, &ExportedEvent{
+				EventID:       fmt.Sprintf("p%d-v%d", stream, version),
+				EventType:     "GoldEarned",
+				AggregateID:   fmt.Sprintf("player-%d", stream),
+				AggregateType: "Player",
+				Version:       version,
+				Timestamp:     base.Add(time.Duration(len(source.events)) * time.Second),
+			})
+		}
+	}
+	return source
+}
+
+func TestEventReplayer_SamplesWholeStreams(t *testing.T) {
+	// Arrange
+	source := newReplayTestSource(200, 3)
+	target := &recordingReplayTarget{}
+	replayer, err := NewEventReplayer(source, target, EventReplayConfig{SamplePercent: 25, Seed: "staging-1"})
+	require.NoError(t, err)
+
+	// Act
+	report, err := replayer.Run(context.Background(), EventExportFilter{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 600, report.Scanned)
+	assert.Equal(t, report.Streams*3, report.Sampled) // Every event of a sampled stream
+	assert.Equal(t, report.Sampled, report.Replayed)
+	assert.InDelta(t, 50, report.Streams, 20)
+
+	versions := make(map[string][]int)
+	for _, event := range target.events {
+		versions[event.AggregateID] = append(versions[event.AggregateID], event.Version)
+	}
+	for aggregateID, seen := range versions {
+		assert.Equal(t, []int{1, 2, 3}, seen, aggregateID)
+	}
+
+	again, err := NewEventReplayer(source, &recordingReplayTarget{}, EventReplayConfig{SamplePercent: 25, Seed: "staging-1"})
+	require.NoError(t, err)
+	assert.Equal(t, replayer.Sampled("Player", "player-7"), again.Sampled("Player", "player-7"))
+}
+
+func TestEventReplayer_RedactsAndPaces(t *testing.T) {
+	// Arrange
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &sliceExportSource{events: []*ExportedEvent{
+		{EventID: "e1", AggregateID: "u1", AggregateType: "User", Version: 1, Timestamp: base,
+			Data:     map[string]interface{}{"email": "player@example.com", "profile": bson.D{{Key: "phone", Value: "010-1234-5678"}}},
+			Metadata: map[string]interface{}{"user_id": "u1", "email": "player@example.com"}},
+		{EventID: "e2", AggregateID: "u1", AggregateType: "User", Version: 2, Timestamp: base.Add(10 * time.Second)},
+		{EventID: "e3", AggregateID: "u1", AggregateType: "User", Version: 3, Timestamp: base.Add(time.Hour)},
+	}}
+	policy := cqrs.NewRedactionPolicy()
+	require.NoError(t, policy.RedactField("email", "email"))
+	require.NoError(t, policy.RedactField("phone", "phone"))
+
+	target := &recordingReplayTarget{}
+	replayer, err := NewEventReplayer(source, target, EventReplayConfig{SamplePercent: 100, Redaction: policy, Speed: 5, MaxDelay: time.Minute})
+	require.NoError(t, err)
+	var delays []time.Duration
+	replayer.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	// Act
+	_, err = replayer.Run(context.Background(), EventExportFilter{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, target.events, 3)
+	assert.Equal(t, "p***@example.com", target.events[0].Data["email"])
+	assert.Equal(t, "***-5678", target.events[0].Data["profile"].(map[string]interface{})["phone"])
+	assert.Equal(t, "p***@example.com", target.events[0].Metadata["email"])
+	assert.Equal(t, "player@example.com", source.events[0].Data["email"]) // Source untouched
+	assert.Equal(t, []time.Duration{2 * time.Second, time.Minute}, delays)
+}
+
+func TestEventReplayer_StopsOnTargetError(t *testing.T) {
+	// Arrange
+	target := &recordingReplayTarget{err: errors.New("staging unavailable")}
+	replayer, err := NewEventReplayer(newReplayTestSource(1, 2), target, EventReplayConfig{SamplePercent: 100})
+	require.NoError(t, err)
+
+	// Act
+	report, err := replayer.Run(context.Background(), EventExportFilter{})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "staging unavailable")
+	assert.Equal(t, 1, report.Scanned)
+
+	_, err = NewEventReplayer(nil, target, EventReplayConfig{SamplePercent: 0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,393 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInjectedFault is returned (wrapped) by operations failed on purpose by a FaultInjector
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultConfig describes which faults to inject and how often.
+// It is JSON-serializable so it can come from config files or the admin API.
+type FaultConfig struct {
+	Enabled       bool          `json:"enabled"`        // Master switch; nothing is injected when false
+	Latency       time.Duration `json:"latency"`        // Fixed delay added before each operation
+	LatencyJitter time.Duration `json:"latency_jitter"` // Random extra delay in [0, jitter)
+	ErrorRate     float64       `json:"error_rate"`     // Probability (0..1) that an operation fails
+	DropRate      float64       `json:"drop_rate"`      // Probability (0..1) that a published event is silently dropped
+	Operations    []string      `json:"operations"`     // Operations to target (e.g. "event_store.save"); empty = all
+}
+
+// Validate checks the fault configuration
+func (c FaultConfig) Validate() error {
+	if c.Latency < 0 || c.LatencyJitter < 0 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeValidationError.String(), "fault latency cannot be negative", nil)
+	}
+	if c.ErrorRate < 0 || c.ErrorRate > 1 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeValidationError.String(), "fault error rate must be between 0 and 1", nil)
+	}
+	if c.DropRate < 0 || c.DropRate > 1 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeValidationError.String(), "fault drop rate must be between 0 and 1", nil)
+	}
+	return nil
+}
+
+// FaultStats counts injected faults
+type FaultStats struct {
+	Delayed int64 `json:"delayed"`
+	Failed  int64 `json:"failed"`
+	Dropped int64 `json:"dropped"`
+}
+
+// Fault injection operation names
+const (
+	FaultOpEventStoreSave    = "event_store.save"
+	FaultOpEventStoreLoad    = "event_store.load"
+	FaultOpEventStoreVersion = "event_store.version"
+	FaultOpEventStoreCompact = "event_store.compact"
+	FaultOpReadStoreWrite    = "read_store.write"
+	FaultOpReadStoreRead     = "read_store.read"
+	FaultOpReadStoreIndex    = "read_store.index"
+	FaultOpEventBusPublish   = "event_bus.publish"
+)
+
+// FaultInjector decides per operation whether to delay, fail or drop.
+// One injector can be shared by several wrapped stores and buses.
+type FaultInjector struct {
+	mu     sync.RWMutex
+	config FaultConfig
+	ops    map[string]bool
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	delayed atomic.Int64
+	failed  atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewFaultInjector creates a new fault injector with the given configuration
+func NewFaultInjector(config FaultConfig) (*FaultInjector, error) {
+	injector := &FaultInjector{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if err := injector.Configure(config); err != nil {
+		return nil, err
+	}
+	return injector, nil
+}
+
+// Configure replaces the active fault configuration
+func (f *FaultInjector) Configure(config FaultConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	ops := make(map[string]bool, len(config.Operations))
+	for _, op := range config.Operations {
+		ops[op] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+	f.ops = ops
+	return nil
+}
+
+// Config returns the active fault configuration
+func (f *FaultInjector) Config() FaultConfig {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.config
+}
+
+// Disable turns off all fault injection while keeping the rest of the configuration
+func (f *FaultInjector) Disable() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config.Enabled = false
+}
+
+// Stats returns the number of injected faults so far
+func (f *FaultInjector) Stats() FaultStats {
+	return FaultStats{
+		Delayed: f.delayed.Load(),
+		Failed:  f.failed.Load(),
+		Dropped: f.dropped.Load(),
+	}
+}
+
+// SetSeed makes fault decisions reproducible (useful in tests)
+func (f *FaultInjector) SetSeed(seed int64) {
+	f.randMu.Lock()
+	defer f.randMu.Unlock()
+	f.rand = rand.New(rand.NewSource(seed))
+}
+
+func (f *FaultInjector) active(op string) (FaultConfig, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if !f.config.Enabled {
+		return f.config, false
+	}
+	if len(f.ops) > 0 && !f.ops[op] {
+		return f.config, false
+	}
+	return f.config, true
+}
+
+func (f *FaultInjector) roll() float64 {
+	f.randMu.Lock()
+	defer f.randMu.Unlock()
+	return f.rand.Float64()
+}
+
+// Inject applies latency and error faults for an operation.
+// It returns a wrapped ErrInjectedFault when the operation should fail.
+func (f *FaultInjector) Inject(ctx context.Context, op string) error {
+	config, ok := f.active(op)
+	if !ok {
+		return nil
+	}
+
+	delay := config.Latency
+	if config.LatencyJitter > 0 {
+		f.randMu.Lock()
+		delay += time.Duration(f.rand.Int63n(int64(config.LatencyJitter)))
+		f.randMu.Unlock()
+	}
+
+	if delay > 0 {
+		f.delayed.Add(1)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if config.ErrorRate > 0 && f.roll() < config.ErrorRate {
+		f.failed.Add(1)
+		return fmt.Errorf("%s: %w", op, ErrInjectedFault)
+	}
+
+	return nil
+}
+
+// ShouldDrop reports whether a published event should be silently dropped
+func (f *FaultInjector) ShouldDrop(op string) bool {
+	config, ok := f.active(op)
+	if !ok || config.DropRate <= 0 {
+		return false
+	}
+
+	if f.roll() < config.DropRate {
+		f.dropped.Add(1)
+		return true
+	}
+	return false
+}
+
+// ServeHTTP exposes the injector as an admin endpoint:
+// GET returns config and stats, PUT/POST replaces the config, DELETE disables injection.
+func (f *FaultInjector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var config FaultConfig
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			http.Error(w, "Invalid fault config", http.StatusBadRequest)
+			return
+		}
+		if err := f.Configure(config); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		f.Disable()
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"config": f.Config(),
+		"stats":  f.Stats(),
+	})
+}
+
+// FaultyEventStore wraps an AggregateEventStore with fault injection
+type FaultyEventStore struct {
+	inner    AggregateEventStore
+	injector *FaultInjector
+}
+
+var _ AggregateEventStore = (*FaultyEventStore)(nil)
+
+// NewFaultyEventStore wraps an event store with the given injector
+func NewFaultyEventStore(inner AggregateEventStore, injector *FaultInjector) *FaultyEventStore {
+	return &FaultyEventStore{inner: inner, injector: injector}
+}
+
+func (s *FaultyEventStore) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	if err := s.injector.Inject(ctx, FaultOpEventStoreSave); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to save events", err)
+	}
+	return s.inner.SaveEvents(ctx, aggregateID, events, expectedVersion)
+}
+
+func (s *FaultyEventStore) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	if err := s.injector.Inject(ctx, FaultOpEventStoreLoad); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to load events", err)
+	}
+	return s.inner.GetEventHistory(ctx, aggregateID, aggregateType, fromVersion)
+}
+
+func (s *FaultyEventStore) GetLastEventVersion(ctx context.Context, aggregateID, aggregateType string) (int, error) {
+	if err := s.injector.Inject(ctx, FaultOpEventStoreVersion); err != nil {
+		return -1, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to get last event version", err)
+	}
+	return s.inner.GetLastEventVersion(ctx, aggregateID, aggregateType)
+}
+
+func (s *FaultyEventStore) CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error {
+	if err := s.injector.Inject(ctx, FaultOpEventStoreCompact); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to compact events", err)
+	}
+	return s.inner.CompactEvents(ctx, aggregateID, aggregateType, beforeVersion)
+}
+
+// FaultyReadStore wraps a cqrs.ReadStore with fault injection
+type FaultyReadStore struct {
+	inner    cqrs.ReadStore
+	injector *FaultInjector
+}
+
+var _ cqrs.ReadStore = (*FaultyReadStore)(nil)
+
+// NewFaultyReadStore wraps a read store with the given injector
+func NewFaultyReadStore(inner cqrs.ReadStore, injector *FaultInjector) *FaultyReadStore {
+	return &FaultyReadStore{inner: inner, injector: injector}
+}
+
+func (s *FaultyReadStore) fault(ctx context.Context, op, message string) error {
+	if err := s.injector.Inject(ctx, op); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), message, err)
+	}
+	return nil
+}
+
+func (s *FaultyReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) error {
+	if err := s.fault(ctx, FaultOpReadStoreWrite, "failed to save read model"); err != nil {
+		return err
+	}
+	return s.inner.Save(ctx, readModel)
+}
+
+func (s *FaultyReadStore) GetByID(ctx context.Context, id string, modelType string) (cqrs.ReadModel, error) {
+	if err := s.fault(ctx, FaultOpReadStoreRead, "failed to get read model"); err != nil {
+		return nil, err
+	}
+	return s.inner.GetByID(ctx, id, modelType)
+}
+
+// GetByIDs injects the read fault once for the whole batch
+func (s *FaultyReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]cqrs.ReadModel, error) {
+	if err := s.fault(ctx, FaultOpReadStoreRead, "failed to get read models"); err != nil {
+		return nil, err
+	}
+	return cqrs.GetReadModelsByIDs(ctx, s.inner, ids, modelType)
+}
+
+func (s *FaultyReadStore) Delete(ctx context.Context, id string, modelType string) error {
+	if err := s.fault(ctx, FaultOpReadStoreWrite, "failed to delete read model"); err != nil {
+		return err
+	}
+	return s.inner.Delete(ctx, id, modelType)
+}
+
+func (s *FaultyReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.ReadModel, error) {
+	if err := s.fault(ctx, FaultOpReadStoreRead, "failed to query read models"); err != nil {
+		return nil, err
+	}
+	return s.inner.Query(ctx, criteria)
+}
+
+func (s *FaultyReadStore) Count(ctx context.Context, criteria cqrs.QueryCriteria) (int64, error) {
+	if err := s.fault(ctx, FaultOpReadStoreRead, "failed to count read models"); err != nil {
+		return 0, err
+	}
+	return s.inner.Count(ctx, criteria)
+}
+
+func (s *FaultyReadStore) SaveBatch(ctx context.Context, readModels []cqrs.ReadModel) error {
+	if err := s.fault(ctx, FaultOpReadStoreWrite, "failed to save read model batch"); err != nil {
+		return err
+	}
+	return s.inner.SaveBatch(ctx, readModels)
+}
+
+func (s *FaultyReadStore) DeleteBatch(ctx context.Context, ids []string, modelType string) error {
+	if err := s.fault(ctx, FaultOpReadStoreWrite, "failed to delete read model batch"); err != nil {
+		return err
+	}
+	return s.inner.DeleteBatch(ctx, ids, modelType)
+}
+
+func (s *FaultyReadStore) CreateIndex(ctx context.Context, modelType string, fields []string) error {
+	if err := s.fault(ctx, FaultOpReadStoreIndex, "failed to create index"); err != nil {
+		return err
+	}
+	return s.inner.CreateIndex(ctx, modelType, fields)
+}
+
+func (s *FaultyReadStore) DropIndex(ctx context.Context, modelType string, indexName string) error {
+	if err := s.fault(ctx, FaultOpReadStoreIndex, "failed to drop index"); err != nil {
+		return err
+	}
+	return s.inner.DropIndex(ctx, modelType, indexName)
+}
+
+// FaultyEventBus wraps a cqrs.EventBus with fault injection.
+// Dropped events are reported as successfully published, like a lost message in transit.
+type FaultyEventBus struct {
+	cqrs.EventBus
+	injector *FaultInjector
+}
+
+// NewFaultyEventBus wraps an event bus with the given injector
+func NewFaultyEventBus(inner cqrs.EventBus, injector *FaultInjector) *FaultyEventBus {
+	return &FaultyEventBus{EventBus: inner, injector: injector}
+}
+
+func (b *FaultyEventBus) Publish(ctx context.Context, event cqrs.EventMessage, options ...cqrs.EventPublishOptions) error {
+	if err := b.injector.Inject(ctx, FaultOpEventBusPublish); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(), "failed to publish event", err)
+	}
+	if b.injector.ShouldDrop(FaultOpEventBusPublish) {
+		return nil
+	}
+	return b.EventBus.Publish(ctx, event, options...)
+}
+
+func (b *FaultyEventBus) PublishBatch(ctx context.Context, events []cqrs.EventMessage, options ...cqrs.EventPublishOptions) error {
+	for _, event := range events {
+		if err := b.Publish(ctx, event, options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultConfig_Validate(t *testing.T) {
+	assert.NoError(t, FaultConfig{ErrorRate: 0.5, DropRate: 1}.Validate())
+	assert.Error(t, FaultConfig{ErrorRate: 1.5}.Validate())
+	assert.Error(t, FaultConfig{DropRate: -0.1}.Validate())
+	assert.Error(t, FaultConfig{Latency: -time.Second}.Validate())
+}
+
+func TestFaultInjector_Inject(t *testing.T) {
+	// Arrange
+	injector, err := NewFaultInjector(FaultConfig{
+		Enabled:    true,
+		ErrorRate:  1,
+		Operations: []string{FaultOpReadStoreRead},
+	})
+	require.NoError(t, err)
+
+	// Act
+	readErr := injector.Inject(context.Background(), FaultOpReadStoreRead)
+	writeErr := injector.Inject(context.Background(), FaultOpReadStoreWrite)
+
+	// Assert
+	assert.True(t, errors.Is(readErr, ErrInjectedFault))
+	assert.NoError(t, writeErr)
+	assert.Equal(t, int64(1), injector.Stats().Failed)
+}
+
+func TestFaultInjector_LatencyRespectsContext(t *testing.T) {
+	// Arrange
+	injector, err := NewFaultInjector(FaultConfig{Enabled: true, Latency: time.Minute})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err = injector.Inject(ctx, FaultOpEventStoreSave)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFaultyReadStore_InjectedError(t *testing.T) {
+	// Arrange
+	injector, err := NewFaultInjector(FaultConfig{Enabled: true, ErrorRate: 1})
+	require.NoError(t, err)
+	store := NewFaultyReadStore(cqrs.NewInMemoryReadStore(), injector)
+
+	// Act
+	_, err = store.GetByID(context.Background(), "user-1", "User")
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInjectedFault))
+
+	// Disabling restores pass-through behavior
+	injector.Disable()
+	_, err = store.GetByID(context.Background(), "user-1", "User")
+	assert.False(t, errors.Is(err, ErrInjectedFault))
+}
+
+func TestFaultyEventBus_DropsEvents(t *testing.T) {
+	// Arrange
+	injector, err := NewFaultInjector(FaultConfig{Enabled: true, DropRate: 1})
+	require.NoError(t, err)
+	bus := NewFaultyEventBus(cqrs.NewInMemoryEventBus(), injector)
+
+	// Act
+	err = bus.Publish(context.Background(), cqrs.NewBaseEventMessage("PlayerJoined"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), injector.Stats().Dropped)
+}
+
+func TestFaultInjector_AdminAPI(t *testing.T) {
+	// Arrange
+	injector, err := NewFaultInjector(FaultConfig{})
+	require.NoError(t, err)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPut, "/admin/faults", strings.NewReader(`{"enabled":true,"error_rate":0.25}`))
+	rec := httptest.NewRecorder()
+	injector.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, injector.Config().Enabled)
+	assert.Equal(t, 0.25, injector.Config().ErrorRate)
+
+	rec = httptest.NewRecorder()
+	injector.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/faults", strings.NewReader(`{"error_rate":2}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	injector.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/faults", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, injector.Config().Enabled)
+}
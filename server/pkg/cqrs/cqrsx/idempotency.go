@@ -0,0 +1,213 @@
+package cqrsx
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key of a command submission
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a previous submission
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyConfig configures the Idempotency-Key middleware
+type IdempotencyConfig struct {
+	Store        cqrs.CommandDeduplicationStore
+	TTL          time.Duration                // How long a completed response is replayed, default 24h
+	PendingTTL   time.Duration                // How long a reservation survives while the handler runs, default 1m
+	Scope        func(r *http.Request) string // Namespaces keys, e.g. by authenticated user; default none
+	Required     bool                         // Reject unsafe requests without a key
+	MaxKeyLength int                          // Default 255
+	MaxBodyBytes int64                        // Largest request body that is fingerprinted, default 1MB
+}
+
+// recordedResponse is the response stored as the deduplication result
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// NewIdempotencyMiddleware returns middleware for command-submitting routes.
+//
+// The first POST/PUT/PATCH/DELETE carrying an Idempotency-Key runs normally and its
+// response is stored; retries with the same key and body within TTL receive the
+// stored response with Idempotent-Replayed: true instead of executing the command
+// again. A retry while the first request is still running gets 409, and reusing a
+// key with a different body gets 422. 5xx responses are not stored so the client
+// can retry them.
+func NewIdempotencyMiddleware(config IdempotencyConfig) (func(http.Handler) http.Handler, error) {
+	if config.Store == nil {
+		return nil, cqrs.NewValidationError("idempotency store is required", nil)
+	}
+	if config.TTL <= 0 {
+		config.TTL = 24 * time.Hour
+	}
+	if config.PendingTTL <= 0 {
+		config.PendingTTL = time.Minute
+	}
+	if config.MaxKeyLength <= 0 {
+		config.MaxKeyLength = 255
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = 1 << 20
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			config.serve(next, w, r)
+		})
+	}, nil
+}
+
+func (c IdempotencyConfig) serve(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+	if idempotencyKey == "" {
+		if c.Required {
+			http.Error(w, IdempotencyKeyHeader+" header is required", http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r)
+		return
+	}
+	if len(idempotencyKey) > c.MaxKeyLength {
+		http.Error(w, IdempotencyKeyHeader+" header is too long", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, c.MaxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > c.MaxBodyBytes {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	scope := ""
+	if c.Scope != nil {
+		scope = c.Scope(r)
+	}
+	key := hashParts(scope, r.Method, r.URL.Path, idempotencyKey)
+	fingerprint := hashParts(r.URL.RawQuery, string(body))
+
+	record, reserved, err := c.Store.Reserve(r.Context(), key, fingerprint, c.PendingTTL)
+	if err != nil {
+		http.Error(w, "Failed to check idempotency key", http.StatusInternalServerError)
+		return
+	}
+	if !reserved {
+		c.replay(w, record, fingerprint)
+		return
+	}
+
+	recorder := &responseRecorder{header: make(http.Header)}
+	func() {
+		// A panicking handler must not leave the key reserved until PendingTTL expires
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				c.Store.Release(context.WithoutCancel(r.Context()), key)
+				panic(recovered)
+			}
+		}()
+		next.ServeHTTP(recorder, r)
+	}()
+
+	ctx := context.WithoutCancel(r.Context())
+	if recorder.status >= http.StatusInternalServerError {
+		c.Store.Release(ctx, key)
+	} else if result, err := json.Marshal(recordedResponse{Status: recorder.status, Header: recorder.header, Body: recorder.body.Bytes()}); err == nil {
+		if err := c.Store.Complete(ctx, key, result, c.TTL); err != nil {
+			c.Store.Release(ctx, key)
+		}
+	}
+
+	recorder.writeTo(w)
+}
+
+// replay answers a request whose key was already used
+func (c IdempotencyConfig) replay(w http.ResponseWriter, record *cqrs.CommandDeduplicationRecord, fingerprint string) {
+	if record.Fingerprint != "" && record.Fingerprint != fingerprint {
+		http.Error(w, IdempotencyKeyHeader+" was already used with a different request", http.StatusUnprocessableEntity)
+		return
+	}
+	if !record.Completed {
+		http.Error(w, "A request with this "+IdempotencyKeyHeader+" is still in progress", http.StatusConflict)
+		return
+	}
+
+	var response recordedResponse
+	if err := json.Unmarshal(record.Result, &response); err != nil {
+		http.Error(w, "Failed to replay stored response", http.StatusInternalServerError)
+		return
+	}
+	recorder := &responseRecorder{header: response.Header, status: response.Status}
+	if recorder.header == nil {
+		recorder.header = make(http.Header)
+	}
+	recorder.body.Write(response.Body)
+	recorder.header.Set(IdempotentReplayedHeader, "true")
+	recorder.writeTo(w)
+}
+
+func hashParts(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder buffers a handler's response so it can be stored before it is sent
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) writeTo(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	w.WriteHeader(r.status)
+	w.Write(r.body.Bytes())
+}
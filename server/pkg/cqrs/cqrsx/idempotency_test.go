@@ -0,0 +1,186 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCommandHandler creates a resource per call and reports the call count
+func countingCommandHandler(calls *int32, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"id":"guild-%d"}`, n)
+	})
+}
+
+func submit(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/guilds", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func newIdempotentHandler(t *testing.T, config IdempotencyConfig, next http.Handler) http.Handler {
+	if config.Store == nil {
+		config.Store = cqrs.NewInMemoryCommandDeduplicationStore()
+	}
+	middleware, err := NewIdempotencyMiddleware(config)
+	require.NoError(t, err)
+	return middleware(next)
+}
+
+func TestIdempotencyMiddleware_ReplaysFirstResponse(t *testing.T) {
+	// Arrange
+	var calls int32
+	handler := newIdempotentHandler(t, IdempotencyConfig{}, countingCommandHandler(&calls, http.StatusCreated))
+
+	// Act
+	first := submit(handler, "key-1", `{"name":"Allies"}`)
+	retry := submit(handler, "key-1", `{"name":"Allies"}`)
+	other := submit(handler, "key-2", `{"name":"Allies"}`)
+	withoutKey := submit(handler, "", `{"name":"Allies"}`)
+
+	// Assert
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+
+	assert.JSONEq(t, `{"id":"guild-2"}`, other.Body.String())
+	assert.JSONEq(t, `{"id":"guild-3"}`, withoutKey.Body.String())
+}
+
+func TestIdempotencyMiddleware_RejectsKeyReuseWithDifferentBody(t *testing.T) {
+	var calls int32
+	handler := newIdempotentHandler(t, IdempotencyConfig{}, countingCommandHandler(&calls, http.StatusOK))
+
+	submit(handler, "key-1", `{"name":"Allies"}`)
+	rec := submit(handler, "key-1", `{"name":"Axis"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyMiddleware_ConcurrentRetryConflicts(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := newIdempotentHandler(t, IdempotencyConfig{}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- submit(handler, "key-1", `{}`) }()
+	<-started
+
+	// Act
+	concurrent := submit(handler, "key-1", `{}`)
+	close(release)
+	first := <-done
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, concurrent.Code)
+	assert.Equal(t, http.StatusAccepted, first.Code)
+	assert.Equal(t, http.StatusAccepted, submit(handler, "key-1", `{}`).Code)
+}
+
+func TestIdempotencyMiddleware_ServerErrorsAreNotStored(t *testing.T) {
+	var calls int32
+	handler := newIdempotentHandler(t, IdempotencyConfig{}, countingCommandHandler(&calls, http.StatusServiceUnavailable))
+
+	submit(handler, "key-1", `{}`)
+	rec := submit(handler, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyMiddleware_ScopeAndMethods(t *testing.T) {
+	// Arrange
+	var calls int32
+	handler := newIdempotentHandler(t, IdempotencyConfig{
+		Required: true,
+		Scope:    func(r *http.Request) string { return r.Header.Get("X-User-ID") },
+	}, countingCommandHandler(&calls, http.StatusOK))
+
+	post := func(user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/guilds", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req.Header.Set("X-User-ID", user)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Act
+	alice := post("alice")
+	bob := post("bob")
+	missingKey := submit(handler, "", `{}`)
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/guilds", nil))
+
+	// Assert
+	assert.NotEqual(t, alice.Body.String(), bob.Body.String())
+	assert.Equal(t, http.StatusBadRequest, missingKey.Code)
+	assert.Equal(t, http.StatusOK, get.Code)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRedisCommandDeduplicationStore(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	store, err := NewRedisCommandDeduplicationStore(client, "")
+	require.NoError(t, err)
+
+	// Act
+	_, reserved, err := store.Reserve(ctx, "key-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+	pending, again, err := store.Reserve(ctx, "key-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, store.Complete(ctx, "key-1", []byte("result"), time.Hour))
+	completed, _, err := store.Reserve(ctx, "key-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, reserved)
+	assert.False(t, again)
+	assert.False(t, pending.Completed)
+	assert.True(t, completed.Completed)
+	assert.Equal(t, "fp-1", completed.Fingerprint)
+	assert.Equal(t, []byte("result"), completed.Result)
+	assert.Equal(t, time.Hour, server.TTL("dedup:key-1"))
+
+	server.FastForward(2 * time.Hour)
+	_, reserved, err = store.Reserve(ctx, "key-1", "fp-2", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	require.NoError(t, store.Release(ctx, "key-1"))
+	assert.False(t, server.Exists("dedup:key-1"))
+}
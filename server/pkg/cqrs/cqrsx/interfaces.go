@@ -3,6 +3,7 @@ package cqrsx
 import (
 	"context"
 	"cqrs"
+	"time"
 )
 
 // EventStore 이벤트 저장소 인터페이스
@@ -20,6 +21,56 @@ type EventStore interface {
 	GetLastEventVersion(ctx context.Context, aggregateID, aggregateType string) (int, error)
 }
 
+// AggregateEventStore 집합체 단위 이벤트 저장소 인터페이스 (MongoEventStore, RedisEventStore)
+type AggregateEventStore interface {
+	// SaveEvents 집합체의 이벤트들을 저장
+	SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error
+
+	// GetEventHistory 이벤트 히스토리 조회
+	GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+
+	// GetLastEventVersion 마지막 이벤트 버전 조회
+	GetLastEventVersion(ctx context.Context, aggregateID, aggregateType string) (int, error)
+
+	// CompactEvents 지정 버전 이전의 이벤트 삭제
+	CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error
+}
+
+var (
+	_ AggregateEventStore = (*MongoEventStore)(nil)
+	_ AggregateEventStore = (*RedisEventStore)(nil)
+	_ TierableEventStore  = (*RedisEventStore)(nil)
+)
+
+// AggregateStreamInfo 집합체 이벤트 스트림 요약 정보
+type AggregateStreamInfo struct {
+	AggregateID   string    `json:"aggregate_id"`
+	AggregateType string    `json:"aggregate_type"`
+	Version       int       `json:"version"`
+	EventCount    int       `json:"event_count"`
+	FirstEventAt  time.Time `json:"first_event_at"`
+	LastEventAt   time.Time `json:"last_event_at"`
+}
+
+// AggregateCatalog 저장된 집합체 스트림 목록 조회 인터페이스 (관리 도구용)
+type AggregateCatalog interface {
+	// ListAggregates 집합체 스트림 목록 조회 (aggregateType이 비어 있으면 전체)
+	ListAggregates(ctx context.Context, aggregateType string, limit, offset int) ([]AggregateStreamInfo, error)
+}
+
+var (
+	_ AggregateCatalog            = (*MongoEventStore)(nil)
+	_ EventExportSource           = (*MongoEventStore)(nil)
+	_ RetentionEventStore         = (*MongoEventStore)(nil)
+	_ cqrs.StorageMetricsProvider = (*MongoEventSourcedRepository)(nil)
+	_ cqrs.StorageMetricsProvider = (*RedisEventSourcedRepository)(nil)
+	_ cqrs.StreamingReadStore     = (*MongoReadStore)(nil)
+	_ cqrs.StreamingReadStore     = (*RedisReadStore)(nil)
+	_ cqrs.BatchReadStore         = (*MongoReadStore)(nil)
+	_ cqrs.BatchReadStore         = (*RedisReadStore)(nil)
+	_ cqrs.BatchReadStore         = (*FaultyReadStore)(nil)
+)
+
 // ReadStore 읽기 저장소 인터페이스
 type ReadStore interface {
 	// Save 읽기 모델 저장
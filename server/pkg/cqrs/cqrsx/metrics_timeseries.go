@@ -0,0 +1,415 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RedisTimeSeriesStore stores metric points in Redis sorted sets scored by timestamp, so it
+// works on any Redis deployment without the RedisTimeSeries module.
+//
+// Layout:
+//   - metrics:names:all        set of metric names
+//   - metrics:series:<name>    set of canonical label JSON documents of the metric
+//   - metrics:points:<series>  sorted set of "<unix ms>:<value>" members scored by unix ms
+type RedisTimeSeriesStore struct {
+	client     *RedisClientManager
+	keyBuilder *RedisKeyBuilder
+}
+
+// NewRedisTimeSeriesStore creates a Redis time series store
+func NewRedisTimeSeriesStore(client *RedisClientManager, keyPrefix string) *RedisTimeSeriesStore {
+	return &RedisTimeSeriesStore{client: client, keyBuilder: NewRedisKeyBuilder(keyPrefix)}
+}
+
+func (s *RedisTimeSeriesStore) namesKey() string {
+	return s.keyBuilder.MetricsKey("names", "all")
+}
+
+func (s *RedisTimeSeriesStore) seriesKey(name string) string {
+	return s.keyBuilder.MetricsKey("series", name)
+}
+
+func (s *RedisTimeSeriesStore) pointsKey(name string, labels map[string]string) string {
+	return s.keyBuilder.MetricsKey("points", cqrs.MetricSeriesKey(name, labels))
+}
+
+func (s *RedisTimeSeriesStore) Append(ctx context.Context, points []cqrs.MetricPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	return s.client.ExecuteCommand(ctx, func() error {
+		pipe := s.client.GetClient().Pipeline()
+		for _, point := range points {
+			labels, err := json.Marshal(point.Labels) // Map keys marshal sorted, so the document is canonical
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize metric labels", err)
+			}
+			millis := point.Timestamp.UnixMilli()
+			pipe.SAdd(ctx, s.namesKey(), point.Name)
+			pipe.SAdd(ctx, s.seriesKey(point.Name), string(labels))
+			pipe.ZAdd(ctx, s.pointsKey(point.Name, point.Labels), redis.Z{
+				Score:  float64(millis),
+				Member: fmt.Sprintf("%d:%s", millis, strconv.FormatFloat(point.Value, 'g', -1, 64)),
+			})
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to append metric points", err)
+		}
+		return nil
+	})
+}
+
+func (s *RedisTimeSeriesStore) Range(ctx context.Context, query cqrs.TimeSeriesQuery) ([]cqrs.MetricPoint, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	min, max := "-inf", "+inf"
+	if !query.From.IsZero() {
+		min = strconv.FormatInt(query.From.UnixMilli(), 10)
+	}
+	if !query.To.IsZero() {
+		max = strconv.FormatInt(query.To.UnixMilli(), 10)
+	}
+
+	var result []cqrs.MetricPoint
+	err := s.client.ExecuteCommand(ctx, func() error {
+		client := s.client.GetClient()
+		documents, err := client.SMembers(ctx, s.seriesKey(query.Name)).Result()
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to list metric series", err)
+		}
+
+		series := make([]map[string]string, 0, len(documents))
+		for _, document := range documents {
+			var labels map[string]string
+			if err := json.Unmarshal([]byte(document), &labels); err != nil {
+				continue // Skip invalid entries
+			}
+			if query.Matches(cqrs.MetricPoint{Name: query.Name, Labels: labels, Timestamp: query.From}) {
+				series = append(series, labels)
+			}
+		}
+		sort.Slice(series, func(i, j int) bool {
+			return cqrs.MetricSeriesKey(query.Name, series[i]) < cqrs.MetricSeriesKey(query.Name, series[j])
+		})
+
+		for _, labels := range series {
+			members, err := client.ZRangeByScore(ctx, s.pointsKey(query.Name, labels), &redis.ZRangeBy{Min: min, Max: max}).Result()
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read metric points", err)
+			}
+			for _, member := range members {
+				millisText, valueText, found := strings.Cut(member, ":")
+				millis, millisErr := strconv.ParseInt(millisText, 10, 64)
+				value, valueErr := strconv.ParseFloat(valueText, 64)
+				if !found || millisErr != nil || valueErr != nil {
+					continue // Skip invalid entries
+				}
+				result = append(result, cqrs.MetricPoint{
+					Name:      query.Name,
+					Labels:    labels,
+					Timestamp: time.UnixMilli(millis).UTC(),
+					Value:     value,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *RedisTimeSeriesStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var removed int64
+	err := s.client.ExecuteCommand(ctx, func() error {
+		client := s.client.GetClient()
+		names, err := client.SMembers(ctx, s.namesKey()).Result()
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to list metric names", err)
+		}
+
+		max := "(" + strconv.FormatInt(cutoff.UnixMilli(), 10)
+		for _, name := range names {
+			documents, err := client.SMembers(ctx, s.seriesKey(name)).Result()
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to list metric series", err)
+			}
+			for _, document := range documents {
+				var labels map[string]string
+				if err := json.Unmarshal([]byte(document), &labels); err != nil {
+					continue
+				}
+				key := s.pointsKey(name, labels)
+				count, err := client.ZRemRangeByScore(ctx, key, "-inf", max).Result()
+				if err != nil {
+					return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to trim metric points", err)
+				}
+				removed += count
+
+				// Forget series that no longer have points
+				if remaining, err := client.ZCard(ctx, key).Result(); err == nil && remaining == 0 {
+					client.SRem(ctx, s.seriesKey(name), document)
+				}
+			}
+			if remaining, err := client.SCard(ctx, s.seriesKey(name)).Result(); err == nil && remaining == 0 {
+				client.SRem(ctx, s.namesKey(), name)
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// MongoTimeSeriesStore stores metric points in a MongoDB time series collection.
+// Retention is enforced by the collection's expireAfterSeconds; DeleteBefore trims explicitly.
+type MongoTimeSeriesStore struct {
+	client     *MongoClientManager
+	collection string
+}
+
+// mongoMetricDocument is the stored form of a metric point
+type mongoMetricDocument struct {
+	Timestamp time.Time       `bson:"timestamp"`
+	Meta      mongoMetricMeta `bson:"meta"`
+	Value     float64         `bson:"value"`
+}
+
+type mongoMetricMeta struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+// NewMongoTimeSeriesStore creates a Mongo time series store over collection (default "metrics")
+func NewMongoTimeSeriesStore(client *MongoClientManager, collection string) *MongoTimeSeriesStore {
+	if collection == "" {
+		collection = "metrics"
+	}
+	return &MongoTimeSeriesStore{client: client, collection: collection}
+}
+
+// EnsureCollection creates the time series collection with the given retention if it does not exist
+func (s *MongoTimeSeriesStore) EnsureCollection(ctx context.Context, retention time.Duration) error {
+	opts := options.CreateCollection().SetTimeSeriesOptions(
+		options.TimeSeries().SetTimeField("timestamp").SetMetaField("meta").SetGranularity("minutes"),
+	)
+	if retention > 0 {
+		opts.SetExpireAfterSeconds(int64(retention / time.Second))
+	}
+
+	return s.client.ExecuteCommand(ctx, func() error {
+		err := s.client.GetDatabase().CreateCollection(ctx, s.collection, opts)
+		var commandErr mongo.CommandError
+		if err != nil && !(errors.As(err, &commandErr) && commandErr.Name == "NamespaceExists") {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to create metrics collection", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoTimeSeriesStore) Append(ctx context.Context, points []cqrs.MetricPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(points))
+	for i, point := range points {
+		documents[i] = mongoMetricDocument{
+			Timestamp: point.Timestamp,
+			Meta:      mongoMetricMeta{Name: point.Name, Labels: point.Labels},
+			Value:     point.Value,
+		}
+	}
+
+	return s.client.ExecuteCommand(ctx, func() error {
+		if _, err := s.client.GetCollection(s.collection).InsertMany(ctx, documents); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to append metric points", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoTimeSeriesStore) Range(ctx context.Context, query cqrs.TimeSeriesQuery) ([]cqrs.MetricPoint, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"meta.name": query.Name}
+	for key, value := range query.Labels {
+		filter["meta.labels."+key] = value
+	}
+	timeRange := bson.M{}
+	if !query.From.IsZero() {
+		timeRange["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		timeRange["$lte"] = query.To
+	}
+	if len(timeRange) > 0 {
+		filter["timestamp"] = timeRange
+	}
+
+	var result []cqrs.MetricPoint
+	err := s.client.ExecuteCommand(ctx, func() error {
+		cursor, err := s.client.GetCollection(s.collection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to query metric points", err)
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var document mongoMetricDocument
+			if err := cursor.Decode(&document); err != nil {
+				continue // Skip invalid entries
+			}
+			result = append(result, cqrs.MetricPoint{
+				Name:      document.Meta.Name,
+				Labels:    document.Meta.Labels,
+				Timestamp: document.Timestamp.UTC(),
+				Value:     document.Value,
+			})
+		}
+		return cursor.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Group by series, keeping time order within each series
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].SeriesKey() < result[j].SeriesKey()
+	})
+	return result, nil
+}
+
+func (s *MongoTimeSeriesStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var removed int64
+	err := s.client.ExecuteCommand(ctx, func() error {
+		deleted, err := s.client.GetCollection(s.collection).DeleteMany(ctx, bson.M{"timestamp": bson.M{"$lt": cutoff}})
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to trim metric points", err)
+		}
+		removed = deleted.DeletedCount
+		return nil
+	})
+	return removed, err
+}
+
+// MetricsHistoryHandler serves recorded metrics for the admin dashboard graphs:
+//
+//	GET ?name=eventbus_published_events&from=<RFC3339>&to=<RFC3339>&step=5m&label.source=main
+//
+// from defaults to one hour before to, and to defaults to now.
+func MetricsHistoryHandler(snapshotter *cqrs.MetricsSnapshotter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query, err := parseTimeSeriesQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		points, err := snapshotter.History(r.Context(), query)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if cqrs.IsValidationError(err) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		type seriesPoint struct {
+			Timestamp time.Time `json:"timestamp"`
+			Value     float64   `json:"value"`
+		}
+		type series struct {
+			Labels map[string]string `json:"labels,omitempty"`
+			Points []seriesPoint     `json:"points"`
+		}
+		var grouped []*series
+		index := make(map[string]*series)
+		for _, point := range points {
+			key := point.SeriesKey()
+			current, ok := index[key]
+			if !ok {
+				current = &series{Labels: point.Labels}
+				index[key] = current
+				grouped = append(grouped, current)
+			}
+			value := point.Value
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue // Not representable in JSON
+			}
+			current.Points = append(current.Points, seriesPoint{Timestamp: point.Timestamp, Value: value})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":   query.Name,
+			"from":   query.From,
+			"to":     query.To,
+			"step":   query.Step.String(),
+			"series": grouped,
+		})
+	})
+}
+
+func parseTimeSeriesQuery(r *http.Request) (cqrs.TimeSeriesQuery, error) {
+	values := r.URL.Query()
+	query := cqrs.TimeSeriesQuery{Name: values.Get("name"), To: time.Now().UTC()}
+
+	if to := values.Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+		query.To = parsed
+	}
+	query.From = query.To.Add(-time.Hour)
+	if from := values.Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+		query.From = parsed
+	}
+	if step := values.Get("step"); step != "" {
+		parsed, err := time.ParseDuration(step)
+		if err != nil {
+			return query, fmt.Errorf("invalid step: %w", err)
+		}
+		query.Step = parsed
+	}
+
+	for key, vals := range values {
+		if label, ok := strings.CutPrefix(key, "label."); ok && len(vals) > 0 {
+			if query.Labels == nil {
+				query.Labels = make(map[string]string)
+			}
+			query.Labels[label] = vals[0]
+		}
+	}
+	return query, nil
+}
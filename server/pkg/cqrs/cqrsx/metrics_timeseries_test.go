@@ -0,0 +1,104 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisTimeSeriesStore(t *testing.T) *RedisTimeSeriesStore {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisTimeSeriesStore(&RedisClientManager{client: client, metrics: &RedisMetrics{}}, "test")
+}
+
+func TestRedisTimeSeriesStore_AppendRangeAndTrim(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newTestRedisTimeSeriesStore(t)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	main := map[string]string{"source": "main"}
+	audit := map[string]string{"source": "audit"}
+	require.NoError(t, store.Append(ctx, []cqrs.MetricPoint{
+		{Name: "eventbus_published_events", Labels: main, Timestamp: base, Value: 10},
+		{Name: "eventbus_published_events", Labels: main, Timestamp: base.Add(time.Minute), Value: 12.5},
+		{Name: "eventbus_published_events", Labels: audit, Timestamp: base.Add(time.Minute), Value: 3},
+		{Name: "eventbus_failed_events", Labels: main, Timestamp: base, Value: 1},
+	}))
+
+	// Act
+	all, err := store.Range(ctx, cqrs.TimeSeriesQuery{Name: "eventbus_published_events"})
+	require.NoError(t, err)
+	mainOnly, err := store.Range(ctx, cqrs.TimeSeriesQuery{Name: "eventbus_published_events", Labels: main, From: base.Add(30 * time.Second)})
+	require.NoError(t, err)
+	removed, err := store.DeleteBefore(ctx, base.Add(time.Second))
+	require.NoError(t, err)
+	failed, err := store.Range(ctx, cqrs.TimeSeriesQuery{Name: "eventbus_failed_events"})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, all, 3)
+	assert.Equal(t, audit, all[0].Labels) // Ordered by series key then time
+	assert.Equal(t, 10.0, all[1].Value)
+	assert.Equal(t, base, all[1].Timestamp)
+	require.Len(t, mainOnly, 1)
+	assert.Equal(t, 12.5, mainOnly[0].Value)
+	assert.Equal(t, int64(2), removed)
+	assert.Empty(t, failed)
+
+	names, err := store.client.GetClient().SMembers(ctx, store.namesKey()).Result()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eventbus_published_events"}, names)
+}
+
+func TestMetricsHistoryHandler(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newTestRedisTimeSeriesStore(t)
+	snapshotter := cqrs.NewMetricsSnapshotter(store, cqrs.MetricsSnapshotConfig{})
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Append(ctx, []cqrs.MetricPoint{
+		{Name: "players_online", Labels: map[string]string{"source": "game"}, Timestamp: base, Value: 10},
+		{Name: "players_online", Labels: map[string]string{"source": "game"}, Timestamp: base.Add(time.Minute), Value: 20},
+		{Name: "players_online", Labels: map[string]string{"source": "lobby"}, Timestamp: base, Value: 5},
+	}))
+	handler := MetricsHistoryHandler(snapshotter)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/admin/metrics/history?name=players_online&from=2026-03-01T11:00:00Z&to=2026-03-01T13:00:00Z&step=5m&label.source=game", nil))
+	invalid := httptest.NewRecorder()
+	handler.ServeHTTP(invalid, httptest.NewRequest(http.MethodGet, "/admin/metrics/history?step=5m", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var body struct {
+		Series []struct {
+			Labels map[string]string `json:"labels"`
+			Points []struct {
+				Timestamp time.Time `json:"timestamp"`
+				Value     float64   `json:"value"`
+			} `json:"points"`
+		} `json:"series"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Len(t, body.Series, 1)
+	assert.Equal(t, "game", body.Series[0].Labels["source"])
+	require.Len(t, body.Series[0].Points, 1)
+	assert.Equal(t, 15.0, body.Series[0].Points[0].Value)
+
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"cqrs"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -22,6 +23,7 @@ type MongoClientManager struct {
 	metrics          *MongoMetrics
 	collectionPrefix string           // Prefix for all collection names
 	collectionNames  *CollectionNames // Configurable collection names
+	monitor          *mongoConnectionMonitor
 }
 
 // CollectionNames holds configurable collection names for CQRS infrastructure
@@ -54,7 +56,7 @@ func NewMongoClientManagerWithPrefix(config *MongoConfig, prefix string) (*Mongo
 // NewMongoClientManagerWithCollections creates a new MongoDB client manager with custom collection names
 func NewMongoClientManagerWithCollections(config *MongoConfig, prefix string, collectionNames *CollectionNames) (*MongoClientManager, error) {
 	if config == nil {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB config cannot be nil", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB config cannot be nil", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if err := validateMongoConfig(config); err != nil {
@@ -74,6 +76,14 @@ func NewMongoClientManagerWithCollections(config *MongoConfig, prefix string, co
 		}
 	}
 
+	manager := &MongoClientManager{
+		config:           config,
+		metrics:          &MongoMetrics{DatabaseName: config.Database},
+		collectionPrefix: prefix,
+		collectionNames:  collectionNames,
+		monitor:          newMongoConnectionMonitor(),
+	}
+
 	// Create MongoDB client options
 	clientOptions := options.Client().ApplyURI(config.URI)
 
@@ -88,6 +98,14 @@ func NewMongoClientManagerWithCollections(config *MongoConfig, prefix string, co
 		clientOptions.SetMaxPoolSize(uint64(config.MaxPoolSize))
 	}
 
+	if config.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(uint64(config.MinPoolSize))
+	}
+
+	if config.MaxConnIdleTime > 0 {
+		clientOptions.SetMaxConnIdleTime(config.MaxConnIdleTime)
+	}
+
 	if config.ConnectTimeout > 0 {
 		clientOptions.SetConnectTimeout(config.ConnectTimeout)
 	}
@@ -100,35 +118,71 @@ func NewMongoClientManagerWithCollections(config *MongoConfig, prefix string, co
 		clientOptions.SetServerSelectionTimeout(config.ServerSelectionTimeout)
 	}
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
-	defer cancel()
+	if config.HeartbeatInterval > 0 {
+		clientOptions.SetHeartbeatInterval(config.HeartbeatInterval)
+	}
 
-	// Connect to MongoDB
-	client, err := mongo.Connect(ctx, clientOptions)
-	if err != nil {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
-			fmt.Sprintf("failed to connect to MongoDB: %v", err), err)
+	if config.RetryWrites != nil {
+		clientOptions.SetRetryWrites(*config.RetryWrites)
 	}
 
-	// Test the connection
-	if err := client.Ping(ctx, readpref.Primary()); err != nil {
-		client.Disconnect(ctx)
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
-			fmt.Sprintf("failed to ping MongoDB: %v", err), err)
+	if config.RetryReads != nil {
+		clientOptions.SetRetryReads(*config.RetryReads)
 	}
 
-	// Get database
-	database := client.Database(config.Database)
+	clientOptions.SetPoolMonitor(manager.poolMonitor())
+	clientOptions.SetServerMonitor(manager.serverMonitor())
 
-	return &MongoClientManager{
-		client:           client,
-		database:         database,
-		config:           config,
-		metrics:          &MongoMetrics{DatabaseName: config.Database},
-		collectionPrefix: prefix,
-		collectionNames:  collectionNames,
-	}, nil
+	// Connect to MongoDB, backing off between attempts
+	client, err := connectMongoWithRetry(config, clientOptions, manager.monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.client = client
+	manager.database = client.Database(config.Database)
+	manager.startHealthMonitor()
+
+	return manager, nil
+}
+
+// connectMongoWithRetry connects and pings MongoDB up to MaxConnectAttempts times
+func connectMongoWithRetry(config *MongoConfig, clientOptions *options.ClientOptions, monitor *mongoConnectionMonitor) (*mongo.Client, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < config.MaxConnectAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(reconnectBackoff(config, attempt-1))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
+
+		client, err := mongo.Connect(ctx, clientOptions)
+		if err != nil {
+			cancel()
+			lastErr = cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to connect to MongoDB: %v", err), err)
+			monitor.recordFailure()
+			continue
+		}
+
+		// Test the connection
+		if err := client.Ping(ctx, readpref.Primary()); err != nil {
+			client.Disconnect(ctx)
+			cancel()
+			lastErr = cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to ping MongoDB: %v", err), err)
+			monitor.recordFailure()
+			continue
+		}
+
+		monitor.recordSuccess()
+		cancel()
+		return client, nil
+	}
+
+	monitor.state.Store(int32(MongoStateDisconnected))
+	return nil, lastErr
 }
 
 // GetClient returns the MongoDB client
@@ -148,6 +202,7 @@ func (mm *MongoClientManager) GetCollection(name string) *mongo.Collection {
 
 // Close closes the MongoDB connection
 func (mm *MongoClientManager) Close(ctx context.Context) error {
+	mm.monitor.stop()
 	if mm.client != nil {
 		return mm.client.Disconnect(ctx)
 	}
@@ -158,7 +213,7 @@ func (mm *MongoClientManager) Close(ctx context.Context) error {
 func (mm *MongoClientManager) GetMetrics() *MongoMetrics {
 	// Return a copy of metrics
 	return &MongoMetrics{
-		ConnectionCount: mm.metrics.ConnectionCount,
+		ConnectionCount: atomic.LoadInt64(&mm.metrics.ConnectionCount),
 		CommandCount:    mm.metrics.CommandCount,
 		ErrorCount:      mm.metrics.ErrorCount,
 		AverageLatency:  mm.metrics.AverageLatency,
@@ -200,7 +255,7 @@ func (mm *MongoClientManager) updateMetrics(duration time.Duration, err error) {
 // validateMongoConfig validates MongoDB configuration
 func validateMongoConfig(config *MongoConfig) error {
 	if config.URI == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB URI is required", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB URI is required", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	// Parse and validate URI
@@ -210,23 +265,39 @@ func validateMongoConfig(config *MongoConfig) error {
 	}
 
 	if config.Database == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB database name is required", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB database name is required", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.MaxPoolSize < 0 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB max pool size cannot be negative", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB max pool size cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.ConnectTimeout < 0 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB connect timeout cannot be negative", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB connect timeout cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.SocketTimeout < 0 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB socket timeout cannot be negative", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB socket timeout cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.ServerSelectionTimeout < 0 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB server selection timeout cannot be negative", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB server selection timeout cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	if config.MinPoolSize < 0 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB min pool size cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	if config.MaxPoolSize > 0 && config.MinPoolSize > config.MaxPoolSize {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB min pool size cannot exceed max pool size", nil)
+	}
+
+	if config.MaxConnIdleTime < 0 || config.HeartbeatInterval < 0 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB idle time and heartbeat interval cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	if config.MaxConnectAttempts < 0 || config.ReconnectBackoff < 0 || config.MaxReconnectBackoff < 0 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB reconnect settings cannot be negative", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	return nil
@@ -249,9 +320,32 @@ func applyMongoConfigDefaults(config *MongoConfig) {
 	if config.ServerSelectionTimeout == 0 {
 		config.ServerSelectionTimeout = 30 * time.Second
 	}
+
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = 15 * time.Second
+	}
+
+	if config.MaxConnectAttempts == 0 {
+		config.MaxConnectAttempts = 1
+	}
+
+	if config.ReconnectBackoff == 0 {
+		config.ReconnectBackoff = 500 * time.Millisecond
+	}
+
+	if config.MaxReconnectBackoff == 0 {
+		config.MaxReconnectBackoff = 30 * time.Second
+	}
+
+	if config.MaxReconnectBackoff < config.ReconnectBackoff {
+		config.MaxReconnectBackoff = config.ReconnectBackoff
+	}
 }
 
 // InitializeEventSourcingSchema creates the standard Event Sourcing collections and indexes
+//
+// Deprecated: run DefaultMongoSchemaMigrations through a SchemaMigrationRunner (cmd/schemamigrate)
+// so deploys record which schema version was applied.
 func (mm *MongoClientManager) InitializeEventSourcingSchema(ctx context.Context) error {
 	// Create events collection with schema validation
 	if err := mm.createEventsCollection(ctx); err != nil {
@@ -296,41 +390,7 @@ func (mm *MongoClientManager) createReadModelsCollection(ctx context.Context) er
 
 // createEventsIndexes creates standard Event Sourcing indexes for events collection
 func (mm *MongoClientManager) createEventsIndexes(ctx context.Context, collection *mongo.Collection) error {
-	indexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "aggregate_id", Value: 1},
-				{Key: "event_version", Value: 1},
-			},
-			Options: options.Index().SetUnique(true).SetName("idx_aggregate_version"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "aggregate_id", Value: 1},
-				{Key: "timestamp", Value: 1},
-			},
-			Options: options.Index().SetName("idx_aggregate_timestamp"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "aggregate_type", Value: 1},
-				{Key: "timestamp", Value: 1},
-			},
-			Options: options.Index().SetName("idx_type_timestamp"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "event_type", Value: 1},
-			},
-			Options: options.Index().SetName("idx_event_type"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "event_id", Value: 1},
-			},
-			Options: options.Index().SetUnique(true).SetName("idx_event_id"),
-		},
-	}
+	indexes := eventsIndexModels()
 
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
@@ -343,22 +403,7 @@ func (mm *MongoClientManager) createEventsIndexes(ctx context.Context, collectio
 
 // createSnapshotsIndexes creates indexes for snapshots collection
 func (mm *MongoClientManager) createSnapshotsIndexes(ctx context.Context, collection *mongo.Collection) error {
-	indexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "aggregate_id", Value: 1},
-				{Key: "aggregate_type", Value: 1},
-			},
-			Options: options.Index().SetUnique(true).SetName("idx_aggregate_snapshot"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "aggregate_type", Value: 1},
-				{Key: "timestamp", Value: -1},
-			},
-			Options: options.Index().SetName("idx_type_timestamp_desc"),
-		},
-	}
+	indexes := snapshotsIndexModels()
 
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
@@ -371,28 +416,7 @@ func (mm *MongoClientManager) createSnapshotsIndexes(ctx context.Context, collec
 
 // createReadModelsIndexes creates indexes for read models collection
 func (mm *MongoClientManager) createReadModelsIndexes(ctx context.Context, collection *mongo.Collection) error {
-	indexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "model_id", Value: 1},
-				{Key: "model_type", Value: 1},
-			},
-			Options: options.Index().SetUnique(true).SetName("idx_model_id_type"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "model_type", Value: 1},
-				{Key: "updated_at", Value: -1},
-			},
-			Options: options.Index().SetName("idx_type_updated"),
-		},
-		{
-			Keys: bson.D{
-				{Key: "ttl", Value: 1},
-			},
-			Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl"),
-		},
-	}
+	indexes := readModelsIndexModels()
 
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
@@ -476,3 +500,88 @@ func (mm *MongoClientManager) GetCollectionNames() *CollectionNames {
 		ReadModels: mm.collectionNames.ReadModels,
 	}
 }
+
+// eventsIndexModels returns the standard indexes of the events collection
+func eventsIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "aggregate_id", Value: 1},
+				{Key: "event_version", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_aggregate_version"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "aggregate_id", Value: 1},
+				{Key: "timestamp", Value: 1},
+			},
+			Options: options.Index().SetName("idx_aggregate_timestamp"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "aggregate_type", Value: 1},
+				{Key: "timestamp", Value: 1},
+			},
+			Options: options.Index().SetName("idx_type_timestamp"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "event_type", Value: 1},
+			},
+			Options: options.Index().SetName("idx_event_type"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "event_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_event_id"),
+		},
+	}
+}
+
+// snapshotsIndexModels returns the standard indexes of the snapshots collection
+func snapshotsIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "aggregate_id", Value: 1},
+				{Key: "aggregate_type", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_aggregate_snapshot"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "aggregate_type", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().SetName("idx_type_timestamp_desc"),
+		},
+	}
+}
+
+// readModelsIndexModels returns the standard indexes of the read models collection
+func readModelsIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "model_id", Value: 1},
+				{Key: "model_type", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_model_id_type"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "model_type", Value: 1},
+				{Key: "updated_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_type_updated"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "ttl", Value: 1},
+			},
+			Options: options.Index().SetExpireAfterSeconds(0).SetName("idx_ttl"),
+		},
+	}
+}
@@ -104,6 +104,25 @@ func TestMongoClientManager_ValidateConfig(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "min pool size exceeds max",
+			config: &MongoConfig{
+				URI:         "mongodb://localhost:27017",
+				Database:    "test",
+				MaxPoolSize: 5,
+				MinPoolSize: 10,
+			},
+			expectError: true,
+		},
+		{
+			name: "negative reconnect backoff",
+			config: &MongoConfig{
+				URI:              "mongodb://localhost:27017",
+				Database:         "test",
+				ReconnectBackoff: -1 * time.Second,
+			},
+			expectError: true,
+		},
 		{
 			name: "valid config with defaults",
 			config: &MongoConfig{
@@ -139,6 +158,48 @@ func TestMongoClientManager_ApplyDefaults(t *testing.T) {
 	assert.Equal(t, 10*time.Second, config.ConnectTimeout)
 	assert.Equal(t, 30*time.Second, config.SocketTimeout)
 	assert.Equal(t, 30*time.Second, config.ServerSelectionTimeout)
+	assert.Equal(t, 15*time.Second, config.HealthCheckInterval)
+	assert.Equal(t, 1, config.MaxConnectAttempts)
+	assert.Equal(t, 500*time.Millisecond, config.ReconnectBackoff)
+	assert.Equal(t, 30*time.Second, config.MaxReconnectBackoff)
+	assert.Nil(t, config.RetryWrites)
+	assert.Nil(t, config.RetryReads)
+}
+
+func TestMongoClientManager_ReconnectBackoff(t *testing.T) {
+	config := &MongoConfig{
+		ReconnectBackoff:    100 * time.Millisecond,
+		MaxReconnectBackoff: time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, reconnectBackoff(config, 0))
+	assert.Equal(t, 200*time.Millisecond, reconnectBackoff(config, 1))
+	assert.Equal(t, 800*time.Millisecond, reconnectBackoff(config, 3))
+	assert.Equal(t, time.Second, reconnectBackoff(config, 4))
+	assert.Equal(t, time.Second, reconnectBackoff(config, 20))
+}
+
+func TestMongoClientManager_ConnectionMonitorState(t *testing.T) {
+	monitor := newMongoConnectionMonitor()
+	assert.Equal(t, MongoStateConnecting, monitor.getState())
+
+	monitor.recordSuccess()
+	assert.Equal(t, MongoStateConnected, monitor.getState())
+	assert.Equal(t, int64(0), monitor.reconnectCount.Load())
+
+	monitor.recordFailure()
+	monitor.recordFailure()
+	assert.Equal(t, MongoStateReconnecting, monitor.getState())
+	assert.Equal(t, int64(2), monitor.consecutiveErrors.Load())
+
+	monitor.recordSuccess()
+	assert.Equal(t, MongoStateConnected, monitor.getState())
+	assert.Equal(t, int64(0), monitor.consecutiveErrors.Load())
+	assert.Equal(t, int64(1), monitor.reconnectCount.Load())
+
+	monitor.stop()
+	monitor.recordFailure()
+	assert.Equal(t, MongoStateClosed, monitor.getState())
 }
 
 func TestMongoClientManager_Metrics(t *testing.T) {
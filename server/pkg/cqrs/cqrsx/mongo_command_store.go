@@ -0,0 +1,220 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCommandStore stores dispatched commands in a MongoDB collection. Retention is
+// enforced by a TTL index on issued_at (see EnsureIndexes); DeleteBefore trims explicitly.
+type MongoCommandStore struct {
+	client     *MongoClientManager
+	collection string
+}
+
+// NewMongoCommandStore creates a Mongo command store over collection (default "command_history")
+func NewMongoCommandStore(client *MongoClientManager, collection string) *MongoCommandStore {
+	if collection == "" {
+		collection = "command_history"
+	}
+	return &MongoCommandStore{client: client, collection: collection}
+}
+
+// EnsureIndexes creates the query indexes and, when retention is positive, the TTL index
+func (s *MongoCommandStore) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "issued_at", Value: -1}}, Options: options.Index().SetName("idx_user_issued")},
+		{Keys: bson.D{{Key: "aggregate_id", Value: 1}, {Key: "issued_at", Value: -1}}, Options: options.Index().SetName("idx_aggregate_issued")},
+		{Keys: bson.D{{Key: "correlation_id", Value: 1}}, Options: options.Index().SetName("idx_correlation").SetSparse(true)},
+	}
+	if retention > 0 {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "issued_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention / time.Second)).SetName("idx_ttl"),
+		})
+	}
+
+	return s.client.ExecuteCommand(ctx, func() error {
+		if _, err := s.client.GetCollection(s.collection).Indexes().CreateMany(ctx, indexes); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to create command history indexes", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoCommandStore) Append(ctx context.Context, record cqrs.CommandRecord) error {
+	return s.client.ExecuteCommand(ctx, func() error {
+		if _, err := s.client.GetCollection(s.collection).InsertOne(ctx, record); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to record command", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoCommandStore) Query(ctx context.Context, query cqrs.CommandHistoryQuery) ([]cqrs.CommandRecord, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "issued_at", Value: -1}}).
+		SetLimit(int64(query.EffectiveLimit()))
+
+	var result []cqrs.CommandRecord
+	err := s.client.ExecuteCommand(ctx, func() error {
+		cursor, err := s.client.GetCollection(s.collection).Find(ctx, commandHistoryFilter(query), opts)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to query command history", err)
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var record cqrs.CommandRecord
+			if err := cursor.Decode(&record); err != nil {
+				continue // Skip invalid entries
+			}
+			record.IssuedAt = record.IssuedAt.UTC()
+			result = append(result, record)
+		}
+		return cursor.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MongoCommandStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var removed int64
+	err := s.client.ExecuteCommand(ctx, func() error {
+		deleted, err := s.client.GetCollection(s.collection).DeleteMany(ctx, bson.M{"issued_at": bson.M{"$lt": cutoff}})
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to trim command history", err)
+		}
+		removed = deleted.DeletedCount
+		return nil
+	})
+	return removed, err
+}
+
+func commandHistoryFilter(query cqrs.CommandHistoryQuery) bson.M {
+	filter := bson.M{}
+	for field, value := range map[string]string{
+		"user_id":        query.UserID,
+		"aggregate_id":   query.AggregateID,
+		"aggregate_type": query.AggregateType,
+		"command_type":   query.CommandType,
+		"correlation_id": query.CorrelationID,
+	} {
+		if value != "" {
+			filter[field] = value
+		}
+	}
+	if query.FailedOnly {
+		filter["success"] = false
+	}
+	timeRange := bson.M{}
+	if !query.From.IsZero() {
+		timeRange["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		timeRange["$lte"] = query.To
+	}
+	if len(timeRange) > 0 {
+		filter["issued_at"] = timeRange
+	}
+	return filter
+}
+
+// CommandHistoryHandler serves recorded commands for support investigations:
+//
+//	GET ?user_id=player-1&since=1h
+//	GET ?aggregate_id=guild-7&from=<RFC3339>&to=<RFC3339>&failed=true&limit=50
+//
+// since is a duration before now and is ignored when from is given.
+func CommandHistoryHandler(store cqrs.CommandStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query, err := parseCommandHistoryQuery(r)
+		var records []cqrs.CommandRecord
+		if err == nil {
+			records, err = store.Query(r.Context(), query)
+		}
+		if err != nil {
+			status := http.StatusInternalServerError
+			if cqrs.IsValidationError(err) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		if records == nil {
+			records = []cqrs.CommandRecord{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":    len(records),
+			"commands": records,
+		})
+	})
+}
+
+func parseCommandHistoryQuery(r *http.Request) (cqrs.CommandHistoryQuery, error) {
+	values := r.URL.Query()
+	query := cqrs.CommandHistoryQuery{
+		UserID:        values.Get("user_id"),
+		AggregateID:   values.Get("aggregate_id"),
+		AggregateType: values.Get("aggregate_type"),
+		CommandType:   values.Get("command_type"),
+		CorrelationID: values.Get("correlation_id"),
+	}
+
+	if to := values.Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return query, cqrs.NewValidationError("invalid to", err)
+		}
+		query.To = parsed
+	}
+	if from := values.Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return query, cqrs.NewValidationError("invalid from", err)
+		}
+		query.From = parsed
+	} else if since := values.Get("since"); since != "" {
+		parsed, err := time.ParseDuration(since)
+		if err != nil {
+			return query, cqrs.NewValidationError("invalid since", err)
+		}
+		query.From = time.Now().UTC().Add(-parsed)
+	}
+	if failed := values.Get("failed"); failed != "" {
+		parsed, err := strconv.ParseBool(failed)
+		if err != nil {
+			return query, cqrs.NewValidationError("invalid failed", err)
+		}
+		query.FailedOnly = parsed
+	}
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return query, cqrs.NewValidationError("invalid limit", err)
+		}
+		query.Limit = parsed
+	}
+	return query, nil
+}
@@ -0,0 +1,71 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCommandHistoryHandler(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryCommandStore()
+	now := time.Now().UTC()
+	require.NoError(t, store.Append(ctx, cqrs.CommandRecord{CommandID: "c1", CommandType: "InviteMember", UserID: "player-1", IssuedAt: now.Add(-2 * time.Hour), Success: true}))
+	require.NoError(t, store.Append(ctx, cqrs.CommandRecord{CommandID: "c2", CommandType: "LeaveGuild", UserID: "player-1", IssuedAt: now.Add(-time.Minute), Success: true}))
+	require.NoError(t, store.Append(ctx, cqrs.CommandRecord{CommandID: "c3", CommandType: "InviteMember", UserID: "player-2", IssuedAt: now.Add(-time.Minute)}))
+	handler := CommandHistoryHandler(store)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/commands?user_id=player-1&since=1h", nil))
+	invalid := httptest.NewRecorder()
+	handler.ServeHTTP(invalid, httptest.NewRequest(http.MethodGet, "/commands?since=yesterday", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var body struct {
+		Count    int                  `json:"count"`
+		Commands []cqrs.CommandRecord `json:"commands"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Count)
+	assert.Equal(t, "c2", body.Commands[0].CommandID)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestParseCommandHistoryQuery_ReturnsValidationErrors(t *testing.T) {
+	for _, rawQuery := range []string{"to=today", "from=today", "since=yesterday", "failed=maybe", "limit=ten"} {
+		// Act
+		_, err := parseCommandHistoryQuery(httptest.NewRequest(http.MethodGet, "/commands?"+rawQuery, nil))
+
+		// Assert
+		require.Error(t, err, rawQuery)
+		assert.True(t, cqrs.IsValidationError(err), rawQuery)
+	}
+}
+
+func TestCommandHistoryFilter(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	query := cqrs.CommandHistoryQuery{UserID: "player-1", CommandType: "InviteMember", FailedOnly: true, From: from}
+
+	// Act
+	filter := commandHistoryFilter(query)
+
+	// Assert
+	assert.Equal(t, bson.M{
+		"user_id":      "player-1",
+		"command_type": "InviteMember",
+		"success":      false,
+		"issued_at":    bson.M{"$gte": from},
+	}, filter)
+}
@@ -0,0 +1,247 @@
+package cqrsx
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// MongoConnectionState represents the observed state of the MongoDB connection
+type MongoConnectionState int32
+
+const (
+	MongoStateConnecting MongoConnectionState = iota
+	MongoStateConnected
+	MongoStateReconnecting
+	MongoStateDisconnected
+	MongoStateClosed
+)
+
+// String returns the string representation of the connection state
+func (s MongoConnectionState) String() string {
+	switch s {
+	case MongoStateConnecting:
+		return "connecting"
+	case MongoStateConnected:
+		return "connected"
+	case MongoStateReconnecting:
+		return "reconnecting"
+	case MongoStateDisconnected:
+		return "disconnected"
+	case MongoStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// Health status values shared with the serverapp health framework
+const (
+	MongoHealthHealthy   = "healthy"
+	MongoHealthDegraded  = "degraded"
+	MongoHealthUnhealthy = "unhealthy"
+)
+
+// MongoHealthStatus describes MongoDB connectivity in the same shape as serverapp.HealthStatus
+type MongoHealthStatus struct {
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// mongoConnectionMonitor tracks connection state and drives reconnect backoff
+type mongoConnectionMonitor struct {
+	state             atomic.Int32
+	consecutiveErrors atomic.Int64
+	reconnectCount    atomic.Int64
+
+	mu          sync.RWMutex
+	lastSuccess time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newMongoConnectionMonitor() *mongoConnectionMonitor {
+	m := &mongoConnectionMonitor{stopCh: make(chan struct{})}
+	m.state.Store(int32(MongoStateConnecting))
+	return m
+}
+
+func (m *mongoConnectionMonitor) getState() MongoConnectionState {
+	return MongoConnectionState(m.state.Load())
+}
+
+func (m *mongoConnectionMonitor) recordSuccess() {
+	m.mu.Lock()
+	m.lastSuccess = time.Now()
+	m.mu.Unlock()
+
+	if prev := m.getState(); prev == MongoStateReconnecting || prev == MongoStateDisconnected {
+		m.reconnectCount.Add(1)
+	}
+	m.consecutiveErrors.Store(0)
+	m.state.Store(int32(MongoStateConnected))
+}
+
+func (m *mongoConnectionMonitor) recordFailure() {
+	m.consecutiveErrors.Add(1)
+	if m.getState() != MongoStateClosed {
+		m.state.Store(int32(MongoStateReconnecting))
+	}
+}
+
+func (m *mongoConnectionMonitor) stop() {
+	m.stopOnce.Do(func() {
+		m.state.Store(int32(MongoStateClosed))
+		close(m.stopCh)
+	})
+	m.wg.Wait()
+}
+
+// reconnectBackoff returns the exponential backoff delay for the given attempt (0-based)
+func reconnectBackoff(config *MongoConfig, attempt int) time.Duration {
+	delay := config.ReconnectBackoff
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= config.MaxReconnectBackoff {
+			return config.MaxReconnectBackoff
+		}
+	}
+	return delay
+}
+
+// poolMonitor keeps ConnectionCount in sync with the driver's connection pool
+func (mm *MongoClientManager) poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				atomic.AddInt64(&mm.metrics.ConnectionCount, 1)
+			case event.ConnectionClosed:
+				atomic.AddInt64(&mm.metrics.ConnectionCount, -1)
+			}
+		},
+	}
+}
+
+// serverMonitor reflects driver heartbeat results into the connection state
+func (mm *MongoClientManager) serverMonitor() *event.ServerMonitor {
+	return &event.ServerMonitor{
+		ServerHeartbeatSucceeded: func(evt *event.ServerHeartbeatSucceededEvent) {
+			mm.monitor.recordSuccess()
+		},
+		ServerHeartbeatFailed: func(evt *event.ServerHeartbeatFailedEvent) {
+			mm.monitor.recordFailure()
+		},
+	}
+}
+
+// startHealthMonitor periodically pings MongoDB, backing off while the server is unreachable
+func (mm *MongoClientManager) startHealthMonitor() {
+	if mm.config.HealthCheckInterval <= 0 {
+		return
+	}
+
+	mm.monitor.wg.Add(1)
+	go func() {
+		defer mm.monitor.wg.Done()
+
+		attempt := 0
+		wait := mm.config.HealthCheckInterval
+		for {
+			select {
+			case <-mm.monitor.stopCh:
+				return
+			case <-time.After(wait):
+			}
+
+			if err := mm.probe(); err != nil {
+				wait = reconnectBackoff(mm.config, attempt)
+				attempt++
+				continue
+			}
+
+			attempt = 0
+			wait = mm.config.HealthCheckInterval
+		}
+	}()
+}
+
+// probe pings the primary once and records the result
+func (mm *MongoClientManager) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), mm.config.ServerSelectionTimeout)
+	defer cancel()
+
+	if err := mm.client.Ping(ctx, readpref.Primary()); err != nil {
+		mm.monitor.recordFailure()
+		return err
+	}
+
+	mm.monitor.recordSuccess()
+	return nil
+}
+
+// ConnectionState returns the last observed MongoDB connection state
+func (mm *MongoClientManager) ConnectionState() MongoConnectionState {
+	return mm.monitor.getState()
+}
+
+// HealthCheck pings MongoDB and reports connectivity for the health framework.
+// A reconnecting client whose last successful contact is recent is reported as degraded.
+func (mm *MongoClientManager) HealthCheck(ctx context.Context) MongoHealthStatus {
+	if mm.monitor.getState() == MongoStateClosed {
+		return MongoHealthStatus{
+			Status:  MongoHealthUnhealthy,
+			Message: "MongoDB client closed",
+			Details: map[string]string{"state": MongoStateClosed.String()},
+		}
+	}
+
+	start := time.Now()
+	err := mm.client.Ping(ctx, readpref.Primary())
+	latency := time.Since(start)
+	if err != nil {
+		mm.monitor.recordFailure()
+	} else {
+		mm.monitor.recordSuccess()
+	}
+
+	mm.monitor.mu.RLock()
+	lastSuccess := mm.monitor.lastSuccess
+	mm.monitor.mu.RUnlock()
+
+	details := map[string]string{
+		"state":              mm.monitor.getState().String(),
+		"database":           mm.config.Database,
+		"latency":            latency.String(),
+		"connections":        strconv.FormatInt(atomic.LoadInt64(&mm.metrics.ConnectionCount), 10),
+		"consecutive_errors": strconv.FormatInt(mm.monitor.consecutiveErrors.Load(), 10),
+		"reconnects":         strconv.FormatInt(mm.monitor.reconnectCount.Load(), 10),
+	}
+	if !lastSuccess.IsZero() {
+		details["last_success"] = lastSuccess.Format(time.RFC3339)
+	}
+
+	if err != nil {
+		details["error"] = err.Error()
+		status := MongoHealthUnhealthy
+		if !lastSuccess.IsZero() && time.Since(lastSuccess) < mm.config.ServerSelectionTimeout {
+			status = MongoHealthDegraded
+		}
+		return MongoHealthStatus{Status: status, Message: "MongoDB connection failed", Details: details}
+	}
+
+	// Slow responses indicate a degraded connection
+	if latency > 100*time.Millisecond {
+		return MongoHealthStatus{Status: MongoHealthDegraded, Message: "MongoDB connection slow", Details: details}
+	}
+
+	return MongoHealthStatus{Status: MongoHealthHealthy, Message: "MongoDB connection healthy", Details: details}
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // MongoEventStore implements EventStore interface using MongoDB
@@ -18,6 +19,28 @@ type MongoEventStore struct {
 	client         *MongoClientManager
 	collectionName string
 	serializer     EventMarshaler
+	options        *MongoEventStoreOptions
+}
+
+// MongoEventStoreOptions controls batching and consistency of MongoEventStore operations
+type MongoEventStoreOptions struct {
+	BatchSize     int                        // Maximum documents per insertMany call (0 = default 500)
+	WriteConcern  *writeconcern.WriteConcern // Write concern for append transactions (nil = client default)
+	ReadBatchSize int32                      // Documents fetched per cursor round trip (0 = driver default)
+}
+
+// DefaultMongoEventStoreOptions returns the default event store options
+func DefaultMongoEventStoreOptions() *MongoEventStoreOptions {
+	return &MongoEventStoreOptions{
+		BatchSize: 500,
+	}
+}
+
+// EventStreamAppend describes events to append to a single aggregate stream
+type EventStreamAppend struct {
+	AggregateID     string
+	Events          []cqrs.EventMessage
+	ExpectedVersion int // -1 skips the optimistic concurrency check
 }
 
 // MongoEventDocument represents the standard Event Sourcing document schema in MongoDB
@@ -34,16 +57,59 @@ type MongoEventDocument struct {
 	Metadata      map[string]interface{} `bson:"metadata,omitempty"` // Additional metadata
 }
 
+// eventLoadProjection limits reads to the fields needed to rebuild event messages
+var eventLoadProjection = bson.D{
+	{Key: "_id", Value: 0},
+	{Key: "aggregate_id", Value: 1},
+	{Key: "aggregate_type", Value: 1},
+	{Key: "event_id", Value: 1},
+	{Key: "event_type", Value: 1},
+	{Key: "event_data", Value: 1},
+	{Key: "event_version", Value: 1},
+	{Key: "timestamp", Value: 1},
+	{Key: "metadata", Value: 1},
+}
+
+// StoredEvent is an event loaded from MongoEventStore.
+// The event_data document is decoded into Data, since the store has no registry of payload types.
+type StoredEvent struct {
+	*cqrs.BaseEventMessage
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+var _ cqrs.EventMessage = (*StoredEvent)(nil)
+
+// EventData returns the stored payload, or nil for events saved without one
+func (e *StoredEvent) EventData() interface{} {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	return e.Data
+}
+
 // NewMongoEventStore creates a new MongoDB event store with standard schema
 func NewMongoEventStore(client *MongoClientManager, collectionName string) *MongoEventStore {
+	return NewMongoEventStoreWithOptions(client, collectionName, nil)
+}
+
+// NewMongoEventStoreWithOptions creates a new MongoDB event store with batching options
+func NewMongoEventStoreWithOptions(client *MongoClientManager, collectionName string, opts *MongoEventStoreOptions) *MongoEventStore {
 	if collectionName == "" {
 		collectionName = "events" // Standard collection name
 	}
 
+	if opts == nil {
+		opts = DefaultMongoEventStoreOptions()
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultMongoEventStoreOptions().BatchSize
+	}
+
 	return &MongoEventStore{
 		client:         client,
 		collectionName: collectionName,
 		serializer:     &BSONEventMarshaler{},
+		options:        opts,
 	}
 }
 
@@ -53,8 +119,33 @@ func (es *MongoEventStore) SaveEvents(ctx context.Context, aggregateID string, e
 		return nil
 	}
 
-	if aggregateID == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+	return es.SaveEventStreams(ctx, []EventStreamAppend{{
+		AggregateID:     aggregateID,
+		Events:          events,
+		ExpectedVersion: expectedVersion,
+	}})
+}
+
+// SaveEventStreams appends events for several aggregates in a single transaction.
+// Documents are written with ordered insertMany batches so every stream keeps its event order.
+// Each aggregate may appear in only one stream: two appends for the same aggregate would both
+// pass the version check inside the transaction and then write the same event versions.
+func (es *MongoEventStore) SaveEventStreams(ctx context.Context, streams []EventStreamAppend) error {
+	seen := make(map[string]struct{}, len(streams))
+	for _, stream := range streams {
+		if stream.AggregateID == "" {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+		}
+		if len(stream.Events) == 0 {
+			continue
+		}
+
+		key := stream.Events[0].AggregateType() + "/" + stream.AggregateID
+		if _, duplicate := seen[key]; duplicate {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+				fmt.Sprintf("aggregate %s appears in more than one stream; append its events in a single stream", key), nil).WithCategory(cqrs.CategoryValidation)
+		}
+		seen[key] = struct{}{}
 	}
 
 	collection := es.client.GetCollection(es.collectionName)
@@ -68,157 +159,245 @@ func (es *MongoEventStore) SaveEvents(ctx context.Context, aggregateID string, e
 		}
 		defer session.EndSession(ctx)
 
+		txnOpts := options.Transaction()
+		if es.options.WriteConcern != nil {
+			txnOpts.SetWriteConcern(es.options.WriteConcern)
+		}
+
 		// Execute transaction
 		_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-			// Check current version for optimistic concurrency control
-			if expectedVersion >= 0 {
-				currentVersion, err := es.getLastEventVersion(sessCtx, aggregateID, events[0].AggregateType())
-				if err != nil {
-					return nil, err
-				}
+			var documents []interface{}
 
-				if currentVersion != expectedVersion {
-					return nil, cqrs.NewCQRSError(cqrs.ErrCodeConcurrencyConflict.String(),
-						fmt.Sprintf("concurrency conflict: expected version %d, got %d", expectedVersion, currentVersion), cqrs.ErrConcurrencyConflict)
+			for _, stream := range streams {
+				if len(stream.Events) == 0 {
+					continue
 				}
-			}
 
-			// Convert events to MongoDB documents using standard schema
-			documents := make([]interface{}, len(events))
-			for i, event := range events {
-				// Serialize event data properly
-				var eventDataBytes []byte
-				var err error
-
-				// Handle different data types properly
-				eventData := event.EventData()
-				if eventData != nil {
-					eventDataBytes, err = bson.Marshal(eventData)
+				// Check current version for optimistic concurrency control
+				if stream.ExpectedVersion >= 0 {
+					currentVersion, err := es.getLastEventVersion(sessCtx, stream.AggregateID, stream.Events[0].AggregateType())
 					if err != nil {
-						return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-							fmt.Sprintf("failed to serialize event data: %v", err), err)
+						return nil, err
 					}
-				} else {
-					// Handle nil event data
-					eventDataBytes, _ = bson.Marshal(bson.M{})
-				}
 
-				// Standard Event Sourcing document structure - no duplication
-				doc := MongoEventDocument{
-					AggregateID:   aggregateID,
-					AggregateType: event.AggregateType(),
-					EventID:       event.EventID(),
-					EventType:     event.EventType(),
-					EventData:     bson.Raw(eventDataBytes), // Only store the actual event data
-					EventVersion:  expectedVersion + i + 1,
-					Timestamp:     event.Timestamp(),
-					Metadata:      event.Metadata(),
+					if currentVersion != stream.ExpectedVersion {
+						return nil, cqrs.NewCQRSError(cqrs.ErrCodeConcurrencyConflict.String(),
+							fmt.Sprintf("concurrency conflict on %s: expected version %d, got %d", stream.AggregateID, stream.ExpectedVersion, currentVersion), cqrs.ErrConcurrencyConflict)
+					}
 				}
 
-				documents[i] = doc
+				streamDocs, err := buildEventDocuments(stream)
+				if err != nil {
+					return nil, err
+				}
+				documents = append(documents, streamDocs...)
 			}
 
-			// Insert events atomically
-			_, err = collection.InsertMany(sessCtx, documents)
-			if err != nil {
-				return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-					fmt.Sprintf("failed to insert events: %v", err), err)
+			// Insert events atomically in ordered batches
+			insertOpts := options.InsertMany().SetOrdered(true)
+			for start := 0; start < len(documents); start += es.options.BatchSize {
+				end := start + es.options.BatchSize
+				if end > len(documents) {
+					end = len(documents)
+				}
+
+				if _, err := collection.InsertMany(sessCtx, documents[start:end], insertOpts); err != nil {
+					return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+						fmt.Sprintf("failed to insert events: %v", err), err)
+				}
 			}
 
 			return nil, nil
-		})
+		}, txnOpts)
 
 		return err
 	})
 }
 
+// buildEventDocuments converts a stream append into MongoDB documents using the standard schema
+func buildEventDocuments(stream EventStreamAppend) ([]interface{}, error) {
+	baseVersion := stream.ExpectedVersion
+	if baseVersion < 0 {
+		baseVersion = 0
+	}
+
+	documents := make([]interface{}, len(stream.Events))
+	for i, event := range stream.Events {
+		// Serialize event data properly
+		var eventDataBytes []byte
+		var err error
+
+		// Handle different data types properly
+		eventData := event.EventData()
+		if eventData != nil {
+			eventDataBytes, err = bson.Marshal(eventData)
+			if err != nil {
+				return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+					fmt.Sprintf("failed to serialize event data: %v", err), err)
+			}
+		} else {
+			// Handle nil event data
+			eventDataBytes, _ = bson.Marshal(bson.M{})
+		}
+
+		// Standard Event Sourcing document structure - no duplication
+		documents[i] = MongoEventDocument{
+			AggregateID:   stream.AggregateID,
+			AggregateType: event.AggregateType(),
+			EventID:       event.EventID(),
+			EventType:     event.EventType(),
+			EventData:     bson.Raw(eventDataBytes), // Only store the actual event data
+			EventVersion:  baseVersion + i + 1,
+			Timestamp:     event.Timestamp(),
+			Metadata:      event.Metadata(),
+		}
+	}
+
+	return documents, nil
+}
+
 // LoadEvents loads events from MongoDB using standard Event Sourcing queries
 func (es *MongoEventStore) LoadEvents(ctx context.Context, aggregateID string, aggregateType string, fromVersion, toVersion int) ([]cqrs.EventMessage, error) {
 	if aggregateID == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
-	collection := es.client.GetCollection(es.collectionName)
-	var events []cqrs.EventMessage
+	// Build filter using standard Event Sourcing query pattern
+	filter := bson.M{
+		"aggregate_id":   aggregateID,
+		"aggregate_type": aggregateType,
+	}
 
-	err := es.client.ExecuteCommand(ctx, func() error {
-		// Build filter using standard Event Sourcing query pattern
-		filter := bson.M{
-			"aggregate_id":   aggregateID,
-			"aggregate_type": aggregateType,
+	if fromVersion > 0 || toVersion > 0 {
+		versionFilter := bson.M{}
+		if fromVersion > 0 {
+			versionFilter["$gte"] = fromVersion
 		}
-
-		if fromVersion > 0 || toVersion > 0 {
-			versionFilter := bson.M{}
-			if fromVersion > 0 {
-				versionFilter["$gte"] = fromVersion
-			}
-			if toVersion > 0 {
-				versionFilter["$lte"] = toVersion
-			}
-			filter["event_version"] = versionFilter
+		if toVersion > 0 {
+			versionFilter["$lte"] = toVersion
 		}
+		filter["event_version"] = versionFilter
+	}
 
+	var events []cqrs.EventMessage
+	err := es.client.ExecuteCommand(ctx, func() error {
 		// Sort by event version (standard Event Sourcing ordering)
-		opts := options.Find().SetSort(bson.D{{Key: "event_version", Value: 1}})
+		return es.findEvents(ctx, filter, bson.D{{Key: "event_version", Value: 1}}, func(event cqrs.EventMessage) {
+			events = append(events, event)
+		})
+	})
 
-		// Find events
-		cursor, err := collection.Find(ctx, filter, opts)
-		if err != nil {
-			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-				fmt.Sprintf("failed to find events: %v", err), err)
-		}
-		defer cursor.Close(ctx)
+	return events, err
+}
 
-		// Decode events
-		for cursor.Next(ctx) {
-			var doc MongoEventDocument
-			if err := cursor.Decode(&doc); err != nil {
-				return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-					fmt.Sprintf("failed to decode event document: %v", err), err)
-			}
+// LoadEventsForAggregates loads the streams of several aggregates with a single query.
+// Results are keyed by aggregate ID and ordered by event version within each stream.
+func (es *MongoEventStore) LoadEventsForAggregates(ctx context.Context, aggregateType string, aggregateIDs []string) (map[string][]cqrs.EventMessage, error) {
+	if aggregateType == "" {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
 
-			// Deserialize only the event data
-			var eventData interface{}
-			if err := bson.Unmarshal(doc.EventData, &eventData); err != nil {
-				return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-					fmt.Sprintf("failed to deserialize event data: %v", err), err)
-			}
+	result := make(map[string][]cqrs.EventMessage, len(aggregateIDs))
+	if len(aggregateIDs) == 0 {
+		return result, nil
+	}
 
-			// Reconstruct the event message
-			event := cqrs.NewBaseEventMessage(doc.EventType)
+	filter := bson.M{
+		"aggregate_id":   bson.M{"$in": aggregateIDs},
+		"aggregate_type": aggregateType,
+	}
+	sort := bson.D{
+		{Key: "aggregate_id", Value: 1},
+		{Key: "event_version", Value: 1},
+	}
 
-			// Set metadata
-			for key, value := range doc.Metadata {
-				event.AddMetadata(key, value)
-			}
+	err := es.client.ExecuteCommand(ctx, func() error {
+		return es.findEvents(ctx, filter, sort, func(event cqrs.EventMessage) {
+			result[event.AggregateID()] = append(result[event.AggregateID()], event)
+		})
+	})
 
-			events = append(events, event)
-		}
+	return result, err
+}
 
-		if err := cursor.Err(); err != nil {
+// findEvents runs a projected, batched find and passes each rebuilt event to collect
+func (es *MongoEventStore) findEvents(ctx context.Context, filter interface{}, sort bson.D, collect func(cqrs.EventMessage)) error {
+	collection := es.client.GetCollection(es.collectionName)
+
+	opts := options.Find().
+		SetSort(sort).
+		SetProjection(eventLoadProjection)
+	if es.options.ReadBatchSize > 0 {
+		opts.SetBatchSize(es.options.ReadBatchSize)
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+			fmt.Sprintf("failed to find events: %v", err), err)
+	}
+	defer cursor.Close(ctx)
+
+	// Decode events
+	for cursor.Next(ctx) {
+		var doc MongoEventDocument
+		if err := cursor.Decode(&doc); err != nil {
 			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
-				fmt.Sprintf("cursor error: %v", err), err)
+				fmt.Sprintf("failed to decode event document: %v", err), err)
 		}
 
-		return nil
-	})
+		event, err := eventFromDocument(&doc)
+		if err != nil {
+			return err
+		}
+		collect(event)
+	}
 
-	return events, err
+	if err := cursor.Err(); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+			fmt.Sprintf("cursor error: %v", err), err)
+	}
+
+	return nil
+}
+
+// eventFromDocument reconstructs the event message and its payload from a stored document
+func eventFromDocument(doc *MongoEventDocument) (*StoredEvent, error) {
+	event := cqrs.NewBaseEventMessage(doc.EventType)
+	event.EventID_ = doc.EventID
+	event.AggregateID_ = doc.AggregateID
+	event.AggregateType_ = doc.AggregateType
+	event.Version_ = doc.EventVersion
+	event.Timestamp_ = doc.Timestamp
+
+	// Set metadata
+	for key, value := range doc.Metadata {
+		event.AddMetadata(key, value)
+	}
+
+	stored := &StoredEvent{BaseEventMessage: event}
+	if len(doc.EventData) > 0 {
+		if err := bson.Unmarshal(doc.EventData, &stored.Data); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+				fmt.Sprintf("failed to decode payload of event %s: %v", doc.EventID, err), err)
+		}
+	}
+
+	return stored, nil
 }
 
 // GetEventHistory retrieves event history for an aggregate (standard Event Sourcing operation)
 func (es *MongoEventStore) GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
 	if aggregateID == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	return es.LoadEvents(ctx, aggregateID, aggregateType, fromVersion, 0)
@@ -227,11 +406,11 @@ func (es *MongoEventStore) GetEventHistory(ctx context.Context, aggregateID stri
 // GetLastEventVersion gets the last event version for an aggregate (standard Event Sourcing query)
 func (es *MongoEventStore) GetLastEventVersion(ctx context.Context, aggregateID string, aggregateType string) (int, error) {
 	if aggregateID == "" {
-		return -1, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return -1, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return -1, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return -1, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	return es.getLastEventVersion(ctx, aggregateID, aggregateType)
@@ -265,11 +444,11 @@ func (es *MongoEventStore) getLastEventVersion(ctx context.Context, aggregateID
 // CompactEvents removes old events (standard Event Sourcing maintenance operation)
 func (es *MongoEventStore) CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error {
 	if aggregateID == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := es.client.GetCollection(es.collectionName)
@@ -292,10 +471,192 @@ func (es *MongoEventStore) CompactEvents(ctx context.Context, aggregateID, aggre
 	})
 }
 
+// ListAggregates lists aggregate streams of a type, most recently updated first.
+// An empty aggregate type lists streams of all types.
+func (es *MongoEventStore) ListAggregates(ctx context.Context, aggregateType string, limit, offset int) ([]AggregateStreamInfo, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	collection := es.client.GetCollection(es.collectionName)
+	var streams []AggregateStreamInfo
+
+	err := es.client.ExecuteCommand(ctx, func() error {
+		match := bson.M{}
+		if aggregateType != "" {
+			match["aggregate_type"] = aggregateType
+		}
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: match}},
+			{{Key: "$group", Value: bson.M{
+				"_id":            bson.M{"aggregate_id": "$aggregate_id", "aggregate_type": "$aggregate_type"},
+				"version":        bson.M{"$max": "$event_version"},
+				"event_count":    bson.M{"$sum": 1},
+				"last_event_at":  bson.M{"$max": "$timestamp"},
+				"first_event_at": bson.M{"$min": "$timestamp"},
+			}}},
+			{{Key: "$sort", Value: bson.D{{Key: "last_event_at", Value: -1}}}},
+			{{Key: "$skip", Value: int64(offset)}},
+			{{Key: "$limit", Value: int64(limit)}},
+		}
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+				fmt.Sprintf("failed to list aggregates: %v", err), err)
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var row struct {
+				ID struct {
+					AggregateID   string `bson:"aggregate_id"`
+					AggregateType string `bson:"aggregate_type"`
+				} `bson:"_id"`
+				Version      int       `bson:"version"`
+				EventCount   int       `bson:"event_count"`
+				LastEventAt  time.Time `bson:"last_event_at"`
+				FirstEventAt time.Time `bson:"first_event_at"`
+			}
+			if err := cursor.Decode(&row); err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+					fmt.Sprintf("failed to decode aggregate stream: %v", err), err)
+			}
+
+			streams = append(streams, AggregateStreamInfo{
+				AggregateID:   row.ID.AggregateID,
+				AggregateType: row.ID.AggregateType,
+				Version:       row.Version,
+				EventCount:    row.EventCount,
+				FirstEventAt:  row.FirstEventAt,
+				LastEventAt:   row.LastEventAt,
+			})
+		}
+
+		return cursor.Err()
+	})
+
+	return streams, err
+}
+
+// ExportEvents streams raw event documents matching the filter in timestamp order.
+// Payloads are decoded generically, so exporting does not require registered event types.
+func (es *MongoEventStore) ExportEvents(ctx context.Context, filter EventExportFilter, fn func(*ExportedEvent) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	query := bson.M{}
+	if len(filter.EventTypes) > 0 {
+		query["event_type"] = bson.M{"$in": filter.EventTypes}
+	}
+	if filter.AggregateType != "" {
+		query["aggregate_type"] = filter.AggregateType
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		timeRange := bson.M{}
+		if !filter.From.IsZero() {
+			timeRange["$gte"] = filter.From
+		}
+		if !filter.To.IsZero() {
+			timeRange["$lt"] = filter.To
+		}
+		query["timestamp"] = timeRange
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	if es.options.ReadBatchSize > 0 {
+		opts.SetBatchSize(es.options.ReadBatchSize)
+	}
+
+	collection := es.client.GetCollection(es.collectionName)
+
+	return es.client.ExecuteCommand(ctx, func() error {
+		cursor, err := collection.Find(ctx, query, opts)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+				fmt.Sprintf("failed to export events: %v", err), err)
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var doc MongoEventDocument
+			if err := cursor.Decode(&doc); err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+					fmt.Sprintf("failed to decode event document: %v", err), err)
+			}
+
+			exported := &ExportedEvent{
+				EventID:       doc.EventID,
+				EventType:     doc.EventType,
+				AggregateID:   doc.AggregateID,
+				AggregateType: doc.AggregateType,
+				Version:       doc.EventVersion,
+				Timestamp:     doc.Timestamp,
+				Metadata:      doc.Metadata,
+			}
+			if len(doc.EventData) > 0 {
+				var data bson.M
+				if err := bson.Unmarshal(doc.EventData, &data); err != nil {
+					return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+						fmt.Sprintf("failed to decode payload of event %s: %v", doc.EventID, err), err)
+				}
+				exported.Data = data
+			}
+
+			if err := fn(exported); err != nil {
+				return err
+			}
+		}
+
+		return cursor.Err()
+	})
+}
+
+// RewriteEventPayload replaces the payload and metadata of one stored event, keeping its
+// identity, version and timestamp. Events are otherwise immutable; this exists for legal
+// obligations such as erasing personal data, not for fixing domain mistakes (use correction events).
+func (es *MongoEventStore) RewriteEventPayload(ctx context.Context, eventID string, data, metadata map[string]interface{}) error {
+	if eventID == "" {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "event ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	payload, err := bson.Marshal(bson.M(data))
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to encode payload of event %s: %v", eventID, err), err)
+	}
+
+	collection := es.client.GetCollection(es.collectionName)
+
+	return es.client.ExecuteCommand(ctx, func() error {
+		update := bson.M{"$set": bson.M{"event_data": bson.Raw(payload), "metadata": metadata}}
+		result, err := collection.UpdateOne(ctx, bson.M{"event_id": eventID}, update)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+				fmt.Sprintf("failed to rewrite event %s: %v", eventID, err), err)
+		}
+		if result.MatchedCount == 0 {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(),
+				fmt.Sprintf("event %s not found", eventID), nil).WithCategory(cqrs.CategoryNotFound)
+		}
+		return nil
+	})
+}
+
 // GetEventsByType gets events by event type (useful for projections)
 func (es *MongoEventStore) GetEventsByType(ctx context.Context, eventType string, fromTimestamp time.Time, limit int) ([]cqrs.EventMessage, error) {
 	if eventType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "event type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "event type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := es.client.GetCollection(es.collectionName)
@@ -325,20 +686,11 @@ func (es *MongoEventStore) GetEventsByType(ctx context.Context, eventType string
 				continue // Skip failed decodes
 			}
 
-			// Deserialize only the event data
-			var eventData interface{}
-			if err := bson.Unmarshal(doc.EventData, &eventData); err != nil {
+			event, err := eventFromDocument(&doc)
+			if err != nil {
 				continue // Skip failed deserializations
 			}
 
-			// Reconstruct the event message
-			event := cqrs.NewBaseEventMessage(doc.EventType)
-
-			// Set metadata
-			for key, value := range doc.Metadata {
-				event.AddMetadata(key, value)
-			}
-
 			events = append(events, event)
 		}
 
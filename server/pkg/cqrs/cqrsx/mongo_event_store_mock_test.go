@@ -0,0 +1,201 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// newMockEventStore mtest 모의 배포에 연결된 이벤트 저장소 (MongoDB 서버 없이 보낸 명령을 확인)
+func newMockEventStore(mt *mtest.T, opts *MongoEventStoreOptions) *MongoEventStore {
+	manager := &MongoClientManager{
+		client:   mt.Client,
+		database: mt.DB,
+		metrics:  &MongoMetrics{DatabaseName: mt.DB.Name()},
+		monitor:  newMongoConnectionMonitor(),
+	}
+	return NewMongoEventStoreWithOptions(manager, mt.Coll.Name(), opts)
+}
+
+// storedEventDocument 모의 find 응답에 넣을 이벤트 문서
+func storedEventDocument(aggregateID string, version int, data bson.D) bson.D {
+	return bson.D{
+		{Key: "aggregate_id", Value: aggregateID},
+		{Key: "aggregate_type", Value: "Guild"},
+		{Key: "event_id", Value: fmt.Sprintf("%s-%d", aggregateID, version)},
+		{Key: "event_type", Value: "MemberJoined"},
+		{Key: "event_data", Value: data},
+		{Key: "event_version", Value: version},
+		{Key: "timestamp", Value: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestMongoEventStore_LoadEventsAttachesPayload(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("payload", func(mt *mtest.T) {
+		// Arrange
+		store := newMockEventStore(mt, nil)
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			storedEventDocument("guild-1", 1, bson.D{{Key: "member", Value: "scout"}, {Key: "level", Value: int32(3)}}),
+			storedEventDocument("guild-1", 2, bson.D{}),
+		))
+
+		// Act
+		events, err := store.LoadEvents(context.Background(), "guild-1", "Guild", 0, 0)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, map[string]interface{}{"member": "scout", "level": int32(3)}, events[0].EventData())
+		assert.Equal(t, "guild-1", events[0].AggregateID())
+		assert.Equal(t, 1, events[0].Version())
+		assert.Nil(t, events[1].EventData()) // 데이터 없이 저장된 이벤트
+
+		started := mt.GetStartedEvent()
+		require.NotNil(t, started)
+		projection := started.Command.Lookup("projection").Document()
+		assert.Equal(t, int32(1), projection.Lookup("event_data").Int32())
+		assert.Equal(t, int32(0), projection.Lookup("_id").Int32())
+	})
+
+	mt.Run("corrupt payload", func(mt *mtest.T) {
+		// Arrange - event_data가 문서가 아닌 값으로 저장된 경우
+		store := newMockEventStore(mt, nil)
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		corrupt := storedEventDocument("guild-1", 1, nil)
+		corrupt[4].Value = "not a document"
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, corrupt))
+
+		// Act
+		_, err := store.LoadEvents(context.Background(), "guild-1", "Guild", 0, 0)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+// guildEvents 저장할 Guild 이벤트 count개
+func guildEvents(count int) []cqrs.EventMessage {
+	events := make([]cqrs.EventMessage, count)
+	for i := range events {
+		event := cqrs.NewBaseEventMessage("MemberJoined")
+		event.AggregateType_ = "Guild"
+		events[i] = event
+	}
+	return events
+}
+
+func TestMongoEventStore_SaveEventStreamsBatchesOrderedInserts(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("batches", func(mt *mtest.T) {
+		// Arrange
+		store := newMockEventStore(mt, &MongoEventStoreOptions{BatchSize: 2, WriteConcern: writeconcern.W1()})
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch), // guild-1 현재 버전 조회 (이벤트 없음)
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(), // commitTransaction
+		)
+
+		// Act
+		err := store.SaveEventStreams(context.Background(), []EventStreamAppend{
+			{AggregateID: "guild-1", Events: guildEvents(3), ExpectedVersion: 0},
+			{AggregateID: "guild-2", Events: guildEvents(2), ExpectedVersion: -1},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		var commands []string
+		var written []string
+		for _, started := range mt.GetAllStartedEvents() {
+			commands = append(commands, started.CommandName)
+			if started.CommandName != "insert" {
+				continue
+			}
+			assert.True(t, started.Command.Lookup("ordered").Boolean())
+			documents, err := started.Command.Lookup("documents").Array().Values()
+			require.NoError(t, err)
+			batch := ""
+			for _, document := range documents {
+				doc := document.Document()
+				batch += fmt.Sprintf("%s@%d ", doc.Lookup("aggregate_id").StringValue(), doc.Lookup("event_version").Int32())
+			}
+			written = append(written, batch)
+		}
+		assert.Equal(t, []string{"find", "insert", "insert", "insert", "commitTransaction"}, commands)
+		// 배치 경계를 넘어도 스트림 순서와 버전이 유지됨
+		assert.Equal(t, []string{"guild-1@1 guild-1@2 ", "guild-1@3 guild-2@1 ", "guild-2@2 "}, written)
+
+		commit := mt.GetAllStartedEvents()[4]
+		assert.Equal(t, int32(1), commit.Command.Lookup("writeConcern", "w").Int32())
+	})
+
+	mt.Run("duplicate aggregate", func(mt *mtest.T) {
+		// Arrange
+		store := newMockEventStore(mt, nil)
+
+		// Act - 같은 집합체를 두 스트림으로 나누면 둘 다 버전 검사를 통과해 같은 버전을 쓰게 됨
+		err := store.SaveEventStreams(context.Background(), []EventStreamAppend{
+			{AggregateID: "guild-1", Events: guildEvents(1), ExpectedVersion: 0},
+			{AggregateID: "guild-2", Events: guildEvents(1), ExpectedVersion: 0},
+			{AggregateID: "guild-1", Events: guildEvents(1), ExpectedVersion: 0},
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.True(t, cqrs.IsValidationError(err))
+		assert.Empty(t, mt.GetAllStartedEvents()) // 트랜잭션을 시작하기 전에 거부
+	})
+}
+
+func TestMongoEventStore_LoadEventsForAggregatesProjectsReads(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("projected", func(mt *mtest.T) {
+		// Arrange
+		store := newMockEventStore(mt, &MongoEventStoreOptions{ReadBatchSize: 100})
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			storedEventDocument("guild-1", 1, bson.D{{Key: "member", Value: "scout"}}),
+			storedEventDocument("guild-1", 2, bson.D{{Key: "member", Value: "medic"}}),
+			storedEventDocument("guild-2", 1, bson.D{{Key: "member", Value: "tank"}}),
+		))
+
+		// Act
+		streams, err := store.LoadEventsForAggregates(context.Background(), "Guild", []string{"guild-1", "guild-2", "guild-3"})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, streams["guild-1"], 2)
+		require.Len(t, streams["guild-2"], 1)
+		assert.Empty(t, streams["guild-3"])
+		assert.Equal(t, 2, streams["guild-1"][1].Version())
+		assert.Equal(t, map[string]interface{}{"member": "tank"}, streams["guild-2"][0].EventData())
+
+		started := mt.GetStartedEvent()
+		require.NotNil(t, started)
+		assert.Equal(t, "find", started.CommandName)
+		assert.Equal(t, int32(100), started.Command.Lookup("batchSize").Int32())
+		ids, err := started.Command.Lookup("filter", "aggregate_id", "$in").Array().Values()
+		require.NoError(t, err)
+		assert.Len(t, ids, 3) // 집합체마다 따로 조회하지 않고 한 번에 조회
+		projection := started.Command.Lookup("projection").Document()
+		elements, err := projection.Elements()
+		require.NoError(t, err)
+		assert.Len(t, elements, len(eventLoadProjection))
+		assert.Equal(t, int32(0), projection.Lookup("_id").Int32())
+	})
+}
@@ -0,0 +1,530 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultIgnoredStateFields are bookkeeping fields that legitimately differ between a
+// stored state and the same aggregate rebuilt from events
+var DefaultIgnoredStateFields = []string{"original_version", "changes", "created_at", "updated_at"}
+
+// MongoAggregateStateDocument is the current state of one aggregate in the state collection
+type MongoAggregateStateDocument struct {
+	AggregateID   string                 `bson:"aggregate_id"`
+	AggregateType string                 `bson:"aggregate_type"`
+	Version       int                    `bson:"version"` // Last event version folded into State
+	State         map[string]interface{} `bson:"state"`   // JSON form of the aggregate
+	UpdatedAt     time.Time              `bson:"updated_at"`
+}
+
+// mongoHybridStates is the state collection used by MongoHybridRepository
+type mongoHybridStates interface {
+	upsertState(ctx context.Context, doc MongoAggregateStateDocument) error
+	loadState(ctx context.Context, aggregateType, id string) (*MongoAggregateStateDocument, error)
+	deleteStates(ctx context.Context, aggregateType string, ids []string) error
+	findStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) ([]MongoAggregateStateDocument, error)
+	countStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) (int64, error)
+}
+
+// StateFieldDiff is one field whose stored value differs from the value rebuilt from events
+type StateFieldDiff struct {
+	Path     string      `json:"path"`               // Dotted JSON path, e.g. "items.0.quantity"
+	Expected interface{} `json:"expected,omitempty"` // Rebuilt from events (nil when missing)
+	Actual   interface{} `json:"actual,omitempty"`   // Stored in the state collection (nil when missing)
+}
+
+// StateDiff compares an aggregate's stored state with the state rebuilt from its events
+type StateDiff struct {
+	AggregateID   string           `json:"aggregate_id"`
+	AggregateType string           `json:"aggregate_type"`
+	EventVersion  int              `json:"event_version"`
+	StateVersion  int              `json:"state_version"` // -1 when the state document is missing
+	Fields        []StateFieldDiff `json:"fields,omitempty"`
+	Repaired      bool             `json:"repaired,omitempty"`
+}
+
+// Consistent reports whether the stored state matches the events
+func (d *StateDiff) Consistent() bool {
+	return d.EventVersion == d.StateVersion && len(d.Fields) == 0
+}
+
+// String summarizes the diff for logs and error messages
+func (d *StateDiff) String() string {
+	if d.Consistent() {
+		return fmt.Sprintf("%s/%s is consistent at version %d", d.AggregateType, d.AggregateID, d.EventVersion)
+	}
+	paths := make([]string, len(d.Fields))
+	for i, field := range d.Fields {
+		paths[i] = field.Path
+	}
+	return fmt.Sprintf("%s/%s state version %d, event version %d, mismatched fields [%s]",
+		d.AggregateType, d.AggregateID, d.StateVersion, d.EventVersion, strings.Join(paths, ", "))
+}
+
+// MongoHybridRepository implements HybridRepository: events (and snapshots) go through a
+// MongoEventSourcedRepository and remain the source of truth, while the current state of
+// every aggregate is also kept in a state collection for fast loads and FindBy queries.
+//
+// Aggregates are stored in the state collection in their JSON form, so they must marshal
+// their full state (and unmarshal it back) for GetByID and FindBy to return complete
+// aggregates. Concrete types are created through the aggregate registry.
+type MongoHybridRepository struct {
+	*MongoEventSourcedRepository
+	states        mongoHybridStates
+	ignoredFields map[string]bool
+}
+
+// NewMongoHybridRepository creates a hybrid repository over the event store, the optional
+// snapshot store and the state collection (default "aggregate_states")
+func NewMongoHybridRepository(client *MongoClientManager, eventStore *MongoEventStore, snapshotStore *MongoSnapshotStore, aggregateType, stateCollection string) *MongoHybridRepository {
+	if stateCollection == "" {
+		stateCollection = "aggregate_states"
+	}
+	return newMongoHybridRepository(
+		NewMongoEventSourcedRepository(eventStore, snapshotStore, aggregateType),
+		&mongoStateCollection{client: client, collectionName: stateCollection},
+	)
+}
+
+func newMongoHybridRepository(events *MongoEventSourcedRepository, states mongoHybridStates) *MongoHybridRepository {
+	repository := &MongoHybridRepository{MongoEventSourcedRepository: events, states: states}
+	repository.SetIgnoredStateFields(DefaultIgnoredStateFields...)
+	return repository
+}
+
+var _ cqrs.HybridRepository = (*MongoHybridRepository)(nil)
+
+// SetIgnoredStateFields replaces the top-level fields skipped by DiffState
+func (r *MongoHybridRepository) SetIgnoredStateFields(fields ...string) {
+	r.ignoredFields = make(map[string]bool, len(fields))
+	for _, field := range fields {
+		r.ignoredFields[field] = true
+	}
+}
+
+// Save stores the aggregate's new events and then its state. The events are the source of
+// truth: when the state write fails the error is logged rather than returned (retrying
+// would duplicate the events), and the stale state is fixed by SyncStateFromEvents.
+func (r *MongoHybridRepository) Save(ctx context.Context, aggregate cqrs.AggregateRoot, expectedVersion int) error {
+	if len(aggregate.Changes()) == 0 {
+		return nil
+	}
+	if err := r.MongoEventSourcedRepository.Save(ctx, aggregate, expectedVersion); err != nil {
+		return err
+	}
+	if err := r.writeState(ctx, aggregate); err != nil {
+		log.Printf("Failed to update state of %s/%s at version %d, run SyncStateFromEvents: %v",
+			aggregate.Type(), aggregate.ID(), aggregate.Version(), err)
+	}
+	return nil
+}
+
+// GetByID loads the aggregate from the state collection, replaying events only when the
+// aggregate has no stored state yet
+func (r *MongoHybridRepository) GetByID(ctx context.Context, id string) (cqrs.AggregateRoot, error) {
+	doc, err := r.states.loadState(ctx, r.aggregateType, id)
+	if cqrs.IsNotFoundError(err) {
+		return r.MongoEventSourcedRepository.GetByID(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.decodeState(*doc)
+}
+
+// StateBasedRepository implementation. Changes still go through events, so Create and
+// Update require aggregates with uncommitted changes.
+
+func (r *MongoHybridRepository) Create(ctx context.Context, aggregate cqrs.AggregateRoot) error {
+	return r.Save(ctx, aggregate, 0)
+}
+
+func (r *MongoHybridRepository) Update(ctx context.Context, aggregate cqrs.AggregateRoot) error {
+	return r.Save(ctx, aggregate, aggregate.OriginalVersion())
+}
+
+// Delete removes the aggregate's state document; its events are kept for the audit trail
+func (r *MongoHybridRepository) Delete(ctx context.Context, id string) error {
+	return r.states.deleteStates(ctx, r.aggregateType, []string{id})
+}
+
+func (r *MongoHybridRepository) FindBy(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.AggregateRoot, error) {
+	docs, err := r.states.findStates(ctx, r.aggregateType, criteria)
+	if err != nil {
+		return nil, err
+	}
+	aggregates := make([]cqrs.AggregateRoot, 0, len(docs))
+	for _, doc := range docs {
+		aggregate, err := r.decodeState(doc)
+		if err != nil {
+			return nil, err
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates, nil
+}
+
+func (r *MongoHybridRepository) Count(ctx context.Context, criteria cqrs.QueryCriteria) (int64, error) {
+	return r.states.countStates(ctx, r.aggregateType, criteria)
+}
+
+func (r *MongoHybridRepository) SaveBatch(ctx context.Context, aggregates []cqrs.AggregateRoot) error {
+	for _, aggregate := range aggregates {
+		if err := r.Save(ctx, aggregate, aggregate.OriginalVersion()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MongoHybridRepository) DeleteBatch(ctx context.Context, ids []string) error {
+	return r.states.deleteStates(ctx, r.aggregateType, ids)
+}
+
+// HybridRepository implementation
+
+// SyncStateFromEvents rebuilds the aggregate from its full event history (snapshots are
+// skipped, since they may be the inconsistent part) and overwrites the stored state.
+// An aggregate without events loses its stale state document and returns a not found error.
+func (r *MongoHybridRepository) SyncStateFromEvents(ctx context.Context, aggregateID string) error {
+	if aggregateID == "" {
+		return cqrs.NewValidationError("aggregate ID cannot be empty", nil)
+	}
+	rebuilt, err := r.rebuild(ctx, aggregateID)
+	if err != nil {
+		return err
+	}
+	if rebuilt == nil {
+		if err := r.states.deleteStates(ctx, r.aggregateType, []string{aggregateID}); err != nil {
+			return err
+		}
+		return cqrs.NewNotFoundError(fmt.Sprintf("no events for %s/%s", r.aggregateType, aggregateID), cqrs.ErrAggregateNotFound)
+	}
+	return r.writeState(ctx, rebuilt)
+}
+
+// ValidateConsistency returns nil when the stored state matches the events, and otherwise
+// a repository error describing the mismatch with the *StateDiff in its "diff" context
+func (r *MongoHybridRepository) ValidateConsistency(ctx context.Context, aggregateID string) error {
+	diff, err := r.DiffState(ctx, aggregateID)
+	if err != nil {
+		return err
+	}
+	if diff.Consistent() {
+		return nil
+	}
+	return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), diff.String(), nil).WithContext("diff", diff)
+}
+
+// RepairConsistency diffs the aggregate and, when it is inconsistent, resyncs the state
+// from the events. The returned diff describes the state before the repair.
+func (r *MongoHybridRepository) RepairConsistency(ctx context.Context, aggregateID string) (*StateDiff, error) {
+	diff, err := r.DiffState(ctx, aggregateID)
+	if err != nil || diff.Consistent() {
+		return diff, err
+	}
+	if err := r.SyncStateFromEvents(ctx, aggregateID); err != nil && !cqrs.IsNotFoundError(err) {
+		return diff, err
+	}
+	diff.Repaired = true
+	return diff, nil
+}
+
+// DiffState compares the stored state field by field with the state rebuilt from events
+func (r *MongoHybridRepository) DiffState(ctx context.Context, aggregateID string) (*StateDiff, error) {
+	if aggregateID == "" {
+		return nil, cqrs.NewValidationError("aggregate ID cannot be empty", nil)
+	}
+	rebuilt, err := r.rebuild(ctx, aggregateID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := r.states.loadState(ctx, r.aggregateType, aggregateID)
+	if err != nil && !cqrs.IsNotFoundError(err) {
+		return nil, err
+	}
+	if rebuilt == nil && stored == nil {
+		return nil, cqrs.NewNotFoundError(fmt.Sprintf("aggregate not found: %s/%s", r.aggregateType, aggregateID), cqrs.ErrAggregateNotFound)
+	}
+
+	diff := &StateDiff{AggregateID: aggregateID, AggregateType: r.aggregateType, StateVersion: -1}
+	var expected, actual map[string]interface{}
+	if rebuilt != nil {
+		diff.EventVersion = rebuilt.Version()
+		if expected, err = encodeAggregateState(rebuilt); err != nil {
+			return nil, err
+		}
+	}
+	if stored != nil {
+		diff.StateVersion = stored.Version
+		actual = stored.State
+	}
+	diff.Fields = r.diffFields(expected, actual)
+	return diff, nil
+}
+
+// rebuild replays the full event history; it returns nil when the aggregate has no events
+func (r *MongoHybridRepository) rebuild(ctx context.Context, aggregateID string) (cqrs.AggregateRoot, error) {
+	events, err := r.eventStore.GetEventHistory(ctx, aggregateID, r.aggregateType, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return r.replay(aggregateID, nil, events)
+}
+
+func (r *MongoHybridRepository) writeState(ctx context.Context, aggregate cqrs.AggregateRoot) error {
+	state, err := encodeAggregateState(aggregate)
+	if err != nil {
+		return err
+	}
+	return r.states.upsertState(ctx, MongoAggregateStateDocument{
+		AggregateID:   aggregate.ID(),
+		AggregateType: aggregate.Type(),
+		Version:       aggregate.Version(),
+		State:         state,
+		UpdatedAt:     time.Now(),
+	})
+}
+
+func (r *MongoHybridRepository) decodeState(doc MongoAggregateStateDocument) (cqrs.AggregateRoot, error) {
+	var aggregate cqrs.AggregateRoot
+	if r.registry != nil && r.registry.IsRegistered(r.aggregateType) {
+		created, err := r.registry.Create(r.aggregateType, doc.AggregateID)
+		if err != nil {
+			return nil, err
+		}
+		aggregate = created
+	} else {
+		aggregate = cqrs.NewBaseAggregate(doc.AggregateID, r.aggregateType)
+	}
+
+	data, err := json.Marshal(doc.State)
+	if err == nil {
+		err = json.Unmarshal(data, aggregate)
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to decode state of %s/%s", doc.AggregateType, doc.AggregateID), err)
+	}
+	if setter, ok := aggregate.(interface{ SetOriginalVersion(int) }); ok {
+		setter.SetOriginalVersion(doc.Version)
+	}
+	return aggregate, nil
+}
+
+// encodeAggregateState converts an aggregate to its JSON object form
+func encodeAggregateState(aggregate cqrs.AggregateRoot) (map[string]interface{}, error) {
+	data, err := json.Marshal(aggregate)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to encode state of %s/%s", aggregate.Type(), aggregate.ID()), err)
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("state of %s/%s is not a JSON object", aggregate.Type(), aggregate.ID()), err)
+	}
+	return state, nil
+}
+
+// diffFields lists the leaf paths whose values differ, in path order
+func (r *MongoHybridRepository) diffFields(expected, actual map[string]interface{}) []StateFieldDiff {
+	expectedLeaves := make(map[string]interface{})
+	actualLeaves := make(map[string]interface{})
+	flattenState("", normalizeState(expected), expectedLeaves)
+	flattenState("", normalizeState(actual), actualLeaves)
+
+	paths := make(map[string]bool)
+	for path := range expectedLeaves {
+		paths[path] = true
+	}
+	for path := range actualLeaves {
+		paths[path] = true
+	}
+
+	var diffs []StateFieldDiff
+	for path := range paths {
+		if top, _, _ := strings.Cut(path, "."); r.ignoredFields[top] {
+			continue
+		}
+		expectedValue, actualValue := expectedLeaves[path], actualLeaves[path]
+		if !reflect.DeepEqual(expectedValue, actualValue) {
+			diffs = append(diffs, StateFieldDiff{Path: path, Expected: expectedValue, Actual: actualValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+// normalizeState round-trips a state through JSON so values read back from BSON
+// (int32, bson.A, primitive.D ...) compare equal to freshly encoded ones
+func normalizeState(state map[string]interface{}) interface{} {
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return state
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return state
+	}
+	return normalized
+}
+
+func flattenState(prefix string, value interface{}, leaves map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 && prefix != "" {
+			leaves[prefix] = typed
+		}
+		for key, child := range typed {
+			flattenState(join(key), child, leaves)
+		}
+	case []interface{}:
+		if len(typed) == 0 {
+			leaves[prefix] = typed
+		}
+		for i, child := range typed {
+			flattenState(join(fmt.Sprint(i)), child, leaves)
+		}
+	case nil:
+	default:
+		leaves[prefix] = typed
+	}
+}
+
+// mongoStateCollection stores MongoAggregateStateDocuments in a MongoDB collection
+type mongoStateCollection struct {
+	client         *MongoClientManager
+	collectionName string
+}
+
+func (c *mongoStateCollection) upsertState(ctx context.Context, doc MongoAggregateStateDocument) error {
+	collection := c.client.GetCollection(c.collectionName)
+	return c.client.ExecuteCommand(ctx, func() error {
+		filter := bson.M{"aggregate_id": doc.AggregateID, "aggregate_type": doc.AggregateType}
+		_, err := collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to save state: %v", err), err)
+		}
+		return nil
+	})
+}
+
+func (c *mongoStateCollection) loadState(ctx context.Context, aggregateType, id string) (*MongoAggregateStateDocument, error) {
+	collection := c.client.GetCollection(c.collectionName)
+	var doc MongoAggregateStateDocument
+	err := c.client.ExecuteCommand(ctx, func() error {
+		err := collection.FindOne(ctx, bson.M{"aggregate_id": id, "aggregate_type": aggregateType}).Decode(&doc)
+		if err == mongo.ErrNoDocuments {
+			return cqrs.NewNotFoundError(fmt.Sprintf("state not found: %s/%s", aggregateType, id), cqrs.ErrAggregateNotFound)
+		}
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to load state: %v", err), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (c *mongoStateCollection) deleteStates(ctx context.Context, aggregateType string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	collection := c.client.GetCollection(c.collectionName)
+	return c.client.ExecuteCommand(ctx, func() error {
+		_, err := collection.DeleteMany(ctx, bson.M{"aggregate_id": bson.M{"$in": ids}, "aggregate_type": aggregateType})
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to delete state: %v", err), err)
+		}
+		return nil
+	})
+}
+
+func (c *mongoStateCollection) findStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) ([]MongoAggregateStateDocument, error) {
+	collection := c.client.GetCollection(c.collectionName)
+	opts := buildFindOptions(criteria)
+	if criteria.SortBy != "" {
+		direction := 1
+		if criteria.SortOrder == cqrs.Descending {
+			direction = -1
+		}
+		opts.SetSort(bson.D{{Key: stateFieldPath(criteria.SortBy), Value: direction}})
+	}
+
+	var docs []MongoAggregateStateDocument
+	err := c.client.ExecuteCommand(ctx, func() error {
+		cursor, err := collection.Find(ctx, stateFilter(aggregateType, criteria), opts)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to find states: %v", err), err)
+		}
+		return cursor.All(ctx, &docs)
+	})
+	return docs, err
+}
+
+func (c *mongoStateCollection) countStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) (int64, error) {
+	collection := c.client.GetCollection(c.collectionName)
+	var count int64
+	err := c.client.ExecuteCommand(ctx, func() error {
+		var err error
+		count, err = collection.CountDocuments(ctx, stateFilter(aggregateType, criteria))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to count states: %v", err), err)
+		}
+		return nil
+	})
+	return count, err
+}
+
+// stateFilter addresses aggregate fields inside the state document; "id" and "version"
+// use the document metadata
+func stateFilter(aggregateType string, criteria cqrs.QueryCriteria) bson.M {
+	filter := bson.M{"aggregate_type": aggregateType}
+	for field, value := range criteria.Filters {
+		filter[stateFieldPath(field)] = value
+	}
+	return filter
+}
+
+func stateFieldPath(field string) string {
+	switch field {
+	case "id":
+		return "aggregate_id"
+	case "version":
+		return "version"
+	default:
+		return "state." + field
+	}
+}
@@ -0,0 +1,187 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hybridOrder 상태 전체를 JSON으로 저장하는 주문 애그리게이트
+type hybridOrder struct {
+	*cqrs.BaseAggregate
+	Status string
+	Items  int
+}
+
+type hybridOrderJSON struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Status  string `json:"status"`
+	Items   int    `json:"items"`
+}
+
+func (o *hybridOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hybridOrderJSON{ID: o.ID(), Version: o.Version(), Status: o.Status, Items: o.Items})
+}
+
+func (o *hybridOrder) UnmarshalJSON(data []byte) error {
+	var state hybridOrderJSON
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	o.BaseAggregate = cqrs.NewBaseAggregate(state.ID, "Order", cqrs.WithOriginalVersion(state.Version))
+	o.Status, o.Items = state.Status, state.Items
+	return nil
+}
+
+func applyHybridOrderEvent(aggregate cqrs.AggregateRoot, event cqrs.EventMessage) error {
+	order := aggregate.(*hybridOrder)
+	switch event.EventType() {
+	case "OrderPlaced":
+		order.Status = "placed"
+	case "ItemAdded":
+		order.Items++
+	}
+	return nil
+}
+
+// fakeHybridStates 메모리 상태 컬렉션 (failSave면 저장 실패)
+type fakeHybridStates struct {
+	docs     map[string]MongoAggregateStateDocument
+	failSave bool
+}
+
+func (f *fakeHybridStates) upsertState(ctx context.Context, doc MongoAggregateStateDocument) error {
+	if f.failSave {
+		return errors.New("state collection unavailable")
+	}
+	f.docs[doc.AggregateID] = doc
+	return nil
+}
+
+func (f *fakeHybridStates) loadState(ctx context.Context, aggregateType, id string) (*MongoAggregateStateDocument, error) {
+	doc, exists := f.docs[id]
+	if !exists {
+		return nil, cqrs.NewNotFoundError("state not found", cqrs.ErrAggregateNotFound)
+	}
+	return &doc, nil
+}
+
+func (f *fakeHybridStates) deleteStates(ctx context.Context, aggregateType string, ids []string) error {
+	for _, id := range ids {
+		delete(f.docs, id)
+	}
+	return nil
+}
+
+func (f *fakeHybridStates) findStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) ([]MongoAggregateStateDocument, error) {
+	var docs []MongoAggregateStateDocument
+	for _, doc := range f.docs {
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func (f *fakeHybridStates) countStates(ctx context.Context, aggregateType string, criteria cqrs.QueryCriteria) (int64, error) {
+	return int64(len(f.docs)), nil
+}
+
+func newTestHybridRepository() (*MongoHybridRepository, *fakeRepositoryEvents, *fakeHybridStates) {
+	events := &fakeRepositoryEvents{}
+	registry := cqrs.NewAggregateRegistry()
+	registry.MustRegister("Order", func(id string) (cqrs.AggregateRoot, error) {
+		return &hybridOrder{BaseAggregate: cqrs.NewBaseAggregate(id, "Order")}, nil
+	}, cqrs.WithApplyFunc(applyHybridOrderEvent))
+	eventSourced := NewMongoEventSourcedRepository(nil, nil, "Order")
+	eventSourced.eventStore = events
+	eventSourced.SetAggregateRegistry(registry)
+	states := &fakeHybridStates{docs: make(map[string]MongoAggregateStateDocument)}
+	return newMongoHybridRepository(eventSourced, states), events, states
+}
+
+func placeHybridOrder(t *testing.T, repository *MongoHybridRepository, items int) *hybridOrder {
+	t.Helper()
+	order := &hybridOrder{BaseAggregate: cqrs.NewBaseAggregate("order-1", "Order")}
+	for _, eventType := range append([]string{"OrderPlaced"}, make([]string, items)...) {
+		if eventType == "" {
+			eventType = "ItemAdded"
+		}
+		event := cqrs.NewBaseEventMessage(eventType)
+		require.NoError(t, order.ApplyEvent(event))
+		require.NoError(t, applyHybridOrderEvent(order, event))
+	}
+	require.NoError(t, repository.Create(context.Background(), order))
+	return order
+}
+
+func TestMongoHybridRepository_GetByIDReadsState(t *testing.T) {
+	// Arrange
+	repository, events, _ := newTestHybridRepository()
+	placeHybridOrder(t, repository, 2)
+
+	// Act
+	loaded, err := repository.GetByID(context.Background(), "order-1")
+
+	// Assert
+	require.NoError(t, err)
+	order := loaded.(*hybridOrder)
+	assert.Equal(t, "placed", order.Status)
+	assert.Equal(t, 2, order.Items)
+	assert.Equal(t, 3, order.Version())
+	assert.Empty(t, events.loadedFrom) // 이벤트 재생 없이 상태에서 로드
+	assert.NoError(t, repository.ValidateConsistency(context.Background(), "order-1"))
+}
+
+func TestMongoHybridRepository_ValidateConsistencyReportsFieldDiff(t *testing.T) {
+	// Arrange
+	repository, _, states := newTestHybridRepository()
+	placeHybridOrder(t, repository, 2)
+	drifted := states.docs["order-1"]
+	drifted.State["items"] = float64(5)
+	drifted.State["status"] = "cancelled"
+	states.docs["order-1"] = drifted
+
+	// Act
+	validateErr := repository.ValidateConsistency(context.Background(), "order-1")
+	diff, repairErr := repository.RepairConsistency(context.Background(), "order-1")
+	afterRepair := repository.ValidateConsistency(context.Background(), "order-1")
+
+	// Assert
+	var cqrsErr *cqrs.CQRSError
+	require.True(t, errors.As(validateErr, &cqrsErr))
+	assert.Contains(t, validateErr.Error(), "items, status")
+	require.NoError(t, repairErr)
+	assert.True(t, diff.Repaired)
+	assert.Equal(t, []StateFieldDiff{
+		{Path: "items", Expected: float64(2), Actual: float64(5)},
+		{Path: "status", Expected: "placed", Actual: "cancelled"},
+	}, diff.Fields)
+	assert.NoError(t, afterRepair)
+}
+
+func TestMongoHybridRepository_SyncStateAfterFailedStateWrite(t *testing.T) {
+	// Arrange
+	repository, events, states := newTestHybridRepository()
+	states.failSave = true
+	placeHybridOrder(t, repository, 1) // 이벤트는 저장되고 상태 저장만 실패
+	states.failSave = false
+
+	// Act
+	diff, diffErr := repository.DiffState(context.Background(), "order-1")
+	syncErr := repository.SyncStateFromEvents(context.Background(), "order-1")
+
+	// Assert
+	require.NoError(t, diffErr)
+	assert.Equal(t, 2, diff.EventVersion)
+	assert.Equal(t, -1, diff.StateVersion)
+	assert.False(t, diff.Consistent())
+	require.NoError(t, syncErr)
+	assert.Len(t, events.events, 2)
+	assert.Equal(t, 2, states.docs["order-1"].Version)
+	assert.NoError(t, repository.ValidateConsistency(context.Background(), "order-1"))
+}
@@ -0,0 +1,430 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// QueryObserver is notified of every query a read store executes
+type QueryObserver interface {
+	ObserveQuery(criteria cqrs.QueryCriteria, duration time.Duration)
+}
+
+// IndexKey is one field of a (suggested or existing) compound index
+type IndexKey struct {
+	Field     string `json:"field"`
+	Direction int    `json:"direction"` // 1 ascending, -1 descending
+}
+
+// QueryPattern is the shape of a query: which fields are matched exactly, by range, and sorted on.
+// Filter values are ignored so queries differing only in values share a pattern.
+type QueryPattern struct {
+	Equality  []string       `json:"equality,omitempty"`
+	Range     []string       `json:"range,omitempty"`
+	SortBy    string         `json:"sort_by,omitempty"`
+	SortOrder cqrs.SortOrder `json:"sort_order,omitempty"`
+}
+
+// QueryPatternFromCriteria extracts the pattern of criteria, using the same field
+// mapping as MongoReadStore ("type" and "id" address the document metadata).
+// $eq and $in operators count as equality; other operators count as range.
+func QueryPatternFromCriteria(criteria cqrs.QueryCriteria) QueryPattern {
+	pattern := QueryPattern{SortBy: criteria.SortBy, SortOrder: criteria.SortOrder}
+	for field, value := range criteria.Filters {
+		switch field {
+		case "type":
+			field = "model_type"
+		case "id":
+			field = "model_id"
+		}
+		if isRangeFilter(value) {
+			pattern.Range = append(pattern.Range, field)
+		} else {
+			pattern.Equality = append(pattern.Equality, field)
+		}
+	}
+	sort.Strings(pattern.Equality)
+	sort.Strings(pattern.Range)
+	if pattern.SortBy == "" {
+		pattern.SortOrder = cqrs.Ascending
+	}
+	return pattern
+}
+
+func isRangeFilter(value interface{}) bool {
+	var operators []string
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key := range typed {
+			operators = append(operators, key)
+		}
+	case bson.D:
+		for _, element := range typed {
+			operators = append(operators, element.Key)
+		}
+	}
+	for _, operator := range operators {
+		if strings.HasPrefix(operator, "$") && operator != "$eq" && operator != "$in" {
+			return true
+		}
+	}
+	return false
+}
+
+// Key identifies the pattern
+func (p QueryPattern) Key() string {
+	return fmt.Sprintf("eq=%s|range=%s|sort=%s:%s",
+		strings.Join(p.Equality, ","), strings.Join(p.Range, ","), p.SortBy, p.SortOrder)
+}
+
+// IndexKeys returns the index that serves the pattern, following the equality-sort-range rule
+func (p QueryPattern) IndexKeys() []IndexKey {
+	keys := make([]IndexKey, 0, len(p.Equality)+len(p.Range)+1)
+	seen := make(map[string]bool)
+	add := func(field string, direction int) {
+		if !seen[field] {
+			seen[field] = true
+			keys = append(keys, IndexKey{Field: field, Direction: direction})
+		}
+	}
+	for _, field := range p.Equality {
+		add(field, 1)
+	}
+	if p.SortBy != "" {
+		direction := 1
+		if p.SortOrder == cqrs.Descending {
+			direction = -1
+		}
+		add(p.SortBy, direction)
+	}
+	for _, field := range p.Range {
+		add(field, 1)
+	}
+	return keys
+}
+
+// QueryPatternStats aggregates observations of one query pattern
+type QueryPatternStats struct {
+	Pattern       QueryPattern  `json:"pattern"`
+	Count         int64         `json:"count"`
+	TotalDuration time.Duration `json:"total_duration"`
+	MaxDuration   time.Duration `json:"max_duration"`
+	LastSeen      time.Time     `json:"last_seen"`
+}
+
+// AverageDuration returns the mean query duration of the pattern
+func (s QueryPatternStats) AverageDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+// IndexSuggestion is an index the advisor recommends for observed queries
+type IndexSuggestion struct {
+	Name            string         `json:"name"`
+	Keys            []IndexKey     `json:"keys"`
+	Patterns        []QueryPattern `json:"patterns"`
+	Occurrences     int64          `json:"occurrences"`
+	AverageDuration time.Duration  `json:"average_duration"`
+}
+
+// IndexModel converts the suggestion to a Mongo index model
+func (s IndexSuggestion) IndexModel() mongo.IndexModel {
+	keys := make(bson.D, len(s.Keys))
+	for i, key := range s.Keys {
+		keys[i] = bson.E{Key: key.Field, Value: key.Direction}
+	}
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(s.Name)}
+}
+
+// SuggestIndexes recommends indexes for patterns seen at least minOccurrences times
+// that no existing index serves. Suggestions that are a prefix of another suggestion
+// are folded into it. Results are ordered by occurrences, most frequent first.
+func SuggestIndexes(stats []QueryPatternStats, existing [][]IndexKey, minOccurrences int64) []IndexSuggestion {
+	var suggestions []IndexSuggestion
+	for _, stat := range stats {
+		keys := stat.Pattern.IndexKeys()
+		if stat.Count < minOccurrences || len(keys) == 0 || servedByAny(keys, existing) {
+			continue
+		}
+		suggestions = append(suggestions, IndexSuggestion{
+			Name:            indexName(keys),
+			Keys:            keys,
+			Patterns:        []QueryPattern{stat.Pattern},
+			Occurrences:     stat.Count,
+			AverageDuration: stat.AverageDuration(),
+		})
+	}
+
+	// Fold suggestions served by a longer suggestion into it
+	sort.SliceStable(suggestions, func(i, j int) bool { return len(suggestions[i].Keys) > len(suggestions[j].Keys) })
+	var folded []IndexSuggestion
+	for _, suggestion := range suggestions {
+		merged := false
+		for i := range folded {
+			if serves(folded[i].Keys, suggestion.Keys) {
+				folded[i].Patterns = append(folded[i].Patterns, suggestion.Patterns...)
+				total := folded[i].AverageDuration*time.Duration(folded[i].Occurrences) + suggestion.AverageDuration*time.Duration(suggestion.Occurrences)
+				folded[i].Occurrences += suggestion.Occurrences
+				folded[i].AverageDuration = total / time.Duration(folded[i].Occurrences)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			folded = append(folded, suggestion)
+		}
+	}
+
+	sort.SliceStable(folded, func(i, j int) bool {
+		if folded[i].Occurrences != folded[j].Occurrences {
+			return folded[i].Occurrences > folded[j].Occurrences
+		}
+		return folded[i].Name < folded[j].Name
+	})
+	return folded
+}
+
+// serves reports whether index can serve a query needing keys: the fields of keys must be a
+// prefix of index. Directions do not matter because queries sort on at most one field and
+// Mongo walks an index either way; special indexes (text, hashed) have direction 0 and never serve.
+func serves(index, keys []IndexKey) bool {
+	if len(keys) > len(index) {
+		return false
+	}
+	for i, key := range keys {
+		if index[i].Field != key.Field || index[i].Direction == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func servedByAny(keys []IndexKey, indexes [][]IndexKey) bool {
+	for _, index := range indexes {
+		if serves(index, keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func indexName(keys []IndexKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s_%d", strings.ReplaceAll(key.Field, ".", "_"), key.Direction)
+	}
+	return "advisor_" + strings.Join(parts, "_")
+}
+
+// IndexAdvisorConfig configures an IndexAdvisor
+type IndexAdvisorConfig struct {
+	MinOccurrences int64 // Observations before a pattern is worth an index, default 10
+	MaxPatterns    int   // Distinct patterns tracked, default 1000; new patterns beyond it are dropped
+	AutoCreate     bool  // Advise also creates the suggested indexes
+}
+
+// IndexAdvisor records query patterns run against a Mongo read model collection and
+// suggests indexes for frequent patterns that no existing index serves.
+// Attach it with MongoReadStore.SetQueryObserver.
+type IndexAdvisor struct {
+	client     *MongoClientManager
+	collection string
+	config     IndexAdvisorConfig
+
+	mu       sync.Mutex
+	patterns map[string]*QueryPatternStats
+	dropped  int64
+}
+
+// NewIndexAdvisor creates an advisor for the collection
+func NewIndexAdvisor(client *MongoClientManager, collection string, config IndexAdvisorConfig) *IndexAdvisor {
+	if config.MinOccurrences <= 0 {
+		config.MinOccurrences = 10
+	}
+	if config.MaxPatterns <= 0 {
+		config.MaxPatterns = 1000
+	}
+	return &IndexAdvisor{
+		client:     client,
+		collection: collection,
+		config:     config,
+		patterns:   make(map[string]*QueryPatternStats),
+	}
+}
+
+// ObserveQuery records one executed query
+func (a *IndexAdvisor) ObserveQuery(criteria cqrs.QueryCriteria, duration time.Duration) {
+	pattern := QueryPatternFromCriteria(criteria)
+	key := pattern.Key()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stats, exists := a.patterns[key]
+	if !exists {
+		if len(a.patterns) >= a.config.MaxPatterns {
+			a.dropped++
+			return
+		}
+		stats = &QueryPatternStats{Pattern: pattern}
+		a.patterns[key] = stats
+	}
+	stats.Count++
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+	stats.LastSeen = time.Now()
+}
+
+// Patterns returns the observed patterns, most frequent first
+func (a *IndexAdvisor) Patterns() []QueryPatternStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stats := make([]QueryPatternStats, 0, len(a.patterns))
+	for _, stat := range a.patterns {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Pattern.Key() < stats[j].Pattern.Key()
+	})
+	return stats
+}
+
+// Reset forgets all observed patterns
+func (a *IndexAdvisor) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.patterns = make(map[string]*QueryPatternStats)
+	a.dropped = 0
+}
+
+// Suggest compares observed patterns with the collection's existing indexes
+func (a *IndexAdvisor) Suggest(ctx context.Context) ([]IndexSuggestion, error) {
+	existing, err := a.existingIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return SuggestIndexes(a.Patterns(), existing, a.config.MinOccurrences), nil
+}
+
+// Advise returns suggestions and, when AutoCreate is set, creates them
+func (a *IndexAdvisor) Advise(ctx context.Context) ([]IndexSuggestion, error) {
+	suggestions, err := a.Suggest(ctx)
+	if err != nil || !a.config.AutoCreate {
+		return suggestions, err
+	}
+	return suggestions, a.Apply(ctx, suggestions)
+}
+
+// Apply creates the suggested indexes
+func (a *IndexAdvisor) Apply(ctx context.Context, suggestions []IndexSuggestion) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+	models := make([]mongo.IndexModel, len(suggestions))
+	for i, suggestion := range suggestions {
+		models[i] = suggestion.IndexModel()
+	}
+	return a.client.ExecuteCommand(ctx, func() error {
+		if _, err := a.client.GetCollection(a.collection).Indexes().CreateMany(ctx, models); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to create advised indexes on %s: %v", a.collection, err), err)
+		}
+		return nil
+	})
+}
+
+func (a *IndexAdvisor) existingIndexes(ctx context.Context) ([][]IndexKey, error) {
+	var indexes [][]IndexKey
+	err := a.client.ExecuteCommand(ctx, func() error {
+		cursor, err := a.client.GetCollection(a.collection).Indexes().List(ctx)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to list indexes on %s: %v", a.collection, err), err)
+		}
+		var specs []struct {
+			Key bson.D `bson:"key"`
+		}
+		if err := cursor.All(ctx, &specs); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to decode indexes on %s: %v", a.collection, err), err)
+		}
+		for _, spec := range specs {
+			keys := make([]IndexKey, 0, len(spec.Key))
+			for _, element := range spec.Key {
+				keys = append(keys, IndexKey{Field: element.Key, Direction: indexDirection(element.Value)})
+			}
+			indexes = append(indexes, keys)
+		}
+		return nil
+	})
+	return indexes, err
+}
+
+// indexDirection normalises the numeric key values Mongo returns (int32, int64, float64);
+// special indexes (text, 2dsphere, hashed) get 0 and never match a suggestion
+func indexDirection(value interface{}) int {
+	switch typed := value.(type) {
+	case int32:
+		return int(typed)
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case int:
+		return typed
+	default:
+		return 0
+	}
+}
+
+// ServeHTTP exposes the advisor as an admin endpoint:
+// GET returns observed patterns and suggestions, POST creates the suggested indexes.
+func (a *IndexAdvisor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	suggestions, err := a.Suggest(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := a.Apply(r.Context(), suggestions); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	a.mu.Lock()
+	dropped := a.dropped
+	a.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"collection":       a.collection,
+		"patterns":         a.Patterns(),
+		"dropped_patterns": dropped,
+		"suggestions":      suggestions,
+		"created":          r.Method == http.MethodPost,
+	})
+}
@@ -0,0 +1,101 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryPatternFromCriteria_IgnoresValuesAndOrdersESR(t *testing.T) {
+	// Arrange
+	first := cqrs.QueryCriteria{
+		Filters: map[string]interface{}{
+			"type":     "GuildView",
+			"region":   "kr",
+			"level":    map[string]interface{}{"$gte": 10},
+			"tag":      map[string]interface{}{"$in": []string{"pvp"}},
+			"language": "ko",
+		},
+		SortBy:    "member_count",
+		SortOrder: cqrs.Descending,
+	}
+	second := cqrs.QueryCriteria{
+		Filters: map[string]interface{}{
+			"type":     "GuildView",
+			"region":   "us",
+			"level":    map[string]interface{}{"$gte": 30},
+			"tag":      map[string]interface{}{"$in": []string{"raid"}},
+			"language": "en",
+		},
+		SortBy:    "member_count",
+		SortOrder: cqrs.Descending,
+	}
+
+	// Act
+	pattern := QueryPatternFromCriteria(first)
+
+	// Assert
+	assert.Equal(t, []string{"language", "model_type", "region", "tag"}, pattern.Equality)
+	assert.Equal(t, []string{"level"}, pattern.Range)
+	assert.Equal(t, pattern.Key(), QueryPatternFromCriteria(second).Key())
+	assert.Equal(t, []IndexKey{
+		{Field: "language", Direction: 1},
+		{Field: "model_type", Direction: 1},
+		{Field: "region", Direction: 1},
+		{Field: "tag", Direction: 1},
+		{Field: "member_count", Direction: -1},
+		{Field: "level", Direction: 1},
+	}, pattern.IndexKeys())
+}
+
+func TestSuggestIndexes_SkipsServedAndRarePatterns(t *testing.T) {
+	// Arrange
+	byType := QueryPatternStats{Pattern: QueryPattern{Equality: []string{"model_type"}}, Count: 50, TotalDuration: 50 * time.Millisecond}
+	byTypeRegion := QueryPatternStats{Pattern: QueryPattern{Equality: []string{"model_type", "region"}, SortBy: "updated_at", SortOrder: cqrs.Descending}, Count: 30, TotalDuration: 300 * time.Millisecond}
+	byRegionOnly := QueryPatternStats{Pattern: QueryPattern{Equality: []string{"region"}}, Count: 20, TotalDuration: 20 * time.Millisecond}
+	rare := QueryPatternStats{Pattern: QueryPattern{Equality: []string{"owner_id"}}, Count: 2}
+	byID := QueryPatternStats{Pattern: QueryPattern{Equality: []string{"model_id", "model_type"}}, Count: 100}
+	existing := [][]IndexKey{
+		{{Field: "model_id", Direction: 1}, {Field: "model_type", Direction: 1}},
+		{{Field: "region", Direction: 0}}, // text index never serves equality
+	}
+
+	// Act
+	suggestions := SuggestIndexes([]QueryPatternStats{byType, byTypeRegion, byRegionOnly, rare, byID}, existing, 10)
+
+	// Assert
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "advisor_model_type_1_region_1_updated_at_-1", suggestions[0].Name)
+	assert.Equal(t, int64(80), suggestions[0].Occurrences) // model_type alone is a prefix and folds in
+	assert.Len(t, suggestions[0].Patterns, 2)
+	assert.Equal(t, 4375*time.Microsecond, suggestions[0].AverageDuration)
+	assert.Equal(t, "advisor_region_1", suggestions[1].Name)
+
+	model := suggestions[1].IndexModel()
+	require.NotNil(t, model.Options.Name)
+	assert.Equal(t, "advisor_region_1", *model.Options.Name)
+}
+
+func TestIndexAdvisor_ObserveQuery(t *testing.T) {
+	// Arrange
+	advisor := NewIndexAdvisor(nil, "read_models", IndexAdvisorConfig{MaxPatterns: 1})
+	criteria := cqrs.QueryCriteria{Filters: map[string]interface{}{"type": "PlayerView"}}
+
+	// Act
+	advisor.ObserveQuery(criteria, 2*time.Millisecond)
+	advisor.ObserveQuery(criteria, 4*time.Millisecond)
+	advisor.ObserveQuery(cqrs.QueryCriteria{SortBy: "score"}, time.Millisecond)
+
+	// Assert
+	patterns := advisor.Patterns()
+	require.Len(t, patterns, 1) // MaxPatterns drops the second shape
+	assert.Equal(t, int64(2), patterns[0].Count)
+	assert.Equal(t, 3*time.Millisecond, patterns[0].AverageDuration())
+	assert.Equal(t, 4*time.Millisecond, patterns[0].MaxDuration)
+
+	advisor.Reset()
+	assert.Empty(t, advisor.Patterns())
+}
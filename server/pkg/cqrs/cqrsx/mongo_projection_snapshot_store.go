@@ -0,0 +1,214 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultProjectionSnapshotChunkSize is the number of read models stored per chunk document
+const DefaultProjectionSnapshotChunkSize = 500
+
+// MongoProjectionSnapshotStore implements cqrs.ProjectionSnapshotStore using MongoDB.
+//
+// A snapshot is written as chunk documents (read models) followed by a header
+// document (checkpoint) that points at them, so large read model sets stay below
+// the 16MB document limit and a crash mid-save never exposes a partial snapshot.
+// Chunks of older snapshots are removed after the header is switched.
+type MongoProjectionSnapshotStore struct {
+	client         *MongoClientManager
+	collectionName string
+	chunkSize      int
+	serializer     ReadModelSerializer
+}
+
+// MongoProjectionSnapshotDocument is the header of the latest snapshot of a projection
+type MongoProjectionSnapshotDocument struct {
+	ProjectionName    string                    `bson:"_id"`
+	SnapshotID        string                    `bson:"snapshot_id"`
+	ProjectionVersion string                    `bson:"projection_version"`
+	Checkpoint        cqrs.ProjectionCheckpoint `bson:"checkpoint"`
+	ModelCount        int                       `bson:"model_count"`
+	ChunkCount        int                       `bson:"chunk_count"`
+	CreatedAt         time.Time                 `bson:"created_at"`
+}
+
+// MongoProjectionSnapshotChunk holds a slice of a snapshot's read models
+type MongoProjectionSnapshotChunk struct {
+	ID             primitive.ObjectID            `bson:"_id,omitempty"`
+	ProjectionName string                        `bson:"projection_name"`
+	SnapshotID     string                        `bson:"snapshot_id"`
+	Sequence       int                           `bson:"seq"`
+	Models         []MongoProjectionSnapshotItem `bson:"models"`
+}
+
+// MongoProjectionSnapshotItem is a serialized read model
+type MongoProjectionSnapshotItem struct {
+	ModelType string `bson:"model_type"`
+	Data      []byte `bson:"data"`
+}
+
+// NewMongoProjectionSnapshotStore creates a projection snapshot store.
+// Chunks are stored in "<collectionName>_chunks".
+func NewMongoProjectionSnapshotStore(client *MongoClientManager, collectionName string) *MongoProjectionSnapshotStore {
+	if collectionName == "" {
+		collectionName = "projection_snapshots"
+	}
+
+	return &MongoProjectionSnapshotStore{
+		client:         client,
+		collectionName: collectionName,
+		chunkSize:      DefaultProjectionSnapshotChunkSize,
+		serializer:     &JSONReadModelSerializer{},
+	}
+}
+
+// SetSerializer sets the read model serializer
+func (s *MongoProjectionSnapshotStore) SetSerializer(serializer ReadModelSerializer) {
+	s.serializer = serializer
+}
+
+// SetChunkSize sets the number of read models per chunk document
+func (s *MongoProjectionSnapshotStore) SetChunkSize(size int) {
+	if size > 0 {
+		s.chunkSize = size
+	}
+}
+
+func (s *MongoProjectionSnapshotStore) chunkCollection() *mongo.Collection {
+	return s.client.GetCollection(s.collectionName + "_chunks")
+}
+
+// SaveProjectionSnapshot stores a snapshot, replacing the previous one of the projection
+func (s *MongoProjectionSnapshotStore) SaveProjectionSnapshot(ctx context.Context, snapshot *cqrs.ProjectionSnapshot) error {
+	if snapshot == nil || snapshot.ProjectionName == "" {
+		return cqrs.NewValidationError("snapshot projection name cannot be empty", nil)
+	}
+
+	snapshotID := primitive.NewObjectID().Hex()
+
+	chunks := make([]interface{}, 0, len(snapshot.ReadModels)/s.chunkSize+1)
+	for start := 0; start < len(snapshot.ReadModels); start += s.chunkSize {
+		end := start + s.chunkSize
+		if end > len(snapshot.ReadModels) {
+			end = len(snapshot.ReadModels)
+		}
+
+		items := make([]MongoProjectionSnapshotItem, 0, end-start)
+		for _, model := range snapshot.ReadModels[start:end] {
+			data, err := s.serializer.SerializeReadModel(model)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+					fmt.Sprintf("failed to serialize read model %s/%s", model.GetType(), model.GetID()), err)
+			}
+			items = append(items, MongoProjectionSnapshotItem{ModelType: model.GetType(), Data: data})
+		}
+
+		chunks = append(chunks, MongoProjectionSnapshotChunk{
+			ProjectionName: snapshot.ProjectionName,
+			SnapshotID:     snapshotID,
+			Sequence:       len(chunks),
+			Models:         items,
+		})
+	}
+
+	return s.client.ExecuteCommand(ctx, func() error {
+		if len(chunks) > 0 {
+			if _, err := s.chunkCollection().InsertMany(ctx, chunks); err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to save projection snapshot chunks", err)
+			}
+		}
+
+		header := MongoProjectionSnapshotDocument{
+			ProjectionName:    snapshot.ProjectionName,
+			SnapshotID:        snapshotID,
+			ProjectionVersion: snapshot.ProjectionVersion,
+			Checkpoint:        snapshot.Checkpoint,
+			ModelCount:        len(snapshot.ReadModels),
+			ChunkCount:        len(chunks),
+			CreatedAt:         snapshot.CreatedAt,
+		}
+		_, err := s.client.GetCollection(s.collectionName).ReplaceOne(ctx,
+			bson.M{"_id": snapshot.ProjectionName}, header, options.Replace().SetUpsert(true))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to save projection snapshot", err)
+		}
+
+		// Older chunks are unreachable now; a failed cleanup only leaves garbage behind
+		s.chunkCollection().DeleteMany(ctx, bson.M{
+			"projection_name": snapshot.ProjectionName,
+			"snapshot_id":     bson.M{"$ne": snapshotID},
+		})
+		return nil
+	})
+}
+
+// LoadProjectionSnapshot loads the latest snapshot of a projection
+func (s *MongoProjectionSnapshotStore) LoadProjectionSnapshot(ctx context.Context, projectionName string) (*cqrs.ProjectionSnapshot, error) {
+	var snapshot *cqrs.ProjectionSnapshot
+
+	err := s.client.ExecuteCommand(ctx, func() error {
+		var header MongoProjectionSnapshotDocument
+		err := s.client.GetCollection(s.collectionName).FindOne(ctx, bson.M{"_id": projectionName}).Decode(&header)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return cqrs.NewCQRSError(cqrs.ErrCodeNotFoundError.String(),
+					fmt.Sprintf("projection snapshot not found: %s", projectionName), nil)
+			}
+			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to load projection snapshot", err)
+		}
+
+		cursor, err := s.chunkCollection().Find(ctx,
+			bson.M{"projection_name": projectionName, "snapshot_id": header.SnapshotID},
+			options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to load projection snapshot chunks", err)
+		}
+		defer cursor.Close(ctx)
+
+		readModels := make([]cqrs.ReadModel, 0, header.ModelCount)
+		chunkCount := 0
+		for cursor.Next(ctx) {
+			var chunk MongoProjectionSnapshotChunk
+			if err := cursor.Decode(&chunk); err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to decode projection snapshot chunk", err)
+			}
+			for _, item := range chunk.Models {
+				model, err := s.serializer.DeserializeReadModel(item.Data, item.ModelType)
+				if err != nil {
+					return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+						fmt.Sprintf("failed to deserialize read model of type %s", item.ModelType), err)
+				}
+				readModels = append(readModels, model)
+			}
+			chunkCount++
+		}
+		if err := cursor.Err(); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "failed to read projection snapshot chunks", err)
+		}
+		if chunkCount != header.ChunkCount {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(),
+				fmt.Sprintf("projection snapshot %s is incomplete: %d of %d chunks", projectionName, chunkCount, header.ChunkCount), nil)
+		}
+
+		snapshot = &cqrs.ProjectionSnapshot{
+			ProjectionName:    header.ProjectionName,
+			ProjectionVersion: header.ProjectionVersion,
+			Checkpoint:        header.Checkpoint,
+			ReadModels:        readModels,
+			CreatedAt:         header.CreatedAt,
+		}
+		return nil
+	})
+
+	return snapshot, err
+}
+
+// Compile-time interface check
+var _ cqrs.ProjectionSnapshotStore = (*MongoProjectionSnapshotStore)(nil)
@@ -18,6 +18,7 @@ type MongoReadStore struct {
 	client         *MongoClientManager
 	collectionName string
 	serializer     ReadModelSerializer
+	observer       QueryObserver
 }
 
 // MongoReadModelDocument represents the standard CQRS read model schema in MongoDB
@@ -65,7 +66,8 @@ func (s *JSONReadModelSerializer) DeserializeReadModel(data []byte, modelType st
 		return readModel, nil
 	}
 
-	return nil, fmt.Errorf("deserialized object is not a ReadModel")
+	return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+		fmt.Sprintf("deserialized object %T is not a ReadModel", result), nil)
 }
 
 // NewMongoReadStore creates a new MongoDB read store with standard schema
@@ -81,10 +83,29 @@ func NewMongoReadStore(client *MongoClientManager, collectionName string) *Mongo
 	}
 }
 
+// SetSerializer replaces the read model serializer (e.g. with a MigratingReadModelSerializer)
+func (rs *MongoReadStore) SetSerializer(serializer ReadModelSerializer) {
+	if serializer != nil {
+		rs.serializer = serializer
+	}
+}
+
+// SetQueryObserver records Query, QueryStream and Count criteria (e.g. with an IndexAdvisor)
+func (rs *MongoReadStore) SetQueryObserver(observer QueryObserver) {
+	rs.observer = observer
+}
+
+// observeQuery reports a finished query to the observer, if any
+func (rs *MongoReadStore) observeQuery(criteria cqrs.QueryCriteria, started time.Time) {
+	if rs.observer != nil {
+		rs.observer.ObserveQuery(criteria, time.Since(started))
+	}
+}
+
 // Save saves a read model to MongoDB using standard CQRS pattern
 func (rs *MongoReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) error {
 	if readModel == nil {
-		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "read model cannot be nil", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "read model cannot be nil", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := rs.client.GetCollection(rs.collectionName)
@@ -143,11 +164,11 @@ func (rs *MongoReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) er
 // GetByID retrieves a read model by ID and type using standard CQRS query
 func (rs *MongoReadStore) GetByID(ctx context.Context, id string, modelType string) (cqrs.ReadModel, error) {
 	if id == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model ID cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if modelType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := rs.client.GetCollection(rs.collectionName)
@@ -184,14 +205,89 @@ func (rs *MongoReadStore) GetByID(ctx context.Context, id string, modelType stri
 	return readModel, err
 }
 
+// GetByIDs retrieves several read models of one type with a single $in query.
+// Results follow the order of ids; ids without a document are omitted.
+func (rs *MongoReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]cqrs.ReadModel, error) {
+	if modelType == "" {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
+	ids = batchReadIDs(ids)
+	if len(ids) == 0 {
+		return []cqrs.ReadModel{}, nil
+	}
+
+	collection := rs.client.GetCollection(rs.collectionName)
+	found := make(map[string]cqrs.ReadModel, len(ids))
+
+	err := rs.client.ExecuteCommand(ctx, func() error {
+		filter := bson.M{
+			"model_id":   bson.M{"$in": ids},
+			"model_type": modelType,
+		}
+
+		cursor, err := collection.Find(ctx, filter)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to find read models: %v", err), err)
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var doc MongoReadModelDocument
+			if err := cursor.Decode(&doc); err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+					fmt.Sprintf("failed to decode read model document: %v", err), err)
+			}
+
+			readModel, err := rs.serializer.DeserializeReadModel([]byte(doc.Data), modelType)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+					fmt.Sprintf("failed to deserialize read model: %v", err), err)
+			}
+			found[doc.ModelID] = readModel
+		}
+
+		if err := cursor.Err(); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("cursor error: %v", err), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readModels := make([]cqrs.ReadModel, 0, len(found))
+	for _, id := range ids {
+		if readModel, ok := found[id]; ok {
+			readModels = append(readModels, readModel)
+		}
+	}
+	return readModels, nil
+}
+
+// batchReadIDs drops empty and duplicate ids while keeping the first occurrence order
+func batchReadIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
 // Delete removes a read model from MongoDB using standard CQRS pattern
 func (rs *MongoReadStore) Delete(ctx context.Context, id string, modelType string) error {
 	if id == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model ID cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if modelType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := rs.client.GetCollection(rs.collectionName)
@@ -219,6 +315,7 @@ func (rs *MongoReadStore) Delete(ctx context.Context, id string, modelType strin
 
 // Query executes a query against read models using standard CQRS pattern
 func (rs *MongoReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.ReadModel, error) {
+	defer rs.observeQuery(criteria, time.Now())
 	collection := rs.client.GetCollection(rs.collectionName)
 	var readModels []cqrs.ReadModel
 
@@ -226,29 +323,8 @@ func (rs *MongoReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria
 		// Build MongoDB filter from criteria
 		filter := rs.buildMongoFilter(criteria)
 
-		// Build options
-		opts := options.Find()
-
-		// Apply sorting
-		if criteria.SortBy != "" {
-			direction := 1
-			if criteria.SortOrder == cqrs.Descending {
-				direction = -1
-			}
-			sortDoc := bson.D{{Key: criteria.SortBy, Value: direction}}
-			opts.SetSort(sortDoc)
-		}
-
-		// Apply pagination
-		if criteria.Limit > 0 {
-			opts.SetLimit(int64(criteria.Limit))
-		}
-		if criteria.Offset > 0 {
-			opts.SetSkip(int64(criteria.Offset))
-		}
-
 		// Execute query
-		cursor, err := collection.Find(ctx, filter, opts)
+		cursor, err := collection.Find(ctx, filter, buildFindOptions(criteria))
 		if err != nil {
 			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
 				fmt.Sprintf("failed to execute query: %v", err), err)
@@ -283,8 +359,109 @@ func (rs *MongoReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria
 	return readModels, err
 }
 
+// mongoStreamBatchSize is the number of documents QueryStream fetches per round trip
+const mongoStreamBatchSize = 500
+
+// QueryStream streams read models matching the criteria through a MongoDB cursor,
+// fetching mongoStreamBatchSize documents per round trip instead of loading them all.
+// Like Query, documents that fail to deserialize are skipped.
+func (rs *MongoReadStore) QueryStream(ctx context.Context, criteria cqrs.QueryCriteria) (cqrs.ReadModelCursor, error) {
+	defer rs.observeQuery(criteria, time.Now())
+	collection := rs.client.GetCollection(rs.collectionName)
+	var cursor *mongo.Cursor
+
+	err := rs.client.ExecuteCommand(ctx, func() error {
+		opts := buildFindOptions(criteria).SetBatchSize(mongoStreamBatchSize)
+
+		var err error
+		cursor, err = collection.Find(ctx, rs.buildMongoFilter(criteria), opts)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to execute query: %v", err), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoReadModelCursor{cursor: cursor, serializer: rs.serializer}, nil
+}
+
+// mongoReadModelCursor adapts a MongoDB cursor to cqrs.ReadModelCursor
+type mongoReadModelCursor struct {
+	cursor     *mongo.Cursor
+	serializer ReadModelSerializer
+	current    cqrs.ReadModel
+	err        error
+}
+
+func (c *mongoReadModelCursor) Next(ctx context.Context) bool {
+	c.current = nil
+	for c.err == nil && c.cursor.Next(ctx) {
+		var doc MongoReadModelDocument
+		if err := c.cursor.Decode(&doc); err != nil {
+			c.err = cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to decode read model document: %v", err), err)
+			return false
+		}
+
+		readModel, err := c.serializer.DeserializeReadModel([]byte(doc.Data), doc.ModelType)
+		if err != nil {
+			continue // Skip failed deserializations
+		}
+
+		c.current = readModel
+		return true
+	}
+
+	if c.err == nil && c.cursor.Err() != nil {
+		c.err = cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+			fmt.Sprintf("cursor error: %v", c.cursor.Err()), c.cursor.Err())
+	}
+	return false
+}
+
+func (c *mongoReadModelCursor) ReadModel() cqrs.ReadModel {
+	return c.current
+}
+
+func (c *mongoReadModelCursor) Err() error {
+	return c.err
+}
+
+func (c *mongoReadModelCursor) Close(ctx context.Context) error {
+	return c.cursor.Close(ctx)
+}
+
+// buildFindOptions applies the sorting and pagination of criteria to find options
+func buildFindOptions(criteria cqrs.QueryCriteria) *options.FindOptions {
+	opts := options.Find()
+
+	// Apply sorting
+	if criteria.SortBy != "" {
+		direction := 1
+		if criteria.SortOrder == cqrs.Descending {
+			direction = -1
+		}
+		sortDoc := bson.D{{Key: criteria.SortBy, Value: direction}}
+		opts.SetSort(sortDoc)
+	}
+
+	// Apply pagination
+	if criteria.Limit > 0 {
+		opts.SetLimit(int64(criteria.Limit))
+	}
+	if criteria.Offset > 0 {
+		opts.SetSkip(int64(criteria.Offset))
+	}
+
+	return opts
+}
+
 // Count counts read models matching the criteria
 func (rs *MongoReadStore) Count(ctx context.Context, criteria cqrs.QueryCriteria) (int64, error) {
+	defer rs.observeQuery(criteria, time.Now())
 	collection := rs.client.GetCollection(rs.collectionName)
 	var count int64
 
@@ -389,7 +566,7 @@ func (rs *MongoReadStore) DeleteBatch(ctx context.Context, ids []string, modelTy
 	}
 
 	if modelType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := rs.client.GetCollection(rs.collectionName)
@@ -414,9 +591,17 @@ func (rs *MongoReadStore) DeleteBatch(ctx context.Context, ids []string, modelTy
 func (rs *MongoReadStore) buildMongoFilter(criteria cqrs.QueryCriteria) bson.M {
 	filter := bson.M{}
 
-	// Add field filters
+	// Add field filters; the reserved "type" and "id" keys address the document
+	// metadata like they do for the in-memory and Redis read stores
 	for field, value := range criteria.Filters {
-		filter[field] = value
+		switch field {
+		case "type":
+			filter["model_type"] = value
+		case "id":
+			filter["model_id"] = value
+		default:
+			filter[field] = value
+		}
 	}
 
 	return filter
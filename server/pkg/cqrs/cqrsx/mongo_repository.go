@@ -0,0 +1,247 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"math"
+	"time"
+)
+
+// mongoRepositoryEvents is the part of MongoEventStore used by MongoEventSourcedRepository
+type mongoRepositoryEvents interface {
+	SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error
+	GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+	GetLastEventVersion(ctx context.Context, aggregateID string, aggregateType string) (int, error)
+	CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error
+}
+
+// mongoRepositorySnapshots is the part of MongoSnapshotStore used by MongoEventSourcedRepository
+type mongoRepositorySnapshots interface {
+	SaveSnapshot(ctx context.Context, aggregate cqrs.AggregateRoot) error
+	LoadSnapshot(ctx context.Context, aggregateID, aggregateType string) (cqrs.AggregateRoot, error)
+	DeleteSnapshot(ctx context.Context, aggregateID, aggregateType string) error
+	GetSnapshot(ctx context.Context, aggregateID string, maxVersion int) (SnapshotData, error)
+	ListSnapshotsForAggregate(ctx context.Context, aggregateID string) ([]SnapshotData, error)
+}
+
+// MongoEventSourcedRepository implements EventSourcedRepository using MongoDB.
+// GetByID starts from the latest snapshot when one exists and replays only the
+// events after it; Save takes a new snapshot every snapshotFrequency events.
+type MongoEventSourcedRepository struct {
+	eventStore        mongoRepositoryEvents
+	snapshotStore     mongoRepositorySnapshots
+	aggregateType     string
+	registry          *cqrs.AggregateRegistry
+	snapshotFrequency int
+	metrics           *storageMetricsRecorder
+}
+
+// NewMongoEventSourcedRepository creates a new MongoDB event sourced repository.
+// snapshotStore may be nil, in which case every load replays the full event stream.
+func NewMongoEventSourcedRepository(eventStore *MongoEventStore, snapshotStore *MongoSnapshotStore, aggregateType string) *MongoEventSourcedRepository {
+	repository := &MongoEventSourcedRepository{
+		eventStore:    eventStore,
+		aggregateType: aggregateType,
+		registry:      cqrs.DefaultAggregateRegistry(),
+		metrics:       newStorageMetricsRecorder(),
+	}
+	if snapshotStore != nil {
+		repository.snapshotStore = snapshotStore
+	}
+	return repository
+}
+
+// SetAggregateRegistry sets the registry used to rehydrate concrete aggregate types
+func (r *MongoEventSourcedRepository) SetAggregateRegistry(registry *cqrs.AggregateRegistry) {
+	r.registry = registry
+}
+
+// SetSnapshotFrequency makes Save snapshot the aggregate every frequency events; 0 disables it
+func (r *MongoEventSourcedRepository) SetSnapshotFrequency(frequency int) {
+	r.snapshotFrequency = frequency
+}
+
+// MongoEventSourcedRepository implementation
+
+func (r *MongoEventSourcedRepository) Save(ctx context.Context, aggregate cqrs.AggregateRoot, expectedVersion int) error {
+	if aggregate.Type() != r.aggregateType {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+			fmt.Sprintf("aggregate type mismatch: expected %s, got %s", r.aggregateType, aggregate.Type()), nil)
+	}
+
+	// Get uncommitted events
+	events := aggregate.Changes()
+	if len(events) == 0 {
+		return nil // No changes to save
+	}
+
+	// Save events
+	if err := r.eventStore.SaveEvents(ctx, aggregate.ID(), events, expectedVersion); err != nil {
+		return err
+	}
+
+	// Clear changes after successful save
+	aggregate.ClearChanges()
+	r.metrics.recordAccess(aggregate.ID())
+
+	// Snapshot when the saved events crossed a multiple of the snapshot frequency.
+	// The events are already stored, so a failed snapshot only makes later loads slower.
+	if r.snapshotStore != nil && r.snapshotFrequency > 0 &&
+		aggregate.Version()/r.snapshotFrequency > expectedVersion/r.snapshotFrequency {
+		_ = r.snapshotStore.SaveSnapshot(ctx, aggregate)
+	}
+
+	return nil
+}
+
+func (r *MongoEventSourcedRepository) GetByID(ctx context.Context, id string) (cqrs.AggregateRoot, error) {
+	loadStarted := time.Now()
+
+	// Try to load from snapshot first; a missing or unreadable snapshot falls back to a full replay
+	var aggregate cqrs.AggregateRoot
+	fromVersion := 0
+	if r.snapshotStore != nil {
+		loaded, err := r.snapshotStore.LoadSnapshot(ctx, id, r.aggregateType)
+		if err == nil && loaded != nil {
+			aggregate = loaded
+			fromVersion = loaded.Version() + 1
+		}
+	}
+
+	// Load events after the snapshot
+	events, err := r.eventStore.GetEventHistory(ctx, id, r.aggregateType, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	replayStarted := time.Now()
+	aggregate, err = r.replay(id, aggregate, events)
+	if err != nil {
+		return nil, err
+	}
+	replayDuration := time.Since(replayStarted)
+
+	if setter, ok := aggregate.(interface{ SetOriginalVersion(int) }); ok {
+		setter.SetOriginalVersion(aggregate.Version())
+	}
+
+	r.metrics.recordLoad(id, fromVersion > 0, len(events), time.Since(loadStarted), replayDuration)
+	return aggregate, nil
+}
+
+// replay applies events on top of the snapshot aggregate, or on a new aggregate without one
+func (r *MongoEventSourcedRepository) replay(id string, aggregate cqrs.AggregateRoot, events []cqrs.EventMessage) (cqrs.AggregateRoot, error) {
+	registered := r.registry != nil && r.registry.IsRegistered(r.aggregateType)
+
+	if aggregate == nil {
+		if registered {
+			return r.registry.Rehydrate(r.aggregateType, id, nil, events)
+		}
+		aggregate = cqrs.NewBaseAggregate(id, r.aggregateType)
+	} else if registered {
+		if err := r.registry.Apply(aggregate, events); err != nil {
+			return nil, err
+		}
+		return aggregate, nil
+	}
+
+	// Apply events to aggregate
+	for _, event := range events {
+		if err := aggregate.ReplayEvent(event); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				fmt.Sprintf("failed to replay event %s", event.EventType()), err)
+		}
+	}
+	return aggregate, nil
+}
+
+func (r *MongoEventSourcedRepository) GetVersion(ctx context.Context, id string) (int, error) {
+	return r.eventStore.GetLastEventVersion(ctx, id, r.aggregateType)
+}
+
+func (r *MongoEventSourcedRepository) Exists(ctx context.Context, id string) bool {
+	version, err := r.GetVersion(ctx, id)
+	return err == nil && version > 0
+}
+
+// EventSourcedRepository specific methods
+
+func (r *MongoEventSourcedRepository) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	return r.eventStore.SaveEvents(ctx, aggregateID, events, expectedVersion)
+}
+
+func (r *MongoEventSourcedRepository) GetEventHistory(ctx context.Context, aggregateID string, fromVersion int) ([]cqrs.EventMessage, error) {
+	return r.eventStore.GetEventHistory(ctx, aggregateID, r.aggregateType, fromVersion)
+}
+
+func (r *MongoEventSourcedRepository) GetEventStream(ctx context.Context, aggregateID string) (<-chan cqrs.EventMessage, error) {
+	// Note: This would require MongoDB change streams
+	return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "event streaming not implemented yet", nil)
+}
+
+// SaveSnapshot is not supported: MongoSnapshotStore snapshots whole aggregates, see SnapshotAggregate
+func (r *MongoEventSourcedRepository) SaveSnapshot(ctx context.Context, snapshot cqrs.SnapshotData) error {
+	return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+		"saving raw snapshot data is not supported, use SnapshotAggregate", nil)
+}
+
+// SnapshotAggregate stores a snapshot of the aggregate's current state
+func (r *MongoEventSourcedRepository) SnapshotAggregate(ctx context.Context, aggregate cqrs.AggregateRoot) error {
+	if r.snapshotStore == nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "snapshot store not configured", nil)
+	}
+	return r.snapshotStore.SaveSnapshot(ctx, aggregate)
+}
+
+func (r *MongoEventSourcedRepository) GetSnapshot(ctx context.Context, aggregateID string) (cqrs.SnapshotData, error) {
+	if r.snapshotStore == nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "snapshot store not configured", nil)
+	}
+	snapshot, err := r.snapshotStore.GetSnapshot(ctx, aggregateID, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	return cqrs.NewBaseSnapshotData(snapshot.ID(), snapshot.Type(), snapshot.Version(), snapshot.Data()), nil
+}
+
+func (r *MongoEventSourcedRepository) DeleteSnapshot(ctx context.Context, aggregateID string) error {
+	if r.snapshotStore == nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "snapshot store not configured", nil)
+	}
+	return r.snapshotStore.DeleteSnapshot(ctx, aggregateID, r.aggregateType)
+}
+
+func (r *MongoEventSourcedRepository) GetLastEventVersion(ctx context.Context, aggregateID string) (int, error) {
+	return r.eventStore.GetLastEventVersion(ctx, aggregateID, r.aggregateType)
+}
+
+func (r *MongoEventSourcedRepository) CompactEvents(ctx context.Context, aggregateID string, beforeVersion int) error {
+	return r.eventStore.CompactEvents(ctx, aggregateID, r.aggregateType, beforeVersion)
+}
+
+// GetStorageMetrics returns the aggregate's stored volumes together with the load
+// timings recorded by this repository instance
+func (r *MongoEventSourcedRepository) GetStorageMetrics(ctx context.Context, aggregateID string) (*cqrs.StorageMetrics, error) {
+	version, err := r.eventStore.GetLastEventVersion(ctx, aggregateID, r.aggregateType)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := r.metrics.snapshot(aggregateID)
+	metrics.EventCount = int64(version)
+	metrics.SnapshotCount = 0
+	metrics.StateSize = 0
+	if r.snapshotStore != nil {
+		snapshots, err := r.snapshotStore.ListSnapshotsForAggregate(ctx, aggregateID)
+		if err != nil {
+			return nil, err
+		}
+		metrics.SnapshotCount = int64(len(snapshots))
+		if len(snapshots) > 0 {
+			metrics.StateSize = snapshots[0].Size() // Latest snapshot comes first
+		}
+	}
+
+	return &metrics, nil
+}
@@ -0,0 +1,166 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepositoryEvents 메모리에 이벤트를 보관하고 조회 시작 버전을 기록
+type fakeRepositoryEvents struct {
+	events       []cqrs.EventMessage
+	loadedFrom   []int
+	compactedTil int
+}
+
+func (f *fakeRepositoryEvents) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	if expectedVersion != len(f.events) {
+		return errors.New("concurrency conflict")
+	}
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func (f *fakeRepositoryEvents) GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	f.loadedFrom = append(f.loadedFrom, fromVersion)
+	result := make([]cqrs.EventMessage, 0)
+	for _, event := range f.events {
+		if event.Version() >= fromVersion {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRepositoryEvents) GetLastEventVersion(ctx context.Context, aggregateID string, aggregateType string) (int, error) {
+	return len(f.events), nil
+}
+
+func (f *fakeRepositoryEvents) CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error {
+	f.compactedTil = beforeVersion
+	return nil
+}
+
+// fakeRepositorySnapshots 마지막 스냅샷 버전만 기억하는 스냅샷 저장소
+type fakeRepositorySnapshots struct {
+	versions []int
+	loadErr  error
+}
+
+func (f *fakeRepositorySnapshots) SaveSnapshot(ctx context.Context, aggregate cqrs.AggregateRoot) error {
+	f.versions = append(f.versions, aggregate.Version())
+	return nil
+}
+
+func (f *fakeRepositorySnapshots) LoadSnapshot(ctx context.Context, aggregateID, aggregateType string) (cqrs.AggregateRoot, error) {
+	if f.loadErr != nil {
+		return nil, f.loadErr
+	}
+	if len(f.versions) == 0 {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotNotFound.String(), "snapshot not found", nil)
+	}
+	version := f.versions[len(f.versions)-1]
+	return cqrs.NewBaseAggregate(aggregateID, aggregateType, cqrs.WithOriginalVersion(version)), nil
+}
+
+func (f *fakeRepositorySnapshots) DeleteSnapshot(ctx context.Context, aggregateID, aggregateType string) error {
+	f.versions = nil
+	return nil
+}
+
+func (f *fakeRepositorySnapshots) GetSnapshot(ctx context.Context, aggregateID string, maxVersion int) (SnapshotData, error) {
+	return nil, errors.New("not used")
+}
+
+func (f *fakeRepositorySnapshots) ListSnapshotsForAggregate(ctx context.Context, aggregateID string) ([]SnapshotData, error) {
+	snapshots := make([]SnapshotData, len(f.versions))
+	for i, version := range f.versions {
+		snapshots[i] = &MongoSnapshotData{doc: &MongoSnapshotDocument{Version: version, Size: 64}}
+	}
+	return snapshots, nil
+}
+
+func newTestMongoRepository() (*MongoEventSourcedRepository, *fakeRepositoryEvents, *fakeRepositorySnapshots) {
+	events := &fakeRepositoryEvents{}
+	snapshots := &fakeRepositorySnapshots{}
+	repository := NewMongoEventSourcedRepository(nil, nil, "Order")
+	repository.eventStore = events
+	repository.snapshotStore = snapshots
+	repository.SetAggregateRegistry(nil)
+	return repository, events, snapshots
+}
+
+func saveOrderEvents(t *testing.T, repository *MongoEventSourcedRepository, aggregate *cqrs.BaseAggregate, count int) {
+	t.Helper()
+	expected := aggregate.Version()
+	for i := 0; i < count; i++ {
+		require.NoError(t, aggregate.ApplyEvent(cqrs.NewBaseEventMessage("OrderUpdated")))
+	}
+	require.NoError(t, repository.Save(context.Background(), aggregate, expected))
+}
+
+func TestMongoEventSourcedRepository_GetByID_ReplaysOnlyEventsAfterSnapshot(t *testing.T) {
+	// Arrange
+	repository, events, snapshots := newTestMongoRepository()
+	repository.SetSnapshotFrequency(5)
+	aggregate := cqrs.NewBaseAggregate("order-1", "Order")
+	saveOrderEvents(t, repository, aggregate, 6)
+	saveOrderEvents(t, repository, aggregate, 2)
+
+	// Act
+	loaded, err := repository.GetByID(context.Background(), "order-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, snapshots.versions)
+	assert.Equal(t, []int{7}, events.loadedFrom)
+	assert.Equal(t, 8, loaded.Version())
+	assert.Equal(t, 8, loaded.OriginalVersion())
+
+	metrics, err := repository.GetStorageMetrics(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), metrics.EventCount)
+	assert.Equal(t, int64(1), metrics.SnapshotCount)
+	assert.Equal(t, int64(1), metrics.LoadCount)
+	assert.Equal(t, int64(1), metrics.SnapshotHits)
+	assert.Equal(t, 2, metrics.LastReplayedEvents)
+	assert.False(t, metrics.LastAccessed.IsZero())
+}
+
+func TestMongoEventSourcedRepository_GetByID_FallsBackToFullReplay(t *testing.T) {
+	// Arrange
+	repository, events, snapshots := newTestMongoRepository()
+	aggregate := cqrs.NewBaseAggregate("order-1", "Order")
+	saveOrderEvents(t, repository, aggregate, 3)
+	snapshots.loadErr = errors.New("corrupted snapshot")
+
+	// Act
+	loaded, err := repository.GetByID(context.Background(), "order-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, events.loadedFrom)
+	assert.Equal(t, 3, loaded.Version())
+
+	metrics, err := repository.GetStorageMetrics(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), metrics.SnapshotHits)
+	assert.Equal(t, 3, metrics.LastReplayedEvents)
+}
+
+func TestMongoEventSourcedRepository_Save_RejectsOtherAggregateTypes(t *testing.T) {
+	// Arrange
+	repository, _, _ := newTestMongoRepository()
+	aggregate := cqrs.NewBaseAggregate("user-1", "User")
+	require.NoError(t, aggregate.ApplyEvent(cqrs.NewBaseEventMessage("UserCreated")))
+
+	// Act
+	err := repository.Save(context.Background(), aggregate, 0)
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"cqrs"
 	"crypto/sha256"
 	"fmt"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -19,22 +20,24 @@ type MongoSnapshotStore struct {
 	client         *MongoClientManager
 	collectionName string
 	serializer     SnapshotSerializer
+	migrations     *ReadModelMigrations // Snapshot state migrations keyed by aggregate type
 }
 
 // MongoSnapshotDocument represents the enhanced Event Sourcing snapshot schema in MongoDB
 // This is a pre-designed schema that developers don't need to worry about
 type MongoSnapshotDocument struct {
 	ID            primitive.ObjectID     `bson:"_id,omitempty"`
-	string        string                 `bson:"aggregate_id"`   // Aggregate identifier
-	AggregateType string                 `bson:"aggregate_type"` // Type of aggregate
-	SnapshotData  bson.Raw               `bson:"snapshot_data"`  // Serialized aggregate state
-	Version       int                    `bson:"version"`        // Version at which snapshot was taken
-	Timestamp     time.Time              `bson:"timestamp"`      // When snapshot was created
-	Size          int64                  `bson:"size"`           // Size of snapshot data in bytes
-	ContentType   string                 `bson:"content_type"`   // Content type (application/json, application/bson)
-	Compression   string                 `bson:"compression"`    // Compression type (none, gzip)
-	Checksum      string                 `bson:"checksum"`       // Data integrity checksum
-	Metadata      map[string]interface{} `bson:"metadata"`       // Additional metadata
+	string        string                 `bson:"aggregate_id"`     // Aggregate identifier
+	AggregateType string                 `bson:"aggregate_type"`   // Type of aggregate
+	SnapshotData  bson.Raw               `bson:"snapshot_data"`    // Serialized aggregate state
+	Version       int                    `bson:"version"`          // Version at which snapshot was taken
+	Timestamp     time.Time              `bson:"timestamp"`        // When snapshot was created
+	Size          int64                  `bson:"size"`             // Size of snapshot data in bytes
+	ContentType   string                 `bson:"content_type"`     // Content type (application/json, application/bson)
+	Compression   string                 `bson:"compression"`      // Compression type (none, gzip)
+	Checksum      string                 `bson:"checksum"`         // Data integrity checksum
+	Metadata      map[string]interface{} `bson:"metadata"`         // Additional metadata
+	Header        *SnapshotHeader        `bson:"header,omitempty"` // Self-description; nil for snapshots written before headers
 }
 
 // SnapshotSerializer interface for snapshot serialization
@@ -85,6 +88,14 @@ func (s *MongoSnapshotData) Compression() string {
 	return s.doc.Compression
 }
 
+// Header returns how the snapshot was written (reconstructed for snapshots without one)
+func (s *MongoSnapshotData) Header() SnapshotHeader {
+	if s.doc.Header != nil {
+		return *s.doc.Header
+	}
+	return legacySnapshotHeader(s.doc)
+}
+
 // NewMongoSnapshotStore creates a new MongoDB snapshot store with standard schema
 func NewMongoSnapshotStore(client *MongoClientManager, collectionName string) *MongoSnapshotStore {
 	if collectionName == "" {
@@ -116,10 +127,25 @@ func (ss *MongoSnapshotStore) SetSerializer(serializer SnapshotSerializer) {
 	ss.serializer = serializer
 }
 
+// SetSnapshotMigrations registers the aggregate state migrations (ModelType = aggregate type).
+// Their latest version is written into snapshot headers, and older snapshots are upgraded on load.
+func (ss *MongoSnapshotStore) SetSnapshotMigrations(migrations *ReadModelMigrations) {
+	ss.migrations = migrations
+}
+
+// currentHeader is the header this store writes for an aggregate type
+func (ss *MongoSnapshotStore) currentHeader(aggregateType string) SnapshotHeader {
+	schemaVersion := 1
+	if ss.migrations != nil {
+		schemaVersion = ss.migrations.LatestVersion(aggregateType)
+	}
+	return NewSnapshotHeader(ss.serializer, aggregateType, schemaVersion)
+}
+
 // SaveSnapshot saves an aggregate snapshot using standard Event Sourcing pattern
 func (ss *MongoSnapshotStore) SaveSnapshot(ctx context.Context, aggregate cqrs.AggregateRoot) error {
 	if aggregate == nil {
-		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate cannot be nil", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate cannot be nil", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := ss.client.GetCollection(ss.collectionName)
@@ -144,6 +170,7 @@ func (ss *MongoSnapshotStore) SaveSnapshot(ctx context.Context, aggregate cqrs.A
 		}
 
 		// Create enhanced snapshot document
+		header := ss.currentHeader(aggregate.Type())
 		doc := MongoSnapshotDocument{
 			string:        aggregate.ID(),
 			AggregateType: aggregate.Type(),
@@ -155,6 +182,7 @@ func (ss *MongoSnapshotStore) SaveSnapshot(ctx context.Context, aggregate cqrs.A
 			Compression:   getCompressionType(ss.serializer),
 			Checksum:      calculateChecksum(snapshotData),
 			Metadata:      metadata,
+			Header:        &header,
 		}
 
 		// Upsert snapshot (replace existing snapshot for this aggregate)
@@ -179,11 +207,11 @@ func (ss *MongoSnapshotStore) SaveSnapshot(ctx context.Context, aggregate cqrs.A
 // LoadSnapshot loads an aggregate snapshot using standard Event Sourcing pattern
 func (ss *MongoSnapshotStore) LoadSnapshot(ctx context.Context, aggregateID, aggregateType string) (cqrs.AggregateRoot, error) {
 	if aggregateID == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := ss.client.GetCollection(ss.collectionName)
@@ -207,8 +235,13 @@ func (ss *MongoSnapshotStore) LoadSnapshot(ctx context.Context, aggregateID, agg
 				fmt.Sprintf("failed to find snapshot: %v", err), err)
 		}
 
+		data, err := ss.compatibleSnapshotData(ctx, &doc)
+		if err != nil {
+			return err
+		}
+
 		// Deserialize snapshot using developer-provided or default serializer
-		aggregate, err = ss.serializer.DeserializeSnapshot([]byte(doc.SnapshotData), aggregateType)
+		aggregate, err = ss.serializer.DeserializeSnapshot(data, aggregateType)
 		if err != nil {
 			return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(),
 				fmt.Sprintf("failed to deserialize snapshot: %v", err), err)
@@ -220,14 +253,56 @@ func (ss *MongoSnapshotStore) LoadSnapshot(ctx context.Context, aggregateID, agg
 	return aggregate, err
 }
 
+// compatibleSnapshotData checks the stored header against the running code and returns the
+// snapshot bytes in the current encoding. Snapshots written by older code are re-serialized
+// and written back, so the conversion runs once per snapshot; incompatible snapshots are
+// reported as not found so the repository rebuilds the aggregate from its events.
+func (ss *MongoSnapshotStore) compatibleSnapshotData(ctx context.Context, doc *MongoSnapshotDocument) ([]byte, error) {
+	stored := legacySnapshotHeader(doc)
+	if doc.Header != nil {
+		stored = *doc.Header
+	}
+	current := ss.currentHeader(doc.AggregateType)
+
+	compatibility, reason := CheckSnapshotCompatibility(stored, current)
+	switch compatibility {
+	case SnapshotCompatible:
+		return []byte(doc.SnapshotData), nil
+	case SnapshotIncompatible:
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotNotFound.String(),
+			fmt.Sprintf("snapshot %s/%s is incompatible: %s", doc.AggregateType, doc.string, reason), cqrs.ErrSnapshotNotFound)
+	}
+
+	data, err := ReserializeSnapshot([]byte(doc.SnapshotData), stored, current, ss.migrations)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(),
+			fmt.Sprintf("failed to re-serialize snapshot %s/%s: %v", doc.AggregateType, doc.string, err), err)
+	}
+
+	// Write the converted snapshot back only if nobody replaced it meanwhile
+	filter := bson.M{"_id": doc.ID, "version": doc.Version, "checksum": doc.Checksum}
+	update := bson.M{"$set": bson.M{
+		"snapshot_data": bson.Raw(data),
+		"size":          int64(len(data)),
+		"content_type":  current.ContentType,
+		"compression":   current.Compression,
+		"checksum":      calculateChecksum(data),
+		"header":        current,
+	}}
+	if _, err := ss.client.GetCollection(ss.collectionName).UpdateOne(ctx, filter, update); err != nil {
+		log.Printf("Failed to store re-serialized snapshot %s/%s: %v (%s)", doc.AggregateType, doc.string, err, reason)
+	}
+	return data, nil
+}
+
 // GetSnapshotVersion gets the version of the latest snapshot
 func (ss *MongoSnapshotStore) GetSnapshotVersion(ctx context.Context, aggregateID, aggregateType string) (int, error) {
 	if aggregateID == "" {
-		return -1, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil)
+		return -1, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return -1, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil)
+		return -1, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := ss.client.GetCollection(ss.collectionName)
@@ -260,11 +335,11 @@ func (ss *MongoSnapshotStore) GetSnapshotVersion(ctx context.Context, aggregateI
 // DeleteSnapshot deletes a snapshot
 func (ss *MongoSnapshotStore) DeleteSnapshot(ctx context.Context, aggregateID, aggregateType string) error {
 	if aggregateID == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if aggregateType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := ss.client.GetCollection(ss.collectionName)
@@ -310,7 +385,7 @@ func (ss *MongoSnapshotStore) SnapshotExists(ctx context.Context, aggregateID, a
 // ListSnapshots lists snapshots by aggregate type with pagination
 func (ss *MongoSnapshotStore) ListSnapshots(ctx context.Context, aggregateType string, limit, offset int) ([]cqrs.AggregateRoot, error) {
 	if aggregateType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSnapshotStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	collection := ss.client.GetCollection(ss.collectionName)
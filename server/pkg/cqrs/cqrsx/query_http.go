@@ -0,0 +1,109 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// QueryRequestDecoder builds the query for an HTTP request
+type QueryRequestDecoder func(r *http.Request) (cqrs.Query, error)
+
+// ReadModelQueryHandler serves read model queries over GET with conditional requests: the
+// response carries the result's ETag, and a request whose If-None-Match still matches is
+// answered with 304 Not Modified and no body. Wrap the dispatcher with
+// cqrs.NewETagQueryDispatcher (or let handlers set the ETag) so results carry one.
+func ReadModelQueryHandler(dispatcher cqrs.QueryDispatcher, decode QueryRequestDecoder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query, err := decode(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx := cqrs.WithIfNoneMatch(r.Context(), r.Header.Get("If-None-Match"))
+		result, err := dispatcher.Dispatch(ctx, query)
+		if err == nil && result != nil && !result.Success {
+			err = result.Error
+		}
+		if err != nil || result == nil {
+			if err == nil {
+				err = cqrs.NewCQRSError(cqrs.ErrCodeQueryValidation.String(), "query returned no result", nil)
+			}
+			http.Error(w, err.Error(), queryErrorStatus(err))
+			return
+		}
+
+		if etag := result.ETag(); etag != "" {
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Cache-Control", "no-cache") // Clients revalidate with If-None-Match
+		}
+		if result.NotModified() {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodHead {
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":        readModelViews(result.Data),
+			"total_count": result.TotalCount,
+			"page":        result.Page,
+			"page_size":   result.PageSize,
+		})
+	})
+}
+
+func queryErrorStatus(err error) int {
+	switch {
+	case cqrs.IsValidationError(err):
+		return http.StatusBadRequest
+	case cqrs.IsNotFoundError(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// readModelView is the JSON form of a read model, whose implementations usually keep
+// their fields unexported
+type readModelView struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	Version     int         `json:"version"`
+	LastUpdated time.Time   `json:"last_updated"`
+	Data        interface{} `json:"data"`
+}
+
+func newReadModelView(model cqrs.ReadModel) readModelView {
+	return readModelView{
+		ID:          model.GetID(),
+		Type:        model.GetType(),
+		Version:     model.GetVersion(),
+		LastUpdated: model.GetLastUpdated(),
+		Data:        model.GetData(),
+	}
+}
+
+// readModelViews converts read model results to views and leaves other data as it is
+func readModelViews(data interface{}) interface{} {
+	switch value := data.(type) {
+	case cqrs.ReadModel:
+		return newReadModelView(value)
+	case []cqrs.ReadModel:
+		views := make([]readModelView, 0, len(value))
+		for _, model := range value {
+			views = append(views, newReadModelView(model))
+		}
+		return views
+	default:
+		return data
+	}
+}
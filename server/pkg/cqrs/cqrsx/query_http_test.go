@@ -0,0 +1,62 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadModelQueryHandler_ConditionalGet(t *testing.T) {
+	// Arrange
+	store := cqrs.NewInMemoryReadStore()
+	model := cqrs.NewBaseReadModel("guild-1", "Guild", map[string]interface{}{"name": "Defenders"})
+	require.NoError(t, store.Save(context.Background(), model))
+	inner := cqrs.NewInMemoryQueryDispatcher()
+	require.NoError(t, cqrs.RegisterQueryHandler(inner, "GetGuild", func(ctx context.Context, query *cqrs.BaseQuery) (cqrs.ReadModel, error) {
+		return store.GetByID(ctx, query.GetCriteria().(string), "Guild")
+	}))
+	handler := ReadModelQueryHandler(cqrs.NewETagQueryDispatcher(inner), func(r *http.Request) (cqrs.Query, error) {
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			return nil, errors.New("id is required")
+		}
+		return cqrs.NewBaseQuery("GetGuild", id), nil
+	})
+	get := func(url, ifNoneMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Act
+	first := get("/guilds?id=guild-1", "")
+	etag := first.Header().Get("ETag")
+	notModified := get("/guilds?id=guild-1", etag)
+	model.SetData(map[string]interface{}{"name": "Defenders of Dawn"}) // 새 버전
+	modified := get("/guilds?id=guild-1", etag)
+	missing := get("/guilds?id=guild-404", "")
+	invalid := get("/guilds", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "no-cache", first.Header().Get("Cache-Control"))
+	assert.Contains(t, first.Body.String(), "Defenders")
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+	assert.Equal(t, etag, notModified.Header().Get("ETag"))
+	require.Equal(t, http.StatusOK, modified.Code)
+	assert.NotEqual(t, etag, modified.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
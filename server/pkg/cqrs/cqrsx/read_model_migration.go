@@ -0,0 +1,396 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReadModelSchemaVersionField is the JSON field that stores a serialized read model's schema version.
+// Documents without it are treated as version 1.
+const ReadModelSchemaVersionField = "_schema_version"
+
+// ReadModelMigrationFunc transforms a serialized read model document in place
+type ReadModelMigrationFunc func(doc map[string]interface{}) error
+
+// ReadModelMigration upgrades one model type from FromVersion to FromVersion+1
+type ReadModelMigration struct {
+	ModelType   string
+	FromVersion int
+	Description string
+	Migrate     ReadModelMigrationFunc
+}
+
+// RenameField returns a migration step that renames a top-level field
+func RenameField(from, to string) ReadModelMigrationFunc {
+	return func(doc map[string]interface{}) error {
+		if value, exists := doc[from]; exists {
+			doc[to] = value
+			delete(doc, from)
+		}
+		return nil
+	}
+}
+
+// SetDefault returns a migration step that sets a field when it is missing
+func SetDefault(field string, value interface{}) ReadModelMigrationFunc {
+	return func(doc map[string]interface{}) error {
+		if _, exists := doc[field]; !exists {
+			doc[field] = value
+		}
+		return nil
+	}
+}
+
+// RemoveField returns a migration step that deletes a top-level field
+func RemoveField(field string) ReadModelMigrationFunc {
+	return func(doc map[string]interface{}) error {
+		delete(doc, field)
+		return nil
+	}
+}
+
+// ChainMigrations combines several steps into one migration function
+func ChainMigrations(steps ...ReadModelMigrationFunc) ReadModelMigrationFunc {
+	return func(doc map[string]interface{}) error {
+		for _, step := range steps {
+			if err := step(doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ReadModelMigrations holds the ordered migrations declared for each read model type
+type ReadModelMigrations struct {
+	mu         sync.RWMutex
+	migrations map[string][]ReadModelMigration
+}
+
+// NewReadModelMigrations creates an empty migration registry
+func NewReadModelMigrations() *ReadModelMigrations {
+	return &ReadModelMigrations{
+		migrations: make(map[string][]ReadModelMigration),
+	}
+}
+
+// Register adds a migration. Migrations must be registered in order starting at version 1.
+func (m *ReadModelMigrations) Register(migration ReadModelMigration) error {
+	if migration.ModelType == "" {
+		return cqrs.NewValidationError("migration model type cannot be empty", nil)
+	}
+	if migration.Migrate == nil {
+		return cqrs.NewValidationError("migration function cannot be nil", nil)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expected := len(m.migrations[migration.ModelType]) + 1
+	if migration.FromVersion != expected {
+		return cqrs.NewValidationError(fmt.Sprintf("migration for %s must start at version %d, got %d",
+			migration.ModelType, expected, migration.FromVersion), nil)
+	}
+
+	m.migrations[migration.ModelType] = append(m.migrations[migration.ModelType], migration)
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func (m *ReadModelMigrations) MustRegister(migration ReadModelMigration) {
+	if err := m.Register(migration); err != nil {
+		panic(err)
+	}
+}
+
+// LatestVersion returns the current schema version of a model type
+func (m *ReadModelMigrations) LatestVersion(modelType string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.migrations[modelType]) + 1
+}
+
+// ModelTypes returns all model types with declared migrations
+func (m *ReadModelMigrations) ModelTypes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	types := make([]string, 0, len(m.migrations))
+	for modelType := range m.migrations {
+		types = append(types, modelType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Upgrade applies pending migrations to a serialized document.
+// It returns the upgraded bytes and whether any migration ran.
+func (m *ReadModelMigrations) Upgrade(modelType string, data []byte) ([]byte, bool, error) {
+	m.mu.RLock()
+	pending := m.migrations[modelType]
+	m.mu.RUnlock()
+
+	if len(pending) == 0 {
+		return data, false, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to decode %s for migration", modelType), err)
+	}
+
+	version := schemaVersionOfDocument(doc)
+	if version > len(pending) {
+		return data, false, nil
+	}
+
+	latest, err := m.MigrateDocument(modelType, doc, version)
+	if err != nil {
+		return nil, false, err
+	}
+	doc[ReadModelSchemaVersionField] = latest
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to encode migrated %s", modelType), err)
+	}
+	return upgraded, true, nil
+}
+
+// MigrateDocument applies the migrations after fromVersion to a decoded document in place
+// and returns the version it reached. Callers that keep the schema version outside the
+// document (snapshot headers) use it directly instead of Upgrade.
+func (m *ReadModelMigrations) MigrateDocument(modelType string, doc map[string]interface{}, fromVersion int) (int, error) {
+	m.mu.RLock()
+	pending := m.migrations[modelType]
+	m.mu.RUnlock()
+
+	if fromVersion < 1 {
+		fromVersion = 1
+	}
+	if fromVersion > len(pending) {
+		return fromVersion, nil
+	}
+
+	for _, migration := range pending[fromVersion-1:] {
+		if err := migration.Migrate(doc); err != nil {
+			return 0, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+				fmt.Sprintf("failed to migrate %s from version %d", modelType, migration.FromVersion), err)
+		}
+	}
+	return len(pending) + 1, nil
+}
+
+// Stamp writes the latest schema version into a serialized document
+func (m *ReadModelMigrations) Stamp(modelType string, data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("failed to decode %s for version stamping", modelType), err)
+	}
+	doc[ReadModelSchemaVersionField] = m.LatestVersion(modelType)
+	return json.Marshal(doc)
+}
+
+func schemaVersionOfDocument(doc map[string]interface{}) int {
+	if version, ok := doc[ReadModelSchemaVersionField].(float64); ok && version >= 1 {
+		return int(version)
+	}
+	return 1
+}
+
+// MigratingReadModelSerializer wraps a JSON ReadModelSerializer and migrates documents lazily on load.
+// Saved documents are stamped with the latest schema version.
+type MigratingReadModelSerializer struct {
+	inner      ReadModelSerializer
+	migrations *ReadModelMigrations
+}
+
+var _ ReadModelSerializer = (*MigratingReadModelSerializer)(nil)
+
+// NewMigratingReadModelSerializer creates a serializer that upgrades documents on read
+func NewMigratingReadModelSerializer(inner ReadModelSerializer, migrations *ReadModelMigrations) *MigratingReadModelSerializer {
+	return &MigratingReadModelSerializer{inner: inner, migrations: migrations}
+}
+
+// SerializeReadModel serializes with the inner serializer and stamps the schema version
+func (s *MigratingReadModelSerializer) SerializeReadModel(model cqrs.ReadModel) ([]byte, error) {
+	data, err := s.inner.SerializeReadModel(model)
+	if err != nil {
+		return nil, err
+	}
+	return s.migrations.Stamp(model.GetType(), data)
+}
+
+// DeserializeReadModel upgrades the document to the latest schema before deserializing
+func (s *MigratingReadModelSerializer) DeserializeReadModel(data []byte, modelType string) (cqrs.ReadModel, error) {
+	upgraded, _, err := s.migrations.Upgrade(modelType, data)
+	if err != nil {
+		return nil, err
+	}
+	return s.inner.DeserializeReadModel(upgraded, modelType)
+}
+
+// ReadModelMigrationRecord records an eager migration run for a model type
+type ReadModelMigrationRecord struct {
+	ModelType string    `json:"model_type"`
+	Version   int       `json:"version"`
+	Migrated  int       `json:"migrated"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// ReadModelMigrationLog persists which schema version has been applied to each model type
+type ReadModelMigrationLog interface {
+	RecordApplied(ctx context.Context, record ReadModelMigrationRecord) error
+	AppliedVersion(ctx context.Context, modelType string) (int, error)
+	History(ctx context.Context) ([]ReadModelMigrationRecord, error)
+}
+
+// InMemoryReadModelMigrationLog is a ReadModelMigrationLog kept in memory
+type InMemoryReadModelMigrationLog struct {
+	mu      sync.RWMutex
+	records []ReadModelMigrationRecord
+	applied map[string]int
+}
+
+// NewInMemoryReadModelMigrationLog creates an empty in-memory migration log
+func NewInMemoryReadModelMigrationLog() *InMemoryReadModelMigrationLog {
+	return &InMemoryReadModelMigrationLog{applied: make(map[string]int)}
+}
+
+func (l *InMemoryReadModelMigrationLog) RecordApplied(ctx context.Context, record ReadModelMigrationRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+	if record.Version > l.applied[record.ModelType] {
+		l.applied[record.ModelType] = record.Version
+	}
+	return nil
+}
+
+func (l *InMemoryReadModelMigrationLog) AppliedVersion(ctx context.Context, modelType string) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.applied[modelType], nil
+}
+
+func (l *InMemoryReadModelMigrationLog) History(ctx context.Context) ([]ReadModelMigrationRecord, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]ReadModelMigrationRecord(nil), l.records...), nil
+}
+
+// ReadModelMigrator eagerly rewrites stored read models to the latest schema.
+// The store must be configured with a MigratingReadModelSerializer so loads are upgraded.
+type ReadModelMigrator struct {
+	store      cqrs.ReadStore
+	migrations *ReadModelMigrations
+	log        ReadModelMigrationLog
+}
+
+// NewReadModelMigrator creates an eager migrator
+func NewReadModelMigrator(store cqrs.ReadStore, migrations *ReadModelMigrations, log ReadModelMigrationLog) *ReadModelMigrator {
+	if log == nil {
+		log = NewInMemoryReadModelMigrationLog()
+	}
+	return &ReadModelMigrator{store: store, migrations: migrations, log: log}
+}
+
+// MigrateModelType loads every read model of the type and saves it back at the latest version.
+// It is a no-op when the log shows the latest version was already applied.
+func (m *ReadModelMigrator) MigrateModelType(ctx context.Context, modelType string) (*ReadModelMigrationRecord, error) {
+	latest := m.migrations.LatestVersion(modelType)
+
+	applied, err := m.log.AppliedVersion(ctx, modelType)
+	if err != nil {
+		return nil, err
+	}
+	if applied >= latest {
+		return &ReadModelMigrationRecord{ModelType: modelType, Version: applied}, nil
+	}
+
+	models, err := m.store.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": modelType},
+	})
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+			fmt.Sprintf("failed to load %s read models for migration", modelType), err)
+	}
+
+	migrated := make([]cqrs.ReadModel, 0, len(models))
+	for _, model := range models {
+		if model.GetType() == modelType {
+			migrated = append(migrated, model)
+		}
+	}
+
+	if len(migrated) > 0 {
+		if err := m.store.SaveBatch(ctx, migrated); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeReadStoreError.String(),
+				fmt.Sprintf("failed to save migrated %s read models", modelType), err)
+		}
+	}
+
+	record := ReadModelMigrationRecord{
+		ModelType: modelType,
+		Version:   latest,
+		Migrated:  len(migrated),
+		AppliedAt: time.Now(),
+	}
+	if err := m.log.RecordApplied(ctx, record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// MigrateAll migrates every model type with declared migrations
+func (m *ReadModelMigrator) MigrateAll(ctx context.Context) ([]ReadModelMigrationRecord, error) {
+	var records []ReadModelMigrationRecord
+	for _, modelType := range m.migrations.ModelTypes() {
+		record, err := m.MigrateModelType(ctx, modelType)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+// ServeHTTP exposes the migrator as an admin endpoint:
+// GET returns the migration history, POST runs migrations (optionally ?model_type=GuildView).
+func (m *ReadModelMigrator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch r.Method {
+	case http.MethodGet:
+		result, err = m.log.History(r.Context())
+	case http.MethodPost:
+		if modelType := r.URL.Query().Get("model_type"); modelType != "" {
+			result, err = m.MigrateModelType(r.Context(), modelType)
+		} else {
+			result, err = m.MigrateAll(r.Context())
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,221 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTestView struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"display_name"`
+	Level       int       `json:"level"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (v *migrationTestView) GetID() string             { return v.ID }
+func (v *migrationTestView) GetType() string           { return "MigrationTestView" }
+func (v *migrationTestView) GetVersion() int           { return 1 }
+func (v *migrationTestView) GetData() interface{}      { return v }
+func (v *migrationTestView) GetLastUpdated() time.Time { return v.UpdatedAt }
+func (v *migrationTestView) Validate() error           { return nil }
+
+// serializedReadStore keeps raw serialized documents like Redis/MongoDB read stores do
+type serializedReadStore struct {
+	cqrs.ReadStore
+	serializer ReadModelSerializer
+	docs       map[string][]byte
+	modelType  string
+}
+
+func (s *serializedReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.ReadModel, error) {
+	if modelType, ok := criteria.Filters["type"]; !ok || modelType != s.modelType {
+		return nil, nil
+	}
+	var models []cqrs.ReadModel
+	for _, data := range s.docs {
+		model, err := s.serializer.DeserializeReadModel(data, s.modelType)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+func (s *serializedReadStore) SaveBatch(ctx context.Context, models []cqrs.ReadModel) error {
+	for _, model := range models {
+		data, err := s.serializer.SerializeReadModel(model)
+		if err != nil {
+			return err
+		}
+		s.docs[model.GetID()] = data
+	}
+	return nil
+}
+
+func newMigrationTestRegistry(t *testing.T) *ReadModelMigrations {
+	cqrs.RegisterReadModelType("MigrationTestView", reflect.TypeOf(&migrationTestView{}))
+
+	migrations := NewReadModelMigrations()
+	require.NoError(t, migrations.Register(ReadModelMigration{
+		ModelType:   "MigrationTestView",
+		FromVersion: 1,
+		Description: "rename name to display_name",
+		Migrate:     RenameField("name", "display_name"),
+	}))
+	require.NoError(t, migrations.Register(ReadModelMigration{
+		ModelType:   "MigrationTestView",
+		FromVersion: 2,
+		Description: "add level",
+		Migrate:     SetDefault("level", 1),
+	}))
+	return migrations
+}
+
+func TestReadModelMigrations_Register_RequiresContiguousVersions(t *testing.T) {
+	// Arrange
+	migrations := NewReadModelMigrations()
+
+	// Act
+	err := migrations.Register(ReadModelMigration{ModelType: "GuildView", FromVersion: 2, Migrate: RemoveField("x")})
+
+	// Assert
+	assert.True(t, cqrs.IsValidationError(err))
+	assert.Equal(t, 1, migrations.LatestVersion("GuildView"))
+}
+
+func TestReadModelMigrations_Upgrade(t *testing.T) {
+	// Arrange
+	migrations := newMigrationTestRegistry(t)
+	v1 := []byte(`{"id":"g1","name":"Allies"}`)
+
+	// Act
+	upgraded, changed, err := migrations.Upgrade("MigrationTestView", v1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, changed)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(upgraded, &doc))
+	assert.Equal(t, "Allies", doc["display_name"])
+	assert.NotContains(t, doc, "name")
+	assert.Equal(t, float64(1), doc["level"])
+	assert.Equal(t, float64(3), doc[ReadModelSchemaVersionField])
+
+	_, changed, err = migrations.Upgrade("MigrationTestView", upgraded)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestMigratingReadModelSerializer_LazyUpgrade(t *testing.T) {
+	// Arrange
+	serializer := NewMigratingReadModelSerializer(&JSONReadModelSerializer{}, newMigrationTestRegistry(t))
+
+	// Act
+	model, err := serializer.DeserializeReadModel([]byte(`{"id":"g1","name":"Allies"}`), "MigrationTestView")
+
+	// Assert
+	require.NoError(t, err)
+	view := model.(*migrationTestView)
+	assert.Equal(t, "Allies", view.DisplayName)
+	assert.Equal(t, 1, view.Level)
+
+	data, err := serializer.SerializeReadModel(view)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_schema_version":3`)
+}
+
+// legacyMigrationTestView is MigrationTestView as stored before the schema migrations
+type legacyMigrationTestView struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (v *legacyMigrationTestView) GetID() string             { return v.ID }
+func (v *legacyMigrationTestView) GetType() string           { return "MigrationTestView" }
+func (v *legacyMigrationTestView) GetVersion() int           { return 1 }
+func (v *legacyMigrationTestView) GetData() interface{}      { return v }
+func (v *legacyMigrationTestView) GetLastUpdated() time.Time { return time.Time{} }
+func (v *legacyMigrationTestView) Validate() error           { return nil }
+
+func TestReadModelMigrator_MigrateModelType(t *testing.T) {
+	// Arrange: 이전 스키마로 저장된 읽기 모델이 있는 실제 Redis 읽기 저장소
+	ctx := context.Background()
+	migrations := newMigrationTestRegistry(t)
+	cqrs.RegisterReadModelType("StreamUserView", reflect.TypeOf(&streamTestView{}))
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	manager := &RedisClientManager{client: client, metrics: &RedisMetrics{}}
+
+	legacy := NewRedisReadStore(manager, "test", &JSONReadModelSerializer{})
+	require.NoError(t, legacy.Save(ctx, &legacyMigrationTestView{ID: "g1", Name: "Allies"}))
+	require.NoError(t, legacy.Save(ctx, &legacyMigrationTestView{ID: "g2", Name: "Axis"}))
+	require.NoError(t, legacy.Save(ctx, &streamTestView{ID: "user-1", Kind: "StreamUserView"})) // 다른 타입은 건드리지 않음
+
+	store := NewRedisReadStore(manager, "test", NewMigratingReadModelSerializer(&JSONReadModelSerializer{}, migrations))
+	log := NewInMemoryReadModelMigrationLog()
+	migrator := NewReadModelMigrator(store, migrations, log)
+
+	// Act
+	record, err := migrator.MigrateModelType(ctx, "MigrationTestView")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, record.Version)
+	assert.Equal(t, 2, record.Migrated)
+
+	raw, err := client.Get(ctx, store.keyBuilder.ReadModelKey("MigrationTestView", "g2")).Result()
+	require.NoError(t, err)
+	assert.Contains(t, raw, `"display_name":"Axis"`)
+	assert.Contains(t, raw, `"_schema_version":3`)
+
+	migrated, err := store.GetByID(ctx, "g1", "MigrationTestView")
+	require.NoError(t, err)
+	assert.Equal(t, "Allies", migrated.(*migrationTestView).DisplayName)
+	assert.Equal(t, 1, migrated.(*migrationTestView).Level)
+
+	applied, err := log.AppliedVersion(ctx, "MigrationTestView")
+	require.NoError(t, err)
+	assert.Equal(t, 3, applied)
+
+	again, err := migrator.MigrateModelType(ctx, "MigrationTestView")
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Migrated)
+}
+
+func TestReadModelMigrator_ServeHTTP(t *testing.T) {
+	// Arrange
+	migrations := newMigrationTestRegistry(t)
+	store := &serializedReadStore{
+		serializer: NewMigratingReadModelSerializer(&JSONReadModelSerializer{}, migrations),
+		docs:       map[string][]byte{"g1": []byte(`{"id":"g1","name":"Allies"}`)},
+		modelType:  "MigrationTestView",
+	}
+	migrator := NewReadModelMigrator(store, migrations, nil)
+
+	// Act
+	post := httptest.NewRecorder()
+	migrator.ServeHTTP(post, httptest.NewRequest(http.MethodPost, "/admin/read-model-migrations?model_type=MigrationTestView", nil))
+	get := httptest.NewRecorder()
+	migrator.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/admin/read-model-migrations", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, post.Code)
+	var history []ReadModelMigrationRecord
+	require.NoError(t, json.Unmarshal(get.Body.Bytes(), &history))
+	require.Len(t, history, 1)
+	assert.Equal(t, 1, history[0].Migrated)
+}
@@ -0,0 +1,31 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+)
+
+// RedactingExportSource masks personal data in exported event data and metadata
+type RedactingExportSource struct {
+	source EventExportSource
+	policy *cqrs.RedactionPolicy
+}
+
+// NewRedactingExportSource wraps source so every exported event passes through policy.
+// Exports requested with a redaction-exempt context are left untouched.
+func NewRedactingExportSource(source EventExportSource, policy *cqrs.RedactionPolicy) *RedactingExportSource {
+	return &RedactingExportSource{source: source, policy: policy}
+}
+
+func (s *RedactingExportSource) ExportEvents(ctx context.Context, filter EventExportFilter, fn func(*ExportedEvent) error) error {
+	if cqrs.IsRedactionExempt(ctx) {
+		return s.source.ExportEvents(ctx, filter, fn)
+	}
+
+	return s.source.ExportEvents(ctx, filter, func(event *ExportedEvent) error {
+		redacted := *event
+		redacted.Data = s.policy.RedactMap(event.Data)
+		redacted.Metadata = s.policy.RedactMap(event.Metadata)
+		return fn(&redacted)
+	})
+}
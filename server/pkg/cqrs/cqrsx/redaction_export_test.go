@@ -0,0 +1,39 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingExportSource_MasksDataAndMetadata(t *testing.T) {
+	// Arrange
+	policy := cqrs.NewRedactionPolicy()
+	require.NoError(t, policy.RedactField("email", "email"))
+	require.NoError(t, policy.RedactField("client_ip", "full"))
+	event := &ExportedEvent{
+		EventID:   "evt-1",
+		EventType: "UserRegistered",
+		Data:      map[string]interface{}{"email": "jane@example.com", "nickname": "jane"},
+		Metadata:  map[string]interface{}{"client_ip": "10.0.0.1"},
+	}
+	source := NewRedactingExportSource(&sliceExportSource{events: []*ExportedEvent{event}}, policy)
+
+	// Act
+	var exported []*ExportedEvent
+	err := source.ExportEvents(context.Background(), EventExportFilter{}, func(e *ExportedEvent) error {
+		exported = append(exported, e)
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.Equal(t, "j***@example.com", exported[0].Data["email"])
+	assert.Equal(t, "jane", exported[0].Data["nickname"])
+	assert.Equal(t, "***", exported[0].Metadata["client_ip"])
+	assert.Equal(t, "jane@example.com", event.Data["email"])
+}
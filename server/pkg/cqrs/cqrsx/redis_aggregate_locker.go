@@ -0,0 +1,202 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireLockScript sets the lock key if free and returns the node's fencing counter, or -1
+var acquireLockScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return tonumber(redis.call('GET', KEYS[2]) or '0')
+end
+return -1
+`)
+
+// advanceFenceScript raises the node's fencing counter to ARGV[1] if it is lower
+var advanceFenceScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// refreshLockScript extends the lock only while it is still owned by ARGV[1]
+var refreshLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lock only while it is still owned by ARGV[1]
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisAggregateLockerConfig configures a RedisAggregateLocker
+type RedisAggregateLockerConfig struct {
+	KeyPrefix  string        // Key prefix, default "lock"
+	RetryDelay time.Duration // Base delay between Acquire attempts, jittered, default 50ms
+	ClockDrift float64       // Fraction of the TTL reserved for clock drift, default 0.01
+}
+
+// RedisAggregateLocker implements cqrs.AggregateLocker with the Redlock algorithm over
+// one or more independent Redis nodes; a lock is held once a majority of nodes grant it.
+//
+// Fencing tokens are kept per node: acquisition reads the counters of the nodes that
+// granted the lock, takes the maximum plus one and writes it back to them. Because any
+// two majorities overlap, a new token is always greater than the previous holder's.
+type RedisAggregateLocker struct {
+	clients []redis.UniversalClient
+	config  RedisAggregateLockerConfig
+}
+
+// NewRedisAggregateLocker creates a locker over independent Redis nodes (not replicas of one another)
+func NewRedisAggregateLocker(config RedisAggregateLockerConfig, clients ...redis.UniversalClient) (*RedisAggregateLocker, error) {
+	if len(clients) == 0 {
+		return nil, cqrs.NewValidationError("at least one redis client is required", nil)
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "lock"
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 50 * time.Millisecond
+	}
+	if config.ClockDrift <= 0 {
+		config.ClockDrift = 0.01
+	}
+	return &RedisAggregateLocker{clients: clients, config: config}, nil
+}
+
+func (l *RedisAggregateLocker) quorum() int {
+	return len(l.clients)/2 + 1
+}
+
+func (l *RedisAggregateLocker) lockKey(aggregateType, aggregateID string) string {
+	return fmt.Sprintf("%s:%s:%s", l.config.KeyPrefix, aggregateType, aggregateID)
+}
+
+func (l *RedisAggregateLocker) fenceKey(aggregateType, aggregateID string) string {
+	return fmt.Sprintf("%s:%s:%s:fence", l.config.KeyPrefix, aggregateType, aggregateID)
+}
+
+// Acquire retries TryAcquire until the lock is obtained or ctx is done
+func (l *RedisAggregateLocker) Acquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (cqrs.AggregateLock, error) {
+	for {
+		lock, err := l.TryAcquire(ctx, aggregateType, aggregateID, ttl)
+		if err == nil || !cqrs.IsConcurrencyError(err) {
+			return lock, err
+		}
+
+		delay := l.config.RetryDelay/2 + time.Duration(rand.Int63n(int64(l.config.RetryDelay)))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// TryAcquire makes a single Redlock attempt
+func (l *RedisAggregateLocker) TryAcquire(ctx context.Context, aggregateType, aggregateID string, ttl time.Duration) (cqrs.AggregateLock, error) {
+	if aggregateType == "" || aggregateID == "" {
+		return nil, cqrs.NewValidationError("aggregate type and id are required", nil)
+	}
+	if ttl <= 0 {
+		return nil, cqrs.NewValidationError("lock ttl must be positive", nil)
+	}
+
+	lock := &redisAggregateLock{
+		locker:        l,
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		key:           l.lockKey(aggregateType, aggregateID),
+		owner:         uuid.NewString(),
+	}
+	fenceKey := l.fenceKey(aggregateType, aggregateID)
+
+	start := time.Now()
+	var granted []redis.UniversalClient
+	var maxFence int64
+	for _, client := range l.clients {
+		fence, err := acquireLockScript.Run(ctx, client, []string{lock.key, fenceKey}, lock.owner, ttl.Milliseconds()).Int64()
+		if err != nil || fence < 0 {
+			continue
+		}
+		granted = append(granted, client)
+		if fence > maxFence {
+			maxFence = fence
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*l.config.ClockDrift) + 2*time.Millisecond
+	validity := ttl - time.Since(start) - drift
+	if len(granted) < l.quorum() || validity <= 0 {
+		lock.releaseOn(context.WithoutCancel(ctx), l.clients)
+		return nil, cqrs.NewConcurrencyError(
+			fmt.Sprintf("aggregate %s:%s is locked", aggregateType, aggregateID), cqrs.ErrAggregateLocked)
+	}
+
+	lock.token = maxFence + 1
+	fenced := 0
+	for _, client := range granted {
+		if err := advanceFenceScript.Run(ctx, client, []string{fenceKey}, lock.token).Err(); err == nil {
+			fenced++
+		}
+	}
+	if fenced < l.quorum() {
+		lock.releaseOn(context.WithoutCancel(ctx), l.clients)
+		return nil, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError,
+			fmt.Sprintf("failed to record fencing token for %s", lock.key), nil)
+	}
+	return lock, nil
+}
+
+type redisAggregateLock struct {
+	locker        *RedisAggregateLocker
+	aggregateType string
+	aggregateID   string
+	key           string
+	owner         string
+	token         int64
+}
+
+func (l *redisAggregateLock) AggregateType() string { return l.aggregateType }
+func (l *redisAggregateLock) AggregateID() string   { return l.aggregateID }
+func (l *redisAggregateLock) Token() int64          { return l.token }
+
+// Refresh extends the lock on every node and fails unless a majority still hold it
+func (l *redisAggregateLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	extended := 0
+	for _, client := range l.locker.clients {
+		if n, err := refreshLockScript.Run(ctx, client, []string{l.key}, l.owner, ttl.Milliseconds()).Int64(); err == nil && n == 1 {
+			extended++
+		}
+	}
+	if extended < l.locker.quorum() {
+		return cqrs.NewConcurrencyError(fmt.Sprintf("lock on %s was lost", l.key), cqrs.ErrAggregateLockLost)
+	}
+	return nil
+}
+
+func (l *redisAggregateLock) Release(ctx context.Context) error {
+	l.releaseOn(ctx, l.locker.clients)
+	return nil
+}
+
+func (l *redisAggregateLock) releaseOn(ctx context.Context, clients []redis.UniversalClient) {
+	for _, client := range clients {
+		releaseLockScript.Run(ctx, client, []string{l.key}, l.owner)
+	}
+}
@@ -0,0 +1,90 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLockNodes(t *testing.T, count int) ([]*miniredis.Miniredis, []redis.UniversalClient) {
+	t.Helper()
+	var servers []*miniredis.Miniredis
+	var clients []redis.UniversalClient
+	for i := 0; i < count; i++ {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		servers = append(servers, server)
+		clients = append(clients, client)
+	}
+	return servers, clients
+}
+
+func TestRedisAggregateLocker_ExclusiveWithIncreasingTokens(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	_, clients := newLockNodes(t, 3)
+	locker, err := NewRedisAggregateLocker(RedisAggregateLockerConfig{}, clients...)
+	require.NoError(t, err)
+
+	// Act
+	first, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+	require.NoError(t, err)
+	_, contended := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+	require.NoError(t, first.Release(ctx))
+	second, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, contended, cqrs.ErrAggregateLocked)
+	assert.Greater(t, second.Token(), first.Token())
+}
+
+func TestRedisAggregateLocker_ToleratesMinorityNodeFailure(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	servers, clients := newLockNodes(t, 3)
+	locker, err := NewRedisAggregateLocker(RedisAggregateLockerConfig{}, clients...)
+	require.NoError(t, err)
+	first, err := locker.TryAcquire(ctx, "Guild", "guild-1", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	// Act
+	servers[0].Close()
+	for _, server := range servers[1:] {
+		server.FastForward(100 * time.Millisecond)
+	}
+	refreshErr := first.Refresh(ctx, time.Second)
+	second, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+
+	// Assert
+	assert.ErrorIs(t, refreshErr, cqrs.ErrAggregateLockLost)
+	require.NoError(t, err)
+	assert.Greater(t, second.Token(), first.Token())
+}
+
+func TestRedisAggregateLocker_AcquireWaitsForRelease(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	_, clients := newLockNodes(t, 1)
+	locker, err := NewRedisAggregateLocker(RedisAggregateLockerConfig{RetryDelay: 5 * time.Millisecond}, clients...)
+	require.NoError(t, err)
+	held, err := locker.TryAcquire(ctx, "Guild", "guild-1", time.Second)
+	require.NoError(t, err)
+
+	// Act
+	time.AfterFunc(20*time.Millisecond, func() { held.Release(context.Background()) })
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	acquired, err := locker.Acquire(waitCtx, "Guild", "guild-1", time.Second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, held.Token()+1, acquired.Token())
+}
@@ -29,7 +29,7 @@ type RedisMetrics struct {
 // NewRedisClientManager creates a new Redis client manager
 func NewRedisClientManager(config *RedisConfig) (*RedisClientManager, error) {
 	if config == nil {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis config cannot be nil", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis config cannot be nil", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if err := validateRedisConfig(config); err != nil {
@@ -145,15 +145,15 @@ func (rm *RedisClientManager) updateMetrics(latency time.Duration, err error) {
 
 func validateRedisConfig(config *RedisConfig) error {
 	if config.Host == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis host cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis host cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.Port <= 0 || config.Port > 65535 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis port must be between 1 and 65535", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis port must be between 1 and 65535", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.Database < 0 || config.Database > 15 {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis database must be between 0 and 15", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis database must be between 0 and 15", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	if config.PoolSize <= 0 {
@@ -216,6 +216,26 @@ func (kb *RedisKeyBuilder) IndexKey(modelType, field string) string {
 	return fmt.Sprintf("%s:index:%s:%s", kb.prefix, modelType, field)
 }
 
+// FieldIndexKey builds a key for the secondary index set of one field value
+func (kb *RedisKeyBuilder) FieldIndexKey(modelType, field, value string) string {
+	return fmt.Sprintf("%s:index:%s:%s:%s", kb.prefix, modelType, field, value)
+}
+
+// IndexEntryKey builds a key for the hash of indexed field values of a read model
+func (kb *RedisKeyBuilder) IndexEntryKey(modelType, modelID string) string {
+	return fmt.Sprintf("%s:indexentry:%s:%s", kb.prefix, modelType, modelID)
+}
+
+// SearchIndexName builds the RediSearch index name of a read model type
+func (kb *RedisKeyBuilder) SearchIndexName(modelType string) string {
+	return fmt.Sprintf("%s:search:%s", kb.prefix, modelType)
+}
+
+// MetricsKey builds a key for metrics time series storage
+func (kb *RedisKeyBuilder) MetricsKey(kind, id string) string {
+	return fmt.Sprintf("%s:metrics:%s:%s", kb.prefix, kind, id)
+}
+
 // MetadataKey builds a key for metadata storage
 func (kb *RedisKeyBuilder) MetadataKey(aggregateType, aggregateID string) string {
 	return fmt.Sprintf("%s:metadata:%s:%s", kb.prefix, aggregateType, aggregateID)
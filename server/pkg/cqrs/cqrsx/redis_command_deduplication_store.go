@@ -0,0 +1,120 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCommandDeduplicationStore implements cqrs.CommandDeduplicationStore with one
+// expiring key per deduplication key; reservations use SET NX so concurrent
+// submissions across server instances see a single winner
+type RedisCommandDeduplicationStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisCommandDeduplicationStore creates a store; keyPrefix defaults to "dedup"
+func NewRedisCommandDeduplicationStore(client redis.UniversalClient, keyPrefix string) (*RedisCommandDeduplicationStore, error) {
+	if client == nil {
+		return nil, cqrs.NewValidationError("redis client is required", nil)
+	}
+	if keyPrefix == "" {
+		keyPrefix = "dedup"
+	}
+	return &RedisCommandDeduplicationStore{client: client, keyPrefix: keyPrefix}, nil
+}
+
+func (s *RedisCommandDeduplicationStore) key(key string) string {
+	return s.keyPrefix + ":" + key
+}
+
+func (s *RedisCommandDeduplicationStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*cqrs.CommandDeduplicationRecord, bool, error) {
+	if key == "" {
+		return nil, false, cqrs.NewValidationError("deduplication key is required", nil)
+	}
+	if ttl <= 0 {
+		return nil, false, cqrs.NewValidationError("deduplication ttl must be positive", nil)
+	}
+
+	record := &cqrs.CommandDeduplicationRecord{Key: key, Fingerprint: fingerprint, CreatedAt: time.Now()}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to encode deduplication record", err)
+	}
+
+	// The existing key may expire between SET NX and GET, so retry once before giving up
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.client.SetNX(ctx, s.key(key), data, ttl).Result()
+		if err != nil {
+			return nil, false, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to reserve deduplication key", err)
+		}
+		if reserved {
+			return record, true, nil
+		}
+
+		existing, err := s.get(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			return existing, false, nil
+		}
+	}
+	return nil, false, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "deduplication key changed during reservation", nil)
+}
+
+func (s *RedisCommandDeduplicationStore) Complete(ctx context.Context, key string, result []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return cqrs.NewValidationError("deduplication ttl must be positive", nil)
+	}
+
+	record, err := s.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		// The reservation expired while the command ran; record the outcome anyway
+		record = &cqrs.CommandDeduplicationRecord{Key: key, CreatedAt: time.Now()}
+	}
+	record.Completed = true
+	record.Result = result
+	record.CompletedAt = time.Now()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to encode deduplication record", err)
+	}
+	if err := s.client.Set(ctx, s.key(key), data, ttl).Err(); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to complete deduplication key", err)
+	}
+	return nil
+}
+
+func (s *RedisCommandDeduplicationStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.key(key)).Err(); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to release deduplication key", err)
+	}
+	return nil
+}
+
+// get loads a record, returning nil if the key does not exist
+func (s *RedisCommandDeduplicationStore) get(ctx context.Context, key string) (*cqrs.CommandDeduplicationRecord, error) {
+	data, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to load deduplication key", err)
+	}
+
+	var record cqrs.CommandDeduplicationRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to decode deduplication record", err)
+	}
+	return &record, nil
+}
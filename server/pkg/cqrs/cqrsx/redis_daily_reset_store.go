@@ -0,0 +1,57 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisDailyResetStore implements cqrs.DailyResetStore with a hash of the last reset per
+// schedule key, so a restarted scheduler catches up on the resets it missed
+type RedisDailyResetStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisDailyResetStore creates a store; keyPrefix defaults to "daily_reset"
+func NewRedisDailyResetStore(client redis.UniversalClient, keyPrefix string) (*RedisDailyResetStore, error) {
+	if client == nil {
+		return nil, cqrs.NewValidationError("redis client is required", nil)
+	}
+	if keyPrefix == "" {
+		keyPrefix = "daily_reset"
+	}
+	return &RedisDailyResetStore{client: client, keyPrefix: keyPrefix}, nil
+}
+
+func (s *RedisDailyResetStore) lastKey() string {
+	return s.keyPrefix + ":last"
+}
+
+func (s *RedisDailyResetStore) LastReset(ctx context.Context, key string) (time.Time, bool, error) {
+	value, err := s.client.HGet(ctx, s.lastKey(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to read last daily reset", err)
+	}
+	last, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to decode last daily reset of "+key, err)
+	}
+	return last, true, nil
+}
+
+func (s *RedisDailyResetStore) RecordReset(ctx context.Context, key string, resetAt time.Time) error {
+	if key == "" {
+		return cqrs.NewValidationError("daily reset key is required", nil)
+	}
+	if err := s.client.HSet(ctx, s.lastKey(), key, resetAt.UTC().Format(time.RFC3339Nano)).Err(); err != nil {
+		return cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to record daily reset", err)
+	}
+	return nil
+}
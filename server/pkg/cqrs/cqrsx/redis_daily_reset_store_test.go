@@ -0,0 +1,81 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisDailyResetStore_RecordsLastReset(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	store, err := NewRedisDailyResetStore(client, "")
+	require.NoError(t, err)
+	resetAt := time.Date(2026, 6, 1, 20, 0, 0, 0, time.FixedZone("KST", 9*60*60))
+
+	// Act
+	_, missingExists, missingErr := store.LastReset(ctx, "daily_quest/kr")
+	recordErr := store.RecordReset(ctx, "daily_quest/kr", resetAt)
+	last, exists, lastErr := store.LastReset(ctx, "daily_quest/kr")
+
+	// Assert
+	require.NoError(t, missingErr)
+	assert.False(t, missingExists)
+	require.NoError(t, recordErr)
+	require.NoError(t, lastErr)
+	assert.True(t, exists)
+	assert.True(t, resetAt.Equal(last))
+}
+
+func TestRedisDailyResetStore_CatchesUpAfterRestart(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	schedules := []cqrs.DailyResetSchedule{{Name: "daily_quest", Region: "global", TimeZone: "UTC", Hour: 0}}
+	newScheduler := func() (*cqrs.DailyResetScheduler, *RedisDeferredCommandStore) {
+		store, err := NewRedisDailyResetStore(client, "")
+		require.NoError(t, err)
+		pending, err := NewRedisDeferredCommandStore(client, "")
+		require.NoError(t, err)
+		dispatcher := cqrs.NewInMemoryCommandDispatcher()
+		deferred, err := cqrs.NewDeferredScheduler(dispatcher, pending)
+		require.NoError(t, err)
+		scheduler, err := cqrs.NewDailyResetScheduler(schedules, cqrs.NewInMemoryEventBus(), store, deferred)
+		require.NoError(t, err)
+		require.NoError(t, scheduler.RegisterWith(dispatcher))
+		return scheduler, pending
+	}
+	first, _ := newScheduler()
+	_, err := first.Schedule(ctx)
+	require.NoError(t, err)
+	// 3일 동안 중단된 것처럼 마지막 초기화를 되돌림
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	store, _ := NewRedisDailyResetStore(client, "")
+	require.NoError(t, store.RecordReset(ctx, "daily_quest/global", today.AddDate(0, 0, -3)))
+
+	// Act
+	restarted, pending := newScheduler()
+	fired, scheduleErr := restarted.Schedule(ctx)
+	scheduled, _ := pending.Due(ctx, today.AddDate(0, 0, 2), 0)
+
+	// Assert
+	require.NoError(t, scheduleErr)
+	require.Len(t, fired, 1)
+	assert.True(t, fired[0].CatchUp)
+	assert.Equal(t, 2, fired[0].Missed)
+	assert.True(t, today.Equal(fired[0].ResetAt))
+	require.Len(t, scheduled, 1)
+	assert.True(t, today.AddDate(0, 0, 1).Equal(scheduled[0].DueAt))
+}
@@ -0,0 +1,111 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisDeferredCommandStore implements cqrs.DeferredCommandStore with a hash of encoded
+// commands and a sorted set ordered by due time, so scheduled commands such as auction
+// settlements survive restarts and are visible to every server instance
+type RedisDeferredCommandStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisDeferredCommandStore creates a store; keyPrefix defaults to "deferred"
+func NewRedisDeferredCommandStore(client redis.UniversalClient, keyPrefix string) (*RedisDeferredCommandStore, error) {
+	if client == nil {
+		return nil, cqrs.NewValidationError("redis client is required", nil)
+	}
+	if keyPrefix == "" {
+		keyPrefix = "deferred"
+	}
+	return &RedisDeferredCommandStore{client: client, keyPrefix: keyPrefix}, nil
+}
+
+func (s *RedisDeferredCommandStore) commandsKey() string {
+	return s.keyPrefix + ":commands"
+}
+
+func (s *RedisDeferredCommandStore) dueKey() string {
+	return s.keyPrefix + ":due"
+}
+
+func (s *RedisDeferredCommandStore) Put(ctx context.Context, deferred cqrs.DeferredCommand) error {
+	if deferred.Key == "" {
+		return cqrs.NewValidationError("deferred command key is required", nil)
+	}
+	data, err := json.Marshal(deferred)
+	if err != nil {
+		return cqrs.NewValidationError("failed to encode deferred command", err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.commandsKey(), deferred.Key, data)
+		pipe.ZAdd(ctx, s.dueKey(), redis.Z{Score: float64(deferred.DueAt.UnixMilli()), Member: deferred.Key})
+		return nil
+	})
+	if err != nil {
+		return cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to store deferred command", err)
+	}
+	return nil
+}
+
+func (s *RedisDeferredCommandStore) Remove(ctx context.Context, key string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, s.commandsKey(), key)
+		pipe.ZRem(ctx, s.dueKey(), key)
+		return nil
+	})
+	if err != nil {
+		return cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to remove deferred command", err)
+	}
+	return nil
+}
+
+func (s *RedisDeferredCommandStore) Due(ctx context.Context, now time.Time, limit int) ([]cqrs.DeferredCommand, error) {
+	// Scores are milliseconds; entries due later within the same millisecond are filtered below
+	query := &redis.ZRangeBy{Min: "-inf", Max: strconv.FormatInt(now.UnixMilli(), 10)}
+	if limit > 0 {
+		query.Count = int64(limit)
+	}
+	keys, err := s.client.ZRangeByScore(ctx, s.dueKey(), query).Result()
+	if err != nil {
+		return nil, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to read due deferred commands", err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	values, err := s.client.HMGet(ctx, s.commandsKey(), keys...).Result()
+	if err != nil {
+		return nil, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to read deferred commands", err)
+	}
+
+	due := make([]cqrs.DeferredCommand, 0, len(values))
+	for i, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			continue // removed between the two reads
+		}
+		var deferred cqrs.DeferredCommand
+		if err := json.Unmarshal([]byte(encoded), &deferred); err != nil {
+			return nil, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to decode deferred command "+keys[i], err)
+		}
+		if !deferred.DueAt.After(now) {
+			due = append(due, deferred)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].DueAt.Equal(due[j].DueAt) {
+			return due[i].Key < due[j].Key
+		}
+		return due[i].DueAt.Before(due[j].DueAt)
+	})
+	return due, nil
+}
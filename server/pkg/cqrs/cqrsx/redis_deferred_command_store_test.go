@@ -0,0 +1,90 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeferredCommandStore(t *testing.T) *RedisDeferredCommandStore {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	store, err := NewRedisDeferredCommandStore(client, "")
+	require.NoError(t, err)
+	return store
+}
+
+func TestRedisDeferredCommandStore_DueInOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newTestDeferredCommandStore(t)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	put := func(key string, dueAt time.Time) {
+		require.NoError(t, store.Put(ctx, cqrs.DeferredCommand{Key: key, DueAt: dueAt, Command: cqrs.NewBaseCommand("SettleAuction", key, "Auction", nil)}))
+	}
+	put("a2", now.Add(-time.Minute))
+	put("a1", now.Add(-time.Hour))
+	put("a3", now.Add(time.Minute))
+	put("a3", now.Add(-time.Second)) // 같은 키는 대체
+	put("a4", now.Add(time.Millisecond/2))
+	put("a5", now)
+	require.NoError(t, store.Remove(ctx, "a5"))
+
+	// Act
+	due, err := store.Due(ctx, now, 0)
+	limited, limitedErr := store.Due(ctx, now, 1)
+
+	// Assert
+	require.NoError(t, err)
+	keys := make([]string, 0, len(due))
+	for _, deferred := range due {
+		keys = append(keys, deferred.Key)
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3"}, keys) // a4는 같은 밀리초 안이지만 아직 이름
+	require.NoError(t, limitedErr)
+	require.Len(t, limited, 1)
+	assert.Equal(t, "a1", limited[0].Key)
+}
+
+func TestRedisDeferredCommandStore_SurvivesSchedulerRestart(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newTestDeferredCommandStore(t)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	scheduling := cqrs.NewInMemoryCommandDispatcher()
+	before, err := cqrs.NewDeferredScheduler(scheduling, store)
+	require.NoError(t, err)
+	command := cqrs.NewBaseCommand("SettleAuction", "a1", "Auction", map[string]interface{}{"reason": "ended"})
+	command.SetUserID("system")
+	require.NoError(t, before.Schedule(ctx, "auction:a1", now, command))
+
+	// Act: 재시작한 서버의 새 스케줄러가 같은 저장소에서 실행
+	var received cqrs.Command
+	restarted := cqrs.NewInMemoryCommandDispatcher()
+	require.NoError(t, cqrs.RegisterCommandHandler(restarted, "SettleAuction", func(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+		received = command
+		return cqrs.NewCommandResult(command.ID(), 1), nil
+	}))
+	after, err := cqrs.NewDeferredScheduler(restarted, store)
+	require.NoError(t, err)
+	handled, runErr := after.RunDueAt(ctx, now)
+	remaining, dueErr := store.Due(ctx, now, 0)
+
+	// Assert
+	require.NoError(t, runErr)
+	require.Len(t, handled, 1)
+	require.NotNil(t, received)
+	assert.Equal(t, "a1", received.ID())
+	assert.Equal(t, "system", received.UserID())
+	assert.Equal(t, map[string]interface{}{"reason": "ended"}, received.GetData())
+	require.NoError(t, dueErr)
+	assert.Empty(t, remaining)
+}
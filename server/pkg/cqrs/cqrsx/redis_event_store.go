@@ -1,6 +1,7 @@
 package cqrsx
 
 import (
+	"bytes"
 	"context"
 	"cqrs"
 	"fmt"
@@ -35,7 +36,7 @@ func (es *RedisEventStore) SaveEvents(ctx context.Context, aggregateID string, e
 	}
 
 	if aggregateID == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	// Get aggregate type from first event
@@ -75,11 +76,30 @@ func (es *RedisEventStore) SaveEvents(ctx context.Context, aggregateID string, e
 		// Create new pipeline for saving events
 		pipe = es.client.GetClient().Pipeline()
 
-		// Serialize and save each event
+		// Serialize and save each event, reusing pooled buffers when supported.
+		// Buffers are referenced by the pipeline until Exec completes.
+		buffered, useBuffers := es.serializer.(BufferedEventMarshaler)
+		var buffers []*bytes.Buffer
+		defer func() {
+			for _, buf := range buffers {
+				ReleaseBuffer(buf)
+			}
+		}()
+
 		for _, event := range events {
-			eventData, err := es.serializer.Marshal(event)
-			if err != nil {
-				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize event", err)
+			var eventData []byte
+			if useBuffers {
+				buf := AcquireBuffer()
+				buffers = append(buffers, buf)
+				if err := buffered.MarshalTo(buf, event); err != nil {
+					return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize event", err)
+				}
+				eventData = buf.Bytes()
+			} else {
+				eventData, err = es.serializer.Marshal(event)
+				if err != nil {
+					return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize event", err)
+				}
 			}
 
 			// Add event to list
@@ -110,10 +130,10 @@ func (es *RedisEventStore) SaveEvents(ctx context.Context, aggregateID string, e
 // GetEventHistory retrieves event history for an aggregate
 func (es *RedisEventStore) GetEventHistory(ctx context.Context, aggregateID string, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
 	if aggregateID == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 	if aggregateType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	eventKey := es.keyBuilder.EventKey(aggregateType, aggregateID)
@@ -158,10 +178,10 @@ func (es *RedisEventStore) GetEventHistory(ctx context.Context, aggregateID stri
 // GetLastEventVersion gets the last event version for an aggregate
 func (es *RedisEventStore) GetLastEventVersion(ctx context.Context, aggregateID string, aggregateType string) (int, error) {
 	if aggregateID == "" {
-		return 0, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return 0, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 	if aggregateType == "" {
-		return 0, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return 0, cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	metadataKey := es.keyBuilder.MetadataKey(aggregateType, aggregateID)
@@ -196,10 +216,10 @@ func (es *RedisEventStore) GetLastEventVersion(ctx context.Context, aggregateID
 // CompactEvents removes old events before a specific version
 func (es *RedisEventStore) CompactEvents(ctx context.Context, aggregateID string, aggregateType string, beforeVersion int) error {
 	if aggregateID == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 	if aggregateType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	eventKey := es.keyBuilder.EventKey(aggregateType, aggregateID)
@@ -245,4 +265,24 @@ func (es *RedisEventStore) CompactEvents(ctx context.Context, aggregateID string
 	})
 }
 
+// DeleteEvents removes the aggregate's whole event stream and its metadata
+func (es *RedisEventStore) DeleteEvents(ctx context.Context, aggregateID string, aggregateType string) error {
+	if aggregateID == "" {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate ID cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
+	if aggregateType == "" {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "aggregate type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	eventKey := es.keyBuilder.EventKey(aggregateType, aggregateID)
+	metadataKey := es.keyBuilder.MetadataKey(aggregateType, aggregateID)
+
+	return es.client.ExecuteCommand(ctx, func() error {
+		if err := es.client.GetClient().Del(ctx, eventKey, metadataKey).Err(); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventStoreError.String(), "failed to delete events", err)
+		}
+		return nil
+	})
+}
+
 // Note: JSONEventSerializer implementation is now in event_serializer.go
@@ -0,0 +1,743 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisQueryStrategy is how RedisReadStore finds the candidate read models of a query
+type RedisQueryStrategy string
+
+const (
+	RedisStrategyKeyLookup      RedisQueryStrategy = "key_lookup"      // "type" and "id" filters address a single key
+	RedisStrategySearch         RedisQueryStrategy = "redisearch"      // FT.SEARCH over the secondary index entries
+	RedisStrategySecondaryIndex RedisQueryStrategy = "secondary_index" // Intersection of the equality index sets
+	RedisStrategyTypeIndex      RedisQueryStrategy = "type_index"      // Every read model of the type
+	RedisStrategyScan           RedisQueryStrategy = "scan"            // Every read model key in the keyspace
+)
+
+const (
+	// maxRedisSearchResults caps the ids one FT.SEARCH returns
+	maxRedisSearchResults = 10000
+	// redisLoadBatchSize is the number of read models fetched per MGET
+	redisLoadBatchSize = 500
+)
+
+// RedisQueryPlan describes how a query was (or would be) executed, for debugging slow queries
+type RedisQueryPlan struct {
+	Strategy        RedisQueryStrategy `json:"strategy"`
+	ModelType       string             `json:"model_type,omitempty"`
+	IndexedFilters  []string           `json:"indexed_filters,omitempty"`  // Pushed down to Redis
+	ResidualFilters []string           `json:"residual_filters,omitempty"` // Evaluated after loading candidates
+	SearchQuery     string             `json:"search_query,omitempty"`
+	Candidates      int                `json:"candidates"` // Read models loaded from Redis
+	Matched         int                `json:"matched"`    // Candidates that passed every filter, before pagination
+	Duration        time.Duration      `json:"duration"`
+	Warning         string             `json:"warning,omitempty"`
+}
+
+// RedisQueryPlannerMetrics counts queries by strategy
+type RedisQueryPlannerMetrics struct {
+	KeyLookups            int64 `json:"key_lookups"`
+	SearchQueries         int64 `json:"search_queries"`
+	SecondaryIndexQueries int64 `json:"secondary_index_queries"`
+	TypeIndexQueries      int64 `json:"type_index_queries"`
+	Scans                 int64 `json:"scans"`
+	ScanFallbacks         int64 `json:"scan_fallbacks"` // Queries whose filters no index covered
+}
+
+type redisPlannerCounters struct {
+	keyLookups, searches, secondary, typeIndex, scans, fallbacks atomic.Int64
+}
+
+// redisIndexRegistry tracks which fields of each model type have secondary indexes
+type redisIndexRegistry struct {
+	mu     sync.RWMutex
+	fields map[string][]string // model type -> sorted indexed fields
+	search map[string]bool     // model type -> RediSearch index created
+
+	probe       sync.Once
+	searchReady bool // RediSearch module loaded
+}
+
+func newRedisIndexRegistry() *redisIndexRegistry {
+	return &redisIndexRegistry{fields: make(map[string][]string), search: make(map[string]bool)}
+}
+
+func (r *redisIndexRegistry) indexedFields(modelType string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.fields[modelType]
+}
+
+func (r *redisIndexRegistry) hasSearchIndex(modelType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.search[modelType]
+}
+
+// searchAvailable probes once for the RediSearch module
+func (rs *RedisReadStore) searchAvailable(ctx context.Context) bool {
+	rs.indexes.probe.Do(func() {
+		rs.indexes.searchReady = rs.client.GetClient().Do(ctx, "FT._LIST").Err() == nil
+	})
+	return rs.indexes.searchReady
+}
+
+// OnQueryPlan registers a listener called with the plan of every executed query (e.g. slow query logging)
+func (rs *RedisReadStore) OnQueryPlan(listener func(plan RedisQueryPlan)) {
+	rs.planListener = listener
+}
+
+// QueryPlannerMetrics returns query counts by strategy
+func (rs *RedisReadStore) QueryPlannerMetrics() RedisQueryPlannerMetrics {
+	return RedisQueryPlannerMetrics{
+		KeyLookups:            rs.counters.keyLookups.Load(),
+		SearchQueries:         rs.counters.searches.Load(),
+		SecondaryIndexQueries: rs.counters.secondary.Load(),
+		TypeIndexQueries:      rs.counters.typeIndex.Load(),
+		Scans:                 rs.counters.scans.Load(),
+		ScanFallbacks:         rs.counters.fallbacks.Load(),
+	}
+}
+
+// Explain returns the plan Query would use for criteria without loading any read model
+func (rs *RedisReadStore) Explain(ctx context.Context, criteria cqrs.QueryCriteria) RedisQueryPlan {
+	return rs.plan(ctx, criteria)
+}
+
+// QueryWithPlan runs the query and returns the executed plan alongside the results
+func (rs *RedisReadStore) QueryWithPlan(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.ReadModel, *RedisQueryPlan, error) {
+	started := time.Now()
+	plan := rs.plan(ctx, criteria)
+	var results []cqrs.ReadModel
+
+	err := rs.client.ExecuteCommand(ctx, func() error {
+		candidates, err := rs.candidates(ctx, &plan, criteria)
+		if err != nil {
+			return err
+		}
+		plan.Candidates = len(candidates)
+
+		return rs.loadReadModels(ctx, candidates, func(readModel cqrs.ReadModel) {
+			if rs.matchesCriteria(readModel, criteria) {
+				results = append(results, readModel)
+			}
+		})
+	})
+	if err != nil {
+		return nil, &plan, err
+	}
+
+	plan.Matched = len(results)
+	results = rs.applySortingAndPagination(results, criteria)
+	plan.Duration = time.Since(started)
+	rs.recordPlan(plan)
+	return results, &plan, nil
+}
+
+func (rs *RedisReadStore) recordPlan(plan RedisQueryPlan) {
+	switch plan.Strategy {
+	case RedisStrategyKeyLookup:
+		rs.counters.keyLookups.Add(1)
+	case RedisStrategySearch:
+		rs.counters.searches.Add(1)
+	case RedisStrategySecondaryIndex:
+		rs.counters.secondary.Add(1)
+	case RedisStrategyTypeIndex:
+		rs.counters.typeIndex.Add(1)
+	case RedisStrategyScan:
+		rs.counters.scans.Add(1)
+	}
+	if plan.Warning != "" {
+		rs.counters.fallbacks.Add(1)
+	}
+	if rs.planListener != nil {
+		rs.planListener(plan)
+	}
+}
+
+// plan chooses the cheapest strategy the filters allow. Only equality filters ($eq, $in or a
+// plain value) on indexed fields are pushed down; every filter is still re-checked on load,
+// so stale index entries (e.g. after a read model's TTL expired) never leak into results.
+func (rs *RedisReadStore) plan(ctx context.Context, criteria cqrs.QueryCriteria) RedisQueryPlan {
+	modelType, _ := criteria.Filters["type"].(string)
+	id, _ := criteria.Filters["id"].(string)
+	plan := RedisQueryPlan{ModelType: modelType}
+
+	fields := make([]string, 0, len(criteria.Filters))
+	for field := range criteria.Filters {
+		if field != "type" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	if modelType == "" {
+		plan.Strategy = RedisStrategyScan
+		plan.ResidualFilters = fields
+		plan.Warning = "no type filter: scanning every read model key"
+		return plan
+	}
+	if id != "" {
+		plan.Strategy = RedisStrategyKeyLookup
+		for _, field := range fields {
+			if field != "id" {
+				plan.ResidualFilters = append(plan.ResidualFilters, field)
+			}
+		}
+		return plan
+	}
+
+	indexed := make(map[string]bool)
+	for _, field := range rs.indexes.indexedFields(modelType) {
+		indexed[field] = true
+	}
+	for _, field := range fields {
+		if _, ok := equalityValues(criteria.Filters[field]); ok && indexed[field] {
+			plan.IndexedFilters = append(plan.IndexedFilters, field)
+		} else {
+			plan.ResidualFilters = append(plan.ResidualFilters, field)
+		}
+	}
+
+	switch {
+	case len(plan.IndexedFilters) > 0 && rs.indexes.hasSearchIndex(modelType) && rs.searchAvailable(ctx):
+		plan.Strategy = RedisStrategySearch
+		plan.SearchQuery = redisSearchQuery(criteria.Filters, plan.IndexedFilters)
+	case len(plan.IndexedFilters) > 0:
+		plan.Strategy = RedisStrategySecondaryIndex
+	default:
+		plan.Strategy = RedisStrategyTypeIndex
+		if len(plan.ResidualFilters) > 0 {
+			plan.Warning = fmt.Sprintf("no secondary index covers %s: loading every %s read model", strings.Join(plan.ResidualFilters, ", "), modelType)
+		}
+	}
+	return plan
+}
+
+// redisCandidate is a read model key and the type to deserialize it as
+type redisCandidate struct {
+	key       string
+	modelType string
+}
+
+func (rs *RedisReadStore) candidates(ctx context.Context, plan *RedisQueryPlan, criteria cqrs.QueryCriteria) ([]redisCandidate, error) {
+	client := rs.client.GetClient()
+	var ids []string
+
+	switch plan.Strategy {
+	case RedisStrategyKeyLookup:
+		ids = []string{criteria.Filters["id"].(string)}
+
+	case RedisStrategySearch:
+		reply, err := client.Do(ctx, "FT.SEARCH", rs.keyBuilder.SearchIndexName(plan.ModelType), plan.SearchQuery,
+			"NOCONTENT", "LIMIT", 0, maxRedisSearchResults).Result()
+		if err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to search read model index", err)
+		}
+		entryPrefix := rs.keyBuilder.IndexEntryKey(plan.ModelType, "")
+		for _, key := range redisSearchDocumentIDs(reply) {
+			ids = append(ids, strings.TrimPrefix(key, entryPrefix))
+		}
+		if len(ids) >= maxRedisSearchResults {
+			plan.Warning = fmt.Sprintf("search returned the %d result cap; results may be incomplete", maxRedisSearchResults)
+		}
+
+	case RedisStrategySecondaryIndex:
+		var err error
+		ids, err = rs.intersectIndexSets(ctx, plan.ModelType, plan.IndexedFilters, criteria.Filters)
+		if err != nil {
+			return nil, err
+		}
+
+	case RedisStrategyTypeIndex:
+		members, err := client.SMembers(ctx, rs.keyBuilder.IndexKey(plan.ModelType, "all")).Result()
+		if err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read type index", err)
+		}
+		ids = members
+
+	case RedisStrategyScan:
+		var candidates []redisCandidate
+		iter := client.Scan(ctx, 0, rs.keyBuilder.ReadModelKey("*", "*"), redisStreamScanCount).Iterator()
+		for iter.Next(ctx) {
+			parts := strings.Split(iter.Val(), ":")
+			if len(parts) < 4 {
+				continue
+			}
+			candidates = append(candidates, redisCandidate{key: iter.Val(), modelType: parts[len(parts)-2]})
+		}
+		if err := iter.Err(); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to scan read model keys", err)
+		}
+		return candidates, nil
+	}
+
+	candidates := make([]redisCandidate, len(ids))
+	for i, id := range ids {
+		candidates[i] = redisCandidate{key: rs.keyBuilder.ReadModelKey(plan.ModelType, id), modelType: plan.ModelType}
+	}
+	return candidates, nil
+}
+
+// intersectIndexSets returns ids present in the index sets of every filter ($in filters union their values)
+func (rs *RedisReadStore) intersectIndexSets(ctx context.Context, modelType string, fields []string, filters map[string]interface{}) ([]string, error) {
+	var result map[string]bool
+	for _, field := range fields {
+		values, _ := equalityValues(filters[field])
+		keys := make([]string, len(values))
+		for i, value := range values {
+			keys[i] = rs.keyBuilder.FieldIndexKey(modelType, field, value)
+		}
+		members, err := rs.client.GetClient().SUnion(ctx, keys...).Result()
+		if err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), fmt.Sprintf("failed to read %s index", field), err)
+		}
+
+		next := make(map[string]bool, len(members))
+		for _, member := range members {
+			if result == nil || result[member] {
+				next[member] = true
+			}
+		}
+		result = next
+		if len(result) == 0 {
+			break
+		}
+	}
+
+	ids := make([]string, 0, len(result))
+	for id := range result {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// loadReadModels fetches candidates with batched MGET; missing keys and undecodable values are skipped
+func (rs *RedisReadStore) loadReadModels(ctx context.Context, candidates []redisCandidate, visit func(cqrs.ReadModel)) error {
+	client := rs.client.GetClient()
+	for start := 0; start < len(candidates); start += redisLoadBatchSize {
+		end := start + redisLoadBatchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		batch := candidates[start:end]
+
+		keys := make([]string, len(batch))
+		for i, candidate := range batch {
+			keys[i] = candidate.key
+		}
+		values, err := client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to get read models", err)
+		}
+
+		for i, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue // Expired or deleted since it was indexed
+			}
+			readModel, err := rs.serializer.DeserializeReadModel([]byte(data), batch[i].modelType)
+			if err != nil {
+				continue // Skip invalid entries
+			}
+			visit(readModel)
+		}
+	}
+	return nil
+}
+
+// indexReadModel updates the secondary index sets and index entry of a saved read model
+func (rs *RedisReadStore) indexReadModel(ctx context.Context, readModel cqrs.ReadModel) error {
+	fields := rs.indexes.indexedFields(readModel.GetType())
+	if len(fields) == 0 {
+		return nil
+	}
+
+	document := rs.readModelDocument(readModel)
+	client := rs.client.GetClient()
+	entryKey := rs.keyBuilder.IndexEntryKey(readModel.GetType(), readModel.GetID())
+	previous, err := client.HGetAll(ctx, entryKey).Result()
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read index entry", err)
+	}
+
+	pipe := client.TxPipeline()
+	for _, field := range fields {
+		value, indexable := indexValue(lookupField(document, field))
+		old, hadOld := previous[field]
+		if hadOld && (!indexable || old != value) {
+			pipe.SRem(ctx, rs.keyBuilder.FieldIndexKey(readModel.GetType(), field, old), readModel.GetID())
+			pipe.HDel(ctx, entryKey, field)
+		}
+		if indexable {
+			pipe.SAdd(ctx, rs.keyBuilder.FieldIndexKey(readModel.GetType(), field, value), readModel.GetID())
+			pipe.HSet(ctx, entryKey, field, value)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to update secondary indexes", err)
+	}
+	return nil
+}
+
+// unindexReadModel removes a read model from the type index and every secondary index
+func (rs *RedisReadStore) unindexReadModel(ctx context.Context, modelType, id string) error {
+	client := rs.client.GetClient()
+	entryKey := rs.keyBuilder.IndexEntryKey(modelType, id)
+	previous, err := client.HGetAll(ctx, entryKey).Result()
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read index entry", err)
+	}
+
+	pipe := client.TxPipeline()
+	pipe.SRem(ctx, rs.keyBuilder.IndexKey(modelType, "all"), id)
+	for field, value := range previous {
+		pipe.SRem(ctx, rs.keyBuilder.FieldIndexKey(modelType, field, value), id)
+	}
+	pipe.Del(ctx, entryKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to remove read model from indexes", err)
+	}
+	return nil
+}
+
+// backfillIndex indexes every stored read model of the type, so indexes created after data exists are complete
+func (rs *RedisReadStore) backfillIndex(ctx context.Context, modelType string) error {
+	members, err := rs.client.GetClient().SMembers(ctx, rs.keyBuilder.IndexKey(modelType, "all")).Result()
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to read type index", err)
+	}
+	candidates := make([]redisCandidate, len(members))
+	for i, id := range members {
+		candidates[i] = redisCandidate{key: rs.keyBuilder.ReadModelKey(modelType, id), modelType: modelType}
+	}
+
+	var indexErr error
+	err = rs.loadReadModels(ctx, candidates, func(readModel cqrs.ReadModel) {
+		if indexErr == nil {
+			indexErr = rs.indexReadModel(ctx, readModel)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return indexErr
+}
+
+// createSearchIndex (re)creates the RediSearch index over the index entries of the type.
+// Dropping the index keeps the entry hashes; RediSearch re-indexes them in the background.
+func (rs *RedisReadStore) createSearchIndex(ctx context.Context, modelType string, fields []string) error {
+	client := rs.client.GetClient()
+	name := rs.keyBuilder.SearchIndexName(modelType)
+	client.FTDropIndex(ctx, name) // Missing index is fine
+
+	rs.indexes.mu.Lock()
+	delete(rs.indexes.search, modelType)
+	rs.indexes.mu.Unlock()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	schema := make([]*redis.FieldSchema, len(fields))
+	for i, field := range fields {
+		schema[i] = &redis.FieldSchema{FieldName: field, As: redisSearchAttribute(field), FieldType: redis.SearchFieldTypeTag}
+	}
+	err := client.FTCreate(ctx, name, &redis.FTCreateOptions{
+		OnHash: true,
+		Prefix: []interface{}{rs.keyBuilder.IndexEntryKey(modelType, "")},
+	}, schema...).Err()
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), fmt.Sprintf("failed to create search index for %s", modelType), err)
+	}
+
+	rs.indexes.mu.Lock()
+	rs.indexes.search[modelType] = true
+	rs.indexes.mu.Unlock()
+	return nil
+}
+
+// sortReadModels orders results by the SortBy field; read models missing the field sort first
+func (rs *RedisReadStore) sortReadModels(results []cqrs.ReadModel, criteria cqrs.QueryCriteria) {
+	keys := make([]interface{}, len(results))
+	for i, readModel := range results {
+		keys[i] = lookupField(rs.readModelDocument(readModel), criteria.SortBy)
+	}
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := keys[order[i]], keys[order[j]]
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		if criteria.SortOrder == cqrs.Descending {
+			return compareValues(a, b) > 0
+		}
+		return compareValues(a, b) < 0
+	})
+	sorted := make([]cqrs.ReadModel, len(results))
+	for i, index := range order {
+		sorted[i] = results[index]
+	}
+	copy(results, sorted)
+}
+
+// readModelDocument decodes the serialized read model into a generic document for field access
+func (rs *RedisReadStore) readModelDocument(readModel cqrs.ReadModel) map[string]interface{} {
+	data, err := rs.serializer.SerializeReadModel(readModel)
+	if err != nil {
+		return nil
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+	return document
+}
+
+// lookupField resolves a dotted path ("data.guild.level") in a decoded document
+func lookupField(document map[string]interface{}, path string) interface{} {
+	var current interface{} = document
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// indexValue normalises a scalar so documents and filters produce the same index key
+func indexValue(value interface{}) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case bool:
+		return strconv.FormatBool(typed), true
+	case time.Time:
+		return typed.Format(time.RFC3339Nano), true // Same form as the serialized read model
+	case nil:
+		return "", false
+	}
+	if number, ok := toFloat(value); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// equalityValues returns the values an equality filter (plain value, $eq or $in) matches
+func equalityValues(filter interface{}) ([]string, bool) {
+	condition, isOperator := filter.(map[string]interface{})
+	if !isOperator {
+		value, ok := indexValue(filter)
+		return []string{value}, ok
+	}
+	if len(condition) != 1 {
+		return nil, false
+	}
+	if value, exists := condition["$eq"]; exists {
+		normalised, ok := indexValue(value)
+		return []string{normalised}, ok
+	}
+	if list, exists := condition["$in"]; exists {
+		items, ok := toSlice(list)
+		if !ok || len(items) == 0 {
+			return nil, false
+		}
+		values := make([]string, len(items))
+		for i, item := range items {
+			if values[i], ok = indexValue(item); !ok {
+				return nil, false
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// matchesFilter evaluates one filter (plain value or operator map: $eq $ne $gt $gte $lt $lte $in) against a field value
+func matchesFilter(actual interface{}, filter interface{}) bool {
+	condition, isOperator := filter.(map[string]interface{})
+	if !isOperator {
+		return compareValues(actual, filter) == 0
+	}
+	for operator, expected := range condition {
+		var ok bool
+		switch operator {
+		case "$eq":
+			ok = compareValues(actual, expected) == 0
+		case "$ne":
+			ok = compareValues(actual, expected) != 0
+		case "$gt":
+			ok = comparable(actual, expected) && compareValues(actual, expected) > 0
+		case "$gte":
+			ok = comparable(actual, expected) && compareValues(actual, expected) >= 0
+		case "$lt":
+			ok = comparable(actual, expected) && compareValues(actual, expected) < 0
+		case "$lte":
+			ok = comparable(actual, expected) && compareValues(actual, expected) <= 0
+		case "$in":
+			items, _ := toSlice(expected)
+			for _, item := range items {
+				if compareValues(actual, item) == 0 {
+					ok = true
+					break
+				}
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// comparable reports whether two values can be ordered (both numbers or both strings)
+func comparable(a, b interface{}) bool {
+	_, aNumber := toFloat(a)
+	_, bNumber := toFloat(b)
+	_, aString := a.(string)
+	_, bString := b.(string)
+	return (aNumber && bNumber) || (aString && bString)
+}
+
+// compareValues orders numbers numerically and everything else by its index form
+func compareValues(a, b interface{}) int {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	x, xOK := indexValue(a)
+	y, yOK := indexValue(b)
+	if !xOK || !yOK {
+		x, y = fmt.Sprint(a), fmt.Sprint(b)
+	}
+	return strings.Compare(x, y)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func toSlice(value interface{}) ([]interface{}, bool) {
+	switch typed := value.(type) {
+	case []interface{}:
+		return typed, true
+	case []string:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = item
+		}
+		return items, true
+	case []int:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = item
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// redisSearchQuery builds a TAG query over the pushed-down equality filters
+func redisSearchQuery(filters map[string]interface{}, fields []string) string {
+	clauses := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values, _ := equalityValues(filters[field])
+		escaped := make([]string, len(values))
+		for i, value := range values {
+			escaped[i] = escapeRedisTag(value)
+		}
+		clauses = append(clauses, fmt.Sprintf("@%s:{%s}", redisSearchAttribute(field), strings.Join(escaped, " | ")))
+	}
+	return strings.Join(clauses, " ")
+}
+
+// redisSearchAttribute maps a field path to a RediSearch attribute name
+func redisSearchAttribute(field string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, field)
+}
+
+// escapeRedisTag escapes TAG query punctuation
+func escapeRedisTag(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if !(r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r > 127) {
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// redisSearchDocumentIDs extracts document keys from an FT.SEARCH NOCONTENT reply in RESP2 or RESP3 form
+func redisSearchDocumentIDs(reply interface{}) []string {
+	var ids []string
+	switch typed := reply.(type) {
+	case []interface{}: // RESP2: [total, id1, id2, ...]
+		for i := 1; i < len(typed); i++ {
+			if id, ok := typed[i].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	case map[interface{}]interface{}: // RESP3: {results: [{id: ...}, ...], total_results: n}
+		results, _ := typed["results"].([]interface{})
+		for _, result := range results {
+			if document, ok := result.(map[interface{}]interface{}); ok {
+				if id, ok := document["id"].(string); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,208 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type plannerGuildView struct {
+	ID          string    `json:"id"`
+	Region      string    `json:"region"`
+	Language    string    `json:"language"`
+	Level       int       `json:"level"`
+	MemberCount int       `json:"member_count"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (v *plannerGuildView) GetID() string             { return v.ID }
+func (v *plannerGuildView) GetType() string           { return "PlannerGuildView" }
+func (v *plannerGuildView) GetVersion() int           { return 1 }
+func (v *plannerGuildView) GetData() interface{}      { return v }
+func (v *plannerGuildView) GetLastUpdated() time.Time { return v.UpdatedAt }
+func (v *plannerGuildView) Validate() error           { return nil }
+
+func newPlannerTestReadStore(t *testing.T) *RedisReadStore {
+	t.Helper()
+	cqrs.RegisterReadModelType("PlannerGuildView", reflect.TypeOf(&plannerGuildView{}))
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	manager := &RedisClientManager{client: client, metrics: &RedisMetrics{}}
+	store := NewRedisReadStore(manager, "test", &JSONReadModelSerializer{})
+
+	ctx := context.Background()
+	for _, guild := range []*plannerGuildView{
+		{ID: "g1", Region: "kr", Language: "ko", Level: 10, MemberCount: 30},
+		{ID: "g2", Region: "kr", Language: "en", Level: 25, MemberCount: 12},
+		{ID: "g3", Region: "us", Language: "en", Level: 40, MemberCount: 50},
+		{ID: "g4", Region: "kr", Language: "ko", Level: 35, MemberCount: 45},
+	} {
+		require.NoError(t, store.Save(ctx, guild))
+	}
+	return store
+}
+
+func guildIDs(models []cqrs.ReadModel) []string {
+	ids := make([]string, len(models))
+	for i, model := range models {
+		ids[i] = model.GetID()
+	}
+	return ids
+}
+
+func TestRedisReadStore_QueryWithPlan_UsesSecondaryIndex(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newPlannerTestReadStore(t)
+	require.NoError(t, store.CreateIndex(ctx, "PlannerGuildView", []string{"region", "language"}))
+
+	// Act
+	results, plan, err := store.QueryWithPlan(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{
+			"type":   "PlannerGuildView",
+			"region": "kr",
+			"level":  map[string]interface{}{"$gte": 20},
+		},
+		SortBy:    "member_count",
+		SortOrder: cqrs.Descending,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"g4", "g2"}, guildIDs(results))
+	assert.Equal(t, RedisStrategySecondaryIndex, plan.Strategy)
+	assert.Equal(t, []string{"region"}, plan.IndexedFilters)
+	assert.Equal(t, []string{"level"}, plan.ResidualFilters)
+	assert.Equal(t, 3, plan.Candidates) // Only the kr guilds were loaded
+	assert.Equal(t, 2, plan.Matched)
+	assert.Empty(t, plan.Warning)
+	assert.Equal(t, int64(1), store.QueryPlannerMetrics().SecondaryIndexQueries)
+}
+
+func TestRedisReadStore_SecondaryIndexFollowsUpdatesAndDeletes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newPlannerTestReadStore(t)
+	require.NoError(t, store.CreateIndex(ctx, "PlannerGuildView", []string{"region"}))
+	criteria := cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "PlannerGuildView", "region": map[string]interface{}{"$in": []string{"us", "jp"}}},
+		SortBy:  "id",
+	}
+
+	// Act
+	require.NoError(t, store.Save(ctx, &plannerGuildView{ID: "g1", Region: "jp", Language: "ja"}))
+	require.NoError(t, store.Delete(ctx, "g3", "PlannerGuildView"))
+	require.NoError(t, store.Save(ctx, &plannerGuildView{ID: "g5", Region: "us"}))
+	require.NoError(t, store.DeleteBatch(ctx, []string{"g5"}, "PlannerGuildView"))
+	results, plan, err := store.QueryWithPlan(ctx, criteria)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, RedisStrategySecondaryIndex, plan.Strategy)
+	assert.Equal(t, []string{"g1"}, guildIDs(results))
+	assert.Equal(t, 1, plan.Candidates)
+
+	members, err := store.client.GetClient().SMembers(ctx, store.keyBuilder.FieldIndexKey("PlannerGuildView", "region", "kr")).Result()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"g2", "g4"}, members)
+}
+
+func TestRedisReadStore_QueryWithPlan_WarnsOnFallback(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newPlannerTestReadStore(t)
+	var observed []RedisQueryPlan
+	store.OnQueryPlan(func(plan RedisQueryPlan) { observed = append(observed, plan) })
+
+	// Act
+	byType, typePlan, err := store.QueryWithPlan(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "PlannerGuildView", "language": "en"},
+	})
+	require.NoError(t, err)
+	scanned, scanPlan, err := store.QueryWithPlan(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"level": map[string]interface{}{"$lt": 20}},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	assert.ElementsMatch(t, []string{"g2", "g3"}, guildIDs(byType))
+	assert.Equal(t, RedisStrategyTypeIndex, typePlan.Strategy)
+	assert.Contains(t, typePlan.Warning, "language")
+	assert.Equal(t, 4, typePlan.Candidates)
+
+	assert.Equal(t, []string{"g1"}, guildIDs(scanned))
+	assert.Equal(t, RedisStrategyScan, scanPlan.Strategy)
+	assert.NotEmpty(t, scanPlan.Warning)
+
+	metrics := store.QueryPlannerMetrics()
+	assert.Equal(t, int64(2), metrics.ScanFallbacks)
+	assert.Equal(t, int64(1), metrics.Scans)
+	assert.Len(t, observed, 2)
+}
+
+func TestRedisReadStore_Explain(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newPlannerTestReadStore(t)
+	require.NoError(t, store.CreateIndex(ctx, "PlannerGuildView", []string{"region"}))
+
+	// Act
+	lookup := store.Explain(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": "PlannerGuildView", "id": "g1"}})
+	ranged := store.Explain(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{
+		"type":   "PlannerGuildView",
+		"region": map[string]interface{}{"$ne": "kr"},
+	}})
+
+	// Assert
+	assert.Equal(t, RedisStrategyKeyLookup, lookup.Strategy)
+	assert.Equal(t, RedisStrategyTypeIndex, ranged.Strategy) // $ne cannot use the index
+	assert.Equal(t, []string{"region"}, ranged.ResidualFilters)
+	assert.Zero(t, store.QueryPlannerMetrics().TypeIndexQueries)
+}
+
+func TestRedisReadStore_DropIndexFallsBack(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newPlannerTestReadStore(t)
+	require.NoError(t, store.CreateIndex(ctx, "PlannerGuildView", []string{"region"}))
+
+	// Act
+	require.NoError(t, store.DropIndex(ctx, "PlannerGuildView", "region"))
+	results, plan, err := store.QueryWithPlan(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "PlannerGuildView", "region": "us"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, RedisStrategyTypeIndex, plan.Strategy)
+	assert.Equal(t, []string{"g3"}, guildIDs(results))
+	keys, err := store.client.GetClient().Keys(ctx, store.keyBuilder.FieldIndexKey("PlannerGuildView", "region", "*")).Result()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestRedisSearchHelpers(t *testing.T) {
+	query := redisSearchQuery(map[string]interface{}{
+		"region":     map[string]interface{}{"$in": []string{"kr", "us-east"}},
+		"stats.tier": 3,
+	}, []string{"region", "stats.tier"})
+	assert.Equal(t, `@region:{kr | us\-east} @stats_tier:{3}`, query)
+
+	resp2 := []interface{}{int64(2), "test:indexentry:PlannerGuildView:g1", "test:indexentry:PlannerGuildView:g2"}
+	resp3 := map[interface{}]interface{}{
+		"total_results": int64(1),
+		"results":       []interface{}{map[interface{}]interface{}{"id": "test:indexentry:PlannerGuildView:g1"}},
+	}
+	assert.Len(t, redisSearchDocumentIDs(resp2), 2)
+	assert.Equal(t, []string{"test:indexentry:PlannerGuildView:g1"}, redisSearchDocumentIDs(resp3))
+}
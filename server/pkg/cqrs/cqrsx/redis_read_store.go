@@ -4,6 +4,7 @@ import (
 	"context"
 	"cqrs"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,10 +27,14 @@ import (
 //   - client: Redis client manager for connection handling
 //   - keyBuilder: Utility for generating consistent Redis keys
 //   - serializer: Pluggable serializer for read model data
+//   - indexes: Secondary index fields registered through CreateIndex
 type RedisReadStore struct {
-	client     *RedisClientManager // Manages Redis connections and operations
-	keyBuilder *RedisKeyBuilder    // Generates consistent Redis keys
-	serializer ReadModelSerializer // Handles read model serialization/deserialization
+	client       *RedisClientManager  // Manages Redis connections and operations
+	keyBuilder   *RedisKeyBuilder     // Generates consistent Redis keys
+	serializer   ReadModelSerializer  // Handles read model serialization/deserialization
+	indexes      *redisIndexRegistry  // Indexed fields per model type
+	counters     redisPlannerCounters // Query counts by plan strategy
+	planListener func(RedisQueryPlan) // Optional hook receiving every executed plan
 }
 
 // ReadModelFactory interface defines the contract for creating read models by type.
@@ -90,14 +95,14 @@ type ReadModelData struct {
 //
 // Usage:
 //
-//	factory := NewUserReadModelFactory()
-//	serializer := NewJSONReadModelSerializer(factory)
-//	readStore := NewRedisReadStore(client, "myapp", serializer)
+//	cqrs.RegisterReadModelType("UserView", reflect.TypeOf(&UserView{}))
+//	readStore := NewRedisReadStore(client, "myapp", &JSONReadModelSerializer{})
 func NewRedisReadStore(client *RedisClientManager, keyPrefix string, serializer ReadModelSerializer) *RedisReadStore {
 	return &RedisReadStore{
 		client:     client,
 		keyBuilder: NewRedisKeyBuilder(keyPrefix),
 		serializer: serializer,
+		indexes:    newRedisIndexRegistry(),
 	}
 }
 
@@ -123,7 +128,7 @@ func NewRedisReadStore(client *RedisClientManager, keyPrefix string, serializer
 func (rs *RedisReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) error {
 	// Validate input parameters
 	if readModel == nil {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "read model cannot be nil", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "read model cannot be nil", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	// Validate read model business rules
@@ -169,7 +174,7 @@ func (rs *RedisReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) er
 // Error conditions:
 //   - id is empty: Returns repository error
 //   - modelType is empty: Returns repository error
-//   - Read model not found: Returns repository error with "not found" message
+//   - Read model not found: Returns READ_MODEL_NOT_FOUND error (cqrs.IsNotFoundError reports true)
 //   - Redis operation fails: Returns repository error with Redis details
 //   - Deserialization fails: Returns serialization error
 //
@@ -179,10 +184,10 @@ func (rs *RedisReadStore) Save(ctx context.Context, readModel cqrs.ReadModel) er
 func (rs *RedisReadStore) GetByID(ctx context.Context, id string, modelType string) (cqrs.ReadModel, error) {
 	// Validate input parameters
 	if id == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "id cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "id cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 	if modelType == "" {
-		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	// Generate consistent Redis key
@@ -197,7 +202,7 @@ func (rs *RedisReadStore) GetByID(ctx context.Context, id string, modelType stri
 		if err != nil {
 			// Handle "key not found" case specifically
 			if err == redis.Nil {
-				return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+				return cqrs.NewCQRSError(cqrs.ErrCodeReadModelNotFound.String(),
 					fmt.Sprintf("read model not found: %s:%s", modelType, id), nil)
 			}
 			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to get read model", err)
@@ -219,13 +224,70 @@ func (rs *RedisReadStore) GetByID(ctx context.Context, id string, modelType stri
 	return readModel, nil
 }
 
+// GetByIDs retrieves several read models of one type with a single MGET.
+// It implements cqrs.BatchReadStore for list queries such as guild member lookups.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - ids: Read model identifiers; empty and duplicate ids are ignored
+//   - modelType: The type of read models to retrieve (must be non-empty)
+//
+// Returns:
+//   - []cqrs.ReadModel: The read models that exist, in the order of ids
+//   - error: nil on success, CQRSError on validation, Redis or deserialization failure
+//
+// Performance: One round trip regardless of the number of ids
+func (rs *RedisReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]cqrs.ReadModel, error) {
+	if modelType == "" {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	ids = batchReadIDs(ids)
+	if len(ids) == 0 {
+		return []cqrs.ReadModel{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = rs.keyBuilder.ReadModelKey(modelType, id)
+	}
+
+	var readModels []cqrs.ReadModel
+	err := rs.client.ExecuteCommand(ctx, func() error {
+		values, err := rs.client.GetClient().MGet(ctx, keys...).Result()
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to get read models", err)
+		}
+
+		readModels = make([]cqrs.ReadModel, 0, len(values))
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue // Missing key
+			}
+
+			readModel, err := rs.serializer.DeserializeReadModel([]byte(data), modelType)
+			if err != nil {
+				return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to deserialize read model", err)
+			}
+			readModels = append(readModels, readModel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return readModels, nil
+}
+
 // Delete removes a read model
 func (rs *RedisReadStore) Delete(ctx context.Context, id string, modelType string) error {
 	if id == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "id cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "id cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 	if modelType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	modelKey := rs.keyBuilder.ReadModelKey(modelType, id)
@@ -254,55 +316,142 @@ func (rs *RedisReadStore) Delete(ctx context.Context, id string, modelType strin
 	})
 }
 
-// Query performs a query on read models
+// Query performs a query on read models.
+// The planner pushes equality filters on fields registered with CreateIndex down to
+// RediSearch or the secondary index sets and only falls back to loading every read model
+// of the type (or scanning the keyspace when no type is given) when no index applies.
+// Use QueryWithPlan or Explain to see which strategy a query takes.
 func (rs *RedisReadStore) Query(ctx context.Context, criteria cqrs.QueryCriteria) ([]cqrs.ReadModel, error) {
-	var results []cqrs.ReadModel
+	results, _, err := rs.QueryWithPlan(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
 
-	err := rs.client.ExecuteCommand(ctx, func() error {
-		// Simple implementation using pattern matching
-		// In a real implementation, you'd use proper indexing
+// redisStreamScanCount is the SCAN COUNT hint QueryStream uses per round trip
+const redisStreamScanCount = 500
+
+// QueryStream streams read models matching the criteria by walking the keyspace with
+// SCAN and fetching each page with MGET, so memory stays bounded by one page.
+// Results come in keyspace order; sorting is not supported, and like any SCAN a key
+// may be returned twice if the keyspace is rehashed during iteration.
+func (rs *RedisReadStore) QueryStream(ctx context.Context, criteria cqrs.QueryCriteria) (cqrs.ReadModelCursor, error) {
+	if criteria.SortBy != "" {
+		return nil, cqrs.NewValidationError("redis read model streams do not support sorting", nil)
+	}
+
+	// Narrow the scan to one model type when the criteria filter on it
+	modelType := "*"
+	if filterType, ok := criteria.Filters["type"].(string); ok && filterType != "" {
+		modelType = filterType
+	}
+
+	return &redisReadModelCursor{
+		store:    rs,
+		criteria: criteria,
+		pattern:  rs.keyBuilder.ReadModelKey(modelType, "*"),
+	}, nil
+}
+
+// redisReadModelCursor iterates read models page by page with SCAN and MGET
+type redisReadModelCursor struct {
+	store      *RedisReadStore
+	criteria   cqrs.QueryCriteria
+	pattern    string
+	scanCursor uint64
+	scanDone   bool
+	buffer     []cqrs.ReadModel
+	skipped    int
+	emitted    int
+	current    cqrs.ReadModel
+	err        error
+	closed     bool
+}
+
+func (c *redisReadModelCursor) Next(ctx context.Context) bool {
+	c.current = nil
+	for c.err == nil && !c.closed {
+		if c.criteria.Limit > 0 && c.emitted >= c.criteria.Limit {
+			return false
+		}
+
+		if len(c.buffer) > 0 {
+			readModel := c.buffer[0]
+			c.buffer = c.buffer[1:]
+			if c.skipped < c.criteria.Offset {
+				c.skipped++
+				continue
+			}
+			c.current = readModel
+			c.emitted++
+			return true
+		}
+
+		if c.scanDone {
+			return false
+		}
+		c.err = c.fetchPage(ctx)
+	}
+	return false
+}
+
+// fetchPage scans the next page of keys and buffers the read models matching the criteria
+func (c *redisReadModelCursor) fetchPage(ctx context.Context) error {
+	client := c.store.client.GetClient()
 
-		pattern := rs.keyBuilder.ReadModelKey("*", "*")
-		keys, err := rs.client.GetClient().Keys(ctx, pattern).Result()
+	return c.store.client.ExecuteCommand(ctx, func() error {
+		keys, next, err := client.Scan(ctx, c.scanCursor, c.pattern, redisStreamScanCount).Result()
 		if err != nil {
-			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to get keys", err)
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to scan read model keys", err)
+		}
+		c.scanCursor = next
+		c.scanDone = next == 0
+		if len(keys) == 0 {
+			return nil
 		}
 
-		for _, key := range keys {
-			data, err := rs.client.GetClient().Get(ctx, key).Result()
-			if err != nil {
-				continue // Skip invalid entries
+		values, err := client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to get read models", err)
+		}
+
+		for i, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue // Deleted between SCAN and MGET
 			}
 
 			// Extract model type from key
-			parts := strings.Split(key, ":")
+			parts := strings.Split(keys[i], ":")
 			if len(parts) < 4 {
 				continue
 			}
-			modelType := parts[len(parts)-2]
 
-			readModel, err := rs.serializer.DeserializeReadModel([]byte(data), modelType)
+			readModel, err := c.store.serializer.DeserializeReadModel([]byte(data), parts[len(parts)-2])
 			if err != nil {
 				continue // Skip invalid entries
 			}
-
-			// Apply filters (simple implementation)
-			if rs.matchesCriteria(readModel, criteria) {
-				results = append(results, readModel)
+			if c.store.matchesCriteria(readModel, c.criteria) {
+				c.buffer = append(c.buffer, readModel)
 			}
 		}
-
-		// Apply sorting and pagination
-		results = rs.applySortingAndPagination(results, criteria)
-
 		return nil
 	})
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *redisReadModelCursor) ReadModel() cqrs.ReadModel {
+	return c.current
+}
 
-	return results, nil
+func (c *redisReadModelCursor) Err() error {
+	return c.err
+}
+
+func (c *redisReadModelCursor) Close(ctx context.Context) error {
+	c.closed = true
+	c.buffer = nil
+	return nil
 }
 
 // Count returns the count of read models matching criteria
@@ -364,7 +513,7 @@ func (rs *RedisReadStore) DeleteBatch(ctx context.Context, ids []string, modelTy
 		return nil
 	}
 	if modelType == "" {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	return rs.client.ExecuteCommand(ctx, func() error {
@@ -384,21 +533,93 @@ func (rs *RedisReadStore) DeleteBatch(ctx context.Context, ids []string, modelTy
 			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to delete read models batch", err)
 		}
 
+		// Remove from type and secondary indexes
+		for _, id := range ids {
+			if id == "" {
+				continue
+			}
+			if err := rs.unindexReadModel(ctx, modelType, id); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
 
-// CreateIndex creates an index for a model type and fields
+// CreateIndex creates secondary indexes on the given fields of a model type.
+// Fields are JSON paths of the serialized read model ("guild_name", "stats.level").
+// Existing read models are backfilled, and a RediSearch TAG index is created as well
+// when the module is loaded. The field registry lives in this process, so services
+// call CreateIndex at startup just like they do for the Mongo read store.
 func (rs *RedisReadStore) CreateIndex(ctx context.Context, modelType string, fields []string) error {
-	// Note: This is a simplified implementation
-	// In a real implementation, you'd create proper secondary indexes
-	return nil
+	if modelType == "" || len(fields) == 0 {
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "model type and fields are required", nil).WithCategory(cqrs.CategoryValidation)
+	}
+
+	rs.indexes.mu.Lock()
+	merged := append([]string(nil), rs.indexes.fields[modelType]...)
+	for _, field := range fields {
+		if field == "" || field == "type" || field == "id" {
+			continue // Served by the type index and key lookups
+		}
+		known := false
+		for _, existing := range merged {
+			known = known || existing == field
+		}
+		if !known {
+			merged = append(merged, field)
+		}
+	}
+	sort.Strings(merged)
+	rs.indexes.fields[modelType] = merged
+	rs.indexes.mu.Unlock()
+
+	return rs.client.ExecuteCommand(ctx, func() error {
+		if err := rs.backfillIndex(ctx, modelType); err != nil {
+			return err
+		}
+		if rs.searchAvailable(ctx) {
+			return rs.createSearchIndex(ctx, modelType, merged)
+		}
+		return nil
+	})
 }
 
-// DropIndex drops an index
+// DropIndex drops the secondary index of one field (indexName) of a model type
 func (rs *RedisReadStore) DropIndex(ctx context.Context, modelType string, indexName string) error {
-	// Note: This is a simplified implementation
-	return nil
+	rs.indexes.mu.Lock()
+	fields := rs.indexes.fields[modelType]
+	remaining := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != indexName {
+			remaining = append(remaining, field)
+		}
+	}
+	rs.indexes.fields[modelType] = remaining
+	rs.indexes.mu.Unlock()
+
+	return rs.client.ExecuteCommand(ctx, func() error {
+		client := rs.client.GetClient()
+		iter := client.Scan(ctx, 0, rs.keyBuilder.FieldIndexKey(modelType, indexName, "*"), redisStreamScanCount).Iterator()
+		for iter.Next(ctx) {
+			client.Del(ctx, iter.Val())
+		}
+		entries := client.Scan(ctx, 0, rs.keyBuilder.IndexEntryKey(modelType, "*"), redisStreamScanCount).Iterator()
+		for entries.Next(ctx) {
+			client.HDel(ctx, entries.Val(), indexName)
+		}
+		if err := iter.Err(); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to drop index", err)
+		}
+		if err := entries.Err(); err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to drop index", err)
+		}
+		if rs.indexes.hasSearchIndex(modelType) {
+			return rs.createSearchIndex(ctx, modelType, remaining)
+		}
+		return nil
+	})
 }
 
 // Helper methods
@@ -406,26 +627,37 @@ func (rs *RedisReadStore) DropIndex(ctx context.Context, modelType string, index
 func (rs *RedisReadStore) updateIndexes(ctx context.Context, readModel cqrs.ReadModel) error {
 	// Simple indexing by type
 	typeIndexKey := rs.keyBuilder.IndexKey(readModel.GetType(), "all")
-	return rs.client.GetClient().SAdd(ctx, typeIndexKey, readModel.GetID()).Err()
+	if err := rs.client.GetClient().SAdd(ctx, typeIndexKey, readModel.GetID()).Err(); err != nil {
+		return err
+	}
+	return rs.indexReadModel(ctx, readModel)
 }
 
 func (rs *RedisReadStore) removeFromIndexes(ctx context.Context, readModel cqrs.ReadModel) error {
-	// Remove from type index
-	typeIndexKey := rs.keyBuilder.IndexKey(readModel.GetType(), "all")
-	return rs.client.GetClient().SRem(ctx, typeIndexKey, readModel.GetID()).Err()
+	return rs.unindexReadModel(ctx, readModel.GetType(), readModel.GetID())
 }
 
 func (rs *RedisReadStore) matchesCriteria(readModel cqrs.ReadModel, criteria cqrs.QueryCriteria) bool {
-	// Simple criteria matching - in real implementation, this would be more sophisticated
 	if len(criteria.Filters) == 0 {
 		return true
 	}
 
+	// Other fields are read from the serialized form, decoded at most once
+	var document map[string]interface{}
 	for key, value := range criteria.Filters {
-		if key == "type" && readModel.GetType() != fmt.Sprintf("%v", value) {
-			return false
+		var actual interface{}
+		switch key {
+		case "type":
+			actual = readModel.GetType()
+		case "id":
+			actual = readModel.GetID()
+		default:
+			if document == nil {
+				document = rs.readModelDocument(readModel)
+			}
+			actual = lookupField(document, key)
 		}
-		if key == "id" && readModel.GetID() != fmt.Sprintf("%v", value) {
+		if !matchesFilter(actual, value) {
 			return false
 		}
 	}
@@ -434,7 +666,10 @@ func (rs *RedisReadStore) matchesCriteria(readModel cqrs.ReadModel, criteria cqr
 }
 
 func (rs *RedisReadStore) applySortingAndPagination(results []cqrs.ReadModel, criteria cqrs.QueryCriteria) []cqrs.ReadModel {
-	// Simple pagination implementation
+	if criteria.SortBy != "" {
+		rs.sortReadModels(results, criteria)
+	}
+
 	if criteria.Limit > 0 {
 		start := criteria.Offset
 		end := start + criteria.Limit
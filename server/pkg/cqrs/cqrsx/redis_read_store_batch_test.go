@@ -0,0 +1,27 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisReadStore_GetByIDs_LoadsExistingModelsInOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newStreamTestReadStore(t, 5, 1)
+
+	// Act
+	models, err := cqrs.GetReadModelsByIDs(ctx, store,
+		[]string{"user-0003", "user-9999", "user-0001", "guild-0000"}, "StreamUserView")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	assert.Equal(t, "user-0003", models[0].GetID())
+	assert.Equal(t, "user-0001", models[1].GetID())
+	assert.IsType(t, &streamTestView{}, models[0])
+}
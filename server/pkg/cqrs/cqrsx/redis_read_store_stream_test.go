@@ -0,0 +1,103 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamTestView struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (v *streamTestView) GetID() string             { return v.ID }
+func (v *streamTestView) GetType() string           { return v.Kind }
+func (v *streamTestView) GetVersion() int           { return 1 }
+func (v *streamTestView) GetData() interface{}      { return v }
+func (v *streamTestView) GetLastUpdated() time.Time { return v.UpdatedAt }
+func (v *streamTestView) Validate() error           { return nil }
+
+func newStreamTestReadStore(t *testing.T, users, guilds int) *RedisReadStore {
+	t.Helper()
+	cqrs.RegisterReadModelType("StreamUserView", reflect.TypeOf(&streamTestView{}))
+	cqrs.RegisterReadModelType("StreamGuildView", reflect.TypeOf(&streamTestView{}))
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	manager := &RedisClientManager{client: client, metrics: &RedisMetrics{}}
+	store := NewRedisReadStore(manager, "test", &JSONReadModelSerializer{})
+
+	ctx := context.Background()
+	for i := 0; i < users; i++ {
+		require.NoError(t, store.Save(ctx, &streamTestView{ID: fmt.Sprintf("user-%04d", i), Kind: "StreamUserView"}))
+	}
+	for i := 0; i < guilds; i++ {
+		require.NoError(t, store.Save(ctx, &streamTestView{ID: fmt.Sprintf("guild-%04d", i), Kind: "StreamGuildView"}))
+	}
+	return store
+}
+
+func TestRedisReadStore_QueryStream_ScansEveryMatchingModel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newStreamTestReadStore(t, 1200, 30)
+
+	// Act
+	seen := make(map[string]bool)
+	err := cqrs.ForEachReadModel(ctx, store, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": "StreamUserView"},
+	}, func(model cqrs.ReadModel) error {
+		seen[model.GetID()] = true
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, seen, 1200)
+	assert.True(t, seen["user-0000"])
+	assert.False(t, seen["guild-0000"])
+}
+
+func TestRedisReadStore_QueryStream_AppliesOffsetAndLimit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := newStreamTestReadStore(t, 50, 0)
+
+	// Act
+	cursor, err := store.QueryStream(ctx, cqrs.QueryCriteria{Offset: 10, Limit: 15})
+	require.NoError(t, err)
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+
+	// Assert
+	require.NoError(t, cursor.Err())
+	assert.Equal(t, 15, count)
+	assert.Nil(t, cursor.ReadModel())
+}
+
+func TestRedisReadStore_QueryStream_RejectsSorting(t *testing.T) {
+	// Arrange
+	store := newStreamTestReadStore(t, 0, 0)
+
+	// Act
+	_, err := store.QueryStream(context.Background(), cqrs.QueryCriteria{SortBy: "id"})
+
+	// Assert
+	assert.True(t, cqrs.IsValidationError(err))
+}
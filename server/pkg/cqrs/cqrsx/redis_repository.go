@@ -4,6 +4,7 @@ import (
 	"context"
 	"cqrs"
 	"fmt"
+	"time"
 )
 
 // RedisEventSourcedRepository implements EventSourcedRepository using Redis
@@ -11,6 +12,9 @@ type RedisEventSourcedRepository struct {
 	eventStore    *RedisEventStore
 	snapshotStore cqrs.SnapshotStore
 	aggregateType string
+	registry      *cqrs.AggregateRegistry
+	metrics       *storageMetricsRecorder
+	coldStorage   *ColdStorageTiering
 }
 
 // NewRedisEventSourcedRepository creates a new Redis event sourced repository
@@ -19,9 +23,29 @@ func NewRedisEventSourcedRepository(eventStore *RedisEventStore, snapshotStore c
 		eventStore:    eventStore,
 		snapshotStore: snapshotStore,
 		aggregateType: aggregateType,
+		registry:      cqrs.DefaultAggregateRegistry(),
+		metrics:       newStorageMetricsRecorder(),
 	}
 }
 
+// SetAggregateRegistry sets the registry used to rehydrate concrete aggregate types
+func (r *RedisEventSourcedRepository) SetAggregateRegistry(registry *cqrs.AggregateRegistry) {
+	r.registry = registry
+}
+
+// SetColdStorage makes GetByID and Exists restore aggregates evicted to cold storage on demand
+func (r *RedisEventSourcedRepository) SetColdStorage(tiering *ColdStorageTiering) {
+	r.coldStorage = tiering
+}
+
+// restoreFromColdStorage moves an evicted aggregate back to Redis; false when it was not evicted
+func (r *RedisEventSourcedRepository) restoreFromColdStorage(ctx context.Context, id string) (bool, error) {
+	if r.coldStorage == nil {
+		return false, nil
+	}
+	return r.coldStorage.Restore(ctx, r.aggregateType, id)
+}
+
 // RedisEventSourcedRepository implementation
 
 func (r *RedisEventSourcedRepository) Save(ctx context.Context, aggregate cqrs.AggregateRoot, expectedVersion int) error {
@@ -44,36 +68,64 @@ func (r *RedisEventSourcedRepository) Save(ctx context.Context, aggregate cqrs.A
 
 	// Clear changes after successful save
 	aggregate.ClearChanges()
+	r.metrics.recordAccess(aggregate.ID())
 
 	return nil
 }
 
 func (r *RedisEventSourcedRepository) GetByID(ctx context.Context, id string) (cqrs.AggregateRoot, error) {
+	loadStarted := time.Now()
+
 	// Try to load from snapshot first
-	var aggregate cqrs.AggregateRoot
+	var snapshot cqrs.SnapshotData
 	var fromVersion int = 0
 
 	if r.snapshotStore != nil {
-		snapshot, err := r.snapshotStore.Load(ctx, id)
-		if err == nil && snapshot != nil {
-			// Create aggregate from snapshot
-			aggregate = cqrs.NewBaseAggregate(id, r.aggregateType, cqrs.WithOriginalVersion(snapshot.Version()))
-			// Note: In real implementation, you'd need to restore aggregate state from snapshot
-			fromVersion = snapshot.Version() + 1
+		loaded, err := r.snapshotStore.Load(ctx, id)
+		if err == nil && loaded != nil {
+			snapshot = loaded
+			fromVersion = loaded.Version() + 1
 		}
 	}
 
-	// If no snapshot, create new aggregate
-	if aggregate == nil {
-		aggregate = cqrs.NewBaseAggregate(id, r.aggregateType)
-	}
-
 	// Load events from event store
 	events, err := r.eventStore.GetEventHistory(ctx, id, r.aggregateType, fromVersion)
 	if err != nil {
 		return nil, err
 	}
 
+	// An empty stream may belong to an aggregate evicted to cold storage
+	if len(events) == 0 && snapshot == nil {
+		restored, err := r.restoreFromColdStorage(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if restored {
+			events, err = r.eventStore.GetEventHistory(ctx, id, r.aggregateType, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	replayStarted := time.Now()
+	defer func() {
+		r.metrics.recordLoad(id, snapshot != nil, len(events), time.Since(loadStarted), time.Since(replayStarted))
+	}()
+
+	// Rehydrate the concrete domain type when the aggregate type is registered
+	if r.registry != nil && r.registry.IsRegistered(r.aggregateType) {
+		return r.registry.Rehydrate(r.aggregateType, id, snapshot, events)
+	}
+
+	var aggregate cqrs.AggregateRoot
+	if snapshot != nil {
+		// Note: without a registered aggregate type only the version can be restored from the snapshot
+		aggregate = cqrs.NewBaseAggregate(id, r.aggregateType, cqrs.WithOriginalVersion(snapshot.Version()))
+	} else {
+		aggregate = cqrs.NewBaseAggregate(id, r.aggregateType)
+	}
+
 	// Apply events to aggregate
 	for _, event := range events {
 		aggregate.ReplayEvent(event) // false = existing event, don't track as change
@@ -88,6 +140,10 @@ func (r *RedisEventSourcedRepository) GetVersion(ctx context.Context, id string)
 
 func (r *RedisEventSourcedRepository) Exists(ctx context.Context, id string) bool {
 	version, err := r.GetVersion(ctx, id)
+	if err == nil && version == 0 && r.coldStorage != nil {
+		archived, archivedErr := r.coldStorage.IsArchived(ctx, r.aggregateType, id)
+		return archivedErr == nil && archived
+	}
 	return err == nil && version > 0
 }
 
@@ -134,3 +190,21 @@ func (r *RedisEventSourcedRepository) GetLastEventVersion(ctx context.Context, a
 func (r *RedisEventSourcedRepository) CompactEvents(ctx context.Context, aggregateID string, beforeVersion int) error {
 	return r.eventStore.CompactEvents(ctx, aggregateID, r.aggregateType, beforeVersion)
 }
+
+// GetStorageMetrics returns the aggregate's event and snapshot counts together with
+// the load timings recorded by this repository instance
+func (r *RedisEventSourcedRepository) GetStorageMetrics(ctx context.Context, aggregateID string) (*cqrs.StorageMetrics, error) {
+	version, err := r.eventStore.GetLastEventVersion(ctx, aggregateID, r.aggregateType)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := r.metrics.snapshot(aggregateID)
+	metrics.EventCount = int64(version)
+	metrics.SnapshotCount = 0
+	if r.snapshotStore != nil && r.snapshotStore.Exists(ctx, aggregateID) {
+		metrics.SnapshotCount = 1 // SnapshotStore keeps only the latest snapshot
+	}
+
+	return &metrics, nil
+}
@@ -0,0 +1,50 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrementWindowScript counts a hit and starts the window's expiry on the first hit;
+// returns the count and the milliseconds left in the window
+var incrementWindowScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// RedisThrottleCounter implements cqrs.ThrottleCounter with one expiring counter per key
+// and window, so command throttles hold across server instances
+type RedisThrottleCounter struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisThrottleCounter creates a counter; keyPrefix defaults to "throttle"
+func NewRedisThrottleCounter(client redis.UniversalClient, keyPrefix string) (*RedisThrottleCounter, error) {
+	if client == nil {
+		return nil, cqrs.NewValidationError("redis client is required", nil)
+	}
+	if keyPrefix == "" {
+		keyPrefix = "throttle"
+	}
+	return &RedisThrottleCounter{client: client, keyPrefix: keyPrefix}, nil
+}
+
+func (c *RedisThrottleCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if window <= 0 {
+		return 0, 0, cqrs.NewValidationError("throttle window must be positive", nil)
+	}
+	values, err := incrementWindowScript.Run(ctx, c.client, []string{c.keyPrefix + ":" + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, cqrs.NewInfrastructureError(cqrs.ErrCodeRepositoryError, "failed to increment throttle counter", err)
+	}
+	return values[0], time.Duration(values[1]) * time.Millisecond, nil
+}
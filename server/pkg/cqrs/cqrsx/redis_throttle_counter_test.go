@@ -0,0 +1,40 @@
+package cqrsx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisThrottleCounter_FixedWindow(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	counter, err := NewRedisThrottleCounter(client, "")
+	require.NoError(t, err)
+
+	// Act
+	first, firstTTL, err := counter.Increment(ctx, "InviteMember:Guild:aggregate:g1", time.Minute)
+	require.NoError(t, err)
+	server.FastForward(20 * time.Second)
+	second, secondTTL, err := counter.Increment(ctx, "InviteMember:Guild:aggregate:g1", time.Minute)
+	require.NoError(t, err)
+	server.FastForward(time.Minute)
+	reset, _, err := counter.Increment(ctx, "InviteMember:Guild:aggregate:g1", time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(1), first)
+	assert.Equal(t, time.Minute, firstTTL)
+	assert.Equal(t, int64(2), second)
+	assert.Equal(t, 40*time.Second, secondTTL)
+	assert.Equal(t, int64(1), reset)
+	assert.True(t, server.Exists("throttle:InviteMember:Guild:aggregate:g1"))
+}
@@ -0,0 +1,442 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetainForever keeps events of a type regardless of their age
+const RetainForever time.Duration = -1
+
+// RetentionRule sets how long events of a type are kept
+type RetentionRule struct {
+	EventType string        `json:"event_type"` // Exact event type, a "Prefix*" pattern or "*" for every type
+	KeepFor   time.Duration `json:"keep_for"`   // Age after which events expire, or RetainForever
+	Archive   bool          `json:"archive"`    // Copy expired events to cold storage before they are compacted
+}
+
+// RetentionRules are the retention rules of an event store.
+// The most specific rule wins: an exact type, then the longest prefix, then "*".
+// Event types without a matching rule are kept forever.
+type RetentionRules []RetentionRule
+
+// Validate checks that every rule has a pattern and a valid period, without duplicates
+func (r RetentionRules) Validate() error {
+	seen := make(map[string]bool, len(r))
+	for _, rule := range r {
+		if rule.EventType == "" {
+			return cqrs.NewValidationError("retention rule needs an event type", nil)
+		}
+		if strings.Contains(strings.TrimSuffix(rule.EventType, "*"), "*") {
+			return cqrs.NewValidationError(fmt.Sprintf("retention rule %q may only end with *", rule.EventType), nil)
+		}
+		if rule.KeepFor <= 0 && rule.KeepFor != RetainForever {
+			return cqrs.NewValidationError(fmt.Sprintf("retention rule %q needs a positive period or RetainForever", rule.EventType), nil)
+		}
+		if seen[rule.EventType] {
+			return cqrs.NewValidationError(fmt.Sprintf("duplicate retention rule %q", rule.EventType), nil)
+		}
+		seen[rule.EventType] = true
+	}
+	return nil
+}
+
+// Match returns the rule for an event type
+func (r RetentionRules) Match(eventType string) (RetentionRule, bool) {
+	var best RetentionRule
+	bestLength := -1
+	for _, rule := range r {
+		if rule.EventType == eventType {
+			return rule, true
+		}
+		prefix, isPattern := strings.CutSuffix(rule.EventType, "*")
+		if isPattern && strings.HasPrefix(eventType, prefix) && len(prefix) > bestLength {
+			best, bestLength = rule, len(prefix)
+		}
+	}
+	return best, bestLength >= 0
+}
+
+// expired reports whether an event is past its retention period at now
+func (r RetentionRules) expired(event cqrs.EventMessage, now time.Time) (RetentionRule, bool) {
+	rule, ok := r.Match(event.EventType())
+	if !ok || rule.KeepFor == RetainForever {
+		return rule, false
+	}
+	return rule, now.Sub(event.Timestamp()) >= rule.KeepFor
+}
+
+// shortest returns the shortest finite retention period, or false when every rule keeps forever
+func (r RetentionRules) shortest() (time.Duration, bool) {
+	shortest, found := time.Duration(0), false
+	for _, rule := range r {
+		if rule.KeepFor != RetainForever && (!found || rule.KeepFor < shortest) {
+			shortest, found = rule.KeepFor, true
+		}
+	}
+	return shortest, found
+}
+
+// Rules converts the policy to retention rules: RetentionDays applies to every type, and
+// EventTypes overrides it per type (a negative number of days keeps the type forever).
+// A disabled policy has no rules.
+func (p *RetentionPolicy) Rules() RetentionRules {
+	if p == nil || !p.Enabled {
+		return nil
+	}
+	days := func(n int) time.Duration {
+		if n < 0 {
+			return RetainForever
+		}
+		return time.Duration(n) * 24 * time.Hour
+	}
+	var rules RetentionRules
+	if p.RetentionDays > 0 {
+		rules = append(rules, RetentionRule{EventType: "*", KeepFor: days(p.RetentionDays), Archive: p.ArchiveEnabled})
+	}
+	eventTypes := make([]string, 0, len(p.EventTypes))
+	for eventType := range p.EventTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	for _, eventType := range eventTypes {
+		rules = append(rules, RetentionRule{EventType: eventType, KeepFor: days(p.EventTypes[eventType]), Archive: p.ArchiveEnabled})
+	}
+	return rules
+}
+
+// RetentionEventStore is an event store whose streams can be listed and compacted
+type RetentionEventStore interface {
+	AggregateCatalog
+	GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+	CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error
+}
+
+// RetentionEngineConfig configures RetentionEngine
+type RetentionEngineConfig struct {
+	Rules          RetentionRules                // Required
+	Events         RetentionEventStore           // Required
+	Cold           ColdStorage                   // Required when a rule archives
+	EventMarshaler EventMarshaler                // Defaults to JSONEventMarshaler
+	Snapshots      cqrs.SnapshotStore            // Optional: compaction stops at the latest snapshot and keeps the last event
+	AggregateTypes []string                      // Streams to scan; empty scans every type
+	PageSize       int                           // Catalog page size; defaults to 100
+	MaxPerRun      int                           // Compactions per run; 0 is unlimited
+	DryRun         bool                          // Background runs only report what they would remove
+	OnReport       func(report *RetentionReport) // Optional: receives every background run's report
+	Locker         cqrs.AggregateLocker          // Optional: serializes compaction with writers
+	LockTTL        time.Duration                 // Defaults to 30 seconds
+}
+
+// RetentionCompaction is the removal of the expired head of one stream
+type RetentionCompaction struct {
+	AggregateType string         `json:"aggregate_type"`
+	AggregateID   string         `json:"aggregate_id"`
+	BeforeVersion int            `json:"before_version"`        // Events below this version are removed
+	EventTypes    map[string]int `json:"event_types"`           // Removed events by type
+	ArchiveKey    string         `json:"archive_key,omitempty"` // Cold storage key of the archived events
+}
+
+// RetentionReport is the outcome of one retention run
+type RetentionReport struct {
+	StartedAt   time.Time             `json:"started_at"`
+	DryRun      bool                  `json:"dry_run"`
+	Scanned     int                   `json:"scanned"` // Streams old enough to hold expired events
+	Compactions []RetentionCompaction `json:"compactions"`
+	Removed     int                   `json:"removed"`          // Events removed, or that would be in a dry run
+	Held        map[string]int        `json:"held,omitempty"`   // Expired events kept because an older event is retained, by type
+	Errors      map[string]string     `json:"errors,omitempty"` // "type/id" -> compaction error
+}
+
+// RetentionEngine removes events past their type's retention period.
+// Streams are append-only, so only the expired head of a stream is removed (with CompactEvents);
+// an expired event after a retained one is held until everything before it expires.
+// Without a snapshot store, streams are treated as logs that are never replayed past the removed head.
+type RetentionEngine struct {
+	config RetentionEngineConfig
+
+	mutex  sync.Mutex
+	now    func() time.Time
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewRetentionEngine creates a retention engine
+func NewRetentionEngine(config RetentionEngineConfig) (*RetentionEngine, error) {
+	if config.Events == nil {
+		return nil, cqrs.NewValidationError("event store is required", nil)
+	}
+	if len(config.Rules) == 0 {
+		return nil, cqrs.NewValidationError("at least one retention rule is required", nil)
+	}
+	if err := config.Rules.Validate(); err != nil {
+		return nil, err
+	}
+	for _, rule := range config.Rules {
+		if rule.Archive && config.Cold == nil {
+			return nil, cqrs.NewValidationError(fmt.Sprintf("retention rule %q archives but no cold storage is configured", rule.EventType), nil)
+		}
+	}
+	if config.EventMarshaler == nil {
+		config.EventMarshaler = &JSONEventMarshaler{}
+	}
+	if config.PageSize <= 0 {
+		config.PageSize = 100
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = 30 * time.Second
+	}
+
+	return &RetentionEngine{config: config, now: time.Now}, nil
+}
+
+// Plan reports what a run would remove without changing anything
+func (e *RetentionEngine) Plan(ctx context.Context) (*RetentionReport, error) {
+	return e.run(ctx, true)
+}
+
+// Run archives and compacts every expired stream head
+func (e *RetentionEngine) Run(ctx context.Context) (*RetentionReport, error) {
+	return e.run(ctx, false)
+}
+
+func (e *RetentionEngine) run(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	now := e.now()
+	report := &RetentionReport{
+		StartedAt:   now,
+		DryRun:      dryRun,
+		Compactions: make([]RetentionCompaction, 0),
+		Held:        make(map[string]int),
+		Errors:      make(map[string]string),
+	}
+	shortest, expires := e.config.Rules.shortest()
+	if !expires {
+		return report, nil
+	}
+
+	// Collect candidates before compacting so that removed streams do not shift the catalog pages
+	streams, err := e.candidates(ctx, now.Add(-shortest))
+	if err != nil {
+		return nil, err
+	}
+	report.Scanned = len(streams)
+
+	for _, stream := range streams {
+		if e.config.MaxPerRun > 0 && len(report.Compactions) >= e.config.MaxPerRun {
+			break
+		}
+		key := coldArchiveKey(stream.AggregateType, stream.AggregateID)
+		compaction, err := e.compact(ctx, stream, now, dryRun, report.Held)
+		if err != nil {
+			report.Errors[key] = err.Error()
+			continue
+		}
+		if compaction == nil {
+			continue
+		}
+		report.Compactions = append(report.Compactions, *compaction)
+		for _, count := range compaction.EventTypes {
+			report.Removed += count
+		}
+	}
+	return report, nil
+}
+
+// candidates lists streams whose first event is older than cutoff
+func (e *RetentionEngine) candidates(ctx context.Context, cutoff time.Time) ([]AggregateStreamInfo, error) {
+	aggregateTypes := e.config.AggregateTypes
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = []string{""}
+	}
+	var candidates []AggregateStreamInfo
+	for _, aggregateType := range aggregateTypes {
+		for offset := 0; ; offset += e.config.PageSize {
+			page, err := e.config.Events.ListAggregates(ctx, aggregateType, e.config.PageSize, offset)
+			if err != nil {
+				return nil, err
+			}
+			for _, stream := range page {
+				if stream.FirstEventAt.IsZero() || !stream.FirstEventAt.After(cutoff) {
+					candidates = append(candidates, stream)
+				}
+			}
+			if len(page) < e.config.PageSize {
+				break
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// compact removes the expired head of one stream, archiving it first when a rule asks for it.
+// It returns nil when nothing in the stream can be removed.
+func (e *RetentionEngine) compact(ctx context.Context, stream AggregateStreamInfo, now time.Time, dryRun bool, held map[string]int) (*RetentionCompaction, error) {
+	var compaction *RetentionCompaction
+	err := e.withLock(ctx, stream.AggregateType, stream.AggregateID, func(ctx context.Context) error {
+		history, err := e.config.Events.GetEventHistory(ctx, stream.AggregateID, stream.AggregateType, 0)
+		if err != nil {
+			return err
+		}
+
+		head, archive := 0, false
+		for _, event := range history {
+			rule, expired := e.config.Rules.expired(event, now)
+			if !expired {
+				break
+			}
+			head++
+			archive = archive || rule.Archive
+		}
+		limit, err := e.compactionLimit(ctx, stream, history)
+		if err != nil {
+			return err
+		}
+		if head > limit {
+			head = limit
+		}
+		for _, event := range history[head:] {
+			if _, expired := e.config.Rules.expired(event, now); expired {
+				held[event.EventType()]++
+			}
+		}
+		if head == 0 {
+			return nil
+		}
+
+		removed := history[:head]
+		compaction = &RetentionCompaction{
+			AggregateType: stream.AggregateType,
+			AggregateID:   stream.AggregateID,
+			BeforeVersion: removed[len(removed)-1].Version() + 1,
+			EventTypes:    make(map[string]int),
+		}
+		for _, event := range removed {
+			compaction.EventTypes[event.EventType()]++
+		}
+		if archive {
+			compaction.ArchiveKey = fmt.Sprintf("retention/%s/%d-%d",
+				coldArchiveKey(stream.AggregateType, stream.AggregateID), removed[0].Version(), removed[len(removed)-1].Version())
+		}
+		if dryRun {
+			return nil
+		}
+
+		// Archive first, so a failure part way leaves the events hot, never lost
+		if archive {
+			if err := e.archive(ctx, compaction, removed, now); err != nil {
+				return err
+			}
+		}
+		return e.config.Events.CompactEvents(ctx, stream.AggregateID, stream.AggregateType, compaction.BeforeVersion)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compaction, nil
+}
+
+// compactionLimit is the number of leading events that may be removed without breaking rebuilds
+func (e *RetentionEngine) compactionLimit(ctx context.Context, stream AggregateStreamInfo, history []cqrs.EventMessage) (int, error) {
+	if e.config.Snapshots == nil {
+		return len(history), nil
+	}
+	snapshot, err := e.config.Snapshots.Load(ctx, stream.AggregateID)
+	if cqrs.IsNotFoundError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	// Keep the last event so the stream version stays known to optimistic concurrency checks
+	limit := 0
+	for limit < len(history)-1 && history[limit].Version() <= snapshot.Version() {
+		limit++
+	}
+	return limit, nil
+}
+
+func (e *RetentionEngine) archive(ctx context.Context, compaction *RetentionCompaction, events []cqrs.EventMessage, now time.Time) error {
+	archive := coldArchive{
+		AggregateID:   compaction.AggregateID,
+		AggregateType: compaction.AggregateType,
+		Version:       events[len(events)-1].Version(),
+		ArchivedAt:    now,
+		Events:        make([][]byte, 0, len(events)),
+	}
+	for _, event := range events {
+		data, err := e.config.EventMarshaler.Marshal(event)
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize event for archive", err)
+		}
+		archive.Events = append(archive.Events, data)
+	}
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to serialize archive", err)
+	}
+	return e.config.Cold.Put(ctx, compaction.ArchiveKey, data)
+}
+
+// withLock runs fn while holding the aggregate lock when a locker is configured
+func (e *RetentionEngine) withLock(ctx context.Context, aggregateType, aggregateID string, fn func(ctx context.Context) error) error {
+	if e.config.Locker == nil {
+		return fn(ctx)
+	}
+	return cqrs.WithAggregateLock(ctx, e.config.Locker, aggregateType, aggregateID, e.config.LockTTL,
+		func(ctx context.Context, lock cqrs.AggregateLock) error {
+			return fn(ctx)
+		})
+}
+
+// Start runs retention every interval until ctx is cancelled or Stop is called.
+// With DryRun set, the background job only reports.
+func (e *RetentionEngine) Start(ctx context.Context, interval time.Duration) {
+	e.mutex.Lock()
+	if e.stopCh != nil {
+		e.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	e.stopCh = stopCh
+	e.mutex.Unlock()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				// Failed compactions keep their events and are retried on the next tick
+				report, err := e.run(ctx, e.config.DryRun)
+				if err == nil && e.config.OnReport != nil {
+					e.config.OnReport(report)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the retention loop started by Start
+func (e *RetentionEngine) Stop() {
+	e.mutex.Lock()
+	stopCh := e.stopCh
+	e.stopCh = nil
+	e.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		e.wg.Wait()
+	}
+}
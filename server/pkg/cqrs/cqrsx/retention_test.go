@@ -0,0 +1,227 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRetentionEvents 목록 조회와 앞부분 압축을 지원하는 메모리 이벤트 저장소
+type fakeRetentionEvents struct {
+	streams map[string][]cqrs.EventMessage // "type/id" -> 이벤트
+}
+
+func newFakeRetentionEvents() *fakeRetentionEvents {
+	return &fakeRetentionEvents{streams: make(map[string][]cqrs.EventMessage)}
+}
+
+// add 집합체 스트림에 (이벤트 타입, 나이) 순서대로 이벤트를 추가
+func (f *fakeRetentionEvents) add(t *testing.T, aggregateType, aggregateID string, now time.Time, events ...interface{}) {
+	t.Helper()
+	aggregate := cqrs.NewBaseAggregate(aggregateID, aggregateType)
+	for i := 0; i < len(events); i += 2 {
+		event := cqrs.NewBaseEventMessage(events[i].(string))
+		event.Timestamp_ = now.Add(-events[i+1].(time.Duration))
+		require.NoError(t, aggregate.ApplyEvent(event))
+	}
+	f.streams[coldArchiveKey(aggregateType, aggregateID)] = aggregate.Changes()
+}
+
+func (f *fakeRetentionEvents) ListAggregates(ctx context.Context, aggregateType string, limit, offset int) ([]AggregateStreamInfo, error) {
+	keys := make([]string, 0, len(f.streams))
+	for key := range f.streams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var streams []AggregateStreamInfo
+	for _, key := range keys {
+		events := f.streams[key]
+		if len(events) == 0 || (aggregateType != "" && events[0].AggregateType() != aggregateType) {
+			continue
+		}
+		streams = append(streams, AggregateStreamInfo{
+			AggregateID:   events[0].AggregateID(),
+			AggregateType: events[0].AggregateType(),
+			Version:       events[len(events)-1].Version(),
+			EventCount:    len(events),
+			FirstEventAt:  events[0].Timestamp(),
+			LastEventAt:   events[len(events)-1].Timestamp(),
+		})
+	}
+	if offset >= len(streams) {
+		return nil, nil
+	}
+	streams = streams[offset:]
+	if len(streams) > limit {
+		streams = streams[:limit]
+	}
+	return streams, nil
+}
+
+func (f *fakeRetentionEvents) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	return f.streams[coldArchiveKey(aggregateType, aggregateID)], nil
+}
+
+func (f *fakeRetentionEvents) CompactEvents(ctx context.Context, aggregateID, aggregateType string, beforeVersion int) error {
+	key := coldArchiveKey(aggregateType, aggregateID)
+	var kept []cqrs.EventMessage
+	for _, event := range f.streams[key] {
+		if event.Version() >= beforeVersion {
+			kept = append(kept, event)
+		}
+	}
+	if len(kept) == 0 {
+		delete(f.streams, key)
+		return nil
+	}
+	f.streams[key] = kept
+	return nil
+}
+
+func (f *fakeRetentionEvents) types(aggregateType, aggregateID string) []string {
+	var types []string
+	for _, event := range f.streams[coldArchiveKey(aggregateType, aggregateID)] {
+		types = append(types, event.EventType())
+	}
+	return types
+}
+
+func TestRetentionRules_MostSpecificRuleWins(t *testing.T) {
+	rules := RetentionRules{
+		{EventType: "*", KeepFor: 365 * 24 * time.Hour},
+		{EventType: "Treasury*", KeepFor: RetainForever},
+		{EventType: "TreasuryAudit*", KeepFor: 30 * 24 * time.Hour},
+		{EventType: "UserLoginAttempted", KeepFor: 90 * 24 * time.Hour, Archive: true},
+	}
+	require.NoError(t, rules.Validate())
+
+	login, _ := rules.Match("UserLoginAttempted")
+	deposit, _ := rules.Match("TreasuryDeposited")
+	audit, _ := rules.Match("TreasuryAuditLogged")
+	other, _ := rules.Match("GuildCreated")
+	assert.Equal(t, 90*24*time.Hour, login.KeepFor)
+	assert.Equal(t, RetainForever, deposit.KeepFor)
+	assert.Equal(t, 30*24*time.Hour, audit.KeepFor)
+	assert.Equal(t, "*", other.EventType)
+	_, matched := RetentionRules{{EventType: "User*", KeepFor: time.Hour}}.Match("GuildCreated")
+	assert.False(t, matched)
+
+	assert.Error(t, RetentionRules{{EventType: "User*Attempted", KeepFor: time.Hour}}.Validate())
+	assert.Error(t, RetentionRules{{EventType: "UserLoginAttempted"}}.Validate())
+	assert.Error(t, RetentionRules{{EventType: "A", KeepFor: time.Hour}, {EventType: "A", KeepFor: 2 * time.Hour}}.Validate())
+
+	policy := &RetentionPolicy{Enabled: true, RetentionDays: 365, ArchiveEnabled: true, EventTypes: map[string]int{"UserLoginAttempted": 90, "Treasury*": -1}}
+	assert.Equal(t, RetentionRules{
+		{EventType: "*", KeepFor: 365 * 24 * time.Hour, Archive: true},
+		{EventType: "Treasury*", KeepFor: RetainForever, Archive: true},
+		{EventType: "UserLoginAttempted", KeepFor: 90 * 24 * time.Hour, Archive: true},
+	}, policy.Rules())
+	assert.Nil(t, (&RetentionPolicy{RetentionDays: 30}).Rules())
+}
+
+func TestRetentionEngine_DryRunThenCompactExpiredHead(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	events := newFakeRetentionEvents()
+	events.add(t, "User", "user-1", now,
+		"UserLoginAttempted", 120*day,
+		"UserLoginAttempted", 100*day,
+		"TreasuryDeposited", 95*day,
+		"UserLoginAttempted", 91*day,
+		"UserLoginAttempted", 10*day)
+	events.add(t, "User", "user-2", now, "UserLoginAttempted", 200*day, "UserLoginAttempted", 150*day)
+	events.add(t, "User", "user-3", now, "UserLoginAttempted", 10*day)
+	cold, err := NewFileColdStorage(t.TempDir())
+	require.NoError(t, err)
+	marshaler := NewJSONEventMarshaler(NewVersionedEventRegistry(WithStrictMode(false)))
+	engine, err := NewRetentionEngine(RetentionEngineConfig{
+		Rules: RetentionRules{
+			{EventType: "UserLoginAttempted", KeepFor: 90 * day, Archive: true},
+			{EventType: "Treasury*", KeepFor: RetainForever},
+		},
+		Events:         events,
+		Cold:           cold,
+		EventMarshaler: marshaler,
+		PageSize:       1,
+		Locker:         cqrs.NewInMemoryAggregateLocker(),
+	})
+	require.NoError(t, err)
+	engine.now = func() time.Time { return now }
+
+	// Act
+	plan, planErr := engine.Plan(ctx)
+	afterPlan := events.types("User", "user-1")
+	report, runErr := engine.Run(ctx)
+
+	// Assert
+	require.NoError(t, planErr)
+	assert.True(t, plan.DryRun)
+	assert.Equal(t, 2, plan.Scanned, "10일 된 스트림은 읽지 않아야 합니다")
+	assert.Equal(t, 4, plan.Removed)
+	assert.Equal(t, map[string]int{"UserLoginAttempted": 1}, plan.Held)
+	assert.Len(t, afterPlan, 5, "드라이 런은 아무것도 지우지 않아야 합니다")
+
+	require.NoError(t, runErr)
+	assert.False(t, report.DryRun)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, plan.Compactions, report.Compactions)
+	require.Len(t, report.Compactions, 2)
+	assert.Equal(t, RetentionCompaction{
+		AggregateType: "User",
+		AggregateID:   "user-1",
+		BeforeVersion: 3,
+		EventTypes:    map[string]int{"UserLoginAttempted": 2},
+		ArchiveKey:    "retention/User/user-1/1-2",
+	}, report.Compactions[0])
+	assert.Equal(t, []string{"TreasuryDeposited", "UserLoginAttempted", "UserLoginAttempted"}, events.types("User", "user-1"))
+	assert.Empty(t, events.types("User", "user-2"))
+	assert.Len(t, events.types("User", "user-3"), 1)
+
+	data, err := cold.Get(ctx, "retention/User/user-2/1-2")
+	require.NoError(t, err)
+	var archive coldArchive
+	require.NoError(t, json.Unmarshal(data, &archive))
+	require.Len(t, archive.Events, 2)
+	archived, err := marshaler.Unmarshal(archive.Events[0])
+	require.NoError(t, err)
+	assert.Equal(t, "UserLoginAttempted", archived.EventType())
+}
+
+func TestRetentionEngine_StopsAtLatestSnapshot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	events := newFakeRetentionEvents()
+	events.add(t, "Guild", "guild-1", now, "GuildChatPosted", 40*time.Hour, "GuildChatPosted", 30*time.Hour, "GuildChatPosted", 20*time.Hour)
+	events.add(t, "Guild", "guild-2", now, "GuildChatPosted", 40*time.Hour, "GuildChatPosted", 30*time.Hour)
+	events.add(t, "Guild", "guild-3", now, "GuildChatPosted", 40*time.Hour)
+	snapshots := cqrs.NewInMemorySnapshotStore()
+	require.NoError(t, snapshots.Save(ctx, cqrs.NewBaseSnapshotData("guild-1", "Guild", 2, map[string]interface{}{"members": 3})))
+	require.NoError(t, snapshots.Save(ctx, cqrs.NewBaseSnapshotData("guild-2", "Guild", 2, map[string]interface{}{"members": 5})))
+	engine, err := NewRetentionEngine(RetentionEngineConfig{
+		Rules:     RetentionRules{{EventType: "GuildChatPosted", KeepFor: 10 * time.Hour}},
+		Events:    events,
+		Snapshots: snapshots,
+	})
+	require.NoError(t, err)
+	engine.now = func() time.Time { return now }
+
+	// Act
+	report, err := engine.Run(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Removed, "스냅샷에 담긴 이벤트만, 마지막 이벤트는 남기고 지워야 합니다")
+	assert.Equal(t, map[string]int{"GuildChatPosted": 3}, report.Held)
+	assert.Len(t, events.types("Guild", "guild-1"), 1)
+	assert.Len(t, events.types("Guild", "guild-2"), 1)
+	assert.Len(t, events.types("Guild", "guild-3"), 1, "스냅샷이 없으면 지우지 않아야 합니다")
+}
@@ -0,0 +1,432 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultSchemaMigrationsCollection is the Mongo collection that records applied schema migrations
+const DefaultSchemaMigrationsCollection = "schema_migrations"
+
+// ErrSchemaMigrationDrift is returned when an applied migration's steps were changed afterwards
+var ErrSchemaMigrationDrift = errors.New("applied schema migration has changed")
+
+// SchemaStep is one idempotent storage schema change (an index, collection options, a key layout).
+// Describe is printed by dry runs and checksummed, so it must identify exactly what Apply does.
+type SchemaStep interface {
+	Describe() string
+	Apply(ctx context.Context) error
+}
+
+type schemaStepFunc struct {
+	description string
+	apply       func(ctx context.Context) error
+}
+
+func (s schemaStepFunc) Describe() string                { return s.description }
+func (s schemaStepFunc) Apply(ctx context.Context) error { return s.apply(ctx) }
+
+// SchemaStepFunc adapts a function to a SchemaStep
+func SchemaStepFunc(description string, apply func(ctx context.Context) error) SchemaStep {
+	return schemaStepFunc{description: description, apply: apply}
+}
+
+// SchemaMigration is a versioned group of schema steps applied together
+type SchemaMigration struct {
+	Version int
+	Name    string
+	Steps   []SchemaStep
+}
+
+// Checksum fingerprints the step descriptions to detect migrations edited after they were applied
+func (m SchemaMigration) Checksum() string {
+	hash := sha256.New()
+	for _, step := range m.Steps {
+		hash.Write([]byte(step.Describe()))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// SchemaMigrationRecord records one applied schema migration
+type SchemaMigrationRecord struct {
+	Version    int       `json:"version" bson:"version"`
+	Name       string    `json:"name" bson:"name"`
+	Checksum   string    `json:"checksum" bson:"checksum"`
+	AppliedAt  time.Time `json:"applied_at" bson:"applied_at"`
+	DurationMs int64     `json:"duration_ms" bson:"duration_ms"`
+}
+
+// SchemaMigrationStore persists which schema migrations have been applied
+type SchemaMigrationStore interface {
+	AppliedMigrations(ctx context.Context) ([]SchemaMigrationRecord, error)
+	RecordMigration(ctx context.Context, record SchemaMigrationRecord) error
+}
+
+// PlannedSchemaMigration is a pending migration and the steps it would run
+type PlannedSchemaMigration struct {
+	Version  int      `json:"version"`
+	Name     string   `json:"name"`
+	Checksum string   `json:"checksum"`
+	Steps    []string `json:"steps"`
+}
+
+// SchemaMigrationOptions controls a migration run
+type SchemaMigrationOptions struct {
+	DryRun bool // Report pending migrations without applying them
+	Target int  // Highest version to apply, 0 means latest
+}
+
+// SchemaMigrationReport is the outcome of a migration run
+type SchemaMigrationReport struct {
+	DryRun         bool                     `json:"dry_run"`
+	CurrentVersion int                      `json:"current_version"`
+	Planned        []PlannedSchemaMigration `json:"planned"`
+	Applied        []SchemaMigrationRecord  `json:"applied"`
+}
+
+// SchemaMigrationRunner applies pending schema migrations in version order and records each one.
+// Run it once per deploy (e.g. cmd/schemamigrate) rather than from every server instance.
+type SchemaMigrationRunner struct {
+	store      SchemaMigrationStore
+	migrations []SchemaMigration
+}
+
+// NewSchemaMigrationRunner validates the migrations and creates a runner
+func NewSchemaMigrationRunner(store SchemaMigrationStore, migrations ...SchemaMigration) (*SchemaMigrationRunner, error) {
+	if store == nil {
+		return nil, cqrs.NewValidationError("schema migration store is required", nil)
+	}
+
+	sorted := append([]SchemaMigration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, migration := range sorted {
+		if migration.Version <= 0 || migration.Name == "" || len(migration.Steps) == 0 {
+			return nil, cqrs.NewValidationError(
+				fmt.Sprintf("schema migration %d (%q) needs a positive version, a name and at least one step", migration.Version, migration.Name), nil)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, cqrs.NewValidationError(fmt.Sprintf("duplicate schema migration version %d", migration.Version), nil)
+		}
+	}
+	return &SchemaMigrationRunner{store: store, migrations: sorted}, nil
+}
+
+// LatestVersion returns the highest registered migration version
+func (r *SchemaMigrationRunner) LatestVersion() int {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
+
+// Status reports the applied version and pending migrations without changing anything
+func (r *SchemaMigrationRunner) Status(ctx context.Context) (*SchemaMigrationReport, error) {
+	return r.Run(ctx, SchemaMigrationOptions{DryRun: true})
+}
+
+// Run applies every unapplied migration up to the target version.
+// It refuses to run when an applied migration's checksum no longer matches its definition.
+// On failure the report lists what was applied before the failing migration.
+func (r *SchemaMigrationRunner) Run(ctx context.Context, opts SchemaMigrationOptions) (*SchemaMigrationReport, error) {
+	records, err := r.store.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to load applied schema migrations", err)
+	}
+
+	report := &SchemaMigrationReport{DryRun: opts.DryRun}
+	applied := make(map[int]SchemaMigrationRecord, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+		if record.Version > report.CurrentVersion {
+			report.CurrentVersion = record.Version
+		}
+	}
+
+	var pending []SchemaMigration
+	for _, migration := range r.migrations {
+		if record, done := applied[migration.Version]; done {
+			if record.Checksum != "" && record.Checksum != migration.Checksum() {
+				return nil, fmt.Errorf("%w: migration %d (%s) was applied with checksum %s but is now %s",
+					ErrSchemaMigrationDrift, migration.Version, migration.Name, record.Checksum, migration.Checksum())
+			}
+			continue
+		}
+		if opts.Target > 0 && migration.Version > opts.Target {
+			continue
+		}
+		pending = append(pending, migration)
+	}
+
+	for _, migration := range pending {
+		steps := make([]string, len(migration.Steps))
+		for i, step := range migration.Steps {
+			steps[i] = step.Describe()
+		}
+		report.Planned = append(report.Planned, PlannedSchemaMigration{
+			Version:  migration.Version,
+			Name:     migration.Name,
+			Checksum: migration.Checksum(),
+			Steps:    steps,
+		})
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for _, migration := range pending {
+		record, err := r.apply(ctx, migration)
+		if err != nil {
+			return report, err
+		}
+		report.Applied = append(report.Applied, record)
+		if migration.Version > report.CurrentVersion {
+			report.CurrentVersion = migration.Version
+		}
+	}
+	return report, nil
+}
+
+func (r *SchemaMigrationRunner) apply(ctx context.Context, migration SchemaMigration) (SchemaMigrationRecord, error) {
+	started := time.Now()
+	for i, step := range migration.Steps {
+		if err := step.Apply(ctx); err != nil {
+			return SchemaMigrationRecord{}, fmt.Errorf("schema migration %d (%s) step %d %q failed: %w",
+				migration.Version, migration.Name, i+1, step.Describe(), err)
+		}
+	}
+
+	record := SchemaMigrationRecord{
+		Version:    migration.Version,
+		Name:       migration.Name,
+		Checksum:   migration.Checksum(),
+		AppliedAt:  time.Now(),
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if err := r.store.RecordMigration(ctx, record); err != nil {
+		return SchemaMigrationRecord{}, cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(),
+			fmt.Sprintf("schema migration %d applied but could not be recorded", migration.Version), err)
+	}
+	return record, nil
+}
+
+// DefaultMongoSchemaMigrations returns the standard event sourcing schema as versioned migrations.
+// Version 1 matches what InitializeEventSourcingSchema used to create.
+func DefaultMongoSchemaMigrations(client *MongoClientManager) []SchemaMigration {
+	names := client.GetCollectionNames()
+	return []SchemaMigration{
+		{
+			Version: 1,
+			Name:    "event_sourcing_baseline",
+			Steps: []SchemaStep{
+				MongoIndexStep(client, names.Events, eventsIndexModels()...),
+				MongoIndexStep(client, names.Snapshots, snapshotsIndexModels()...),
+				MongoIndexStep(client, names.ReadModels, readModelsIndexModels()...),
+			},
+		},
+	}
+}
+
+// MongoCollectionStep creates a collection with options (capped size, validator, time series...).
+// An existing collection is left as is.
+func MongoCollectionStep(client *MongoClientManager, collection string, opts *options.CreateCollectionOptions) SchemaStep {
+	description := fmt.Sprintf("mongo: create collection %s%s", collection, describeCollectionOptions(opts))
+	return SchemaStepFunc(description, func(ctx context.Context) error {
+		err := client.GetDatabase().CreateCollection(ctx, collection, opts)
+		var commandErr mongo.CommandError
+		if errors.As(err, &commandErr) && commandErr.Name == "NamespaceExists" {
+			return nil
+		}
+		return err
+	})
+}
+
+func describeCollectionOptions(opts *options.CreateCollectionOptions) string {
+	if opts == nil {
+		return ""
+	}
+	var parts []string
+	if opts.Capped != nil && *opts.Capped {
+		parts = append(parts, "capped")
+	}
+	if opts.SizeInBytes != nil {
+		parts = append(parts, fmt.Sprintf("size=%d", *opts.SizeInBytes))
+	}
+	if opts.MaxDocuments != nil {
+		parts = append(parts, fmt.Sprintf("max=%d", *opts.MaxDocuments))
+	}
+	if opts.ExpireAfterSeconds != nil {
+		parts = append(parts, fmt.Sprintf("expireAfterSeconds=%d", *opts.ExpireAfterSeconds))
+	}
+	if opts.Validator != nil {
+		if validator, err := bson.MarshalExtJSON(opts.Validator, false, false); err == nil {
+			parts = append(parts, "validator="+string(validator))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// MongoIndexStep creates indexes on a collection; every index must be named so the step is stable
+func MongoIndexStep(client *MongoClientManager, collection string, indexes ...mongo.IndexModel) SchemaStep {
+	described := make([]string, len(indexes))
+	for i, index := range indexes {
+		name := "<unnamed>"
+		unique := ""
+		if index.Options != nil {
+			if index.Options.Name != nil {
+				name = *index.Options.Name
+			}
+			if index.Options.Unique != nil && *index.Options.Unique {
+				unique = " unique"
+			}
+		}
+		keys, err := bson.MarshalExtJSON(index.Keys, false, false)
+		if err != nil {
+			keys = []byte(fmt.Sprint(index.Keys))
+		}
+		described[i] = fmt.Sprintf("%s%s %s", name, unique, keys)
+	}
+	description := fmt.Sprintf("mongo: create indexes on %s: %s", collection, strings.Join(described, ", "))
+	return SchemaStepFunc(description, func(ctx context.Context) error {
+		_, err := client.GetCollection(collection).Indexes().CreateMany(ctx, indexes)
+		return err
+	})
+}
+
+// RedisKeyLayoutStep moves keys matching pattern to the layout returned by rename
+// (return false to leave a key alone). Keys already at their new name are skipped,
+// so an interrupted step can be re-run.
+func RedisKeyLayoutStep(client *RedisClientManager, name, pattern string, rename func(key string) (string, bool)) SchemaStep {
+	description := fmt.Sprintf("redis: move keys matching %s to %s layout", pattern, name)
+	return SchemaStepFunc(description, func(ctx context.Context) error {
+		rdb := client.GetClient()
+		iter := rdb.Scan(ctx, 0, pattern, 500).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			target, move := rename(key)
+			if !move || target == key {
+				continue
+			}
+			if err := rdb.RenameNX(ctx, key, target).Err(); err != nil {
+				return fmt.Errorf("rename %s to %s: %w", key, target, err)
+			}
+		}
+		return iter.Err()
+	})
+}
+
+// InMemorySchemaMigrationStore keeps schema migration records in memory (tests, single-process tools)
+type InMemorySchemaMigrationStore struct {
+	mu      sync.RWMutex
+	records map[int]SchemaMigrationRecord
+}
+
+// NewInMemorySchemaMigrationStore creates an empty in-memory store
+func NewInMemorySchemaMigrationStore() *InMemorySchemaMigrationStore {
+	return &InMemorySchemaMigrationStore{records: make(map[int]SchemaMigrationRecord)}
+}
+
+func (s *InMemorySchemaMigrationStore) AppliedMigrations(ctx context.Context) ([]SchemaMigrationRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]SchemaMigrationRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Version < records[j].Version })
+	return records, nil
+}
+
+func (s *InMemorySchemaMigrationStore) RecordMigration(ctx context.Context, record SchemaMigrationRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Version] = record
+	return nil
+}
+
+// MongoSchemaMigrationStore records applied schema migrations in a Mongo collection
+type MongoSchemaMigrationStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSchemaMigrationStore creates a store on the collection (default DefaultSchemaMigrationsCollection)
+func NewMongoSchemaMigrationStore(client *MongoClientManager, collection string) *MongoSchemaMigrationStore {
+	if collection == "" {
+		collection = client.GetCollectionName(DefaultSchemaMigrationsCollection)
+	}
+	return &MongoSchemaMigrationStore{collection: client.GetCollection(collection)}
+}
+
+func (s *MongoSchemaMigrationStore) AppliedMigrations(ctx context.Context) ([]SchemaMigrationRecord, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var records []SchemaMigrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (s *MongoSchemaMigrationStore) RecordMigration(ctx context.Context, record SchemaMigrationRecord) error {
+	_, err := s.collection.ReplaceOne(ctx, bson.M{"version": record.Version}, record, options.Replace().SetUpsert(true))
+	return err
+}
+
+// RedisSchemaMigrationStore records applied schema migrations in a Redis hash keyed by version
+type RedisSchemaMigrationStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisSchemaMigrationStore creates a store on the hash key (default "cqrs:schema_migrations")
+func NewRedisSchemaMigrationStore(client *RedisClientManager, key string) *RedisSchemaMigrationStore {
+	if key == "" {
+		key = "cqrs:" + DefaultSchemaMigrationsCollection
+	}
+	return &RedisSchemaMigrationStore{client: client.GetClient(), key: key}
+}
+
+func (s *RedisSchemaMigrationStore) AppliedMigrations(ctx context.Context) ([]SchemaMigrationRecord, error) {
+	values, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]SchemaMigrationRecord, 0, len(values))
+	for _, value := range values {
+		var record SchemaMigrationRecord
+		if err := json.Unmarshal([]byte(value), &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Version < records[j].Version })
+	return records, nil
+}
+
+func (s *RedisSchemaMigrationStore) RecordMigration(ctx context.Context, record SchemaMigrationRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, strconv.Itoa(record.Version), data).Err()
+}
@@ -0,0 +1,131 @@
+package cqrsx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSchemaStep struct {
+	description string
+	applied     *[]string
+	err         error
+}
+
+func (s recordingSchemaStep) Describe() string { return s.description }
+
+func (s recordingSchemaStep) Apply(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	*s.applied = append(*s.applied, s.description)
+	return nil
+}
+
+func TestSchemaMigrationRunner_AppliesPendingInOrder(t *testing.T) {
+	// Arrange
+	var applied []string
+	store := NewInMemorySchemaMigrationStore()
+	runner, err := NewSchemaMigrationRunner(store,
+		SchemaMigration{Version: 2, Name: "guild_indexes", Steps: []SchemaStep{recordingSchemaStep{description: "b", applied: &applied}}},
+		SchemaMigration{Version: 1, Name: "baseline", Steps: []SchemaStep{recordingSchemaStep{description: "a", applied: &applied}}},
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	first, err := runner.Run(ctx, SchemaMigrationOptions{})
+	require.NoError(t, err)
+	second, err := runner.Run(ctx, SchemaMigrationOptions{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"a", "b"}, applied)
+	require.Len(t, first.Applied, 2)
+	assert.Equal(t, 2, first.CurrentVersion)
+	assert.Empty(t, second.Planned)
+	assert.Empty(t, second.Applied)
+	assert.Equal(t, 2, runner.LatestVersion())
+
+	records, err := store.AppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "baseline", records[0].Name)
+	assert.NotEmpty(t, records[0].Checksum)
+}
+
+func TestSchemaMigrationRunner_DryRunAndTarget(t *testing.T) {
+	// Arrange
+	var applied []string
+	runner, err := NewSchemaMigrationRunner(NewInMemorySchemaMigrationStore(),
+		SchemaMigration{Version: 1, Name: "baseline", Steps: []SchemaStep{recordingSchemaStep{description: "create events indexes", applied: &applied}}},
+		SchemaMigration{Version: 2, Name: "capped_audit", Steps: []SchemaStep{recordingSchemaStep{description: "create audit collection", applied: &applied}}},
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	plan, err := runner.Status(ctx)
+	require.NoError(t, err)
+	targeted, err := runner.Run(ctx, SchemaMigrationOptions{Target: 1})
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, plan.DryRun)
+	require.Len(t, plan.Planned, 2)
+	assert.Equal(t, []string{"create events indexes"}, plan.Planned[0].Steps)
+	assert.Empty(t, plan.Applied)
+	assert.Equal(t, []string{"create events indexes"}, applied)
+	assert.Equal(t, 1, targeted.CurrentVersion)
+}
+
+func TestSchemaMigrationRunner_StopsOnFailureAndDetectsDrift(t *testing.T) {
+	// Arrange
+	var applied []string
+	store := NewInMemorySchemaMigrationStore()
+	failing, err := NewSchemaMigrationRunner(store,
+		SchemaMigration{Version: 1, Name: "baseline", Steps: []SchemaStep{recordingSchemaStep{description: "a", applied: &applied}}},
+		SchemaMigration{Version: 2, Name: "broken", Steps: []SchemaStep{recordingSchemaStep{description: "b", err: errors.New("index build failed")}}},
+	)
+	require.NoError(t, err)
+	edited, err := NewSchemaMigrationRunner(store,
+		SchemaMigration{Version: 1, Name: "baseline", Steps: []SchemaStep{recordingSchemaStep{description: "a changed", applied: &applied}}},
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	report, runErr := failing.Run(ctx, SchemaMigrationOptions{})
+	_, driftErr := edited.Run(ctx, SchemaMigrationOptions{})
+
+	// Assert
+	require.Error(t, runErr)
+	assert.Contains(t, runErr.Error(), "index build failed")
+	require.Len(t, report.Applied, 1)
+	assert.Equal(t, 1, report.CurrentVersion)
+	assert.ErrorIs(t, driftErr, ErrSchemaMigrationDrift)
+}
+
+func TestSchemaMigrationRunner_RejectsInvalidMigrations(t *testing.T) {
+	step := SchemaStepFunc("noop", func(ctx context.Context) error { return nil })
+
+	_, err := NewSchemaMigrationRunner(NewInMemorySchemaMigrationStore(),
+		SchemaMigration{Version: 1, Name: "a", Steps: []SchemaStep{step}},
+		SchemaMigration{Version: 1, Name: "b", Steps: []SchemaStep{step}},
+	)
+	assert.Error(t, err)
+
+	_, err = NewSchemaMigrationRunner(NewInMemorySchemaMigrationStore(), SchemaMigration{Version: 1, Name: "empty"})
+	assert.Error(t, err)
+}
+
+func TestMongoIndexStep_DescribesNamedIndexes(t *testing.T) {
+	step := MongoIndexStep(nil, "events", eventsIndexModels()...)
+
+	assert.Contains(t, step.Describe(), "mongo: create indexes on events")
+	assert.Contains(t, step.Describe(), `idx_aggregate_version unique {"aggregate_id":1,"event_version":1}`)
+	assert.Equal(t, step.Describe(), MongoIndexStep(nil, "events", eventsIndexModels()...).Describe())
+}
@@ -0,0 +1,235 @@
+package cqrsx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SnapshotFormatVersion is the header layout written by this code. Snapshots saved
+// before headers existed are read as format 0.
+const SnapshotFormatVersion = 1
+
+// SnapshotHeader describes how a snapshot was written, so code that did not write it can
+// decide whether it can read it and how to convert it
+type SnapshotHeader struct {
+	FormatVersion int    `bson:"format_version" json:"format_version"`
+	Serializer    string `bson:"serializer" json:"serializer"`         // json, bson or a custom SerializerName
+	ContentType   string `bson:"content_type" json:"content_type"`     // application/json, application/bson
+	Compression   string `bson:"compression" json:"compression"`       // none, gzip
+	SchemaVersion int    `bson:"schema_version" json:"schema_version"` // Aggregate state schema version
+	AggregateType string `bson:"aggregate_type" json:"aggregate_type"`
+}
+
+// NamedSnapshotSerializer lets custom serializers name their format in snapshot headers
+type NamedSnapshotSerializer interface {
+	SerializerName() string
+}
+
+// NewSnapshotHeader describes snapshots of aggregateType written by serializer at schemaVersion
+func NewSnapshotHeader(serializer SnapshotSerializer, aggregateType string, schemaVersion int) SnapshotHeader {
+	if schemaVersion < 1 {
+		schemaVersion = 1
+	}
+	header := SnapshotHeader{
+		FormatVersion: SnapshotFormatVersion,
+		ContentType:   getContentType(serializer),
+		Compression:   getCompressionType(serializer),
+		SchemaVersion: schemaVersion,
+		AggregateType: aggregateType,
+	}
+	if named, ok := serializer.(NamedSnapshotSerializer); ok {
+		header.Serializer = named.SerializerName()
+	} else {
+		header.Serializer = serializerNameOf(header.ContentType)
+	}
+	return header
+}
+
+// legacySnapshotHeader reconstructs the header of a snapshot saved before headers existed
+func legacySnapshotHeader(doc *MongoSnapshotDocument) SnapshotHeader {
+	contentType := doc.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	compression := doc.Compression
+	if compression == "" {
+		compression = "none"
+	}
+	return SnapshotHeader{
+		ContentType:   contentType,
+		Compression:   compression,
+		Serializer:    serializerNameOf(contentType),
+		SchemaVersion: 1,
+		AggregateType: doc.AggregateType,
+	}
+}
+
+func serializerNameOf(contentType string) string {
+	switch contentType {
+	case "application/json":
+		return "json"
+	case "application/bson":
+		return "bson"
+	default:
+		return contentType
+	}
+}
+
+// sameEncoding reports whether two headers describe byte-identical encodings
+func (h SnapshotHeader) sameEncoding(other SnapshotHeader) bool {
+	return h.Serializer == other.Serializer && h.ContentType == other.ContentType && h.Compression == other.Compression
+}
+
+// convertible reports whether the generic decoder/encoder understands the encoding
+func (h SnapshotHeader) convertible() bool {
+	return (h.Serializer == "json" || h.Serializer == "bson") && (h.Compression == "none" || h.Compression == "gzip")
+}
+
+// SnapshotCompatibility is the outcome of comparing a stored snapshot with the running code
+type SnapshotCompatibility int
+
+const (
+	// SnapshotCompatible snapshots are read as they are
+	SnapshotCompatible SnapshotCompatibility = iota
+	// SnapshotNeedsReserialization snapshots were written by older code (older schema or a
+	// different serializer) and are converted on load
+	SnapshotNeedsReserialization
+	// SnapshotIncompatible snapshots cannot be read (written by newer code, a different
+	// aggregate type or an unknown format); the aggregate is rebuilt from its events
+	SnapshotIncompatible
+)
+
+func (c SnapshotCompatibility) String() string {
+	switch c {
+	case SnapshotCompatible:
+		return "compatible"
+	case SnapshotNeedsReserialization:
+		return "needs_reserialization"
+	case SnapshotIncompatible:
+		return "incompatible"
+	default:
+		return "unknown"
+	}
+}
+
+// CheckSnapshotCompatibility compares a stored snapshot header with the header the running
+// code would write and explains the decision
+func CheckSnapshotCompatibility(stored, current SnapshotHeader) (SnapshotCompatibility, string) {
+	switch {
+	case stored.FormatVersion > SnapshotFormatVersion:
+		return SnapshotIncompatible, fmt.Sprintf("snapshot header format %d is newer than supported %d", stored.FormatVersion, SnapshotFormatVersion)
+	case stored.AggregateType != current.AggregateType:
+		return SnapshotIncompatible, fmt.Sprintf("snapshot of %s cannot restore %s", stored.AggregateType, current.AggregateType)
+	case stored.SchemaVersion > current.SchemaVersion:
+		return SnapshotIncompatible, fmt.Sprintf("snapshot schema version %d is newer than supported %d", stored.SchemaVersion, current.SchemaVersion)
+	case stored.SchemaVersion == current.SchemaVersion && stored.sameEncoding(current):
+		if stored.FormatVersion < SnapshotFormatVersion {
+			return SnapshotNeedsReserialization, "snapshot has no header"
+		}
+		return SnapshotCompatible, ""
+	case !stored.convertible() || !current.convertible():
+		return SnapshotIncompatible, fmt.Sprintf("cannot convert %s/%s snapshot to %s/%s",
+			stored.Serializer, stored.Compression, current.Serializer, current.Compression)
+	case stored.SchemaVersion < current.SchemaVersion:
+		return SnapshotNeedsReserialization, fmt.Sprintf("snapshot schema version %d is older than %d", stored.SchemaVersion, current.SchemaVersion)
+	default:
+		return SnapshotNeedsReserialization, fmt.Sprintf("snapshot was written by the %s/%s serializer", stored.Serializer, stored.Compression)
+	}
+}
+
+// ReserializeSnapshot converts snapshot bytes written with the stored header into the
+// current header's encoding, running the registered migrations between the two schema
+// versions. migrations may be nil when only the encoding changed.
+func ReserializeSnapshot(data []byte, stored, current SnapshotHeader, migrations *ReadModelMigrations) ([]byte, error) {
+	if stored.SchemaVersion == current.SchemaVersion && stored.sameEncoding(current) {
+		return data, nil
+	}
+
+	doc, err := decodeSnapshotDocument(data, stored)
+	if err != nil {
+		return nil, err
+	}
+	if stored.SchemaVersion < current.SchemaVersion {
+		if migrations == nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+				fmt.Sprintf("no migrations registered to upgrade %s snapshots", stored.AggregateType), nil)
+		}
+		reached, err := migrations.MigrateDocument(stored.AggregateType, doc, stored.SchemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		if reached != current.SchemaVersion {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+				fmt.Sprintf("%s snapshot migrations reach version %d, expected %d", stored.AggregateType, reached, current.SchemaVersion), nil)
+		}
+	}
+	return encodeSnapshotDocument(doc, current)
+}
+
+func decodeSnapshotDocument(data []byte, header SnapshotHeader) (map[string]interface{}, error) {
+	if header.Compression == "gzip" {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to decompress snapshot", err)
+		}
+		defer reader.Close()
+		if data, err = io.ReadAll(reader); err != nil {
+			return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to decompress snapshot", err)
+		}
+	}
+
+	var doc map[string]interface{}
+	var err error
+	switch header.Serializer {
+	case "json":
+		err = json.Unmarshal(data, &doc)
+	case "bson":
+		var raw bson.M
+		if err = bson.Unmarshal(data, &raw); err == nil {
+			doc = map[string]interface{}(raw)
+		}
+	default:
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("unsupported snapshot serializer: %s", header.Serializer), cqrs.ErrUnsupportedFormat)
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to decode snapshot", err)
+	}
+	return doc, nil
+}
+
+func encodeSnapshotDocument(doc map[string]interface{}, header SnapshotHeader) ([]byte, error) {
+	var data []byte
+	var err error
+	switch header.Serializer {
+	case "json":
+		data, err = json.Marshal(doc)
+	case "bson":
+		data, err = bson.Marshal(doc)
+	default:
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("unsupported snapshot serializer: %s", header.Serializer), cqrs.ErrUnsupportedFormat)
+	}
+	if err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to encode snapshot", err)
+	}
+
+	if header.Compression != "gzip" {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to compress snapshot", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(), "failed to compress snapshot", err)
+	}
+	return buf.Bytes(), nil
+}
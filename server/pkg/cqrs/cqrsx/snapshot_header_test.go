@@ -0,0 +1,68 @@
+package cqrsx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSnapshotCompatibility(t *testing.T) {
+	// Arrange
+	current := NewSnapshotHeader(NewJSONSnapshotSerializer(false), "Guild", 2)
+	legacy := legacySnapshotHeader(&MongoSnapshotDocument{AggregateType: "Guild"})
+	newer := current
+	newer.SchemaVersion = 3
+	otherType := current
+	otherType.AggregateType = "User"
+	custom := current
+	custom.Serializer = "protobuf"
+	custom.SchemaVersion = 1
+
+	// Act & Assert
+	check := func(stored SnapshotHeader) SnapshotCompatibility {
+		compatibility, _ := CheckSnapshotCompatibility(stored, current)
+		return compatibility
+	}
+	assert.Equal(t, "json", current.Serializer)
+	assert.Equal(t, SnapshotCompatible, check(current))
+	assert.Equal(t, SnapshotNeedsReserialization, check(legacy)) // 헤더 없는 v1 스냅샷
+	assert.Equal(t, SnapshotIncompatible, check(newer))
+	assert.Equal(t, SnapshotIncompatible, check(otherType))
+	assert.Equal(t, SnapshotIncompatible, check(custom))
+}
+
+func TestReserializeSnapshot_UpgradesSchemaAndEncoding(t *testing.T) {
+	// Arrange
+	migrations := NewReadModelMigrations()
+	migrations.MustRegister(ReadModelMigration{ModelType: "Guild", FromVersion: 1, Migrate: RenameField("tag", "short_name")})
+	stored := legacySnapshotHeader(&MongoSnapshotDocument{AggregateType: "Guild", ContentType: "application/json"})
+	current := NewSnapshotHeader(NewCompressedBSONSnapshotSerializer("gzip"), "Guild", migrations.LatestVersion("Guild"))
+	data, err := json.Marshal(map[string]interface{}{"id": "guild-1", "tag": "DA"})
+	require.NoError(t, err)
+
+	// Act
+	converted, convertErr := ReserializeSnapshot(data, stored, current, migrations)
+	doc, decodeErr := decodeSnapshotDocument(converted, current)
+	compatibility, _ := CheckSnapshotCompatibility(current, current)
+
+	// Assert
+	require.NoError(t, convertErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, "DA", doc["short_name"])
+	assert.NotContains(t, doc, "tag")
+	assert.Equal(t, SnapshotCompatible, compatibility)
+}
+
+func TestReserializeSnapshot_RequiresMigrations(t *testing.T) {
+	// Arrange
+	stored := NewSnapshotHeader(NewJSONSnapshotSerializer(false), "Guild", 1)
+	current := NewSnapshotHeader(NewJSONSnapshotSerializer(false), "Guild", 2)
+
+	// Act
+	_, err := ReserializeSnapshot([]byte(`{"id":"guild-1"}`), stored, current, nil)
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -110,6 +110,26 @@ func (e *SnapshotError) Unwrap() error {
 	return e.Cause
 }
 
+// GetCategory maps the snapshot error code to a cqrs.ErrorCategory
+func (e *SnapshotError) GetCategory() cqrs.ErrorCategory {
+	switch e.Code {
+	case ErrCodeSnapshotNotFound:
+		return cqrs.CategoryNotFound
+	case ErrCodeConfigurationInvalid:
+		return cqrs.CategoryValidation
+	case ErrCodeSerializationFailed, ErrCodeDeserializationFailed, ErrCodeStorageFailed:
+		return cqrs.CategoryInfrastructure
+	default:
+		return cqrs.CategoryUnknown
+	}
+}
+
+// Is supports errors.Is against a cqrs.ErrorCategory
+func (e *SnapshotError) Is(target error) bool {
+	category, ok := target.(cqrs.ErrorCategory)
+	return ok && e.GetCategory() == category
+}
+
 // Error codes
 const (
 	ErrCodeSnapshotNotFound       = "SNAPSHOT_NOT_FOUND"
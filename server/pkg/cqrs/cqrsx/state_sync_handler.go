@@ -0,0 +1,191 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// StateSyncHandler serves read model deltas over HTTP:
+//
+//	GET ?model_type=GuildRoster&id=guild-7&version=42
+//
+// version is the version the client last applied (0 or absent for the full state).
+func StateSyncHandler(service *cqrs.StateSyncService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		values := r.URL.Query()
+		version := 0
+		if text := values.Get("version"); text != "" {
+			parsed, err := strconv.Atoi(text)
+			if err != nil || parsed < 0 {
+				http.Error(w, "invalid version", http.StatusBadRequest)
+				return
+			}
+			version = parsed
+		}
+
+		response, err := service.Sync(r.Context(), values.Get("model_type"), values.Get("id"), version)
+		if err != nil {
+			http.Error(w, err.Error(), queryErrorStatus(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+// StateSyncMessage is exchanged over the state sync WebSocket.
+//
+// Client messages: {"type":"subscribe","model_type":"GuildRoster","id":"guild-7","version":42}
+// and {"type":"unsubscribe","model_type":"GuildRoster","id":"guild-7"}. The server answers a
+// subscribe with a "sync" message and sends another whenever the read model changes; each
+// sync is assumed applied, so the next one is a delta from its version. Resubscribing with
+// a lower version resets the acknowledged version.
+type StateSyncMessage struct {
+	Type      string                  `json:"type"` // subscribe, unsubscribe, sync, error
+	ModelType string                  `json:"model_type,omitempty"`
+	ID        string                  `json:"id,omitempty"`
+	Version   int                     `json:"version,omitempty"`
+	Sync      *cqrs.StateSyncResponse `json:"sync,omitempty"`
+	Message   string                  `json:"message,omitempty"`
+}
+
+// StateSyncSocketConfig configures StateSyncWebSocketHandler
+type StateSyncSocketConfig struct {
+	PollInterval time.Duration // How often subscribed read models are checked for changes (default 1s)
+	// CheckOrigin validates the Origin header (default: allow every origin)
+	CheckOrigin func(r *http.Request) bool
+}
+
+// StateSyncWebSocketHandler pushes read model deltas to subscribed clients
+func StateSyncWebSocketHandler(service *cqrs.StateSyncService, config StateSyncSocketConfig) http.Handler {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	server := websocket.Server{
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			if config.CheckOrigin != nil && !config.CheckOrigin(r) {
+				return cqrs.NewValidationError("origin not allowed", nil)
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			newStateSyncConn(service, ws).serve(config.PollInterval)
+		},
+	}
+	return server
+}
+
+// stateSyncConn tracks the acknowledged versions of one WebSocket client
+type stateSyncConn struct {
+	service *cqrs.StateSyncService
+	ws      *websocket.Conn
+
+	sendMutex sync.Mutex
+	mutex     sync.Mutex
+	acked     map[[2]string]int // (model type, id) -> acknowledged version
+}
+
+func newStateSyncConn(service *cqrs.StateSyncService, ws *websocket.Conn) *stateSyncConn {
+	return &stateSyncConn{service: service, ws: ws, acked: make(map[[2]string]int)}
+}
+
+func (c *stateSyncConn) serve(pollInterval time.Duration) {
+	ctx, cancel := context.WithCancel(c.ws.Request().Context())
+	defer cancel()
+	defer c.ws.Close()
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.pushChanges(ctx)
+			}
+		}
+	}()
+
+	for {
+		var message StateSyncMessage
+		if err := websocket.JSON.Receive(c.ws, &message); err != nil {
+			return
+		}
+		key := [2]string{message.ModelType, message.ID}
+		switch message.Type {
+		case "subscribe":
+			c.mutex.Lock()
+			c.acked[key] = message.Version
+			c.mutex.Unlock()
+			c.push(ctx, key, true)
+		case "unsubscribe":
+			c.mutex.Lock()
+			delete(c.acked, key)
+			c.mutex.Unlock()
+		default:
+			c.send(StateSyncMessage{Type: "error", Message: "unknown message type: " + message.Type})
+		}
+	}
+}
+
+func (c *stateSyncConn) pushChanges(ctx context.Context) {
+	c.mutex.Lock()
+	keys := make([][2]string, 0, len(c.acked))
+	for key := range c.acked {
+		keys = append(keys, key)
+	}
+	c.mutex.Unlock()
+
+	for _, key := range keys {
+		c.push(ctx, key, false)
+	}
+}
+
+// push sends the delta since the acknowledged version; always sends something when requested
+func (c *stateSyncConn) push(ctx context.Context, key [2]string, always bool) {
+	c.mutex.Lock()
+	acked, subscribed := c.acked[key]
+	c.mutex.Unlock()
+	if !subscribed {
+		return
+	}
+
+	response, err := c.service.Sync(ctx, key[0], key[1], acked)
+	if err != nil {
+		if always {
+			c.send(StateSyncMessage{Type: "error", ModelType: key[0], ID: key[1], Message: err.Error()})
+		}
+		return
+	}
+	if response.UpToDate() && !always {
+		return
+	}
+	if c.send(StateSyncMessage{Type: "sync", ModelType: key[0], ID: key[1], Version: response.Version, Sync: response}) != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	if current, stillSubscribed := c.acked[key]; stillSubscribed && current == acked {
+		c.acked[key] = response.Version
+	}
+	c.mutex.Unlock()
+}
+
+func (c *stateSyncConn) send(message StateSyncMessage) error {
+	c.sendMutex.Lock()
+	defer c.sendMutex.Unlock()
+	return websocket.JSON.Send(c.ws, message)
+}
@@ -0,0 +1,89 @@
+package cqrsx
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// saveSyncRoster 20명 명단 뒤에 extra 멤버를 붙여 저장
+func saveSyncRoster(t *testing.T, store cqrs.ReadStore, version int, extra ...string) {
+	t.Helper()
+	members := make([]string, 0, 20+len(extra))
+	for i := 0; i < 20; i++ {
+		members = append(members, fmt.Sprintf("player-%02d", i))
+	}
+	model := cqrs.NewBaseReadModel("guild-1", "GuildRoster", map[string]interface{}{"members": append(members, extra...)})
+	model.SetVersion(version)
+	require.NoError(t, store.Save(context.Background(), model))
+}
+
+func TestStateSyncHandler(t *testing.T) {
+	// Arrange
+	history := cqrs.NewInMemoryReadModelHistory(0)
+	store := cqrs.NewStateHistoryReadStore(cqrs.NewInMemoryReadStore(), history)
+	saveSyncRoster(t, store, 1)
+	saveSyncRoster(t, store, 2, "erin")
+	handler := StateSyncHandler(cqrs.NewStateSyncService(store, history))
+
+	// Act
+	delta := httptest.NewRecorder()
+	handler.ServeHTTP(delta, httptest.NewRequest(http.MethodGet, "/sync?model_type=GuildRoster&id=guild-1&version=1", nil))
+	missing := httptest.NewRecorder()
+	handler.ServeHTTP(missing, httptest.NewRequest(http.MethodGet, "/sync?model_type=GuildRoster&id=guild-9", nil))
+	invalid := httptest.NewRecorder()
+	handler.ServeHTTP(invalid, httptest.NewRequest(http.MethodGet, "/sync?model_type=GuildRoster&id=guild-1&version=x", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, delta.Code)
+	assert.JSONEq(t, `{"model_type":"GuildRoster","model_id":"guild-1","from_version":1,"version":2,"full":false,
+		"patch":[{"op":"add","path":"/members/20","value":"erin"}]}`, delta.Body.String())
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestStateSyncWebSocketHandler_PushesDeltas(t *testing.T) {
+	// Arrange
+	history := cqrs.NewInMemoryReadModelHistory(0)
+	store := cqrs.NewStateHistoryReadStore(cqrs.NewInMemoryReadStore(), history)
+	saveSyncRoster(t, store, 1)
+	server := httptest.NewServer(StateSyncWebSocketHandler(cqrs.NewStateSyncService(store, history), StateSyncSocketConfig{PollInterval: 10 * time.Millisecond}))
+	defer server.Close()
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	receive := func() StateSyncMessage {
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+		var message StateSyncMessage
+		require.NoError(t, websocket.JSON.Receive(ws, &message))
+		return message
+	}
+
+	// Act
+	require.NoError(t, websocket.JSON.Send(ws, StateSyncMessage{Type: "subscribe", ModelType: "GuildRoster", ID: "guild-1"}))
+	initial := receive()
+	saveSyncRoster(t, store, 2, "erin") // 폴링으로 변경을 감지해 델타 전송
+	pushed := receive()
+
+	// Assert
+	assert.Equal(t, "sync", initial.Type)
+	require.NotNil(t, initial.Sync)
+	assert.True(t, initial.Sync.Full)
+	assert.Equal(t, 1, initial.Version)
+	require.NotNil(t, pushed.Sync)
+	assert.False(t, pushed.Sync.Full)
+	assert.Equal(t, 1, pushed.Sync.FromVersion)
+	assert.Equal(t, 2, pushed.Version)
+	patch, _ := json.Marshal(pushed.Sync.Patch)
+	assert.JSONEq(t, `[{"op":"add","path":"/members/20","value":"erin"}]`, string(patch))
+}
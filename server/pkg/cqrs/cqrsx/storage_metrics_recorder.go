@@ -0,0 +1,60 @@
+package cqrsx
+
+import (
+	"cqrs"
+	"sync"
+	"time"
+)
+
+// storageMetricsRecorder keeps the access and load metrics a repository observed
+// for each aggregate; stored volumes are read from the stores on demand
+type storageMetricsRecorder struct {
+	mutex   sync.Mutex
+	metrics map[string]*cqrs.StorageMetrics
+}
+
+func newStorageMetricsRecorder() *storageMetricsRecorder {
+	return &storageMetricsRecorder{metrics: make(map[string]*cqrs.StorageMetrics)}
+}
+
+func (r *storageMetricsRecorder) metricsFor(aggregateID string) *cqrs.StorageMetrics {
+	metrics, exists := r.metrics[aggregateID]
+	if !exists {
+		metrics = &cqrs.StorageMetrics{}
+		r.metrics[aggregateID] = metrics
+	}
+	return metrics
+}
+
+// snapshot returns a copy of the recorded metrics of an aggregate
+func (r *storageMetricsRecorder) snapshot(aggregateID string) cqrs.StorageMetrics {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if recorded, exists := r.metrics[aggregateID]; exists {
+		return *recorded
+	}
+	return cqrs.StorageMetrics{}
+}
+
+func (r *storageMetricsRecorder) recordAccess(aggregateID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.metricsFor(aggregateID).LastAccessed = time.Now()
+}
+
+func (r *storageMetricsRecorder) recordLoad(aggregateID string, fromSnapshot bool, replayed int, loadDuration, replayDuration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	metrics := r.metricsFor(aggregateID)
+	metrics.LastAccessed = time.Now()
+	metrics.LoadCount++
+	if fromSnapshot {
+		metrics.SnapshotHits++
+	}
+	metrics.LastLoadDuration = loadDuration
+	metrics.LastReplayDuration = replayDuration
+	metrics.LastReplayedEvents = replayed
+}
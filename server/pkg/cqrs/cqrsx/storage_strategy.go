@@ -51,15 +51,25 @@ type RedisConfig struct {
 //   - Database: Target database name
 //   - Username, Password: Authentication credentials
 //   - Connection pooling and timeout settings
+//   - Retryable reads/writes and reconnect backoff for resilience
 type MongoConfig struct {
 	URI                    string        `json:"uri"`                      // MongoDB connection URI
 	Database               string        `json:"database"`                 // MongoDB database name
 	Username               string        `json:"username"`                 // MongoDB username (optional)
 	Password               string        `json:"password"`                 // MongoDB password (optional)
 	MaxPoolSize            int           `json:"max_pool_size"`            // Maximum number of connections in the pool
+	MinPoolSize            int           `json:"min_pool_size"`            // Minimum number of idle connections kept in the pool
+	MaxConnIdleTime        time.Duration `json:"max_conn_idle_time"`       // Idle time after which a pooled connection is closed (0 = no limit)
 	ConnectTimeout         time.Duration `json:"connect_timeout"`          // Timeout for establishing new connections
 	SocketTimeout          time.Duration `json:"socket_timeout"`           // Timeout for socket operations
 	ServerSelectionTimeout time.Duration `json:"server_selection_timeout"` // Timeout for server selection
+	RetryWrites            *bool         `json:"retry_writes,omitempty"`   // Retry writes once on transient errors (nil = driver default, true)
+	RetryReads             *bool         `json:"retry_reads,omitempty"`    // Retry reads once on transient errors (nil = driver default, true)
+	HeartbeatInterval      time.Duration `json:"heartbeat_interval"`       // Interval between driver server monitoring heartbeats
+	HealthCheckInterval    time.Duration `json:"health_check_interval"`    // Interval between connection state probes (negative = monitoring disabled)
+	MaxConnectAttempts     int           `json:"max_connect_attempts"`     // Initial connection attempts before giving up
+	ReconnectBackoff       time.Duration `json:"reconnect_backoff"`        // Initial delay between reconnect attempts
+	MaxReconnectBackoff    time.Duration `json:"max_reconnect_backoff"`    // Upper bound for exponential reconnect backoff
 }
 
 // EventSourcingConfig represents event sourcing specific configuration.
@@ -103,10 +113,11 @@ type CompactionPolicy struct {
 //   - Archive storage can be used for long-term retention
 //   - Policies can be customized based on business requirements
 type RetentionPolicy struct {
-	Enabled        bool   `json:"enabled"`         // Whether retention policy is enabled
-	RetentionDays  int    `json:"retention_days"`  // Number of days to retain events
-	ArchiveEnabled bool   `json:"archive_enabled"` // Whether to archive old events instead of deleting
-	ArchiveStorage string `json:"archive_storage"` // Archive storage location/configuration
+	Enabled        bool           `json:"enabled"`               // Whether retention policy is enabled
+	RetentionDays  int            `json:"retention_days"`        // Number of days to retain events
+	ArchiveEnabled bool           `json:"archive_enabled"`       // Whether to archive old events instead of deleting
+	ArchiveStorage string         `json:"archive_storage"`       // Archive storage location/configuration
+	EventTypes     map[string]int `json:"event_types,omitempty"` // Retention days per event type overriding RetentionDays (negative = forever)
 }
 
 // PerformanceConfig represents performance tuning configuration.
@@ -232,28 +243,28 @@ func (css *ConfigurableStorageStrategy) ValidateConfiguration() error {
 func (sc *StorageConfiguration) ValidateConfiguration() error {
 	// At least one storage configuration must be provided
 	if sc.Redis == nil && sc.MongoDB == nil {
-		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "at least one storage configuration (Redis or MongoDB) is required", nil)
+		return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "at least one storage configuration (Redis or MongoDB) is required", nil).WithCategory(cqrs.CategoryValidation)
 	}
 
 	// Validate Redis configuration if provided
 	if sc.Redis != nil {
 		if sc.Redis.Host == "" {
-			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis host is required", nil)
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis host is required", nil).WithCategory(cqrs.CategoryValidation)
 		}
 
 		if sc.Redis.Port <= 0 {
-			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis port must be positive", nil)
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "Redis port must be positive", nil).WithCategory(cqrs.CategoryValidation)
 		}
 	}
 
 	// Validate MongoDB configuration if provided
 	if sc.MongoDB != nil {
 		if sc.MongoDB.URI == "" {
-			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB URI is required", nil)
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB URI is required", nil).WithCategory(cqrs.CategoryValidation)
 		}
 
 		if sc.MongoDB.Database == "" {
-			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB database name is required", nil)
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "MongoDB database name is required", nil).WithCategory(cqrs.CategoryValidation)
 		}
 	}
 
@@ -0,0 +1,392 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DailyResetEventType is published when a region reaches its daily reset time
+const DailyResetEventType = "DailyReset"
+
+// Catch-up policies: what a scheduler does with resets missed while it was down
+const (
+	CatchUpLatest = "latest" // Publish only the most recent missed reset, with Missed counting the skipped ones
+	CatchUpAll    = "all"    // Publish every missed reset in order, up to MaxCatchUp
+)
+
+// DailyResetSchedule fires once per local day at Hour:Minute in TimeZone, e.g. the daily
+// quest reset at 05:00 in each region. JSON-configurable:
+// {"name":"daily_quest","region":"kr","timezone":"Asia/Seoul","hour":5}
+type DailyResetSchedule struct {
+	Name       string `json:"name"`
+	Region     string `json:"region"`
+	TimeZone   string `json:"timezone"` // IANA name; DST transitions follow the zone rules
+	Hour       int    `json:"hour"`
+	Minute     int    `json:"minute,omitempty"`
+	CatchUp    string `json:"catch_up,omitempty"`     // CatchUpLatest (default) or CatchUpAll
+	MaxCatchUp int    `json:"max_catch_up,omitempty"` // Cap for CatchUpAll; default 7
+}
+
+// Key identifies the schedule's reset stream; one name can have a schedule per region
+func (s DailyResetSchedule) Key() string {
+	if s.Region == "" {
+		return s.Name
+	}
+	return s.Name + "/" + s.Region
+}
+
+// Validate checks the schedule and that its time zone is known
+func (s DailyResetSchedule) Validate() error {
+	if s.Name == "" {
+		return NewValidationError("daily reset name is required", nil)
+	}
+	if s.Hour < 0 || s.Hour > 23 || s.Minute < 0 || s.Minute > 59 {
+		return NewValidationError(fmt.Sprintf("daily reset %s has an invalid time %02d:%02d", s.Key(), s.Hour, s.Minute), nil)
+	}
+	if s.CatchUp != "" && s.CatchUp != CatchUpLatest && s.CatchUp != CatchUpAll {
+		return NewValidationError(fmt.Sprintf("unknown catch-up policy %q", s.CatchUp), nil)
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil || s.TimeZone == "" {
+		return NewValidationError(fmt.Sprintf("daily reset %s has an unknown time zone %q", s.Key(), s.TimeZone), err)
+	}
+	return nil
+}
+
+// DailyReset describes one fired reset
+type DailyReset struct {
+	Name      string    `json:"name"`
+	Region    string    `json:"region,omitempty"`
+	TimeZone  string    `json:"timezone"`
+	LocalDate string    `json:"local_date"` // Local calendar day that starts with this reset (YYYY-MM-DD); use it to deduplicate
+	ResetAt   time.Time `json:"reset_at"`   // Scheduled instant, not the time it was published
+	Missed    int       `json:"missed,omitempty"`
+	CatchUp   bool      `json:"catch_up,omitempty"` // Published after downtime, when more than one reset was due
+}
+
+// DailyResetEvent announces a daily reset for one region
+type DailyResetEvent struct {
+	*BaseEventMessage
+	Reset DailyReset `json:"reset"`
+}
+
+// NewDailyResetEvent creates a DailyReset event
+func NewDailyResetEvent(key string, reset DailyReset) *DailyResetEvent {
+	event := &DailyResetEvent{BaseEventMessage: NewBaseEventMessage(DailyResetEventType), Reset: reset}
+	event.setAggregateInfo(key, "DailyReset", 1)
+	return event
+}
+
+func (e *DailyResetEvent) EventData() interface{} {
+	return e.Reset
+}
+
+// NextDailyReset returns the first Hour:Minute local time in loc strictly after after.
+// A reset time skipped by a DST jump fires at the first instant after the gap; a reset
+// time repeated when clocks fall back fires on its first occurrence only.
+func NextDailyReset(after time.Time, loc *time.Location, hour, minute int) time.Time {
+	local := after.In(loc)
+	year, month, day := local.Date()
+	for i := 0; i < 3; i++ {
+		candidate := dailyResetInstant(year, month, day+i, loc, hour, minute)
+		if candidate.After(after) {
+			return candidate
+		}
+	}
+	return dailyResetInstant(year, month, day+3, loc, hour, minute)
+}
+
+// dailyResetInstant resolves a local wall-clock time to an instant
+func dailyResetInstant(year int, month time.Month, day int, loc *time.Location, hour, minute int) time.Time {
+	instant := time.Date(year, month, day, hour, minute, 0, 0, loc)
+	wall := hour*60 + minute
+	clock := func(t time.Time) (int, int) { return t.YearDay(), t.Hour()*60 + t.Minute() }
+	targetDay := time.Date(year, month, day, 12, 0, 0, 0, loc).YearDay()
+
+	if resolvedDay, resolvedWall := clock(instant); resolvedDay == targetDay && resolvedWall == wall {
+		// Repeated hour: prefer the earlier of the two instants with the same wall clock
+		for _, shift := range []time.Duration{-time.Hour, -30 * time.Minute} {
+			if earlierDay, earlierWall := clock(instant.Add(shift)); earlierDay == targetDay && earlierWall == wall {
+				return instant.Add(shift)
+			}
+		}
+		return instant
+	}
+
+	// Skipped time: time.Date may resolve before the gap, so move past it first,
+	// then walk back to the first instant whose wall clock is at or after the target
+	for step := 0; step < 180; step++ {
+		if resolvedDay, resolvedWall := clock(instant); resolvedDay != targetDay || resolvedWall >= wall {
+			break
+		}
+		instant = instant.Add(time.Minute)
+	}
+	for step := 0; step < 180; step++ {
+		previous := instant.Add(-time.Minute)
+		if previousDay, previousWall := clock(previous); previousDay != targetDay || previousWall < wall {
+			break
+		}
+		instant = previous
+	}
+	return instant
+}
+
+// DailyResetStore remembers the last reset published per schedule, so a restarted
+// scheduler knows which resets it missed. Implementations must be durable for catch-up
+// to survive restarts; cqrsx.RedisDailyResetStore is one.
+type DailyResetStore interface {
+	LastReset(ctx context.Context, key string) (time.Time, bool, error)
+	RecordReset(ctx context.Context, key string, resetAt time.Time) error
+}
+
+// InMemoryDailyResetStore is a process-local DailyResetStore, for tests and single-process tools
+type InMemoryDailyResetStore struct {
+	mutex sync.Mutex
+	last  map[string]time.Time
+}
+
+// NewInMemoryDailyResetStore creates an empty store
+func NewInMemoryDailyResetStore() *InMemoryDailyResetStore {
+	return &InMemoryDailyResetStore{last: make(map[string]time.Time)}
+}
+
+func (s *InMemoryDailyResetStore) LastReset(ctx context.Context, key string) (time.Time, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	last, exists := s.last[key]
+	return last, exists, nil
+}
+
+func (s *InMemoryDailyResetStore) RecordReset(ctx context.Context, key string, resetAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.last[key] = resetAt
+	return nil
+}
+
+// DailyResetCommandType is the deferred command that publishes a schedule's due resets.
+// Its aggregate ID is the schedule key.
+const DailyResetCommandType = "PublishDailyReset"
+
+type dailyResetEntry struct {
+	schedule DailyResetSchedule
+	location *time.Location
+}
+
+// DailyResetScheduler publishes DailyReset events when each region reaches its local reset
+// time. It keeps no timer of its own: every schedule's next reset is a deferred command on
+// a DeferredScheduler, so a durable DeferredCommandStore carries pending resets across
+// restarts and the deferred scheduler's loop retries failed publishes.
+//
+// On its first check a schedule only records the most recent reset (nothing is replayed
+// for a brand new schedule); afterwards resets missed during downtime are published
+// according to the schedule's catch-up policy.
+type DailyResetScheduler struct {
+	mutex    sync.Mutex
+	entries  map[string]dailyResetEntry
+	keys     []string
+	store    DailyResetStore
+	eventBus EventBus
+	deferred *DeferredScheduler
+	now      func() time.Time
+}
+
+// NewDailyResetScheduler creates a scheduler that runs on deferred; store defaults to
+// NewInMemoryDailyResetStore. Register it with RegisterWith on the dispatcher deferred
+// dispatches to, then call Schedule once at startup.
+func NewDailyResetScheduler(schedules []DailyResetSchedule, eventBus EventBus, store DailyResetStore, deferred *DeferredScheduler) (*DailyResetScheduler, error) {
+	if eventBus == nil {
+		return nil, NewValidationError("event bus is required", nil)
+	}
+	if deferred == nil {
+		return nil, NewValidationError("deferred scheduler is required", nil)
+	}
+	if store == nil {
+		store = NewInMemoryDailyResetStore()
+	}
+
+	entries := make(map[string]dailyResetEntry, len(schedules))
+	keys := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		if err := schedule.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := entries[schedule.Key()]; exists {
+			return nil, NewValidationError(fmt.Sprintf("duplicate daily reset %s", schedule.Key()), nil)
+		}
+		if schedule.CatchUp == "" {
+			schedule.CatchUp = CatchUpLatest
+		}
+		if schedule.MaxCatchUp <= 0 {
+			schedule.MaxCatchUp = 7
+		}
+		location, _ := time.LoadLocation(schedule.TimeZone)
+		entries[schedule.Key()] = dailyResetEntry{schedule: schedule, location: location}
+		keys = append(keys, schedule.Key())
+	}
+
+	return &DailyResetScheduler{entries: entries, keys: keys, store: store, eventBus: eventBus, deferred: deferred, now: time.Now}, nil
+}
+
+// RegisterWith registers the handler for DailyResetCommandType with dispatcher
+func (s *DailyResetScheduler) RegisterWith(dispatcher CommandDispatcher) error {
+	return RegisterCommandHandler(dispatcher, DailyResetCommandType, s.handleReset)
+}
+
+// NextResets returns the upcoming reset instant per schedule key
+func (s *DailyResetScheduler) NextResets() map[string]time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.now()
+	next := make(map[string]time.Time, len(s.entries))
+	for key, entry := range s.entries {
+		next[key] = s.nextReset(entry, now)
+	}
+	return next
+}
+
+// Schedule publishes the resets missed while the server was down and schedules each
+// schedule's next reset on the deferred scheduler. A schedule whose catch-up fails is
+// scheduled immediately so the deferred scheduler retries it.
+func (s *DailyResetScheduler) Schedule(ctx context.Context) ([]DailyReset, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	var fired []DailyReset
+	var firstErr error
+	for _, key := range s.keys {
+		entry := s.entries[key]
+		resets, err := s.checkEntry(ctx, entry, now)
+		fired = append(fired, resets...)
+		dueAt := s.nextReset(entry, now)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			dueAt = now
+		}
+		if err := s.deferred.Schedule(ctx, dailyResetKey(key), dueAt, newDailyResetCommand(key)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return fired, firstErr
+}
+
+// CheckSchedule publishes the resets that are due and returns them, without touching the
+// deferred scheduler. A failed publish stops that schedule without recording it, so the
+// reset is retried on the next check.
+func (s *DailyResetScheduler) CheckSchedule(ctx context.Context) ([]DailyReset, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	var fired []DailyReset
+	var firstErr error
+	for _, key := range s.keys {
+		resets, err := s.checkEntry(ctx, s.entries[key], now)
+		fired = append(fired, resets...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return fired, firstErr
+}
+
+// handleReset publishes a schedule's due resets and schedules its next one. An
+// infrastructure error leaves the deferred command in place for the next run.
+func (s *DailyResetScheduler) handleReset(ctx context.Context, command Command) (*CommandResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := command.ID()
+	entry, exists := s.entries[key]
+	if !exists {
+		// The schedule was removed from configuration; drop its pending reset
+		return &CommandResult{Success: false, Error: NewValidationError(fmt.Sprintf("unknown daily reset %s", key), nil)}, nil
+	}
+	now := s.now()
+	if _, err := s.checkEntry(ctx, entry, now); err != nil {
+		return &CommandResult{Success: false, Error: err}, nil
+	}
+	if err := s.deferred.Schedule(ctx, dailyResetKey(key), s.nextReset(entry, now), newDailyResetCommand(key)); err != nil {
+		return &CommandResult{Success: false, Error: err}, nil
+	}
+	return &CommandResult{Success: true, AggregateID: key}, nil
+}
+
+func (s *DailyResetScheduler) nextReset(entry dailyResetEntry, now time.Time) time.Time {
+	return NextDailyReset(now, entry.location, entry.schedule.Hour, entry.schedule.Minute)
+}
+
+func (s *DailyResetScheduler) checkEntry(ctx context.Context, entry dailyResetEntry, now time.Time) ([]DailyReset, error) {
+	schedule := entry.schedule
+	key := schedule.Key()
+	last, exists, err := s.store.LastReset(ctx, key)
+	if err != nil {
+		return nil, NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to read last reset of %s", key), err)
+	}
+	if !exists {
+		// New schedule: start counting from the most recent boundary without firing it
+		next := s.nextReset(entry, now).In(entry.location)
+		latest := dailyResetInstant(next.Year(), next.Month(), next.Day()-1, entry.location, schedule.Hour, schedule.Minute)
+		if err := s.store.RecordReset(ctx, key, latest); err != nil {
+			return nil, NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to record reset of %s", key), err)
+		}
+		return nil, nil
+	}
+
+	var due []time.Time
+	for next := s.nextReset(entry, last); !next.After(now); next = s.nextReset(entry, next) {
+		due = append(due, next)
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	catchUp := len(due) > 1
+	missed := 0
+	switch schedule.CatchUp {
+	case CatchUpAll:
+		if len(due) > schedule.MaxCatchUp {
+			missed = len(due) - schedule.MaxCatchUp
+			due = due[missed:]
+		}
+	default:
+		missed = len(due) - 1
+		due = due[len(due)-1:]
+	}
+
+	var fired []DailyReset
+	for i, resetAt := range due {
+		reset := DailyReset{
+			Name:      schedule.Name,
+			Region:    schedule.Region,
+			TimeZone:  schedule.TimeZone,
+			LocalDate: resetAt.In(entry.location).Format("2006-01-02"),
+			ResetAt:   resetAt,
+			CatchUp:   catchUp,
+		}
+		if i == 0 {
+			reset.Missed = missed
+		}
+		if err := s.eventBus.Publish(ctx, NewDailyResetEvent(key, reset)); err != nil {
+			return fired, NewInfrastructureError(ErrCodeEventBusError, fmt.Sprintf("failed to publish daily reset %s for %s", key, reset.LocalDate), err)
+		}
+		if err := s.store.RecordReset(ctx, key, resetAt); err != nil {
+			return fired, NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to record reset of %s", key), err)
+		}
+		fired = append(fired, reset)
+	}
+	return fired, nil
+}
+
+// dailyResetKey is the deferred command key of a schedule
+func dailyResetKey(scheduleKey string) string {
+	return "daily_reset:" + scheduleKey
+}
+
+func newDailyResetCommand(scheduleKey string) Command {
+	return NewBaseCommand(DailyResetCommandType, scheduleKey, "DailyReset", nil)
+}
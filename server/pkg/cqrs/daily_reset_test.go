@@ -0,0 +1,203 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyEventBus failures 횟수만큼 발행에 실패하는 이벤트 버스
+type flakyEventBus struct {
+	EventBus
+	failures int
+}
+
+func (b *flakyEventBus) Publish(ctx context.Context, event EventMessage, options ...EventPublishOptions) error {
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("broker unavailable")
+	}
+	return b.EventBus.Publish(ctx, event, options...)
+}
+
+// newDailyResetScheduler 디스패처와 인메모리 지연 명령 저장소 위에서 동작하는 스케줄러를 만듭니다
+func newDailyResetScheduler(t *testing.T, schedules []DailyResetSchedule, bus EventBus, store DailyResetStore) (*DailyResetScheduler, *DeferredScheduler, *InMemoryDeferredCommandStore) {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	pending := NewInMemoryDeferredCommandStore()
+	deferred, err := NewDeferredScheduler(dispatcher, pending)
+	require.NoError(t, err)
+	scheduler, err := NewDailyResetScheduler(schedules, bus, store, deferred)
+	require.NoError(t, err)
+	require.NoError(t, scheduler.RegisterWith(dispatcher))
+	return scheduler, deferred, pending
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return location
+}
+
+func TestNextDailyReset_TimeZonesAndDST(t *testing.T) {
+	// Arrange
+	seoul := mustLoadLocation(t, "Asia/Seoul")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	// Act
+	seoulReset := NextDailyReset(time.Date(2026, 3, 1, 19, 0, 0, 0, time.UTC), seoul, 5, 0)
+	// 2026-03-08 02:00 EST -> 03:00 EDT: 02:30은 존재하지 않음
+	springForward := NextDailyReset(time.Date(2026, 3, 8, 0, 0, 0, 0, newYork), newYork, 2, 30)
+	// 2026-11-01 02:00 EDT -> 01:00 EST: 01:30이 두 번 있음
+	fallBack := NextDailyReset(time.Date(2026, 11, 1, 0, 0, 0, 0, newYork), newYork, 1, 30)
+	afterFallBack := NextDailyReset(fallBack, newYork, 1, 30)
+	// 5시 초기화는 DST 전후로 같은 현지 시각, 다른 UTC 시각
+	beforeDST := NextDailyReset(time.Date(2026, 3, 6, 12, 0, 0, 0, newYork), newYork, 5, 0)
+	afterDST := NextDailyReset(beforeDST, newYork, 5, 0)
+
+	// Assert
+	assert.Equal(t, time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC), seoulReset.UTC())
+	assert.Equal(t, time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC), springForward.UTC()) // 03:00 EDT
+	assert.Equal(t, time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), fallBack.UTC())    // 첫 번째 01:30 (EDT)
+	assert.Equal(t, time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC), afterFallBack.UTC())
+	assert.Equal(t, time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC), beforeDST.UTC())
+	assert.Equal(t, time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), afterDST.UTC())
+	assert.Equal(t, 23*time.Hour, afterDST.Sub(beforeDST))
+}
+
+func TestDailyResetScheduler_FiresPerRegion(t *testing.T) {
+	// Arrange
+	bus := NewInMemoryEventBus()
+	scheduler, _, _ := newDailyResetScheduler(t, []DailyResetSchedule{
+		{Name: "daily_quest", Region: "kr", TimeZone: "Asia/Seoul", Hour: 5},
+		{Name: "daily_quest", Region: "na", TimeZone: "America/New_York", Hour: 5},
+	}, bus, nil)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Act
+	first, firstErr := scheduler.CheckSchedule(ctx)     // 새 스케줄은 기준점만 기록
+	now = time.Date(2026, 6, 1, 20, 30, 0, 0, time.UTC) // 서울 6/2 05:30
+	seoul, seoulErr := scheduler.CheckSchedule(ctx)
+	again, _ := scheduler.CheckSchedule(ctx)
+	now = time.Date(2026, 6, 2, 9, 5, 0, 0, time.UTC) // 뉴욕 6/2 05:05 (EDT)
+	newYork, newYorkErr := scheduler.CheckSchedule(ctx)
+
+	// Assert
+	require.NoError(t, firstErr)
+	assert.Empty(t, first)
+	require.NoError(t, seoulErr)
+	require.Len(t, seoul, 1)
+	assert.Equal(t, "kr", seoul[0].Region)
+	assert.Equal(t, "2026-06-02", seoul[0].LocalDate)
+	assert.Equal(t, time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), seoul[0].ResetAt.UTC())
+	assert.False(t, seoul[0].CatchUp)
+	assert.Empty(t, again)
+	require.NoError(t, newYorkErr)
+	require.Len(t, newYork, 1)
+	assert.Equal(t, "na", newYork[0].Region)
+	assert.Equal(t, "2026-06-02", newYork[0].LocalDate)
+	assert.Equal(t, time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC), newYork[0].ResetAt.UTC())
+}
+
+func TestDailyResetScheduler_CatchUpAfterDowntime(t *testing.T) {
+	// Arrange
+	store := NewInMemoryDailyResetStore()
+	ctx := context.Background()
+	lastReset := time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC) // 서울 6/2 05:00
+	require.NoError(t, store.RecordReset(ctx, "daily_quest/kr", lastReset))
+	require.NoError(t, store.RecordReset(ctx, "weekly_shop/kr", lastReset))
+	scheduler, _, _ := newDailyResetScheduler(t, []DailyResetSchedule{
+		{Name: "daily_quest", Region: "kr", TimeZone: "Asia/Seoul", Hour: 5},
+		{Name: "weekly_shop", Region: "kr", TimeZone: "Asia/Seoul", Hour: 5, CatchUp: CatchUpAll, MaxCatchUp: 2},
+	}, NewInMemoryEventBus(), store)
+	// 3일 동안 중단 후 재시작 (6/3, 6/4, 6/5 초기화를 놓침)
+	scheduler.now = func() time.Time { return time.Date(2026, 6, 4, 21, 0, 0, 0, time.UTC) }
+
+	// Act
+	fired, checkErr := scheduler.CheckSchedule(ctx)
+	recorded, _, _ := store.LastReset(ctx, "daily_quest/kr")
+
+	// Assert
+	require.NoError(t, checkErr)
+	require.Len(t, fired, 3)
+	assert.Equal(t, "daily_quest", fired[0].Name) // latest: 마지막 한 번만, 건너뛴 횟수 기록
+	assert.Equal(t, "2026-06-05", fired[0].LocalDate)
+	assert.Equal(t, 2, fired[0].Missed)
+	assert.True(t, fired[0].CatchUp)
+	assert.Equal(t, "weekly_shop", fired[1].Name) // all: 최대 2번까지 순서대로
+	assert.Equal(t, "2026-06-04", fired[1].LocalDate)
+	assert.Equal(t, 1, fired[1].Missed)
+	assert.Equal(t, "2026-06-05", fired[2].LocalDate)
+	assert.Equal(t, time.Date(2026, 6, 4, 20, 0, 0, 0, time.UTC), recorded.UTC())
+}
+
+func TestDailyResetScheduler_RetriesFailedPublish(t *testing.T) {
+	// Arrange
+	store := NewInMemoryDailyResetStore()
+	ctx := context.Background()
+	require.NoError(t, store.RecordReset(ctx, "daily_quest/kr", time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)))
+	bus := &flakyEventBus{EventBus: NewInMemoryEventBus(), failures: 1}
+	scheduler, _, _ := newDailyResetScheduler(t, []DailyResetSchedule{
+		{Name: "daily_quest", Region: "kr", TimeZone: "Asia/Seoul", Hour: 5},
+	}, bus, store)
+	scheduler.now = func() time.Time { return time.Date(2026, 6, 2, 21, 0, 0, 0, time.UTC) }
+
+	// Act
+	_, failedErr := scheduler.CheckSchedule(ctx)
+	retried, retryErr := scheduler.CheckSchedule(ctx)
+
+	// Assert
+	assert.True(t, IsInfrastructureError(failedErr))
+	require.NoError(t, retryErr)
+	require.Len(t, retried, 1)
+	assert.Equal(t, "2026-06-03", retried[0].LocalDate)
+}
+
+func TestDailyResetScheduler_RunsOnDeferredScheduler(t *testing.T) {
+	// Arrange
+	store := NewInMemoryDailyResetStore()
+	bus := &flakyEventBus{EventBus: NewInMemoryEventBus()}
+	scheduler, deferred, pending := newDailyResetScheduler(t, []DailyResetSchedule{
+		{Name: "daily_quest", Region: "kr", TimeZone: "Asia/Seoul", Hour: 5},
+	}, bus, store)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+	ctx := context.Background()
+	_, err := scheduler.Schedule(ctx)
+	require.NoError(t, err)
+	scheduled, _ := pending.Due(ctx, now.Add(48*time.Hour), 0)
+
+	// Act
+	now = time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC) // 서울 6/2 05:00
+	bus.failures = 1
+	_, failedErr := deferred.RunDueAt(ctx, now) // 발행 실패: 지연 명령이 남아 다음 실행에서 재시도
+	retried, retryErr := deferred.RunDueAt(ctx, now)
+	recorded, _, _ := store.LastReset(ctx, "daily_quest/kr")
+	rescheduled, _ := pending.Due(ctx, now.Add(48*time.Hour), 0)
+
+	// Assert
+	require.Len(t, scheduled, 1)
+	assert.Equal(t, "daily_reset:daily_quest/kr", scheduled[0].Key)
+	assert.Equal(t, time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), scheduled[0].DueAt.UTC())
+	assert.Error(t, failedErr)
+	require.NoError(t, retryErr)
+	assert.Len(t, retried, 1)
+	assert.Equal(t, time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), recorded.UTC())
+	require.Len(t, rescheduled, 1) // 다음 초기화 (서울 6/3 05:00)로 다시 예약
+	assert.Equal(t, time.Date(2026, 6, 2, 20, 0, 0, 0, time.UTC), rescheduled[0].DueAt.UTC())
+}
+
+func TestDailyResetSchedule_Validate(t *testing.T) {
+	assert.NoError(t, DailyResetSchedule{Name: "daily_quest", TimeZone: "Europe/Berlin", Hour: 5}.Validate())
+	assert.Error(t, DailyResetSchedule{Name: "daily_quest", TimeZone: "Mars/Olympus", Hour: 5}.Validate())
+	assert.Error(t, DailyResetSchedule{Name: "daily_quest", TimeZone: "UTC", Hour: 24}.Validate())
+	assert.Error(t, DailyResetSchedule{Name: "daily_quest", TimeZone: "UTC", CatchUp: "never"}.Validate())
+	assert.Error(t, DailyResetSchedule{TimeZone: "UTC"}.Validate())
+}
@@ -0,0 +1,282 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DeferredCommand is a command to dispatch at DueAt, e.g. closing an auction when it ends.
+// Key identifies the entry so it can be rescheduled or cancelled. It encodes to JSON with
+// the command's type, target and payload, so durable stores can keep it across restarts.
+type DeferredCommand struct {
+	Key     string
+	DueAt   time.Time
+	Command Command
+}
+
+// deferredCommandRecord is the JSON form of a DeferredCommand
+type deferredCommandRecord struct {
+	Key           string          `json:"key"`
+	DueAt         time.Time       `json:"due_at"`
+	CommandID     string          `json:"command_id"`
+	CommandType   string          `json:"command_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	AggregateType string          `json:"aggregate_type"`
+	UserID        string          `json:"user_id,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+// MarshalJSON encodes the command by type and payload
+func (d DeferredCommand) MarshalJSON() ([]byte, error) {
+	if d.Command == nil {
+		return nil, fmt.Errorf("deferred command %s has no command", d.Key)
+	}
+	record := deferredCommandRecord{
+		Key:           d.Key,
+		DueAt:         d.DueAt,
+		CommandID:     d.Command.CommandID(),
+		CommandType:   d.Command.CommandType(),
+		AggregateID:   d.Command.ID(),
+		AggregateType: d.Command.Type(),
+		UserID:        d.Command.UserID(),
+		CorrelationID: d.Command.CorrelationID(),
+		Timestamp:     d.Command.Timestamp(),
+	}
+	if data := d.Command.GetData(); data != nil {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode data of deferred command %s: %w", d.Key, err)
+		}
+		record.Data = encoded
+	}
+	return json.Marshal(record)
+}
+
+// UnmarshalJSON rebuilds the command as a BaseCommand. The payload comes back as decoded
+// JSON (maps, slices, float64), so handlers must accept data read from a serialized store.
+func (d *DeferredCommand) UnmarshalJSON(encoded []byte) error {
+	var record deferredCommandRecord
+	if err := json.Unmarshal(encoded, &record); err != nil {
+		return err
+	}
+	var data interface{}
+	if len(record.Data) > 0 {
+		if err := json.Unmarshal(record.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode data of deferred command %s: %w", record.Key, err)
+		}
+	}
+	command := NewBaseCommand(record.CommandType, record.AggregateID, record.AggregateType, data)
+	command.SetCommandID(record.CommandID)
+	command.SetUserID(record.UserID)
+	command.SetCorrelationID(record.CorrelationID)
+	command.SetTimestamp(record.Timestamp)
+	*d = DeferredCommand{Key: record.Key, DueAt: record.DueAt, Command: command}
+	return nil
+}
+
+// DeferredCommandStore keeps pending deferred commands. Implementations must be durable
+// for scheduled commands to survive restarts; cqrsx.RedisDeferredCommandStore is one.
+type DeferredCommandStore interface {
+	Put(ctx context.Context, deferred DeferredCommand) error // Replaces an entry with the same key
+	Remove(ctx context.Context, key string) error
+	Due(ctx context.Context, now time.Time, limit int) ([]DeferredCommand, error) // Oldest DueAt first
+}
+
+// InMemoryDeferredCommandStore is a process-local DeferredCommandStore for tests and
+// single-process development; scheduled commands are lost on restart
+type InMemoryDeferredCommandStore struct {
+	mutex   sync.Mutex
+	pending map[string]DeferredCommand
+}
+
+// NewInMemoryDeferredCommandStore creates an empty store
+func NewInMemoryDeferredCommandStore() *InMemoryDeferredCommandStore {
+	return &InMemoryDeferredCommandStore{pending: make(map[string]DeferredCommand)}
+}
+
+func (s *InMemoryDeferredCommandStore) Put(ctx context.Context, deferred DeferredCommand) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pending[deferred.Key] = deferred
+	return nil
+}
+
+func (s *InMemoryDeferredCommandStore) Remove(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.pending, key)
+	return nil
+}
+
+func (s *InMemoryDeferredCommandStore) Due(ctx context.Context, now time.Time, limit int) ([]DeferredCommand, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var due []DeferredCommand
+	for _, deferred := range s.pending {
+		if !deferred.DueAt.After(now) {
+			due = append(due, deferred)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].DueAt.Equal(due[j].DueAt) {
+			return due[i].Key < due[j].Key
+		}
+		return due[i].DueAt.Before(due[j].DueAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// DeferredScheduler dispatches commands once they are due. A command whose dispatch fails
+// with an infrastructure error stays scheduled and is retried on the next run; a command
+// the handler rejects is dropped, since retrying it would be rejected again.
+type DeferredScheduler struct {
+	mutex      sync.Mutex
+	dispatcher CommandDispatcher
+	store      DeferredCommandStore
+	batchSize  int
+	now        func() time.Time
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewDeferredScheduler creates a scheduler; store defaults to NewInMemoryDeferredCommandStore
+func NewDeferredScheduler(dispatcher CommandDispatcher, store DeferredCommandStore) (*DeferredScheduler, error) {
+	if dispatcher == nil {
+		return nil, NewValidationError("command dispatcher is required", nil)
+	}
+	if store == nil {
+		store = NewInMemoryDeferredCommandStore()
+	}
+	return &DeferredScheduler{dispatcher: dispatcher, store: store, batchSize: 100, now: time.Now}, nil
+}
+
+// Schedule dispatches command at dueAt, replacing any command already scheduled under key
+func (s *DeferredScheduler) Schedule(ctx context.Context, key string, dueAt time.Time, command Command) error {
+	if key == "" {
+		return NewValidationError("deferred command key is required", nil)
+	}
+	if command == nil {
+		return NewValidationError(fmt.Sprintf("deferred command %s has no command", key), nil)
+	}
+	if err := s.store.Put(ctx, DeferredCommand{Key: key, DueAt: dueAt, Command: command}); err != nil {
+		return NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to schedule deferred command %s", key), err)
+	}
+	return nil
+}
+
+// Cancel removes the command scheduled under key; cancelling an unknown key is not an error
+func (s *DeferredScheduler) Cancel(ctx context.Context, key string) error {
+	if err := s.store.Remove(ctx, key); err != nil {
+		return NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to cancel deferred command %s", key), err)
+	}
+	return nil
+}
+
+// RunDue dispatches the commands that are due and returns the ones that were handled,
+// successfully or not. Commands left for retry are not returned.
+func (s *DeferredScheduler) RunDue(ctx context.Context) ([]DeferredCommand, error) {
+	return s.RunDueAt(ctx, s.now())
+}
+
+// RunDueAt is RunDue with an explicit current time, for callers that run their own clock
+func (s *DeferredScheduler) RunDueAt(ctx context.Context, now time.Time) ([]DeferredCommand, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	due, err := s.store.Due(ctx, now, s.batchSize)
+	if err != nil {
+		return nil, NewInfrastructureError(ErrCodeRepositoryError, "failed to read due deferred commands", err)
+	}
+
+	var handled []DeferredCommand
+	var firstErr error
+	for _, deferred := range due {
+		result, err := s.dispatcher.Dispatch(ctx, deferred.Command)
+		if err == nil && result != nil && !result.Success && IsInfrastructureError(result.Error) {
+			err = result.Error
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to dispatch deferred command %s", deferred.Key), err)
+			}
+			continue
+		}
+		// The handler may have rescheduled the key while running; only remove this entry
+		if err := s.removeIfUnchanged(ctx, deferred); err != nil {
+			if firstErr == nil {
+				firstErr = NewInfrastructureError(ErrCodeRepositoryError, fmt.Sprintf("failed to remove deferred command %s", deferred.Key), err)
+			}
+			continue
+		}
+		handled = append(handled, deferred)
+	}
+	return handled, firstErr
+}
+
+// removeIfUnchanged removes deferred unless its key now holds a different schedule
+func (s *DeferredScheduler) removeIfUnchanged(ctx context.Context, deferred DeferredCommand) error {
+	pending, err := s.store.Due(ctx, deferred.DueAt, 0)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range pending {
+		if candidate.Key == deferred.Key && candidate.DueAt.Equal(deferred.DueAt) {
+			return s.store.Remove(ctx, deferred.Key)
+		}
+	}
+	return nil
+}
+
+// Start runs due commands every interval until Stop is called
+func (s *DeferredScheduler) Start(ctx context.Context, interval time.Duration) {
+	s.mutex.Lock()
+	if s.stopCh != nil {
+		s.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	s.stopCh = stopCh
+	s.mutex.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// Run commands that came due while stopped without waiting for the first tick
+		_, _ = s.RunDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				// Failed dispatches stay scheduled and are retried on the next tick
+				_, _ = s.RunDue(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the loop started by Start
+func (s *DeferredScheduler) Stop() {
+	s.mutex.Lock()
+	stopCh := s.stopCh
+	s.stopCh = nil
+	s.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		s.wg.Wait()
+	}
+}
@@ -0,0 +1,139 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedCommandHandler 처리한 커맨드 집합 ID를 기록하고 정해진 결과를 돌려주는 핸들러
+type scriptedCommandHandler struct {
+	handled  []string
+	failures int   // 남은 인프라 오류 횟수
+	reject   error // 설정하면 커맨드를 거부
+}
+
+func (h *scriptedCommandHandler) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	if h.failures > 0 {
+		h.failures--
+		return nil, errors.New("event store unavailable")
+	}
+	h.handled = append(h.handled, command.ID())
+	if h.reject != nil {
+		return NewFailedCommandResult(h.reject), nil
+	}
+	return NewCommandResult(command.ID(), 1), nil
+}
+
+func (h *scriptedCommandHandler) CanHandle(commandType string) bool {
+	return commandType == "CloseAuction"
+}
+
+func (h *scriptedCommandHandler) GetHandlerName() string {
+	return "scripted"
+}
+
+func newDeferredTestScheduler(t *testing.T, handler *scriptedCommandHandler, now time.Time) *DeferredScheduler {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	require.NoError(t, dispatcher.RegisterHandler("CloseAuction", handler))
+	scheduler, err := NewDeferredScheduler(dispatcher, nil)
+	require.NoError(t, err)
+	scheduler.now = func() time.Time { return now }
+	return scheduler
+}
+
+func TestDeferredScheduler_RunsDueCommandsInOrder(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := &scriptedCommandHandler{}
+	scheduler := newDeferredTestScheduler(t, handler, now)
+	ctx := context.Background()
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a2", now.Add(-time.Minute), NewBaseCommand("CloseAuction", "a2", "Auction", nil)))
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a1", now.Add(-time.Hour), NewBaseCommand("CloseAuction", "a1", "Auction", nil)))
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a3", now.Add(time.Minute), NewBaseCommand("CloseAuction", "a3", "Auction", nil)))
+	// 같은 키로 다시 예약하면 이전 예약을 대체
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a3", now.Add(-time.Second), NewBaseCommand("CloseAuction", "a3", "Auction", nil)))
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a4", now, NewBaseCommand("CloseAuction", "a4", "Auction", nil)))
+	require.NoError(t, scheduler.Cancel(ctx, "auction:a4"))
+
+	// Act
+	handled, err := scheduler.RunDue(ctx)
+	again, againErr := scheduler.RunDue(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2", "a3"}, handler.handled)
+	assert.Len(t, handled, 3)
+	require.NoError(t, againErr)
+	assert.Empty(t, again)
+}
+
+func TestDeferredScheduler_RetriesInfrastructureFailures(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := &scriptedCommandHandler{failures: 1}
+	scheduler := newDeferredTestScheduler(t, handler, now)
+	ctx := context.Background()
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a1", now, NewBaseCommand("CloseAuction", "a1", "Auction", nil)))
+
+	// Act
+	failed, failedErr := scheduler.RunDue(ctx)
+	retried, retryErr := scheduler.RunDue(ctx)
+
+	// Assert
+	assert.True(t, IsInfrastructureError(failedErr))
+	assert.Empty(t, failed)
+	require.NoError(t, retryErr)
+	require.Len(t, retried, 1)
+	assert.Equal(t, []string{"a1"}, handler.handled)
+}
+
+func TestDeferredScheduler_DropsRejectedCommands(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := &scriptedCommandHandler{reject: errors.New("auction already settled")}
+	scheduler := newDeferredTestScheduler(t, handler, now)
+	ctx := context.Background()
+	require.NoError(t, scheduler.Schedule(ctx, "auction:a1", now, NewBaseCommand("CloseAuction", "a1", "Auction", nil)))
+
+	// Act
+	handled, err := scheduler.RunDue(ctx)
+	again, _ := scheduler.RunDue(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, handled, 1)
+	assert.Empty(t, again) // 거부된 커맨드는 다시 시도하지 않음
+	assert.Error(t, scheduler.Schedule(ctx, "", now, NewBaseCommand("CloseAuction", "a1", "Auction", nil)))
+}
+
+func TestDeferredCommand_JSONRoundTrip(t *testing.T) {
+	// Arrange
+	dueAt := time.Date(2026, 6, 1, 12, 0, 0, 123, time.UTC)
+	command := NewBaseCommand("CloseAuction", "a1", "Auction", map[string]interface{}{"reason": "ended", "bids": 3})
+	command.SetUserID("system")
+	deferred := DeferredCommand{Key: "auction:a1", DueAt: dueAt, Command: command}
+
+	// Act
+	encoded, err := json.Marshal(deferred)
+	require.NoError(t, err)
+	var decoded DeferredCommand
+	decodeErr := json.Unmarshal(encoded, &decoded)
+
+	// Assert
+	require.NoError(t, decodeErr)
+	assert.Equal(t, "auction:a1", decoded.Key)
+	assert.True(t, dueAt.Equal(decoded.DueAt))
+	assert.Equal(t, command.CommandID(), decoded.Command.CommandID())
+	assert.Equal(t, "CloseAuction", decoded.Command.CommandType())
+	assert.Equal(t, "a1", decoded.Command.ID())
+	assert.Equal(t, "Auction", decoded.Command.Type())
+	assert.Equal(t, "system", decoded.Command.UserID())
+	assert.Equal(t, map[string]interface{}{"reason": "ended", "bids": float64(3)}, decoded.Command.GetData())
+}
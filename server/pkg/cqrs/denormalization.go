@@ -0,0 +1,120 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// VersionedReadModel is a read model whose version tracks the last event applied to it;
+// BaseReadModel satisfies it
+type VersionedReadModel interface {
+	ReadModel
+	SetVersion(version int)
+}
+
+// LoadReadModel loads a read model and checks that it has the expected concrete type
+func LoadReadModel[T ReadModel](ctx context.Context, store ReadStore, id, modelType string) (T, error) {
+	var zero T
+	readModel, err := store.GetByID(ctx, id, modelType)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := readModel.(T)
+	if !ok {
+		return zero, NewCQRSError(ErrCodeReadStoreError.String(),
+			fmt.Sprintf("invalid read model type: expected %T, got %T", zero, readModel), nil)
+	}
+	return typed, nil
+}
+
+// UpdateReadModel loads an existing read model, applies fn and saves it
+func UpdateReadModel[T ReadModel](ctx context.Context, store ReadStore, id, modelType string, fn func(T) error) error {
+	model, err := LoadReadModel[T](ctx, store, id, modelType)
+	if err != nil {
+		return err
+	}
+	if err := fn(model); err != nil {
+		return err
+	}
+	return store.Save(ctx, model)
+}
+
+// UpsertReadModel loads the read model, or creates it when it does not exist yet, applies fn and saves it
+func UpsertReadModel[T ReadModel](ctx context.Context, store ReadStore, id, modelType string, create func() T, fn func(T) error) error {
+	model, err := LoadReadModel[T](ctx, store, id, modelType)
+	if err != nil {
+		if !IsNotFoundError(err) {
+			return err
+		}
+		model = create()
+	}
+	if err := fn(model); err != nil {
+		return err
+	}
+	return store.Save(ctx, model)
+}
+
+// ApplyIfNewer runs fn and moves the model to version only when version is newer than
+// the model's, so redelivered or replayed events are not applied twice
+func ApplyIfNewer(model VersionedReadModel, version int, fn func()) bool {
+	if version <= model.GetVersion() {
+		return false
+	}
+	fn()
+	model.SetVersion(version)
+	return true
+}
+
+// ProjectEvent is the common projection step: load the read model (creating it with create
+// when it is missing and create is not nil), apply fn if the event is newer than the model,
+// stamp the event version and save. Events that were already applied are skipped without a save.
+func ProjectEvent[T VersionedReadModel](ctx context.Context, store ReadStore, event EventMessage, id, modelType string, create func() T, fn func(T)) error {
+	model, err := LoadReadModel[T](ctx, store, id, modelType)
+	if err != nil {
+		if create == nil || !IsNotFoundError(err) {
+			return err
+		}
+		model = create()
+		fn(model)
+		model.SetVersion(event.Version())
+		return store.Save(ctx, model)
+	}
+
+	if !ApplyIfNewer(model, event.Version(), func() { fn(model) }) {
+		return nil
+	}
+	return store.Save(ctx, model)
+}
+
+// AdjustCount adds delta to a denormalized counter without letting it drop below zero
+func AdjustCount[N ~int | ~int32 | ~int64](count *N, delta N) {
+	*count += delta
+	if *count < 0 {
+		*count = 0
+	}
+}
+
+// AddMember appends item to a denormalized list unless it is already present
+func AddMember[T comparable](list []T, item T) ([]T, bool) {
+	for _, existing := range list {
+		if existing == item {
+			return list, false
+		}
+	}
+	return append(list, item), true
+}
+
+// RemoveMember removes every occurrence of item from a denormalized list
+func RemoveMember[T comparable](list []T, item T) ([]T, bool) {
+	kept := make([]T, 0, len(list))
+	removed := false
+	for _, existing := range list {
+		if existing == item {
+			removed = true
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	return kept, removed
+}
@@ -0,0 +1,91 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rosterView 길드 멤버 목록과 인원 수를 비정규화한 테스트 읽기 모델
+type rosterView struct {
+	*BaseReadModel
+	Members     []string
+	MemberCount int
+}
+
+func newRosterView(id string) *rosterView {
+	return &rosterView{BaseReadModel: NewBaseReadModel(id, "RosterView", map[string]interface{}{})}
+}
+
+func rosterEvent(eventType string, version int) EventMessage {
+	event := NewBaseEventMessage(eventType)
+	event.setAggregateInfo("guild-1", "Guild", version)
+	return event
+}
+
+func TestProjectEvent_CreatesAppliesAndSkipsReplays(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	join := func(user string) func(view *rosterView) {
+		return func(view *rosterView) {
+			var added bool
+			if view.Members, added = AddMember(view.Members, user); added {
+				AdjustCount(&view.MemberCount, 1)
+			}
+		}
+	}
+	create := func() *rosterView { return newRosterView("guild-1") }
+
+	// Act
+	require.NoError(t, ProjectEvent(ctx, store, rosterEvent("MemberJoined", 1), "guild-1", "RosterView", create, join("alice")))
+	require.NoError(t, ProjectEvent(ctx, store, rosterEvent("MemberJoined", 2), "guild-1", "RosterView", create, join("bob")))
+	// 같은 이벤트가 다시 전달되어도 반영되지 않아야 함
+	require.NoError(t, ProjectEvent(ctx, store, rosterEvent("MemberJoined", 2), "guild-1", "RosterView", create, join("bob")))
+	require.NoError(t, ProjectEvent(ctx, store, rosterEvent("MemberJoined", 1), "guild-1", "RosterView", create, join("carol")))
+
+	// Assert
+	view, err := LoadReadModel[*rosterView](ctx, store, "guild-1", "RosterView")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, view.Members)
+	assert.Equal(t, 2, view.MemberCount)
+	assert.Equal(t, 2, view.GetVersion())
+}
+
+func TestUpdateReadModel_RequiresExistingModelOfType(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	require.NoError(t, store.Save(ctx, NewBaseReadModel("guild-2", "RosterView", map[string]interface{}{})))
+	noop := func(view *rosterView) error { return nil }
+
+	// Act
+	missingErr := UpdateReadModel(ctx, store, "guild-9", "RosterView", noop)
+	wrongTypeErr := UpdateReadModel(ctx, store, "guild-2", "RosterView", noop)
+
+	// Assert
+	assert.True(t, IsNotFoundError(missingErr))
+	require.Error(t, wrongTypeErr)
+	assert.Contains(t, wrongTypeErr.Error(), "invalid read model type")
+}
+
+func TestDenormalizedListAndCounterHelpers(t *testing.T) {
+	// Arrange
+	members := []string{"alice", "bob"}
+	count := 1
+
+	// Act
+	members, duplicate := AddMember(members, "alice")
+	members, removed := RemoveMember(members, "alice")
+	_, removedAgain := RemoveMember(members, "alice")
+	AdjustCount(&count, -3)
+
+	// Assert
+	assert.False(t, duplicate)
+	assert.True(t, removed)
+	assert.False(t, removedAgain)
+	assert.Equal(t, []string{"bob"}, members)
+	assert.Equal(t, 0, count)
+}
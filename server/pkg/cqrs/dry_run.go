@@ -0,0 +1,218 @@
+package cqrs
+
+import (
+	"context"
+	"sync"
+)
+
+// dryRunKey marks a context whose writes are simulated
+type dryRunKey struct{}
+
+// dryRunRecorder collects what a dry-run command would have written
+type dryRunRecorder struct {
+	mutex      sync.Mutex
+	aggregates map[string]AggregateRoot // type/id -> simulated aggregate
+	order      []string
+	events     []EventMessage
+	eventIDs   map[string]bool
+}
+
+func aggregateKey(aggregateType, id string) string {
+	return aggregateType + "/" + id
+}
+
+func (r *dryRunRecorder) save(aggregate AggregateRoot) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := aggregateKey(aggregate.Type(), aggregate.ID())
+	if _, exists := r.aggregates[key]; !exists {
+		r.order = append(r.order, key)
+	}
+	r.aggregates[key] = aggregate
+	r.recordEvents(aggregate.Changes())
+}
+
+func (r *dryRunRecorder) load(aggregateType, id string) (AggregateRoot, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	aggregate, exists := r.aggregates[aggregateKey(aggregateType, id)]
+	return aggregate, exists
+}
+
+func (r *dryRunRecorder) publish(events []EventMessage) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.recordEvents(events)
+}
+
+// recordEvents keeps each event once; handlers often publish the events they just saved
+func (r *dryRunRecorder) recordEvents(events []EventMessage) {
+	for _, event := range events {
+		if event == nil || r.eventIDs[event.EventID()] {
+			continue
+		}
+		r.eventIDs[event.EventID()] = true
+		r.events = append(r.events, event)
+	}
+}
+
+// WithDryRun marks ctx so DryRunRepository and DryRunEventBus simulate writes instead of
+// performing them. DryRunDispatcher.DryRun sets it up; use this directly only to run
+// something other than a dispatched command in dry-run mode.
+func WithDryRun(ctx context.Context) context.Context {
+	if dryRunFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, dryRunKey{}, &dryRunRecorder{
+		aggregates: make(map[string]AggregateRoot),
+		eventIDs:   make(map[string]bool),
+	})
+}
+
+// IsDryRun reports whether ctx was marked with WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	return dryRunFrom(ctx) != nil
+}
+
+func dryRunFrom(ctx context.Context) *dryRunRecorder {
+	recorder, _ := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	return recorder
+}
+
+// DryRunRepository decorates a Repository for dry runs: in a WithDryRun context Save keeps
+// the aggregate and its uncommitted events in memory, and GetByID returns the simulated
+// aggregate so later steps of the same command see earlier changes. Outside dry runs every
+// call passes through.
+type DryRunRepository struct {
+	Repository
+	aggregateType string
+}
+
+// NewDryRunRepository wraps the repository of aggregateType for dry runs
+func NewDryRunRepository(repository Repository, aggregateType string) *DryRunRepository {
+	return &DryRunRepository{Repository: repository, aggregateType: aggregateType}
+}
+
+func (r *DryRunRepository) Save(ctx context.Context, aggregate AggregateRoot, expectedVersion int) error {
+	recorder := dryRunFrom(ctx)
+	if recorder == nil {
+		return r.Repository.Save(ctx, aggregate, expectedVersion)
+	}
+	if aggregate == nil {
+		return NewValidationError("aggregate cannot be nil", nil)
+	}
+	if err := aggregate.Validate(); err != nil {
+		return err
+	}
+	recorder.save(aggregate)
+	return nil
+}
+
+func (r *DryRunRepository) GetByID(ctx context.Context, id string) (AggregateRoot, error) {
+	if recorder := dryRunFrom(ctx); recorder != nil {
+		if aggregate, exists := recorder.load(r.aggregateType, id); exists {
+			return aggregate, nil
+		}
+	}
+	return r.Repository.GetByID(ctx, id)
+}
+
+func (r *DryRunRepository) GetVersion(ctx context.Context, id string) (int, error) {
+	if recorder := dryRunFrom(ctx); recorder != nil {
+		if aggregate, exists := recorder.load(r.aggregateType, id); exists {
+			return aggregate.Version(), nil
+		}
+	}
+	return r.Repository.GetVersion(ctx, id)
+}
+
+func (r *DryRunRepository) Exists(ctx context.Context, id string) bool {
+	if recorder := dryRunFrom(ctx); recorder != nil {
+		if _, exists := recorder.load(r.aggregateType, id); exists {
+			return true
+		}
+	}
+	return r.Repository.Exists(ctx, id)
+}
+
+// DryRunEventBus decorates an EventBus for dry runs: in a WithDryRun context published
+// events are collected for the preview instead of reaching subscribers
+type DryRunEventBus struct {
+	EventBus
+}
+
+// NewDryRunEventBus wraps bus for dry runs
+func NewDryRunEventBus(bus EventBus) *DryRunEventBus {
+	return &DryRunEventBus{EventBus: bus}
+}
+
+func (b *DryRunEventBus) Publish(ctx context.Context, event EventMessage, options ...EventPublishOptions) error {
+	if recorder := dryRunFrom(ctx); recorder != nil {
+		recorder.publish([]EventMessage{event})
+		return nil
+	}
+	return b.EventBus.Publish(ctx, event, options...)
+}
+
+func (b *DryRunEventBus) PublishBatch(ctx context.Context, events []EventMessage, options ...EventPublishOptions) error {
+	if recorder := dryRunFrom(ctx); recorder != nil {
+		recorder.publish(events)
+		return nil
+	}
+	return b.EventBus.PublishBatch(ctx, events, options...)
+}
+
+// DryRunResult is the projected outcome of a command that was not persisted
+type DryRunResult struct {
+	Result     *CommandResult  `json:"result"`
+	Events     []EventMessage  `json:"events"`     // Events the command would store or publish, in order
+	Aggregates []AggregateRoot `json:"aggregates"` // Resulting state of every aggregate the command would save
+}
+
+// Aggregate returns the simulated state of an aggregate, or nil when the command did not change it
+func (r *DryRunResult) Aggregate(aggregateType, id string) AggregateRoot {
+	for _, aggregate := range r.Aggregates {
+		if aggregate.Type() == aggregateType && aggregate.ID() == id {
+			return aggregate
+		}
+	}
+	return nil
+}
+
+// DryRunDispatcher adds a what-if mode to a dispatcher, e.g. to preview the reward split
+// of joining a recruitment before the player confirms. Handlers must reach storage through
+// DryRunRepository and DryRunEventBus; writes made any other way are not simulated.
+type DryRunDispatcher struct {
+	CommandDispatcher
+}
+
+// NewDryRunDispatcher wraps dispatcher with the dry-run mode
+func NewDryRunDispatcher(dispatcher CommandDispatcher) *DryRunDispatcher {
+	return &DryRunDispatcher{CommandDispatcher: dispatcher}
+}
+
+// DryRun dispatches the command with simulated writes and returns the events and resulting
+// aggregate states. A rejected command returns its failed result with no events.
+func (d *DryRunDispatcher) DryRun(ctx context.Context, command Command) (*DryRunResult, error) {
+	if command == nil {
+		return nil, NewCQRSError(ErrCodeCommandValidation.String(), "command cannot be nil", nil)
+	}
+	ctx = WithDryRun(ctx)
+	result, err := d.CommandDispatcher.Dispatch(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &DryRunResult{Result: result}
+	if result != nil && !result.Success {
+		return preview, nil
+	}
+	recorder := dryRunFrom(ctx)
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	preview.Events = append(preview.Events, recorder.events...)
+	for _, key := range recorder.order {
+		preview.Aggregates = append(preview.Aggregates, recorder.aggregates[key])
+	}
+	return preview, nil
+}
@@ -0,0 +1,94 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDryRunTestDispatcher 카운터를 두 번 저장하고 이벤트를 발행하는 핸들러를 등록
+func newDryRunTestDispatcher(t *testing.T, repository Repository, bus EventBus) *DryRunDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = func(ctx context.Context, command Command) (*CommandResult, error) {
+		if command.(*TestCommand).TestData == "reject" {
+			return NewFailedCommandResult(NewValidationError("recruitment closed", nil)), nil
+		}
+		var events []EventMessage
+		for i := 0; i < 2; i++ { // 두 번째 로드는 첫 번째 저장 결과를 봐야 함
+			aggregate, err := repository.GetByID(ctx, command.ID())
+			if err != nil {
+				return nil, err
+			}
+			if err := increment(ctx, aggregate); err != nil {
+				return nil, err
+			}
+			if err := repository.Save(ctx, aggregate, aggregate.OriginalVersion()); err != nil {
+				return nil, err
+			}
+			events = aggregate.Changes()
+		}
+		if err := bus.PublishBatch(ctx, events); err != nil {
+			return nil, err
+		}
+		return NewCommandResult(command.ID(), 2, events...), nil
+	}
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	return NewDryRunDispatcher(dispatcher)
+}
+
+func TestDryRunDispatcher_SimulatesWithoutPersisting(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{count: 5}
+	repository := NewDryRunRepository(inner, "Counter")
+	innerBus := NewInMemoryEventBus()
+	require.NoError(t, innerBus.Start(context.Background()))
+	defer innerBus.Stop(context.Background())
+	subscriber := NewTestEventHandler("subscriber", []string{"Incremented"})
+	_, err := innerBus.Subscribe("Incremented", subscriber)
+	require.NoError(t, err)
+	dispatcher := newDryRunTestDispatcher(t, repository, NewDryRunEventBus(innerBus))
+
+	// Act
+	preview, err := dispatcher.DryRun(context.Background(), NewTestCommand("guild-1", "join"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, preview.Result.Success)
+	assert.Len(t, preview.Events, 2) // 저장과 발행이 겹쳐도 한 번씩
+	require.Len(t, preview.Aggregates, 1)
+	counter := preview.Aggregate("Counter", "guild-1").(*counterAggregate)
+	assert.Equal(t, 7, counter.Count)
+	assert.Equal(t, 0, inner.saves)
+	assert.Equal(t, 5, inner.count)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 0, subscriber.GetHandledEventCount())
+}
+
+func TestDryRunDispatcher_RejectedAndRealDispatch(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{}
+	dispatcher := newDryRunTestDispatcher(t, NewDryRunRepository(inner, "Counter"), NewDryRunEventBus(NewInMemoryEventBus()))
+	history := NewCommandHistoryDispatcher(dispatcher, CommandHistoryConfig{})
+	ctx := context.Background()
+
+	// Act
+	rejected, rejectErr := dispatcher.DryRun(ctx, NewTestCommand("guild-1", "reject"))
+	_, historyErr := NewDryRunDispatcher(history).DryRun(ctx, NewTestCommand("guild-1", "join"))
+	recorded, _ := history.History(ctx, CommandHistoryQuery{})
+	_, dispatchErr := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "join"))
+
+	// Assert
+	require.NoError(t, rejectErr)
+	assert.False(t, rejected.Result.Success)
+	assert.Empty(t, rejected.Events)
+	require.NoError(t, historyErr)
+	assert.Empty(t, recorded) // 미리보기는 커맨드 이력에 남지 않음
+	require.NoError(t, dispatchErr)
+	assert.Equal(t, 2, inner.saves)
+	assert.Equal(t, 2, inner.count)
+}
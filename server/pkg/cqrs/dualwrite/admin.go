@@ -0,0 +1,44 @@
+package dualwrite
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// bridgeStatus is the GET response of AdminHandler
+type bridgeStatus struct {
+	Enabled    bool               `json:"enabled"`
+	Stats      Stats              `json:"stats"`
+	Quarantine []*QuarantineEntry `json:"quarantine"`
+}
+
+// AdminHandler exposes the bridge to operators: GET returns stats and the quarantine,
+// POST retries the quarantine and returns the RetryReport
+func AdminHandler(bridge *Bridge) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			entries, err := bridge.quarantine.List(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeJSON(w, bridgeStatus{Enabled: bridge.Enabled(), Stats: bridge.Stats(), Quarantine: entries})
+		case http.MethodPost:
+			report, err := bridge.RetryQuarantined(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeJSON(w, report)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
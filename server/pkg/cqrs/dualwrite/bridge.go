@@ -0,0 +1,267 @@
+package dualwrite
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// lockPrefix keeps bridge locks apart from the command side's aggregate locks, which may
+// still be held while a synchronous event handler runs
+const lockPrefix = "dualwrite:"
+
+// Config selects what the bridge mirrors
+type Config struct {
+	CommandTypes []string // Commands mirrored after they succeed (via Dispatcher)
+	EventTypes   []string // Events mirrored when the bridge handles them
+	// MaxAttempts is the delivery budget of a quarantined mirror before it is abandoned (default 5)
+	MaxAttempts int
+	// Locker serializes mirrors of one aggregate; use a Redis locker when several servers
+	// run the bridge (default in-memory)
+	Locker  cqrs.AggregateLocker
+	LockTTL time.Duration // default 30s
+}
+
+// Stats counts bridge activity since start
+type Stats struct {
+	Mirrored    int64 `json:"mirrored"`    // Delivered on the first attempt
+	Failed      int64 `json:"failed"`      // Quarantined after a delivery error
+	Held        int64 `json:"held"`        // Quarantined behind an earlier failure of the same aggregate
+	Redelivered int64 `json:"redelivered"` // Delivered from quarantine
+	Abandoned   int64 `json:"abandoned"`   // Exceeded MaxAttempts
+	Skipped     int64 `json:"skipped"`     // Not sent because the bridge was disabled
+}
+
+// RetryReport summarizes a RetryQuarantined run
+type RetryReport struct {
+	Attempted   int `json:"attempted"`
+	Redelivered int `json:"redelivered"`
+	Failed      int `json:"failed"`
+	Abandoned   int `json:"abandoned"` // Newly abandoned in this run
+	Remaining   int `json:"remaining"` // Entries still quarantined, including abandoned ones
+}
+
+// Bridge mirrors commands and events to the legacy system. Register it as an event handler
+// for the configured event types and wrap the command dispatcher with Dispatcher.
+type Bridge struct {
+	*cqrs.BaseEventHandler
+	client       LegacyClient
+	quarantine   QuarantineStore
+	commandTypes map[string]bool
+	maxAttempts  int
+	locker       cqrs.AggregateLocker
+	lockTTL      time.Duration
+	enabled      atomic.Bool
+	now          func() time.Time
+
+	mirrored, failed, held, redelivered, abandoned, skipped atomic.Int64
+}
+
+// NewBridge creates an enabled bridge
+func NewBridge(client LegacyClient, quarantine QuarantineStore, config Config) *Bridge {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.Locker == nil {
+		config.Locker = cqrs.NewInMemoryAggregateLocker()
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = 30 * time.Second
+	}
+
+	bridge := &Bridge{
+		BaseEventHandler: cqrs.NewBaseEventHandler("DualWriteBridge", cqrs.NotificationHandler, config.EventTypes),
+		client:           client,
+		quarantine:       quarantine,
+		commandTypes:     make(map[string]bool, len(config.CommandTypes)),
+		maxAttempts:      config.MaxAttempts,
+		locker:           config.Locker,
+		lockTTL:          config.LockTTL,
+		now:              time.Now,
+	}
+	for _, commandType := range config.CommandTypes {
+		bridge.commandTypes[commandType] = true
+	}
+	bridge.enabled.Store(true)
+	return bridge
+}
+
+// SetEnabled turns mirroring on or off, e.g. once an aggregate type has been cut over.
+// Quarantined mirrors are kept while disabled.
+func (b *Bridge) SetEnabled(enabled bool) {
+	b.enabled.Store(enabled)
+}
+
+// Enabled reports whether the bridge mirrors new commands and events
+func (b *Bridge) Enabled() bool {
+	return b.enabled.Load()
+}
+
+// Stats returns the activity counters
+func (b *Bridge) Stats() Stats {
+	return Stats{
+		Mirrored:    b.mirrored.Load(),
+		Failed:      b.failed.Load(),
+		Held:        b.held.Load(),
+		Redelivered: b.redelivered.Load(),
+		Abandoned:   b.abandoned.Load(),
+		Skipped:     b.skipped.Load(),
+	}
+}
+
+// Handle mirrors an event. Delivery failures are quarantined, not returned, so the legacy
+// system can never stall projections; only a quarantine store failure is an error.
+func (b *Bridge) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	if !b.CanHandle(event.EventType()) {
+		return nil
+	}
+	return b.Mirror(ctx, EventMirror(event))
+}
+
+// Mirror sends one mirror, or quarantines it when delivery fails or the aggregate already
+// has quarantined mirrors that must reach the legacy system first
+func (b *Bridge) Mirror(ctx context.Context, mirror Mirror) error {
+	if !b.Enabled() {
+		b.skipped.Add(1)
+		return nil
+	}
+
+	return b.withLock(ctx, mirror.AggregateType, mirror.AggregateID, func(ctx context.Context) error {
+		held, err := b.quarantine.Holds(ctx, mirror.AggregateType, mirror.AggregateID)
+		if err != nil {
+			return fmt.Errorf("failed to check dual-write quarantine: %w", err)
+		}
+		if held {
+			b.held.Add(1)
+			return b.quarantine.Put(ctx, &QuarantineEntry{
+				Mirror:        mirror,
+				LastError:     "held behind an earlier quarantined mirror",
+				QuarantinedAt: b.now(),
+			})
+		}
+
+		sendErr := b.client.Send(ctx, mirror)
+		if sendErr == nil {
+			b.mirrored.Add(1)
+			return nil
+		}
+		b.failed.Add(1)
+		now := b.now()
+		return b.quarantine.Put(ctx, &QuarantineEntry{
+			Mirror:        mirror,
+			Attempts:      1,
+			LastError:     sendErr.Error(),
+			QuarantinedAt: now,
+			LastAttemptAt: now,
+		})
+	})
+}
+
+// RetryQuarantined redelivers quarantined mirrors oldest first. Within an aggregate it stops
+// at the first failure, so the legacy system never sees that aggregate's mirrors out of order.
+func (b *Bridge) RetryQuarantined(ctx context.Context) (*RetryReport, error) {
+	entries, err := b.quarantine.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RetryReport{}
+	byAggregate := make(map[string][]*QuarantineEntry)
+	var order []string
+	for _, entry := range entries {
+		key := entry.AggregateType + "/" + entry.AggregateID
+		if _, exists := byAggregate[key]; !exists {
+			order = append(order, key)
+		}
+		byAggregate[key] = append(byAggregate[key], entry)
+	}
+
+	for _, key := range order {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		queue := byAggregate[key]
+		err := b.withLock(ctx, queue[0].AggregateType, queue[0].AggregateID, func(ctx context.Context) error {
+			return b.retryAggregate(ctx, queue, report)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	remaining, err := b.quarantine.List(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Remaining = len(remaining)
+	return report, nil
+}
+
+func (b *Bridge) retryAggregate(ctx context.Context, queue []*QuarantineEntry, report *RetryReport) error {
+	for _, entry := range queue {
+		if entry.Abandoned {
+			return nil
+		}
+
+		report.Attempted++
+		entry.Attempts++
+		entry.LastAttemptAt = b.now()
+		sendErr := b.client.Send(ctx, entry.Mirror)
+		if sendErr == nil {
+			if err := b.quarantine.Remove(ctx, entry.ID); err != nil {
+				return err
+			}
+			report.Redelivered++
+			b.redelivered.Add(1)
+			continue
+		}
+
+		report.Failed++
+		entry.LastError = sendErr.Error()
+		if entry.Attempts >= b.maxAttempts {
+			entry.Abandoned = true
+			report.Abandoned++
+			b.abandoned.Add(1)
+		}
+		return b.quarantine.Put(ctx, entry)
+	}
+	return nil
+}
+
+// Discard drops a quarantined mirror after an operator fixed the legacy side by hand,
+// releasing the mirrors held behind it for the next retry
+func (b *Bridge) Discard(ctx context.Context, mirrorID string) error {
+	return b.quarantine.Remove(ctx, mirrorID)
+}
+
+func (b *Bridge) withLock(ctx context.Context, aggregateType, aggregateID string, fn func(ctx context.Context) error) error {
+	return cqrs.WithAggregateLock(ctx, b.locker, lockPrefix+aggregateType, aggregateID, b.lockTTL,
+		func(ctx context.Context, _ cqrs.AggregateLock) error {
+			return fn(ctx)
+		})
+}
+
+// Dispatcher wraps a command dispatcher so configured command types are mirrored after
+// they succeed. The command result is returned unchanged even when mirroring fails.
+func (b *Bridge) Dispatcher(inner cqrs.CommandDispatcher) cqrs.CommandDispatcher {
+	return &mirroringDispatcher{CommandDispatcher: inner, bridge: b}
+}
+
+type mirroringDispatcher struct {
+	cqrs.CommandDispatcher
+	bridge *Bridge
+}
+
+func (d *mirroringDispatcher) Dispatch(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	result, err := d.CommandDispatcher.Dispatch(ctx, command)
+	if err != nil || result == nil || !result.Success || !d.bridge.commandTypes[command.CommandType()] {
+		return result, err
+	}
+
+	if mirrorErr := d.bridge.Mirror(ctx, CommandMirror(command)); mirrorErr != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("legacy mirror failed: %v", mirrorErr))
+	}
+	return result, nil
+}
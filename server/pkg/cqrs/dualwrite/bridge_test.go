@@ -0,0 +1,236 @@
+package dualwrite
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyServer 레거시 API 흉내: 받은 미러를 기록하고 플레이어 상태를 돌려줌
+type legacyServer struct {
+	mu      sync.Mutex
+	down    bool
+	mirrors []Mirror
+	players map[string]map[string]interface{}
+}
+
+func (s *legacyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method == http.MethodGet {
+		id := strings.TrimPrefix(r.URL.Path, "/aggregates/Player/")
+		state, exists := s.players[id]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(state)
+		return
+	}
+	var mirror Mirror
+	json.NewDecoder(r.Body).Decode(&mirror)
+	if r.Header.Get("Idempotency-Key") != mirror.ID {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mirrors = append(s.mirrors, mirror)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *legacyServer) setDown(down bool) {
+	s.mu.Lock()
+	s.down = down
+	s.mu.Unlock()
+}
+
+func (s *legacyServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, mirror := range s.mirrors {
+		ids = append(ids, mirror.ID)
+	}
+	return ids
+}
+
+type goldEarned struct {
+	cqrs.BaseEventMessage
+	Amount int `json:"amount"`
+}
+
+func (e *goldEarned) EventData() interface{} {
+	return map[string]int{"amount": e.Amount}
+}
+
+func newGoldEarned(playerID string, version, amount int) *goldEarned {
+	event := &goldEarned{BaseEventMessage: *cqrs.NewBaseEventMessage("GoldEarned"), Amount: amount}
+	event.AggregateID_, event.AggregateType_, event.Version_ = playerID, "Player", version
+	return event
+}
+
+func TestBridge_QuarantinesAndRedeliversInOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	legacy := &legacyServer{}
+	server := httptest.NewServer(legacy)
+	defer server.Close()
+	quarantine := NewInMemoryQuarantineStore()
+	bridge := NewBridge(NewHTTPLegacyClient(server.URL), quarantine, Config{EventTypes: []string{"GoldEarned"}})
+
+	first, second, other := newGoldEarned("p1", 1, 10), newGoldEarned("p1", 2, 20), newGoldEarned("p2", 1, 5)
+
+	// Act
+	legacy.setDown(true)
+	require.NoError(t, bridge.Handle(ctx, first))
+	legacy.setDown(false)
+	require.NoError(t, bridge.Handle(ctx, second)) // Held behind first
+	require.NoError(t, bridge.Handle(ctx, other))
+
+	held, _ := quarantine.List(ctx)
+	report, err := bridge.RetryQuarantined(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, held, 2)
+	assert.Equal(t, 1, held[0].Attempts)
+	assert.Contains(t, held[0].LastError, "status 503")
+	assert.Zero(t, held[1].Attempts)
+
+	assert.Equal(t, &RetryReport{Attempted: 2, Redelivered: 2}, report)
+	assert.Equal(t, []string{other.EventID(), first.EventID(), second.EventID()}, legacy.received())
+	assert.Equal(t, Stats{Mirrored: 1, Failed: 1, Held: 1, Redelivered: 2}, bridge.Stats())
+
+	mirror := legacy.mirrors[1]
+	assert.Equal(t, KindEvent, mirror.Kind)
+	assert.Equal(t, "Player", mirror.AggregateType)
+	assert.Equal(t, 1, mirror.Version)
+	assert.Equal(t, float64(10), mirror.Payload["amount"])
+}
+
+func TestBridge_AbandonsAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	legacy := &legacyServer{down: true}
+	server := httptest.NewServer(legacy)
+	defer server.Close()
+	bridge := NewBridge(NewHTTPLegacyClient(server.URL), NewInMemoryQuarantineStore(), Config{EventTypes: []string{"GoldEarned"}, MaxAttempts: 2})
+	event := newGoldEarned("p1", 1, 10)
+	require.NoError(t, bridge.Handle(ctx, event))
+
+	// Act
+	report, err := bridge.RetryQuarantined(ctx)
+	require.NoError(t, err)
+	legacy.setDown(false)
+	afterRecovery, err := bridge.RetryQuarantined(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, &RetryReport{Attempted: 1, Failed: 1, Abandoned: 1, Remaining: 1}, report)
+	assert.Equal(t, &RetryReport{Remaining: 1}, afterRecovery) // Needs an operator
+	assert.Empty(t, legacy.received())
+
+	require.NoError(t, bridge.Discard(ctx, event.EventID()))
+	require.NoError(t, bridge.Handle(ctx, newGoldEarned("p1", 2, 20)))
+	assert.Len(t, legacy.received(), 1)
+}
+
+type grantGold struct {
+	cqrs.BaseCommand
+	Amount int `json:"amount"`
+}
+
+func (c *grantGold) GetData() interface{} { return map[string]int{"amount": c.Amount} }
+
+type succeedingHandler struct {
+	*cqrs.BaseCommandHandler
+}
+
+func (h succeedingHandler) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	return &cqrs.CommandResult{Success: true, AggregateID: command.ID()}, nil
+}
+
+func TestBridge_MirrorsSuccessfulCommands(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	legacy := &legacyServer{}
+	server := httptest.NewServer(legacy)
+	defer server.Close()
+	bridge := NewBridge(NewHTTPLegacyClient(server.URL), NewInMemoryQuarantineStore(), Config{CommandTypes: []string{"GrantGold"}})
+
+	inner := cqrs.NewInMemoryCommandDispatcher()
+	handler := succeedingHandler{cqrs.NewBaseCommandHandler("GoldHandler", []string{"GrantGold", "Ban"})}
+	require.NoError(t, inner.RegisterHandler("GrantGold", handler))
+	require.NoError(t, inner.RegisterHandler("Ban", handler))
+	dispatcher := bridge.Dispatcher(inner)
+
+	grant := &grantGold{BaseCommand: *cqrs.NewBaseCommand("GrantGold", "p1", "Player", nil), Amount: 50}
+
+	// Act
+	_, err := dispatcher.Dispatch(ctx, grant)
+	require.NoError(t, err)
+	_, err = dispatcher.Dispatch(ctx, cqrs.NewBaseCommand("Ban", "p1", "Player", nil))
+	require.NoError(t, err)
+	bridge.SetEnabled(false)
+	_, err = dispatcher.Dispatch(ctx, grant)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, legacy.mirrors, 1)
+	assert.Equal(t, KindCommand, legacy.mirrors[0].Kind)
+	assert.Equal(t, "GrantGold", legacy.mirrors[0].Type)
+	assert.Equal(t, float64(50), legacy.mirrors[0].Payload["amount"])
+	assert.Equal(t, int64(1), bridge.Stats().Skipped)
+}
+
+func TestReconciler_ReportsDrift(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	legacy := &legacyServer{players: map[string]map[string]interface{}{
+		"p1": {"gold": 100, "nick": "alice"},
+		"p2": {"gold": 90, "nick": "bob"},
+		"p4": {"gold": 1},
+	}}
+	server := httptest.NewServer(legacy)
+	defer server.Close()
+	current := map[string]map[string]interface{}{
+		"p1": {"gold": 100, "nickname": "alice"},
+		"p2": {"gold": 95, "nickname": "bob"},
+		"p3": {"gold": 1, "nickname": "carol"},
+		"p5": {"gold": 7, "nickname": "erin"},
+	}
+	source := func(ctx context.Context, aggregateType, aggregateID string) (map[string]interface{}, error) {
+		return toMap(current[aggregateID]), nil
+	}
+	quarantine := NewInMemoryQuarantineStore()
+	require.NoError(t, quarantine.Put(ctx, &QuarantineEntry{Mirror: Mirror{ID: "m1", AggregateType: "Player", AggregateID: "p5"}, QuarantinedAt: time.Now()}))
+	reconciler := NewReconciler(source, NewHTTPLegacyClient(server.URL), quarantine)
+
+	// Act
+	report, err := reconciler.Reconcile(ctx, ReconcileConfig{
+		AggregateType: "Player",
+		LegacyFields:  map[string]string{"nickname": "nick"},
+	}, []string{"p1", "p2", "p3", "p4", "p5"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, report.Consistent())
+	assert.Equal(t, 5, report.Checked)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, []Discrepancy{{AggregateID: "p2", Field: "gold", Current: float64(95), Legacy: float64(90)}}, report.Mismatched)
+	assert.Equal(t, []string{"p3"}, report.MissingInLegacy)
+	assert.Equal(t, []string{"p4"}, report.MissingInCurrent)
+	assert.Equal(t, []string{"p5"}, report.Pending)
+}
@@ -0,0 +1,177 @@
+// Package dualwrite mirrors selected commands and events to the legacy system's API
+// during the migration window, so both systems stay in step until cut-over.
+//
+// The CQRS side is the source of truth: a failed mirror never fails the command or
+// event that caused it. Failed mirrors are quarantined per aggregate instead, later
+// mirrors of the same aggregate queue up behind them to keep the legacy side in order,
+// and RetryQuarantined redelivers them. The Reconciler compares both sides and reports
+// the aggregates that drifted apart.
+package dualwrite
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Kind tells whether a mirror carries a command or an event
+type Kind string
+
+const (
+	KindCommand Kind = "command"
+	KindEvent   Kind = "event"
+)
+
+// Mirror is one command or event as sent to the legacy system
+type Mirror struct {
+	ID            string                 `json:"id" bson:"_id"`
+	Kind          Kind                   `json:"kind" bson:"kind"`
+	Type          string                 `json:"type" bson:"type"`
+	AggregateID   string                 `json:"aggregate_id" bson:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type" bson:"aggregate_type"`
+	Version       int                    `json:"version,omitempty" bson:"version,omitempty"`
+	Payload       map[string]interface{} `json:"payload,omitempty" bson:"payload,omitempty"`
+	OccurredAt    time.Time              `json:"occurred_at" bson:"occurred_at"`
+}
+
+// CommandMirror converts a command to a mirror
+func CommandMirror(command cqrs.Command) Mirror {
+	return Mirror{
+		ID:            command.CommandID(),
+		Kind:          KindCommand,
+		Type:          command.CommandType(),
+		AggregateID:   command.ID(),
+		AggregateType: command.Type(),
+		Payload:       toMap(command.GetData()),
+		OccurredAt:    command.Timestamp(),
+	}
+}
+
+// EventMirror converts an event to a mirror
+func EventMirror(event cqrs.EventMessage) Mirror {
+	return Mirror{
+		ID:            event.EventID(),
+		Kind:          KindEvent,
+		Type:          event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		Version:       event.Version(),
+		Payload:       toMap(event.EventData()),
+		OccurredAt:    event.Timestamp(),
+	}
+}
+
+// toMap converts a payload to its JSON object form
+func toMap(data interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return map[string]interface{}{"value": json.RawMessage(encoded)}
+	}
+	return result
+}
+
+// LegacyClient talks to the legacy system
+type LegacyClient interface {
+	// Send applies a mirrored command or event; it must be idempotent on Mirror.ID
+	Send(ctx context.Context, mirror Mirror) error
+	// Fetch returns the legacy state of an aggregate, or nil when the legacy system has none
+	Fetch(ctx context.Context, aggregateType, aggregateID string) (map[string]interface{}, error)
+}
+
+// HTTPLegacyClient is a LegacyClient for a JSON HTTP API:
+//
+//	POST {BaseURL}/{kind}s/{type}               body: Mirror, header Idempotency-Key: Mirror.ID
+//	GET  {BaseURL}/aggregates/{type}/{id}       404 means the aggregate does not exist
+type HTTPLegacyClient struct {
+	BaseURL string
+	Client  *http.Client
+	Headers map[string]string // e.g. an API token for the legacy gateway
+}
+
+// NewHTTPLegacyClient creates a client with a 5 second timeout
+func NewHTTPLegacyClient(baseURL string) *HTTPLegacyClient {
+	return &HTTPLegacyClient{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *HTTPLegacyClient) Send(ctx context.Context, mirror Mirror) error {
+	body, err := json.Marshal(mirror)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/%ss/%s", c.BaseURL, mirror.Kind, url.PathEscape(mirror.Type))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", mirror.ID)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("legacy API returned status %d for %s %s", resp.StatusCode, mirror.Kind, mirror.Type)
+	}
+	return nil
+}
+
+func (c *HTTPLegacyClient) Fetch(ctx context.Context, aggregateType, aggregateID string) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/aggregates/%s/%s", c.BaseURL, url.PathEscape(aggregateType), url.PathEscape(aggregateID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		io.Copy(io.Discard, resp.Body)
+		return nil, nil
+	}
+	if resp.StatusCode >= 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("legacy API returned status %d for %s %s", resp.StatusCode, aggregateType, aggregateID)
+	}
+
+	var state map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode legacy state of %s %s: %w", aggregateType, aggregateID, err)
+	}
+	return state, nil
+}
+
+func (c *HTTPLegacyClient) do(req *http.Request) (*http.Response, error) {
+	for key, value := range c.Headers {
+		req.Header.Set(key, value)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
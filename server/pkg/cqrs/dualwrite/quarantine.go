@@ -0,0 +1,164 @@
+package dualwrite
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// QuarantineEntry is a mirror the legacy system has not accepted yet
+type QuarantineEntry struct {
+	Mirror        `bson:",inline"`
+	Attempts      int       `json:"attempts" bson:"attempts"`
+	LastError     string    `json:"last_error" bson:"last_error"`
+	QuarantinedAt time.Time `json:"quarantined_at" bson:"quarantined_at"`
+	LastAttemptAt time.Time `json:"last_attempt_at" bson:"last_attempt_at"`
+	// Abandoned entries exceeded the retry budget; they stay quarantined (and keep holding
+	// their aggregate) until an operator resolves them with Bridge.Discard
+	Abandoned bool `json:"abandoned" bson:"abandoned"`
+}
+
+// QuarantineStore keeps failed mirrors
+type QuarantineStore interface {
+	// Put inserts or replaces the entry with the same mirror ID
+	Put(ctx context.Context, entry *QuarantineEntry) error
+	Remove(ctx context.Context, mirrorID string) error
+	// List returns every entry, oldest quarantine first
+	List(ctx context.Context) ([]*QuarantineEntry, error)
+	// Holds reports whether the aggregate has a quarantined mirror
+	Holds(ctx context.Context, aggregateType, aggregateID string) (bool, error)
+}
+
+// sortEntries orders entries by quarantine time, then by aggregate version
+func sortEntries(entries []*QuarantineEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].QuarantinedAt.Equal(entries[j].QuarantinedAt) {
+			return entries[i].QuarantinedAt.Before(entries[j].QuarantinedAt)
+		}
+		return entries[i].Version < entries[j].Version
+	})
+}
+
+// InMemoryQuarantineStore is a QuarantineStore for tests and single-node tools
+type InMemoryQuarantineStore struct {
+	mutex   sync.RWMutex
+	entries map[string]*QuarantineEntry
+}
+
+// NewInMemoryQuarantineStore creates an empty store
+func NewInMemoryQuarantineStore() *InMemoryQuarantineStore {
+	return &InMemoryQuarantineStore{entries: make(map[string]*QuarantineEntry)}
+}
+
+func (s *InMemoryQuarantineStore) Put(ctx context.Context, entry *QuarantineEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	copied := *entry
+	s.entries[entry.ID] = &copied
+	return nil
+}
+
+func (s *InMemoryQuarantineStore) Remove(ctx context.Context, mirrorID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.entries, mirrorID)
+	return nil
+}
+
+func (s *InMemoryQuarantineStore) List(ctx context.Context) ([]*QuarantineEntry, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	entries := make([]*QuarantineEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		copied := *entry
+		entries = append(entries, &copied)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+func (s *InMemoryQuarantineStore) Holds(ctx context.Context, aggregateType, aggregateID string) (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, entry := range s.entries {
+		if entry.AggregateType == aggregateType && entry.AggregateID == aggregateID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MongoQuarantineStore keeps quarantined mirrors in a Mongo collection so they survive restarts
+type MongoQuarantineStore struct {
+	client     *cqrsx.MongoClientManager
+	collection string
+}
+
+// NewMongoQuarantineStore creates a store on collection (default "dualwrite_quarantine")
+func NewMongoQuarantineStore(client *cqrsx.MongoClientManager, collection string) *MongoQuarantineStore {
+	if collection == "" {
+		collection = "dualwrite_quarantine"
+	}
+	return &MongoQuarantineStore{client: client, collection: collection}
+}
+
+// EnsureIndexes creates the aggregate index used by Holds
+func (s *MongoQuarantineStore) EnsureIndexes(ctx context.Context) error {
+	return s.client.ExecuteCommand(ctx, func() error {
+		_, err := s.client.GetCollection(s.collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "aggregate_type", Value: 1}, {Key: "aggregate_id", Value: 1}},
+		})
+		return err
+	})
+}
+
+func (s *MongoQuarantineStore) Put(ctx context.Context, entry *QuarantineEntry) error {
+	return s.client.ExecuteCommand(ctx, func() error {
+		_, err := s.client.GetCollection(s.collection).ReplaceOne(ctx, bson.M{"_id": entry.ID}, entry, options.Replace().SetUpsert(true))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to save quarantined mirror", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoQuarantineStore) Remove(ctx context.Context, mirrorID string) error {
+	return s.client.ExecuteCommand(ctx, func() error {
+		_, err := s.client.GetCollection(s.collection).DeleteOne(ctx, bson.M{"_id": mirrorID})
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to remove quarantined mirror", err)
+		}
+		return nil
+	})
+}
+
+func (s *MongoQuarantineStore) List(ctx context.Context) ([]*QuarantineEntry, error) {
+	var entries []*QuarantineEntry
+	err := s.client.ExecuteCommand(ctx, func() error {
+		cursor, err := s.client.GetCollection(s.collection).Find(ctx, bson.M{},
+			options.Find().SetSort(bson.D{{Key: "quarantined_at", Value: 1}, {Key: "version", Value: 1}}))
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeRepositoryError.String(), "failed to list quarantined mirrors", err)
+		}
+		return cursor.All(ctx, &entries)
+	})
+	return entries, err
+}
+
+func (s *MongoQuarantineStore) Holds(ctx context.Context, aggregateType, aggregateID string) (bool, error) {
+	var count int64
+	err := s.client.ExecuteCommand(ctx, func() error {
+		var err error
+		count, err = s.client.GetCollection(s.collection).CountDocuments(ctx,
+			bson.M{"aggregate_type": aggregateType, "aggregate_id": aggregateID}, options.Count().SetLimit(1))
+		return err
+	})
+	return count > 0, err
+}
@@ -0,0 +1,168 @@
+package dualwrite
+
+import (
+	"context"
+	"cqrs"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// StateSource loads the CQRS-side state of an aggregate, or nil when it does not exist
+type StateSource func(ctx context.Context, aggregateType, aggregateID string) (map[string]interface{}, error)
+
+// ReadModelStateSource reads state from read models of modelType with the aggregate's ID
+func ReadModelStateSource(store cqrs.ReadStore, modelType string) StateSource {
+	return func(ctx context.Context, aggregateType, aggregateID string) (map[string]interface{}, error) {
+		model, err := store.GetByID(ctx, aggregateID, modelType)
+		if err != nil {
+			if cqrs.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return toMap(model.GetData()), nil
+	}
+}
+
+// ReconcileConfig selects what is compared
+type ReconcileConfig struct {
+	AggregateType string
+	// Fields compared; empty compares every field of the CQRS-side state
+	Fields []string
+	// LegacyFields maps a CQRS field name to the legacy field name when they differ
+	LegacyFields map[string]string
+}
+
+// Discrepancy is one field whose value differs between the two systems
+type Discrepancy struct {
+	AggregateID string      `json:"aggregate_id"`
+	Field       string      `json:"field"`
+	Current     interface{} `json:"current"`
+	Legacy      interface{} `json:"legacy"`
+}
+
+// ReconciliationReport lists how far the legacy system drifted from the CQRS side
+type ReconciliationReport struct {
+	AggregateType    string        `json:"aggregate_type"`
+	Checked          int           `json:"checked"`
+	Matched          int           `json:"matched"`
+	Mismatched       []Discrepancy `json:"mismatched,omitempty"`
+	MissingInLegacy  []string      `json:"missing_in_legacy,omitempty"`
+	MissingInCurrent []string      `json:"missing_in_current,omitempty"`
+	// Pending aggregates have quarantined mirrors, so differences are expected and not
+	// counted as mismatches until the quarantine drains
+	Pending     []string          `json:"pending,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"` // Aggregate ID -> load error
+	GeneratedAt time.Time         `json:"generated_at"`
+	Duration    time.Duration     `json:"duration"`
+}
+
+// Consistent reports whether every checked aggregate matched
+func (r *ReconciliationReport) Consistent() bool {
+	return r.Matched == r.Checked
+}
+
+// Reconciler compares aggregates between the CQRS side and the legacy system
+type Reconciler struct {
+	current    StateSource
+	legacy     LegacyClient
+	quarantine QuarantineStore
+}
+
+// NewReconciler creates a reconciler; quarantine may be nil
+func NewReconciler(current StateSource, legacy LegacyClient, quarantine QuarantineStore) *Reconciler {
+	return &Reconciler{current: current, legacy: legacy, quarantine: quarantine}
+}
+
+// Reconcile compares the given aggregates. Load errors are recorded per aggregate; only a
+// cancelled context or quarantine store failure aborts the run.
+func (r *Reconciler) Reconcile(ctx context.Context, config ReconcileConfig, aggregateIDs []string) (*ReconciliationReport, error) {
+	if config.AggregateType == "" {
+		return nil, cqrs.NewValidationError("aggregate type is required", nil)
+	}
+
+	started := time.Now()
+	report := &ReconciliationReport{AggregateType: config.AggregateType, GeneratedAt: started}
+
+	for _, aggregateID := range aggregateIDs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Checked++
+
+		if r.quarantine != nil {
+			held, err := r.quarantine.Holds(ctx, config.AggregateType, aggregateID)
+			if err != nil {
+				return report, err
+			}
+			if held {
+				report.Pending = append(report.Pending, aggregateID)
+				continue
+			}
+		}
+
+		current, err := r.current(ctx, config.AggregateType, aggregateID)
+		if err != nil {
+			report.recordError(aggregateID, err)
+			continue
+		}
+		legacy, err := r.legacy.Fetch(ctx, config.AggregateType, aggregateID)
+		if err != nil {
+			report.recordError(aggregateID, err)
+			continue
+		}
+
+		switch {
+		case current == nil && legacy == nil:
+			report.Matched++
+		case legacy == nil:
+			report.MissingInLegacy = append(report.MissingInLegacy, aggregateID)
+		case current == nil:
+			report.MissingInCurrent = append(report.MissingInCurrent, aggregateID)
+		default:
+			discrepancies := compareStates(aggregateID, config, current, toMap(legacy))
+			if len(discrepancies) == 0 {
+				report.Matched++
+			}
+			report.Mismatched = append(report.Mismatched, discrepancies...)
+		}
+	}
+
+	report.Duration = time.Since(started)
+	return report, nil
+}
+
+func (r *ReconciliationReport) recordError(aggregateID string, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]string)
+	}
+	r.Errors[aggregateID] = err.Error()
+}
+
+func compareStates(aggregateID string, config ReconcileConfig, current, legacy map[string]interface{}) []Discrepancy {
+	fields := config.Fields
+	if len(fields) == 0 {
+		for field := range current {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+	}
+
+	var discrepancies []Discrepancy
+	for _, field := range fields {
+		legacyField := field
+		if renamed, exists := config.LegacyFields[field]; exists {
+			legacyField = renamed
+		}
+		if !reflect.DeepEqual(current[field], legacy[legacyField]) {
+			discrepancies = append(discrepancies, Discrepancy{
+				AggregateID: aggregateID,
+				Field:       field,
+				Current:     current[field],
+				Legacy:      legacy[legacyField],
+			})
+		}
+	}
+	return discrepancies
+}
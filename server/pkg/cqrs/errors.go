@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common CQRS errors
@@ -14,6 +15,8 @@ var (
 	ErrInvalidAggregateType = errors.New("invalid aggregate type")
 	ErrInvalidVersion       = errors.New("invalid version")
 	ErrConcurrencyConflict  = errors.New("concurrency conflict")
+	ErrAggregateLocked      = errors.New("aggregate is locked")
+	ErrAggregateLockLost    = errors.New("aggregate lock lost")
 
 	// Command errors
 	ErrInvalidCommand          = errors.New("invalid command")
@@ -25,10 +28,14 @@ var (
 	ErrQueryHandlerNotFound  = errors.New("query handler not found")
 	ErrQueryValidationFailed = errors.New("query validation failed")
 
+	// Typed dispatch errors
+	ErrResultTypeMismatch = errors.New("result type mismatch")
+
 	// Event errors
 	ErrInvalidEvent          = errors.New("invalid event")
 	ErrEventHandlerNotFound  = errors.New("event handler not found")
 	ErrEventValidationFailed = errors.New("event validation failed")
+	ErrNoCompatibleHandler   = errors.New("no handler supports the event schema version")
 
 	// Snapshot errors
 	ErrSnapshotNotFound         = errors.New("snapshot not found")
@@ -49,19 +56,48 @@ var (
 	ErrEventBusNotFound = errors.New("event bus not found")
 	ErrPublishEvent     = errors.New("failed to publish event")
 	ErrSubscribeEvent   = errors.New("failed to subscribe to event")
+	ErrEventQueueFull   = errors.New("event queue is full")
 
 	// Serialization errors
 	ErrSerializationFailed   = errors.New("serialization failed")
 	ErrDeserializationFailed = errors.New("deserialization failed")
 	ErrUnsupportedFormat     = errors.New("unsupported serialization format")
+
+	// Permission errors
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// Availability errors
+	ErrMaintenanceInProgress = errors.New("maintenance in progress")
+	ErrCommandThrottled      = errors.New("command throttled")
+)
+
+// ErrorCategory groups error codes by how callers should react to them.
+// A category can be used directly as an errors.Is target:
+//
+//	if errors.Is(err, cqrs.CategoryConcurrency) { /* reload and retry */ }
+type ErrorCategory string
+
+const (
+	CategoryValidation     ErrorCategory = "validation"     // Bad input; retrying will not help
+	CategoryConcurrency    ErrorCategory = "concurrency"    // Optimistic concurrency conflict; reload and retry
+	CategoryNotFound       ErrorCategory = "not_found"      // Requested aggregate, snapshot or read model does not exist
+	CategoryPermission     ErrorCategory = "permission"     // Caller is not allowed to perform the operation
+	CategoryInfrastructure ErrorCategory = "infrastructure" // Storage, bus or serialization failure; may be transient
+	CategoryRateLimited    ErrorCategory = "rate_limited"   // Too many requests; retry after the "retry_after" delay (HTTP 429)
+	CategoryUnknown        ErrorCategory = "unknown"
 )
 
+func (c ErrorCategory) Error() string {
+	return string(c)
+}
+
 // CQRSError represents a CQRS-specific error with additional context
 type CQRSError struct {
-	Code    string
-	Message string
-	Cause   error
-	Context map[string]interface{}
+	Code     string
+	Message  string
+	Cause    error
+	Context  map[string]interface{}
+	Category ErrorCategory // Optional; derived from Code when empty
 }
 
 func (e *CQRSError) Error() string {
@@ -75,6 +111,25 @@ func (e *CQRSError) Unwrap() error {
 	return e.Cause
 }
 
+// GetCategory returns the explicit category or the one derived from the error code
+func (e *CQRSError) GetCategory() ErrorCategory {
+	if e.Category != "" {
+		return e.Category
+	}
+	return CategoryForCode(e.Code)
+}
+
+// Is supports errors.Is against an ErrorCategory or another CQRSError with the same code
+func (e *CQRSError) Is(target error) bool {
+	switch t := target.(type) {
+	case ErrorCategory:
+		return e.GetCategory() == t
+	case *CQRSError:
+		return t.Code != "" && t.Code == e.Code
+	}
+	return false
+}
+
 // NewCQRSError creates a new CQRSError
 func NewCQRSError(code, message string, cause error) *CQRSError {
 	return &CQRSError{
@@ -94,6 +149,53 @@ func (e *CQRSError) WithContext(key string, value interface{}) *CQRSError {
 	return e
 }
 
+// WithCategory overrides the category derived from the error code
+func (e *CQRSError) WithCategory(category ErrorCategory) *CQRSError {
+	e.Category = category
+	return e
+}
+
+// NewValidationError creates a validation category error
+func NewValidationError(message string, cause error) *CQRSError {
+	return NewCQRSError(ErrCodeValidationError.String(), message, cause)
+}
+
+// NewConcurrencyError creates a concurrency conflict error
+func NewConcurrencyError(message string, cause error) *CQRSError {
+	if cause == nil {
+		cause = ErrConcurrencyConflict
+	}
+	return NewCQRSError(ErrCodeConcurrencyConflict.String(), message, cause)
+}
+
+// NewNotFoundError creates a not found category error
+func NewNotFoundError(message string, cause error) *CQRSError {
+	return NewCQRSError(ErrCodeNotFoundError.String(), message, cause)
+}
+
+// NewPermissionError creates a permission denied error
+func NewPermissionError(message string, cause error) *CQRSError {
+	if cause == nil {
+		cause = ErrPermissionDenied
+	}
+	return NewCQRSError(ErrCodePermissionDenied.String(), message, cause)
+}
+
+// NewMaintenanceError creates a maintenance error; callers should retry once maintenance ends
+func NewMaintenanceError(message string) *CQRSError {
+	return NewCQRSError(ErrCodeMaintenanceInProgress.String(), message, ErrMaintenanceInProgress)
+}
+
+// NewThrottledError creates a throttling error; retryAfter is stored in the "retry_after" context entry
+func NewThrottledError(message string, retryAfter time.Duration) *CQRSError {
+	return NewCQRSError(ErrCodeCommandThrottled.String(), message, ErrCommandThrottled).WithContext("retry_after", retryAfter)
+}
+
+// NewInfrastructureError creates an infrastructure error for the given store/bus error code
+func NewInfrastructureError(code ErrorCode, message string, cause error) *CQRSError {
+	return NewCQRSError(code.String(), message, cause).WithCategory(CategoryInfrastructure)
+}
+
 // ErrorCode represents CQRS error codes
 type ErrorCode int
 
@@ -116,6 +218,9 @@ const (
 	ErrCodeReadModelNotFound
 	ErrCodeValidationError
 	ErrCodeNotFoundError
+	ErrCodePermissionDenied
+	ErrCodeMaintenanceInProgress
+	ErrCodeCommandThrottled
 )
 
 func (ec ErrorCode) String() string {
@@ -156,35 +261,133 @@ func (ec ErrorCode) String() string {
 		return "VALIDATION_ERROR"
 	case ErrCodeNotFoundError:
 		return "NOT_FOUND_ERROR"
+	case ErrCodePermissionDenied:
+		return "PERMISSION_DENIED"
+	case ErrCodeMaintenanceInProgress:
+		return "MAINTENANCE_IN_PROGRESS"
+	case ErrCodeCommandThrottled:
+		return "COMMAND_THROTTLED"
 	default:
 		return "UNKNOWN_ERROR"
 	}
 }
 
-// IsNotFoundError checks if an error is a "not found" type error
-func IsNotFoundError(err error) bool {
+// Category returns the category of the given code
+func (ec ErrorCode) Category() ErrorCategory {
+	switch ec {
+	case ErrCodeInvalidAggregate, ErrCodeCommandValidation, ErrCodeQueryValidation,
+		ErrCodeEventValidation, ErrCodeSnapshotValidationFailed, ErrCodeValidationError:
+		return CategoryValidation
+	case ErrCodeConcurrencyConflict:
+		return CategoryConcurrency
+	case ErrCodeAggregateNotFound, ErrCodeSnapshotNotFound, ErrCodeReadModelNotFound, ErrCodeNotFoundError:
+		return CategoryNotFound
+	case ErrCodePermissionDenied:
+		return CategoryPermission
+	case ErrCodeCommandThrottled:
+		return CategoryRateLimited
+	case ErrCodeSerializationError, ErrCodeRepositoryError, ErrCodeEventStoreError, ErrCodeEventBusError,
+		ErrCodeStateStoreError, ErrCodeSnapshotStoreError, ErrCodeReadStoreError, ErrCodeMaintenanceInProgress:
+		return CategoryInfrastructure
+	default:
+		return CategoryUnknown
+	}
+}
+
+// codeCategories maps error code strings to categories
+var codeCategories = func() map[string]ErrorCategory {
+	categories := make(map[string]ErrorCategory)
+	for code := ErrCodeAggregateNotFound; code <= ErrCodeCommandThrottled; code++ {
+		categories[code.String()] = code.Category()
+	}
+	return categories
+}()
+
+// CategoryForCode returns the category of an error code string
+func CategoryForCode(code string) ErrorCategory {
+	if category, ok := codeCategories[code]; ok {
+		return category
+	}
+	return CategoryUnknown
+}
+
+// categorySentinels maps plain sentinel errors to categories for errors not wrapped in CQRSError
+var categorySentinels = map[ErrorCategory][]error{
+	CategoryConcurrency: {ErrConcurrencyConflict},
+	CategoryNotFound:    {ErrAggregateNotFound, ErrSnapshotNotFound},
+	CategoryPermission:  {ErrPermissionDenied},
+	CategoryRateLimited: {ErrCommandThrottled},
+}
+
+// GetErrorCategory returns the category of the outermost categorized error in the chain
+func GetErrorCategory(err error) ErrorCategory {
 	if err == nil {
-		return false
+		return ""
 	}
 
-	// Check for CQRS error codes
-	if cqrsErr, ok := err.(*CQRSError); ok {
-		switch cqrsErr.Code {
-		case ErrCodeAggregateNotFound.String(),
-			ErrCodeSnapshotNotFound.String(),
-			ErrCodeReadModelNotFound.String():
-			return true
+	var categorized interface{ GetCategory() ErrorCategory }
+	if errors.As(err, &categorized) {
+		if category := categorized.GetCategory(); category != CategoryUnknown {
+			return category
 		}
 	}
 
-	// Check for standard errors
-	if errors.Is(err, ErrAggregateNotFound) {
-		return true
+	for _, category := range []ErrorCategory{CategoryConcurrency, CategoryNotFound, CategoryPermission, CategoryRateLimited} {
+		for _, sentinel := range categorySentinels[category] {
+			if errors.Is(err, sentinel) {
+				return category
+			}
+		}
 	}
+	return CategoryUnknown
+}
 
+// HasErrorCategory reports whether any error in the chain belongs to the category
+func HasErrorCategory(err error, category ErrorCategory) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, category) {
+		return true
+	}
+	for _, sentinel := range categorySentinels[category] {
+		if errors.Is(err, sentinel) {
+			return true
+		}
+	}
 	return false
 }
 
+// IsNotFoundError checks if an error is a "not found" type error
+func IsNotFoundError(err error) bool {
+	return HasErrorCategory(err, CategoryNotFound)
+}
+
+// IsValidationError checks if an error is a validation error
+func IsValidationError(err error) bool {
+	return HasErrorCategory(err, CategoryValidation)
+}
+
+// IsConcurrencyError checks if an error is an optimistic concurrency conflict
+func IsConcurrencyError(err error) bool {
+	return HasErrorCategory(err, CategoryConcurrency)
+}
+
+// IsPermissionError checks if an error is a permission error
+func IsPermissionError(err error) bool {
+	return HasErrorCategory(err, CategoryPermission)
+}
+
+// IsRateLimitedError checks if an error is a throttling rejection the caller may retry later
+func IsRateLimitedError(err error) bool {
+	return HasErrorCategory(err, CategoryRateLimited)
+}
+
+// IsInfrastructureError checks if an error is a storage, bus or serialization failure
+func IsInfrastructureError(err error) bool {
+	return HasErrorCategory(err, CategoryInfrastructure)
+}
+
 // Helper function for checksum calculation
 func calculateDataChecksum(aggregateID, aggregateType string, version int, data interface{}) string {
 	input := fmt.Sprintf("%s:%s:%d:%v", aggregateID, aggregateType, version, data)
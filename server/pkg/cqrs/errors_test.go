@@ -0,0 +1,77 @@
+package cqrs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCQRSError_CategoryFromCode(t *testing.T) {
+	testCases := []struct {
+		code     ErrorCode
+		expected ErrorCategory
+	}{
+		{ErrCodeCommandValidation, CategoryValidation},
+		{ErrCodeConcurrencyConflict, CategoryConcurrency},
+		{ErrCodeReadModelNotFound, CategoryNotFound},
+		{ErrCodePermissionDenied, CategoryPermission},
+		{ErrCodeEventStoreError, CategoryInfrastructure},
+		{ErrCodeMaintenanceInProgress, CategoryInfrastructure},
+		{ErrCodeCommandThrottled, CategoryRateLimited},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code.String(), func(t *testing.T) {
+			err := NewCQRSError(tc.code.String(), "test", nil)
+			assert.Equal(t, tc.expected, err.GetCategory())
+			assert.True(t, errors.Is(err, tc.expected))
+		})
+	}
+}
+
+func TestCQRSError_WithCategoryOverridesCode(t *testing.T) {
+	// Arrange
+	err := NewCQRSError(ErrCodeReadStoreError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
+
+	// Assert
+	assert.True(t, IsValidationError(err))
+	assert.False(t, IsInfrastructureError(err))
+	assert.Equal(t, ErrCodeReadStoreError.String(), err.Code)
+}
+
+func TestCQRSError_IsAs(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("handler failed: %w", NewConcurrencyError("version mismatch", nil))
+
+	// Act
+	var cqrsErr *CQRSError
+	found := errors.As(err, &cqrsErr)
+
+	// Assert
+	assert.True(t, found)
+	assert.True(t, IsConcurrencyError(err))
+	assert.True(t, errors.Is(err, ErrConcurrencyConflict))
+	assert.True(t, errors.Is(err, &CQRSError{Code: ErrCodeConcurrencyConflict.String()}))
+	assert.False(t, errors.Is(err, &CQRSError{Code: ErrCodeEventStoreError.String()}))
+}
+
+func TestErrorCategoryHelpers(t *testing.T) {
+	assert.True(t, IsNotFoundError(NewNotFoundError("missing", nil)))
+	assert.True(t, IsNotFoundError(fmt.Errorf("wrapped: %w", ErrAggregateNotFound)))
+	assert.True(t, IsPermissionError(NewPermissionError("denied", nil)))
+	assert.True(t, IsInfrastructureError(NewInfrastructureError(ErrCodeEventBusError, "publish failed", nil)))
+	assert.True(t, IsValidationError(NewValidationError("bad input", nil)))
+	assert.True(t, IsRateLimitedError(NewThrottledError("slow down", time.Second)))
+	assert.True(t, IsRateLimitedError(fmt.Errorf("wrapped: %w", ErrCommandThrottled)))
+	assert.False(t, IsPermissionError(NewThrottledError("slow down", time.Second)))
+	assert.False(t, IsNotFoundError(nil))
+	assert.Equal(t, CategoryUnknown, GetErrorCategory(errors.New("plain")))
+
+	// An infrastructure error wrapping a not-found sentinel reports its own category first
+	wrapped := NewInfrastructureError(ErrCodeRepositoryError, "load failed", ErrAggregateNotFound)
+	assert.Equal(t, CategoryInfrastructure, GetErrorCategory(wrapped))
+	assert.True(t, IsNotFoundError(wrapped))
+}
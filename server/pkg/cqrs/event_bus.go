@@ -16,6 +16,7 @@ const (
 	ProcessManagerHandler
 	SagaHandler
 	NotificationHandler
+	AnalyticsHandler
 )
 
 func (ht HandlerType) String() string {
@@ -28,6 +29,8 @@ func (ht HandlerType) String() string {
 		return "saga"
 	case NotificationHandler:
 		return "notification"
+	case AnalyticsHandler:
+		return "analytics"
 	default:
 		return "unknown"
 	}
@@ -81,6 +84,13 @@ type EventBusMetrics struct {
 	ActiveSubscribers int
 	AverageLatency    time.Duration
 	LastEventTime     time.Time
+
+	// Async queue metrics
+	QueueDepth     int   // Events currently waiting
+	QueueCapacity  int   // Maximum queued events
+	MaxQueueDepth  int   // Highest depth observed
+	DroppedEvents  int64 // Events discarded by OverflowDropOldest
+	RejectedEvents int64 // Events refused by OverflowError or a cancelled blocking publish
 }
 
 // StreamPosition represents position in an event stream
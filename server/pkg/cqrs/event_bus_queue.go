@@ -0,0 +1,129 @@
+package cqrs
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what happens when an async publish finds the event queue full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for space until the publisher's context is done
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event to make room
+	OverflowDropOldest
+	// OverflowError rejects the event with ErrEventQueueFull
+	OverflowError
+)
+
+func (op OverflowPolicy) String() string {
+	switch op {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// EventQueueConfig bounds the queue used for async publishing
+type EventQueueConfig struct {
+	Capacity int            // Maximum queued events
+	Workers  int            // Goroutines draining the queue; 1 keeps publish order
+	Overflow OverflowPolicy // Behavior when the queue is full
+}
+
+// DefaultEventQueueConfig returns a single-worker queue of 1024 events that blocks when full
+func DefaultEventQueueConfig() EventQueueConfig {
+	return EventQueueConfig{
+		Capacity: 1024,
+		Workers:  1,
+		Overflow: OverflowBlock,
+	}
+}
+
+func (c EventQueueConfig) normalized() EventQueueConfig {
+	defaults := DefaultEventQueueConfig()
+	if c.Capacity <= 0 {
+		c.Capacity = defaults.Capacity
+	}
+	if c.Workers <= 0 {
+		c.Workers = defaults.Workers
+	}
+	return c
+}
+
+// queuedEvent is an event waiting for an async worker
+type queuedEvent struct {
+	ctx   context.Context
+	event EventMessage
+}
+
+// eventQueue is a bounded FIFO with an overflow policy
+type eventQueue struct {
+	items    chan queuedEvent
+	policy   OverflowPolicy
+	dropped  atomic.Int64
+	rejected atomic.Int64
+	maxDepth atomic.Int64
+}
+
+func newEventQueue(config EventQueueConfig) *eventQueue {
+	return &eventQueue{
+		items:  make(chan queuedEvent, config.Capacity),
+		policy: config.Overflow,
+	}
+}
+
+// push enqueues an event according to the overflow policy
+func (q *eventQueue) push(ctx context.Context, item queuedEvent) error {
+	select {
+	case q.items <- item:
+		q.recordDepth()
+		return nil
+	default:
+	}
+
+	switch q.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.items <- item:
+				q.recordDepth()
+				return nil
+			default:
+			}
+			select {
+			case <-q.items:
+				q.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowError:
+		q.rejected.Add(1)
+		return NewCQRSError(ErrCodeEventBusError.String(), "event queue is full", ErrEventQueueFull)
+	default:
+		select {
+		case q.items <- item:
+			q.recordDepth()
+			return nil
+		case <-ctx.Done():
+			q.rejected.Add(1)
+			return NewCQRSError(ErrCodeEventBusError.String(), "timed out waiting for event queue space", ctx.Err())
+		}
+	}
+}
+
+func (q *eventQueue) recordDepth() {
+	depth := int64(len(q.items))
+	for {
+		current := q.maxDepth.Load()
+		if depth <= current || q.maxDepth.CompareAndSwap(current, depth) {
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedHandler gate가 열릴 때까지 처리를 막는 느린 핸들러
+type gatedHandler struct {
+	*BaseEventHandler
+	gate    chan struct{}
+	mu      sync.Mutex
+	handled []string
+}
+
+func newGatedHandler() *gatedHandler {
+	return &gatedHandler{
+		BaseEventHandler: NewBaseEventHandler("gated", ProjectionHandler, []string{"BulkImported"}),
+		gate:             make(chan struct{}),
+	}
+}
+
+func (h *gatedHandler) Handle(ctx context.Context, event EventMessage) error {
+	<-h.gate
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, event.EventID())
+	return nil
+}
+
+func (h *gatedHandler) handledIDs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.handled...)
+}
+
+func newBulkEvent() EventMessage {
+	return NewBaseEventMessage("BulkImported")
+}
+
+// fillQueue 첫 이벤트가 워커에서 막힌 뒤 큐를 가득 채운다
+func fillQueue(t *testing.T, bus *InMemoryEventBus, capacity int) []EventMessage {
+	t.Helper()
+	ctx := context.Background()
+	async := EventPublishOptions{Async: true}
+
+	events := []EventMessage{newBulkEvent()}
+	require.NoError(t, bus.Publish(ctx, events[0], async))
+	require.Eventually(t, func() bool { return bus.GetMetrics().QueueDepth == 0 }, time.Second, time.Millisecond)
+
+	for i := 0; i < capacity; i++ {
+		event := newBulkEvent()
+		require.NoError(t, bus.Publish(ctx, event, async))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventBusQueue_ErrorPolicyRejectsWhenFull(t *testing.T) {
+	// Arrange
+	bus := NewInMemoryEventBusWithQueue(EventQueueConfig{Capacity: 2, Workers: 1, Overflow: OverflowError})
+	handler := newGatedHandler()
+	bus.Subscribe("BulkImported", handler)
+	fillQueue(t, bus, 2)
+
+	// Act
+	err := bus.Publish(context.Background(), newBulkEvent(), EventPublishOptions{Async: true})
+
+	// Assert
+	assert.True(t, errors.Is(err, ErrEventQueueFull))
+	metrics := bus.GetMetrics()
+	assert.Equal(t, 2, metrics.QueueDepth)
+	assert.Equal(t, 2, metrics.QueueCapacity)
+	assert.Equal(t, int64(1), metrics.RejectedEvents)
+
+	close(handler.gate)
+	require.Eventually(t, func() bool { return len(handler.handledIDs()) == 3 }, time.Second, time.Millisecond)
+}
+
+func TestEventBusQueue_DropOldestKeepsNewestEvents(t *testing.T) {
+	// Arrange
+	bus := NewInMemoryEventBusWithQueue(EventQueueConfig{Capacity: 2, Workers: 1, Overflow: OverflowDropOldest})
+	handler := newGatedHandler()
+	bus.Subscribe("BulkImported", handler)
+	events := fillQueue(t, bus, 2)
+	newest := newBulkEvent()
+
+	// Act
+	err := bus.Publish(context.Background(), newest, EventPublishOptions{Async: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), bus.GetMetrics().DroppedEvents)
+
+	close(handler.gate)
+	require.Eventually(t, func() bool { return len(handler.handledIDs()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{events[0].EventID(), events[2].EventID(), newest.EventID()}, handler.handledIDs())
+}
+
+func TestEventBusQueue_BlockPolicyWaitsForSpace(t *testing.T) {
+	// Arrange
+	bus := NewInMemoryEventBusWithQueue(EventQueueConfig{Capacity: 1, Workers: 1, Overflow: OverflowBlock})
+	handler := newGatedHandler()
+	bus.Subscribe("BulkImported", handler)
+	fillQueue(t, bus, 1)
+
+	// Act
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	timedOut := bus.Publish(timeoutCtx, newBulkEvent(), EventPublishOptions{Async: true})
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(context.Background(), newBulkEvent(), EventPublishOptions{Async: true})
+	}()
+	close(handler.gate)
+
+	// Assert
+	assert.Error(t, timedOut)
+	assert.Equal(t, int64(1), bus.GetMetrics().RejectedEvents)
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("blocked publish was not released")
+	}
+}
+
+func TestEventBusQueue_StopDrainsQueue(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := NewInMemoryEventBusWithQueue(EventQueueConfig{Capacity: 8, Workers: 2})
+	handler := newGatedHandler()
+	close(handler.gate)
+	bus.Subscribe("BulkImported", handler)
+	require.NoError(t, bus.Start(ctx))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, bus.Publish(ctx, newBulkEvent(), EventPublishOptions{Async: true}))
+	}
+
+	// Act
+	err := bus.Stop(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, handler.handledIDs(), 5)
+	assert.Equal(t, int64(5), bus.GetMetrics().ProcessedEvents)
+	assert.Equal(t, 0, bus.GetMetrics().QueueDepth)
+}
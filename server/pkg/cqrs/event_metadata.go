@@ -0,0 +1,168 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// Standard event metadata keys. Producers set them through EventMetadata so consumers
+// (projections, analytics, audit) can rely on one spelling and type for each field.
+const (
+	MetadataUserID        = "user_id"
+	MetadataSessionID     = "session_id"
+	MetadataDeviceID      = "device_id"
+	MetadataPlatform      = "platform"
+	MetadataAppVersion    = "app_version"
+	MetadataServerVersion = "server_version"
+	MetadataRegion        = "region"
+)
+
+// EventMetadata is the standard metadata envelope carried by every published event
+type EventMetadata struct {
+	UserID        string `json:"user_id,omitempty"`
+	SessionID     string `json:"session_id,omitempty"`
+	DeviceID      string `json:"device_id,omitempty"`
+	Platform      string `json:"platform,omitempty"`
+	AppVersion    string `json:"app_version,omitempty"`
+	ServerVersion string `json:"server_version,omitempty"`
+	Region        string `json:"region,omitempty"`
+}
+
+// fields lists the envelope as key/value pairs in a fixed order
+func (m EventMetadata) fields() [][2]string {
+	return [][2]string{
+		{MetadataUserID, m.UserID},
+		{MetadataSessionID, m.SessionID},
+		{MetadataDeviceID, m.DeviceID},
+		{MetadataPlatform, m.Platform},
+		{MetadataAppVersion, m.AppVersion},
+		{MetadataServerVersion, m.ServerVersion},
+		{MetadataRegion, m.Region},
+	}
+}
+
+// IsZero reports whether no field is set
+func (m EventMetadata) IsZero() bool {
+	return m == EventMetadata{}
+}
+
+// Merge returns m with its empty fields filled from fallback
+func (m EventMetadata) Merge(fallback EventMetadata) EventMetadata {
+	fill := func(value *string, other string) {
+		if *value == "" {
+			*value = other
+		}
+	}
+	fill(&m.UserID, fallback.UserID)
+	fill(&m.SessionID, fallback.SessionID)
+	fill(&m.DeviceID, fallback.DeviceID)
+	fill(&m.Platform, fallback.Platform)
+	fill(&m.AppVersion, fallback.AppVersion)
+	fill(&m.ServerVersion, fallback.ServerVersion)
+	fill(&m.Region, fallback.Region)
+	return m
+}
+
+// ToMap returns the set fields keyed by the standard metadata keys
+func (m EventMetadata) ToMap() map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, field := range m.fields() {
+		if field[1] != "" {
+			result[field[0]] = field[1]
+		}
+	}
+	return result
+}
+
+// EventMetadataOf reads the standard envelope from an event's metadata.
+// Non-string values written by older producers are formatted with %v.
+func EventMetadataOf(event EventMessage) EventMetadata {
+	metadata := event.Metadata()
+	read := func(key string) string {
+		value, exists := metadata[key]
+		if !exists || value == nil {
+			return ""
+		}
+		if text, ok := value.(string); ok {
+			return text
+		}
+		return fmt.Sprintf("%v", value)
+	}
+	return EventMetadata{
+		UserID:        read(MetadataUserID),
+		SessionID:     read(MetadataSessionID),
+		DeviceID:      read(MetadataDeviceID),
+		Platform:      read(MetadataPlatform),
+		AppVersion:    read(MetadataAppVersion),
+		ServerVersion: read(MetadataServerVersion),
+		Region:        read(MetadataRegion),
+	}
+}
+
+// ApplyEventMetadata writes the set fields of m into the event's metadata.
+// Keys the event already carries are kept, so explicit producer values win.
+func ApplyEventMetadata(event EventMessage, m EventMetadata) {
+	adder, ok := event.(interface{ AddMetadata(key string, value interface{}) })
+	metadata := event.Metadata()
+	for _, field := range m.fields() {
+		if field[1] == "" {
+			continue
+		}
+		if existing, exists := metadata[field[0]]; exists && existing != nil && existing != "" {
+			continue
+		}
+		switch {
+		case ok:
+			adder.AddMetadata(field[0], field[1])
+		case metadata != nil:
+			metadata[field[0]] = field[1]
+		}
+	}
+}
+
+type eventMetadataKey struct{}
+
+// WithEventMetadata attaches metadata to a context for MetadataEnrichingEventBus.
+// Fields already attached by an outer call are kept unless m sets them.
+func WithEventMetadata(ctx context.Context, m EventMetadata) context.Context {
+	return context.WithValue(ctx, eventMetadataKey{}, m.Merge(EventMetadataFromContext(ctx)))
+}
+
+// EventMetadataFromContext returns metadata attached with WithEventMetadata
+func EventMetadataFromContext(ctx context.Context) EventMetadata {
+	m, _ := ctx.Value(eventMetadataKey{}).(EventMetadata)
+	return m
+}
+
+// MetadataEnrichingEventBus fills the standard metadata envelope of every published event.
+// Precedence is: values already on the event, then the publishing context, then Defaults
+// (typically the server version and region of the running process).
+type MetadataEnrichingEventBus struct {
+	EventBus
+	defaults EventMetadata
+}
+
+// NewMetadataEnrichingEventBus wraps an event bus with metadata enrichment
+func NewMetadataEnrichingEventBus(inner EventBus, defaults EventMetadata) *MetadataEnrichingEventBus {
+	return &MetadataEnrichingEventBus{EventBus: inner, defaults: defaults}
+}
+
+// Enrich applies the context and default metadata to the event
+func (b *MetadataEnrichingEventBus) Enrich(ctx context.Context, event EventMessage) {
+	if event == nil {
+		return
+	}
+	ApplyEventMetadata(event, EventMetadataFromContext(ctx).Merge(b.defaults))
+}
+
+func (b *MetadataEnrichingEventBus) Publish(ctx context.Context, event EventMessage, options ...EventPublishOptions) error {
+	b.Enrich(ctx, event)
+	return b.EventBus.Publish(ctx, event, options...)
+}
+
+func (b *MetadataEnrichingEventBus) PublishBatch(ctx context.Context, events []EventMessage, options ...EventPublishOptions) error {
+	for _, event := range events {
+		b.Enrich(ctx, event)
+	}
+	return b.EventBus.PublishBatch(ctx, events, options...)
+}
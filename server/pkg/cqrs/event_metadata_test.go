@@ -0,0 +1,72 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublishBus records published events; other EventBus methods are not used
+type recordingPublishBus struct {
+	EventBus
+	published []EventMessage
+}
+
+func (b *recordingPublishBus) Publish(ctx context.Context, event EventMessage, options ...EventPublishOptions) error {
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *recordingPublishBus) PublishBatch(ctx context.Context, events []EventMessage, options ...EventPublishOptions) error {
+	b.published = append(b.published, events...)
+	return nil
+}
+
+func TestMetadataEnrichingEventBus_AppliesPrecedence(t *testing.T) {
+	// Arrange
+	inner := &recordingPublishBus{}
+	bus := NewMetadataEnrichingEventBus(inner, EventMetadata{ServerVersion: "1.4.0", Region: "kr"})
+	ctx := WithEventMetadata(context.Background(), EventMetadata{UserID: "user-1", SessionID: "s-1", Region: "jp"})
+	ctx = WithEventMetadata(ctx, EventMetadata{DeviceID: "device-9"})
+
+	explicit := NewBaseEventMessage("GoldEarned")
+	explicit.AddMetadata(MetadataUserID, "admin-7")
+	plain := NewBaseEventMessage("GoldSpent")
+
+	// Act
+	require.NoError(t, bus.Publish(ctx, explicit))
+	require.NoError(t, bus.PublishBatch(ctx, []EventMessage{plain}))
+
+	// Assert
+	require.Len(t, inner.published, 2)
+	assert.Equal(t, EventMetadata{
+		UserID:        "admin-7", // Set by the producer
+		SessionID:     "s-1",
+		DeviceID:      "device-9", // Nested WithEventMetadata keeps outer fields
+		ServerVersion: "1.4.0",
+		Region:        "jp", // Context wins over defaults
+	}, EventMetadataOf(inner.published[0]))
+	assert.Equal(t, "user-1", EventMetadataOf(inner.published[1]).UserID)
+}
+
+func TestEventMetadataOf_NormalisesLegacyValues(t *testing.T) {
+	// Arrange
+	event := NewBaseEventMessage("PlayerJoined")
+	event.AddMetadata(MetadataUserID, 42)
+	event.AddMetadata(MetadataRegion, nil)
+	event.AddMetadata("custom", "kept")
+
+	// Act
+	metadata := EventMetadataOf(event)
+	ApplyEventMetadata(event, EventMetadata{Region: "us"})
+
+	// Assert
+	assert.Equal(t, "42", metadata.UserID)
+	assert.Empty(t, metadata.Region)
+	assert.Equal(t, "us", event.Metadata()[MetadataRegion]) // nil counts as unset
+	assert.Equal(t, "kept", event.Metadata()["custom"])
+	assert.Equal(t, map[string]interface{}{MetadataUserID: "42"}, metadata.ToMap())
+	assert.True(t, EventMetadataFromContext(context.Background()).IsZero())
+}
@@ -0,0 +1,396 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// MetadataSchemaVersion is the metadata key carrying an event's payload schema version.
+// It is independent of Version(), which is the aggregate version. Events without it are
+// schema version 1.
+const MetadataSchemaVersion = "schema_version"
+
+// DefaultMaxParkedEvents bounds the events a VersionNegotiatingEventBus holds for a group
+// while no member supports their schema version
+const DefaultMaxParkedEvents = 1000
+
+// EventSchemaVersion returns the event's schema version (1 when unset or invalid).
+// Numbers decoded from JSON and numeric strings are accepted.
+func EventSchemaVersion(event EventMessage) int {
+	version := 0
+	switch value := event.Metadata()[MetadataSchemaVersion].(type) {
+	case int:
+		version = value
+	case int64:
+		version = int(value)
+	case float64:
+		version = int(value)
+	case string:
+		version, _ = strconv.Atoi(value)
+	}
+	if version < 1 {
+		return 1
+	}
+	return version
+}
+
+// SetEventSchemaVersion stamps the schema version into the event's metadata
+func SetEventSchemaVersion(event EventMessage, version int) {
+	adder, ok := event.(interface {
+		AddMetadata(key string, value interface{})
+	})
+	if ok {
+		adder.AddMetadata(MetadataSchemaVersion, version)
+		return
+	}
+	if metadata := event.Metadata(); metadata != nil {
+		metadata[MetadataSchemaVersion] = version
+	}
+}
+
+// SchemaVersionedHandler is implemented by handlers that only understand some schema
+// versions of the events they handle. Handlers without it are treated as supporting
+// every version.
+type SchemaVersionedHandler interface {
+	EventHandler
+	// SupportedSchemaVersions lists the schema versions the handler can process for the
+	// event type; an empty result means every version
+	SupportedSchemaVersions(eventType string) []int
+}
+
+// versionedEventHandler adds advertised versions to an existing handler
+type versionedEventHandler struct {
+	EventHandler
+	versions map[string][]int
+}
+
+// NewVersionedEventHandler wraps a handler so it advertises the schema versions it
+// supports per event type. Event types missing from versions accept every version.
+func NewVersionedEventHandler(handler EventHandler, versions map[string][]int) SchemaVersionedHandler {
+	return &versionedEventHandler{EventHandler: handler, versions: versions}
+}
+
+func (h *versionedEventHandler) SupportedSchemaVersions(eventType string) []int {
+	return h.versions[eventType]
+}
+
+// SupportsSchemaVersion reports whether the handler advertises the schema version for the event type
+func SupportsSchemaVersion(handler EventHandler, eventType string, version int) bool {
+	versioned, ok := handler.(SchemaVersionedHandler)
+	if !ok {
+		return true
+	}
+	supported := versioned.SupportedSchemaVersions(eventType)
+	if len(supported) == 0 {
+		return true
+	}
+	for _, candidate := range supported {
+		if candidate == version {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiationMember is one instance of a handler group
+type negotiationMember struct {
+	id      SubscriptionID
+	handler EventHandler
+}
+
+// negotiationGroup collects the instances of one logical handler (same handler name and
+// event type); the inner bus delivers each event once to the group, which picks a member
+type negotiationGroup struct {
+	bus       *VersionNegotiatingEventBus
+	key       string
+	eventType string // Empty for SubscribeAll groups
+	name      string
+	innerID   SubscriptionID
+	members   []*negotiationMember
+	parked    []EventMessage
+}
+
+// VersionNegotiatingEventBus lets old and new instances of a consumer run side by side
+// during a rolling upgrade. Handlers subscribed under the same name form a group; each
+// event goes to the most recently subscribed member that supports its schema version, so
+// new instances take over the versions only they understand while old instances keep
+// draining the rest. Events no member supports yet are parked (up to MaxParked per group)
+// and delivered as soon as a compatible instance subscribes.
+//
+// Publishing, lifecycle and metrics pass through to the wrapped bus.
+type VersionNegotiatingEventBus struct {
+	EventBus
+
+	// MaxParked caps parked events per group; beyond it Handle returns ErrNoCompatibleHandler
+	// so the wrapped bus can retry or dead-letter the event
+	MaxParked int
+
+	mutex         sync.Mutex
+	groups        map[string]*negotiationGroup
+	subscriptions map[SubscriptionID]*negotiationGroup
+	nextSubID     int64
+}
+
+// NewVersionNegotiatingEventBus wraps an event bus with schema version negotiation
+func NewVersionNegotiatingEventBus(inner EventBus) *VersionNegotiatingEventBus {
+	return &VersionNegotiatingEventBus{
+		EventBus:      inner,
+		MaxParked:     DefaultMaxParkedEvents,
+		groups:        make(map[string]*negotiationGroup),
+		subscriptions: make(map[SubscriptionID]*negotiationGroup),
+	}
+}
+
+func (b *VersionNegotiatingEventBus) Subscribe(eventType string, handler EventHandler) (SubscriptionID, error) {
+	if eventType == "" {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "event type cannot be empty", nil)
+	}
+	return b.subscribe(eventType, handler)
+}
+
+func (b *VersionNegotiatingEventBus) SubscribeAll(handler EventHandler) (SubscriptionID, error) {
+	return b.subscribe("", handler)
+}
+
+func (b *VersionNegotiatingEventBus) subscribe(eventType string, handler EventHandler) (SubscriptionID, error) {
+	if handler == nil {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "handler cannot be nil", nil)
+	}
+
+	b.mutex.Lock()
+	key := eventType + "\x00" + handler.GetHandlerName()
+	group, exists := b.groups[key]
+	if !exists {
+		group = &negotiationGroup{bus: b, key: key, eventType: eventType, name: handler.GetHandlerName()}
+		innerID, err := subscribeOn(b.EventBus, eventType, group)
+		if err != nil {
+			b.mutex.Unlock()
+			return "", err
+		}
+		group.innerID = innerID
+		b.groups[key] = group
+	}
+
+	b.nextSubID++
+	id := SubscriptionID(fmt.Sprintf("versioned_sub_%d", b.nextSubID))
+	member := &negotiationMember{id: id, handler: handler}
+	group.members = append(group.members, member)
+	b.subscriptions[id] = group
+
+	// Hand the parked events the new instance understands over to it
+	var ready []EventMessage
+	remaining := group.parked[:0]
+	for _, event := range group.parked {
+		if SupportsSchemaVersion(handler, event.EventType(), EventSchemaVersion(event)) {
+			ready = append(ready, event)
+		} else {
+			remaining = append(remaining, event)
+		}
+	}
+	group.parked = remaining
+	b.mutex.Unlock()
+
+	b.deliverParked(context.Background(), group, member, ready)
+	return id, nil
+}
+
+// deliverParked hands parked events to a member, parking again the ones it fails on
+func (b *VersionNegotiatingEventBus) deliverParked(ctx context.Context, group *negotiationGroup, member *negotiationMember, events []EventMessage) {
+	var failed []EventMessage
+	for _, event := range events {
+		if err := member.handler.Handle(ctx, event); err != nil {
+			failed = append(failed, event)
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	group.parked = append(failed, group.parked...)
+}
+
+func (b *VersionNegotiatingEventBus) Unsubscribe(subscriptionID SubscriptionID) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	group, exists := b.subscriptions[subscriptionID]
+	if !exists {
+		return NewNotFoundError(fmt.Sprintf("subscription not found: %s", subscriptionID), nil)
+	}
+	for i, member := range group.members {
+		if member.id == subscriptionID {
+			group.members = append(group.members[:i], group.members[i+1:]...)
+			break
+		}
+	}
+	delete(b.subscriptions, subscriptionID)
+
+	// The last instance leaving drops the group together with its parked events
+	if len(group.members) == 0 {
+		if err := b.EventBus.Unsubscribe(group.innerID); err != nil {
+			return err
+		}
+		delete(b.groups, group.key)
+	}
+	return nil
+}
+
+// RedeliverParked retries every parked event against the current members and returns
+// how many were delivered
+func (b *VersionNegotiatingEventBus) RedeliverParked(ctx context.Context) int {
+	b.mutex.Lock()
+	groups := make([]*negotiationGroup, 0, len(b.groups))
+	for _, group := range b.groups {
+		groups = append(groups, group)
+	}
+	b.mutex.Unlock()
+
+	delivered := 0
+	for _, group := range groups {
+		b.mutex.Lock()
+		parked := group.parked
+		group.parked = nil
+		b.mutex.Unlock()
+
+		var failed []EventMessage
+		for _, event := range parked {
+			b.mutex.Lock()
+			member := b.pick(group, event)
+			b.mutex.Unlock()
+			if member == nil || member.handler.Handle(ctx, event) != nil {
+				failed = append(failed, event)
+				continue
+			}
+			delivered++
+		}
+		if len(failed) > 0 {
+			b.mutex.Lock()
+			group.parked = append(failed, group.parked...)
+			b.mutex.Unlock()
+		}
+	}
+	return delivered
+}
+
+// ParkedEvents returns the number of events waiting for a compatible handler
+func (b *VersionNegotiatingEventBus) ParkedEvents() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	total := 0
+	for _, group := range b.groups {
+		total += len(group.parked)
+	}
+	return total
+}
+
+// NegotiatedSchemaVersion returns the highest schema version, up to latest, that every
+// subscribed group can route to one of its members. Producers publish this version until
+// the last old instance has been replaced, then switch to latest.
+func (b *VersionNegotiatingEventBus) NegotiatedSchemaVersion(eventType string, latest int) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	negotiated := latest
+	for _, group := range b.groups {
+		if group.eventType != "" && group.eventType != eventType {
+			continue
+		}
+		best := 0
+		for _, member := range group.members {
+			for version := negotiated; version > best; version-- {
+				if SupportsSchemaVersion(member.handler, eventType, version) {
+					best = version
+					break
+				}
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		negotiated = best
+	}
+	return negotiated
+}
+
+// SupportedVersions lists the schema versions advertised for an event type per handler
+// name, for operators checking the progress of an upgrade. Handlers that accept every
+// version are omitted.
+func (b *VersionNegotiatingEventBus) SupportedVersions(eventType string) map[string][]int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	result := make(map[string][]int)
+	for _, group := range b.groups {
+		if group.eventType != "" && group.eventType != eventType {
+			continue
+		}
+		seen := make(map[int]bool)
+		for _, member := range group.members {
+			versioned, ok := member.handler.(SchemaVersionedHandler)
+			if !ok {
+				continue
+			}
+			for _, version := range versioned.SupportedSchemaVersions(eventType) {
+				if !seen[version] {
+					seen[version] = true
+					result[group.name] = append(result[group.name], version)
+				}
+			}
+		}
+		sort.Ints(result[group.name])
+	}
+	return result
+}
+
+// pick returns the most recently subscribed member supporting the event's schema version
+func (b *VersionNegotiatingEventBus) pick(group *negotiationGroup, event EventMessage) *negotiationMember {
+	version := EventSchemaVersion(event)
+	for i := len(group.members) - 1; i >= 0; i-- {
+		if SupportsSchemaVersion(group.members[i].handler, event.EventType(), version) {
+			return group.members[i]
+		}
+	}
+	return nil
+}
+
+// EventHandler implementation: the wrapped bus delivers to the group
+
+func (g *negotiationGroup) Handle(ctx context.Context, event EventMessage) error {
+	g.bus.mutex.Lock()
+	member := g.bus.pick(g, event)
+	if member == nil {
+		if len(g.parked) >= g.bus.MaxParked {
+			g.bus.mutex.Unlock()
+			return NewCQRSError(ErrCodeEventBusError.String(),
+				fmt.Sprintf("no %s instance supports schema version %d of %s", g.name, EventSchemaVersion(event), event.EventType()),
+				ErrNoCompatibleHandler)
+		}
+		g.parked = append(g.parked, event)
+		g.bus.mutex.Unlock()
+		return nil
+	}
+	g.bus.mutex.Unlock()
+	return member.handler.Handle(ctx, event)
+}
+
+func (g *negotiationGroup) CanHandle(eventType string) bool {
+	return g.eventType == "" || g.eventType == eventType
+}
+
+func (g *negotiationGroup) GetHandlerName() string {
+	return g.name
+}
+
+func (g *negotiationGroup) GetHandlerType() HandlerType {
+	g.bus.mutex.Lock()
+	defer g.bus.mutex.Unlock()
+	if len(g.members) == 0 {
+		return ProjectionHandler
+	}
+	return g.members[0].handler.GetHandlerType()
+}
@@ -0,0 +1,112 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchemaEvent(version int) EventMessage {
+	event := NewBaseEventMessage("GuildCreated")
+	SetEventSchemaVersion(event, version)
+	return event
+}
+
+func TestEventSchemaVersion(t *testing.T) {
+	event := NewBaseEventMessage("GuildCreated")
+	assert.Equal(t, 1, EventSchemaVersion(event)) // 기본값
+
+	event.AddMetadata(MetadataSchemaVersion, float64(3)) // JSON에서 읽은 값
+	assert.Equal(t, 3, EventSchemaVersion(event))
+
+	event.AddMetadata(MetadataSchemaVersion, "2")
+	assert.Equal(t, 2, EventSchemaVersion(event))
+}
+
+func TestVersionNegotiatingEventBus_RollingUpgrade(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := NewVersionNegotiatingEventBus(NewInMemoryEventBus())
+	require.NoError(t, bus.Start(ctx))
+	oldHandler := NewTestEventHandler("guild_projection", []string{"GuildCreated"})
+	newHandler := NewTestEventHandler("guild_projection", []string{"GuildCreated"})
+	oldID, err := bus.Subscribe("GuildCreated", NewVersionedEventHandler(oldHandler, map[string][]int{"GuildCreated": {1}}))
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, bus.Publish(ctx, newSchemaEvent(1)))
+	require.NoError(t, bus.Publish(ctx, newSchemaEvent(2))) // 아직 v2를 아는 인스턴스가 없음
+	parkedBeforeUpgrade := bus.ParkedEvents()
+	negotiatedBeforeUpgrade := bus.NegotiatedSchemaVersion("GuildCreated", 2)
+	_, err = bus.Subscribe("GuildCreated", NewVersionedEventHandler(newHandler, map[string][]int{"GuildCreated": {1, 2}}))
+	require.NoError(t, err)
+	negotiatedAfterUpgrade := bus.NegotiatedSchemaVersion("GuildCreated", 2)
+	require.NoError(t, bus.Publish(ctx, newSchemaEvent(1)))
+	require.NoError(t, bus.Unsubscribe(oldID))
+
+	// Assert
+	assert.Equal(t, 1, parkedBeforeUpgrade)
+	assert.Equal(t, 1, negotiatedBeforeUpgrade)
+	assert.Equal(t, 2, negotiatedAfterUpgrade)
+	assert.Equal(t, 0, bus.ParkedEvents())
+	assert.Equal(t, 1, oldHandler.GetHandledEventCount())
+	require.Equal(t, 2, newHandler.GetHandledEventCount()) // 보류된 v2 + 새로 발행된 v1
+	assert.Equal(t, 2, EventSchemaVersion(newHandler.HandledEvents[0]))
+	assert.Equal(t, map[string][]int{"guild_projection": {1, 2}}, bus.SupportedVersions("GuildCreated"))
+}
+
+func TestVersionNegotiatingEventBus_ParkingLimit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := NewVersionNegotiatingEventBus(NewInMemoryEventBus())
+	bus.MaxParked = 1
+	require.NoError(t, bus.Start(ctx))
+	handler := NewTestEventHandler("guild_projection", []string{"GuildCreated"})
+	_, err := bus.Subscribe("GuildCreated", NewVersionedEventHandler(handler, map[string][]int{"GuildCreated": {1}}))
+	require.NoError(t, err)
+
+	// Act
+	firstErr := bus.Publish(ctx, newSchemaEvent(2))
+	overflowErr := bus.Publish(ctx, newSchemaEvent(2))
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.True(t, errors.Is(overflowErr, ErrNoCompatibleHandler))
+	assert.Equal(t, 1, bus.ParkedEvents())
+	assert.Equal(t, 0, handler.GetHandledEventCount())
+}
+
+func TestVersionNegotiatingEventBus_RedeliverParked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	bus := NewVersionNegotiatingEventBus(NewInMemoryEventBus())
+	require.NoError(t, bus.Start(ctx))
+	failures := 1
+	handler := NewTestEventHandler("guild_projection", []string{"GuildCreated"})
+	handler.HandleFunc = func(ctx context.Context, event EventMessage) error {
+		if failures > 0 {
+			failures--
+			return errors.New("read store unavailable")
+		}
+		return nil
+	}
+	require.NoError(t, bus.Publish(ctx, newSchemaEvent(2))) // 구독자가 없으므로 버스가 그냥 버림
+	_, err := bus.Subscribe("GuildCreated", NewVersionedEventHandler(NewTestEventHandler("guild_projection", []string{"GuildCreated"}), map[string][]int{"GuildCreated": {1}}))
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, newSchemaEvent(2)))
+
+	// Act
+	_, err = bus.Subscribe("GuildCreated", handler) // 모든 버전을 지원하지만 첫 전달은 실패
+	require.NoError(t, err)
+	parkedAfterFailure := bus.ParkedEvents()
+	delivered := bus.RedeliverParked(ctx)
+
+	// Assert
+	assert.Equal(t, 1, parkedAfterFailure)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 0, bus.ParkedEvents())
+	assert.Equal(t, 2, handler.GetHandledEventCount())
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: eventfeed/v1/event_feed.proto
+
+package eventfeedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubscribeEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event types to receive; empty receives every type.
+	EventTypes []string `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Aggregate types to receive; empty receives every type.
+	AggregateTypes []string `protobuf:"bytes,2,rep,name=aggregate_types,json=aggregateTypes,proto3" json:"aggregate_types,omitempty"`
+	// Aggregate IDs to receive; empty receives every aggregate.
+	AggregateIds []string `protobuf:"bytes,3,rep,name=aggregate_ids,json=aggregateIds,proto3" json:"aggregate_ids,omitempty"`
+	// Token from a previously received FeedEvent; empty starts from live events.
+	ResumeToken   string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_eventfeed_v1_event_feed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventfeed_v1_event_feed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_eventfeed_v1_event_feed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetAggregateTypes() []string {
+	if x != nil {
+		return x.AggregateTypes
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetAggregateIds() []string {
+	if x != nil {
+		return x.AggregateIds
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type FeedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	AggregateId   string                 `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	AggregateType string                 `protobuf:"bytes,4,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// JSON-encoded event payload.
+	Data []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	// JSON-encoded event metadata.
+	Metadata []byte `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Opaque position to resume after this event.
+	ResumeToken   string `protobuf:"bytes,9,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedEvent) Reset() {
+	*x = FeedEvent{}
+	mi := &file_eventfeed_v1_event_feed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedEvent) ProtoMessage() {}
+
+func (x *FeedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_eventfeed_v1_event_feed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedEvent.ProtoReflect.Descriptor instead.
+func (*FeedEvent) Descriptor() ([]byte, []int) {
+	return file_eventfeed_v1_event_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FeedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *FeedEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FeedEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FeedEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+var File_eventfeed_v1_event_feed_proto protoreflect.FileDescriptor
+
+const file_eventfeed_v1_event_feed_proto_rawDesc = "" +
+	"\n" +
+	"\x1deventfeed/v1/event_feed.proto\x12\feventfeed.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x01\n" +
+	"\x16SubscribeEventsRequest\x12\x1f\n" +
+	"\vevent_types\x18\x01 \x03(\tR\n" +
+	"eventTypes\x12'\n" +
+	"\x0faggregate_types\x18\x02 \x03(\tR\x0eaggregateTypes\x12#\n" +
+	"\raggregate_ids\x18\x03 \x03(\tR\faggregateIds\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"\xb6\x02\n" +
+	"\tFeedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12%\n" +
+	"\x0eaggregate_type\x18\x04 \x01(\tR\raggregateType\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04data\x18\a \x01(\fR\x04data\x12\x1a\n" +
+	"\bmetadata\x18\b \x01(\fR\bmetadata\x12!\n" +
+	"\fresume_token\x18\t \x01(\tR\vresumeToken2_\n" +
+	"\tEventFeed\x12R\n" +
+	"\x0fSubscribeEvents\x12$.eventfeed.v1.SubscribeEventsRequest\x1a\x17.eventfeed.v1.FeedEvent0\x01B\x1cZ\x1acqrs/eventfeed/eventfeedpbb\x06proto3"
+
+var (
+	file_eventfeed_v1_event_feed_proto_rawDescOnce sync.Once
+	file_eventfeed_v1_event_feed_proto_rawDescData []byte
+)
+
+func file_eventfeed_v1_event_feed_proto_rawDescGZIP() []byte {
+	file_eventfeed_v1_event_feed_proto_rawDescOnce.Do(func() {
+		file_eventfeed_v1_event_feed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_eventfeed_v1_event_feed_proto_rawDesc), len(file_eventfeed_v1_event_feed_proto_rawDesc)))
+	})
+	return file_eventfeed_v1_event_feed_proto_rawDescData
+}
+
+var file_eventfeed_v1_event_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_eventfeed_v1_event_feed_proto_goTypes = []any{
+	(*SubscribeEventsRequest)(nil), // 0: eventfeed.v1.SubscribeEventsRequest
+	(*FeedEvent)(nil),              // 1: eventfeed.v1.FeedEvent
+	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
+}
+var file_eventfeed_v1_event_feed_proto_depIdxs = []int32{
+	2, // 0: eventfeed.v1.FeedEvent.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 1: eventfeed.v1.EventFeed.SubscribeEvents:input_type -> eventfeed.v1.SubscribeEventsRequest
+	1, // 2: eventfeed.v1.EventFeed.SubscribeEvents:output_type -> eventfeed.v1.FeedEvent
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_eventfeed_v1_event_feed_proto_init() }
+func file_eventfeed_v1_event_feed_proto_init() {
+	if File_eventfeed_v1_event_feed_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_eventfeed_v1_event_feed_proto_rawDesc), len(file_eventfeed_v1_event_feed_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_eventfeed_v1_event_feed_proto_goTypes,
+		DependencyIndexes: file_eventfeed_v1_event_feed_proto_depIdxs,
+		MessageInfos:      file_eventfeed_v1_event_feed_proto_msgTypes,
+	}.Build()
+	File_eventfeed_v1_event_feed_proto = out.File
+	file_eventfeed_v1_event_feed_proto_goTypes = nil
+	file_eventfeed_v1_event_feed_proto_depIdxs = nil
+}
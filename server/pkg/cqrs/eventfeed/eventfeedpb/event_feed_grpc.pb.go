@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: eventfeed/v1/event_feed.proto
+
+package eventfeedpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EventFeed_SubscribeEvents_FullMethodName = "/eventfeed.v1.EventFeed/SubscribeEvents"
+)
+
+// EventFeedClient is the client API for EventFeed service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EventFeed streams domain events to sibling services (leaderboard, anti-cheat, ...)
+// so they can consume the event feed without direct Redis/Mongo access.
+type EventFeedClient interface {
+	// SubscribeEvents streams events matching the filter until the client disconnects.
+	// Pass the resume_token of the last processed event to continue after a reconnect.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeedEvent], error)
+}
+
+type eventFeedClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventFeedClient(cc grpc.ClientConnInterface) EventFeedClient {
+	return &eventFeedClient{cc}
+}
+
+func (c *eventFeedClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeedEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventFeed_ServiceDesc.Streams[0], EventFeed_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, FeedEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventFeed_SubscribeEventsClient = grpc.ServerStreamingClient[FeedEvent]
+
+// EventFeedServer is the server API for EventFeed service.
+// All implementations must embed UnimplementedEventFeedServer
+// for forward compatibility.
+//
+// EventFeed streams domain events to sibling services (leaderboard, anti-cheat, ...)
+// so they can consume the event feed without direct Redis/Mongo access.
+type EventFeedServer interface {
+	// SubscribeEvents streams events matching the filter until the client disconnects.
+	// Pass the resume_token of the last processed event to continue after a reconnect.
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[FeedEvent]) error
+	mustEmbedUnimplementedEventFeedServer()
+}
+
+// UnimplementedEventFeedServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventFeedServer struct{}
+
+func (UnimplementedEventFeedServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[FeedEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedEventFeedServer) mustEmbedUnimplementedEventFeedServer() {}
+func (UnimplementedEventFeedServer) testEmbeddedByValue()                   {}
+
+// UnsafeEventFeedServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventFeedServer will
+// result in compilation errors.
+type UnsafeEventFeedServer interface {
+	mustEmbedUnimplementedEventFeedServer()
+}
+
+func RegisterEventFeedServer(s grpc.ServiceRegistrar, srv EventFeedServer) {
+	// If the following call pancis, it indicates UnimplementedEventFeedServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventFeed_ServiceDesc, srv)
+}
+
+func _EventFeed_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventFeedServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, FeedEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventFeed_SubscribeEventsServer = grpc.ServerStreamingServer[FeedEvent]
+
+// EventFeed_ServiceDesc is the grpc.ServiceDesc for EventFeed service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventFeed_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eventfeed.v1.EventFeed",
+	HandlerType: (*EventFeedServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _EventFeed_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "eventfeed/v1/event_feed.proto",
+}
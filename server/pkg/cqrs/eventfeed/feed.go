@@ -0,0 +1,318 @@
+// Package eventfeed exposes the domain event stream to sibling services over gRPC.
+//
+// Feed subscribes to the event bus and keeps the most recent events in a
+// sequenced ring buffer. Server streams them through the EventFeed.SubscribeEvents
+// RPC (see proto/eventfeed/v1/event_feed.proto) so services such as the
+// leaderboard or anti-cheat can consume events without direct Redis/Mongo
+// access. Every streamed event carries a resume token; a reconnecting client
+// passes the last token back and continues from the buffer, or from the event
+// store when the position has already been evicted.
+package eventfeed
+
+import (
+	"context"
+	"crypto/rand"
+	"cqrs"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBufferSize is the number of recent events kept for resumption
+const DefaultBufferSize = 4096
+
+// Event is a sequenced, serialized domain event
+type Event struct {
+	Sequence      uint64
+	EventID       string
+	EventType     string
+	AggregateID   string
+	AggregateType string
+	Version       int
+	Timestamp     time.Time
+	Data          []byte // JSON
+	Metadata      []byte // JSON
+}
+
+// Filter selects the events delivered to a subscriber; empty lists match everything
+type Filter struct {
+	EventTypes     []string
+	AggregateTypes []string
+	AggregateIDs   []string
+}
+
+// Matches reports whether the event passes the filter
+func (f Filter) Matches(event *Event) bool {
+	return matchAny(f.EventTypes, event.EventType) &&
+		matchAny(f.AggregateTypes, event.AggregateType) &&
+		matchAny(f.AggregateIDs, event.AggregateID)
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ResumeToken is the decoded position of a streamed event.
+// Epoch identifies the feed instance; sequences are only comparable within one epoch.
+type ResumeToken struct {
+	Epoch     string
+	Sequence  uint64
+	Timestamp time.Time
+	EventID   string
+}
+
+// Encode returns the opaque string form sent to clients
+func (t ResumeToken) Encode() string {
+	raw := strings.Join([]string{
+		t.Epoch,
+		strconv.FormatUint(t.Sequence, 10),
+		strconv.FormatInt(t.Timestamp.UnixNano(), 10),
+		t.EventID,
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeResumeToken parses a token produced by Encode
+func DecodeResumeToken(token string) (ResumeToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ResumeToken{}, cqrs.NewValidationError("malformed resume token", err)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 4)
+	if len(parts) != 4 {
+		return ResumeToken{}, cqrs.NewValidationError("malformed resume token", nil)
+	}
+
+	sequence, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return ResumeToken{}, cqrs.NewValidationError("malformed resume token sequence", err)
+	}
+	nanos, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return ResumeToken{}, cqrs.NewValidationError("malformed resume token timestamp", err)
+	}
+
+	return ResumeToken{
+		Epoch:     parts[0],
+		Sequence:  sequence,
+		Timestamp: time.Unix(0, nanos).UTC(),
+		EventID:   parts[3],
+	}, nil
+}
+
+// Subscription receives live events from a Feed.
+// Events is closed when the subscription is cancelled or falls too far behind.
+type Subscription struct {
+	Events <-chan *Event
+
+	events  chan *Event
+	filter  Filter
+	feed    *Feed
+	once    sync.Once
+	overrun bool
+}
+
+// Overrun reports whether the subscription was dropped because its buffer filled up
+func (s *Subscription) Overrun() bool {
+	s.feed.mutex.RLock()
+	defer s.feed.mutex.RUnlock()
+	return s.overrun
+}
+
+// Cancel stops delivery and closes Events
+func (s *Subscription) Cancel() {
+	s.feed.mutex.Lock()
+	defer s.feed.mutex.Unlock()
+	s.feed.removeLocked(s)
+}
+
+// Feed is an event bus handler that sequences events and fans them out to subscribers
+type Feed struct {
+	*cqrs.BaseEventHandler
+
+	epoch         string
+	mutex         sync.RWMutex
+	buffer        []*Event
+	start         int // index of the oldest buffered event
+	count         int
+	nextSequence  uint64
+	subscribers   map[*Subscription]struct{}
+	subscriberBuf int
+}
+
+// NewFeed creates a feed keeping bufferSize recent events (DefaultBufferSize when <= 0).
+// Register it with EventBus.SubscribeAll.
+func NewFeed(bufferSize int) *Feed {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &Feed{
+		BaseEventHandler: cqrs.NewBaseEventHandler("EventFeed", cqrs.NotificationHandler, nil),
+		epoch:            newEpoch(),
+		buffer:           make([]*Event, bufferSize),
+		nextSequence:     1,
+		subscribers:      make(map[*Subscription]struct{}),
+		subscriberBuf:    256,
+	}
+}
+
+func newEpoch() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Epoch returns the identifier of this feed instance
+func (f *Feed) Epoch() string {
+	return f.epoch
+}
+
+// CanHandle accepts every event type
+func (f *Feed) CanHandle(eventType string) bool {
+	return true
+}
+
+// Handle sequences the event and delivers it to matching subscribers.
+// Slow subscribers never block the bus: when a subscriber's buffer is full it is
+// dropped and must reconnect with its last resume token.
+func (f *Feed) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	record, err := newEvent(event)
+	if err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	record.Sequence = f.nextSequence
+	f.nextSequence++
+	f.appendLocked(record)
+
+	for sub := range f.subscribers {
+		if !sub.filter.Matches(record) {
+			continue
+		}
+		select {
+		case sub.events <- record:
+		default:
+			sub.overrun = true
+			f.removeLocked(sub)
+		}
+	}
+	return nil
+}
+
+func newEvent(event cqrs.EventMessage) (*Event, error) {
+	record := &Event{
+		EventID:       event.EventID(),
+		EventType:     event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		Version:       event.Version(),
+		Timestamp:     event.Timestamp(),
+	}
+
+	var err error
+	if data := event.EventData(); data != nil {
+		if record.Data, err = json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("failed to encode event data of %s: %w", event.EventID(), err)
+		}
+	}
+	if metadata := event.Metadata(); len(metadata) > 0 {
+		if record.Metadata, err = json.Marshal(metadata); err != nil {
+			return nil, fmt.Errorf("failed to encode event metadata of %s: %w", event.EventID(), err)
+		}
+	}
+	return record, nil
+}
+
+func (f *Feed) appendLocked(event *Event) {
+	size := len(f.buffer)
+	if f.count < size {
+		f.buffer[(f.start+f.count)%size] = event
+		f.count++
+		return
+	}
+	f.buffer[f.start] = event
+	f.start = (f.start + 1) % size
+}
+
+func (f *Feed) removeLocked(sub *Subscription) {
+	if _, exists := f.subscribers[sub]; !exists {
+		return
+	}
+	delete(f.subscribers, sub)
+	sub.once.Do(func() { close(sub.events) })
+}
+
+// Token returns the resume token of a sequenced event
+func (f *Feed) Token(event *Event) string {
+	return ResumeToken{
+		Epoch:     f.epoch,
+		Sequence:  event.Sequence,
+		Timestamp: event.Timestamp,
+		EventID:   event.EventID,
+	}.Encode()
+}
+
+// Subscribe registers a live subscriber and returns the buffered events after the token.
+//
+// The backlog and the subscription are taken atomically, so no event is lost or
+// duplicated between them. resumable is false when the token belongs to another
+// epoch or has been evicted from the buffer; the caller must then catch up from
+// the event store. An empty token subscribes from the live tail.
+func (f *Feed) Subscribe(filter Filter, token *ResumeToken) (sub *Subscription, backlog []*Event, resumable bool) {
+	events := make(chan *Event, f.subscriberBuf)
+	sub = &Subscription{Events: events, events: events, filter: filter, feed: f}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.subscribers[sub] = struct{}{}
+
+	if token == nil {
+		return sub, nil, true
+	}
+	if token.Epoch != f.epoch {
+		return sub, nil, false
+	}
+
+	// The event right after the token must still be buffered
+	oldest := f.nextSequence - uint64(f.count)
+	if token.Sequence+1 < oldest {
+		return sub, nil, false
+	}
+
+	size := len(f.buffer)
+	for i := 0; i < f.count; i++ {
+		event := f.buffer[(f.start+i)%size]
+		if event.Sequence > token.Sequence && filter.Matches(event) {
+			backlog = append(backlog, event)
+		}
+	}
+	return sub, backlog, true
+}
+
+// SubscriberCount returns the number of connected subscribers
+func (f *Feed) SubscriberCount() int {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return len(f.subscribers)
+}
@@ -0,0 +1,239 @@
+package eventfeed
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"cqrs/eventfeed/eventfeedpb"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate buf generate
+
+// Server implements the EventFeed gRPC service
+type Server struct {
+	eventfeedpb.UnimplementedEventFeedServer
+
+	feed   *Feed
+	store  cqrsx.EventExportSource
+	access *cqrs.StreamAccessPolicy
+}
+
+// ServerOption configures a Server
+type ServerOption func(*Server)
+
+// WithAccessPolicy restricts subscriptions to the aggregate types the subscriber's
+// cqrs.StreamReader may read (see StreamReaderInterceptor). Subscriptions naming a hidden
+// type are rejected with codes.PermissionDenied; unfiltered ones silently skip hidden streams.
+func WithAccessPolicy(policy *cqrs.StreamAccessPolicy) ServerOption {
+	return func(s *Server) {
+		s.access = policy
+	}
+}
+
+// NewServer creates the gRPC service for a feed.
+// store is optional; without it, clients whose resume token has been evicted get
+// codes.OutOfRange and must resynchronize through another channel.
+func NewServer(feed *Feed, store cqrsx.EventExportSource, options ...ServerOption) *Server {
+	server := &Server{feed: feed, store: store}
+	for _, option := range options {
+		option(server)
+	}
+	return server
+}
+
+// SubscribeEvents streams matching events until the client disconnects
+func (s *Server) SubscribeEvents(req *eventfeedpb.SubscribeEventsRequest, stream eventfeedpb.EventFeed_SubscribeEventsServer) error {
+	filter := Filter{
+		EventTypes:     req.GetEventTypes(),
+		AggregateTypes: req.GetAggregateTypes(),
+		AggregateIDs:   req.GetAggregateIds(),
+	}
+	if s.access != nil {
+		if err := s.access.Authorize(stream.Context(), filter.AggregateTypes...); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	var token *ResumeToken
+	if req.GetResumeToken() != "" {
+		decoded, err := DecodeResumeToken(req.GetResumeToken())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		token = &decoded
+	}
+
+	sub, backlog, resumable := s.feed.Subscribe(filter, token)
+	defer sub.Cancel()
+
+	// Events already sent from the store; live events are skipped until the
+	// stream has moved past them
+	var sent map[string]bool
+	if !resumable {
+		if s.store == nil {
+			return status.Error(codes.OutOfRange, "resume token is no longer available")
+		}
+
+		var err error
+		if sent, err = s.catchUp(stream, filter, *token); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range backlog {
+		if !s.visible(stream.Context(), event) {
+			continue
+		}
+		if err := stream.Send(s.toProto(event)); err != nil {
+			return err
+		}
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				if sub.Overrun() {
+					return status.Error(codes.ResourceExhausted, "subscriber fell too far behind; resume with the last token")
+				}
+				return status.Error(codes.Unavailable, "event feed closed")
+			}
+			if sent[event.EventID] {
+				delete(sent, event.EventID)
+				continue
+			}
+			if !s.visible(ctx, event) {
+				continue
+			}
+			if err := stream.Send(s.toProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// catchUp streams stored events from the token position.
+// Delivery is at-least-once: events sharing the token's timestamp may be sent
+// again, so consumers should deduplicate by event ID. Store events are not part
+// of the feed sequence, so their tokens carry only the timestamp and event ID.
+func (s *Server) catchUp(stream eventfeedpb.EventFeed_SubscribeEventsServer, filter Filter, token ResumeToken) (map[string]bool, error) {
+	exportFilter := cqrsx.EventExportFilter{
+		EventTypes: filter.EventTypes,
+		From:       token.Timestamp,
+	}
+	if len(filter.AggregateTypes) == 1 {
+		exportFilter.AggregateType = filter.AggregateTypes[0]
+	}
+
+	sent := make(map[string]bool)
+	err := s.store.ExportEvents(stream.Context(), exportFilter, func(exported *cqrsx.ExportedEvent) error {
+		// The export range is inclusive of the token's own event
+		if exported.EventID == token.EventID {
+			return nil
+		}
+
+		event, err := fromExported(exported)
+		if err != nil {
+			return err
+		}
+		if !filter.Matches(event) || !s.visible(stream.Context(), event) {
+			return nil
+		}
+
+		resume := ResumeToken{Timestamp: event.Timestamp, EventID: event.EventID}.Encode()
+		if err := stream.Send(toProto(event, resume)); err != nil {
+			return err
+		}
+		sent[event.EventID] = true
+		return nil
+	})
+	if err != nil {
+		if cqrs.IsValidationError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return sent, nil
+}
+
+func fromExported(exported *cqrsx.ExportedEvent) (*Event, error) {
+	event := &Event{
+		EventID:       exported.EventID,
+		EventType:     exported.EventType,
+		AggregateID:   exported.AggregateID,
+		AggregateType: exported.AggregateType,
+		Version:       exported.Version,
+		Timestamp:     exported.Timestamp,
+	}
+
+	var err error
+	if exported.Data != nil {
+		if event.Data, err = json.Marshal(exported.Data); err != nil {
+			return nil, err
+		}
+	}
+	if len(exported.Metadata) > 0 {
+		if event.Metadata, err = json.Marshal(exported.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+// visible reports whether the subscriber may read the event's stream
+func (s *Server) visible(ctx context.Context, event *Event) bool {
+	return s.access == nil || s.access.Allows(ctx, event.AggregateType)
+}
+
+// StreamReaderInterceptor authenticates subscribers and attaches their cqrs.StreamReader to
+// the stream context for WithAccessPolicy. resolve typically reads a token from the
+// incoming metadata; its error is returned to the client as is, so use status errors.
+func StreamReaderInterceptor(resolve func(ctx context.Context) (cqrs.StreamReader, error)) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		reader, err := resolve(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &readerStream{ServerStream: ss, ctx: cqrs.WithStreamReader(ss.Context(), reader)})
+	}
+}
+
+// readerStream overrides the context of a server stream
+type readerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *readerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *Server) toProto(event *Event) *eventfeedpb.FeedEvent {
+	return toProto(event, s.feed.Token(event))
+}
+
+func toProto(event *Event, resumeToken string) *eventfeedpb.FeedEvent {
+	return &eventfeedpb.FeedEvent{
+		EventId:       event.EventID,
+		EventType:     event.EventType,
+		AggregateId:   event.AggregateID,
+		AggregateType: event.AggregateType,
+		Version:       int64(event.Version),
+		Timestamp:     timestamppb.New(event.Timestamp),
+		Data:          event.Data,
+		Metadata:      event.Metadata,
+		ResumeToken:   resumeToken,
+	}
+}
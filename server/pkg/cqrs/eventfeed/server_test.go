@@ -0,0 +1,247 @@
+package eventfeed
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"cqrs/eventfeed/eventfeedpb"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type scoreEvent struct {
+	cqrs.BaseEventMessage
+	Score int `json:"score"`
+}
+
+func (e *scoreEvent) EventData() interface{} {
+	return map[string]int{"score": e.Score}
+}
+
+func newScoreEvent(eventType, aggregateID string, score int) *scoreEvent {
+	event := &scoreEvent{BaseEventMessage: *cqrs.NewBaseEventMessage(eventType), Score: score}
+	event.AggregateID_ = aggregateID
+	event.AggregateType_ = "Player"
+	event.Version_ = 1
+	return event
+}
+
+// exportSource 저장된 이벤트를 재생하는 테스트용 EventExportSource
+type exportSource struct {
+	events []*cqrsx.ExportedEvent
+}
+
+func (s *exportSource) ExportEvents(ctx context.Context, filter cqrsx.EventExportFilter, fn func(*cqrsx.ExportedEvent) error) error {
+	for _, event := range s.events {
+		if event.Timestamp.Before(filter.From) {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startServer(t *testing.T, feed *Feed, store cqrsx.EventExportSource, options ...ServerOption) eventfeedpb.EventFeedClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	// 테스트용 인증: x-reader-role 메타데이터를 조회자 역할로 사용
+	server := grpc.NewServer(grpc.StreamInterceptor(StreamReaderInterceptor(func(ctx context.Context) (cqrs.StreamReader, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return cqrs.StreamReader{Name: "test-client", Roles: md.Get("x-reader-role")}, nil
+	})))
+	eventfeedpb.RegisterEventFeedServer(server, NewServer(feed, store, options...))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return eventfeedpb.NewEventFeedClient(conn)
+}
+
+func waitForSubscribers(t *testing.T, feed *Feed, count int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return feed.SubscriberCount() == count }, time.Second, 5*time.Millisecond)
+}
+
+func TestServer_StreamsFilteredLiveEvents(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	feed := NewFeed(16)
+	client := startServer(t, feed, nil)
+
+	stream, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{EventTypes: []string{"ScoreGained"}})
+	require.NoError(t, err)
+	waitForSubscribers(t, feed, 1)
+
+	// Act
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("PlayerJoined", "p-1", 0)))
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 50)))
+
+	// Assert
+	received, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ScoreGained", received.EventType)
+	assert.Equal(t, "p-1", received.AggregateId)
+	assert.JSONEq(t, `{"score":50}`, string(received.Data))
+	assert.NotEmpty(t, received.ResumeToken)
+}
+
+func TestServer_AppliesStreamAccessPolicy(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	feed := NewFeed(16)
+	policy := cqrs.NewStreamAccessPolicy()
+	require.NoError(t, policy.Restrict("UserCredential", "auth-service"))
+	client := startServer(t, feed, nil, WithAccessPolicy(policy))
+	gameplayCtx := metadata.AppendToOutgoingContext(ctx, "x-reader-role", "gameplay")
+
+	credential := newScoreEvent("PasswordChanged", "u-1", 0)
+	credential.AggregateType_ = "UserCredential"
+
+	// Act
+	denied, err := client.SubscribeEvents(gameplayCtx, &eventfeedpb.SubscribeEventsRequest{AggregateTypes: []string{"UserCredential"}})
+	require.NoError(t, err)
+	_, deniedErr := denied.Recv()
+
+	stream, err := client.SubscribeEvents(gameplayCtx, &eventfeedpb.SubscribeEventsRequest{})
+	require.NoError(t, err)
+	waitForSubscribers(t, feed, 1)
+	require.NoError(t, feed.Handle(ctx, credential))
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 5)))
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(deniedErr))
+	received, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ScoreGained", received.EventType) // 자격 증명 이벤트는 건너뜀
+}
+
+func TestServer_ResumesFromBufferedToken(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	feed := NewFeed(16)
+	client := startServer(t, feed, nil)
+
+	first, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{})
+	require.NoError(t, err)
+	waitForSubscribers(t, feed, 1)
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 10)))
+	received, err := first.Recv()
+	require.NoError(t, err)
+
+	// 연결이 끊긴 동안 발생한 이벤트
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 20)))
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 30)))
+
+	// Act
+	resumed, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{ResumeToken: received.ResumeToken})
+	require.NoError(t, err)
+
+	// Assert
+	for _, expected := range []string{`{"score":20}`, `{"score":30}`} {
+		event, err := resumed.Recv()
+		require.NoError(t, err)
+		assert.JSONEq(t, expected, string(event.Data))
+	}
+}
+
+func TestServer_CatchesUpFromStoreWhenTokenEvicted(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	base := time.Now().UTC().Add(-time.Minute)
+	store := &exportSource{events: []*cqrsx.ExportedEvent{
+		{EventID: "e-1", EventType: "ScoreGained", AggregateID: "p-1", AggregateType: "Player", Timestamp: base, Data: map[string]interface{}{"score": 1}},
+		{EventID: "e-2", EventType: "ScoreGained", AggregateID: "p-1", AggregateType: "Player", Timestamp: base.Add(time.Second), Data: map[string]interface{}{"score": 2}},
+		{EventID: "e-3", EventType: "ScoreGained", AggregateID: "p-2", AggregateType: "Player", Timestamp: base.Add(2 * time.Second), Data: map[string]interface{}{"score": 3}},
+	}}
+	feed := NewFeed(16)
+	client := startServer(t, feed, store)
+	token := ResumeToken{Epoch: "previous-instance", Sequence: 7, Timestamp: base, EventID: "e-1"}.Encode()
+
+	// Act
+	stream, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{ResumeToken: token, AggregateIds: []string{"p-1"}})
+	require.NoError(t, err)
+
+	// Assert
+	caughtUp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "e-2", caughtUp.EventId)
+
+	waitForSubscribers(t, feed, 1)
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 4)))
+	live, err := stream.Recv()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"score":4}`, string(live.Data))
+}
+
+func TestServer_EvictedTokenWithoutStore(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	feed := NewFeed(2)
+	client := startServer(t, feed, nil)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", i)))
+	}
+	token := ResumeToken{Epoch: feed.Epoch(), Sequence: 1}.Encode()
+
+	// Act
+	stream, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{ResumeToken: token})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	// Assert
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestServer_RejectsMalformedToken(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := startServer(t, NewFeed(4), nil)
+
+	// Act
+	stream, err := client.SubscribeEvents(ctx, &eventfeedpb.SubscribeEventsRequest{ResumeToken: "not a token"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFeed_DropsOverrunSubscriber(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	feed := NewFeed(8)
+	feed.subscriberBuf = 1
+	sub, _, _ := feed.Subscribe(Filter{}, nil)
+
+	// Act
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 1)))
+	require.NoError(t, feed.Handle(ctx, newScoreEvent("ScoreGained", "p-1", 2)))
+
+	// Assert
+	assert.True(t, sub.Overrun())
+	assert.Equal(t, 0, feed.SubscriberCount())
+}
@@ -0,0 +1,42 @@
+package cqrs
+
+import (
+	"context"
+	"sync"
+)
+
+// FeatureFlags answers whether a named flag is currently enabled.
+// Implementations may be backed by config, Redis or a remote flag service;
+// callers re-check on every decision so flips take effect without a restart.
+type FeatureFlags interface {
+	IsEnabled(ctx context.Context, flag string) bool
+}
+
+// InMemoryFeatureFlags is a process-local FeatureFlags implementation
+type InMemoryFeatureFlags struct {
+	mutex sync.RWMutex
+	flags map[string]bool
+}
+
+// NewInMemoryFeatureFlags creates flags with the given flags enabled
+func NewInMemoryFeatureFlags(enabled ...string) *InMemoryFeatureFlags {
+	flags := &InMemoryFeatureFlags{flags: make(map[string]bool)}
+	for _, flag := range enabled {
+		flags.flags[flag] = true
+	}
+	return flags
+}
+
+// Set enables or disables a flag
+func (f *InMemoryFeatureFlags) Set(flag string, enabled bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.flags[flag] = enabled
+}
+
+// IsEnabled reports whether the flag is enabled; unknown flags are disabled
+func (f *InMemoryFeatureFlags) IsEnabled(ctx context.Context, flag string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.flags[flag]
+}
@@ -0,0 +1,141 @@
+package cqrs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CommandTypeProvider is implemented by command handlers that declare the command types they
+// handle, so they can be registered without repeating the list (BaseCommandHandler implements it)
+type CommandTypeProvider interface {
+	GetSupportedCommandTypes() []string
+}
+
+// QueryTypeProvider is implemented by query handlers that declare the query types they
+// handle, so they can be registered without repeating the list (BaseQueryHandler implements it)
+type QueryTypeProvider interface {
+	GetSupportedQueryTypes() []string
+}
+
+// Struct tags a handler can declare its types with instead of a method, usually on a blank field:
+//
+//	type TransferHandler struct {
+//		_ struct{} `commands:"StartTransfer,CancelTransfer"`
+//	}
+const (
+	CommandTypesTag = "commands"
+	QueryTypesTag   = "queries"
+)
+
+// HandledCommandTypes returns the command types a handler declares through CommandTypeProvider
+// and the commands struct tag, sorted. Every declared type must pass the handler's CanHandle.
+func HandledCommandTypes(handler CommandHandler) ([]string, error) {
+	if handler == nil {
+		return nil, NewValidationError("handler cannot be nil", nil)
+	}
+	var declared []string
+	if provider, ok := handler.(CommandTypeProvider); ok {
+		declared = provider.GetSupportedCommandTypes()
+	}
+	return discoverHandledTypes("command", CommandTypesTag, handler, declared, handler.CanHandle)
+}
+
+// HandledQueryTypes returns the query types a handler declares through QueryTypeProvider
+// and the queries struct tag, sorted. Every declared type must pass the handler's CanHandle.
+func HandledQueryTypes(handler QueryHandler) ([]string, error) {
+	if handler == nil {
+		return nil, NewValidationError("handler cannot be nil", nil)
+	}
+	var declared []string
+	if provider, ok := handler.(QueryTypeProvider); ok {
+		declared = provider.GetSupportedQueryTypes()
+	}
+	return discoverHandledTypes("query", QueryTypesTag, handler, declared, handler.CanHandle)
+}
+
+// AutoRegisterCommandHandler registers a handler for every command type it declares and returns them.
+// Registration is all or nothing: if one type fails, the types registered before it are unregistered.
+func AutoRegisterCommandHandler(dispatcher CommandDispatcher, handler CommandHandler) ([]string, error) {
+	commandTypes, err := HandledCommandTypes(handler)
+	if err != nil {
+		return nil, err
+	}
+	for i, commandType := range commandTypes {
+		if err := dispatcher.RegisterHandler(commandType, handler); err != nil {
+			for _, registered := range commandTypes[:i] {
+				dispatcher.UnregisterHandler(registered)
+			}
+			return nil, fmt.Errorf("failed to register %s for %s: %w", handler.GetHandlerName(), commandType, err)
+		}
+	}
+	return commandTypes, nil
+}
+
+// AutoRegisterQueryHandler registers a handler for every query type it declares and returns them.
+// Registration is all or nothing: if one type fails, the types registered before it are unregistered.
+func AutoRegisterQueryHandler(dispatcher QueryDispatcher, handler QueryHandler) ([]string, error) {
+	queryTypes, err := HandledQueryTypes(handler)
+	if err != nil {
+		return nil, err
+	}
+	for i, queryType := range queryTypes {
+		if err := dispatcher.RegisterHandler(queryType, handler); err != nil {
+			for _, registered := range queryTypes[:i] {
+				dispatcher.UnregisterHandler(registered)
+			}
+			return nil, fmt.Errorf("failed to register %s for %s: %w", handler.GetHandlerName(), queryType, err)
+		}
+	}
+	return queryTypes, nil
+}
+
+func discoverHandledTypes(kind, tag string, handler interface{}, declared []string, canHandle func(string) bool) ([]string, error) {
+	candidates := append(append([]string{}, declared...), taggedTypes(reflect.TypeOf(handler), tag, make(map[reflect.Type]bool))...)
+	seen := make(map[string]bool)
+	handledTypes := []string{}
+	for _, handledType := range candidates {
+		if handledType == "" {
+			return nil, NewValidationError(fmt.Sprintf("%s handler %T declares an empty %s type", kind, handler, kind), nil)
+		}
+		if seen[handledType] {
+			continue
+		}
+		if !canHandle(handledType) {
+			return nil, NewValidationError(fmt.Sprintf("%s handler %T declares %s type %s but cannot handle it", kind, handler, kind, handledType), nil)
+		}
+		seen[handledType] = true
+		handledTypes = append(handledTypes, handledType)
+	}
+	if len(handledTypes) == 0 {
+		return nil, NewValidationError(fmt.Sprintf("%s handler %T declares no %s types", kind, handler, kind), nil)
+	}
+	sort.Strings(handledTypes)
+	return handledTypes, nil
+}
+
+// taggedTypes collects the types listed in tag on the fields of a struct and of the structs it embeds
+func taggedTypes(t reflect.Type, tag string, visited map[reflect.Type]bool) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+	visited[t] = true
+
+	var types []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if value, ok := field.Tag.Lookup(tag); ok {
+			for _, handledType := range strings.Split(value, ",") {
+				types = append(types, strings.TrimSpace(handledType))
+			}
+		}
+		if field.Anonymous {
+			types = append(types, taggedTypes(field.Type, tag, visited)...)
+		}
+	}
+	return types
+}
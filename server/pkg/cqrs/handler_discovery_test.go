@@ -0,0 +1,151 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taggedQueryHandler 구조체 태그로 쿼리 타입을 선언하는 핸들러
+type taggedQueryHandler struct {
+	_ struct{} `queries:"GetBalance, GetHistory"`
+}
+
+func (h *taggedQueryHandler) Handle(ctx context.Context, query Query) (*QueryResult, error) {
+	return &QueryResult{Success: true, Data: query.QueryType()}, nil
+}
+
+func (h *taggedQueryHandler) CanHandle(queryType string) bool {
+	return queryType == "GetBalance" || queryType == "GetHistory" || queryType == "GetLimits"
+}
+
+func (h *taggedQueryHandler) GetHandlerName() string {
+	return "TaggedQueryHandler"
+}
+
+// embeddedTaggedQueryHandler 임베드한 구조체의 태그와 자기 태그를 함께 선언하는 핸들러
+type embeddedTaggedQueryHandler struct {
+	taggedQueryHandler
+	_ struct{} `queries:"GetLimits,GetBalance"`
+}
+
+// untaggedCommandHandler 처리할 타입을 선언하지 않은 핸들러
+type untaggedCommandHandler struct{}
+
+func (h *untaggedCommandHandler) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	return &CommandResult{Success: true}, nil
+}
+
+func (h *untaggedCommandHandler) CanHandle(commandType string) bool {
+	return true
+}
+
+func (h *untaggedCommandHandler) GetHandlerName() string {
+	return "UntaggedCommandHandler"
+}
+
+// mistaggedCommandHandler 태그에는 있지만 CanHandle이 거절하는 타입을 선언한 핸들러
+type mistaggedCommandHandler struct {
+	*BaseCommandHandler `commands:"Transfer"`
+}
+
+// newWalletCommandHandler 주어진 명령 타입을 선언하는 테스트 핸들러를 만듭니다
+func newWalletCommandHandler(name string, commandTypes []string) *TestCommandHandler {
+	return &TestCommandHandler{BaseCommandHandler: NewBaseCommandHandler(name, commandTypes)}
+}
+
+func TestAutoRegisterCommandHandler_RegistersDeclaredTypes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := newWalletCommandHandler("WalletHandler", []string{"Withdraw", "Deposit"})
+
+	// Act
+	registered, err := AutoRegisterCommandHandler(dispatcher, handler)
+	result, dispatchErr := dispatcher.Dispatch(ctx, NewBaseCommand("Deposit", "wallet-1", "Wallet", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Deposit", "Withdraw"}, registered)
+	assert.Equal(t, 2, dispatcher.GetHandlerCount())
+	require.NoError(t, dispatchErr)
+	assert.True(t, result.Success)
+}
+
+func TestHandledQueryTypes_ReadsStructTags(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := NewInMemoryQueryDispatcher()
+
+	// Act
+	tagged, taggedErr := HandledQueryTypes(&taggedQueryHandler{})
+	embedded, embeddedErr := HandledQueryTypes(&embeddedTaggedQueryHandler{})
+	registered, registerErr := AutoRegisterQueryHandler(dispatcher, &embeddedTaggedQueryHandler{})
+	queryType, queryErr := DispatchQuery[string](ctx, dispatcher, NewBaseQuery("GetLimits", nil))
+
+	// Assert
+	require.NoError(t, taggedErr)
+	assert.Equal(t, []string{"GetBalance", "GetHistory"}, tagged)
+	require.NoError(t, embeddedErr)
+	assert.Equal(t, []string{"GetBalance", "GetHistory", "GetLimits"}, embedded)
+	require.NoError(t, registerErr)
+	assert.Equal(t, embedded, registered)
+	require.NoError(t, queryErr)
+	assert.Equal(t, "GetLimits", queryType)
+}
+
+func TestHandledCommandTypes_RejectsMissingOrUnhandledTypes(t *testing.T) {
+	// Act
+	_, missingErr := HandledCommandTypes(&untaggedCommandHandler{})
+	_, unhandledErr := HandledCommandTypes(&mistaggedCommandHandler{BaseCommandHandler: NewBaseCommandHandler("Mistagged", []string{"Deposit"})})
+
+	// Assert
+	assert.True(t, IsValidationError(missingErr))
+	assert.Contains(t, missingErr.Error(), "declares no command types")
+	assert.True(t, IsValidationError(unhandledErr))
+	assert.Contains(t, unhandledErr.Error(), "Transfer")
+}
+
+func TestAutoRegisterCommandHandler_RollsBackOnConflict(t *testing.T) {
+	// Arrange
+	dispatcher := NewInMemoryCommandDispatcher()
+	require.NoError(t, dispatcher.RegisterHandler("Deposit", newWalletCommandHandler("Existing", []string{"Deposit"})))
+	handler := newWalletCommandHandler("WalletHandler", []string{"Close", "Deposit", "Withdraw"})
+
+	// Act
+	registered, err := AutoRegisterCommandHandler(dispatcher, handler)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, registered)
+	assert.Contains(t, err.Error(), "WalletHandler")
+	assert.Equal(t, []string{"Deposit"}, dispatcher.GetRegisteredHandlers(), "먼저 등록한 Close는 되돌려야 합니다")
+}
+
+func TestContainer_BootstrapUsesDeclaredHandlerTypes(t *testing.T) {
+	// Arrange
+	container := NewContainer()
+	commands := NewInMemoryCommandDispatcher()
+	queries := NewInMemoryQueryDispatcher()
+	require.NoError(t, ProvideValue[CommandDispatcher](container, commands))
+	require.NoError(t, ProvideValue[QueryDispatcher](container, queries))
+	require.NoError(t, ProvideCommandHandler(container, func(r Resolver) (*TestCommandHandler, error) {
+		return newWalletCommandHandler("WalletHandler", []string{"Deposit", "Withdraw"}), nil
+	}))
+	require.NoError(t, ProvideQueryHandler(container, func(r Resolver) (*taggedQueryHandler, error) {
+		return &taggedQueryHandler{}, nil
+	}))
+
+	// Act
+	err := container.Bootstrap()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, commands.HasHandler("Deposit"))
+	assert.True(t, commands.HasHandler("Withdraw"))
+	assert.True(t, queries.HasHandler("GetBalance"))
+	assert.True(t, queries.HasHandler("GetHistory"))
+	assert.False(t, queries.HasHandler("GetLimits"))
+}
@@ -16,10 +16,25 @@ type InMemoryEventBus struct {
 	mutex         sync.RWMutex
 	nextSubID     int64
 	subIDMutex    sync.Mutex
+
+	// Async publishing
+	queueConfig EventQueueConfig
+	queue       *eventQueue
+	workerMutex sync.Mutex
+	workerStop  chan struct{}
+	workerWG    sync.WaitGroup
 }
 
-// NewInMemoryEventBus creates a new in-memory event bus
+// NewInMemoryEventBus creates a new in-memory event bus with the default async queue
 func NewInMemoryEventBus() *InMemoryEventBus {
+	return NewInMemoryEventBusWithQueue(DefaultEventQueueConfig())
+}
+
+// NewInMemoryEventBusWithQueue creates an in-memory event bus whose async publishes
+// go through a bounded queue, so a slow handler applies backpressure instead of
+// growing memory without limit
+func NewInMemoryEventBusWithQueue(config EventQueueConfig) *InMemoryEventBus {
+	config = config.normalized()
 	return &InMemoryEventBus{
 		subscriptions: make(map[string][]EventHandler),
 		allHandlers:   make([]EventHandler, 0),
@@ -31,7 +46,9 @@ func NewInMemoryEventBus() *InMemoryEventBus {
 			AverageLatency:    0,
 			LastEventTime:     time.Time{},
 		},
-		running: false,
+		running:     false,
+		queueConfig: config,
+		queue:       newEventQueue(config),
 	}
 }
 
@@ -59,16 +76,17 @@ func (bus *InMemoryEventBus) Publish(ctx context.Context, event EventMessage, op
 		opts = options[0]
 	}
 
-	// Process event
+	// Async events are handed to the queue workers, which record their outcome
 	if opts.Async {
-		go bus.processEvent(ctx, event)
-	} else {
-		if err := bus.processEvent(ctx, event); err != nil {
-			bus.mutex.Lock()
-			bus.metrics.FailedEvents++
-			bus.mutex.Unlock()
-			return err
-		}
+		bus.startWorkers()
+		return bus.queue.push(ctx, queuedEvent{ctx: context.WithoutCancel(ctx), event: event})
+	}
+
+	if err := bus.processEvent(ctx, event); err != nil {
+		bus.mutex.Lock()
+		bus.metrics.FailedEvents++
+		bus.mutex.Unlock()
+		return err
 	}
 
 	// Update metrics
@@ -149,18 +167,21 @@ func (bus *InMemoryEventBus) Start(ctx context.Context) error {
 	}
 
 	bus.running = true
+	bus.startWorkers()
 	return nil
 }
 
+// Stop stops the bus after the async queue has been drained
 func (bus *InMemoryEventBus) Stop(ctx context.Context) error {
 	bus.mutex.Lock()
-	defer bus.mutex.Unlock()
-
 	if !bus.running {
+		bus.mutex.Unlock()
 		return NewCQRSError(ErrCodeEventBusError.String(), "event bus is not running", nil)
 	}
-
 	bus.running = false
+	bus.mutex.Unlock()
+
+	bus.stopWorkers()
 	return nil
 }
 
@@ -183,11 +204,80 @@ func (bus *InMemoryEventBus) GetMetrics() *EventBusMetrics {
 		ActiveSubscribers: bus.metrics.ActiveSubscribers,
 		AverageLatency:    bus.metrics.AverageLatency,
 		LastEventTime:     bus.metrics.LastEventTime,
+		QueueDepth:        len(bus.queue.items),
+		QueueCapacity:     bus.queueConfig.Capacity,
+		MaxQueueDepth:     int(bus.queue.maxDepth.Load()),
+		DroppedEvents:     bus.queue.dropped.Load(),
+		RejectedEvents:    bus.queue.rejected.Load(),
 	}
 }
 
 // Helper methods
 
+// startWorkers starts the async queue workers unless they are already running.
+// Async publishing works without Start; the workers are started on first use.
+func (bus *InMemoryEventBus) startWorkers() {
+	bus.workerMutex.Lock()
+	defer bus.workerMutex.Unlock()
+
+	if bus.workerStop != nil {
+		return
+	}
+
+	bus.workerStop = make(chan struct{})
+	for i := 0; i < bus.queueConfig.Workers; i++ {
+		bus.workerWG.Add(1)
+		go bus.runWorker(bus.workerStop)
+	}
+}
+
+// stopWorkers lets the workers drain the queue and waits for them to exit
+func (bus *InMemoryEventBus) stopWorkers() {
+	bus.workerMutex.Lock()
+	defer bus.workerMutex.Unlock()
+
+	if bus.workerStop == nil {
+		return
+	}
+
+	close(bus.workerStop)
+	bus.workerWG.Wait()
+	bus.workerStop = nil
+}
+
+func (bus *InMemoryEventBus) runWorker(stop <-chan struct{}) {
+	defer bus.workerWG.Done()
+
+	for {
+		select {
+		case item := <-bus.queue.items:
+			bus.processQueued(item)
+		case <-stop:
+			for {
+				select {
+				case item := <-bus.queue.items:
+					bus.processQueued(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (bus *InMemoryEventBus) processQueued(item queuedEvent) {
+	err := bus.processEvent(item.ctx, item.event)
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if err != nil {
+		bus.metrics.FailedEvents++
+		return
+	}
+	bus.metrics.ProcessedEvents++
+}
+
 func (bus *InMemoryEventBus) processEvent(ctx context.Context, event EventMessage) error {
 	bus.mutex.RLock()
 
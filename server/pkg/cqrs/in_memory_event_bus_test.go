@@ -360,6 +360,7 @@ func TestHandlerType_String(t *testing.T) {
 		{ProcessManagerHandler, "process_manager"},
 		{SagaHandler, "saga"},
 		{NotificationHandler, "notification"},
+		{AnalyticsHandler, "analytics"},
 		{HandlerType(999), "unknown"},
 	}
 
@@ -10,15 +10,26 @@ import (
 // InMemoryProjectionManager provides an in-memory implementation of ProjectionManager
 type InMemoryProjectionManager struct {
 	projections map[string]Projection
+	checkpoints map[string]*ProjectionCheckpoint
 	metrics     *ProjectionMetrics
 	running     bool
 	mutex       sync.RWMutex
+
+	// projectMutex serializes projection updates with snapshot capture and restore
+	projectMutex sync.Mutex
+
+	// Lag monitoring
+	head          projectionHead
+	lagThresholds []ProjectionLagThreshold
+	lagAlerting   map[string]bool
+	lagAlertFuncs []ProjectionLagAlertFunc
 }
 
 // NewInMemoryProjectionManager creates a new in-memory projection manager
 func NewInMemoryProjectionManager() *InMemoryProjectionManager {
 	return &InMemoryProjectionManager{
 		projections: make(map[string]Projection),
+		checkpoints: make(map[string]*ProjectionCheckpoint),
 		metrics: &ProjectionMetrics{
 			TotalProjections:      0,
 			RunningProjections:    0,
@@ -28,7 +39,8 @@ func NewInMemoryProjectionManager() *InMemoryProjectionManager {
 			LastProcessedEvent:    time.Time{},
 			Errors:                make([]ProjectionError, 0),
 		},
-		running: false,
+		running:     false,
+		lagAlerting: make(map[string]bool),
 	}
 }
 
@@ -73,10 +85,12 @@ func (pm *InMemoryProjectionManager) UnregisterProjection(projectionName string)
 
 	projection, exists := pm.projections[projectionName]
 	if !exists {
-		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+		return NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
 	}
 
 	delete(pm.projections, projectionName)
+	delete(pm.checkpoints, projectionName)
+	delete(pm.lagAlerting, projectionName)
 	pm.metrics.TotalProjections--
 
 	if projection.GetState() == ProjectionRunning {
@@ -156,7 +170,7 @@ func (pm *InMemoryProjectionManager) GetProjectionState(projectionName string) (
 
 	projection, exists := pm.projections[projectionName]
 	if !exists {
-		return ProjectionStopped, NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+		return ProjectionStopped, NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
 	}
 
 	return projection.GetState(), nil
@@ -172,7 +186,7 @@ func (pm *InMemoryProjectionManager) ResetProjection(ctx context.Context, projec
 
 	projection, exists := pm.projections[projectionName]
 	if !exists {
-		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+		return NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
 	}
 
 	// Update state counters
@@ -195,7 +209,7 @@ func (pm *InMemoryProjectionManager) RebuildProjection(ctx context.Context, proj
 
 	projection, exists := pm.projections[projectionName]
 	if !exists {
-		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+		return NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
 	}
 
 	// Update state counters
@@ -216,6 +230,16 @@ func (pm *InMemoryProjectionManager) GetMetrics() *ProjectionMetrics {
 	errorsCopy := make([]ProjectionError, len(pm.metrics.Errors))
 	copy(errorsCopy, pm.metrics.Errors)
 
+	lags := make(map[string]ProjectionLag, len(pm.projections))
+	var maxEventsBehind int64
+	for name := range pm.projections {
+		lag := pm.lagLocked(name)
+		lags[name] = lag
+		if lag.EventsBehind > maxEventsBehind {
+			maxEventsBehind = lag.EventsBehind
+		}
+	}
+
 	return &ProjectionMetrics{
 		TotalProjections:      pm.metrics.TotalProjections,
 		RunningProjections:    pm.metrics.RunningProjections,
@@ -224,6 +248,8 @@ func (pm *InMemoryProjectionManager) GetMetrics() *ProjectionMetrics {
 		AverageProcessingTime: pm.metrics.AverageProcessingTime,
 		LastProcessedEvent:    pm.metrics.LastProcessedEvent,
 		Errors:                errorsCopy,
+		Lag:                   lags,
+		MaxEventsBehind:       maxEventsBehind,
 	}
 }
 
@@ -262,8 +288,12 @@ func (pm *InMemoryProjectionManager) ProcessEvent(ctx context.Context, event Eve
 	}
 	pm.mutex.RUnlock()
 
+	position := pm.observeHead(event)
 	start := time.Now()
 
+	pm.projectMutex.Lock()
+	defer pm.projectMutex.Unlock()
+
 	for _, projection := range projections {
 		if err := projection.Project(ctx, event); err != nil {
 			// Record error
@@ -294,7 +324,9 @@ func (pm *InMemoryProjectionManager) ProcessEvent(ctx context.Context, event Eve
 
 			return err
 		}
+		pm.advanceCheckpoint(projection.GetProjectionName(), event)
 	}
+	pm.markPositions(projections, event, position)
 
 	// Update metrics
 	pm.mutex.Lock()
@@ -347,6 +379,9 @@ func (pm *InMemoryProjectionManager) Clear() {
 	defer pm.mutex.Unlock()
 
 	pm.projections = make(map[string]Projection)
+	pm.checkpoints = make(map[string]*ProjectionCheckpoint)
+	pm.head = projectionHead{}
+	pm.lagAlerting = make(map[string]bool)
 	pm.metrics = &ProjectionMetrics{
 		TotalProjections:      0,
 		RunningProjections:    0,
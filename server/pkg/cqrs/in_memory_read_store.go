@@ -26,7 +26,7 @@ func NewInMemoryReadStore() *InMemoryReadStore {
 
 func (rs *InMemoryReadStore) Save(ctx context.Context, readModel ReadModel) error {
 	if readModel == nil {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "read model cannot be nil", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "read model cannot be nil", nil).WithCategory(CategoryValidation)
 	}
 
 	if err := readModel.Validate(); err != nil {
@@ -44,10 +44,10 @@ func (rs *InMemoryReadStore) Save(ctx context.Context, readModel ReadModel) erro
 
 func (rs *InMemoryReadStore) GetByID(ctx context.Context, id string, modelType string) (ReadModel, error) {
 	if id == "" {
-		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "id cannot be empty", nil)
+		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "id cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 	if modelType == "" {
-		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 
 	rs.mutex.RLock()
@@ -58,15 +58,33 @@ func (rs *InMemoryReadStore) GetByID(ctx context.Context, id string, modelType s
 		return model, nil
 	}
 
-	return nil, NewCQRSError(ErrCodeRepositoryError.String(), fmt.Sprintf("read model not found: %s:%s", modelType, id), nil)
+	return nil, NewCQRSError(ErrCodeReadModelNotFound.String(), fmt.Sprintf("read model not found: %s:%s", modelType, id), nil)
+}
+
+// GetByIDs implements BatchReadStore under a single read lock
+func (rs *InMemoryReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]ReadModel, error) {
+	if modelType == "" {
+		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
+	}
+
+	rs.mutex.RLock()
+	defer rs.mutex.RUnlock()
+
+	models := make([]ReadModel, 0, len(ids))
+	for _, id := range uniqueIDs(ids) {
+		if model, exists := rs.models[rs.getModelKey(modelType, id)]; exists {
+			models = append(models, model)
+		}
+	}
+	return models, nil
 }
 
 func (rs *InMemoryReadStore) Delete(ctx context.Context, id string, modelType string) error {
 	if id == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "id cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "id cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 	if modelType == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 
 	rs.mutex.Lock()
@@ -74,7 +92,7 @@ func (rs *InMemoryReadStore) Delete(ctx context.Context, id string, modelType st
 
 	key := rs.getModelKey(modelType, id)
 	if _, exists := rs.models[key]; !exists {
-		return NewCQRSError(ErrCodeRepositoryError.String(), fmt.Sprintf("read model not found: %s:%s", modelType, id), nil)
+		return NewCQRSError(ErrCodeReadModelNotFound.String(), fmt.Sprintf("read model not found: %s:%s", modelType, id), nil)
 	}
 
 	delete(rs.models, key)
@@ -144,7 +162,7 @@ func (rs *InMemoryReadStore) SaveBatch(ctx context.Context, readModels []ReadMod
 
 	for _, model := range readModels {
 		if model == nil {
-			return NewCQRSError(ErrCodeRepositoryError.String(), "read model cannot be nil", nil)
+			return NewCQRSError(ErrCodeRepositoryError.String(), "read model cannot be nil", nil).WithCategory(CategoryValidation)
 		}
 
 		if err := model.Validate(); err != nil {
@@ -163,7 +181,7 @@ func (rs *InMemoryReadStore) DeleteBatch(ctx context.Context, ids []string, mode
 		return nil
 	}
 	if modelType == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 
 	rs.mutex.Lock()
@@ -183,10 +201,10 @@ func (rs *InMemoryReadStore) DeleteBatch(ctx context.Context, ids []string, mode
 
 func (rs *InMemoryReadStore) CreateIndex(ctx context.Context, modelType string, fields []string) error {
 	if modelType == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 	if len(fields) == 0 {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "fields cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "fields cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 
 	rs.mutex.Lock()
@@ -206,10 +224,10 @@ func (rs *InMemoryReadStore) CreateIndex(ctx context.Context, modelType string,
 
 func (rs *InMemoryReadStore) DropIndex(ctx context.Context, modelType string, indexName string) error {
 	if modelType == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 	if indexName == "" {
-		return NewCQRSError(ErrCodeRepositoryError.String(), "index name cannot be empty", nil)
+		return NewCQRSError(ErrCodeRepositoryError.String(), "index name cannot be empty", nil).WithCategory(CategoryValidation)
 	}
 
 	rs.mutex.Lock()
@@ -0,0 +1,267 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EventReference points at an event that contributed to an invariant violation
+type EventReference struct {
+	EventID     string `json:"event_id"`
+	EventType   string `json:"event_type"`
+	AggregateID string `json:"aggregate_id"`
+	Version     int    `json:"version"`
+}
+
+// EventReferenceOf returns the reference of event
+func EventReferenceOf(event EventMessage) EventReference {
+	return EventReference{
+		EventID:     event.EventID(),
+		EventType:   event.EventType(),
+		AggregateID: event.AggregateID(),
+		Version:     event.Version(),
+	}
+}
+
+// InvariantViolation describes one broken consistency rule
+type InvariantViolation struct {
+	Rule        string           `json:"rule"`
+	AggregateID string           `json:"aggregate_id,omitempty"`
+	Message     string           `json:"message"`
+	Expected    interface{}      `json:"expected,omitempty"`
+	Actual      interface{}      `json:"actual,omitempty"`
+	Events      []EventReference `json:"events,omitempty"` // Events behind the expected value
+	DetectedAt  time.Time        `json:"detected_at"`
+}
+
+// InvariantRule checks an invariant that may span aggregates and read models.
+// Check returns the violations found; an error means the rule could not be evaluated.
+type InvariantRule interface {
+	Name() string
+	Check(ctx context.Context) ([]InvariantViolation, error)
+}
+
+type invariantRuleFunc struct {
+	name  string
+	check func(ctx context.Context) ([]InvariantViolation, error)
+}
+
+func (r *invariantRuleFunc) Name() string { return r.name }
+
+func (r *invariantRuleFunc) Check(ctx context.Context) ([]InvariantViolation, error) {
+	return r.check(ctx)
+}
+
+// NewInvariantRule creates a rule from a check function
+func NewInvariantRule(name string, check func(ctx context.Context) ([]InvariantViolation, error)) InvariantRule {
+	return &invariantRuleFunc{name: name, check: check}
+}
+
+// InvariantReport is the outcome of one run of the checker
+type InvariantReport struct {
+	StartedAt    time.Time            `json:"started_at"`
+	CompletedAt  time.Time            `json:"completed_at"`
+	RulesChecked int                  `json:"rules_checked"`
+	Violations   []InvariantViolation `json:"violations"`
+	Errors       map[string]error     `json:"-"` // Rule name -> evaluation error
+}
+
+// Passed returns true if every rule was evaluated and none was violated
+func (r *InvariantReport) Passed() bool {
+	return len(r.Violations) == 0 && len(r.Errors) == 0
+}
+
+// ViolationsOf returns the violations reported by one rule
+func (r *InvariantReport) ViolationsOf(rule string) []InvariantViolation {
+	violations := make([]InvariantViolation, 0)
+	for _, violation := range r.Violations {
+		if violation.Rule == rule {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// InvariantReportFunc receives reports that found violations or rule errors
+type InvariantReportFunc func(report *InvariantReport)
+
+// InvariantChecker runs consistency rules on demand or on a schedule
+type InvariantChecker struct {
+	mutex      sync.RWMutex
+	rules      map[string]InvariantRule
+	reportFunc []InvariantReportFunc
+	lastReport *InvariantReport
+	now        func() time.Time
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewInvariantChecker creates a checker with the given rules
+func NewInvariantChecker(rules ...InvariantRule) (*InvariantChecker, error) {
+	checker := &InvariantChecker{
+		rules: make(map[string]InvariantRule),
+		now:   time.Now,
+	}
+	for _, rule := range rules {
+		if err := checker.AddRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	return checker, nil
+}
+
+// AddRule registers a rule; rule names must be unique
+func (c *InvariantChecker) AddRule(rule InvariantRule) error {
+	if rule == nil || rule.Name() == "" {
+		return NewValidationError("invariant rule needs a name", nil)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.rules[rule.Name()]; exists {
+		return NewValidationError(fmt.Sprintf("invariant rule already registered: %s", rule.Name()), nil)
+	}
+	c.rules[rule.Name()] = rule
+	return nil
+}
+
+// RemoveRule unregisters a rule
+func (c *InvariantChecker) RemoveRule(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.rules, name)
+}
+
+// OnViolation registers a callback for reports that did not pass
+func (c *InvariantChecker) OnViolation(fn InvariantReportFunc) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.reportFunc = append(c.reportFunc, fn)
+}
+
+// LastReport returns the report of the most recent run, or nil before the first run
+func (c *InvariantChecker) LastReport() *InvariantReport {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.lastReport
+}
+
+// Check evaluates every rule in name order
+func (c *InvariantChecker) Check(ctx context.Context) *InvariantReport {
+	c.mutex.RLock()
+	rules := make([]InvariantRule, 0, len(c.rules))
+	for _, rule := range c.rules {
+		rules = append(rules, rule)
+	}
+	c.mutex.RUnlock()
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name() < rules[j].Name()
+	})
+	return c.run(ctx, rules)
+}
+
+// CheckRule evaluates a single rule
+func (c *InvariantChecker) CheckRule(ctx context.Context, name string) (*InvariantReport, error) {
+	c.mutex.RLock()
+	rule, exists := c.rules[name]
+	c.mutex.RUnlock()
+
+	if !exists {
+		return nil, NewNotFoundError("invariant rule not found: "+name, nil)
+	}
+	return c.run(ctx, []InvariantRule{rule}), nil
+}
+
+func (c *InvariantChecker) run(ctx context.Context, rules []InvariantRule) *InvariantReport {
+	report := &InvariantReport{
+		StartedAt:  c.now(),
+		Violations: make([]InvariantViolation, 0),
+		Errors:     make(map[string]error),
+	}
+
+	for _, rule := range rules {
+		if ctx.Err() != nil {
+			report.Errors[rule.Name()] = ctx.Err()
+			continue
+		}
+
+		violations, err := rule.Check(ctx)
+		report.RulesChecked++
+		if err != nil {
+			report.Errors[rule.Name()] = err
+			continue
+		}
+		for _, violation := range violations {
+			if violation.Rule == "" {
+				violation.Rule = rule.Name()
+			}
+			if violation.DetectedAt.IsZero() {
+				violation.DetectedAt = report.StartedAt
+			}
+			report.Violations = append(report.Violations, violation)
+		}
+	}
+	report.CompletedAt = c.now()
+
+	c.mutex.Lock()
+	c.lastReport = report
+	reportFuncs := append([]InvariantReportFunc(nil), c.reportFunc...)
+	c.mutex.Unlock()
+
+	if !report.Passed() {
+		for _, fn := range reportFuncs {
+			fn(report)
+		}
+	}
+	return report
+}
+
+// Start runs every rule each interval until ctx is cancelled or Stop is called
+func (c *InvariantChecker) Start(ctx context.Context, interval time.Duration) {
+	c.mutex.Lock()
+	if c.stopCh != nil {
+		c.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	c.stopCh = stopCh
+	c.mutex.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				c.Check(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the schedule loop started by Start
+func (c *InvariantChecker) Stop() {
+	c.mutex.Lock()
+	stopCh := c.stopCh
+	c.stopCh = nil
+	c.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		c.wg.Wait()
+	}
+}
@@ -0,0 +1,139 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treasuryRule 기부 이벤트 합계와 읽기 모델의 금고 값을 비교하는 규칙
+func treasuryRule(donations []EventMessage, amounts []int64, treasury *int64) InvariantRule {
+	return NewInvariantRule("treasury_matches_donations", func(ctx context.Context) ([]InvariantViolation, error) {
+		var expected int64
+		refs := make([]EventReference, 0, len(donations))
+		for i, event := range donations {
+			expected += amounts[i]
+			refs = append(refs, EventReferenceOf(event))
+		}
+		if expected == *treasury {
+			return nil, nil
+		}
+		return []InvariantViolation{{
+			AggregateID: "guild-1",
+			Message:     "treasury does not match donations",
+			Expected:    expected,
+			Actual:      *treasury,
+			Events:      refs,
+		}}, nil
+	})
+}
+
+func newDonationEvent(version int) EventMessage {
+	event := NewBaseEventMessage("TreasuryDonated")
+	event.setAggregateInfo("guild-1", "Guild", version)
+	return event
+}
+
+func TestInvariantChecker_Check_ReportsViolationWithEventReferences(t *testing.T) {
+	// Arrange
+	donations := []EventMessage{newDonationEvent(1), newDonationEvent(2)}
+	treasury := int64(150)
+	checker, err := NewInvariantChecker(treasuryRule(donations, []int64{100, 100}, &treasury))
+	require.NoError(t, err)
+
+	var alerted *InvariantReport
+	checker.OnViolation(func(report *InvariantReport) { alerted = report })
+
+	// Act
+	report := checker.Check(context.Background())
+
+	// Assert
+	assert.False(t, report.Passed())
+	assert.Equal(t, 1, report.RulesChecked)
+	require.Len(t, report.Violations, 1)
+	violation := report.Violations[0]
+	assert.Equal(t, "treasury_matches_donations", violation.Rule)
+	assert.Equal(t, int64(200), violation.Expected)
+	assert.Equal(t, int64(150), violation.Actual)
+	assert.False(t, violation.DetectedAt.IsZero())
+	require.Len(t, violation.Events, 2)
+	assert.Equal(t, donations[0].EventID(), violation.Events[0].EventID)
+	assert.Equal(t, 2, violation.Events[1].Version)
+	assert.Same(t, report, alerted)
+	assert.Same(t, report, checker.LastReport())
+}
+
+func TestInvariantChecker_Check_PassesWhenConsistent(t *testing.T) {
+	// Arrange
+	treasury := int64(100)
+	checker, err := NewInvariantChecker(treasuryRule([]EventMessage{newDonationEvent(1)}, []int64{100}, &treasury))
+	require.NoError(t, err)
+	checker.OnViolation(func(report *InvariantReport) { t.Fatal("unexpected violation alert") })
+
+	// Act
+	report := checker.Check(context.Background())
+
+	// Assert
+	assert.True(t, report.Passed())
+	assert.Empty(t, report.Violations)
+}
+
+func TestInvariantChecker_Check_RecordsRuleErrors(t *testing.T) {
+	// Arrange
+	checker, err := NewInvariantChecker(
+		NewInvariantRule("broken", func(ctx context.Context) ([]InvariantViolation, error) {
+			return nil, errors.New("read store unavailable")
+		}),
+		NewInvariantRule("healthy", func(ctx context.Context) ([]InvariantViolation, error) {
+			return nil, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	// Act
+	report := checker.Check(context.Background())
+
+	// Assert
+	assert.False(t, report.Passed())
+	assert.Equal(t, 2, report.RulesChecked)
+	assert.Empty(t, report.Violations)
+	assert.EqualError(t, report.Errors["broken"], "read store unavailable")
+}
+
+func TestInvariantChecker_AddRule_RejectsDuplicateNames(t *testing.T) {
+	// Arrange
+	rule := NewInvariantRule("unique", func(ctx context.Context) ([]InvariantViolation, error) { return nil, nil })
+	checker, err := NewInvariantChecker(rule)
+	require.NoError(t, err)
+
+	// Act
+	err = checker.AddRule(rule)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestInvariantChecker_CheckRule(t *testing.T) {
+	// Arrange
+	calls := 0
+	checker, err := NewInvariantChecker(
+		NewInvariantRule("counted", func(ctx context.Context) ([]InvariantViolation, error) {
+			calls++
+			return []InvariantViolation{{Message: "always broken"}}, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	// Act
+	report, err := checker.CheckRule(context.Background(), "counted")
+	_, missingErr := checker.CheckRule(context.Background(), "missing")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Len(t, report.ViolationsOf("counted"), 1)
+	assert.Error(t, missingErr)
+}
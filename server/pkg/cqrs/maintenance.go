@@ -0,0 +1,181 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaintenanceModeFlag is the default feature flag that switches on soft maintenance
+const MaintenanceModeFlag = "maintenance_mode"
+
+// MaintenanceMode decides what happens to gated commands while maintenance is on
+type MaintenanceMode string
+
+const (
+	MaintenanceReject MaintenanceMode = "reject" // Fail gated commands immediately
+	MaintenanceQueue  MaintenanceMode = "queue"  // Hold gated commands until maintenance ends or the caller gives up
+)
+
+// MaintenanceConfig configures a MaintenanceDispatcher
+type MaintenanceConfig struct {
+	Flags        FeatureFlags
+	Flag         string                                          // Flag name, default MaintenanceModeFlag
+	Mode         MaintenanceMode                                 // Default MaintenanceReject
+	IsAdmin      func(ctx context.Context, command Command) bool // Optional; admin commands are never gated
+	MaxQueued    int                                             // Commands held at once in queue mode, default 1000
+	PollInterval time.Duration                                   // How often held commands re-check the flag, default 500ms
+}
+
+// MaintenanceDispatcher gates commands behind a maintenance feature flag.
+// While the flag is on, non-admin commands fail with a MAINTENANCE_IN_PROGRESS error
+// (or wait in queue mode); queries go through the query dispatcher and are unaffected.
+// Drain stops accepting commands and waits for in-flight ones before shutdown.
+type MaintenanceDispatcher struct {
+	CommandDispatcher
+	config MaintenanceConfig
+
+	mutex    sync.Mutex
+	queued   int
+	draining bool
+	drainCh  chan struct{}
+	inFlight sync.WaitGroup
+}
+
+// NewMaintenanceDispatcher wraps dispatcher with maintenance gating
+func NewMaintenanceDispatcher(dispatcher CommandDispatcher, config MaintenanceConfig) *MaintenanceDispatcher {
+	if config.Flag == "" {
+		config.Flag = MaintenanceModeFlag
+	}
+	if config.Mode == "" {
+		config.Mode = MaintenanceReject
+	}
+	if config.MaxQueued <= 0 {
+		config.MaxQueued = 1000
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 500 * time.Millisecond
+	}
+	return &MaintenanceDispatcher{
+		CommandDispatcher: dispatcher,
+		config:            config,
+		drainCh:           make(chan struct{}),
+	}
+}
+
+type maintenanceBypassKey struct{}
+
+// WithMaintenanceBypass marks ctx as an operator request that is never gated (except by Drain)
+func WithMaintenanceBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, maintenanceBypassKey{}, true)
+}
+
+// IsMaintenanceBypass reports whether ctx was marked with WithMaintenanceBypass
+func IsMaintenanceBypass(ctx context.Context) bool {
+	bypass, _ := ctx.Value(maintenanceBypassKey{}).(bool)
+	return bypass
+}
+
+// Active reports whether the maintenance flag is on
+func (d *MaintenanceDispatcher) Active(ctx context.Context) bool {
+	return d.config.Flags != nil && d.config.Flags.IsEnabled(ctx, d.config.Flag)
+}
+
+// Queued returns the number of commands held in queue mode
+func (d *MaintenanceDispatcher) Queued() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.queued
+}
+
+func (d *MaintenanceDispatcher) Dispatch(ctx context.Context, command Command) (*CommandResult, error) {
+	if !d.enter() {
+		return NewFailedCommandResult(NewMaintenanceError("dispatcher is draining for shutdown")), nil
+	}
+	defer d.inFlight.Done()
+
+	if command != nil && d.Active(ctx) && !d.exempt(ctx, command) {
+		if d.config.Mode != MaintenanceQueue {
+			return NewFailedCommandResult(NewMaintenanceError(fmt.Sprintf("command %s rejected during maintenance", command.CommandType()))), nil
+		}
+		if err := d.hold(ctx, command); err != nil {
+			return NewFailedCommandResult(err), nil
+		}
+	}
+	return d.CommandDispatcher.Dispatch(ctx, command)
+}
+
+// Drain stops accepting commands, releases held ones with a maintenance error
+// and waits for in-flight commands to finish or ctx to be done
+func (d *MaintenanceDispatcher) Drain(ctx context.Context) error {
+	d.mutex.Lock()
+	if !d.draining {
+		d.draining = true
+		close(d.drainCh)
+	}
+	d.mutex.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enter registers an in-flight command unless draining has begun
+func (d *MaintenanceDispatcher) enter() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inFlight.Add(1)
+	return true
+}
+
+func (d *MaintenanceDispatcher) exempt(ctx context.Context, command Command) bool {
+	if IsMaintenanceBypass(ctx) {
+		return true
+	}
+	return d.config.IsAdmin != nil && d.config.IsAdmin(ctx, command)
+}
+
+// hold waits until maintenance ends; commands are released together, not in arrival order
+func (d *MaintenanceDispatcher) hold(ctx context.Context, command Command) error {
+	d.mutex.Lock()
+	if d.queued >= d.config.MaxQueued {
+		d.mutex.Unlock()
+		return NewMaintenanceError(fmt.Sprintf("maintenance queue is full, command %s rejected", command.CommandType()))
+	}
+	d.queued++
+	d.mutex.Unlock()
+
+	defer func() {
+		d.mutex.Lock()
+		d.queued--
+		d.mutex.Unlock()
+	}()
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !d.Active(ctx) {
+				return nil
+			}
+		case <-d.drainCh:
+			return NewMaintenanceError(fmt.Sprintf("command %s dropped while draining for shutdown", command.CommandType()))
+		case <-ctx.Done():
+			return NewMaintenanceError(fmt.Sprintf("command %s was still queued when the caller gave up: %v", command.CommandType(), ctx.Err()))
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaintenanceTestDispatcher(t *testing.T, config MaintenanceConfig, handle func(ctx context.Context, command Command) (*CommandResult, error)) *MaintenanceDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = handle
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+	return NewMaintenanceDispatcher(dispatcher, config)
+}
+
+func succeedMaintenanceTest(ctx context.Context, command Command) (*CommandResult, error) {
+	return NewCommandResult(command.ID(), 1), nil
+}
+
+func TestMaintenanceDispatcher_RejectsNonAdminCommands(t *testing.T) {
+	// Arrange
+	flags := NewInMemoryFeatureFlags()
+	dispatcher := newMaintenanceTestDispatcher(t, MaintenanceConfig{
+		Flags:   flags,
+		IsAdmin: func(ctx context.Context, command Command) bool { return command.UserID() == "operator" },
+	}, succeedMaintenanceTest)
+	ctx := context.Background()
+	admin := NewTestCommand("guild-1", "admin")
+	admin.SetUserID("operator")
+
+	// Act
+	before, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "before"))
+	require.NoError(t, err)
+	flags.Set(MaintenanceModeFlag, true)
+	rejected, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "during"))
+	require.NoError(t, err)
+	adminResult, err := dispatcher.Dispatch(ctx, admin)
+	require.NoError(t, err)
+	bypassed, err := dispatcher.Dispatch(WithMaintenanceBypass(ctx), NewTestCommand("guild-1", "bypass"))
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, before.Success)
+	assert.False(t, rejected.Success)
+	assert.True(t, errors.Is(rejected.Error, ErrMaintenanceInProgress))
+	assert.True(t, IsInfrastructureError(rejected.Error))
+	var cqrsErr *CQRSError
+	require.True(t, errors.As(rejected.Error, &cqrsErr))
+	assert.Equal(t, ErrCodeMaintenanceInProgress.String(), cqrsErr.Code)
+	assert.True(t, adminResult.Success)
+	assert.True(t, bypassed.Success)
+	assert.True(t, dispatcher.Active(ctx))
+}
+
+func TestMaintenanceDispatcher_QueueModeReleasesWhenFlagClears(t *testing.T) {
+	// Arrange
+	flags := NewInMemoryFeatureFlags(MaintenanceModeFlag)
+	dispatcher := newMaintenanceTestDispatcher(t, MaintenanceConfig{
+		Flags:        flags,
+		Mode:         MaintenanceQueue,
+		MaxQueued:    1,
+		PollInterval: 5 * time.Millisecond,
+	}, succeedMaintenanceTest)
+	ctx := context.Background()
+	held := make(chan *CommandResult, 1)
+
+	// Act
+	go func() {
+		result, _ := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "held"))
+		held <- result
+	}()
+	require.Eventually(t, func() bool { return dispatcher.Queued() == 1 }, time.Second, time.Millisecond)
+	overflow, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "overflow"))
+	require.NoError(t, err)
+	flags.Set(MaintenanceModeFlag, false)
+
+	// Assert
+	assert.False(t, overflow.Success)
+	assert.True(t, errors.Is(overflow.Error, ErrMaintenanceInProgress))
+	select {
+	case result := <-held:
+		assert.True(t, result.Success)
+	case <-time.After(time.Second):
+		t.Fatal("queued command was not released")
+	}
+	assert.Zero(t, dispatcher.Queued())
+}
+
+func TestMaintenanceDispatcher_QueuedCommandGivesUpWithCaller(t *testing.T) {
+	// Arrange
+	dispatcher := newMaintenanceTestDispatcher(t, MaintenanceConfig{
+		Flags:        NewInMemoryFeatureFlags(MaintenanceModeFlag),
+		Mode:         MaintenanceQueue,
+		PollInterval: 5 * time.Millisecond,
+	}, succeedMaintenanceTest)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	result, err := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "held"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, errors.Is(result.Error, ErrMaintenanceInProgress))
+}
+
+func TestMaintenanceDispatcher_DrainWaitsForInFlightCommands(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	flags := NewInMemoryFeatureFlags()
+	dispatcher := newMaintenanceTestDispatcher(t, MaintenanceConfig{
+		Flags:        flags,
+		Mode:         MaintenanceQueue,
+		PollInterval: 5 * time.Millisecond,
+	}, func(ctx context.Context, command Command) (*CommandResult, error) {
+		if command.(*TestCommand).TestData == "slow" {
+			close(started)
+			<-release
+		}
+		return NewCommandResult(command.ID(), 1), nil
+	})
+	ctx := context.Background()
+	slow := make(chan *CommandResult, 1)
+	queued := make(chan *CommandResult, 1)
+
+	go func() {
+		result, _ := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "slow"))
+		slow <- result
+	}()
+	<-started
+	flags.Set(MaintenanceModeFlag, true)
+	go func() {
+		result, _ := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "queued"))
+		queued <- result
+	}()
+	require.Eventually(t, func() bool { return dispatcher.Queued() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	drained := make(chan error, 1)
+	go func() { drained <- dispatcher.Drain(ctx) }()
+	dropped := <-queued
+	afterDrain, err := dispatcher.Dispatch(WithMaintenanceBypass(ctx), NewTestCommand("guild-1", "late"))
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, dropped.Success)
+	assert.True(t, errors.Is(dropped.Error, ErrMaintenanceInProgress))
+	assert.False(t, afterDrain.Success)
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the in-flight command finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-drained)
+	assert.True(t, (<-slow).Success)
+}
+
+func TestMaintenanceDispatcher_DrainHonorsDeadline(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	dispatcher := newMaintenanceTestDispatcher(t, MaintenanceConfig{}, func(ctx context.Context, command Command) (*CommandResult, error) {
+		close(started)
+		<-release
+		return NewCommandResult(command.ID(), 1), nil
+	})
+	go dispatcher.Dispatch(context.Background(), NewTestCommand("guild-1", "stuck"))
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := dispatcher.Drain(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
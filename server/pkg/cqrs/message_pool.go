@@ -0,0 +1,72 @@
+package cqrs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// eventMessagePool reuses BaseEventMessage envelopes on hot publish/consume paths.
+// Pooled messages must only be released once nothing else holds a reference to them
+// (e.g. after a transient notification has been published), never after Aggregate.Apply.
+var eventMessagePool = sync.Pool{
+	New: func() interface{} {
+		return &BaseEventMessage{Metadata_: make(map[string]interface{})}
+	},
+}
+
+// commandPool reuses BaseCommand envelopes for short-lived command dispatches
+var commandPool = sync.Pool{
+	New: func() interface{} {
+		return &BaseCommand{}
+	},
+}
+
+// AcquireEventMessage returns a pooled BaseEventMessage initialized like NewBaseEventMessage
+func AcquireEventMessage(eventType string) *BaseEventMessage {
+	e := eventMessagePool.Get().(*BaseEventMessage)
+	e.EventID_ = uuid.NewString()
+	e.EventType_ = eventType
+	e.Timestamp_ = time.Now().UTC()
+	return e
+}
+
+// ReleaseEventMessage resets the message and returns it to the pool
+func ReleaseEventMessage(e *BaseEventMessage) {
+	if e == nil {
+		return
+	}
+
+	metadata := e.Metadata_
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	clear(metadata)
+
+	*e = BaseEventMessage{Metadata_: metadata}
+	eventMessagePool.Put(e)
+}
+
+// AcquireCommand returns a pooled BaseCommand initialized like NewBaseCommand
+func AcquireCommand(commandType, aggregateID, aggregateType string, data interface{}) *BaseCommand {
+	c := commandPool.Get().(*BaseCommand)
+	c.commandID = uuid.New().String()
+	c.commandType = commandType
+	c.aggregateID = aggregateID
+	c.aggregateType = aggregateType
+	c.timestamp = time.Now()
+	c.data = data
+	return c
+}
+
+// ReleaseCommand resets the command and returns it to the pool.
+// Call it only after the dispatcher has finished handling the command.
+func ReleaseCommand(c *BaseCommand) {
+	if c == nil {
+		return
+	}
+
+	*c = BaseCommand{}
+	commandPool.Put(c)
+}
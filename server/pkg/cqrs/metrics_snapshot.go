@@ -0,0 +1,421 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricSample is one value reported by a metrics source
+type MetricSample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+// MetricPoint is a sample recorded at a point in time
+type MetricPoint struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Value     float64           `json:"value"`
+}
+
+// SeriesKey identifies the series of the point: the name followed by its sorted labels
+func (p MetricPoint) SeriesKey() string {
+	return MetricSeriesKey(p.Name, p.Labels)
+}
+
+// MetricSeriesKey formats a series identifier such as `eventbus_published{source="main"}`
+func MetricSeriesKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%q", key, labels[key])
+	}
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// TimeSeriesQuery selects recorded points
+type TimeSeriesQuery struct {
+	Name   string            `json:"name"`             // Required metric name
+	Labels map[string]string `json:"labels,omitempty"` // Points must carry every label given
+	From   time.Time         `json:"from"`             // Inclusive; zero means no lower bound
+	To     time.Time         `json:"to"`               // Inclusive; zero means no upper bound
+	Step   time.Duration     `json:"step,omitempty"`   // Averages points into buckets of Step; zero returns raw points
+}
+
+// Validate validates the time series query
+func (q TimeSeriesQuery) Validate() error {
+	if q.Name == "" {
+		return NewValidationError("metric name is required", nil)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return NewValidationError("to must not be before from", nil)
+	}
+	if q.Step < 0 {
+		return NewValidationError("step cannot be negative", nil)
+	}
+	return nil
+}
+
+// Matches reports whether the point belongs to the query's name, labels and time range
+func (q TimeSeriesQuery) Matches(point MetricPoint) bool {
+	if point.Name != q.Name {
+		return false
+	}
+	for key, value := range q.Labels {
+		if point.Labels[key] != value {
+			return false
+		}
+	}
+	if !q.From.IsZero() && point.Timestamp.Before(q.From) {
+		return false
+	}
+	return q.To.IsZero() || !point.Timestamp.After(q.To)
+}
+
+// TimeSeriesStore persists metric points for historical graphs
+type TimeSeriesStore interface {
+	// Append records points
+	Append(ctx context.Context, points []MetricPoint) error
+	// Range returns raw points matching the query ordered by series then time; Step is ignored
+	Range(ctx context.Context, query TimeSeriesQuery) ([]MetricPoint, error)
+	// DeleteBefore removes points older than cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// DownsamplePoints averages each series into buckets of step aligned to the Unix epoch.
+// Points must be ordered by series then time, as TimeSeriesStore.Range returns them.
+func DownsamplePoints(points []MetricPoint, step time.Duration) []MetricPoint {
+	if step <= 0 || len(points) == 0 {
+		return points
+	}
+
+	var result []MetricPoint
+	var sum float64
+	var count int
+	flush := func() {
+		if count > 0 {
+			result[len(result)-1].Value = sum / float64(count)
+		}
+	}
+
+	var series string
+	var bucket time.Time
+	for _, point := range points {
+		pointSeries := point.SeriesKey()
+		pointBucket := point.Timestamp.Truncate(step)
+		if count > 0 && pointSeries == series && pointBucket.Equal(bucket) {
+			sum += point.Value
+			count++
+			continue
+		}
+		flush()
+		series, bucket = pointSeries, pointBucket
+		sum, count = point.Value, 1
+		result = append(result, MetricPoint{Name: point.Name, Labels: point.Labels, Timestamp: pointBucket})
+	}
+	flush()
+	return result
+}
+
+// MetricsSource reports the current value of a set of metrics
+type MetricsSource func(ctx context.Context) ([]MetricSample, error)
+
+// EventBusMetricsSource reports event bus throughput and async queue metrics
+func EventBusMetricsSource(bus interface{ GetMetrics() *EventBusMetrics }) MetricsSource {
+	return func(ctx context.Context) ([]MetricSample, error) {
+		metrics := bus.GetMetrics()
+		if metrics == nil {
+			return nil, nil
+		}
+		return []MetricSample{
+			{Name: "eventbus_published_events", Value: float64(metrics.PublishedEvents)},
+			{Name: "eventbus_processed_events", Value: float64(metrics.ProcessedEvents)},
+			{Name: "eventbus_failed_events", Value: float64(metrics.FailedEvents)},
+			{Name: "eventbus_active_subscribers", Value: float64(metrics.ActiveSubscribers)},
+			{Name: "eventbus_average_latency_ms", Value: durationMillis(metrics.AverageLatency)},
+			{Name: "eventbus_queue_depth", Value: float64(metrics.QueueDepth)},
+			{Name: "eventbus_dropped_events", Value: float64(metrics.DroppedEvents)},
+			{Name: "eventbus_rejected_events", Value: float64(metrics.RejectedEvents)},
+		}, nil
+	}
+}
+
+// ProjectionMetricsSource reports projection counts, throughput and per-projection lag
+func ProjectionMetricsSource(manager interface{ GetMetrics() *ProjectionMetrics }) MetricsSource {
+	return func(ctx context.Context) ([]MetricSample, error) {
+		metrics := manager.GetMetrics()
+		if metrics == nil {
+			return nil, nil
+		}
+		samples := []MetricSample{
+			{Name: "projection_total", Value: float64(metrics.TotalProjections)},
+			{Name: "projection_running", Value: float64(metrics.RunningProjections)},
+			{Name: "projection_faulted", Value: float64(metrics.FaultedProjections)},
+			{Name: "projection_processed_events", Value: float64(metrics.ProcessedEvents)},
+			{Name: "projection_average_processing_ms", Value: durationMillis(metrics.AverageProcessingTime)},
+			{Name: "projection_max_events_behind", Value: float64(metrics.MaxEventsBehind)},
+		}
+		for name, lag := range metrics.Lag {
+			samples = append(samples, MetricSample{
+				Name:   "projection_events_behind",
+				Labels: map[string]string{"projection": name},
+				Value:  float64(lag.EventsBehind),
+			})
+		}
+		return samples, nil
+	}
+}
+
+// StorageMetricsSource reports per aggregate type storage totals from the collector
+func StorageMetricsSource(collector *StorageMetricsCollector) MetricsSource {
+	return func(ctx context.Context) ([]MetricSample, error) {
+		var samples []MetricSample
+		for _, aggregateType := range collector.AggregateTypes() {
+			summary, err := collector.Collect(ctx, StorageMetricsQuery{AggregateType: aggregateType, Limit: 1})
+			if err != nil {
+				return samples, err
+			}
+			labels := map[string]string{"aggregate_type": aggregateType}
+			samples = append(samples,
+				MetricSample{Name: "repository_aggregates", Labels: labels, Value: float64(summary.Aggregates)},
+				MetricSample{Name: "repository_events", Labels: labels, Value: float64(summary.TotalEvents)},
+				MetricSample{Name: "repository_snapshots", Labels: labels, Value: float64(summary.TotalSnapshots)},
+				MetricSample{Name: "repository_state_bytes", Labels: labels, Value: float64(summary.TotalStateSize)},
+			)
+		}
+		return samples, nil
+	}
+}
+
+func durationMillis(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+// MetricsSnapshotConfig configures a MetricsSnapshotter
+type MetricsSnapshotConfig struct {
+	Interval  time.Duration // Time between snapshots (default 1m)
+	Retention time.Duration // Points older than this are deleted after each snapshot (default 7 days)
+}
+
+// MetricsSnapshotter periodically records metrics from registered sources into a time series store.
+// Every sample is labelled with source=<name> so several buses or managers can share metric names.
+type MetricsSnapshotter struct {
+	store  TimeSeriesStore
+	config MetricsSnapshotConfig
+	now    func() time.Time
+
+	mutex   sync.RWMutex
+	sources map[string]MetricsSource
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMetricsSnapshotter creates a snapshotter writing into store
+func NewMetricsSnapshotter(store TimeSeriesStore, config MetricsSnapshotConfig) *MetricsSnapshotter {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+	return &MetricsSnapshotter{
+		store:   store,
+		config:  config,
+		now:     time.Now,
+		sources: make(map[string]MetricsSource),
+	}
+}
+
+// AddSource registers a metrics source under a unique name
+func (s *MetricsSnapshotter) AddSource(name string, source MetricsSource) error {
+	if name == "" || source == nil {
+		return NewValidationError("metrics source name and function are required", nil)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.sources[name]; exists {
+		return NewValidationError(fmt.Sprintf("metrics source already registered: %s", name), nil)
+	}
+	s.sources[name] = source
+	return nil
+}
+
+// Snapshot records one point per sample of every source and trims points past retention.
+// A failing source does not prevent the others from being recorded; its error is returned.
+func (s *MetricsSnapshotter) Snapshot(ctx context.Context) (int, error) {
+	s.mutex.RLock()
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sources := make(map[string]MetricsSource, len(s.sources))
+	for name, source := range s.sources {
+		sources[name] = source
+	}
+	s.mutex.RUnlock()
+	sort.Strings(names)
+
+	timestamp := s.now().UTC()
+	var points []MetricPoint
+	var errs []error
+	for _, name := range names {
+		samples, err := sources[name](ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("metrics source %s: %w", name, err))
+		}
+		for _, sample := range samples {
+			labels := make(map[string]string, len(sample.Labels)+1)
+			for key, value := range sample.Labels {
+				labels[key] = value
+			}
+			labels["source"] = name
+			points = append(points, MetricPoint{Name: sample.Name, Labels: labels, Timestamp: timestamp, Value: sample.Value})
+		}
+	}
+
+	if len(points) > 0 {
+		if err := s.store.Append(ctx, points); err != nil {
+			return 0, NewCQRSError(ErrCodeRepositoryError.String(), "failed to record metrics snapshot", err)
+		}
+	}
+	if _, err := s.store.DeleteBefore(ctx, timestamp.Add(-s.config.Retention)); err != nil {
+		errs = append(errs, fmt.Errorf("metrics retention: %w", err))
+	}
+	return len(points), errors.Join(errs...)
+}
+
+// History returns recorded points for the query, downsampled when Step is set
+func (s *MetricsSnapshotter) History(ctx context.Context, query TimeSeriesQuery) ([]MetricPoint, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	points, err := s.store.Range(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return DownsamplePoints(points, query.Step), nil
+}
+
+// Start records a snapshot each interval until ctx is cancelled or Stop is called
+func (s *MetricsSnapshotter) Start(ctx context.Context) {
+	s.mutex.Lock()
+	if s.stopCh != nil {
+		s.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	s.stopCh = stopCh
+	s.mutex.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				s.Snapshot(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the schedule loop started by Start
+func (s *MetricsSnapshotter) Stop() {
+	s.mutex.Lock()
+	stopCh := s.stopCh
+	s.stopCh = nil
+	s.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		s.wg.Wait()
+	}
+}
+
+// InMemoryTimeSeriesStore keeps points in memory, for tests and single-node development
+type InMemoryTimeSeriesStore struct {
+	mutex  sync.RWMutex
+	series map[string][]MetricPoint // series key -> points in time order
+}
+
+// NewInMemoryTimeSeriesStore creates an empty in-memory time series store
+func NewInMemoryTimeSeriesStore() *InMemoryTimeSeriesStore {
+	return &InMemoryTimeSeriesStore{series: make(map[string][]MetricPoint)}
+}
+
+func (s *InMemoryTimeSeriesStore) Append(ctx context.Context, points []MetricPoint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, point := range points {
+		key := point.SeriesKey()
+		series := append(s.series[key], point)
+		// Keep time order when points arrive late
+		for i := len(series) - 1; i > 0 && series[i].Timestamp.Before(series[i-1].Timestamp); i-- {
+			series[i], series[i-1] = series[i-1], series[i]
+		}
+		s.series[key] = series
+	}
+	return nil
+}
+
+func (s *InMemoryTimeSeriesStore) Range(ctx context.Context, query TimeSeriesQuery) ([]MetricPoint, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	keys := make([]string, 0, len(s.series))
+	for key := range s.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []MetricPoint
+	for _, key := range keys {
+		for _, point := range s.series[key] {
+			if query.Matches(point) {
+				result = append(result, point)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *InMemoryTimeSeriesStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var removed int64
+	for key, series := range s.series {
+		keep := sort.Search(len(series), func(i int) bool { return !series[i].Timestamp.Before(cutoff) })
+		removed += int64(keep)
+		if keep == len(series) {
+			delete(s.series, key)
+			continue
+		}
+		s.series[key] = series[keep:]
+	}
+	return removed, nil
+}
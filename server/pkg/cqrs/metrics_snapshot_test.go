@@ -0,0 +1,125 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticBusMetrics struct{ metrics *EventBusMetrics }
+
+func (s staticBusMetrics) GetMetrics() *EventBusMetrics { return s.metrics }
+
+type staticProjectionMetrics struct{ metrics *ProjectionMetrics }
+
+func (s staticProjectionMetrics) GetMetrics() *ProjectionMetrics { return s.metrics }
+
+func TestMetricsSnapshotter_RecordsLabelledSamples(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryTimeSeriesStore()
+	snapshotter := NewMetricsSnapshotter(store, MetricsSnapshotConfig{})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	snapshotter.now = func() time.Time { return now }
+
+	bus := &EventBusMetrics{PublishedEvents: 10, AverageLatency: 1500 * time.Microsecond}
+	require.NoError(t, snapshotter.AddSource("main_bus", EventBusMetricsSource(staticBusMetrics{bus})))
+	require.NoError(t, snapshotter.AddSource("projections", ProjectionMetricsSource(staticProjectionMetrics{&ProjectionMetrics{
+		Lag: map[string]ProjectionLag{"guild_ranking": {EventsBehind: 7}},
+	}})))
+	assert.Error(t, snapshotter.AddSource("main_bus", EventBusMetricsSource(staticBusMetrics{bus})))
+
+	// Act
+	first, err := snapshotter.Snapshot(ctx)
+	require.NoError(t, err)
+	bus.PublishedEvents = 25
+	now = now.Add(time.Minute)
+	_, err = snapshotter.Snapshot(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 15, first) // 8 bus samples, 6 projection samples and one lag sample
+	published, err := snapshotter.History(ctx, TimeSeriesQuery{Name: "eventbus_published_events"})
+	require.NoError(t, err)
+	require.Len(t, published, 2)
+	assert.Equal(t, map[string]string{"source": "main_bus"}, published[0].Labels)
+	assert.Equal(t, []float64{10, 25}, []float64{published[0].Value, published[1].Value})
+
+	latency, err := snapshotter.History(ctx, TimeSeriesQuery{Name: "eventbus_average_latency_ms", To: now.Add(-time.Second)})
+	require.NoError(t, err)
+	require.Len(t, latency, 1)
+	assert.Equal(t, 1.5, latency[0].Value)
+
+	lag, err := snapshotter.History(ctx, TimeSeriesQuery{Name: "projection_events_behind", Labels: map[string]string{"projection": "guild_ranking"}})
+	require.NoError(t, err)
+	require.Len(t, lag, 2)
+	assert.Equal(t, `projection_events_behind{projection="guild_ranking",source="projections"}`, lag[0].SeriesKey())
+}
+
+func TestMetricsSnapshotter_KeepsGoingWhenSourceFailsAndTrimsRetention(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryTimeSeriesStore()
+	snapshotter := NewMetricsSnapshotter(store, MetricsSnapshotConfig{Retention: time.Hour})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	snapshotter.now = func() time.Time { return now }
+
+	require.NoError(t, snapshotter.AddSource("broken", func(ctx context.Context) ([]MetricSample, error) {
+		return nil, errors.New("repository unavailable")
+	}))
+	require.NoError(t, snapshotter.AddSource("static", func(ctx context.Context) ([]MetricSample, error) {
+		return []MetricSample{{Name: "players_online", Value: 42}}, nil
+	}))
+
+	// Act
+	recorded, err := snapshotter.Snapshot(ctx)
+	now = now.Add(90 * time.Minute)
+	_, _ = snapshotter.Snapshot(ctx)
+
+	// Assert
+	assert.Equal(t, 1, recorded)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+
+	points, err := snapshotter.History(ctx, TimeSeriesQuery{Name: "players_online"})
+	require.NoError(t, err)
+	require.Len(t, points, 1) // First snapshot is past retention
+	assert.Equal(t, now, points[0].Timestamp)
+}
+
+func TestDownsamplePoints_AveragesPerSeriesBucket(t *testing.T) {
+	// Arrange
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	a := map[string]string{"source": "a"}
+	b := map[string]string{"source": "b"}
+	points := []MetricPoint{
+		{Name: "m", Labels: a, Timestamp: base, Value: 1},
+		{Name: "m", Labels: a, Timestamp: base.Add(2 * time.Minute), Value: 3},
+		{Name: "m", Labels: a, Timestamp: base.Add(6 * time.Minute), Value: 10},
+		{Name: "m", Labels: b, Timestamp: base.Add(time.Minute), Value: 4},
+	}
+
+	// Act
+	downsampled := DownsamplePoints(points, 5*time.Minute)
+
+	// Assert
+	require.Len(t, downsampled, 3)
+	assert.Equal(t, 2.0, downsampled[0].Value)
+	assert.Equal(t, base.Add(5*time.Minute), downsampled[1].Timestamp)
+	assert.Equal(t, 10.0, downsampled[1].Value)
+	assert.Equal(t, b, downsampled[2].Labels)
+	assert.Equal(t, 4.0, downsampled[2].Value)
+}
+
+func TestTimeSeriesQuery_Validate(t *testing.T) {
+	now := time.Now()
+
+	assert.Error(t, TimeSeriesQuery{}.Validate())
+	assert.Error(t, TimeSeriesQuery{Name: "m", From: now, To: now.Add(-time.Minute)}.Validate())
+	assert.Error(t, TimeSeriesQuery{Name: "m", Step: -time.Second}.Validate())
+	assert.NoError(t, TimeSeriesQuery{Name: "m", From: now, To: now}.Validate())
+}
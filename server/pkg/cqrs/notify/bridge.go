@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ScopeResolver returns the scope (e.g. guild ID) whose channel configuration applies to an event
+type ScopeResolver func(view EventView) string
+
+// GuildScopeResolver uses the payload's guild_id, falling back to the aggregate ID
+func GuildScopeResolver(view EventView) string {
+	if guildID, ok := view.Data["guild_id"].(string); ok && guildID != "" {
+		return guildID
+	}
+	if guildID, ok := view.Metadata["guild_id"].(string); ok && guildID != "" {
+		return guildID
+	}
+	return view.AggregateID
+}
+
+// BridgeOption configures a Bridge
+type BridgeOption func(*Bridge)
+
+// WithHTTPClient sets the client used to call webhooks
+func WithHTTPClient(client *http.Client) BridgeOption {
+	return func(b *Bridge) {
+		b.client = client
+	}
+}
+
+// WithScopeResolver overrides how events are mapped to channel configurations
+func WithScopeResolver(resolver ScopeResolver) BridgeOption {
+	return func(b *Bridge) {
+		b.resolveScope = resolver
+	}
+}
+
+// Bridge is a notification event handler that posts templated events to chat webhooks
+type Bridge struct {
+	*cqrs.BaseEventHandler
+	configs      *ChannelConfigStore
+	templates    map[string]*Template
+	client       *http.Client
+	resolveScope ScopeResolver
+}
+
+// NewBridge creates a bridge for the given templates (see DefaultGuildTemplates)
+func NewBridge(configs *ChannelConfigStore, templates map[string]Template, options ...BridgeOption) (*Bridge, error) {
+	eventTypes := make([]string, 0, len(templates))
+	compiled := make(map[string]*Template, len(templates))
+	for eventType, tmpl := range templates {
+		tmpl := tmpl
+		if err := tmpl.compile(eventType); err != nil {
+			return nil, err
+		}
+		compiled[eventType] = &tmpl
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	bridge := &Bridge{
+		BaseEventHandler: cqrs.NewBaseEventHandler("NotificationBridge", cqrs.NotificationHandler, eventTypes),
+		configs:          configs,
+		templates:        compiled,
+		client:           &http.Client{Timeout: 5 * time.Second},
+		resolveScope:     GuildScopeResolver,
+	}
+	for _, option := range options {
+		option(bridge)
+	}
+	return bridge, nil
+}
+
+// Handle renders the event and posts it to every subscribed webhook of its scope.
+// Delivery errors are joined so one broken webhook does not hide the others.
+func (b *Bridge) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	tmpl, exists := b.templates[event.EventType()]
+	if !exists {
+		return nil
+	}
+
+	view := NewEventView(event)
+	scopeID := b.resolveScope(view)
+	if scopeID == "" {
+		return nil
+	}
+
+	config, err := b.configs.Get(ctx, scopeID)
+	if err != nil {
+		return fmt.Errorf("failed to load notification channels for %s: %w", scopeID, err)
+	}
+	if config == nil {
+		return nil
+	}
+
+	webhooks := config.WebhooksFor(event.EventType())
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	message, err := tmpl.Render(view)
+	if err != nil {
+		return fmt.Errorf("failed to render %s notification: %w", event.EventType(), err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if err := b.post(ctx, webhook, message); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bridge) post(ctx context.Context, webhook Webhook, message Message) error {
+	var payload interface{}
+	switch webhook.Platform {
+	case PlatformDiscord:
+		payload = discordPayload(message)
+	case PlatformSlack:
+		payload = slackPayload(message)
+	default:
+		return fmt.Errorf("unsupported platform %q", webhook.Platform)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
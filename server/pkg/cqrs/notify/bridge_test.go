@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type guildCreatedEvent struct {
+	cqrs.BaseEventMessage
+	GuildID         string `json:"guild_id"`
+	Name            string `json:"name"`
+	FounderUsername string `json:"founder_username"`
+}
+
+func (e *guildCreatedEvent) EventData() interface{} {
+	return struct {
+		GuildID         string `json:"guild_id"`
+		Name            string `json:"name"`
+		FounderUsername string `json:"founder_username"`
+	}{e.GuildID, e.Name, e.FounderUsername}
+}
+
+func newGuildCreatedEvent(guildID string) *guildCreatedEvent {
+	return &guildCreatedEvent{
+		BaseEventMessage: *cqrs.NewBaseEventMessage(GuildCreatedEventType),
+		GuildID:          guildID,
+		Name:             "Allies",
+		FounderUsername:  "commander",
+	}
+}
+
+// webhookRecorder 수신한 웹훅 본문을 기록하는 테스트 서버
+type webhookRecorder struct {
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body map[string]interface{}
+	json.NewDecoder(req.Body).Decode(&body)
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestBridge_PostsToSubscribedWebhooks(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	discord, slack := &webhookRecorder{}, &webhookRecorder{}
+	discordServer, slackServer := httptest.NewServer(discord), httptest.NewServer(slack)
+	defer discordServer.Close()
+	defer slackServer.Close()
+
+	configs := NewChannelConfigStore(cqrs.NewInMemoryReadStore())
+	config := NewChannelConfig("guild-1")
+	config.SetWebhook(Webhook{Name: "discord", Platform: PlatformDiscord, URL: discordServer.URL, Enabled: true})
+	config.SetWebhook(Webhook{Name: "slack", Platform: PlatformSlack, URL: slackServer.URL, Enabled: true,
+		EventTypes: []string{GuildWarDeclaredEventType}})
+	require.NoError(t, configs.Save(ctx, config))
+
+	bridge, err := NewBridge(configs, DefaultGuildTemplates())
+	require.NoError(t, err)
+
+	// Act
+	err = bridge.Handle(ctx, newGuildCreatedEvent("guild-1"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, discord.bodies, 1)
+	assert.Empty(t, slack.bodies)
+	embed := discord.bodies[0]["embeds"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "🏰 New guild: Allies", embed["title"])
+	assert.Contains(t, embed["description"], "commander founded **Allies**")
+	assert.True(t, bridge.CanHandle(GuildWarDeclaredEventType))
+	assert.Equal(t, cqrs.NotificationHandler, bridge.GetHandlerType())
+}
+
+func TestBridge_IgnoresUnconfiguredScope(t *testing.T) {
+	// Arrange
+	bridge, err := NewBridge(NewChannelConfigStore(cqrs.NewInMemoryReadStore()), DefaultGuildTemplates())
+	require.NoError(t, err)
+
+	// Act
+	err = bridge.Handle(context.Background(), newGuildCreatedEvent("unknown"))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestBridge_ReportsWebhookFailure(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	configs := NewChannelConfigStore(cqrs.NewInMemoryReadStore())
+	config := NewChannelConfig("guild-1")
+	config.SetWebhook(Webhook{Name: "broken", Platform: PlatformSlack, URL: server.URL, Enabled: true})
+	require.NoError(t, configs.Save(ctx, config))
+	bridge, err := NewBridge(configs, DefaultGuildTemplates())
+	require.NoError(t, err)
+
+	// Act
+	err = bridge.Handle(ctx, newGuildCreatedEvent("guild-1"))
+
+	// Assert
+	assert.ErrorContains(t, err, "webhook broken")
+}
+
+func TestChannelConfig_Validate(t *testing.T) {
+	config := NewChannelConfig("guild-1")
+	config.SetWebhook(Webhook{Name: "x", Platform: "teams", URL: "http://example"})
+	assert.Error(t, config.Validate())
+
+	assert.True(t, config.RemoveWebhook("x"))
+	assert.NoError(t, config.Validate())
+}
+
+func TestNewBridge_InvalidTemplate(t *testing.T) {
+	_, err := NewBridge(nil, map[string]Template{"X": {Title: "{{.Broken"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+// Package notify bridges domain events to chat webhooks (Discord, Slack).
+//
+// Each scope (usually a guild) owns a ChannelConfig read model listing its
+// webhooks and the event types they subscribe to. The Bridge event handler
+// renders a message for every templated event and posts it to the matching
+// webhooks of the event's scope.
+package notify
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ChannelConfigReadModelType is the read model type of ChannelConfig
+const ChannelConfigReadModelType = "NotificationChannel"
+
+// Platform identifies the webhook payload format
+type Platform string
+
+const (
+	PlatformDiscord Platform = "discord"
+	PlatformSlack   Platform = "slack"
+)
+
+// Webhook is a single chat destination
+type Webhook struct {
+	Name       string   `json:"name"`
+	Platform   Platform `json:"platform"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types,omitempty"` // Empty subscribes to every templated event
+	Enabled    bool     `json:"enabled"`
+}
+
+// Accepts reports whether the webhook wants the event type
+func (w Webhook) Accepts(eventType string) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range w.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelConfig is the per-scope (e.g. per-guild) notification configuration read model
+type ChannelConfig struct {
+	ScopeID   string    `json:"scope_id"`
+	Webhooks  []Webhook `json:"webhooks"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func init() {
+	cqrs.RegisterReadModelType(ChannelConfigReadModelType, reflect.TypeOf(&ChannelConfig{}))
+}
+
+// NewChannelConfig creates an empty configuration for a scope
+func NewChannelConfig(scopeID string) *ChannelConfig {
+	return &ChannelConfig{ScopeID: scopeID, Version: 1, UpdatedAt: time.Now()}
+}
+
+// ReadModel interface implementation
+
+func (c *ChannelConfig) GetID() string             { return c.ScopeID }
+func (c *ChannelConfig) GetType() string           { return ChannelConfigReadModelType }
+func (c *ChannelConfig) GetVersion() int           { return c.Version }
+func (c *ChannelConfig) GetData() interface{}      { return c }
+func (c *ChannelConfig) GetLastUpdated() time.Time { return c.UpdatedAt }
+
+func (c *ChannelConfig) Validate() error {
+	if c.ScopeID == "" {
+		return fmt.Errorf("scope ID cannot be empty")
+	}
+	for _, webhook := range c.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("webhook %q has no URL", webhook.Name)
+		}
+		if webhook.Platform != PlatformDiscord && webhook.Platform != PlatformSlack {
+			return fmt.Errorf("webhook %q has unsupported platform %q", webhook.Name, webhook.Platform)
+		}
+	}
+	return nil
+}
+
+// SetWebhook adds or replaces a webhook by name
+func (c *ChannelConfig) SetWebhook(webhook Webhook) {
+	for i := range c.Webhooks {
+		if c.Webhooks[i].Name == webhook.Name {
+			c.Webhooks[i] = webhook
+			c.touch()
+			return
+		}
+	}
+	c.Webhooks = append(c.Webhooks, webhook)
+	c.touch()
+}
+
+// RemoveWebhook removes a webhook by name
+func (c *ChannelConfig) RemoveWebhook(name string) bool {
+	for i := range c.Webhooks {
+		if c.Webhooks[i].Name == name {
+			c.Webhooks = append(c.Webhooks[:i], c.Webhooks[i+1:]...)
+			c.touch()
+			return true
+		}
+	}
+	return false
+}
+
+// WebhooksFor returns the webhooks subscribed to an event type
+func (c *ChannelConfig) WebhooksFor(eventType string) []Webhook {
+	var matched []Webhook
+	for _, webhook := range c.Webhooks {
+		if webhook.Accepts(eventType) {
+			matched = append(matched, webhook)
+		}
+	}
+	return matched
+}
+
+func (c *ChannelConfig) touch() {
+	c.Version++
+	c.UpdatedAt = time.Now()
+}
+
+// ChannelConfigStore loads and saves ChannelConfig read models
+type ChannelConfigStore struct {
+	store cqrs.ReadStore
+}
+
+// NewChannelConfigStore wraps a read store
+func NewChannelConfigStore(store cqrs.ReadStore) *ChannelConfigStore {
+	return &ChannelConfigStore{store: store}
+}
+
+// Get returns the configuration of a scope, or nil when none is stored
+func (s *ChannelConfigStore) Get(ctx context.Context, scopeID string) (*ChannelConfig, error) {
+	model, err := s.store.GetByID(ctx, scopeID, ChannelConfigReadModelType)
+	if err != nil {
+		if cqrs.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	config, ok := model.(*ChannelConfig)
+	if !ok {
+		return nil, fmt.Errorf("unexpected read model type %T for %s", model, ChannelConfigReadModelType)
+	}
+	return config, nil
+}
+
+// Save stores the configuration of a scope
+func (s *ChannelConfigStore) Save(ctx context.Context, config *ChannelConfig) error {
+	return s.store.Save(ctx, config)
+}
@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"bytes"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// Event types with default templates
+const (
+	GuildCreatedEventType       = "GuildCreated"
+	TransportCompletedEventType = "TransportCompleted"
+	GuildWarDeclaredEventType   = "GuildWarDeclared"
+)
+
+// Message is a platform-independent chat message
+type Message struct {
+	Title  string
+	Text   string
+	Color  int // RGB, e.g. 0x2ecc71
+	Fields []Field
+}
+
+// Field is a labelled value shown under the message text
+type Field struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// Template renders a Message from an event.
+// Title and text are text/template strings executed against the event view
+// ({{.EventType}}, {{.AggregateID}}, {{.Data.some_field}}, ...).
+type Template struct {
+	Title  string
+	Text   string
+	Color  int
+	Fields []FieldTemplate
+
+	title, text *template.Template
+	fields      []*template.Template
+}
+
+// FieldTemplate renders a single Field value
+type FieldTemplate struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// EventView is the data passed to templates
+type EventView struct {
+	EventID       string
+	EventType     string
+	AggregateID   string
+	AggregateType string
+	Version       int
+	Data          map[string]interface{}
+	Metadata      map[string]interface{}
+}
+
+// NewEventView flattens an event payload for templates and scope resolution
+func NewEventView(event cqrs.EventMessage) EventView {
+	view := EventView{
+		EventID:       event.EventID(),
+		EventType:     event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		Version:       event.Version(),
+		Metadata:      event.Metadata(),
+		Data:          map[string]interface{}{},
+	}
+
+	if data := event.EventData(); data != nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			json.Unmarshal(encoded, &view.Data)
+		}
+	}
+	return view
+}
+
+// compile parses the template strings once
+func (t *Template) compile(name string) error {
+	var err error
+	if t.title, err = template.New(name + ".title").Option("missingkey=zero").Parse(t.Title); err != nil {
+		return fmt.Errorf("invalid title template for %s: %w", name, err)
+	}
+	if t.text, err = template.New(name + ".text").Option("missingkey=zero").Parse(t.Text); err != nil {
+		return fmt.Errorf("invalid text template for %s: %w", name, err)
+	}
+
+	t.fields = make([]*template.Template, len(t.Fields))
+	for i, field := range t.Fields {
+		if t.fields[i], err = template.New(name + "." + field.Name).Option("missingkey=zero").Parse(field.Value); err != nil {
+			return fmt.Errorf("invalid field template %s for %s: %w", field.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// Render executes the template against an event view
+func (t *Template) Render(view EventView) (Message, error) {
+	message := Message{Color: t.Color}
+
+	var err error
+	if message.Title, err = execute(t.title, view); err != nil {
+		return message, err
+	}
+	if message.Text, err = execute(t.text, view); err != nil {
+		return message, err
+	}
+	for i, field := range t.Fields {
+		value, err := execute(t.fields[i], view)
+		if err != nil {
+			return message, err
+		}
+		message.Fields = append(message.Fields, Field{Name: field.Name, Value: value, Inline: field.Inline})
+	}
+	return message, nil
+}
+
+func execute(tmpl *template.Template, view EventView) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, view); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DefaultGuildTemplates returns templates for the guild events announced by default
+func DefaultGuildTemplates() map[string]Template {
+	return map[string]Template{
+		GuildCreatedEventType: {
+			Title: "🏰 New guild: {{.Data.name}}",
+			Text:  "{{.Data.founder_username}} founded **{{.Data.name}}**. {{.Data.description}}",
+			Color: 0x2ecc71,
+		},
+		TransportCompletedEventType: {
+			Title: "🚚 Transport completed",
+			Text:  "A transport of guild {{.Data.guild_id}} arrived safely.",
+			Color: 0x3498db,
+			Fields: []FieldTemplate{
+				{Name: "Transport", Value: "{{.Data.transport_id}}", Inline: true},
+				{Name: "Delivered", Value: "{{.Data.delivered_minerals}}", Inline: true},
+			},
+		},
+		GuildWarDeclaredEventType: {
+			Title: "⚔️ War declared!",
+			Text:  "**{{.Data.attacker_name}}** declared war on **{{.Data.defender_name}}**.",
+			Color: 0xe74c3c,
+		},
+	}
+}
+
+// discordPayload builds a Discord webhook body
+func discordPayload(message Message) map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(message.Fields))
+	for _, field := range message.Fields {
+		fields = append(fields, map[string]interface{}{"name": field.Name, "value": field.Value, "inline": field.Inline})
+	}
+
+	return map[string]interface{}{
+		"embeds": []map[string]interface{}{{
+			"title":       message.Title,
+			"description": message.Text,
+			"color":       message.Color,
+			"fields":      fields,
+		}},
+	}
+}
+
+// slackPayload builds a Slack incoming webhook body
+func slackPayload(message Message) map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(message.Fields))
+	for _, field := range message.Fields {
+		fields = append(fields, map[string]interface{}{"title": field.Name, "value": field.Value, "short": field.Inline})
+	}
+
+	return map[string]interface{}{
+		"text": message.Title,
+		"attachments": []map[string]interface{}{{
+			"color":  fmt.Sprintf("#%06x", message.Color),
+			"text":   message.Text,
+			"fields": fields,
+		}},
+	}
+}
@@ -52,6 +52,8 @@ type ProjectionMetrics struct {
 	AverageProcessingTime time.Duration
 	LastProcessedEvent    time.Time
 	Errors                []ProjectionError
+	Lag                   map[string]ProjectionLag // Per-projection lag behind the stream head
+	MaxEventsBehind       int64                    // Largest EventsBehind across projections
 }
 
 // Projection interface for event projections
@@ -0,0 +1,238 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// ProjectionHandlerFunc applies one event to the read models of a projection
+type ProjectionHandlerFunc func(ctx context.Context, event EventMessage) error
+
+// ProjectionRule maps one event type to the read model mutation it triggers
+type ProjectionRule struct {
+	EventType    string
+	ReadModel    string // Read model type the handler writes, for documentation
+	Description  string
+	Handle       ProjectionHandlerFunc
+	PartitionKey func(event EventMessage) string // Optional; defaults to the aggregate ID
+}
+
+// ProjectionRuleFor declares a rule whose handler receives the concrete event type E
+func ProjectionRuleFor[E EventMessage](eventType, readModel, description string, handle func(ctx context.Context, event E) error) ProjectionRule {
+	return ProjectionRule{
+		EventType:   eventType,
+		ReadModel:   readModel,
+		Description: description,
+		Handle: func(ctx context.Context, event EventMessage) error {
+			typed, ok := event.(E)
+			if !ok {
+				var expected E
+				return fmt.Errorf("projection rule for %s expects %T, got %T", eventType, expected, event)
+			}
+			return handle(ctx, typed)
+		},
+	}
+}
+
+// ProjectionDefinition declares a projection as a table of event type → mutation rules.
+// CanHandle, documentation and rebuild partitioning are all derived from the table,
+// so there is no separate event type list to keep in sync with a switch statement.
+type ProjectionDefinition struct {
+	Name        string
+	Version     string
+	Description string
+	Rules       []ProjectionRule
+}
+
+// Validate checks that the definition is named and every rule is complete and unique
+func (d ProjectionDefinition) Validate() error {
+	if d.Name == "" {
+		return NewValidationError("projection name cannot be empty", nil)
+	}
+	if len(d.Rules) == 0 {
+		return NewValidationError(fmt.Sprintf("projection %s declares no rules", d.Name), nil)
+	}
+
+	seen := make(map[string]bool, len(d.Rules))
+	for _, rule := range d.Rules {
+		if rule.EventType == "" {
+			return NewValidationError(fmt.Sprintf("projection %s has a rule without event type", d.Name), nil)
+		}
+		if rule.Handle == nil {
+			return NewValidationError(fmt.Sprintf("projection %s rule for %s has no handler", d.Name, rule.EventType), nil)
+		}
+		if seen[rule.EventType] {
+			return NewValidationError(fmt.Sprintf("projection %s declares %s twice", d.Name, rule.EventType), nil)
+		}
+		seen[rule.EventType] = true
+	}
+	return nil
+}
+
+// EventTypes returns the handled event types in declaration order
+func (d ProjectionDefinition) EventTypes() []string {
+	eventTypes := make([]string, 0, len(d.Rules))
+	for _, rule := range d.Rules {
+		eventTypes = append(eventTypes, rule.EventType)
+	}
+	return eventTypes
+}
+
+// ReadModelTypes returns the distinct read model types written by the projection
+func (d ProjectionDefinition) ReadModelTypes() []string {
+	var readModels []string
+	for _, rule := range d.Rules {
+		if rule.ReadModel != "" {
+			readModels, _ = AddMember(readModels, rule.ReadModel)
+		}
+	}
+	return readModels
+}
+
+// Describe renders the definition as a Markdown section for generated documentation
+func (d ProjectionDefinition) Describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s", d.Name)
+	if d.Version != "" {
+		fmt.Fprintf(&b, " (v%s)", d.Version)
+	}
+	b.WriteString("\n\n")
+	if d.Description != "" {
+		b.WriteString(d.Description + "\n\n")
+	}
+	b.WriteString("| Event | Read model | Mutation |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, rule := range d.Rules {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", rule.EventType, rule.ReadModel, rule.Description)
+	}
+	return b.String()
+}
+
+// DeclarativeProjection runs a ProjectionDefinition
+type DeclarativeProjection struct {
+	*BaseProjection
+	definition ProjectionDefinition
+	rules      map[string]ProjectionRule
+}
+
+// NewDeclarativeProjection creates a projection from a validated definition
+func NewDeclarativeProjection(definition ProjectionDefinition) (*DeclarativeProjection, error) {
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string]ProjectionRule, len(definition.Rules))
+	for _, rule := range definition.Rules {
+		rules[rule.EventType] = rule
+	}
+	return &DeclarativeProjection{
+		BaseProjection: NewBaseProjection(definition.Name, definition.Version, definition.EventTypes()),
+		definition:     definition,
+		rules:          rules,
+	}, nil
+}
+
+// MustNewDeclarativeProjection is NewDeclarativeProjection for static definitions; it panics on an invalid definition
+func MustNewDeclarativeProjection(definition ProjectionDefinition) *DeclarativeProjection {
+	projection, err := NewDeclarativeProjection(definition)
+	if err != nil {
+		panic(err)
+	}
+	return projection
+}
+
+// Definition returns the declared definition
+func (p *DeclarativeProjection) Definition() ProjectionDefinition {
+	return p.definition
+}
+
+// Project dispatches the event to the rule declared for its type
+func (p *DeclarativeProjection) Project(ctx context.Context, event EventMessage) error {
+	rule, ok := p.rules[event.EventType()]
+	if !ok {
+		return fmt.Errorf("projection %s does not handle event type %s", p.definition.Name, event.EventType())
+	}
+	if err := p.BaseProjection.Project(ctx, event); err != nil {
+		return err
+	}
+	return rule.Handle(ctx, event)
+}
+
+// Partition returns the rebuild partition of event. Events with the same partition key,
+// by default the aggregate ID, always share a partition so they are applied in order.
+func (p *DeclarativeProjection) Partition(event EventMessage, partitions int) int {
+	if partitions <= 1 {
+		return 0
+	}
+
+	key := event.AggregateID()
+	if rule, ok := p.rules[event.EventType()]; ok && rule.PartitionKey != nil {
+		key = rule.PartitionKey(event)
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(partitions))
+}
+
+// RebuildPartitioned replays events with one worker per partition. Events of types the
+// projection does not handle are skipped; the first handler error stops the rebuild.
+func (p *DeclarativeProjection) RebuildPartitioned(ctx context.Context, events []EventMessage, partitions int) error {
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	queues := make([][]EventMessage, partitions)
+	for _, event := range events {
+		if !p.CanHandle(event.EventType()) {
+			continue
+		}
+		partition := p.Partition(event, partitions)
+		queues[partition] = append(queues[partition], event)
+	}
+
+	p.SetState(ProjectionRebuilding)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for _, queue := range queues {
+		if len(queue) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(queue []EventMessage) {
+			defer wg.Done()
+			for _, event := range queue {
+				err := ctx.Err()
+				if err == nil {
+					err = p.rules[event.EventType()].Handle(ctx, event)
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}(queue)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		p.SetState(ProjectionFaulted)
+		return firstErr
+	}
+	if len(events) > 0 {
+		p.SetLastProcessedEvent(events[len(events)-1].EventID())
+	}
+	p.SetState(ProjectionRunning)
+	return nil
+}
@@ -0,0 +1,116 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memberCounter 길드별 가입/탈퇴 이벤트 순서를 기록하는 테스트 투영 대상
+type memberCounter struct {
+	mutex  sync.Mutex
+	counts map[string]int
+	order  map[string][]int
+}
+
+func (c *memberCounter) apply(event *BaseEventMessage, delta int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.counts[event.AggregateID()] += delta
+	c.order[event.AggregateID()] = append(c.order[event.AggregateID()], event.Version())
+	return nil
+}
+
+func newMemberCountDefinition(counter *memberCounter) ProjectionDefinition {
+	return ProjectionDefinition{
+		Name:        "GuildMemberCount",
+		Version:     "1.0.0",
+		Description: "Counts members per guild.",
+		Rules: []ProjectionRule{
+			ProjectionRuleFor("MemberJoined", "GuildView", "Increments member count",
+				func(ctx context.Context, event *BaseEventMessage) error { return counter.apply(event, 1) }),
+			ProjectionRuleFor("MemberLeft", "GuildView", "Decrements member count",
+				func(ctx context.Context, event *BaseEventMessage) error { return counter.apply(event, -1) }),
+		},
+	}
+}
+
+func guildEvent(eventType, guildID string, version int) *BaseEventMessage {
+	event := NewBaseEventMessage(eventType)
+	event.setAggregateInfo(guildID, "Guild", version)
+	return event
+}
+
+func TestDeclarativeProjection_DerivesHandlingFromRules(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	counter := &memberCounter{counts: map[string]int{}, order: map[string][]int{}}
+	projection, err := NewDeclarativeProjection(newMemberCountDefinition(counter))
+	require.NoError(t, err)
+
+	// Act
+	joinErr := projection.Project(ctx, guildEvent("MemberJoined", "guild-1", 1))
+	unknownErr := projection.Project(ctx, guildEvent("GuildRenamed", "guild-1", 2))
+	doc := projection.Definition().Describe()
+
+	// Assert
+	require.NoError(t, joinErr)
+	require.Error(t, unknownErr)
+	assert.True(t, projection.CanHandle("MemberLeft"))
+	assert.False(t, projection.CanHandle("GuildRenamed"))
+	assert.Equal(t, 1, counter.counts["guild-1"])
+	assert.Equal(t, []string{"GuildView"}, projection.Definition().ReadModelTypes())
+	assert.Contains(t, doc, "## GuildMemberCount (v1.0.0)")
+	assert.Contains(t, doc, "| MemberLeft | GuildView | Decrements member count |")
+}
+
+func TestProjectionDefinition_RejectsDuplicateEventType(t *testing.T) {
+	// Arrange
+	counter := &memberCounter{counts: map[string]int{}, order: map[string][]int{}}
+	definition := newMemberCountDefinition(counter)
+	definition.Rules = append(definition.Rules, definition.Rules[0])
+
+	// Act
+	_, err := NewDeclarativeProjection(definition)
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+	assert.Contains(t, err.Error(), "declares MemberJoined twice")
+}
+
+func TestDeclarativeProjection_RebuildPartitionedKeepsPerAggregateOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	counter := &memberCounter{counts: map[string]int{}, order: map[string][]int{}}
+	projection := MustNewDeclarativeProjection(newMemberCountDefinition(counter))
+
+	var events []EventMessage
+	for version := 1; version <= 30; version++ {
+		for guild := 0; guild < 10; guild++ {
+			eventType := "MemberJoined"
+			if version%3 == 0 {
+				eventType = "MemberLeft"
+			}
+			events = append(events, guildEvent(eventType, fmt.Sprintf("guild-%d", guild), version))
+		}
+	}
+	// 처리하지 않는 이벤트는 건너뛰어야 함
+	events = append(events, guildEvent("GuildRenamed", "guild-0", 31))
+
+	// Act
+	err := projection.RebuildPartitioned(ctx, events, 4)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ProjectionRunning, projection.GetState())
+	require.Len(t, counter.counts, 10)
+	for guild, count := range counter.counts {
+		assert.Equal(t, 10, count, guild)
+		assert.IsIncreasing(t, counter.order[guild], guild)
+	}
+}
@@ -0,0 +1,229 @@
+package cqrs
+
+import (
+	"context"
+	"time"
+)
+
+// ProjectionLag is how far a projection trails the event stream head
+type ProjectionLag struct {
+	ProjectionName     string        `json:"projection_name"`
+	HeadPosition       int64         `json:"head_position"`
+	CheckpointPosition int64         `json:"checkpoint_position"`
+	EventsBehind       int64         `json:"events_behind"`
+	TimeBehind         time.Duration `json:"time_behind"`
+}
+
+// ProjectionInfo summarizes a registered projection for monitoring
+type ProjectionInfo struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	State      ProjectionState      `json:"state"`
+	Checkpoint ProjectionCheckpoint `json:"checkpoint"`
+	Lag        ProjectionLag        `json:"lag"`
+}
+
+// ProjectionLagThreshold triggers an alert when a projection falls too far behind.
+// A zero limit is not checked.
+type ProjectionLagThreshold struct {
+	ProjectionName  string        // Empty applies to every projection without its own threshold
+	MaxEventsBehind int64         // Alert when more events than this are unprocessed
+	MaxTimeBehind   time.Duration // Alert when the checkpoint is older than the head by more than this
+}
+
+func (t ProjectionLagThreshold) exceededBy(lag ProjectionLag) bool {
+	return (t.MaxEventsBehind > 0 && lag.EventsBehind > t.MaxEventsBehind) ||
+		(t.MaxTimeBehind > 0 && lag.TimeBehind > t.MaxTimeBehind)
+}
+
+// ProjectionLagAlert is delivered when a projection crosses its threshold and again when it recovers
+type ProjectionLagAlert struct {
+	Lag       ProjectionLag
+	Threshold ProjectionLagThreshold
+	Recovered bool
+	At        time.Time
+}
+
+// ProjectionLagAlertFunc receives lag alerts
+type ProjectionLagAlertFunc func(alert ProjectionLagAlert)
+
+// projectionHead is the newest event the manager has received
+type projectionHead struct {
+	position       int64
+	eventTime      time.Time
+	firstEventTime time.Time
+}
+
+// SetLagThresholds replaces the lag thresholds; per-projection thresholds override the default one
+func (pm *InMemoryProjectionManager) SetLagThresholds(thresholds ...ProjectionLagThreshold) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	pm.lagThresholds = append([]ProjectionLagThreshold(nil), thresholds...)
+	pm.lagAlerting = make(map[string]bool)
+}
+
+// OnLagAlert registers a callback for lag alerts
+func (pm *InMemoryProjectionManager) OnLagAlert(fn ProjectionLagAlertFunc) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	pm.lagAlertFuncs = append(pm.lagAlertFuncs, fn)
+}
+
+// GetProjectionLag returns the lag of one projection
+func (pm *InMemoryProjectionManager) GetProjectionLag(projectionName string) (ProjectionLag, error) {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+
+	if _, exists := pm.projections[projectionName]; !exists {
+		return ProjectionLag{}, NewNotFoundError("projection not found: "+projectionName, nil)
+	}
+	return pm.lagLocked(projectionName), nil
+}
+
+// GetAllProjectionInfo returns state, checkpoint and lag of every projection
+func (pm *InMemoryProjectionManager) GetAllProjectionInfo() []ProjectionInfo {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+
+	infos := make([]ProjectionInfo, 0, len(pm.projections))
+	for name, projection := range pm.projections {
+		info := ProjectionInfo{
+			Name:    name,
+			Version: projection.GetVersion(),
+			State:   projection.GetState(),
+			Lag:     pm.lagLocked(name),
+		}
+		if checkpoint, exists := pm.checkpoints[name]; exists {
+			info.Checkpoint = *checkpoint
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// CheckLag evaluates every projection against the thresholds and fires alerts on transitions
+func (pm *InMemoryProjectionManager) CheckLag() []ProjectionLagAlert {
+	pm.mutex.Lock()
+	var alerts []ProjectionLagAlert
+	now := time.Now()
+	for name := range pm.projections {
+		threshold, ok := pm.thresholdFor(name)
+		if !ok {
+			continue
+		}
+
+		lag := pm.lagLocked(name)
+		exceeded := threshold.exceededBy(lag)
+		if exceeded == pm.lagAlerting[name] {
+			continue
+		}
+		pm.lagAlerting[name] = exceeded
+		alerts = append(alerts, ProjectionLagAlert{Lag: lag, Threshold: threshold, Recovered: !exceeded, At: now})
+	}
+	alertFuncs := append([]ProjectionLagAlertFunc(nil), pm.lagAlertFuncs...)
+	pm.mutex.Unlock()
+
+	for _, alert := range alerts {
+		for _, fn := range alertFuncs {
+			fn(alert)
+		}
+	}
+	return alerts
+}
+
+// StartLagMonitor runs CheckLag every interval until ctx is done
+func (pm *InMemoryProjectionManager) StartLagMonitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pm.CheckLag()
+			}
+		}
+	}()
+}
+
+// observeHead assigns the next stream position to an incoming event
+func (pm *InMemoryProjectionManager) observeHead(event EventMessage) int64 {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	pm.head.position++
+	if pm.head.firstEventTime.IsZero() || event.Timestamp().Before(pm.head.firstEventTime) {
+		pm.head.firstEventTime = event.Timestamp()
+	}
+	if event.Timestamp().After(pm.head.eventTime) {
+		pm.head.eventTime = event.Timestamp()
+	}
+	return pm.head.position
+}
+
+// markPositions advances the checkpoint position of projections that are up to date with position:
+// those that processed the event and running ones that do not handle its type
+func (pm *InMemoryProjectionManager) markPositions(processed []Projection, event EventMessage, position int64) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	mark := func(name string) {
+		checkpoint, exists := pm.checkpoints[name]
+		if !exists {
+			checkpoint = &ProjectionCheckpoint{ProjectionName: name}
+			pm.checkpoints[name] = checkpoint
+		}
+		if position > checkpoint.Position {
+			checkpoint.Position = position
+		}
+	}
+
+	for _, projection := range processed {
+		mark(projection.GetProjectionName())
+	}
+	for name, projection := range pm.projections {
+		if projection.GetState() == ProjectionRunning && !projection.CanHandle(event.EventType()) {
+			mark(name)
+			if checkpoint := pm.checkpoints[name]; event.Timestamp().After(checkpoint.LastEventTime) {
+				checkpoint.LastEventTime = event.Timestamp()
+			}
+		}
+	}
+}
+
+// lagLocked computes a projection's lag; callers hold pm.mutex
+func (pm *InMemoryProjectionManager) lagLocked(projectionName string) ProjectionLag {
+	lag := ProjectionLag{ProjectionName: projectionName, HeadPosition: pm.head.position}
+
+	var lastEventTime time.Time
+	if checkpoint, exists := pm.checkpoints[projectionName]; exists {
+		lag.CheckpointPosition = checkpoint.Position
+		lastEventTime = checkpoint.LastEventTime
+	}
+	lag.EventsBehind = lag.HeadPosition - lag.CheckpointPosition
+	if lag.EventsBehind > 0 && !pm.head.eventTime.IsZero() {
+		if lastEventTime.IsZero() {
+			lastEventTime = pm.head.firstEventTime
+		}
+		lag.TimeBehind = pm.head.eventTime.Sub(lastEventTime)
+	}
+	return lag
+}
+
+// thresholdFor returns the projection's own threshold, falling back to the default one
+func (pm *InMemoryProjectionManager) thresholdFor(projectionName string) (ProjectionLagThreshold, bool) {
+	var fallback ProjectionLagThreshold
+	found := false
+	for _, threshold := range pm.lagThresholds {
+		if threshold.ProjectionName == projectionName {
+			return threshold, true
+		}
+		if threshold.ProjectionName == "" {
+			fallback, found = threshold, true
+		}
+	}
+	return fallback, found
+}
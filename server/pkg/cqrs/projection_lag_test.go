@@ -0,0 +1,76 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStalledProjection() *guildCountProjection {
+	projection := newGuildCountProjection("1")
+	projection.BaseProjection = NewBaseProjection("StalledView", "1", []string{"MemberJoined"})
+	return projection
+}
+
+func TestProjectionManager_TracksLagPerProjection(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+	pm := NewInMemoryProjectionManager()
+	require.NoError(t, pm.RegisterProjection(newGuildCountProjection("1")))
+	require.NoError(t, pm.RegisterProjection(newStalledProjection()))
+
+	// Act
+	for i := 0; i < 5; i++ {
+		require.NoError(t, pm.ProcessEvent(ctx, newMemberJoined("guild-1", base.Add(time.Duration(i)*time.Minute))))
+	}
+
+	// Assert
+	current, err := pm.GetProjectionLag("GuildView")
+	require.NoError(t, err)
+	assert.Zero(t, current.EventsBehind)
+
+	stalled, err := pm.GetProjectionLag("StalledView")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stalled.EventsBehind)
+	assert.Equal(t, 4*time.Minute, stalled.TimeBehind)
+
+	metrics := pm.GetMetrics()
+	assert.Equal(t, int64(5), metrics.MaxEventsBehind)
+	assert.Len(t, metrics.Lag, 2)
+	assert.Len(t, pm.GetAllProjectionInfo(), 2)
+}
+
+func TestProjectionManager_CheckLag_AlertsOnTransitions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pm := NewInMemoryProjectionManager()
+	stalled := newStalledProjection()
+	require.NoError(t, pm.RegisterProjection(newGuildCountProjection("1")))
+	require.NoError(t, pm.RegisterProjection(stalled))
+	pm.SetLagThresholds(ProjectionLagThreshold{MaxEventsBehind: 3})
+
+	var received []ProjectionLagAlert
+	pm.OnLagAlert(func(alert ProjectionLagAlert) { received = append(received, alert) })
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, pm.ProcessEvent(ctx, newMemberJoined("guild-1", time.Now())))
+	}
+
+	// Act
+	pm.CheckLag()
+	pm.CheckLag()
+	stalled.SetState(ProjectionRunning)
+	require.NoError(t, pm.ProcessEvent(ctx, newMemberJoined("guild-1", time.Now())))
+	pm.CheckLag()
+
+	// Assert
+	require.Len(t, received, 2)
+	assert.Equal(t, "StalledView", received[0].Lag.ProjectionName)
+	assert.False(t, received[0].Recovered)
+	assert.Equal(t, int64(4), received[0].Lag.EventsBehind)
+	assert.True(t, received[1].Recovered)
+}
@@ -0,0 +1,242 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ProjectionCheckpoint records how far a projection has processed the event stream
+type ProjectionCheckpoint struct {
+	ProjectionName  string    `json:"projection_name"`
+	LastEventID     string    `json:"last_event_id"`
+	LastEventTime   time.Time `json:"last_event_time"`
+	ProcessedEvents int64     `json:"processed_events"`
+	Position        int64     `json:"position"` // Stream position the projection is up to date with, used for lag
+}
+
+// IsZero reports whether no event has been processed yet
+func (c ProjectionCheckpoint) IsZero() bool {
+	return c.LastEventID == "" && c.LastEventTime.IsZero()
+}
+
+// ProjectionSnapshot is a point-in-time copy of a projection's read models and checkpoint.
+// Restoring it and replaying only the events after the checkpoint replaces a full rebuild.
+type ProjectionSnapshot struct {
+	ProjectionName    string
+	ProjectionVersion string
+	Checkpoint        ProjectionCheckpoint
+	ReadModels        []ReadModel
+	CreatedAt         time.Time
+}
+
+// SnapshottableProjection is a projection whose read model set can be captured and restored
+type SnapshottableProjection interface {
+	Projection
+
+	// SnapshotReadModels returns every read model maintained by the projection
+	SnapshotReadModels(ctx context.Context) ([]ReadModel, error)
+	// RestoreReadModels replaces the projection's read models with the snapshot contents
+	RestoreReadModels(ctx context.Context, readModels []ReadModel) error
+}
+
+// ProjectionSnapshotStore persists projection snapshots, keeping the latest per projection
+type ProjectionSnapshotStore interface {
+	SaveProjectionSnapshot(ctx context.Context, snapshot *ProjectionSnapshot) error
+	// LoadProjectionSnapshot returns a not-found error when no snapshot exists
+	LoadProjectionSnapshot(ctx context.Context, projectionName string) (*ProjectionSnapshot, error)
+}
+
+// ProjectionReplayFunc streams events that occurred after the checkpoint, oldest first.
+// A zero checkpoint replays the whole stream.
+type ProjectionReplayFunc func(ctx context.Context, from ProjectionCheckpoint, fn func(event EventMessage) error) error
+
+// InMemoryProjectionSnapshotStore keeps projection snapshots in memory
+type InMemoryProjectionSnapshotStore struct {
+	snapshots map[string]*ProjectionSnapshot
+	mutex     sync.RWMutex
+}
+
+// NewInMemoryProjectionSnapshotStore creates an empty snapshot store
+func NewInMemoryProjectionSnapshotStore() *InMemoryProjectionSnapshotStore {
+	return &InMemoryProjectionSnapshotStore{snapshots: make(map[string]*ProjectionSnapshot)}
+}
+
+func (s *InMemoryProjectionSnapshotStore) SaveProjectionSnapshot(ctx context.Context, snapshot *ProjectionSnapshot) error {
+	if snapshot == nil || snapshot.ProjectionName == "" {
+		return NewCQRSError(ErrCodeEventValidation.String(), "snapshot projection name cannot be empty", nil)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	copied := *snapshot
+	copied.ReadModels = append([]ReadModel(nil), snapshot.ReadModels...)
+	s.snapshots[snapshot.ProjectionName] = &copied
+	return nil
+}
+
+func (s *InMemoryProjectionSnapshotStore) LoadProjectionSnapshot(ctx context.Context, projectionName string) (*ProjectionSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	snapshot, exists := s.snapshots[projectionName]
+	if !exists {
+		return nil, NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection snapshot not found: %s", projectionName), nil)
+	}
+	copied := *snapshot
+	copied.ReadModels = append([]ReadModel(nil), snapshot.ReadModels...)
+	return &copied, nil
+}
+
+// Projection snapshot support for InMemoryProjectionManager
+
+// GetCheckpoint returns the checkpoint of a projection
+func (pm *InMemoryProjectionManager) GetCheckpoint(projectionName string) (ProjectionCheckpoint, bool) {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+
+	checkpoint, exists := pm.checkpoints[projectionName]
+	if !exists {
+		return ProjectionCheckpoint{ProjectionName: projectionName}, false
+	}
+	return *checkpoint, true
+}
+
+// advanceCheckpoint records a processed event; callers hold projectMutex
+func (pm *InMemoryProjectionManager) advanceCheckpoint(projectionName string, event EventMessage) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	checkpoint, exists := pm.checkpoints[projectionName]
+	if !exists {
+		checkpoint = &ProjectionCheckpoint{ProjectionName: projectionName}
+		pm.checkpoints[projectionName] = checkpoint
+	}
+	checkpoint.LastEventID = event.EventID()
+	checkpoint.LastEventTime = event.Timestamp()
+	checkpoint.ProcessedEvents++
+}
+
+// SnapshotProjection captures the read models and checkpoint of one projection.
+// Event processing is paused while the state is captured so both stay consistent.
+func (pm *InMemoryProjectionManager) SnapshotProjection(ctx context.Context, store ProjectionSnapshotStore, projectionName string) error {
+	projection, exists := pm.GetProjection(projectionName)
+	if !exists {
+		return NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+	}
+	snapshottable, ok := projection.(SnapshottableProjection)
+	if !ok {
+		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection does not support snapshots: %s", projectionName), nil)
+	}
+
+	pm.projectMutex.Lock()
+	readModels, err := snapshottable.SnapshotReadModels(ctx)
+	checkpoint, _ := pm.GetCheckpoint(projectionName)
+	pm.projectMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to snapshot projection %s: %w", projectionName, err)
+	}
+
+	return store.SaveProjectionSnapshot(ctx, &ProjectionSnapshot{
+		ProjectionName:    projectionName,
+		ProjectionVersion: projection.GetVersion(),
+		Checkpoint:        checkpoint,
+		ReadModels:        readModels,
+		CreatedAt:         time.Now(),
+	})
+}
+
+// SnapshotAll snapshots every projection that supports snapshots
+func (pm *InMemoryProjectionManager) SnapshotAll(ctx context.Context, store ProjectionSnapshotStore) error {
+	for name, projection := range pm.GetAllProjections() {
+		if _, ok := projection.(SnapshottableProjection); !ok {
+			continue
+		}
+		if err := pm.SnapshotProjection(ctx, store, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartPeriodicSnapshots snapshots all projections every interval until ctx is cancelled.
+// Errors are passed to onError (if set) and do not stop the loop.
+func (pm *InMemoryProjectionManager) StartPeriodicSnapshots(ctx context.Context, store ProjectionSnapshotStore, interval time.Duration, onError func(error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := pm.SnapshotAll(ctx, store); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// RestoreProjection restores a projection from its latest snapshot, then replays the
+// events after the snapshot checkpoint. Without a usable snapshot (none stored, or one
+// taken by a different projection version) the projection is reset and fully replayed.
+func (pm *InMemoryProjectionManager) RestoreProjection(ctx context.Context, store ProjectionSnapshotStore, projectionName string, replay ProjectionReplayFunc) error {
+	projection, exists := pm.GetProjection(projectionName)
+	if !exists {
+		return NewCQRSError(ErrCodeNotFoundError.String(), fmt.Sprintf("projection not found: %s", projectionName), nil)
+	}
+	snapshottable, ok := projection.(SnapshottableProjection)
+	if !ok {
+		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("projection does not support snapshots: %s", projectionName), nil)
+	}
+
+	pm.projectMutex.Lock()
+	defer pm.projectMutex.Unlock()
+
+	checkpoint := ProjectionCheckpoint{ProjectionName: projectionName}
+
+	snapshot, err := store.LoadProjectionSnapshot(ctx, projectionName)
+	switch {
+	case err != nil && !IsNotFoundError(err):
+		return fmt.Errorf("failed to load snapshot of projection %s: %w", projectionName, err)
+	case err == nil && snapshot.ProjectionVersion == projection.GetVersion():
+		if err := snapshottable.RestoreReadModels(ctx, snapshot.ReadModels); err != nil {
+			return fmt.Errorf("failed to restore projection %s: %w", projectionName, err)
+		}
+		checkpoint = snapshot.Checkpoint
+		checkpoint.ProjectionName = projectionName
+	default:
+		if err := projection.Reset(ctx); err != nil {
+			return err
+		}
+		if err := snapshottable.RestoreReadModels(ctx, nil); err != nil {
+			return fmt.Errorf("failed to clear projection %s: %w", projectionName, err)
+		}
+	}
+
+	pm.mutex.Lock()
+	pm.checkpoints[projectionName] = &checkpoint
+	pm.mutex.Unlock()
+
+	if replay == nil {
+		return nil
+	}
+
+	return replay(ctx, checkpoint, func(event EventMessage) error {
+		// Replay sources are usually inclusive of the checkpoint event
+		if event.EventID() == checkpoint.LastEventID {
+			return nil
+		}
+		if projection.CanHandle(event.EventType()) {
+			if err := projection.Project(ctx, event); err != nil {
+				return fmt.Errorf("failed to replay event %s into projection %s: %w", event.EventID(), projectionName, err)
+			}
+		}
+		pm.advanceCheckpoint(projectionName, event)
+		return nil
+	})
+}
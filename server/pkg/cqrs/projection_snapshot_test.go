@@ -0,0 +1,156 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// guildCountProjection 길드별 이벤트 수를 세는 스냅샷 가능 프로젝션
+type guildCountProjection struct {
+	*BaseProjection
+	counts map[string]int
+}
+
+func newGuildCountProjection(version string) *guildCountProjection {
+	projection := &guildCountProjection{
+		BaseProjection: NewBaseProjection("GuildView", version, []string{"MemberJoined"}),
+		counts:         make(map[string]int),
+	}
+	projection.SetState(ProjectionRunning)
+	return projection
+}
+
+func (p *guildCountProjection) Project(ctx context.Context, event EventMessage) error {
+	p.counts[event.AggregateID()]++
+	p.SetLastProcessedEvent(event.EventID())
+	return nil
+}
+
+func (p *guildCountProjection) SnapshotReadModels(ctx context.Context) ([]ReadModel, error) {
+	models := make([]ReadModel, 0, len(p.counts))
+	for guildID, count := range p.counts {
+		models = append(models, NewBaseReadModel(guildID, "GuildView", count))
+	}
+	return models, nil
+}
+
+func (p *guildCountProjection) RestoreReadModels(ctx context.Context, readModels []ReadModel) error {
+	p.counts = make(map[string]int, len(readModels))
+	for _, model := range readModels {
+		p.counts[model.GetID()] = model.GetData().(int)
+	}
+	return nil
+}
+
+func newMemberJoined(guildID string, at time.Time) EventMessage {
+	event := NewBaseEventMessage("MemberJoined")
+	event.AggregateID_ = guildID
+	event.Timestamp_ = at
+	return event
+}
+
+// replayFrom 체크포인트 이후(포함) 이벤트를 재생하는 테스트용 ProjectionReplayFunc
+func replayFrom(events []EventMessage) ProjectionReplayFunc {
+	return func(ctx context.Context, from ProjectionCheckpoint, fn func(EventMessage) error) error {
+		for _, event := range events {
+			if event.Timestamp().Before(from.LastEventTime) {
+				continue
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestProjectionManager_SnapshotAndRestore(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+	events := []EventMessage{
+		newMemberJoined("guild-1", base),
+		newMemberJoined("guild-1", base.Add(time.Second)),
+		newMemberJoined("guild-2", base.Add(2*time.Second)),
+		newMemberJoined("guild-2", base.Add(3*time.Second)),
+	}
+	store := NewInMemoryProjectionSnapshotStore()
+
+	pm := NewInMemoryProjectionManager()
+	require.NoError(t, pm.RegisterProjection(newGuildCountProjection("1")))
+	for _, event := range events[:3] {
+		require.NoError(t, pm.ProcessEvent(ctx, event))
+	}
+	require.NoError(t, pm.SnapshotAll(ctx, store))
+
+	// 재시작된 프로세스
+	restarted := NewInMemoryProjectionManager()
+	projection := newGuildCountProjection("1")
+	require.NoError(t, restarted.RegisterProjection(projection))
+	replayed := 0
+	replay := func(ctx context.Context, from ProjectionCheckpoint, fn func(EventMessage) error) error {
+		return replayFrom(events)(ctx, from, func(event EventMessage) error {
+			replayed++
+			return fn(event)
+		})
+	}
+
+	// Act
+	err := restarted.RestoreProjection(ctx, store, "GuildView", replay)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"guild-1": 2, "guild-2": 2}, projection.counts)
+	assert.Equal(t, 2, replayed, "only the checkpoint event and the newer event are replayed")
+
+	checkpoint, exists := restarted.GetCheckpoint("GuildView")
+	assert.True(t, exists)
+	assert.Equal(t, events[3].EventID(), checkpoint.LastEventID)
+	assert.Equal(t, int64(4), checkpoint.ProcessedEvents)
+}
+
+func TestProjectionManager_RestoreRebuildsOnVersionChange(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+	events := []EventMessage{newMemberJoined("guild-1", base), newMemberJoined("guild-1", base.Add(time.Second))}
+	store := NewInMemoryProjectionSnapshotStore()
+	require.NoError(t, store.SaveProjectionSnapshot(ctx, &ProjectionSnapshot{
+		ProjectionName:    "GuildView",
+		ProjectionVersion: "1",
+		Checkpoint:        ProjectionCheckpoint{LastEventID: events[1].EventID(), LastEventTime: events[1].Timestamp()},
+		ReadModels:        []ReadModel{NewBaseReadModel("guild-1", "GuildView", 99)},
+	}))
+
+	pm := NewInMemoryProjectionManager()
+	projection := newGuildCountProjection("2")
+	require.NoError(t, pm.RegisterProjection(projection))
+
+	// Act
+	err := pm.RestoreProjection(ctx, store, "GuildView", replayFrom(events))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"guild-1": 2}, projection.counts)
+}
+
+func TestProjectionManager_SnapshotRequiresSnapshottableProjection(t *testing.T) {
+	// Arrange
+	pm := NewInMemoryProjectionManager()
+	require.NoError(t, pm.RegisterProjection(NewTestProjection("Plain", "1", []string{"TestEvent"})))
+	store := NewInMemoryProjectionSnapshotStore()
+
+	// Act
+	err := pm.SnapshotProjection(context.Background(), store, "Plain")
+	allErr := pm.SnapshotAll(context.Background(), store)
+	_, loadErr := store.LoadProjectionSnapshot(context.Background(), "Plain")
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, allErr)
+	assert.True(t, IsNotFoundError(loadErr))
+}
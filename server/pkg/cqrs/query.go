@@ -195,13 +195,14 @@ func (so *SortingOptions) ToLegacySorting() *Sorting {
 
 // QueryResult represents query execution result
 type QueryResult struct {
-	Success       bool          `json:"success"`
-	Data          interface{}   `json:"data"`
-	Error         error         `json:"error,omitempty"`
-	TotalCount    int64         `json:"total_count,omitempty"`
-	Page          int           `json:"page,omitempty"`
-	PageSize      int           `json:"page_size,omitempty"`
-	ExecutionTime time.Duration `json:"execution_time"`
+	Success       bool                   `json:"success"`
+	Data          interface{}            `json:"data"`
+	Error         error                  `json:"error,omitempty"`
+	TotalCount    int64                  `json:"total_count,omitempty"`
+	Page          int                    `json:"page,omitempty"`
+	PageSize      int                    `json:"page_size,omitempty"`
+	ExecutionTime time.Duration          `json:"execution_time"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"` // Transport hints such as QueryMetadataETag
 }
 
 // QueryHandler interface for handling queries
@@ -0,0 +1,148 @@
+package cqrs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// QueryResult metadata keys set by ETagQueryDispatcher
+const (
+	QueryMetadataETag        = "etag"         // Entity tag of the result data
+	QueryMetadataNotModified = "not_modified" // true when the caller's If-None-Match matched; Data is nil
+)
+
+// ifNoneMatchKey carries the caller's If-None-Match header value
+type ifNoneMatchKey struct{}
+
+// WithIfNoneMatch passes the If-None-Match header value of a conditional request to the
+// query dispatcher
+func WithIfNoneMatch(ctx context.Context, ifNoneMatch string) context.Context {
+	if ifNoneMatch == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ifNoneMatchKey{}, ifNoneMatch)
+}
+
+// IfNoneMatch returns the If-None-Match value set by WithIfNoneMatch
+func IfNoneMatch(ctx context.Context) string {
+	value, _ := ctx.Value(ifNoneMatchKey{}).(string)
+	return value
+}
+
+// ETag returns the result's entity tag, or "" when none was computed
+func (r *QueryResult) ETag() string {
+	if r == nil {
+		return ""
+	}
+	etag, _ := r.Metadata[QueryMetadataETag].(string)
+	return etag
+}
+
+// NotModified reports whether the caller already holds the current result
+func (r *QueryResult) NotModified() bool {
+	if r == nil {
+		return false
+	}
+	notModified, _ := r.Metadata[QueryMetadataNotModified].(bool)
+	return notModified
+}
+
+// SetMetadata records a metadata value on the result
+func (r *QueryResult) SetMetadata(key string, value interface{}) *QueryResult {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]interface{})
+	}
+	r.Metadata[key] = value
+	return r
+}
+
+// ReadModelETag derives a weak ETag from the identity and version of read models, so it
+// changes whenever any of them is updated without hashing their data
+func ReadModelETag(models ...ReadModel) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d", len(models))
+	for _, model := range models {
+		if model == nil {
+			continue
+		}
+		fmt.Fprintf(hash, "|%s/%s@%d", model.GetType(), model.GetID(), model.GetVersion())
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
+
+// QueryResultETag derives the ETag of a result: version-derived for read models and read
+// model slices, content-derived for any other data. Paging fields are included because
+// the same page of models can belong to a different total.
+func QueryResultETag(result *QueryResult) (string, error) {
+	paging := fmt.Sprintf("%d:%d:%d", result.TotalCount, result.Page, result.PageSize)
+	switch data := result.Data.(type) {
+	case ReadModel:
+		return ReadModelETag(data), nil
+	case []ReadModel:
+		return combineETag(ReadModelETag(data...), paging), nil
+	}
+
+	content, err := json.Marshal(result.Data)
+	if err != nil {
+		return "", NewCQRSError(ErrCodeSerializationError.String(), "failed to serialize query result for etag", err)
+	}
+	hash := sha256.Sum256(append(content, paging...))
+	return `W/"` + hex.EncodeToString(hash[:])[:32] + `"`, nil
+}
+
+func combineETag(etag, suffix string) string {
+	hash := sha256.Sum256([]byte(etag + "|" + suffix))
+	return `W/"` + hex.EncodeToString(hash[:])[:32] + `"`
+}
+
+// ETagMatches implements the weak comparison of If-None-Match: a list of tags or "*"
+func ETagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// ETagQueryDispatcher adds an ETag to every successful query result and answers
+// conditional queries (WithIfNoneMatch) whose tag still matches with a NotModified result
+// without data, so HTTP endpoints can reply 304. Handlers that know a cheaper tag set
+// QueryMetadataETag themselves, e.g. with ReadModelETag.
+type ETagQueryDispatcher struct {
+	QueryDispatcher
+}
+
+// NewETagQueryDispatcher wraps dispatcher with ETag support
+func NewETagQueryDispatcher(dispatcher QueryDispatcher) *ETagQueryDispatcher {
+	return &ETagQueryDispatcher{QueryDispatcher: dispatcher}
+}
+
+func (d *ETagQueryDispatcher) Dispatch(ctx context.Context, query Query) (*QueryResult, error) {
+	result, err := d.QueryDispatcher.Dispatch(ctx, query)
+	if err != nil || result == nil || !result.Success {
+		return result, err
+	}
+
+	etag := result.ETag()
+	if etag == "" {
+		if etag, err = QueryResultETag(result); err != nil {
+			return nil, err
+		}
+		result.SetMetadata(QueryMetadataETag, etag)
+	}
+	if ETagMatches(IfNoneMatch(ctx), etag) {
+		result.Data = nil
+		result.SetMetadata(QueryMetadataNotModified, true)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagTestDispatcher(t *testing.T, store ReadStore) *ETagQueryDispatcher {
+	t.Helper()
+	dispatcher := NewInMemoryQueryDispatcher()
+	require.NoError(t, RegisterQueryHandler(dispatcher, "GetGuild", func(ctx context.Context, query *BaseQuery) (ReadModel, error) {
+		return store.GetByID(ctx, query.GetCriteria().(string), "Guild")
+	}))
+	require.NoError(t, RegisterQueryHandler(dispatcher, "GetGuildName", func(ctx context.Context, query *BaseQuery) (string, error) {
+		model, err := store.GetByID(ctx, query.GetCriteria().(string), "Guild")
+		if err != nil {
+			return "", err
+		}
+		return model.GetData().(string), nil
+	}))
+	return NewETagQueryDispatcher(dispatcher)
+}
+
+func TestETagQueryDispatcher_ConditionalQuery(t *testing.T) {
+	// Arrange
+	store := NewInMemoryReadStore()
+	model := NewBaseReadModel("guild-1", "Guild", "Defenders")
+	require.NoError(t, store.Save(context.Background(), model))
+	dispatcher := newETagTestDispatcher(t, store)
+	ctx := context.Background()
+	versionETag := ReadModelETag(model)
+
+	// Act
+	first, firstErr := dispatcher.Dispatch(ctx, NewBaseQuery("GetGuild", "guild-1"))
+	cached, cachedErr := dispatcher.Dispatch(WithIfNoneMatch(ctx, first.ETag()), NewBaseQuery("GetGuild", "guild-1"))
+	model.SetData("Defenders of Dawn") // 버전 증가
+	changed, changedErr := dispatcher.Dispatch(WithIfNoneMatch(ctx, first.ETag()), NewBaseQuery("GetGuild", "guild-1"))
+
+	// Assert
+	require.NoError(t, firstErr)
+	assert.Equal(t, versionETag, first.ETag())
+	assert.False(t, first.NotModified())
+	require.NoError(t, cachedErr)
+	assert.True(t, cached.NotModified())
+	assert.Nil(t, cached.Data)
+	require.NoError(t, changedErr)
+	assert.False(t, changed.NotModified())
+	assert.NotEqual(t, first.ETag(), changed.ETag())
+	assert.NotNil(t, changed.Data)
+}
+
+func TestETagQueryDispatcher_ContentETagForOtherData(t *testing.T) {
+	// Arrange
+	store := NewInMemoryReadStore()
+	require.NoError(t, store.Save(context.Background(), NewBaseReadModel("guild-1", "Guild", "Defenders")))
+	dispatcher := newETagTestDispatcher(t, store)
+	ctx := context.Background()
+
+	// Act
+	first, _ := dispatcher.Dispatch(ctx, NewBaseQuery("GetGuildName", "guild-1"))
+	again, _ := dispatcher.Dispatch(WithIfNoneMatch(ctx, `"other", `+first.ETag()), NewBaseQuery("GetGuildName", "guild-1"))
+	missing, _ := dispatcher.Dispatch(ctx, NewBaseQuery("GetGuildName", "guild-404"))
+
+	// Assert
+	assert.NotEmpty(t, first.ETag())
+	assert.True(t, again.NotModified())
+	assert.False(t, missing.Success)
+	assert.Empty(t, missing.ETag()) // 실패한 결과에는 ETag 없음
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, ETagMatches(`W/"abc"`, `W/"abc"`))
+	assert.True(t, ETagMatches(`"abc"`, `W/"abc"`))
+	assert.True(t, ETagMatches(`*`, `W/"abc"`))
+	assert.False(t, ETagMatches(`W/"abd"`, `W/"abc"`))
+	assert.False(t, ETagMatches(``, `W/"abc"`))
+}
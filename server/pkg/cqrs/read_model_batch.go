@@ -0,0 +1,52 @@
+package cqrs
+
+import "context"
+
+// BatchReadStore is implemented by read stores that can load several read models of
+// one type in a single round trip instead of one GetByID call per id
+type BatchReadStore interface {
+	// GetByIDs returns the read models that exist in the order of ids; missing ids are omitted
+	GetByIDs(ctx context.Context, ids []string, modelType string) ([]ReadModel, error)
+}
+
+// GetReadModelsByIDs loads the read models for ids in one batch when the store supports it.
+// Other stores fall back to GetByID per id, skipping ids that are not found.
+func GetReadModelsByIDs(ctx context.Context, store ReadStore, ids []string, modelType string) ([]ReadModel, error) {
+	if len(ids) == 0 {
+		return []ReadModel{}, nil
+	}
+	if modelType == "" {
+		return nil, NewCQRSError(ErrCodeRepositoryError.String(), "model type cannot be empty", nil).WithCategory(CategoryValidation)
+	}
+
+	if batch, ok := store.(BatchReadStore); ok {
+		return batch.GetByIDs(ctx, ids, modelType)
+	}
+
+	models := make([]ReadModel, 0, len(ids))
+	for _, id := range uniqueIDs(ids) {
+		model, err := store.GetByID(ctx, id, modelType)
+		if err != nil {
+			if IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// uniqueIDs drops empty and duplicate ids while keeping the first occurrence order
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
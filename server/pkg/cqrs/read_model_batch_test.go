@@ -0,0 +1,64 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// singleGetReadStore GetByIDs를 숨기고 GetByID 호출 횟수를 세는 읽기 저장소
+type singleGetReadStore struct {
+	ReadStore
+	gets int
+}
+
+func (s *singleGetReadStore) GetByID(ctx context.Context, id string, modelType string) (ReadModel, error) {
+	s.gets++
+	return s.ReadStore.GetByID(ctx, id, modelType)
+}
+
+func readModelIDs(models []ReadModel) []string {
+	ids := make([]string, 0, len(models))
+	for _, model := range models {
+		ids = append(ids, model.GetID())
+	}
+	return ids
+}
+
+func TestInMemoryReadStore_GetByIDs_KeepsOrderAndSkipsMissing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	for _, id := range []string{"guild-1:user-1", "guild-1:user-2", "guild-1:user-3"} {
+		require.NoError(t, store.Save(ctx, NewBaseReadModel(id, "MemberView", map[string]interface{}{"id": id})))
+	}
+	require.NoError(t, store.Save(ctx, NewBaseReadModel("guild-1:user-4", "UserView", map[string]interface{}{"id": "guild-1:user-4"})))
+
+	// Act
+	models, err := GetReadModelsByIDs(ctx, store,
+		[]string{"guild-1:user-3", "guild-1:missing", "guild-1:user-1", "guild-1:user-3", "guild-1:user-4"}, "MemberView")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guild-1:user-3", "guild-1:user-1"}, readModelIDs(models))
+}
+
+func TestGetReadModelsByIDs_FallsBackToGetByID(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	inner := NewInMemoryReadStore()
+	for _, id := range []string{"user-1", "user-2"} {
+		require.NoError(t, inner.Save(ctx, NewBaseReadModel(id, "UserView", map[string]interface{}{"id": id})))
+	}
+	store := &singleGetReadStore{ReadStore: inner}
+
+	// Act
+	models, err := GetReadModelsByIDs(ctx, store, []string{"user-2", "user-9", "user-1"}, "UserView")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2", "user-1"}, readModelIDs(models))
+	assert.Equal(t, 3, store.gets)
+}
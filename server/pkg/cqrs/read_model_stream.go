@@ -0,0 +1,99 @@
+package cqrs
+
+import "context"
+
+// ReadModelCursor iterates query results one read model at a time, so large result
+// sets never have to be held in memory at once.
+//
+// Usage:
+//
+//	cursor, err := StreamQuery(ctx, store, criteria)
+//	if err != nil { ... }
+//	defer cursor.Close(ctx)
+//	for cursor.Next(ctx) {
+//		model := cursor.ReadModel()
+//	}
+//	if err := cursor.Err(); err != nil { ... }
+type ReadModelCursor interface {
+	// Next advances to the next read model; false when exhausted or on error
+	Next(ctx context.Context) bool
+	// ReadModel returns the read model Next advanced to
+	ReadModel() ReadModel
+	// Err returns the error that stopped iteration, if any
+	Err() error
+	// Close releases the server-side cursor; safe to call more than once
+	Close(ctx context.Context) error
+}
+
+// StreamingReadStore is implemented by read stores that can stream query results
+// with a server-side cursor instead of materializing them like ReadStore.Query
+type StreamingReadStore interface {
+	QueryStream(ctx context.Context, criteria QueryCriteria) (ReadModelCursor, error)
+}
+
+// sliceReadModelCursor iterates an already loaded result slice
+type sliceReadModelCursor struct {
+	models  []ReadModel
+	current ReadModel
+}
+
+// NewSliceReadModelCursor returns a cursor over already loaded read models
+func NewSliceReadModelCursor(models []ReadModel) ReadModelCursor {
+	return &sliceReadModelCursor{models: models}
+}
+
+func (c *sliceReadModelCursor) Next(ctx context.Context) bool {
+	if len(c.models) == 0 || ctx.Err() != nil {
+		c.current = nil
+		return false
+	}
+	c.current, c.models = c.models[0], c.models[1:]
+	return true
+}
+
+func (c *sliceReadModelCursor) ReadModel() ReadModel {
+	return c.current
+}
+
+func (c *sliceReadModelCursor) Err() error {
+	return nil
+}
+
+func (c *sliceReadModelCursor) Close(ctx context.Context) error {
+	c.models = nil
+	c.current = nil
+	return nil
+}
+
+// StreamQuery opens a cursor over the query results. Stores without streaming support
+// fall back to Query, which loads every result before iteration starts.
+func StreamQuery(ctx context.Context, store ReadStore, criteria QueryCriteria) (ReadModelCursor, error) {
+	if streaming, ok := store.(StreamingReadStore); ok {
+		return streaming.QueryStream(ctx, criteria)
+	}
+
+	models, err := store.Query(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	return NewSliceReadModelCursor(models), nil
+}
+
+// ForEachReadModel calls fn for every query result in order and stops at the first error
+func ForEachReadModel(ctx context.Context, store ReadStore, criteria QueryCriteria, fn func(ReadModel) error) error {
+	cursor, err := StreamQuery(ctx, store, criteria)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := fn(cursor.ReadModel()); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,58 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamQuery_FallsBackToQuery(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, store.Save(ctx, NewBaseReadModel(id, "UserView", map[string]interface{}{"name": id})))
+	}
+
+	// Act
+	cursor, err := StreamQuery(ctx, store, QueryCriteria{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0)
+	for cursor.Next(ctx) {
+		ids = append(ids, cursor.ReadModel().GetID())
+	}
+
+	// Assert
+	require.NoError(t, cursor.Err())
+	require.NoError(t, cursor.Close(ctx))
+	assert.ElementsMatch(t, []string{"user-1", "user-2", "user-3"}, ids)
+	assert.False(t, cursor.Next(ctx))
+}
+
+func TestForEachReadModel_StopsAtCallbackError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryReadStore()
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, store.Save(ctx, NewBaseReadModel(id, "UserView", map[string]interface{}{"name": id})))
+	}
+	stop := errors.New("enough")
+
+	// Act
+	calls := 0
+	err := ForEachReadModel(ctx, store, QueryCriteria{}, func(model ReadModel) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,291 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MaskFunc replaces a sensitive value with its masked form
+type MaskFunc func(value string) string
+
+// MaskAll hides the whole value
+func MaskAll(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "***"
+}
+
+// MaskEmail keeps the first character of the local part and the domain: j***@example.com
+func MaskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return MaskAll(value)
+	}
+	return value[:1] + "***" + value[at:]
+}
+
+// MaskPhone keeps only the last four digits: ***-5678
+func MaskPhone(value string) string {
+	digits := make([]rune, 0, len(value))
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) <= 4 {
+		return MaskAll(value)
+	}
+	return "***-" + string(digits[len(digits)-4:])
+}
+
+// RedactionPolicy masks personal data (email, phone, ...) before events and read models
+// leave the trusted boundary: exports, logs and queries from non-privileged callers.
+//
+// Fields are selected in two ways:
+//   - a field registry: RedactField("email", "email") masks every "email" key at any depth
+//   - struct tags: `redact:"phone"` on a struct field masks that field (by its JSON name)
+type RedactionPolicy struct {
+	mutex   sync.RWMutex
+	maskers map[string]MaskFunc
+	fields  map[string]string // lower-case field name -> masker name
+
+	tagCache sync.Map // reflect.Type -> map[string]string (JSON path -> masker name)
+}
+
+// NewRedactionPolicy creates a policy with the built-in "full", "email" and "phone" maskers
+func NewRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{
+		maskers: map[string]MaskFunc{
+			"full":  MaskAll,
+			"email": MaskEmail,
+			"phone": MaskPhone,
+		},
+		fields: make(map[string]string),
+	}
+}
+
+// RegisterMasker adds or replaces a named masker
+func (p *RedactionPolicy) RegisterMasker(name string, mask MaskFunc) error {
+	if name == "" || mask == nil {
+		return NewValidationError("masker name and function are required", nil)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.maskers[name] = mask
+	return nil
+}
+
+// RedactField masks every field with the given name (case-insensitive) using the named masker
+func (p *RedactionPolicy) RedactField(field, masker string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, exists := p.maskers[masker]; !exists {
+		return NewValidationError(fmt.Sprintf("unknown masker: %s", masker), nil)
+	}
+	p.fields[strings.ToLower(field)] = masker
+	return nil
+}
+
+// RedactMap returns a masked deep copy of a JSON-like map; the input is not modified
+func (p *RedactionPolicy) RedactMap(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.walk(data, "", nil).(map[string]interface{})
+}
+
+// Redact returns a masked JSON-like copy of value (map, struct, slice).
+// Structs are converted through their JSON form, so the result uses JSON field names.
+func (p *RedactionPolicy) Redact(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if data, ok := value.(map[string]interface{}); ok {
+		return p.RedactMap(data), nil
+	}
+
+	tagRules := p.tagRules(reflect.TypeOf(value))
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(), "failed to serialize value for redaction", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(), "failed to decode value for redaction", err)
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.walk(generic, "", tagRules), nil
+}
+
+// RedactFor redacts value unless ctx carries a redaction exemption
+func (p *RedactionPolicy) RedactFor(ctx context.Context, value interface{}) (interface{}, error) {
+	if IsRedactionExempt(ctx) {
+		return value, nil
+	}
+	return p.Redact(value)
+}
+
+func (p *RedactionPolicy) walk(value interface{}, path string, tagRules map[string]string) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			childPath := path + key
+			if masker := p.maskerFor(key, childPath, tagRules); masker != nil && child != nil {
+				masked[key] = p.maskValue(child, masker)
+				continue
+			}
+			masked[key] = p.walk(child, childPath+".", tagRules)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(typed))
+		for i, child := range typed {
+			masked[i] = p.walk(child, path, tagRules)
+		}
+		return masked
+	default:
+		return value
+	}
+}
+
+func (p *RedactionPolicy) maskerFor(key, path string, tagRules map[string]string) MaskFunc {
+	if name, exists := tagRules[path]; exists {
+		if masker, exists := p.maskers[name]; exists {
+			return masker
+		}
+		return MaskAll
+	}
+	if name, exists := p.fields[strings.ToLower(key)]; exists {
+		return p.maskers[name]
+	}
+	return nil
+}
+
+// maskValue masks scalars and every scalar inside nested values
+func (p *RedactionPolicy) maskValue(value interface{}, masker MaskFunc) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return masker(typed)
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			masked[key] = p.maskValue(child, masker)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(typed))
+		for i, child := range typed {
+			masked[i] = p.maskValue(child, masker)
+		}
+		return masked
+	case nil:
+		return nil
+	default:
+		return masker(fmt.Sprint(typed))
+	}
+}
+
+// tagRules collects `redact` struct tags as JSON paths; results are cached per type
+func (p *RedactionPolicy) tagRules(t reflect.Type) map[string]string {
+	if cached, ok := p.tagCache.Load(t); ok {
+		return cached.(map[string]string)
+	}
+	rules := make(map[string]string)
+	collectRedactTags(t, "", rules, make(map[reflect.Type]bool))
+	p.tagCache.Store(t, rules)
+	return rules
+}
+
+func collectRedactTags(t reflect.Type, prefix string, rules map[string]string, visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			jsonName := strings.Split(tag, ",")[0]
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName != "" {
+				name = jsonName
+			} else if field.Anonymous {
+				name = ""
+			}
+		} else if field.Anonymous {
+			name = ""
+		}
+
+		if masker := field.Tag.Get("redact"); masker != "" && name != "" {
+			rules[prefix+name] = masker
+			continue
+		}
+		if name == "" {
+			collectRedactTags(field.Type, prefix, rules, visiting)
+		} else {
+			collectRedactTags(field.Type, prefix+name+".", rules, visiting)
+		}
+	}
+}
+
+type redactionExemptKey struct{}
+
+// WithRedactionExempt marks ctx as privileged (e.g. an operator or the data subject), skipping redaction
+func WithRedactionExempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactionExemptKey{}, true)
+}
+
+// IsRedactionExempt reports whether ctx was marked with WithRedactionExempt
+func IsRedactionExempt(ctx context.Context) bool {
+	exempt, _ := ctx.Value(redactionExemptKey{}).(bool)
+	return exempt
+}
+
+// RedactingQueryDispatcher masks QueryResult.Data for callers without a redaction exemption
+type RedactingQueryDispatcher struct {
+	QueryDispatcher
+	policy *RedactionPolicy
+}
+
+// NewRedactingQueryDispatcher wraps dispatcher with the redaction policy
+func NewRedactingQueryDispatcher(dispatcher QueryDispatcher, policy *RedactionPolicy) *RedactingQueryDispatcher {
+	return &RedactingQueryDispatcher{QueryDispatcher: dispatcher, policy: policy}
+}
+
+func (d *RedactingQueryDispatcher) Dispatch(ctx context.Context, query Query) (*QueryResult, error) {
+	result, err := d.QueryDispatcher.Dispatch(ctx, query)
+	if err != nil || result == nil || result.Data == nil || IsRedactionExempt(ctx) {
+		return result, err
+	}
+
+	data, err := d.policy.Redact(result.Data)
+	if err != nil {
+		return nil, err
+	}
+	redacted := *result
+	redacted.Data = data
+	return &redacted, nil
+}
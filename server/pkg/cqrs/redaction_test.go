@@ -0,0 +1,94 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type playerContact struct {
+	Phone string `json:"phone" redact:"phone"`
+}
+
+type playerProfileView struct {
+	PlayerID string          `json:"player_id"`
+	Nickname string          `json:"nickname"`
+	Email    string          `json:"email"`
+	Contacts []playerContact `json:"contacts"`
+	Memo     string          `json:"memo" redact:"full"`
+}
+
+func TestRedactionPolicy_Redact_UsesTagsAndFieldRegistry(t *testing.T) {
+	// Arrange
+	policy := NewRedactionPolicy()
+	require.NoError(t, policy.RedactField("email", "email"))
+	view := playerProfileView{
+		PlayerID: "player-1",
+		Nickname: "commander",
+		Email:    "jane@example.com",
+		Contacts: []playerContact{{Phone: "010-1234-5678"}},
+		Memo:     "lives in Seoul",
+	}
+
+	// Act
+	redacted, err := policy.Redact(view)
+
+	// Assert
+	require.NoError(t, err)
+	data := redacted.(map[string]interface{})
+	assert.Equal(t, "player-1", data["player_id"])
+	assert.Equal(t, "j***@example.com", data["email"])
+	assert.Equal(t, "***", data["memo"])
+	assert.Equal(t, "***-5678", data["contacts"].([]interface{})[0].(map[string]interface{})["phone"])
+	assert.Equal(t, "jane@example.com", view.Email, "the source value is not modified")
+}
+
+func TestRedactionPolicy_RedactMap_MasksNestedFields(t *testing.T) {
+	// Arrange
+	policy := NewRedactionPolicy()
+	require.NoError(t, policy.RedactField("Phone", "phone"))
+	data := map[string]interface{}{
+		"guild":   "alpha",
+		"members": []interface{}{map[string]interface{}{"phone": "01012345678"}},
+	}
+
+	// Act
+	redacted := policy.RedactMap(data)
+
+	// Assert
+	assert.Equal(t, "***-5678", redacted["members"].([]interface{})[0].(map[string]interface{})["phone"])
+	assert.Equal(t, "01012345678", data["members"].([]interface{})[0].(map[string]interface{})["phone"])
+}
+
+func TestRedactionPolicy_RedactField_RejectsUnknownMasker(t *testing.T) {
+	// Act
+	err := NewRedactionPolicy().RedactField("email", "scramble")
+
+	// Assert
+	assert.True(t, IsValidationError(err))
+}
+
+func TestRedactingQueryDispatcher_SkipsExemptCallers(t *testing.T) {
+	// Arrange
+	policy := NewRedactionPolicy()
+	require.NoError(t, policy.RedactField("email", "email"))
+	inner := NewInMemoryQueryDispatcher()
+	err := RegisterQueryHandler(inner, "GetPlayer", func(ctx context.Context, query *BaseQuery) (map[string]interface{}, error) {
+		return map[string]interface{}{"email": "jane@example.com"}, nil
+	})
+	require.NoError(t, err)
+	dispatcher := NewRedactingQueryDispatcher(inner, policy)
+	query := NewBaseQuery("GetPlayer", "player-1")
+
+	// Act
+	public, err := dispatcher.Dispatch(context.Background(), query)
+	require.NoError(t, err)
+	privileged, err := dispatcher.Dispatch(WithRedactionExempt(context.Background()), query)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "j***@example.com", public.Data.(map[string]interface{})["email"])
+	assert.Equal(t, "jane@example.com", privileged.Data.(map[string]interface{})["email"])
+}
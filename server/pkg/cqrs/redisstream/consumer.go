@@ -0,0 +1,337 @@
+package redisstream
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ConsumerConfig configures a Consumer
+type ConsumerConfig struct {
+	Group          string   // Consumer group name, shared by every instance
+	Name           string   // Unique instance name (e.g. hostname-pid)
+	AggregateTypes []string // Aggregate types whose shards are consumed
+	Sharding       Sharding
+
+	BatchSize int64         // Entries per XREADGROUP, default 100
+	Block     time.Duration // XREADGROUP block timeout, default 1s
+	LeaseTTL  time.Duration // Membership expiry; also the idle time before pending entries are reclaimed, default 15s
+	Heartbeat time.Duration // Membership refresh and rebalance interval, default LeaseTTL/3
+
+	// OnHandleError reports failed entries. Entries that cannot be decoded are reported with
+	// a pooled envelope carrying the entry's IDs, which must not be kept after the call.
+	OnHandleError func(event cqrs.EventMessage, err error)
+
+	// Serializers decode entries by content type, in addition to the built-in JSON serializer.
+	// Entries with an unknown content type are reported and left pending, so an instance that
+	// knows the type reclaims them once upgraded.
+	Serializers []Serializer
+}
+
+func (c *ConsumerConfig) applyDefaults() {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.Block <= 0 {
+		c.Block = time.Second
+	}
+	if c.LeaseTTL <= 0 {
+		c.LeaseTTL = 15 * time.Second
+	}
+	if c.Heartbeat <= 0 {
+		c.Heartbeat = c.LeaseTTL / 3
+	}
+}
+
+// Consumer reads the shards assigned to it and dispatches events to a handler.
+//
+// Instances of a group register in a membership sorted set and refresh it every
+// heartbeat. Shards are split deterministically by position in the sorted member
+// list, so every instance computes the same assignment without coordination.
+// During a rebalance two instances may briefly read the same shard; the consumer
+// group still delivers each entry once, but per-aggregate ordering is only
+// guaranteed while the assignment is stable. Entries left pending by a departed
+// instance are reclaimed once they have been idle for LeaseTTL.
+type Consumer struct {
+	client      redis.UniversalClient
+	config      ConsumerConfig
+	handler     cqrs.EventHandler
+	registry    cqrsx.EventRegistry
+	serializers serializerSet
+
+	mutex    sync.RWMutex
+	assigned []string
+}
+
+// NewConsumer creates a consumer; registry resolves concrete event types
+func NewConsumer(client redis.UniversalClient, config ConsumerConfig, handler cqrs.EventHandler, registry cqrsx.EventRegistry) (*Consumer, error) {
+	if config.Group == "" || config.Name == "" {
+		return nil, cqrs.NewValidationError("consumer group and name are required", nil)
+	}
+	if len(config.AggregateTypes) == 0 {
+		return nil, cqrs.NewValidationError("at least one aggregate type is required", nil)
+	}
+	if handler == nil || registry == nil {
+		return nil, cqrs.NewValidationError("handler and registry are required", nil)
+	}
+	serializers, err := newSerializerSet(config.Serializers)
+	if err != nil {
+		return nil, err
+	}
+	config.applyDefaults()
+
+	return &Consumer{client: client, config: config, handler: handler, registry: registry, serializers: serializers}, nil
+}
+
+// membersKey is the sorted set of live instances scored by last heartbeat
+func (c *Consumer) membersKey() string {
+	prefix := c.config.Sharding.KeyPrefix
+	if prefix == "" {
+		prefix = "events"
+	}
+	return prefix + ":consumers:" + c.config.Group
+}
+
+// Assignment returns the stream keys currently assigned to this instance
+func (c *Consumer) Assignment() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return append([]string(nil), c.assigned...)
+}
+
+// Run consumes until ctx is cancelled, then leaves the group membership
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.ensureGroups(ctx); err != nil {
+		return err
+	}
+	if err := c.Rebalance(ctx); err != nil {
+		return err
+	}
+	defer c.leave()
+
+	ticker := time.NewTicker(c.config.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := c.Rebalance(ctx); err != nil && ctx.Err() == nil {
+				return err
+			}
+		default:
+		}
+
+		if err := c.Poll(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+}
+
+// ensureGroups creates the consumer group on every shard stream
+func (c *Consumer) ensureGroups(ctx context.Context) error {
+	for _, key := range c.config.Sharding.StreamKeys(c.config.AggregateTypes...) {
+		err := c.client.XGroupCreateMkStream(ctx, key, c.config.Group, "0").Err()
+		if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(),
+				fmt.Sprintf("failed to create consumer group on %s", key), err)
+		}
+	}
+	return nil
+}
+
+// Rebalance refreshes this instance's membership and recomputes its shard assignment
+func (c *Consumer) Rebalance(ctx context.Context) error {
+	now := time.Now()
+	key := c.membersKey()
+
+	pipe := c.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: c.config.Name})
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-c.config.LeaseTTL).UnixMilli(), 10))
+	members := pipe.ZRange(ctx, key, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(), "failed to refresh consumer membership", err)
+	}
+
+	assigned := AssignShards(c.config.Sharding.StreamKeys(c.config.AggregateTypes...), members.Val(), c.config.Name)
+
+	c.mutex.Lock()
+	c.assigned = assigned
+	c.mutex.Unlock()
+	return nil
+}
+
+// AssignShards returns the keys owned by member: key i belongs to the member at
+// position i mod len(members) of the sorted member list
+func AssignShards(keys []string, members []string, member string) []string {
+	sorted := append([]string(nil), members...)
+	sort.Strings(sorted)
+
+	index := sort.SearchStrings(sorted, member)
+	if index == len(sorted) || sorted[index] != member {
+		return nil
+	}
+
+	var assigned []string
+	for i, key := range keys {
+		if i%len(sorted) == index {
+			assigned = append(assigned, key)
+		}
+	}
+	return assigned
+}
+
+func (c *Consumer) leave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.client.ZRem(ctx, c.membersKey(), c.config.Name)
+}
+
+// Poll reclaims stale pending entries and reads one batch from the assigned shards
+func (c *Consumer) Poll(ctx context.Context) error {
+	keys := c.Assignment()
+	if len(keys) == 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(c.config.Block):
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		if err := c.reclaim(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	streams := make([]string, 0, len(keys)*2)
+	streams = append(streams, keys...)
+	for range keys {
+		streams = append(streams, ">")
+	}
+
+	result, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.config.Group,
+		Consumer: c.config.Name,
+		Streams:  streams,
+		Count:    c.config.BatchSize,
+		Block:    c.config.Block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+			return nil
+		}
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(), "failed to read event streams", err)
+	}
+
+	for _, stream := range result {
+		c.process(ctx, stream.Stream, stream.Messages)
+	}
+	return nil
+}
+
+// reclaim takes over entries another (possibly departed) instance left pending
+func (c *Consumer) reclaim(ctx context.Context, key string) error {
+	messages, _, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   key,
+		Group:    c.config.Group,
+		Consumer: c.config.Name,
+		MinIdle:  c.config.LeaseTTL,
+		Start:    "0-0",
+		Count:    c.config.BatchSize,
+	}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(),
+			fmt.Sprintf("failed to reclaim pending entries of %s", key), err)
+	}
+
+	c.process(ctx, key, messages)
+	return nil
+}
+
+// process dispatches entries and acknowledges the successful ones.
+// Failed entries stay pending and are retried after LeaseTTL; entries that cannot
+// be decoded are reported (with an envelope event) and acknowledged, since a retry
+// would never succeed, unless only their content type is unknown.
+func (c *Consumer) process(ctx context.Context, key string, messages []redis.XMessage) {
+	var acked []string
+	for _, msg := range messages {
+		event, err := c.decode(msg)
+		if err != nil {
+			c.reportUndecodable(msg, err)
+			if !errors.Is(err, ErrUnsupportedContentType) {
+				acked = append(acked, msg.ID)
+			}
+			continue
+		}
+
+		if c.handler.CanHandle(event.EventType()) {
+			if err := c.handler.Handle(ctx, event); err != nil {
+				c.reportError(event, err)
+				continue
+			}
+		}
+		acked = append(acked, msg.ID)
+	}
+
+	if len(acked) > 0 {
+		c.client.XAck(ctx, key, c.config.Group, acked...)
+	}
+}
+
+func (c *Consumer) reportError(event cqrs.EventMessage, err error) {
+	if c.config.OnHandleError != nil {
+		c.config.OnHandleError(event, err)
+	}
+}
+
+// reportUndecodable reports an entry through a pooled envelope built from its fields
+func (c *Consumer) reportUndecodable(msg redis.XMessage, err error) {
+	if c.config.OnHandleError == nil {
+		return
+	}
+	eventType, _ := msg.Values[FieldEventType].(string)
+	envelope := cqrs.AcquireEventMessage(eventType)
+	defer cqrs.ReleaseEventMessage(envelope)
+
+	envelope.EventID_, _ = msg.Values[FieldEventID].(string)
+	envelope.AggregateID_, _ = msg.Values[FieldAggregateID].(string)
+	envelope.AggregateType_, _ = msg.Values[FieldAggregateType].(string)
+	c.config.OnHandleError(envelope, err)
+}
+
+func (c *Consumer) decode(msg redis.XMessage) (cqrs.EventMessage, error) {
+	payload, ok := msg.Values[FieldPayload].(string)
+	if !ok {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("stream entry %s has no payload", msg.ID), nil)
+	}
+	contentType, _ := msg.Values[FieldContentType].(string)
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+	serializer, ok := c.serializers[contentType]
+	if !ok {
+		return nil, cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+			fmt.Sprintf("stream entry %s has content type %s, supported: %s", msg.ID, contentType, strings.Join(c.serializers.contentTypes(), ", ")),
+			ErrUnsupportedContentType)
+	}
+	eventType, _ := msg.Values[FieldEventType].(string)
+
+	// Serializers do not retain the payload, so it is copied into a pooled buffer
+	// rather than a fresh slice per entry
+	buf := cqrsx.AcquireBuffer()
+	defer cqrsx.ReleaseBuffer(buf)
+	buf.WriteString(payload)
+	return serializer.Unmarshal(eventType, buf.Bytes(), c.registry)
+}
@@ -0,0 +1,212 @@
+package redisstream
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memberJoined struct {
+	cqrs.BaseEventMessage
+	Member string `json:"member"`
+}
+
+func newMemberJoined(guildID, member string) *memberJoined {
+	event := &memberJoined{BaseEventMessage: *cqrs.NewBaseEventMessage("MemberJoined"), Member: member}
+	event.AggregateID_ = guildID
+	event.AggregateType_ = "Guild"
+	return event
+}
+
+func newTestRegistry(t testing.TB) *cqrsx.VersionedEventRegistry {
+	registry := cqrsx.NewVersionedEventRegistry()
+	require.NoError(t, cqrsx.RegisterEvent[memberJoined](registry, "MemberJoined", 1))
+	return registry
+}
+
+func newTestClient(t testing.TB) redis.UniversalClient {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// recordingHandler 처리한 이벤트를 기록하는 테스트 핸들러
+type recordingHandler struct {
+	*cqrs.BaseEventHandler
+	mu     sync.Mutex
+	events []cqrs.EventMessage
+	fail   bool
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{BaseEventHandler: cqrs.NewBaseEventHandler("recorder", cqrs.ProjectionHandler, []string{"MemberJoined"})}
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.fail {
+		return errors.New("handler failed")
+	}
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.events)
+}
+
+func TestSharding_RoutesAggregateToStableShard(t *testing.T) {
+	// Arrange
+	sharding := NewSharding(map[string]int{"Guild": 4})
+
+	// Act
+	first := sharding.StreamKeyFor("Guild", "guild-42")
+	second := sharding.StreamKeyFor("Guild", "guild-42")
+	used := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		used[sharding.StreamKeyFor("Guild", fmt.Sprintf("guild-%d", i))] = true
+	}
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.Len(t, used, 4)
+	assert.Len(t, sharding.StreamKeys("Guild", "User"), 4+DefaultShardCount)
+	assert.Equal(t, "events:Guild:3", sharding.StreamKey("Guild", 3))
+}
+
+func TestAssignShards_CoversEveryShardOnce(t *testing.T) {
+	// Arrange
+	keys := NewSharding(map[string]int{"Guild": 5}).StreamKeys("Guild")
+	members := []string{"node-c", "node-a", "node-b"}
+
+	// Act
+	owners := make(map[string]string)
+	for _, member := range members {
+		for _, key := range AssignShards(keys, members, member) {
+			_, duplicated := owners[key]
+			assert.False(t, duplicated, key)
+			owners[key] = member
+		}
+	}
+
+	// Assert
+	assert.Len(t, owners, len(keys))
+	assert.Empty(t, AssignShards(keys, members, "node-z"))
+}
+
+func TestConsumer_ConsumesPublishedEvents(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 4})
+	handler := newRecordingHandler()
+	consumer, err := NewConsumer(client, ConsumerConfig{
+		Group: "projections", Name: "node-a", AggregateTypes: []string{"Guild"}, Sharding: sharding, Block: 10 * time.Millisecond,
+	}, handler, newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, consumer.ensureGroups(ctx))
+	require.NoError(t, consumer.Rebalance(ctx))
+
+	publisher := NewPublisher(client, sharding)
+	var events []cqrs.EventMessage
+	for i := 0; i < 20; i++ {
+		events = append(events, newMemberJoined(fmt.Sprintf("guild-%d", i), "commander"))
+	}
+
+	// Act
+	require.NoError(t, publisher.Publish(ctx, events...))
+	for i := 0; i < 5 && handler.count() < len(events); i++ {
+		require.NoError(t, consumer.Poll(ctx))
+	}
+
+	// Assert
+	assert.Equal(t, len(events), handler.count())
+	decoded, ok := handler.events[0].(*memberJoined)
+	require.True(t, ok)
+	assert.Equal(t, "commander", decoded.Member)
+
+	pending, err := client.XPending(ctx, sharding.StreamKeyFor("Guild", "guild-0"), "projections").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestConsumer_RebalancesBetweenInstances(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 4})
+	newConsumer := func(name string) *Consumer {
+		consumer, err := NewConsumer(client, ConsumerConfig{
+			Group: "projections", Name: name, AggregateTypes: []string{"Guild"}, Sharding: sharding,
+		}, newRecordingHandler(), newTestRegistry(t))
+		require.NoError(t, err)
+		return consumer
+	}
+	nodeA, nodeB := newConsumer("node-a"), newConsumer("node-b")
+
+	// Act
+	require.NoError(t, nodeA.Rebalance(ctx))
+	alone := nodeA.Assignment()
+	require.NoError(t, nodeB.Rebalance(ctx))
+	require.NoError(t, nodeA.Rebalance(ctx))
+
+	// Assert
+	assert.Len(t, alone, 4)
+	assert.Len(t, nodeA.Assignment(), 2)
+	assert.Len(t, nodeB.Assignment(), 2)
+	assert.NotContains(t, nodeB.Assignment(), nodeA.Assignment()[0])
+
+	nodeB.leave()
+	require.NoError(t, nodeA.Rebalance(ctx))
+	assert.Len(t, nodeA.Assignment(), 4)
+}
+
+func TestConsumer_ReclaimsEntriesLeftPendingByDepartedInstance(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 1})
+	config := ConsumerConfig{
+		Group: "projections", AggregateTypes: []string{"Guild"}, Sharding: sharding,
+		Block: 10 * time.Millisecond, LeaseTTL: 20 * time.Millisecond,
+	}
+
+	failing := newRecordingHandler()
+	failing.fail = true
+	config.Name = "node-a"
+	departed, err := NewConsumer(client, config, failing, newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, departed.ensureGroups(ctx))
+	require.NoError(t, departed.Rebalance(ctx))
+
+	require.NoError(t, NewPublisher(client, sharding).Publish(ctx, newMemberJoined("guild-1", "scout")))
+	require.NoError(t, departed.Poll(ctx))
+	departed.leave()
+
+	healthy := newRecordingHandler()
+	config.Name = "node-b"
+	survivor, err := NewConsumer(client, config, healthy, newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, survivor.Rebalance(ctx))
+	time.Sleep(30 * time.Millisecond)
+
+	// Act
+	require.NoError(t, survivor.Poll(ctx))
+
+	// Assert
+	assert.Equal(t, 1, healthy.count())
+}
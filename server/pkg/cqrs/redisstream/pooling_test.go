@@ -0,0 +1,178 @@
+package redisstream
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unbufferedSerializer MarshalTo를 숨겨 풀을 사용하지 않는 기존 경로와 비교하기 위한 래퍼
+type unbufferedSerializer struct {
+	Serializer
+}
+
+func TestPublisher_ReusedEntriesKeepTheirOwnFields(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 1})
+	publisher := NewPublisher(client, sharding)
+	first, second := newMemberJoined("guild-1", "scout"), newMemberJoined("guild-2", "medic")
+
+	// Act
+	require.NoError(t, publisher.Publish(ctx, first, second))
+	require.NoError(t, publisher.Publish(ctx, first))
+
+	// Assert - 재사용된 XADD 봉투에 이전 이벤트의 필드가 남지 않아야 함
+	entries, err := client.XRange(ctx, sharding.StreamKey("Guild", 0), "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for i, expected := range []cqrs.EventMessage{first, second, first} {
+		assert.Equal(t, expected.EventID(), entries[i].Values[FieldEventID])
+		assert.Equal(t, expected.AggregateID(), entries[i].Values[FieldAggregateID])
+		assert.Len(t, entries[i].Values, 6)
+	}
+}
+
+func TestConsumer_ReportsUndecodableEntryThroughPooledEnvelope(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 1})
+	key := sharding.StreamKey("Guild", 0)
+	var reported []string
+	config := ConsumerConfig{Group: "projections", Name: "node-1", AggregateTypes: []string{"Guild"}, Sharding: sharding, Block: 10 * time.Millisecond}
+	config.OnHandleError = func(event cqrs.EventMessage, err error) {
+		// 봉투는 콜백이 끝나면 풀로 돌아가므로 값만 기록
+		reported = append(reported, fmt.Sprintf("%s:%s:%s:%s", event.EventID(), event.EventType(), event.AggregateType(), event.AggregateID()))
+	}
+	consumer, err := NewConsumer(client, config, newRecordingHandler(), newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, consumer.ensureGroups(ctx))
+	require.NoError(t, consumer.Rebalance(ctx))
+	for _, id := range []string{"event-1", "event-2"} {
+		require.NoError(t, client.XAdd(ctx, &redis.XAddArgs{Stream: key, Values: map[string]interface{}{
+			FieldEventID: id, FieldEventType: "MemberJoined", FieldAggregateID: "guild-1", FieldAggregateType: "Guild", FieldPayload: "{broken",
+		}}).Err())
+	}
+
+	// Act
+	require.NoError(t, consumer.Poll(ctx))
+
+	// Assert
+	assert.Equal(t, []string{"event-1:MemberJoined:Guild:guild-1", "event-2:MemberJoined:Guild:guild-1"}, reported)
+	pending, err := client.XPending(ctx, key, "projections").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count, "디코딩할 수 없는 항목은 확인 응답해야 합니다")
+}
+
+func TestPublisher_PooledPayloadsMatchUnpooled(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sharding := NewSharding(map[string]int{"Guild": 1})
+	events := []cqrs.EventMessage{newMemberJoined("guild-1", "scout"), newMemberJoined("guild-1", "medic")}
+	registry := newTestRegistry(t)
+
+	for _, serializer := range []Serializer{JSONSerializer{}, MsgpackSerializer{}, NewProtobufSerializer()} {
+		t.Run(serializer.ContentType(), func(t *testing.T) {
+			pooledClient, plainClient := newTestClient(t), newTestClient(t)
+
+			// Act
+			require.NoError(t, NewPublisher(pooledClient, sharding, WithSerializer(serializer)).Publish(ctx, events...))
+			require.NoError(t, NewPublisher(plainClient, sharding, WithSerializer(unbufferedSerializer{serializer})).Publish(ctx, events...))
+
+			// Assert - 같은 파이프라인 안의 버퍼가 서로 덮어쓰지 않아야 함
+			pooled, err := pooledClient.XRange(ctx, sharding.StreamKey("Guild", 0), "-", "+").Result()
+			require.NoError(t, err)
+			plain, err := plainClient.XRange(ctx, sharding.StreamKey("Guild", 0), "-", "+").Result()
+			require.NoError(t, err)
+			require.Len(t, pooled, len(events))
+			for i := range events {
+				// protobuf Struct의 필드 순서는 고정되지 않으므로 디코딩한 이벤트로 비교
+				expected, err := serializer.Unmarshal("MemberJoined", []byte(plain[i].Values[FieldPayload].(string)), registry)
+				require.NoError(t, err)
+				actual, err := serializer.Unmarshal("MemberJoined", []byte(pooled[i].Values[FieldPayload].(string)), registry)
+				require.NoError(t, err)
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, events[i].(*memberJoined).Member, actual.(*memberJoined).Member)
+			}
+		})
+	}
+}
+
+// publishBatchSize 벤치마크 한 번에 게시하는 이벤트 수 (10k events/sec 부하에서 100ms 분량)
+const publishBatchSize = 1000
+
+func BenchmarkPublisher_Publish(b *testing.B) {
+	ctx := context.Background()
+	sharding := NewSharding(map[string]int{"Guild": 16})
+	events := make([]cqrs.EventMessage, publishBatchSize)
+	for i := range events {
+		events[i] = newMemberJoined(fmt.Sprintf("guild-%d", i), "scout")
+	}
+
+	for _, bench := range []struct {
+		name       string
+		serializer Serializer
+	}{
+		{"json/pooled", JSONSerializer{}},
+		{"json/unpooled", unbufferedSerializer{JSONSerializer{}}},
+		{"msgpack/pooled", MsgpackSerializer{}},
+		{"msgpack/unpooled", unbufferedSerializer{MsgpackSerializer{}}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			client := newTestClient(b)
+			publisher := NewPublisher(client, sharding, WithSerializer(bench.serializer), WithMaxLen(publishBatchSize))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := publisher.Publish(ctx, events...); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.N*publishBatchSize)/b.Elapsed().Seconds(), "events/s")
+		})
+	}
+}
+
+func BenchmarkConsumer_Decode(b *testing.B) {
+	registry := newTestRegistry(b)
+	event := newMemberJoined("guild-1", "scout")
+
+	for _, serializer := range []Serializer{JSONSerializer{}, MsgpackSerializer{}} {
+		payload, err := serializer.Marshal(event)
+		if err != nil {
+			b.Fatal(err)
+		}
+		msg := redis.XMessage{ID: "1-0", Values: map[string]interface{}{
+			FieldEventType:   "MemberJoined",
+			FieldPayload:     string(payload),
+			FieldContentType: serializer.ContentType(),
+		}}
+		consumer := &Consumer{registry: registry, serializers: serializerSet{serializer.ContentType(): serializer}}
+
+		b.Run(serializer.ContentType()+"/pooled", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := consumer.decode(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(serializer.ContentType()+"/unpooled", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := serializer.Unmarshal("MemberJoined", []byte(msg.Values[FieldPayload].(string)), registry); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package redisstream
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Stream entry fields
+const (
+	FieldEventID       = "event_id"
+	FieldEventType     = "event_type"
+	FieldAggregateID   = "aggregate_id"
+	FieldAggregateType = "aggregate_type"
+	FieldPayload       = "payload"
+	FieldContentType   = "content_type" // Serializer of the payload; absent means JSON
+)
+
+// entryPool reuses the XADD envelopes built per event. XAdd copies the values into the
+// queued command, so an envelope can go back as soon as it has been added to the pipeline.
+var entryPool = sync.Pool{
+	New: func() interface{} {
+		return &redis.XAddArgs{Values: make(map[string]interface{}, 6)}
+	},
+}
+
+func acquireEntry() *redis.XAddArgs {
+	return entryPool.Get().(*redis.XAddArgs)
+}
+
+func releaseEntry(args *redis.XAddArgs) {
+	values := args.Values.(map[string]interface{})
+	clear(values)
+	*args = redis.XAddArgs{Values: values}
+	entryPool.Put(args)
+}
+
+// Publisher appends events to their aggregate's shard stream
+type Publisher struct {
+	*cqrs.BaseEventHandler
+
+	client      redis.UniversalClient
+	sharding    Sharding
+	maxLen      int64
+	serializer  Serializer
+	serializers map[string]Serializer // Overrides by event type
+	payloads    payloadMetrics
+}
+
+// PublisherOption configures a Publisher
+type PublisherOption func(*Publisher)
+
+// WithMaxLen caps every shard stream at approximately maxLen entries (XADD MAXLEN ~)
+func WithMaxLen(maxLen int64) PublisherOption {
+	return func(p *Publisher) {
+		p.maxLen = maxLen
+	}
+}
+
+// WithSerializer sets the serializer for event types without an override (default JSON)
+func WithSerializer(serializer Serializer) PublisherOption {
+	return func(p *Publisher) {
+		p.serializer = serializer
+	}
+}
+
+// WithEventSerializer serializes eventType events with serializer, e.g. protobuf for a
+// high-volume event while the rest stay JSON. Consumers need the serializer too.
+func WithEventSerializer(eventType string, serializer Serializer) PublisherOption {
+	return func(p *Publisher) {
+		p.serializers[eventType] = serializer
+	}
+}
+
+// NewPublisher creates a sharded stream publisher.
+// It is also an event handler, so it can forward an in-process bus via SubscribeAll.
+func NewPublisher(client redis.UniversalClient, sharding Sharding, options ...PublisherOption) *Publisher {
+	publisher := &Publisher{
+		BaseEventHandler: cqrs.NewBaseEventHandler("RedisStreamPublisher", cqrs.NotificationHandler, nil),
+		client:           client,
+		sharding:         sharding,
+		serializer:       JSONSerializer{},
+		serializers:      make(map[string]Serializer),
+	}
+	for _, option := range options {
+		option(publisher)
+	}
+	return publisher
+}
+
+// Publish appends the events in a single pipeline.
+// Stream entry envelopes come from a pool and go back once queued; payloads of
+// BufferedSerializers are encoded into pooled buffers, which go back once the pipeline
+// has been written.
+func (p *Publisher) Publish(ctx context.Context, events ...cqrs.EventMessage) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	buffers := make([]*bytes.Buffer, 0, len(events))
+	defer func() {
+		for _, buf := range buffers {
+			cqrsx.ReleaseBuffer(buf)
+		}
+	}()
+
+	pipe := p.client.Pipeline()
+	sizes := make([]int, len(events))
+	contentTypes := make([]string, len(events))
+	for i, event := range events {
+		if event.AggregateType() == "" {
+			return cqrs.NewCQRSError(cqrs.ErrCodeEventValidation.String(),
+				fmt.Sprintf("event %s has no aggregate type", event.EventID()), nil)
+		}
+
+		serializer := p.serializerFor(event.EventType())
+		var payload []byte
+		var err error
+		if buffered, ok := serializer.(BufferedSerializer); ok {
+			buf := cqrsx.AcquireBuffer()
+			buffers = append(buffers, buf)
+			err = buffered.MarshalTo(buf, event)
+			payload = buf.Bytes()
+		} else {
+			payload, err = serializer.Marshal(event)
+		}
+		if err != nil {
+			return cqrs.NewCQRSError(cqrs.ErrCodeSerializationError.String(),
+				fmt.Sprintf("failed to serialize event %s", event.EventID()), err)
+		}
+
+		args := acquireEntry()
+		args.Stream = p.sharding.StreamKeyFor(event.AggregateType(), event.AggregateID())
+		values := args.Values.(map[string]interface{})
+		values[FieldEventID] = event.EventID()
+		values[FieldEventType] = event.EventType()
+		values[FieldAggregateID] = event.AggregateID()
+		values[FieldAggregateType] = event.AggregateType()
+		values[FieldPayload] = payload
+		values[FieldContentType] = serializer.ContentType()
+		sizes[i], contentTypes[i] = len(payload), serializer.ContentType()
+		if p.maxLen > 0 {
+			args.MaxLen = p.maxLen
+			args.Approx = true
+		}
+		pipe.XAdd(ctx, args)
+		releaseEntry(args)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return cqrs.NewCQRSError(cqrs.ErrCodeEventBusError.String(), "failed to publish events to redis streams", err)
+	}
+	for i, event := range events {
+		p.payloads.record(event.EventType(), contentTypes[i], sizes[i])
+	}
+	return nil
+}
+
+// PayloadStats returns the sizes of the payloads published so far, by event type
+func (p *Publisher) PayloadStats() map[string]PayloadStats {
+	return p.payloads.snapshot()
+}
+
+func (p *Publisher) serializerFor(eventType string) Serializer {
+	if serializer, ok := p.serializers[eventType]; ok {
+		return serializer
+	}
+	return p.serializer
+}
+
+// CanHandle accepts every event type
+func (p *Publisher) CanHandle(eventType string) bool {
+	return true
+}
+
+// Handle publishes a single event
+func (p *Publisher) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	return p.Publish(ctx, event)
+}
@@ -0,0 +1,270 @@
+package redisstream
+
+import (
+	"bytes"
+	"cqrs"
+	"cqrs/cqrsx"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Content types written to FieldContentType so consumers pick the matching serializer
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeMsgpack  = "application/msgpack"
+)
+
+// ErrUnsupportedContentType is reported for stream entries no configured serializer can decode
+var ErrUnsupportedContentType = errors.New("unsupported stream entry content type")
+
+// Serializer encodes stream entry payloads.
+// Every serializer round-trips the event's JSON form, so the event registry resolves concrete
+// types the same way for every format.
+type Serializer interface {
+	ContentType() string
+	Marshal(event cqrs.EventMessage) ([]byte, error)
+	Unmarshal(eventType string, data []byte, registry cqrsx.EventRegistry) (cqrs.EventMessage, error)
+}
+
+// BufferedSerializer is implemented by serializers that can encode into a caller-provided
+// buffer, which lets the publisher reuse pooled buffers instead of allocating a payload per event
+type BufferedSerializer interface {
+	Serializer
+	MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error
+}
+
+// JSONSerializer stores the event JSON as is; it is the default, and entries without a
+// content type (written before content types existed) are decoded with it
+type JSONSerializer struct{}
+
+func (JSONSerializer) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSONSerializer) Marshal(event cqrs.EventMessage) ([]byte, error) {
+	return cqrsx.MarshalEventJSON(event)
+}
+
+func (JSONSerializer) MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error {
+	return cqrsx.MarshalEventJSONTo(buf, event)
+}
+
+func (JSONSerializer) Unmarshal(eventType string, data []byte, registry cqrsx.EventRegistry) (cqrs.EventMessage, error) {
+	return cqrsx.UnmarshalEventJSON(data, registry)
+}
+
+// MsgpackSerializer stores the event JSON re-encoded as MessagePack, which drops the JSON
+// punctuation and stores numbers in binary
+type MsgpackSerializer struct{}
+
+func (MsgpackSerializer) ContentType() string {
+	return ContentTypeMsgpack
+}
+
+func (s MsgpackSerializer) Marshal(event cqrs.EventMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.MarshalTo(&buf, event); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (MsgpackSerializer) MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error {
+	value, err := eventJSONValue(event)
+	if err != nil {
+		return err
+	}
+	return encodeMsgpack(buf, value)
+}
+
+func (MsgpackSerializer) Unmarshal(eventType string, data []byte, registry cqrsx.EventRegistry) (cqrs.EventMessage, error) {
+	value, err := decodeMsgpack(data)
+	if err != nil {
+		return nil, err
+	}
+	payload := cqrsx.AcquireBuffer()
+	defer cqrsx.ReleaseBuffer(payload)
+	if err := json.NewEncoder(payload).Encode(value); err != nil {
+		return nil, err
+	}
+	return cqrsx.UnmarshalEventJSON(payload.Bytes(), registry)
+}
+
+// ProtobufSerializer stores events as protobuf messages.
+// Event types registered with a prototype are converted to that message by its protojson
+// field names, so the message must declare every JSON field of the event, envelope fields
+// (eventId, eventType, aggregateId, ...) included; conversion fails rather than drop a field.
+// Other event types are stored as a google.protobuf.Struct.
+type ProtobufSerializer struct {
+	mu         sync.RWMutex
+	prototypes map[string]proto.Message
+}
+
+// NewProtobufSerializer creates a protobuf serializer without registered prototypes
+func NewProtobufSerializer() *ProtobufSerializer {
+	return &ProtobufSerializer{prototypes: make(map[string]proto.Message)}
+}
+
+// Register stores eventType events as prototype messages
+func (s *ProtobufSerializer) Register(eventType string, prototype proto.Message) error {
+	if eventType == "" || prototype == nil {
+		return cqrs.NewValidationError("event type and prototype are required", nil)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.prototypes[eventType]; exists {
+		return cqrs.NewValidationError(fmt.Sprintf("protobuf prototype for %s is already registered", eventType), nil)
+	}
+	s.prototypes[eventType] = prototype
+	return nil
+}
+
+func (s *ProtobufSerializer) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (s *ProtobufSerializer) Marshal(event cqrs.EventMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.MarshalTo(&buf, event); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *ProtobufSerializer) MarshalTo(buf *bytes.Buffer, event cqrs.EventMessage) error {
+	payload := cqrsx.AcquireBuffer()
+	defer cqrsx.ReleaseBuffer(payload)
+	if err := cqrsx.MarshalEventJSONTo(payload, event); err != nil {
+		return err
+	}
+	message := s.newMessage(event.EventType())
+	if err := protojson.Unmarshal(payload.Bytes(), message); err != nil {
+		return fmt.Errorf("failed to convert %s to %s: %w", event.EventType(), message.ProtoReflect().Descriptor().FullName(), err)
+	}
+	encoded, err := proto.MarshalOptions{}.MarshalAppend(buf.AvailableBuffer(), message)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
+
+func (s *ProtobufSerializer) Unmarshal(eventType string, data []byte, registry cqrsx.EventRegistry) (cqrs.EventMessage, error) {
+	message := s.newMessage(eventType)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("failed to decode %s protobuf payload: %w", eventType, err)
+	}
+	payload := cqrsx.AcquireBuffer()
+	defer cqrsx.ReleaseBuffer(payload)
+	encoded, err := protojson.MarshalOptions{}.MarshalAppend(payload.AvailableBuffer(), message)
+	if err != nil {
+		return nil, err
+	}
+	payload.Write(encoded)
+	return cqrsx.UnmarshalEventJSON(payload.Bytes(), registry)
+}
+
+func (s *ProtobufSerializer) newMessage(eventType string) proto.Message {
+	s.mu.RLock()
+	prototype, ok := s.prototypes[eventType]
+	s.mu.RUnlock()
+	if !ok {
+		return &structpb.Struct{}
+	}
+	return prototype.ProtoReflect().New().Interface()
+}
+
+// eventJSONValue returns the event's JSON form as maps and slices, keeping numbers exact
+func eventJSONValue(event cqrs.EventMessage) (interface{}, error) {
+	payload := cqrsx.AcquireBuffer()
+	defer cqrsx.ReleaseBuffer(payload)
+	if err := cqrsx.MarshalEventJSONTo(payload, event); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(payload)
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// PayloadStats summarizes the serialized payload sizes of one event type
+type PayloadStats struct {
+	ContentType string `json:"contentType"`
+	Count       int64  `json:"count"`
+	TotalBytes  int64  `json:"totalBytes"`
+	MaxBytes    int64  `json:"maxBytes"`
+}
+
+// AverageBytes returns the mean payload size
+func (s PayloadStats) AverageBytes() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.TotalBytes) / float64(s.Count)
+}
+
+// payloadMetrics tracks PayloadStats by event type
+type payloadMetrics struct {
+	mu    sync.Mutex
+	stats map[string]PayloadStats
+}
+
+func (m *payloadMetrics) record(eventType, contentType string, size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stats == nil {
+		m.stats = make(map[string]PayloadStats)
+	}
+	stats := m.stats[eventType]
+	stats.ContentType = contentType
+	stats.Count++
+	stats.TotalBytes += int64(size)
+	if int64(size) > stats.MaxBytes {
+		stats.MaxBytes = int64(size)
+	}
+	m.stats[eventType] = stats
+}
+
+func (m *payloadMetrics) snapshot() map[string]PayloadStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make(map[string]PayloadStats, len(m.stats))
+	for eventType, entry := range m.stats {
+		stats[eventType] = entry
+	}
+	return stats
+}
+
+// serializerSet resolves serializers by content type when decoding
+type serializerSet map[string]Serializer
+
+func newSerializerSet(serializers []Serializer) (serializerSet, error) {
+	set := serializerSet{ContentTypeJSON: JSONSerializer{}}
+	for _, serializer := range serializers {
+		if serializer == nil || serializer.ContentType() == "" {
+			return nil, cqrs.NewValidationError("serializers must not be nil and need a content type", nil)
+		}
+		set[serializer.ContentType()] = serializer
+	}
+	return set, nil
+}
+
+func (s serializerSet) contentTypes() []string {
+	types := make([]string, 0, len(s))
+	for contentType := range s {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
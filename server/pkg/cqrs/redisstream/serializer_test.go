@@ -0,0 +1,144 @@
+package redisstream
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSerializers_RoundTripEvents(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry(t)
+	event := newMemberJoined("guild-1", "commander")
+	event.Version_ = 42
+	event.Metadata_["shard"] = json.Number("-7")
+	jsonPayload, err := JSONSerializer{}.Marshal(event)
+	require.NoError(t, err)
+
+	for _, serializer := range []Serializer{JSONSerializer{}, MsgpackSerializer{}, NewProtobufSerializer()} {
+		t.Run(serializer.ContentType(), func(t *testing.T) {
+			// Act
+			payload, err := serializer.Marshal(event)
+			require.NoError(t, err)
+			decoded, err := serializer.Unmarshal("MemberJoined", payload, registry)
+
+			// Assert
+			require.NoError(t, err)
+			joined, ok := decoded.(*memberJoined)
+			require.True(t, ok)
+			assert.Equal(t, "commander", joined.Member)
+			assert.Equal(t, event.EventID(), joined.EventID())
+			assert.Equal(t, "guild-1", joined.AggregateID())
+			assert.Equal(t, 42, joined.Version())
+			assert.True(t, event.Timestamp().Equal(joined.Timestamp()))
+			assert.EqualValues(t, -7, joined.Metadata()["shard"])
+			if serializer.ContentType() == ContentTypeMsgpack {
+				assert.Less(t, len(payload), len(jsonPayload))
+			}
+		})
+	}
+}
+
+func TestProtobufSerializer_RejectsEventsThePrototypeCannotHold(t *testing.T) {
+	// Arrange
+	serializer := NewProtobufSerializer()
+	require.NoError(t, serializer.Register("MemberJoined", &wrapperspb.StringValue{}))
+
+	// Act
+	_, marshalErr := serializer.Marshal(newMemberJoined("guild-1", "commander"))
+	duplicateErr := serializer.Register("MemberJoined", &wrapperspb.StringValue{})
+
+	// Assert
+	require.Error(t, marshalErr)
+	assert.Contains(t, marshalErr.Error(), "google.protobuf.StringValue")
+	assert.True(t, cqrs.IsValidationError(duplicateErr))
+}
+
+func TestMsgpack_RoundTripsJSONValues(t *testing.T) {
+	// Arrange
+	long := strings.Repeat("x", 300)
+	items := make([]interface{}, 20)
+	for i := range items {
+		items[i] = json.Number("1")
+	}
+	value := map[string]interface{}{
+		"small": json.Number("5"), "negative": json.Number("-100000"), "large": json.Number("9007199254740993"),
+		"float": json.Number("1.25"), "long": long, "items": items, "nil": nil, "flag": true,
+		"nested": map[string]interface{}{"empty": []interface{}{}},
+	}
+
+	// Act
+	var buf bytes.Buffer
+	require.NoError(t, encodeMsgpack(&buf, value))
+	decoded, err := decodeMsgpack(buf.Bytes())
+	_, truncatedErr := decodeMsgpack(buf.Bytes()[:buf.Len()-1])
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := json.Marshal(value)
+	require.NoError(t, err)
+	actual, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Contains(t, string(actual), "9007199254740993", "정수는 float64를 거치지 않아야 합니다")
+	assert.Error(t, truncatedErr)
+}
+
+func TestPublisher_SerializesByEventTypeAndConsumerNegotiatesContentType(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := newTestClient(t)
+	sharding := NewSharding(map[string]int{"Guild": 1})
+	key := sharding.StreamKey("Guild", 0)
+	publisher := NewPublisher(client, sharding, WithSerializer(NewProtobufSerializer()), WithEventSerializer("MemberJoined", MsgpackSerializer{}))
+	config := ConsumerConfig{Group: "projections", AggregateTypes: []string{"Guild"}, Sharding: sharding, Block: 10 * time.Millisecond}
+
+	var reported []error
+	outdatedHandler := newRecordingHandler()
+	config.Name = "node-outdated"
+	config.OnHandleError = func(event cqrs.EventMessage, err error) { reported = append(reported, err) }
+	outdated, err := NewConsumer(client, config, outdatedHandler, newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, outdated.ensureGroups(ctx))
+	require.NoError(t, outdated.Rebalance(ctx))
+
+	// Act
+	require.NoError(t, publisher.Publish(ctx, newMemberJoined("guild-1", "scout"), newMemberJoined("guild-2", "medic")))
+	require.NoError(t, outdated.Poll(ctx))
+	outdated.leave()
+
+	upgradedHandler := newRecordingHandler()
+	config.Name, config.OnHandleError, config.LeaseTTL = "node-upgraded", nil, time.Millisecond
+	config.Serializers = []Serializer{MsgpackSerializer{}}
+	upgraded, err := NewConsumer(client, config, upgradedHandler, newTestRegistry(t))
+	require.NoError(t, err)
+	require.NoError(t, upgraded.Rebalance(ctx))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, upgraded.Poll(ctx))
+
+	// Assert
+	entries, err := client.XRange(ctx, key, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, ContentTypeMsgpack, entries[0].Values[FieldContentType])
+
+	assert.Zero(t, outdatedHandler.count())
+	require.Len(t, reported, 2)
+	assert.True(t, errors.Is(reported[0], ErrUnsupportedContentType))
+	assert.Equal(t, 2, upgradedHandler.count(), "지원하지 않는 형식의 항목은 확인 응답하지 않고 남겨야 합니다")
+
+	stats := publisher.PayloadStats()["MemberJoined"]
+	assert.Equal(t, ContentTypeMsgpack, stats.ContentType)
+	assert.EqualValues(t, 2, stats.Count)
+	assert.Greater(t, stats.MaxBytes, int64(0))
+	assert.InDelta(t, float64(stats.TotalBytes)/2, stats.AverageBytes(), 0.001)
+}
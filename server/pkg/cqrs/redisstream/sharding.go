@@ -0,0 +1,77 @@
+// Package redisstream publishes and consumes domain events through Redis Streams.
+//
+// A single stream per deployment caps throughput at what one Redis node can
+// append, so events are spread over N streams per aggregate type
+// ("events:Guild:0" .. "events:Guild:N-1"), picked by hashing the aggregate ID.
+// Events of one aggregate always land in the same shard, which keeps their
+// order. Shard keys carry no hash tag, so a Redis Cluster distributes them over
+// its nodes.
+//
+// Consumers of a group divide the shards among themselves automatically (see
+// Consumer); adding or removing a consumer rebalances within a lease period.
+package redisstream
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// DefaultShardCount is used for aggregate types without an explicit shard count
+const DefaultShardCount = 8
+
+// Sharding maps aggregates to stream keys.
+// Changing the shard count of a type moves aggregates between shards; drain the
+// streams (or accept reordering across the switch) before resharding.
+type Sharding struct {
+	KeyPrefix     string         // Default "events"
+	DefaultShards int            // Default DefaultShardCount
+	Shards        map[string]int // Shard count per aggregate type
+}
+
+// NewSharding creates a sharding configuration with defaults
+func NewSharding(shards map[string]int) Sharding {
+	return Sharding{KeyPrefix: "events", DefaultShards: DefaultShardCount, Shards: shards}
+}
+
+// ShardCount returns the number of shards of an aggregate type
+func (s Sharding) ShardCount(aggregateType string) int {
+	if n := s.Shards[aggregateType]; n > 0 {
+		return n
+	}
+	if s.DefaultShards > 0 {
+		return s.DefaultShards
+	}
+	return DefaultShardCount
+}
+
+// ShardFor returns the shard of an aggregate
+func (s Sharding) ShardFor(aggregateType, aggregateID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(aggregateID))
+	return int(h.Sum32() % uint32(s.ShardCount(aggregateType)))
+}
+
+// StreamKey returns the stream key of a shard
+func (s Sharding) StreamKey(aggregateType string, shard int) string {
+	prefix := s.KeyPrefix
+	if prefix == "" {
+		prefix = "events"
+	}
+	return fmt.Sprintf("%s:%s:%d", prefix, aggregateType, shard)
+}
+
+// StreamKeyFor returns the stream key an aggregate's events are appended to
+func (s Sharding) StreamKeyFor(aggregateType, aggregateID string) string {
+	return s.StreamKey(aggregateType, s.ShardFor(aggregateType, aggregateID))
+}
+
+// StreamKeys returns every shard key of the aggregate types
+func (s Sharding) StreamKeys(aggregateTypes ...string) []string {
+	var keys []string
+	for _, aggregateType := range aggregateTypes {
+		for shard := 0; shard < s.ShardCount(aggregateType); shard++ {
+			keys = append(keys, s.StreamKey(aggregateType, shard))
+		}
+	}
+	return keys
+}
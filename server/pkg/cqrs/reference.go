@@ -0,0 +1,151 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Reference points at another aggregate by type and ID, e.g. the user an InviteMember
+// command adds to a guild
+type Reference struct {
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+}
+
+// NewReference creates a reference to an aggregate
+func NewReference(aggregateType, aggregateID string) Reference {
+	return Reference{AggregateType: aggregateType, AggregateID: aggregateID}
+}
+
+// String returns "type/id"
+func (r Reference) String() string {
+	return r.AggregateType + "/" + r.AggregateID
+}
+
+// Validate checks that both type and ID are set
+func (r Reference) Validate() error {
+	if r.AggregateType == "" {
+		return NewValidationError("reference aggregate type cannot be empty", nil)
+	}
+	if r.AggregateID == "" {
+		return NewValidationError(fmt.Sprintf("reference to %s has no aggregate ID", r.AggregateType), nil)
+	}
+	return nil
+}
+
+// ReferencingCommand is implemented by commands that declare the aggregates they refer to
+type ReferencingCommand interface {
+	References() []Reference
+}
+
+// ReferenceExtractor returns the references of a command that does not declare them itself
+type ReferenceExtractor func(command Command) []Reference
+
+// AggregateExistenceChecker reports whether an aggregate exists; every Repository satisfies it
+type AggregateExistenceChecker interface {
+	Exists(ctx context.Context, id string) bool
+}
+
+// ExistenceCheckFunc adapts a function to AggregateExistenceChecker
+type ExistenceCheckFunc func(ctx context.Context, id string) bool
+
+func (f ExistenceCheckFunc) Exists(ctx context.Context, id string) bool {
+	return f(ctx, id)
+}
+
+// ReferenceValidator verifies that referenced aggregates exist, using one existence
+// checker (usually the repository) per aggregate type
+type ReferenceValidator struct {
+	mutex    sync.RWMutex
+	checkers map[string]AggregateExistenceChecker
+}
+
+// NewReferenceValidator creates a validator without checkers
+func NewReferenceValidator() *ReferenceValidator {
+	return &ReferenceValidator{checkers: make(map[string]AggregateExistenceChecker)}
+}
+
+// Register sets the existence checker for an aggregate type
+func (v *ReferenceValidator) Register(aggregateType string, checker AggregateExistenceChecker) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.checkers[aggregateType] = checker
+}
+
+// Validate returns a validation error for the first reference that is malformed,
+// has no registered checker or points at a missing aggregate
+func (v *ReferenceValidator) Validate(ctx context.Context, references ...Reference) error {
+	for _, reference := range references {
+		if err := reference.Validate(); err != nil {
+			return err
+		}
+
+		v.mutex.RLock()
+		checker, ok := v.checkers[reference.AggregateType]
+		v.mutex.RUnlock()
+		if !ok {
+			return NewValidationError(fmt.Sprintf("no reference checker registered for aggregate type %s", reference.AggregateType), nil)
+		}
+
+		if !checker.Exists(ctx, reference.AggregateID) {
+			return NewValidationError(fmt.Sprintf("referenced aggregate %s does not exist", reference), nil).
+				WithContext("reference", reference)
+		}
+	}
+	return nil
+}
+
+// ReferenceValidatingDispatcher rejects commands whose referenced aggregates do not exist
+// before they reach their handler. References come from ReferencingCommand and from the
+// extractors registered per command type, so checks can be added without touching commands.
+type ReferenceValidatingDispatcher struct {
+	CommandDispatcher
+	validator *ReferenceValidator
+
+	mutex      sync.RWMutex
+	extractors map[string][]ReferenceExtractor
+}
+
+// NewReferenceValidatingDispatcher wraps dispatcher with reference checks
+func NewReferenceValidatingDispatcher(dispatcher CommandDispatcher, validator *ReferenceValidator) *ReferenceValidatingDispatcher {
+	return &ReferenceValidatingDispatcher{
+		CommandDispatcher: dispatcher,
+		validator:         validator,
+		extractors:        make(map[string][]ReferenceExtractor),
+	}
+}
+
+// Require adds an extractor whose references must exist before commandType is handled
+func (d *ReferenceValidatingDispatcher) Require(commandType string, extractor ReferenceExtractor) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.extractors[commandType] = append(d.extractors[commandType], extractor)
+}
+
+// References returns every reference checked for command
+func (d *ReferenceValidatingDispatcher) References(command Command) []Reference {
+	var references []Reference
+	if referencing, ok := command.(ReferencingCommand); ok {
+		references = append(references, referencing.References()...)
+	}
+
+	d.mutex.RLock()
+	extractors := d.extractors[command.CommandType()]
+	d.mutex.RUnlock()
+	for _, extractor := range extractors {
+		references = append(references, extractor(command)...)
+	}
+	return references
+}
+
+func (d *ReferenceValidatingDispatcher) Dispatch(ctx context.Context, command Command) (*CommandResult, error) {
+	if command == nil {
+		return d.CommandDispatcher.Dispatch(ctx, command)
+	}
+
+	if err := d.validator.Validate(ctx, d.References(command)...); err != nil {
+		return NewFailedCommandResult(err), nil
+	}
+	return d.CommandDispatcher.Dispatch(ctx, command)
+}
@@ -0,0 +1,83 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referencingTestCommand 참조를 직접 선언하는 테스트 커맨드
+type referencingTestCommand struct {
+	*TestCommand
+	references []Reference
+}
+
+func (c *referencingTestCommand) References() []Reference {
+	return c.references
+}
+
+func newReferenceTestDispatcher(t *testing.T, users ...string) (*ReferenceValidatingDispatcher, *int) {
+	t.Helper()
+	handled := 0
+	dispatcher := NewInMemoryCommandDispatcher()
+	handler := NewTestCommandHandler()
+	handler.HandleFunc = func(ctx context.Context, command Command) (*CommandResult, error) {
+		handled++
+		return NewCommandResult(command.ID(), 1), nil
+	}
+	require.NoError(t, dispatcher.RegisterHandler("TestCommand", handler))
+
+	existing := make(map[string]bool)
+	for _, user := range users {
+		existing[user] = true
+	}
+	validator := NewReferenceValidator()
+	validator.Register("User", ExistenceCheckFunc(func(ctx context.Context, id string) bool {
+		return existing[id]
+	}))
+	return NewReferenceValidatingDispatcher(dispatcher, validator), &handled
+}
+
+func TestReferenceValidatingDispatcher_RejectsMissingReference(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher, handled := newReferenceTestDispatcher(t, "alice")
+	// TestData에 초대할 사용자 ID를 담음
+	dispatcher.Require("TestCommand", func(command Command) []Reference {
+		return []Reference{NewReference("User", command.(*TestCommand).TestData)}
+	})
+
+	// Act
+	accepted, acceptedErr := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "alice"))
+	rejected, rejectedErr := dispatcher.Dispatch(ctx, NewTestCommand("guild-1", "ghost"))
+
+	// Assert
+	require.NoError(t, acceptedErr)
+	require.NoError(t, rejectedErr)
+	assert.True(t, accepted.Success)
+	assert.False(t, rejected.Success)
+	assert.True(t, IsValidationError(rejected.Error))
+	assert.Contains(t, rejected.Error.Error(), "User/ghost")
+	assert.Equal(t, 1, *handled)
+}
+
+func TestReferenceValidatingDispatcher_ChecksDeclaredReferences(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher, handled := newReferenceTestDispatcher(t, "alice")
+	command := &referencingTestCommand{
+		TestCommand: NewTestCommand("guild-1", "invite"),
+		references:  []Reference{NewReference("User", "alice"), NewReference("Guild", "guild-2")},
+	}
+
+	// Act
+	result, err := dispatcher.Dispatch(ctx, command)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error.Error(), "no reference checker registered for aggregate type Guild")
+	assert.Equal(t, 0, *handled)
+}
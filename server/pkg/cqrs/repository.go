@@ -126,12 +126,18 @@ type QueryCriteria struct {
 // Metrics categories:
 //   - Volume metrics: EventCount, SnapshotCount, StateSize
 //   - Access metrics: LastAccessed
-//   - Performance metrics: (can be extended with timing data)
+//   - Performance metrics: LoadCount, SnapshotHits, LastLoadDuration, LastReplayDuration, LastReplayedEvents
 type StorageMetrics struct {
 	EventCount    int64     `json:"event_count"`    // Total number of events stored for the aggregate
 	SnapshotCount int64     `json:"snapshot_count"` // Number of snapshots created for the aggregate
 	StateSize     int64     `json:"state_size"`     // Size of stored state in bytes
 	LastAccessed  time.Time `json:"last_accessed"`  // Timestamp of last access (read or write)
+
+	LoadCount          int64         `json:"load_count"`           // Number of times the aggregate was loaded
+	SnapshotHits       int64         `json:"snapshot_hits"`        // Loads that started from a snapshot
+	LastLoadDuration   time.Duration `json:"last_load_duration"`   // Total time of the last load
+	LastReplayDuration time.Duration `json:"last_replay_duration"` // Time spent replaying events in the last load
+	LastReplayedEvents int           `json:"last_replayed_events"` // Events replayed by the last load
 }
 
 // EventSourcedRepository extends Repository with event sourcing capabilities.
@@ -0,0 +1,156 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// AggregateMutation executes a command's logic against a freshly loaded aggregate.
+// It must be safe to run more than once, since it is re-applied after a version conflict.
+type AggregateMutation func(ctx context.Context, aggregate AggregateRoot) error
+
+// ConflictResolution tells the RetryingRepository how to continue after a version conflict
+type ConflictResolution int
+
+const (
+	// ConflictRetry re-runs the mutation against the reloaded aggregate
+	ConflictRetry ConflictResolution = iota
+	// ConflictMerged saves the reloaded aggregate as changed by the resolver
+	ConflictMerged
+	// ConflictAbort gives up and returns the conflict error
+	ConflictAbort
+)
+
+// ConflictResolver lets the domain decide how to handle a version conflict.
+// current is the reloaded aggregate and rejected holds the events that failed to save;
+// a resolver returning ConflictMerged must apply whatever it keeps to current itself.
+type ConflictResolver func(ctx context.Context, current AggregateRoot, rejected []EventMessage) (ConflictResolution, error)
+
+// DefaultConflictRetryPolicy returns 4 attempts with exponential backoff starting at 10ms
+func DefaultConflictRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		Delay:       10 * time.Millisecond,
+		BackoffType: ExponentialBackoff,
+	}
+}
+
+// maxConflictDelay caps a single backoff delay
+const maxConflictDelay = time.Second
+
+// backoff returns the jittered delay before the given retry (1-based)
+func backoff(policy RetryPolicy, retry int) time.Duration {
+	delay := policy.Delay
+	switch policy.BackoffType {
+	case ExponentialBackoff:
+		delay = policy.Delay << (retry - 1)
+	case LinearBackoff:
+		delay = policy.Delay * time.Duration(retry)
+	}
+	if delay <= 0 || delay > maxConflictDelay {
+		delay = maxConflictDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// RetryingRepository decorates a Repository so command handlers survive optimistic
+// concurrency conflicts: Update reloads the aggregate and re-applies the mutation when
+// Save reports ErrCodeConcurrencyConflict, up to the policy's MaxAttempts in total.
+type RetryingRepository struct {
+	Repository
+
+	policy   RetryPolicy
+	resolver ConflictResolver
+}
+
+// RetryingRepositoryOption configures a RetryingRepository
+type RetryingRepositoryOption func(*RetryingRepository)
+
+// WithConflictRetryPolicy overrides the default conflict retry policy
+func WithConflictRetryPolicy(policy RetryPolicy) RetryingRepositoryOption {
+	return func(r *RetryingRepository) {
+		r.policy = policy
+	}
+}
+
+// WithConflictResolver installs a domain-provided conflict resolver
+func WithConflictResolver(resolver ConflictResolver) RetryingRepositoryOption {
+	return func(r *RetryingRepository) {
+		r.resolver = resolver
+	}
+}
+
+// NewRetryingRepository wraps repository with conflict retries
+func NewRetryingRepository(repository Repository, options ...RetryingRepositoryOption) *RetryingRepository {
+	r := &RetryingRepository{
+		Repository: repository,
+		policy:     DefaultConflictRetryPolicy(),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if r.policy.MaxAttempts <= 0 {
+		r.policy.MaxAttempts = 1
+	}
+	return r
+}
+
+// Update loads the aggregate, applies mutate and saves it, retrying on version conflicts.
+// Errors from mutate and non-conflict save errors are returned immediately.
+func (r *RetryingRepository) Update(ctx context.Context, id string, mutate AggregateMutation) (AggregateRoot, error) {
+	aggregate, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutate(ctx, aggregate); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		saveErr := r.Save(ctx, aggregate, aggregate.OriginalVersion())
+		if saveErr == nil {
+			return aggregate, nil
+		}
+		if !IsConcurrencyError(saveErr) || attempt >= r.policy.MaxAttempts {
+			return nil, r.wrapConflict(saveErr, attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff(r.policy, attempt)):
+		}
+
+		rejected := aggregate.Changes()
+		if aggregate, err = r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+
+		resolution := ConflictRetry
+		if r.resolver != nil {
+			if resolution, err = r.resolver(ctx, aggregate, rejected); err != nil {
+				return nil, err
+			}
+		}
+
+		switch resolution {
+		case ConflictAbort:
+			return nil, saveErr
+		case ConflictMerged:
+			continue
+		default:
+			if err := mutate(ctx, aggregate); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (r *RetryingRepository) wrapConflict(err error, attempts int) error {
+	if !IsConcurrencyError(err) || attempts == 1 {
+		return err
+	}
+	return NewConcurrencyError(fmt.Sprintf("version conflict persisted after %d attempts", attempts), err)
+}
@@ -0,0 +1,120 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contendedCounterRepository 다른 프로세스가 먼저 저장한 것처럼 충돌을 흉내내는 저장소
+type contendedCounterRepository struct {
+	count     int
+	version   int
+	conflicts int
+	saves     int
+}
+
+func (r *contendedCounterRepository) Save(ctx context.Context, aggregate AggregateRoot, expectedVersion int) error {
+	r.saves++
+	if r.conflicts > 0 {
+		r.conflicts--
+		r.count++
+		r.version++
+	}
+	if expectedVersion != r.version {
+		return NewConcurrencyError("version mismatch", nil)
+	}
+	r.count = aggregate.(*counterAggregate).Count
+	r.version += len(aggregate.Changes())
+	return nil
+}
+
+func (r *contendedCounterRepository) GetByID(ctx context.Context, id string) (AggregateRoot, error) {
+	return &counterAggregate{
+		BaseAggregate: NewBaseAggregate(id, "Counter", WithOriginalVersion(r.version)),
+		Count:         r.count,
+	}, nil
+}
+
+func (r *contendedCounterRepository) GetVersion(ctx context.Context, id string) (int, error) {
+	return r.version, nil
+}
+
+func (r *contendedCounterRepository) Exists(ctx context.Context, id string) bool {
+	return true
+}
+
+func increment(ctx context.Context, aggregate AggregateRoot) error {
+	counter := aggregate.(*counterAggregate)
+	counter.Count++
+	return counter.ApplyEvent(NewBaseEventMessage("Incremented"))
+}
+
+var fastRetries = WithConflictRetryPolicy(RetryPolicy{MaxAttempts: 4, Delay: time.Microsecond, BackoffType: ExponentialBackoff})
+
+func TestRetryingRepository_Update_ReappliesMutationAfterConflict(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{conflicts: 2}
+	repository := NewRetryingRepository(inner, fastRetries)
+
+	// Act
+	aggregate, err := repository.Update(context.Background(), "guild-1", increment)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, aggregate.(*counterAggregate).Count)
+	assert.Equal(t, 3, inner.count)
+	assert.Equal(t, 3, inner.saves)
+}
+
+func TestRetryingRepository_Update_GivesUpAfterMaxRetries(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{conflicts: 10}
+	repository := NewRetryingRepository(inner, fastRetries)
+
+	// Act
+	_, err := repository.Update(context.Background(), "guild-1", increment)
+
+	// Assert
+	assert.True(t, IsConcurrencyError(err))
+	assert.Equal(t, 4, inner.saves)
+}
+
+func TestRetryingRepository_Update_UsesConflictResolver(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{conflicts: 1}
+	var rejectedCount int
+	resolver := func(ctx context.Context, current AggregateRoot, rejected []EventMessage) (ConflictResolution, error) {
+		rejectedCount = len(rejected)
+		return ConflictAbort, nil
+	}
+	repository := NewRetryingRepository(inner, fastRetries, WithConflictResolver(resolver))
+
+	// Act
+	_, err := repository.Update(context.Background(), "guild-1", increment)
+
+	// Assert
+	assert.True(t, IsConcurrencyError(err))
+	assert.Equal(t, 1, rejectedCount)
+	assert.Equal(t, 1, inner.saves)
+}
+
+func TestRetryingRepository_Update_ReturnsMutationError(t *testing.T) {
+	// Arrange
+	inner := &contendedCounterRepository{}
+	repository := NewRetryingRepository(inner, fastRetries)
+	guildFull := errors.New("guild is full")
+
+	// Act
+	_, err := repository.Update(context.Background(), "guild-1", func(ctx context.Context, aggregate AggregateRoot) error {
+		return guildFull
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, guildFull)
+	assert.Zero(t, inner.saves)
+}
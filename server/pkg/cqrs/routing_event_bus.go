@@ -0,0 +1,337 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RoutingKeyFunc extracts the route (shard, region, tenant) an event belongs to.
+// An empty result selects the default route.
+type RoutingKeyFunc func(event EventMessage) string
+
+// MetadataRoutingKey routes by a string metadata value such as "region"
+func MetadataRoutingKey(key string) RoutingKeyFunc {
+	return func(event EventMessage) string {
+		if value, ok := event.Metadata()[key].(string); ok {
+			return value
+		}
+		return ""
+	}
+}
+
+// routedSubscription maps a RoutingEventBus subscription to the route subscriptions behind it
+type routedSubscription struct {
+	route     string // Empty for subscriptions on every route
+	eventType string // Empty for SubscribeAll
+	handler   EventHandler
+	inner     map[string]SubscriptionID
+}
+
+// RoutingEventBus sends each event to the physical bus of its route, so regional game
+// servers can share one codebase while keeping their streams apart. Each route is a
+// complete EventBus (in-memory, Redis Streams, ...).
+//
+// Subscribe and SubscribeAll attach a handler to every route, including routes added
+// later; SubscribeRoute and SubscribeAllRoute attach per-route consumers.
+type RoutingEventBus struct {
+	routingKey   RoutingKeyFunc
+	defaultRoute string
+
+	mutex         sync.RWMutex
+	routes        map[string]EventBus
+	subscriptions map[SubscriptionID]*routedSubscription
+	nextSubID     int64
+	running       bool
+}
+
+// NewRoutingEventBus creates a router; events without a routing key go to defaultRoute
+func NewRoutingEventBus(routingKey RoutingKeyFunc, defaultRoute string) *RoutingEventBus {
+	return &RoutingEventBus{
+		routingKey:    routingKey,
+		defaultRoute:  defaultRoute,
+		routes:        make(map[string]EventBus),
+		subscriptions: make(map[SubscriptionID]*routedSubscription),
+	}
+}
+
+// AddRoute registers the bus backing a route and attaches existing all-route subscriptions to it
+func (r *RoutingEventBus) AddRoute(ctx context.Context, route string, bus EventBus) error {
+	if route == "" || bus == nil {
+		return NewCQRSError(ErrCodeEventValidation.String(), "route name and bus are required", nil)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.routes[route]; exists {
+		return NewCQRSError(ErrCodeEventValidation.String(), fmt.Sprintf("route already registered: %s", route), nil)
+	}
+	for _, subscription := range r.subscriptions {
+		if subscription.route != "" {
+			continue
+		}
+		id, err := subscribeOn(bus, subscription.eventType, subscription.handler)
+		if err != nil {
+			return err
+		}
+		subscription.inner[route] = id
+	}
+	if r.running && !bus.IsRunning() {
+		if err := bus.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	r.routes[route] = bus
+	return nil
+}
+
+// Routes returns the registered route names
+func (r *RoutingEventBus) Routes() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	routes := make([]string, 0, len(r.routes))
+	for route := range r.routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// RouteOf returns the route an event would be published to
+func (r *RoutingEventBus) RouteOf(event EventMessage) string {
+	if route := r.routingKey(event); route != "" {
+		return route
+	}
+	return r.defaultRoute
+}
+
+func (r *RoutingEventBus) busFor(event EventMessage) (EventBus, error) {
+	route := r.RouteOf(event)
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	bus, exists := r.routes[route]
+	if !exists {
+		return nil, NewCQRSError(ErrCodeEventBusError.String(),
+			fmt.Sprintf("no event bus registered for route %q (event %s)", route, event.EventID()), ErrEventBusNotFound)
+	}
+	return bus, nil
+}
+
+// EventBus interface implementation
+
+func (r *RoutingEventBus) Publish(ctx context.Context, event EventMessage, options ...EventPublishOptions) error {
+	if event == nil {
+		return NewCQRSError(ErrCodeEventValidation.String(), "event cannot be nil", nil)
+	}
+
+	bus, err := r.busFor(event)
+	if err != nil {
+		return err
+	}
+	return bus.Publish(ctx, event, options...)
+}
+
+// PublishBatch groups the events by route, keeping their relative order within each route
+func (r *RoutingEventBus) PublishBatch(ctx context.Context, events []EventMessage, options ...EventPublishOptions) error {
+	var order []EventBus
+	batches := make(map[EventBus][]EventMessage)
+	for _, event := range events {
+		if event == nil {
+			return NewCQRSError(ErrCodeEventValidation.String(), "event cannot be nil", nil)
+		}
+		bus, err := r.busFor(event)
+		if err != nil {
+			return err
+		}
+		if _, exists := batches[bus]; !exists {
+			order = append(order, bus)
+		}
+		batches[bus] = append(batches[bus], event)
+	}
+
+	for _, bus := range order {
+		if err := bus.PublishBatch(ctx, batches[bus], options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RoutingEventBus) Subscribe(eventType string, handler EventHandler) (SubscriptionID, error) {
+	if eventType == "" {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "event type cannot be empty", nil)
+	}
+	return r.subscribe("", eventType, handler)
+}
+
+func (r *RoutingEventBus) SubscribeAll(handler EventHandler) (SubscriptionID, error) {
+	return r.subscribe("", "", handler)
+}
+
+// SubscribeRoute attaches a handler to one route only
+func (r *RoutingEventBus) SubscribeRoute(route, eventType string, handler EventHandler) (SubscriptionID, error) {
+	if route == "" || eventType == "" {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "route and event type cannot be empty", nil)
+	}
+	return r.subscribe(route, eventType, handler)
+}
+
+// SubscribeAllRoute attaches a catch-all handler to one route only
+func (r *RoutingEventBus) SubscribeAllRoute(route string, handler EventHandler) (SubscriptionID, error) {
+	if route == "" {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "route cannot be empty", nil)
+	}
+	return r.subscribe(route, "", handler)
+}
+
+func (r *RoutingEventBus) subscribe(route, eventType string, handler EventHandler) (SubscriptionID, error) {
+	if handler == nil {
+		return "", NewCQRSError(ErrCodeEventValidation.String(), "handler cannot be nil", nil)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	targets := r.routes
+	if route != "" {
+		bus, exists := r.routes[route]
+		if !exists {
+			return "", NewCQRSError(ErrCodeEventBusError.String(), fmt.Sprintf("route not registered: %s", route), ErrEventBusNotFound)
+		}
+		targets = map[string]EventBus{route: bus}
+	}
+
+	subscription := &routedSubscription{route: route, eventType: eventType, handler: handler, inner: make(map[string]SubscriptionID)}
+	for name, bus := range targets {
+		id, err := subscribeOn(bus, eventType, handler)
+		if err != nil {
+			return "", err
+		}
+		subscription.inner[name] = id
+	}
+
+	r.nextSubID++
+	id := SubscriptionID(fmt.Sprintf("route_sub_%d", r.nextSubID))
+	r.subscriptions[id] = subscription
+	return id, nil
+}
+
+func subscribeOn(bus EventBus, eventType string, handler EventHandler) (SubscriptionID, error) {
+	if eventType == "" {
+		return bus.SubscribeAll(handler)
+	}
+	return bus.Subscribe(eventType, handler)
+}
+
+func (r *RoutingEventBus) Unsubscribe(subscriptionID SubscriptionID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	subscription, exists := r.subscriptions[subscriptionID]
+	if !exists {
+		return NewNotFoundError(fmt.Sprintf("subscription not found: %s", subscriptionID), nil)
+	}
+	for route, id := range subscription.inner {
+		if bus, exists := r.routes[route]; exists {
+			if err := bus.Unsubscribe(id); err != nil {
+				return err
+			}
+		}
+	}
+	delete(r.subscriptions, subscriptionID)
+	return nil
+}
+
+func (r *RoutingEventBus) Start(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.running {
+		return NewCQRSError(ErrCodeEventBusError.String(), "event bus is already running", nil)
+	}
+	for route, bus := range r.routes {
+		if bus.IsRunning() {
+			continue
+		}
+		if err := bus.Start(ctx); err != nil {
+			return NewCQRSError(ErrCodeEventBusError.String(), fmt.Sprintf("failed to start route %s", route), err)
+		}
+	}
+	r.running = true
+	return nil
+}
+
+func (r *RoutingEventBus) Stop(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.running {
+		return NewCQRSError(ErrCodeEventBusError.String(), "event bus is not running", nil)
+	}
+	r.running = false
+
+	var firstErr error
+	for route, bus := range r.routes {
+		if !bus.IsRunning() {
+			continue
+		}
+		if err := bus.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = NewCQRSError(ErrCodeEventBusError.String(), fmt.Sprintf("failed to stop route %s", route), err)
+		}
+	}
+	return firstErr
+}
+
+func (r *RoutingEventBus) IsRunning() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.running
+}
+
+// GetMetrics sums the metrics of every route
+func (r *RoutingEventBus) GetMetrics() *EventBusMetrics {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	total := &EventBusMetrics{}
+	for _, bus := range r.routes {
+		metrics := bus.GetMetrics()
+		total.PublishedEvents += metrics.PublishedEvents
+		total.ProcessedEvents += metrics.ProcessedEvents
+		total.FailedEvents += metrics.FailedEvents
+		total.ActiveSubscribers += metrics.ActiveSubscribers
+		total.QueueDepth += metrics.QueueDepth
+		total.QueueCapacity += metrics.QueueCapacity
+		total.DroppedEvents += metrics.DroppedEvents
+		total.RejectedEvents += metrics.RejectedEvents
+		if metrics.MaxQueueDepth > total.MaxQueueDepth {
+			total.MaxQueueDepth = metrics.MaxQueueDepth
+		}
+		if metrics.AverageLatency > total.AverageLatency {
+			total.AverageLatency = metrics.AverageLatency
+		}
+		if metrics.LastEventTime.After(total.LastEventTime) {
+			total.LastEventTime = metrics.LastEventTime
+		}
+	}
+	return total
+}
+
+// GetRouteMetrics returns the metrics of one route
+func (r *RoutingEventBus) GetRouteMetrics(route string) (*EventBusMetrics, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	bus, exists := r.routes[route]
+	if !exists {
+		return nil, NewNotFoundError(fmt.Sprintf("route not registered: %s", route), nil)
+	}
+	return bus.GetMetrics(), nil
+}
@@ -0,0 +1,100 @@
+package cqrs
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regionRecorder 받은 이벤트의 region을 기록하는 핸들러
+type regionRecorder struct {
+	*BaseEventHandler
+	mu      sync.Mutex
+	regions []string
+}
+
+func newRegionRecorder() *regionRecorder {
+	return &regionRecorder{BaseEventHandler: NewBaseEventHandler("region-recorder", ProjectionHandler, []string{"MatchFinished"})}
+}
+
+func (h *regionRecorder) Handle(ctx context.Context, event EventMessage) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	region, _ := event.Metadata()["region"].(string)
+	h.regions = append(h.regions, region)
+	return nil
+}
+
+func newRegionalEvent(region string) EventMessage {
+	event := NewBaseEventMessage("MatchFinished")
+	if region != "" {
+		event.AddMetadata("region", region)
+	}
+	return event
+}
+
+func newRegionalBus(t *testing.T) (*RoutingEventBus, map[string]*InMemoryEventBus) {
+	t.Helper()
+	router := NewRoutingEventBus(MetadataRoutingKey("region"), "global")
+	buses := map[string]*InMemoryEventBus{}
+	for _, route := range []string{"global", "kr", "eu"} {
+		buses[route] = NewInMemoryEventBus()
+		require.NoError(t, router.AddRoute(context.Background(), route, buses[route]))
+	}
+	return router, buses
+}
+
+func TestRoutingEventBus_PublishesToRouteBus(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	router, buses := newRegionalBus(t)
+	everywhere := newRegionRecorder()
+	koreaOnly := newRegionRecorder()
+	_, err := router.Subscribe("MatchFinished", everywhere)
+	require.NoError(t, err)
+	_, err = router.SubscribeRoute("kr", "MatchFinished", koreaOnly)
+	require.NoError(t, err)
+
+	// Act
+	err = router.PublishBatch(ctx, []EventMessage{newRegionalEvent("kr"), newRegionalEvent("eu"), newRegionalEvent("")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"kr", "eu", ""}, everywhere.regions)
+	assert.Equal(t, []string{"kr"}, koreaOnly.regions)
+	assert.Equal(t, int64(1), buses["kr"].GetMetrics().PublishedEvents)
+	assert.Equal(t, int64(1), buses["global"].GetMetrics().PublishedEvents)
+	assert.Equal(t, int64(3), router.GetMetrics().PublishedEvents)
+}
+
+func TestRoutingEventBus_UnknownRouteFails(t *testing.T) {
+	// Arrange
+	router, _ := newRegionalBus(t)
+
+	// Act
+	err := router.Publish(context.Background(), newRegionalEvent("us"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrEventBusNotFound)
+}
+
+func TestRoutingEventBus_AddRouteAttachesExistingSubscriptions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	router, _ := newRegionalBus(t)
+	handler := newRegionRecorder()
+	_, err := router.Subscribe("MatchFinished", handler)
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, router.AddRoute(ctx, "us", NewInMemoryEventBus()))
+	err = router.Publish(ctx, newRegionalEvent("us"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us"}, handler.regions)
+	assert.Equal(t, []string{"eu", "global", "kr", "us"}, router.Routes())
+}
@@ -95,29 +95,16 @@ func GetReadModelType(typeName string) (reflect.Type, error) {
 // AggregateFactory function type for creating aggregate instances
 type AggregateFactory func(id string) (AggregateRoot, error)
 
-// AggregateType registry for aggregate factories
-var aggregateFactories = make(map[string]AggregateFactory)
-
-// RegisterAggregateType registers an aggregate factory
-func RegisterAggregateType(typeName string, factory AggregateFactory) {
-	aggregateFactories[typeName] = factory
+// RegisterAggregateType registers an aggregate factory in the default AggregateRegistry.
+// Re-registering a type replaces only its factory; apply and snapshot functions registered
+// with options are kept. Use DefaultAggregateRegistry().Replace to replace those as well.
+func RegisterAggregateType(typeName string, factory AggregateFactory) error {
+	return defaultAggregateRegistry.replaceFactory(typeName, factory)
 }
 
 // CreateAggregateInstance creates an aggregate instance of the specified type
 func CreateAggregateInstance(aggregateType, id string) (AggregateRoot, error) {
-	factory, exists := aggregateFactories[aggregateType]
-	if !exists {
-		return nil, NewCQRSError(ErrCodeSerializationError.String(),
-			fmt.Sprintf("aggregate type not registered: %s", aggregateType), nil)
-	}
-
-	aggregate, err := factory(id)
-	if err != nil {
-		return nil, NewCQRSError(ErrCodeSerializationError.String(),
-			fmt.Sprintf("failed to create aggregate instance: %v", err), err)
-	}
-
-	return aggregate, nil
+	return defaultAggregateRegistry.Create(aggregateType, id)
 }
 
 // Note: EventSerializer interfaces and implementations are now in cqrsx/event_serializer.go
@@ -0,0 +1,412 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultStateHistoryVersions is how many versions per read model InMemoryReadModelHistory keeps
+const DefaultStateHistoryVersions = 20
+
+// JSON patch operations produced by DiffJSON (RFC 6902 subset)
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// JSONPatchOperation is one RFC 6902 operation
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON keeps explicit null values of add and replace operations
+func (o JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == PatchRemove {
+		return json.Marshal(map[string]string{"op": o.Op, "path": o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// normalizeJSON converts a value to its generic JSON form (maps, slices, float64, ...)
+func normalizeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(), "failed to serialize state", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, NewCQRSError(ErrCodeSerializationError.String(), "failed to deserialize state", err)
+	}
+	return normalized, nil
+}
+
+// DiffJSON returns the patch that turns the JSON form of from into the JSON form of to.
+// Objects are diffed per key and arrays per index, so changing one member of a large
+// roster produces one operation; arrays are grown and shrunk at the end.
+func DiffJSON(from, to interface{}) ([]JSONPatchOperation, error) {
+	normalizedFrom, err := normalizeJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	normalizedTo, err := normalizeJSON(to)
+	if err != nil {
+		return nil, err
+	}
+	var ops []JSONPatchOperation
+	diffJSONValues("", normalizedFrom, normalizedTo, &ops)
+	return ops, nil
+}
+
+func diffJSONValues(path string, from, to interface{}, ops *[]JSONPatchOperation) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(fromValue)+len(toValue))
+		for key := range fromValue {
+			keys = append(keys, key)
+		}
+		for key := range toValue {
+			if _, exists := fromValue[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := path + "/" + escapePointer(key)
+			oldChild, inFrom := fromValue[key]
+			newChild, inTo := toValue[key]
+			switch {
+			case !inTo:
+				*ops = append(*ops, JSONPatchOperation{Op: PatchRemove, Path: childPath})
+			case !inFrom:
+				*ops = append(*ops, JSONPatchOperation{Op: PatchAdd, Path: childPath, Value: newChild})
+			default:
+				diffJSONValues(childPath, oldChild, newChild, ops)
+			}
+		}
+		return
+	case []interface{}:
+		toValue, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		common := len(fromValue)
+		if len(toValue) < common {
+			common = len(toValue)
+		}
+		for i := 0; i < common; i++ {
+			diffJSONValues(path+"/"+strconv.Itoa(i), fromValue[i], toValue[i], ops)
+		}
+		for i := len(fromValue) - 1; i >= len(toValue); i-- {
+			*ops = append(*ops, JSONPatchOperation{Op: PatchRemove, Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := common; i < len(toValue); i++ {
+			*ops = append(*ops, JSONPatchOperation{Op: PatchAdd, Path: path + "/" + strconv.Itoa(i), Value: toValue[i]})
+		}
+		return
+	}
+	if !reflect.DeepEqual(from, to) {
+		*ops = append(*ops, JSONPatchOperation{Op: PatchReplace, Path: path, Value: to})
+	}
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// ApplyJSONPatch applies add, remove and replace operations to the JSON form of doc and
+// returns the patched document
+func ApplyJSONPatch(doc interface{}, ops []JSONPatchOperation) (interface{}, error) {
+	result, err := normalizeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.Path == "" {
+			if op.Op == PatchRemove {
+				return nil, NewValidationError("cannot remove the document root", nil)
+			}
+			result = op.Value
+			continue
+		}
+		if result, err = applyPatchOperation(result, strings.Split(op.Path[1:], "/"), op); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func applyPatchOperation(node interface{}, tokens []string, op JSONPatchOperation) (interface{}, error) {
+	token := unescapePointer(tokens[0])
+	last := len(tokens) == 1
+	switch current := node.(type) {
+	case map[string]interface{}:
+		if last {
+			switch op.Op {
+			case PatchRemove:
+				delete(current, token)
+			case PatchAdd, PatchReplace:
+				current[token] = op.Value
+			default:
+				return nil, NewValidationError(fmt.Sprintf("unsupported patch operation: %s", op.Op), nil)
+			}
+			return current, nil
+		}
+		child, exists := current[token]
+		if !exists {
+			return nil, NewValidationError(fmt.Sprintf("patch path not found: %s", op.Path), nil)
+		}
+		updated, err := applyPatchOperation(child, tokens[1:], op)
+		if err != nil {
+			return nil, err
+		}
+		current[token] = updated
+		return current, nil
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if token == "-" {
+			index, err = len(current), nil
+		}
+		if err != nil || index < 0 || index > len(current) || (index == len(current) && !(last && op.Op == PatchAdd)) {
+			return nil, NewValidationError(fmt.Sprintf("invalid array index in patch path: %s", op.Path), nil)
+		}
+		if !last {
+			updated, err := applyPatchOperation(current[index], tokens[1:], op)
+			if err != nil {
+				return nil, err
+			}
+			current[index] = updated
+			return current, nil
+		}
+		switch op.Op {
+		case PatchAdd:
+			current = append(current, nil)
+			copy(current[index+1:], current[index:])
+			current[index] = op.Value
+		case PatchRemove:
+			current = append(current[:index], current[index+1:]...)
+		case PatchReplace:
+			current[index] = op.Value
+		default:
+			return nil, NewValidationError(fmt.Sprintf("unsupported patch operation: %s", op.Op), nil)
+		}
+		return current, nil
+	default:
+		return nil, NewValidationError(fmt.Sprintf("patch path not found: %s", op.Path), nil)
+	}
+}
+
+// ReadModelHistory keeps past read model states so deltas can be computed against the
+// version a client acknowledged
+type ReadModelHistory interface {
+	// Record stores the state of a read model version
+	Record(ctx context.Context, modelType, id string, version int, state interface{}) error
+	// Load returns a recorded state; false when the version is no longer (or never was) kept
+	Load(ctx context.Context, modelType, id string, version int) (interface{}, bool, error)
+}
+
+// InMemoryReadModelHistory keeps the most recent versions of each read model in memory
+type InMemoryReadModelHistory struct {
+	maxVersions int
+
+	mutex    sync.RWMutex
+	versions map[string][]recordedReadModelState // type/id -> versions in ascending order
+}
+
+type recordedReadModelState struct {
+	version int
+	state   []byte
+}
+
+// NewInMemoryReadModelHistory keeps up to maxVersions per read model (default DefaultStateHistoryVersions)
+func NewInMemoryReadModelHistory(maxVersions int) *InMemoryReadModelHistory {
+	if maxVersions <= 0 {
+		maxVersions = DefaultStateHistoryVersions
+	}
+	return &InMemoryReadModelHistory{maxVersions: maxVersions, versions: make(map[string][]recordedReadModelState)}
+}
+
+func (h *InMemoryReadModelHistory) Record(ctx context.Context, modelType, id string, version int, state interface{}) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return NewCQRSError(ErrCodeSerializationError.String(), "failed to serialize read model state", err)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	key := modelType + "/" + id
+	versions := h.versions[key]
+	position := sort.Search(len(versions), func(i int) bool { return versions[i].version >= version })
+	if position < len(versions) && versions[position].version == version {
+		versions[position].state = data
+		return nil
+	}
+	versions = append(versions, recordedReadModelState{})
+	copy(versions[position+1:], versions[position:])
+	versions[position] = recordedReadModelState{version: version, state: data}
+	if len(versions) > h.maxVersions {
+		versions = append([]recordedReadModelState(nil), versions[len(versions)-h.maxVersions:]...)
+	}
+	h.versions[key] = versions
+	return nil
+}
+
+func (h *InMemoryReadModelHistory) Load(ctx context.Context, modelType, id string, version int) (interface{}, bool, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	for _, recorded := range h.versions[modelType+"/"+id] {
+		if recorded.version != version {
+			continue
+		}
+		var state interface{}
+		if err := json.Unmarshal(recorded.state, &state); err != nil {
+			return nil, false, NewCQRSError(ErrCodeSerializationError.String(), "failed to deserialize read model state", err)
+		}
+		return state, true, nil
+	}
+	return nil, false, nil
+}
+
+// StateHistoryReadStore records every saved read model version in a ReadModelHistory, so
+// the projections writing the store also feed delta sync
+type StateHistoryReadStore struct {
+	ReadStore
+	history ReadModelHistory
+}
+
+// NewStateHistoryReadStore wraps store with history recording
+func NewStateHistoryReadStore(store ReadStore, history ReadModelHistory) *StateHistoryReadStore {
+	return &StateHistoryReadStore{ReadStore: store, history: history}
+}
+
+func (s *StateHistoryReadStore) Save(ctx context.Context, readModel ReadModel) error {
+	if err := s.ReadStore.Save(ctx, readModel); err != nil {
+		return err
+	}
+	return s.record(ctx, readModel)
+}
+
+func (s *StateHistoryReadStore) SaveBatch(ctx context.Context, readModels []ReadModel) error {
+	if err := s.ReadStore.SaveBatch(ctx, readModels); err != nil {
+		return err
+	}
+	for _, readModel := range readModels {
+		if err := s.record(ctx, readModel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByIDs keeps batch loading of the wrapped store available
+func (s *StateHistoryReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]ReadModel, error) {
+	return GetReadModelsByIDs(ctx, s.ReadStore, ids, modelType)
+}
+
+func (s *StateHistoryReadStore) record(ctx context.Context, readModel ReadModel) error {
+	return s.history.Record(ctx, readModel.GetType(), readModel.GetID(), readModel.GetVersion(), readModel.GetData())
+}
+
+// StateSyncResponse brings a client from its acknowledged version to the latest one,
+// either as a patch against the acknowledged state or as the full state
+type StateSyncResponse struct {
+	ModelType   string               `json:"model_type"`
+	ModelID     string               `json:"model_id"`
+	FromVersion int                  `json:"from_version"`
+	Version     int                  `json:"version"`
+	Full        bool                 `json:"full"`
+	State       interface{}          `json:"state,omitempty"` // Set when Full
+	Patch       []JSONPatchOperation `json:"patch,omitempty"` // Applied to the acknowledged state otherwise
+}
+
+// UpToDate reports whether the client already has the latest version
+func (r *StateSyncResponse) UpToDate() bool {
+	return !r.Full && r.FromVersion == r.Version
+}
+
+// StateSyncService serves read model deltas to clients, e.g. a guild roster where usually
+// only a few members change between two client refreshes. It falls back to the full state
+// when the acknowledged version is no longer in the history or the patch would not be smaller.
+type StateSyncService struct {
+	store   ReadStore
+	history ReadModelHistory
+}
+
+// NewStateSyncService creates a sync service; wrap the store with NewStateHistoryReadStore
+// so every version the projections write can be diffed against
+func NewStateSyncService(store ReadStore, history ReadModelHistory) *StateSyncService {
+	if history == nil {
+		history = NewInMemoryReadModelHistory(0)
+	}
+	return &StateSyncService{store: store, history: history}
+}
+
+// Sync returns what the client needs to go from ackedVersion (0 for nothing) to the latest version
+func (s *StateSyncService) Sync(ctx context.Context, modelType, id string, ackedVersion int) (*StateSyncResponse, error) {
+	if modelType == "" || id == "" {
+		return nil, NewValidationError("model type and id are required", nil)
+	}
+	latest, err := s.store.GetByID(ctx, id, modelType)
+	if err != nil {
+		return nil, err
+	}
+	state, err := normalizeJSON(latest.GetData())
+	if err != nil {
+		return nil, err
+	}
+	// Stores not wrapped by StateHistoryReadStore still build up history as clients sync
+	if err := s.history.Record(ctx, modelType, id, latest.GetVersion(), state); err != nil {
+		return nil, err
+	}
+
+	response := &StateSyncResponse{ModelType: modelType, ModelID: id, FromVersion: ackedVersion, Version: latest.GetVersion()}
+	if ackedVersion == latest.GetVersion() {
+		return response, nil
+	}
+	if ackedVersion > 0 && ackedVersion < latest.GetVersion() {
+		acked, found, err := s.history.Load(ctx, modelType, id, ackedVersion)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			patch, err := DiffJSON(acked, state)
+			if err != nil {
+				return nil, err
+			}
+			if smallerThan(patch, state) {
+				response.Patch = patch
+				return response, nil
+			}
+		}
+	}
+	response.Full = true
+	response.State = state
+	return response, nil
+}
+
+// smallerThan reports whether the patch serializes smaller than the full state
+func smallerThan(patch []JSONPatchOperation, state interface{}) bool {
+	patchData, patchErr := json.Marshal(patch)
+	stateData, stateErr := json.Marshal(state)
+	return patchErr == nil && stateErr == nil && len(patchData) < len(stateData)
+}
@@ -0,0 +1,112 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// guildRoster 큰 길드 명단 읽기 모델 데이터
+func guildRoster(members int) map[string]interface{} {
+	roster := make([]interface{}, members)
+	for i := range roster {
+		roster[i] = map[string]interface{}{"id": fmt.Sprintf("player-%d", i), "name": fmt.Sprintf("Player %d", i), "level": 10}
+	}
+	return map[string]interface{}{"name": "Defenders", "members": roster}
+}
+
+func saveRoster(t *testing.T, store ReadStore, version int, data map[string]interface{}) {
+	t.Helper()
+	model := NewBaseReadModel("guild-1", "GuildRoster", data)
+	model.SetVersion(version)
+	require.NoError(t, store.Save(context.Background(), model))
+}
+
+func TestDiffJSON_RoundTrip(t *testing.T) {
+	// Arrange
+	from := map[string]interface{}{"name": "a/b", "tags": []interface{}{"x", "y", "z"}, "gone": true, "nested": map[string]interface{}{"n": 1}}
+	to := map[string]interface{}{"name": "a/b", "tags": []interface{}{"x", "w"}, "new": "v", "nested": map[string]interface{}{"n": 2, "m": nil}}
+
+	// Act
+	patch, diffErr := DiffJSON(from, to)
+	patched, applyErr := ApplyJSONPatch(from, patch)
+	expected, _ := normalizeJSON(to)
+	encoded, encodeErr := json.Marshal(patch[:2])
+
+	// Assert
+	require.NoError(t, diffErr)
+	require.NoError(t, applyErr)
+	assert.Equal(t, expected, patched)
+	require.NoError(t, encodeErr)
+	assert.JSONEq(t, `[{"op":"remove","path":"/gone"},{"op":"add","path":"/nested/m","value":null}]`, string(encoded))
+	assert.Equal(t, []JSONPatchOperation{
+		{Op: PatchRemove, Path: "/gone"},
+		{Op: PatchAdd, Path: "/nested/m"},
+		{Op: PatchReplace, Path: "/nested/n", Value: float64(2)},
+		{Op: PatchAdd, Path: "/new", Value: "v"},
+		{Op: PatchReplace, Path: "/tags/1", Value: "w"},
+		{Op: PatchRemove, Path: "/tags/2"},
+	}, patch)
+}
+
+func TestStateSyncService_DeltaForAcknowledgedVersion(t *testing.T) {
+	// Arrange
+	history := NewInMemoryReadModelHistory(2)
+	store := NewStateHistoryReadStore(NewInMemoryReadStore(), history)
+	service := NewStateSyncService(store, history)
+	ctx := context.Background()
+	saveRoster(t, store, 1, guildRoster(50))
+	updated := guildRoster(50)
+	updated["members"].([]interface{})[3].(map[string]interface{})["level"] = 11
+	saveRoster(t, store, 2, updated)
+
+	// Act
+	delta, deltaErr := service.Sync(ctx, "GuildRoster", "guild-1", 1)
+	acked, _, _ := history.Load(ctx, "GuildRoster", "guild-1", 1)
+	patched, applyErr := ApplyJSONPatch(acked, delta.Patch)
+	upToDate, upToDateErr := service.Sync(ctx, "GuildRoster", "guild-1", 2)
+	fresh, freshErr := service.Sync(ctx, "GuildRoster", "guild-1", 0)
+	saveRoster(t, store, 3, updated) // 버전 1은 기록에서 밀려남
+	expired, expiredErr := service.Sync(ctx, "GuildRoster", "guild-1", 1)
+
+	// Assert
+	require.NoError(t, deltaErr)
+	assert.False(t, delta.Full)
+	assert.Equal(t, []JSONPatchOperation{{Op: PatchReplace, Path: "/members/3/level", Value: float64(11)}}, delta.Patch)
+	require.NoError(t, applyErr)
+	expected, _ := normalizeJSON(updated)
+	assert.Equal(t, expected, patched)
+	require.NoError(t, upToDateErr)
+	assert.True(t, upToDate.UpToDate())
+	require.NoError(t, freshErr)
+	assert.True(t, fresh.Full)
+	assert.Equal(t, 2, fresh.Version)
+	require.NoError(t, expiredErr)
+	assert.True(t, expired.Full)
+	assert.Equal(t, 3, expired.Version)
+}
+
+func TestStateSyncService_FullStateWhenPatchIsLarger(t *testing.T) {
+	// Arrange
+	store := NewInMemoryReadStore()
+	service := NewStateSyncService(store, nil)
+	ctx := context.Background()
+	saveRoster(t, store, 1, guildRoster(2))
+	_, err := service.Sync(ctx, "GuildRoster", "guild-1", 0) // 동기화하면서 기록이 쌓임
+	require.NoError(t, err)
+	saveRoster(t, store, 2, map[string]interface{}{"name": "Renamed", "members": []interface{}{}})
+
+	// Act
+	response, syncErr := service.Sync(ctx, "GuildRoster", "guild-1", 1)
+	_, missingErr := service.Sync(ctx, "GuildRoster", "guild-404", 0)
+
+	// Assert
+	require.NoError(t, syncErr)
+	assert.True(t, response.Full)
+	assert.Empty(t, response.Patch)
+	assert.Error(t, missingErr)
+}
@@ -0,0 +1,212 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StorageMetricsProvider is implemented by repositories that report per-aggregate storage metrics
+type StorageMetricsProvider interface {
+	GetStorageMetrics(ctx context.Context, aggregateID string) (*StorageMetrics, error)
+}
+
+// AggregateIDLister lists the IDs of the stored aggregates of one type
+type AggregateIDLister func(ctx context.Context, aggregateType string) ([]string, error)
+
+// StorageMetricsSortField selects how aggregated metrics are ranked
+type StorageMetricsSortField string
+
+const (
+	SortByEventCount StorageMetricsSortField = "event_count" // Most events first
+	SortByStateSize  StorageMetricsSortField = "state_size"  // Largest state first
+	SortByStaleness  StorageMetricsSortField = "staleness"   // Longest unaccessed first
+)
+
+// AggregateStorageMetrics is the storage metrics of one aggregate
+type AggregateStorageMetrics struct {
+	AggregateType string        `json:"aggregate_type"`
+	AggregateID   string        `json:"aggregate_id"`
+	Staleness     time.Duration `json:"staleness"` // Time since LastAccessed; zero LastAccessed counts as never accessed
+	StorageMetrics
+}
+
+// StorageMetricsQuery selects the aggregates to rank
+type StorageMetricsQuery struct {
+	AggregateType string                  `json:"aggregate_type"` // Empty collects every registered type
+	SortBy        StorageMetricsSortField `json:"sort_by"`        // Defaults to SortByEventCount
+	Limit         int                     `json:"limit"`          // Top N aggregates; 0 returns all
+}
+
+// Validate validates the storage metrics query
+func (q StorageMetricsQuery) Validate() error {
+	switch q.SortBy {
+	case "", SortByEventCount, SortByStateSize, SortByStaleness:
+	default:
+		return NewValidationError(fmt.Sprintf("unsupported storage metrics sort field: %s", q.SortBy), nil)
+	}
+	if q.Limit < 0 {
+		return NewValidationError("limit cannot be negative", nil)
+	}
+	return nil
+}
+
+// StorageMetricsSummary aggregates storage metrics for capacity planning
+type StorageMetricsSummary struct {
+	CollectedAt    time.Time                 `json:"collected_at"`
+	SortBy         StorageMetricsSortField   `json:"sort_by"`
+	Aggregates     int                       `json:"aggregates"`
+	TotalEvents    int64                     `json:"total_events"`
+	TotalSnapshots int64                     `json:"total_snapshots"`
+	TotalStateSize int64                     `json:"total_state_size"`
+	Top            []AggregateStorageMetrics `json:"top"`
+	Errors         map[string]string         `json:"errors,omitempty"` // "type/id" -> error of aggregates that could not be measured
+}
+
+type storageMetricsSource struct {
+	provider StorageMetricsProvider
+	lister   AggregateIDLister
+}
+
+// StorageMetricsCollector collects storage metrics from every registered repository
+type StorageMetricsCollector struct {
+	mutex   sync.RWMutex
+	sources map[string]storageMetricsSource
+	now     func() time.Time
+}
+
+// NewStorageMetricsCollector creates an empty collector
+func NewStorageMetricsCollector() *StorageMetricsCollector {
+	return &StorageMetricsCollector{
+		sources: make(map[string]storageMetricsSource),
+		now:     time.Now,
+	}
+}
+
+// Register adds the repository of an aggregate type and the lister of its aggregate IDs
+func (c *StorageMetricsCollector) Register(aggregateType string, provider StorageMetricsProvider, lister AggregateIDLister) error {
+	if aggregateType == "" || provider == nil || lister == nil {
+		return NewValidationError("aggregate type, metrics provider and ID lister are required", nil)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sources[aggregateType] = storageMetricsSource{provider: provider, lister: lister}
+	return nil
+}
+
+// AggregateTypes returns the registered aggregate types in name order
+func (c *StorageMetricsCollector) AggregateTypes() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	types := make([]string, 0, len(c.sources))
+	for aggregateType := range c.sources {
+		types = append(types, aggregateType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Collect measures every aggregate selected by query and returns the totals and top N.
+// Aggregates that fail to report are listed in Errors instead of failing the whole collection.
+func (c *StorageMetricsCollector) Collect(ctx context.Context, query StorageMetricsQuery) (*StorageMetricsSummary, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.SortBy == "" {
+		query.SortBy = SortByEventCount
+	}
+
+	c.mutex.RLock()
+	sources := make(map[string]storageMetricsSource, len(c.sources))
+	for aggregateType, source := range c.sources {
+		if query.AggregateType == "" || query.AggregateType == aggregateType {
+			sources[aggregateType] = source
+		}
+	}
+	c.mutex.RUnlock()
+
+	if query.AggregateType != "" && len(sources) == 0 {
+		return nil, NewNotFoundError("no storage metrics registered for aggregate type: "+query.AggregateType, nil)
+	}
+
+	now := c.now()
+	summary := &StorageMetricsSummary{
+		CollectedAt: now,
+		SortBy:      query.SortBy,
+		Top:         make([]AggregateStorageMetrics, 0),
+		Errors:      make(map[string]string),
+	}
+
+	all := make([]AggregateStorageMetrics, 0)
+	for aggregateType, source := range sources {
+		ids, err := source.lister(ctx, aggregateType)
+		if err != nil {
+			return nil, NewCQRSError(ErrCodeRepositoryError.String(), fmt.Sprintf("failed to list %s aggregates", aggregateType), err)
+		}
+
+		for _, id := range ids {
+			metrics, err := source.provider.GetStorageMetrics(ctx, id)
+			if err != nil {
+				summary.Errors[aggregateType+"/"+id] = err.Error()
+				continue
+			}
+
+			entry := AggregateStorageMetrics{
+				AggregateType:  aggregateType,
+				AggregateID:    id,
+				StorageMetrics: *metrics,
+			}
+			if !metrics.LastAccessed.IsZero() {
+				entry.Staleness = now.Sub(metrics.LastAccessed)
+			}
+			all = append(all, entry)
+
+			summary.TotalEvents += metrics.EventCount
+			summary.TotalSnapshots += metrics.SnapshotCount
+			summary.TotalStateSize += metrics.StateSize
+		}
+	}
+	summary.Aggregates = len(all)
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return storageMetricsLess(all[j], all[i], query.SortBy)
+	})
+	if query.Limit > 0 && len(all) > query.Limit {
+		all = all[:query.Limit]
+	}
+	summary.Top = all
+
+	return summary, nil
+}
+
+// storageMetricsLess orders a before b when a ranks lower; ties fall back to type and ID
+func storageMetricsLess(a, b AggregateStorageMetrics, sortBy StorageMetricsSortField) bool {
+	switch sortBy {
+	case SortByStateSize:
+		if a.StateSize != b.StateSize {
+			return a.StateSize < b.StateSize
+		}
+	case SortByStaleness:
+		// Never accessed ranks as the stalest
+		if a.LastAccessed.IsZero() != b.LastAccessed.IsZero() {
+			return !a.LastAccessed.IsZero()
+		}
+		if a.Staleness != b.Staleness {
+			return a.Staleness < b.Staleness
+		}
+	default:
+		if a.EventCount != b.EventCount {
+			return a.EventCount < b.EventCount
+		}
+	}
+
+	if a.AggregateType != b.AggregateType {
+		return a.AggregateType > b.AggregateType
+	}
+	return a.AggregateID > b.AggregateID
+}
@@ -0,0 +1,110 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetricsProvider 집합체별 고정 메트릭을 돌려주는 제공자
+type fakeMetricsProvider map[string]*StorageMetrics
+
+func (p fakeMetricsProvider) GetStorageMetrics(ctx context.Context, aggregateID string) (*StorageMetrics, error) {
+	metrics, exists := p[aggregateID]
+	if !exists {
+		return nil, errors.New("aggregate unavailable")
+	}
+	return metrics, nil
+}
+
+func listerOf(ids ...string) AggregateIDLister {
+	return func(ctx context.Context, aggregateType string) ([]string, error) {
+		return ids, nil
+	}
+}
+
+func newMetricsCollectorFixture(t *testing.T) (*StorageMetricsCollector, time.Time) {
+	t.Helper()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	collector := NewStorageMetricsCollector()
+	collector.now = func() time.Time { return now }
+
+	guilds := fakeMetricsProvider{
+		"guild-1": {EventCount: 120, SnapshotCount: 1, StateSize: 4096, LastAccessed: now.Add(-time.Hour)},
+		"guild-2": {EventCount: 30, StateSize: 512, LastAccessed: now.Add(-72 * time.Hour)},
+	}
+	users := fakeMetricsProvider{
+		"user-1": {EventCount: 60, SnapshotCount: 2, StateSize: 8192},
+	}
+	require.NoError(t, collector.Register("Guild", guilds, listerOf("guild-1", "guild-2", "guild-missing")))
+	require.NoError(t, collector.Register("User", users, listerOf("user-1")))
+	return collector, now
+}
+
+func TestStorageMetricsCollector_Collect_TopByEventCount(t *testing.T) {
+	// Arrange
+	collector, now := newMetricsCollectorFixture(t)
+
+	// Act
+	summary, err := collector.Collect(context.Background(), StorageMetricsQuery{Limit: 2})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, now, summary.CollectedAt)
+	assert.Equal(t, SortByEventCount, summary.SortBy)
+	assert.Equal(t, 3, summary.Aggregates)
+	assert.Equal(t, int64(210), summary.TotalEvents)
+	assert.Equal(t, int64(3), summary.TotalSnapshots)
+	assert.Equal(t, int64(12800), summary.TotalStateSize)
+	require.Len(t, summary.Top, 2)
+	assert.Equal(t, "guild-1", summary.Top[0].AggregateID)
+	assert.Equal(t, "user-1", summary.Top[1].AggregateID)
+	assert.Contains(t, summary.Errors, "Guild/guild-missing")
+}
+
+func TestStorageMetricsCollector_Collect_SortByStateSizeAndStaleness(t *testing.T) {
+	// Arrange
+	collector, _ := newMetricsCollectorFixture(t)
+
+	// Act
+	bySize, sizeErr := collector.Collect(context.Background(), StorageMetricsQuery{SortBy: SortByStateSize})
+	byStaleness, stalenessErr := collector.Collect(context.Background(), StorageMetricsQuery{SortBy: SortByStaleness})
+
+	// Assert
+	require.NoError(t, sizeErr)
+	require.NoError(t, stalenessErr)
+	assert.Equal(t, []string{"user-1", "guild-1", "guild-2"}, aggregateIDsOf(bySize.Top))
+	// user-1 was never accessed, so it ranks as the stalest
+	assert.Equal(t, []string{"user-1", "guild-2", "guild-1"}, aggregateIDsOf(byStaleness.Top))
+	assert.Equal(t, 72*time.Hour, byStaleness.Top[1].Staleness)
+}
+
+func TestStorageMetricsCollector_Collect_FiltersByAggregateType(t *testing.T) {
+	// Arrange
+	collector, _ := newMetricsCollectorFixture(t)
+
+	// Act
+	summary, err := collector.Collect(context.Background(), StorageMetricsQuery{AggregateType: "User"})
+	_, unknownErr := collector.Collect(context.Background(), StorageMetricsQuery{AggregateType: "Party"})
+	_, invalidErr := collector.Collect(context.Background(), StorageMetricsQuery{SortBy: "size"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1"}, aggregateIDsOf(summary.Top))
+	assert.Empty(t, summary.Errors)
+	assert.Error(t, unknownErr)
+	assert.Error(t, invalidErr)
+	assert.Equal(t, []string{"Guild", "User"}, collector.AggregateTypes())
+}
+
+func aggregateIDsOf(entries []AggregateStorageMetrics) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.AggregateID
+	}
+	return ids
+}
@@ -0,0 +1,132 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// AnyStreamRole in a rule lets every reader, even one without roles, read the streams
+const AnyStreamRole = "*"
+
+// StreamReader identifies who reads event streams through a read API
+type StreamReader struct {
+	Name  string   `json:"name"`  // Service or operator name, for error messages and audit logs
+	Roles []string `json:"roles"` // e.g. "gameplay", "auth-service", "support"
+}
+
+type streamReaderKey struct{}
+
+// WithStreamReader attaches the authenticated reader to a context; read APIs check the
+// StreamAccessPolicy against it. Authentication middleware or interceptors set it.
+func WithStreamReader(ctx context.Context, reader StreamReader) context.Context {
+	return context.WithValue(ctx, streamReaderKey{}, reader)
+}
+
+// StreamReaderFromContext returns the reader attached with WithStreamReader
+func StreamReaderFromContext(ctx context.Context) (StreamReader, bool) {
+	reader, ok := ctx.Value(streamReaderKey{}).(StreamReader)
+	return reader, ok
+}
+
+// StreamAccessPolicy decides which roles may read which aggregate types' event streams,
+// so e.g. user credential streams stay hidden from gameplay services. Aggregate types
+// without a rule fall back to the default roles, which allow everyone unless SetDefault
+// narrows them. It is safe for concurrent use.
+type StreamAccessPolicy struct {
+	mutex        sync.RWMutex
+	rules        map[string]map[string]bool
+	defaultRoles map[string]bool
+}
+
+// NewStreamAccessPolicy creates a policy that allows every stream until rules are added
+func NewStreamAccessPolicy() *StreamAccessPolicy {
+	return &StreamAccessPolicy{
+		rules:        make(map[string]map[string]bool),
+		defaultRoles: map[string]bool{AnyStreamRole: true},
+	}
+}
+
+// Restrict allows only the given roles to read the aggregate type's streams.
+// Calling it again replaces the rule.
+func (p *StreamAccessPolicy) Restrict(aggregateType string, roles ...string) error {
+	if aggregateType == "" {
+		return NewValidationError("aggregate type is required", nil)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rules[aggregateType] = roleSet(roles)
+	return nil
+}
+
+// SetDefault sets the roles that may read aggregate types without a rule; with no roles,
+// unlisted streams become unreadable (deny by default)
+func (p *StreamAccessPolicy) SetDefault(roles ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.defaultRoles = roleSet(roles)
+}
+
+// CanRead reports whether the reader may read the aggregate type's streams
+func (p *StreamAccessPolicy) CanRead(reader StreamReader, aggregateType string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	allowed, exists := p.rules[aggregateType]
+	if !exists {
+		allowed = p.defaultRoles
+	}
+	if allowed[AnyStreamRole] {
+		return true
+	}
+	for _, role := range reader.Roles {
+		if allowed[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks the context's reader against every listed aggregate type and returns a
+// permission error naming the first denied one. Contexts without a reader have no roles.
+func (p *StreamAccessPolicy) Authorize(ctx context.Context, aggregateTypes ...string) error {
+	reader, _ := StreamReaderFromContext(ctx)
+	for _, aggregateType := range aggregateTypes {
+		if !p.CanRead(reader, aggregateType) {
+			name := reader.Name
+			if name == "" {
+				name = "anonymous reader"
+			}
+			return NewPermissionError(fmt.Sprintf("%s may not read %s event streams", name, aggregateType), nil)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the context's reader may read the aggregate type's streams.
+// Read APIs use it to drop events of hidden types from unfiltered results.
+func (p *StreamAccessPolicy) Allows(ctx context.Context, aggregateType string) bool {
+	reader, _ := StreamReaderFromContext(ctx)
+	return p.CanRead(reader, aggregateType)
+}
+
+// RestrictedTypes lists the aggregate types that have their own rule
+func (p *StreamAccessPolicy) RestrictedTypes() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	types := make([]string, 0, len(p.rules))
+	for aggregateType := range p.rules {
+		types = append(types, aggregateType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func roleSet(roles []string) map[string]bool {
+	set := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		set[role] = true
+	}
+	return set
+}
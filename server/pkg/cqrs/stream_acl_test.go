@@ -0,0 +1,44 @@
+package cqrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamAccessPolicy_RestrictsAggregateTypes(t *testing.T) {
+	// Arrange
+	policy := NewStreamAccessPolicy()
+	require.NoError(t, policy.Restrict("UserCredential", "auth-service"))
+	gameplay := WithStreamReader(context.Background(), StreamReader{Name: "battle-server", Roles: []string{"gameplay"}})
+	auth := WithStreamReader(context.Background(), StreamReader{Name: "auth", Roles: []string{"auth-service"}})
+
+	// Act
+	deniedErr := policy.Authorize(gameplay, "Guild", "UserCredential")
+	anonymousErr := policy.Authorize(context.Background(), "UserCredential")
+
+	// Assert
+	assert.True(t, IsPermissionError(deniedErr))
+	assert.Contains(t, deniedErr.Error(), "battle-server may not read UserCredential event streams")
+	assert.Contains(t, anonymousErr.Error(), "anonymous reader")
+	assert.NoError(t, policy.Authorize(auth, "Guild", "UserCredential"))
+	assert.True(t, policy.Allows(gameplay, "Guild"))
+	assert.Equal(t, []string{"UserCredential"}, policy.RestrictedTypes())
+	assert.True(t, IsValidationError(policy.Restrict("")))
+}
+
+func TestStreamAccessPolicy_DenyByDefault(t *testing.T) {
+	// Arrange
+	policy := NewStreamAccessPolicy()
+	policy.SetDefault()
+	require.NoError(t, policy.Restrict("Guild", AnyStreamRole))
+	require.NoError(t, policy.Restrict("Player", "gameplay", "support"))
+	support := StreamReader{Name: "ops", Roles: []string{"support"}}
+
+	// Act & Assert
+	assert.True(t, policy.CanRead(StreamReader{}, "Guild"))
+	assert.True(t, policy.CanRead(support, "Player"))
+	assert.False(t, policy.CanRead(support, "Wallet"))
+}
@@ -0,0 +1,150 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// Dispatch sends a command and returns CommandResult.Data as TResult.
+// It replaces unchecked `result.Data.(T)` assertions: a failed command returns its error,
+// and a Data value of the wrong type returns ErrResultTypeMismatch instead of panicking.
+//
+// Usage:
+//
+//	userID, err := cqrs.Dispatch[string](ctx, dispatcher, createUserCmd)
+func Dispatch[TResult any](ctx context.Context, dispatcher CommandDispatcher, command Command) (TResult, error) {
+	var zero TResult
+
+	result, err := dispatcher.Dispatch(ctx, command)
+	if err != nil {
+		return zero, err
+	}
+	if result == nil {
+		return zero, NewCQRSError(ErrCodeCommandValidation.String(), "command dispatcher returned no result", nil)
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return zero, result.Error
+		}
+		return zero, NewCQRSError(ErrCodeCommandValidation.String(), "command execution failed", nil)
+	}
+
+	return castResult[TResult](result.Data)
+}
+
+// DispatchQuery sends a query and returns QueryResult.Data as TResult.
+//
+// Usage:
+//
+//	view, err := cqrs.DispatchQuery[*projections.UserView](ctx, queryDispatcher, getUserQuery)
+func DispatchQuery[TResult any](ctx context.Context, dispatcher QueryDispatcher, query Query) (TResult, error) {
+	var zero TResult
+
+	result, err := dispatcher.Dispatch(ctx, query)
+	if err != nil {
+		return zero, err
+	}
+	if result == nil {
+		return zero, NewCQRSError(ErrCodeQueryValidation.String(), "query dispatcher returned no result", nil)
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return zero, result.Error
+		}
+		return zero, NewCQRSError(ErrCodeQueryValidation.String(), "query execution failed", nil)
+	}
+
+	return castResult[TResult](result.Data)
+}
+
+// castResult converts result data to TResult; nil data yields the zero value
+func castResult[TResult any](data interface{}) (TResult, error) {
+	var zero TResult
+	if data == nil {
+		return zero, nil
+	}
+
+	typed, ok := data.(TResult)
+	if !ok {
+		return zero, NewCQRSError(ErrCodeValidationError.String(),
+			fmt.Sprintf("expected result of type %T, got %T", zero, data), ErrResultTypeMismatch)
+	}
+	return typed, nil
+}
+
+// TypedCommandHandler adapts a function taking a concrete command type to CommandHandler
+type TypedCommandHandler[C Command] struct {
+	*BaseCommandHandler
+	handle func(ctx context.Context, command C) (*CommandResult, error)
+}
+
+// NewTypedCommandHandler creates a CommandHandler that receives commands as C
+func NewTypedCommandHandler[C Command](name, commandType string, handle func(ctx context.Context, command C) (*CommandResult, error)) *TypedCommandHandler[C] {
+	return &TypedCommandHandler[C]{
+		BaseCommandHandler: NewBaseCommandHandler(name, []string{commandType}),
+		handle:             handle,
+	}
+}
+
+// Handle converts the command to C and invokes the typed function
+func (h *TypedCommandHandler[C]) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	typed, ok := command.(C)
+	if !ok {
+		var zero C
+		return &CommandResult{
+			Success: false,
+			Error: NewCQRSError(ErrCodeCommandValidation.String(),
+				fmt.Sprintf("expected command of type %T, got %T", zero, command), ErrInvalidCommand),
+		}, nil
+	}
+	return h.handle(ctx, typed)
+}
+
+// RegisterCommandHandler registers a typed command function with the dispatcher
+func RegisterCommandHandler[C Command](dispatcher CommandDispatcher, commandType string, handle func(ctx context.Context, command C) (*CommandResult, error)) error {
+	if handle == nil {
+		return NewCQRSError(ErrCodeCommandValidation.String(), "handler cannot be nil", nil)
+	}
+	return dispatcher.RegisterHandler(commandType, NewTypedCommandHandler(commandType+"Handler", commandType, handle))
+}
+
+// TypedQueryHandler adapts a function taking a concrete query type and returning TResult to QueryHandler
+type TypedQueryHandler[Q Query, TResult any] struct {
+	*BaseQueryHandler
+	handle func(ctx context.Context, query Q) (TResult, error)
+}
+
+// NewTypedQueryHandler creates a QueryHandler that receives queries as Q and wraps TResult in a QueryResult
+func NewTypedQueryHandler[Q Query, TResult any](name, queryType string, handle func(ctx context.Context, query Q) (TResult, error)) *TypedQueryHandler[Q, TResult] {
+	return &TypedQueryHandler[Q, TResult]{
+		BaseQueryHandler: NewBaseQueryHandler(name, []string{queryType}),
+		handle:           handle,
+	}
+}
+
+// Handle converts the query to Q and invokes the typed function
+func (h *TypedQueryHandler[Q, TResult]) Handle(ctx context.Context, query Query) (*QueryResult, error) {
+	typed, ok := query.(Q)
+	if !ok {
+		var zero Q
+		return &QueryResult{
+			Success: false,
+			Error: NewCQRSError(ErrCodeQueryValidation.String(),
+				fmt.Sprintf("expected query of type %T, got %T", zero, query), ErrInvalidQuery),
+		}, nil
+	}
+
+	data, err := h.handle(ctx, typed)
+	if err != nil {
+		return &QueryResult{Success: false, Error: err}, nil
+	}
+	return &QueryResult{Success: true, Data: data}, nil
+}
+
+// RegisterQueryHandler registers a typed query function with the dispatcher
+func RegisterQueryHandler[Q Query, TResult any](dispatcher QueryDispatcher, queryType string, handle func(ctx context.Context, query Q) (TResult, error)) error {
+	if handle == nil {
+		return NewCQRSError(ErrCodeQueryValidation.String(), "handler cannot be nil", nil)
+	}
+	return dispatcher.RegisterHandler(queryType, NewTypedQueryHandler(queryType+"Handler", queryType, handle))
+}
@@ -0,0 +1,120 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatch_TypedResult(t *testing.T) {
+	// Arrange
+	dispatcher := NewInMemoryCommandDispatcher()
+	err := RegisterCommandHandler(dispatcher, "TestCommand", func(ctx context.Context, command *TestCommand) (*CommandResult, error) {
+		return &CommandResult{Success: true, Data: command.TestData}, nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	data, err := Dispatch[string](context.Background(), dispatcher, NewTestCommand("agg-1", "hello"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", data)
+}
+
+func TestDispatch_TypeMismatch(t *testing.T) {
+	// Arrange
+	dispatcher := NewInMemoryCommandDispatcher()
+	err := RegisterCommandHandler(dispatcher, "TestCommand", func(ctx context.Context, command *TestCommand) (*CommandResult, error) {
+		return &CommandResult{Success: true, Data: 42}, nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	data, err := Dispatch[string](context.Background(), dispatcher, NewTestCommand("agg-1", "hello"))
+
+	// Assert
+	assert.True(t, errors.Is(err, ErrResultTypeMismatch))
+	assert.Empty(t, data)
+}
+
+func TestDispatch_FailedCommand(t *testing.T) {
+	// Arrange
+	dispatcher := NewInMemoryCommandDispatcher()
+	handlerErr := errors.New("boom")
+	err := RegisterCommandHandler(dispatcher, "TestCommand", func(ctx context.Context, command *TestCommand) (*CommandResult, error) {
+		return &CommandResult{Success: false, Error: handlerErr}, nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	_, err = Dispatch[string](context.Background(), dispatcher, NewTestCommand("agg-1", "hello"))
+
+	// Assert
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+func TestTypedCommandHandler_WrongCommandType(t *testing.T) {
+	// Arrange
+	handler := NewTypedCommandHandler("TestHandler", "TestCommand", func(ctx context.Context, command *TestCommand) (*CommandResult, error) {
+		return &CommandResult{Success: true}, nil
+	})
+
+	// Act
+	result, err := handler.Handle(context.Background(), NewBaseCommand("TestCommand", "agg-1", "TestAggregate", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, errors.Is(result.Error, ErrInvalidCommand))
+}
+
+func TestDispatchQuery_TypedResult(t *testing.T) {
+	// Arrange
+	type view struct{ Name string }
+	dispatcher := NewInMemoryQueryDispatcher()
+	err := RegisterQueryHandler(dispatcher, "GetView", func(ctx context.Context, query *BaseQuery) (*view, error) {
+		return &view{Name: query.GetCriteria().(string)}, nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	result, err := DispatchQuery[*view](context.Background(), dispatcher, NewBaseQuery("GetView", "alice"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result.Name)
+
+	_, err = DispatchQuery[[]*view](context.Background(), dispatcher, NewBaseQuery("GetView", "alice"))
+	assert.True(t, errors.Is(err, ErrResultTypeMismatch))
+}
+
+func TestCommandResult_Envelope(t *testing.T) {
+	// Arrange
+	event := NewBaseEventMessage("GuildCreated")
+	event.setAggregateInfo("guild-1", "Guild", 1)
+
+	// Act
+	result := NewCommandResult("guild-1", 1, event).
+		WithMessage("Guild created successfully").
+		WithData(map[string]string{"name": "Allies"}).
+		AddWarning("guild tag truncated")
+
+	// Assert
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{event.EventID()}, result.EventIDs())
+	assert.Equal(t, []string{"GuildCreated"}, result.EventTypes())
+	assert.Equal(t, 1, result.ProducedEvents[0].Version)
+	assert.True(t, result.HasWarnings())
+	assert.Equal(t, "Guild created successfully", result.Message)
+
+	payload, err := CommandPayload[map[string]string](result)
+	assert.NoError(t, err)
+	assert.Equal(t, "Allies", payload["name"])
+
+	_, err = CommandPayload[string](result)
+	assert.True(t, errors.Is(err, ErrResultTypeMismatch))
+}
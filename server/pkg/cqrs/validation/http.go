@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// MaxBodySize limits request bodies read by DecodeJSON
+const MaxBodySize = 1 << 20
+
+// ErrorResponse is the 400 response body for a failed validation
+type ErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// DecodeJSON decodes a JSON request body into dst and validates it.
+// Malformed bodies and rule failures are both returned as cqrs validation errors.
+func DecodeJSON(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(io.LimitReader(r.Body, MaxBodySize))
+	if err := decoder.Decode(dst); err != nil {
+		return cqrs.NewValidationError("invalid JSON body", err)
+	}
+	return Struct(dst)
+}
+
+// WriteError writes err as a JSON error response: 400 with field details for validation
+// errors, 500 otherwise
+func WriteError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	response := ErrorResponse{Error: err.Error()}
+
+	if cqrs.IsValidationError(err) {
+		statusCode = http.StatusBadRequest
+		response.Error = "validation failed"
+		if fieldErrs, ok := Fields(err); ok {
+			response.Fields = fieldErrs
+		} else {
+			var cqrsErr *cqrs.CQRSError
+			if errors.As(err, &cqrsErr) {
+				response.Error = cqrsErr.Message
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
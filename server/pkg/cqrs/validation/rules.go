@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var (
+	emailPattern    = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	alphanumPattern = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+)
+
+// builtinRules are available in every Validator
+//
+//	min=N, max=N   length for strings (in runes), slices and maps; value for numbers
+//	len=N          exact length
+//	gt=N, lt=N     exclusive numeric bounds
+//	oneof=a b c    value must be one of the space separated options
+//	email, uuid, alphanum
+var builtinRules = map[string]RuleFunc{
+	"min": func(value reflect.Value, param string) (string, bool) {
+		return compare(value, param, "at least", func(actual, limit float64) bool { return actual >= limit })
+	},
+	"max": func(value reflect.Value, param string) (string, bool) {
+		return compare(value, param, "at most", func(actual, limit float64) bool { return actual <= limit })
+	},
+	"len": func(value reflect.Value, param string) (string, bool) {
+		return compare(value, param, "exactly", func(actual, limit float64) bool { return actual == limit })
+	},
+	"gt": func(value reflect.Value, param string) (string, bool) {
+		return compare(value, param, "greater than", func(actual, limit float64) bool { return actual > limit })
+	},
+	"lt": func(value reflect.Value, param string) (string, bool) {
+		return compare(value, param, "less than", func(actual, limit float64) bool { return actual < limit })
+	},
+	"oneof": func(value reflect.Value, param string) (string, bool) {
+		actual := fmt.Sprint(value)
+		for _, option := range strings.Fields(param) {
+			if actual == option {
+				return "", true
+			}
+		}
+		return "must be one of: " + strings.Join(strings.Fields(param), ", "), false
+	},
+	"email":    stringRule(emailPattern, "must be a valid email address"),
+	"uuid":     stringRule(uuidPattern, "must be a valid UUID"),
+	"alphanum": stringRule(alphanumPattern, "must contain only letters and digits"),
+}
+
+// compare checks a length or numeric value against the rule parameter
+func compare(value reflect.Value, param, relation string, ok func(actual, limit float64) bool) (string, bool) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Sprintf("invalid rule parameter %q", param), false
+	}
+
+	var actual float64
+	unit := ""
+	switch value.Kind() {
+	case reflect.String:
+		actual, unit = float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual, unit = float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	case reflect.Invalid:
+		return "", true // nil pointers are handled by required/omitempty
+	default:
+		return fmt.Sprintf("cannot be compared (%s)", value.Kind()), false
+	}
+
+	if ok(actual, limit) {
+		return "", true
+	}
+	return fmt.Sprintf("must be %s %s%s", relation, param, unit), false
+}
+
+// stringRule builds a rule that matches string values against a pattern
+func stringRule(pattern *regexp.Regexp, message string) RuleFunc {
+	return func(value reflect.Value, param string) (string, bool) {
+		if value.Kind() != reflect.String {
+			return "must be a string", false
+		}
+		if value.String() == "" || pattern.MatchString(value.String()) {
+			return "", true
+		}
+		return message, false
+	}
+}
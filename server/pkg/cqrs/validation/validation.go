@@ -0,0 +1,269 @@
+// Package validation provides declarative struct validation shared by HTTP request
+// bodies and command Validate implementations.
+//
+// Rules are declared with the `validate` struct tag and separated by commas:
+//
+//	type CreateGuildRequest struct {
+//		Name string `json:"name" validate:"required,min=3,max=50"`
+//		Tag  string `json:"tag" validate:"omitempty,len=4,alphanum"`
+//		Role string `json:"role" validate:"oneof=leader officer member"`
+//	}
+//
+// Failures are reported per field, using the json name, as a cqrs validation error
+// so cqrs.IsValidationError and HTTP 400 mapping work unchanged.
+package validation
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagName is the struct tag holding validation rules
+const TagName = "validate"
+
+// FieldError describes one failed rule on one field
+type FieldError struct {
+	Field   string `json:"field"`           // JSON path of the field, e.g. "members[0].role"
+	Rule    string `json:"rule"`            // Name of the failed rule
+	Param   string `json:"param,omitempty"` // Rule parameter, e.g. "3" for min=3
+	Message string `json:"message"`
+}
+
+// Errors is the list of field errors of a failed validation
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Fields returns the field errors carried by err, if it is a validation failure
+func Fields(err error) (Errors, bool) {
+	var fieldErrs Errors
+	if errors.As(err, &fieldErrs) {
+		return fieldErrs, true
+	}
+	return nil, false
+}
+
+// RuleFunc checks a field value against a rule parameter and returns a message on failure
+type RuleFunc func(value reflect.Value, param string) (message string, ok bool)
+
+// Validator validates structs with built-in and registered rules
+type Validator struct {
+	mu    sync.RWMutex
+	rules map[string]RuleFunc
+}
+
+// New creates a Validator with the built-in rules
+func New() *Validator {
+	v := &Validator{rules: make(map[string]RuleFunc, len(builtinRules))}
+	for name, rule := range builtinRules {
+		v.rules[name] = rule
+	}
+	return v
+}
+
+// RegisterRule adds or replaces a named rule usable in `validate` tags
+func (v *Validator) RegisterRule(name string, rule RuleFunc) error {
+	if name == "" || strings.ContainsAny(name, ",= ") || rule == nil {
+		return fmt.Errorf("invalid validation rule %q", name)
+	}
+	if name == "required" || name == "omitempty" || name == "dive" {
+		return fmt.Errorf("validation rule %q is reserved", name)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+	return nil
+}
+
+// Struct validates s (a struct or pointer to struct) and returns nil or a cqrs
+// validation error wrapping Errors
+func (v *Validator) Struct(s interface{}) error {
+	value := reflect.ValueOf(s)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return cqrs.NewValidationError("validation failed: nil value", nil)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return cqrs.NewValidationError(fmt.Sprintf("validation failed: expected a struct, got %s", value.Kind()), nil)
+	}
+
+	var fieldErrs Errors
+	v.validateStruct(value, "", &fieldErrs)
+	if len(fieldErrs) == 0 {
+		return nil
+	}
+	return cqrs.NewValidationError("validation failed", fieldErrs).WithContext("fields", []FieldError(fieldErrs))
+}
+
+func (v *Validator) validateStruct(value reflect.Value, prefix string, fieldErrs *Errors) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := value.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Untagged embedded structs are flattened like encoding/json does
+		if field.Anonymous && name == "" {
+			embedded := fieldValue
+			for embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					break
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				v.validateStruct(embedded, prefix, fieldErrs)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		v.validateField(fieldValue, field.Tag.Get(TagName), joinPath(prefix, name), fieldErrs)
+	}
+}
+
+func (v *Validator) validateField(value reflect.Value, tag, path string, fieldErrs *Errors) {
+	rules, elementRules, dive := strings.Cut(tag, ",dive")
+	if strings.HasPrefix(tag, "dive") {
+		rules, elementRules, dive = "", strings.TrimPrefix(tag, "dive"), true
+	}
+
+	zero := isZero(value)
+	target := indirect(value)
+	nilRef := (target.Kind() == reflect.Ptr || target.Kind() == reflect.Interface) && target.IsNil()
+	for _, rule := range splitRules(rules) {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "omitempty":
+			if zero {
+				return
+			}
+			continue
+		case "required":
+			if zero {
+				*fieldErrs = append(*fieldErrs, FieldError{Field: path, Rule: name, Message: "is required"})
+				return
+			}
+			continue
+		}
+
+		if nilRef {
+			continue // Only required/omitempty apply to nil values
+		}
+
+		v.mu.RLock()
+		check, exists := v.rules[name]
+		v.mu.RUnlock()
+		if !exists {
+			*fieldErrs = append(*fieldErrs, FieldError{Field: path, Rule: name, Message: fmt.Sprintf("unknown validation rule %q", name)})
+			continue
+		}
+		if message, ok := check(target, param); !ok {
+			*fieldErrs = append(*fieldErrs, FieldError{Field: path, Rule: name, Param: param, Message: message})
+		}
+	}
+
+	// Nested structs and collections of structs are validated recursively
+	switch target.Kind() {
+	case reflect.Struct:
+		if target.Type() != timeType {
+			v.validateStruct(target, path, fieldErrs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < target.Len(); i++ {
+			element := target.Index(i)
+			elementPath := path + "[" + strconv.Itoa(i) + "]"
+			if dive {
+				v.validateField(element, strings.TrimPrefix(elementRules, ","), elementPath, fieldErrs)
+			} else if indirect(element).Kind() == reflect.Struct {
+				v.validateStruct(indirect(element), elementPath, fieldErrs)
+			}
+		}
+	case reflect.Map:
+		if dive {
+			iter := target.MapRange()
+			for iter.Next() {
+				elementPath := path + "[" + fmt.Sprint(iter.Key()) + "]"
+				v.validateField(iter.Value(), strings.TrimPrefix(elementRules, ","), elementPath, fieldErrs)
+			}
+		}
+	}
+}
+
+func splitRules(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	rules := strings.Split(tag, ",")
+	result := rules[:0]
+	for _, rule := range rules {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value
+}
+
+func isZero(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map:
+		return value.IsNil() || value.Len() == 0
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	}
+	return value.IsZero()
+}
+
+// defaultValidator backs the package-level functions
+var defaultValidator = New()
+
+// Struct validates s with the default validator
+func Struct(s interface{}) error {
+	return defaultValidator.Struct(s)
+}
+
+// RegisterRule adds a rule to the default validator
+func RegisterRule(name string, rule RuleFunc) error {
+	return defaultValidator.RegisterRule(name, rule)
+}
@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memberInput struct {
+	UserID string `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"oneof=leader officer member"`
+}
+
+type createGuildInput struct {
+	*cqrs.BaseCommand
+	Name     string            `json:"name" validate:"required,min=3,max=10"`
+	Tag      string            `json:"tag,omitempty" validate:"omitempty,len=4,alphanum"`
+	Email    string            `json:"email" validate:"email"`
+	Level    *int              `json:"level,omitempty" validate:"omitempty,min=1,max=100"`
+	Members  []memberInput     `json:"members" validate:"min=1"`
+	Tags     []string          `json:"tags" validate:"max=3,dive,required,max=8"`
+	Settings map[string]string `json:"settings" validate:"dive,max=5"`
+	Ignored  string            `json:"-" validate:"required"`
+}
+
+func validGuildInput() *createGuildInput {
+	return &createGuildInput{
+		BaseCommand: cqrs.NewBaseCommand("CreateGuild", "guild-1", "Guild", nil),
+		Name:        "Allies",
+		Email:       "leader@allies.gg",
+		Members:     []memberInput{{UserID: "user-1", Role: "leader"}},
+		Tags:        []string{"pvp"},
+	}
+}
+
+func fieldNames(t *testing.T, err error) []string {
+	fieldErrs, ok := Fields(err)
+	require.True(t, ok, "expected field errors, got %v", err)
+	names := make([]string, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		names[i] = fieldErr.Field + ":" + fieldErr.Rule
+	}
+	return names
+}
+
+func TestStruct_ValidInput(t *testing.T) {
+	assert.NoError(t, Struct(validGuildInput()))
+}
+
+func TestStruct_ReportsFieldErrors(t *testing.T) {
+	// Arrange
+	level := 0
+	input := validGuildInput()
+	input.Name = "Al"
+	input.Tag = "AB!"
+	input.Email = "not-an-email"
+	input.Level = &level
+	input.Members = []memberInput{{UserID: "", Role: "admin"}}
+	input.Tags = []string{"pvp", "", "this-is-too-long"}
+	input.Settings = map[string]string{"lang": "korean"}
+
+	// Act
+	err := Struct(input)
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, cqrs.IsValidationError(err))
+	assert.ElementsMatch(t, []string{
+		"name:min",
+		"tag:len",
+		"tag:alphanum",
+		"email:email",
+		"level:min",
+		"members[0].user_id:required",
+		"members[0].role:oneof",
+		"tags[1]:required",
+		"tags[2]:max",
+		"settings[lang]:max",
+	}, fieldNames(t, err))
+
+	fieldErrs, _ := Fields(err)
+	assert.Equal(t, "must be at least 3 characters", fieldErrs[0].Message)
+	assert.Equal(t, "3", fieldErrs[0].Param)
+}
+
+func TestStruct_RequiredAndOmitempty(t *testing.T) {
+	input := validGuildInput()
+	input.Name = "   "
+	input.Members = nil
+
+	err := Struct(input)
+
+	assert.ElementsMatch(t, []string{"name:required", "members:min"}, fieldNames(t, err))
+}
+
+func TestStruct_RejectsNonStruct(t *testing.T) {
+	var input *createGuildInput
+
+	assert.True(t, cqrs.IsValidationError(Struct(input)))
+	assert.True(t, cqrs.IsValidationError(Struct("guild")))
+}
+
+func TestValidator_CustomRule(t *testing.T) {
+	// Arrange
+	validator := New()
+	require.NoError(t, validator.RegisterRule("guildname", func(value reflect.Value, param string) (string, bool) {
+		if strings.Contains(strings.ToLower(value.String()), "admin") {
+			return "must not impersonate staff", false
+		}
+		return "", true
+	}))
+	require.Error(t, validator.RegisterRule("required", func(reflect.Value, string) (string, bool) { return "", true }))
+
+	type renameInput struct {
+		Name  string `json:"name" validate:"required,guildname"`
+		Other string `json:"other" validate:"unknownrule"`
+	}
+
+	// Act
+	err := validator.Struct(renameInput{Name: "TheAdmins", Other: "x"})
+
+	// Assert
+	fieldErrs, ok := Fields(err)
+	require.True(t, ok)
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, FieldError{Field: "name", Rule: "guildname", Message: "must not impersonate staff"}, fieldErrs[0])
+	assert.Equal(t, "unknownrule", fieldErrs[1].Rule)
+
+	// The default validator does not know the rule
+	defaultErrs, _ := Fields(Struct(renameInput{Name: "TheAdmins", Other: "x"}))
+	require.Len(t, defaultErrs, 2)
+	assert.Equal(t, `unknown validation rule "guildname"`, defaultErrs[0].Message)
+}
+
+func TestDecodeJSONAndWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields int
+	}{
+		{name: "valid", body: `{"name":"Allies","email":"a@b.cd","members":[{"user_id":"u1","role":"member"}]}`, wantStatus: http.StatusOK},
+		{name: "malformed", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "invalid fields", body: `{"name":"A","members":[]}`, wantStatus: http.StatusBadRequest, wantFields: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/guilds", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			// Act
+			var input createGuildInput
+			if err := DecodeJSON(req, &input); err != nil {
+				WriteError(rec, err)
+			}
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Len(t, response.Fields, tt.wantFields)
+				assert.NotEmpty(t, response.Error)
+			}
+		})
+	}
+}
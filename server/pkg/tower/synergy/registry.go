@@ -0,0 +1,65 @@
+package synergy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// Registry holds the active synergy matrix and allows replacing it at runtime.
+// Callers should take a snapshot with Current and keep using it for the whole
+// match so results stay deterministic after a reload.
+type Registry struct {
+	current atomic.Pointer[Matrix]
+}
+
+// NewRegistry creates a registry with an initial matrix (nil disables synergy until loaded)
+func NewRegistry(initial *Matrix) *Registry {
+	registry := &Registry{}
+	if initial != nil {
+		registry.current.Store(initial)
+	}
+	return registry
+}
+
+// Current returns the active matrix, or nil if none is loaded
+func (r *Registry) Current() *Matrix {
+	return r.current.Load()
+}
+
+// Set validates and activates a matrix
+func (r *Registry) Set(matrix *Matrix) error {
+	if matrix == nil {
+		return fmt.Errorf("synergy matrix cannot be nil")
+	}
+	if err := matrix.Validate(); err != nil {
+		return err
+	}
+	r.current.Store(matrix)
+	return nil
+}
+
+// Load reads a JSON matrix and activates it; the active matrix is kept on error
+func (r *Registry) Load(reader io.Reader) (*Matrix, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synergy matrix: %w", err)
+	}
+	matrix, err := ParseMatrix(data)
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(matrix)
+	return matrix, nil
+}
+
+// LoadFile reads a JSON matrix from a file and activates it
+func (r *Registry) LoadFile(filename string) (*Matrix, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open synergy matrix: %w", err)
+	}
+	defer file.Close()
+	return r.Load(file)
+}
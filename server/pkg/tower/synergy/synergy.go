@@ -0,0 +1,170 @@
+// Package synergy implements the cooperative tower synergy rules: a tower
+// buffs adjacent towers placed by allied players according to a configurable
+// synergy matrix.
+//
+// Calculations are deterministic (same towers and matrix always give the same
+// result, independent of input order), so the match simulation and replays
+// agree on every tick.
+package synergy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// WildcardKind matches any tower kind in a synergy matrix
+const WildcardKind = "*"
+
+// Bonus is a set of stat bonuses in percent
+type Bonus struct {
+	Damage      int `json:"damage,omitempty"`
+	Range       int `json:"range,omitempty"`
+	AttackSpeed int `json:"attack_speed,omitempty"`
+}
+
+// IsZero reports whether the bonus has no effect
+func (b Bonus) IsZero() bool {
+	return b == Bonus{}
+}
+
+func (b Bonus) add(other Bonus) Bonus {
+	return Bonus{
+		Damage:      b.Damage + other.Damage,
+		Range:       b.Range + other.Range,
+		AttackSpeed: b.AttackSpeed + other.AttackSpeed,
+	}
+}
+
+func (b Bonus) clamp(limit int) Bonus {
+	if limit <= 0 {
+		return b
+	}
+	clamp := func(value int) int {
+		if value > limit {
+			return limit
+		}
+		return value
+	}
+	return Bonus{Damage: clamp(b.Damage), Range: clamp(b.Range), AttackSpeed: clamp(b.AttackSpeed)}
+}
+
+// Matrix is a versioned synergy configuration.
+// Effects[source][target] is the bonus a source tower gives each adjacent allied target tower.
+type Matrix struct {
+	Version         string                      `json:"version"`
+	Radius          int                         `json:"radius"`                      // Chebyshev tile distance counted as adjacent (default 1)
+	AllowSamePlayer bool                        `json:"allow_same_player,omitempty"` // Also link towers of the same player
+	MaxBonus        int                         `json:"max_bonus,omitempty"`         // Cap per stat after stacking, 0 for no cap
+	Effects         map[string]map[string]Bonus `json:"effects"`
+}
+
+// ParseMatrix decodes and validates a JSON synergy matrix
+func ParseMatrix(data []byte) (*Matrix, error) {
+	var matrix Matrix
+	if err := json.Unmarshal(data, &matrix); err != nil {
+		return nil, fmt.Errorf("failed to parse synergy matrix: %w", err)
+	}
+	if err := matrix.Validate(); err != nil {
+		return nil, err
+	}
+	return &matrix, nil
+}
+
+// Validate checks the matrix and applies defaults
+func (m *Matrix) Validate() error {
+	if m.Version == "" {
+		return fmt.Errorf("synergy matrix version is required")
+	}
+	if m.Radius < 0 || m.MaxBonus < 0 {
+		return fmt.Errorf("synergy matrix radius and max bonus cannot be negative")
+	}
+	if m.Radius == 0 {
+		m.Radius = 1
+	}
+	for source, targets := range m.Effects {
+		if source == "" {
+			return fmt.Errorf("synergy matrix has an empty source kind")
+		}
+		for target := range targets {
+			if target == "" {
+				return fmt.Errorf("synergy matrix source %s has an empty target kind", source)
+			}
+		}
+	}
+	return nil
+}
+
+// effect returns the bonus source gives target; an exact target entry wins over the wildcard
+func (m *Matrix) effect(source, target string) (Bonus, bool) {
+	targets, exists := m.Effects[source]
+	if !exists {
+		return Bonus{}, false
+	}
+	if bonus, exists := targets[target]; exists {
+		return bonus, true
+	}
+	bonus, exists := targets[WildcardKind]
+	return bonus, exists
+}
+
+// Tower is the position and owner of a placed tower
+type Tower struct {
+	ID      string
+	OwnerID string
+	Kind    string
+	X       int
+	Y       int
+}
+
+// Link is one active synergy between two towers
+type Link struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	Bonus    Bonus  `json:"bonus"`
+}
+
+// Result is the synergy state of a set of towers
+type Result struct {
+	Links   []Link           `json:"links"`   // Sorted by source then target
+	Bonuses map[string]Bonus `json:"bonuses"` // Tower ID -> total (capped) bonus; towers without synergy are omitted
+}
+
+// Calculate computes all active synergies between towers
+func (m *Matrix) Calculate(towers []Tower) Result {
+	sorted := append([]Tower(nil), towers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	result := Result{Bonuses: make(map[string]Bonus)}
+	for _, source := range sorted {
+		for _, target := range sorted {
+			if source.ID == target.ID || !m.adjacent(source, target) {
+				continue
+			}
+			if source.OwnerID == target.OwnerID && !m.AllowSamePlayer {
+				continue
+			}
+			bonus, exists := m.effect(source.Kind, target.Kind)
+			if !exists || bonus.IsZero() {
+				continue
+			}
+			result.Links = append(result.Links, Link{SourceID: source.ID, TargetID: target.ID, Bonus: bonus})
+			result.Bonuses[target.ID] = result.Bonuses[target.ID].add(bonus)
+		}
+	}
+	for id, bonus := range result.Bonuses {
+		result.Bonuses[id] = bonus.clamp(m.MaxBonus)
+	}
+	return result
+}
+
+func (m *Matrix) adjacent(a, b Tower) bool {
+	return abs(a.X-b.X) <= m.Radius && abs(a.Y-b.Y) <= m.Radius
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
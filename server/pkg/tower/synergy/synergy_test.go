@@ -0,0 +1,152 @@
+package synergy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testMatrixJSON = `{
+	"version": "v1",
+	"max_bonus": 30,
+	"effects": {
+		"frost":  {"cannon": {"damage": 20}, "*": {"range": 5}},
+		"banner": {"*": {"attack_speed": 10}}
+	}
+}`
+
+func TestCalculateAdjacentAllies(t *testing.T) {
+	matrix, err := ParseMatrix([]byte(testMatrixJSON))
+	if err != nil {
+		t.Fatalf("Failed to parse matrix: %v", err)
+	}
+	if matrix.Radius != 1 {
+		t.Errorf("Expected default radius 1, got %d", matrix.Radius)
+	}
+
+	towers := []Tower{
+		{ID: "t1", OwnerID: "alice", Kind: "frost", X: 0, Y: 0},
+		{ID: "t2", OwnerID: "bob", Kind: "cannon", X: 1, Y: 1},
+		{ID: "t3", OwnerID: "bob", Kind: "arrow", X: 0, Y: 1},
+		{ID: "t4", OwnerID: "bob", Kind: "cannon", X: 5, Y: 5},
+	}
+	result := matrix.Calculate(towers)
+
+	// frost -> cannon uses the exact entry, frost -> arrow falls back to the wildcard
+	if got := result.Bonuses["t2"]; got != (Bonus{Damage: 20}) {
+		t.Errorf("Expected t2 damage bonus 20, got %+v", got)
+	}
+	if got := result.Bonuses["t3"]; got != (Bonus{Range: 5}) {
+		t.Errorf("Expected t3 range bonus 5, got %+v", got)
+	}
+	if _, exists := result.Bonuses["t4"]; exists {
+		t.Error("Expected distant tower to have no synergy")
+	}
+	if _, exists := result.Bonuses["t1"]; exists {
+		t.Error("Expected frost tower to receive no synergy from unlisted kinds")
+	}
+	if len(result.Links) != 2 {
+		t.Errorf("Expected 2 links, got %d", len(result.Links))
+	}
+}
+
+func TestCalculateSamePlayerAndCap(t *testing.T) {
+	matrix, err := ParseMatrix([]byte(testMatrixJSON))
+	if err != nil {
+		t.Fatalf("Failed to parse matrix: %v", err)
+	}
+
+	towers := []Tower{
+		{ID: "a", OwnerID: "alice", Kind: "banner", X: 0, Y: 0},
+		{ID: "b", OwnerID: "carol", Kind: "banner", X: 2, Y: 0},
+		{ID: "c", OwnerID: "alice", Kind: "banner", X: 1, Y: 2},
+		{ID: "target", OwnerID: "bob", Kind: "cannon", X: 1, Y: 1},
+		{ID: "own", OwnerID: "alice", Kind: "cannon", X: 1, Y: 0},
+	}
+	result := matrix.Calculate(towers)
+
+	// Three allied banners stack to 30, then the cap applies
+	if got := result.Bonuses["target"]; got.AttackSpeed != 30 {
+		t.Errorf("Expected capped attack speed 30, got %+v", got)
+	}
+	// Only carol's banner buffs alice's own cannon
+	if got := result.Bonuses["own"]; got.AttackSpeed != 10 {
+		t.Errorf("Expected cross-player only bonus 10, got %+v", got)
+	}
+
+	matrix.AllowSamePlayer = true
+	if got := matrix.Calculate(towers).Bonuses["own"]; got.AttackSpeed != 20 {
+		t.Errorf("Expected same-player bonus 20, got %+v", got)
+	}
+}
+
+func TestCalculateDeterministic(t *testing.T) {
+	matrix, err := ParseMatrix([]byte(testMatrixJSON))
+	if err != nil {
+		t.Fatalf("Failed to parse matrix: %v", err)
+	}
+	towers := []Tower{
+		{ID: "t1", OwnerID: "alice", Kind: "frost", X: 0, Y: 0},
+		{ID: "t2", OwnerID: "bob", Kind: "cannon", X: 1, Y: 0},
+		{ID: "t3", OwnerID: "carol", Kind: "banner", X: 0, Y: 1},
+	}
+	reversed := []Tower{towers[2], towers[1], towers[0]}
+
+	if !reflect.DeepEqual(matrix.Calculate(towers), matrix.Calculate(reversed)) {
+		t.Error("Expected result to be independent of tower order")
+	}
+}
+
+func TestParseMatrixValidation(t *testing.T) {
+	cases := map[string]string{
+		"missing version": `{"effects": {}}`,
+		"negative radius": `{"version": "v1", "radius": -1}`,
+		"empty target":    `{"version": "v1", "effects": {"frost": {"": {"damage": 1}}}}`,
+		"malformed":       `{"version":`,
+	}
+	for name, data := range cases {
+		if _, err := ParseMatrix([]byte(data)); err == nil {
+			t.Errorf("%s: expected parse error", name)
+		}
+	}
+}
+
+func TestRegistryReload(t *testing.T) {
+	registry := NewRegistry(nil)
+	if registry.Current() != nil {
+		t.Error("Expected empty registry")
+	}
+
+	if _, err := registry.Load(strings.NewReader(testMatrixJSON)); err != nil {
+		t.Fatalf("Failed to load matrix: %v", err)
+	}
+	first := registry.Current()
+	if first == nil || first.Version != "v1" {
+		t.Fatalf("Expected v1 matrix, got %+v", first)
+	}
+
+	// An invalid reload keeps the active matrix
+	if _, err := registry.Load(strings.NewReader(`{"effects": {}}`)); err == nil {
+		t.Error("Expected invalid matrix to be rejected")
+	}
+	if registry.Current() != first {
+		t.Error("Expected active matrix to be kept after failed reload")
+	}
+
+	filename := filepath.Join(t.TempDir(), "synergy.json")
+	if err := os.WriteFile(filename, []byte(`{"version": "v2", "radius": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.LoadFile(filename); err != nil {
+		t.Fatalf("Failed to load matrix file: %v", err)
+	}
+	if got := registry.Current(); got.Version != "v2" || got.Radius != 2 {
+		t.Errorf("Expected v2 matrix with radius 2, got %+v", got)
+	}
+	// The earlier snapshot is unaffected
+	if first.Version != "v1" {
+		t.Error("Expected earlier snapshot to stay unchanged")
+	}
+}
@@ -0,0 +1,305 @@
+package apikey
+
+import (
+	"cqrs"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// ApiKeyAggregateType API 키 애그리게이트 타입 (애그리게이트 ID는 키 ID)
+const ApiKeyAggregateType = "ApiKey"
+
+// ScopeAll 모든 권한을 허용하는 범위
+const ScopeAll = "*"
+
+// API 키 이벤트 타입
+const (
+	ApiKeyIssuedEventType  = "ApiKeyIssued"
+	ApiKeyRotatedEventType = "ApiKeyRotated"
+	ApiKeyRevokedEventType = "ApiKeyRevoked"
+)
+
+var (
+	ErrInvalidCommand = errors.New("invalid api key command")
+	ErrInvalidApiKey  = errors.New("invalid api key")
+	ErrApiKeyRevoked  = errors.New("api key is revoked")
+	ErrApiKeyExpired  = errors.New("api key is expired")
+	ErrScopeDenied    = errors.New("api key scope does not allow this call")
+	ErrStoreRequired  = eventstore.ErrStoreRequired
+)
+
+// API 키 이벤트 데이터 (비밀 값은 해시만 저장)
+type (
+	ApiKeyIssuedData struct {
+		Service    string    `json:"service"`
+		Scopes     []string  `json:"scopes"`
+		SecretHash string    `json:"secret_hash"`
+		IssuedBy   string    `json:"issued_by"`
+		IssuedAt   time.Time `json:"issued_at"`
+		ExpiresAt  time.Time `json:"expires_at,omitempty"` // 비어 있으면 만료 없음
+	}
+	ApiKeyRotatedData struct {
+		SecretHash string    `json:"secret_hash"`
+		GraceUntil time.Time `json:"grace_until,omitempty"` // 이전 비밀 값이 유효한 시각 (비어 있으면 즉시 무효)
+		RotatedBy  string    `json:"rotated_by"`
+		RotatedAt  time.Time `json:"rotated_at"`
+	}
+	ApiKeyRevokedData struct {
+		Reason    string    `json:"reason"`
+		RevokedBy string    `json:"revoked_by"`
+		RevokedAt time.Time `json:"revoked_at"`
+	}
+)
+
+// ApiKeyEvent API 키 애그리게이트 이벤트
+type ApiKeyEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *ApiKeyEvent) EventData() interface{} {
+	return e.data
+}
+
+// HashSecret 비밀 값의 저장용 해시 (키는 충분히 무작위이므로 솔트 없는 SHA-256으로 충분)
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScopeAllows granted 범위가 required 범위를 포함하는지 확인합니다
+// "*"는 모든 범위, "match:*"는 "match:"로 시작하는 모든 범위를 포함합니다
+func ScopeAllows(granted, required string) bool {
+	if granted == ScopeAll || granted == required {
+		return true
+	}
+	if prefix, wildcard := strings.CutSuffix(granted, "*"); wildcard {
+		return strings.HasPrefix(required, prefix)
+	}
+	return false
+}
+
+// ApiKeyAggregate 서비스 간 호출에 쓰는 API 키 하나
+// 교체 시 이전 비밀 값은 유예 기간 동안 함께 유효해 무중단 교체가 가능합니다
+type ApiKeyAggregate struct {
+	*cqrs.BaseAggregate
+	service      string
+	scopes       []string
+	secretHash   string
+	previousHash string
+	graceUntil   time.Time
+	issuedBy     string
+	issuedAt     time.Time
+	expiresAt    time.Time
+	rotatedAt    time.Time
+	revoked      *ApiKeyRevokedData
+}
+
+// NewApiKeyAggregate 새로운 ApiKeyAggregate를 생성합니다
+func NewApiKeyAggregate(keyID string) *ApiKeyAggregate {
+	return &ApiKeyAggregate{BaseAggregate: cqrs.NewBaseAggregate(keyID, ApiKeyAggregateType)}
+}
+
+// Issued 발급된 키인지 확인합니다
+func (a *ApiKeyAggregate) Issued() bool {
+	return a.secretHash != ""
+}
+
+// Service 키를 사용하는 서비스 이름
+func (a *ApiKeyAggregate) Service() string {
+	return a.service
+}
+
+// Scopes 키에 허용된 권한 범위
+func (a *ApiKeyAggregate) Scopes() []string {
+	return append([]string(nil), a.scopes...)
+}
+
+// Allows 키가 scope 범위의 호출을 허용하는지 확인합니다
+func (a *ApiKeyAggregate) Allows(scope string) bool {
+	for _, granted := range a.scopes {
+		if ScopeAllows(granted, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// Issue 키를 발급합니다 (secretHash는 HashSecret으로 만든 값)
+func (a *ApiKeyAggregate) Issue(service string, scopes []string, secretHash, issuedBy string, expiresAt, now time.Time) error {
+	if a.Issued() {
+		return fmt.Errorf("%w: key %s is already issued", ErrInvalidCommand, a.ID())
+	}
+	if service == "" || issuedBy == "" || secretHash == "" {
+		return fmt.Errorf("%w: service, issuer and secret are required", ErrInvalidCommand)
+	}
+	if len(scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidCommand)
+	}
+	for _, scope := range scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\n") {
+			return fmt.Errorf("%w: invalid scope %q", ErrInvalidCommand, scope)
+		}
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return fmt.Errorf("%w: expiry must be in the future", ErrInvalidCommand)
+	}
+	return a.raise(ApiKeyIssuedEventType, ApiKeyIssuedData{
+		Service:    service,
+		Scopes:     append([]string(nil), scopes...),
+		SecretHash: secretHash,
+		IssuedBy:   issuedBy,
+		IssuedAt:   now,
+		ExpiresAt:  expiresAt,
+	})
+}
+
+// Rotate 비밀 값을 교체합니다. 이전 비밀 값은 grace 동안 계속 유효합니다
+func (a *ApiKeyAggregate) Rotate(secretHash, rotatedBy string, grace time.Duration, now time.Time) error {
+	if err := a.usable(now); err != nil {
+		return err
+	}
+	if secretHash == "" || rotatedBy == "" {
+		return fmt.Errorf("%w: secret and issuer are required", ErrInvalidCommand)
+	}
+	if grace < 0 {
+		return fmt.Errorf("%w: grace period must not be negative", ErrInvalidCommand)
+	}
+	data := ApiKeyRotatedData{SecretHash: secretHash, RotatedBy: rotatedBy, RotatedAt: now}
+	if grace > 0 {
+		data.GraceUntil = now.Add(grace)
+	}
+	return a.raise(ApiKeyRotatedEventType, data)
+}
+
+// Revoke 키를 폐기합니다 (교체 유예 중인 이전 비밀 값도 함께 무효)
+func (a *ApiKeyAggregate) Revoke(reason, revokedBy string, now time.Time) error {
+	if !a.Issued() {
+		return ErrInvalidApiKey
+	}
+	if a.revoked != nil {
+		return ErrApiKeyRevoked
+	}
+	if revokedBy == "" {
+		return fmt.Errorf("%w: issuer is required", ErrInvalidCommand)
+	}
+	return a.raise(ApiKeyRevokedEventType, ApiKeyRevokedData{Reason: reason, RevokedBy: revokedBy, RevokedAt: now})
+}
+
+// Verify 비밀 값이 now 기준으로 유효한지 확인합니다
+func (a *ApiKeyAggregate) Verify(secret string, now time.Time) error {
+	if err := a.usable(now); err != nil {
+		return err
+	}
+	hash := HashSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(a.secretHash)) == 1 {
+		return nil
+	}
+	if a.previousHash != "" && now.Before(a.graceUntil) &&
+		subtle.ConstantTimeCompare([]byte(hash), []byte(a.previousHash)) == 1 {
+		return nil
+	}
+	return ErrInvalidApiKey
+}
+
+// View 키 상태 (비밀 값 해시 제외)
+func (a *ApiKeyAggregate) View() ApiKeyView {
+	view := ApiKeyView{
+		KeyID:     a.ID(),
+		Service:   a.service,
+		Scopes:    a.Scopes(),
+		IssuedBy:  a.issuedBy,
+		IssuedAt:  a.issuedAt,
+		ExpiresAt: a.expiresAt,
+		RotatedAt: a.rotatedAt,
+	}
+	if a.revoked != nil {
+		view.Revoked = true
+		view.RevokedAt = a.revoked.RevokedAt
+		view.RevokeReason = a.revoked.Reason
+	}
+	return view
+}
+
+func (a *ApiKeyAggregate) usable(now time.Time) error {
+	switch {
+	case !a.Issued():
+		return ErrInvalidApiKey
+	case a.revoked != nil:
+		return ErrApiKeyRevoked
+	case !a.expiresAt.IsZero() && !now.Before(a.expiresAt):
+		return ErrApiKeyExpired
+	}
+	return nil
+}
+
+// LoadFromHistory 이벤트 스트림에서 키 상태를 복원합니다
+func (a *ApiKeyAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *ApiKeyAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := apiKeyEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown api key event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *ApiKeyAggregate) raise(eventType string, data interface{}) error {
+	event := &ApiKeyEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *ApiKeyAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case ApiKeyIssuedData:
+		a.service = data.Service
+		a.scopes = data.Scopes
+		a.secretHash = data.SecretHash
+		a.issuedBy = data.IssuedBy
+		a.issuedAt = data.IssuedAt
+		a.expiresAt = data.ExpiresAt
+	case ApiKeyRotatedData:
+		a.previousHash = a.secretHash
+		a.graceUntil = data.GraceUntil
+		a.secretHash = data.SecretHash
+		a.rotatedAt = data.RotatedAt
+	case ApiKeyRevokedData:
+		revoked := data
+		a.revoked = &revoked
+	}
+}
+
+// apiKeyEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var apiKeyEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	ApiKeyIssuedEventType:  eventstore.DecodeEventData[ApiKeyIssuedData],
+	ApiKeyRotatedEventType: eventstore.DecodeEventData[ApiKeyRotatedData],
+	ApiKeyRevokedEventType: eventstore.DecodeEventData[ApiKeyRevokedData],
+}
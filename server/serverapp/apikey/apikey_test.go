@@ -0,0 +1,261 @@
+package apikey
+
+import (
+	"context"
+	"cqrs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newService(t *testing.T, config ServiceConfig) *Service {
+	t.Helper()
+	if config.Store == nil {
+		config.Store = eventstore.NewInMemoryEventStore()
+	}
+	service, err := NewService(config)
+	require.NoError(t, err)
+	return service
+}
+
+func issue(t *testing.T, service *Service, serviceName string, scopes ...string) IssuedApiKey {
+	t.Helper()
+	result := testkit.Handle(t, service, NewIssueApiKeyCommand(serviceName, scopes, "admin", 0))
+	require.True(t, result.Success, result.Error)
+	return result.Data.(IssuedApiKey)
+}
+
+func TestScopeAllows(t *testing.T) {
+	assert.True(t, ScopeAllows("*", "match:write"))
+	assert.True(t, ScopeAllows("match:*", "match:write"))
+	assert.True(t, ScopeAllows("match:read", "match:read"))
+	assert.False(t, ScopeAllows("match:read", "match:write"))
+	assert.False(t, ScopeAllows("match:*", "rating:read"))
+}
+
+func TestService_IssueRotateRevoke(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(testkit.DefaultStart)
+	store := eventstore.NewInMemoryEventStore()
+	service := newService(t, ServiceConfig{Store: store, Now: clock.Now})
+	ctx := context.Background()
+	issued := issue(t, service, "matchmaker", "match:*")
+
+	// Act
+	principal, authErr := service.Authorize(ctx, issued.Key, "match:write")
+	_, scopeErr := service.Authorize(ctx, issued.Key, "rating:write")
+
+	rotated, err := service.Handle(ctx, NewRotateApiKeyCommand(issued.KeyID, "admin", time.Hour))
+	require.NoError(t, err)
+	newKey := rotated.Data.(IssuedApiKey).Key
+	_, graceErr := service.Authenticate(ctx, issued.Key)
+	clock.Advance(2 * time.Hour)
+	_, afterGraceErr := service.Authenticate(ctx, issued.Key)
+
+	revoked, err := service.Handle(ctx, NewRevokeApiKeyCommand(issued.KeyID, "admin", "leaked"))
+	require.NoError(t, err)
+	_, revokedErr := service.Authenticate(ctx, newKey)
+
+	// Assert
+	require.NoError(t, authErr)
+	assert.Equal(t, "matchmaker", principal.Service)
+	assert.ErrorIs(t, scopeErr, ErrScopeDenied)
+	assert.NoError(t, graceErr) // 유예 기간 동안 이전 키 허용
+	assert.ErrorIs(t, afterGraceErr, ErrInvalidApiKey)
+	require.True(t, revoked.Success)
+	assert.ErrorIs(t, revokedErr, ErrApiKeyRevoked)
+
+	// 저장소에는 평문 비밀 값이 남지 않음
+	history, err := store.GetEventHistory(ctx, issued.KeyID, ApiKeyAggregateType, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	_, secret, err := ParseKey(issued.Key)
+	require.NoError(t, err)
+	issuedData := history[0].EventData().(ApiKeyIssuedData)
+	assert.Equal(t, HashSecret(secret), issuedData.SecretHash)
+	assert.NotContains(t, issuedData.SecretHash, secret)
+
+	// 재시작 후에도 저장소에서 키를 복원
+	restarted := newService(t, ServiceConfig{Store: store, Now: clock.Now})
+	view, err := restarted.Key(ctx, issued.KeyID)
+	require.NoError(t, err)
+	assert.True(t, view.Revoked)
+	assert.Equal(t, "leaked", view.RevokeReason)
+
+	usage, exists := service.Usage(issued.KeyID)
+	require.True(t, exists)
+	assert.Equal(t, int64(2), usage.Requests)
+	assert.Equal(t, int64(3), usage.Rejected)
+	assert.Equal(t, int64(1), usage.Scopes["match:write"])
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 발급한 키가 사라짐
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestService_SeesChangesFromOtherInstances(t *testing.T) {
+	// Arrange - 같은 저장소와 버스를 쓰는 두 인스턴스
+	clock := testkit.NewClock(testkit.DefaultStart)
+	store := eventstore.NewInMemoryEventStore()
+	bus := testkit.StartedEventBus(t)
+	ctx := context.Background()
+	admin := newService(t, ServiceConfig{Store: store, EventBus: bus, Now: clock.Now})
+	subscribed := newService(t, ServiceConfig{Store: store, Now: clock.Now})
+	_, err := subscribed.Subscribe(bus)
+	require.NoError(t, err)
+	unsubscribed := newService(t, ServiceConfig{Store: store, CacheTTL: time.Minute, Now: clock.Now})
+
+	revokedKey := issue(t, admin, "matchmaker", "match:*")
+	rotatedKey := issue(t, admin, "rating", "rating:*")
+	for _, service := range []*Service{subscribed, unsubscribed} {
+		for _, key := range []IssuedApiKey{revokedKey, rotatedKey} {
+			_, err := service.Authenticate(ctx, key.Key)
+			require.NoError(t, err) // 두 인스턴스 모두 키를 캐시
+		}
+	}
+
+	// Act
+	revoked := testkit.Handle(t, admin, NewRevokeApiKeyCommand(revokedKey.KeyID, "admin", "leaked"))
+	rotated := testkit.Handle(t, admin, NewRotateApiKeyCommand(rotatedKey.KeyID, "admin", 0))
+	require.True(t, revoked.Success)
+	require.True(t, rotated.Success)
+	require.Eventually(t, func() bool {
+		_, err := subscribed.Authenticate(ctx, revokedKey.Key)
+		return err != nil
+	}, time.Second, 5*time.Millisecond)
+	_, rotatedErr := subscribed.Authenticate(ctx, rotatedKey.Key)
+	_, beforeTTLErr := unsubscribed.Authenticate(ctx, revokedKey.Key)
+	clock.Advance(time.Minute)
+	_, afterTTLErr := unsubscribed.Authenticate(ctx, revokedKey.Key)
+
+	// Assert
+	assert.ErrorIs(t, rotatedErr, ErrInvalidApiKey)
+	assert.NoError(t, beforeTTLErr) // 이벤트를 받지 못한 인스턴스는 CacheTTL까지 캐시 사용
+	assert.ErrorIs(t, afterTTLErr, ErrApiKeyRevoked)
+}
+
+func TestService_IssueValidation(t *testing.T) {
+	// Arrange
+	service := newService(t, ServiceConfig{})
+	ctx := context.Background()
+
+	// Act
+	noScopes, err := service.Handle(ctx, NewIssueApiKeyCommand("matchmaker", nil, "admin", 0))
+	require.NoError(t, err)
+	_, malformedErr := service.Authenticate(ctx, "not-a-key")
+	_, unknownErr := service.Authenticate(ctx, FormatKey("missing", "secret"))
+
+	// Assert
+	assert.ErrorIs(t, noScopes.Error, ErrInvalidCommand)
+	assert.ErrorIs(t, malformedErr, ErrInvalidApiKey)
+	assert.ErrorIs(t, unknownErr, ErrInvalidApiKey)
+	assert.Empty(t, service.UsageReport())
+}
+
+func TestService_HTTPMiddleware(t *testing.T) {
+	// Arrange
+	service := newService(t, ServiceConfig{})
+	issued := issue(t, service, "battle-server", "rating:read")
+	handler := service.Middleware("rating:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := PrincipalFromContext(r.Context())
+		reader, _ := cqrs.StreamReaderFromContext(r.Context())
+		w.Write([]byte(principal.Service + "/" + reader.Name))
+	}))
+	writeOnly := service.Middleware("rating:write")(handler)
+
+	request := func(h http.Handler, header, value string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/ratings", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return rec
+	}
+
+	// Act
+	allowed := request(handler, "Authorization", "ApiKey "+issued.Key)
+	missing := request(handler, "", "")
+	denied := request(writeOnly, HeaderName, issued.Key)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, "battle-server/battle-server", allowed.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, missing.Code)
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+}
+
+func TestService_UnaryServerInterceptor(t *testing.T) {
+	// Arrange
+	service := newService(t, ServiceConfig{})
+	issued := issue(t, service, "battle-server", "rating:read")
+	interceptor := service.UnaryServerInterceptor(func(fullMethod string) string {
+		if strings.HasSuffix(fullMethod, "/Update") {
+			return "rating:write"
+		}
+		return "rating:read"
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal, _ := PrincipalFromContext(ctx)
+		return principal.Service, nil
+	}
+	call := func(method string, md metadata.MD) (interface{}, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/rating.Ratings/" + method}, handler)
+	}
+
+	// Act
+	response, err := call("Get", metadata.Pairs(MetadataName, issued.Key))
+	_, deniedErr := call("Update", metadata.Pairs(MetadataName, issued.Key))
+	_, missingErr := call("Get", metadata.MD{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "battle-server", response)
+	assert.Equal(t, codes.PermissionDenied, status.Code(deniedErr))
+	assert.Equal(t, codes.Unauthenticated, status.Code(missingErr))
+}
+
+func TestApiKeyApp_IssueAndUsage(t *testing.T) {
+	// Arrange
+	app, err := NewApiKeyApp(Config{
+		Service:  ServiceConfig{Store: eventstore.NewInMemoryEventStore()},
+		Auth:     func(next http.Handler) http.Handler { return next },
+		Identify: func(r *http.Request) string { return "admin" },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+
+	// Act
+	issueRec := httptest.NewRecorder()
+	mux.ServeHTTP(issueRec, httptest.NewRequest(http.MethodPost, "/api-keys/issue",
+		strings.NewReader(`{"service":"matchmaker","scopes":["match:*"]}`)))
+	usageRec := httptest.NewRecorder()
+	mux.ServeHTTP(usageRec, httptest.NewRequest(http.MethodGet, "/api-keys/usage", nil))
+	_, missingAuth := NewApiKeyApp(Config{Identify: func(r *http.Request) string { return "" }})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, issueRec.Code)
+	assert.Contains(t, issueRec.Body.String(), `"key":"dak_`)
+	assert.NotContains(t, issueRec.Body.String(), "secret_hash")
+	assert.Equal(t, http.StatusOK, usageRec.Code)
+	assert.Error(t, missingAuth)
+}
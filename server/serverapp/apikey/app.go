@@ -0,0 +1,266 @@
+package apikey
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath API 키 관리 기본 경로
+const DefaultBasePath = "/api-keys"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 16 << 10
+
+// Config API 키 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /api-keys)
+	Service  ServiceConfig                   // API 키 서비스 설정
+	Auth     func(http.Handler) http.Handler // 필수: 관리자 인증 미들웨어
+	Identify func(r *http.Request) string    // 필수: 키를 관리하는 관리자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Auth == nil {
+		return errors.New("admin auth middleware is required")
+	}
+	if c.Identify == nil {
+		return errors.New("identify is required to record who manages api keys")
+	}
+	return nil
+}
+
+// ApiKeyApp 서비스 간 호출용 API 키를 발급, 교체, 폐기하는 서버앱
+// 내부 서비스의 HTTP/gRPC API는 Service().Middleware 또는 gRPC 인터셉터로 키를 확인합니다
+type ApiKeyApp struct {
+	*serverapp.BaseApp
+	config        Config
+	service       *Service
+	subscriptions []cqrs.SubscriptionID
+}
+
+// NewApiKeyApp 새로운 ApiKeyApp을 생성합니다
+func NewApiKeyApp(config Config) (*ApiKeyApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &ApiKeyApp{
+		BaseApp: serverapp.NewBaseApp("apikey"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Start 다른 인스턴스의 키 교체/폐기 이벤트 구독을 시작합니다
+func (a *ApiKeyApp) Start(ctx context.Context) error {
+	if bus := a.config.Service.EventBus; bus != nil {
+		subscriptions, err := a.service.Subscribe(bus)
+		a.subscriptions = subscriptions
+		if err != nil {
+			a.unsubscribe()
+			return err
+		}
+	}
+	return a.BaseApp.Start(ctx)
+}
+
+// Stop 구독을 해제합니다
+func (a *ApiKeyApp) Stop(ctx context.Context) error {
+	a.unsubscribe()
+	return a.BaseApp.Stop(ctx)
+}
+
+func (a *ApiKeyApp) unsubscribe() {
+	for _, subscription := range a.subscriptions {
+		if err := a.config.Service.EventBus.Unsubscribe(subscription); err != nil {
+			log.Printf("[ApiKey] Failed to unsubscribe: %v", err)
+		}
+	}
+	a.subscriptions = nil
+}
+
+// Service API 키 서비스
+func (a *ApiKeyApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *ApiKeyApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+
+	mux.Handle(base, protect(http.HandlerFunc(a.status)))
+	mux.Handle(base+"/issue", protect(http.HandlerFunc(a.issue)))
+	mux.Handle(base+"/rotate", protect(http.HandlerFunc(a.rotate)))
+	mux.Handle(base+"/revoke", protect(http.HandlerFunc(a.revoke)))
+	mux.Handle(base+"/usage", protect(http.HandlerFunc(a.usageReport)))
+
+	log.Printf("[ApiKey] Routes registered under %s", base)
+}
+
+// DescribeAPI API 키 엔드포인트 설명 (/openapi.json)
+func (a *ApiKeyApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	keyID := serverapp.APIParameter{Name: "key_id", In: "query", Required: true}
+	return []serverapp.APIOperation{
+		{Method: http.MethodGet, Path: base, Summary: "API 키 상태와 사용량 조회", Parameters: []serverapp.APIParameter{keyID}, Response: KeyStatus{}, Secured: true},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/issue",
+			Summary:     "API 키 발급",
+			Description: "응답의 key는 이때만 확인할 수 있습니다. 저장소에는 해시만 기록됩니다.",
+			Request:     IssueApiKeyData{},
+			Response:    IssuedApiKey{},
+			Secured:     true,
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/rotate",
+			Summary:     "API 키 교체",
+			Description: "새 키를 발급하고, 이전 키는 grace_seconds 동안 계속 허용합니다.",
+			Parameters:  []serverapp.APIParameter{keyID},
+			Request:     RotateApiKeyData{},
+			Response:    IssuedApiKey{},
+			Secured:     true,
+		},
+		{Method: http.MethodPost, Path: base + "/revoke", Summary: "API 키 폐기", Parameters: []serverapp.APIParameter{keyID}, Request: RevokeApiKeyData{}, Response: ApiKeyView{}, Secured: true},
+		{Method: http.MethodGet, Path: base + "/usage", Summary: "키별 사용량 메트릭", Response: []KeyUsage{}, Secured: true},
+	}
+}
+
+// KeyStatus 키 상태와 사용량
+type KeyStatus struct {
+	ApiKeyView
+	Usage *KeyUsage `json:"usage,omitempty"`
+}
+
+func (a *ApiKeyApp) status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	keyID := r.URL.Query().Get("key_id")
+	if keyID == "" {
+		sendError(w, http.StatusBadRequest, "key_id is required")
+		return
+	}
+	view, err := a.service.Key(r.Context(), keyID)
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	status := KeyStatus{ApiKeyView: view}
+	if usage, exists := a.service.Usage(keyID); exists {
+		status.Usage = &usage
+	}
+	sendJSON(w, http.StatusOK, status)
+}
+
+func (a *ApiKeyApp) usageReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	sendJSON(w, http.StatusOK, map[string]interface{}{"keys": a.service.UsageReport()})
+}
+
+func (a *ApiKeyApp) issue(w http.ResponseWriter, r *http.Request) {
+	var request IssueApiKeyData
+	if !a.decode(w, r, &request, false) {
+		return
+	}
+	ttl := time.Duration(request.TTLSeconds) * time.Second
+	a.execute(w, r, NewIssueApiKeyCommand(request.Service, request.Scopes, a.config.Identify(r), ttl))
+}
+
+func (a *ApiKeyApp) rotate(w http.ResponseWriter, r *http.Request) {
+	var request RotateApiKeyData
+	if !a.decode(w, r, &request, true) {
+		return
+	}
+	grace := time.Duration(request.GraceSeconds) * time.Second
+	a.execute(w, r, NewRotateApiKeyCommand(r.URL.Query().Get("key_id"), a.config.Identify(r), grace))
+}
+
+func (a *ApiKeyApp) revoke(w http.ResponseWriter, r *http.Request) {
+	var request RevokeApiKeyData
+	if !a.decode(w, r, &request, true) {
+		return
+	}
+	a.execute(w, r, NewRevokeApiKeyCommand(r.URL.Query().Get("key_id"), a.config.Identify(r), request.Reason))
+}
+
+func (a *ApiKeyApp) decode(w http.ResponseWriter, r *http.Request, request interface{}, needsKeyID bool) bool {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	if needsKeyID && r.URL.Query().Get("key_id") == "" {
+		sendError(w, http.StatusBadRequest, "key_id is required")
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(request); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return false
+	}
+	return true
+}
+
+func (a *ApiKeyApp) execute(w http.ResponseWriter, r *http.Request, command cqrs.Command) {
+	result, err := a.service.Handle(r.Context(), command)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !result.Success {
+		sendError(w, statusForError(result.Error), result.Error.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, result.Data)
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidCommand):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrInvalidApiKey):
+		return http.StatusNotFound
+	case errors.Is(err, ErrApiKeyRevoked), errors.Is(err, ErrApiKeyExpired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
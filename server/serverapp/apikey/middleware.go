@@ -0,0 +1,150 @@
+package apikey
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 키를 전달하는 HTTP 헤더와 gRPC 메타데이터 키
+const (
+	HeaderName   = "X-Api-Key"
+	MetadataName = "x-api-key"
+)
+
+// Principal 키로 인증된 호출 서비스
+type Principal struct {
+	KeyID   string   `json:"key_id"`
+	Service string   `json:"service"`
+	Scopes  []string `json:"scopes"`
+}
+
+// StreamReader 이벤트 스트림 접근 제어용 조회자 (범위를 역할로 사용)
+func (p Principal) StreamReader() cqrs.StreamReader {
+	return cqrs.StreamReader{Name: p.Service, Roles: p.Scopes}
+}
+
+type principalKey struct{}
+
+// WithPrincipal 인증된 서비스를 컨텍스트에 담습니다
+// cqrs.StreamAccessPolicy가 확인할 수 있도록 스트림 조회자도 함께 담습니다
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return cqrs.WithStreamReader(ctx, principal.StreamReader())
+}
+
+// PrincipalFromContext 미들웨어가 인증한 서비스를 반환합니다
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// KeyFromRequest X-Api-Key 헤더 또는 "Authorization: ApiKey <키>"에서 키를 읽습니다
+func KeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get(HeaderName); key != "" {
+		return key
+	}
+	if key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "ApiKey "); ok {
+		return strings.TrimSpace(key)
+	}
+	return ""
+}
+
+// Middleware scope 범위의 키를 요구하는 HTTP 미들웨어 (빈 scope는 인증만)
+// 키가 없거나 잘못되면 401, 범위 밖이면 403으로 거부하고, 통과하면 Principal을 컨텍스트에 담습니다
+func (s *Service) Middleware(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := KeyFromRequest(r)
+			if key == "" {
+				sendError(w, http.StatusUnauthorized, "api key is required")
+				return
+			}
+			principal, err := s.Authorize(r.Context(), key, scope)
+			if err != nil {
+				sendError(w, httpStatusForAuthError(err), err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// UnaryServerInterceptor x-api-key 메타데이터로 단항 gRPC 호출을 인증합니다
+// scopeFor는 전체 메서드 이름(/package.Service/Method)에 필요한 범위를 반환합니다 (nil이거나 빈 범위는 인증만)
+func (s *Service) UnaryServerInterceptor(scopeFor func(fullMethod string) string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authorizeRPC(ctx, info.FullMethod, scopeFor)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor x-api-key 메타데이터로 스트리밍 gRPC 호출을 인증합니다
+func (s *Service) StreamServerInterceptor(scopeFor func(fullMethod string) string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authorizeRPC(ss.Context(), info.FullMethod, scopeFor)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (s *Service) authorizeRPC(ctx context.Context, fullMethod string, scopeFor func(string) string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(MetadataName)
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "api key is required")
+	}
+	scope := ""
+	if scopeFor != nil {
+		scope = scopeFor(fullMethod)
+	}
+	principal, err := s.Authorize(ctx, keys[0], scope)
+	if err != nil {
+		return nil, status.Error(grpcCodeForAuthError(err), err.Error())
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// principalStream 인증된 컨텍스트를 돌려주는 서버 스트림
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+func httpStatusForAuthError(err error) int {
+	switch {
+	case errors.Is(err, ErrScopeDenied):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidApiKey), errors.Is(err, ErrApiKeyRevoked), errors.Is(err, ErrApiKeyExpired):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func grpcCodeForAuthError(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrScopeDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrInvalidApiKey), errors.Is(err, ErrApiKeyRevoked), errors.Is(err, ErrApiKeyExpired):
+		return codes.Unauthenticated
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,458 @@
+package apikey
+
+import (
+	"context"
+	"cqrs"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// KeyPrefix 발급된 키의 접두사 (로그, 비밀 스캐너에서 식별용)
+const KeyPrefix = "dak_"
+
+// API 키 명령 타입
+const (
+	IssueApiKeyCommandType  = "IssueApiKey"
+	RotateApiKeyCommandType = "RotateApiKey"
+	RevokeApiKeyCommandType = "RevokeApiKey"
+)
+
+// API 키 명령 데이터 (명령의 UserID는 키를 관리하는 관리자)
+type (
+	IssueApiKeyData struct {
+		Service    string   `json:"service"`
+		Scopes     []string `json:"scopes"`
+		TTLSeconds int64    `json:"ttl_seconds,omitempty"` // 0이면 만료 없음
+	}
+	RotateApiKeyData struct {
+		GraceSeconds int64 `json:"grace_seconds,omitempty"` // 이전 키를 계속 허용할 기간
+	}
+	RevokeApiKeyData struct {
+		Reason string `json:"reason"`
+	}
+)
+
+// NewIssueApiKeyCommand 새 키 발급 명령 (키 ID는 명령의 ID로 생성됩니다)
+func NewIssueApiKeyCommand(service string, scopes []string, issuedBy string, ttl time.Duration) cqrs.Command {
+	return newApiKeyCommand(IssueApiKeyCommandType, newKeyID(), issuedBy, IssueApiKeyData{
+		Service:    service,
+		Scopes:     scopes,
+		TTLSeconds: int64(ttl / time.Second),
+	})
+}
+
+// NewRotateApiKeyCommand 키 교체 명령
+func NewRotateApiKeyCommand(keyID, rotatedBy string, grace time.Duration) cqrs.Command {
+	return newApiKeyCommand(RotateApiKeyCommandType, keyID, rotatedBy, RotateApiKeyData{GraceSeconds: int64(grace / time.Second)})
+}
+
+// NewRevokeApiKeyCommand 키 폐기 명령
+func NewRevokeApiKeyCommand(keyID, revokedBy, reason string) cqrs.Command {
+	return newApiKeyCommand(RevokeApiKeyCommandType, keyID, revokedBy, RevokeApiKeyData{Reason: reason})
+}
+
+func newApiKeyCommand(commandType, keyID, issuedBy string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, keyID, ApiKeyAggregateType, data)
+	command.SetUserID(issuedBy)
+	return command
+}
+
+// ApiKeyView 키 상태 (비밀 값과 해시는 포함하지 않음)
+type ApiKeyView struct {
+	KeyID        string    `json:"key_id"`
+	Service      string    `json:"service"`
+	Scopes       []string  `json:"scopes"`
+	IssuedBy     string    `json:"issued_by"`
+	IssuedAt     time.Time `json:"issued_at"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	RotatedAt    time.Time `json:"rotated_at,omitempty"`
+	Revoked      bool      `json:"revoked"`
+	RevokedAt    time.Time `json:"revoked_at,omitempty"`
+	RevokeReason string    `json:"revoke_reason,omitempty"`
+}
+
+// IssuedApiKey 발급 또는 교체 결과. Key는 이때 한 번만 확인할 수 있습니다
+type IssuedApiKey struct {
+	ApiKeyView
+	Key string `json:"key"`
+}
+
+// KeyUsage 키 사용량 메트릭 (프로세스 시작 이후 누적)
+type KeyUsage struct {
+	KeyID      string           `json:"key_id"`
+	Service    string           `json:"service"`
+	Requests   int64            `json:"requests"` // 인증과 권한 확인을 통과한 호출
+	Rejected   int64            `json:"rejected"` // 잘못된 비밀 값, 폐기, 만료, 범위 밖 호출
+	LastUsedAt time.Time        `json:"last_used_at,omitempty"`
+	Scopes     map[string]int64 `json:"scopes,omitempty"` // 범위별 허용 호출 수
+}
+
+// DefaultCacheTTL 캐시한 키를 저장소에서 다시 읽기 전까지의 기본 시간
+const DefaultCacheTTL = 30 * time.Second
+
+// ServiceConfig API 키 서비스 설정
+type ServiceConfig struct {
+	Store    eventstore.EventStore // 필수: API 키 이벤트 저장소
+	EventBus cqrs.EventBus         // 선택: API 키 이벤트 발행, 다른 인스턴스의 교체/폐기 구독
+	CacheTTL time.Duration         // 캐시한 키를 저장소에서 다시 읽는 주기 (기본값: 30초)
+	Now      func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service API 키 명령을 처리하고 서비스 간 호출을 인증합니다
+// 발급된 키는 CacheTTL 동안 메모리에 캐시하므로 인증은 저장소를 매번 읽지 않습니다
+// 다른 인스턴스에서 교체하거나 폐기한 키는 Subscribe로 받은 이벤트로 즉시, 이벤트를 받지 못해도 CacheTTL 안에 반영됩니다
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu     sync.Mutex // 키 변경 직렬화
+	cacheM sync.RWMutex
+	cache  map[string]cachedKey // 키 ID -> 발급된 키
+
+	usageM sync.Mutex
+	usage  map[string]*KeyUsage
+}
+
+// cachedKey 캐시한 키 (캐시된 애그리게이트는 변경하지 않음)
+type cachedKey struct {
+	aggregate *ApiKeyAggregate
+	loadedAt  time.Time
+}
+
+// NewService 새로운 Service를 생성합니다
+// 발급한 키가 재시작 후에도 남아야 하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("apikey: %w", ErrStoreRequired)
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultCacheTTL
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("apikey", []string{IssueApiKeyCommandType, RotateApiKeyCommandType, RevokeApiKeyCommandType}),
+		config:             config,
+		cache:              make(map[string]cachedKey),
+		usage:              make(map[string]*KeyUsage),
+	}, nil
+}
+
+// RegisterWith API 키 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle API 키 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+// 발급과 교체 결과 데이터는 평문 키를 담은 IssuedApiKey, 폐기 결과는 ApiKeyView입니다
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	keyID, issuedBy := command.ID(), command.UserID()
+	if keyID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: key ID is required", ErrInvalidCommand)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	var secret string
+	switch command.CommandType() {
+	case IssueApiKeyCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[IssueApiKeyData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			var expiresAt time.Time
+			if data.TTLSeconds > 0 {
+				expiresAt = now.Add(time.Duration(data.TTLSeconds) * time.Second)
+			}
+			secret = newSecret()
+			err = aggregate.Issue(data.Service, data.Scopes, HashSecret(secret), issuedBy, expiresAt, now)
+		}
+	case RotateApiKeyCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[RotateApiKeyData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			secret = newSecret()
+			err = aggregate.Rotate(HashSecret(secret), issuedBy, time.Duration(data.GraceSeconds)*time.Second, now)
+		}
+	case RevokeApiKeyCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[RevokeApiKeyData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			err = aggregate.Revoke(data.Reason, issuedBy, now)
+		}
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidCommand, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	log.Printf("[ApiKey] %s applied to %s (%s) by %s", command.CommandType(), keyID, aggregate.Service(), issuedBy)
+	result := cqrs.NewCommandResult(keyID, aggregate.Version(), events...)
+	if secret != "" {
+		result.WithData(IssuedApiKey{ApiKeyView: aggregate.View(), Key: FormatKey(keyID, secret)})
+	} else {
+		result.WithData(aggregate.View())
+	}
+	return result, nil
+}
+
+// Key 키 상태를 조회합니다
+func (s *Service) Key(ctx context.Context, keyID string) (ApiKeyView, error) {
+	aggregate, err := s.lookup(ctx, keyID)
+	if err != nil {
+		return ApiKeyView{}, err
+	}
+	if aggregate == nil {
+		return ApiKeyView{}, ErrInvalidApiKey
+	}
+	return aggregate.View(), nil
+}
+
+// Authenticate 평문 키를 확인하고 호출한 서비스를 반환합니다
+func (s *Service) Authenticate(ctx context.Context, key string) (Principal, error) {
+	return s.Authorize(ctx, key, "")
+}
+
+// Authorize 평문 키를 확인하고 scope 범위의 호출을 허용하는지 확인합니다 (빈 scope는 인증만)
+// 결과는 키 사용량 메트릭에 기록됩니다
+func (s *Service) Authorize(ctx context.Context, key, scope string) (Principal, error) {
+	keyID, secret, err := ParseKey(key)
+	if err != nil {
+		return Principal{}, err
+	}
+	aggregate, err := s.lookup(ctx, keyID)
+	if err != nil {
+		return Principal{}, err
+	}
+	if aggregate == nil {
+		return Principal{}, ErrInvalidApiKey
+	}
+
+	now := s.config.Now()
+	if err := aggregate.Verify(secret, now); err != nil {
+		s.recordUsage(aggregate, scope, false, now)
+		return Principal{}, err
+	}
+	if scope != "" && !aggregate.Allows(scope) {
+		s.recordUsage(aggregate, scope, false, now)
+		return Principal{}, fmt.Errorf("%w: %s may not call %s", ErrScopeDenied, aggregate.Service(), scope)
+	}
+	s.recordUsage(aggregate, scope, true, now)
+	return Principal{KeyID: keyID, Service: aggregate.Service(), Scopes: aggregate.Scopes()}, nil
+}
+
+// Usage 키 사용량 메트릭 (사용 기록이 없으면 false)
+func (s *Service) Usage(keyID string) (KeyUsage, bool) {
+	s.usageM.Lock()
+	defer s.usageM.Unlock()
+	usage, exists := s.usage[keyID]
+	if !exists {
+		return KeyUsage{}, false
+	}
+	return copyUsage(usage), true
+}
+
+// UsageReport 모든 키의 사용량 메트릭 (호출이 많은 순)
+func (s *Service) UsageReport() []KeyUsage {
+	s.usageM.Lock()
+	report := make([]KeyUsage, 0, len(s.usage))
+	for _, usage := range s.usage {
+		report = append(report, copyUsage(usage))
+	}
+	s.usageM.Unlock()
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Requests != report[j].Requests {
+			return report[i].Requests > report[j].Requests
+		}
+		return report[i].KeyID < report[j].KeyID
+	})
+	return report
+}
+
+func (s *Service) recordUsage(aggregate *ApiKeyAggregate, scope string, allowed bool, now time.Time) {
+	s.usageM.Lock()
+	defer s.usageM.Unlock()
+	usage, exists := s.usage[aggregate.ID()]
+	if !exists {
+		usage = &KeyUsage{KeyID: aggregate.ID(), Service: aggregate.Service(), Scopes: make(map[string]int64)}
+		s.usage[aggregate.ID()] = usage
+	}
+	if !allowed {
+		usage.Rejected++
+		return
+	}
+	usage.Requests++
+	usage.LastUsedAt = now
+	if scope != "" {
+		usage.Scopes[scope]++
+	}
+}
+
+func copyUsage(usage *KeyUsage) KeyUsage {
+	copied := *usage
+	copied.Scopes = make(map[string]int64, len(usage.Scopes))
+	for scope, count := range usage.Scopes {
+		copied.Scopes[scope] = count
+	}
+	return copied
+}
+
+// lookup 캐시에서 키를 찾고, 없거나 CacheTTL이 지났으면 저장소에서 읽습니다 (발급되지 않은 키는 nil)
+// 존재하지 않는 키 ID는 캐시하지 않아 임의의 키로 캐시가 커지지 않습니다
+// 저장소 조회는 키 변경 잠금 밖에서 하므로 모르는 키 ID가 다른 인증과 키 변경을 막지 않습니다
+func (s *Service) lookup(ctx context.Context, keyID string) (*ApiKeyAggregate, error) {
+	s.cacheM.RLock()
+	cached, known := s.cache[keyID]
+	s.cacheM.RUnlock()
+	if known && s.config.Now().Sub(cached.loadedAt) < s.config.CacheTTL {
+		return cached.aggregate, nil
+	}
+
+	aggregate, err := s.load(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !aggregate.Issued() {
+		return nil, nil
+	}
+	return s.remember(aggregate), nil
+}
+
+func (s *Service) load(ctx context.Context, keyID string) (*ApiKeyAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, keyID, ApiKeyAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load api key %s: %w", keyID, err)
+	}
+	aggregate := NewApiKeyAggregate(keyID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 캐시를 갱신한 뒤 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *ApiKeyAggregate) error {
+	events := aggregate.Changes()
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save api key %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+	s.remember(aggregate)
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[ApiKey] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// remember 키를 캐시하고 캐시된 키를 반환합니다
+// 동시에 읽은 오래된 상태가 이미 캐시된 새 상태를 덮지 않도록 버전이 낮으면 캐시된 키를 유지합니다
+func (s *Service) remember(aggregate *ApiKeyAggregate) *ApiKeyAggregate {
+	s.cacheM.Lock()
+	defer s.cacheM.Unlock()
+	if cached, known := s.cache[aggregate.ID()]; known && cached.aggregate.Version() > aggregate.Version() {
+		return cached.aggregate
+	}
+	s.cache[aggregate.ID()] = cachedKey{aggregate: aggregate, loadedAt: s.config.Now()}
+	return aggregate
+}
+
+// forget 캐시된 키가 version보다 오래되었으면 캐시에서 지웁니다
+func (s *Service) forget(keyID string, version int) {
+	s.cacheM.Lock()
+	defer s.cacheM.Unlock()
+	if cached, known := s.cache[keyID]; known && cached.aggregate.Version() < version {
+		delete(s.cache, keyID)
+	}
+}
+
+// Subscribe 다른 인스턴스에서 교체하거나 폐기한 키를 캐시에서 지우도록 이벤트 버스를 구독합니다
+// 인스턴스 사이에 이벤트를 전달하는 버스여야 다른 인스턴스의 변경이 즉시 반영됩니다
+func (s *Service) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	invalidator := &cacheInvalidator{
+		BaseEventHandler: cqrs.NewBaseEventHandler("apikey-cache", cqrs.NotificationHandler, []string{ApiKeyRotatedEventType, ApiKeyRevokedEventType}),
+		service:          s,
+	}
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(invalidator.GetSupportedEventTypes()))
+	for _, eventType := range invalidator.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, invalidator)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// cacheInvalidator 키 교체/폐기 이벤트로 캐시를 지우는 이벤트 핸들러
+type cacheInvalidator struct {
+	*cqrs.BaseEventHandler
+	service *Service
+}
+
+func (h *cacheInvalidator) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	h.service.forget(event.AggregateID(), event.Version())
+	return nil
+}
+
+// FormatKey 키 ID와 비밀 값으로 평문 키를 만듭니다 (dak_<키 ID>.<비밀 값>)
+func FormatKey(keyID, secret string) string {
+	return KeyPrefix + keyID + "." + secret
+}
+
+// ParseKey 평문 키를 키 ID와 비밀 값으로 나눕니다
+func ParseKey(key string) (keyID, secret string, err error) {
+	rest, ok := strings.CutPrefix(key, KeyPrefix)
+	if !ok {
+		return "", "", ErrInvalidApiKey
+	}
+	keyID, secret, ok = strings.Cut(rest, ".")
+	if !ok || keyID == "" || secret == "" {
+		return "", "", ErrInvalidApiKey
+	}
+	return keyID, secret, nil
+}
+
+func newKeyID() string {
+	return hex.EncodeToString(randomBytes(8))
+}
+
+func newSecret() string {
+	return base64.RawURLEncoding.EncodeToString(randomBytes(32))
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("apikey: failed to read random bytes: %v", err))
+	}
+	return b
+}
@@ -0,0 +1,138 @@
+package asset
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 에셋 서버 기본 경로
+const DefaultBasePath = "/assets"
+
+// maxRequestBodySize 요청 본문 최대 크기 (업로드 URL 요청용, 에셋 바이트는 저장소로 직접 전송)
+const maxRequestBodySize = 16 << 10
+
+// Config 에셋 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /assets)
+	Service  ServiceConfig                   // 에셋 서비스 설정
+	Auth     func(http.Handler) http.Handler // 선택: 업로드 URL 발급 인증 미들웨어
+}
+
+// AssetApp 길드 엠블럼 같은 사용자 에셋의 업로드 URL을 발급하는 서버앱
+// LocalBlobStore를 사용하면 서명된 업로드(PUT /upload)와 내려받기(GET /files/)도 처리합니다
+type AssetApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewAssetApp 새로운 AssetApp을 생성합니다
+func NewAssetApp(config Config) (*AssetApp, error) {
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	return &AssetApp{
+		BaseApp: serverapp.NewBaseApp("asset"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 에셋 서비스
+func (a *AssetApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *AssetApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/uploads", protect(http.HandlerFunc(a.requestUpload)))
+
+	// 업로드 URL 자체가 서명되어 있으므로 인증 미들웨어를 거치지 않습니다
+	if local, ok := a.service.Store().(*LocalBlobStore); ok {
+		mux.HandleFunc(base+"/upload", local.ServeUpload)
+		mux.HandleFunc(base+"/files/", func(w http.ResponseWriter, r *http.Request) {
+			local.ServeFile(w, r, strings.TrimPrefix(r.URL.Path, base+"/files/"))
+		})
+	}
+
+	log.Printf("[Asset] Routes registered under %s", base)
+}
+
+// DescribeAPI 에셋 엔드포인트 설명 (/openapi.json)
+func (a *AssetApp) DescribeAPI() []serverapp.APIOperation {
+	return []serverapp.APIOperation{
+		{
+			Method:      http.MethodPost,
+			Path:        a.config.BasePath + "/uploads",
+			Summary:     "에셋 업로드 URL 발급",
+			Description: "응답의 upload.url로 upload.headers를 붙여 직접 업로드한 뒤, object_key를 도메인 명령(예: UpdateGuildEmblem)에 전달합니다.",
+			Request:     UploadRequest{},
+			Response:    UploadTicket{},
+			Secured:     a.config.Auth != nil,
+		},
+	}
+}
+
+func (a *AssetApp) requestUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var request UploadRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	ticket, err := a.service.RequestUpload(r.Context(), request)
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, ticket)
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrUnknownKind), errors.Is(err, ErrInvalidUpload), errors.Is(err, ErrContentTypeNotAllowed):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrAssetTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrAssetNotUploaded):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
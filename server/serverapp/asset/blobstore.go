@@ -0,0 +1,428 @@
+package asset
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrObjectNotFound 저장소에 객체가 없음
+var ErrObjectNotFound = errors.New("object not found")
+
+// UploadTarget 클라이언트가 객체를 직접 올릴 서명된 업로드 대상
+type UploadTarget struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"` // 업로드 요청에 그대로 실어야 하는 헤더
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ObjectInfo 저장된 객체 정보
+type ObjectInfo struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+}
+
+// BlobStore 에셋 바이트를 보관하는 저장소 (로컬 디스크, S3)
+// 서버는 업로드 URL만 발급하고, 바이트는 클라이언트가 저장소로 직접 올립니다
+type BlobStore interface {
+	// PresignUpload contentType과 size가 고정된 업로드 URL을 발급합니다
+	PresignUpload(ctx context.Context, key, contentType string, size int64, expires time.Duration) (UploadTarget, error)
+	// Stat 업로드된 객체 정보를 반환합니다 (없으면 ErrObjectNotFound)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// URL 객체를 내려받을 공개 URL
+	URL(key string) string
+	// Delete 객체를 삭제합니다 (없어도 에러가 아님)
+	Delete(ctx context.Context, key string) error
+}
+
+// validObjectKey 경로 조작이 불가능한 키인지 확인합니다
+func validObjectKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || len(key) > 512 {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// LocalBlobStore 로컬 디렉터리에 객체를 저장하는 BlobStore (개발, 단일 서버용)
+// 업로드 URL은 HMAC으로 서명되며 AssetApp이 BaseURL 아래 /upload와 /files/ 라우트로 처리합니다
+type LocalBlobStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocalBlobStore 새로운 LocalBlobStore를 생성합니다
+// baseURL은 AssetApp이 등록된 외부 주소입니다 (예: http://localhost:8080/assets)
+func NewLocalBlobStore(dir, baseURL string, secret []byte) (*LocalBlobStore, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("local blob store requires a signing secret")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &LocalBlobStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), secret: secret, now: time.Now}, nil
+}
+
+func (s *LocalBlobStore) PresignUpload(ctx context.Context, key, contentType string, size int64, expires time.Duration) (UploadTarget, error) {
+	if !validObjectKey(key) {
+		return UploadTarget{}, fmt.Errorf("invalid object key %q", key)
+	}
+	expiresAt := s.now().Add(expires).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("content_type", contentType)
+	query.Set("size", strconv.FormatInt(size, 10))
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.sign(key, contentType, size, expiresAt.Unix()))
+	return UploadTarget{
+		URL:       s.baseURL + "/upload?" + query.Encode(),
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *LocalBlobStore) sign(key, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%d", key, contentType, size, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeUpload 서명된 업로드 URL로 들어온 PUT 요청을 저장합니다
+// 서명, 만료, Content-Type과 크기가 발급 당시와 다르면 거부합니다
+func (s *LocalBlobStore) ServeUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	key, contentType := query.Get("key"), query.Get("content_type")
+	size, sizeErr := strconv.ParseInt(query.Get("size"), 10, 64)
+	expires, expiresErr := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if sizeErr != nil || expiresErr != nil || !validObjectKey(key) ||
+		!hmac.Equal([]byte(query.Get("signature")), []byte(s.sign(key, contentType, size, expires))) {
+		sendError(w, http.StatusForbidden, "invalid upload signature")
+		return
+	}
+	if s.now().Unix() > expires {
+		sendError(w, http.StatusForbidden, "upload url expired")
+		return
+	}
+	if r.Header.Get("Content-Type") != contentType {
+		sendError(w, http.StatusBadRequest, "content type does not match the upload url")
+		return
+	}
+	if r.ContentLength > size {
+		sendError(w, http.StatusRequestEntityTooLarge, "object is larger than the upload url allows")
+		return
+	}
+
+	if err := s.write(key, http.MaxBytesReader(w, r.Body, size)); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			sendError(w, http.StatusRequestEntityTooLarge, "object is larger than the upload url allows")
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "failed to store object")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// write 임시 파일에 쓴 뒤 이름을 바꿔 반쯤 쓰인 객체가 보이지 않게 합니다
+func (s *LocalBlobStore) write(key string, body io.Reader) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ServeFile 저장된 객체를 내려줍니다 (디렉터리 목록은 제공하지 않음)
+func (s *LocalBlobStore) ServeFile(w http.ResponseWriter, r *http.Request, key string) {
+	if !validObjectKey(key) {
+		http.NotFound(w, r)
+		return
+	}
+	info, err := os.Stat(s.path(key))
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeFile(w, r, s.path(key))
+}
+
+// Stat 파일 크기와 내용으로 판별한 Content-Type을 반환합니다
+// 업로드 헤더가 아닌 실제 바이트로 판별하므로 확장자만 바꾼 파일을 걸러냅니다
+func (s *LocalBlobStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if !validObjectKey(key) {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	file, err := os.Open(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Key: key, Size: info.Size(), ContentType: http.DetectContentType(head[:n])}, nil
+}
+
+func (s *LocalBlobStore) URL(key string) string {
+	return s.baseURL + "/files/" + key
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	if !validObjectKey(key) {
+		return nil
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// S3Config S3 호환 저장소 설정
+type S3Config struct {
+	Endpoint        string       // 버킷 기본 URL (예: https://bucket.s3.ap-northeast-2.amazonaws.com, http://minio:9000/bucket)
+	Region          string       // 서명 리전 (예: ap-northeast-2)
+	AccessKeyID     string       // 접근 키
+	SecretAccessKey string       // 비밀 키
+	PublicURL       string       // 선택: 내려받기용 CDN 주소 (없으면 Endpoint)
+	Client          *http.Client // 선택: Stat/Delete 요청에 사용할 클라이언트
+}
+
+// S3BlobStore S3(또는 MinIO 등 호환 저장소)에 객체를 저장하는 BlobStore
+// AWS Signature V4 쿼리 서명으로 업로드 URL을 발급하므로 SDK 없이 동작합니다
+type S3BlobStore struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3BlobStore 새로운 S3BlobStore를 생성합니다
+func NewS3BlobStore(config S3Config) (*S3BlobStore, error) {
+	if config.Endpoint == "" || config.Region == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("s3 blob store requires endpoint, region and credentials")
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	config.PublicURL = strings.TrimSuffix(config.PublicURL, "/")
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &S3BlobStore{config: config, client: client, now: time.Now}, nil
+}
+
+func (s *S3BlobStore) PresignUpload(ctx context.Context, key, contentType string, size int64, expires time.Duration) (UploadTarget, error) {
+	if !validObjectKey(key) {
+		return UploadTarget{}, fmt.Errorf("invalid object key %q", key)
+	}
+	// Content-Type과 Content-Length를 서명에 포함해 다른 형식이나 크기로 올리면 S3가 거부합니다
+	headers := map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": strconv.FormatInt(size, 10),
+	}
+	signedAt := s.now().UTC()
+	return UploadTarget{
+		URL:       s.presign(http.MethodPut, key, signedAt, expires, headers),
+		Method:    http.MethodPut,
+		Headers:   headers,
+		ExpiresAt: signedAt.Add(expires).Truncate(time.Second),
+	}, nil
+}
+
+func (s *S3BlobStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if !validObjectKey(key) {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	response, err := s.do(ctx, http.MethodHead, key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return ObjectInfo{}, ErrObjectNotFound
+	case response.StatusCode != http.StatusOK:
+		return ObjectInfo{}, fmt.Errorf("s3 head %s: unexpected status %d", key, response.StatusCode)
+	}
+	return ObjectInfo{Key: key, Size: response.ContentLength, ContentType: response.Header.Get("Content-Type")}, nil
+}
+
+func (s *S3BlobStore) URL(key string) string {
+	if s.config.PublicURL != "" {
+		return s.config.PublicURL + "/" + key
+	}
+	return s.config.Endpoint + "/" + key
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	if !validObjectKey(key) {
+		return nil
+	}
+	response, err := s.do(ctx, http.MethodDelete, key)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("s3 delete %s: unexpected status %d", key, response.StatusCode)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) do(ctx context.Context, method, key string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, s.presign(method, key, s.now().UTC(), time.Minute, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(request)
+}
+
+// presign AWS Signature V4 쿼리 서명 URL을 만듭니다 (페이로드는 서명하지 않음)
+func (s *S3BlobStore) presign(method, key string, signedAt time.Time, expires time.Duration, headers map[string]string) string {
+	endpoint, _ := url.Parse(s.config.Endpoint)
+	amzDate := signedAt.Format("20060102T150405Z")
+	date := signedAt.Format("20060102")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+
+	canonicalHeaders := map[string]string{"host": endpoint.Host}
+	for name, value := range headers {
+		canonicalHeaders[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	names := make([]string, 0, len(canonicalHeaders))
+	for name := range canonicalHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var headerBlock strings.Builder
+	for _, name := range names {
+		headerBlock.WriteString(name + ":" + canonicalHeaders[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.config.AccessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expires / time.Second)),
+		"X-Amz-SignedHeaders": signedHeaders,
+	}
+	canonicalQuery := canonicalQueryString(query)
+	path := endpoint.EscapedPath() + "/" + uriEncode(key, false)
+
+	canonicalRequest := strings.Join([]string{
+		method, path, canonicalQuery, headerBlock.String(), signedHeaders, "UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return endpoint.Scheme + "://" + endpoint.Host + path + "?" + canonicalQuery + "&X-Amz-Signature=" + signature
+}
+
+func canonicalQueryString(query map[string]string) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(query[name], true))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode SigV4 규칙의 URI 인코딩 (비예약 문자만 그대로 둠)
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
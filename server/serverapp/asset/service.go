@@ -0,0 +1,211 @@
+package asset
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// 에셋 종류
+const (
+	KindGuildEmblem = "guild_emblem"
+)
+
+// 에셋 에러
+var (
+	ErrUnknownKind           = errors.New("unknown asset kind")
+	ErrInvalidUpload         = errors.New("invalid upload request")
+	ErrContentTypeNotAllowed = errors.New("content type is not allowed")
+	ErrAssetTooLarge         = errors.New("asset is too large")
+	ErrAssetNotUploaded      = errors.New("asset has not been uploaded")
+)
+
+// Policy 에셋 종류별 업로드 제한
+type Policy struct {
+	MaxSize      int64    // 최대 바이트 수
+	ContentTypes []string // 허용하는 Content-Type
+}
+
+func (p Policy) allows(contentType string) bool {
+	for _, allowed := range p.ContentTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPolicies 기본 에셋 정책
+func DefaultPolicies() map[string]Policy {
+	return map[string]Policy{
+		KindGuildEmblem: {MaxSize: 256 << 10, ContentTypes: []string{"image/png", "image/webp"}},
+	}
+}
+
+// extensions 객체 키에 붙일 확장자
+var extensions = map[string]string{
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+}
+
+// ServiceConfig 에셋 서비스 설정
+type ServiceConfig struct {
+	Store     BlobStore         // 필수: 객체 저장소
+	Policies  map[string]Policy // 선택: 종류별 정책 (기본값: DefaultPolicies)
+	UploadTTL time.Duration     // 선택: 업로드 URL 유효 시간 (기본값: 15분)
+}
+
+// UploadRequest 업로드 URL 요청
+type UploadRequest struct {
+	Kind        string `json:"kind"`
+	OwnerID     string `json:"owner_id"` // 에셋을 소유하는 애그리게잇 ID (예: 길드 ID)
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// UploadTicket 발급된 업로드 URL과 업로드 후 애그리게잇에 연결할 객체 키
+type UploadTicket struct {
+	AssetID   string       `json:"asset_id"`
+	ObjectKey string       `json:"object_key"`
+	Upload    UploadTarget `json:"upload"`
+}
+
+// StoredAsset 검증을 마친 업로드 객체
+type StoredAsset struct {
+	ObjectKey   string `json:"object_key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+// Service 업로드 URL을 발급하고 업로드된 객체를 검증하는 에셋 서비스
+// 객체를 애그리게잇에 연결하는 것은 각 도메인 명령(예: 길드 엠블럼 변경)이 Verify 결과로 수행합니다
+type Service struct {
+	store     BlobStore
+	policies  map[string]Policy
+	uploadTTL time.Duration
+}
+
+// NewService 새로운 에셋 서비스를 생성합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, errors.New("asset blob store is required")
+	}
+	if config.Policies == nil {
+		config.Policies = DefaultPolicies()
+	}
+	if config.UploadTTL <= 0 {
+		config.UploadTTL = 15 * time.Minute
+	}
+	return &Service{store: config.Store, policies: config.Policies, uploadTTL: config.UploadTTL}, nil
+}
+
+// Store 객체 저장소
+func (s *Service) Store() BlobStore {
+	return s.store
+}
+
+// RequestUpload 정책을 확인하고 업로드 URL을 발급합니다
+// 객체 키는 kind/owner/assetID.ext 형식이라 다른 소유자의 에셋과 섞이지 않습니다
+func (s *Service) RequestUpload(ctx context.Context, request UploadRequest) (UploadTicket, error) {
+	policy, exists := s.policies[request.Kind]
+	if !exists {
+		return UploadTicket{}, fmt.Errorf("%w: %q", ErrUnknownKind, request.Kind)
+	}
+	if !validOwnerID(request.OwnerID) {
+		return UploadTicket{}, fmt.Errorf("%w: owner_id is required and may only contain letters, digits, '-' and '_'", ErrInvalidUpload)
+	}
+	if request.Size <= 0 {
+		return UploadTicket{}, fmt.Errorf("%w: size must be positive", ErrInvalidUpload)
+	}
+	if request.Size > policy.MaxSize {
+		return UploadTicket{}, fmt.Errorf("%w: %d bytes exceeds %d", ErrAssetTooLarge, request.Size, policy.MaxSize)
+	}
+	if !policy.allows(request.ContentType) {
+		return UploadTicket{}, fmt.Errorf("%w: %q", ErrContentTypeNotAllowed, request.ContentType)
+	}
+
+	assetID := newAssetID()
+	key := ownerPrefix(request.Kind, request.OwnerID) + assetID + extensions[request.ContentType]
+	target, err := s.store.PresignUpload(ctx, key, request.ContentType, request.Size, s.uploadTTL)
+	if err != nil {
+		return UploadTicket{}, fmt.Errorf("failed to presign upload: %w", err)
+	}
+	return UploadTicket{AssetID: assetID, ObjectKey: key, Upload: target}, nil
+}
+
+// Verify 업로드된 객체가 소유자와 정책에 맞는지 확인합니다
+// 정책을 어긴 객체는 저장소에서 삭제합니다
+func (s *Service) Verify(ctx context.Context, kind, ownerID, objectKey string) (StoredAsset, error) {
+	policy, exists := s.policies[kind]
+	if !exists {
+		return StoredAsset{}, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+	if !validOwnerID(ownerID) || !validObjectKey(objectKey) || !strings.HasPrefix(objectKey, ownerPrefix(kind, ownerID)) {
+		return StoredAsset{}, fmt.Errorf("%w: object %q does not belong to %s", ErrInvalidUpload, objectKey, ownerID)
+	}
+
+	info, err := s.store.Stat(ctx, objectKey)
+	if errors.Is(err, ErrObjectNotFound) {
+		return StoredAsset{}, fmt.Errorf("%w: %s", ErrAssetNotUploaded, objectKey)
+	}
+	if err != nil {
+		return StoredAsset{}, fmt.Errorf("failed to stat asset: %w", err)
+	}
+
+	var violation error
+	switch {
+	case info.Size <= 0:
+		violation = fmt.Errorf("%w: %s is empty", ErrInvalidUpload, objectKey)
+	case info.Size > policy.MaxSize:
+		violation = fmt.Errorf("%w: %d bytes exceeds %d", ErrAssetTooLarge, info.Size, policy.MaxSize)
+	case !policy.allows(info.ContentType):
+		violation = fmt.Errorf("%w: %q", ErrContentTypeNotAllowed, info.ContentType)
+	}
+	if violation != nil {
+		if err := s.store.Delete(ctx, objectKey); err != nil {
+			log.Printf("[Asset] Failed to delete rejected object %s: %v", objectKey, err)
+		}
+		return StoredAsset{}, violation
+	}
+
+	return StoredAsset{
+		ObjectKey:   objectKey,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		URL:         s.store.URL(objectKey),
+	}, nil
+}
+
+func ownerPrefix(kind, ownerID string) string {
+	return kind + "/" + ownerID + "/"
+}
+
+func validOwnerID(ownerID string) bool {
+	if ownerID == "" || len(ownerID) > 128 {
+		return false
+	}
+	for _, r := range ownerID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newAssetID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("asset: failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
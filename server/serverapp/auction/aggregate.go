@@ -0,0 +1,404 @@
+package auction
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// AuctionAggregateType 경매 애그리게이트 타입 (애그리게이트 ID는 경매 ID)
+const AuctionAggregateType = "Auction"
+
+// 경매 상태
+const (
+	StatusActive    = "active"    // 입찰을 받는 중
+	StatusSold      = "sold"      // 즉시 구매 또는 최고 입찰자에게 낙찰
+	StatusExpired   = "expired"   // 입찰 없이 종료, 아이템은 판매자에게 돌아감
+	StatusCancelled = "cancelled" // 입찰 전에 판매자가 취소
+)
+
+// 경매 이벤트 타입
+// 아이템과 대금 이전은 AuctionSettled 하나만 구독하면 됩니다 (즉시 구매도 AuctionBoughtOut 다음에 AuctionSettled를 발생)
+const (
+	AuctionListedEventType    = "AuctionListed"
+	BidPlacedEventType        = "BidPlaced"
+	AuctionExtendedEventType  = "AuctionExtended"
+	AuctionBoughtOutEventType = "AuctionBoughtOut"
+	AuctionSettledEventType   = "AuctionSettled"
+	AuctionCancelledEventType = "AuctionCancelled"
+)
+
+var (
+	ErrInvalidAuction    = errors.New("invalid auction")
+	ErrAuctionNotFound   = errors.New("auction not found")
+	ErrAuctionClosed     = errors.New("auction is already closed")
+	ErrAuctionEnded      = errors.New("auction has ended")
+	ErrAuctionNotEnded   = errors.New("auction has not ended yet")
+	ErrBidTooLow         = errors.New("bid is below the minimum bid")
+	ErrSellerCannotBid   = errors.New("seller cannot bid on their own auction")
+	ErrAlreadyHighestBid = errors.New("bidder already holds the highest bid")
+	ErrNoBuyout          = errors.New("auction has no buyout price")
+	ErrNotSeller         = errors.New("user is not the seller")
+	ErrAuctionHasBids    = errors.New("auction already has bids")
+	ErrStoreRequired     = eventstore.ErrStoreRequired
+)
+
+// Listing 판매자가 올리는 경매 조건
+type Listing struct {
+	ItemID        string        `json:"item_id"`
+	Quantity      int64         `json:"quantity"`
+	StartingPrice int64         `json:"starting_price"`
+	MinIncrement  int64         `json:"min_increment"`          // 최고 입찰가보다 최소 이만큼 높게 입찰해야 함
+	BuyoutPrice   int64         `json:"buyout_price,omitempty"` // 0이면 즉시 구매 없음
+	Duration      time.Duration `json:"duration"`
+}
+
+// Validate 경매 조건을 확인합니다
+func (l Listing) Validate() error {
+	switch {
+	case l.ItemID == "" || l.Quantity <= 0:
+		return fmt.Errorf("%w: item and a positive quantity are required", ErrInvalidAuction)
+	case l.StartingPrice <= 0 || l.MinIncrement <= 0:
+		return fmt.Errorf("%w: starting price and minimum increment must be positive", ErrInvalidAuction)
+	case l.BuyoutPrice != 0 && l.BuyoutPrice < l.StartingPrice:
+		return fmt.Errorf("%w: buyout price is below the starting price", ErrInvalidAuction)
+	case l.Duration <= 0:
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidAuction)
+	}
+	return nil
+}
+
+// SnipeProtection 종료 직전 입찰(스나이핑) 방지 설정
+// 종료까지 Window보다 적게 남았을 때 입찰이 들어오면 종료 시각을 입찰 시각 + Extension으로 늦춥니다
+type SnipeProtection struct {
+	Window    time.Duration `json:"window"`
+	Extension time.Duration `json:"extension"`
+}
+
+// 경매 이벤트 데이터
+type (
+	AuctionListedData struct {
+		SellerID   string          `json:"seller_id"`
+		Listing    Listing         `json:"listing"`
+		Protection SnipeProtection `json:"protection"`
+		ListedAt   time.Time       `json:"listed_at"`
+		EndsAt     time.Time       `json:"ends_at"`
+	}
+	BidPlacedData struct {
+		BidderID         string    `json:"bidder_id"`
+		Amount           int64     `json:"amount"`
+		PreviousBidderID string    `json:"previous_bidder_id,omitempty"` // 밀려난 입찰자 (입찰금 반환, 알림 대상)
+		PreviousAmount   int64     `json:"previous_amount,omitempty"`
+		PlacedAt         time.Time `json:"placed_at"`
+	}
+	AuctionExtendedData struct {
+		PreviousEndsAt time.Time `json:"previous_ends_at"`
+		EndsAt         time.Time `json:"ends_at"`
+	}
+	AuctionBoughtOutData struct {
+		BuyerID          string    `json:"buyer_id"`
+		Price            int64     `json:"price"`
+		PreviousBidderID string    `json:"previous_bidder_id,omitempty"`
+		PreviousAmount   int64     `json:"previous_amount,omitempty"`
+		BoughtAt         time.Time `json:"bought_at"`
+	}
+	AuctionSettledData struct {
+		SellerID  string    `json:"seller_id"`
+		WinnerID  string    `json:"winner_id,omitempty"` // 비어 있으면 유찰
+		ItemID    string    `json:"item_id"`
+		Quantity  int64     `json:"quantity"`
+		Price     int64     `json:"price,omitempty"`
+		BoughtOut bool      `json:"bought_out,omitempty"`
+		SettledAt time.Time `json:"settled_at"`
+	}
+	AuctionCancelledData struct {
+		SellerID    string    `json:"seller_id"`
+		CancelledAt time.Time `json:"cancelled_at"`
+	}
+)
+
+// AuctionEvent 경매 애그리게이트 이벤트
+type AuctionEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *AuctionEvent) EventData() interface{} {
+	return e.data
+}
+
+// AuctionAggregate 아이템 하나의 경매
+// 등록 -> 입찰(스나이핑 방지 연장) -> 종료 시각에 정산 순서로 진행하며, 즉시 구매가 들어오면 바로 정산합니다
+type AuctionAggregate struct {
+	*cqrs.BaseAggregate
+	sellerID      string
+	listing       Listing
+	protection    SnipeProtection
+	status        string
+	endsAt        time.Time
+	highestBidder string
+	highestBid    int64
+	bidCount      int
+}
+
+// NewAuctionAggregate 새로운 AuctionAggregate를 생성합니다
+func NewAuctionAggregate(auctionID string) *AuctionAggregate {
+	return &AuctionAggregate{BaseAggregate: cqrs.NewBaseAggregate(auctionID, AuctionAggregateType)}
+}
+
+// Exists 경매가 등록되었는지 확인합니다
+func (a *AuctionAggregate) Exists() bool {
+	return a.status != ""
+}
+
+// Status 경매 상태
+func (a *AuctionAggregate) Status() string {
+	return a.status
+}
+
+// SellerID 판매자
+func (a *AuctionAggregate) SellerID() string {
+	return a.sellerID
+}
+
+// EndsAt 경매 종료 시각 (스나이핑 방지로 늦춰질 수 있음)
+func (a *AuctionAggregate) EndsAt() time.Time {
+	return a.endsAt
+}
+
+// HighestBid 최고 입찰자와 입찰가 (입찰이 없으면 빈 문자열, 0)
+func (a *AuctionAggregate) HighestBid() (string, int64) {
+	return a.highestBidder, a.highestBid
+}
+
+// MinimumBid 다음 입찰이 넘어야 하는 최소 금액
+func (a *AuctionAggregate) MinimumBid() int64 {
+	if a.bidCount == 0 {
+		return a.listing.StartingPrice
+	}
+	return a.highestBid + a.listing.MinIncrement
+}
+
+// List 경매를 등록합니다
+func (a *AuctionAggregate) List(sellerID string, listing Listing, protection SnipeProtection, now time.Time) error {
+	if a.Exists() {
+		return fmt.Errorf("%w: auction already exists", ErrInvalidAuction)
+	}
+	if sellerID == "" {
+		return fmt.Errorf("%w: seller is required", ErrInvalidAuction)
+	}
+	if err := listing.Validate(); err != nil {
+		return err
+	}
+	return a.raise(AuctionListedEventType, AuctionListedData{
+		SellerID:   sellerID,
+		Listing:    listing,
+		Protection: protection,
+		ListedAt:   now,
+		EndsAt:     now.Add(listing.Duration),
+	})
+}
+
+// PlaceBid 입찰합니다
+// 즉시 구매가 이상의 입찰은 즉시 구매로 처리하고, 종료 직전 입찰은 종료 시각을 늦춥니다
+func (a *AuctionAggregate) PlaceBid(bidderID string, amount int64, now time.Time) error {
+	if err := a.requireBiddable(bidderID, now); err != nil {
+		return err
+	}
+	if bidderID == a.highestBidder {
+		return ErrAlreadyHighestBid
+	}
+	if minimum := a.MinimumBid(); amount < minimum {
+		return fmt.Errorf("%w: minimum bid is %d", ErrBidTooLow, minimum)
+	}
+	if a.listing.BuyoutPrice > 0 && amount >= a.listing.BuyoutPrice {
+		return a.buyout(bidderID, now)
+	}
+
+	if err := a.raise(BidPlacedEventType, BidPlacedData{
+		BidderID:         bidderID,
+		Amount:           amount,
+		PreviousBidderID: a.highestBidder,
+		PreviousAmount:   a.highestBid,
+		PlacedAt:         now,
+	}); err != nil {
+		return err
+	}
+	if a.protection.Window <= 0 || a.endsAt.Sub(now) >= a.protection.Window {
+		return nil
+	}
+	extended := now.Add(a.protection.Extension)
+	if !extended.After(a.endsAt) {
+		return nil
+	}
+	return a.raise(AuctionExtendedEventType, AuctionExtendedData{PreviousEndsAt: a.endsAt, EndsAt: extended})
+}
+
+// Buyout 즉시 구매가로 구매하고 바로 정산합니다
+func (a *AuctionAggregate) Buyout(buyerID string, now time.Time) error {
+	if err := a.requireBiddable(buyerID, now); err != nil {
+		return err
+	}
+	if a.listing.BuyoutPrice <= 0 {
+		return ErrNoBuyout
+	}
+	return a.buyout(buyerID, now)
+}
+
+// Settle 종료 시각이 지난 경매를 최고 입찰자에게 낙찰하거나 유찰합니다
+func (a *AuctionAggregate) Settle(now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if now.Before(a.endsAt) {
+		return fmt.Errorf("%w: ends at %s", ErrAuctionNotEnded, a.endsAt.Format(time.RFC3339))
+	}
+	return a.raise(AuctionSettledEventType, AuctionSettledData{
+		SellerID:  a.sellerID,
+		WinnerID:  a.highestBidder,
+		ItemID:    a.listing.ItemID,
+		Quantity:  a.listing.Quantity,
+		Price:     a.highestBid,
+		SettledAt: now,
+	})
+}
+
+// Cancel 판매자가 입찰이 없는 경매를 취소합니다
+func (a *AuctionAggregate) Cancel(sellerID string, now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if sellerID != a.sellerID {
+		return ErrNotSeller
+	}
+	if a.bidCount > 0 {
+		return ErrAuctionHasBids
+	}
+	return a.raise(AuctionCancelledEventType, AuctionCancelledData{SellerID: a.sellerID, CancelledAt: now})
+}
+
+func (a *AuctionAggregate) buyout(buyerID string, now time.Time) error {
+	if err := a.raise(AuctionBoughtOutEventType, AuctionBoughtOutData{
+		BuyerID:          buyerID,
+		Price:            a.listing.BuyoutPrice,
+		PreviousBidderID: a.highestBidder,
+		PreviousAmount:   a.highestBid,
+		BoughtAt:         now,
+	}); err != nil {
+		return err
+	}
+	return a.raise(AuctionSettledEventType, AuctionSettledData{
+		SellerID:  a.sellerID,
+		WinnerID:  buyerID,
+		ItemID:    a.listing.ItemID,
+		Quantity:  a.listing.Quantity,
+		Price:     a.listing.BuyoutPrice,
+		BoughtOut: true,
+		SettledAt: now,
+	})
+}
+
+func (a *AuctionAggregate) requireActive() error {
+	if !a.Exists() {
+		return ErrAuctionNotFound
+	}
+	if a.status != StatusActive {
+		return fmt.Errorf("%w: auction is %s", ErrAuctionClosed, a.status)
+	}
+	return nil
+}
+
+func (a *AuctionAggregate) requireBiddable(bidderID string, now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if bidderID == "" {
+		return fmt.Errorf("%w: bidder is required", ErrInvalidAuction)
+	}
+	if bidderID == a.sellerID {
+		return ErrSellerCannotBid
+	}
+	if !now.Before(a.endsAt) {
+		return ErrAuctionEnded
+	}
+	return nil
+}
+
+// LoadFromHistory 이벤트 스트림에서 경매 상태를 복원합니다
+func (a *AuctionAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *AuctionAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := auctionEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown auction event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *AuctionAggregate) raise(eventType string, data interface{}) error {
+	event := &AuctionEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *AuctionAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case AuctionListedData:
+		a.sellerID = data.SellerID
+		a.listing = data.Listing
+		a.protection = data.Protection
+		a.endsAt = data.EndsAt
+		a.status = StatusActive
+	case BidPlacedData:
+		a.highestBidder = data.BidderID
+		a.highestBid = data.Amount
+		a.bidCount++
+	case AuctionExtendedData:
+		a.endsAt = data.EndsAt
+	case AuctionBoughtOutData:
+		a.highestBidder = data.BuyerID
+		a.highestBid = data.Price
+		a.bidCount++
+	case AuctionSettledData:
+		if data.WinnerID == "" {
+			a.status = StatusExpired
+		} else {
+			a.status = StatusSold
+		}
+	case AuctionCancelledData:
+		a.status = StatusCancelled
+	}
+}
+
+// auctionEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var auctionEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	AuctionListedEventType:    eventstore.DecodeEventData[AuctionListedData],
+	BidPlacedEventType:        eventstore.DecodeEventData[BidPlacedData],
+	AuctionExtendedEventType:  eventstore.DecodeEventData[AuctionExtendedData],
+	AuctionBoughtOutEventType: eventstore.DecodeEventData[AuctionBoughtOutData],
+	AuctionSettledEventType:   eventstore.DecodeEventData[AuctionSettledData],
+	AuctionCancelledEventType: eventstore.DecodeEventData[AuctionCancelledData],
+}
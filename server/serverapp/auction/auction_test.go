@@ -0,0 +1,192 @@
+package auction
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/defense-allies/pagit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auctionFixture 디스패처, 지연 스케줄러, 목록 프로젝션을 연결한 경매 서비스
+type auctionFixture struct {
+	clock      *testkit.Clock
+	dispatcher *cqrs.InMemoryCommandDispatcher
+	scheduler  *cqrs.DeferredScheduler
+	readStore  *cqrs.InMemoryReadStore
+}
+
+func newAuctionFixture(t *testing.T) *auctionFixture {
+	t.Helper()
+	clock := testkit.NewClock(testkit.DefaultStart.Add(12 * time.Hour))
+	dispatcher := cqrs.NewInMemoryCommandDispatcher()
+	scheduler, err := cqrs.NewDeferredScheduler(dispatcher, nil)
+	require.NoError(t, err)
+	bus := testkit.StartedEventBus(t)
+	readStore := cqrs.NewInMemoryReadStore()
+	_, err = NewListingProjection(readStore).Subscribe(bus)
+	require.NoError(t, err)
+
+	service, err := NewService(ServiceConfig{Store: eventstore.NewInMemoryEventStore(), EventBus: bus, Scheduler: scheduler, Now: clock.Now})
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterWith(dispatcher))
+	return &auctionFixture{clock: clock, dispatcher: dispatcher, scheduler: scheduler, readStore: readStore}
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 입찰 기록이 사라짐
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestAuctionAggregate_BiddingRules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	auction := NewAuctionAggregate("a1")
+	listing := Listing{ItemID: "plasma-core", Quantity: 1, StartingPrice: 100, MinIncrement: 10, BuyoutPrice: 500, Duration: time.Hour}
+
+	assert.ErrorIs(t, auction.PlaceBid("bob", 100, now), ErrAuctionNotFound)
+	assert.ErrorIs(t, auction.List("alice", Listing{ItemID: "plasma-core", Quantity: 1, StartingPrice: 100, MinIncrement: 10, BuyoutPrice: 50, Duration: time.Hour}, SnipeProtection{}, now), ErrInvalidAuction)
+	require.NoError(t, auction.List("alice", listing, SnipeProtection{}, now))
+
+	assert.ErrorIs(t, auction.PlaceBid("alice", 200, now), ErrSellerCannotBid)
+	assert.ErrorIs(t, auction.PlaceBid("bob", 99, now), ErrBidTooLow)
+	require.NoError(t, auction.PlaceBid("bob", 100, now))
+	assert.ErrorIs(t, auction.PlaceBid("bob", 150, now), ErrAlreadyHighestBid)
+	assert.ErrorIs(t, auction.PlaceBid("carol", 109, now), ErrBidTooLow) // 최소 증가폭 10
+	require.NoError(t, auction.PlaceBid("carol", 110, now))
+	assert.ErrorIs(t, auction.Cancel("alice", now), ErrAuctionHasBids)
+	assert.ErrorIs(t, auction.Settle(now), ErrAuctionNotEnded)
+
+	// 즉시 구매가 이상의 입찰은 즉시 구매로 처리
+	require.NoError(t, auction.PlaceBid("dave", 600, now))
+	assert.Equal(t, StatusSold, auction.Status())
+	winner, price := auction.HighestBid()
+	assert.Equal(t, "dave", winner)
+	assert.Equal(t, int64(500), price)
+	assert.ErrorIs(t, auction.Buyout("erin", now), ErrAuctionClosed)
+
+	changes := auction.Changes()
+	assert.Equal(t, AuctionBoughtOutEventType, changes[len(changes)-2].EventType())
+	assert.Equal(t, AuctionSettledEventType, changes[len(changes)-1].EventType())
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewAuctionAggregate("a1")
+	require.NoError(t, restored.LoadFromHistory(changes))
+	assert.Equal(t, StatusSold, restored.Status())
+	assert.Equal(t, auction.Version(), restored.Version())
+}
+
+func TestAuctionAggregate_SnipingExtendsEnd(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	protection := SnipeProtection{Window: 2 * time.Minute, Extension: 2 * time.Minute}
+	auction := NewAuctionAggregate("a1")
+	require.NoError(t, auction.List("alice", Listing{ItemID: "plasma-core", Quantity: 1, StartingPrice: 100, MinIncrement: 10, Duration: time.Hour}, protection, now))
+	endsAt := auction.EndsAt()
+
+	require.NoError(t, auction.PlaceBid("bob", 100, endsAt.Add(-10*time.Minute)))
+	assert.Equal(t, endsAt, auction.EndsAt()) // 종료까지 충분히 남으면 연장하지 않음
+
+	require.NoError(t, auction.PlaceBid("carol", 110, endsAt.Add(-30*time.Second)))
+	assert.Equal(t, endsAt.Add(90*time.Second), auction.EndsAt())
+
+	// 원래 종료 시각이 지나도 연장된 시각 전에는 입찰 가능
+	require.NoError(t, auction.PlaceBid("bob", 120, endsAt.Add(time.Minute)))
+	assert.Equal(t, endsAt.Add(3*time.Minute), auction.EndsAt())
+	assert.ErrorIs(t, auction.PlaceBid("carol", 130, endsAt.Add(3*time.Minute)), ErrAuctionEnded)
+}
+
+func TestService_ScheduledSettlementFollowsExtensions(t *testing.T) {
+	// Arrange
+	fixture := newAuctionFixture(t)
+	ctx := context.Background()
+	start := fixture.clock.Now()
+	listing := Listing{ItemID: "plasma-core", Quantity: 2, StartingPrice: 100, MinIncrement: 10, Duration: time.Hour}
+	require.True(t, testkit.Dispatch(t, fixture.dispatcher, NewListAuctionCommand("a1", "alice", listing)).Success)
+	require.True(t, testkit.Dispatch(t, fixture.dispatcher, NewListAuctionCommand("a2", "alice", listing)).Success)
+	fixture.clock.Set(start.Add(59 * time.Minute))
+	bid := testkit.Dispatch(t, fixture.dispatcher, NewPlaceBidCommand("a1", "bob", 150))
+	require.True(t, bid.Success, bid.Error)
+
+	// Act
+	fixture.clock.Set(start.Add(time.Hour))
+	atOriginalEnd := runDue(t, fixture, fixture.clock.Now())
+	fixture.clock.Set(start.Add(61 * time.Minute))
+	atExtendedEnd := runDue(t, fixture, fixture.clock.Now())
+
+	// Assert
+	assert.Equal(t, []string{ScheduleKey("a2")}, atOriginalEnd) // 유찰
+	assert.Equal(t, []string{ScheduleKey("a1")}, atExtendedEnd) // 입찰로 1분 연장된 뒤 낙찰
+	sold, err := cqrs.LoadReadModel[*ListingView](ctx, fixture.readStore, "a1", ListingViewType)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSold, sold.Status)
+	assert.Equal(t, "bob", sold.WinnerID)
+	expired, err := cqrs.LoadReadModel[*ListingView](ctx, fixture.readStore, "a2", ListingViewType)
+	require.NoError(t, err)
+	assert.Equal(t, StatusExpired, expired.Status)
+}
+
+func TestBrowse_FiltersSortsAndPaginates(t *testing.T) {
+	// Arrange
+	fixture := newAuctionFixture(t)
+	ctx := context.Background()
+	list := func(auctionID, sellerID, itemID string, price int64, duration time.Duration) {
+		listing := Listing{ItemID: itemID, Quantity: 1, StartingPrice: price, MinIncrement: 10, Duration: duration}
+		require.True(t, testkit.Dispatch(t, fixture.dispatcher, NewListAuctionCommand(auctionID, sellerID, listing)).Success)
+	}
+	list("a1", "alice", "plasma-core", 100, 3*time.Hour)
+	list("a2", "alice", "plasma-rifle", 300, time.Hour)
+	list("a3", "bob", "plasma-shield", 200, 2*time.Hour)
+	list("a4", "bob", "iron-plate", 50, 4*time.Hour)
+	list("a5", "carol", "plasma-core", 120, 5*time.Hour)
+	require.True(t, testkit.Dispatch(t, fixture.dispatcher, NewPlaceBidCommand("a1", "dave", 400)).Success)
+	require.True(t, testkit.Dispatch(t, fixture.dispatcher, NewCancelAuctionCommand("a5", "carol")).Success)
+
+	// Act
+	endingSoon, err := Browse(ctx, fixture.readStore, BrowseFilter{Search: "PLASMA"}, pagit.OffsetRequest{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	secondPage, err := Browse(ctx, fixture.readStore, BrowseFilter{Search: "plasma"}, pagit.OffsetRequest{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	byPrice, err := Browse(ctx, fixture.readStore, BrowseFilter{MinPrice: 100, MaxPrice: 400, Sort: pagit.NewSort().Desc(SortByPrice)}, pagit.OffsetRequest{})
+	require.NoError(t, err)
+	bySeller, err := Browse(ctx, fixture.readStore, BrowseFilter{SellerID: "bob", Sort: pagit.NewSort().Asc(SortByPrice)}, pagit.OffsetRequest{})
+	require.NoError(t, err)
+	_, unknownSortErr := Browse(ctx, fixture.readStore, BrowseFilter{Sort: pagit.NewSort().Asc("name")}, pagit.OffsetRequest{})
+
+	// Assert
+	assert.Equal(t, []string{"a2", "a3"}, listingIDs(endingSoon.Items))
+	assert.Equal(t, int64(3), endingSoon.Total) // 취소된 a5는 제외
+	assert.True(t, endingSoon.HasNext)
+	assert.Equal(t, []string{"a1"}, listingIDs(secondPage.Items))
+	assert.Equal(t, []string{"a1", "a2", "a3"}, listingIDs(byPrice.Items)) // a1은 입찰로 400
+	assert.Equal(t, []string{"a4", "a3"}, listingIDs(bySeller.Items))
+	assert.ErrorIs(t, unknownSortErr, ErrInvalidAuction)
+}
+
+// runDue 지연 스케줄러를 now 시각으로 한 번 돌리고 처리한 예약 키를 반환합니다
+func runDue(t *testing.T, fixture *auctionFixture, now time.Time) []string {
+	t.Helper()
+	handled, err := fixture.scheduler.RunDueAt(context.Background(), now)
+	require.NoError(t, err)
+	keys := make([]string, 0, len(handled))
+	for _, deferred := range handled {
+		keys = append(keys, deferred.Key)
+	}
+	return keys
+}
+
+func listingIDs(views []*ListingView) []string {
+	ids := make([]string, 0, len(views))
+	for _, view := range views {
+		ids = append(ids, view.AuctionID)
+	}
+	return ids
+}
@@ -0,0 +1,284 @@
+package auction
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/defense-allies/pagit"
+)
+
+// ListingViewType 경매 목록 읽기 모델 타입 (읽기 모델 ID는 경매 ID)
+const ListingViewType = "AuctionListing"
+
+// 경매 목록 정렬 필드 (pagit.SortConfig의 Field)
+const (
+	SortByEndsAt   = "ends_at"
+	SortByPrice    = "price"
+	SortByBids     = "bids"
+	SortByListedAt = "listed_at"
+)
+
+// ListingView 경매 하나의 목록 정보
+type ListingView struct {
+	*cqrs.BaseReadModel
+	AuctionID       string    `json:"auction_id"`
+	SellerID        string    `json:"seller_id"`
+	ItemID          string    `json:"item_id"`
+	Quantity        int64     `json:"quantity"`
+	StartingPrice   int64     `json:"starting_price"`
+	MinIncrement    int64     `json:"min_increment"`
+	BuyoutPrice     int64     `json:"buyout_price,omitempty"`
+	HighestBid      int64     `json:"highest_bid,omitempty"`
+	HighestBidderID string    `json:"highest_bidder_id,omitempty"`
+	BidCount        int       `json:"bid_count"`
+	Status          string    `json:"status"`
+	WinnerID        string    `json:"winner_id,omitempty"`
+	ListedAt        time.Time `json:"listed_at"`
+	EndsAt          time.Time `json:"ends_at"`
+}
+
+// NewListingView 새로운 ListingView를 생성합니다
+func NewListingView(auctionID string) *ListingView {
+	return &ListingView{
+		BaseReadModel: cqrs.NewBaseReadModel(auctionID, ListingViewType, map[string]interface{}{}),
+		AuctionID:     auctionID,
+	}
+}
+
+// Price 현재 가격 (입찰이 없으면 시작가)
+func (v *ListingView) Price() int64 {
+	if v.BidCount == 0 {
+		return v.StartingPrice
+	}
+	return v.HighestBid
+}
+
+// GetData 읽기 모델 데이터
+func (v *ListingView) GetData() interface{} {
+	return map[string]interface{}{
+		"auction_id":  v.AuctionID,
+		"seller_id":   v.SellerID,
+		"item_id":     v.ItemID,
+		"quantity":    v.Quantity,
+		"price":       v.Price(),
+		"buyout":      v.BuyoutPrice,
+		"bid_count":   v.BidCount,
+		"status":      v.Status,
+		"ends_at":     v.EndsAt,
+		"winner_id":   v.WinnerID,
+		"highest_bid": v.HighestBid,
+	}
+}
+
+// ListingProjection 경매 이벤트로 목록 읽기 모델을 갱신합니다
+// 읽기 모델 버전을 이벤트 버전과 맞추므로 같은 이벤트를 다시 받아도 한 번만 적용됩니다
+type ListingProjection struct {
+	*cqrs.BaseEventHandler
+	store cqrs.ReadStore
+}
+
+// NewListingProjection 새로운 ListingProjection을 생성합니다
+func NewListingProjection(store cqrs.ReadStore) *ListingProjection {
+	return &ListingProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler("auction-listing", cqrs.ProjectionHandler, []string{
+			AuctionListedEventType,
+			BidPlacedEventType,
+			AuctionExtendedEventType,
+			AuctionBoughtOutEventType,
+			AuctionSettledEventType,
+			AuctionCancelledEventType,
+		}),
+		store: store,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *ListingProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// Handle 경매 이벤트를 적용합니다
+func (p *ListingProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	decode, known := auctionEventDecoders[event.EventType()]
+	if !known {
+		return nil
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	auctionID := event.AggregateID()
+
+	var create func() *ListingView
+	if _, listed := data.(AuctionListedData); listed {
+		create = func() *ListingView { return NewListingView(auctionID) }
+	}
+	return cqrs.ProjectEvent(ctx, p.store, event, auctionID, ListingViewType, create, func(view *ListingView) {
+		switch data := data.(type) {
+		case AuctionListedData:
+			view.SellerID = data.SellerID
+			view.ItemID = data.Listing.ItemID
+			view.Quantity = data.Listing.Quantity
+			view.StartingPrice = data.Listing.StartingPrice
+			view.MinIncrement = data.Listing.MinIncrement
+			view.BuyoutPrice = data.Listing.BuyoutPrice
+			view.ListedAt = data.ListedAt
+			view.EndsAt = data.EndsAt
+			view.Status = StatusActive
+		case BidPlacedData:
+			view.HighestBid = data.Amount
+			view.HighestBidderID = data.BidderID
+			view.BidCount++
+		case AuctionExtendedData:
+			view.EndsAt = data.EndsAt
+		case AuctionBoughtOutData:
+			view.HighestBid = data.Price
+			view.HighestBidderID = data.BuyerID
+			view.BidCount++
+		case AuctionSettledData:
+			view.WinnerID = data.WinnerID
+			if data.WinnerID == "" {
+				view.Status = StatusExpired
+			} else {
+				view.Status = StatusSold
+			}
+			view.EndsAt = data.SettledAt
+		case AuctionCancelledData:
+			view.Status = StatusCancelled
+			view.EndsAt = data.CancelledAt
+		}
+	})
+}
+
+// BrowseFilter 경매 목록 검색 조건 (비어 있는 조건은 적용하지 않음)
+type BrowseFilter struct {
+	Search     string           `json:"search,omitempty"`    // 아이템 ID 부분 일치 (대소문자 무시)
+	ItemID     string           `json:"item_id,omitempty"`   // 아이템 ID 정확히 일치
+	SellerID   string           `json:"seller_id,omitempty"` // 판매자
+	MinPrice   int64            `json:"min_price,omitempty"` // 현재 가격 하한
+	MaxPrice   int64            `json:"max_price,omitempty"` // 현재 가격 상한
+	Status     string           `json:"status,omitempty"`    // 기본값: active
+	BuyoutOnly bool             `json:"buyout_only,omitempty"`
+	Sort       pagit.SortConfig `json:"-"` // 기본값: 곧 끝나는 순 (ends_at 오름차순)
+}
+
+func (f BrowseFilter) matches(view *ListingView) bool {
+	status := f.Status
+	if status == "" {
+		status = StatusActive
+	}
+	switch {
+	case view.Status != status:
+		return false
+	case f.ItemID != "" && view.ItemID != f.ItemID:
+		return false
+	case f.Search != "" && !strings.Contains(strings.ToLower(view.ItemID), strings.ToLower(f.Search)):
+		return false
+	case f.SellerID != "" && view.SellerID != f.SellerID:
+		return false
+	case f.MinPrice > 0 && view.Price() < f.MinPrice:
+		return false
+	case f.MaxPrice > 0 && view.Price() > f.MaxPrice:
+		return false
+	case f.BuyoutOnly && view.BuyoutPrice == 0:
+		return false
+	}
+	return true
+}
+
+// Browse 조건에 맞는 경매를 정렬해 페이지 단위로 반환합니다
+func Browse(ctx context.Context, store cqrs.ReadStore, filter BrowseFilter, page pagit.OffsetRequest) (*pagit.OffsetResponse[*ListingView], error) {
+	sortConfig := filter.Sort
+	if sortConfig.IsEmpty() {
+		sortConfig = pagit.NewSort().Asc(SortByEndsAt)
+	}
+	for _, field := range sortConfig {
+		if _, known := listingSortKeys[field.Field]; !known {
+			return nil, fmt.Errorf("%w: unknown sort field %q", ErrInvalidAuction, field.Field)
+		}
+	}
+	return pagit.Paginate[*ListingView](ctx, page, &listingAdapter{store: store, filter: filter, sort: sortConfig})
+}
+
+// listingSortKeys 정렬 필드별 비교 값
+var listingSortKeys = map[string]func(view *ListingView) int64{
+	SortByEndsAt:   func(view *ListingView) int64 { return view.EndsAt.UnixNano() },
+	SortByPrice:    func(view *ListingView) int64 { return view.Price() },
+	SortByBids:     func(view *ListingView) int64 { return int64(view.BidCount) },
+	SortByListedAt: func(view *ListingView) int64 { return view.ListedAt.UnixNano() },
+}
+
+// listingAdapter 읽기 모델 저장소의 경매 목록을 pagit.OffsetAdapter로 감쌉니다
+// ReadStore 조회는 타입 필터만 지원하므로 한 번 읽어 메모리에서 거르고 정렬합니다
+type listingAdapter struct {
+	store  cqrs.ReadStore
+	filter BrowseFilter
+	sort   pagit.SortConfig
+
+	loaded   bool
+	listings []*ListingView
+}
+
+func (a *listingAdapter) Count(ctx context.Context) (int64, error) {
+	if err := a.load(ctx); err != nil {
+		return 0, err
+	}
+	return int64(len(a.listings)), nil
+}
+
+func (a *listingAdapter) Fetch(ctx context.Context, offset, limit int) ([]*ListingView, error) {
+	if err := a.load(ctx); err != nil {
+		return nil, err
+	}
+	if offset >= len(a.listings) {
+		return []*ListingView{}, nil
+	}
+	end := offset + limit
+	if end > len(a.listings) {
+		end = len(a.listings)
+	}
+	return a.listings[offset:end], nil
+}
+
+func (a *listingAdapter) load(ctx context.Context) error {
+	if a.loaded {
+		return nil
+	}
+	models, err := a.store.Query(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": ListingViewType}})
+	if err != nil {
+		return fmt.Errorf("failed to query auction listings: %w", err)
+	}
+	for _, model := range models {
+		view, ok := model.(*ListingView)
+		if ok && a.filter.matches(view) {
+			a.listings = append(a.listings, view)
+		}
+	}
+	sort.SliceStable(a.listings, func(i, j int) bool {
+		for _, field := range a.sort {
+			left, right := listingSortKeys[field.Field](a.listings[i]), listingSortKeys[field.Field](a.listings[j])
+			if left == right {
+				continue
+			}
+			if field.Direction == pagit.SortDesc {
+				return left > right
+			}
+			return left < right
+		}
+		return a.listings[i].AuctionID < a.listings[j].AuctionID
+	})
+	a.loaded = true
+	return nil
+}
@@ -0,0 +1,233 @@
+package auction
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 경매 명령 타입 (명령의 ID는 경매 ID, UserID는 명령을 보낸 사용자)
+const (
+	ListAuctionCommandType   = "ListAuction"
+	PlaceBidCommandType      = "PlaceBid"
+	BuyoutAuctionCommandType = "BuyoutAuction"
+	SettleAuctionCommandType = "SettleAuction" // 종료 시각에 지연 스케줄러가 보냅니다
+	CancelAuctionCommandType = "CancelAuction"
+)
+
+// SystemUserID 시스템이 보내는 정산 명령의 UserID
+const SystemUserID = "system"
+
+// 경매 명령 데이터
+type (
+	ListAuctionData struct {
+		Listing Listing `json:"listing"`
+	}
+	PlaceBidData struct {
+		Amount int64 `json:"amount"`
+	}
+)
+
+// NewListAuctionCommand 경매 등록 명령
+func NewListAuctionCommand(auctionID, sellerID string, listing Listing) cqrs.Command {
+	return newAuctionCommand(ListAuctionCommandType, auctionID, sellerID, ListAuctionData{Listing: listing})
+}
+
+// NewPlaceBidCommand 입찰 명령
+func NewPlaceBidCommand(auctionID, bidderID string, amount int64) cqrs.Command {
+	return newAuctionCommand(PlaceBidCommandType, auctionID, bidderID, PlaceBidData{Amount: amount})
+}
+
+// NewBuyoutAuctionCommand 즉시 구매 명령
+func NewBuyoutAuctionCommand(auctionID, buyerID string) cqrs.Command {
+	return newAuctionCommand(BuyoutAuctionCommandType, auctionID, buyerID, nil)
+}
+
+// NewSettleAuctionCommand 경매 정산 명령 (시스템 명령)
+func NewSettleAuctionCommand(auctionID string) cqrs.Command {
+	return newAuctionCommand(SettleAuctionCommandType, auctionID, SystemUserID, nil)
+}
+
+// NewCancelAuctionCommand 경매 취소 명령
+func NewCancelAuctionCommand(auctionID, sellerID string) cqrs.Command {
+	return newAuctionCommand(CancelAuctionCommandType, auctionID, sellerID, nil)
+}
+
+func newAuctionCommand(commandType, auctionID, userID string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, auctionID, AuctionAggregateType, data)
+	command.SetUserID(userID)
+	return command
+}
+
+// ScheduleKey 경매 정산 예약 키
+func ScheduleKey(auctionID string) string {
+	return "auction:" + auctionID
+}
+
+// ServiceConfig 경매 서비스 설정
+type ServiceConfig struct {
+	Store       eventstore.EventStore   // 필수: 경매 이벤트 저장소 (입찰, 낙찰 기록)
+	EventBus    cqrs.EventBus           // 선택: 경매 이벤트 발행 (목록 프로젝션이 구독)
+	Scheduler   *cqrs.DeferredScheduler // 선택: 종료 시각에 SettleAuction 명령 예약 (없으면 정산 명령을 직접 보내야 함, 재시작 후에도 정산하려면 cqrsx.RedisDeferredCommandStore 사용)
+	Protection  SnipeProtection         // 스나이핑 방지 (기본값: 종료 2분 전 입찰이면 입찰 시각 + 2분으로 연장)
+	MaxDuration time.Duration           // 최대 경매 기간 (기본값: 48h)
+	Now         func() time.Time        // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 경매 명령을 처리하고 종료 시각에 정산을 예약합니다
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu sync.Mutex // 경매 변경 직렬화
+}
+
+// NewService 새로운 Service를 생성합니다
+// 입찰과 낙찰 기록이 재시작 후에도 남아야 하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("auction: %w", ErrStoreRequired)
+	}
+	if config.Protection == (SnipeProtection{}) {
+		config.Protection = SnipeProtection{Window: 2 * time.Minute, Extension: 2 * time.Minute}
+	}
+	if config.MaxDuration <= 0 {
+		config.MaxDuration = 48 * time.Hour
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("auction", []string{
+			ListAuctionCommandType,
+			PlaceBidCommandType,
+			BuyoutAuctionCommandType,
+			SettleAuctionCommandType,
+			CancelAuctionCommandType,
+		}),
+		config: config,
+	}, nil
+}
+
+// RegisterWith 경매 명령 핸들러를 디스패처에 등록합니다
+// 지연 스케줄러는 같은 디스패처로 SettleAuction 명령을 보내야 합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load 경매 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, auctionID string) (*AuctionAggregate, error) {
+	return s.load(ctx, auctionID)
+}
+
+// Handle 경매 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	auctionID, userID := command.ID(), command.UserID()
+	if auctionID == "" || userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: auction ID and user ID are required", ErrInvalidAuction)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	switch command.CommandType() {
+	case ListAuctionCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[ListAuctionData](command.GetData(), ErrInvalidAuction)
+		if err = decodeErr; err == nil {
+			if data.Listing.Duration > s.config.MaxDuration {
+				err = fmt.Errorf("%w: duration exceeds %s", ErrInvalidAuction, s.config.MaxDuration)
+			} else {
+				err = aggregate.List(userID, data.Listing, s.config.Protection, now)
+			}
+		}
+	case PlaceBidCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[PlaceBidData](command.GetData(), ErrInvalidAuction)
+		if err = decodeErr; err == nil {
+			err = aggregate.PlaceBid(userID, data.Amount, now)
+		}
+	case BuyoutAuctionCommandType:
+		err = aggregate.Buyout(userID, now)
+	case SettleAuctionCommandType:
+		err = aggregate.Settle(now)
+	case CancelAuctionCommandType:
+		err = aggregate.Cancel(userID, now)
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidAuction, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	log.Printf("[Auction] %s applied to %s by %s", command.CommandType(), auctionID, userID)
+	return cqrs.NewCommandResult(auctionID, aggregate.Version(), events...).WithData(aggregate.Status()), nil
+}
+
+func (s *Service) load(ctx context.Context, auctionID string) (*AuctionAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, auctionID, AuctionAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auction %s: %w", auctionID, err)
+	}
+	aggregate := NewAuctionAggregate(auctionID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 정산 예약을 갱신한 뒤 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *AuctionAggregate) error {
+	events := aggregate.Changes()
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save auction %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	s.reschedule(ctx, aggregate)
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Auction] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// reschedule 진행 중인 경매는 (연장된) 종료 시각에 정산을 예약하고, 끝난 경매는 예약을 지웁니다
+// 예약에 실패해도 이벤트는 이미 저장되었으므로 기록만 남기고, 정산은 SettleAuction 명령으로 직접 보낼 수 있습니다
+func (s *Service) reschedule(ctx context.Context, aggregate *AuctionAggregate) {
+	if s.config.Scheduler == nil {
+		return
+	}
+	key := ScheduleKey(aggregate.ID())
+	var err error
+	if aggregate.Status() == StatusActive {
+		err = s.config.Scheduler.Schedule(ctx, key, aggregate.EndsAt(), NewSettleAuctionCommand(aggregate.ID()))
+	} else {
+		err = s.config.Scheduler.Cancel(ctx, key)
+	}
+	if err != nil {
+		log.Printf("[Auction] Failed to schedule settlement for %s: %v", aggregate.ID(), err)
+	}
+}
@@ -0,0 +1,225 @@
+package eventbrowser
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"embed"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+//go:embed ui/index.html
+var uiFiles embed.FS
+
+// DefaultBasePath 이벤트 브라우저 기본 경로
+const DefaultBasePath = "/admin/events"
+
+// SnapshotLister 집합체별 스냅샷 목록 조회 인터페이스 (cqrsx.AdvancedSnapshotStore가 구현)
+type SnapshotLister interface {
+	ListSnapshotsForAggregate(ctx context.Context, aggregateID string) ([]cqrsx.SnapshotData, error)
+}
+
+// ReplayFunc 집합체 이벤트를 fromVersion부터 다시 발행하고 재생한 이벤트 수를 반환합니다
+type ReplayFunc func(ctx context.Context, aggregateType, aggregateID string, fromVersion int) (int, error)
+
+// Config 이벤트 브라우저 설정
+type Config struct {
+	BasePath   string                          // 라우트 기본 경로 (기본값: /admin/events)
+	EventStore cqrsx.AggregateEventStore       // 필수: 이벤트 조회용 저장소
+	Catalog    cqrsx.AggregateCatalog          // 선택: 집합체 목록 조회 (없으면 목록 API 비활성)
+	Snapshots  SnapshotLister                  // 선택: 스냅샷 조회
+	Replay     ReplayFunc                      // 선택: 재생 트리거
+	Metrics    *cqrs.StorageMetricsCollector   // 선택: 저장소 용량 메트릭 집계
+	Auth       func(http.Handler) http.Handler // 필수: 관리자 인증 미들웨어
+	// Access 선택: 집합체 타입별 스트림 읽기 권한 (없으면 모든 스트림 조회 가능)
+	// Auth 미들웨어가 cqrs.WithStreamReader로 요청 컨텍스트에 조회자를 설정해야 합니다
+	Access *cqrs.StreamAccessPolicy
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.EventStore == nil {
+		return fmt.Errorf("event store is required")
+	}
+	if c.Auth == nil {
+		return fmt.Errorf("admin auth middleware is required")
+	}
+	return nil
+}
+
+// EventBrowserApp 이벤트 저장소를 탐색하는 관리자용 웹 UI 서버앱
+// 집합체 목록, 이벤트 JSON, 스냅샷 조회, 재생 트리거 및 저장소 메트릭을 제공합니다
+type EventBrowserApp struct {
+	*serverapp.BaseApp
+	config Config
+}
+
+// NewEventBrowserApp 새로운 EventBrowserApp을 생성합니다
+func NewEventBrowserApp(config Config) (*EventBrowserApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+
+	return &EventBrowserApp{
+		BaseApp: serverapp.NewBaseApp("eventbrowser"),
+		config:  config,
+	}, nil
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+// UI 페이지는 데이터가 없는 정적 셸이므로 공개하고, 데이터 API는 모두 관리자 인증을 거칩니다
+// (UI는 입력받은 관리자 토큰을 X-Admin-Token 헤더로 전송)
+func (a *EventBrowserApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+
+	mux.HandleFunc(base+"/", a.serveUI)
+	mux.Handle(base+"/api/aggregates", protect(http.HandlerFunc(a.listAggregates)))
+	mux.Handle(base+"/api/events", protect(http.HandlerFunc(a.listEvents)))
+	mux.Handle(base+"/api/snapshots", protect(http.HandlerFunc(a.listSnapshots)))
+	mux.Handle(base+"/api/replay", protect(http.HandlerFunc(a.replay)))
+	mux.Handle(base+"/api/storage-metrics", protect(http.HandlerFunc(a.storageMetrics)))
+
+	// 이벤트 저장소가 내보내기를 지원하면 분석용 JSONL/CSV 내보내기 API도 제공
+	if source, ok := a.config.EventStore.(cqrsx.EventExportSource); ok {
+		if a.config.Access != nil {
+			source = cqrsx.NewAccessControlledExportSource(source, a.config.Access)
+		}
+		mux.Handle(base+"/api/export", protect(cqrsx.NewEventExportHandler(source)))
+	}
+
+	log.Printf("[EventBrowser] Routes registered under %s", base)
+}
+
+// DescribeAPI 관리자 데이터 API 라우트 설명 (/openapi.json)
+func (a *EventBrowserApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	aggregateParams := []serverapp.APIParameter{
+		{Name: "type", In: "query", Required: true, Description: "집합체 타입"},
+		{Name: "id", In: "query", Required: true, Description: "집합체 ID"},
+		{Name: "from", In: "query", Type: "integer", Description: "시작 버전 (기본값 0)"},
+	}
+
+	operations := []serverapp.APIOperation{
+		{
+			Method:     http.MethodGet,
+			Path:       base + "/api/events",
+			Summary:    "집합체 이벤트 이력 조회",
+			Parameters: aggregateParams,
+			Response: struct {
+				AggregateID   string      `json:"aggregate_id"`
+				AggregateType string      `json:"aggregate_type"`
+				Events        []EventView `json:"events"`
+			}{},
+			Secured: true,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       base + "/api/replay",
+			Summary:    "집합체 이벤트 재생",
+			Parameters: aggregateParams,
+			Response: struct {
+				AggregateID   string `json:"aggregate_id"`
+				AggregateType string `json:"aggregate_type"`
+				Replayed      int    `json:"replayed"`
+				Success       bool   `json:"success"`
+			}{},
+			Secured: true,
+		},
+	}
+
+	if a.config.Catalog != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:  http.MethodGet,
+			Path:    base + "/api/aggregates",
+			Summary: "집합체 스트림 목록 조회",
+			Parameters: []serverapp.APIParameter{
+				{Name: "type", In: "query", Description: "집합체 타입 (비우면 전체)"},
+				{Name: "limit", In: "query", Type: "integer"},
+				{Name: "offset", In: "query", Type: "integer"},
+			},
+			Response: struct {
+				Aggregates []cqrsx.AggregateStreamInfo `json:"aggregates"`
+				Limit      int                         `json:"limit"`
+				Offset     int                         `json:"offset"`
+			}{},
+			Secured: true,
+		})
+	}
+	if a.config.Snapshots != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:     http.MethodGet,
+			Path:       base + "/api/snapshots",
+			Summary:    "집합체 스냅샷 조회",
+			Parameters: []serverapp.APIParameter{{Name: "id", In: "query", Required: true, Description: "집합체 ID"}},
+			Response: struct {
+				AggregateID string         `json:"aggregate_id"`
+				Snapshots   []SnapshotView `json:"snapshots"`
+			}{},
+			Secured: true,
+		})
+	}
+	if a.config.Metrics != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:  http.MethodGet,
+			Path:    base + "/api/storage-metrics",
+			Summary: "저장소 용량 상위 N개 집합체",
+			Parameters: []serverapp.APIParameter{
+				{Name: "type", In: "query", Description: "집합체 타입"},
+				{Name: "sort", In: "query", Description: "정렬 기준"},
+				{Name: "limit", In: "query", Type: "integer"},
+			},
+			Response: cqrs.StorageMetricsSummary{},
+			Secured:  true,
+		})
+	}
+	return operations
+}
+
+// serveUI 내장된 브라우저 UI 페이지 제공
+func (a *EventBrowserApp) serveUI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if r.URL.Path != a.config.BasePath+"/" {
+		sendError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	page, err := uiFiles.ReadFile("ui/index.html")
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
+
+// NewEventBusReplayer 이벤트 저장소의 이력을 이벤트 버스에 다시 발행하는 ReplayFunc 생성
+// 프로젝션 재구성이나 누락된 이벤트 재처리에 사용합니다
+func NewEventBusReplayer(store cqrsx.AggregateEventStore, bus cqrs.EventBus) ReplayFunc {
+	return func(ctx context.Context, aggregateType, aggregateID string, fromVersion int) (int, error) {
+		events, err := store.GetEventHistory(ctx, aggregateID, aggregateType, fromVersion)
+		if err != nil {
+			return 0, err
+		}
+
+		for i, event := range events {
+			if err := bus.Publish(ctx, event); err != nil {
+				return i, fmt.Errorf("failed to replay event %s: %w", event.EventID(), err)
+			}
+		}
+		return len(events), nil
+	}
+}
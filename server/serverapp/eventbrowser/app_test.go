@@ -0,0 +1,203 @@
+package eventbrowser
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"defense-allies-server/serverapp/timesquare/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent 페이로드를 가진 테스트용 이벤트
+type testEvent struct {
+	cqrs.BaseEventMessage
+	Name string `json:"name"`
+}
+
+func (e *testEvent) EventData() interface{} {
+	return map[string]string{"name": e.Name}
+}
+
+// fakeEventStore 메모리 기반 테스트용 이벤트 저장소
+type fakeEventStore struct {
+	cqrsx.AggregateEventStore
+	events []cqrs.EventMessage
+}
+
+func (s *fakeEventStore) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	var result []cqrs.EventMessage
+	for _, event := range s.events {
+		if event.AggregateID() == aggregateID && event.AggregateType() == aggregateType && event.Version() >= fromVersion {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func newTestEvent(version int, name string) *testEvent {
+	event := &testEvent{BaseEventMessage: *cqrs.NewBaseEventMessage("GuildRenamed"), Name: name}
+	event.AggregateID_ = "guild-1"
+	event.AggregateType_ = "Guild"
+	event.Version_ = version
+	return event
+}
+
+func newTestMux(t *testing.T, config Config) *http.ServeMux {
+	config.EventStore = &fakeEventStore{events: []cqrs.EventMessage{newTestEvent(1, "Allies"), newTestEvent(2, "Axis")}}
+	config.Auth = middleware.NewAdminAuthMiddleware("secret").Protect
+
+	app, err := NewEventBrowserApp(config)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	return mux
+}
+
+func TestNewEventBrowserApp_RequiresAuth(t *testing.T) {
+	// Act
+	_, err := NewEventBrowserApp(Config{EventStore: &fakeEventStore{}})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestEventBrowserApp_EventsRequireAdminToken(t *testing.T) {
+	// Arrange
+	mux := newTestMux(t, Config{})
+
+	// Act
+	missing := httptest.NewRecorder()
+	mux.ServeHTTP(missing, httptest.NewRequest(http.MethodGet, "/admin/events/api/events?type=Guild&id=guild-1", nil))
+	wrong := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/events/api/events?type=Guild&id=guild-1", nil)
+	req.Header.Set(middleware.AdminTokenHeader, "nope")
+	mux.ServeHTTP(wrong, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, missing.Code)
+	assert.Equal(t, http.StatusForbidden, wrong.Code)
+}
+
+func TestEventBrowserApp_ListEvents(t *testing.T) {
+	// Arrange
+	mux := newTestMux(t, Config{})
+	req := httptest.NewRequest(http.MethodGet, "/admin/events/api/events?type=Guild&id=guild-1&from=2", nil)
+	req.Header.Set(middleware.AdminTokenHeader, "secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Events []EventView `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Events, 1)
+	assert.Equal(t, 2, body.Events[0].Version)
+	assert.JSONEq(t, `{"name":"Axis"}`, string(body.Events[0].Data))
+}
+
+func TestEventBrowserApp_Replay(t *testing.T) {
+	// Arrange
+	var replayedFrom int
+	mux := newTestMux(t, Config{
+		Replay: func(ctx context.Context, aggregateType, aggregateID string, fromVersion int) (int, error) {
+			replayedFrom = fromVersion
+			return 2, nil
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/admin/events/api/replay?type=Guild&id=guild-1&from=1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, replayedFrom)
+	assert.Contains(t, rec.Body.String(), `"replayed":2`)
+}
+
+// fakeMetricsProvider 고정 메트릭을 돌려주는 테스트용 제공자
+type fakeMetricsProvider map[string]*cqrs.StorageMetrics
+
+func (p fakeMetricsProvider) GetStorageMetrics(ctx context.Context, aggregateID string) (*cqrs.StorageMetrics, error) {
+	return p[aggregateID], nil
+}
+
+func TestEventBrowserApp_StorageMetrics(t *testing.T) {
+	// Arrange
+	collector := cqrs.NewStorageMetricsCollector()
+	provider := fakeMetricsProvider{
+		"guild-1": {EventCount: 10, StateSize: 2048},
+		"guild-2": {EventCount: 40, StateSize: 512},
+	}
+	require.NoError(t, collector.Register("Guild", provider, func(ctx context.Context, aggregateType string) ([]string, error) {
+		return []string{"guild-1", "guild-2"}, nil
+	}))
+	mux := newTestMux(t, Config{Metrics: collector})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/events/api/storage-metrics?sort=state_size&limit=1", nil)
+	req.Header.Set(middleware.AdminTokenHeader, "secret")
+	rec := httptest.NewRecorder()
+	invalid := httptest.NewRequest(http.MethodGet, "/admin/events/api/storage-metrics?sort=bogus", nil)
+	invalid.Header.Set(middleware.AdminTokenHeader, "secret")
+	invalidRec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+	mux.ServeHTTP(invalidRec, invalid)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	var summary cqrs.StorageMetricsSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	assert.Equal(t, 2, summary.Aggregates)
+	assert.Equal(t, int64(50), summary.TotalEvents)
+	require.Len(t, summary.Top, 1)
+	assert.Equal(t, "guild-1", summary.Top[0].AggregateID)
+	assert.Equal(t, http.StatusBadRequest, invalidRec.Code)
+}
+
+func TestEventBrowserApp_UnconfiguredFeatures(t *testing.T) {
+	// Arrange
+	mux := newTestMux(t, Config{})
+
+	for _, path := range []string{"/admin/events/api/aggregates", "/admin/events/api/snapshots?id=guild-1", "/admin/events/api/storage-metrics"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(middleware.AdminTokenHeader, "secret")
+		rec := httptest.NewRecorder()
+
+		// Act
+		mux.ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotImplemented, rec.Code, path)
+	}
+}
+
+func TestEventBrowserApp_ServesUI(t *testing.T) {
+	// Arrange
+	mux := newTestMux(t, Config{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/events/", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Event Store Browser")
+	// 집합체 ID 같은 데이터는 인라인 핸들러가 아닌 data-* 속성으로 전달 (속성 값의 &#39;는 JS 실행 전에 복원됨)
+	assert.NotRegexp(t, `on\w+="[^"]*\$\{esc\(`, rec.Body.String())
+}
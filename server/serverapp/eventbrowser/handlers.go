@@ -0,0 +1,299 @@
+package eventbrowser
+
+import (
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// EventView 브라우저에 표시할 이벤트 정보
+type EventView struct {
+	EventID       string                 `json:"event_id"`
+	EventType     string                 `json:"event_type"`
+	AggregateID   string                 `json:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type"`
+	Version       int                    `json:"version"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Data          json.RawMessage        `json:"data,omitempty"`
+}
+
+// SnapshotView 브라우저에 표시할 스냅샷 정보
+type SnapshotView struct {
+	AggregateID string                 `json:"aggregate_id"`
+	Type        string                 `json:"type"`
+	Version     int                    `json:"version"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Size        int64                  `json:"size"`
+	ContentType string                 `json:"content_type"`
+	Compression string                 `json:"compression,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Data        json.RawMessage        `json:"data,omitempty"`
+	RawData     []byte                 `json:"raw_data,omitempty"` // JSON이 아닌 스냅샷 (base64)
+}
+
+// newEventView 이벤트 메시지를 표시용 구조로 변환
+func newEventView(event cqrs.EventMessage) EventView {
+	view := EventView{
+		EventID:       event.EventID(),
+		EventType:     event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		Version:       event.Version(),
+		Timestamp:     event.Timestamp(),
+		Metadata:      event.Metadata(),
+	}
+
+	if data := event.EventData(); data != nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			view.Data = encoded
+		} else {
+			view.Data, _ = json.Marshal(fmt.Sprintf("%+v", data))
+		}
+	}
+	return view
+}
+
+// listAggregates GET /api/aggregates?type=&limit=&offset=
+func (a *EventBrowserApp) listAggregates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if a.config.Catalog == nil {
+		sendError(w, http.StatusNotImplemented, "Aggregate listing is not supported by this event store")
+		return
+	}
+
+	query := r.URL.Query()
+	limit := queryInt(query.Get("limit"), 50)
+	offset := queryInt(query.Get("offset"), 0)
+
+	if !a.authorize(w, r, query.Get("type")) {
+		return
+	}
+
+	streams, err := a.config.Catalog.ListAggregates(r.Context(), query.Get("type"), limit, offset)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if a.config.Access != nil {
+		visible := streams[:0]
+		for _, stream := range streams {
+			if a.config.Access.Allows(r.Context(), stream.AggregateType) {
+				visible = append(visible, stream)
+			}
+		}
+		streams = visible
+	}
+
+	sendJSON(w, http.StatusOK, map[string]interface{}{
+		"aggregates": streams,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// listEvents GET /api/events?type=&id=&from=
+func (a *EventBrowserApp) listEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	aggregateType, aggregateID := query.Get("type"), query.Get("id")
+	if aggregateType == "" || aggregateID == "" {
+		sendError(w, http.StatusBadRequest, "type and id are required")
+		return
+	}
+	if !a.authorize(w, r, aggregateType) {
+		return
+	}
+
+	events, err := a.config.EventStore.GetEventHistory(r.Context(), aggregateID, aggregateType, queryInt(query.Get("from"), 0))
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	views := make([]EventView, 0, len(events))
+	for _, event := range events {
+		views = append(views, newEventView(event))
+	}
+
+	sendJSON(w, http.StatusOK, map[string]interface{}{
+		"aggregate_id":   aggregateID,
+		"aggregate_type": aggregateType,
+		"events":         views,
+	})
+}
+
+// listSnapshots GET /api/snapshots?id=
+func (a *EventBrowserApp) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if a.config.Snapshots == nil {
+		sendError(w, http.StatusNotImplemented, "Snapshot browsing is not configured")
+		return
+	}
+
+	aggregateID := r.URL.Query().Get("id")
+	if aggregateID == "" {
+		sendError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	snapshots, err := a.config.Snapshots.ListSnapshotsForAggregate(r.Context(), aggregateID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	views := make([]SnapshotView, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if a.config.Access != nil && !a.config.Access.Allows(r.Context(), snapshot.Type()) {
+			continue
+		}
+		view := SnapshotView{
+			AggregateID: snapshot.ID(),
+			Type:        snapshot.Type(),
+			Version:     snapshot.Version(),
+			Timestamp:   snapshot.Timestamp(),
+			Size:        snapshot.Size(),
+			ContentType: snapshot.ContentType(),
+			Compression: snapshot.Compression(),
+			Metadata:    snapshot.Metadata(),
+		}
+		if data := snapshot.Data(); json.Valid(data) {
+			view.Data = data
+		} else {
+			view.RawData = data
+		}
+		views = append(views, view)
+	}
+
+	sendJSON(w, http.StatusOK, map[string]interface{}{
+		"aggregate_id": aggregateID,
+		"snapshots":    views,
+	})
+}
+
+// replay POST /api/replay?type=&id=&from=
+func (a *EventBrowserApp) replay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if a.config.Replay == nil {
+		sendError(w, http.StatusNotImplemented, "Replay is not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	aggregateType, aggregateID := query.Get("type"), query.Get("id")
+	if aggregateType == "" || aggregateID == "" {
+		sendError(w, http.StatusBadRequest, "type and id are required")
+		return
+	}
+	if !a.authorize(w, r, aggregateType) {
+		return
+	}
+
+	replayed, err := a.config.Replay(r.Context(), aggregateType, aggregateID, queryInt(query.Get("from"), 0))
+	if err != nil {
+		sendJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"error":    err.Error(),
+			"replayed": replayed,
+			"success":  false,
+		})
+		return
+	}
+
+	sendJSON(w, http.StatusOK, map[string]interface{}{
+		"aggregate_id":   aggregateID,
+		"aggregate_type": aggregateType,
+		"replayed":       replayed,
+		"success":        true,
+	})
+}
+
+// storageMetrics GET /api/storage-metrics?type=&sort=&limit=
+// 용량 계획용으로 이벤트 수 / 상태 크기 / 미접근 기간 기준 상위 N개 집합체를 반환합니다
+func (a *EventBrowserApp) storageMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if a.config.Metrics == nil {
+		sendError(w, http.StatusNotImplemented, "Storage metrics are not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	summary, err := a.config.Metrics.Collect(r.Context(), cqrs.StorageMetricsQuery{
+		AggregateType: query.Get("type"),
+		SortBy:        cqrs.StorageMetricsSortField(query.Get("sort")),
+		Limit:         queryInt(query.Get("limit"), 20),
+	})
+	if err != nil {
+		switch {
+		case cqrs.IsValidationError(err):
+			sendError(w, http.StatusBadRequest, err.Error())
+		case cqrs.IsNotFoundError(err):
+			sendError(w, http.StatusNotFound, err.Error())
+		default:
+			sendError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	sendJSON(w, http.StatusOK, summary)
+}
+
+// queryInt 쿼리 파라미터를 정수로 변환 (실패 시 기본값)
+func queryInt(value string, defaultValue int) int {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return defaultValue
+	}
+	return parsed
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// authorize 요청자가 집합체 타입의 스트림을 읽을 수 있는지 확인하고, 아니면 403을 응답합니다
+// 타입이 비어 있으면 (전체 조회) 통과시키고 결과에서 숨겨진 타입을 걸러냅니다
+func (a *EventBrowserApp) authorize(w http.ResponseWriter, r *http.Request, aggregateType string) bool {
+	if a.config.Access == nil || aggregateType == "" {
+		return true
+	}
+	if err := a.config.Access.Authorize(r.Context(), aggregateType); err != nil {
+		sendError(w, http.StatusForbidden, err.Error())
+		return false
+	}
+	return true
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
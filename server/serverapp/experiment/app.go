@@ -0,0 +1,184 @@
+package experiment
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 실험 기본 경로
+const DefaultBasePath = "/experiments"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 4 << 10
+
+// Config 실험 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /experiments)
+	Service  ServiceConfig                   // 실험 서비스 설정
+	Auth     func(http.Handler) http.Handler // 선택: 사용자 인증 미들웨어
+	Identify func(r *http.Request) string    // 필수: 배정할 사용자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to bind assignments to users")
+	}
+	return nil
+}
+
+// ExposureRequest 노출 기록 요청
+type ExposureRequest struct {
+	Experiment string `json:"experiment"`
+}
+
+// ExperimentApp 사용자의 실험 변형 조회와 노출 기록을 제공하는 서버앱
+type ExperimentApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewExperimentApp 새로운 ExperimentApp을 생성합니다
+func NewExperimentApp(config Config) (*ExperimentApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &ExperimentApp{
+		BaseApp: serverapp.NewBaseApp("experiment"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 실험 서비스
+func (a *ExperimentApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *ExperimentApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/variants", protect(http.HandlerFunc(a.variants)))
+	mux.Handle(base+"/exposures", protect(http.HandlerFunc(a.exposures)))
+
+	log.Printf("[Experiment] Routes registered under %s", base)
+}
+
+// DescribeAPI 실험 엔드포인트 설명 (/openapi.json)
+func (a *ExperimentApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	return []serverapp.APIOperation{
+		{
+			Method:      http.MethodGet,
+			Path:        base + "/variants",
+			Summary:     "내 실험 변형 조회",
+			Description: "experiment를 주면 그 실험 하나, 없으면 모든 실험의 배정을 돌려줍니다. 노출은 기록하지 않습니다.",
+			Parameters:  []serverapp.APIParameter{{Name: "experiment", In: "query", Description: "실험 키"}},
+			Response:    []Assignment{},
+			Secured:     secured,
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/exposures",
+			Summary:     "실험 노출 기록",
+			Description: "클라이언트가 변형을 실제로 보여준 순간 호출합니다. 실험에 참여한 사용자만 ExperimentExposed 이벤트를 발행합니다.",
+			Request:     ExposureRequest{},
+			Response:    Assignment{},
+			Secured:     secured,
+		},
+	}
+}
+
+func (a *ExperimentApp) variants(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	key := r.URL.Query().Get("experiment")
+	if key == "" {
+		sendJSON(w, http.StatusOK, a.service.Assignments(r.Context(), userID))
+		return
+	}
+	assignment, err := a.service.Variant(r.Context(), userID, key)
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, []Assignment{assignment})
+}
+
+func (a *ExperimentApp) exposures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	var request ExposureRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&request); err != nil || request.Experiment == "" {
+		sendError(w, http.StatusBadRequest, "experiment is required")
+		return
+	}
+	assignment, err := a.service.Expose(r.Context(), userID, request.Experiment)
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, assignment)
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrExperimentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidExperiment):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
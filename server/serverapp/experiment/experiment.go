@@ -0,0 +1,162 @@
+package experiment
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// ExperimentExposedEventType 사용자가 실험 변형을 실제로 본 순간 (이벤트 버스로 발행, 데이터는 Exposure)
+// 분석 파이프라인이 구독해 변형별 지표를 나눕니다
+const ExperimentExposedEventType = "ExperimentExposed"
+
+// bucketCount 배정 버킷 수 (가중치와 트래픽 비율의 해상도)
+const bucketCount = 10000
+
+var (
+	ErrInvalidExperiment  = errors.New("invalid experiment")
+	ErrExperimentNotFound = errors.New("experiment not found")
+)
+
+// Variant 실험 변형 하나
+type Variant struct {
+	Name   string                 `json:"name"`
+	Weight int                    `json:"weight"`           // 다른 변형과의 상대 비율
+	Params map[string]interface{} `json:"params,omitempty"` // 게임플레이 조정 값 (예: {"spawn_rate": 1.2})
+}
+
+// Experiment 게임플레이 조정 실험
+// JSON 예: {"key":"tower-cost-v2","flag":"experiments.tower_cost","traffic":0.5,"variants":[{"name":"control","weight":1},{"name":"cheap","weight":1,"params":{"cost":0.8}}]}
+type Experiment struct {
+	Key         string    `json:"key"`
+	Description string    `json:"description,omitempty"`
+	Flag        string    `json:"flag,omitempty"`    // 선택: 꺼져 있으면 모두 첫 변형(대조군)이고 노출을 기록하지 않음
+	Salt        string    `json:"salt,omitempty"`    // 배정 해시 소금 (기본값: Key, 바꾸면 전원 재배정)
+	Traffic     float64   `json:"traffic,omitempty"` // 실험에 참여하는 사용자 비율 (0~1, 기본값: 1)
+	Variants    []Variant `json:"variants"`          // 첫 변형이 대조군
+	StartsAt    time.Time `json:"starts_at,omitempty"`
+	EndsAt      time.Time `json:"ends_at,omitempty"`
+}
+
+// Validate 실험 유효성 검사
+func (e Experiment) Validate() error {
+	if strings.TrimSpace(e.Key) == "" {
+		return fmt.Errorf("%w: key is required", ErrInvalidExperiment)
+	}
+	if len(e.Variants) < 2 {
+		return fmt.Errorf("%w: %s needs at least two variants", ErrInvalidExperiment, e.Key)
+	}
+	if e.Traffic < 0 || e.Traffic > 1 {
+		return fmt.Errorf("%w: %s traffic must be between 0 and 1", ErrInvalidExperiment, e.Key)
+	}
+	if !e.StartsAt.IsZero() && !e.EndsAt.IsZero() && !e.EndsAt.After(e.StartsAt) {
+		return fmt.Errorf("%w: %s must end after it starts", ErrInvalidExperiment, e.Key)
+	}
+	names := make(map[string]bool, len(e.Variants))
+	for _, variant := range e.Variants {
+		if variant.Name == "" || variant.Weight <= 0 {
+			return fmt.Errorf("%w: %s variants need a name and a positive weight", ErrInvalidExperiment, e.Key)
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("%w: %s has duplicate variant %s", ErrInvalidExperiment, e.Key, variant.Name)
+		}
+		names[variant.Name] = true
+	}
+	return nil
+}
+
+// RunningAt at 시각에 실험 기간 안인지 확인합니다 (기간이 없으면 항상)
+func (e Experiment) RunningAt(at time.Time) bool {
+	if !e.StartsAt.IsZero() && at.Before(e.StartsAt) {
+		return false
+	}
+	return e.EndsAt.IsZero() || at.Before(e.EndsAt)
+}
+
+// Control 대조군 (첫 변형)
+func (e Experiment) Control() Variant {
+	return e.Variants[0]
+}
+
+// Assign 사용자를 변형에 배정합니다 (같은 사용자, 같은 소금이면 항상 같은 결과)
+// 참여 여부와 변형은 서로 다른 해시로 정하므로 Traffic을 늘려도 이미 참여한 사용자의 변형은 바뀌지 않습니다
+func (e Experiment) Assign(userID string) (Variant, bool) {
+	salt := e.Salt
+	if salt == "" {
+		salt = e.Key
+	}
+	traffic := e.Traffic
+	if traffic == 0 {
+		traffic = 1
+	}
+	if bucket(salt+":traffic:"+userID) >= int(traffic*bucketCount) {
+		return e.Control(), false
+	}
+
+	total := 0
+	for _, variant := range e.Variants {
+		total += variant.Weight
+	}
+	point := bucket(salt+":variant:"+userID) * total / bucketCount
+	for _, variant := range e.Variants {
+		if point < variant.Weight {
+			return variant, true
+		}
+		point -= variant.Weight
+	}
+	return e.Control(), true
+}
+
+// bucket 문자열을 [0, bucketCount) 버킷으로 고르게 나눕니다
+func bucket(value string) int {
+	hash := fnv.New64a()
+	hash.Write([]byte(value))
+	return int(mix64(hash.Sum64()) % bucketCount)
+}
+
+// mix64 FNV 결과를 전체 범위로 퍼뜨립니다 (splitmix64 finalizer, analytics.Sampler와 같은 방식)
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Assignment 사용자 한 명의 실험 배정
+type Assignment struct {
+	Experiment string                 `json:"experiment"`
+	Variant    string                 `json:"variant"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Enrolled   bool                   `json:"enrolled"` // false면 실험 밖 (대조군 값을 쓰고 노출도 기록하지 않음)
+}
+
+// Exposure 노출 이벤트 데이터
+type Exposure struct {
+	Experiment string    `json:"experiment"`
+	Variant    string    `json:"variant"`
+	UserID     string    `json:"user_id"`
+	ExposedAt  time.Time `json:"exposed_at"`
+}
+
+// ExposureEvent 실험 노출 이벤트
+type ExposureEvent struct {
+	*cqrs.BaseEventMessage
+	Exposure Exposure `json:"exposure"`
+}
+
+// NewExposureEvent 새로운 ExposureEvent를 생성합니다
+// 분석 파이프라인의 DefaultMetadataEnricher가 사용자 ID를 옮기도록 메타데이터에도 넣습니다
+func NewExposureEvent(exposure Exposure) *ExposureEvent {
+	event := &ExposureEvent{BaseEventMessage: cqrs.NewBaseEventMessage(ExperimentExposedEventType), Exposure: exposure}
+	event.AddMetadata(cqrs.MetadataUserID, exposure.UserID)
+	return event
+}
+
+func (e *ExposureEvent) EventData() interface{} {
+	return e.Exposure
+}
@@ -0,0 +1,173 @@
+package experiment
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/analytics"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exposureRecorder 이벤트 버스로 받은 노출을 기록하는 핸들러
+type exposureRecorder struct {
+	*cqrs.BaseEventHandler
+	mu        sync.Mutex
+	exposures []Exposure
+	userIDs   []interface{}
+}
+
+func newExposureRecorder(t *testing.T, bus cqrs.EventBus) *exposureRecorder {
+	t.Helper()
+	recorder := &exposureRecorder{
+		BaseEventHandler: cqrs.NewBaseEventHandler("experiment-recorder", cqrs.AnalyticsHandler, []string{ExperimentExposedEventType}),
+	}
+	_, err := bus.Subscribe(ExperimentExposedEventType, recorder)
+	require.NoError(t, err)
+	return recorder
+}
+
+func (r *exposureRecorder) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exposures = append(r.exposures, event.EventData().(Exposure))
+	r.userIDs = append(r.userIDs, event.Metadata()[cqrs.MetadataUserID])
+	return nil
+}
+
+var testNow = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func towerCostExperiment() Experiment {
+	return Experiment{
+		Key:  "tower-cost-v2",
+		Flag: "experiments.tower_cost",
+		Variants: []Variant{
+			{Name: "control", Weight: 1},
+			{Name: "cheap", Weight: 3, Params: map[string]interface{}{"cost_multiplier": 0.8}},
+		},
+	}
+}
+
+func TestExperiment_AssignIsDeterministicAndWeighted(t *testing.T) {
+	experiment := towerCostExperiment()
+
+	assert.ErrorIs(t, Experiment{Key: "x", Variants: []Variant{{Name: "a", Weight: 1}}}.Validate(), ErrInvalidExperiment)
+	assert.ErrorIs(t, Experiment{Key: "x", Variants: []Variant{{Name: "a", Weight: 1}, {Name: "a", Weight: 1}}}.Validate(), ErrInvalidExperiment)
+	require.NoError(t, experiment.Validate())
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		first, enrolled := experiment.Assign(userID)
+		again, _ := experiment.Assign(userID)
+		require.True(t, enrolled)
+		require.Equal(t, first.Name, again.Name)
+		counts[first.Name]++
+	}
+	// 가중치 1:3 → 약 25%, 75%
+	assert.InDelta(t, 1000, counts["control"], 150)
+	assert.InDelta(t, 3000, counts["cheap"], 150)
+
+	// 트래픽을 늘려도 이미 참여한 사용자의 변형은 그대로
+	half := experiment
+	half.Traffic = 0.5
+	enrolledCount := 0
+	for i := 0; i < 4000; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		variant, enrolled := half.Assign(userID)
+		if !enrolled {
+			continue
+		}
+		enrolledCount++
+		full, _ := experiment.Assign(userID)
+		require.Equal(t, full.Name, variant.Name)
+	}
+	assert.InDelta(t, 2000, enrolledCount, 150)
+}
+
+func TestService_FlagGatesExposureAndEnrichment(t *testing.T) {
+	// Arrange
+	bus := cqrs.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+	recorder := newExposureRecorder(t, bus)
+	flags := cqrs.NewInMemoryFeatureFlags()
+	service, err := NewService(ServiceConfig{
+		Experiments: []Experiment{towerCostExperiment()},
+		Flags:       flags,
+		EventBus:    bus,
+		Now:         func() time.Time { return testNow },
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	expected, _ := towerCostExperiment().Assign("player-1")
+
+	// Act
+	gated, err := service.Expose(ctx, "player-1", "tower-cost-v2")
+	require.NoError(t, err)
+	flags.Set("experiments.tower_cost", true)
+	exposed, err := service.Expose(ctx, "player-1", "tower-cost-v2")
+	require.NoError(t, err)
+	_, missingErr := service.Variant(ctx, "player-1", "unknown")
+	event := &analytics.Event{EventType: "TowerPlaced"}
+	event.SetContext(analytics.ContextUserID, "player-1")
+	require.NoError(t, service.Enricher().Enrich(ctx, cqrs.NewBaseEventMessage("TowerPlaced"), event))
+
+	// Assert
+	assert.False(t, gated.Enrolled)
+	assert.Equal(t, "control", gated.Variant)
+	assert.True(t, exposed.Enrolled)
+	assert.Equal(t, expected.Name, exposed.Variant)
+	assert.ErrorIs(t, missingErr, ErrExperimentNotFound)
+	require.Len(t, recorder.exposures, 1)
+	assert.Equal(t, Exposure{Experiment: "tower-cost-v2", Variant: expected.Name, UserID: "player-1", ExposedAt: testNow}, recorder.exposures[0])
+	assert.Equal(t, []interface{}{"player-1"}, recorder.userIDs)
+	assert.Equal(t, map[string]string{"tower-cost-v2": expected.Name}, event.Context[ContextExperiments])
+}
+
+func TestExperimentApp_VariantsAndExposures(t *testing.T) {
+	// Arrange
+	app, err := NewExperimentApp(Config{
+		Service:  ServiceConfig{Experiments: []Experiment{towerCostExperiment()}},
+		Identify: func(r *http.Request) string { return r.Header.Get("X-User-ID") },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, body, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if userID != "" {
+			req.Header.Set("X-User-ID", userID)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act
+	all := request(http.MethodGet, "/experiments/variants", "", "player-1")
+	missing := request(http.MethodGet, "/experiments/variants?experiment=unknown", "", "player-1")
+	anonymous := request(http.MethodGet, "/experiments/variants", "", "")
+	exposed := request(http.MethodPost, "/experiments/exposures", `{"experiment": "tower-cost-v2"}`, "player-1")
+
+	// Assert
+	require.Equal(t, http.StatusOK, all.Code, all.Body.String())
+	var assignments []Assignment
+	require.NoError(t, json.Unmarshal(all.Body.Bytes(), &assignments))
+	require.Len(t, assignments, 1)
+	assert.True(t, assignments[0].Enrolled)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	require.Equal(t, http.StatusOK, exposed.Code, exposed.Body.String())
+	var assignment Assignment
+	require.NoError(t, json.Unmarshal(exposed.Body.Bytes(), &assignment))
+	assert.Equal(t, assignments[0].Variant, assignment.Variant)
+}
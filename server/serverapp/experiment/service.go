@@ -0,0 +1,168 @@
+package experiment
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/analytics"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ContextExperiments 분석 이벤트 컨텍스트 키 (실험 키 → 변형 이름)
+const ContextExperiments = "experiments"
+
+// ServiceConfig 실험 서비스 설정
+type ServiceConfig struct {
+	Experiments []Experiment      // 시작할 때 등록할 실험
+	Flags       cqrs.FeatureFlags // 선택: 실험의 Flag를 확인할 기능 플래그 (없으면 Flag를 무시)
+	EventBus    cqrs.EventBus     // 선택: 노출 이벤트를 발행할 버스 (없으면 노출을 기록하지 않음)
+	Now         func() time.Time  // 현재 시각 (기본값: time.Now)
+}
+
+// Service 사용자를 실험 변형에 배정하고 노출을 기록하는 서비스
+// 배정은 사용자 ID 해시로 정하므로 저장하지 않으며, 서버 여러 대에서도 같은 결과를 냅니다
+type Service struct {
+	config      ServiceConfig
+	mu          sync.RWMutex
+	experiments map[string]Experiment
+}
+
+// NewService 새로운 Service를 생성합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	service := &Service{config: config, experiments: make(map[string]Experiment)}
+	if err := service.Replace(config.Experiments); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// Register 실험을 등록합니다 (같은 키는 덮어씀)
+func (s *Service) Register(experiment Experiment) error {
+	if err := experiment.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.experiments[experiment.Key] = experiment
+	return nil
+}
+
+// Replace 등록된 실험 전체를 바꿉니다 (하나라도 잘못되면 바꾸지 않음)
+func (s *Service) Replace(experiments []Experiment) error {
+	replaced := make(map[string]Experiment, len(experiments))
+	for _, experiment := range experiments {
+		if err := experiment.Validate(); err != nil {
+			return err
+		}
+		if _, exists := replaced[experiment.Key]; exists {
+			return fmt.Errorf("%w: duplicate key %s", ErrInvalidExperiment, experiment.Key)
+		}
+		replaced[experiment.Key] = experiment
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.experiments = replaced
+	return nil
+}
+
+// Experiments 등록된 실험 목록 (키 순)
+func (s *Service) Experiments() []Experiment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	experiments := make([]Experiment, 0, len(s.experiments))
+	for _, experiment := range s.experiments {
+		experiments = append(experiments, experiment)
+	}
+	sort.Slice(experiments, func(i, j int) bool { return experiments[i].Key < experiments[j].Key })
+	return experiments
+}
+
+// Variant 사용자의 변형을 조회합니다 (노출은 기록하지 않음)
+func (s *Service) Variant(ctx context.Context, userID, key string) (Assignment, error) {
+	s.mu.RLock()
+	experiment, ok := s.experiments[key]
+	s.mu.RUnlock()
+	if !ok {
+		return Assignment{}, fmt.Errorf("%w: %s", ErrExperimentNotFound, key)
+	}
+	return s.assign(ctx, experiment, userID), nil
+}
+
+// Assignments 사용자의 모든 실험 배정 (실험 키 순)
+func (s *Service) Assignments(ctx context.Context, userID string) []Assignment {
+	experiments := s.Experiments()
+	assignments := make([]Assignment, 0, len(experiments))
+	for _, experiment := range experiments {
+		assignments = append(assignments, s.assign(ctx, experiment, userID))
+	}
+	return assignments
+}
+
+// Expose 사용자가 변형을 실제로 본 순간 호출합니다
+// 참여한 사용자만 ExperimentExposed를 발행하므로, 분석에서는 노출된 사용자끼리 변형을 비교합니다
+func (s *Service) Expose(ctx context.Context, userID, key string) (Assignment, error) {
+	assignment, err := s.Variant(ctx, userID, key)
+	if err != nil || !assignment.Enrolled || s.config.EventBus == nil {
+		return assignment, err
+	}
+	event := NewExposureEvent(Exposure{
+		Experiment: assignment.Experiment,
+		Variant:    assignment.Variant,
+		UserID:     userID,
+		ExposedAt:  s.config.Now(),
+	})
+	if err := s.config.EventBus.Publish(ctx, event); err != nil {
+		return assignment, fmt.Errorf("publish exposure: %w", err)
+	}
+	return assignment, nil
+}
+
+// assign 플래그와 기간을 확인한 뒤 배정합니다 (꺼져 있거나 기간 밖이면 대조군, 미참여)
+func (s *Service) assign(ctx context.Context, experiment Experiment, userID string) Assignment {
+	variant, enrolled := experiment.Control(), false
+	if userID != "" && experiment.RunningAt(s.config.Now()) && s.flagEnabled(ctx, experiment) {
+		variant, enrolled = experiment.Assign(userID)
+	}
+	return Assignment{
+		Experiment: experiment.Key,
+		Variant:    variant.Name,
+		Params:     variant.Params,
+		Enrolled:   enrolled,
+	}
+}
+
+func (s *Service) flagEnabled(ctx context.Context, experiment Experiment) bool {
+	if experiment.Flag == "" || s.config.Flags == nil {
+		return true
+	}
+	return s.config.Flags.IsEnabled(ctx, experiment.Flag)
+}
+
+// Enricher 분석 이벤트에 사용자가 참여 중인 실험 변형을 붙이는 Enricher
+// 분석 파이프라인의 DefaultMetadataEnricher 뒤에 두면 모든 게임플레이 이벤트를 변형별로 나눌 수 있습니다
+func (s *Service) Enricher() analytics.Enricher {
+	return analytics.EnricherFunc(func(ctx context.Context, source cqrs.EventMessage, event *analytics.Event) error {
+		userID, _ := event.Context[analytics.ContextUserID].(string)
+		if userID == "" {
+			userID, _ = source.Metadata()[cqrs.MetadataUserID].(string)
+		}
+		if userID == "" {
+			return nil
+		}
+		variants := make(map[string]string)
+		for _, assignment := range s.Assignments(ctx, userID) {
+			if assignment.Enrolled {
+				variants[assignment.Experiment] = assignment.Variant
+			}
+		}
+		if len(variants) > 0 {
+			event.SetContext(ContextExperiments, variants)
+		}
+		return nil
+	})
+}
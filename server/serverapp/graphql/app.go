@@ -0,0 +1,221 @@
+package graphql
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath GraphQL 엔드포인트 기본 경로
+const DefaultBasePath = "/graphql"
+
+// maxRequestBodySize GraphQL 요청 본문 최대 크기
+const maxRequestBodySize = 1 << 20
+
+// Config GraphQL 게이트웨이 설정
+type Config struct {
+	BasePath          string                          // 라우트 기본 경로 (기본값: /graphql)
+	Schema            *Schema                         // 필수: 노출할 읽기 모델 스키마
+	ReadStore         cqrs.ReadStore                  // 필수: 읽기 모델 조회 저장소
+	QueryDispatcher   cqrs.QueryDispatcher            // 선택: AddQueryField 필드용 쿼리 디스패처
+	Auth              func(http.Handler) http.Handler // 필수: 인증 미들웨어
+	MaxDepth          int                             // 선택 집합 최대 깊이 (기본값: 8)
+	MaxListLimit      int                             // 목록 필드 limit 상한 (기본값: 500)
+	LoaderConcurrency int                             // GetByIDs 미지원 저장소의 동시 조회 수 (기본값: 8)
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Schema == nil {
+		return fmt.Errorf("schema is required")
+	}
+	if c.ReadStore == nil {
+		return fmt.Errorf("read store is required")
+	}
+	if c.Auth == nil {
+		return fmt.Errorf("auth middleware is required")
+	}
+	return nil
+}
+
+// GraphQLApp 읽기 모델을 GraphQL로 노출하는 서버앱 (웹 클라이언트용)
+// 같은 깊이의 참조 필드는 데이터로더로 모아 읽기 저장소에서 일괄 조회합니다
+type GraphQLApp struct {
+	*serverapp.BaseApp
+	config Config
+}
+
+// NewGraphQLApp 새로운 GraphQLApp을 생성합니다
+func NewGraphQLApp(config Config) (*GraphQLApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	if config.MaxDepth <= 0 {
+		config.MaxDepth = DefaultMaxDepth
+	}
+	if config.MaxListLimit <= 0 {
+		config.MaxListLimit = DefaultMaxListLimit
+	}
+
+	return &GraphQLApp{
+		BaseApp: serverapp.NewBaseApp("graphql"),
+		config:  config,
+	}, nil
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *GraphQLApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+
+	mux.Handle(base, protect(http.HandlerFunc(a.serveQuery)))
+	mux.Handle(base+"/schema", protect(http.HandlerFunc(a.serveSchema)))
+
+	log.Printf("[GraphQL] Routes registered under %s", base)
+}
+
+// DescribeAPI GraphQL 엔드포인트 설명 (/openapi.json)
+func (a *GraphQLApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	return []serverapp.APIOperation{
+		{
+			Method:   http.MethodPost,
+			Path:     base,
+			Summary:  "GraphQL 쿼리 실행 (읽기 모델 조회)",
+			Request:  Request{},
+			Response: Response{},
+			Secured:  true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    base,
+			Summary: "GraphQL 쿼리 실행 (쿼리 파라미터)",
+			Parameters: []serverapp.APIParameter{
+				{Name: "query", In: "query", Required: true},
+				{Name: "operationName", In: "query"},
+				{Name: "variables", In: "query", Description: "JSON 인코딩된 변수"},
+			},
+			Response: Response{},
+			Secured:  true,
+		},
+		{Method: http.MethodGet, Path: base + "/schema", Summary: "GraphQL 스키마 SDL", Secured: true},
+	}
+}
+
+// Request GraphQL HTTP 요청 본문
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Execute 쿼리를 실행합니다
+// 파싱 또는 검증에 실패하면 data 없이 errors만 담긴 응답과 false를 반환합니다
+func (a *GraphQLApp) Execute(ctx context.Context, request Request) (*Response, bool) {
+	doc, err := parseDocument(request.Query)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}, false
+	}
+	if request.OperationName != "" && doc.name != "" && request.OperationName != doc.name {
+		return &Response{Errors: []Error{{Message: fmt.Sprintf("unknown operation %q", request.OperationName)}}}, false
+	}
+	if errs := a.config.Schema.validate(doc, a.config.MaxDepth); len(errs) > 0 {
+		return &Response{Errors: errs}, false
+	}
+
+	variables := make(map[string]interface{}, len(doc.variables))
+	for _, definition := range doc.variables {
+		if value, exists := request.Variables[definition.name]; exists {
+			variables[definition.name] = value
+		} else if definition.hasDefault {
+			variables[definition.name] = definition.defaultValue
+		}
+	}
+
+	exec := &executor{
+		schema:       a.config.Schema,
+		store:        a.config.ReadStore,
+		dispatcher:   a.config.QueryDispatcher,
+		loader:       newDataLoader(a.config.ReadStore, a.config.LoaderConcurrency),
+		variables:    variables,
+		maxListLimit: a.config.MaxListLimit,
+	}
+	data := exec.execute(ctx, doc)
+	return &Response{Data: data, Errors: exec.errors}, true
+}
+
+// serveQuery GET(query 파라미터) 또는 POST(JSON 본문) 쿼리 요청 처리
+func (a *GraphQLApp) serveQuery(w http.ResponseWriter, r *http.Request) {
+	var request Request
+
+	switch r.Method {
+	case http.MethodGet:
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if raw := r.URL.Query().Get("variables"); raw != "" {
+			if err := decodeJSON(strings.NewReader(raw), &request.Variables); err != nil {
+				sendErrors(w, http.StatusBadRequest, "invalid variables: "+err.Error())
+				return
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+		if err := decodeJSON(body, &request); err != nil {
+			sendErrors(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+	default:
+		sendErrors(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if strings.TrimSpace(request.Query) == "" {
+		sendErrors(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	response, ok := a.Execute(r.Context(), request)
+	statusCode := http.StatusOK
+	if !ok {
+		statusCode = http.StatusBadRequest
+	}
+	sendJSON(w, statusCode, response)
+}
+
+// serveSchema 스키마 SDL 제공 (웹 클라이언트 타입 생성용)
+func (a *GraphQLApp) serveSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrors(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(a.config.Schema.SDL()))
+}
+
+// decodeJSON 숫자를 json.Number로 보존하며 디코딩합니다 (Int 인자 정밀도 유지)
+func decodeJSON(reader io.Reader, target interface{}) error {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func sendErrors(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, Response{Errors: []Error{{Message: message}}})
+}
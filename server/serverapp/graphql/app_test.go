@@ -0,0 +1,471 @@
+package graphql
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testGuildView struct {
+	*cqrs.BaseReadModel
+	Name        string `json:"name"`
+	MemberCount int    `json:"member_count"`
+	FounderID   string `json:"founder_id"`
+}
+
+type testMemberView struct {
+	*cqrs.BaseReadModel
+	GuildID string `json:"guild_id"`
+	UserID  string `json:"user_id"`
+	Role    string `json:"role"`
+}
+
+type testUserView struct {
+	*cqrs.BaseReadModel
+	Name      string   `json:"name"`
+	FriendIDs []string `json:"friend_ids"`
+}
+
+type testLeaderboardEntry struct {
+	Rank   int    `json:"rank"`
+	UserID string `json:"user_id"`
+	Score  int64  `json:"score"`
+}
+
+// countingReadStore GetByID 호출 수를 세는 읽기 저장소
+type countingReadStore struct {
+	cqrs.ReadStore
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *countingReadStore) GetByID(ctx context.Context, id string, modelType string) (cqrs.ReadModel, error) {
+	s.mu.Lock()
+	s.calls[modelType+":"+id]++
+	s.mu.Unlock()
+	return s.ReadStore.GetByID(ctx, id, modelType)
+}
+
+// batchReadStore GetByIDs 호출을 기록하는 배치 지원 읽기 저장소
+type batchReadStore struct {
+	cqrs.ReadStore
+	batches [][]string
+}
+
+func (s *batchReadStore) GetByIDs(ctx context.Context, ids []string, modelType string) ([]cqrs.ReadModel, error) {
+	s.batches = append(s.batches, append([]string(nil), ids...))
+	var models []cqrs.ReadModel
+	for _, id := range ids {
+		if model, err := s.ReadStore.GetByID(ctx, id, modelType); err == nil {
+			models = append(models, model)
+		}
+	}
+	return models, nil
+}
+
+// leaderboardHandler 리더보드 쿼리 테스트 핸들러
+type leaderboardHandler struct{}
+
+func (h *leaderboardHandler) Handle(ctx context.Context, query cqrs.Query) (*cqrs.QueryResult, error) {
+	limit := query.GetCriteria().(int64)
+	entries := []testLeaderboardEntry{{Rank: 1, UserID: "user-1", Score: 900}, {Rank: 2, UserID: "user-2", Score: 700}, {Rank: 3, UserID: "user-3", Score: 500}}
+	return &cqrs.QueryResult{Success: true, Data: entries[:limit]}, nil
+}
+
+func (h *leaderboardHandler) CanHandle(queryType string) bool { return queryType == "GetLeaderboard" }
+func (h *leaderboardHandler) GetHandlerName() string          { return "leaderboard" }
+
+func newTestSchema(t *testing.T) *Schema {
+	schema := NewSchema()
+	require.NoError(t, schema.AddObject(ObjectConfig{Name: "UserView", Sample: testUserView{}, ModelType: "UserView", ByIDField: "user"}))
+	require.NoError(t, schema.AddObject(ObjectConfig{Name: "GuildView", Sample: testGuildView{}, ModelType: "GuildView", ByIDField: "guild", ListField: "guilds"}))
+	require.NoError(t, schema.AddObject(ObjectConfig{Name: "MemberView", Sample: testMemberView{}, ModelType: "MemberView", ListField: "members"}))
+	require.NoError(t, schema.AddObject(ObjectConfig{Name: "LeaderboardEntry", Sample: testLeaderboardEntry{}}))
+
+	require.NoError(t, schema.AddRelation(RelationConfig{Type: "MemberView", Field: "guild", Target: "GuildView", KeyField: "guild_id"}))
+	require.NoError(t, schema.AddRelation(RelationConfig{Type: "MemberView", Field: "user", Target: "UserView", KeyField: "user_id"}))
+	require.NoError(t, schema.AddRelation(RelationConfig{Type: "GuildView", Field: "founder", Target: "UserView", KeyField: "founder_id"}))
+	require.NoError(t, schema.AddRelation(RelationConfig{Type: "UserView", Field: "friends", Target: "UserView", KeyField: "friend_ids"}))
+	require.NoError(t, schema.AddRelation(RelationConfig{Type: "LeaderboardEntry", Field: "user", Target: "UserView", KeyField: "user_id"}))
+
+	require.NoError(t, schema.AddQueryField(QueryFieldConfig{
+		Name: "leaderboard",
+		Type: "LeaderboardEntry",
+		List: true,
+		Args: []Argument{{Name: "limit", Type: TypeRef{Name: TypeInt, NonNull: true}}},
+		Build: func(ctx context.Context, args map[string]interface{}) (cqrs.Query, error) {
+			return cqrs.NewBaseQuery("GetLeaderboard", args["limit"]), nil
+		},
+	}))
+	return schema
+}
+
+func newTestStore(t *testing.T) cqrs.ReadStore {
+	store := cqrs.NewInMemoryReadStore()
+	ctx := context.Background()
+
+	save := func(id, modelType string, data map[string]interface{}) {
+		require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel(id, modelType, data)))
+	}
+	save("user-1", "UserView", map[string]interface{}{"name": "Alice", "friend_ids": []string{"user-2", "user-3"}})
+	save("user-2", "UserView", map[string]interface{}{"name": "Bob", "friend_ids": []string{"user-1"}})
+	save("user-3", "UserView", map[string]interface{}{"name": "Carol", "friend_ids": []string{}})
+	save("guild-1", "GuildView", map[string]interface{}{"name": "Allies", "member_count": 3, "founder_id": "user-1"})
+	save("guild-1:user-1", "MemberView", map[string]interface{}{"guild_id": "guild-1", "user_id": "user-1", "role": "leader"})
+	save("guild-1:user-2", "MemberView", map[string]interface{}{"guild_id": "guild-1", "user_id": "user-2", "role": "member"})
+	save("guild-1:user-3", "MemberView", map[string]interface{}{"guild_id": "guild-1", "user_id": "user-3", "role": "member"})
+	return store
+}
+
+// passThrough 테스트용 인증 미들웨어 (모든 요청 통과)
+func passThrough(next http.Handler) http.Handler { return next }
+
+func newTestApp(t *testing.T, store cqrs.ReadStore) *GraphQLApp {
+	dispatcher := cqrs.NewInMemoryQueryDispatcher()
+	require.NoError(t, dispatcher.RegisterHandler("GetLeaderboard", &leaderboardHandler{}))
+
+	app, err := NewGraphQLApp(Config{Schema: newTestSchema(t), ReadStore: store, QueryDispatcher: dispatcher, Auth: passThrough})
+	require.NoError(t, err)
+	return app
+}
+
+func postQuery(t *testing.T, app *GraphQLApp, body string) (int, map[string]interface{}) {
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec.Code, response
+}
+
+func TestGraphQLApp_ResolvesReadModelsWithAliasesAndVariables(t *testing.T) {
+	// Arrange
+	app := newTestApp(t, newTestStore(t))
+	body := `{"query":"query Guild($id: ID!) { g: guild(id: $id) { __typename id name member_count founder { name } } missing: guild(id: \"nope\") { id } }","variables":{"id":"guild-1"}}`
+
+	// Act
+	code, response := postQuery(t, app, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response["errors"])
+	data := response["data"].(map[string]interface{})
+	guild := data["g"].(map[string]interface{})
+	assert.Equal(t, "GuildView", guild["__typename"])
+	assert.Equal(t, "guild-1", guild["id"])
+	assert.Equal(t, "Allies", guild["name"])
+	assert.Equal(t, float64(3), guild["member_count"])
+	assert.Equal(t, "Alice", guild["founder"].(map[string]interface{})["name"])
+	assert.Contains(t, data, "missing")
+	assert.Nil(t, data["missing"])
+}
+
+func TestGraphQLApp_BatchesRelationsPerLevel(t *testing.T) {
+	// Arrange
+	store := &countingReadStore{ReadStore: newTestStore(t), calls: make(map[string]int)}
+	app := newTestApp(t, store)
+	body := `{"query":"{ members(limit: 10) { role guild { name } user { name friends { name } } } }"}`
+
+	// Act
+	code, response := postQuery(t, app, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response["errors"])
+	members := response["data"].(map[string]interface{})["members"].([]interface{})
+	assert.Len(t, members, 3)
+
+	// 세 멤버가 같은 길드와 겹치는 친구를 참조해도 모델마다 한 번만 조회
+	for key, count := range store.calls {
+		assert.Equal(t, 1, count, "read model %s loaded more than once", key)
+	}
+	assert.Equal(t, 1, store.calls["GuildView:guild-1"])
+	assert.Equal(t, 1, store.calls["UserView:user-1"])
+}
+
+func TestGraphQLApp_UsesBatchReadStore(t *testing.T) {
+	// Arrange
+	store := &batchReadStore{ReadStore: newTestStore(t)}
+	app := newTestApp(t, store)
+	body := `{"query":"{ a: user(id: \"user-1\") { friends { name } } b: user(id: \"user-2\") { name } }"}`
+
+	// Act
+	code, response := postQuery(t, app, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response["errors"])
+	require.Len(t, store.batches, 2)
+	assert.ElementsMatch(t, []string{"user-1", "user-2"}, store.batches[0])
+	assert.Equal(t, []string{"user-3"}, store.batches[1]) // user-2는 이미 캐시됨
+
+	friends := response["data"].(map[string]interface{})["a"].(map[string]interface{})["friends"].([]interface{})
+	assert.Len(t, friends, 2)
+}
+
+func TestGraphQLApp_DispatchesQueryFields(t *testing.T) {
+	// Arrange
+	app := newTestApp(t, newTestStore(t))
+	body := `{"query":"{ leaderboard(limit: 2) { rank score user { name } } }"}`
+
+	// Act
+	code, response := postQuery(t, app, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response["errors"])
+	entries := response["data"].(map[string]interface{})["leaderboard"].([]interface{})
+	require.Len(t, entries, 2)
+	first := entries[0].(map[string]interface{})
+	assert.Equal(t, float64(1), first["rank"])
+	assert.Equal(t, float64(900), first["score"])
+	assert.Equal(t, "Alice", first["user"].(map[string]interface{})["name"])
+}
+
+func TestGraphQLApp_ReportsErrors(t *testing.T) {
+	app := newTestApp(t, newTestStore(t))
+
+	t.Run("syntax error", func(t *testing.T) {
+		code, response := postQuery(t, app, `{"query":"{ guild(id: \"guild-1\") { name }"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.NotContains(t, response, "data")
+		assert.NotEmpty(t, response["errors"])
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		code, response := postQuery(t, app, `{"query":"{ guild(id: \"guild-1\") { password } }"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		errs := response["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].(map[string]interface{})["message"], `"password"`)
+	})
+
+	t.Run("object without selection", func(t *testing.T) {
+		code, _ := postQuery(t, app, `{"query":"{ guild(id: \"guild-1\") }"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("invalid argument is a field error", func(t *testing.T) {
+		code, response := postQuery(t, app, `{"query":"{ guilds(limit: 100000) { name } guild(id: \"guild-1\") { name } }"}`)
+		assert.Equal(t, http.StatusOK, code)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, []interface{}{}, data["guilds"])
+		assert.Equal(t, "Allies", data["guild"].(map[string]interface{})["name"])
+		errs := response["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		assert.Equal(t, []interface{}{"guilds"}, errs[0].(map[string]interface{})["path"])
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		query := `{ user(id: \"user-1\") { friends { friends { friends { friends { friends { friends { friends { friends { name } } } } } } } } } }`
+		code, _ := postQuery(t, app, `{"query":"`+query+`"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+// introspectionQuery GraphiQL과 graphql-js getIntrospectionQuery()가 보내는 표준 인트로스펙션 쿼리
+const introspectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives { name description locations args { ...InputValue } }
+  }
+}
+fragment FullType on __Type {
+  kind name description
+  fields(includeDeprecated: true) { name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+fragment TypeRef on __Type {
+  kind name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } }
+}`
+
+// findByName 인트로스펙션 목록에서 name이 같은 항목
+func findByName(t *testing.T, items interface{}, name string) map[string]interface{} {
+	for _, item := range items.([]interface{}) {
+		if object := item.(map[string]interface{}); object["name"] == name {
+			return object
+		}
+	}
+	t.Fatalf("%s not found", name)
+	return nil
+}
+
+func TestGraphQLApp_AnswersIntrospectionQuery(t *testing.T) {
+	// Arrange
+	app := newTestApp(t, newTestStore(t))
+
+	// Act
+	response, ok := app.Execute(context.Background(), Request{Query: introspectionQuery, OperationName: "IntrospectionQuery"})
+
+	// Assert
+	require.True(t, ok)
+	require.Empty(t, response.Errors)
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	schema := decoded["data"].(map[string]interface{})["__schema"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Query"}, schema["queryType"])
+	assert.Nil(t, schema["mutationType"])
+	assert.Equal(t, []interface{}{}, schema["directives"])
+
+	scalar := findByName(t, schema["types"], "JSON")
+	assert.Equal(t, "SCALAR", scalar["kind"])
+	assert.Nil(t, scalar["fields"])
+
+	guild := findByName(t, schema["types"], "GuildView")
+	assert.Equal(t, "OBJECT", guild["kind"])
+	assert.Equal(t, []interface{}{}, guild["interfaces"])
+	id := findByName(t, guild["fields"], "id")
+	assert.Equal(t, map[string]interface{}{"kind": "NON_NULL", "name": nil, "ofType": map[string]interface{}{"kind": "SCALAR", "name": "ID", "ofType": nil}}, id["type"])
+
+	query := findByName(t, schema["types"], "Query")
+	guilds := findByName(t, query["fields"], "guilds")
+	assert.Len(t, guilds["args"], 4)
+	listType := guilds["type"].(map[string]interface{})["ofType"].(map[string]interface{})
+	assert.Equal(t, "LIST", listType["kind"])
+	assert.Equal(t, "GuildView", listType["ofType"].(map[string]interface{})["name"])
+	leaderboard := findByName(t, query["fields"], "leaderboard")
+	assert.Equal(t, "limit", leaderboard["args"].([]interface{})[0].(map[string]interface{})["name"])
+
+	// 인트로스펙션 필드는 쿼리 타입의 필드 목록에 나오지 않음
+	for _, field := range query["fields"].([]interface{}) {
+		assert.NotContains(t, field.(map[string]interface{})["name"], "__")
+	}
+}
+
+func TestGraphQLApp_IntrospectsSingleType(t *testing.T) {
+	// Arrange
+	app := newTestApp(t, newTestStore(t))
+	body := `{"query":"{ member: __type(name: \"MemberView\") { __typename name fields { name type { name kind } } } missing: __type(name: \"Nope\") { name } }"}`
+
+	// Act
+	code, response := postQuery(t, app, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response["errors"])
+	data := response["data"].(map[string]interface{})
+	member := data["member"].(map[string]interface{})
+	assert.Equal(t, "__Type", member["__typename"])
+	assert.Equal(t, "MemberView", member["name"])
+	guild := findByName(t, member["fields"], "guild")
+	assert.Equal(t, map[string]interface{}{"name": "GuildView", "kind": "OBJECT"}, guild["type"])
+	assert.Contains(t, data, "missing")
+	assert.Nil(t, data["missing"])
+}
+
+func TestGraphQLApp_ExpandsFragments(t *testing.T) {
+	// Arrange
+	app := newTestApp(t, newTestStore(t))
+	query := `
+query { guild(id: "guild-1") { id ...GuildFields founder { ... on UserView { name } } } }
+fragment GuildFields on GuildView { name founder { id } ... { member_count } }`
+
+	// Act
+	response, ok := app.Execute(context.Background(), Request{Query: query})
+
+	// Assert
+	require.True(t, ok)
+	require.Empty(t, response.Errors)
+	raw, err := json.Marshal(response.Data)
+	require.NoError(t, err)
+	// fragment는 spread 위치에 펼쳐지고, 같은 응답 키의 선택은 처음 선택된 위치로 병합
+	assert.Equal(t, `{"guild":{"id":"guild-1","name":"Allies","founder":{"id":"user-1","name":"Alice"},"member_count":3}}`, string(raw))
+}
+
+func TestGraphQLApp_RejectsInvalidFragments(t *testing.T) {
+	app := newTestApp(t, newTestStore(t))
+
+	for name, query := range map[string]string{
+		"unknown fragment":  `{ guild(id: "guild-1") { ...Missing } }`,
+		"cyclic fragment":   `{ guild(id: "guild-1") { ...A } } fragment A on GuildView { ...B } fragment B on GuildView { ...A }`,
+		"wrong type":        `{ guild(id: "guild-1") { ...U } } fragment U on UserView { name }`,
+		"conflicting alias": `{ guild(id: "guild-1") { name ... { name: member_count } } }`,
+		"directive":         `{ guild(id: "guild-1") { ... on GuildView @include(if: true) { name } } }`,
+		"fragment only":     `fragment A on GuildView { name }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			response, ok := app.Execute(context.Background(), Request{Query: query})
+			assert.False(t, ok)
+			assert.Nil(t, response.Data)
+			assert.NotEmpty(t, response.Errors)
+		})
+	}
+}
+
+func TestGraphQLApp_RejectsExponentialFragmentExpansion(t *testing.T) {
+	// Arrange - 각 fragment가 다음 fragment를 두 번 펼치면 필드 수가 2^n으로 늘어남
+	app := newTestApp(t, newTestStore(t))
+	var query strings.Builder
+	query.WriteString(`{ guild(id: "guild-1") { ...F0 } }`)
+	const depth = 30
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&query, " fragment F%d on GuildView { a: founder { ...F%d } b: founder { ...F%d } }", i, i+1, i+1)
+	}
+	fmt.Fprintf(&query, " fragment F%d on UserView { name }", depth)
+
+	// Act
+	start := time.Now()
+	response, ok := app.Execute(context.Background(), Request{Query: query.String()})
+
+	// Assert
+	assert.False(t, ok)
+	require.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, "more than")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestNewGraphQLApp_RequiresAuth(t *testing.T) {
+	_, err := NewGraphQLApp(Config{Schema: newTestSchema(t), ReadStore: newTestStore(t)})
+	assert.Error(t, err)
+}
+
+func TestSchema_SDL(t *testing.T) {
+	// Arrange
+	schema := newTestSchema(t)
+
+	// Act
+	sdl := schema.SDL()
+
+	// Assert
+	assert.Contains(t, sdl, "type GuildView {\n  id: ID!\n  name: String\n  member_count: Int\n  founder_id: String\n  founder: UserView\n}")
+	assert.Contains(t, sdl, "friend_ids: [String]")
+	assert.Contains(t, sdl, "friends: [UserView]")
+	assert.Contains(t, sdl, "guild(id: ID!): GuildView")
+	assert.Contains(t, sdl, "guilds(limit: Int, offset: Int, sortBy: String, descending: Boolean): [GuildView]!")
+	assert.Contains(t, sdl, "leaderboard(limit: Int!): [LeaderboardEntry]!")
+}
+
+func TestSchema_RejectsInvalidDefinitions(t *testing.T) {
+	schema := newTestSchema(t)
+
+	assert.Error(t, schema.AddObject(ObjectConfig{Name: "GuildView", Sample: testGuildView{}}))
+	assert.Error(t, schema.AddObject(ObjectConfig{Name: "Orphan", Sample: testGuildView{}, ByIDField: "orphan"}))
+	assert.Error(t, schema.AddRelation(RelationConfig{Type: "MemberView", Field: "leader", Target: "LeaderboardEntry", KeyField: "user_id"}))
+	assert.Error(t, schema.AddRelation(RelationConfig{Type: "MemberView", Field: "owner", Target: "UserView", KeyField: "owner_id"}))
+}
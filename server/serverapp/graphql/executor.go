@@ -0,0 +1,555 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// 기본 실행 제한
+const (
+	DefaultMaxDepth     = 8
+	DefaultListLimit    = 50
+	DefaultMaxListLimit = 500
+)
+
+// Error GraphQL 응답 오류
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Response GraphQL 응답 (검증 실패 시 data 없이 errors만 포함)
+type Response struct {
+	Data   *orderedObject `json:"data,omitempty"`
+	Errors []Error        `json:"errors,omitempty"`
+}
+
+// orderedObject 선택 순서를 유지하는 응답 객체
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedObject(size int) *orderedObject {
+	return &orderedObject{keys: make([]string, 0, size), values: make(map[string]interface{}, size)}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON 선택 순서대로 키를 출력합니다
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// objectTask 한 깊이에서 완성해야 할 응답 객체
+type objectTask struct {
+	out        *orderedObject
+	objectType *ObjectType
+	source     map[string]interface{}
+	selections []*selection
+	path       []interface{}
+}
+
+// executor 요청 하나의 실행 상태
+type executor struct {
+	schema       *Schema
+	store        cqrs.ReadStore
+	dispatcher   cqrs.QueryDispatcher
+	loader       *dataLoader
+	variables    map[string]interface{}
+	maxListLimit int
+	errors       []Error
+
+	// 인트로스펙션 필드를 선택한 요청에서만 만들어집니다
+	introspected bool
+	metaSchema   map[string]interface{}
+	metaTypes    map[string]map[string]interface{}
+}
+
+// validate 실행 전에 선택 집합을 스키마와 대조합니다
+func (s *Schema) validate(doc *document, maxDepth int) []Error {
+	var errs []Error
+	var walk func(objectType *ObjectType, selections []*selection, path []interface{}, maxDepth int)
+	walk = func(objectType *ObjectType, selections []*selection, path []interface{}, maxDepth int) {
+		if len(path) >= maxDepth {
+			errs = append(errs, Error{Message: fmt.Sprintf("query exceeds the maximum depth of %d", maxDepth), Path: path})
+			return
+		}
+		for _, sel := range selections {
+			fieldPath := appendPath(path, sel.responseKey())
+			if sel.on != "" && sel.on != objectType.Name {
+				errs = append(errs, Error{Message: fmt.Sprintf("fragment on %s cannot be spread on type %q", sel.on, objectType.Name), Path: fieldPath})
+				continue
+			}
+			if sel.name == "__typename" {
+				if len(sel.selections) > 0 || len(sel.arguments) > 0 {
+					errs = append(errs, Error{Message: "__typename cannot have arguments or selections", Path: fieldPath})
+				}
+				continue
+			}
+
+			field, exists := objectType.Field(sel.name)
+			if !exists && objectType == s.query {
+				field, exists = s.rootField(sel.name)
+			}
+			if !exists {
+				errs = append(errs, Error{Message: fmt.Sprintf("cannot query field %q on type %q", sel.name, objectType.Name), Path: fieldPath})
+				continue
+			}
+			for name := range sel.arguments {
+				if _, known := field.argIndex[name]; !known {
+					errs = append(errs, Error{Message: fmt.Sprintf("unknown argument %q on field %s.%s", name, objectType.Name, field.Name), Path: fieldPath})
+				}
+			}
+
+			switch {
+			case field.target == nil && len(sel.selections) > 0:
+				errs = append(errs, Error{Message: fmt.Sprintf("field %q of type %s must not have a selection", field.Name, field.Type), Path: fieldPath})
+			case field.target != nil && len(sel.selections) == 0:
+				errs = append(errs, Error{Message: fmt.Sprintf("field %q of type %s must have a selection of subfields", field.Name, field.Type), Path: fieldPath})
+			case field.kind == fieldIntrospection:
+				walk(field.target, sel.selections, fieldPath, introspectionMaxDepth)
+			case field.target != nil:
+				walk(field.target, sel.selections, fieldPath, maxDepth)
+			}
+		}
+	}
+	walk(s.query, doc.selections, nil, maxDepth)
+	return errs
+}
+
+// execute 검증된 문서를 실행합니다
+// 루트부터 한 깊이씩 내려가며, 각 깊이에서 필요한 참조 키를 모아 데이터로더로 일괄 조회합니다
+func (e *executor) execute(ctx context.Context, doc *document) *orderedObject {
+	data := newOrderedObject(len(doc.selections))
+	next := e.resolveRoot(ctx, data, doc.selections)
+
+	for len(next) > 0 {
+		if ctx.Err() != nil {
+			e.errors = append(e.errors, Error{Message: ctx.Err().Error()})
+			break
+		}
+		next = e.resolveLevel(ctx, next)
+	}
+	return data
+}
+
+// resolveRoot 루트 필드를 해석하고 다음 깊이의 작업을 반환합니다
+func (e *executor) resolveRoot(ctx context.Context, data *orderedObject, selections []*selection) []objectTask {
+	// ID 조회 루트 필드는 모델 타입별로 모아서 한 번에 조회
+	args := make([]map[string]interface{}, len(selections))
+	argErrs := make([]error, len(selections))
+	byType := make(map[string][]string)
+	for i, sel := range selections {
+		field, exists := e.schema.rootField(sel.name)
+		if !exists {
+			continue
+		}
+		args[i], argErrs[i] = e.coerceArguments(field, sel)
+		if argErrs[i] == nil && field.kind == fieldRootByID {
+			byType[field.target.ModelType] = append(byType[field.target.ModelType], args[i]["id"].(string))
+		}
+	}
+	for modelType, ids := range byType {
+		e.loader.load(ctx, modelType, ids)
+	}
+
+	var next []objectTask
+	for i, sel := range selections {
+		key := sel.responseKey()
+		path := []interface{}{key}
+		if sel.name == "__typename" {
+			data.set(key, e.schema.query.Name)
+			continue
+		}
+
+		field, _ := e.schema.rootField(sel.name)
+		if argErrs[i] != nil {
+			e.fail(path, argErrs[i])
+			data.set(key, e.emptyValue(field))
+			continue
+		}
+
+		var objects []map[string]interface{}
+		var err error
+		switch field.kind {
+		case fieldRootByID:
+			result := e.loader.get(field.target.ModelType, args[i]["id"].(string))
+			err = result.err
+			if result.object != nil {
+				objects = []map[string]interface{}{result.object}
+			}
+		case fieldRootList:
+			objects, err = e.queryList(ctx, field, args[i])
+		case fieldRootQuery:
+			objects, err = e.dispatch(ctx, field, args[i])
+		case fieldIntrospection:
+			objects = introspectionObjects(e.introspect(field, args[i]))
+		}
+		if err != nil {
+			e.fail(path, err)
+			data.set(key, e.emptyValue(field))
+			continue
+		}
+
+		value, tasks := buildObjects(field, sel, objects, path)
+		data.set(key, value)
+		next = append(next, tasks...)
+	}
+	return next
+}
+
+// resolveLevel 한 깊이의 객체들을 채우고 다음 깊이의 작업을 반환합니다
+func (e *executor) resolveLevel(ctx context.Context, tasks []objectTask) []objectTask {
+	// 1) 이 깊이의 참조 키를 모델 타입별로 수집해 일괄 조회
+	byType := make(map[string][]string)
+	for _, task := range tasks {
+		for _, sel := range task.selections {
+			field, exists := task.objectType.Field(sel.name)
+			if !exists || field.kind != fieldRelation {
+				continue
+			}
+			byType[field.target.ModelType] = append(byType[field.target.ModelType], relationKeys(task.source[field.source])...)
+		}
+	}
+	for modelType, ids := range byType {
+		e.loader.load(ctx, modelType, ids)
+	}
+
+	// 2) 값을 채우고 하위 객체를 다음 깊이로 넘김
+	var next []objectTask
+	for _, task := range tasks {
+		for _, sel := range task.selections {
+			key := sel.responseKey()
+			if sel.name == "__typename" {
+				task.out.set(key, task.objectType.Name)
+				continue
+			}
+
+			field, _ := task.objectType.Field(sel.name)
+			if field.kind == fieldValue {
+				task.out.set(key, task.source[field.source])
+				continue
+			}
+
+			path := appendPath(task.path, key)
+			if field.kind == fieldIntrospection {
+				raw := task.source[field.source]
+				if raw == nil {
+					task.out.set(key, nil)
+					continue
+				}
+				value, children := buildObjects(field, sel, introspectionObjects(raw), path)
+				task.out.set(key, value)
+				next = append(next, children...)
+				continue
+			}
+
+			var objects []map[string]interface{}
+			for _, id := range relationKeys(task.source[field.source]) {
+				result := e.loader.get(field.target.ModelType, id)
+				if result.err != nil {
+					e.fail(path, result.err)
+					continue
+				}
+				if result.object != nil {
+					objects = append(objects, result.object)
+				}
+			}
+
+			value, children := buildObjects(field, sel, objects, path)
+			task.out.set(key, value)
+			next = append(next, children...)
+		}
+	}
+	return next
+}
+
+// buildObjects 조회한 객체로 응답 값(객체 또는 목록)과 하위 작업을 만듭니다
+func buildObjects(field *Field, sel *selection, objects []map[string]interface{}, path []interface{}) (interface{}, []objectTask) {
+	tasks := make([]objectTask, 0, len(objects))
+	newTask := func(source map[string]interface{}, taskPath []interface{}) *orderedObject {
+		out := newOrderedObject(len(sel.selections))
+		tasks = append(tasks, objectTask{out: out, objectType: field.target, source: source, selections: sel.selections, path: taskPath})
+		return out
+	}
+
+	if !field.Type.List {
+		if len(objects) == 0 {
+			return nil, nil
+		}
+		return newTask(objects[0], path), tasks
+	}
+
+	list := make([]interface{}, len(objects))
+	for i, object := range objects {
+		list[i] = newTask(object, appendPath(path, i))
+	}
+	return list, tasks
+}
+
+// introspect 인트로스펙션 루트 필드 값 (__schema 또는 __type)
+func (e *executor) introspect(field *Field, args map[string]interface{}) interface{} {
+	if !e.introspected {
+		e.metaSchema, e.metaTypes = e.schema.introspection()
+		e.introspected = true
+	}
+	if field.Name == "__type" {
+		if metaType, exists := e.metaTypes[args["name"].(string)]; exists {
+			return metaType
+		}
+		return nil
+	}
+	return e.metaSchema
+}
+
+// queryList 읽기 저장소에서 모델 타입의 목록을 조회합니다
+func (e *executor) queryList(ctx context.Context, field *Field, args map[string]interface{}) ([]map[string]interface{}, error) {
+	criteria := cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": field.target.ModelType},
+		Limit:   DefaultListLimit,
+	}
+	if limit, ok := args["limit"].(int64); ok {
+		if limit < 1 || limit > int64(e.maxListLimit) {
+			return nil, fmt.Errorf("limit must be between 1 and %d", e.maxListLimit)
+		}
+		criteria.Limit = int(limit)
+	}
+	if offset, ok := args["offset"].(int64); ok {
+		if offset < 0 {
+			return nil, fmt.Errorf("offset must not be negative")
+		}
+		criteria.Offset = int(offset)
+	}
+	if sortBy, ok := args["sortBy"].(string); ok {
+		if _, exists := field.target.Field(sortBy); !exists {
+			return nil, fmt.Errorf("cannot sort %s by unknown field %q", field.target.Name, sortBy)
+		}
+		criteria.SortBy = sortBy
+	}
+	if descending, ok := args["descending"].(bool); ok && descending {
+		criteria.SortOrder = cqrs.Descending
+	}
+
+	models, err := e.store.Query(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]map[string]interface{}, 0, len(models))
+	for _, model := range models {
+		objects = append(objects, e.loader.prime(model))
+	}
+	return objects, nil
+}
+
+// dispatch 쿼리 디스패처에 쿼리를 위임하고 결과를 객체로 변환합니다
+func (e *executor) dispatch(ctx context.Context, field *Field, args map[string]interface{}) ([]map[string]interface{}, error) {
+	if e.dispatcher == nil {
+		return nil, fmt.Errorf("field %q requires a query dispatcher", field.Name)
+	}
+
+	query, err := field.buildFn(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := e.dispatcher.Dispatch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	if !result.Success && result.Error != nil {
+		return nil, result.Error
+	}
+	return e.resultObjects(result.Data), nil
+}
+
+// resultObjects 쿼리 결과 데이터를 객체 목록으로 변환합니다 (읽기 모델, 구조체, 맵 또는 그 슬라이스)
+func (e *executor) resultObjects(data interface{}) []map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	if model, ok := data.(cqrs.ReadModel); ok {
+		return []map[string]interface{}{e.loader.prime(model)}
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		objects := make([]map[string]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			objects = append(objects, e.resultObjects(value.Index(i).Interface())...)
+		}
+		return objects
+	}
+
+	if object, ok := toJSONValue(data).(map[string]interface{}); ok {
+		return []map[string]interface{}{object}
+	}
+	return nil
+}
+
+// coerceArguments 인자 리터럴과 변수를 필드 인자 타입으로 변환합니다
+func (e *executor) coerceArguments(field *Field, sel *selection) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(field.Args))
+	for _, arg := range field.Args {
+		raw, exists := sel.arguments[arg.Name]
+		if ref, isVariable := raw.(variableRef); isVariable {
+			raw, exists = e.variables[string(ref)]
+		}
+		if !exists || raw == nil {
+			if arg.Type.NonNull {
+				return nil, fmt.Errorf("argument %q of type %s is required", arg.Name, arg.Type)
+			}
+			continue
+		}
+
+		value, err := coerceValue(raw, arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %v", arg.Name, err)
+		}
+		args[arg.Name] = value
+	}
+	return args, nil
+}
+
+// coerceValue 단일 값을 스칼라 타입으로 변환합니다
+func coerceValue(raw interface{}, typeRef TypeRef) (interface{}, error) {
+	if ref, isVariable := raw.(variableRef); isVariable {
+		return nil, fmt.Errorf("variable $%s is not allowed here", string(ref))
+	}
+
+	if typeRef.List {
+		items, ok := raw.([]interface{})
+		if !ok {
+			items = []interface{}{raw}
+		}
+		element := TypeRef{Name: typeRef.Name}
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			value, err := coerceValue(item, element)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	if enum, ok := raw.(enumValue); ok {
+		raw = string(enum)
+	}
+
+	switch typeRef.Name {
+	case TypeString:
+		if value, ok := raw.(string); ok {
+			return value, nil
+		}
+	case TypeID:
+		switch value := raw.(type) {
+		case string:
+			return value, nil
+		case int64:
+			return strconv.FormatInt(value, 10), nil
+		case json.Number:
+			return value.String(), nil
+		}
+	case TypeInt:
+		switch value := raw.(type) {
+		case int64:
+			return value, nil
+		case json.Number:
+			if parsed, err := value.Int64(); err == nil {
+				return parsed, nil
+			}
+		case float64:
+			if value == math.Trunc(value) && math.Abs(value) <= 1<<53 {
+				return int64(value), nil
+			}
+		}
+	case TypeFloat:
+		switch value := raw.(type) {
+		case float64:
+			return value, nil
+		case int64:
+			return float64(value), nil
+		case json.Number:
+			if parsed, err := value.Float64(); err == nil {
+				return parsed, nil
+			}
+		}
+	case TypeBoolean:
+		if value, ok := raw.(bool); ok {
+			return value, nil
+		}
+	case TypeJSON:
+		return raw, nil
+	}
+	return nil, fmt.Errorf("expected %s, got %v", typeRef.Name, raw)
+}
+
+// emptyValue 해석에 실패한 필드의 값 (목록은 빈 목록, 그 외 null)
+func (e *executor) emptyValue(field *Field) interface{} {
+	if field.Type.List && field.Type.NonNull {
+		return []interface{}{}
+	}
+	return nil
+}
+
+func (e *executor) fail(path []interface{}, err error) {
+	e.errors = append(e.errors, Error{Message: err.Error(), Path: path})
+}
+
+// relationKeys 참조 필드 값에서 대상 ID 목록을 추출합니다
+func relationKeys(value interface{}) []string {
+	switch key := value.(type) {
+	case string:
+		if key == "" {
+			return nil
+		}
+		return []string{key}
+	case json.Number:
+		return []string{key.String()}
+	case []interface{}:
+		keys := make([]string, 0, len(key))
+		for _, item := range key {
+			keys = append(keys, relationKeys(item)...)
+		}
+		return keys
+	}
+	return nil
+}
+
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	extended := make([]interface{}, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, element)
+}
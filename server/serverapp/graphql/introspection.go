@@ -0,0 +1,184 @@
+package graphql
+
+// 인트로스펙션 (__schema, __type)
+// GraphiQL, 웹 클라이언트 코드 생성기 등이 표준 인트로스펙션 쿼리로 스키마를 읽을 수 있도록
+// 스키마 정의를 인트로스펙션 객체로 변환합니다. 값은 요청마다 스키마에서 만들고
+// 일반 객체 필드와 같은 방식(선택 검증, 깊이별 실행)으로 응답을 채웁니다
+
+// introspectionMaxDepth 인트로스펙션 선택의 최대 깊이
+// 표준 인트로스펙션 쿼리는 ofType을 여러 단계 중첩하므로 설정의 MaxDepth보다 깊습니다
+// 값은 스키마 정의에서 만들어지고 저장소를 조회하지 않으므로 깊이가 비용으로 이어지지 않습니다
+const introspectionMaxDepth = 20
+
+// 인트로스펙션 객체 타입
+var (
+	schemaMetaType     = newIntrospectionType("__Schema")
+	typeMetaType       = newIntrospectionType("__Type")
+	fieldMetaType      = newIntrospectionType("__Field")
+	inputValueMetaType = newIntrospectionType("__InputValue")
+	enumValueMetaType  = newIntrospectionType("__EnumValue")
+	directiveMetaType  = newIntrospectionType("__Directive")
+
+	// metaFields 쿼리 루트에서만 선택할 수 있는 인트로스펙션 필드 (SDL과 타입 목록에는 나오지 않습니다)
+	metaFields = newIntrospectionType("Query")
+)
+
+func init() {
+	includeDeprecated := []Argument{{Name: "includeDeprecated", Type: TypeRef{Name: TypeBoolean}}}
+
+	addIntrospectionFields(schemaMetaType,
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaObject("types", TypeRef{Name: "__Type", List: true, NonNull: true}, typeMetaType, nil),
+		metaObject("queryType", TypeRef{Name: "__Type", NonNull: true}, typeMetaType, nil),
+		metaObject("mutationType", TypeRef{Name: "__Type"}, typeMetaType, nil),
+		metaObject("subscriptionType", TypeRef{Name: "__Type"}, typeMetaType, nil),
+		metaObject("directives", TypeRef{Name: "__Directive", List: true, NonNull: true}, directiveMetaType, nil),
+	)
+	addIntrospectionFields(typeMetaType,
+		metaValue("kind", TypeRef{Name: "__TypeKind", NonNull: true}),
+		metaValue("name", TypeRef{Name: TypeString}),
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaValue("specifiedByURL", TypeRef{Name: TypeString}),
+		metaObject("fields", TypeRef{Name: "__Field", List: true}, fieldMetaType, includeDeprecated),
+		metaObject("interfaces", TypeRef{Name: "__Type", List: true}, typeMetaType, nil),
+		metaObject("possibleTypes", TypeRef{Name: "__Type", List: true}, typeMetaType, nil),
+		metaObject("enumValues", TypeRef{Name: "__EnumValue", List: true}, enumValueMetaType, includeDeprecated),
+		metaObject("inputFields", TypeRef{Name: "__InputValue", List: true}, inputValueMetaType, includeDeprecated),
+		metaObject("ofType", TypeRef{Name: "__Type"}, typeMetaType, nil),
+		metaValue("isOneOf", TypeRef{Name: TypeBoolean}),
+	)
+	addIntrospectionFields(fieldMetaType,
+		metaValue("name", TypeRef{Name: TypeString, NonNull: true}),
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaObject("args", TypeRef{Name: "__InputValue", List: true, NonNull: true}, inputValueMetaType, includeDeprecated),
+		metaObject("type", TypeRef{Name: "__Type", NonNull: true}, typeMetaType, nil),
+		metaValue("isDeprecated", TypeRef{Name: TypeBoolean, NonNull: true}),
+		metaValue("deprecationReason", TypeRef{Name: TypeString}),
+	)
+	addIntrospectionFields(inputValueMetaType,
+		metaValue("name", TypeRef{Name: TypeString, NonNull: true}),
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaObject("type", TypeRef{Name: "__Type", NonNull: true}, typeMetaType, nil),
+		metaValue("defaultValue", TypeRef{Name: TypeString}),
+		metaValue("isDeprecated", TypeRef{Name: TypeBoolean, NonNull: true}),
+		metaValue("deprecationReason", TypeRef{Name: TypeString}),
+	)
+	addIntrospectionFields(enumValueMetaType,
+		metaValue("name", TypeRef{Name: TypeString, NonNull: true}),
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaValue("isDeprecated", TypeRef{Name: TypeBoolean, NonNull: true}),
+		metaValue("deprecationReason", TypeRef{Name: TypeString}),
+	)
+	addIntrospectionFields(directiveMetaType,
+		metaValue("name", TypeRef{Name: TypeString, NonNull: true}),
+		metaValue("description", TypeRef{Name: TypeString}),
+		metaValue("locations", TypeRef{Name: "__DirectiveLocation", List: true, NonNull: true}),
+		metaObject("args", TypeRef{Name: "__InputValue", List: true, NonNull: true}, inputValueMetaType, includeDeprecated),
+		metaValue("isRepeatable", TypeRef{Name: TypeBoolean, NonNull: true}),
+	)
+	addIntrospectionFields(metaFields,
+		metaObject("__schema", TypeRef{Name: "__Schema", NonNull: true}, schemaMetaType, nil),
+		metaObject("__type", TypeRef{Name: "__Type"}, typeMetaType, []Argument{{Name: "name", Type: TypeRef{Name: TypeString, NonNull: true}}}),
+	)
+}
+
+func newIntrospectionType(name string) *ObjectType {
+	return &ObjectType{Name: name, fieldIndex: make(map[string]*Field)}
+}
+
+func addIntrospectionFields(objectType *ObjectType, fields ...*Field) {
+	for _, field := range fields {
+		if err := objectType.addField(field); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// metaValue 스칼라 또는 enum 값 인트로스펙션 필드
+func metaValue(name string, typeRef TypeRef) *Field {
+	return &Field{Name: name, Type: typeRef, kind: fieldValue, source: name}
+}
+
+// metaObject 인트로스펙션 객체를 값으로 갖는 필드
+func metaObject(name string, typeRef TypeRef, target *ObjectType, args []Argument) *Field {
+	return &Field{Name: name, Type: typeRef, Args: args, kind: fieldIntrospection, source: name, target: target}
+}
+
+// rootField 쿼리 루트 필드 조회 (인트로스펙션 필드 포함)
+func (s *Schema) rootField(name string) (*Field, bool) {
+	if field, exists := s.query.Field(name); exists {
+		return field, true
+	}
+	return metaFields.Field(name)
+}
+
+// introspection 스키마를 인트로스펙션 객체로 변환합니다
+// 반환하는 __Schema 객체와 이름별 __Type 객체는 서로를 참조하므로 JSON으로 직접 직렬화하지 않습니다
+func (s *Schema) introspection() (map[string]interface{}, map[string]map[string]interface{}) {
+	named := make(map[string]map[string]interface{}, len(scalarTypes)+len(s.types)+1)
+	types := make([]map[string]interface{}, 0, len(scalarTypes)+len(s.types)+1)
+	addType := func(kind, name string) map[string]interface{} {
+		metaType := map[string]interface{}{"kind": kind, "name": name}
+		named[name] = metaType
+		types = append(types, metaType)
+		return metaType
+	}
+
+	for _, name := range []string{TypeString, TypeInt, TypeFloat, TypeBoolean, TypeID} {
+		addType("SCALAR", name)
+	}
+	addType("SCALAR", TypeJSON)["description"] = "중첩 구조체, 맵 등 스키마로 펼치지 않는 JSON 값"
+
+	objectTypes := make([]*ObjectType, 0, len(s.order)+1)
+	for _, name := range s.order {
+		objectTypes = append(objectTypes, s.types[name])
+	}
+	objectTypes = append(objectTypes, s.query)
+	for _, objectType := range objectTypes {
+		metaType := addType("OBJECT", objectType.Name)
+		metaType["interfaces"] = []map[string]interface{}{}
+	}
+
+	typeRef := func(ref TypeRef) map[string]interface{} {
+		metaType := named[ref.Name]
+		if ref.List {
+			metaType = map[string]interface{}{"kind": "LIST", "ofType": metaType}
+		}
+		if ref.NonNull {
+			metaType = map[string]interface{}{"kind": "NON_NULL", "ofType": metaType}
+		}
+		return metaType
+	}
+
+	for _, objectType := range objectTypes {
+		fields := make([]map[string]interface{}, 0, len(objectType.Fields))
+		for _, field := range objectType.Fields {
+			args := make([]map[string]interface{}, 0, len(field.Args))
+			for _, arg := range field.Args {
+				args = append(args, map[string]interface{}{"name": arg.Name, "type": typeRef(arg.Type), "isDeprecated": false})
+			}
+			fields = append(fields, map[string]interface{}{"name": field.Name, "args": args, "type": typeRef(field.Type), "isDeprecated": false})
+		}
+		named[objectType.Name]["fields"] = fields
+	}
+
+	schema := map[string]interface{}{
+		"types":      types,
+		"queryType":  named[s.query.Name],
+		"directives": []map[string]interface{}{}, // directive는 지원하지 않습니다
+	}
+	return schema, named
+}
+
+// introspectionObjects 인트로스펙션 필드 값을 객체 목록으로 변환합니다
+func introspectionObjects(value interface{}) []map[string]interface{} {
+	switch objects := value.(type) {
+	case map[string]interface{}:
+		if objects != nil {
+			return []map[string]interface{}{objects}
+		}
+	case []map[string]interface{}:
+		return objects
+	}
+	return nil
+}
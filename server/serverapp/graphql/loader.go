@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"cqrs"
+	"encoding/json"
+	"sync"
+)
+
+// defaultLoaderConcurrency GetByIDs를 지원하지 않는 저장소에 대한 동시 GetByID 수
+const defaultLoaderConcurrency = 8
+
+// BatchReadStore 여러 읽기 모델을 한 번에 조회할 수 있는 읽기 저장소
+// 존재하지 않는 ID는 결과에서 빠집니다
+type BatchReadStore = cqrs.BatchReadStore
+
+// loadResult 데이터로더 캐시 항목 (object가 nil이면 존재하지 않는 모델)
+type loadResult struct {
+	object map[string]interface{}
+	err    error
+}
+
+// dataLoader 요청 단위 읽기 모델 로더
+// 실행기가 한 깊이에서 필요한 키를 모두 모은 뒤 load를 호출하면 모델 타입별로 한 번에 조회하고,
+// 같은 요청 안의 중복 조회는 캐시로 제거합니다
+type dataLoader struct {
+	store       cqrs.ReadStore
+	concurrency int
+	cache       map[string]map[string]loadResult // 모델 타입 -> ID -> 결과
+}
+
+func newDataLoader(store cqrs.ReadStore, concurrency int) *dataLoader {
+	if concurrency <= 0 {
+		concurrency = defaultLoaderConcurrency
+	}
+	return &dataLoader{
+		store:       store,
+		concurrency: concurrency,
+		cache:       make(map[string]map[string]loadResult),
+	}
+}
+
+// load 캐시에 없는 ID들을 한 번에 조회해 캐시에 채웁니다
+func (l *dataLoader) load(ctx context.Context, modelType string, ids []string) {
+	cached, exists := l.cache[modelType]
+	if !exists {
+		cached = make(map[string]loadResult)
+		l.cache[modelType] = cached
+	}
+
+	missing := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, done := cached[id]; !done {
+			cached[id] = loadResult{}
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	if batch, ok := l.store.(BatchReadStore); ok {
+		models, err := batch.GetByIDs(ctx, missing, modelType)
+		if err != nil {
+			for _, id := range missing {
+				cached[id] = loadResult{err: err}
+			}
+			return
+		}
+		for _, model := range models {
+			if model != nil {
+				cached[model.GetID()] = loadResult{object: readModelObject(model)}
+			}
+		}
+		return
+	}
+
+	// 배치 조회를 지원하지 않으면 제한된 동시성으로 개별 조회
+	results := make([]loadResult, len(missing))
+	semaphore := make(chan struct{}, l.concurrency)
+	var wg sync.WaitGroup
+	for i, id := range missing {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			model, err := l.store.GetByID(ctx, id, modelType)
+			switch {
+			case err != nil && cqrs.IsNotFoundError(err):
+			case err != nil:
+				results[i] = loadResult{err: err}
+			case model != nil:
+				results[i] = loadResult{object: readModelObject(model)}
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	for i, id := range missing {
+		cached[id] = results[i]
+	}
+}
+
+// get load로 채운 결과를 반환합니다
+func (l *dataLoader) get(modelType, id string) loadResult {
+	return l.cache[modelType][id]
+}
+
+// prime 목록 조회 등으로 이미 읽은 모델을 캐시에 넣습니다
+func (l *dataLoader) prime(model cqrs.ReadModel) map[string]interface{} {
+	object := readModelObject(model)
+	cached, exists := l.cache[model.GetType()]
+	if !exists {
+		cached = make(map[string]loadResult)
+		l.cache[model.GetType()] = cached
+	}
+	cached[model.GetID()] = loadResult{object: object}
+	return object
+}
+
+// readModelObject 읽기 모델 데이터를 JSON 객체로 변환합니다
+// GetData가 객체가 아니면 모델 자체를 직렬화하고, id가 없으면 GetID 값을 넣습니다
+func readModelObject(model cqrs.ReadModel) map[string]interface{} {
+	object, ok := toJSONValue(model.GetData()).(map[string]interface{})
+	if !ok {
+		object, ok = toJSONValue(model).(map[string]interface{})
+	}
+	if !ok {
+		object = make(map[string]interface{})
+	}
+	if _, exists := object["id"]; !exists {
+		object["id"] = model.GetID()
+	}
+	return object
+}
+
+// toJSONValue 값을 JSON 왕복 변환해 map/slice/json.Number 등 범용 값으로 만듭니다
+func toJSONValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,564 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// 지원하는 GraphQL 문법 부분집합:
+//   - query 연산 하나 (익명 또는 이름 있음, 변수 정의 포함)
+//   - 필드, 별칭, 인자, 중첩 선택
+//   - fragment 정의와 spread, 인라인 fragment (파싱 후 필드로 펼쳐 병합)
+//   - 인자 값: 문자열, 정수, 실수, 불리언, null, enum, 리스트, 객체, 변수
+//   - 인트로스펙션 필드 __schema, __type, __typename (introspection.go)
+// directive, mutation, subscription, 여러 연산을 담은 문서는 지원하지 않습니다
+//
+// 이 부분집합으로 충분한 이유:
+//   - 게이트웨이는 읽기 모델 조회 전용입니다. 상태 변경은 각 서버앱의 명령 API로,
+//     실시간 갱신은 realtime 채널로 처리하므로 mutation과 subscription이 필요 없습니다
+//   - 스키마에는 객체 타입만 있고 인터페이스와 유니온이 없습니다. fragment의 타입 조건은
+//     항상 선택된 타입과 같아야 하므로, 파싱 단계에서 필드로 펼쳐도 의미가 같습니다
+//   - @include/@skip은 변수에 따라 쿼리 문자열을 고르는 것으로 대신할 수 있고,
+//     GraphiQL과 웹 클라이언트 코드 생성기가 보내는 표준 인트로스펙션 쿼리는
+//     fragment만 사용하고 directive는 사용하지 않습니다
+//   - 요청마다 연산 하나만 실행하므로 operationName은 문서의 연산 이름과 대조만 합니다
+
+// document 파싱된 쿼리 문서
+type document struct {
+	name       string
+	variables  []variableDefinition
+	selections []*selection
+}
+
+// variableDefinition 연산의 변수 정의
+type variableDefinition struct {
+	name         string
+	defaultValue interface{}
+	hasDefault   bool
+}
+
+// selection 선택된 필드
+type selection struct {
+	alias      string
+	name       string
+	arguments  map[string]interface{} // 변수는 variableRef로 남아 실행 시 치환됩니다
+	selections []*selection
+	on         string // fragment에서 펼쳐진 필드의 타입 조건 (검증 시 선택된 타입과 대조)
+
+	// 파싱 중에만 사용: fragment spread와 인라인 fragment는 파싱 후 필드로 펼쳐집니다
+	spread string
+	inline bool
+	pos    int
+}
+
+// fragmentDefinition 이름 있는 fragment 정의
+type fragmentDefinition struct {
+	typeCondition string
+	selections    []*selection
+}
+
+// responseKey 응답에 사용할 키 (별칭 우선)
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variableRef 인자 값 안의 변수 참조
+type variableRef string
+
+// enumValue 인자 값 안의 enum 리터럴
+type enumValue string
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// maxExpandedFields fragment를 펼친 뒤 문서 전체의 최대 필드 수
+// fragment가 다음 fragment를 여러 번 펼치면 필드 수가 지수적으로 늘어나므로, 펼치는 도중에 세어 중단합니다
+// 표준 인트로스펙션 쿼리는 펼쳐도 수백 개 수준입니다
+const maxExpandedFields = 2000
+
+type parser struct {
+	source   string
+	pos      int
+	token    token
+	expanded int // 지금까지 펼친 필드 수
+}
+
+// parseDocument 쿼리 문자열을 파싱합니다
+func parseDocument(source string) (doc *document, err error) {
+	p := &parser{source: strings.TrimPrefix(source, "\uFEFF")}
+	defer func() {
+		if r := recover(); r != nil {
+			if syntaxErr, ok := r.(syntaxError); ok {
+				doc, err = nil, syntaxErr
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	p.advance()
+	doc = &document{}
+	fragments := make(map[string]*fragmentDefinition)
+	operations := 0
+
+	for p.token.kind != tokenEOF {
+		if p.token.kind == tokenName && p.token.value == "fragment" {
+			p.advance()
+			name := p.expectName()
+			if name == "on" {
+				p.fail("fragment needs a name")
+			}
+			if _, exists := fragments[name]; exists {
+				p.fail("fragment %q is already defined", name)
+			}
+			p.expectKeyword("on")
+			fragment := &fragmentDefinition{typeCondition: p.expectName()}
+			p.failOnDirective()
+			fragment.selections = p.parseSelectionSet()
+			fragments[name] = fragment
+			continue
+		}
+
+		operations++
+		if operations > 1 {
+			p.fail("only a single operation is supported")
+		}
+		if p.token.kind == tokenName {
+			switch p.token.value {
+			case "query":
+				p.advance()
+				if p.token.kind == tokenName {
+					doc.name = p.token.value
+					p.advance()
+				}
+				if p.peekPunct("(") {
+					doc.variables = p.parseVariableDefinitions()
+				}
+			case "mutation", "subscription":
+				p.fail("%s operations are not supported", p.token.value)
+			default:
+				p.fail("unexpected %q", p.token.value)
+			}
+		}
+		doc.selections = p.parseSelectionSet()
+	}
+	if operations == 0 {
+		p.fail("document has no operation")
+	}
+
+	doc.selections = p.expandFragments(doc.selections, fragments, make(map[string]bool))
+	return doc, nil
+}
+
+type syntaxError struct {
+	message string
+	pos     int
+}
+
+func (e syntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.pos, e.message)
+}
+
+func (p *parser) fail(format string, args ...interface{}) {
+	p.failAt(p.token.pos, format, args...)
+}
+
+func (p *parser) failAt(pos int, format string, args ...interface{}) {
+	panic(syntaxError{message: fmt.Sprintf(format, args...), pos: pos})
+}
+
+func (p *parser) failOnDirective() {
+	if p.peekPunct("@") {
+		p.fail("directives are not supported")
+	}
+}
+
+func (p *parser) peekPunct(value string) bool {
+	return p.token.kind == tokenPunct && p.token.value == value
+}
+
+func (p *parser) expectPunct(value string) {
+	if !p.peekPunct(value) {
+		p.fail("expected %q, found %q", value, p.token.value)
+	}
+	p.advance()
+}
+
+func (p *parser) expectKeyword(keyword string) {
+	if p.token.kind != tokenName || p.token.value != keyword {
+		p.fail("expected %q, found %q", keyword, p.token.value)
+	}
+	p.advance()
+}
+
+func (p *parser) expectName() string {
+	if p.token.kind != tokenName {
+		p.fail("expected name, found %q", p.token.value)
+	}
+	name := p.token.value
+	p.advance()
+	return name
+}
+
+func (p *parser) parseVariableDefinitions() []variableDefinition {
+	p.expectPunct("(")
+	var definitions []variableDefinition
+	for !p.peekPunct(")") {
+		p.expectPunct("$")
+		definition := variableDefinition{name: p.expectName()}
+		p.expectPunct(":")
+		p.skipType()
+		if p.peekPunct("=") {
+			p.advance()
+			definition.defaultValue = p.parseValue(true)
+			definition.hasDefault = true
+		}
+		definitions = append(definitions, definition)
+	}
+	p.expectPunct(")")
+	return definitions
+}
+
+// skipType 변수 타입 표기를 건너뜁니다 (인자 타입 검사는 리졸버가 담당)
+func (p *parser) skipType() {
+	if p.peekPunct("[") {
+		p.advance()
+		p.skipType()
+		p.expectPunct("]")
+	} else {
+		p.expectName()
+	}
+	if p.peekPunct("!") {
+		p.advance()
+	}
+}
+
+func (p *parser) parseSelectionSet() []*selection {
+	p.expectPunct("{")
+	var selections []*selection
+	for !p.peekPunct("}") {
+		if p.peekPunct("...") {
+			selections = append(selections, p.parseFragment())
+			continue
+		}
+		selections = append(selections, p.parseField())
+	}
+	p.expectPunct("}")
+	return selections
+}
+
+func (p *parser) parseField() *selection {
+	field := &selection{pos: p.token.pos}
+	field.name = p.expectName()
+	if p.peekPunct(":") {
+		p.advance()
+		field.alias = field.name
+		field.name = p.expectName()
+	}
+	if p.peekPunct("(") {
+		p.advance()
+		field.arguments = make(map[string]interface{})
+		for !p.peekPunct(")") {
+			name := p.expectName()
+			p.expectPunct(":")
+			field.arguments[name] = p.parseValue(false)
+		}
+		p.expectPunct(")")
+	}
+	p.failOnDirective()
+	if p.peekPunct("{") {
+		field.selections = p.parseSelectionSet()
+	}
+	return field
+}
+
+// parseFragment fragment spread(...Name) 또는 인라인 fragment(... on Type { })
+func (p *parser) parseFragment() *selection {
+	fragment := &selection{pos: p.token.pos}
+	p.expectPunct("...")
+	if p.token.kind == tokenName && p.token.value != "on" {
+		fragment.spread = p.expectName()
+		p.failOnDirective()
+		return fragment
+	}
+
+	fragment.inline = true
+	if p.token.kind == tokenName {
+		p.advance()
+		fragment.on = p.expectName()
+	}
+	p.failOnDirective()
+	fragment.selections = p.parseSelectionSet()
+	return fragment
+}
+
+// expandFragments fragment를 필드로 펼치고 같은 응답 키의 필드를 병합합니다
+// fragment 정의는 여러 곳에서 펼쳐질 수 있으므로 정의의 선택은 수정하지 않고 복사합니다
+func (p *parser) expandFragments(selections []*selection, fragments map[string]*fragmentDefinition, visiting map[string]bool) []*selection {
+	var fields []*selection
+	for _, sel := range selections {
+		switch {
+		case sel.spread != "":
+			fragment, exists := fragments[sel.spread]
+			if !exists {
+				p.failAt(sel.pos, "unknown fragment %q", sel.spread)
+			}
+			if visiting[sel.spread] {
+				p.failAt(sel.pos, "fragment %q spreads itself", sel.spread)
+			}
+			visiting[sel.spread] = true
+			expanded := p.expandFragments(fragment.selections, fragments, visiting)
+			delete(visiting, sel.spread)
+			fields = append(fields, p.withCondition(expanded, fragment.typeCondition, sel.pos)...)
+		case sel.inline:
+			expanded := p.expandFragments(sel.selections, fragments, visiting)
+			fields = append(fields, p.withCondition(expanded, sel.on, sel.pos)...)
+		default:
+			p.expanded++
+			if p.expanded > maxExpandedFields {
+				p.failAt(sel.pos, "query expands to more than %d fields", maxExpandedFields)
+			}
+			field := *sel
+			if len(sel.selections) > 0 {
+				field.selections = p.expandFragments(sel.selections, fragments, visiting)
+			}
+			fields = append(fields, &field)
+		}
+	}
+	return p.mergeFields(fields)
+}
+
+// withCondition 펼친 필드에 fragment의 타입 조건을 붙입니다
+// 객체 타입만 있으므로 중첩된 fragment의 타입 조건이 서로 다르면 어느 타입에도 적용될 수 없습니다
+func (p *parser) withCondition(fields []*selection, typeCondition string, pos int) []*selection {
+	if typeCondition == "" {
+		return fields
+	}
+	for _, field := range fields {
+		if field.on != "" && field.on != typeCondition {
+			p.failAt(pos, "fragment on %s cannot be spread within %s", field.on, typeCondition)
+		}
+		field.on = typeCondition
+	}
+	return fields
+}
+
+// mergeFields 같은 응답 키로 여러 번 선택된 필드를 하나로 합칩니다
+func (p *parser) mergeFields(fields []*selection) []*selection {
+	merged := make([]*selection, 0, len(fields))
+	index := make(map[string]*selection, len(fields))
+	for _, field := range fields {
+		key := field.responseKey()
+		existing, exists := index[key]
+		if !exists {
+			index[key] = field
+			merged = append(merged, field)
+			continue
+		}
+
+		if existing.name != field.name || !reflect.DeepEqual(existing.arguments, field.arguments) {
+			p.failAt(field.pos, "fields %q conflict: they select different fields or arguments", key)
+		}
+		if existing.on != "" && field.on != "" && existing.on != field.on {
+			p.failAt(field.pos, "fields %q conflict: they are selected on %s and %s", key, existing.on, field.on)
+		}
+		if existing.on == "" {
+			existing.on = field.on
+		}
+		if len(field.selections) > 0 {
+			existing.selections = p.mergeFields(append(existing.selections, field.selections...))
+		}
+	}
+	return merged
+}
+
+func (p *parser) parseValue(constant bool) interface{} {
+	tok := p.token
+	switch tok.kind {
+	case tokenInt:
+		p.advance()
+		value, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			p.fail("invalid integer %s", tok.value)
+		}
+		return value
+	case tokenFloat:
+		p.advance()
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			p.fail("invalid number %s", tok.value)
+		}
+		return value
+	case tokenString:
+		p.advance()
+		return tok.value
+	case tokenName:
+		p.advance()
+		switch tok.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enumValue(tok.value)
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				p.fail("variables are not allowed in default values")
+			}
+			p.advance()
+			return variableRef(p.expectName())
+		case "[":
+			p.advance()
+			list := make([]interface{}, 0)
+			for !p.peekPunct("]") {
+				list = append(list, p.parseValue(constant))
+			}
+			p.advance()
+			return list
+		case "{":
+			p.advance()
+			object := make(map[string]interface{})
+			for !p.peekPunct("}") {
+				name := p.expectName()
+				p.expectPunct(":")
+				object[name] = p.parseValue(constant)
+			}
+			p.advance()
+			return object
+		}
+	}
+	p.fail("unexpected %q", tok.value)
+	return nil
+}
+
+// advance 다음 토큰을 읽습니다 (공백, 쉼표, 주석은 무시)
+func (p *parser) advance() {
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		if c == '#' {
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			p.pos++
+			continue
+		}
+		break
+	}
+
+	start := p.pos
+	if p.pos >= len(p.source) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return
+	}
+
+	c := p.source[p.pos]
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.token = token{kind: tokenPunct, value: "...", pos: start}
+	case strings.ContainsRune("!$():=@[]{}|", rune(c)):
+		p.pos++
+		p.token = token{kind: tokenPunct, value: string(c), pos: start}
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isAlphaNumeric(p.source[p.pos])) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, value: p.source[start:p.pos], pos: start}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.lexNumber(start)
+	case c == '"':
+		p.lexString(start)
+	default:
+		p.token = token{kind: tokenPunct, value: string(c), pos: start}
+		p.fail("unexpected character %q", c)
+	}
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *parser) lexNumber(start int) {
+	kind := tokenInt
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '.' || c == 'e' || c == 'E':
+			kind = tokenFloat
+		case (c == '+' || c == '-') && kind == tokenFloat:
+		default:
+			p.token = token{kind: kind, value: p.source[start:p.pos], pos: start}
+			return
+		}
+		p.pos++
+	}
+	p.token = token{kind: kind, value: p.source[start:p.pos], pos: start}
+}
+
+func (p *parser) lexString(start int) {
+	if strings.HasPrefix(p.source[p.pos:], `"""`) {
+		end := strings.Index(p.source[p.pos+3:], `"""`)
+		if end < 0 {
+			p.token = token{pos: start}
+			p.fail("unterminated block string")
+		}
+		value := p.source[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		p.token = token{kind: tokenString, value: value, pos: start}
+		return
+	}
+
+	p.pos++
+	for p.pos < len(p.source) {
+		switch p.source[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			value, err := strconv.Unquote(p.source[start:p.pos])
+			if err != nil {
+				p.token = token{pos: start}
+				p.fail("invalid string %s", p.source[start:p.pos])
+			}
+			p.token = token{kind: tokenString, value: value, pos: start}
+			return
+		case '\n':
+			p.token = token{pos: start}
+			p.fail("unterminated string")
+		}
+		p.pos++
+	}
+	p.token = token{pos: start}
+	p.fail("unterminated string")
+}
@@ -0,0 +1,403 @@
+package graphql
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// 스칼라 타입 이름
+const (
+	TypeString  = "String"
+	TypeInt     = "Int"
+	TypeFloat   = "Float"
+	TypeBoolean = "Boolean"
+	TypeID      = "ID"
+	TypeJSON    = "JSON" // 중첩 구조체, 맵 등 스키마로 펼치지 않는 값
+)
+
+var scalarTypes = map[string]bool{
+	TypeString: true, TypeInt: true, TypeFloat: true, TypeBoolean: true, TypeID: true, TypeJSON: true,
+}
+
+// TypeRef 필드 또는 인자의 타입 (예: [GuildView!]!)
+type TypeRef struct {
+	Name    string
+	List    bool
+	NonNull bool
+}
+
+// String SDL 표기
+func (t TypeRef) String() string {
+	name := t.Name
+	if t.List {
+		name = "[" + name + "]"
+	}
+	if t.NonNull {
+		name += "!"
+	}
+	return name
+}
+
+// Argument 필드 인자 정의
+type Argument struct {
+	Name string
+	Type TypeRef
+}
+
+// fieldKind 필드 값을 얻는 방법
+type fieldKind int
+
+const (
+	fieldValue         fieldKind = iota // 부모 객체의 JSON 값
+	fieldRelation                       // 다른 읽기 모델 참조 (데이터로더로 일괄 조회)
+	fieldRootByID                       // 루트: ID로 읽기 모델 조회
+	fieldRootList                       // 루트: 읽기 저장소 목록 조회
+	fieldRootQuery                      // 루트: 쿼리 디스패처로 위임
+	fieldIntrospection                  // 인트로스펙션 객체 (부모 값 또는 스키마 정의)
+)
+
+// Field 객체 타입의 필드
+type Field struct {
+	Name string
+	Type TypeRef
+	Args []Argument
+
+	kind     fieldKind
+	source   string             // fieldValue/fieldRelation: 부모 객체의 JSON 키
+	target   *ObjectType        // fieldRelation/fieldRoot*: 대상 타입
+	buildFn  QueryBuilder       // fieldRootQuery
+	argIndex map[string]TypeRef // 인자 이름 -> 타입
+}
+
+// ObjectType GraphQL 객체 타입
+type ObjectType struct {
+	Name      string
+	ModelType string // 읽기 저장소의 모델 타입 (없으면 쿼리 결과 전용 타입)
+	Fields    []*Field
+
+	fieldIndex map[string]*Field
+}
+
+// Field 이름으로 필드 조회
+func (o *ObjectType) Field(name string) (*Field, bool) {
+	field, exists := o.fieldIndex[name]
+	return field, exists
+}
+
+func (o *ObjectType) addField(field *Field) error {
+	if _, exists := o.fieldIndex[field.Name]; exists {
+		return fmt.Errorf("field %s.%s is already defined", o.Name, field.Name)
+	}
+	field.argIndex = make(map[string]TypeRef, len(field.Args))
+	for _, arg := range field.Args {
+		field.argIndex[arg.Name] = arg.Type
+	}
+	o.Fields = append(o.Fields, field)
+	o.fieldIndex[field.Name] = field
+	return nil
+}
+
+// QueryBuilder GraphQL 인자로 디스패치할 cqrs 쿼리를 만듭니다
+type QueryBuilder func(ctx context.Context, args map[string]interface{}) (cqrs.Query, error)
+
+// ObjectConfig 읽기 모델 객체 타입 설정
+type ObjectConfig struct {
+	Name      string      // GraphQL 타입 이름 (예: GuildView)
+	Sample    interface{} // 필드를 생성할 읽기 모델 구조체 값 (json 태그 사용)
+	ModelType string      // 읽기 저장소 모델 타입; 비우면 쿼리 결과 전용 타입
+	ByIDField string      // 선택: ID 조회 루트 필드 이름 (예: guild)
+	ListField string      // 선택: 목록 루트 필드 이름 (예: guilds)
+}
+
+// RelationConfig 읽기 모델 간 참조 필드 설정
+type RelationConfig struct {
+	Type     string // 필드를 추가할 타입 (예: MemberView)
+	Field    string // 추가할 필드 이름 (예: guild)
+	Target   string // 참조 대상 타입 (예: GuildView)
+	KeyField string // 대상 ID를 담은 JSON 필드 (예: guild_id); ID 배열이면 목록 필드가 됩니다
+}
+
+// QueryFieldConfig 쿼리 디스패처로 위임하는 루트 필드 설정 (예: 리더보드)
+type QueryFieldConfig struct {
+	Name  string       // 루트 필드 이름 (예: leaderboard)
+	Type  string       // 결과 객체 타입 이름
+	List  bool         // 결과가 목록인지 여부
+	Args  []Argument   // 필드 인자
+	Build QueryBuilder // 인자로 cqrs 쿼리 생성
+}
+
+// Schema 읽기 모델에서 생성되는 GraphQL 스키마
+type Schema struct {
+	types map[string]*ObjectType
+	order []string
+	query *ObjectType
+}
+
+// NewSchema 빈 스키마를 생성합니다
+func NewSchema() *Schema {
+	return &Schema{
+		types: make(map[string]*ObjectType),
+		query: &ObjectType{Name: "Query", fieldIndex: make(map[string]*Field)},
+	}
+}
+
+// Type 이름으로 객체 타입 조회
+func (s *Schema) Type(name string) (*ObjectType, bool) {
+	objectType, exists := s.types[name]
+	return objectType, exists
+}
+
+// AddObject 읽기 모델 구조체에서 객체 타입을 생성해 추가합니다
+func (s *Schema) AddObject(config ObjectConfig) error {
+	if !isValidName(config.Name) || config.Sample == nil {
+		return fmt.Errorf("object type needs a valid name and a sample value")
+	}
+	if _, exists := s.types[config.Name]; exists || scalarTypes[config.Name] || config.Name == "Query" {
+		return fmt.Errorf("type %s is already defined", config.Name)
+	}
+	if (config.ByIDField != "" || config.ListField != "") && config.ModelType == "" {
+		return fmt.Errorf("root fields of %s need a read model type", config.Name)
+	}
+
+	sampleType := reflect.TypeOf(config.Sample)
+	for sampleType.Kind() == reflect.Ptr {
+		sampleType = sampleType.Elem()
+	}
+	if sampleType.Kind() != reflect.Struct {
+		return fmt.Errorf("sample of %s must be a struct", config.Name)
+	}
+
+	objectType := &ObjectType{Name: config.Name, ModelType: config.ModelType, fieldIndex: make(map[string]*Field)}
+	fields := structFields(sampleType)
+
+	// 읽기 모델 타입은 항상 id 필드를 가집니다 (ReadModel.GetID 값)
+	if config.ModelType != "" {
+		hasID := false
+		for _, field := range fields {
+			hasID = hasID || field.Name == "id"
+		}
+		if !hasID {
+			idField := &Field{Name: "id", Type: TypeRef{Name: TypeID, NonNull: true}, kind: fieldValue, source: "id"}
+			fields = append([]*Field{idField}, fields...)
+		}
+	}
+
+	for _, field := range fields {
+		if err := objectType.addField(field); err != nil {
+			return err
+		}
+	}
+
+	if config.ByIDField != "" {
+		if err := s.query.addField(&Field{
+			Name:   config.ByIDField,
+			Type:   TypeRef{Name: config.Name},
+			Args:   []Argument{{Name: "id", Type: TypeRef{Name: TypeID, NonNull: true}}},
+			kind:   fieldRootByID,
+			target: objectType,
+		}); err != nil {
+			return err
+		}
+	}
+	if config.ListField != "" {
+		if err := s.query.addField(&Field{
+			Name: config.ListField,
+			Type: TypeRef{Name: config.Name, List: true, NonNull: true},
+			Args: []Argument{
+				{Name: "limit", Type: TypeRef{Name: TypeInt}},
+				{Name: "offset", Type: TypeRef{Name: TypeInt}},
+				{Name: "sortBy", Type: TypeRef{Name: TypeString}},
+				{Name: "descending", Type: TypeRef{Name: TypeBoolean}},
+			},
+			kind:   fieldRootList,
+			target: objectType,
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.types[config.Name] = objectType
+	s.order = append(s.order, config.Name)
+	return nil
+}
+
+// AddRelation 다른 읽기 모델을 참조하는 필드를 추가합니다
+// 같은 깊이의 참조는 요청 단위 데이터로더로 모아서 한 번에 조회됩니다
+func (s *Schema) AddRelation(config RelationConfig) error {
+	source, exists := s.types[config.Type]
+	if !exists {
+		return fmt.Errorf("unknown type %s", config.Type)
+	}
+	target, exists := s.types[config.Target]
+	if !exists || target.ModelType == "" {
+		return fmt.Errorf("relation target %s must be a read model type", config.Target)
+	}
+	key, exists := source.fieldIndex[config.KeyField]
+	if !exists || key.kind != fieldValue {
+		return fmt.Errorf("unknown key field %s.%s", config.Type, config.KeyField)
+	}
+	if !isValidName(config.Field) {
+		return fmt.Errorf("invalid field name %q", config.Field)
+	}
+
+	return source.addField(&Field{
+		Name:   config.Field,
+		Type:   TypeRef{Name: config.Target, List: key.Type.List},
+		kind:   fieldRelation,
+		source: config.KeyField,
+		target: target,
+	})
+}
+
+// AddQueryField 쿼리 디스패처로 위임하는 루트 필드를 추가합니다
+func (s *Schema) AddQueryField(config QueryFieldConfig) error {
+	target, exists := s.types[config.Type]
+	if !exists {
+		return fmt.Errorf("unknown type %s", config.Type)
+	}
+	if !isValidName(config.Name) || config.Build == nil {
+		return fmt.Errorf("query field needs a valid name and a query builder")
+	}
+	for _, arg := range config.Args {
+		if !isValidName(arg.Name) || !scalarTypes[arg.Type.Name] {
+			return fmt.Errorf("argument %s of %s must have a scalar type", arg.Name, config.Name)
+		}
+	}
+
+	return s.query.addField(&Field{
+		Name:    config.Name,
+		Type:    TypeRef{Name: config.Type, List: config.List, NonNull: config.List},
+		Args:    config.Args,
+		kind:    fieldRootQuery,
+		target:  target,
+		buildFn: config.Build,
+	})
+}
+
+// SDL 스키마를 GraphQL SDL 문자열로 출력합니다 (웹 클라이언트 코드 생성용)
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	b.WriteString("scalar JSON\n")
+
+	for _, name := range s.order {
+		writeObjectSDL(&b, s.types[name])
+	}
+	writeObjectSDL(&b, s.query)
+
+	b.WriteString("\nschema {\n  query: Query\n}\n")
+	return b.String()
+}
+
+func writeObjectSDL(b *strings.Builder, objectType *ObjectType) {
+	fmt.Fprintf(b, "\ntype %s {\n", objectType.Name)
+	for _, field := range objectType.Fields {
+		b.WriteString("  " + field.Name)
+		if len(field.Args) > 0 {
+			args := make([]string, len(field.Args))
+			for i, arg := range field.Args {
+				args[i] = arg.Name + ": " + arg.Type.String()
+			}
+			b.WriteString("(" + strings.Join(args, ", ") + ")")
+		}
+		b.WriteString(": " + field.Type.String() + "\n")
+	}
+	b.WriteString("}\n")
+}
+
+// structFields json 태그를 기준으로 구조체 필드를 GraphQL 필드로 변환합니다
+func structFields(structType reflect.Type) []*Field {
+	fields := make([]*Field, 0, structType.NumField())
+	seen := make(map[string]bool)
+
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// 태그 없는 임베디드 구조체는 필드를 펼칩니다 (encoding/json과 동일)
+		embedded := sf.Type
+		for embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if sf.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			for _, field := range structFields(embedded) {
+				if !seen[field.Name] {
+					seen[field.Name] = true
+					fields = append(fields, field)
+				}
+			}
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		if !isValidName(name) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		fields = append(fields, &Field{Name: name, Type: typeRefOf(sf.Type), kind: fieldValue, source: name})
+	}
+
+	return fields
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeRefOf Go 타입을 GraphQL 타입으로 매핑합니다
+func typeRefOf(goType reflect.Type) TypeRef {
+	for goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+	if goType == timeType {
+		return TypeRef{Name: TypeString}
+	}
+
+	switch goType.Kind() {
+	case reflect.String:
+		return TypeRef{Name: TypeString}
+	case reflect.Bool:
+		return TypeRef{Name: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeRef{Name: TypeInt}
+	case reflect.Float32, reflect.Float64:
+		return TypeRef{Name: TypeFloat}
+	case reflect.Slice, reflect.Array:
+		if goType.Elem().Kind() == reflect.Uint8 {
+			return TypeRef{Name: TypeString} // []byte는 base64 문자열
+		}
+		element := typeRefOf(goType.Elem())
+		if element.List {
+			return TypeRef{Name: TypeJSON}
+		}
+		return TypeRef{Name: element.Name, List: true}
+	default:
+		return TypeRef{Name: TypeJSON}
+	}
+}
+
+func isValidName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "__") {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
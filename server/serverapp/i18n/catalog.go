@@ -0,0 +1,341 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLocale 카탈로그 기본 로케일
+const DefaultLocale = "en"
+
+// CatalogConfig 메시지 카탈로그 설정
+type CatalogConfig struct {
+	Dir           string              `json:"catalog_dir"`         // 선택: <로케일>.json 파일이 있는 디렉터리
+	DefaultLocale string              `json:"default_locale"`      // 마지막 대체 로케일 (기본값: en)
+	Fallbacks     map[string][]string `json:"fallbacks,omitempty"` // 로케일별 추가 대체 순서 (예: "pt-BR": ["pt-PT"])
+}
+
+// Catalog 서버가 만드는 문자열(메일, 알림, 에러 메시지)의 로케일별 메시지 카탈로그
+// 메시지는 {name} 자리표시자를 가진 템플릿이며, 로케일에 없으면 대체 체인을 따라 찾습니다
+// nil Catalog는 키(또는 에러의 원래 메시지)를 그대로 반환하므로 선택 설정으로 쓸 수 있습니다
+type Catalog struct {
+	config CatalogConfig
+
+	mu       sync.RWMutex
+	messages map[string]map[string]string // 로케일 -> 키 -> 템플릿
+	files    map[string]fileStamp         // 마지막으로 읽은 파일 상태 (변경 감지용)
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewCatalog 새로운 카탈로그를 생성하고 Dir이 있으면 파일을 읽습니다
+func NewCatalog(config CatalogConfig) (*Catalog, error) {
+	if config.DefaultLocale == "" {
+		config.DefaultLocale = DefaultLocale
+	}
+	config.DefaultLocale = NormalizeLocale(config.DefaultLocale)
+	catalog := &Catalog{config: config, messages: make(map[string]map[string]string)}
+	if config.Dir != "" {
+		if err := catalog.Reload(); err != nil {
+			return nil, err
+		}
+	}
+	return catalog, nil
+}
+
+// DefaultLocale 마지막 대체 로케일
+func (c *Catalog) DefaultLocale() string {
+	if c == nil {
+		return DefaultLocale
+	}
+	return c.config.DefaultLocale
+}
+
+// Set locale의 메시지를 추가하거나 덮어씁니다 (코드에 내장한 기본 메시지, 테스트용)
+// Reload는 파일에서 읽은 메시지로 카탈로그 전체를 교체하므로 Set한 메시지는 사라집니다
+func (c *Catalog) Set(locale string, messages map[string]string) {
+	locale = NormalizeLocale(locale)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string, len(messages))
+	}
+	for key, template := range messages {
+		c.messages[locale][key] = template
+	}
+}
+
+// Reload Dir의 <로케일>.json 파일을 다시 읽어 카탈로그를 교체합니다
+// 한 파일이라도 잘못되면 에러를 반환하고 기존 카탈로그를 유지합니다
+func (c *Catalog) Reload() error {
+	if c.config.Dir == "" {
+		return errors.New("catalog has no directory to reload from")
+	}
+	entries, err := os.ReadDir(c.config.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read catalog directory: %w", err)
+	}
+
+	messages := make(map[string]map[string]string)
+	files := make(map[string]fileStamp)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(c.config.Dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var localeMessages map[string]string
+		if err := json.Unmarshal(data, &localeMessages); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		messages[NormalizeLocale(strings.TrimSuffix(entry.Name(), ".json"))] = localeMessages
+		files[entry.Name()] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+	}
+
+	c.mu.Lock()
+	c.messages = messages
+	c.files = files
+	c.mu.Unlock()
+	return nil
+}
+
+// Watch interval마다 Dir의 변경을 확인해 바뀐 카탈로그를 다시 읽습니다 (ctx가 끝나면 멈춤)
+// 잘못된 파일은 로그만 남기고 기존 메시지를 계속 사용합니다
+func (c *Catalog) Watch(ctx context.Context, interval time.Duration) {
+	if c.config.Dir == "" || interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !c.changed() {
+					continue
+				}
+				if err := c.Reload(); err != nil {
+					log.Printf("[I18n] Failed to reload catalog: %v", err)
+					continue
+				}
+				log.Printf("[I18n] Catalog reloaded from %s", c.config.Dir)
+			}
+		}
+	}()
+}
+
+// changed 마지막으로 읽은 뒤 파일이 추가, 삭제, 수정되었는지 확인합니다
+func (c *Catalog) changed() bool {
+	entries, err := os.ReadDir(c.config.Dir)
+	if err != nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	seen := 0
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return true
+		}
+		stamp, exists := c.files[entry.Name()]
+		if !exists || stamp.size != info.Size() || !stamp.modTime.Equal(info.ModTime()) {
+			return true
+		}
+		seen++
+	}
+	return seen != len(c.files)
+}
+
+// Locales 메시지가 있는 로케일 목록
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Chain locale의 대체 체인을 반환합니다
+// 예: ko-KR -> [ko-KR, (설정된 대체), ko, en]
+func (c *Catalog) Chain(locale string) []string {
+	chain := c.expand(locale)
+	for _, candidate := range chain {
+		if candidate == c.DefaultLocale() {
+			return chain
+		}
+	}
+	return append(chain, c.DefaultLocale())
+}
+
+// expand 기본 로케일을 제외한 대체 체인 (설정된 대체, 지역을 뗀 언어 순)
+func (c *Catalog) expand(locale string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	var visit func(candidate string)
+	visit = func(candidate string) {
+		if candidate == "" || seen[candidate] {
+			return
+		}
+		seen[candidate] = true
+		chain = append(chain, candidate)
+		if c != nil {
+			for _, fallback := range c.config.Fallbacks[candidate] {
+				visit(NormalizeLocale(fallback))
+			}
+		}
+		if base, _, found := strings.Cut(candidate, "-"); found {
+			visit(base)
+		}
+	}
+	visit(NormalizeLocale(locale))
+	return chain
+}
+
+// Supports locale(또는 대체 체인)의 메시지가 있는지 확인합니다 (기본 로케일로의 대체는 제외)
+func (c *Catalog) Supports(locale string) bool {
+	if c == nil {
+		return false
+	}
+	chain := c.expand(locale)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range chain {
+		if _, exists := c.messages[candidate]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate locale의 key 메시지에 params를 채워 반환합니다
+// 체인 어디에도 없으면 key를 그대로 반환합니다
+func (c *Catalog) Translate(locale, key string, params map[string]interface{}) string {
+	if message, found := c.lookup(locale, key); found {
+		return format(message, params)
+	}
+	return key
+}
+
+// Localize 컨텍스트의 로케일(WithLocale)로 key 메시지를 반환합니다
+func (c *Catalog) Localize(ctx context.Context, key string, params map[string]interface{}) string {
+	return c.Translate(LocaleFromContext(ctx), key, params)
+}
+
+// LocalizeOr Localize와 같지만 메시지가 없으면 fallback을 반환합니다
+func (c *Catalog) LocalizeOr(ctx context.Context, key, fallback string, params map[string]interface{}) string {
+	if message, found := c.lookup(LocaleFromContext(ctx), key); found {
+		return format(message, params)
+	}
+	return fallback
+}
+
+func (c *Catalog) lookup(locale, key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	chain := c.Chain(locale)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range chain {
+		if message, exists := c.messages[candidate][key]; exists {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// ErrorKey 센티널 에러와 메시지 키의 연결
+type ErrorKey struct {
+	Err error
+	Key string
+}
+
+// Error err와 일치하는(errors.Is) 첫 키의 메시지를 반환합니다
+// 일치하는 키나 메시지가 없으면 err.Error()를 반환하므로 영어 에러 문구가 최종 대체가 됩니다
+func (c *Catalog) Error(ctx context.Context, err error, keys []ErrorKey, params map[string]interface{}) string {
+	if err == nil {
+		return ""
+	}
+	for _, errorKey := range keys {
+		if errors.Is(err, errorKey.Err) {
+			return c.LocalizeOr(ctx, errorKey.Key, err.Error(), params)
+		}
+	}
+	return err.Error()
+}
+
+// format {name} 자리표시자를 params 값으로 바꿉니다 (없는 이름은 그대로 둠)
+func format(message string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	var formatted strings.Builder
+	for {
+		start := strings.IndexByte(message, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(message[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		formatted.WriteString(message[:start])
+		if value, exists := params[message[start+1:end]]; exists {
+			fmt.Fprint(&formatted, value)
+		} else {
+			formatted.WriteString(message[start : end+1])
+		}
+		message = message[end+1:]
+	}
+	formatted.WriteString(message)
+	return formatted.String()
+}
+
+// NormalizeLocale 로케일 표기를 통일합니다 (ko_kr, KO-KR -> ko-KR)
+func NormalizeLocale(locale string) string {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return ""
+	}
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i]) // 지역 (KR)
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:]) // 문자 체계 (Hant)
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"defense-allies-server/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCatalog(t *testing.T, dir, locale, body string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, locale+".json"), []byte(body), 0o644))
+}
+
+// stubPreferences 사용자별 언어 설정
+type stubPreferences map[string]string
+
+func (s stubPreferences) LoadUserPreferences(ctx context.Context, userID string) (*user.UserPreferences, error) {
+	language, exists := s[userID]
+	if !exists {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	preferences := user.NewUserPreferences(userID)
+	preferences.Language = language
+	return preferences, nil
+}
+
+func TestCatalog_FallbackChain(t *testing.T) {
+	// Arrange
+	catalog, err := NewCatalog(CatalogConfig{Fallbacks: map[string][]string{"pt-BR": {"pt-PT"}}})
+	require.NoError(t, err)
+	catalog.Set("en", map[string]string{"greeting": "Hello {name}", "farewell": "Bye"})
+	catalog.Set("ko", map[string]string{"greeting": "{name}님 안녕하세요"})
+	catalog.Set("pt-PT", map[string]string{"greeting": "Olá {name}"})
+	params := map[string]interface{}{"name": "Alice"}
+
+	// Act & Assert
+	assert.Equal(t, []string{"ko-KR", "ko", "en"}, catalog.Chain("ko_kr"))
+	assert.Equal(t, "Alice님 안녕하세요", catalog.Translate("ko-KR", "greeting", params))
+	assert.Equal(t, "Bye", catalog.Translate("ko-KR", "farewell", nil)) // 기본 로케일로 대체
+	assert.Equal(t, "Olá Alice", catalog.Translate("pt-BR", "greeting", params))
+	assert.Equal(t, "Hello {name}", catalog.Translate("fr", "greeting", nil))
+	assert.Equal(t, "missing.key", catalog.Translate("ko", "missing.key", nil))
+	assert.True(t, catalog.Supports("ko-KR"))
+	assert.False(t, catalog.Supports("fr"))
+}
+
+func TestCatalog_ErrorAndNilCatalog(t *testing.T) {
+	// Arrange
+	errRoomClosed := errors.New("room is closed")
+	keys := []ErrorKey{{Err: errRoomClosed, Key: "room.closed"}}
+	catalog, err := NewCatalog(CatalogConfig{})
+	require.NoError(t, err)
+	catalog.Set("ko", map[string]string{"room.closed": "종료된 방입니다"})
+	ctx := WithLocale(context.Background(), "ko")
+	var missing *Catalog
+
+	// Act & Assert
+	assert.Equal(t, "종료된 방입니다", catalog.Error(ctx, fmt.Errorf("join: %w", errRoomClosed), keys, nil))
+	assert.Equal(t, "other failure", catalog.Error(ctx, errors.New("other failure"), keys, nil))
+	assert.Equal(t, "room is closed", missing.Error(ctx, errRoomClosed, keys, nil))
+	assert.Equal(t, "fallback", missing.LocalizeOr(ctx, "room.closed", "fallback", nil))
+}
+
+func TestCatalog_ReloadKeepsPreviousOnError(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeCatalog(t, dir, "en", `{"welcome":"Welcome"}`)
+	catalog, err := NewCatalog(CatalogConfig{Dir: dir})
+	require.NoError(t, err)
+
+	// Act
+	writeCatalog(t, dir, "ko", `{"welcome":`)
+	brokenErr := catalog.Reload()
+	afterBroken := catalog.Translate("en", "welcome", nil)
+	writeCatalog(t, dir, "ko", `{"welcome":"환영합니다"}`)
+	fixedErr := catalog.Reload()
+
+	// Assert
+	assert.Error(t, brokenErr)
+	assert.Equal(t, "Welcome", afterBroken)
+	require.NoError(t, fixedErr)
+	assert.Equal(t, "환영합니다", catalog.Translate("ko", "welcome", nil))
+}
+
+func TestCatalog_WatchReloadsChangedFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeCatalog(t, dir, "en", `{"welcome":"Welcome"}`)
+	catalog, err := NewCatalog(CatalogConfig{Dir: dir})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	catalog.Watch(ctx, 10*time.Millisecond)
+	writeCatalog(t, dir, "en", `{"welcome":"Welcome back"}`)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return catalog.Translate("en", "welcome", nil) == "Welcome back"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"ko-KR", "ko", "en-US"}, ParseAcceptLanguage("en-US;q=0.5, ko-KR, ko;q=0.8, *;q=0.1"))
+	assert.Empty(t, ParseAcceptLanguage(""))
+}
+
+func TestMiddleware_ResolvesLocale(t *testing.T) {
+	// Arrange
+	catalog, err := NewCatalog(CatalogConfig{})
+	require.NoError(t, err)
+	catalog.Set("en", map[string]string{"welcome": "Welcome"})
+	catalog.Set("ko", map[string]string{"welcome": "환영합니다"})
+	resolver := PreferencesLocaleResolver(stubPreferences{"player-ko": "ko"})
+	identify := func(r *http.Request) string { return r.Header.Get("X-User-ID") }
+	handler := Middleware(catalog, resolver, identify)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(catalog.Localize(r.Context(), "welcome", nil)))
+	}))
+	request := func(userID, acceptLanguage string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-User-ID", userID)
+		r.Header.Set("Accept-Language", acceptLanguage)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec
+	}
+
+	// Act
+	preferred := request("player-ko", "en-US")
+	header := request("", "fr;q=0.9, ko-KR;q=0.8")
+	unsupported := request("unknown", "fr")
+
+	// Assert
+	assert.Equal(t, "환영합니다", preferred.Body.String()) // 사용자 설정이 헤더보다 우선
+	assert.Equal(t, "ko", preferred.Header().Get("Content-Language"))
+	assert.Equal(t, "환영합니다", header.Body.String())
+	assert.Equal(t, "ko-KR", header.Header().Get("Content-Language"))
+	assert.Equal(t, "Welcome", unsupported.Body.String())
+	assert.Equal(t, "en", unsupported.Header().Get("Content-Language"))
+}
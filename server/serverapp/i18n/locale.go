@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"defense-allies-server/internal/domain/user"
+)
+
+type localeKey struct{}
+
+// WithLocale 요청 로케일을 컨텍스트에 담습니다
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, NormalizeLocale(locale))
+}
+
+// LocaleFromContext 컨텍스트의 로케일 (없으면 빈 문자열이며, 카탈로그는 기본 로케일을 사용)
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// LocaleResolver 사용자의 선호 로케일을 찾습니다 (설정이 없으면 빈 문자열)
+type LocaleResolver interface {
+	ResolveLocale(ctx context.Context, userID string) (string, error)
+}
+
+// LocaleResolverFunc 함수를 LocaleResolver로 사용합니다
+type LocaleResolverFunc func(ctx context.Context, userID string) (string, error)
+
+func (f LocaleResolverFunc) ResolveLocale(ctx context.Context, userID string) (string, error) {
+	return f(ctx, userID)
+}
+
+// PreferencesLoader 사용자 설정 로더 (user.UserLazyLoader가 구현)
+type PreferencesLoader interface {
+	LoadUserPreferences(ctx context.Context, userID string) (*user.UserPreferences, error)
+}
+
+// PreferencesLocaleResolver UserPreferences.Language를 선호 로케일로 사용합니다
+func PreferencesLocaleResolver(loader PreferencesLoader) LocaleResolver {
+	return LocaleResolverFunc(func(ctx context.Context, userID string) (string, error) {
+		preferences, err := loader.LoadUserPreferences(ctx, userID)
+		if err != nil || preferences == nil {
+			return "", err
+		}
+		return preferences.Language, nil
+	})
+}
+
+// ParseAcceptLanguage Accept-Language 헤더를 선호도(q) 순 로케일 목록으로 바꿉니다
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var candidates []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, weighted{locale: NormalizeLocale(tag), q: q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	locales := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		locales[i] = candidate.locale
+	}
+	return locales
+}
+
+// ResolveRequestLocale 요청 로케일을 정합니다
+// 사용자 설정(resolver) -> 카탈로그가 지원하는 Accept-Language -> 기본 로케일 순입니다
+func ResolveRequestLocale(r *http.Request, catalog *Catalog, resolver LocaleResolver, identify func(r *http.Request) string) string {
+	if resolver != nil && identify != nil {
+		if userID := identify(r); userID != "" {
+			if locale, err := resolver.ResolveLocale(r.Context(), userID); err == nil && locale != "" {
+				return NormalizeLocale(locale)
+			}
+		}
+	}
+	for _, locale := range ParseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if catalog.Supports(locale) {
+			return locale
+		}
+	}
+	return catalog.DefaultLocale()
+}
+
+// Middleware 요청 로케일을 컨텍스트(WithLocale)와 Content-Language 헤더에 담는 HTTP 미들웨어
+// resolver와 identify는 선택이며, 없으면 Accept-Language만 사용합니다
+func Middleware(catalog *Catalog, resolver LocaleResolver, identify func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := ResolveRequestLocale(r, catalog, resolver, identify)
+			w.Header().Set("Content-Language", locale)
+			next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+		})
+	}
+}
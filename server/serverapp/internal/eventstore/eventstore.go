@@ -0,0 +1,92 @@
+package eventstore
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStoreRequired 재화를 다루는 서비스에 이벤트 저장소를 지정하지 않았을 때의 에러
+// (메모리 저장소로 조용히 대체하면 재시작할 때 잔액과 거래 기록이 사라집니다)
+var ErrStoreRequired = errors.New("event store is required")
+
+// EventStore 서버앱 애그리게이트의 이벤트 저장소
+// cqrsx.RedisEventStore, cqrsx.MongoEventStore가 이 메서드들을 구현합니다
+type EventStore interface {
+	SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error
+	GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+}
+
+// InMemoryEventStore 메모리 이벤트 저장소 (개발, 테스트용)
+type InMemoryEventStore struct {
+	mu     sync.RWMutex
+	events map[string][]cqrs.EventMessage
+}
+
+// NewInMemoryEventStore 새로운 InMemoryEventStore를 생성합니다
+func NewInMemoryEventStore() *InMemoryEventStore {
+	return &InMemoryEventStore{events: make(map[string][]cqrs.EventMessage)}
+}
+
+func (s *InMemoryEventStore) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	if len(events) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current := len(s.events[aggregateID]); current != expectedVersion {
+		return cqrs.NewConcurrencyError(fmt.Sprintf("expected version %d, current version %d", expectedVersion, current), nil)
+	}
+	s.events[aggregateID] = append(s.events[aggregateID], events...)
+	return nil
+}
+
+func (s *InMemoryEventStore) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history := make([]cqrs.EventMessage, 0, len(s.events[aggregateID]))
+	for _, event := range s.events[aggregateID] {
+		if event.Version() > fromVersion {
+			history = append(history, event)
+		}
+	}
+	return history, nil
+}
+
+// DecodeCommandData 명령 데이터를 T로 되돌립니다 (데이터가 없으면 T의 zero 값)
+// 변환에 실패하면 invalid로 감싼 에러를 반환합니다
+func DecodeCommandData[T any](data interface{}, invalid error) (T, error) {
+	if data == nil {
+		var zero T
+		return zero, nil
+	}
+	value, err := DecodeEventData[T](data)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("%w: %v", invalid, err)
+	}
+	return value.(T), nil
+}
+
+// DecodeEventData 값, 포인터, map(직렬화 저장소)으로 오는 데이터를 T 값으로 되돌립니다
+// 애그리게이트의 이벤트 디코더 표에 넣을 수 있도록 interface{}로 반환합니다
+func DecodeEventData[T any](data interface{}) (interface{}, error) {
+	switch value := data.(type) {
+	case T:
+		return value, nil
+	case *T:
+		return *value, nil
+	}
+	var decoded T
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", decoded, err)
+	}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", decoded, err)
+	}
+	return decoded, nil
+}
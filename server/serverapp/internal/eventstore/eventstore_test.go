@@ -0,0 +1,61 @@
+package eventstore
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// granted 디코딩 테스트용 이벤트 데이터
+type granted struct {
+	UserID string `json:"user_id"`
+	Amount int64  `json:"amount"`
+}
+
+var errInvalid = errors.New("invalid grant")
+
+func TestInMemoryEventStore_AppendsByExpectedVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := NewInMemoryEventStore()
+	event := func(version int) cqrs.EventMessage {
+		event := cqrs.NewBaseEventMessage("Granted")
+		event.AggregateID_, event.AggregateType_, event.Version_ = "wallet-1", "Wallet", version
+		return event
+	}
+
+	// Act
+	first := store.SaveEvents(ctx, "wallet-1", []cqrs.EventMessage{event(1), event(2)}, 0)
+	stale := store.SaveEvents(ctx, "wallet-1", []cqrs.EventMessage{event(2)}, 0)
+	history, err := store.GetEventHistory(ctx, "wallet-1", "Wallet", 1)
+
+	// Assert
+	require.NoError(t, first)
+	assert.True(t, cqrs.IsConcurrencyError(stale))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 2, history[0].Version())
+}
+
+func TestDecodeData(t *testing.T) {
+	// 값, 포인터, 직렬화 저장소의 map 모두 같은 값으로 복원
+	for _, data := range []interface{}{
+		granted{UserID: "alice", Amount: 5},
+		&granted{UserID: "alice", Amount: 5},
+		map[string]interface{}{"user_id": "alice", "amount": 5},
+	} {
+		decoded, err := DecodeEventData[granted](data)
+		require.NoError(t, err)
+		assert.Equal(t, granted{UserID: "alice", Amount: 5}, decoded)
+	}
+
+	empty, err := DecodeCommandData[granted](nil, errInvalid)
+	require.NoError(t, err)
+	assert.Zero(t, empty)
+	_, err = DecodeCommandData[granted](map[string]interface{}{"amount": "many"}, errInvalid)
+	assert.ErrorIs(t, err, errInvalid)
+}
@@ -0,0 +1,85 @@
+package testkit
+
+import (
+	"context"
+	"cqrs"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultStart 서버앱 테스트 시계의 기본 시작 시각
+var DefaultStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Clock 테스트에서 시간을 직접 움직이는 시계 (서비스 설정의 Now에 clock.Now를 넘깁니다)
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock start에서 멈춰 있는 시계를 생성합니다
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance 시계를 d만큼 앞으로 움직입니다
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set 시계를 지정한 시각으로 옮깁니다
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// StartedEventBus 시작된 메모리 이벤트 버스를 생성하고 테스트가 끝나면 종료합니다
+func StartedEventBus(t testing.TB) *cqrs.InMemoryEventBus {
+	t.Helper()
+	bus := cqrs.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+	return bus
+}
+
+// Registrar RegisterWith로 명령 핸들러를 디스패처에 등록하는 서비스
+type Registrar interface {
+	RegisterWith(dispatcher cqrs.CommandDispatcher) error
+}
+
+// NewDispatcher 서비스의 명령 핸들러를 등록한 메모리 디스패처를 생성합니다
+func NewDispatcher(t testing.TB, services ...Registrar) *cqrs.InMemoryCommandDispatcher {
+	t.Helper()
+	dispatcher := cqrs.NewInMemoryCommandDispatcher()
+	for _, service := range services {
+		require.NoError(t, service.RegisterWith(dispatcher))
+	}
+	return dispatcher
+}
+
+// Dispatch 명령을 보내고, 디스패치 자체의 에러 없이 결과를 반환하는지 확인합니다
+func Dispatch(t testing.TB, dispatcher cqrs.CommandDispatcher, command cqrs.Command) *cqrs.CommandResult {
+	t.Helper()
+	result, err := dispatcher.Dispatch(context.Background(), command)
+	require.NoError(t, err)
+	return result
+}
+
+// Handle 명령을 핸들러에 직접 보내고, 에러 없이 결과를 반환하는지 확인합니다
+func Handle(t testing.TB, handler cqrs.CommandHandler, command cqrs.Command) *cqrs.CommandResult {
+	t.Helper()
+	result, err := handler.Handle(context.Background(), command)
+	require.NoError(t, err)
+	return result
+}
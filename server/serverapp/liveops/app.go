@@ -0,0 +1,203 @@
+package liveops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 라이브옵스 기본 경로
+const DefaultBasePath = "/liveops"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 64 << 10
+
+// Config 라이브옵스 서버앱 설정
+type Config struct {
+	BasePath      string                          // 라우트 기본 경로 (기본값: /liveops)
+	Service       ServiceConfig                   // 라이브옵스 서비스 설정
+	ScheduleFile  string                          // 선택: 시작할 때 읽을 JSON 일정 파일
+	CheckInterval time.Duration                   // 일정 확인 주기 (기본값: 30초)
+	Auth          func(http.Handler) http.Handler // 선택: 일정 변경 라우트 인증 미들웨어 (조회 라우트는 공개)
+}
+
+// ActiveResponse 진행 중인 이벤트와 보정치
+type ActiveResponse struct {
+	Events    []ScheduledEvent `json:"events"`
+	Modifiers Modifiers        `json:"modifiers"`
+	Upcoming  []ScheduledEvent `json:"upcoming"` // 하루 안에 시작하는 이벤트
+}
+
+// LiveOpsApp 기간 한정 이벤트 일정을 관리하고 진행 중인 보정치를 알려주는 서버앱
+// 경제 설정 등 다른 모듈은 Service().ActiveModifiers로 보정치를 읽습니다
+type LiveOpsApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewLiveOpsApp 새로운 LiveOpsApp을 생성합니다
+func NewLiveOpsApp(config Config) (*LiveOpsApp, error) {
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = 30 * time.Second
+	}
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	if config.ScheduleFile != "" {
+		if err := service.LoadFile(config.ScheduleFile); err != nil {
+			return nil, err
+		}
+	}
+	return &LiveOpsApp{
+		BaseApp: serverapp.NewBaseApp("liveops"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 라이브옵스 서비스
+func (a *LiveOpsApp) Service() *Service {
+	return a.service
+}
+
+// Start 일정 확인을 시작합니다
+func (a *LiveOpsApp) Start(ctx context.Context) error {
+	a.service.Start(context.Background(), a.config.CheckInterval)
+	return a.BaseApp.Start(ctx)
+}
+
+// Stop 일정 확인을 멈춥니다
+func (a *LiveOpsApp) Stop(ctx context.Context) error {
+	a.service.Stop()
+	return a.BaseApp.Stop(ctx)
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *LiveOpsApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.HandleFunc(base+"/active", a.active)
+	mux.Handle(base+"/events", protect(http.HandlerFunc(a.events)))
+	mux.Handle(base+"/events/cancel", protect(http.HandlerFunc(a.cancel)))
+
+	log.Printf("[LiveOps] Routes registered under %s", base)
+}
+
+// DescribeAPI 라이브옵스 엔드포인트 설명 (/openapi.json)
+func (a *LiveOpsApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	return []serverapp.APIOperation{
+		{Method: http.MethodGet, Path: base + "/active", Summary: "진행 중인 이벤트와 보정치", Response: ActiveResponse{}},
+		{Method: http.MethodGet, Path: base + "/events", Summary: "전체 이벤트 일정", Response: []ScheduledEvent{}, Secured: secured},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/events",
+			Summary:     "이벤트 등록, 수정",
+			Description: "같은 ID는 덮어씁니다. 끝난 이벤트의 ID는 다시 시작하지 않으므로 새 이벤트는 새 ID로 등록합니다.",
+			Request:     ScheduledEvent{},
+			Response:    ScheduledEvent{},
+			Secured:     secured,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       base + "/events/cancel",
+			Summary:    "이벤트 취소",
+			Parameters: []serverapp.APIParameter{{Name: "id", In: "query", Required: true}},
+			Secured:    secured,
+		},
+	}
+}
+
+func (a *LiveOpsApp) active(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	sendJSON(w, http.StatusOK, ActiveResponse{
+		Events:    a.service.Active(),
+		Modifiers: a.service.ActiveModifiers(),
+		Upcoming:  a.service.Upcoming(24 * time.Hour),
+	})
+}
+
+func (a *LiveOpsApp) events(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		sendJSON(w, http.StatusOK, a.service.Events())
+	case http.MethodPost:
+		var event ScheduledEvent
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&event); err != nil {
+			sendError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		if err := a.service.Schedule(event); err != nil {
+			sendError(w, statusForError(err), err.Error())
+			return
+		}
+		sendJSON(w, http.StatusOK, event)
+	default:
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *LiveOpsApp) cancel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	eventID := r.URL.Query().Get("id")
+	if eventID == "" {
+		sendError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+	if err := a.service.Cancel(eventID); err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, map[string]interface{}{"success": true, "id": eventID})
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidEvent):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrEventNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
@@ -0,0 +1,191 @@
+package liveops
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock 테스트에서 시간을 직접 움직이는 시계
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// transitionRecorder 이벤트 버스로 받은 시작, 종료를 기록하는 핸들러
+type transitionRecorder struct {
+	*cqrs.BaseEventHandler
+	mu          sync.Mutex
+	transitions []string
+}
+
+func newTransitionRecorder(t *testing.T, bus cqrs.EventBus) *transitionRecorder {
+	t.Helper()
+	recorder := &transitionRecorder{
+		BaseEventHandler: cqrs.NewBaseEventHandler("liveops-recorder", cqrs.NotificationHandler, []string{LiveOpsEventStartedEventType, LiveOpsEventEndedEventType}),
+	}
+	for _, eventType := range recorder.GetSupportedEventTypes() {
+		_, err := bus.Subscribe(eventType, recorder)
+		require.NoError(t, err)
+	}
+	return recorder
+}
+
+func (r *transitionRecorder) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	transition := event.EventData().(Transition)
+	entry := event.EventType() + ":" + transition.EventID
+	if transition.Cancelled {
+		entry += ":cancelled"
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transitions = append(r.transitions, entry)
+	return nil
+}
+
+func (r *transitionRecorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	taken := r.transitions
+	r.transitions = nil
+	return taken
+}
+
+var testStart = time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC)
+
+func newTestBus(t *testing.T) cqrs.EventBus {
+	t.Helper()
+	bus := cqrs.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+	return bus
+}
+
+func TestScheduledEvent_ModifiersCombine(t *testing.T) {
+	weekend := ScheduledEvent{ID: "double-yield", Name: "Double Yield Weekend", StartsAt: testStart, EndsAt: testStart.Add(48 * time.Hour), Modifiers: map[string]float64{ModifierMiningYield: 2}}
+	goldRush := ScheduledEvent{ID: "gold-rush", Name: "Gold Rush", StartsAt: testStart.Add(24 * time.Hour), EndsAt: testStart.Add(72 * time.Hour), Modifiers: map[string]float64{ModifierMiningYield + ".gold": 1.5}}
+
+	assert.ErrorIs(t, ScheduledEvent{ID: "x", Name: "x", StartsAt: testStart, EndsAt: testStart}.Validate(), ErrInvalidEvent)
+	assert.ErrorIs(t, ScheduledEvent{ID: "x", Name: "x", StartsAt: testStart, EndsAt: testStart.Add(time.Hour), Modifiers: map[string]float64{"mining.yield": 0}}.Validate(), ErrInvalidEvent)
+
+	// 시작 시각은 포함, 종료 시각은 제외
+	assert.True(t, weekend.ActiveAt(testStart))
+	assert.False(t, weekend.ActiveAt(testStart.Add(48*time.Hour)))
+
+	events := []ScheduledEvent{weekend, goldRush}
+	first := activeModifiers(events, testStart)
+	assert.Equal(t, 2.0, first.For(ModifierMiningYield, "gold"))
+	overlap := activeModifiers(events, testStart.Add(30*time.Hour))
+	assert.Equal(t, 3.0, overlap.For(ModifierMiningYield, "gold"))
+	assert.Equal(t, 2.0, overlap.For(ModifierMiningYield, "iron"))
+	after := activeModifiers(events, testStart.Add(72*time.Hour))
+	assert.Equal(t, 1.0, after.For(ModifierMiningYield, "gold"))
+}
+
+func TestService_PublishesTransitionsOnce(t *testing.T) {
+	// Arrange
+	clock := &testClock{now: testStart}
+	bus := newTestBus(t)
+	recorder := newTransitionRecorder(t, bus)
+	store := NewInMemoryPhaseStore()
+	service, err := NewService(ServiceConfig{
+		EventBus: bus,
+		Store:    store,
+		Now:      clock.Now,
+		Events: []ScheduledEvent{
+			{ID: "missed", Name: "Missed While Down", StartsAt: testStart.Add(-5 * time.Hour), EndsAt: testStart.Add(-time.Hour)},
+			{ID: "happy-hour", Name: "Happy Hour", StartsAt: testStart.Add(-time.Hour), EndsAt: testStart.Add(2 * time.Hour)},
+			{ID: "weekend", Name: "Double Yield Weekend", StartsAt: testStart.Add(time.Hour), EndsAt: testStart.Add(3 * time.Hour), Modifiers: map[string]float64{ModifierMiningYield: 2}},
+		},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	check := func() []string {
+		_, err := service.CheckSchedule(ctx)
+		require.NoError(t, err)
+		return recorder.take()
+	}
+
+	// Act
+	atStart := check()
+	repeated := check()
+	clock.now = testStart.Add(time.Hour)
+	weekendStarted := check()
+	modifiers := service.ActiveModifiers()
+	require.NoError(t, service.Cancel("happy-hour"))
+	cancelled := check()
+	// 재시작: 같은 단계 저장소를 쓰는 새 서비스
+	restarted, err := NewService(ServiceConfig{EventBus: bus, Store: store, Now: clock.Now, Events: service.Events()})
+	require.NoError(t, err)
+	_, err = restarted.CheckSchedule(ctx)
+	require.NoError(t, err)
+	afterRestart := recorder.take()
+	clock.now = testStart.Add(3 * time.Hour)
+	_, err = restarted.CheckSchedule(ctx)
+	require.NoError(t, err)
+	ended := recorder.take()
+
+	// Assert
+	assert.Equal(t, []string{"LiveOpsEventStarted:happy-hour"}, atStart)
+	assert.Empty(t, repeated)
+	assert.Equal(t, []string{"LiveOpsEventStarted:weekend"}, weekendStarted)
+	assert.Equal(t, 2.0, modifiers.Get(ModifierMiningYield))
+	assert.Equal(t, []string{"LiveOpsEventEnded:happy-hour:cancelled"}, cancelled)
+	assert.Empty(t, afterRestart)
+	assert.Equal(t, []string{"LiveOpsEventEnded:weekend"}, ended)
+	assert.Empty(t, service.ActiveModifiers())
+}
+
+func TestLiveOpsApp_ScheduleAndQuery(t *testing.T) {
+	// Arrange
+	clock := &testClock{now: testStart}
+	schedule := filepath.Join(t.TempDir(), "liveops.json")
+	require.NoError(t, os.WriteFile(schedule, []byte(`{"events": [
+		{"id": "gold-rush", "name": "Gold Rush", "starts_at": "2025-03-20T00:00:00Z", "ends_at": "2025-03-22T00:00:00Z", "modifiers": {"mining.yield.gold": 1.5}}
+	]}`), 0o644))
+	app, err := NewLiveOpsApp(Config{
+		Service:      ServiceConfig{EventBus: newTestBus(t), Now: clock.Now},
+		ScheduleFile: schedule,
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	// Act
+	created := request(http.MethodPost, "/liveops/events", `{"id": "weekend", "name": "Double Yield Weekend", "starts_at": "2025-03-21T12:00:00Z", "ends_at": "2025-03-23T00:00:00Z", "modifiers": {"mining.yield": 2}}`)
+	invalid := request(http.MethodPost, "/liveops/events", `{"id": "broken", "name": "Broken", "starts_at": "2025-03-21T12:00:00Z", "ends_at": "2025-03-21T00:00:00Z"}`)
+	missing := request(http.MethodPost, "/liveops/events/cancel?id=nope", "")
+	active := request(http.MethodGet, "/liveops/active", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, created.Code, created.Body.String())
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	require.Equal(t, http.StatusOK, active.Code)
+	var response ActiveResponse
+	require.NoError(t, json.Unmarshal(active.Body.Bytes(), &response))
+	require.Len(t, response.Events, 1)
+	assert.Equal(t, "gold-rush", response.Events[0].ID)
+	assert.Equal(t, 1.5, response.Modifiers.For(ModifierMiningYield, "gold"))
+	require.Len(t, response.Upcoming, 1)
+	assert.Equal(t, "weekend", response.Upcoming[0].ID)
+}
@@ -0,0 +1,140 @@
+package liveops
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 라이브옵스 이벤트 타입 (이벤트 버스로 발행, 데이터는 Transition)
+const (
+	LiveOpsEventStartedEventType = "LiveOpsEventStarted"
+	LiveOpsEventEndedEventType   = "LiveOpsEventEnded"
+)
+
+// 경제 설정이 읽는 보정치 키 (광물별 키는 뒤에 ".<광물 이름>"을 붙임, 예: mining.yield.gold)
+const (
+	ModifierMiningYield         = "mining.yield"
+	ModifierMineralValue        = "mineral.value"
+	ModifierTransportRewardRate = "transport.reward_rate"
+)
+
+var (
+	ErrInvalidEvent  = errors.New("invalid live-ops event")
+	ErrEventNotFound = errors.New("live-ops event not found")
+)
+
+// ScheduledEvent 기획자가 데이터로 등록하는 기간 한정 이벤트 (예: 주말 채굴량 2배)
+// JSON 예: {"id":"double-yield-w12","name":"Double Yield Weekend","starts_at":"2025-03-22T00:00:00Z","ends_at":"2025-03-24T00:00:00Z","modifiers":{"mining.yield":2}}
+type ScheduledEvent struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	StartsAt    time.Time          `json:"starts_at"`
+	EndsAt      time.Time          `json:"ends_at"` // 이 시각부터 끝남
+	Modifiers   map[string]float64 `json:"modifiers,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+}
+
+// Validate 이벤트 유효성 검사
+func (e ScheduledEvent) Validate() error {
+	if strings.TrimSpace(e.ID) == "" || strings.TrimSpace(e.Name) == "" {
+		return fmt.Errorf("%w: id and name are required", ErrInvalidEvent)
+	}
+	if e.StartsAt.IsZero() || !e.EndsAt.After(e.StartsAt) {
+		return fmt.Errorf("%w: %s must end after it starts", ErrInvalidEvent, e.ID)
+	}
+	for key, multiplier := range e.Modifiers {
+		if key == "" || multiplier <= 0 {
+			return fmt.Errorf("%w: %s modifier %q must be a positive multiplier", ErrInvalidEvent, e.ID, key)
+		}
+	}
+	return nil
+}
+
+// ActiveAt at 시각에 진행 중인지 확인합니다 (StartsAt 포함, EndsAt 제외)
+func (e ScheduledEvent) ActiveAt(at time.Time) bool {
+	return !at.Before(e.StartsAt) && at.Before(e.EndsAt)
+}
+
+// clone 보정치 맵을 복사한 이벤트
+func (e ScheduledEvent) clone() ScheduledEvent {
+	cloned := e
+	if e.Modifiers != nil {
+		cloned.Modifiers = make(map[string]float64, len(e.Modifiers))
+		for key, multiplier := range e.Modifiers {
+			cloned.Modifiers[key] = multiplier
+		}
+	}
+	cloned.Tags = append([]string(nil), e.Tags...)
+	return cloned
+}
+
+// Modifiers 진행 중인 이벤트들의 보정치 (같은 키는 곱함)
+type Modifiers map[string]float64
+
+// Get key의 배율 (없으면 1)
+func (m Modifiers) Get(key string) float64 {
+	if multiplier, ok := m[key]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// For 전체 키와 세부 키를 곱한 배율 (예: For("mining.yield", "gold")는 mining.yield × mining.yield.gold)
+func (m Modifiers) For(key, detail string) float64 {
+	return m.Get(key) * m.Get(key+"."+detail)
+}
+
+// activeModifiers at 시각에 진행 중인 이벤트들의 보정치를 합칩니다
+func activeModifiers(events []ScheduledEvent, at time.Time) Modifiers {
+	modifiers := Modifiers{}
+	for _, event := range events {
+		if !event.ActiveAt(at) {
+			continue
+		}
+		for key, multiplier := range event.Modifiers {
+			modifiers[key] = modifiers.Get(key) * multiplier
+		}
+	}
+	return modifiers
+}
+
+// sortEvents 시작 시각, ID 순으로 정렬합니다
+func sortEvents(events []ScheduledEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].StartsAt.Equal(events[j].StartsAt) {
+			return events[i].StartsAt.Before(events[j].StartsAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
+
+// Transition 라이브옵스 이벤트의 시작 또는 종료 (LiveOpsEventStarted, LiveOpsEventEnded 데이터)
+type Transition struct {
+	EventID   string             `json:"event_id"`
+	Name      string             `json:"name"`
+	StartsAt  time.Time          `json:"starts_at"`
+	EndsAt    time.Time          `json:"ends_at"`
+	Modifiers map[string]float64 `json:"modifiers,omitempty"`
+	Cancelled bool               `json:"cancelled,omitempty"` // 진행 중에 일정에서 빠져 끝남
+	At        time.Time          `json:"at"`                  // 발행한 시각
+}
+
+// TransitionEvent 라이브옵스 이벤트 시작, 종료 알림
+type TransitionEvent struct {
+	*cqrs.BaseEventMessage
+	Transition Transition `json:"transition"`
+}
+
+// NewTransitionEvent 새로운 TransitionEvent를 생성합니다
+func NewTransitionEvent(eventType string, transition Transition) *TransitionEvent {
+	return &TransitionEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), Transition: transition}
+}
+
+func (e *TransitionEvent) EventData() interface{} {
+	return e.Transition
+}
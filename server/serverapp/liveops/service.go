@@ -0,0 +1,358 @@
+package liveops
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Phase 라이브옵스 이벤트의 발행 단계
+type Phase string
+
+const (
+	PhaseScheduled Phase = ""        // 아직 시작을 발행하지 않음
+	PhaseStarted   Phase = "started" // 시작을 발행함
+	PhaseEnded     Phase = "ended"   // 종료를 발행함 (같은 ID는 다시 시작하지 않음)
+)
+
+// PhaseStore 이벤트별로 마지막으로 발행한 단계를 기억합니다
+// 재시작한 서버가 시작, 종료를 두 번 발행하지 않으려면 영속 저장소로 구현해야 합니다
+type PhaseStore interface {
+	Phase(ctx context.Context, eventID string) (Phase, error)
+	RecordPhase(ctx context.Context, event ScheduledEvent, phase Phase) error
+	Started(ctx context.Context) ([]ScheduledEvent, error) // 시작을 발행하고 아직 끝나지 않은 이벤트
+}
+
+// InMemoryPhaseStore 메모리 단계 저장소 (개발, 테스트용)
+type InMemoryPhaseStore struct {
+	mu      sync.RWMutex
+	phases  map[string]Phase
+	started map[string]ScheduledEvent
+}
+
+// NewInMemoryPhaseStore 새로운 InMemoryPhaseStore를 생성합니다
+func NewInMemoryPhaseStore() *InMemoryPhaseStore {
+	return &InMemoryPhaseStore{phases: make(map[string]Phase), started: make(map[string]ScheduledEvent)}
+}
+
+func (s *InMemoryPhaseStore) Phase(ctx context.Context, eventID string) (Phase, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.phases[eventID], nil
+}
+
+func (s *InMemoryPhaseStore) RecordPhase(ctx context.Context, event ScheduledEvent, phase Phase) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases[event.ID] = phase
+	if phase == PhaseStarted {
+		s.started[event.ID] = event.clone()
+	} else {
+		delete(s.started, event.ID)
+	}
+	return nil
+}
+
+func (s *InMemoryPhaseStore) Started(ctx context.Context) ([]ScheduledEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events := make([]ScheduledEvent, 0, len(s.started))
+	for _, event := range s.started {
+		events = append(events, event.clone())
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// ServiceConfig 라이브옵스 서비스 설정
+type ServiceConfig struct {
+	EventBus cqrs.EventBus    // 필수: LiveOpsEventStarted, LiveOpsEventEnded 발행
+	Store    PhaseStore       // 발행 단계 저장소 (기본값: 메모리)
+	Events   []ScheduledEvent // 처음 일정
+	Now      func() time.Time // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 라이브옵스 일정을 들고 있다가 시작, 종료 시각에 이벤트를 발행하고 진행 중인 보정치를 알려줍니다
+// 일정 변경(Schedule, Cancel, Replace)은 다음 CheckSchedule에서 발행에 반영됩니다
+type Service struct {
+	config ServiceConfig
+	store  PhaseStore
+
+	mu     sync.RWMutex // 일정 보호
+	events map[string]ScheduledEvent
+
+	checkMu sync.Mutex // CheckSchedule 직렬화
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewService 새로운 Service를 생성합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.EventBus == nil {
+		return nil, errors.New("event bus is required to publish live-ops events")
+	}
+	if config.Store == nil {
+		config.Store = NewInMemoryPhaseStore()
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	service := &Service{config: config, store: config.Store, events: make(map[string]ScheduledEvent)}
+	if err := service.Replace(config.Events); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// Schedule 이벤트를 추가하거나 같은 ID의 이벤트를 바꿉니다
+// 이미 끝난 이벤트의 ID를 다시 쓰면 시작하지 않으므로 새 이벤트는 새 ID로 등록합니다
+func (s *Service) Schedule(event ScheduledEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[event.ID] = event.clone()
+	return nil
+}
+
+// Cancel 일정에서 이벤트를 뺍니다 (진행 중이었으면 다음 확인 때 종료를 발행)
+func (s *Service) Cancel(eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.events[eventID]; !ok {
+		return fmt.Errorf("%w: %s", ErrEventNotFound, eventID)
+	}
+	delete(s.events, eventID)
+	return nil
+}
+
+// Replace 일정 전체를 바꿉니다 (하나라도 잘못되면 아무것도 바꾸지 않음)
+func (s *Service) Replace(events []ScheduledEvent) error {
+	replaced := make(map[string]ScheduledEvent, len(events))
+	for _, event := range events {
+		if err := event.Validate(); err != nil {
+			return err
+		}
+		if _, duplicate := replaced[event.ID]; duplicate {
+			return fmt.Errorf("%w: duplicate id %s", ErrInvalidEvent, event.ID)
+		}
+		replaced[event.ID] = event.clone()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = replaced
+	return nil
+}
+
+// scheduleFile 일정 파일 형식
+type scheduleFile struct {
+	Events []ScheduledEvent `json:"events"`
+}
+
+// LoadFile JSON 일정 파일로 일정 전체를 바꿉니다 ({"events": [...]})
+func (s *Service) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read live-ops schedule: %w", err)
+	}
+	var file scheduleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse live-ops schedule: %w", err)
+	}
+	return s.Replace(file.Events)
+}
+
+// Event ID로 이벤트를 찾습니다
+func (s *Service) Event(eventID string) (ScheduledEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	event, ok := s.events[eventID]
+	if !ok {
+		return ScheduledEvent{}, fmt.Errorf("%w: %s", ErrEventNotFound, eventID)
+	}
+	return event.clone(), nil
+}
+
+// Events 시작 시각 순으로 정렬한 전체 일정
+func (s *Service) Events() []ScheduledEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events := make([]ScheduledEvent, 0, len(s.events))
+	for _, event := range s.events {
+		events = append(events, event.clone())
+	}
+	sortEvents(events)
+	return events
+}
+
+// Active 지금 진행 중인 이벤트
+func (s *Service) Active() []ScheduledEvent {
+	now := s.config.Now()
+	active := []ScheduledEvent{}
+	for _, event := range s.Events() {
+		if event.ActiveAt(now) {
+			active = append(active, event)
+		}
+	}
+	return active
+}
+
+// Upcoming within 안에 시작하는 이벤트
+func (s *Service) Upcoming(within time.Duration) []ScheduledEvent {
+	now := s.config.Now()
+	upcoming := []ScheduledEvent{}
+	for _, event := range s.Events() {
+		if event.StartsAt.After(now) && !event.StartsAt.After(now.Add(within)) {
+			upcoming = append(upcoming, event)
+		}
+	}
+	return upcoming
+}
+
+// ActiveModifiers 지금 진행 중인 이벤트들의 보정치
+// 발행 여부가 아니라 일정 시각으로 판단하므로 확인 주기가 늦어도 시작, 종료 시각에 바로 바뀝니다
+func (s *Service) ActiveModifiers() Modifiers {
+	return s.ModifiersAt(s.config.Now())
+}
+
+// ModifiersAt at 시각의 보정치
+func (s *Service) ModifiersAt(at time.Time) Modifiers {
+	return activeModifiers(s.Events(), at)
+}
+
+// CheckSchedule 시작, 종료 시각이 지난 이벤트를 발행하고 발행한 전환을 반환합니다
+// 발행에 실패한 전환은 기록하지 않으므로 다음 확인 때 다시 발행합니다
+// 서버가 꺼져 있는 동안 통째로 지나간 이벤트는 발행하지 않고 끝난 것으로만 기록합니다
+func (s *Service) CheckSchedule(ctx context.Context) ([]Transition, error) {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	now := s.config.Now()
+	events := s.Events()
+	scheduled := make(map[string]bool, len(events))
+	var fired []Transition
+	for _, event := range events {
+		scheduled[event.ID] = true
+		phase, err := s.store.Phase(ctx, event.ID)
+		if err != nil {
+			return fired, fmt.Errorf("failed to read phase of %s: %w", event.ID, err)
+		}
+
+		var (
+			eventType string
+			next      Phase
+			cancelled bool
+		)
+		switch {
+		case phase == PhaseScheduled && event.ActiveAt(now):
+			eventType, next = LiveOpsEventStartedEventType, PhaseStarted
+		case phase == PhaseScheduled && !now.Before(event.EndsAt):
+			if err := s.store.RecordPhase(ctx, event, PhaseEnded); err != nil {
+				return fired, fmt.Errorf("failed to record phase of %s: %w", event.ID, err)
+			}
+			continue
+		case phase == PhaseStarted && !now.Before(event.EndsAt):
+			eventType, next = LiveOpsEventEndedEventType, PhaseEnded
+		case phase == PhaseStarted && now.Before(event.StartsAt):
+			// 진행 중에 뒤로 미룬 이벤트는 끝내고 새 시작 시각에 다시 시작
+			eventType, next, cancelled = LiveOpsEventEndedEventType, PhaseScheduled, true
+		default:
+			continue
+		}
+		transition, err := s.publish(ctx, eventType, event, next, cancelled, now)
+		if err != nil {
+			return fired, err
+		}
+		fired = append(fired, transition)
+	}
+
+	started, err := s.store.Started(ctx)
+	if err != nil {
+		return fired, fmt.Errorf("failed to read started live-ops events: %w", err)
+	}
+	for _, event := range started {
+		if scheduled[event.ID] {
+			continue
+		}
+		transition, err := s.publish(ctx, LiveOpsEventEndedEventType, event, PhaseEnded, true, now)
+		if err != nil {
+			return fired, err
+		}
+		fired = append(fired, transition)
+	}
+	return fired, nil
+}
+
+func (s *Service) publish(ctx context.Context, eventType string, event ScheduledEvent, next Phase, cancelled bool, now time.Time) (Transition, error) {
+	transition := Transition{
+		EventID:   event.ID,
+		Name:      event.Name,
+		StartsAt:  event.StartsAt,
+		EndsAt:    event.EndsAt,
+		Modifiers: event.clone().Modifiers,
+		Cancelled: cancelled,
+		At:        now,
+	}
+	if err := s.config.EventBus.Publish(ctx, NewTransitionEvent(eventType, transition)); err != nil {
+		return transition, fmt.Errorf("failed to publish %s for %s: %w", eventType, event.ID, err)
+	}
+	if err := s.store.RecordPhase(ctx, event, next); err != nil {
+		return transition, fmt.Errorf("failed to record phase of %s: %w", event.ID, err)
+	}
+	log.Printf("[LiveOps] %s %s (%s)", eventType, event.ID, event.Name)
+	return transition, nil
+}
+
+// Start interval마다 일정을 확인합니다 (Stop을 부를 때까지)
+func (s *Service) Start(ctx context.Context, interval time.Duration) {
+	s.checkMu.Lock()
+	if s.stopCh != nil {
+		s.checkMu.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	s.stopCh = stopCh
+	s.checkMu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// 재시작 직후 밀린 전환을 바로 발행
+		if _, err := s.CheckSchedule(ctx); err != nil {
+			log.Printf("[LiveOps] Failed to check schedule: %v", err)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if _, err := s.CheckSchedule(ctx); err != nil {
+					log.Printf("[LiveOps] Failed to check schedule: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop 일정 확인을 멈춥니다
+func (s *Service) Stop() {
+	s.checkMu.Lock()
+	stopCh := s.stopCh
+	s.stopCh = nil
+	s.checkMu.Unlock()
+	if stopCh != nil {
+		close(stopCh)
+		s.wg.Wait()
+	}
+}
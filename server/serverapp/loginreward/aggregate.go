@@ -0,0 +1,300 @@
+package loginreward
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// LoginRewardAggregateType 출석 보상 애그리게이트 타입 (애그리게이트 ID는 사용자 ID)
+const LoginRewardAggregateType = "LoginReward"
+
+// 출석 이벤트 타입
+const (
+	LoginCheckedInEventType     = "LoginCheckedIn"
+	LoginRewardClaimedEventType = "LoginRewardClaimed"
+)
+
+// DayLayout 게임 날짜 형식 (리셋 시각 기준 지역 날짜)
+const DayLayout = "2006-01-02"
+
+var (
+	ErrInvalidCheckIn   = errors.New("invalid check-in")
+	ErrDayNotAdvanced   = errors.New("game day is before the last check-in")
+	ErrNotCheckedIn     = errors.New("not checked in today")
+	ErrAlreadyClaimed   = errors.New("today's login reward was already claimed")
+	ErrNoRewardSchedule = errors.New("login reward schedule is empty")
+	ErrStoreRequired    = eventstore.ErrStoreRequired
+)
+
+// Reward 출석 보상
+type Reward struct {
+	Currency int64            `json:"currency,omitempty"` // 프리미엄 재화
+	Items    map[string]int64 `json:"items,omitempty"`    // 아이템 ID -> 수량
+}
+
+// Scale percent(100 = 1배)만큼 늘린 보상 (소수점 이하는 버림)
+func (r Reward) Scale(percent int) Reward {
+	scaled := Reward{Currency: r.Currency * int64(percent) / 100}
+	if len(r.Items) > 0 {
+		scaled.Items = make(map[string]int64, len(r.Items))
+		for itemID, quantity := range r.Items {
+			scaled.Items[itemID] = quantity * int64(percent) / 100
+		}
+	}
+	return scaled
+}
+
+// StreakTier 연속 출석 일수에 따른 보상 배율
+type StreakTier struct {
+	MinStreak int `json:"min_streak"`
+	Percent   int `json:"percent"` // 100 = 1배
+}
+
+// Policy 출석 보상 정책
+type Policy struct {
+	Schedule  []Reward     `json:"schedule"`   // 주기 N일째 보상 (Schedule[0]이 1일째, 끝나면 처음부터)
+	Tiers     []StreakTier `json:"tiers"`      // 연속 출석 배율 (MinStreak가 가장 큰 조건 적용, 없으면 1배)
+	ResetHour int          `json:"reset_hour"` // 지역 시각 기준 하루가 바뀌는 시각 (0~23)
+}
+
+// CycleDay 연속 출석 streak일째가 보상 주기의 며칠째인지 (1부터)
+func (p Policy) CycleDay(streak int) int {
+	if len(p.Schedule) == 0 || streak <= 0 {
+		return 0
+	}
+	return (streak-1)%len(p.Schedule) + 1
+}
+
+// Multiplier 연속 출석 streak일째 보상 배율 (100 = 1배)
+func (p Policy) Multiplier(streak int) int {
+	percent, best := 100, 0
+	for _, tier := range p.Tiers {
+		if streak >= tier.MinStreak && tier.MinStreak >= best {
+			percent, best = tier.Percent, tier.MinStreak
+		}
+	}
+	return percent
+}
+
+// RewardFor 연속 출석 streak일째 보상 (배율 적용)
+func (p Policy) RewardFor(streak int) Reward {
+	cycleDay := p.CycleDay(streak)
+	if cycleDay == 0 {
+		return Reward{}
+	}
+	return p.Schedule[cycleDay-1].Scale(p.Multiplier(streak))
+}
+
+// GameDay 사용자 시간대 기준 at이 속한 게임 날짜
+// 하루는 지역 시각 ResetHour에 시작하며, 서머타임으로 건너뛰거나 반복되는 시각은 cqrs.NextDailyReset 규칙을 따릅니다
+func GameDay(at time.Time, loc *time.Location, resetHour int) string {
+	next := cqrs.NextDailyReset(at, loc, resetHour, 0).In(loc)
+	return time.Date(next.Year(), next.Month(), next.Day()-1, 0, 0, 0, 0, time.UTC).Format(DayLayout)
+}
+
+// daysBetween 두 게임 날짜 사이의 일수 (to - from)
+func daysBetween(from, to string) int {
+	fromDate, err := time.Parse(DayLayout, from)
+	if err != nil {
+		return 0
+	}
+	toDate, err := time.Parse(DayLayout, to)
+	if err != nil {
+		return 0
+	}
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// 출석 이벤트 데이터
+type (
+	LoginCheckedInData struct {
+		UserID      string    `json:"user_id"`
+		Day         string    `json:"day"`
+		Timezone    string    `json:"timezone"`
+		Streak      int       `json:"streak"`
+		CycleDay    int       `json:"cycle_day"`
+		CheckedInAt time.Time `json:"checked_in_at"`
+	}
+	LoginRewardClaimedData struct {
+		UserID    string    `json:"user_id"`
+		Day       string    `json:"day"`
+		Streak    int       `json:"streak"`
+		CycleDay  int       `json:"cycle_day"`
+		Percent   int       `json:"percent"`
+		Reward    Reward    `json:"reward"`
+		ClaimedAt time.Time `json:"claimed_at"`
+	}
+)
+
+// LoginRewardEvent 출석 보상 애그리게이트 이벤트
+type LoginRewardEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *LoginRewardEvent) EventData() interface{} {
+	return e.data
+}
+
+// LoginRewardAggregate 사용자 한 명의 출석과 보상 수령 기록
+type LoginRewardAggregate struct {
+	*cqrs.BaseAggregate
+	lastDay     string
+	timezone    string
+	streak      int
+	bestStreak  int
+	claimedDay  string
+	totalLogins int
+}
+
+// NewLoginRewardAggregate 새로운 LoginRewardAggregate를 생성합니다
+func NewLoginRewardAggregate(userID string) *LoginRewardAggregate {
+	return &LoginRewardAggregate{BaseAggregate: cqrs.NewBaseAggregate(userID, LoginRewardAggregateType)}
+}
+
+// LastDay 마지막으로 출석한 게임 날짜
+func (a *LoginRewardAggregate) LastDay() string {
+	return a.lastDay
+}
+
+// Timezone 마지막 출석에 쓴 시간대
+func (a *LoginRewardAggregate) Timezone() string {
+	return a.timezone
+}
+
+// Streak 마지막 출석 기준 연속 출석 일수
+func (a *LoginRewardAggregate) Streak() int {
+	return a.streak
+}
+
+// BestStreak 가장 긴 연속 출석 일수
+func (a *LoginRewardAggregate) BestStreak() int {
+	return a.bestStreak
+}
+
+// TotalLogins 지금까지 출석한 날 수
+func (a *LoginRewardAggregate) TotalLogins() int {
+	return a.totalLogins
+}
+
+// Claimed 게임 날짜 day의 보상을 받았는지 확인합니다
+func (a *LoginRewardAggregate) Claimed(day string) bool {
+	return day != "" && a.claimedDay == day
+}
+
+// CheckIn 게임 날짜 day에 출석합니다
+// 어제 출석했으면 연속 출석이 이어지고 하루라도 빠지면 1부터 다시 셉니다
+// 이미 출석한 날이면 아무것도 하지 않고 true를 반환합니다
+// 시간대를 바꿔 지나간 날짜로 돌아가는 출석은 ErrDayNotAdvanced로 거절합니다
+func (a *LoginRewardAggregate) CheckIn(day, timezone string, policy Policy, now time.Time) (bool, error) {
+	if _, err := time.Parse(DayLayout, day); err != nil {
+		return false, fmt.Errorf("%w: day %q", ErrInvalidCheckIn, day)
+	}
+	if len(policy.Schedule) == 0 {
+		return false, ErrNoRewardSchedule
+	}
+	if day == a.lastDay {
+		return true, nil
+	}
+	if a.lastDay != "" && day < a.lastDay {
+		return false, fmt.Errorf("%w: %s < %s", ErrDayNotAdvanced, day, a.lastDay)
+	}
+
+	streak := 1
+	if a.lastDay != "" && daysBetween(a.lastDay, day) == 1 {
+		streak = a.streak + 1
+	}
+	return false, a.raise(LoginCheckedInEventType, LoginCheckedInData{
+		UserID:      a.ID(),
+		Day:         day,
+		Timezone:    timezone,
+		Streak:      streak,
+		CycleDay:    policy.CycleDay(streak),
+		CheckedInAt: now,
+	})
+}
+
+// Claim 오늘(마지막 출석한 게임 날짜 day) 보상을 받습니다
+func (a *LoginRewardAggregate) Claim(day string, policy Policy, now time.Time) (Reward, error) {
+	if a.lastDay == "" || a.lastDay != day {
+		return Reward{}, ErrNotCheckedIn
+	}
+	if a.Claimed(day) {
+		return Reward{}, ErrAlreadyClaimed
+	}
+	if len(policy.Schedule) == 0 {
+		return Reward{}, ErrNoRewardSchedule
+	}
+	reward := policy.RewardFor(a.streak)
+	return reward, a.raise(LoginRewardClaimedEventType, LoginRewardClaimedData{
+		UserID:    a.ID(),
+		Day:       day,
+		Streak:    a.streak,
+		CycleDay:  policy.CycleDay(a.streak),
+		Percent:   policy.Multiplier(a.streak),
+		Reward:    reward,
+		ClaimedAt: now,
+	})
+}
+
+// LoadFromHistory 이벤트 스트림에서 출석 상태를 복원합니다
+func (a *LoginRewardAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *LoginRewardAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := loginRewardEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown login reward event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *LoginRewardAggregate) raise(eventType string, data interface{}) error {
+	event := &LoginRewardEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *LoginRewardAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case LoginCheckedInData:
+		a.lastDay = data.Day
+		a.timezone = data.Timezone
+		a.streak = data.Streak
+		a.totalLogins++
+		if a.streak > a.bestStreak {
+			a.bestStreak = a.streak
+		}
+	case LoginRewardClaimedData:
+		a.claimedDay = data.Day
+	}
+}
+
+// loginRewardEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var loginRewardEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	LoginCheckedInEventType:     eventstore.DecodeEventData[LoginCheckedInData],
+	LoginRewardClaimedEventType: eventstore.DecodeEventData[LoginRewardClaimedData],
+}
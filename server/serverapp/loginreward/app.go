@@ -0,0 +1,224 @@
+package loginreward
+
+import (
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 출석 보상 기본 경로
+const DefaultBasePath = "/login-rewards"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 4 << 10
+
+// Config 출석 보상 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /login-rewards)
+	Service  ServiceConfig                   // 출석 보상 서비스 설정
+	Calendar *CalendarProjection             // 선택: 설정하면 달력 조회 라우트 제공 (Service.EventBus를 구독시켜야 함)
+	Auth     func(http.Handler) http.Handler // 선택: 사용자 인증 미들웨어
+	Identify func(r *http.Request) string    // 필수: 출석하는 사용자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to bind check-ins to users")
+	}
+	if len(c.Service.Policy.Schedule) == 0 {
+		return ErrNoRewardSchedule
+	}
+	if c.Service.Policy.ResetHour < 0 || c.Service.Policy.ResetHour > 23 {
+		return errors.New("reset hour must be between 0 and 23")
+	}
+	return nil
+}
+
+// LoginRewardApp 매일 출석, 연속 출석 보상, 출석 달력을 제공하는 서버앱
+type LoginRewardApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewLoginRewardApp 새로운 LoginRewardApp을 생성합니다
+func NewLoginRewardApp(config Config) (*LoginRewardApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginRewardApp{
+		BaseApp: serverapp.NewBaseApp("loginreward"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 출석 보상 서비스
+func (a *LoginRewardApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *LoginRewardApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/check-in", protect(http.HandlerFunc(a.checkIn)))
+	mux.Handle(base+"/claim", protect(http.HandlerFunc(a.claim)))
+	if a.config.Calendar != nil {
+		mux.Handle(base+"/calendar", protect(http.HandlerFunc(a.calendar)))
+	}
+
+	log.Printf("[LoginReward] Routes registered under %s", base)
+}
+
+// DescribeAPI 출석 보상 엔드포인트 설명 (/openapi.json)
+func (a *LoginRewardApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	operations := []serverapp.APIOperation{
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/check-in",
+			Summary:     "오늘 출석",
+			Description: "timezone을 비우면 마지막 출석 시간대를 씁니다. 같은 날 다시 출석하면 duplicate가 true로 돌아옵니다.",
+			Request:     CheckInData{},
+			Response:    CheckInResult{},
+			Secured:     secured,
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     base + "/claim",
+			Summary:  "오늘 출석 보상 수령",
+			Response: ClaimResult{},
+			Secured:  secured,
+		},
+	}
+	if a.config.Calendar != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:  http.MethodGet,
+			Path:    base + "/calendar",
+			Summary: "출석 달력",
+			Parameters: []serverapp.APIParameter{
+				{Name: "timezone", In: "query", Description: "아직 출석 기록이 없을 때 쓸 IANA 시간대"},
+			},
+			Response: Calendar{},
+			Secured:  secured,
+		})
+	}
+	return operations
+}
+
+func (a *LoginRewardApp) checkIn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var data CheckInData
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	a.handle(w, r, NewCheckInCommand(a.config.Identify(r), data.Timezone))
+}
+
+func (a *LoginRewardApp) claim(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	a.handle(w, r, NewClaimLoginRewardCommand(a.config.Identify(r)))
+}
+
+func (a *LoginRewardApp) handle(w http.ResponseWriter, r *http.Request, command cqrs.Command) {
+	if command.ID() == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	result, err := a.service.Handle(r.Context(), command)
+	if err != nil {
+		log.Printf("[LoginReward] Failed to handle %s for %s: %v", command.CommandType(), command.ID(), err)
+		sendError(w, http.StatusInternalServerError, "login reward could not be processed, retry later")
+		return
+	}
+	if !result.Success {
+		sendError(w, statusForError(result.Error), result.Error.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, result.Data)
+}
+
+func (a *LoginRewardApp) calendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	view, err := a.config.Calendar.View(r.Context(), userID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	timezone := view.Timezone
+	if timezone == "" {
+		timezone = r.URL.Query().Get("timezone")
+	}
+	loc, err := a.service.Location(timezone)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, BuildCalendar(view, a.service.Policy(), loc, a.service.config.Now()))
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrAlreadyClaimed), errors.Is(err, ErrDayNotAdvanced):
+		return http.StatusConflict
+	case errors.Is(err, ErrNotCheckedIn):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrInvalidCheckIn):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
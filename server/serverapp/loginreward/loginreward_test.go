@@ -0,0 +1,226 @@
+package loginreward
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingGranter 지급 ID별로 한 번만 지급을 기록하는 Granter
+type recordingGranter struct {
+	failures int
+	grants   map[string]map[string]int64
+}
+
+func (g *recordingGranter) Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error {
+	if g.failures > 0 {
+		g.failures--
+		return errors.New("wallet unavailable")
+	}
+	if g.grants == nil {
+		g.grants = make(map[string]map[string]int64)
+	}
+	if _, exists := g.grants[grantID]; !exists {
+		g.grants[grantID] = amounts
+	}
+	return nil
+}
+
+// testPolicy 3일 주기, 3일 연속 출석부터 1.5배, 7일부터 2배
+var testPolicy = Policy{
+	Schedule: []Reward{
+		{Currency: 10},
+		{Currency: 20},
+		{Currency: 30, Items: map[string]int64{"plasma-core": 1}},
+	},
+	Tiers:     []StreakTier{{MinStreak: 7, Percent: 200}, {MinStreak: 3, Percent: 150}},
+	ResetHour: 5,
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestGameDay_UsesLocalResetHour(t *testing.T) {
+	seoul := mustLocation(t, "Asia/Seoul")
+	losAngeles := mustLocation(t, "America/Los_Angeles")
+
+	// 서울 05:00 직전과 직후
+	assert.Equal(t, "2025-01-01", GameDay(time.Date(2025, 1, 1, 19, 59, 0, 0, time.UTC), seoul, 5))
+	assert.Equal(t, "2025-01-02", GameDay(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC), seoul, 5))
+	// 같은 순간이라도 시간대마다 게임 날짜가 다름
+	assert.Equal(t, "2024-12-31", GameDay(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), losAngeles, 5))
+	// 서머타임 시작일 02:00이 없는 날에도 리셋 시각 2시로 하루가 바뀜
+	assert.Equal(t, "2025-03-08", GameDay(time.Date(2025, 3, 9, 9, 59, 0, 0, time.UTC), losAngeles, 2))
+	assert.Equal(t, "2025-03-09", GameDay(time.Date(2025, 3, 9, 10, 0, 0, 0, time.UTC), losAngeles, 2))
+}
+
+func TestLoginRewardAggregate_Streaks(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	login := NewLoginRewardAggregate("alice")
+
+	_, err := login.Claim("2025-01-01", testPolicy, now)
+	assert.ErrorIs(t, err, ErrNotCheckedIn)
+	_, err = login.CheckIn("2025-01-01", "UTC", Policy{}, now)
+	assert.ErrorIs(t, err, ErrNoRewardSchedule)
+
+	for _, day := range []string{"2025-01-01", "2025-01-02", "2025-01-03"} {
+		duplicate, err := login.CheckIn(day, "UTC", testPolicy, now)
+		require.NoError(t, err)
+		assert.False(t, duplicate)
+	}
+	duplicate, err := login.CheckIn("2025-01-03", "UTC", testPolicy, now)
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+	assert.Equal(t, 3, login.Streak())
+
+	// 3일째 보상은 1.5배
+	reward, err := login.Claim("2025-01-03", testPolicy, now)
+	require.NoError(t, err)
+	assert.Equal(t, Reward{Currency: 45, Items: map[string]int64{"plasma-core": 1}}, reward)
+	_, err = login.Claim("2025-01-03", testPolicy, now)
+	assert.ErrorIs(t, err, ErrAlreadyClaimed)
+
+	// 지나간 날짜로는 돌아갈 수 없고, 하루 빠지면 1부터 다시 셈
+	_, err = login.CheckIn("2025-01-02", "Asia/Seoul", testPolicy, now)
+	assert.ErrorIs(t, err, ErrDayNotAdvanced)
+	_, err = login.CheckIn("2025-01-05", "UTC", testPolicy, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, login.Streak())
+	assert.Equal(t, 3, login.BestStreak())
+
+	// 7일째부터 2배, 주기는 3일마다 처음부터
+	assert.Equal(t, 1, testPolicy.CycleDay(7))
+	assert.Equal(t, Reward{Currency: 20}, testPolicy.RewardFor(7))
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewLoginRewardAggregate("alice")
+	require.NoError(t, restored.LoadFromHistory(login.Changes()))
+	assert.Equal(t, "2025-01-05", restored.LastDay())
+	assert.Equal(t, 4, restored.TotalLogins())
+	assert.True(t, restored.Claimed("2025-01-03"))
+}
+
+func TestService_CheckInAndClaim(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(time.Date(2025, 1, 1, 21, 0, 0, 0, time.UTC)) // 서울 1월 2일 06:00
+	wallet := &recordingGranter{}
+	inventory := &recordingGranter{}
+	service, err := NewService(ServiceConfig{Store: eventstore.NewInMemoryEventStore(), Policy: testPolicy, Wallet: wallet, Inventory: inventory, Now: clock.Now})
+	require.NoError(t, err)
+	var claims []*cqrs.CommandResult
+
+	// Act
+	invalid := testkit.Handle(t, service, NewCheckInCommand("alice", "Mars/Olympus"))
+	for day := 0; day < 3; day++ {
+		testkit.Handle(t, service, NewCheckInCommand("alice", "Asia/Seoul"))
+		if day == 2 {
+			// 지갑 장애로 첫 수령은 실패하고 다시 요청
+			wallet.failures = 1
+			_, err := service.Handle(context.Background(), NewClaimLoginRewardCommand("alice"))
+			require.Error(t, err)
+		}
+		claims = append(claims, testkit.Handle(t, service, NewClaimLoginRewardCommand("alice")))
+		clock.Advance(24 * time.Hour)
+	}
+	again := testkit.Handle(t, service, NewCheckInCommand("alice", ""))
+	clock.Advance(48 * time.Hour)
+	missed := testkit.Handle(t, service, NewClaimLoginRewardCommand("alice"))
+
+	// Assert
+	assert.ErrorIs(t, invalid.Error, ErrInvalidCheckIn)
+	require.Len(t, claims, 3)
+	for _, claim := range claims {
+		require.True(t, claim.Success, claim.Error)
+	}
+	third := claims[2].Data.(ClaimResult)
+	assert.Equal(t, "2025-01-04", third.Day)
+	assert.Equal(t, 3, third.Streak)
+	assert.Equal(t, 150, third.Percent)
+	assert.Equal(t, int64(45), third.Reward.Currency)
+
+	assert.Equal(t, map[string]int64{"currency": 45}, wallet.grants["login:alice:2025-01-04"])
+	assert.Len(t, wallet.grants, 3)
+	assert.Equal(t, map[string]int64{"plasma-core": 1}, inventory.grants["login:alice:2025-01-04"])
+
+	checkIn := again.Data.(CheckInResult)
+	assert.Equal(t, 4, checkIn.Streak) // 시간대를 비우면 마지막 출석 시간대
+	assert.Equal(t, 1, checkIn.CycleDay)
+	assert.ErrorIs(t, missed.Error, ErrNotCheckedIn)
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{Policy: testPolicy, Wallet: &recordingGranter{}})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 출석과 수령 기록이 사라져 보상을 다시 받을 수 있음
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestLoginRewardApp_Calendar(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(time.Date(2025, 1, 1, 21, 0, 0, 0, time.UTC))
+	readStore := cqrs.NewInMemoryReadStore()
+	bus := testkit.StartedEventBus(t)
+	calendar := NewCalendarProjection(readStore)
+	_, err := calendar.Subscribe(bus)
+	require.NoError(t, err)
+	app, err := NewLoginRewardApp(Config{
+		Service:  ServiceConfig{Store: eventstore.NewInMemoryEventStore(), EventBus: bus, Policy: testPolicy, Wallet: &recordingGranter{}, Inventory: &recordingGranter{}, Now: clock.Now},
+		Calendar: calendar,
+		Identify: func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-User", "alice")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act
+	first := request(http.MethodPost, "/login-rewards/check-in", `{"timezone": "Asia/Seoul"}`)
+	request(http.MethodPost, "/login-rewards/claim", "")
+	clock.Advance(24 * time.Hour)
+	request(http.MethodPost, "/login-rewards/check-in", "")
+	secondClaim := request(http.MethodPost, "/login-rewards/claim", "")
+	claimTwice := request(http.MethodPost, "/login-rewards/claim", "")
+	clock.Advance(24 * time.Hour)
+	response := request(http.MethodGet, "/login-rewards/calendar", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	require.Equal(t, http.StatusOK, secondClaim.Code, secondClaim.Body.String())
+	assert.Equal(t, http.StatusConflict, claimTwice.Code)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	var got Calendar
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &got))
+	assert.Equal(t, "2025-01-04", got.Today)
+	assert.Equal(t, "Asia/Seoul", got.Timezone)
+	assert.Equal(t, time.Date(2025, 1, 4, 20, 0, 0, 0, time.UTC), got.NextResetAt.UTC())
+	assert.Equal(t, 3, got.Streak)
+	assert.False(t, got.Claimable)
+	require.Len(t, got.Cycle, 3)
+	assert.Equal(t, []string{SlotClaimed, SlotClaimed, SlotCheckIn}, []string{got.Cycle[0].Status, got.Cycle[1].Status, got.Cycle[2].Status})
+	assert.Equal(t, int64(45), got.Cycle[2].Reward.Currency)
+	assert.Equal(t, []string{"2025-01-02", "2025-01-03"}, got.History)
+}
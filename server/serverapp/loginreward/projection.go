@@ -0,0 +1,234 @@
+package loginreward
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CalendarViewType 출석 달력 읽기 모델 타입 (읽기 모델 ID는 사용자 ID)
+const CalendarViewType = "LoginCalendar"
+
+// historyDays 달력에 남기는 지난 출석 기록 일수 (두 달치)
+const historyDays = 62
+
+// 달력 칸 상태
+const (
+	SlotClaimed   = "claimed"   // 출석하고 보상을 받음
+	SlotUnclaimed = "unclaimed" // 출석했지만 보상을 받지 않고 날이 지남
+	SlotAvailable = "available" // 오늘 출석함, 보상 수령 가능
+	SlotCheckIn   = "check_in"  // 오늘 아직 출석하지 않음
+	SlotUpcoming  = "upcoming"  // 앞으로 연속 출석하면 받을 보상
+)
+
+// CalendarDay 출석한 하루
+type CalendarDay struct {
+	Streak   int    `json:"streak"`
+	CycleDay int    `json:"cycle_day"`
+	Claimed  bool   `json:"claimed"`
+	Reward   Reward `json:"reward,omitempty"` // 받은 보상
+}
+
+// CalendarView 사용자 한 명의 출석 기록
+type CalendarView struct {
+	*cqrs.BaseReadModel
+	UserID     string                 `json:"user_id"`
+	Timezone   string                 `json:"timezone"`
+	LastDay    string                 `json:"last_day"`
+	Streak     int                    `json:"streak"`
+	BestStreak int                    `json:"best_streak"`
+	Days       map[string]CalendarDay `json:"days"` // 게임 날짜 -> 출석 기록 (최근 historyDays일)
+}
+
+// NewCalendarView 새로운 CalendarView를 생성합니다
+func NewCalendarView(userID string) *CalendarView {
+	return &CalendarView{
+		BaseReadModel: cqrs.NewBaseReadModel(userID, CalendarViewType, map[string]interface{}{}),
+		UserID:        userID,
+		Days:          make(map[string]CalendarDay),
+	}
+}
+
+// GetData 읽기 모델 데이터
+func (v *CalendarView) GetData() interface{} {
+	return map[string]interface{}{
+		"user_id":     v.UserID,
+		"last_day":    v.LastDay,
+		"streak":      v.Streak,
+		"best_streak": v.BestStreak,
+	}
+}
+
+// CalendarProjection 출석 이벤트로 출석 달력을 갱신합니다
+// 날짜별 기록을 덮어쓰므로 같은 이벤트를 다시 받아도 바뀌지 않습니다
+type CalendarProjection struct {
+	*cqrs.BaseEventHandler
+	store cqrs.ReadStore
+
+	mu sync.Mutex // 같은 사용자 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewCalendarProjection 새로운 CalendarProjection을 생성합니다
+func NewCalendarProjection(store cqrs.ReadStore) *CalendarProjection {
+	return &CalendarProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler("login-calendar", cqrs.ProjectionHandler, []string{
+			LoginCheckedInEventType,
+			LoginRewardClaimedEventType,
+		}),
+		store: store,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *CalendarProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// View 사용자의 출석 기록 (없으면 빈 읽기 모델)
+func (p *CalendarProjection) View(ctx context.Context, userID string) (*CalendarView, error) {
+	view, err := cqrs.LoadReadModel[*CalendarView](ctx, p.store, userID, CalendarViewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewCalendarView(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login calendar of %s: %w", userID, err)
+	}
+	return view, nil
+}
+
+// Handle 출석 이벤트를 적용합니다
+func (p *CalendarProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	decode, known := loginRewardEventDecoders[event.EventType()]
+	if !known {
+		return nil
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	userID := event.AggregateID()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return cqrs.UpsertReadModel(ctx, p.store, userID, CalendarViewType,
+		func() *CalendarView { return NewCalendarView(userID) },
+		func(view *CalendarView) error {
+			if view.Days == nil {
+				view.Days = make(map[string]CalendarDay)
+			}
+			switch data := data.(type) {
+			case LoginCheckedInData:
+				day := view.Days[data.Day]
+				day.Streak, day.CycleDay = data.Streak, data.CycleDay
+				view.Days[data.Day] = day
+				if data.Day >= view.LastDay {
+					view.LastDay, view.Streak, view.Timezone = data.Day, data.Streak, data.Timezone
+				}
+				if data.Streak > view.BestStreak {
+					view.BestStreak = data.Streak
+				}
+				for recorded := range view.Days {
+					if daysBetween(recorded, view.LastDay) >= historyDays {
+						delete(view.Days, recorded)
+					}
+				}
+			case LoginRewardClaimedData:
+				day := view.Days[data.Day]
+				day.Claimed, day.Reward = true, data.Reward
+				view.Days[data.Day] = day
+			}
+			view.IncrementVersion()
+			return nil
+		})
+}
+
+// CalendarSlot 현재 보상 주기의 하루
+type CalendarSlot struct {
+	CycleDay int    `json:"cycle_day"`
+	Day      string `json:"day"` // 게임 날짜 (앞으로의 날은 매일 출석했을 때의 날짜)
+	Percent  int    `json:"percent"`
+	Reward   Reward `json:"reward"` // 배율을 적용한 보상
+	Status   string `json:"status"`
+}
+
+// Calendar 클라이언트 출석 화면 응답
+type Calendar struct {
+	UserID      string         `json:"user_id"`
+	Timezone    string         `json:"timezone"`
+	Today       string         `json:"today"`
+	NextResetAt time.Time      `json:"next_reset_at"`
+	Streak      int            `json:"streak"` // 오늘 출석하면 (또는 출석했으면) 이어지는 연속 출석 일수
+	BestStreak  int            `json:"best_streak"`
+	Claimable   bool           `json:"claimable"`
+	Cycle       []CalendarSlot `json:"cycle"`
+	History     []string       `json:"history"` // 최근 출석한 게임 날짜 (오래된 순)
+}
+
+// BuildCalendar 출석 기록과 정책으로 now 시점의 출석 달력을 만듭니다
+func BuildCalendar(view *CalendarView, policy Policy, loc *time.Location, now time.Time) *Calendar {
+	today := GameDay(now, loc, policy.ResetHour)
+	checkedIn := view.LastDay == today
+	streak := 1
+	switch {
+	case checkedIn:
+		streak = view.Streak
+	case view.LastDay != "" && daysBetween(view.LastDay, today) == 1:
+		streak = view.Streak + 1
+	}
+
+	calendar := &Calendar{
+		UserID:      view.UserID,
+		Timezone:    loc.String(),
+		Today:       today,
+		NextResetAt: cqrs.NextDailyReset(now, loc, policy.ResetHour, 0),
+		Streak:      streak,
+		BestStreak:  view.BestStreak,
+		Claimable:   checkedIn && !view.Days[today].Claimed,
+		Cycle:       []CalendarSlot{},
+		History:     []string{},
+	}
+	todayDate, _ := time.Parse(DayLayout, today)
+	if cycleDay := policy.CycleDay(streak); cycleDay > 0 {
+		start := streak - cycleDay + 1
+		for offset := range policy.Schedule {
+			slotStreak := start + offset
+			day := todayDate.AddDate(0, 0, slotStreak-streak).Format(DayLayout)
+			slot := CalendarSlot{
+				CycleDay: offset + 1,
+				Day:      day,
+				Percent:  policy.Multiplier(slotStreak),
+				Reward:   policy.RewardFor(slotStreak),
+			}
+			switch {
+			case view.Days[day].Claimed:
+				slot.Status = SlotClaimed
+			case slotStreak < streak:
+				slot.Status = SlotUnclaimed
+			case slotStreak == streak && checkedIn:
+				slot.Status = SlotAvailable
+			case slotStreak == streak:
+				slot.Status = SlotCheckIn
+			default:
+				slot.Status = SlotUpcoming
+			}
+			calendar.Cycle = append(calendar.Cycle, slot)
+		}
+	}
+	for offset := historyDays - 1; offset >= 0; offset-- {
+		day := todayDate.AddDate(0, 0, -offset).Format(DayLayout)
+		if _, ok := view.Days[day]; ok {
+			calendar.History = append(calendar.History, day)
+		}
+	}
+	return calendar
+}
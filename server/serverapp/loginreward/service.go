@@ -0,0 +1,273 @@
+package loginreward
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 출석 명령 타입 (명령의 ID는 사용자 ID)
+const (
+	CheckInCommandType          = "CheckInLogin"
+	ClaimLoginRewardCommandType = "ClaimLoginReward"
+)
+
+// CheckInData 출석 명령 데이터
+type CheckInData struct {
+	Timezone string `json:"timezone,omitempty"` // IANA 시간대 (비우면 마지막 출석 시간대, 그것도 없으면 기본 시간대)
+}
+
+// NewCheckInCommand 출석 명령
+func NewCheckInCommand(userID, timezone string) cqrs.Command {
+	return newLoginRewardCommand(CheckInCommandType, userID, CheckInData{Timezone: timezone})
+}
+
+// NewClaimLoginRewardCommand 오늘 출석 보상 수령 명령
+func NewClaimLoginRewardCommand(userID string) cqrs.Command {
+	return newLoginRewardCommand(ClaimLoginRewardCommandType, userID, nil)
+}
+
+func newLoginRewardCommand(commandType, userID string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, userID, LoginRewardAggregateType, data)
+	command.SetUserID(userID)
+	return command
+}
+
+// CheckInResult 출석 결과 (CommandResult.Data)
+type CheckInResult struct {
+	Day       string `json:"day"`
+	Streak    int    `json:"streak"`
+	CycleDay  int    `json:"cycle_day"`
+	Claimable bool   `json:"claimable"`           // 오늘 보상을 아직 받지 않음
+	Duplicate bool   `json:"duplicate,omitempty"` // 오늘 이미 출석함
+}
+
+// ClaimResult 보상 수령 결과 (CommandResult.Data)
+type ClaimResult struct {
+	Day      string `json:"day"`
+	Streak   int    `json:"streak"`
+	CycleDay int    `json:"cycle_day"`
+	Percent  int    `json:"percent"`
+	Reward   Reward `json:"reward"`
+}
+
+// Granter 사용자에게 재화나 아이템을 지급합니다
+// 같은 grantID는 한 번만 적용해야 합니다 (wallet.Service, trade.Service.Inventory가 구현)
+type Granter interface {
+	Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error
+}
+
+// ServiceConfig 출석 보상 서비스 설정
+type ServiceConfig struct {
+	Store           eventstore.EventStore // 필수: 출석 이벤트 저장소
+	EventBus        cqrs.EventBus         // 선택: 출석 이벤트 발행 (달력 프로젝션이 구독)
+	Policy          Policy                // 보상 주기, 연속 출석 배율, 리셋 시각
+	DefaultTimezone string                // 시간대를 모를 때 쓰는 시간대 (기본값: UTC)
+	Wallet          Granter               // 프리미엄 재화 지급 (보상에 재화가 있으면 필수)
+	Inventory       Granter               // 아이템 지급 (보상에 아이템이 있으면 필수)
+	Now             func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 출석과 보상 수령 명령을 처리하는 명령 핸들러
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu sync.Mutex // 출석 변경 직렬화
+}
+
+// NewService 새로운 Service를 생성합니다
+// 출석과 수령 기록이 재시작 후에도 남아야 재화를 다시 받지 못하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("loginreward: %w", ErrStoreRequired)
+	}
+	if config.DefaultTimezone == "" {
+		config.DefaultTimezone = "UTC"
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("login-reward", []string{CheckInCommandType, ClaimLoginRewardCommandType}),
+		config:             config,
+	}, nil
+}
+
+// RegisterWith 출석 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Policy 출석 보상 정책
+func (s *Service) Policy() Policy {
+	return s.config.Policy
+}
+
+// Location 시간대 이름을 불러옵니다 (비우면 기본 시간대)
+func (s *Service) Location(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = s.config.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidCheckIn, timezone)
+	}
+	return loc, nil
+}
+
+// Load 출석 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, userID string) (*LoginRewardAggregate, error) {
+	return s.load(ctx, userID)
+}
+
+// Handle 출석 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	userID := command.ID()
+	if userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: user ID is required", ErrInvalidCheckIn)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch command.CommandType() {
+	case CheckInCommandType:
+		return s.checkIn(ctx, aggregate, command)
+	case ClaimLoginRewardCommandType:
+		return s.claim(ctx, aggregate)
+	default:
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: unsupported command type %q", ErrInvalidCheckIn, command.CommandType())), nil
+	}
+}
+
+func (s *Service) checkIn(ctx context.Context, aggregate *LoginRewardAggregate, command cqrs.Command) (*cqrs.CommandResult, error) {
+	data, err := eventstore.DecodeCommandData[CheckInData](command.GetData(), ErrInvalidCheckIn)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	timezone := data.Timezone
+	if timezone == "" {
+		timezone = aggregate.Timezone()
+	}
+	loc, err := s.Location(timezone)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	now := s.config.Now()
+	day := GameDay(now, loc, s.config.Policy.ResetHour)
+	duplicate, err := aggregate.CheckIn(day, loc.String(), s.config.Policy, now)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	return cqrs.NewCommandResult(aggregate.ID(), aggregate.Version(), events...).WithData(CheckInResult{
+		Day:       day,
+		Streak:    aggregate.Streak(),
+		CycleDay:  s.config.Policy.CycleDay(aggregate.Streak()),
+		Claimable: !aggregate.Claimed(day),
+		Duplicate: duplicate,
+	}), nil
+}
+
+// claim 오늘 보상을 지급한 뒤 수령을 기록합니다
+// 지급 ID가 사용자와 날짜로 정해지므로 기록에 실패해 다시 요청해도 두 번 지급되지 않습니다
+func (s *Service) claim(ctx context.Context, aggregate *LoginRewardAggregate) (*cqrs.CommandResult, error) {
+	loc, err := s.Location(aggregate.Timezone())
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	now := s.config.Now()
+	day := GameDay(now, loc, s.config.Policy.ResetHour)
+	reward, err := aggregate.Claim(day, s.config.Policy, now)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	if err := s.grant(ctx, aggregate.ID(), "login:"+aggregate.ID()+":"+day, reward); err != nil {
+		return nil, err
+	}
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	log.Printf("[LoginReward] %s claimed day %d of streak %d (%s)", aggregate.ID(), s.config.Policy.CycleDay(aggregate.Streak()), aggregate.Streak(), day)
+	return cqrs.NewCommandResult(aggregate.ID(), aggregate.Version(), events...).WithData(ClaimResult{
+		Day:      day,
+		Streak:   aggregate.Streak(),
+		CycleDay: s.config.Policy.CycleDay(aggregate.Streak()),
+		Percent:  s.config.Policy.Multiplier(aggregate.Streak()),
+		Reward:   reward,
+	}), nil
+}
+
+func (s *Service) grant(ctx context.Context, userID, grantID string, reward Reward) error {
+	if reward.Currency > 0 {
+		if s.config.Wallet == nil {
+			return fmt.Errorf("no wallet configured for login reward %s", grantID)
+		}
+		if err := s.config.Wallet.Grant(ctx, userID, grantID, map[string]int64{"currency": reward.Currency}); err != nil {
+			return fmt.Errorf("failed to grant currency of %s: %w", grantID, err)
+		}
+	}
+	if len(reward.Items) > 0 {
+		if s.config.Inventory == nil {
+			return fmt.Errorf("no inventory configured for login reward %s", grantID)
+		}
+		if err := s.config.Inventory.Grant(ctx, userID, grantID, reward.Items); err != nil {
+			return fmt.Errorf("failed to grant items of %s: %w", grantID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) load(ctx context.Context, userID string) (*LoginRewardAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, userID, LoginRewardAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login rewards of %s: %w", userID, err)
+	}
+	aggregate := NewLoginRewardAggregate(userID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *LoginRewardAggregate) error {
+	events := aggregate.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save login rewards of %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[LoginReward] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,551 @@
+package serverapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LifecyclePhase 서버앱 생명주기 단계
+type LifecyclePhase int
+
+const (
+	PhaseRegistered LifecyclePhase = iota
+	PhaseInit
+	PhaseStart
+	PhaseReady
+	PhaseStopped
+	PhaseFailed
+)
+
+// String LifecyclePhase의 문자열 표현
+func (p LifecyclePhase) String() string {
+	switch p {
+	case PhaseRegistered:
+		return "registered"
+	case PhaseInit:
+		return "init"
+	case PhaseStart:
+		return "start"
+	case PhaseReady:
+		return "ready"
+	case PhaseStopped:
+		return "stopped"
+	case PhaseFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Initializer Start 이전에 초기화 단계가 필요한 서버앱이 구현합니다
+type Initializer interface {
+	// Init 서버앱을 초기화합니다 (의존 앱이 시작된 이후 호출됨)
+	Init(ctx context.Context) error
+}
+
+// ReadinessChecker 트래픽을 받을 준비가 되었는지 보고하는 서버앱이 구현합니다
+type ReadinessChecker interface {
+	// Ready 준비가 완료되면 nil을 반환합니다
+	Ready(ctx context.Context) error
+}
+
+// 기본 타임아웃 값
+const (
+	DefaultStartTimeout      = 30 * time.Second
+	DefaultStopTimeout       = 30 * time.Second
+	DefaultReadyPollInterval = 100 * time.Millisecond
+)
+
+// 매니저 에러
+var (
+	ErrAppAlreadyRegistered = errors.New("server app already registered")
+	ErrAppNotFound          = errors.New("server app not found")
+	ErrDependencyCycle      = errors.New("server app dependency cycle detected")
+	ErrDependencyFailed     = errors.New("server app dependency failed")
+	ErrManagerStarted       = errors.New("server app manager already started")
+)
+
+// RegisterOption 서버앱 등록 옵션
+type RegisterOption func(*appEntry)
+
+// DependsOn 먼저 준비 상태가 되어야 하는 서버앱 이름을 지정합니다
+func DependsOn(names ...string) RegisterOption {
+	return func(e *appEntry) {
+		e.dependsOn = append(e.dependsOn, names...)
+	}
+}
+
+// WithStartTimeout 서버앱의 init/start/ready 단계 전체에 대한 타임아웃을 지정합니다
+func WithStartTimeout(timeout time.Duration) RegisterOption {
+	return func(e *appEntry) {
+		e.startTimeout = timeout
+	}
+}
+
+// Optional 시작에 실패해도 매니저의 준비 상태를 막지 않는 서버앱으로 지정합니다.
+// 실패는 Phase와 HealthCheck로만 보고되며 StartAll의 에러에는 포함되지 않습니다.
+func Optional() RegisterOption {
+	return func(e *appEntry) {
+		e.optional = true
+	}
+}
+
+// WithStopTimeout 서버앱의 종료 타임아웃을 지정합니다
+func WithStopTimeout(timeout time.Duration) RegisterOption {
+	return func(e *appEntry) {
+		e.stopTimeout = timeout
+	}
+}
+
+// appEntry 매니저가 관리하는 서버앱 정보
+type appEntry struct {
+	app          ServerApp
+	dependsOn    []string
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	optional     bool
+	phase        LifecyclePhase
+	err          error
+}
+
+// Manager 여러 ServerApp의 의존성 순서와 생명주기 단계를 관리합니다
+type Manager struct {
+	mu         sync.RWMutex
+	apps       map[string]*appEntry
+	order      []string // 등록 순서
+	startOrder []string // 실제 시작된 순서 (종료는 역순)
+	mux        *http.ServeMux
+	openAPI    *OpenAPIGenerator
+	started    bool
+	ready      bool
+
+	readyPollInterval time.Duration
+	lateStarts        sync.WaitGroup // 타임아웃 이후에도 실행 중인 Start 호출
+}
+
+// NewManager 새로운 Manager를 생성합니다
+func NewManager() *Manager {
+	m := &Manager{
+		apps:              make(map[string]*appEntry),
+		mux:               http.NewServeMux(),
+		openAPI:           NewOpenAPIGenerator("Defense Allies Server API", "1.0.0"),
+		readyPollInterval: DefaultReadyPollInterval,
+	}
+	m.mux.Handle(OpenAPIPath, m.openAPI)
+	return m
+}
+
+// Register 서버앱을 등록하고 라우트를 Mux에 추가합니다
+func (m *Manager) Register(app ServerApp, opts ...RegisterOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return ErrManagerStarted
+	}
+
+	name := app.Name()
+	if _, exists := m.apps[name]; exists {
+		return fmt.Errorf("%w: %s", ErrAppAlreadyRegistered, name)
+	}
+
+	entry := &appEntry{
+		app:          app,
+		startTimeout: DefaultStartTimeout,
+		stopTimeout:  DefaultStopTimeout,
+		phase:        PhaseRegistered,
+	}
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	m.apps[name] = entry
+	m.order = append(m.order, name)
+	app.RegisterRoutes(m.mux)
+
+	// API 설명을 제공하는 서버앱은 OpenAPI 문서에 라우트를 기여
+	if describer, ok := app.(APIDescriber); ok {
+		m.openAPI.Add(name, describer.DescribeAPI()...)
+	}
+
+	return nil
+}
+
+// GetMux 등록된 서버앱들의 라우트를 가진 Mux를 반환합니다
+func (m *Manager) GetMux() *http.ServeMux {
+	return m.mux
+}
+
+// OpenAPI 등록된 서버앱들의 API 설명으로 /openapi.json을 생성하는 생성기를 반환합니다
+func (m *Manager) OpenAPI() *OpenAPIGenerator {
+	return m.openAPI
+}
+
+// resolveOrder 의존성을 고려한 시작 순서를 계산합니다 (동일 레벨은 등록 순서 유지)
+func (m *Manager) resolveOrder() ([]string, error) {
+	index := make(map[string]int, len(m.order))
+	for i, name := range m.order {
+		index[name] = i
+	}
+
+	inDegree := make(map[string]int, len(m.order))
+	dependents := make(map[string][]string, len(m.order))
+	for _, name := range m.order {
+		entry := m.apps[name]
+		for _, dep := range entry.dependsOn {
+			if _, ok := m.apps[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrAppNotFound, name, dep)
+			}
+			inDegree[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var queue []string
+	for _, name := range m.order {
+		if inDegree[name] == 0 {
+			queue = append(queue, name)
+		}
+	}
+
+	result := make([]string, 0, len(m.order))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		result = append(result, name)
+
+		for _, dependent := range dependents[name] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				queue = append(queue, dependent)
+				sort.SliceStable(queue, func(i, j int) bool {
+					return index[queue[i]] < index[queue[j]]
+				})
+			}
+		}
+	}
+
+	if len(result) != len(m.order) {
+		var cyclic []string
+		for _, name := range m.order {
+			if inDegree[name] > 0 {
+				cyclic = append(cyclic, name)
+			}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDependencyCycle, cyclic)
+	}
+
+	return result, nil
+}
+
+// StartAll 의존성 순서대로 서버앱을 init -> start -> ready 단계로 시작합니다.
+// 하나의 앱이 실패하면 그 앱에 의존하는 앱만 건너뛰고 나머지는 계속 시작합니다.
+// 필수 서버앱이 모두 준비되면 Optional 앱의 실패와 관계없이 준비 상태가 됩니다.
+func (m *Manager) StartAll(ctx context.Context) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return ErrManagerStarted
+	}
+	order, err := m.resolveOrder()
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	m.started = true
+	m.mu.Unlock()
+
+	var errs []error
+	for _, name := range order {
+		entry := m.entry(name)
+
+		if depErr := m.checkDependencies(entry); depErr != nil {
+			m.setPhase(entry, PhaseFailed, depErr)
+			if !entry.optional {
+				errs = append(errs, depErr)
+			}
+			log.Printf("[Manager] Skipping %s: %v", name, depErr)
+			continue
+		}
+
+		if err := m.startApp(ctx, entry); err != nil {
+			m.setPhase(entry, PhaseFailed, err)
+			if !entry.optional {
+				errs = append(errs, err)
+			}
+			log.Printf("[Manager] Failed to start %s: %v", name, err)
+		}
+	}
+
+	// Optional 앱의 실패는 errs에 포함되지 않으므로 필수 앱만 준비 상태를 결정
+	m.mu.Lock()
+	m.ready = len(errs) == 0
+	m.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// checkDependencies 의존 앱이 모두 준비 상태인지 확인합니다
+func (m *Manager) checkDependencies(entry *appEntry) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, dep := range entry.dependsOn {
+		if m.apps[dep].phase != PhaseReady {
+			return fmt.Errorf("%w: %s requires %s (%s)", ErrDependencyFailed, entry.app.Name(), dep, m.apps[dep].phase)
+		}
+	}
+	return nil
+}
+
+// startApp 단일 서버앱의 init/start/ready 단계를 타임아웃 내에서 수행합니다
+func (m *Manager) startApp(ctx context.Context, entry *appEntry) error {
+	name := entry.app.Name()
+
+	startCtx, cancel := context.WithTimeout(ctx, entry.startTimeout)
+	defer cancel()
+
+	if initializer, ok := entry.app.(Initializer); ok {
+		m.setPhase(entry, PhaseInit, nil)
+		if err := runWithContext(startCtx, initializer.Init); err != nil {
+			return fmt.Errorf("init %s: %w", name, err)
+		}
+	}
+
+	m.setPhase(entry, PhaseStart, nil)
+	if err := m.runStart(startCtx, entry); err != nil {
+		return fmt.Errorf("start %s: %w", name, err)
+	}
+
+	// Start가 반환된 이후에는 StopAll이 종료할 수 있도록 종료 목록에 추가
+	m.mu.Lock()
+	m.startOrder = append(m.startOrder, name)
+	m.mu.Unlock()
+
+	if checker, ok := entry.app.(ReadinessChecker); ok {
+		if err := m.waitReady(startCtx, checker); err != nil {
+			// 준비되지 못한 앱은 종료 목록에서 빼고 바로 종료 (이미 StopAll이 가져갔다면 그쪽에서 종료)
+			if m.removeStarted(name) {
+				if stopErr := m.stopApp(ctx, entry); stopErr != nil {
+					log.Printf("[Manager] Failed to stop %s after readiness failure: %v", name, stopErr)
+				}
+			}
+			return fmt.Errorf("ready %s: %w", name, err)
+		}
+	}
+
+	m.setPhase(entry, PhaseReady, nil)
+	log.Printf("[Manager] %s is ready", name)
+	return nil
+}
+
+// waitReady 준비 상태가 될 때까지 주기적으로 확인합니다
+func (m *Manager) waitReady(ctx context.Context, checker ReadinessChecker) error {
+	ticker := time.NewTicker(m.readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := checker.Ready(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// runStart Start를 타임아웃 내에서 실행합니다.
+// 타임아웃으로 먼저 반환한 경우 Start의 결과를 기다렸다가, 뒤늦게 성공했다면 앱을 종료시킵니다.
+func (m *Manager) runStart(ctx context.Context, entry *appEntry) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- entry.app.Start(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		m.lateStarts.Add(1)
+		go m.stopLateStart(entry, done)
+		return ctx.Err()
+	}
+}
+
+// stopLateStart 타임아웃 이후 반환된 Start가 성공했다면 서버앱을 종료합니다
+func (m *Manager) stopLateStart(entry *appEntry, done <-chan error) {
+	defer m.lateStarts.Done()
+
+	if err := <-done; err != nil {
+		return
+	}
+
+	name := entry.app.Name()
+	log.Printf("[Manager] %s started after its timeout, stopping it", name)
+	if err := m.stopApp(context.Background(), entry); err != nil {
+		log.Printf("[Manager] Failed to stop %s after late start: %v", name, err)
+	}
+}
+
+// stopApp 단일 서버앱을 종료 타임아웃 내에서 종료합니다
+func (m *Manager) stopApp(ctx context.Context, entry *appEntry) error {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), entry.stopTimeout)
+	defer cancel()
+	return runWithContext(stopCtx, entry.app.Stop)
+}
+
+// removeStarted 종료 목록에서 서버앱을 제거하고, 목록에 있었는지 반환합니다
+func (m *Manager) removeStarted(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, started := range m.startOrder {
+		if started == name {
+			m.startOrder = append(m.startOrder[:i], m.startOrder[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// StopAll 시작된 서버앱을 시작 역순으로 종료합니다.
+// 타임아웃 이후에도 실행 중인 Start 호출이 정리될 때까지 ctx 범위 내에서 기다립니다.
+func (m *Manager) StopAll(ctx context.Context) error {
+	m.mu.Lock()
+	m.ready = false
+	startOrder := make([]string, len(m.startOrder))
+	copy(startOrder, m.startOrder)
+	m.startOrder = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(startOrder) - 1; i >= 0; i-- {
+		entry := m.entry(startOrder[i])
+
+		if err := m.stopApp(ctx, entry); err != nil {
+			m.setPhase(entry, PhaseFailed, err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", startOrder[i], err))
+			log.Printf("[Manager] Failed to stop %s: %v", startOrder[i], err)
+			continue
+		}
+		m.setPhase(entry, PhaseStopped, nil)
+	}
+
+	lateDone := make(chan struct{})
+	go func() {
+		m.lateStarts.Wait()
+		close(lateDone)
+	}()
+	select {
+	case <-lateDone:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("wait for timed out starts: %w", ctx.Err()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsReady 필수 서버앱이 모두 준비 상태인지 확인합니다
+func (m *Manager) IsReady() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ready
+}
+
+// Phase 서버앱의 현재 생명주기 단계를 반환합니다
+func (m *Manager) Phase(name string) (LifecyclePhase, error) {
+	entry := m.entry(name)
+	if entry == nil {
+		return PhaseFailed, fmt.Errorf("%w: %s", ErrAppNotFound, name)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return entry.phase, entry.err
+}
+
+// HealthCheck 모든 서버앱의 헬스 상태를 반환합니다
+func (m *Manager) HealthCheck() map[string]HealthStatus {
+	m.mu.RLock()
+	entries := make(map[string]*appEntry, len(m.apps))
+	for name, entry := range m.apps {
+		entries[name] = entry
+	}
+	m.mu.RUnlock()
+
+	result := make(map[string]HealthStatus, len(entries))
+	for name, entry := range entries {
+		status := entry.app.Health()
+		if status.Details == nil {
+			status.Details = make(map[string]string)
+		}
+
+		phase, err := m.Phase(name)
+		status.Details["phase"] = phase.String()
+		if err != nil {
+			status.Status = HealthStatusUnhealthy
+			status.Details["error"] = err.Error()
+		}
+		result[name] = status
+	}
+	return result
+}
+
+// ReadinessGate 필수 서버앱이 모두 준비되기 전까지 요청을 503으로 거절하는 핸들러를 반환합니다.
+// HTTP 리스너를 먼저 열고 StartAll을 수행할 때 사용합니다.
+func (m *Manager) ReadinessGate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.IsReady() {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(HealthStatus{
+				Status:  HealthStatusUnhealthy,
+				Message: "Server is not ready",
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// entry 이름으로 등록 정보를 조회합니다
+func (m *Manager) entry(name string) *appEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.apps[name]
+}
+
+// setPhase 서버앱의 생명주기 단계를 변경합니다
+func (m *Manager) setPhase(entry *appEntry, phase LifecyclePhase, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry.phase = phase
+	entry.err = err
+}
+
+// runWithContext 함수를 실행하되 컨텍스트가 먼저 종료되면 즉시 반환합니다
+func runWithContext(ctx context.Context, fn func(context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,290 @@
+package serverapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp 생명주기 호출 순서를 기록하는 테스트용 서버앱
+type testApp struct {
+	*BaseApp
+	events   *[]string
+	mu       *sync.Mutex
+	startErr error
+	startFor time.Duration
+	readyIn  int // 음수이면 준비되지 않음
+
+	ignoreCancel bool // true이면 컨텍스트 취소를 무시하고 startFor 동안 시작
+}
+
+func newTestApp(name string, events *[]string, mu *sync.Mutex) *testApp {
+	return &testApp{BaseApp: NewBaseApp(name), events: events, mu: mu}
+}
+
+func (a *testApp) record(event string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	*a.events = append(*a.events, a.Name()+":"+event)
+}
+
+func (a *testApp) Init(ctx context.Context) error {
+	a.record("init")
+	return nil
+}
+
+func (a *testApp) Start(ctx context.Context) error {
+	a.record("start")
+	if a.startFor > 0 && a.ignoreCancel {
+		time.Sleep(a.startFor)
+	} else if a.startFor > 0 {
+		select {
+		case <-time.After(a.startFor):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if a.startErr != nil {
+		return a.startErr
+	}
+	return a.BaseApp.Start(ctx)
+}
+
+func (a *testApp) Ready(ctx context.Context) error {
+	if a.readyIn < 0 {
+		return errors.New("never ready")
+	}
+	if a.readyIn > 0 {
+		a.readyIn--
+		return errors.New("warming up")
+	}
+	a.record("ready")
+	return nil
+}
+
+func (a *testApp) Stop(ctx context.Context) error {
+	a.record("stop")
+	return a.BaseApp.Stop(ctx)
+}
+
+func TestManager_StartAll_DependencyOrder(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	manager.readyPollInterval = time.Millisecond
+
+	matchmaking := newTestApp("matchmaking", &events, &mu)
+	auth := newTestApp("auth", &events, &mu)
+	auth.readyIn = 2
+
+	require.NoError(t, manager.Register(matchmaking, DependsOn("auth")))
+	require.NoError(t, manager.Register(auth))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, manager.IsReady())
+	assert.Equal(t, []string{
+		"auth:init", "auth:start", "auth:ready",
+		"matchmaking:init", "matchmaking:start", "matchmaking:ready",
+	}, events)
+
+	// Act - 종료는 역순
+	events = nil
+	require.NoError(t, manager.StopAll(context.Background()))
+	assert.Equal(t, []string{"matchmaking:stop", "auth:stop"}, events)
+	assert.False(t, manager.IsReady())
+}
+
+func TestManager_StartAll_DependencyCycle(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	require.NoError(t, manager.Register(newTestApp("a", &events, &mu), DependsOn("b")))
+	require.NoError(t, manager.Register(newTestApp("b", &events, &mu), DependsOn("a")))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Empty(t, events)
+}
+
+func TestManager_StartAll_UnknownDependency(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	require.NoError(t, manager.Register(newTestApp("a", &events, &mu), DependsOn("missing")))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAppNotFound)
+}
+
+func TestManager_StartAll_FailedDependencySkipsDependents(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+
+	auth := newTestApp("auth", &events, &mu)
+	auth.startErr = errors.New("boom")
+	require.NoError(t, manager.Register(auth))
+	require.NoError(t, manager.Register(newTestApp("matchmaking", &events, &mu), DependsOn("auth")))
+	require.NoError(t, manager.Register(newTestApp("health", &events, &mu)))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDependencyFailed)
+	assert.False(t, manager.IsReady())
+
+	phase, _ := manager.Phase("matchmaking")
+	assert.Equal(t, PhaseFailed, phase)
+	phase, _ = manager.Phase("health")
+	assert.Equal(t, PhaseReady, phase)
+	assert.NotContains(t, events, "matchmaking:start")
+}
+
+func TestManager_StartAll_StartTimeout(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+
+	slow := newTestApp("slow", &events, &mu)
+	slow.startFor = time.Second
+	require.NoError(t, manager.Register(slow, WithStartTimeout(10*time.Millisecond)))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	phase, phaseErr := manager.Phase("slow")
+	assert.Equal(t, PhaseFailed, phase)
+	assert.Error(t, phaseErr)
+}
+
+func TestManager_StartAll_LateStartIsStopped(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+
+	slow := newTestApp("slow", &events, &mu)
+	slow.startFor = 50 * time.Millisecond
+	slow.ignoreCancel = true
+	require.NoError(t, manager.Register(slow, WithStartTimeout(10*time.Millisecond)))
+
+	// Act
+	err := manager.StartAll(context.Background())
+	require.NoError(t, manager.StopAll(context.Background()))
+
+	// Assert - 타임아웃 이후 성공한 Start는 StopAll 전에 종료됨
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"slow:init", "slow:start", "slow:stop"}, events)
+	assert.False(t, slow.IsRunning())
+}
+
+func TestManager_StartAll_ReadyFailureStopsApp(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	manager.readyPollInterval = time.Millisecond
+
+	stuck := newTestApp("stuck", &events, &mu)
+	stuck.readyIn = -1
+	require.NoError(t, manager.Register(stuck, WithStartTimeout(20*time.Millisecond)))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert - 준비에 실패한 앱은 바로 종료되고 StopAll에서 다시 종료되지 않음
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"stuck:init", "stuck:start", "stuck:stop"}, events)
+	assert.False(t, stuck.IsRunning())
+
+	require.NoError(t, manager.StopAll(context.Background()))
+	assert.Equal(t, []string{"stuck:init", "stuck:start", "stuck:stop"}, events)
+}
+
+func TestManager_StartAll_OptionalFailureKeepsReady(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+
+	metrics := newTestApp("metrics", &events, &mu)
+	metrics.startErr = errors.New("exporter unavailable")
+	require.NoError(t, manager.Register(metrics, Optional()))
+	require.NoError(t, manager.Register(newTestApp("auth", &events, &mu)))
+
+	// Act
+	err := manager.StartAll(context.Background())
+
+	// Assert - 선택 앱의 실패는 준비 상태를 막지 않고 헬스 체크로만 보고됨
+	require.NoError(t, err)
+	assert.True(t, manager.IsReady())
+
+	phase, phaseErr := manager.Phase("metrics")
+	assert.Equal(t, PhaseFailed, phase)
+	assert.Error(t, phaseErr)
+	assert.Equal(t, HealthStatusUnhealthy, manager.HealthCheck()["metrics"].Status)
+}
+
+func TestManager_ReadinessGate(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	require.NoError(t, manager.Register(newTestApp("auth", &events, &mu)))
+
+	handler := manager.ReadinessGate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Act & Assert - 시작 전에는 503
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// Act & Assert - 준비 후에는 통과
+	require.NoError(t, manager.StartAll(context.Background()))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestManager_Register_Duplicate(t *testing.T) {
+	// Arrange
+	var events []string
+	var mu sync.Mutex
+	manager := NewManager()
+	require.NoError(t, manager.Register(newTestApp("auth", &events, &mu)))
+
+	// Act
+	err := manager.Register(newTestApp("auth", &events, &mu))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAppAlreadyRegistered)
+}
@@ -0,0 +1,346 @@
+package match
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+
+	"defense-allies-server/pkg/tower/synergy"
+)
+
+// MatchAggregateType 매치 애그리게이트 타입
+const MatchAggregateType = "Match"
+
+// 매치 상태
+const (
+	StatusInProgress = "in_progress"
+	StatusEnded      = "ended"
+)
+
+// 매치 결과
+const (
+	ResultVictory = "victory"
+	ResultDefeat  = "defeat"
+)
+
+// 매치 이벤트 타입
+const (
+	MatchStartedEventType = "MatchStarted"
+	TowerPlacedEventType  = "TowerPlaced"
+	TowerSoldEventType    = "TowerSold"
+	WaveStartedEventType  = "WaveStarted"
+	WaveClearedEventType  = "WaveCleared"
+	MatchEndedEventType   = "MatchEnded"
+)
+
+// ErrInvalidCommand 게임 규칙상 처리할 수 없는 명령 (클라이언트 오류)
+var ErrInvalidCommand = errors.New("invalid match command")
+
+// Rules 매치 규칙
+type Rules struct {
+	StartingGold      int64            `json:"starting_gold"`
+	TowerCosts        map[string]int64 `json:"tower_costs"`         // 타워 종류별 가격
+	SellRefundPercent int64            `json:"sell_refund_percent"` // 판매 시 돌려받는 비율
+	WaveDurationTicks int64            `json:"wave_duration_ticks"` // 웨이브 하나가 진행되는 틱 수
+	WaveReward        int64            `json:"wave_reward"`         // 웨이브 클리어 시 플레이어별 보상
+	FinalWave         int              `json:"final_wave"`          // 이 웨이브를 클리어하면 승리
+	Synergy           *synergy.Matrix  `json:"synergy,omitempty"`   // 협동 시너지 매트릭스 (nil이면 시너지 없음)
+}
+
+// DefaultRules 기본 매치 규칙
+func DefaultRules() Rules {
+	return Rules{
+		StartingGold:      300,
+		TowerCosts:        map[string]int64{"arrow": 100, "cannon": 150, "frost": 120},
+		SellRefundPercent: 50,
+		WaveDurationTicks: 300,
+		WaveReward:        100,
+		FinalWave:         10,
+	}
+}
+
+// Tower 배치된 타워
+type Tower struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+	Kind    string `json:"kind"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Cost    int64  `json:"cost"`
+}
+
+// MatchState 매치 상태 스냅샷 (브로드캐스트와 조회용, 생성 후 변경하지 않음)
+type MatchState struct {
+	MatchID        string                   `json:"match_id"`
+	Tick           int64                    `json:"tick"` // 상태를 만든 룸 틱
+	Status         string                   `json:"status"`
+	Result         string                   `json:"result,omitempty"`
+	Wave           int                      `json:"wave"`
+	WaveInProgress bool                     `json:"wave_in_progress"`
+	WaveEndsAtTick int64                    `json:"wave_ends_at_tick,omitempty"`
+	Gold           map[string]int64         `json:"gold"`
+	Towers         map[string]Tower         `json:"towers"`
+	Synergy        map[string]synergy.Bonus `json:"synergy,omitempty"` // 타워별 시너지 보너스 (보너스가 있는 타워만)
+}
+
+// 이벤트 데이터
+type (
+	MatchStartedData struct {
+		Players      []string `json:"players"`
+		StartingGold int64    `json:"starting_gold"`
+	}
+	TowerPlacedData struct {
+		Tower Tower `json:"tower"`
+	}
+	TowerSoldData struct {
+		TowerID  string `json:"tower_id"`
+		PlayerID string `json:"player_id"`
+		Refund   int64  `json:"refund"`
+	}
+	WaveStartedData struct {
+		Wave       int    `json:"wave"`
+		StartedBy  string `json:"started_by"`
+		EndsAtTick int64  `json:"ends_at_tick"`
+	}
+	WaveClearedData struct {
+		Wave   int   `json:"wave"`
+		Reward int64 `json:"reward"`
+	}
+	MatchEndedData struct {
+		Result string `json:"result"`
+		Reason string `json:"reason"`
+	}
+)
+
+// MatchEvent 매치 애그리게이트 이벤트
+type MatchEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func newMatchEvent(eventType string, data interface{}) *MatchEvent {
+	return &MatchEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+}
+
+func (e *MatchEvent) EventData() interface{} {
+	return e.data
+}
+
+// MatchAggregate 진행 중인 매치 하나의 상태
+// 동시성 보호가 없으므로 매치를 소유한 룸 고루틴에서만 다뤄야 합니다
+type MatchAggregate struct {
+	*cqrs.BaseAggregate
+	rules          Rules
+	status         string
+	result         string
+	wave           int
+	waveInProgress bool
+	waveEndsAt     int64
+	gold           map[string]int64
+	towers         map[string]Tower
+}
+
+// NewMatchAggregate 새로운 매치 애그리게이트를 생성합니다
+func NewMatchAggregate(matchID string, rules Rules) *MatchAggregate {
+	return &MatchAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(matchID, MatchAggregateType),
+		rules:         rules,
+		gold:          make(map[string]int64),
+		towers:        make(map[string]Tower),
+	}
+}
+
+// Start 매치를 시작합니다
+func (m *MatchAggregate) Start(players []string) error {
+	if m.status != "" {
+		return fmt.Errorf("%w: match already started", ErrInvalidCommand)
+	}
+	if len(players) == 0 {
+		return fmt.Errorf("%w: match needs at least one player", ErrInvalidCommand)
+	}
+	seen := make(map[string]bool, len(players))
+	for _, player := range players {
+		if player == "" || seen[player] {
+			return fmt.Errorf("%w: player IDs must be unique and non-empty", ErrInvalidCommand)
+		}
+		seen[player] = true
+	}
+	return m.raise(MatchStartedEventType, MatchStartedData{Players: players, StartingGold: m.rules.StartingGold})
+}
+
+// Execute 플레이어 명령을 적용합니다 (tick은 명령이 처리되는 틱)
+func (m *MatchAggregate) Execute(command Command, tick int64) error {
+	if m.status != StatusInProgress {
+		return fmt.Errorf("%w: match is not in progress", ErrInvalidCommand)
+	}
+	if _, joined := m.gold[command.PlayerID]; !joined {
+		return fmt.Errorf("%w: player %q is not in this match", ErrInvalidCommand, command.PlayerID)
+	}
+
+	switch command.Type {
+	case CommandPlaceTower:
+		cost, known := m.rules.TowerCosts[command.Kind]
+		if !known {
+			return fmt.Errorf("%w: unknown tower kind %q", ErrInvalidCommand, command.Kind)
+		}
+		if command.TowerID == "" {
+			return fmt.Errorf("%w: tower ID is required", ErrInvalidCommand)
+		}
+		if _, exists := m.towers[command.TowerID]; exists {
+			return fmt.Errorf("%w: tower %q already exists", ErrInvalidCommand, command.TowerID)
+		}
+		for _, tower := range m.towers {
+			if tower.X == command.X && tower.Y == command.Y {
+				return fmt.Errorf("%w: tile (%d, %d) is occupied", ErrInvalidCommand, command.X, command.Y)
+			}
+		}
+		if m.gold[command.PlayerID] < cost {
+			return fmt.Errorf("%w: not enough gold", ErrInvalidCommand)
+		}
+		return m.raise(TowerPlacedEventType, TowerPlacedData{Tower: Tower{
+			ID: command.TowerID, OwnerID: command.PlayerID, Kind: command.Kind, X: command.X, Y: command.Y, Cost: cost,
+		}})
+
+	case CommandSellTower:
+		tower, exists := m.towers[command.TowerID]
+		if !exists {
+			return fmt.Errorf("%w: tower %q not found", ErrInvalidCommand, command.TowerID)
+		}
+		if tower.OwnerID != command.PlayerID {
+			return fmt.Errorf("%w: tower %q belongs to another player", ErrInvalidCommand, command.TowerID)
+		}
+		refund := tower.Cost * m.rules.SellRefundPercent / 100
+		return m.raise(TowerSoldEventType, TowerSoldData{TowerID: tower.ID, PlayerID: command.PlayerID, Refund: refund})
+
+	case CommandStartWave:
+		if m.waveInProgress {
+			return fmt.Errorf("%w: wave %d is still in progress", ErrInvalidCommand, m.wave)
+		}
+		return m.raise(WaveStartedEventType, WaveStartedData{
+			Wave: m.wave + 1, StartedBy: command.PlayerID, EndsAtTick: tick + m.rules.WaveDurationTicks,
+		})
+
+	case CommandSurrender:
+		return m.raise(MatchEndedEventType, MatchEndedData{Result: ResultDefeat, Reason: "surrendered by " + command.PlayerID})
+
+	default:
+		return fmt.Errorf("%w: unknown command type %q", ErrInvalidCommand, command.Type)
+	}
+}
+
+// Tick 한 틱의 시간 경과를 적용합니다 (웨이브 종료, 승리 판정)
+func (m *MatchAggregate) Tick(tick int64) error {
+	if m.status != StatusInProgress || !m.waveInProgress || tick < m.waveEndsAt {
+		return nil
+	}
+	if err := m.raise(WaveClearedEventType, WaveClearedData{Wave: m.wave, Reward: m.rules.WaveReward}); err != nil {
+		return err
+	}
+	if m.rules.FinalWave > 0 && m.wave >= m.rules.FinalWave {
+		return m.raise(MatchEndedEventType, MatchEndedData{Result: ResultVictory, Reason: "final wave cleared"})
+	}
+	return nil
+}
+
+// Ended 매치가 끝났는지 확인합니다
+func (m *MatchAggregate) Ended() bool {
+	return m.status == StatusEnded
+}
+
+// State 현재 상태 스냅샷을 만듭니다
+func (m *MatchAggregate) State() MatchState {
+	state := MatchState{
+		MatchID:        m.ID(),
+		Status:         m.status,
+		Result:         m.result,
+		Wave:           m.wave,
+		WaveInProgress: m.waveInProgress,
+		Gold:           make(map[string]int64, len(m.gold)),
+		Towers:         make(map[string]Tower, len(m.towers)),
+	}
+	if m.waveInProgress {
+		state.WaveEndsAtTick = m.waveEndsAt
+	}
+	for player, gold := range m.gold {
+		state.Gold[player] = gold
+	}
+	for id, tower := range m.towers {
+		state.Towers[id] = tower
+	}
+	state.Synergy = m.synergy()
+	return state
+}
+
+// synergy 배치된 타워의 협동 시너지 보너스를 계산합니다
+func (m *MatchAggregate) synergy() map[string]synergy.Bonus {
+	if m.rules.Synergy == nil || len(m.towers) == 0 {
+		return nil
+	}
+	towers := make([]synergy.Tower, 0, len(m.towers))
+	for _, tower := range m.towers {
+		towers = append(towers, synergy.Tower{ID: tower.ID, OwnerID: tower.OwnerID, Kind: tower.Kind, X: tower.X, Y: tower.Y})
+	}
+	bonuses := m.rules.Synergy.Calculate(towers).Bonuses
+	if len(bonuses) == 0 {
+		return nil
+	}
+	return bonuses
+}
+
+// LoadFromHistory 저장된 이벤트로 상태를 복원합니다
+func (m *MatchAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := m.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayEvent 저장된 이벤트를 상태에 반영합니다 (변경 사항으로 추적하지 않음)
+func (m *MatchAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	if err := m.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	m.when(event)
+	return nil
+}
+
+func (m *MatchAggregate) raise(eventType string, data interface{}) error {
+	event := newMatchEvent(eventType, data)
+	if err := m.ApplyEvent(event); err != nil {
+		return err
+	}
+	m.when(event)
+	return nil
+}
+
+// when 이벤트를 상태에 반영합니다
+func (m *MatchAggregate) when(event cqrs.EventMessage) {
+	switch data := event.EventData().(type) {
+	case MatchStartedData:
+		m.status = StatusInProgress
+		for _, player := range data.Players {
+			m.gold[player] = data.StartingGold
+		}
+	case TowerPlacedData:
+		m.towers[data.Tower.ID] = data.Tower
+		m.gold[data.Tower.OwnerID] -= data.Tower.Cost
+	case TowerSoldData:
+		delete(m.towers, data.TowerID)
+		m.gold[data.PlayerID] += data.Refund
+	case WaveStartedData:
+		m.wave = data.Wave
+		m.waveInProgress = true
+		m.waveEndsAt = data.EndsAtTick
+	case WaveClearedData:
+		m.waveInProgress = false
+		for player := range m.gold {
+			m.gold[player] += data.Reward
+		}
+	case MatchEndedData:
+		m.status = StatusEnded
+		m.result = data.Result
+		m.waveInProgress = false
+	}
+}
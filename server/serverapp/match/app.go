@@ -0,0 +1,459 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp"
+	"defense-allies-server/serverapp/i18n"
+)
+
+// DefaultBasePath 매치 서버 기본 경로
+const DefaultBasePath = "/match"
+
+// maxCommandBodySize 명령 요청 본문 최대 크기
+const maxCommandBodySize = 64 << 10
+
+// Config 매치 서버앱 설정
+type Config struct {
+	BasePath    string                          // 라우트 기본 경로 (기본값: /match)
+	Broadcaster Broadcaster                     // 필수: 상태 diff 발행 (realtime.RealtimeApp)
+	Manager     ManagerConfig                   // 룸 런타임 설정
+	Auth        func(http.Handler) http.Handler // 선택: 인증 미들웨어
+	Identify    func(r *http.Request) string    // 선택: 명령을 보낸 플레이어 식별 (설정하면 본문의 player_id 대신 사용)
+	Messages    *i18n.Catalog                   // 선택: 에러 메시지 카탈로그 (요청 로케일은 i18n.Middleware가 지정)
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Broadcaster == nil {
+		return errors.New("broadcaster is required")
+	}
+	return nil
+}
+
+// MatchApp 진행 중인 매치를 룸 단위로 실행하는 서버앱
+// 명령은 HTTP로 받아 매치 룸 메일박스에 넣고, 상태 변경은 실시간 채널 match:<id>로 발행합니다
+// 관전자는 지연되고 필터된 상태를 관전 채널 spectate:<id>로 받습니다
+type MatchApp struct {
+	*serverapp.BaseApp
+	config  Config
+	manager *RoomManager
+}
+
+// NewMatchApp 새로운 MatchApp을 생성합니다
+func NewMatchApp(config Config) (*MatchApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+
+	manager, err := NewRoomManager(config.Manager, config.Broadcaster)
+	if err != nil {
+		return nil, err
+	}
+	return &MatchApp{
+		BaseApp: serverapp.NewBaseApp("match"),
+		config:  config,
+		manager: manager,
+	}, nil
+}
+
+// Rooms 룸 관리자
+func (a *MatchApp) Rooms() *RoomManager {
+	return a.manager
+}
+
+// Stop 실행 중인 룸을 모두 닫습니다
+func (a *MatchApp) Stop(ctx context.Context) error {
+	if err := a.manager.Shutdown(ctx); err != nil {
+		log.Printf("[Match] Failed to close rooms: %v", err)
+	}
+	return a.BaseApp.Stop(ctx)
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *MatchApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/rooms", protect(http.HandlerFunc(a.rooms)))
+	mux.Handle(base+"/rooms/commands", protect(http.HandlerFunc(a.dispatch)))
+	mux.Handle(base+"/rooms/close", protect(http.HandlerFunc(a.closeRoom)))
+	mux.Handle(base+"/spectators/join", protect(http.HandlerFunc(a.joinSpectator)))
+	mux.Handle(base+"/spectators/leave", protect(http.HandlerFunc(a.leaveSpectator)))
+	mux.Handle(base+"/spectators/state", protect(http.HandlerFunc(a.spectatorState)))
+	mux.Handle(base+"/metrics", protect(http.HandlerFunc(a.metrics)))
+	if a.config.Manager.Replays != nil {
+		mux.Handle(base+"/replays", protect(http.HandlerFunc(a.listReplays)))
+		mux.Handle(base+"/replays/file", protect(http.HandlerFunc(a.downloadReplay)))
+		mux.Handle(base+"/replays/frames", protect(http.HandlerFunc(a.replayFrames)))
+	}
+
+	log.Printf("[Match] Routes registered under %s", base)
+}
+
+// DescribeAPI 매치 엔드포인트 설명 (/openapi.json)
+func (a *MatchApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	matchID := serverapp.APIParameter{Name: "match_id", In: "query", Required: true}
+	spectatorID := serverapp.APIParameter{Name: "spectator_id", In: "query", Description: "플레이어 식별이 설정되지 않은 경우에만 사용"}
+	operations := []serverapp.APIOperation{
+		{Method: http.MethodPost, Path: base + "/rooms", Summary: "매치 룸 생성", Request: CreateRoomRequest{}, Response: MatchState{}, Secured: secured},
+		{
+			Method:      http.MethodGet,
+			Path:        base + "/rooms",
+			Summary:     "매치 상태 조회",
+			Description: "플레이어 식별이 설정된 경우 매치 참가자만 조회할 수 있습니다. 관전자는 /spectators/state를 사용합니다.",
+			Parameters:  []serverapp.APIParameter{matchID},
+			Response:    MatchState{},
+			Secured:     secured,
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/rooms/commands",
+			Summary:     "매치 명령 전송",
+			Description: "명령은 다음 틱에 적용되며, 적용 결과를 응답합니다. 상태 변경은 실시간 채널 match:<match_id>의 " + StateDiffEventType + " 이벤트로 발행됩니다.",
+			Parameters:  []serverapp.APIParameter{matchID},
+			Request:     Command{},
+			Secured:     secured,
+		},
+		{Method: http.MethodPost, Path: base + "/rooms/close", Summary: "매치 룸 종료", Parameters: []serverapp.APIParameter{matchID}, Secured: secured},
+		{
+			Method:  http.MethodPost,
+			Path:    base + "/spectators/join",
+			Summary: "매치 관전 입장",
+			Description: "응답의 channel(spectate:<match_id>)을 구독한 뒤 /spectators/state로 상태를 다시 읽고, tick이 그보다 큰 " +
+				StateDiffEventType + "만 적용합니다. 관전 상태는 고스팅 방지를 위해 지연되고 일부 정보가 제거됩니다.",
+			Parameters: []serverapp.APIParameter{matchID, spectatorID},
+			Response:   SpectatorTicket{},
+			Secured:    secured,
+		},
+		{Method: http.MethodPost, Path: base + "/spectators/leave", Summary: "매치 관전 종료", Parameters: []serverapp.APIParameter{matchID, spectatorID}, Secured: secured},
+		{Method: http.MethodGet, Path: base + "/spectators/state", Summary: "관전 상태 조회 (지연, 필터 적용)", Parameters: []serverapp.APIParameter{matchID, spectatorID}, Response: MatchState{}, Secured: secured},
+		{Method: http.MethodGet, Path: base + "/metrics", Summary: "룸 런타임 지표", Response: MetricsSnapshot{}, Secured: secured},
+	}
+	if a.config.Manager.Replays == nil {
+		return operations
+	}
+	return append(operations,
+		serverapp.APIOperation{
+			Method:  http.MethodGet,
+			Path:    base + "/replays",
+			Summary: "리플레이 목록 (최근에 끝난 순)",
+			Parameters: []serverapp.APIParameter{
+				{Name: "player_id", In: "query"},
+				{Name: "result", In: "query"},
+				{Name: "since", In: "query", Description: "RFC3339"},
+				{Name: "until", In: "query", Description: "RFC3339"},
+				{Name: "limit", In: "query", Description: "기본값 50"},
+			},
+			Response: []ReplayHeader{},
+			Secured:  secured,
+		},
+		serverapp.APIOperation{Method: http.MethodGet, Path: base + "/replays/file", Summary: "리플레이 파일 다운로드", Parameters: []serverapp.APIParameter{matchID}, Secured: secured},
+		serverapp.APIOperation{
+			Method:      http.MethodGet,
+			Path:        base + "/replays/frames",
+			Summary:     "리플레이 구간 재생",
+			Description: "from 틱의 상태와, from 이후 to까지 이벤트가 있는 틱의 diff를 반환합니다.",
+			Parameters:  []serverapp.APIParameter{matchID, {Name: "from", In: "query"}, {Name: "to", In: "query"}},
+			Response:    ReplayFramesResponse{},
+			Secured:     secured,
+		},
+	)
+}
+
+// CreateRoomRequest 매치 룸 생성 요청
+type CreateRoomRequest struct {
+	MatchID string   `json:"match_id"`
+	Players []string `json:"players"`
+}
+
+func (a *MatchApp) rooms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		room, exists := a.manager.Get(r.URL.Query().Get("match_id"))
+		if !exists {
+			sendError(w, http.StatusNotFound, a.message(r, ErrRoomNotFound))
+			return
+		}
+		// 실시간 상태는 참가자에게만 공개 (관전자는 지연된 관전 상태만 조회)
+		if a.config.Identify != nil && !room.isPlayer(a.config.Identify(r)) {
+			sendError(w, http.StatusForbidden, a.config.Messages.LocalizeOr(r.Context(), "match.players_only", "only players can read the live match state", nil))
+			return
+		}
+		sendJSON(w, http.StatusOK, room.State())
+
+	case http.MethodPost:
+		var request CreateRoomRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCommandBodySize)).Decode(&request); err != nil {
+			sendError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		room, err := a.manager.Create(request.MatchID, request.Players)
+		if err != nil {
+			sendError(w, statusForError(err), a.message(r, err))
+			return
+		}
+		sendJSON(w, http.StatusCreated, room.State())
+
+	default:
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *MatchApp) dispatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var command Command
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCommandBodySize)).Decode(&command); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if a.config.Identify != nil {
+		command.PlayerID = a.config.Identify(r)
+	}
+
+	if err := a.manager.Dispatch(r.Context(), r.URL.Query().Get("match_id"), command); err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	sendJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+func (a *MatchApp) closeRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := a.manager.Close(r.Context(), r.URL.Query().Get("match_id")); err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	sendJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+func (a *MatchApp) joinSpectator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ticket, err := a.manager.JoinSpectator(r.Context(), r.URL.Query().Get("match_id"), a.spectatorID(r))
+	if err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	sendJSON(w, http.StatusOK, ticket)
+}
+
+func (a *MatchApp) leaveSpectator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := a.manager.LeaveSpectator(r.Context(), r.URL.Query().Get("match_id"), a.spectatorID(r)); err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	sendJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+func (a *MatchApp) spectatorState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	room, exists := a.manager.Get(r.URL.Query().Get("match_id"))
+	if !exists {
+		sendError(w, http.StatusNotFound, a.message(r, ErrRoomNotFound))
+		return
+	}
+	if !room.Spectating(a.spectatorID(r)) {
+		sendError(w, http.StatusForbidden, a.message(r, ErrNotSpectating))
+		return
+	}
+	sendJSON(w, http.StatusOK, room.SpectatorState())
+}
+
+// spectatorID 관전자 식별 (Identify가 없으면 spectator_id 쿼리 파라미터)
+func (a *MatchApp) spectatorID(r *http.Request) string {
+	if a.config.Identify != nil {
+		return a.config.Identify(r)
+	}
+	return r.URL.Query().Get("spectator_id")
+}
+
+// ReplayFramesResponse 리플레이 구간 재생 응답
+type ReplayFramesResponse struct {
+	Header ReplayHeader  `json:"header"`
+	State  MatchState    `json:"state"` // from 틱의 상태
+	Frames []ReplayFrame `json:"frames"`
+}
+
+func (a *MatchApp) listReplays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	params := r.URL.Query()
+	query := ReplayQuery{PlayerID: params.Get("player_id"), Result: params.Get("result"), Limit: 50}
+	if limit, err := strconv.Atoi(params.Get("limit")); err == nil && limit > 0 {
+		query.Limit = limit
+	}
+	for name, target := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := params.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				sendError(w, http.StatusBadRequest, name+" must be an RFC3339 timestamp")
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	headers, err := a.config.Manager.Replays.List(r.Context(), query)
+	if err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	sendJSON(w, http.StatusOK, headers)
+}
+
+func (a *MatchApp) downloadReplay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	matchID := r.URL.Query().Get("match_id")
+	file, err := a.config.Manager.Replays.Open(r.Context(), matchID)
+	if err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+matchID+replayFileExt+`"`)
+	if _, err := io.Copy(w, file); err != nil {
+		log.Printf("[Match] Failed to send replay %s: %v", matchID, err)
+	}
+}
+
+func (a *MatchApp) replayFrames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	params := r.URL.Query()
+	replay, err := a.config.Manager.Replays.Get(r.Context(), params.Get("match_id"))
+	if err != nil {
+		sendError(w, statusForError(err), a.message(r, err))
+		return
+	}
+	playback, err := NewPlayback(replay)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	from, _ := strconv.ParseInt(params.Get("from"), 10, 64)
+	to := replay.Header.Ticks
+	if value := params.Get("to"); value != "" {
+		if to, err = strconv.ParseInt(value, 10, 64); err != nil {
+			sendError(w, http.StatusBadRequest, "to must be a tick number")
+			return
+		}
+	}
+	if err := playback.SeekTick(from); err != nil {
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	response := ReplayFramesResponse{Header: replay.Header, State: playback.State()}
+	if response.Frames, err = playback.Frames(from, to); err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, response)
+}
+
+func (a *MatchApp) metrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	sendJSON(w, http.StatusOK, a.manager.Metrics())
+}
+
+// errorMessageKeys 플레이어에게 보이는 에러의 메시지 키
+var errorMessageKeys = []i18n.ErrorKey{
+	{Err: ErrRoomNotFound, Key: "match.room_not_found"},
+	{Err: ErrRoomExists, Key: "match.room_exists"},
+	{Err: ErrTooManyRooms, Key: "match.too_many_rooms"},
+	{Err: ErrRoomClosed, Key: "match.room_closed"},
+	{Err: ErrMailboxFull, Key: "match.mailbox_full"},
+	{Err: ErrSpectatorLimit, Key: "match.spectator_limit"},
+	{Err: ErrSpectatorForbidden, Key: "match.spectator_forbidden"},
+	{Err: ErrNotSpectating, Key: "match.not_spectating"},
+	{Err: ErrReplayNotFound, Key: "match.replay_not_found"},
+}
+
+// message 요청 로케일로 에러 메시지를 만듭니다 (카탈로그가 없거나 키가 없으면 원래 메시지)
+func (a *MatchApp) message(r *http.Request, err error) string {
+	return a.config.Messages.Error(r.Context(), err, errorMessageKeys, nil)
+}
+
+// statusForError 룸 런타임 에러의 HTTP 상태 코드
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidCommand):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrSpectatorForbidden), errors.Is(err, ErrNotSpectating):
+		return http.StatusForbidden
+	case errors.Is(err, ErrRoomNotFound), errors.Is(err, ErrReplayNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrRoomExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrRoomClosed):
+		return http.StatusGone
+	case errors.Is(err, ErrMailboxFull), errors.Is(err, ErrTooManyRooms), errors.Is(err, ErrSpectatorLimit):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
@@ -0,0 +1,120 @@
+package match
+
+import (
+	"sort"
+
+	"defense-allies-server/pkg/tower/synergy"
+)
+
+// 명령 타입
+const (
+	CommandPlaceTower = "place_tower"
+	CommandSellTower  = "sell_tower"
+	CommandStartWave  = "start_wave"
+	CommandSurrender  = "surrender"
+)
+
+// Command 플레이어가 룸에 보내는 명령
+// 명령은 룸 메일박스에 쌓였다가 다음 틱에 도착 순서대로 적용됩니다
+type Command struct {
+	Type     string `json:"type"`
+	PlayerID string `json:"player_id"`
+	TowerID  string `json:"tower_id,omitempty"` // place_tower, sell_tower
+	Kind     string `json:"kind,omitempty"`     // place_tower
+	X        int    `json:"x,omitempty"`        // place_tower
+	Y        int    `json:"y,omitempty"`        // place_tower
+}
+
+// StateDiffEventType 틱마다 브로드캐스트하는 상태 변경 이벤트 타입
+const StateDiffEventType = "MatchStateDiff"
+
+// StateDiff 이전 브로드캐스트 이후 바뀐 상태
+// 클라이언트는 매치 시작 시 전체 상태를 받고, 이후에는 diff만 적용합니다
+type StateDiff struct {
+	MatchID        string                   `json:"match_id"`
+	Tick           int64                    `json:"tick"`
+	Events         []string                 `json:"events,omitempty"` // 이번 틱에 발생한 이벤트 타입
+	Status         string                   `json:"status,omitempty"`
+	Result         string                   `json:"result,omitempty"`
+	Wave           *int                     `json:"wave,omitempty"`
+	WaveInProgress *bool                    `json:"wave_in_progress,omitempty"`
+	WaveEndsAtTick *int64                   `json:"wave_ends_at_tick,omitempty"`
+	Gold           map[string]int64         `json:"gold,omitempty"`           // 바뀐 플레이어 골드
+	TowersAdded    []Tower                  `json:"towers_added,omitempty"`   // 새로 배치된 타워
+	TowersRemoved  []string                 `json:"towers_removed,omitempty"` // 제거된 타워 ID
+	Synergy        map[string]synergy.Bonus `json:"synergy,omitempty"`        // 바뀐 타워 시너지 (빈 보너스는 시너지 해제)
+}
+
+// Empty 바뀐 내용이 없는지 확인합니다
+func (d StateDiff) Empty() bool {
+	return len(d.Events) == 0 && d.Status == "" && d.Result == "" && d.Wave == nil && d.WaveInProgress == nil &&
+		d.WaveEndsAtTick == nil && len(d.Gold) == 0 && len(d.TowersAdded) == 0 && len(d.TowersRemoved) == 0 &&
+		len(d.Synergy) == 0
+}
+
+// diffState 두 상태 스냅샷의 차이를 계산합니다
+func diffState(prev, next MatchState, tick int64) StateDiff {
+	diff := StateDiff{MatchID: next.MatchID, Tick: tick}
+	if prev.Status != next.Status {
+		diff.Status = next.Status
+	}
+	if prev.Result != next.Result {
+		diff.Result = next.Result
+	}
+	if prev.Wave != next.Wave {
+		wave := next.Wave
+		diff.Wave = &wave
+	}
+	if prev.WaveInProgress != next.WaveInProgress {
+		inProgress := next.WaveInProgress
+		diff.WaveInProgress = &inProgress
+	}
+	if prev.WaveEndsAtTick != next.WaveEndsAtTick {
+		endsAt := next.WaveEndsAtTick
+		diff.WaveEndsAtTick = &endsAt
+	}
+
+	for player, gold := range next.Gold {
+		if previous, exists := prev.Gold[player]; !exists || previous != gold {
+			if diff.Gold == nil {
+				diff.Gold = make(map[string]int64)
+			}
+			diff.Gold[player] = gold
+		}
+	}
+	for id, tower := range next.Towers {
+		if previous, exists := prev.Towers[id]; !exists || previous != tower {
+			diff.TowersAdded = append(diff.TowersAdded, tower)
+		}
+	}
+	for id := range prev.Towers {
+		if _, exists := next.Towers[id]; !exists {
+			diff.TowersRemoved = append(diff.TowersRemoved, id)
+		}
+	}
+	for id, bonus := range next.Synergy {
+		if previous, exists := prev.Synergy[id]; !exists || previous != bonus {
+			if diff.Synergy == nil {
+				diff.Synergy = make(map[string]synergy.Bonus)
+			}
+			diff.Synergy[id] = bonus
+		}
+	}
+	for id := range prev.Synergy {
+		// 제거된 타워는 TowersRemoved로 충분합니다
+		if _, exists := next.Synergy[id]; !exists && hasTower(next, id) {
+			if diff.Synergy == nil {
+				diff.Synergy = make(map[string]synergy.Bonus)
+			}
+			diff.Synergy[id] = synergy.Bonus{}
+		}
+	}
+	sort.Slice(diff.TowersAdded, func(i, j int) bool { return diff.TowersAdded[i].ID < diff.TowersAdded[j].ID })
+	sort.Strings(diff.TowersRemoved)
+	return diff
+}
+
+func hasTower(state MatchState, id string) bool {
+	_, exists := state.Towers[id]
+	return exists
+}
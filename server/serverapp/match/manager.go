@@ -0,0 +1,302 @@
+package match
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"defense-allies-server/pkg/tower/synergy"
+)
+
+var (
+	ErrRoomNotFound = errors.New("match room not found")
+	ErrRoomExists   = errors.New("match room already exists")
+	ErrTooManyRooms = errors.New("too many active match rooms")
+)
+
+// ManagerConfig 룸 관리자 설정
+type ManagerConfig struct {
+	Rules              Rules         // 매치 규칙 (기본값: DefaultRules)
+	TickRate           int           // 초당 틱 수 (기본값: 10)
+	IdleTimeout        time.Duration // 입력이 없으면 룸을 닫는 시간, 0이면 닫지 않음 (기본값: 5m)
+	MailboxSize        int           // 룸별 메일박스 크기 (기본값: 256)
+	MaxCommandsPerTick int           // 틱마다 적용하는 최대 명령 수 (기본값: MailboxSize)
+	MaxRooms           int           // 동시에 실행할 최대 룸 수, 0이면 제한 없음
+
+	SpectatorDelay  time.Duration   // 관전 지연 (고스팅 방지, 기본값: 30s)
+	MaxSpectators   int             // 매치별 최대 관전자 수 (기본값: 100)
+	SpectatorFilter SpectatorFilter // 관전자에게 숨길 정보 제거 (기본값: HidePlayerEconomy)
+	EventBus        cqrs.EventBus   // 선택: 관전 입퇴장과 매치 결과 이벤트 발행 (분석 파이프라인, 레이팅 구독)
+
+	Replays ReplayStore // 선택: 끝난 매치를 리플레이 파일로 저장
+
+	// 선택: 런타임에 교체 가능한 협동 시너지 매트릭스
+	// 룸 생성 시점의 매트릭스를 규칙에 고정하므로, 다시 불러와도 진행 중인 매치와 리플레이는 바뀌지 않습니다
+	Synergy *synergy.Registry
+}
+
+// Metrics 룸 런타임 지표
+type Metrics struct {
+	activeRooms        atomic.Int64
+	roomsCreated       atomic.Int64
+	roomsClosed        atomic.Int64
+	ticks              atomic.Int64
+	tickNanos          atomic.Int64
+	tickOverruns       atomic.Int64
+	commandsProcessed  atomic.Int64
+	commandsRejected   atomic.Int64
+	activeSpectators   atomic.Int64
+	spectatorsRejected atomic.Int64
+	replaysSaved       atomic.Int64
+	replayFailures     atomic.Int64
+}
+
+func (m *Metrics) recordTick(elapsed time.Duration, overrun bool) {
+	m.ticks.Add(1)
+	m.tickNanos.Add(int64(elapsed))
+	if overrun {
+		m.tickOverruns.Add(1)
+	}
+}
+
+// MetricsSnapshot 룸 런타임 지표 스냅샷
+type MetricsSnapshot struct {
+	ActiveRooms        int64   `json:"active_rooms"`
+	RoomsCreated       int64   `json:"rooms_created"`
+	RoomsClosed        int64   `json:"rooms_closed"`
+	Ticks              int64   `json:"ticks"`
+	TickOverruns       int64   `json:"tick_overruns"` // 틱 주기보다 오래 걸린 틱
+	AverageTickMillis  float64 `json:"average_tick_ms"`
+	CommandsProcessed  int64   `json:"commands_processed"`
+	CommandsRejected   int64   `json:"commands_rejected"` // 규칙 위반, 메일박스 초과 포함
+	ActiveSpectators   int64   `json:"active_spectators"`
+	SpectatorsRejected int64   `json:"spectators_rejected"` // 관전자 수 제한 초과
+	ReplaysSaved       int64   `json:"replays_saved"`
+	ReplayFailures     int64   `json:"replay_failures"`
+}
+
+// Snapshot 현재 지표를 읽습니다
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	snapshot := MetricsSnapshot{
+		ActiveRooms:        m.activeRooms.Load(),
+		RoomsCreated:       m.roomsCreated.Load(),
+		RoomsClosed:        m.roomsClosed.Load(),
+		Ticks:              m.ticks.Load(),
+		TickOverruns:       m.tickOverruns.Load(),
+		CommandsProcessed:  m.commandsProcessed.Load(),
+		CommandsRejected:   m.commandsRejected.Load(),
+		ActiveSpectators:   m.activeSpectators.Load(),
+		SpectatorsRejected: m.spectatorsRejected.Load(),
+		ReplaysSaved:       m.replaysSaved.Load(),
+		ReplayFailures:     m.replayFailures.Load(),
+	}
+	if snapshot.Ticks > 0 {
+		snapshot.AverageTickMillis = float64(m.tickNanos.Load()) / float64(snapshot.Ticks) / float64(time.Millisecond)
+	}
+	return snapshot
+}
+
+// RoomManager 매치 룸의 생성, 조회, 종료를 관리합니다
+// 룸은 매치가 끝나거나, 입력 없이 IdleTimeout이 지나거나, Close/Shutdown으로 닫히면 목록에서 제거됩니다
+type RoomManager struct {
+	config      ManagerConfig
+	room        roomConfig
+	broadcaster Broadcaster
+	metrics     *Metrics
+
+	mu     sync.Mutex
+	rooms  map[string]*Room
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRoomManager 새로운 RoomManager를 생성합니다
+func NewRoomManager(config ManagerConfig, broadcaster Broadcaster) (*RoomManager, error) {
+	if broadcaster == nil {
+		return nil, fmt.Errorf("broadcaster is required")
+	}
+	if config.Rules.TowerCosts == nil {
+		config.Rules = DefaultRules()
+	}
+	if config.TickRate <= 0 {
+		config.TickRate = 10
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = 5 * time.Minute
+	}
+	if config.MailboxSize <= 0 {
+		config.MailboxSize = 256
+	}
+	if config.MaxCommandsPerTick <= 0 {
+		config.MaxCommandsPerTick = config.MailboxSize
+	}
+	if config.SpectatorDelay <= 0 {
+		config.SpectatorDelay = 30 * time.Second
+	}
+	if config.MaxSpectators <= 0 {
+		config.MaxSpectators = 100
+	}
+	if config.SpectatorFilter == nil {
+		config.SpectatorFilter = HidePlayerEconomy
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RoomManager{
+		config: config,
+		room: roomConfig{
+			tickInterval:       time.Second / time.Duration(config.TickRate),
+			idleTimeout:        config.IdleTimeout,
+			maxCommandsPerTick: config.MaxCommandsPerTick,
+			spectatorDelay:     config.SpectatorDelay,
+			maxSpectators:      config.MaxSpectators,
+			spectatorFilter:    config.SpectatorFilter,
+			tickRate:           config.TickRate,
+		},
+		broadcaster: broadcaster,
+		metrics:     &Metrics{},
+		rooms:       make(map[string]*Room),
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+// Create 매치를 시작하고 룸을 실행합니다
+// 시작 상태는 틱 0으로 바로 브로드캐스트됩니다
+func (m *RoomManager) Create(matchID string, players []string) (*Room, error) {
+	if matchID == "" {
+		return nil, fmt.Errorf("%w: match ID is required", ErrInvalidCommand)
+	}
+
+	rules := m.config.Rules
+	if m.config.Synergy != nil {
+		if matrix := m.config.Synergy.Current(); matrix != nil {
+			rules.Synergy = matrix
+		}
+	}
+	match := NewMatchAggregate(matchID, rules)
+	if err := match.Start(players); err != nil {
+		return nil, err
+	}
+	room := &Room{
+		id:             matchID,
+		players:        append([]string(nil), players...),
+		config:         m.room,
+		match:          match,
+		mailbox:        make(chan envelope, m.config.MailboxSize),
+		broadcaster:    m.broadcaster,
+		eventBus:       m.config.EventBus,
+		replays:        m.config.Replays,
+		startedAt:      time.Now(),
+		metrics:        m.metrics,
+		onClose:        m.remove,
+		spectatorState: MatchState{MatchID: matchID},
+		spectators:     make(map[string]time.Time),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	if m.ctx.Err() != nil {
+		m.mu.Unlock()
+		return nil, ErrRoomClosed
+	}
+	if _, exists := m.rooms[matchID]; exists {
+		m.mu.Unlock()
+		return nil, ErrRoomExists
+	}
+	if m.config.MaxRooms > 0 && len(m.rooms) >= m.config.MaxRooms {
+		m.mu.Unlock()
+		return nil, ErrTooManyRooms
+	}
+	m.rooms[matchID] = room
+	m.metrics.roomsCreated.Add(1)
+	m.metrics.activeRooms.Add(1)
+	m.wg.Add(1)
+	m.mu.Unlock()
+
+	room.broadcast(m.ctx)
+	go func() {
+		defer m.wg.Done()
+		room.run(m.ctx)
+	}()
+	return room, nil
+}
+
+// Get 실행 중인 룸을 찾습니다
+func (m *RoomManager) Get(matchID string) (*Room, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	room, exists := m.rooms[matchID]
+	return room, exists
+}
+
+// Dispatch 매치 룸에 명령을 보내고 적용 결과를 기다립니다
+func (m *RoomManager) Dispatch(ctx context.Context, matchID string, command Command) error {
+	room, exists := m.Get(matchID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+	return room.Dispatch(ctx, command)
+}
+
+// Close 룸을 닫고 종료될 때까지 기다립니다
+func (m *RoomManager) Close(ctx context.Context, matchID string) error {
+	room, exists := m.Get(matchID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+	room.Close()
+	select {
+	case <-room.Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ActiveRooms 실행 중인 룸 수
+func (m *RoomManager) ActiveRooms() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.rooms)
+}
+
+// Metrics 룸 런타임 지표
+func (m *RoomManager) Metrics() MetricsSnapshot {
+	return m.metrics.Snapshot()
+}
+
+// Shutdown 모든 룸을 닫고 종료될 때까지 기다립니다 (이후 Create는 실패)
+func (m *RoomManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.cancel()
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// remove 닫힌 룸을 목록에서 제거합니다 (룸 고루틴에서 호출)
+func (m *RoomManager) remove(room *Room, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rooms[room.id] == room {
+		delete(m.rooms, room.id)
+		m.metrics.activeRooms.Add(-1)
+		m.metrics.roomsClosed.Add(1)
+	}
+}
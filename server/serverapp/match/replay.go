@@ -0,0 +1,306 @@
+package match
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrReplayNotFound  = errors.New("replay not found")
+	ErrReplayCorrupted = errors.New("replay file is corrupted")
+)
+
+// 리플레이 파일 형식
+// magic(4) | version(uint16) | header length(uint32) | header JSON | gzip(이벤트 JSON 배열)
+// 헤더만 읽어도 목록 조회가 가능하도록 헤더를 압축하지 않고 앞에 둡니다
+const (
+	replayMagic         = "DARP"
+	ReplayFormatVersion = 1
+	maxReplayHeaderSize = 1 << 20
+)
+
+// ReplayHeader 리플레이 메타데이터
+type ReplayHeader struct {
+	FormatVersion int       `json:"format_version"`
+	MatchID       string    `json:"match_id"`
+	Players       []string  `json:"players"`
+	Result        string    `json:"result,omitempty"`
+	Rules         Rules     `json:"rules"`
+	TickRate      int       `json:"tick_rate"`
+	Ticks         int64     `json:"ticks"` // 마지막 틱
+	EventCount    int       `json:"event_count"`
+	StartedAt     time.Time `json:"started_at"`
+	EndedAt       time.Time `json:"ended_at"`
+	PayloadSize   int64     `json:"payload_size"`
+	Checksum      string    `json:"checksum"` // 압축된 페이로드의 SHA-256 (hex)
+}
+
+// ReplayEvent 리플레이에 기록된 매치 이벤트
+type ReplayEvent struct {
+	Tick int64           `json:"tick"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Replay 끝난 매치의 이벤트 스트림
+type Replay struct {
+	Header ReplayHeader  `json:"header"`
+	Events []ReplayEvent `json:"events"`
+}
+
+// WriteReplay 리플레이를 파일 형식으로 씁니다 (헤더의 크기와 체크섬은 여기서 채움)
+func WriteReplay(w io.Writer, replay *Replay) error {
+	var payload bytes.Buffer
+	zw := gzip.NewWriter(&payload)
+	if err := json.NewEncoder(zw).Encode(replay.Events); err != nil {
+		return fmt.Errorf("failed to encode replay events: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress replay events: %w", err)
+	}
+
+	sum := sha256.Sum256(payload.Bytes())
+	header := replay.Header
+	header.FormatVersion = ReplayFormatVersion
+	header.EventCount = len(replay.Events)
+	header.PayloadSize = int64(payload.Len())
+	header.Checksum = hex.EncodeToString(sum[:])
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to encode replay header: %w", err)
+	}
+
+	prefix := make([]byte, 0, 10)
+	prefix = append(prefix, replayMagic...)
+	prefix = binary.BigEndian.AppendUint16(prefix, ReplayFormatVersion)
+	prefix = binary.BigEndian.AppendUint32(prefix, uint32(len(headerJSON)))
+	for _, part := range [][]byte{prefix, headerJSON, payload.Bytes()} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	replay.Header = header
+	return nil
+}
+
+// ReadReplayHeader 헤더만 읽습니다 (페이로드는 검증하지 않음)
+func ReadReplayHeader(r io.Reader) (ReplayHeader, error) {
+	prefix := make([]byte, 10)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return ReplayHeader{}, fmt.Errorf("%w: %v", ErrReplayCorrupted, err)
+	}
+	if string(prefix[:4]) != replayMagic {
+		return ReplayHeader{}, fmt.Errorf("%w: not a replay file", ErrReplayCorrupted)
+	}
+	if version := binary.BigEndian.Uint16(prefix[4:6]); version != ReplayFormatVersion {
+		return ReplayHeader{}, fmt.Errorf("%w: unsupported format version %d", ErrReplayCorrupted, version)
+	}
+	length := binary.BigEndian.Uint32(prefix[6:10])
+	if length > maxReplayHeaderSize {
+		return ReplayHeader{}, fmt.Errorf("%w: header too large", ErrReplayCorrupted)
+	}
+
+	headerJSON := make([]byte, length)
+	if _, err := io.ReadFull(r, headerJSON); err != nil {
+		return ReplayHeader{}, fmt.Errorf("%w: %v", ErrReplayCorrupted, err)
+	}
+	var header ReplayHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return ReplayHeader{}, fmt.Errorf("%w: %v", ErrReplayCorrupted, err)
+	}
+	return header, nil
+}
+
+// ReadReplay 리플레이를 읽고 체크섬을 검증합니다
+func ReadReplay(r io.Reader) (*Replay, error) {
+	br := bufio.NewReader(r)
+	header, err := ReadReplayHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(br, header.PayloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(payload)
+	if int64(len(payload)) != header.PayloadSize || hex.EncodeToString(sum[:]) != header.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrReplayCorrupted)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReplayCorrupted, err)
+	}
+	defer zr.Close()
+	replay := &Replay{Header: header}
+	if err := json.NewDecoder(zr).Decode(&replay.Events); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReplayCorrupted, err)
+	}
+	if len(replay.Events) != header.EventCount {
+		return nil, fmt.Errorf("%w: event count mismatch", ErrReplayCorrupted)
+	}
+	return replay, nil
+}
+
+// matchEventDecoders 리플레이 이벤트 데이터를 이벤트 타입별 구조체로 되돌립니다
+var matchEventDecoders = map[string]func(data json.RawMessage) (interface{}, error){
+	MatchStartedEventType: decodeEventData[MatchStartedData],
+	TowerPlacedEventType:  decodeEventData[TowerPlacedData],
+	TowerSoldEventType:    decodeEventData[TowerSoldData],
+	WaveStartedEventType:  decodeEventData[WaveStartedData],
+	WaveClearedEventType:  decodeEventData[WaveClearedData],
+	MatchEndedEventType:   decodeEventData[MatchEndedData],
+}
+
+// decodeEventData when이 값 타입으로 분기하므로 포인터가 아닌 값을 반환합니다
+func decodeEventData[T any](data json.RawMessage) (interface{}, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ReplayFrame 재생 중 한 틱의 결과
+type ReplayFrame struct {
+	Tick   int64      `json:"tick"`
+	Events []string   `json:"events,omitempty"`
+	Diff   StateDiff  `json:"diff"`
+	State  MatchState `json:"-"`
+}
+
+// Playback 리플레이를 틱 단위로 재생해 매치 상태를 복원합니다 (리플레이 뷰어용)
+type Playback struct {
+	header ReplayHeader
+	ticks  map[int64][]*MatchEvent
+	match  *MatchAggregate
+	tick   int64
+	state  MatchState
+}
+
+// NewPlayback 재생기를 만들고 틱 0(매치 시작)까지 적용합니다
+func NewPlayback(replay *Replay) (*Playback, error) {
+	p := &Playback{header: replay.Header, ticks: make(map[int64][]*MatchEvent)}
+	for _, recorded := range replay.Events {
+		decode, known := matchEventDecoders[recorded.Type]
+		if !known {
+			return nil, fmt.Errorf("%w: unknown event type %q", ErrReplayCorrupted, recorded.Type)
+		}
+		data, err := decode(recorded.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to decode %s: %v", ErrReplayCorrupted, recorded.Type, err)
+		}
+		if recorded.Tick < 0 || recorded.Tick > replay.Header.Ticks {
+			return nil, fmt.Errorf("%w: event tick %d out of range", ErrReplayCorrupted, recorded.Tick)
+		}
+		p.ticks[recorded.Tick] = append(p.ticks[recorded.Tick], newMatchEvent(recorded.Type, data))
+	}
+	if err := p.reset(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Header 리플레이 메타데이터
+func (p *Playback) Header() ReplayHeader {
+	return p.header
+}
+
+// Tick 현재 재생 위치
+func (p *Playback) Tick() int64 {
+	return p.tick
+}
+
+// State 현재 재생 위치의 매치 상태
+func (p *Playback) State() MatchState {
+	return p.state
+}
+
+// Done 마지막 틱까지 재생했는지 확인합니다
+func (p *Playback) Done() bool {
+	return p.tick >= p.header.Ticks
+}
+
+// Step 한 틱 진행합니다 (마지막 틱을 지나면 io.EOF)
+func (p *Playback) Step() (ReplayFrame, error) {
+	if p.Done() {
+		return ReplayFrame{}, io.EOF
+	}
+	return p.apply(p.tick + 1)
+}
+
+// SeekTick 지정한 틱으로 이동합니다 (뒤로 가면 처음부터 다시 재생)
+func (p *Playback) SeekTick(tick int64) error {
+	if tick < 0 || tick > p.header.Ticks {
+		return fmt.Errorf("tick %d is out of range [0, %d]", tick, p.header.Ticks)
+	}
+	if tick < p.tick {
+		if err := p.reset(); err != nil {
+			return err
+		}
+	}
+	for p.tick < tick {
+		if _, err := p.apply(p.tick + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Frames from 이후 to까지 이벤트가 있는 틱의 프레임만 모읍니다 (뷰어가 구간 단위로 받음)
+func (p *Playback) Frames(from, to int64) ([]ReplayFrame, error) {
+	if to > p.header.Ticks {
+		to = p.header.Ticks
+	}
+	if err := p.SeekTick(from); err != nil {
+		return nil, err
+	}
+	frames := make([]ReplayFrame, 0)
+	for p.tick < to {
+		frame, err := p.apply(p.tick + 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(frame.Events) > 0 {
+			frames = append(frames, frame)
+		}
+	}
+	return frames, nil
+}
+
+func (p *Playback) reset() error {
+	p.match = NewMatchAggregate(p.header.MatchID, p.header.Rules)
+	p.tick = -1
+	p.state = MatchState{MatchID: p.header.MatchID}
+	_, err := p.apply(0)
+	return err
+}
+
+// apply tick의 이벤트를 적용합니다
+func (p *Playback) apply(tick int64) (ReplayFrame, error) {
+	frame := ReplayFrame{Tick: tick}
+	for _, event := range p.ticks[tick] {
+		if err := p.match.ReplayEvent(event); err != nil {
+			return ReplayFrame{}, err
+		}
+		frame.Events = append(frame.Events, event.EventType())
+	}
+	next := p.match.State()
+	next.Tick = tick
+	frame.Diff = diffState(p.state, next, tick)
+	frame.Diff.Events = frame.Events
+	frame.State = next
+	p.state = next
+	p.tick = tick
+	return frame, nil
+}
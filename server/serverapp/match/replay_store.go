@@ -0,0 +1,158 @@
+package match
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReplayQuery 리플레이 목록 조회 조건
+type ReplayQuery struct {
+	PlayerID string    // 참가한 플레이어
+	Result   string    // 매치 결과
+	Since    time.Time // 이 시각 이후에 끝난 매치
+	Until    time.Time // 이 시각 이전에 끝난 매치
+	Limit    int       // 최대 개수, 0이면 제한 없음
+}
+
+func (q ReplayQuery) matches(header ReplayHeader) bool {
+	if q.Result != "" && header.Result != q.Result {
+		return false
+	}
+	if !q.Since.IsZero() && header.EndedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !header.EndedAt.Before(q.Until) {
+		return false
+	}
+	if q.PlayerID != "" {
+		for _, player := range header.Players {
+			if player == q.PlayerID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ReplayStore 리플레이 파일 저장소
+type ReplayStore interface {
+	Save(ctx context.Context, replay *Replay) error
+	// Open 리플레이 파일 원본을 엽니다 (클라이언트 다운로드용)
+	Open(ctx context.Context, matchID string) (io.ReadCloser, error)
+	Get(ctx context.Context, matchID string) (*Replay, error)
+	// List 조건에 맞는 리플레이 헤더를 최근에 끝난 순으로 반환합니다
+	List(ctx context.Context, query ReplayQuery) ([]ReplayHeader, error)
+}
+
+// replayFileExt 리플레이 파일 확장자
+const replayFileExt = ".replay"
+
+// FileReplayStore 디렉터리에 매치별 리플레이 파일을 저장합니다
+type FileReplayStore struct {
+	dir string
+}
+
+// NewFileReplayStore 새로운 FileReplayStore를 생성합니다 (디렉터리가 없으면 만듦)
+func NewFileReplayStore(dir string) (*FileReplayStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	return &FileReplayStore{dir: dir}, nil
+}
+
+func (s *FileReplayStore) path(matchID string) (string, error) {
+	if matchID == "" || matchID != filepath.Base(matchID) || strings.HasPrefix(matchID, ".") {
+		return "", fmt.Errorf("%w: invalid match ID %q", ErrInvalidCommand, matchID)
+	}
+	return filepath.Join(s.dir, matchID+replayFileExt), nil
+}
+
+// Save 임시 파일에 쓴 뒤 이름을 바꿔, 읽는 쪽이 쓰다 만 파일을 보지 않게 합니다
+func (s *FileReplayStore) Save(ctx context.Context, replay *Replay) error {
+	path, err := s.path(replay.Header.MatchID)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create replay file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := WriteReplay(file, replay); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write replay file: %w", err)
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (s *FileReplayStore) Open(ctx context.Context, matchID string) (io.ReadCloser, error) {
+	path, err := s.path(matchID)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrReplayNotFound
+	}
+	return file, err
+}
+
+func (s *FileReplayStore) Get(ctx context.Context, matchID string) (*Replay, error) {
+	file, err := s.Open(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadReplay(file)
+}
+
+// List 파일마다 헤더만 읽습니다 (손상된 파일은 건너뜀)
+func (s *FileReplayStore) List(ctx context.Context, query ReplayQuery) ([]ReplayHeader, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+replayFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]ReplayHeader, 0)
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		header, err := readHeaderFile(path)
+		if err != nil {
+			log.Printf("[Match] Skipping replay %s: %v", filepath.Base(path), err)
+			continue
+		}
+		if query.matches(header) {
+			headers = append(headers, header)
+		}
+	}
+
+	sort.Slice(headers, func(i, j int) bool { return headers[i].EndedAt.After(headers[j].EndedAt) })
+	if query.Limit > 0 && len(headers) > query.Limit {
+		headers = headers[:query.Limit]
+	}
+	return headers, nil
+}
+
+func readHeaderFile(path string) (ReplayHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return ReplayHeader{}, err
+	}
+	defer file.Close()
+	return ReadReplayHeader(file)
+}
@@ -0,0 +1,227 @@
+package match
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playMatch 웨이브 두 번을 클리어해 매치를 끝내고 리플레이가 저장될 때까지 기다립니다
+func playMatch(t *testing.T, manager *RoomManager, matchID string, players ...string) *Room {
+	t.Helper()
+	room, err := manager.Create(matchID, players)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: players[0], TowerID: "t-1", Kind: "arrow", X: 1, Y: 2}))
+	for wave := 0; wave < 2; wave++ {
+		require.Eventually(t, func() bool {
+			return room.Dispatch(ctx, Command{Type: CommandStartWave, PlayerID: players[0]}) == nil
+		}, 2*time.Second, 5*time.Millisecond)
+	}
+	select {
+	case <-room.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("match did not end")
+	}
+	return room
+}
+
+func TestReplay_WriteAndRead(t *testing.T) {
+	// Arrange
+	replay := &Replay{
+		Header: ReplayHeader{MatchID: "m-1", Players: []string{"alice"}, Result: ResultVictory, Ticks: 5},
+		Events: []ReplayEvent{
+			{Tick: 0, Type: MatchStartedEventType, Data: json.RawMessage(`{"players":["alice"],"starting_gold":200}`)},
+			{Tick: 5, Type: MatchEndedEventType, Data: json.RawMessage(`{"result":"victory","reason":"final wave cleared"}`)},
+		},
+	}
+	var file bytes.Buffer
+
+	// Act
+	require.NoError(t, WriteReplay(&file, replay))
+	header, headerErr := ReadReplayHeader(bytes.NewReader(file.Bytes()))
+	decoded, err := ReadReplay(bytes.NewReader(file.Bytes()))
+
+	// Assert
+	require.NoError(t, headerErr)
+	require.NoError(t, err)
+	assert.Equal(t, ReplayFormatVersion, header.FormatVersion)
+	assert.Equal(t, 2, header.EventCount)
+	assert.NotEmpty(t, header.Checksum)
+	assert.Equal(t, header, decoded.Header)
+	assert.Equal(t, replay.Events, decoded.Events)
+}
+
+func TestReplay_DetectsCorruption(t *testing.T) {
+	var file bytes.Buffer
+	require.NoError(t, WriteReplay(&file, &Replay{
+		Header: ReplayHeader{MatchID: "m-1"},
+		Events: []ReplayEvent{{Type: MatchStartedEventType, Data: json.RawMessage(`{}`)}},
+	}))
+	data := file.Bytes()
+
+	tampered := append([]byte(nil), data...)
+	tampered[len(tampered)-5] ^= 0xff
+	_, err := ReadReplay(bytes.NewReader(tampered))
+	assert.ErrorIs(t, err, ErrReplayCorrupted)
+
+	_, err = ReadReplay(bytes.NewReader(data[:len(data)-3]))
+	assert.ErrorIs(t, err, ErrReplayCorrupted)
+
+	_, err = ReadReplay(bytes.NewReader([]byte("not a replay file")))
+	assert.ErrorIs(t, err, ErrReplayCorrupted)
+}
+
+func TestRoom_SavesReplayAndPlaybackReconstructsState(t *testing.T) {
+	// Arrange
+	store, err := NewFileReplayStore(t.TempDir())
+	require.NoError(t, err)
+	manager, broadcaster := newTestManager(t, ManagerConfig{Replays: store})
+	room := playMatch(t, manager, "m-1", "alice", "bob")
+
+	// Act
+	replay, err := store.Get(context.Background(), "m-1")
+	require.NoError(t, err)
+	playback, err := NewPlayback(replay)
+	require.NoError(t, err)
+
+	// Assert: 틱 0은 매치 시작 상태
+	assert.Equal(t, []string{"alice", "bob"}, replay.Header.Players)
+	assert.Equal(t, ResultVictory, replay.Header.Result)
+	assert.Equal(t, 200, replay.Header.TickRate)
+	assert.Equal(t, StatusInProgress, playback.State().Status)
+	assert.Equal(t, int64(200), playback.State().Gold["bob"])
+
+	// 틱 단위 재생의 diff는 라이브 브로드캐스트와 같아야 함
+	var replayed []StateDiff
+	for {
+		frame, err := playback.Step()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if !frame.Diff.Empty() {
+			replayed = append(replayed, frame.Diff)
+		}
+	}
+	live := broadcaster.published(Channel("m-1"))
+	assert.Equal(t, live[1:], replayed)
+
+	final := room.State()
+	final.Tick = playback.State().Tick
+	assert.Equal(t, final, playback.State())
+	assert.Equal(t, int64(1), manager.Metrics().ReplaysSaved)
+
+	// 뒤로 이동하면 처음부터 다시 재생
+	require.NoError(t, playback.SeekTick(0))
+	assert.Empty(t, playback.State().Towers)
+	assert.Error(t, playback.SeekTick(replay.Header.Ticks+1))
+}
+
+func TestRoom_DoesNotSaveReplayForAbandonedMatch(t *testing.T) {
+	store, err := NewFileReplayStore(t.TempDir())
+	require.NoError(t, err)
+	manager, _ := newTestManager(t, ManagerConfig{Replays: store})
+	_, err = manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Close(context.Background(), "m-1"))
+
+	_, err = store.Get(context.Background(), "m-1")
+	assert.ErrorIs(t, err, ErrReplayNotFound)
+}
+
+func TestFileReplayStore_List(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	store, err := NewFileReplayStore(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, header := range []ReplayHeader{
+		{MatchID: "m-1", Players: []string{"alice", "bob"}, Result: ResultVictory, EndedAt: base},
+		{MatchID: "m-2", Players: []string{"alice"}, Result: ResultDefeat, EndedAt: base.Add(time.Hour)},
+		{MatchID: "m-3", Players: []string{"bob"}, Result: ResultVictory, EndedAt: base.Add(2 * time.Hour)},
+	} {
+		require.NoError(t, store.Save(ctx, &Replay{Header: header}), i)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken"+replayFileExt), []byte("garbage"), 0o644))
+
+	ids := func(query ReplayQuery) []string {
+		headers, err := store.List(ctx, query)
+		require.NoError(t, err)
+		var ids []string
+		for _, header := range headers {
+			ids = append(ids, header.MatchID)
+		}
+		return ids
+	}
+
+	// Act & Assert
+	assert.Equal(t, []string{"m-3", "m-2", "m-1"}, ids(ReplayQuery{}))
+	assert.Equal(t, []string{"m-2", "m-1"}, ids(ReplayQuery{PlayerID: "alice"}))
+	assert.Equal(t, []string{"m-3", "m-1"}, ids(ReplayQuery{Result: ResultVictory}))
+	assert.Equal(t, []string{"m-2"}, ids(ReplayQuery{Since: base.Add(time.Minute), Until: base.Add(2 * time.Hour)}))
+	assert.Equal(t, []string{"m-3"}, ids(ReplayQuery{Limit: 1}))
+
+	assert.ErrorIs(t, store.Save(ctx, &Replay{Header: ReplayHeader{MatchID: "../escape"}}), ErrInvalidCommand)
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrReplayNotFound)
+}
+
+func TestMatchApp_ReplayRoutes(t *testing.T) {
+	// Arrange
+	store, err := NewFileReplayStore(t.TempDir())
+	require.NoError(t, err)
+	app, err := NewMatchApp(Config{
+		Broadcaster: newRecordingBroadcaster(),
+		Manager:     ManagerConfig{Rules: testRules(), TickRate: 200, Replays: store},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { app.Stop(context.Background()) })
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	playMatch(t, app.Rooms(), "m-1", "alice")
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	// Act & Assert
+	rec := get("/match/replays?player_id=alice")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var headers []ReplayHeader
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &headers))
+	require.Len(t, headers, 1)
+	assert.Equal(t, "m-1", headers[0].MatchID)
+	assert.Equal(t, http.StatusBadRequest, get("/match/replays?since=yesterday").Code)
+
+	rec = get("/match/replays/file?match_id=m-1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	downloaded, err := ReadReplay(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, headers[0], downloaded.Header)
+
+	rec = get("/match/replays/frames?match_id=m-1&from=0")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var frames ReplayFramesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &frames))
+	assert.Equal(t, StatusInProgress, frames.State.Status)
+	require.NotEmpty(t, frames.Frames)
+	assert.Equal(t, []string{WaveClearedEventType, MatchEndedEventType}, frames.Frames[len(frames.Frames)-1].Events)
+
+	assert.Equal(t, http.StatusNotFound, get("/match/replays/file?match_id=m-9").Code)
+}
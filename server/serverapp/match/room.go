@@ -0,0 +1,343 @@
+package match
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRoomClosed  = errors.New("match room is closed")
+	ErrMailboxFull = errors.New("match room mailbox is full")
+)
+
+// 룸 종료 사유
+const (
+	CloseReasonEnded   = "ended"   // 매치 종료
+	CloseReasonIdle    = "idle"    // 입력 없이 IdleTimeout 경과
+	CloseReasonStopped = "stopped" // 관리자 또는 서버 종료
+)
+
+// Broadcaster 매치 채널로 이벤트를 발행합니다 (realtime.RealtimeApp이 구현)
+type Broadcaster interface {
+	Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error)
+}
+
+// Channel 매치 상태 diff가 발행되는 채널 이름
+func Channel(matchID string) string {
+	return "match:" + matchID
+}
+
+// MatchCompletedEventType 끝난 매치 결과 (통합 이벤트, 이벤트 버스로 발행)
+// 레이팅 같은 다른 서비스가 구독하며, 포기되거나 중단된 매치는 발행하지 않습니다
+const MatchCompletedEventType = "MatchCompleted"
+
+// MatchCompletedData 끝난 매치 결과
+type MatchCompletedData struct {
+	MatchID      string    `json:"match_id"`
+	Players      []string  `json:"players"`
+	Result       string    `json:"result"`
+	WavesCleared int       `json:"waves_cleared"`
+	FinalWave    int       `json:"final_wave"`
+	Ticks        int64     `json:"ticks"`
+	EndedAt      time.Time `json:"ended_at"`
+}
+
+// roomConfig 룸 실행 설정 (RoomManager가 기본값을 채움)
+type roomConfig struct {
+	tickInterval       time.Duration
+	idleTimeout        time.Duration
+	maxCommandsPerTick int
+	spectatorDelay     time.Duration
+	maxSpectators      int
+	spectatorFilter    SpectatorFilter
+	tickRate           int
+}
+
+// envelope 메일박스에 쌓인 명령과 결과를 돌려받을 채널
+type envelope struct {
+	command Command
+	reply   chan error
+}
+
+// Room 매치 하나를 실행하는 액터
+// 매치 애그리게이트는 룸 고루틴만 다루며, 외부에서는 메일박스로 명령을 보내고
+// 고정 주기 틱마다 쌓인 명령을 적용한 뒤 바뀐 상태만 브로드캐스트합니다
+type Room struct {
+	id          string
+	players     []string
+	config      roomConfig
+	match       *MatchAggregate
+	mailbox     chan envelope
+	broadcaster Broadcaster
+	eventBus    cqrs.EventBus // 선택: 관전 입퇴장과 매치 결과 기록
+	replays     ReplayStore   // 선택: 끝난 매치의 리플레이 저장
+	startedAt   time.Time
+	metrics     *Metrics
+	onClose     func(room *Room, reason string)
+
+	// 룸 고루틴 전용
+	tick           int64
+	lastActivity   time.Time
+	spectatorQueue []delayedState
+	history        []ReplayEvent // 리플레이용 매치 이벤트
+
+	mu             sync.RWMutex
+	state          MatchState // 마지막으로 브로드캐스트한 상태
+	spectatorState MatchState // 관전자에게 공개된 상태
+	spectators     map[string]time.Time
+	reason         string
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// ID 매치 ID
+func (r *Room) ID() string {
+	return r.id
+}
+
+// State 마지막 틱 이후의 매치 상태
+func (r *Room) State() MatchState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.state
+}
+
+// Tick 처리한 틱 수
+func (r *Room) Tick() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.state.Tick
+}
+
+// Done 룸이 종료되면 닫히는 채널
+func (r *Room) Done() <-chan struct{} {
+	return r.done
+}
+
+// CloseReason 종료 사유 (실행 중이면 "")
+func (r *Room) CloseReason() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.reason
+}
+
+// Dispatch 명령을 메일박스에 넣고 다음 틱에 적용될 때까지 기다립니다
+// 메일박스가 가득 차면 기다리지 않고 ErrMailboxFull을 반환합니다
+func (r *Room) Dispatch(ctx context.Context, command Command) error {
+	request := envelope{command: command, reply: make(chan error, 1)}
+	select {
+	case <-r.done:
+		return ErrRoomClosed
+	default:
+	}
+	select {
+	case r.mailbox <- request:
+	default:
+		r.metrics.commandsRejected.Add(1)
+		return ErrMailboxFull
+	}
+
+	select {
+	case err := <-request.reply:
+		return err
+	case <-r.done:
+		// 종료 직전 틱에서 처리됐을 수 있음
+		select {
+		case err := <-request.reply:
+			return err
+		default:
+			return ErrRoomClosed
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 룸을 멈춥니다 (이미 종료됐으면 아무것도 하지 않음)
+func (r *Room) Close() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// run 틱 루프 (룸 고루틴)
+func (r *Room) run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.tickInterval)
+	defer ticker.Stop()
+
+	reason := CloseReasonStopped
+	defer func() { r.finish(reason) }()
+
+	r.lastActivity = time.Now()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if closeReason := r.step(ctx); closeReason != "" {
+				reason = closeReason
+				return
+			}
+		}
+	}
+}
+
+// step 한 틱을 처리하고, 룸을 닫아야 하면 종료 사유를 반환합니다
+func (r *Room) step(ctx context.Context) string {
+	started := time.Now()
+	r.tick++
+
+	// 틱 시작 시점에 쌓여 있던 명령만 적용 (이후 도착한 명령은 다음 틱)
+	pending := len(r.mailbox)
+	if pending > r.config.maxCommandsPerTick {
+		pending = r.config.maxCommandsPerTick
+	}
+	for i := 0; i < pending; i++ {
+		request := <-r.mailbox
+		err := r.match.Execute(request.command, r.tick)
+		if err != nil {
+			r.metrics.commandsRejected.Add(1)
+		} else {
+			r.metrics.commandsProcessed.Add(1)
+		}
+		request.reply <- err
+		r.lastActivity = started
+	}
+
+	if err := r.match.Tick(r.tick); err != nil {
+		log.Printf("[Match] Room %s tick %d failed: %v", r.id, r.tick, err)
+	}
+	r.broadcast(ctx)
+
+	elapsed := time.Since(started)
+	r.metrics.recordTick(elapsed, elapsed > r.config.tickInterval)
+
+	switch {
+	case r.match.Ended():
+		return CloseReasonEnded
+	case r.config.idleTimeout > 0 && started.Sub(r.lastActivity) >= r.config.idleTimeout:
+		return CloseReasonIdle
+	}
+	return ""
+}
+
+// broadcast 이번 틱의 변경 사항을 diff로 발행합니다
+func (r *Room) broadcast(ctx context.Context) {
+	changes := r.match.Changes()
+	r.match.ClearChanges()
+
+	next := r.match.State()
+	r.mu.RLock()
+	diff := diffState(r.state, next, r.tick)
+	r.mu.RUnlock()
+	for _, event := range changes {
+		diff.Events = append(diff.Events, event.EventType())
+		r.recordHistory(event)
+	}
+
+	next.Tick = r.tick
+	r.mu.Lock()
+	r.state = next
+	r.mu.Unlock()
+
+	now := time.Now()
+	if !diff.Empty() {
+		if _, err := r.broadcaster.Publish(ctx, Channel(r.id), StateDiffEventType, diff); err != nil {
+			log.Printf("[Match] Room %s failed to broadcast tick %d: %v", r.id, r.tick, err)
+		}
+		r.queueSpectatorState(now, next, diff.Events)
+	}
+	r.releaseSpectatorStates(ctx, now, false)
+}
+
+// finish 남은 명령을 거절하고 종료를 알립니다
+// 관리자 목록에서 먼저 제거한 뒤 done을 닫으므로, Done 이후에는 Get으로 찾을 수 없습니다
+func (r *Room) finish(reason string) {
+	r.mu.Lock()
+	r.reason = reason
+	r.mu.Unlock()
+
+	for drained := false; !drained; {
+		select {
+		case request := <-r.mailbox:
+			request.reply <- ErrRoomClosed
+		default:
+			drained = true
+		}
+	}
+
+	// 매치가 끝났으므로 관전 상태를 지연 없이 공개하고 관전자를 내보냄
+	ctx := context.Background()
+	r.releaseSpectatorStates(ctx, time.Now(), true)
+	r.closeSpectators(ctx)
+	if reason == CloseReasonEnded {
+		r.record(ctx, MatchCompletedEventType, r.completed())
+		if r.replays != nil {
+			r.saveReplay(ctx)
+		}
+	}
+
+	if r.onClose != nil {
+		r.onClose(r, reason)
+	}
+	log.Printf("[Match] Room %s closed after %d ticks (%s)", r.id, r.tick, reason)
+	close(r.done)
+}
+
+// completed 끝난 매치의 결과를 만듭니다
+func (r *Room) completed() MatchCompletedData {
+	state := r.match.State()
+	cleared := state.Wave
+	if state.WaveInProgress {
+		cleared--
+	}
+	return MatchCompletedData{
+		MatchID:      r.id,
+		Players:      append([]string(nil), r.players...),
+		Result:       state.Result,
+		WavesCleared: cleared,
+		FinalWave:    r.match.rules.FinalWave,
+		Ticks:        r.tick,
+		EndedAt:      time.Now(),
+	}
+}
+
+// recordHistory 리플레이용으로 이벤트를 기록합니다
+func (r *Room) recordHistory(event cqrs.EventMessage) {
+	data, err := json.Marshal(event.EventData())
+	if err != nil {
+		log.Printf("[Match] Room %s failed to record %s for replay: %v", r.id, event.EventType(), err)
+		return
+	}
+	r.history = append(r.history, ReplayEvent{Tick: r.tick, Type: event.EventType(), Data: data})
+}
+
+// saveReplay 끝난 매치의 이벤트 스트림을 리플레이로 저장합니다
+func (r *Room) saveReplay(ctx context.Context) {
+	replay := &Replay{
+		Header: ReplayHeader{
+			MatchID:   r.id,
+			Players:   r.players,
+			Result:    r.match.result,
+			Rules:     r.match.rules,
+			TickRate:  r.config.tickRate,
+			Ticks:     r.tick,
+			StartedAt: r.startedAt,
+			EndedAt:   time.Now(),
+		},
+		Events: r.history,
+	}
+	if err := r.replays.Save(ctx, replay); err != nil {
+		r.metrics.replayFailures.Add(1)
+		log.Printf("[Match] Room %s failed to save replay: %v", r.id, err)
+		return
+	}
+	r.metrics.replaysSaved.Add(1)
+}
@@ -0,0 +1,336 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"defense-allies-server/pkg/tower/synergy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBroadcaster 발행된 diff를 기록합니다
+type recordingBroadcaster struct {
+	mu    sync.Mutex
+	diffs map[string][]StateDiff
+}
+
+func newRecordingBroadcaster() *recordingBroadcaster {
+	return &recordingBroadcaster{diffs: make(map[string][]StateDiff)}
+}
+
+func (b *recordingBroadcaster) Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if diff, ok := data.(StateDiff); ok && eventType == StateDiffEventType {
+		b.diffs[channel] = append(b.diffs[channel], diff)
+	}
+	return "", nil
+}
+
+func (b *recordingBroadcaster) published(channel string) []StateDiff {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]StateDiff(nil), b.diffs[channel]...)
+}
+
+func testRules() Rules {
+	return Rules{
+		StartingGold:      200,
+		TowerCosts:        map[string]int64{"arrow": 100},
+		SellRefundPercent: 50,
+		WaveDurationTicks: 3,
+		WaveReward:        40,
+		FinalWave:         2,
+	}
+}
+
+func newTestManager(t *testing.T, config ManagerConfig) (*RoomManager, *recordingBroadcaster) {
+	t.Helper()
+	broadcaster := newRecordingBroadcaster()
+	if config.Rules.TowerCosts == nil {
+		config.Rules = testRules()
+	}
+	if config.TickRate == 0 {
+		config.TickRate = 200
+	}
+	manager, err := NewRoomManager(config, broadcaster)
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Shutdown(context.Background()) })
+	return manager, broadcaster
+}
+
+func TestMatchAggregate_Rules(t *testing.T) {
+	match := NewMatchAggregate("m-1", testRules())
+	require.NoError(t, match.Start([]string{"alice", "bob"}))
+
+	assert.ErrorIs(t, match.Start([]string{"alice"}), ErrInvalidCommand)
+	assert.ErrorIs(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "eve", TowerID: "t-1", Kind: "arrow"}, 1), ErrInvalidCommand)
+	assert.ErrorIs(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "laser"}, 1), ErrInvalidCommand)
+	require.NoError(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "arrow", X: 1, Y: 1}, 1))
+	assert.ErrorIs(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "bob", TowerID: "t-2", Kind: "arrow", X: 1, Y: 1}, 1), ErrInvalidCommand)
+	assert.ErrorIs(t, match.Execute(Command{Type: CommandSellTower, PlayerID: "bob", TowerID: "t-1"}, 1), ErrInvalidCommand)
+	require.NoError(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-2", Kind: "arrow", X: 2, Y: 1}, 1))
+	assert.ErrorIs(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-3", Kind: "arrow", X: 3, Y: 1}, 1), ErrInvalidCommand)
+	require.NoError(t, match.Execute(Command{Type: CommandSellTower, PlayerID: "alice", TowerID: "t-2"}, 1))
+
+	state := match.State()
+	assert.Equal(t, int64(50), state.Gold["alice"])
+	assert.Equal(t, int64(200), state.Gold["bob"])
+	assert.Len(t, state.Towers, 1)
+}
+
+func TestMatchAggregate_LoadFromHistory(t *testing.T) {
+	// Arrange
+	match := NewMatchAggregate("m-1", testRules())
+	require.NoError(t, match.Start([]string{"alice"}))
+	require.NoError(t, match.Execute(Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "arrow"}, 1))
+	require.NoError(t, match.Execute(Command{Type: CommandStartWave, PlayerID: "alice"}, 1))
+	require.NoError(t, match.Tick(4))
+
+	// Act
+	restored := NewMatchAggregate("m-1", testRules())
+	require.NoError(t, restored.LoadFromHistory(match.Changes()))
+
+	// Assert
+	assert.Equal(t, match.State(), restored.State())
+	assert.Equal(t, match.Version(), restored.Version())
+	assert.Empty(t, restored.Changes())
+}
+
+func TestRoom_AppliesCommandsOnTickAndBroadcastsDiffs(t *testing.T) {
+	// Arrange
+	manager, broadcaster := newTestManager(t, ManagerConfig{})
+	room, err := manager.Create("m-1", []string{"alice", "bob"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "arrow", X: 3, Y: 4}))
+	err = room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: "bob", TowerID: "t-2", Kind: "arrow", X: 3, Y: 4})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+	diffs := broadcaster.published(Channel("m-1"))
+	require.GreaterOrEqual(t, len(diffs), 2)
+
+	initial := diffs[0]
+	assert.Equal(t, int64(0), initial.Tick)
+	assert.Equal(t, []string{MatchStartedEventType}, initial.Events)
+	assert.Equal(t, StatusInProgress, initial.Status)
+	assert.Equal(t, map[string]int64{"alice": 200, "bob": 200}, initial.Gold)
+
+	placed := diffs[1]
+	assert.Positive(t, placed.Tick)
+	assert.Equal(t, []string{TowerPlacedEventType}, placed.Events)
+	assert.Equal(t, map[string]int64{"alice": 100}, placed.Gold) // 바뀐 플레이어만
+	require.Len(t, placed.TowersAdded, 1)
+	assert.Equal(t, "t-1", placed.TowersAdded[0].ID)
+	assert.Empty(t, placed.Status)
+
+	state := room.State()
+	assert.Equal(t, int64(100), state.Gold["alice"])
+	assert.Contains(t, state.Towers, "t-1")
+}
+
+func TestRoom_SynergyPinnedAtCreate(t *testing.T) {
+	// Arrange
+	registry := synergy.NewRegistry(nil)
+	_, err := registry.Load(strings.NewReader(`{"version": "v1", "effects": {"arrow": {"arrow": {"damage": 10}}}}`))
+	require.NoError(t, err)
+	manager, broadcaster := newTestManager(t, ManagerConfig{Synergy: registry})
+	room, err := manager.Create("m-1", []string{"alice", "bob"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	_, err = registry.Load(strings.NewReader(`{"version": "v2", "effects": {"arrow": {"arrow": {"damage": 50}}}}`))
+	require.NoError(t, err)
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "arrow", X: 0, Y: 0}))
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: "bob", TowerID: "t-2", Kind: "arrow", X: 1, Y: 1}))
+	require.Eventually(t, func() bool { return len(room.State().Towers) == 2 }, time.Second, time.Millisecond)
+	linked := room.State()
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandSellTower, PlayerID: "bob", TowerID: "t-2"}))
+
+	// Assert
+	assert.Equal(t, map[string]synergy.Bonus{"t-1": {Damage: 10}, "t-2": {Damage: 10}}, linked.Synergy) // 생성 시점의 v1 매트릭스
+	require.Eventually(t, func() bool { return len(room.State().Towers) == 1 }, time.Second, time.Millisecond)
+	assert.Empty(t, room.State().Synergy)
+
+	var lost StateDiff
+	require.Eventually(t, func() bool {
+		for _, diff := range broadcaster.published(Channel("m-1")) {
+			if len(diff.TowersRemoved) > 0 {
+				lost = diff
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, map[string]synergy.Bonus{"t-1": {}}, lost.Synergy) // 빈 보너스는 시너지 해제
+}
+
+func TestRoom_ClosesWhenMatchEnds(t *testing.T) {
+	// Arrange
+	manager, broadcaster := newTestManager(t, ManagerConfig{})
+	room, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act: 웨이브 두 번 클리어 (FinalWave = 2)
+	for wave := 0; wave < 2; wave++ {
+		require.Eventually(t, func() bool {
+			return room.Dispatch(ctx, Command{Type: CommandStartWave, PlayerID: "alice"}) == nil
+		}, 2*time.Second, 5*time.Millisecond)
+	}
+
+	// Assert
+	select {
+	case <-room.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("room did not close after the final wave")
+	}
+	assert.Equal(t, CloseReasonEnded, room.CloseReason())
+	assert.Equal(t, ResultVictory, room.State().Result)
+	assert.ErrorIs(t, room.Dispatch(ctx, Command{Type: CommandStartWave, PlayerID: "alice"}), ErrRoomClosed)
+
+	diffs := broadcaster.published(Channel("m-1"))
+	last := diffs[len(diffs)-1]
+	assert.Equal(t, StatusEnded, last.Status)
+	assert.Equal(t, ResultVictory, last.Result)
+
+	require.Eventually(t, func() bool { return manager.ActiveRooms() == 0 }, time.Second, 5*time.Millisecond)
+	_, exists := manager.Get("m-1")
+	assert.False(t, exists)
+}
+
+func TestRoom_ClosesWhenIdle(t *testing.T) {
+	manager, _ := newTestManager(t, ManagerConfig{IdleTimeout: 30 * time.Millisecond})
+	room, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+
+	select {
+	case <-room.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle room was not closed")
+	}
+	assert.Equal(t, CloseReasonIdle, room.CloseReason())
+	assert.ErrorIs(t, manager.Dispatch(context.Background(), "m-1", Command{Type: CommandSurrender, PlayerID: "alice"}), ErrRoomNotFound)
+}
+
+func TestRoom_RejectsCommandsWhenMailboxIsFull(t *testing.T) {
+	// Arrange: 틱이 거의 돌지 않는 룸
+	manager, _ := newTestManager(t, ManagerConfig{TickRate: 1, MailboxSize: 1})
+	room, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queued := make(chan error, 1)
+	go func() { queued <- room.Dispatch(ctx, Command{Type: CommandStartWave, PlayerID: "alice"}) }()
+	require.Eventually(t, func() bool { return len(room.mailbox) == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	err = room.Dispatch(ctx, Command{Type: CommandStartWave, PlayerID: "alice"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrMailboxFull)
+	assert.Equal(t, int64(1), manager.Metrics().CommandsRejected)
+	cancel()
+	assert.ErrorIs(t, <-queued, context.Canceled)
+}
+
+func TestRoomManager_LifecycleAndMetrics(t *testing.T) {
+	// Arrange
+	manager, _ := newTestManager(t, ManagerConfig{MaxRooms: 2})
+	ctx := context.Background()
+
+	// Act
+	_, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	_, err = manager.Create("m-1", []string{"bob"})
+	assert.ErrorIs(t, err, ErrRoomExists)
+	_, err = manager.Create("m-2", nil)
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+	_, err = manager.Create("m-2", []string{"bob"})
+	require.NoError(t, err)
+	_, err = manager.Create("m-3", []string{"carol"})
+	assert.ErrorIs(t, err, ErrTooManyRooms)
+
+	require.NoError(t, manager.Dispatch(ctx, "m-1", Command{Type: CommandStartWave, PlayerID: "alice"}))
+	require.NoError(t, manager.Close(ctx, "m-2"))
+	assert.ErrorIs(t, manager.Close(ctx, "m-2"), ErrRoomNotFound)
+
+	// Assert
+	metrics := manager.Metrics()
+	assert.Equal(t, int64(1), metrics.ActiveRooms)
+	assert.Equal(t, int64(2), metrics.RoomsCreated)
+	assert.Equal(t, int64(1), metrics.RoomsClosed)
+	assert.Equal(t, int64(1), metrics.CommandsProcessed)
+	assert.Positive(t, metrics.Ticks)
+
+	require.NoError(t, manager.Shutdown(ctx))
+	assert.Equal(t, 0, manager.ActiveRooms())
+	_, err = manager.Create("m-4", []string{"dave"})
+	assert.ErrorIs(t, err, ErrRoomClosed)
+}
+
+func TestMatchApp_Routes(t *testing.T) {
+	// Arrange
+	app, err := NewMatchApp(Config{
+		Broadcaster: newRecordingBroadcaster(),
+		Manager:     ManagerConfig{Rules: testRules(), TickRate: 200},
+		Identify:    func(r *http.Request) string { return r.Header.Get("X-Player") },
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { app.Stop(context.Background()) })
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+
+	do := func(method, target, player, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-Player", player)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Act & Assert
+	rec := do(http.MethodPost, "/match/rooms", "", `{"match_id":"m-1","players":["alice","bob"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var state MatchState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Equal(t, StatusInProgress, state.Status)
+
+	// 본문의 player_id가 아닌 인증된 플레이어로 명령을 적용
+	rec = do(http.MethodPost, "/match/rooms/commands?match_id=m-1", "alice", `{"type":"place_tower","player_id":"bob","tower_id":"t-1","kind":"arrow"}`)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = do(http.MethodPost, "/match/rooms/commands?match_id=m-1", "alice", `{"type":"sell_tower","tower_id":"missing"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	rec = do(http.MethodPost, "/match/rooms/commands?match_id=m-9", "alice", `{"type":"start_wave"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = do(http.MethodGet, "/match/rooms?match_id=m-1", "alice", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Equal(t, "alice", state.Towers["t-1"].OwnerID)
+
+	rec = do(http.MethodGet, "/match/metrics", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var metrics MetricsSnapshot
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metrics))
+	assert.Equal(t, int64(1), metrics.ActiveRooms)
+	assert.Equal(t, int64(1), metrics.CommandsProcessed)
+
+	rec = do(http.MethodPost, "/match/rooms/close?match_id=m-1", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = do(http.MethodGet, "/match/rooms?match_id=m-1", "alice", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
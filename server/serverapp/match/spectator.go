@@ -0,0 +1,275 @@
+package match
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSpectatorLimit     = errors.New("match spectator limit reached")
+	ErrNotSpectating      = errors.New("not spectating this match")
+	ErrSpectatorForbidden = errors.New("players cannot spectate their own match")
+)
+
+// 관전 이벤트 타입 (분석용, 이벤트 버스로 발행)
+const (
+	SpectatorJoinedEventType = "SpectatorJoined"
+	SpectatorLeftEventType   = "SpectatorLeft"
+)
+
+// 관전 종료 사유
+const (
+	LeaveReasonLeft        = "left"
+	LeaveReasonMatchClosed = "match_closed"
+)
+
+// spectatorChannelPrefix 관전 채널 접두사
+const spectatorChannelPrefix = "spectate:"
+
+// SpectatorChannel 관전자용 지연 diff가 발행되는 채널 이름
+func SpectatorChannel(matchID string) string {
+	return spectatorChannelPrefix + matchID
+}
+
+// SpectatorFilter 관전자에게 보낼 상태를 만듭니다 (입력 상태는 수정하지 않아야 함)
+type SpectatorFilter func(state MatchState) MatchState
+
+// HidePlayerEconomy 플레이어별 골드를 숨기는 기본 관전 필터
+func HidePlayerEconomy(state MatchState) MatchState {
+	state.Gold = nil
+	return state
+}
+
+// 관전 이벤트 데이터
+type (
+	SpectatorJoinedData struct {
+		SpectatorID string `json:"spectator_id"`
+		Spectators  int    `json:"spectators"` // 입장 후 관전자 수
+	}
+	SpectatorLeftData struct {
+		SpectatorID    string  `json:"spectator_id"`
+		Reason         string  `json:"reason"`
+		WatchedSeconds float64 `json:"watched_seconds"`
+		Spectators     int     `json:"spectators"` // 퇴장 후 관전자 수
+	}
+)
+
+// SpectatorTicket 관전 입장 결과
+// 클라이언트는 Channel을 구독한 뒤 SpectatorState를 다시 읽고, Tick이 그보다 큰 diff만 적용합니다
+type SpectatorTicket struct {
+	MatchID    string        `json:"match_id"`
+	Channel    string        `json:"channel"`
+	Delay      time.Duration `json:"delay"`
+	State      MatchState    `json:"state"` // 지연된 관전 상태
+	Spectators int           `json:"spectators"`
+}
+
+// delayedState 공개 시각을 기다리는 관전 상태
+type delayedState struct {
+	releaseAt time.Time
+	events    []string
+	state     MatchState // 필터 적용됨
+}
+
+// SpectatorState 관전자에게 공개된 (지연, 필터된) 매치 상태
+func (r *Room) SpectatorState() MatchState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.spectatorState
+}
+
+// Spectators 현재 관전자 수
+func (r *Room) Spectators() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.spectators)
+}
+
+// Spectating 관전 중인지 확인합니다
+func (r *Room) Spectating(spectatorID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.spectators[spectatorID]
+	return exists
+}
+
+// JoinSpectator 관전자로 입장합니다 (이미 입장했으면 입장 정보만 다시 반환)
+func (r *Room) JoinSpectator(ctx context.Context, spectatorID string) (*SpectatorTicket, error) {
+	if spectatorID == "" {
+		return nil, fmt.Errorf("%w: spectator ID is required", ErrInvalidCommand)
+	}
+	if r.isPlayer(spectatorID) {
+		return nil, ErrSpectatorForbidden
+	}
+
+	r.mu.Lock()
+	_, rejoined := r.spectators[spectatorID]
+	if !rejoined {
+		if r.reason != "" { // 종료 중
+			r.mu.Unlock()
+			return nil, ErrRoomClosed
+		}
+		if r.config.maxSpectators > 0 && len(r.spectators) >= r.config.maxSpectators {
+			r.mu.Unlock()
+			r.metrics.spectatorsRejected.Add(1)
+			return nil, ErrSpectatorLimit
+		}
+		r.spectators[spectatorID] = time.Now()
+		r.metrics.activeSpectators.Add(1)
+	}
+	ticket := &SpectatorTicket{
+		MatchID:    r.id,
+		Channel:    SpectatorChannel(r.id),
+		Delay:      r.config.spectatorDelay,
+		State:      r.spectatorState,
+		Spectators: len(r.spectators),
+	}
+	r.mu.Unlock()
+
+	if !rejoined {
+		r.record(ctx, SpectatorJoinedEventType, SpectatorJoinedData{SpectatorID: spectatorID, Spectators: ticket.Spectators})
+	}
+	return ticket, nil
+}
+
+// LeaveSpectator 관전을 끝냅니다
+func (r *Room) LeaveSpectator(ctx context.Context, spectatorID string) error {
+	r.mu.Lock()
+	joinedAt, exists := r.spectators[spectatorID]
+	if !exists {
+		r.mu.Unlock()
+		return ErrNotSpectating
+	}
+	delete(r.spectators, spectatorID)
+	remaining := len(r.spectators)
+	r.mu.Unlock()
+
+	r.metrics.activeSpectators.Add(-1)
+	r.record(ctx, SpectatorLeftEventType, SpectatorLeftData{
+		SpectatorID:    spectatorID,
+		Reason:         LeaveReasonLeft,
+		WatchedSeconds: time.Since(joinedAt).Seconds(),
+		Spectators:     remaining,
+	})
+	return nil
+}
+
+// isPlayer 매치 참가자인지 확인합니다 (참가자 목록은 생성 후 바뀌지 않음)
+func (r *Room) isPlayer(id string) bool {
+	for _, player := range r.players {
+		if player == id {
+			return true
+		}
+	}
+	return false
+}
+
+// queueSpectatorState 이번 틱 상태를 지연 큐에 넣습니다 (룸 고루틴)
+func (r *Room) queueSpectatorState(now time.Time, state MatchState, events []string) {
+	r.spectatorQueue = append(r.spectatorQueue, delayedState{
+		releaseAt: now.Add(r.config.spectatorDelay),
+		events:    events,
+		state:     r.config.spectatorFilter(state),
+	})
+}
+
+// releaseSpectatorStates 공개 시각이 지난 상태를 관전 채널로 발행합니다 (룸 고루틴)
+// all이면 지연과 관계없이 모두 공개합니다 (매치 종료 시)
+func (r *Room) releaseSpectatorStates(ctx context.Context, now time.Time, all bool) {
+	released := 0
+	for _, entry := range r.spectatorQueue {
+		if !all && entry.releaseAt.After(now) {
+			break
+		}
+		released++
+
+		r.mu.Lock()
+		diff := diffState(r.spectatorState, entry.state, entry.state.Tick)
+		diff.Events = entry.events
+		r.spectatorState = entry.state
+		watching := len(r.spectators) > 0
+		r.mu.Unlock()
+
+		// 관전자가 없으면 상태만 갱신 (입장 시 지연 상태로 시작)
+		if !watching || diff.Empty() {
+			continue
+		}
+		if _, err := r.broadcaster.Publish(ctx, SpectatorChannel(r.id), StateDiffEventType, diff); err != nil {
+			log.Printf("[Match] Room %s failed to broadcast spectator tick %d: %v", r.id, diff.Tick, err)
+		}
+	}
+	r.spectatorQueue = r.spectatorQueue[released:]
+}
+
+// closeSpectators 룸 종료 시 남은 관전자를 모두 내보냅니다
+func (r *Room) closeSpectators(ctx context.Context) {
+	r.mu.Lock()
+	spectators := r.spectators
+	r.spectators = make(map[string]time.Time)
+	r.mu.Unlock()
+
+	now := time.Now()
+	remaining := len(spectators)
+	for spectatorID, joinedAt := range spectators {
+		remaining--
+		r.metrics.activeSpectators.Add(-1)
+		r.record(ctx, SpectatorLeftEventType, SpectatorLeftData{
+			SpectatorID:    spectatorID,
+			Reason:         LeaveReasonMatchClosed,
+			WatchedSeconds: now.Sub(joinedAt).Seconds(),
+			Spectators:     remaining,
+		})
+	}
+}
+
+// record 관전 이벤트와 매치 결과를 이벤트 버스로 발행합니다
+func (r *Room) record(ctx context.Context, eventType string, data interface{}) {
+	if r.eventBus == nil {
+		return
+	}
+	event := newMatchEvent(eventType, data)
+	event.AggregateID_ = r.id
+	event.AggregateType_ = MatchAggregateType
+	if err := r.eventBus.Publish(ctx, event); err != nil {
+		log.Printf("[Match] Room %s failed to record %s: %v", r.id, eventType, err)
+	}
+}
+
+// AuthorizeSpectator 관전 채널 구독 권한을 확인합니다 (realtime.Config.Authorize에 연결)
+// 관전 채널이 아니면 허용하며, 관전 채널은 JoinSpectator로 입장한 사용자만 구독할 수 있습니다
+func (m *RoomManager) AuthorizeSpectator(ctx context.Context, userID, channel string) error {
+	matchID, isSpectatorChannel := strings.CutPrefix(channel, spectatorChannelPrefix)
+	if !isSpectatorChannel {
+		return nil
+	}
+	room, exists := m.Get(matchID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+	if !room.Spectating(userID) {
+		return ErrNotSpectating
+	}
+	return nil
+}
+
+// JoinSpectator 매치에 관전자로 입장합니다
+func (m *RoomManager) JoinSpectator(ctx context.Context, matchID, spectatorID string) (*SpectatorTicket, error) {
+	room, exists := m.Get(matchID)
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+	return room.JoinSpectator(ctx, spectatorID)
+}
+
+// LeaveSpectator 매치 관전을 끝냅니다
+func (m *RoomManager) LeaveSpectator(ctx context.Context, matchID, spectatorID string) error {
+	room, exists := m.Get(matchID)
+	if !exists {
+		return ErrRoomNotFound
+	}
+	return room.LeaveSpectator(ctx, spectatorID)
+}
@@ -0,0 +1,188 @@
+package match
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler 관전 분석 이벤트를 기록합니다
+type recordingHandler struct {
+	*cqrs.BaseEventHandler
+	mu     sync.Mutex
+	events []cqrs.EventMessage
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{BaseEventHandler: cqrs.NewBaseEventHandler("spectator-analytics", cqrs.AnalyticsHandler, nil)}
+}
+
+func (h *recordingHandler) CanHandle(eventType string) bool { return true }
+
+func (h *recordingHandler) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingHandler) recorded() []cqrs.EventMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]cqrs.EventMessage(nil), h.events...)
+}
+
+func TestRoom_SpectatorStreamIsDelayedAndFiltered(t *testing.T) {
+	// Arrange
+	delay := 150 * time.Millisecond
+	manager, broadcaster := newTestManager(t, ManagerConfig{SpectatorDelay: delay})
+	room, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ticket, err := room.JoinSpectator(ctx, "viewer")
+	require.NoError(t, err)
+	assert.Equal(t, SpectatorChannel("m-1"), ticket.Channel)
+	assert.Empty(t, ticket.State.Status) // 시작 상태도 아직 공개 전
+
+	// Act
+	placedAt := time.Now()
+	require.NoError(t, room.Dispatch(ctx, Command{Type: CommandPlaceTower, PlayerID: "alice", TowerID: "t-1", Kind: "arrow"}))
+
+	// Assert: 플레이어 채널은 즉시, 관전 채널은 지연 후
+	require.Eventually(t, func() bool { return len(broadcaster.published(Channel("m-1"))) == 2 }, time.Second, time.Millisecond)
+	assert.NotContains(t, room.SpectatorState().Towers, "t-1")
+
+	var spectated []StateDiff
+	require.Eventually(t, func() bool {
+		spectated = broadcaster.published(SpectatorChannel("m-1"))
+		return len(spectated) == 2
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(placedAt), delay)
+
+	assert.Equal(t, []string{MatchStartedEventType}, spectated[0].Events)
+	assert.Equal(t, []string{TowerPlacedEventType}, spectated[1].Events)
+	for _, diff := range spectated {
+		assert.Nil(t, diff.Gold, "player economy must be hidden from spectators")
+	}
+	state := room.SpectatorState()
+	assert.Contains(t, state.Towers, "t-1")
+	assert.Nil(t, state.Gold)
+}
+
+func TestRoom_SpectatorCapAndAnalytics(t *testing.T) {
+	// Arrange
+	bus := cqrs.NewInMemoryEventBus()
+	handler := newRecordingHandler()
+	_, err := bus.SubscribeAll(handler)
+	require.NoError(t, err)
+	manager, _ := newTestManager(t, ManagerConfig{MaxSpectators: 2, EventBus: bus})
+	room, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	_, err = manager.JoinSpectator(ctx, "m-1", "alice")
+	assert.ErrorIs(t, err, ErrSpectatorForbidden)
+	_, err = manager.JoinSpectator(ctx, "m-1", "v1")
+	require.NoError(t, err)
+	_, err = manager.JoinSpectator(ctx, "m-1", "v1") // 재입장은 자리를 더 차지하지 않음
+	require.NoError(t, err)
+	_, err = manager.JoinSpectator(ctx, "m-1", "v2")
+	require.NoError(t, err)
+	_, err = manager.JoinSpectator(ctx, "m-1", "v3")
+	assert.ErrorIs(t, err, ErrSpectatorLimit)
+
+	require.NoError(t, manager.LeaveSpectator(ctx, "m-1", "v1"))
+	assert.ErrorIs(t, manager.LeaveSpectator(ctx, "m-1", "v1"), ErrNotSpectating)
+	_, err = manager.JoinSpectator(ctx, "m-1", "v3")
+	require.NoError(t, err)
+	require.NoError(t, manager.Close(ctx, "m-1"))
+
+	// Assert
+	var joined, left []string
+	for _, event := range handler.recorded() {
+		assert.Equal(t, "m-1", event.AggregateID())
+		switch data := event.EventData().(type) {
+		case SpectatorJoinedData:
+			joined = append(joined, data.SpectatorID)
+		case SpectatorLeftData:
+			left = append(left, data.SpectatorID+":"+data.Reason)
+		}
+	}
+	assert.Equal(t, []string{"v1", "v2", "v3"}, joined)
+	assert.ElementsMatch(t, []string{"v1:" + LeaveReasonLeft, "v2:" + LeaveReasonMatchClosed, "v3:" + LeaveReasonMatchClosed}, left)
+	assert.Zero(t, room.Spectators())
+
+	metrics := manager.Metrics()
+	assert.Equal(t, int64(0), metrics.ActiveSpectators)
+	assert.Equal(t, int64(1), metrics.SpectatorsRejected)
+}
+
+func TestRoomManager_AuthorizeSpectator(t *testing.T) {
+	manager, _ := newTestManager(t, ManagerConfig{})
+	_, err := manager.Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, manager.AuthorizeSpectator(ctx, "viewer", Channel("m-1")))
+	assert.ErrorIs(t, manager.AuthorizeSpectator(ctx, "viewer", SpectatorChannel("m-1")), ErrNotSpectating)
+	assert.ErrorIs(t, manager.AuthorizeSpectator(ctx, "viewer", SpectatorChannel("m-9")), ErrRoomNotFound)
+
+	_, err = manager.JoinSpectator(ctx, "m-1", "viewer")
+	require.NoError(t, err)
+	assert.NoError(t, manager.AuthorizeSpectator(ctx, "viewer", SpectatorChannel("m-1")))
+}
+
+func TestMatchApp_SpectatorRoutes(t *testing.T) {
+	// Arrange
+	app, err := NewMatchApp(Config{
+		Broadcaster: newRecordingBroadcaster(),
+		Manager:     ManagerConfig{Rules: testRules(), TickRate: 200, SpectatorDelay: time.Hour},
+		Identify:    func(r *http.Request) string { return r.Header.Get("X-Player") },
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { app.Stop(context.Background()) })
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	_, err = app.Rooms().Create("m-1", []string{"alice"})
+	require.NoError(t, err)
+
+	do := func(method, target, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("X-Player", user)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Act & Assert
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/match/rooms?match_id=m-1", "viewer").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/match/spectators/state?match_id=m-1", "viewer").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/match/spectators/join?match_id=m-1", "alice").Code)
+
+	rec := do(http.MethodPost, "/match/spectators/join?match_id=m-1", "viewer")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var ticket SpectatorTicket
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ticket))
+	assert.Equal(t, "spectate:m-1", ticket.Channel)
+	assert.Equal(t, time.Hour, ticket.Delay)
+
+	rec = do(http.MethodGet, "/match/spectators/state?match_id=m-1", "viewer")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var state MatchState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Equal(t, "m-1", state.MatchID)
+	assert.Empty(t, state.Status) // 한 시간 지연
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/match/spectators/leave?match_id=m-1", "viewer").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/match/spectators/leave?match_id=m-1", "viewer").Code)
+}
@@ -0,0 +1,136 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Verdict 검열 결과
+type Verdict int
+
+const (
+	VerdictAllow  Verdict = iota // 그대로 통과
+	VerdictFlag                  // 통과시키되 운영자 검토 대기열에 올림
+	VerdictReject                // 차단
+)
+
+// String 검열 결과의 문자열 표현
+func (v Verdict) String() string {
+	switch v {
+	case VerdictAllow:
+		return "Allow"
+	case VerdictFlag:
+		return "Flag"
+	case VerdictReject:
+		return "Reject"
+	default:
+		return "Unknown"
+	}
+}
+
+// Content 검열할 사용자 작성 텍스트
+type Content struct {
+	ScopeID  string // 텍스트가 속한 범위 (예: 길드 ID)
+	AuthorID string // 작성자
+	Kind     string // 텍스트 종류 (예: GuildName, ChatMessage)
+	Text     string
+}
+
+// Result 필터의 판정
+type Result struct {
+	Verdict Verdict
+	Reasons []string
+}
+
+// Filter 사용자 작성 텍스트를 받아들이기 전에 검사합니다
+// 외부 서비스를 호출하는 구현이 있으므로 Review는 컨텍스트를 받습니다
+type Filter interface {
+	Review(ctx context.Context, content Content) (Result, error)
+}
+
+// RejectedError 필터가 텍스트를 차단했을 때 반환하는 에러
+type RejectedError struct {
+	Kind    string
+	Reasons []string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("%s rejected by moderation: %s", e.Kind, strings.Join(e.Reasons, ", "))
+}
+
+// Check 필터를 실행하고 차단된 텍스트는 *RejectedError로 반환합니다
+// nil 필터는 모든 텍스트를 통과시킵니다
+func Check(ctx context.Context, filter Filter, content Content) (Result, error) {
+	if filter == nil {
+		return Result{Verdict: VerdictAllow}, nil
+	}
+
+	result, err := filter.Review(ctx, content)
+	if err != nil {
+		return result, fmt.Errorf("failed to moderate %s: %w", content.Kind, err)
+	}
+	if result.Verdict == VerdictReject {
+		return result, &RejectedError{Kind: content.Kind, Reasons: result.Reasons}
+	}
+	return result, nil
+}
+
+// WordListFilter 기본 필터: 금지어는 차단하고 주의어는 통과시키되 검토 대상으로 표시합니다
+// 대소문자를 구분하지 않고 단어 단위로 비교합니다
+type WordListFilter struct {
+	blocked map[string]struct{}
+	flagged map[string]struct{}
+}
+
+// NewWordListFilter 새로운 WordListFilter를 생성합니다
+func NewWordListFilter(blocked, flagged []string) *WordListFilter {
+	return &WordListFilter{
+		blocked: toWordSet(blocked),
+		flagged: toWordSet(flagged),
+	}
+}
+
+// Review 텍스트를 단어 목록과 비교합니다
+func (f *WordListFilter) Review(ctx context.Context, content Content) (Result, error) {
+	result := Result{Verdict: VerdictAllow}
+
+	seen := make(map[string]bool)
+	for _, word := range splitWords(content.Text) {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		if _, blocked := f.blocked[word]; blocked {
+			result.Verdict = VerdictReject
+			result.Reasons = append(result.Reasons, fmt.Sprintf("blocked word: %s", word))
+			continue
+		}
+		if _, flagged := f.flagged[word]; flagged {
+			if result.Verdict < VerdictFlag {
+				result.Verdict = VerdictFlag
+			}
+			result.Reasons = append(result.Reasons, fmt.Sprintf("flagged word: %s", word))
+		}
+	}
+
+	return result, nil
+}
+
+func toWordSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			set[word] = struct{}{}
+		}
+	}
+	return set
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
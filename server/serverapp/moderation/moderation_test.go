@@ -0,0 +1,145 @@
+package moderation
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingFilter 항상 에러를 반환하는 필터 (외부 서비스 장애)
+type failingFilter struct{}
+
+func (failingFilter) Review(ctx context.Context, content Content) (Result, error) {
+	return Result{}, errors.New("service unavailable")
+}
+
+func TestWordListFilter_Review(t *testing.T) {
+	filter := NewWordListFilter([]string{"Cheat", " exploit "}, []string{"trade", ""})
+
+	tests := []struct {
+		name    string
+		text    string
+		verdict Verdict
+		reasons []string
+	}{
+		{"깨끗한 텍스트", "Raid tonight at 8", VerdictAllow, nil},
+		{"주의어는 표시", "Want to TRADE gems?", VerdictFlag, []string{"flagged word: trade"}},
+		{"금지어는 차단", "free cheat codes", VerdictReject, []string{"blocked word: cheat"}},
+		{"차단이 표시보다 우선", "trade this exploit", VerdictReject, []string{"flagged word: trade", "blocked word: exploit"}},
+		{"단어 단위로만 비교", "cheater trades", VerdictAllow, nil},
+		{"반복된 단어는 한 번만", "trade, trade... trade!", VerdictFlag, []string{"flagged word: trade"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := filter.Review(context.Background(), Content{Kind: "ChatMessage", Text: tt.text})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.verdict, result.Verdict)
+			assert.Equal(t, tt.reasons, result.Reasons)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	filter := NewWordListFilter([]string{"cheat"}, []string{"trade"})
+
+	// Act
+	allowed, allowErr := Check(ctx, nil, Content{Kind: "GuildName", Text: "cheat"})
+	flagged, flagErr := Check(ctx, filter, Content{Kind: "ChatMessage", Text: "trade?"})
+	_, rejectErr := Check(ctx, filter, Content{Kind: "GuildNotice", Text: "cheat"})
+	_, failErr := Check(ctx, failingFilter{}, Content{Kind: "ChatMessage", Text: "hi"})
+
+	// Assert
+	require.NoError(t, allowErr)
+	assert.Equal(t, VerdictAllow, allowed.Verdict) // nil 필터는 모두 통과
+	require.NoError(t, flagErr)
+	assert.Equal(t, VerdictFlag, flagged.Verdict)
+
+	var rejected *RejectedError
+	require.ErrorAs(t, rejectErr, &rejected)
+	assert.Equal(t, "GuildNotice", rejected.Kind)
+	assert.Equal(t, []string{"blocked word: cheat"}, rejected.Reasons)
+
+	require.Error(t, failErr)
+	assert.False(t, errors.As(failErr, &rejected))
+	assert.Contains(t, failErr.Error(), "service unavailable")
+}
+
+func TestReviewQueue_PendingAndResolve(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := cqrs.NewInMemoryReadStore()
+	queue := NewReviewQueue(ReviewQueueConfig{ReadStore: store, Now: func() time.Time { return now }})
+
+	items := []*ReviewItem{
+		NewReviewItem("item-3", "msg-3", Content{ScopeID: "guild-1", AuthorID: "u1", Kind: "ChatMessage", Text: "sell"}, []string{"flagged word: sell"}, now.Add(-time.Minute)),
+		NewReviewItem("item-1", "notice", Content{ScopeID: "guild-1", AuthorID: "u2", Kind: "GuildNotice", Text: "trade"}, []string{"flagged word: trade"}, now.Add(-time.Hour)),
+		NewReviewItem("item-2", "msg-2", Content{ScopeID: "guild-2", AuthorID: "u3", Kind: "ChatMessage", Text: "trade"}, []string{"flagged word: trade"}, now.Add(-30*time.Minute)),
+	}
+	for _, item := range items {
+		require.NoError(t, queue.Enqueue(ctx, item))
+	}
+
+	// Act
+	all, err := queue.Pending(ctx, "", 0)
+	require.NoError(t, err)
+	guild1, err := queue.Pending(ctx, "guild-1", 1)
+	require.NoError(t, err)
+	require.NoError(t, queue.Approve(ctx, "item-1", "mod-1"))
+	require.NoError(t, queue.Remove(ctx, "item-2", "mod-1"))
+	remaining, err := queue.Pending(ctx, "", 0)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"item-1", "item-2", "item-3"}, []string{all[0].GetID(), all[1].GetID(), all[2].GetID()}) // 오래된 순
+	require.Len(t, guild1, 1)
+	assert.Equal(t, "item-1", guild1[0].GetID())
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "item-3", remaining[0].GetID())
+
+	approved, err := cqrs.LoadReadModel[*ReviewItem](ctx, store, "item-1", ReviewItemModelType)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewStatusApproved, approved.Status)
+	assert.Equal(t, "mod-1", approved.ReviewedBy)
+	assert.Equal(t, now, approved.ReviewedAt)
+
+	removed, err := cqrs.LoadReadModel[*ReviewItem](ctx, store, "item-2", ReviewItemModelType)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewStatusRemoved, removed.Status)
+}
+
+func TestReviewQueue_ResolveTwiceAndRedelivery(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	queue := NewReviewQueue(ReviewQueueConfig{ReadStore: store})
+	content := Content{ScopeID: "guild-1", AuthorID: "u1", Kind: "ChatMessage", Text: "trade"}
+	require.NoError(t, queue.Enqueue(ctx, NewReviewItem("item-1", "msg-1", content, nil, time.Now())))
+	require.NoError(t, queue.Approve(ctx, "item-1", "mod-1"))
+
+	// Act
+	resolveErr := queue.Remove(ctx, "item-1", "mod-2")
+	enqueueErr := queue.Enqueue(ctx, NewReviewItem("item-1", "msg-1", content, nil, time.Now())) // 이벤트 재전달
+	missingErr := queue.Approve(ctx, "missing", "mod-1")
+
+	// Assert
+	assert.ErrorIs(t, resolveErr, ErrAlreadyResolved)
+	require.NoError(t, enqueueErr)
+	item, err := cqrs.LoadReadModel[*ReviewItem](ctx, store, "item-1", ReviewItemModelType)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewStatusApproved, item.Status) // 재전달이 처리 결과를 덮어쓰지 않음
+	assert.Equal(t, "mod-1", item.ReviewedBy)
+	assert.Error(t, missingErr)
+}
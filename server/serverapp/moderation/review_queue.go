@@ -0,0 +1,171 @@
+package moderation
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReviewItemModelType 검토 항목 읽기 모델 타입
+const ReviewItemModelType = "ModerationReviewItem"
+
+// 검토 상태
+const (
+	ReviewStatusPending  = "Pending"  // 운영자 검토 대기
+	ReviewStatusApproved = "Approved" // 검토 후 유지
+	ReviewStatusRemoved  = "Removed"  // 검토 후 삭제
+)
+
+// ErrAlreadyResolved 이미 처리된 검토 항목을 다시 처리하려 함
+var ErrAlreadyResolved = errors.New("review item already resolved")
+
+// ReviewItem 운영자 검토를 기다리는 표시된 텍스트
+type ReviewItem struct {
+	*cqrs.BaseReadModel
+	ScopeID    string    `json:"scope_id"`
+	ContentKey string    `json:"content_key"` // 범위 안에서 텍스트를 가리키는 키 (예: 채팅 메시지 ID, 필드 이름)
+	Kind       string    `json:"kind"`
+	AuthorID   string    `json:"author_id"`
+	Text       string    `json:"text"`
+	Reasons    []string  `json:"reasons"`
+	Status     string    `json:"status"`
+	FlaggedAt  time.Time `json:"flagged_at"`
+
+	// 처리 정보
+	ReviewedBy string    `json:"reviewed_by,omitempty"`
+	ReviewedAt time.Time `json:"reviewed_at,omitempty"`
+}
+
+// NewReviewItem 대기 상태의 검토 항목을 생성합니다
+func NewReviewItem(id, contentKey string, content Content, reasons []string, flaggedAt time.Time) *ReviewItem {
+	return &ReviewItem{
+		BaseReadModel: cqrs.NewBaseReadModel(id, ReviewItemModelType, map[string]interface{}{}),
+		ScopeID:       content.ScopeID,
+		ContentKey:    contentKey,
+		Kind:          content.Kind,
+		AuthorID:      content.AuthorID,
+		Text:          content.Text,
+		Reasons:       append(make([]string, 0, len(reasons)), reasons...),
+		Status:        ReviewStatusPending,
+		FlaggedAt:     flaggedAt,
+	}
+}
+
+// GetData 직렬화용 데이터
+func (item *ReviewItem) GetData() interface{} {
+	return map[string]interface{}{
+		"scope_id":    item.ScopeID,
+		"content_key": item.ContentKey,
+		"kind":        item.Kind,
+		"author_id":   item.AuthorID,
+		"text":        item.Text,
+		"reasons":     item.Reasons,
+		"status":      item.Status,
+		"flagged_at":  item.FlaggedAt,
+		"reviewed_by": item.ReviewedBy,
+		"reviewed_at": item.ReviewedAt,
+	}
+}
+
+// IsPending 아직 운영자가 처리하지 않았는지 여부
+func (item *ReviewItem) IsPending() bool {
+	return item.Status == ReviewStatusPending
+}
+
+// ReviewQueueConfig 검토 대기열 설정
+type ReviewQueueConfig struct {
+	ReadStore cqrs.ReadStore   // 검토 항목 저장소
+	Now       func() time.Time // 테스트용 시계 (기본값: time.Now)
+}
+
+// ReviewQueue 표시된 텍스트의 검토 대기열
+// 명령 처리와 분리된 프로젝션이 항목을 추가하므로, 표시된 텍스트는 플레이어에게 바로 보이고
+// 프로젝션이 따라잡은 뒤 운영자에게 도착합니다
+type ReviewQueue struct {
+	readStore cqrs.ReadStore
+	now       func() time.Time
+}
+
+// NewReviewQueue 새로운 ReviewQueue를 생성합니다
+func NewReviewQueue(config ReviewQueueConfig) *ReviewQueue {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &ReviewQueue{readStore: config.ReadStore, now: config.Now}
+}
+
+// Enqueue 검토 항목을 추가합니다
+// 같은 ID의 항목이 이미 있으면 (이벤트 재전달) 그대로 둡니다
+func (q *ReviewQueue) Enqueue(ctx context.Context, item *ReviewItem) error {
+	if _, err := q.readStore.GetByID(ctx, item.GetID(), ReviewItemModelType); err == nil {
+		return nil
+	} else if !cqrs.IsNotFoundError(err) {
+		return fmt.Errorf("failed to load review item: %w", err)
+	}
+	return q.readStore.Save(ctx, item)
+}
+
+// Pending 대기 중인 항목을 오래된 순으로 반환합니다 (scopeID가 비어 있으면 전체 범위)
+func (q *ReviewQueue) Pending(ctx context.Context, scopeID string, limit int) ([]*ReviewItem, error) {
+	readModels, err := q.readStore.Query(ctx, cqrs.QueryCriteria{
+		Filters: map[string]interface{}{"type": ReviewItemModelType},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review items: %w", err)
+	}
+
+	items := make([]*ReviewItem, 0)
+	for _, readModel := range readModels {
+		item, ok := readModel.(*ReviewItem)
+		if !ok || !item.IsPending() {
+			continue
+		}
+		if scopeID != "" && item.ScopeID != scopeID {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].FlaggedAt.Equal(items[j].FlaggedAt) {
+			return items[i].FlaggedAt.Before(items[j].FlaggedAt)
+		}
+		return items[i].GetID() < items[j].GetID()
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, nil
+}
+
+// Approve 검토 후 텍스트를 유지합니다
+func (q *ReviewQueue) Approve(ctx context.Context, itemID, reviewerID string) error {
+	return q.resolve(ctx, itemID, reviewerID, ReviewStatusApproved)
+}
+
+// Remove 검토 후 텍스트를 삭제 처리합니다
+func (q *ReviewQueue) Remove(ctx context.Context, itemID, reviewerID string) error {
+	return q.resolve(ctx, itemID, reviewerID, ReviewStatusRemoved)
+}
+
+func (q *ReviewQueue) resolve(ctx context.Context, itemID, reviewerID, status string) error {
+	item, err := cqrs.LoadReadModel[*ReviewItem](ctx, q.readStore, itemID, ReviewItemModelType)
+	if err != nil {
+		return fmt.Errorf("failed to load review item: %w", err)
+	}
+
+	if !item.IsPending() {
+		return fmt.Errorf("%w: %s was resolved as %s", ErrAlreadyResolved, itemID, item.Status)
+	}
+
+	item.Status = status
+	item.ReviewedBy = reviewerID
+	item.ReviewedAt = q.now()
+	item.IncrementVersion()
+
+	return q.readStore.Save(ctx, item)
+}
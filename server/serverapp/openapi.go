@@ -0,0 +1,360 @@
+package serverapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpenAPIPath 생성된 OpenAPI 문서를 제공하는 경로
+const OpenAPIPath = "/openapi.json"
+
+// APIParameter 경로/쿼리/헤더 파라미터 설명
+type APIParameter struct {
+	Name        string
+	In          string // "query", "path", "header"
+	Type        string // JSON 스키마 타입 (기본값: string)
+	Description string
+	Required    bool
+}
+
+// APIOperation 서버앱이 제공하는 HTTP 엔드포인트 하나의 설명
+// Request/Response에는 본문의 샘플 값(구조체, 명령, 쿼리 등)을 넣으면 json 태그로 스키마가 생성됩니다
+type APIOperation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string // 비우면 서버앱 이름을 사용
+	Parameters  []APIParameter
+	Request     interface{} // 요청 본문 샘플 (nil이면 본문 없음)
+	Response    interface{} // 200 응답 본문 샘플 (nil이면 스키마 없음)
+	Secured     bool        // Bearer 토큰 인증 필요 여부
+}
+
+// APIDescriber 라우트 설명을 OpenAPI 문서에 기여하는 서버앱이 구현합니다
+// Manager.Register 시점에 수집되어 /openapi.json에 반영됩니다
+type APIDescriber interface {
+	DescribeAPI() []APIOperation
+}
+
+// OpenAPIGenerator 서버앱들의 API 설명을 모아 OpenAPI 3 문서를 생성합니다
+type OpenAPIGenerator struct {
+	mu          sync.RWMutex
+	title       string
+	version     string
+	description string
+	operations  []APIOperation
+}
+
+// NewOpenAPIGenerator 새로운 OpenAPIGenerator를 생성합니다
+func NewOpenAPIGenerator(title, version string) *OpenAPIGenerator {
+	return &OpenAPIGenerator{title: title, version: version}
+}
+
+// SetInfo 문서의 info 항목을 설정합니다
+func (g *OpenAPIGenerator) SetInfo(title, version, description string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.title, g.version, g.description = title, version, description
+}
+
+// Add 서버앱의 API 설명을 추가합니다 (태그가 없으면 tag 사용)
+func (g *OpenAPIGenerator) Add(tag string, operations ...APIOperation) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, operation := range operations {
+		if len(operation.Tags) == 0 && tag != "" {
+			operation.Tags = []string{tag}
+		}
+		g.operations = append(g.operations, operation)
+	}
+}
+
+// Document OpenAPI 3 문서를 생성합니다
+func (g *OpenAPIGenerator) Document() map[string]interface{} {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	schemas := newSchemaRegistry()
+	paths := make(map[string]interface{})
+	secured := false
+
+	for _, operation := range g.operations {
+		item, exists := paths[operation.Path].(map[string]interface{})
+		if !exists {
+			item = make(map[string]interface{})
+			paths[operation.Path] = item
+		}
+
+		op := map[string]interface{}{
+			"operationId": operationID(operation),
+			"responses":   operationResponses(operation, schemas),
+		}
+		if operation.Summary != "" {
+			op["summary"] = operation.Summary
+		}
+		if operation.Description != "" {
+			op["description"] = operation.Description
+		}
+		if len(operation.Tags) > 0 {
+			op["tags"] = operation.Tags
+		}
+		if len(operation.Parameters) > 0 {
+			op["parameters"] = operationParameters(operation.Parameters)
+		}
+		if operation.Request != nil {
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(operation.Request))},
+				},
+			}
+		}
+		if operation.Secured {
+			secured = true
+			op["security"] = []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+		}
+
+		item[strings.ToLower(operation.Method)] = op
+	}
+
+	info := map[string]interface{}{"title": g.title, "version": g.version}
+	if g.description != "" {
+		info["description"] = g.description
+	}
+
+	components := map[string]interface{}{"schemas": schemas.definitions}
+	if secured {
+		components["securitySchemes"] = map[string]interface{}{
+			"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       info,
+		"paths":      paths,
+		"components": components,
+	}
+}
+
+// ServeHTTP 생성된 문서를 JSON으로 제공합니다
+func (g *OpenAPIGenerator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g.Document())
+}
+
+func operationID(operation APIOperation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(operation.Method))
+	upper := true
+	for _, c := range operation.Path {
+		switch {
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9':
+			if upper && c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b.WriteRune(c)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+func operationParameters(parameters []APIParameter) []interface{} {
+	result := make([]interface{}, 0, len(parameters))
+	for _, parameter := range parameters {
+		paramType := parameter.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		param := map[string]interface{}{
+			"name":     parameter.Name,
+			"in":       parameter.In,
+			"required": parameter.Required || parameter.In == "path",
+			"schema":   map[string]interface{}{"type": paramType},
+		}
+		if parameter.Description != "" {
+			param["description"] = parameter.Description
+		}
+		result = append(result, param)
+	}
+	return result
+}
+
+func operationResponses(operation APIOperation, schemas *schemaRegistry) map[string]interface{} {
+	ok := map[string]interface{}{"description": "OK"}
+	if operation.Response != nil {
+		ok["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(operation.Response))},
+		}
+	}
+
+	responses := map[string]interface{}{"200": ok}
+	if operation.Request != nil || len(operation.Parameters) > 0 {
+		responses["400"] = map[string]interface{}{"description": "Bad Request"}
+	}
+	if operation.Secured {
+		responses["401"] = map[string]interface{}{"description": "Unauthorized"}
+	}
+	return responses
+}
+
+// schemaRegistry 구조체 타입을 components/schemas에 한 번씩 등록합니다
+type schemaRegistry struct {
+	definitions map[string]interface{}
+	names       map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		definitions: make(map[string]interface{}),
+		names:       make(map[reflect.Type]string),
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor Go 타입의 JSON 스키마를 반환합니다 (이름 있는 구조체는 $ref)
+func (s *schemaRegistry) schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + s.register(t)}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// register 구조체 스키마를 등록하고 이름을 반환합니다 (재귀 타입 안전)
+func (s *schemaRegistry) register(t reflect.Type) string {
+	if name, exists := s.names[t]; exists {
+		return name
+	}
+
+	name := schemaName(t)
+	if _, taken := s.definitions[name]; taken {
+		// 다른 패키지의 같은 이름 타입은 패키지 이름을 붙여 구분
+		pkg := t.PkgPath()
+		name = schemaName(t) + "_" + strings.ReplaceAll(pkg[strings.LastIndex(pkg, "/")+1:], ".", "_")
+	}
+
+	s.names[t] = name
+	s.definitions[name] = map[string]interface{}{} // 재귀 참조용 자리 표시
+	s.definitions[name] = s.structSchema(t)
+	return name
+}
+
+// structSchema json 태그 기준으로 구조체 스키마를 만듭니다 (omitempty가 아닌 필드는 required)
+func (s *schemaRegistry) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	s.collectFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (s *schemaRegistry) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// 태그 없는 임베디드 구조체는 필드를 펼칩니다 (encoding/json과 동일)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			s.collectFields(fieldType, properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if _, exists := properties[name]; exists {
+			continue
+		}
+		properties[name] = s.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// schemaName 타입 이름을 스키마 이름으로 변환합니다
+// 제네릭 타입은 타입 인자의 이름을 붙입니다 (예: Envelope[pkg.Entry] -> Envelope_Entry)
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	base, args, generic := strings.Cut(name, "[")
+	if !generic {
+		return name
+	}
+
+	parts := []string{base}
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		arg = strings.TrimSpace(arg)
+		list := strings.HasPrefix(arg, "[]")
+		arg = strings.TrimLeft(arg, "[]*")
+		arg = arg[strings.LastIndex(arg, ".")+1:]
+		if list {
+			arg += "List"
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,120 @@
+package serverapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBaseCommand 내보내지 않는 필드만 가진 임베디드 명령 베이스
+type testBaseCommand struct {
+	commandID string
+}
+
+// testCreateGuildCommand 명령 페이로드 스키마 테스트용 명령
+type testCreateGuildCommand struct {
+	testBaseCommand
+	Name      string    `json:"name"`
+	Tag       string    `json:"tag,omitempty"`
+	MaxMember *int      `json:"max_members"`
+	Founded   time.Time `json:"founded_at"`
+}
+
+type testEntry struct {
+	ID    string         `json:"id"`
+	Score int64          `json:"score"`
+	Next  *testEntry     `json:"next,omitempty"`
+	Tags  []string       `json:"tags"`
+	Extra map[string]int `json:"extra,omitempty"`
+}
+
+type testEnvelope[T any] struct {
+	Success bool `json:"success"`
+	Data    T    `json:"data"`
+}
+
+// describedApp API 설명을 제공하는 테스트용 서버앱
+type describedApp struct {
+	*BaseApp
+}
+
+func (a *describedApp) DescribeAPI() []APIOperation {
+	return []APIOperation{
+		{Method: http.MethodPost, Path: "/api/v1/guilds", Summary: "create guild", Request: testCreateGuildCommand{}, Response: testEnvelope[testEntry]{}, Secured: true},
+		{
+			Method:     http.MethodGet,
+			Path:       "/api/v1/guilds/leaderboard",
+			Parameters: []APIParameter{{Name: "limit", In: "query", Type: "integer"}},
+			Response:   testEnvelope[[]testEntry]{},
+		},
+	}
+}
+
+func fetchOpenAPI(t *testing.T, manager *Manager) map[string]interface{} {
+	rec := httptest.NewRecorder()
+	manager.GetMux().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	return doc
+}
+
+func TestManager_ServesOpenAPIFromDescribedApps(t *testing.T) {
+	// Arrange
+	manager := NewManager()
+	require.NoError(t, manager.Register(&describedApp{BaseApp: NewBaseApp("guild")}))
+	require.NoError(t, manager.Register(NewBaseApp("plain")))
+
+	// Act
+	doc := fetchOpenAPI(t, manager)
+
+	// Assert
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	paths := doc["paths"].(map[string]interface{})
+	require.Len(t, paths, 2)
+
+	create := paths["/api/v1/guilds"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"guild"}, create["tags"])
+	assert.Equal(t, "postApiV1Guilds", create["operationId"])
+	assert.NotNil(t, create["security"])
+	requestSchema := create["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"]
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/testCreateGuildCommand"}, requestSchema)
+
+	leaderboard := paths["/api/v1/guilds/leaderboard"].(map[string]interface{})["get"].(map[string]interface{})
+	params := leaderboard["parameters"].([]interface{})
+	assert.Equal(t, "limit", params[0].(map[string]interface{})["name"])
+
+	components := doc["components"].(map[string]interface{})
+	assert.Contains(t, components, "securitySchemes")
+	schemas := components["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "testEnvelope_testEntry")
+	assert.Contains(t, schemas, "testEnvelope_testEntryList")
+}
+
+func TestOpenAPIGenerator_CommandPayloadSchema(t *testing.T) {
+	// Arrange
+	generator := NewOpenAPIGenerator("test", "1.0.0")
+	generator.Add("guild", APIOperation{Method: http.MethodPost, Path: "/guilds", Request: &testCreateGuildCommand{}, Response: testEntry{}})
+
+	// Act
+	schemas := generator.Document()["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	// Assert
+	command := schemas["testCreateGuildCommand"].(map[string]interface{})
+	properties := command["properties"].(map[string]interface{})
+	assert.Len(t, properties, 4) // 임베디드 베이스의 비공개 필드는 제외
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["founded_at"])
+	assert.Equal(t, []string{"founded_at", "name"}, command["required"])
+
+	entry := schemas["testEntry"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/testEntry"}, entry["next"]) // 재귀 타입
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int64"}, entry["score"])
+	assert.Equal(t, "array", entry["tags"].(map[string]interface{})["type"])
+	assert.Equal(t, "object", entry["extra"].(map[string]interface{})["type"])
+}
@@ -0,0 +1,405 @@
+package party
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// PartyAggregateType 파티 애그리게이트 타입 (애그리게이트 ID는 파티 ID)
+const PartyAggregateType = "Party"
+
+// 파티 이벤트 타입
+const (
+	PartyCreatedEventType           = "PartyCreated"
+	PartyMemberInvitedEventType     = "PartyMemberInvited"
+	PartyMemberJoinedEventType      = "PartyMemberJoined"
+	PartyMemberLeftEventType        = "PartyMemberLeft"
+	PartyReadyCheckStartedEventType = "PartyReadyCheckStarted"
+	PartyMemberReadiedEventType     = "PartyMemberReadied"
+	PartyDisbandedEventType         = "PartyDisbanded"
+
+	// PartyReadyEventType 레디 체크를 모두 통과한 파티 (통합 이벤트, 이벤트 버스로 발행)
+	// 매치메이킹이 구독해 파티원 전체를 한 팀으로 대기열에 넣습니다
+	PartyReadyEventType = "PartyReady"
+)
+
+var (
+	ErrInvalidCommand       = errors.New("invalid party command")
+	ErrNotLeader            = errors.New("only the party leader can do this")
+	ErrNotMember            = errors.New("user is not a party member")
+	ErrPartyFull            = errors.New("party is full")
+	ErrNotInvited           = errors.New("user has no pending party invitation")
+	ErrPartyDisbanded       = errors.New("party is disbanded")
+	ErrNoReadyCheck         = errors.New("no ready check in progress")
+	ErrReadyCheckExpired    = errors.New("ready check expired")
+	ErrReadyCheckInProgress = errors.New("ready check already in progress")
+)
+
+// DefaultMaxSize 기본 최대 파티 인원
+const DefaultMaxSize = 4
+
+// ReadyCheck 진행 중인 레디 체크
+type ReadyCheck struct {
+	StartedAt time.Time       `json:"started_at"`
+	Deadline  time.Time       `json:"deadline"`
+	Ready     map[string]bool `json:"ready"` // 준비 완료한 파티원
+}
+
+// Expired now 기준으로 마감되었는지 확인합니다
+func (c ReadyCheck) Expired(now time.Time) bool {
+	return !now.Before(c.Deadline)
+}
+
+// 파티 이벤트 데이터
+type (
+	PartyCreatedData struct {
+		LeaderID  string    `json:"leader_id"`
+		MaxSize   int       `json:"max_size"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	PartyMemberInvitedData struct {
+		InviteeID string    `json:"invitee_id"`
+		InvitedBy string    `json:"invited_by"`
+		InvitedAt time.Time `json:"invited_at"`
+	}
+	PartyMemberJoinedData struct {
+		UserID   string    `json:"user_id"`
+		JoinedAt time.Time `json:"joined_at"`
+	}
+	PartyMemberLeftData struct {
+		UserID      string    `json:"user_id"`
+		NewLeaderID string    `json:"new_leader_id,omitempty"` // 리더가 나가면 다음 파티원이 리더가 됨
+		LeftAt      time.Time `json:"left_at"`
+	}
+	PartyReadyCheckStartedData struct {
+		StartedBy string    `json:"started_by"`
+		StartedAt time.Time `json:"started_at"`
+		Deadline  time.Time `json:"deadline"`
+	}
+	PartyMemberReadiedData struct {
+		UserID    string    `json:"user_id"`
+		ReadiedAt time.Time `json:"readied_at"`
+	}
+	PartyReadyData struct {
+		PartyID  string    `json:"party_id"`
+		LeaderID string    `json:"leader_id"`
+		Members  []string  `json:"members"` // 합류 순서, 첫 번째가 리더
+		ReadyAt  time.Time `json:"ready_at"`
+	}
+	PartyDisbandedData struct {
+		DisbandedBy string    `json:"disbanded_by"`
+		DisbandedAt time.Time `json:"disbanded_at"`
+	}
+)
+
+// PartyEvent 파티 애그리게이트 이벤트
+type PartyEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *PartyEvent) EventData() interface{} {
+	return e.data
+}
+
+// PartyAggregate 함께 대기열에 들어가는 소규모 파티 (스쿼드)
+// 파티원 변경은 진행 중인 레디 체크를 취소합니다
+type PartyAggregate struct {
+	*cqrs.BaseAggregate
+	leaderID   string
+	maxSize    int
+	members    []string // 합류 순서
+	invites    map[string]bool
+	readyCheck *ReadyCheck
+	disbanded  bool
+}
+
+// NewPartyAggregate 새로운 PartyAggregate를 생성합니다
+func NewPartyAggregate(partyID string) *PartyAggregate {
+	return &PartyAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(partyID, PartyAggregateType),
+		invites:       make(map[string]bool),
+	}
+}
+
+// Exists 파티가 만들어졌는지 확인합니다
+func (a *PartyAggregate) Exists() bool {
+	return a.leaderID != "" || a.disbanded
+}
+
+// LeaderID 파티 리더
+func (a *PartyAggregate) LeaderID() string {
+	return a.leaderID
+}
+
+// Members 파티원 목록 (합류 순서)
+func (a *PartyAggregate) Members() []string {
+	return append([]string(nil), a.members...)
+}
+
+// IsMember 파티원인지 확인합니다
+func (a *PartyAggregate) IsMember(userID string) bool {
+	for _, member := range a.members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Invited 대기 중인 초대가 있는지 확인합니다
+func (a *PartyAggregate) Invited(userID string) bool {
+	return a.invites[userID]
+}
+
+// Disbanded 해산되었는지 확인합니다
+func (a *PartyAggregate) Disbanded() bool {
+	return a.disbanded
+}
+
+// ReadyCheck 진행 중인 레디 체크
+func (a *PartyAggregate) ReadyCheck() (ReadyCheck, bool) {
+	if a.readyCheck == nil {
+		return ReadyCheck{}, false
+	}
+	return *a.readyCheck, true
+}
+
+// Create 파티를 만듭니다 (만든 사용자가 리더)
+func (a *PartyAggregate) Create(leaderID string, maxSize int, now time.Time) error {
+	if a.Exists() {
+		return fmt.Errorf("%w: party already exists", ErrInvalidCommand)
+	}
+	if leaderID == "" {
+		return fmt.Errorf("%w: leader is required", ErrInvalidCommand)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxSize < 2 {
+		return fmt.Errorf("%w: party must allow at least 2 members", ErrInvalidCommand)
+	}
+	return a.raise(PartyCreatedEventType, PartyCreatedData{LeaderID: leaderID, MaxSize: maxSize, CreatedAt: now})
+}
+
+// Invite 리더가 사용자를 초대합니다
+func (a *PartyAggregate) Invite(inviterID, inviteeID string, now time.Time) error {
+	if err := a.requireLeader(inviterID); err != nil {
+		return err
+	}
+	if inviteeID == "" {
+		return fmt.Errorf("%w: invitee is required", ErrInvalidCommand)
+	}
+	if a.IsMember(inviteeID) {
+		return fmt.Errorf("%w: %s is already a member", ErrInvalidCommand, inviteeID)
+	}
+	if len(a.members) >= a.maxSize {
+		return ErrPartyFull
+	}
+	return a.raise(PartyMemberInvitedEventType, PartyMemberInvitedData{InviteeID: inviteeID, InvitedBy: inviterID, InvitedAt: now})
+}
+
+// Join 초대받은 사용자가 파티에 합류합니다
+func (a *PartyAggregate) Join(userID string, now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if !a.invites[userID] {
+		return ErrNotInvited
+	}
+	if len(a.members) >= a.maxSize {
+		return ErrPartyFull
+	}
+	return a.raise(PartyMemberJoinedEventType, PartyMemberJoinedData{UserID: userID, JoinedAt: now})
+}
+
+// Leave 파티원이 나갑니다 (마지막 파티원이 나가면 파티 해산)
+func (a *PartyAggregate) Leave(userID string, now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if !a.IsMember(userID) {
+		return ErrNotMember
+	}
+	if len(a.members) == 1 {
+		return a.raise(PartyDisbandedEventType, PartyDisbandedData{DisbandedBy: userID, DisbandedAt: now})
+	}
+	data := PartyMemberLeftData{UserID: userID, LeftAt: now}
+	if userID == a.leaderID {
+		for _, member := range a.members {
+			if member != userID {
+				data.NewLeaderID = member
+				break
+			}
+		}
+	}
+	return a.raise(PartyMemberLeftEventType, data)
+}
+
+// StartReadyCheck 리더가 레디 체크를 시작합니다 (리더는 자동으로 준비 완료)
+// 마감이 지난 레디 체크는 새 레디 체크로 대체됩니다
+func (a *PartyAggregate) StartReadyCheck(leaderID string, timeout time.Duration, now time.Time) error {
+	if err := a.requireLeader(leaderID); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("%w: ready check timeout must be positive", ErrInvalidCommand)
+	}
+	if len(a.members) < 2 {
+		return fmt.Errorf("%w: party needs at least 2 members to queue", ErrInvalidCommand)
+	}
+	if a.readyCheck != nil && !a.readyCheck.Expired(now) {
+		return ErrReadyCheckInProgress
+	}
+	if err := a.raise(PartyReadyCheckStartedEventType, PartyReadyCheckStartedData{StartedBy: leaderID, StartedAt: now, Deadline: now.Add(timeout)}); err != nil {
+		return err
+	}
+	return a.ConfirmReady(leaderID, now)
+}
+
+// ConfirmReady 파티원이 준비 완료합니다
+// 모든 파티원이 준비되면 PartyReady 이벤트가 기록되고 레디 체크가 끝납니다
+func (a *PartyAggregate) ConfirmReady(userID string, now time.Time) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if !a.IsMember(userID) {
+		return ErrNotMember
+	}
+	if a.readyCheck == nil {
+		return ErrNoReadyCheck
+	}
+	if a.readyCheck.Expired(now) {
+		return ErrReadyCheckExpired
+	}
+	if a.readyCheck.Ready[userID] {
+		return nil
+	}
+	if err := a.raise(PartyMemberReadiedEventType, PartyMemberReadiedData{UserID: userID, ReadiedAt: now}); err != nil {
+		return err
+	}
+	if len(a.readyCheck.Ready) < len(a.members) {
+		return nil
+	}
+	return a.raise(PartyReadyEventType, PartyReadyData{PartyID: a.ID(), LeaderID: a.leaderID, Members: a.Members(), ReadyAt: now})
+}
+
+// Disband 리더가 파티를 해산합니다
+func (a *PartyAggregate) Disband(leaderID string, now time.Time) error {
+	if err := a.requireLeader(leaderID); err != nil {
+		return err
+	}
+	return a.raise(PartyDisbandedEventType, PartyDisbandedData{DisbandedBy: leaderID, DisbandedAt: now})
+}
+
+func (a *PartyAggregate) requireActive() error {
+	if a.disbanded {
+		return ErrPartyDisbanded
+	}
+	if !a.Exists() {
+		return fmt.Errorf("%w: party does not exist", ErrInvalidCommand)
+	}
+	return nil
+}
+
+func (a *PartyAggregate) requireLeader(userID string) error {
+	if err := a.requireActive(); err != nil {
+		return err
+	}
+	if userID != a.leaderID {
+		return ErrNotLeader
+	}
+	return nil
+}
+
+// LoadFromHistory 이벤트 스트림에서 파티 상태를 복원합니다
+func (a *PartyAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *PartyAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := partyEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown party event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *PartyAggregate) raise(eventType string, data interface{}) error {
+	event := &PartyEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *PartyAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case PartyCreatedData:
+		a.leaderID = data.LeaderID
+		a.maxSize = data.MaxSize
+		a.members = []string{data.LeaderID}
+	case PartyMemberInvitedData:
+		a.invites[data.InviteeID] = true
+	case PartyMemberJoinedData:
+		delete(a.invites, data.UserID)
+		a.members = append(a.members, data.UserID)
+		a.readyCheck = nil
+	case PartyMemberLeftData:
+		for i, member := range a.members {
+			if member == data.UserID {
+				a.members = append(a.members[:i:i], a.members[i+1:]...)
+				break
+			}
+		}
+		if data.NewLeaderID != "" {
+			a.leaderID = data.NewLeaderID
+		}
+		a.readyCheck = nil
+	case PartyReadyCheckStartedData:
+		a.readyCheck = &ReadyCheck{StartedAt: data.StartedAt, Deadline: data.Deadline, Ready: make(map[string]bool)}
+	case PartyMemberReadiedData:
+		if a.readyCheck != nil {
+			a.readyCheck.Ready[data.UserID] = true
+		}
+	case PartyReadyData:
+		a.readyCheck = nil
+	case PartyDisbandedData:
+		a.disbanded = true
+		a.members = nil
+		a.invites = make(map[string]bool)
+		a.readyCheck = nil
+	}
+}
+
+// partyEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var partyEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	PartyCreatedEventType:           eventstore.DecodeEventData[PartyCreatedData],
+	PartyMemberInvitedEventType:     eventstore.DecodeEventData[PartyMemberInvitedData],
+	PartyMemberJoinedEventType:      eventstore.DecodeEventData[PartyMemberJoinedData],
+	PartyMemberLeftEventType:        eventstore.DecodeEventData[PartyMemberLeftData],
+	PartyReadyCheckStartedEventType: eventstore.DecodeEventData[PartyReadyCheckStartedData],
+	PartyMemberReadiedEventType:     eventstore.DecodeEventData[PartyMemberReadiedData],
+	PartyReadyEventType:             eventstore.DecodeEventData[PartyReadyData],
+	PartyDisbandedEventType:         eventstore.DecodeEventData[PartyDisbandedData],
+}
@@ -0,0 +1,134 @@
+package party
+
+import (
+	"cqrs"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// MembershipAggregateType 사용자별 파티 소속 애그리게이트 타입 (애그리게이트 ID는 MembershipID(사용자 ID))
+// "한 사용자는 한 파티에만" 규칙을 이벤트 저장소의 낙관적 동시성으로 지키기 위한 스트림입니다
+// 재시작하거나 다른 인스턴스가 명령을 처리해도 같은 스트림을 읽으므로 소속이 어긋나지 않습니다
+const MembershipAggregateType = "PartyMembership"
+
+// 파티 소속 이벤트 타입
+const (
+	PartyMembershipClaimedEventType  = "PartyMembershipClaimed"
+	PartyMembershipReleasedEventType = "PartyMembershipReleased"
+)
+
+// membershipClaimGrace 파티 이벤트가 저장되기 전의 소속 선점을 유효하게 보는 시간
+// 선점과 파티 저장 사이에 다른 인스턴스가 소속을 가로채지 못하게 하고,
+// 그 사이 프로세스가 죽어 파티에 합류하지 못한 선점은 이 시간이 지나면 풀립니다
+const membershipClaimGrace = 30 * time.Second
+
+// 파티 소속 이벤트 데이터
+type (
+	PartyMembershipClaimedData struct {
+		PartyID   string    `json:"party_id"`
+		ClaimedAt time.Time `json:"claimed_at"`
+	}
+	PartyMembershipReleasedData struct {
+		PartyID    string    `json:"party_id"`
+		ReleasedAt time.Time `json:"released_at"`
+	}
+)
+
+// MembershipID 사용자 소속 스트림의 애그리게이트 ID (파티 ID와 겹치지 않도록 접두사를 붙입니다)
+func MembershipID(userID string) string {
+	return "party-member:" + userID
+}
+
+// MembershipAggregate 사용자가 선점한 파티
+// 선점만으로는 파티원이 아니며, 파티 스트림에 합류가 기록되어야 소속이 확정됩니다
+type MembershipAggregate struct {
+	*cqrs.BaseAggregate
+	userID    string
+	partyID   string
+	claimedAt time.Time
+}
+
+// NewMembershipAggregate 새로운 MembershipAggregate를 생성합니다
+func NewMembershipAggregate(userID string) *MembershipAggregate {
+	return &MembershipAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(MembershipID(userID), MembershipAggregateType),
+		userID:        userID,
+	}
+}
+
+// PartyID 선점한 파티 ID (없으면 빈 문자열)
+func (a *MembershipAggregate) PartyID() string {
+	return a.partyID
+}
+
+// Claim 파티 소속을 선점합니다 (이미 선점한 파티면 이벤트를 남기지 않음)
+func (a *MembershipAggregate) Claim(partyID string, now time.Time) error {
+	if a.partyID == partyID {
+		return nil
+	}
+	return a.raise(PartyMembershipClaimedEventType, PartyMembershipClaimedData{PartyID: partyID, ClaimedAt: now})
+}
+
+// Release 파티 소속을 해제합니다 (partyID를 선점하고 있지 않으면 이벤트를 남기지 않음)
+func (a *MembershipAggregate) Release(partyID string, now time.Time) error {
+	if a.partyID == "" || a.partyID != partyID {
+		return nil
+	}
+	return a.raise(PartyMembershipReleasedEventType, PartyMembershipReleasedData{PartyID: partyID, ReleasedAt: now})
+}
+
+// LoadFromHistory 이벤트 스트림에서 소속 상태를 복원합니다
+func (a *MembershipAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *MembershipAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := membershipEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown party membership event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *MembershipAggregate) raise(eventType string, data interface{}) error {
+	event := &PartyEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *MembershipAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case PartyMembershipClaimedData:
+		a.partyID = data.PartyID
+		a.claimedAt = data.ClaimedAt
+	case PartyMembershipReleasedData:
+		a.partyID = ""
+		a.claimedAt = time.Time{}
+	}
+}
+
+// membershipEventDecoders 저장소에서 읽은 소속 이벤트 데이터를 값 타입으로 되돌립니다
+var membershipEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	PartyMembershipClaimedEventType:  eventstore.DecodeEventData[PartyMembershipClaimedData],
+	PartyMembershipReleasedEventType: eventstore.DecodeEventData[PartyMembershipReleasedData],
+}
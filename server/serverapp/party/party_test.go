@@ -0,0 +1,197 @@
+package party
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBroadcaster 파티 채널로 발행된 이벤트 타입을 기록합니다
+type recordingBroadcaster struct {
+	mu     sync.Mutex
+	events map[string][]string
+}
+
+func (b *recordingBroadcaster) Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.events == nil {
+		b.events = make(map[string][]string)
+	}
+	b.events[channel] = append(b.events[channel], eventType)
+	return "", nil
+}
+
+// onlineSet 접속 중인 사용자 목록
+type onlineSet map[string]bool
+
+func (s onlineSet) Online(ctx context.Context, userID string) bool {
+	return s[userID]
+}
+
+func TestPartyAggregate_Rules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	party := NewPartyAggregate("p1")
+
+	assert.ErrorIs(t, party.Join("bob", now), ErrInvalidCommand)
+	require.NoError(t, party.Create("alice", 3, now))
+	assert.ErrorIs(t, party.Invite("bob", "carol", now), ErrNotLeader)
+	assert.ErrorIs(t, party.Join("bob", now), ErrNotInvited)
+	assert.ErrorIs(t, party.StartReadyCheck("alice", time.Minute, now), ErrInvalidCommand) // 혼자서는 대기열에 들어갈 수 없음
+
+	require.NoError(t, party.Invite("alice", "bob", now))
+	require.NoError(t, party.Join("bob", now))
+	require.NoError(t, party.StartReadyCheck("alice", time.Minute, now))
+	assert.ErrorIs(t, party.StartReadyCheck("alice", time.Minute, now), ErrReadyCheckInProgress)
+
+	// 파티원이 바뀌면 레디 체크 취소
+	require.NoError(t, party.Invite("alice", "carol", now))
+	require.NoError(t, party.Join("carol", now))
+	_, inProgress := party.ReadyCheck()
+	assert.False(t, inProgress)
+	assert.ErrorIs(t, party.Invite("alice", "dave", now), ErrPartyFull)
+
+	// 리더가 나가면 다음 파티원이 리더
+	require.NoError(t, party.Leave("alice", now))
+	assert.Equal(t, "bob", party.LeaderID())
+	assert.Equal(t, []string{"bob", "carol"}, party.Members())
+
+	require.NoError(t, party.Disband("bob", now))
+	assert.True(t, party.Disbanded())
+	assert.ErrorIs(t, party.Leave("carol", now), ErrPartyDisbanded)
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewPartyAggregate("p1")
+	require.NoError(t, restored.LoadFromHistory(party.Changes()))
+	assert.True(t, restored.Disbanded())
+	assert.Equal(t, party.Version(), restored.Version())
+}
+
+func TestPartyAggregate_ReadyCheckExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	party := NewPartyAggregate("p1")
+	require.NoError(t, party.Create("alice", 0, now))
+	require.NoError(t, party.Invite("alice", "bob", now))
+	require.NoError(t, party.Join("bob", now))
+	require.NoError(t, party.StartReadyCheck("alice", 30*time.Second, now))
+
+	assert.ErrorIs(t, party.ConfirmReady("bob", now.Add(time.Minute)), ErrReadyCheckExpired)
+	require.NoError(t, party.StartReadyCheck("alice", 30*time.Second, now.Add(time.Minute)))
+	require.NoError(t, party.ConfirmReady("bob", now.Add(time.Minute)))
+
+	changes := party.Changes()
+	assert.Equal(t, PartyReadyEventType, changes[len(changes)-1].EventType())
+	_, inProgress := party.ReadyCheck()
+	assert.False(t, inProgress)
+}
+
+func TestService_ReadyCheckPublishesPartyReady(t *testing.T) {
+	// Arrange
+	broadcaster := &recordingBroadcaster{}
+	online := onlineSet{"alice": true, "bob": true}
+	service := NewService(ServiceConfig{Broadcaster: broadcaster, Presence: online})
+	ctx := context.Background()
+	require.True(t, testkit.Handle(t, service, NewCreatePartyCommand("p1", "alice", 0)).Success)
+	require.True(t, testkit.Handle(t, service, NewInviteToPartyCommand("p1", "alice", "bob")).Success)
+	require.True(t, testkit.Handle(t, service, NewJoinPartyCommand("p1", "bob")).Success)
+
+	// Act
+	started := testkit.Handle(t, service, NewStartReadyCheckCommand("p1", "alice", 0))
+	confirmed := testkit.Handle(t, service, NewConfirmReadyCommand("p1", "bob"))
+
+	// Assert
+	require.True(t, started.Success, started.Error)
+	require.True(t, confirmed.Success, confirmed.Error)
+	assert.Equal(t, PartyReadyEventType, confirmed.Events[len(confirmed.Events)-1].EventType())
+	ready, ok := confirmed.Events[len(confirmed.Events)-1].EventData().(PartyReadyData)
+	require.True(t, ok)
+	assert.Equal(t, []string{"alice", "bob"}, ready.Members)
+	assert.Contains(t, broadcaster.events[Channel("p1")], PartyReadyEventType)
+
+	history, err := service.config.Store.GetEventHistory(ctx, "p1", PartyAggregateType, 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 7) // 생성, 초대, 합류, 레디 체크 시작, 리더 준비, 파티원 준비, 파티 준비
+}
+
+func TestService_PresenceAndMembership(t *testing.T) {
+	// Arrange
+	online := onlineSet{"alice": true, "bob": true, "carol": true}
+	service := NewService(ServiceConfig{Presence: online})
+	require.True(t, testkit.Handle(t, service, NewCreatePartyCommand("p1", "alice", 0)).Success)
+	require.True(t, testkit.Handle(t, service, NewCreatePartyCommand("p2", "carol", 0)).Success)
+
+	// Act
+	offline := testkit.Handle(t, service, NewInviteToPartyCommand("p1", "alice", "dave"))
+	testkit.Handle(t, service, NewInviteToPartyCommand("p1", "alice", "carol"))
+	alreadyInParty := testkit.Handle(t, service, NewJoinPartyCommand("p1", "carol"))
+	testkit.Handle(t, service, NewInviteToPartyCommand("p1", "alice", "bob"))
+	testkit.Handle(t, service, NewJoinPartyCommand("p1", "bob"))
+	online["bob"] = false
+	readyCheck := testkit.Handle(t, service, NewStartReadyCheckCommand("p1", "alice", time.Minute))
+	testkit.Handle(t, service, NewDisbandPartyCommand("p1", "alice"))
+
+	// Assert
+	assert.ErrorIs(t, offline.Error, ErrPlayerOffline)
+	assert.ErrorIs(t, alreadyInParty.Error, ErrAlreadyInParty)
+	assert.ErrorIs(t, readyCheck.Error, ErrPlayerOffline)
+	_, bobInParty, err := service.PartyOf(context.Background(), "bob")
+	require.NoError(t, err)
+	assert.False(t, bobInParty) // 해산하면 소속 해제
+	partyID, carolInParty, err := service.PartyOf(context.Background(), "carol")
+	require.NoError(t, err)
+	assert.True(t, carolInParty)
+	assert.Equal(t, "p2", partyID)
+}
+
+func TestService_MembershipSharedAcrossInstances(t *testing.T) {
+	// Arrange: 같은 저장소를 쓰는 두 인스턴스 (재시작 또는 수평 확장)
+	store := eventstore.NewInMemoryEventStore()
+	clock := testkit.NewClock(testkit.DefaultStart)
+	first := NewService(ServiceConfig{Store: store, Now: clock.Now})
+	second := NewService(ServiceConfig{Store: store, Now: clock.Now})
+	require.True(t, testkit.Handle(t, first, NewCreatePartyCommand("p1", "alice", 0)).Success)
+	require.True(t, testkit.Handle(t, first, NewInviteToPartyCommand("p1", "alice", "bob")).Success)
+	require.True(t, testkit.Handle(t, first, NewJoinPartyCommand("p1", "bob")).Success)
+	require.True(t, testkit.Handle(t, second, NewCreatePartyCommand("p2", "carol", 0)).Success)
+	require.True(t, testkit.Handle(t, second, NewInviteToPartyCommand("p2", "carol", "bob")).Success)
+	clock.Advance(time.Hour)
+
+	// Act
+	joinedElsewhere := testkit.Handle(t, second, NewJoinPartyCommand("p2", "bob"))
+	require.True(t, testkit.Handle(t, first, NewLeavePartyCommand("p1", "bob")).Success)
+	joinedAfterLeaving := testkit.Handle(t, second, NewJoinPartyCommand("p2", "bob"))
+
+	// Assert
+	assert.ErrorIs(t, joinedElsewhere.Error, ErrAlreadyInParty)
+	assert.True(t, joinedAfterLeaving.Success, joinedAfterLeaving.Error)
+	partyID, inParty, err := first.PartyOf(context.Background(), "bob")
+	require.NoError(t, err)
+	assert.True(t, inParty)
+	assert.Equal(t, "p2", partyID)
+}
+
+func TestService_StaleMembershipClaimExpires(t *testing.T) {
+	// Arrange: 소속 선점 뒤 파티 저장 전에 프로세스가 죽은 경우
+	store := eventstore.NewInMemoryEventStore()
+	clock := testkit.NewClock(testkit.DefaultStart)
+	service := NewService(ServiceConfig{Store: store, Now: clock.Now})
+	membership := NewMembershipAggregate("bob")
+	require.NoError(t, membership.Claim("lost", clock.Now()))
+	require.NoError(t, store.SaveEvents(context.Background(), membership.ID(), membership.Changes(), 0))
+
+	// Act
+	pending := testkit.Handle(t, service, NewCreatePartyCommand("p1", "bob", 0))
+	clock.Advance(membershipClaimGrace)
+	expired := testkit.Handle(t, service, NewCreatePartyCommand("p1", "bob", 0))
+
+	// Assert
+	assert.ErrorIs(t, pending.Error, ErrAlreadyInParty)
+	assert.True(t, expired.Success, expired.Error)
+}
@@ -0,0 +1,396 @@
+package party
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 파티 명령 타입 (명령의 ID는 파티 ID, UserID는 명령을 보낸 사용자)
+const (
+	CreatePartyCommandType     = "CreateParty"
+	InviteToPartyCommandType   = "InviteToParty"
+	JoinPartyCommandType       = "JoinParty"
+	LeavePartyCommandType      = "LeaveParty"
+	StartReadyCheckCommandType = "StartReadyCheck"
+	ConfirmReadyCommandType    = "ConfirmReady"
+	DisbandPartyCommandType    = "DisbandParty"
+)
+
+var (
+	ErrPlayerOffline  = errors.New("player is offline")
+	ErrAlreadyInParty = errors.New("player is already in another party")
+)
+
+// 파티 명령 데이터
+type (
+	CreatePartyData struct {
+		MaxSize int `json:"max_size,omitempty"` // 0이면 DefaultMaxSize
+	}
+	InviteToPartyData struct {
+		InviteeID string `json:"invitee_id"`
+	}
+	StartReadyCheckData struct {
+		TimeoutSeconds int64 `json:"timeout_seconds,omitempty"` // 0이면 서비스 기본값
+	}
+)
+
+// NewCreatePartyCommand 파티 생성 명령
+func NewCreatePartyCommand(partyID, leaderID string, maxSize int) cqrs.Command {
+	return newPartyCommand(CreatePartyCommandType, partyID, leaderID, CreatePartyData{MaxSize: maxSize})
+}
+
+// NewInviteToPartyCommand 파티 초대 명령
+func NewInviteToPartyCommand(partyID, inviterID, inviteeID string) cqrs.Command {
+	return newPartyCommand(InviteToPartyCommandType, partyID, inviterID, InviteToPartyData{InviteeID: inviteeID})
+}
+
+// NewJoinPartyCommand 초대받은 파티 합류 명령
+func NewJoinPartyCommand(partyID, userID string) cqrs.Command {
+	return newPartyCommand(JoinPartyCommandType, partyID, userID, nil)
+}
+
+// NewLeavePartyCommand 파티 탈퇴 명령
+func NewLeavePartyCommand(partyID, userID string) cqrs.Command {
+	return newPartyCommand(LeavePartyCommandType, partyID, userID, nil)
+}
+
+// NewStartReadyCheckCommand 레디 체크 시작 명령 (timeout이 0이면 서비스 기본값)
+func NewStartReadyCheckCommand(partyID, leaderID string, timeout time.Duration) cqrs.Command {
+	return newPartyCommand(StartReadyCheckCommandType, partyID, leaderID, StartReadyCheckData{TimeoutSeconds: int64(timeout / time.Second)})
+}
+
+// NewConfirmReadyCommand 준비 완료 명령
+func NewConfirmReadyCommand(partyID, userID string) cqrs.Command {
+	return newPartyCommand(ConfirmReadyCommandType, partyID, userID, nil)
+}
+
+// NewDisbandPartyCommand 파티 해산 명령
+func NewDisbandPartyCommand(partyID, leaderID string) cqrs.Command {
+	return newPartyCommand(DisbandPartyCommandType, partyID, leaderID, nil)
+}
+
+func newPartyCommand(commandType, partyID, userID string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, partyID, PartyAggregateType, data)
+	command.SetUserID(userID)
+	return command
+}
+
+// Channel 파티 이벤트가 발행되는 실시간 채널 이름
+func Channel(partyID string) string {
+	return "party:" + partyID
+}
+
+// Broadcaster 파티 채널로 이벤트를 발행합니다 (realtime.RealtimeApp이 구현)
+type Broadcaster interface {
+	Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error)
+}
+
+// Presence 사용자 접속 여부를 확인합니다 (realtime.RealtimeApp이 구현)
+type Presence interface {
+	Online(ctx context.Context, userID string) bool
+}
+
+// ServiceConfig 파티 서비스 설정
+type ServiceConfig struct {
+	Store             eventstore.EventStore // 파티 이벤트 저장소 (기본값: 메모리)
+	EventBus          cqrs.EventBus         // 선택: 파티 이벤트 발행 (매치메이킹은 PartyReady를 구독)
+	Broadcaster       Broadcaster           // 선택: 파티원에게 파티 채널로 변경 전달
+	Presence          Presence              // 선택: 초대와 레디 체크 시 접속 여부 확인 (없으면 확인하지 않음)
+	MaxSize           int                   // 파티 생성 시 최대 인원 기본값 (기본값: DefaultMaxSize)
+	ReadyCheckTimeout time.Duration         // 레디 체크 기본 마감 시간 (기본값: 30s)
+	Now               func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 파티 명령을 처리합니다
+// 한 사용자는 한 파티에만 속할 수 있으며, 소속은 저장소의 사용자별 소속 스트림(MembershipAggregate)으로 지킵니다
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu sync.Mutex // 파티 변경 직렬화 (인스턴스 간 경합은 저장소의 버전 검사가 막음)
+}
+
+// NewService 새로운 Service를 생성합니다
+func NewService(config ServiceConfig) *Service {
+	if config.Store == nil {
+		config.Store = eventstore.NewInMemoryEventStore()
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultMaxSize
+	}
+	if config.ReadyCheckTimeout <= 0 {
+		config.ReadyCheckTimeout = 30 * time.Second
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("party", []string{
+			CreatePartyCommandType,
+			InviteToPartyCommandType,
+			JoinPartyCommandType,
+			LeavePartyCommandType,
+			StartReadyCheckCommandType,
+			ConfirmReadyCommandType,
+			DisbandPartyCommandType,
+		}),
+		config: config,
+	}
+}
+
+// RegisterWith 파티 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PartyOf 사용자가 속한 파티 ID (합류가 파티 스트림에 기록된 소속만)
+func (s *Service) PartyOf(ctx context.Context, userID string) (string, bool, error) {
+	membership, err := s.loadMembership(ctx, userID)
+	if err != nil || membership.PartyID() == "" {
+		return "", false, err
+	}
+	aggregate, err := s.load(ctx, membership.PartyID())
+	if err != nil {
+		return "", false, err
+	}
+	if !aggregate.IsMember(userID) {
+		return "", false, nil
+	}
+	return membership.PartyID(), true, nil
+}
+
+// Load 파티 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, partyID string) (*PartyAggregate, error) {
+	return s.load(ctx, partyID)
+}
+
+// Handle 파티 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	partyID, userID := command.ID(), command.UserID()
+	if partyID == "" || userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: party ID and user ID are required", ErrInvalidCommand)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, partyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	members := aggregate.Members() // 해산하면 모든 파티원의 소속을 해제
+	joining := ""                  // 파티에 들어오는 사용자 (소속을 먼저 선점)
+	switch command.CommandType() {
+	case CreatePartyCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[CreatePartyData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			if data.MaxSize <= 0 {
+				data.MaxSize = s.config.MaxSize
+			}
+			err = aggregate.Create(userID, data.MaxSize, now)
+			joining = userID
+		}
+	case InviteToPartyCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[InviteToPartyData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			if err = s.requireOnline(ctx, data.InviteeID); err == nil {
+				err = aggregate.Invite(userID, data.InviteeID, now)
+			}
+		}
+	case JoinPartyCommandType:
+		err = aggregate.Join(userID, now)
+		joining = userID
+	case LeavePartyCommandType:
+		err = aggregate.Leave(userID, now)
+	case StartReadyCheckCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[StartReadyCheckData](command.GetData(), ErrInvalidCommand)
+		if err = decodeErr; err == nil {
+			timeout := time.Duration(data.TimeoutSeconds) * time.Second
+			if timeout <= 0 {
+				timeout = s.config.ReadyCheckTimeout
+			}
+			if err = s.requireOnline(ctx, aggregate.Members()...); err == nil {
+				err = aggregate.StartReadyCheck(userID, timeout, now)
+			}
+		}
+	case ConfirmReadyCommandType:
+		err = aggregate.ConfirmReady(userID, now)
+	case DisbandPartyCommandType:
+		err = aggregate.Disband(userID, now)
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidCommand, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	if joining != "" {
+		if err := s.claim(ctx, joining, partyID, now); err != nil {
+			if errors.Is(err, ErrAlreadyInParty) {
+				return cqrs.NewFailedCommandResult(err), nil
+			}
+			return nil, err
+		}
+	}
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		if joining != "" {
+			s.release(ctx, joining, partyID, now)
+		}
+		return nil, err
+	}
+	s.releaseDeparted(ctx, partyID, members, events, now)
+	log.Printf("[Party] %s applied to %s by %s", command.CommandType(), partyID, userID)
+	return cqrs.NewCommandResult(partyID, aggregate.Version(), events...).WithData(aggregate.Members()), nil
+}
+
+// claim 사용자의 소속 스트림에 partyID를 선점합니다
+// 다른 파티에 속해 있으면 ErrAlreadyInParty, 다른 인스턴스가 먼저 선점했으면 저장소의 동시성 에러를 반환합니다
+func (s *Service) claim(ctx context.Context, userID, partyID string, now time.Time) error {
+	membership, err := s.loadMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if current := membership.PartyID(); current != "" && current != partyID {
+		held, err := s.holds(ctx, membership, now)
+		if err != nil {
+			return err
+		}
+		if held {
+			return fmt.Errorf("%w: %s", ErrAlreadyInParty, current)
+		}
+	}
+	if err := membership.Claim(partyID, now); err != nil {
+		return err
+	}
+	return s.saveMembership(ctx, membership)
+}
+
+// holds 선점한 파티가 아직 유효한지 확인합니다
+// 파티 스트림에 파티원으로 남아 있거나, 선점 직후라 파티 저장을 기다리는 중이면 유효합니다
+func (s *Service) holds(ctx context.Context, membership *MembershipAggregate, now time.Time) (bool, error) {
+	if now.Sub(membership.claimedAt) < membershipClaimGrace {
+		return true, nil
+	}
+	aggregate, err := s.load(ctx, membership.PartyID())
+	if err != nil {
+		return false, err
+	}
+	return aggregate.IsMember(membership.userID), nil
+}
+
+// releaseDeparted 저장한 이벤트로 파티를 떠난 사용자의 소속을 해제합니다
+func (s *Service) releaseDeparted(ctx context.Context, partyID string, members []string, events []cqrs.EventMessage, now time.Time) {
+	for _, event := range events {
+		switch data := event.EventData().(type) {
+		case PartyMemberLeftData:
+			s.release(ctx, data.UserID, partyID, now)
+		case PartyDisbandedData:
+			for _, member := range members {
+				s.release(ctx, member, partyID, now)
+			}
+		}
+	}
+}
+
+// release 사용자의 partyID 소속을 해제합니다
+// 실패해도 파티 스트림에서 이미 빠졌으므로 다음 선점 때 유효하지 않은 소속으로 처리됩니다
+func (s *Service) release(ctx context.Context, userID, partyID string, now time.Time) {
+	membership, err := s.loadMembership(ctx, userID)
+	if err == nil {
+		if err = membership.Release(partyID, now); err == nil {
+			err = s.saveMembership(ctx, membership)
+		}
+	}
+	if err != nil {
+		log.Printf("[Party] Failed to release membership of %s in %s: %v", userID, partyID, err)
+	}
+}
+
+// requireOnline 사용자들이 접속 중인지 확인합니다 (Presence가 없으면 확인하지 않음)
+func (s *Service) requireOnline(ctx context.Context, userIDs ...string) error {
+	if s.config.Presence == nil {
+		return nil
+	}
+	for _, userID := range userIDs {
+		if !s.config.Presence.Online(ctx, userID) {
+			return fmt.Errorf("%w: %s", ErrPlayerOffline, userID)
+		}
+	}
+	return nil
+}
+
+func (s *Service) load(ctx context.Context, partyID string) (*PartyAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, partyID, PartyAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load party %s: %w", partyID, err)
+	}
+	aggregate := NewPartyAggregate(partyID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스와 파티 채널로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *PartyAggregate) error {
+	events := aggregate.Changes()
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save party %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	for _, event := range events {
+		if s.config.EventBus != nil {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Party] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+		if s.config.Broadcaster != nil {
+			if _, err := s.config.Broadcaster.Publish(ctx, Channel(aggregate.ID()), event.EventType(), event.EventData()); err != nil {
+				log.Printf("[Party] Failed to broadcast %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) loadMembership(ctx context.Context, userID string) (*MembershipAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, MembershipID(userID), MembershipAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load party membership of %s: %w", userID, err)
+	}
+	membership := NewMembershipAggregate(userID)
+	if err := membership.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// saveMembership 소속 이벤트를 저장합니다 (소속 스트림은 내부 상태라 이벤트 버스로 발행하지 않음)
+func (s *Service) saveMembership(ctx context.Context, membership *MembershipAggregate) error {
+	events := membership.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, membership.ID(), events, membership.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save party membership of %s: %w", membership.userID, err)
+	}
+	membership.ClearChanges()
+	membership.SetOriginalVersion(membership.Version())
+	return nil
+}
@@ -0,0 +1,389 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 개인정보 기본 경로
+const DefaultBasePath = "/privacy"
+
+// Config 개인정보 서버앱 설정
+type Config struct {
+	BasePath      string                          // 라우트 기본 경로 (기본값: /privacy)
+	Exporter      ExporterConfig                  // 데이터 내보내기 설정
+	SigningKey    []byte                          // 필수: 다운로드 링크 서명 키 (16바이트 이상)
+	LinkTTL       time.Duration                   // 다운로드 링크 유효 기간 (기본값: 1시간, 상태를 다시 조회하면 새 링크 발급)
+	SweepInterval time.Duration                   // 만료 아카이브 정리 주기 (기본값: 10분)
+	Auth          func(http.Handler) http.Handler // 선택: 사용자 인증 미들웨어 (다운로드 링크는 서명으로 확인)
+	Identify      func(r *http.Request) string    // 필수: 요청한 사용자 식별
+	Erasure       *EraserConfig                   // 선택: 잊힐 권리 삭제 워크플로 (내보내기 정리 단계는 자동으로 붙음)
+	AdminAuth     func(http.Handler) http.Handler // Erasure를 쓰면 필수: 삭제 요청, 보고서 조회 권한 확인
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to bind exports to users")
+	}
+	if c.Erasure != nil && c.AdminAuth == nil {
+		return errors.New("admin auth is required to expose erasure routes")
+	}
+	return nil
+}
+
+// ExportResponse 내보내기 작업 상태와 다운로드 링크
+type ExportResponse struct {
+	ExportJob
+	DownloadURL       string    `json:"download_url,omitempty"` // 완료된 작업만
+	DownloadExpiresAt time.Time `json:"download_expires_at,omitempty"`
+}
+
+// ErasureRequest 삭제 요청 본문
+type ErasureRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// PrivacyApp 사용자 데이터 내보내기(데이터 이동권)와 삭제(잊힐 권리)를 제공하는 서버앱
+// 사용자가 요청하면 이벤트, 읽기 모델, 캐시에서 사용자를 참조하는 데이터를 비동기로 모아 zip으로 내려줍니다
+// 삭제는 운영자가 요청을 확인한 뒤 관리자 경로로 실행하고, 준법 보고서로 결과를 확인합니다
+type PrivacyApp struct {
+	*serverapp.BaseApp
+	config   Config
+	exporter *Exporter
+	eraser   *Eraser // Erasure 설정이 없으면 nil
+	signer   *LinkSigner
+	stop     chan struct{}
+}
+
+// NewPrivacyApp 새로운 PrivacyApp을 생성합니다
+func NewPrivacyApp(config Config) (*PrivacyApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	if config.LinkTTL <= 0 {
+		config.LinkTTL = time.Hour
+	}
+	if config.SweepInterval <= 0 {
+		config.SweepInterval = 10 * time.Minute
+	}
+	signer, err := NewLinkSigner(config.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := NewExporter(config.Exporter)
+	if err != nil {
+		return nil, err
+	}
+	var eraser *Eraser
+	if config.Erasure != nil {
+		erasure := *config.Erasure
+		erasure.Steps = append(append([]ErasureStep(nil), erasure.Steps...), NewExportCleanupStep(exporter))
+		if eraser, err = NewEraser(erasure); err != nil {
+			return nil, err
+		}
+	}
+	return &PrivacyApp{
+		BaseApp:  serverapp.NewBaseApp("privacy"),
+		config:   config,
+		exporter: exporter,
+		eraser:   eraser,
+		signer:   signer,
+	}, nil
+}
+
+// Exporter 데이터 내보내기
+func (a *PrivacyApp) Exporter() *Exporter {
+	return a.exporter
+}
+
+// Eraser 삭제 워크플로 (Erasure 설정이 없으면 nil)
+func (a *PrivacyApp) Eraser() *Eraser {
+	return a.eraser
+}
+
+// Start 만료 아카이브 정리를 시작합니다
+func (a *PrivacyApp) Start(ctx context.Context) error {
+	a.stop = make(chan struct{})
+	go a.sweepLoop(a.stop)
+	return a.BaseApp.Start(ctx)
+}
+
+// Stop 정리를 멈추고 실행 중인 내보내기가 끝나기를 기다립니다
+func (a *PrivacyApp) Stop(ctx context.Context) error {
+	if a.stop != nil {
+		close(a.stop)
+		a.stop = nil
+	}
+	a.exporter.Wait()
+	if a.eraser != nil {
+		a.eraser.Wait()
+	}
+	return a.BaseApp.Stop(ctx)
+}
+
+func (a *PrivacyApp) sweepLoop(stop chan struct{}) {
+	ticker := time.NewTicker(a.config.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := a.exporter.Sweep(context.Background()); err != nil {
+				log.Printf("[Privacy] Failed to sweep export archives: %v", err)
+			}
+		}
+	}
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *PrivacyApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/exports", protect(http.HandlerFunc(a.exports)))
+	mux.HandleFunc(base+"/exports/download", a.download)
+	if a.eraser != nil {
+		mux.Handle(base+"/erasures", a.config.AdminAuth(http.HandlerFunc(a.erasures)))
+		mux.Handle(base+"/erasures/resume", a.config.AdminAuth(http.HandlerFunc(a.resumeErasure)))
+	}
+
+	log.Printf("[Privacy] Routes registered under %s", base)
+}
+
+// DescribeAPI 개인정보 엔드포인트 설명 (/openapi.json)
+func (a *PrivacyApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	operations := []serverapp.APIOperation{
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/exports",
+			Summary:     "내 데이터 내보내기 요청",
+			Description: "비동기로 실행합니다. 진행 중인 요청이 있으면 그 작업을 돌려줍니다.",
+			Response:    ExportResponse{},
+			Secured:     secured,
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        base + "/exports",
+			Summary:     "내 데이터 내보내기 상태",
+			Description: "id가 없으면 내 작업 목록을 돌려줍니다. 완료된 작업에는 만료되는 다운로드 링크가 붙습니다.",
+			Parameters:  []serverapp.APIParameter{{Name: "id", In: "query", Description: "작업 ID"}},
+			Response:    []ExportResponse{},
+			Secured:     secured,
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        base + "/exports/download",
+			Summary:     "내보내기 아카이브 다운로드",
+			Description: "상태 조회로 받은 서명 링크로만 열 수 있습니다.",
+			Parameters: []serverapp.APIParameter{
+				{Name: "id", In: "query", Required: true},
+				{Name: "expires", In: "query", Type: "integer", Required: true},
+				{Name: "signature", In: "query", Required: true},
+			},
+		},
+	}
+	if a.eraser == nil {
+		return operations
+	}
+	return append(operations,
+		serverapp.APIOperation{
+			Method:      http.MethodPost,
+			Path:        base + "/erasures",
+			Summary:     "사용자 데이터 삭제 (관리자)",
+			Description: "비동기로 실행합니다. 같은 사용자의 삭제가 진행 중이면 그 작업을 돌려줍니다.",
+			Request:     ErasureRequest{},
+			Response:    ErasureRecord{},
+			Secured:     true,
+		},
+		serverapp.APIOperation{
+			Method:     http.MethodGet,
+			Path:       base + "/erasures",
+			Summary:    "삭제 준법 보고서 (관리자)",
+			Parameters: []serverapp.APIParameter{{Name: "id", In: "query", Required: true, Description: "삭제 작업 ID"}},
+			Response:   ComplianceReport{},
+			Secured:    true,
+		},
+		serverapp.APIOperation{
+			Method:      http.MethodPost,
+			Path:        base + "/erasures/resume",
+			Summary:     "실패한 삭제 이어서 실행 (관리자)",
+			Description: "끝낸 단계는 건너뛰고 실패한 단계부터 다시 실행합니다.",
+			Parameters:  []serverapp.APIParameter{{Name: "id", In: "query", Required: true, Description: "삭제 작업 ID"}},
+			Response:    ErasureRecord{},
+			Secured:     true,
+		},
+	)
+}
+
+func (a *PrivacyApp) exports(w http.ResponseWriter, r *http.Request) {
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		job, err := a.exporter.Request(r.Context(), userID)
+		if err != nil {
+			sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sendJSON(w, http.StatusAccepted, a.response(job))
+	case http.MethodGet:
+		jobID := r.URL.Query().Get("id")
+		if jobID == "" {
+			jobs := a.exporter.Jobs(userID)
+			responses := make([]ExportResponse, 0, len(jobs))
+			for _, job := range jobs {
+				responses = append(responses, a.response(job))
+			}
+			sendJSON(w, http.StatusOK, responses)
+			return
+		}
+		job, err := a.exporter.Job(jobID)
+		if err != nil || job.UserID != userID {
+			// 다른 사용자의 작업은 있는지도 알리지 않습니다
+			sendError(w, http.StatusNotFound, ErrExportNotFound.Error())
+			return
+		}
+		sendJSON(w, http.StatusOK, a.response(job))
+	default:
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// response 완료된 작업에 새 다운로드 링크를 붙입니다 (아카이브 만료보다 늦게 끝나지 않음)
+func (a *PrivacyApp) response(job ExportJob) ExportResponse {
+	response := ExportResponse{ExportJob: job}
+	if job.Status != ExportCompleted {
+		return response
+	}
+	expiresAt := a.exporter.config.Now().Add(a.config.LinkTTL)
+	if expiresAt.After(job.ExpiresAt) {
+		expiresAt = job.ExpiresAt
+	}
+	response.DownloadURL = a.config.BasePath + "/exports/download?" + a.signer.Query(job.ID, expiresAt).Encode()
+	response.DownloadExpiresAt = expiresAt
+	return response
+}
+
+func (a *PrivacyApp) download(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	jobID, err := a.signer.Verify(r.URL.Query(), a.exporter.config.Now())
+	if err != nil {
+		sendError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	archive, job, err := a.exporter.Open(r.Context(), jobID)
+	switch {
+	case errors.Is(err, ErrExportNotFound), errors.Is(err, ErrArchiveNotFound):
+		sendError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, ErrExportNotReady):
+		sendError(w, http.StatusGone, err.Error())
+		return
+	case err != nil:
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer archive.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%s.zip"`, job.ID))
+	w.Header().Set("Content-Length", strconv.FormatInt(job.Size, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := io.Copy(w, archive); err != nil {
+		log.Printf("[Privacy] Failed to send export %s: %v", job.ID, err)
+	}
+}
+
+func (a *PrivacyApp) erasures(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var request ErasureRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.UserID == "" {
+			sendError(w, http.StatusBadRequest, "user_id is required")
+			return
+		}
+		record, err := a.eraser.Request(r.Context(), request.UserID)
+		if err != nil {
+			sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sendJSON(w, http.StatusAccepted, record)
+	case http.MethodGet:
+		report, err := a.eraser.Report(r.Context(), r.URL.Query().Get("id"))
+		if err != nil {
+			sendError(w, statusForErasureError(err), err.Error())
+			return
+		}
+		sendJSON(w, http.StatusOK, report)
+	default:
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *PrivacyApp) resumeErasure(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	record, err := a.eraser.Resume(r.Context(), r.URL.Query().Get("id"))
+	if err != nil {
+		sendError(w, statusForErasureError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusAccepted, record)
+}
+
+// statusForErasureError 삭제 에러에 맞는 HTTP 상태
+func statusForErasureError(err error) int {
+	switch {
+	case errors.Is(err, ErrErasureNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrErasureNotResumable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
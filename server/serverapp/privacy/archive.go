@@ -0,0 +1,172 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ErrArchiveNotFound 아카이브가 없거나 이미 지워짐
+var ErrArchiveNotFound = errors.New("export archive not found")
+
+// ArchiveStore 완성된 내보내기 아카이브 저장소
+type ArchiveStore interface {
+	Save(ctx context.Context, jobID string, archive []byte) error
+	Open(ctx context.Context, jobID string) (io.ReadCloser, error)
+	Delete(ctx context.Context, jobID string) error
+}
+
+// InMemoryArchiveStore 메모리 아카이브 저장소 (개발, 테스트용)
+type InMemoryArchiveStore struct {
+	mu       sync.RWMutex
+	archives map[string][]byte
+}
+
+// NewInMemoryArchiveStore 새로운 InMemoryArchiveStore를 생성합니다
+func NewInMemoryArchiveStore() *InMemoryArchiveStore {
+	return &InMemoryArchiveStore{archives: make(map[string][]byte)}
+}
+
+func (s *InMemoryArchiveStore) Save(ctx context.Context, jobID string, archive []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archives[jobID] = archive
+	return nil
+}
+
+func (s *InMemoryArchiveStore) Open(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	archive, ok := s.archives[jobID]
+	if !ok {
+		return nil, ErrArchiveNotFound
+	}
+	return io.NopCloser(bytes.NewReader(archive)), nil
+}
+
+func (s *InMemoryArchiveStore) Delete(ctx context.Context, jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.archives, jobID)
+	return nil
+}
+
+// jobIDPattern 파일 이름으로 쓸 수 있는 작업 ID
+var jobIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// DirArchiveStore 디렉터리에 <작업 ID>.zip으로 저장하는 아카이브 저장소
+type DirArchiveStore struct {
+	dir string
+}
+
+// NewDirArchiveStore 새로운 DirArchiveStore를 생성합니다 (디렉터리가 없으면 만듦)
+func NewDirArchiveStore(dir string) (*DirArchiveStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create export archive directory: %w", err)
+	}
+	return &DirArchiveStore{dir: dir}, nil
+}
+
+func (s *DirArchiveStore) path(jobID string) (string, error) {
+	if !jobIDPattern.MatchString(jobID) {
+		return "", fmt.Errorf("%w: invalid job id %q", ErrArchiveNotFound, jobID)
+	}
+	return filepath.Join(s.dir, jobID+".zip"), nil
+}
+
+func (s *DirArchiveStore) Save(ctx context.Context, jobID string, archive []byte) error {
+	path, err := s.path(jobID)
+	if err != nil {
+		return err
+	}
+	// 다운로드 중에 반쯤 쓴 파일이 보이지 않도록 임시 파일에 쓰고 바꿉니다
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, archive, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+func (s *DirArchiveStore) Open(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	path, err := s.path(jobID)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrArchiveNotFound
+	}
+	return file, err
+}
+
+func (s *DirArchiveStore) Delete(ctx context.Context, jobID string) error {
+	path, err := s.path(jobID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SectionSummary 아카이브 파일 하나의 요약
+type SectionSummary struct {
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+}
+
+// Manifest 아카이브의 manifest.json
+type Manifest struct {
+	UserID      string           `json:"user_id"`
+	JobID       string           `json:"job_id"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	Sections    []SectionSummary `json:"sections"`
+}
+
+// buildArchive collectors가 모은 데이터를 zip 아카이브로 만듭니다
+// 각 Collector는 <Name>.jsonl에 레코드를 한 줄씩 쓰고, 마지막에 manifest.json을 씁니다
+func buildArchive(ctx context.Context, jobID, userID string, collectors []Collector, now time.Time) ([]byte, Manifest, error) {
+	manifest := Manifest{UserID: userID, JobID: jobID, GeneratedAt: now, Sections: []SectionSummary{}}
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, collector := range collectors {
+		section := SectionSummary{Name: collector.Name(), File: collector.Name() + ".jsonl"}
+		file, err := archive.Create(section.File)
+		if err != nil {
+			return nil, Manifest{}, err
+		}
+		encoder := json.NewEncoder(file)
+		err = collector.Collect(ctx, userID, func(record interface{}) error {
+			section.Records++
+			return encoder.Encode(record)
+		})
+		if err != nil {
+			return nil, Manifest{}, fmt.Errorf("collector %s: %w", collector.Name(), err)
+		}
+		manifest.Sections = append(manifest.Sections, section)
+	}
+	file, err := archive.Create("manifest.json")
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, Manifest{}, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, Manifest{}, err
+	}
+	return buffer.Bytes(), manifest, nil
+}
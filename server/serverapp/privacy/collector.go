@@ -0,0 +1,284 @@
+package privacy
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserIDPlaceholder 캐시 키 패턴에서 사용자 ID로 바뀌는 자리 (예: session:{user_id}:*)
+const UserIDPlaceholder = "{user_id}"
+
+// Collector 사용자 한 명을 참조하는 데이터를 한 종류의 저장소에서 모읍니다
+// 내보내기 아카이브에는 Name()마다 <Name>.jsonl 파일 하나가 생깁니다
+type Collector interface {
+	Name() string
+	Collect(ctx context.Context, userID string, emit func(record interface{}) error) error
+}
+
+// References value 안에 userID를 가리키는 문자열이 있는지 확인합니다 (맵, 배열은 재귀)
+// 값 전체가 userID이거나 ":"로 나뉜 조각 하나가 userID일 때만 참조로 봅니다 (예: "wallet:alice", "login:alice:2025-01-01")
+// 부분 문자열은 참조가 아닙니다 ("alice"는 "alicent"를 참조하지 않음)
+func References(value interface{}, userID string) bool {
+	if userID == "" {
+		return false
+	}
+	switch value := value.(type) {
+	case string:
+		if value == userID {
+			return true
+		}
+		if !strings.Contains(value, ":") {
+			return false
+		}
+		for _, part := range strings.Split(value, ":") {
+			if part == userID {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		for key, nested := range value {
+			if key == userID || References(nested, userID) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, nested := range value {
+			if References(nested, userID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toGeneric 구조체를 JSON 모양의 맵, 배열로 바꿉니다 (References 검사와 아카이브 기록용)
+func toGeneric(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// EventCollector 사용자의 이벤트를 모읍니다
+// 애그리게이트 ID나 메타데이터 user_id가 사용자이거나 이벤트 데이터가 사용자를 참조하면 포함합니다
+// 이벤트 저장소 전체를 훑으므로 비동기 내보내기 작업에서만 씁니다
+type EventCollector struct {
+	source cqrsx.EventExportSource
+	filter cqrsx.EventExportFilter
+}
+
+// NewEventCollector 새로운 EventCollector를 생성합니다 (cqrsx.MongoEventStore가 source를 구현)
+// filter로 훑을 이벤트 타입이나 기간을 좁힐 수 있습니다 (Limit은 무시)
+func NewEventCollector(source cqrsx.EventExportSource, filter cqrsx.EventExportFilter) *EventCollector {
+	filter.Limit = 0
+	return &EventCollector{source: source, filter: filter}
+}
+
+func (c *EventCollector) Name() string {
+	return "events"
+}
+
+func (c *EventCollector) Collect(ctx context.Context, userID string, emit func(record interface{}) error) error {
+	return c.source.ExportEvents(ctx, c.filter, func(event *cqrsx.ExportedEvent) error {
+		if event.AggregateID == userID || event.Metadata[cqrs.MetadataUserID] == userID || References(event.Data, userID) {
+			return emit(event)
+		}
+		return nil
+	})
+}
+
+// ReadModelRecord 내보낸 읽기 모델 하나
+type ReadModelRecord struct {
+	Type    string      `json:"type"`
+	ID      string      `json:"id"`
+	Version int         `json:"version"`
+	Data    interface{} `json:"data"`
+}
+
+// ReadModelCollector 사용자의 읽기 모델을 모읍니다
+// 읽기 모델 ID가 사용자이거나 JSON으로 바꾼 내용이 사용자를 참조하면 포함합니다
+type ReadModelCollector struct {
+	store      cqrs.ReadStore
+	modelTypes []string
+}
+
+// NewReadModelCollector 새로운 ReadModelCollector를 생성합니다
+// modelTypes는 훑을 읽기 모델 타입입니다 (예: loginreward.CalendarViewType, wallet.BalanceViewType)
+func NewReadModelCollector(store cqrs.ReadStore, modelTypes ...string) *ReadModelCollector {
+	return &ReadModelCollector{store: store, modelTypes: modelTypes}
+}
+
+func (c *ReadModelCollector) Name() string {
+	return "read_models"
+}
+
+func (c *ReadModelCollector) Collect(ctx context.Context, userID string, emit func(record interface{}) error) error {
+	for _, modelType := range c.modelTypes {
+		models, err := c.store.Query(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": modelType}})
+		if err != nil {
+			return fmt.Errorf("failed to query %s read models: %w", modelType, err)
+		}
+		for _, model := range models {
+			data, err := toGeneric(model)
+			if fields, ok := data.(map[string]interface{}); err == nil && ok && len(fields) == 0 {
+				// 필드를 드러내지 않는 읽기 모델 (cqrs.BaseReadModel 그대로)은 GetData로 읽습니다
+				data, err = toGeneric(model.GetData())
+			}
+			if err != nil {
+				return fmt.Errorf("failed to encode %s read model %s: %w", modelType, model.GetID(), err)
+			}
+			if model.GetID() != userID && !References(data, userID) {
+				continue
+			}
+			if err := emit(ReadModelRecord{Type: model.GetType(), ID: model.GetID(), Version: model.GetVersion(), Data: data}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CacheRecord 내보낸 캐시 키 하나
+type CacheRecord struct {
+	Key        string      `json:"key"`
+	Type       string      `json:"type"`
+	Value      interface{} `json:"value,omitempty"`
+	TTLSeconds int64       `json:"ttl_seconds,omitempty"` // 만료가 없으면 0
+}
+
+// RedisCacheCollector 사용자 ID가 들어간 키 패턴으로 Redis 캐시를 모읍니다
+// string, hash, list, set 값은 내용을, 그 밖의 타입은 키와 타입만 기록합니다
+type RedisCacheCollector struct {
+	client   redis.UniversalClient
+	patterns []string
+}
+
+// NewRedisCacheCollector 새로운 RedisCacheCollector를 생성합니다
+// 패턴마다 {user_id}가 있어야 합니다 (없으면 다른 사용자의 키까지 내보냄)
+func NewRedisCacheCollector(client redis.UniversalClient, patterns ...string) (*RedisCacheCollector, error) {
+	if client == nil {
+		return nil, errors.New("redis client is required")
+	}
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, UserIDPlaceholder) {
+			return nil, fmt.Errorf("cache key pattern %q must contain %s", pattern, UserIDPlaceholder)
+		}
+	}
+	return &RedisCacheCollector{client: client, patterns: patterns}, nil
+}
+
+func (c *RedisCacheCollector) Name() string {
+	return "cache"
+}
+
+func (c *RedisCacheCollector) Collect(ctx context.Context, userID string, emit func(record interface{}) error) error {
+	seen := make(map[string]bool)
+	for _, pattern := range c.patterns {
+		match := strings.ReplaceAll(pattern, UserIDPlaceholder, escapeGlob(userID))
+		iter := c.client.Scan(ctx, 0, match, 100).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			record, err := c.read(ctx, key)
+			if errors.Is(err, redis.Nil) {
+				continue // 훑는 사이에 만료됨
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read cache key %s: %w", key, err)
+			}
+			if err := emit(record); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("failed to scan cache keys %s: %w", match, err)
+		}
+	}
+	return nil
+}
+
+func (c *RedisCacheCollector) read(ctx context.Context, key string) (CacheRecord, error) {
+	keyType, err := c.client.Type(ctx, key).Result()
+	if err != nil {
+		return CacheRecord{}, err
+	}
+	if keyType == "none" {
+		return CacheRecord{}, redis.Nil
+	}
+	record := CacheRecord{Key: key, Type: keyType}
+	switch keyType {
+	case "string":
+		value, err := c.client.Get(ctx, key).Result()
+		if err != nil {
+			return CacheRecord{}, err
+		}
+		var decoded interface{}
+		if json.Unmarshal([]byte(value), &decoded) == nil {
+			record.Value = decoded
+		} else {
+			record.Value = value
+		}
+	case "hash":
+		record.Value, err = c.client.HGetAll(ctx, key).Result()
+	case "list":
+		record.Value, err = c.client.LRange(ctx, key, 0, -1).Result()
+	case "set":
+		record.Value, err = c.client.SMembers(ctx, key).Result()
+	}
+	if err != nil {
+		return CacheRecord{}, err
+	}
+	if ttl, err := c.client.TTL(ctx, key).Result(); err == nil && ttl > 0 {
+		record.TTLSeconds = int64((ttl + time.Second - 1) / time.Second)
+	}
+	return record, nil
+}
+
+// escapeGlob Redis SCAN 패턴 문자를 이스케이프합니다 (사용자 ID에 *가 있어도 다른 키와 맞지 않도록)
+func escapeGlob(value string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// CollectorFunc 함수로 만드는 Collector (사용자 프로필 등 저장소마다 조회 방법이 다른 데이터)
+type CollectorFunc struct {
+	name    string
+	collect func(ctx context.Context, userID string, emit func(record interface{}) error) error
+}
+
+// NewCollectorFunc 새로운 CollectorFunc를 생성합니다
+func NewCollectorFunc(name string, collect func(ctx context.Context, userID string, emit func(record interface{}) error) error) *CollectorFunc {
+	return &CollectorFunc{name: name, collect: collect}
+}
+
+func (c *CollectorFunc) Name() string {
+	return c.name
+}
+
+func (c *CollectorFunc) Collect(ctx context.Context, userID string, emit func(record interface{}) error) error {
+	return c.collect(ctx, userID, emit)
+}
@@ -0,0 +1,308 @@
+package privacy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 삭제 작업 상태
+const (
+	ErasurePending   = "pending"   // 차례를 기다림
+	ErasureRunning   = "running"   // 단계를 실행하는 중
+	ErasureCompleted = "completed" // 모든 단계 완료
+	ErasureFailed    = "failed"    // 단계가 실패함 (Resume으로 실패한 단계부터 다시 실행)
+)
+
+var (
+	ErrErasureNotFound     = errors.New("erasure not found")
+	ErrErasureNotResumable = errors.New("erasure is not resumable")
+)
+
+// ErasureAction 단계가 한 일 하나 (준법 보고서 항목)
+type ErasureAction struct {
+	Step   string `json:"step"`
+	Kind   string `json:"kind"`   // deleted, crypto_shredded, anonymized, manual_review
+	Target string `json:"target"` // 대상 데이터 설명 (사용자 ID는 담지 않음)
+	Count  int    `json:"count"`
+}
+
+// ErasureRecord 삭제 작업 기록 (사가 상태)
+// 단계가 끝날 때마다 저장하므로 서버가 멈춰도 마지막으로 끝낸 단계 다음부터 이어갈 수 있습니다
+// 완료되면 사용자 ID를 지우고 가명만 남깁니다
+type ErasureRecord struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id,omitempty"`
+	Pseudonym      string          `json:"pseudonym"`
+	Status         string          `json:"status"`
+	RequestedAt    time.Time       `json:"requested_at"`
+	CompletedAt    time.Time       `json:"completed_at,omitempty"`
+	CompletedSteps []string        `json:"completed_steps"`
+	Actions        []ErasureAction `json:"actions"`
+	Attempts       int             `json:"attempts"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// completed 단계를 이미 끝냈는지 확인합니다
+func (r ErasureRecord) completed(step string) bool {
+	for _, name := range r.CompletedSteps {
+		if name == step {
+			return true
+		}
+	}
+	return false
+}
+
+// ComplianceReport 삭제 요청 처리 결과 보고서 (감독 기관, 사용자 회신용)
+type ComplianceReport struct {
+	ErasureID      string          `json:"erasure_id"`
+	Pseudonym      string          `json:"pseudonym"`
+	Status         string          `json:"status"`
+	RequestedAt    time.Time       `json:"requested_at"`
+	CompletedAt    time.Time       `json:"completed_at,omitempty"`
+	Steps          []string        `json:"steps"`         // 끝낸 단계
+	PendingSteps   []string        `json:"pending_steps"` // 아직 실행하지 않은 단계
+	Actions        []ErasureAction `json:"actions"`
+	RequiresReview bool            `json:"requires_review"` // 사람이 확인해야 할 항목이 있음
+	Error          string          `json:"error,omitempty"`
+}
+
+// ErasureStore 삭제 작업 기록 저장소
+type ErasureStore interface {
+	Save(ctx context.Context, record ErasureRecord) error
+	Get(ctx context.Context, id string) (ErasureRecord, error) // 없으면 ErrErasureNotFound
+}
+
+// InMemoryErasureStore 메모리 삭제 작업 기록 저장소 (개발, 테스트용)
+type InMemoryErasureStore struct {
+	mu      sync.RWMutex
+	records map[string]ErasureRecord
+}
+
+// NewInMemoryErasureStore 새로운 InMemoryErasureStore를 생성합니다
+func NewInMemoryErasureStore() *InMemoryErasureStore {
+	return &InMemoryErasureStore{records: make(map[string]ErasureRecord)}
+}
+
+func (s *InMemoryErasureStore) Save(ctx context.Context, record ErasureRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.CompletedSteps = append([]string(nil), record.CompletedSteps...)
+	record.Actions = append([]ErasureAction(nil), record.Actions...)
+	s.records[record.ID] = record
+	return nil
+}
+
+func (s *InMemoryErasureStore) Get(ctx context.Context, id string) (ErasureRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.records[id]
+	if !ok {
+		return ErasureRecord{}, ErrErasureNotFound
+	}
+	return record, nil
+}
+
+// EraserConfig 삭제 워크플로 설정
+type EraserConfig struct {
+	Steps        []ErasureStep    // 필수: 순서대로 실행할 단계 (이름이 겹치면 안 됨)
+	Store        ErasureStore     // 작업 기록 저장소 (기본값: 메모리)
+	PseudonymKey []byte           // 필수: 가명을 만드는 키 (16바이트 이상, 바꾸면 같은 사용자의 가명이 달라짐)
+	Now          func() time.Time // 테스트용 시계 (기본값: time.Now)
+}
+
+// Eraser 잊힐 권리 삭제 워크플로 (사가)
+// 단계는 되돌릴 수 없는 작업이라 보상 트랜잭션 대신 앞으로 복구합니다:
+// 실패하면 기록을 남기고 멈추며, Resume이 실패한 단계부터 다시 실행합니다
+type Eraser struct {
+	config EraserConfig
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	active map[string]string // 실행 중인 사용자 ID -> 작업 ID
+}
+
+// NewEraser 새로운 Eraser를 생성합니다
+func NewEraser(config EraserConfig) (*Eraser, error) {
+	if len(config.Steps) == 0 {
+		return nil, errors.New("at least one erasure step is required")
+	}
+	names := make(map[string]bool, len(config.Steps))
+	for _, step := range config.Steps {
+		if step.Name() == "" || names[step.Name()] {
+			return nil, fmt.Errorf("erasure step name %q must be unique and not empty", step.Name())
+		}
+		names[step.Name()] = true
+	}
+	if len(config.PseudonymKey) < 16 {
+		return nil, errors.New("pseudonym key must be at least 16 bytes")
+	}
+	if config.Store == nil {
+		config.Store = NewInMemoryErasureStore()
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Eraser{config: config, active: make(map[string]string)}, nil
+}
+
+// Pseudonym 사용자의 가명 (같은 키로는 항상 같은 값이라 익명화한 데이터끼리의 관계는 유지됨)
+func (e *Eraser) Pseudonym(userID string) string {
+	mac := hmac.New(sha256.New, e.config.PseudonymKey)
+	mac.Write([]byte(userID))
+	return "erased-" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// Request 사용자 데이터 삭제를 요청합니다
+// 같은 사용자의 작업이 이미 실행 중이면 그 작업을 반환합니다
+func (e *Eraser) Request(ctx context.Context, userID string) (ErasureRecord, error) {
+	if userID == "" {
+		return ErasureRecord{}, errors.New("user ID is required")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if id, ok := e.active[userID]; ok {
+		return e.config.Store.Get(ctx, id)
+	}
+	record := ErasureRecord{
+		ID:             uuid.NewString(),
+		UserID:         userID,
+		Pseudonym:      e.Pseudonym(userID),
+		Status:         ErasurePending,
+		RequestedAt:    e.config.Now(),
+		CompletedSteps: []string{},
+		Actions:        []ErasureAction{},
+	}
+	if err := e.config.Store.Save(ctx, record); err != nil {
+		return ErasureRecord{}, fmt.Errorf("failed to save erasure: %w", err)
+	}
+	e.start(record)
+	log.Printf("[Privacy] Erasure %s requested as %s", record.ID, record.Pseudonym)
+	return record, nil
+}
+
+// Resume 실패했거나 서버가 멈춰 끝나지 못한 작업을 남은 단계부터 다시 실행합니다
+func (e *Eraser) Resume(ctx context.Context, erasureID string) (ErasureRecord, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	record, err := e.config.Store.Get(ctx, erasureID)
+	if err != nil {
+		return ErasureRecord{}, err
+	}
+	if _, running := e.active[record.UserID]; running || record.Status == ErasureCompleted {
+		return ErasureRecord{}, fmt.Errorf("%w: %s is %s", ErrErasureNotResumable, erasureID, record.Status)
+	}
+	record.Status, record.Error = ErasurePending, ""
+	if err := e.config.Store.Save(ctx, record); err != nil {
+		return ErasureRecord{}, fmt.Errorf("failed to save erasure: %w", err)
+	}
+	e.start(record)
+	log.Printf("[Privacy] Erasure %s resumed", record.ID)
+	return record, nil
+}
+
+// Get 작업 기록
+func (e *Eraser) Get(ctx context.Context, erasureID string) (ErasureRecord, error) {
+	return e.config.Store.Get(ctx, erasureID)
+}
+
+// Report 작업의 준법 보고서
+func (e *Eraser) Report(ctx context.Context, erasureID string) (ComplianceReport, error) {
+	record, err := e.config.Store.Get(ctx, erasureID)
+	if err != nil {
+		return ComplianceReport{}, err
+	}
+	report := ComplianceReport{
+		ErasureID:    record.ID,
+		Pseudonym:    record.Pseudonym,
+		Status:       record.Status,
+		RequestedAt:  record.RequestedAt,
+		CompletedAt:  record.CompletedAt,
+		Steps:        record.CompletedSteps,
+		PendingSteps: []string{},
+		Actions:      record.Actions,
+		Error:        record.Error,
+	}
+	for _, step := range e.config.Steps {
+		if !record.completed(step.Name()) {
+			report.PendingSteps = append(report.PendingSteps, step.Name())
+		}
+	}
+	for _, action := range record.Actions {
+		if action.Kind == ActionManualReview {
+			report.RequiresReview = true
+		}
+	}
+	return report, nil
+}
+
+// Wait 실행 중인 작업이 모두 끝날 때까지 기다립니다 (종료, 테스트용)
+func (e *Eraser) Wait() {
+	e.wg.Wait()
+}
+
+// start 작업을 백그라운드에서 실행합니다 (e.mu를 잡은 상태로 호출)
+func (e *Eraser) start(record ErasureRecord) {
+	e.active[record.UserID] = record.ID
+	e.wg.Add(1)
+	go e.run(record)
+}
+
+// run 남은 단계를 순서대로 실행하고 단계마다 기록을 저장합니다 (요청 컨텍스트가 끝나도 계속 실행)
+func (e *Eraser) run(record ErasureRecord) {
+	defer e.wg.Done()
+	userID := record.UserID
+	defer func() {
+		e.mu.Lock()
+		delete(e.active, userID)
+		e.mu.Unlock()
+	}()
+
+	ctx := context.Background()
+	record.Status = ErasureRunning
+	record.Attempts++
+	if err := e.config.Store.Save(ctx, record); err != nil {
+		log.Printf("[Privacy] Failed to save erasure %s: %v", record.ID, err)
+		return
+	}
+
+	subject := ErasureSubject{UserID: userID, Pseudonym: record.Pseudonym}
+	for _, step := range e.config.Steps {
+		if record.completed(step.Name()) {
+			continue
+		}
+		actions, err := step.Erase(ctx, subject)
+		if err != nil {
+			record.Status, record.Error = ErasureFailed, fmt.Sprintf("step %s failed: %v", step.Name(), err)
+			log.Printf("[Privacy] Erasure %s failed at %s: %v", record.ID, step.Name(), err)
+			if err := e.config.Store.Save(ctx, record); err != nil {
+				log.Printf("[Privacy] Failed to save erasure %s: %v", record.ID, err)
+			}
+			return
+		}
+		for _, action := range actions {
+			action.Step = step.Name()
+			record.Actions = append(record.Actions, action)
+		}
+		record.CompletedSteps = append(record.CompletedSteps, step.Name())
+		if err := e.config.Store.Save(ctx, record); err != nil {
+			log.Printf("[Privacy] Failed to save erasure %s after %s: %v", record.ID, step.Name(), err)
+			return
+		}
+	}
+
+	record.Status, record.CompletedAt, record.UserID = ErasureCompleted, e.config.Now(), ""
+	if err := e.config.Store.Save(ctx, record); err != nil {
+		log.Printf("[Privacy] Failed to save erasure %s: %v", record.ID, err)
+		return
+	}
+	log.Printf("[Privacy] Erasure %s completed (%d actions)", record.ID, len(record.Actions))
+}
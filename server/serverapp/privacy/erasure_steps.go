@@ -0,0 +1,377 @@
+package privacy
+
+import (
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 삭제 작업 종류 (준법 보고서의 ErasureAction.Kind)
+const (
+	ActionDeleted        = "deleted"         // 데이터를 지움
+	ActionCryptoShredded = "crypto_shredded" // 암호화 키를 지워 데이터를 읽을 수 없게 함
+	ActionAnonymized     = "anonymized"      // 사용자 참조를 가명으로 바꿈
+	ActionManualReview   = "manual_review"   // 자동으로 처리할 수 없어 사람이 확인해야 함
+)
+
+// ErrKeyShredded 사용자의 데이터 키가 파기됨 (암호화된 데이터는 더 이상 읽을 수 없음)
+var ErrKeyShredded = errors.New("user data key has been shredded")
+
+// ErasureSubject 삭제 대상
+type ErasureSubject struct {
+	UserID    string
+	Pseudonym string // 남는 참조를 대신할 값 (같은 사용자는 항상 같은 가명)
+}
+
+// ErasureStep 삭제 워크플로의 한 단계
+// 실패하면 워크플로를 다시 실행할 때 이 단계부터 다시 하므로, 이미 처리한 데이터를 만나도 실패하지 않아야 합니다
+type ErasureStep interface {
+	Name() string
+	Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error)
+}
+
+// Anonymize value 안에서 userID를 가리키는 문자열을 pseudonym으로 바꾼 복사본을 반환합니다
+// References와 같은 규칙으로 찾으며 (":"로 나뉜 조각 포함), 바뀐 것이 있으면 true입니다
+func Anonymize(value interface{}, userID, pseudonym string) (interface{}, bool) {
+	if userID == "" {
+		return value, false
+	}
+	switch value := value.(type) {
+	case string:
+		if value == userID {
+			return pseudonym, true
+		}
+		if !strings.Contains(value, ":") {
+			return value, false
+		}
+		parts := strings.Split(value, ":")
+		changed := false
+		for i, part := range parts {
+			if part == userID {
+				parts[i], changed = pseudonym, true
+			}
+		}
+		return strings.Join(parts, ":"), changed
+	case map[string]interface{}:
+		anonymized := make(map[string]interface{}, len(value))
+		changed := false
+		for key, nested := range value {
+			if key == userID {
+				key, changed = pseudonym, true
+			}
+			replaced, nestedChanged := Anonymize(nested, userID, pseudonym)
+			anonymized[key] = replaced
+			changed = changed || nestedChanged
+		}
+		return anonymized, changed
+	case []interface{}:
+		anonymized := make([]interface{}, len(value))
+		changed := false
+		for i, nested := range value {
+			replaced, nestedChanged := Anonymize(nested, userID, pseudonym)
+			anonymized[i] = replaced
+			changed = changed || nestedChanged
+		}
+		return anonymized, changed
+	}
+	return value, false
+}
+
+// KeyVault 사용자별 데이터 키 보관소 (크립토 쉬레딩)
+// 자격 증명, 프로필처럼 개인정보가 담긴 필드를 사용자 키로 암호화해 두면 키만 지워 복사본과 백업까지 읽을 수 없게 됩니다
+type KeyVault interface {
+	// ShredKey 사용자 키를 지웁니다 (키가 없었으면 false)
+	ShredKey(ctx context.Context, userID string) (bool, error)
+}
+
+// InMemoryKeyVault 메모리 사용자 키 보관소 (AES-256-GCM, 개발, 테스트용)
+type InMemoryKeyVault struct {
+	mu       sync.Mutex
+	keys     map[string][]byte
+	shredded map[string]bool
+}
+
+// NewInMemoryKeyVault 새로운 InMemoryKeyVault를 생성합니다
+func NewInMemoryKeyVault() *InMemoryKeyVault {
+	return &InMemoryKeyVault{keys: make(map[string][]byte), shredded: make(map[string]bool)}
+}
+
+// Encrypt 사용자 키로 암호화합니다 (키가 없으면 만듦, 파기된 사용자는 ErrKeyShredded)
+func (v *InMemoryKeyVault) Encrypt(ctx context.Context, userID string, plaintext []byte) ([]byte, error) {
+	aead, err := v.cipher(userID, true)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, []byte(userID)), nil
+}
+
+// Decrypt 사용자 키로 복호화합니다 (파기된 사용자는 ErrKeyShredded)
+func (v *InMemoryKeyVault) Decrypt(ctx context.Context, userID string, ciphertext []byte) ([]byte, error) {
+	aead, err := v.cipher(userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, []byte(userID))
+}
+
+func (v *InMemoryKeyVault) ShredKey(ctx context.Context, userID string) (bool, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	_, existed := v.keys[userID]
+	delete(v.keys, userID)
+	v.shredded[userID] = true
+	return existed, nil
+}
+
+func (v *InMemoryKeyVault) cipher(userID string, create bool) (cipher.AEAD, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.shredded[userID] {
+		return nil, ErrKeyShredded
+	}
+	key, ok := v.keys[userID]
+	if !ok {
+		if !create {
+			return nil, fmt.Errorf("no data key for user %s", userID)
+		}
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		v.keys[userID] = key
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// CryptoShredStep 사용자 데이터 키를 파기하는 단계
+type CryptoShredStep struct {
+	vault  KeyVault
+	target string
+}
+
+// NewCryptoShredStep 새로운 CryptoShredStep을 생성합니다 (target은 보고서에 남길 데이터 설명, 예: "credentials, profile")
+func NewCryptoShredStep(vault KeyVault, target string) *CryptoShredStep {
+	return &CryptoShredStep{vault: vault, target: target}
+}
+
+func (s *CryptoShredStep) Name() string {
+	return "crypto_shred"
+}
+
+func (s *CryptoShredStep) Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+	existed, err := s.vault.ShredKey(ctx, subject.UserID)
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	if existed {
+		count = 1
+	}
+	return []ErasureAction{{Kind: ActionCryptoShredded, Target: s.target, Count: count}}, nil
+}
+
+// Anonymizable 스스로 사용자 참조를 가명으로 바꿀 수 있는 읽기 모델
+type Anonymizable interface {
+	// AnonymizeUser userID 참조를 pseudonym으로 바꾸고, 바뀐 것이 있으면 true를 반환합니다
+	AnonymizeUser(userID, pseudonym string) bool
+}
+
+// ReadModelErasureStep 읽기 모델에서 사용자를 지우는 단계
+// deleteTypes는 ID가 사용자인 읽기 모델을 지우고 (프로필, 지갑 잔액 등),
+// anonymizeTypes는 사용자를 참조하는 읽기 모델을 Anonymizable로 가명 처리합니다 (길드 명단 등)
+// Anonymizable이 아닌 모델은 수동 확인 대상으로 보고합니다
+type ReadModelErasureStep struct {
+	store          cqrs.ReadStore
+	deleteTypes    []string
+	anonymizeTypes []string
+}
+
+// NewReadModelErasureStep 새로운 ReadModelErasureStep을 생성합니다
+func NewReadModelErasureStep(store cqrs.ReadStore, deleteTypes, anonymizeTypes []string) *ReadModelErasureStep {
+	return &ReadModelErasureStep{store: store, deleteTypes: deleteTypes, anonymizeTypes: anonymizeTypes}
+}
+
+func (s *ReadModelErasureStep) Name() string {
+	return "read_models"
+}
+
+func (s *ReadModelErasureStep) Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+	var actions []ErasureAction
+	for _, modelType := range s.deleteTypes {
+		err := s.store.Delete(ctx, subject.UserID, modelType)
+		if cqrs.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return actions, fmt.Errorf("failed to delete %s read model: %w", modelType, err)
+		}
+		actions = append(actions, ErasureAction{Kind: ActionDeleted, Target: "read model " + modelType, Count: 1})
+	}
+
+	for _, modelType := range s.anonymizeTypes {
+		models, err := s.store.Query(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": modelType}})
+		if err != nil {
+			return actions, fmt.Errorf("failed to query %s read models: %w", modelType, err)
+		}
+		anonymized := 0
+		for _, model := range models {
+			if anonymizable, ok := model.(Anonymizable); ok {
+				if !anonymizable.AnonymizeUser(subject.UserID, subject.Pseudonym) {
+					continue
+				}
+				if err := s.store.Save(ctx, model); err != nil {
+					return actions, fmt.Errorf("failed to save anonymized %s read model %s: %w", modelType, model.GetID(), err)
+				}
+				anonymized++
+				continue
+			}
+			data, err := toGeneric(model)
+			if fields, ok := data.(map[string]interface{}); err == nil && ok && len(fields) == 0 {
+				data, err = toGeneric(model.GetData())
+			}
+			if err != nil {
+				return actions, fmt.Errorf("failed to encode %s read model %s: %w", modelType, model.GetID(), err)
+			}
+			if model.GetID() == subject.UserID || References(data, subject.UserID) {
+				actions = append(actions, ErasureAction{Kind: ActionManualReview, Target: "read model " + modelType + "/" + model.GetID(), Count: 1})
+			}
+		}
+		if anonymized > 0 {
+			actions = append(actions, ErasureAction{Kind: ActionAnonymized, Target: "read model " + modelType, Count: anonymized})
+		}
+	}
+	return actions, nil
+}
+
+// EventRewriter 저장된 이벤트를 훑고 페이로드를 바꿀 수 있는 이벤트 저장소 (cqrsx.MongoEventStore가 구현)
+type EventRewriter interface {
+	cqrsx.EventExportSource
+	RewriteEventPayload(ctx context.Context, eventID string, data, metadata map[string]interface{}) error
+}
+
+// EventAnonymizationStep 이벤트 데이터와 메타데이터의 사용자 참조를 가명으로 바꾸는 단계
+// 스트림 키인 애그리게이트 ID는 바꾸지 않습니다 (사용자 ID는 개인정보가 없는 불투명한 값이어야 함)
+type EventAnonymizationStep struct {
+	store  EventRewriter
+	filter cqrsx.EventExportFilter
+}
+
+// NewEventAnonymizationStep 새로운 EventAnonymizationStep을 생성합니다
+// filter로 훑을 이벤트 타입을 좁힐 수 있습니다 (예: 길드, 멤버 이벤트)
+func NewEventAnonymizationStep(store EventRewriter, filter cqrsx.EventExportFilter) *EventAnonymizationStep {
+	filter.Limit = 0
+	return &EventAnonymizationStep{store: store, filter: filter}
+}
+
+func (s *EventAnonymizationStep) Name() string {
+	return "events"
+}
+
+func (s *EventAnonymizationStep) Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+	// 훑는 중에 같은 컬렉션을 고치지 않도록 바꿀 내용을 먼저 모읍니다
+	type rewrite struct {
+		eventID        string
+		data, metadata map[string]interface{}
+	}
+	var rewrites []rewrite
+	affectedTypes := make(map[string]int)
+	err := s.store.ExportEvents(ctx, s.filter, func(event *cqrsx.ExportedEvent) error {
+		data, dataChanged := Anonymize(event.Data, subject.UserID, subject.Pseudonym)
+		metadata, metadataChanged := Anonymize(event.Metadata, subject.UserID, subject.Pseudonym)
+		if !dataChanged && !metadataChanged {
+			return nil
+		}
+		dataMap, _ := data.(map[string]interface{})
+		metadataMap, _ := metadata.(map[string]interface{})
+		rewrites = append(rewrites, rewrite{eventID: event.EventID, data: dataMap, metadata: metadataMap})
+		affectedTypes[event.EventType]++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan events: %w", err)
+	}
+	for _, rewrite := range rewrites {
+		if err := s.store.RewriteEventPayload(ctx, rewrite.eventID, rewrite.data, rewrite.metadata); err != nil {
+			return nil, fmt.Errorf("failed to anonymize event %s: %w", rewrite.eventID, err)
+		}
+	}
+
+	eventTypes := make([]string, 0, len(affectedTypes))
+	for eventType := range affectedTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	actions := make([]ErasureAction, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		actions = append(actions, ErasureAction{Kind: ActionAnonymized, Target: "event " + eventType, Count: affectedTypes[eventType]})
+	}
+	return actions, nil
+}
+
+// ExportCleanupStep 사용자가 받아 간 내보내기 아카이브와 작업 기록을 지우는 단계
+// PrivacyApp은 삭제 워크플로를 켜면 이 단계를 마지막에 자동으로 붙입니다
+type ExportCleanupStep struct {
+	exporter *Exporter
+}
+
+// NewExportCleanupStep 새로운 ExportCleanupStep을 생성합니다
+func NewExportCleanupStep(exporter *Exporter) *ExportCleanupStep {
+	return &ExportCleanupStep{exporter: exporter}
+}
+
+func (s *ExportCleanupStep) Name() string {
+	return "exports"
+}
+
+func (s *ExportCleanupStep) Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+	for _, job := range s.exporter.Jobs(subject.UserID) {
+		if job.active() {
+			return nil, fmt.Errorf("export %s is still %s", job.ID, job.Status)
+		}
+	}
+	count, err := s.exporter.Forget(ctx, subject.UserID)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	return []ErasureAction{{Kind: ActionDeleted, Target: "export archives", Count: count}}, nil
+}
+
+// FuncStep 함수로 만드는 단계 (자격 증명 저장소, 외부 서비스 등 저장소마다 지우는 방법이 다른 데이터)
+type FuncStep struct {
+	name  string
+	erase func(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error)
+}
+
+// NewFuncStep 새로운 FuncStep을 생성합니다
+func NewFuncStep(name string, erase func(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error)) *FuncStep {
+	return &FuncStep{name: name, erase: erase}
+}
+
+func (s *FuncStep) Name() string {
+	return s.name
+}
+
+func (s *FuncStep) Erase(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+	return s.erase(ctx, subject)
+}
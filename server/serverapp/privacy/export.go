@@ -0,0 +1,263 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 내보내기 작업 상태
+const (
+	ExportPending   = "pending"   // 차례를 기다림
+	ExportRunning   = "running"   // 데이터를 모으는 중
+	ExportCompleted = "completed" // 다운로드 가능
+	ExportFailed    = "failed"    // 실패 (다시 요청할 수 있음)
+	ExportExpired   = "expired"   // 보관 기간이 지나 아카이브를 지움
+)
+
+var (
+	ErrExportNotFound = errors.New("export job not found")
+	ErrExportNotReady = errors.New("export archive is not ready")
+)
+
+// ExportJob 사용자 데이터 내보내기 작업
+type ExportJob struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Status      string           `json:"status"`
+	RequestedAt time.Time        `json:"requested_at"`
+	StartedAt   time.Time        `json:"started_at,omitempty"`
+	CompletedAt time.Time        `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at,omitempty"` // 이 시각에 아카이브를 지움
+	Size        int64            `json:"size,omitempty"`       // 아카이브 바이트 수
+	Sections    []SectionSummary `json:"sections,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// active 아직 끝나지 않은 작업인지 확인합니다
+func (j ExportJob) active() bool {
+	return j.Status == ExportPending || j.Status == ExportRunning
+}
+
+// ExporterConfig 내보내기 설정
+type ExporterConfig struct {
+	Collectors  []Collector      // 필수: 데이터를 모을 저장소 (이름이 겹치면 안 됨)
+	Archives    ArchiveStore     // 아카이브 저장소 (기본값: 메모리)
+	Retention   time.Duration    // 완성된 아카이브 보관 기간 (기본값: 72시간)
+	Concurrency int              // 동시에 실행할 작업 수 (기본값: 2)
+	Now         func() time.Time // 테스트용 시계 (기본값: time.Now)
+}
+
+// Exporter 사용자 데이터 내보내기 작업을 비동기로 실행하고 상태를 추적합니다
+// 작업 상태는 이 서버의 메모리에만 있으므로 재시작하면 진행 중이던 요청은 다시 해야 합니다
+type Exporter struct {
+	config ExporterConfig
+	slots  chan struct{}
+	wg     sync.WaitGroup
+
+	mu   sync.RWMutex
+	jobs map[string]*ExportJob
+}
+
+// NewExporter 새로운 Exporter를 생성합니다
+func NewExporter(config ExporterConfig) (*Exporter, error) {
+	if len(config.Collectors) == 0 {
+		return nil, errors.New("at least one collector is required")
+	}
+	names := make(map[string]bool, len(config.Collectors))
+	for _, collector := range config.Collectors {
+		if collector.Name() == "" || collector.Name() == "manifest" || names[collector.Name()] {
+			return nil, fmt.Errorf("collector name %q must be unique and not empty", collector.Name())
+		}
+		names[collector.Name()] = true
+	}
+	if config.Archives == nil {
+		config.Archives = NewInMemoryArchiveStore()
+	}
+	if config.Retention <= 0 {
+		config.Retention = 72 * time.Hour
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 2
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Exporter{
+		config: config,
+		slots:  make(chan struct{}, config.Concurrency),
+		jobs:   make(map[string]*ExportJob),
+	}, nil
+}
+
+// Request 사용자 데이터 내보내기를 요청합니다
+// 같은 사용자의 작업이 이미 진행 중이면 그 작업을 반환합니다
+func (e *Exporter) Request(ctx context.Context, userID string) (ExportJob, error) {
+	if userID == "" {
+		return ExportJob{}, errors.New("user ID is required")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, job := range e.jobs {
+		if job.UserID == userID && job.active() {
+			return *job, nil
+		}
+	}
+	job := &ExportJob{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Status:      ExportPending,
+		RequestedAt: e.config.Now(),
+	}
+	e.jobs[job.ID] = job
+	e.wg.Add(1)
+	go e.run(job.ID, userID)
+	log.Printf("[Privacy] Export %s requested for %s", job.ID, userID)
+	return *job, nil
+}
+
+// Job 작업 상태
+func (e *Exporter) Job(jobID string) (ExportJob, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	job, ok := e.jobs[jobID]
+	if !ok {
+		return ExportJob{}, ErrExportNotFound
+	}
+	return *job, nil
+}
+
+// Jobs 사용자의 작업 목록 (최근 요청 순)
+func (e *Exporter) Jobs(userID string) []ExportJob {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	jobs := []ExportJob{}
+	for _, job := range e.jobs {
+		if job.UserID == userID {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].RequestedAt.After(jobs[j].RequestedAt) })
+	return jobs
+}
+
+// Open 완성된 아카이브를 엽니다
+func (e *Exporter) Open(ctx context.Context, jobID string) (io.ReadCloser, ExportJob, error) {
+	job, err := e.Job(jobID)
+	if err != nil {
+		return nil, ExportJob{}, err
+	}
+	if job.Status != ExportCompleted || !e.config.Now().Before(job.ExpiresAt) {
+		return nil, job, fmt.Errorf("%w: %s is %s", ErrExportNotReady, jobID, job.Status)
+	}
+	archive, err := e.config.Archives.Open(ctx, jobID)
+	if err != nil {
+		return nil, job, err
+	}
+	return archive, job, nil
+}
+
+// Sweep 보관 기간이 지난 아카이브를 지우고 지운 수를 반환합니다
+// 작업 기록은 만료 상태로 남기고, 만료 뒤 보관 기간이 한 번 더 지나면 기록도 지웁니다
+func (e *Exporter) Sweep(ctx context.Context) (int, error) {
+	now := e.config.Now()
+	e.mu.Lock()
+	var expired []string
+	for id, job := range e.jobs {
+		switch {
+		case job.Status == ExportCompleted && !now.Before(job.ExpiresAt):
+			job.Status = ExportExpired
+			expired = append(expired, id)
+		case (job.Status == ExportExpired || job.Status == ExportFailed) && now.Sub(job.RequestedAt) >= 2*e.config.Retention:
+			delete(e.jobs, id)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, id := range expired {
+		if err := e.config.Archives.Delete(ctx, id); err != nil {
+			return 0, fmt.Errorf("failed to delete export archive %s: %w", id, err)
+		}
+	}
+	return len(expired), nil
+}
+
+// Forget 사용자의 끝난 작업 기록과 아카이브를 지우고 지운 작업 수를 반환합니다 (데이터 삭제 요청용)
+// 실행 중인 작업은 남기므로, 끝난 뒤 다시 호출해야 합니다
+func (e *Exporter) Forget(ctx context.Context, userID string) (int, error) {
+	e.mu.RLock()
+	var forgotten []string
+	for id, job := range e.jobs {
+		if job.UserID == userID && !job.active() {
+			forgotten = append(forgotten, id)
+		}
+	}
+	e.mu.RUnlock()
+
+	// 아카이브를 먼저 지워야 실패했을 때 다시 호출해도 남는 것이 없습니다
+	for _, id := range forgotten {
+		if err := e.config.Archives.Delete(ctx, id); err != nil {
+			return 0, fmt.Errorf("failed to delete export archive %s: %w", id, err)
+		}
+	}
+	e.mu.Lock()
+	for _, id := range forgotten {
+		delete(e.jobs, id)
+	}
+	e.mu.Unlock()
+	return len(forgotten), nil
+}
+
+// Wait 실행 중인 작업이 모두 끝날 때까지 기다립니다 (종료, 테스트용)
+func (e *Exporter) Wait() {
+	e.wg.Wait()
+}
+
+// run 작업 하나를 실행합니다 (요청 컨텍스트가 끝나도 계속 실행)
+func (e *Exporter) run(jobID, userID string) {
+	defer e.wg.Done()
+	e.slots <- struct{}{}
+	defer func() { <-e.slots }()
+
+	ctx := context.Background()
+	e.update(jobID, func(job *ExportJob) {
+		job.Status, job.StartedAt = ExportRunning, e.config.Now()
+	})
+
+	archive, manifest, err := buildArchive(ctx, jobID, userID, e.config.Collectors, e.config.Now())
+	if err == nil {
+		err = e.config.Archives.Save(ctx, jobID, archive)
+	}
+	if err != nil {
+		log.Printf("[Privacy] Export %s for %s failed: %v", jobID, userID, err)
+		e.update(jobID, func(job *ExportJob) {
+			job.Status, job.Error, job.CompletedAt = ExportFailed, err.Error(), e.config.Now()
+		})
+		return
+	}
+
+	now := e.config.Now()
+	e.update(jobID, func(job *ExportJob) {
+		job.Status = ExportCompleted
+		job.CompletedAt = now
+		job.ExpiresAt = now.Add(e.config.Retention)
+		job.Size = int64(len(archive))
+		job.Sections = manifest.Sections
+	})
+	log.Printf("[Privacy] Export %s for %s completed (%d bytes)", jobID, userID, len(archive))
+}
+
+func (e *Exporter) update(jobID string, apply func(job *ExportJob)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if job, ok := e.jobs[jobID]; ok {
+		apply(job)
+	}
+}
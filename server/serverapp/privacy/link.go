@@ -0,0 +1,60 @@
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidLink 서명이 맞지 않거나 만료된 다운로드 링크
+var ErrInvalidLink = errors.New("download link is invalid or expired")
+
+// LinkSigner 인증 없이 열 수 있는 만료 다운로드 링크를 HMAC으로 서명합니다
+// 링크는 작업 ID와 만료 시각만 담으므로 메일 등으로 보내도 다른 작업에 쓸 수 없습니다
+type LinkSigner struct {
+	key []byte
+}
+
+// NewLinkSigner 새로운 LinkSigner를 생성합니다 (키는 16바이트 이상)
+func NewLinkSigner(key []byte) (*LinkSigner, error) {
+	if len(key) < 16 {
+		return nil, errors.New("download link signing key must be at least 16 bytes")
+	}
+	return &LinkSigner{key: key}, nil
+}
+
+// Query 작업 ID와 만료 시각에 서명한 쿼리 (id, expires, signature)
+func (s *LinkSigner) Query(jobID string, expiresAt time.Time) url.Values {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return url.Values{
+		"id":        {jobID},
+		"expires":   {expires},
+		"signature": {s.sign(jobID, expires)},
+	}
+}
+
+// Verify 쿼리의 서명과 만료를 확인하고 작업 ID를 반환합니다
+func (s *LinkSigner) Verify(query url.Values, now time.Time) (string, error) {
+	jobID, expires, signature := query.Get("id"), query.Get("expires"), query.Get("signature")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if jobID == "" || err != nil {
+		return "", ErrInvalidLink
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(jobID, expires))) {
+		return "", ErrInvalidLink
+	}
+	if !now.Before(time.Unix(unix, 0)) {
+		return "", ErrInvalidLink
+	}
+	return jobID, nil
+}
+
+func (s *LinkSigner) sign(jobID, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(jobID + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,434 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"cqrs"
+	"cqrs/cqrsx"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock 테스트에서 시간을 직접 움직이는 시계
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// sliceEventSource 메모리 이벤트 목록을 내보내는 EventExportSource
+type sliceEventSource []*cqrsx.ExportedEvent
+
+func (s sliceEventSource) ExportEvents(ctx context.Context, filter cqrsx.EventExportFilter, fn func(*cqrsx.ExportedEvent) error) error {
+	for _, event := range s {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var testEvents = sliceEventSource{
+	{EventID: "e1", EventType: "LoginCheckedIn", AggregateID: "alice", AggregateType: "LoginReward", Version: 1},
+	{EventID: "e2", EventType: "TradeCompleted", AggregateID: "trade-1", AggregateType: "Trade", Version: 1, Data: map[string]interface{}{"buyer": "bob", "seller": "alice"}},
+	{EventID: "e3", EventType: "GuildJoined", AggregateID: "guild-1", AggregateType: "Guild", Version: 2, Metadata: map[string]interface{}{cqrs.MetadataUserID: "alice"}},
+	{EventID: "e4", EventType: "WalletCredited", AggregateID: "alicent", AggregateType: "Wallet", Version: 1, Data: map[string]interface{}{"counterparty": "wallet:alicent"}},
+	{EventID: "e5", EventType: "LoginCheckedIn", AggregateID: "bob", AggregateType: "LoginReward", Version: 1},
+}
+
+// readArchive zip 아카이브를 파일 이름 -> 줄 목록으로 읽습니다
+func readArchive(t *testing.T, archive []byte) map[string][]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	files := make(map[string][]string)
+	for _, file := range reader.File {
+		opened, err := file.Open()
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(opened)
+		files[file.Name] = []string{}
+		for scanner.Scan() {
+			files[file.Name] = append(files[file.Name], scanner.Text())
+		}
+		require.NoError(t, opened.Close())
+	}
+	return files
+}
+
+func newTestCollectors(t *testing.T) []Collector {
+	t.Helper()
+	ctx := context.Background()
+	store := cqrs.NewInMemoryReadStore()
+	require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel("alice", "PlayerProfile", map[string]interface{}{"nickname": "Alice"})))
+	require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel("guild-1", "GuildRoster", map[string]interface{}{"members": []interface{}{"alice", "bob"}})))
+	require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel("bob", "PlayerProfile", map[string]interface{}{"nickname": "Bob"})))
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	require.NoError(t, client.Set(ctx, "session:alice", `{"device":"ios"}`, time.Hour).Err())
+	require.NoError(t, client.HSet(ctx, "presence:alice", "status", "online").Err())
+	require.NoError(t, client.Set(ctx, "session:bob", `{"device":"android"}`, 0).Err())
+	_, err := NewRedisCacheCollector(client, "session:*")
+	require.Error(t, err)
+	cache, err := NewRedisCacheCollector(client, "session:{user_id}", "presence:{user_id}")
+	require.NoError(t, err)
+
+	return []Collector{
+		NewEventCollector(testEvents, cqrsx.EventExportFilter{}),
+		NewReadModelCollector(store, "PlayerProfile", "GuildRoster"),
+		cache,
+	}
+}
+
+func TestReferences_MatchesWholeSegments(t *testing.T) {
+	assert.True(t, References("alice", "alice"))
+	assert.True(t, References("wallet:alice", "alice"))
+	assert.True(t, References(map[string]interface{}{"members": []interface{}{"bob", "login:alice:2025-01-01"}}, "alice"))
+	assert.False(t, References("alicent", "alice"))
+	assert.False(t, References("wallet:alicent", "alice"))
+	assert.False(t, References(map[string]interface{}{"note": "alice was here"}, "alice"))
+	assert.False(t, References("", ""))
+}
+
+func TestExporter_CollectsUserDataIntoArchive(t *testing.T) {
+	// Arrange
+	clock := &testClock{now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	exporter, err := NewExporter(ExporterConfig{Collectors: newTestCollectors(t), Retention: 24 * time.Hour, Now: clock.Now})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	job, err := exporter.Request(ctx, "alice")
+	require.NoError(t, err)
+	exporter.Wait()
+	completed, err := exporter.Job(job.ID)
+	require.NoError(t, err)
+	archive, _, err := exporter.Open(ctx, job.ID)
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	clock.Advance(24 * time.Hour)
+	swept, err := exporter.Sweep(ctx)
+	require.NoError(t, err)
+	_, _, expiredErr := exporter.Open(ctx, job.ID)
+
+	// Assert
+	assert.Equal(t, ExportCompleted, completed.Status, completed.Error)
+	assert.Equal(t, int64(len(content)), completed.Size)
+	files := readArchive(t, content)
+	assert.Len(t, files["events.jsonl"], 3)
+	for _, line := range files["events.jsonl"] {
+		assert.NotContains(t, line, "alicent")
+		assert.NotContains(t, line, `"e5"`)
+	}
+	assert.Len(t, files["read_models.jsonl"], 2)
+	require.Len(t, files["cache.jsonl"], 2)
+	assert.Contains(t, files["cache.jsonl"][0], `"ttl_seconds":3600`)
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal([]byte(strings.Join(files["manifest.json"], "\n")), &manifest))
+	assert.Equal(t, "alice", manifest.UserID)
+	assert.Equal(t, []SectionSummary{
+		{Name: "events", File: "events.jsonl", Records: 3},
+		{Name: "read_models", File: "read_models.jsonl", Records: 2},
+		{Name: "cache", File: "cache.jsonl", Records: 2},
+	}, manifest.Sections)
+	assert.Equal(t, 1, swept)
+	assert.ErrorIs(t, expiredErr, ErrExportNotReady)
+}
+
+func TestPrivacyApp_ExpiringDownloadLinks(t *testing.T) {
+	// Arrange
+	clock := &testClock{now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	app, err := NewPrivacyApp(Config{
+		Exporter:   ExporterConfig{Collectors: newTestCollectors(t), Now: clock.Now},
+		SigningKey: []byte("0123456789abcdef0123456789abcdef"),
+		LinkTTL:    time.Hour,
+		Identify:   func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if user != "" {
+			req.Header.Set("X-User", user)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act
+	requested := request(http.MethodPost, "/privacy/exports", "alice")
+	var job ExportResponse
+	require.NoError(t, json.Unmarshal(requested.Body.Bytes(), &job))
+	app.Exporter().Wait()
+	status := request(http.MethodGet, "/privacy/exports?id="+job.ID, "alice")
+	otherUser := request(http.MethodGet, "/privacy/exports?id="+job.ID, "bob")
+	var completed ExportResponse
+	require.NoError(t, json.Unmarshal(status.Body.Bytes(), &completed))
+	downloaded := request(http.MethodGet, completed.DownloadURL, "")
+	tampered := request(http.MethodGet, strings.Replace(completed.DownloadURL, "id="+job.ID, "id=other", 1), "")
+	clock.Advance(2 * time.Hour)
+	expiredLink := request(http.MethodGet, completed.DownloadURL, "")
+	refreshed := request(http.MethodGet, "/privacy/exports", "alice")
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, requested.Code)
+	require.Equal(t, http.StatusOK, status.Code)
+	assert.Equal(t, ExportCompleted, completed.Status)
+	assert.Equal(t, http.StatusNotFound, otherUser.Code)
+	require.Equal(t, http.StatusOK, downloaded.Code, downloaded.Body.String())
+	assert.Equal(t, "application/zip", downloaded.Header().Get("Content-Type"))
+	assert.Contains(t, readArchive(t, downloaded.Body.Bytes()), "manifest.json")
+	assert.Equal(t, http.StatusForbidden, tampered.Code)
+	assert.Equal(t, http.StatusForbidden, expiredLink.Code)
+	var jobs []ExportResponse
+	require.NoError(t, json.Unmarshal(refreshed.Body.Bytes(), &jobs))
+	require.Len(t, jobs, 1)
+	assert.NotEqual(t, completed.DownloadURL, jobs[0].DownloadURL)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, jobs[0].DownloadURL, "").Code)
+}
+
+// rewritableEvents 페이로드를 고칠 수 있는 메모리 이벤트 저장소 (EventRewriter)
+type rewritableEvents struct {
+	mu     sync.Mutex
+	events []*cqrsx.ExportedEvent
+}
+
+func (s *rewritableEvents) ExportEvents(ctx context.Context, filter cqrsx.EventExportFilter, fn func(*cqrsx.ExportedEvent) error) error {
+	s.mu.Lock()
+	events := append([]*cqrsx.ExportedEvent(nil), s.events...)
+	s.mu.Unlock()
+	return sliceEventSource(events).ExportEvents(ctx, filter, fn)
+}
+
+func (s *rewritableEvents) RewriteEventPayload(ctx context.Context, eventID string, data, metadata map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, event := range s.events {
+		if event.EventID == eventID {
+			rewritten := *event
+			rewritten.Data, rewritten.Metadata = data, metadata
+			s.events[i] = &rewritten
+			return nil
+		}
+	}
+	return cqrs.NewCQRSError(cqrs.ErrCodeNotFoundError.String(), "event not found", nil)
+}
+
+// guildRoster 스스로 가명 처리하는 길드 명단 읽기 모델
+type guildRoster struct {
+	*cqrs.BaseReadModel
+	Members []string `json:"members"`
+}
+
+func (r *guildRoster) AnonymizeUser(userID, pseudonym string) bool {
+	changed := false
+	for i, member := range r.Members {
+		if member == userID {
+			r.Members[i], changed = pseudonym, true
+		}
+	}
+	return changed
+}
+
+func TestAnonymize_ReplacesWholeSegments(t *testing.T) {
+	value := map[string]interface{}{
+		"alice":   1,
+		"members": []interface{}{"bob", "alice"},
+		"key":     "login:alice:2025-01-01",
+		"note":    "alicent",
+	}
+
+	anonymized, changed := Anonymize(value, "alice", "erased-1")
+
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{
+		"erased-1": 1,
+		"members":  []interface{}{"bob", "erased-1"},
+		"key":      "login:erased-1:2025-01-01",
+		"note":     "alicent",
+	}, anonymized)
+	assert.Equal(t, []interface{}{"bob", "alice"}, value["members"], "원본은 바뀌지 않아야 합니다")
+	_, changed = Anonymize("wallet:alicent", "alice", "erased-1")
+	assert.False(t, changed)
+}
+
+func TestEraser_ResumesFromFailedStep(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	vault := NewInMemoryKeyVault()
+	credentials, err := vault.Encrypt(ctx, "alice", []byte("password-hash"))
+	require.NoError(t, err)
+	events := &rewritableEvents{events: []*cqrsx.ExportedEvent{
+		{EventID: "e1", EventType: "GuildMemberJoined", AggregateID: "guild-1", Data: map[string]interface{}{"member_id": "alice"}, Metadata: map[string]interface{}{cqrs.MetadataUserID: "alice"}},
+		{EventID: "e2", EventType: "GuildMemberJoined", AggregateID: "guild-1", Data: map[string]interface{}{"member_id": "bob"}},
+		{EventID: "e3", EventType: "GuildChatPosted", AggregateID: "guild-1", Data: map[string]interface{}{"author": "guild-1:alice"}},
+	}}
+	store := cqrs.NewInMemoryReadStore()
+	require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel("alice", "PlayerProfile", map[string]interface{}{"nickname": "Alice"})))
+	require.NoError(t, store.Save(ctx, &guildRoster{BaseReadModel: cqrs.NewBaseReadModel("guild-1", "GuildRoster", map[string]interface{}{"name": "Defenders"}), Members: []string{"alice", "bob"}}))
+	require.NoError(t, store.Save(ctx, cqrs.NewBaseReadModel("board-1", "Leaderboard", map[string]interface{}{"top": []interface{}{"alice"}})))
+	profileCalls := 0
+	profiles := NewFuncStep("profile_store", func(ctx context.Context, subject ErasureSubject) ([]ErasureAction, error) {
+		profileCalls++
+		if profileCalls == 1 {
+			return nil, errors.New("profile store unavailable")
+		}
+		return []ErasureAction{{Kind: ActionDeleted, Target: "profile", Count: 1}}, nil
+	})
+	eraser, err := NewEraser(EraserConfig{
+		Steps: []ErasureStep{
+			NewCryptoShredStep(vault, "credentials"),
+			profiles,
+			NewEventAnonymizationStep(events, cqrsx.EventExportFilter{}),
+			NewReadModelErasureStep(store, []string{"PlayerProfile"}, []string{"GuildRoster", "Leaderboard"}),
+		},
+		PseudonymKey: []byte("0123456789abcdef"),
+		Now:          clock.Now,
+	})
+	require.NoError(t, err)
+
+	// Act
+	requested, err := eraser.Request(ctx, "alice")
+	require.NoError(t, err)
+	eraser.Wait()
+	failed, err := eraser.Report(ctx, requested.ID)
+	require.NoError(t, err)
+	_, err = eraser.Resume(ctx, requested.ID)
+	require.NoError(t, err)
+	eraser.Wait()
+	report, err := eraser.Report(ctx, requested.ID)
+	require.NoError(t, err)
+	record, err := eraser.Get(ctx, requested.ID)
+	require.NoError(t, err)
+	_, resumeCompletedErr := eraser.Resume(ctx, requested.ID)
+
+	// Assert
+	assert.Equal(t, ErasureFailed, failed.Status)
+	assert.Equal(t, []string{"crypto_shred"}, failed.Steps)
+	assert.Equal(t, []string{"profile_store", "events", "read_models"}, failed.PendingSteps)
+	assert.Contains(t, failed.Error, "profile store unavailable")
+
+	require.Equal(t, ErasureCompleted, report.Status, report.Error)
+	assert.Equal(t, requested.Pseudonym, report.Pseudonym)
+	assert.Empty(t, report.PendingSteps)
+	assert.True(t, report.RequiresReview)
+	assert.Equal(t, []ErasureAction{
+		{Step: "crypto_shred", Kind: ActionCryptoShredded, Target: "credentials", Count: 1},
+		{Step: "profile_store", Kind: ActionDeleted, Target: "profile", Count: 1},
+		{Step: "events", Kind: ActionAnonymized, Target: "event GuildChatPosted", Count: 1},
+		{Step: "events", Kind: ActionAnonymized, Target: "event GuildMemberJoined", Count: 1},
+		{Step: "read_models", Kind: ActionDeleted, Target: "read model PlayerProfile", Count: 1},
+		{Step: "read_models", Kind: ActionAnonymized, Target: "read model GuildRoster", Count: 1},
+		{Step: "read_models", Kind: ActionManualReview, Target: "read model Leaderboard/board-1", Count: 1},
+	}, report.Actions)
+	assert.Empty(t, record.UserID, "완료된 기록에는 사용자 ID가 남지 않아야 합니다")
+	assert.Equal(t, 2, record.Attempts)
+	assert.Equal(t, 2, profileCalls)
+	assert.ErrorIs(t, resumeCompletedErr, ErrErasureNotResumable)
+
+	_, err = vault.Decrypt(ctx, "alice", credentials)
+	assert.ErrorIs(t, err, ErrKeyShredded)
+	assert.Equal(t, "erased-", requested.Pseudonym[:7])
+	assert.Equal(t, requested.Pseudonym, events.events[0].Data["member_id"])
+	assert.Equal(t, requested.Pseudonym, events.events[0].Metadata[cqrs.MetadataUserID])
+	assert.Equal(t, "bob", events.events[1].Data["member_id"])
+	assert.Equal(t, "guild-1:"+requested.Pseudonym, events.events[2].Data["author"])
+	_, err = store.GetByID(ctx, "alice", "PlayerProfile")
+	assert.True(t, cqrs.IsNotFoundError(err))
+	roster, err := store.GetByID(ctx, "guild-1", "GuildRoster")
+	require.NoError(t, err)
+	assert.Equal(t, []string{requested.Pseudonym, "bob"}, roster.(*guildRoster).Members)
+}
+
+func TestPrivacyApp_ErasureRoutesRequireAdmin(t *testing.T) {
+	// Arrange
+	config := Config{
+		Exporter:   ExporterConfig{Collectors: newTestCollectors(t)},
+		SigningKey: []byte("0123456789abcdef0123456789abcdef"),
+		Identify:   func(r *http.Request) string { return r.Header.Get("X-User") },
+		Erasure: &EraserConfig{
+			Steps:        []ErasureStep{NewCryptoShredStep(NewInMemoryKeyVault(), "credentials")},
+			PseudonymKey: []byte("0123456789abcdef"),
+		},
+	}
+	_, missingAdminErr := NewPrivacyApp(config)
+	config.AdminAuth = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Admin") != "true" {
+				sendError(w, http.StatusForbidden, "admin only")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	app, err := NewPrivacyApp(config)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, body string, admin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-User", "alice")
+		if admin {
+			req.Header.Set("X-Admin", "true")
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+	request(http.MethodPost, "/privacy/exports", "", false)
+	app.Exporter().Wait()
+
+	// Act
+	forbidden := request(http.MethodPost, "/privacy/erasures", `{"user_id":"alice"}`, false)
+	requested := request(http.MethodPost, "/privacy/erasures", `{"user_id":"alice"}`, true)
+	var record ErasureRecord
+	require.NoError(t, json.Unmarshal(requested.Body.Bytes(), &record))
+	app.Eraser().Wait()
+	reported := request(http.MethodGet, "/privacy/erasures?id="+record.ID, "", true)
+	var report ComplianceReport
+	require.NoError(t, json.Unmarshal(reported.Body.Bytes(), &report))
+	resumed := request(http.MethodPost, "/privacy/erasures/resume?id="+record.ID, "", true)
+	missing := request(http.MethodGet, "/privacy/erasures?id=unknown", "", true)
+
+	// Assert
+	assert.Error(t, missingAdminErr)
+	assert.Equal(t, http.StatusForbidden, forbidden.Code)
+	assert.Equal(t, http.StatusAccepted, requested.Code)
+	require.Equal(t, http.StatusOK, reported.Code)
+	assert.Equal(t, ErasureCompleted, report.Status)
+	assert.Equal(t, []string{"crypto_shred", "exports"}, report.Steps)
+	assert.Contains(t, report.Actions, ErasureAction{Step: "exports", Kind: ActionDeleted, Target: "export archives", Count: 1})
+	assert.NotContains(t, reported.Body.String(), "alice")
+	assert.Empty(t, app.Exporter().Jobs("alice"))
+	assert.Equal(t, http.StatusConflict, resumed.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+}
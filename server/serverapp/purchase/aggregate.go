@@ -0,0 +1,191 @@
+package purchase
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// PurchaseAggregateType 결제 애그리게이트 타입 (애그리게이트 ID는 PurchaseID(스토어, 거래 ID))
+// 거래 ID마다 애그리게이트가 하나이므로 같은 영수증을 여러 번 보내도 결제는 한 번만 기록됩니다
+const PurchaseAggregateType = "Purchase"
+
+// 결제 이벤트 타입
+const (
+	PurchaseCompletedEventType = "PurchaseCompleted"
+	PurchaseGrantedEventType   = "PurchaseGranted" // 아이템, 광물 지급까지 끝남
+)
+
+var (
+	ErrInvalidPurchase    = errors.New("invalid purchase")
+	ErrUnknownProduct     = errors.New("unknown product")
+	ErrTransactionClaimed = errors.New("transaction was already redeemed by another user")
+	ErrSandboxPurchase    = errors.New("sandbox purchases are not accepted")
+	ErrPurchaseNotFound   = errors.New("purchase not found")
+	ErrStoreRequired      = eventstore.ErrStoreRequired
+)
+
+// PurchaseID 결제 애그리게이트 ID
+func PurchaseID(store, transactionID string) string {
+	return store + ":" + transactionID
+}
+
+// Grant 상품 하나를 사면 받는 것
+type Grant struct {
+	Entitlements []string         `json:"entitlements,omitempty"` // 광고 제거, 시즌 패스 등 영구 권한
+	Items        map[string]int64 `json:"items,omitempty"`        // 아이템 ID -> 수량
+	Minerals     map[string]int64 `json:"minerals,omitempty"`     // 광물 종류 -> 양
+	Currency     int64            `json:"currency,omitempty"`     // 프리미엄 재화 (지갑에 적립)
+}
+
+// 결제 이벤트 데이터
+type (
+	PurchaseCompletedData struct {
+		UserID      string           `json:"user_id"`
+		Purchase    VerifiedPurchase `json:"purchase"`
+		Grant       Grant            `json:"grant"`
+		CompletedAt time.Time        `json:"completed_at"`
+	}
+	PurchaseGrantedData struct {
+		UserID    string    `json:"user_id"`
+		GrantedAt time.Time `json:"granted_at"`
+	}
+)
+
+// PurchaseEvent 결제 애그리게이트 이벤트
+type PurchaseEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *PurchaseEvent) EventData() interface{} {
+	return e.data
+}
+
+// PurchaseAggregate 스토어 거래 하나
+// 처음 검증한 사용자에게 묶이며, 다른 사용자가 같은 영수증을 보내면 거절합니다
+type PurchaseAggregate struct {
+	*cqrs.BaseAggregate
+	userID   string
+	purchase VerifiedPurchase
+	grant    Grant
+	granted  bool
+}
+
+// NewPurchaseAggregate 새로운 PurchaseAggregate를 생성합니다
+func NewPurchaseAggregate(purchaseID string) *PurchaseAggregate {
+	return &PurchaseAggregate{BaseAggregate: cqrs.NewBaseAggregate(purchaseID, PurchaseAggregateType)}
+}
+
+// Exists 결제가 기록되었는지 확인합니다
+func (a *PurchaseAggregate) Exists() bool {
+	return a.userID != ""
+}
+
+// UserID 결제한 사용자
+func (a *PurchaseAggregate) UserID() string {
+	return a.userID
+}
+
+// Purchase 스토어가 확인한 결제
+func (a *PurchaseAggregate) Purchase() VerifiedPurchase {
+	return a.purchase
+}
+
+// Grant 지급할 (또는 지급한) 것
+func (a *PurchaseAggregate) Grant() Grant {
+	return a.grant
+}
+
+// Granted 아이템, 광물 지급이 끝났는지 확인합니다
+func (a *PurchaseAggregate) Granted() bool {
+	return a.granted
+}
+
+// Complete 검증된 결제를 기록합니다
+// 같은 사용자가 다시 보낸 영수증이면 아무것도 하지 않고 true를 반환합니다
+func (a *PurchaseAggregate) Complete(userID string, purchase VerifiedPurchase, grant Grant, now time.Time) (bool, error) {
+	if a.Exists() {
+		if a.userID != userID {
+			return false, ErrTransactionClaimed
+		}
+		return true, nil
+	}
+	if userID == "" || purchase.Store == "" || purchase.TransactionID == "" || purchase.ProductID == "" {
+		return false, fmt.Errorf("%w: user, store, transaction and product are required", ErrInvalidPurchase)
+	}
+	return false, a.raise(PurchaseCompletedEventType, PurchaseCompletedData{
+		UserID:      userID,
+		Purchase:    purchase,
+		Grant:       grant,
+		CompletedAt: now,
+	})
+}
+
+// MarkGranted 아이템, 광물 지급이 끝났음을 기록합니다
+func (a *PurchaseAggregate) MarkGranted(now time.Time) error {
+	if !a.Exists() {
+		return ErrPurchaseNotFound
+	}
+	if a.granted {
+		return nil
+	}
+	return a.raise(PurchaseGrantedEventType, PurchaseGrantedData{UserID: a.userID, GrantedAt: now})
+}
+
+// LoadFromHistory 이벤트 스트림에서 결제 상태를 복원합니다
+func (a *PurchaseAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *PurchaseAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := purchaseEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown purchase event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *PurchaseAggregate) raise(eventType string, data interface{}) error {
+	event := &PurchaseEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *PurchaseAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case PurchaseCompletedData:
+		a.userID = data.UserID
+		a.purchase = data.Purchase
+		a.grant = data.Grant
+	case PurchaseGrantedData:
+		a.granted = true
+	}
+}
+
+// purchaseEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var purchaseEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	PurchaseCompletedEventType: eventstore.DecodeEventData[PurchaseCompletedData],
+	PurchaseGrantedEventType:   eventstore.DecodeEventData[PurchaseGrantedData],
+}
@@ -0,0 +1,196 @@
+package purchase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 결제 기본 경로
+const DefaultBasePath = "/purchases"
+
+// maxRequestBodySize 요청 본문 최대 크기 (Apple 영수증은 수십 KB까지 커질 수 있음)
+const maxRequestBodySize = 256 << 10
+
+// Config 결제 서버앱 설정
+type Config struct {
+	BasePath     string                          // 라우트 기본 경로 (기본값: /purchases)
+	Service      ServiceConfig                   // 결제 서비스 설정
+	Entitlements *EntitlementProjection          // 선택: 설정하면 권한 조회 라우트 제공 (Service.EventBus를 구독시켜야 함)
+	Auth         func(http.Handler) http.Handler // 선택: 사용자 인증 미들웨어
+	Identify     func(r *http.Request) string    // 필수: 결제한 사용자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to bind purchases to users")
+	}
+	if len(c.Service.Validators) == 0 {
+		return errors.New("at least one receipt validator is required")
+	}
+	return nil
+}
+
+// PurchaseApp 인앱 결제 영수증을 검증하고 상품을 지급하는 서버앱
+type PurchaseApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewPurchaseApp 새로운 PurchaseApp을 생성합니다
+func NewPurchaseApp(config Config) (*PurchaseApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &PurchaseApp{
+		BaseApp: serverapp.NewBaseApp("purchase"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 결제 서비스
+func (a *PurchaseApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *PurchaseApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/redeem", protect(http.HandlerFunc(a.redeem)))
+	if a.config.Entitlements != nil {
+		mux.Handle(base+"/entitlements", protect(http.HandlerFunc(a.entitlements)))
+	}
+
+	log.Printf("[Purchase] Routes registered under %s", base)
+}
+
+// DescribeAPI 결제 엔드포인트 설명 (/openapi.json)
+func (a *PurchaseApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	operations := []serverapp.APIOperation{
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/redeem",
+			Summary:     "스토어 영수증 검증과 상품 지급",
+			Description: "같은 영수증을 다시 보내도 한 번만 지급하며 duplicate가 true로 돌아옵니다. 502 응답은 스토어 통신 오류이므로 같은 영수증으로 재시도합니다.",
+			Request:     Receipt{},
+			Response:    PurchaseResult{},
+			Secured:     secured,
+		},
+	}
+	if a.config.Entitlements != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:   http.MethodGet,
+			Path:     base + "/entitlements",
+			Summary:  "내 권한 목록",
+			Response: []Entitlement{},
+			Secured:  secured,
+		})
+	}
+	return operations
+}
+
+func (a *PurchaseApp) redeem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var receipt Receipt
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&receipt); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	result, err := a.service.Redeem(r.Context(), a.config.Identify(r), receipt)
+	if err != nil {
+		log.Printf("[Purchase] Failed to redeem %s receipt: %v", receipt.Store, err)
+		sendError(w, http.StatusBadGateway, "purchase could not be completed, retry later")
+		return
+	}
+	if !result.Success {
+		sendError(w, statusForError(result.Error), result.Error.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, result.Data)
+}
+
+func (a *PurchaseApp) entitlements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	view, err := a.config.Entitlements.View(r.Context(), userID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, view.List())
+}
+
+// HasEntitlement 사용자가 권한을 가졌는지 확인합니다 (다른 서버앱에서 광고 제거 등 확인용)
+func (a *PurchaseApp) HasEntitlement(ctx context.Context, userID, name string) (bool, error) {
+	if a.config.Entitlements == nil {
+		return false, errors.New("entitlement projection is not configured")
+	}
+	view, err := a.config.Entitlements.View(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return view.Has(name), nil
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrTransactionClaimed), errors.Is(err, ErrPurchasePending):
+		return http.StatusConflict
+	case errors.Is(err, ErrSandboxPurchase):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidReceipt), errors.Is(err, ErrUnknownProduct), errors.Is(err, ErrUnsupportedStore), errors.Is(err, ErrInvalidPurchase):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
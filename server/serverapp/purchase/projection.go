@@ -0,0 +1,140 @@
+package purchase
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// EntitlementViewType 권한 읽기 모델 타입 (읽기 모델 ID는 사용자 ID)
+const EntitlementViewType = "PurchaseEntitlements"
+
+// Entitlement 사용자가 가진 권한 하나
+type Entitlement struct {
+	Name       string    `json:"name"`
+	PurchaseID string    `json:"purchase_id"` // 권한을 처음 준 결제
+	ProductID  string    `json:"product_id"`
+	GrantedAt  time.Time `json:"granted_at"`
+}
+
+// EntitlementView 사용자 한 명의 권한
+type EntitlementView struct {
+	*cqrs.BaseReadModel
+	UserID       string                 `json:"user_id"`
+	Entitlements map[string]Entitlement `json:"entitlements"`
+}
+
+// NewEntitlementView 새로운 EntitlementView를 생성합니다
+func NewEntitlementView(userID string) *EntitlementView {
+	return &EntitlementView{
+		BaseReadModel: cqrs.NewBaseReadModel(userID, EntitlementViewType, map[string]interface{}{}),
+		UserID:        userID,
+		Entitlements:  make(map[string]Entitlement),
+	}
+}
+
+// Has 권한이 있는지 확인합니다
+func (v *EntitlementView) Has(name string) bool {
+	_, ok := v.Entitlements[name]
+	return ok
+}
+
+// List 이름 순으로 정렬한 권한 목록
+func (v *EntitlementView) List() []Entitlement {
+	list := make([]Entitlement, 0, len(v.Entitlements))
+	for _, entitlement := range v.Entitlements {
+		list = append(list, entitlement)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// GetData 읽기 모델 데이터
+func (v *EntitlementView) GetData() interface{} {
+	return map[string]interface{}{
+		"user_id":      v.UserID,
+		"entitlements": v.List(),
+	}
+}
+
+// EntitlementProjection PurchaseCompleted 이벤트로 사용자 권한을 갱신합니다
+// 권한은 처음 받은 결제만 기록하므로 같은 이벤트를 다시 받아도 바뀌지 않습니다
+type EntitlementProjection struct {
+	*cqrs.BaseEventHandler
+	store cqrs.ReadStore
+
+	mu sync.Mutex // 같은 사용자 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewEntitlementProjection 새로운 EntitlementProjection을 생성합니다
+func NewEntitlementProjection(store cqrs.ReadStore) *EntitlementProjection {
+	return &EntitlementProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler("purchase-entitlements", cqrs.ProjectionHandler, []string{PurchaseCompletedEventType}),
+		store:            store,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *EntitlementProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// View 사용자의 권한 (없으면 빈 읽기 모델)
+func (p *EntitlementProjection) View(ctx context.Context, userID string) (*EntitlementView, error) {
+	view, err := cqrs.LoadReadModel[*EntitlementView](ctx, p.store, userID, EntitlementViewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewEntitlementView(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entitlements of %s: %w", userID, err)
+	}
+	return view, nil
+}
+
+// Handle 결제 이벤트를 적용합니다
+func (p *EntitlementProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	if event.EventType() != PurchaseCompletedEventType {
+		return nil
+	}
+	decoded, err := eventstore.DecodeEventData[PurchaseCompletedData](event.EventData())
+	if err != nil {
+		return err
+	}
+	data := decoded.(PurchaseCompletedData)
+	if len(data.Grant.Entitlements) == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return cqrs.UpsertReadModel(ctx, p.store, data.UserID, EntitlementViewType,
+		func() *EntitlementView { return NewEntitlementView(data.UserID) },
+		func(view *EntitlementView) error {
+			for _, name := range data.Grant.Entitlements {
+				if view.Has(name) {
+					continue
+				}
+				view.Entitlements[name] = Entitlement{
+					Name:       name,
+					PurchaseID: event.AggregateID(),
+					ProductID:  data.Purchase.ProductID,
+					GrantedAt:  data.CompletedAt,
+				}
+			}
+			view.IncrementVersion()
+			return nil
+		})
+}
@@ -0,0 +1,367 @@
+package purchase
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+	"defense-allies-server/serverapp/trade"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeValidator 영수증 내용을 거래 ID로 그대로 돌려주는 검증기
+type fakeValidator struct {
+	environment string
+	err         error
+}
+
+func (v *fakeValidator) Validate(ctx context.Context, receipt Receipt) (VerifiedPurchase, error) {
+	if v.err != nil {
+		return VerifiedPurchase{}, v.err
+	}
+	environment := v.environment
+	if environment == "" {
+		environment = EnvironmentProduction
+	}
+	return VerifiedPurchase{
+		Store:         receipt.Store,
+		ProductID:     receipt.ProductID,
+		TransactionID: receipt.Payload,
+		Environment:   environment,
+		PurchasedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+// flakyGranter failures 횟수만큼 지급에 실패하는 Granter
+type flakyGranter struct {
+	Granter
+	failures int
+}
+
+func (g *flakyGranter) Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error {
+	if g.failures > 0 {
+		g.failures--
+		return errors.New("inventory unavailable")
+	}
+	return g.Granter.Grant(ctx, userID, grantID, amounts)
+}
+
+var testCatalog = map[string]Grant{
+	"starter_pack": {Entitlements: []string{"no_ads"}, Items: map[string]int64{"plasma-core": 2}, Minerals: map[string]int64{"crystal": 500}},
+	"crystal_1000": {Minerals: map[string]int64{"crystal": 1000}},
+}
+
+// purchaseFixture 결제 서비스와 인벤토리, 재화, 권한 프로젝션을 연결합니다
+type purchaseFixture struct {
+	readStore    cqrs.ReadStore
+	trades       *trade.Service
+	entitlements *EntitlementProjection
+	validator    *fakeValidator
+	config       ServiceConfig
+}
+
+func newPurchaseFixture(t *testing.T) *purchaseFixture {
+	t.Helper()
+	readStore := cqrs.NewInMemoryReadStore()
+	bus := testkit.StartedEventBus(t)
+	entitlements := NewEntitlementProjection(readStore)
+	_, err := entitlements.Subscribe(bus)
+	require.NoError(t, err)
+	trades, err := trade.NewService(trade.ServiceConfig{Store: eventstore.NewInMemoryEventStore()})
+	require.NoError(t, err)
+
+	fixture := &purchaseFixture{
+		readStore:    readStore,
+		trades:       trades,
+		entitlements: entitlements,
+		validator:    &fakeValidator{},
+	}
+	fixture.config = ServiceConfig{
+		Store:      eventstore.NewInMemoryEventStore(),
+		EventBus:   bus,
+		Validators: map[string]ReceiptValidator{StoreGoogle: fixture.validator, StoreApple: fixture.validator},
+		Catalog:    testCatalog,
+		Inventory:  trades.Inventory(),
+		Treasury:   trades.Treasury(),
+	}
+	return fixture
+}
+
+func (f *purchaseFixture) newService(t *testing.T) *Service {
+	t.Helper()
+	service, err := NewService(f.config)
+	require.NoError(t, err)
+	return service
+}
+
+// balance 보유량 스트림의 아이템 또는 광물 잔액
+func (f *purchaseFixture) balance(t *testing.T, part func(trade.Offer) map[string]int64, userID, key string) int64 {
+	t.Helper()
+	holdings, err := f.trades.LoadHoldings(context.Background(), userID)
+	require.NoError(t, err)
+	return part(holdings.Balances())[key]
+}
+
+func items(offer trade.Offer) map[string]int64    { return offer.Items }
+func minerals(offer trade.Offer) map[string]int64 { return offer.Minerals }
+
+func redeem(t *testing.T, service *Service, userID string, receipt Receipt) *cqrs.CommandResult {
+	t.Helper()
+	result, err := service.Redeem(context.Background(), userID, receipt)
+	require.NoError(t, err)
+	return result
+}
+
+func TestPurchaseAggregate_Rules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	verified := VerifiedPurchase{Store: StoreGoogle, ProductID: "starter_pack", TransactionID: "GPA.1", Environment: EnvironmentProduction}
+	purchase := NewPurchaseAggregate(PurchaseID(StoreGoogle, "GPA.1"))
+
+	assert.ErrorIs(t, purchase.MarkGranted(now), ErrPurchaseNotFound)
+	_, err := purchase.Complete("alice", VerifiedPurchase{Store: StoreGoogle}, Grant{}, now)
+	assert.ErrorIs(t, err, ErrInvalidPurchase)
+
+	duplicate, err := purchase.Complete("alice", verified, testCatalog["starter_pack"], now)
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+	duplicate, err = purchase.Complete("alice", verified, testCatalog["starter_pack"], now)
+	require.NoError(t, err)
+	assert.True(t, duplicate) // 같은 사용자가 다시 보낸 영수증
+	_, err = purchase.Complete("mallory", verified, testCatalog["starter_pack"], now)
+	assert.ErrorIs(t, err, ErrTransactionClaimed)
+
+	require.NoError(t, purchase.MarkGranted(now))
+	require.NoError(t, purchase.MarkGranted(now))
+	assert.Len(t, purchase.Changes(), 2)
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewPurchaseAggregate(purchase.ID())
+	require.NoError(t, restored.LoadFromHistory(purchase.Changes()))
+	assert.True(t, restored.Granted())
+	assert.Equal(t, "alice", restored.UserID())
+	assert.Equal(t, int64(2), restored.Grant().Items["plasma-core"])
+}
+
+func TestAppleValidator_FallsBackToSandbox(t *testing.T) {
+	// Arrange
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		var request map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "secret", request["password"])
+		if r.URL.Path == "/production" {
+			w.Write([]byte(`{"status": 21007}`))
+			return
+		}
+		w.Write([]byte(`{"status": 0, "environment": "Sandbox", "receipt": {"bundle_id": "com.defense.allies", "in_app": [
+			{"product_id": "crystal_1000", "transaction_id": "1000001", "purchase_date_ms": "1735689600000"},
+			{"product_id": "starter_pack", "transaction_id": "1000002", "purchase_date_ms": "1735689600000", "cancellation_date_ms": "1735693200000"}
+		]}}`))
+	}))
+	defer server.Close()
+	validator, err := NewAppleValidator(AppleConfig{
+		BundleID:      "com.defense.allies",
+		SharedSecret:  "secret",
+		ProductionURL: server.URL + "/production",
+		SandboxURL:    server.URL + "/sandbox",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	verified, err := validator.Validate(ctx, Receipt{Store: StoreApple, ProductID: "crystal_1000", Payload: "MIIT..."})
+	_, refundedErr := validator.Validate(ctx, Receipt{Store: StoreApple, ProductID: "starter_pack", Payload: "MIIT..."})
+	_, missingErr := validator.Validate(ctx, Receipt{Store: StoreApple, ProductID: "crystal_1000", Payload: "MIIT...", TransactionID: "999"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "1000001", verified.TransactionID)
+	assert.Equal(t, EnvironmentSandbox, verified.Environment)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), verified.PurchasedAt)
+	assert.ErrorIs(t, refundedErr, ErrInvalidReceipt)
+	assert.ErrorIs(t, missingErr, ErrInvalidReceipt)
+	assert.Equal(t, []string{"/production", "/sandbox"}, calls[:2])
+}
+
+func TestGoogleValidator_PurchaseStates(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer play-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/androidpublisher/v3/applications/com.defense.allies/purchases/products/crystal_1000/tokens/real":
+			w.Write([]byte(`{"orderId": "GPA.1", "purchaseState": 0, "purchaseTimeMillis": "1735689600000"}`))
+		case "/androidpublisher/v3/applications/com.defense.allies/purchases/products/crystal_1000/tokens/test":
+			w.Write([]byte(`{"orderId": "GPA.2", "purchaseState": 0, "purchaseType": 0, "purchaseTimeMillis": "1735689600000"}`))
+		case "/androidpublisher/v3/applications/com.defense.allies/purchases/products/crystal_1000/tokens/pending":
+			w.Write([]byte(`{"orderId": "GPA.3", "purchaseState": 2}`))
+		case "/androidpublisher/v3/applications/com.defense.allies/purchases/products/crystal_1000/tokens/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	validator, err := NewGoogleValidator(GoogleConfig{
+		PackageName: "com.defense.allies",
+		Token:       func(ctx context.Context) (string, error) { return "play-token", nil },
+		BaseURL:     server.URL,
+	})
+	require.NoError(t, err)
+	validate := func(token string) (VerifiedPurchase, error) {
+		return validator.Validate(context.Background(), Receipt{Store: StoreGoogle, ProductID: "crystal_1000", Payload: token})
+	}
+
+	// Act
+	real, realErr := validate("real")
+	test, testErr := validate("test")
+	_, pendingErr := validate("pending")
+	_, unknownErr := validate("forged")
+	_, downErr := validate("down")
+
+	// Assert
+	require.NoError(t, realErr)
+	assert.Equal(t, "GPA.1", real.TransactionID)
+	assert.Equal(t, EnvironmentProduction, real.Environment)
+	require.NoError(t, testErr)
+	assert.Equal(t, EnvironmentSandbox, test.Environment)
+	assert.ErrorIs(t, pendingErr, ErrPurchasePending)
+	assert.ErrorIs(t, unknownErr, ErrInvalidReceipt)
+	require.Error(t, downErr)
+	assert.NotErrorIs(t, downErr, ErrInvalidReceipt) // 스토어 장애는 재시도 대상
+}
+
+func TestService_RedeemGrantsOnce(t *testing.T) {
+	// Arrange
+	fixture := newPurchaseFixture(t)
+	service := fixture.newService(t)
+	receipt := Receipt{Store: StoreGoogle, ProductID: "starter_pack", Payload: "GPA.1"}
+
+	// Act
+	first := redeem(t, service, "alice", receipt)
+	again := redeem(t, service, "alice", receipt)
+	stolen := redeem(t, service, "mallory", receipt)
+	unknown := redeem(t, service, "alice", Receipt{Store: StoreGoogle, ProductID: "gem_9999", Payload: "GPA.2"})
+	fixture.validator.environment = EnvironmentSandbox
+	sandbox := redeem(t, service, "alice", Receipt{Store: StoreApple, ProductID: "crystal_1000", Payload: "1000001"})
+
+	// Assert
+	require.True(t, first.Success, first.Error)
+	assert.False(t, first.Data.(PurchaseResult).Duplicate)
+	require.True(t, again.Success, again.Error)
+	assert.True(t, again.Data.(PurchaseResult).Duplicate)
+	assert.ErrorIs(t, stolen.Error, ErrTransactionClaimed)
+	assert.ErrorIs(t, unknown.Error, ErrUnknownProduct)
+	assert.ErrorIs(t, sandbox.Error, ErrSandboxPurchase)
+
+	assert.Equal(t, int64(2), fixture.balance(t, items, "alice", "plasma-core"))
+	assert.Equal(t, int64(500), fixture.balance(t, minerals, "alice", "crystal"))
+	entitlements, err := fixture.entitlements.View(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.True(t, entitlements.Has("no_ads"))
+	assert.Equal(t, PurchaseID(StoreGoogle, "GPA.1"), entitlements.Entitlements["no_ads"].PurchaseID)
+}
+
+func TestService_RetriesFailedGrant(t *testing.T) {
+	// Arrange
+	fixture := newPurchaseFixture(t)
+	fixture.config.Treasury = &flakyGranter{Granter: fixture.trades.Treasury(), failures: 1}
+	service := fixture.newService(t)
+	receipt := Receipt{Store: StoreApple, ProductID: "crystal_1000", Payload: "1000001"}
+
+	// Act
+	_, failedErr := service.Redeem(context.Background(), "alice", receipt)
+	recorded, err := service.Load(context.Background(), PurchaseID(StoreApple, "1000001"))
+	require.NoError(t, err)
+	retried := redeem(t, service, "alice", receipt)
+
+	// Assert
+	require.Error(t, failedErr)
+	assert.True(t, recorded.Exists())
+	assert.False(t, recorded.Granted()) // 결제는 기록, 지급은 아직
+	require.True(t, retried.Success, retried.Error)
+	assert.True(t, retried.Data.(PurchaseResult).Duplicate)
+	assert.Equal(t, int64(1000), fixture.balance(t, minerals, "alice", "crystal"))
+}
+
+func TestService_IsNotACommandHandler(t *testing.T) {
+	// Arrange
+	service := newPurchaseFixture(t).newService(t)
+
+	// Act
+	_, isHandler := interface{}(service).(cqrs.CommandHandler)
+
+	// Assert - 디스패처에 등록되면 검증하지 않은 결제를 명령으로 기록할 수 있음
+	assert.False(t, isHandler)
+}
+
+func TestNewPurchaseApp_RequiresStore(t *testing.T) {
+	// Arrange
+	fixture := newPurchaseFixture(t)
+	fixture.config.Store = nil
+
+	// Act
+	app, err := NewPurchaseApp(Config{
+		Service:  fixture.config,
+		Identify: func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+
+	// Assert - 메모리 저장소로 대체하면 재시작 후 같은 영수증으로 다시 지급됨
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, app)
+}
+
+func TestPurchaseApp_RedeemAndEntitlements(t *testing.T) {
+	// Arrange
+	fixture := newPurchaseFixture(t)
+	app, err := NewPurchaseApp(Config{
+		Service:      fixture.config,
+		Entitlements: fixture.entitlements,
+		Identify:     func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	request := func(method, target, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-User", user)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act
+	redeemed := request(http.MethodPost, "/purchases/redeem", "alice", `{"store": "google", "product_id": "starter_pack", "payload": "GPA.1"}`)
+	stolen := request(http.MethodPost, "/purchases/redeem", "mallory", `{"store": "google", "product_id": "starter_pack", "payload": "GPA.1"}`)
+	unsupported := request(http.MethodPost, "/purchases/redeem", "alice", `{"store": "steam", "product_id": "starter_pack", "payload": "1"}`)
+	fixture.validator.err = errors.New("connection reset")
+	storeDown := request(http.MethodPost, "/purchases/redeem", "alice", `{"store": "google", "product_id": "crystal_1000", "payload": "GPA.2"}`)
+	entitlements := request(http.MethodGet, "/purchases/entitlements", "alice", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, redeemed.Code, redeemed.Body.String())
+	var result PurchaseResult
+	require.NoError(t, json.Unmarshal(redeemed.Body.Bytes(), &result))
+	assert.Equal(t, "GPA.1", result.TransactionID)
+	assert.Equal(t, http.StatusConflict, stolen.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, unsupported.Code)
+	assert.Equal(t, http.StatusBadGateway, storeDown.Code)
+	require.Equal(t, http.StatusOK, entitlements.Code)
+	var list []Entitlement
+	require.NoError(t, json.Unmarshal(entitlements.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "no_ads", list[0].Name)
+	hasNoAds, err := app.HasEntitlement(context.Background(), "alice", "no_ads")
+	require.NoError(t, err)
+	assert.True(t, hasNoAds)
+}
@@ -0,0 +1,300 @@
+package purchase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 스토어
+const (
+	StoreGoogle = "google"
+	StoreApple  = "apple"
+)
+
+// 결제 환경
+const (
+	EnvironmentProduction = "production"
+	EnvironmentSandbox    = "sandbox" // Apple 샌드박스, Google 라이선스 테스트 결제
+)
+
+var (
+	ErrInvalidReceipt   = errors.New("receipt was rejected by the store")
+	ErrPurchasePending  = errors.New("purchase is still pending")
+	ErrUnsupportedStore = errors.New("unsupported store")
+)
+
+// Receipt 클라이언트가 보내는 스토어 영수증
+type Receipt struct {
+	Store         string `json:"store"`                    // StoreGoogle, StoreApple
+	ProductID     string `json:"product_id"`               // 스토어 상품 ID
+	Payload       string `json:"payload"`                  // Google 구매 토큰, Apple base64 영수증
+	TransactionID string `json:"transaction_id,omitempty"` // 선택: Apple 영수증에 거래가 여러 개일 때 검증할 거래
+}
+
+// VerifiedPurchase 스토어가 확인한 결제
+type VerifiedPurchase struct {
+	Store         string    `json:"store"`
+	ProductID     string    `json:"product_id"`
+	TransactionID string    `json:"transaction_id"` // Google 주문 ID, Apple 거래 ID
+	Environment   string    `json:"environment"`
+	PurchasedAt   time.Time `json:"purchased_at"`
+}
+
+// ReceiptValidator 스토어 서버에 영수증을 검증합니다
+// 스토어가 거절한 영수증은 ErrInvalidReceipt, ErrPurchasePending으로 감싸고, 그 밖의 오류는 재시도할 수 있는 통신 오류입니다
+type ReceiptValidator interface {
+	Validate(ctx context.Context, receipt Receipt) (VerifiedPurchase, error)
+}
+
+// Apple verifyReceipt 기본 주소
+const (
+	AppleProductionURL = "https://buy.itunes.apple.com/verifyReceipt"
+	AppleSandboxURL    = "https://sandbox.itunes.apple.com/verifyReceipt"
+)
+
+// Apple verifyReceipt 상태 코드
+const (
+	appleStatusOK             = 0
+	appleStatusSandboxReceipt = 21007 // 샌드박스 영수증을 운영 주소로 보냄
+)
+
+// AppleConfig App Store 영수증 검증 설정
+type AppleConfig struct {
+	BundleID      string       // 필수: 앱 번들 ID
+	SharedSecret  string       // 선택: 앱 공유 암호 (구독 상품에 필요)
+	ProductionURL string       // 기본값: AppleProductionURL
+	SandboxURL    string       // 기본값: AppleSandboxURL
+	Client        *http.Client // 선택: 검증 요청에 사용할 클라이언트
+}
+
+// AppleValidator App Store verifyReceipt로 영수증을 검증합니다
+// 운영 주소로 먼저 보내고 샌드박스 영수증이면(21007) 샌드박스 주소로 다시 보냅니다 (Apple 권장 순서, 심사용 빌드 대응)
+type AppleValidator struct {
+	config AppleConfig
+	client *http.Client
+}
+
+// NewAppleValidator 새로운 AppleValidator를 생성합니다
+func NewAppleValidator(config AppleConfig) (*AppleValidator, error) {
+	if config.BundleID == "" {
+		return nil, errors.New("apple validator requires a bundle ID")
+	}
+	if config.ProductionURL == "" {
+		config.ProductionURL = AppleProductionURL
+	}
+	if config.SandboxURL == "" {
+		config.SandboxURL = AppleSandboxURL
+	}
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &AppleValidator{config: config, client: client}, nil
+}
+
+type appleReceiptResponse struct {
+	Status      int    `json:"status"`
+	Environment string `json:"environment"`
+	Receipt     struct {
+		BundleID string `json:"bundle_id"`
+		InApp    []struct {
+			ProductID          string `json:"product_id"`
+			TransactionID      string `json:"transaction_id"`
+			PurchaseDateMs     string `json:"purchase_date_ms"`
+			CancellationDateMs string `json:"cancellation_date_ms,omitempty"`
+		} `json:"in_app"`
+	} `json:"receipt"`
+}
+
+func (v *AppleValidator) Validate(ctx context.Context, receipt Receipt) (VerifiedPurchase, error) {
+	if receipt.Payload == "" || receipt.ProductID == "" {
+		return VerifiedPurchase{}, fmt.Errorf("%w: receipt and product ID are required", ErrInvalidReceipt)
+	}
+	response, err := v.verify(ctx, v.config.ProductionURL, receipt.Payload)
+	if err == nil && response.Status == appleStatusSandboxReceipt {
+		response, err = v.verify(ctx, v.config.SandboxURL, receipt.Payload)
+	}
+	if err != nil {
+		return VerifiedPurchase{}, err
+	}
+	if response.Status != appleStatusOK {
+		return VerifiedPurchase{}, fmt.Errorf("%w: app store status %d", ErrInvalidReceipt, response.Status)
+	}
+	if response.Receipt.BundleID != v.config.BundleID {
+		return VerifiedPurchase{}, fmt.Errorf("%w: receipt belongs to bundle %q", ErrInvalidReceipt, response.Receipt.BundleID)
+	}
+
+	environment := EnvironmentProduction
+	if strings.EqualFold(response.Environment, "Sandbox") {
+		environment = EnvironmentSandbox
+	}
+	for _, inApp := range response.Receipt.InApp {
+		if inApp.ProductID != receipt.ProductID || (receipt.TransactionID != "" && inApp.TransactionID != receipt.TransactionID) {
+			continue
+		}
+		if inApp.CancellationDateMs != "" {
+			return VerifiedPurchase{}, fmt.Errorf("%w: transaction %s was refunded", ErrInvalidReceipt, inApp.TransactionID)
+		}
+		return VerifiedPurchase{
+			Store:         StoreApple,
+			ProductID:     inApp.ProductID,
+			TransactionID: inApp.TransactionID,
+			Environment:   environment,
+			PurchasedAt:   parseMillis(inApp.PurchaseDateMs),
+		}, nil
+	}
+	return VerifiedPurchase{}, fmt.Errorf("%w: no transaction for product %s", ErrInvalidReceipt, receipt.ProductID)
+}
+
+func (v *AppleValidator) verify(ctx context.Context, endpoint, payload string) (appleReceiptResponse, error) {
+	var response appleReceiptResponse
+	body, err := json.Marshal(map[string]interface{}{
+		"receipt-data":             payload,
+		"password":                 v.config.SharedSecret,
+		"exclude-old-transactions": true,
+	})
+	if err != nil {
+		return response, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return response, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	err = doJSON(v.client, req, &response)
+	return response, err
+}
+
+// GooglePlayBaseURL Google Play Developer API 기본 주소
+const GooglePlayBaseURL = "https://androidpublisher.googleapis.com"
+
+// Google Play 구매 상태
+const (
+	googlePurchased = 0
+	googlePending   = 2
+)
+
+// GoogleConfig Google Play 영수증 검증 설정
+type GoogleConfig struct {
+	PackageName string                                    // 필수: 앱 패키지 이름
+	Token       func(ctx context.Context) (string, error) // 필수: androidpublisher 범위의 OAuth 액세스 토큰 (서비스 계정)
+	BaseURL     string                                    // 기본값: GooglePlayBaseURL
+	Client      *http.Client                              // 선택: 검증 요청에 사용할 클라이언트
+}
+
+// GoogleValidator Google Play Developer API(purchases.products.get)로 구매 토큰을 검증합니다
+// 라이선스 테스트 계정의 결제(purchaseType 0)는 샌드박스 결제로 구분합니다
+type GoogleValidator struct {
+	config GoogleConfig
+	client *http.Client
+}
+
+// NewGoogleValidator 새로운 GoogleValidator를 생성합니다
+func NewGoogleValidator(config GoogleConfig) (*GoogleValidator, error) {
+	if config.PackageName == "" || config.Token == nil {
+		return nil, errors.New("google validator requires a package name and a token source")
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = GooglePlayBaseURL
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &GoogleValidator{config: config, client: client}, nil
+}
+
+type googleProductPurchase struct {
+	OrderID            string `json:"orderId"`
+	PurchaseState      int    `json:"purchaseState"`
+	PurchaseTimeMillis string `json:"purchaseTimeMillis"`
+	PurchaseType       *int   `json:"purchaseType,omitempty"` // 없으면 일반 결제, 0이면 테스트 결제
+}
+
+func (v *GoogleValidator) Validate(ctx context.Context, receipt Receipt) (VerifiedPurchase, error) {
+	if receipt.Payload == "" || receipt.ProductID == "" {
+		return VerifiedPurchase{}, fmt.Errorf("%w: purchase token and product ID are required", ErrInvalidReceipt)
+	}
+	token, err := v.config.Token(ctx)
+	if err != nil {
+		return VerifiedPurchase{}, fmt.Errorf("failed to get google play access token: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/androidpublisher/v3/applications/%s/purchases/products/%s/tokens/%s",
+		v.config.BaseURL, url.PathEscape(v.config.PackageName), url.PathEscape(receipt.ProductID), url.PathEscape(receipt.Payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return VerifiedPurchase{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var purchase googleProductPurchase
+	if err := doJSON(v.client, req, &purchase); err != nil {
+		return VerifiedPurchase{}, err
+	}
+	switch purchase.PurchaseState {
+	case googlePurchased:
+	case googlePending:
+		return VerifiedPurchase{}, ErrPurchasePending
+	default:
+		return VerifiedPurchase{}, fmt.Errorf("%w: purchase state %d", ErrInvalidReceipt, purchase.PurchaseState)
+	}
+	if purchase.OrderID == "" {
+		return VerifiedPurchase{}, fmt.Errorf("%w: purchase has no order ID", ErrInvalidReceipt)
+	}
+
+	environment := EnvironmentProduction
+	if purchase.PurchaseType != nil && *purchase.PurchaseType == 0 {
+		environment = EnvironmentSandbox
+	}
+	return VerifiedPurchase{
+		Store:         StoreGoogle,
+		ProductID:     receipt.ProductID,
+		TransactionID: purchase.OrderID,
+		Environment:   environment,
+		PurchasedAt:   parseMillis(purchase.PurchaseTimeMillis),
+	}, nil
+}
+
+// doJSON 요청을 보내고 JSON 응답을 읽습니다
+// 스토어가 영수증을 모르는 경우(인증 오류를 뺀 4xx)는 ErrInvalidReceipt, 그 밖의 오류는 그대로 반환합니다
+func doJSON(client *http.Client, req *http.Request, response interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("store request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read store response: %w", err)
+	}
+	switch {
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("store returned %d", resp.StatusCode)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("store rejected the server credentials with %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return fmt.Errorf("%w: store returned %d", ErrInvalidReceipt, resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("failed to decode store response: %w", err)
+	}
+	return nil
+}
+
+func parseMillis(value string) time.Time {
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis).UTC()
+}
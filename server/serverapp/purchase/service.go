@@ -0,0 +1,218 @@
+package purchase
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// PurchaseResult 결제 처리 결과 (CommandResult.Data)
+type PurchaseResult struct {
+	PurchaseID    string `json:"purchase_id"`
+	ProductID     string `json:"product_id"`
+	TransactionID string `json:"transaction_id"`
+	Environment   string `json:"environment"`
+	Grant         Grant  `json:"grant"`
+	Duplicate     bool   `json:"duplicate,omitempty"` // 이미 처리한 영수증 (지급은 한 번만)
+}
+
+// Granter 사용자에게 아이템이나 광물을 지급합니다
+// 같은 grantID는 한 번만 적용해야 합니다 (trade.Service의 Inventory, Treasury가 구현)
+type Granter interface {
+	Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error
+}
+
+// ServiceConfig 결제 서비스 설정
+type ServiceConfig struct {
+	Store        eventstore.EventStore       // 필수: 결제 이벤트 저장소 (거래 ID별 지급 기록)
+	EventBus     cqrs.EventBus               // 선택: 결제 이벤트 발행 (권한 프로젝션이 구독)
+	Validators   map[string]ReceiptValidator // 스토어별 영수증 검증 (StoreGoogle, StoreApple)
+	Catalog      map[string]Grant            // 상품 ID -> 지급 내용
+	AllowSandbox bool                        // 샌드박스, 테스트 결제 허용 (개발, QA 서버)
+	Inventory    Granter                     // 아이템 지급 (상품에 아이템이 있으면 필수)
+	Treasury     Granter                     // 광물 지급 (상품에 광물이 있으면 필수)
+	Wallet       Granter                     // 프리미엄 재화 적립 (상품에 재화가 있으면 필수, wallet.Service가 구현)
+	Now          func() time.Time            // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 영수증을 검증하고 결제를 기록한 뒤 상품을 지급합니다
+// 결제 기록은 스토어가 검증한 결제만 믿을 수 있으므로 명령 디스패처에 등록하지 않고 Redeem으로만 받습니다
+type Service struct {
+	config ServiceConfig
+
+	mu sync.Mutex // 결제 변경 직렬화
+}
+
+// NewService 새로운 Service를 생성합니다
+// 지급 기록이 재시작 후에도 남아야 중복 지급을 막을 수 있으므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("purchase: %w", ErrStoreRequired)
+	}
+	if config.Validators == nil {
+		config.Validators = make(map[string]ReceiptValidator)
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{config: config}, nil
+}
+
+// Load 결제 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, purchaseID string) (*PurchaseAggregate, error) {
+	return s.load(ctx, purchaseID)
+}
+
+// Redeem 영수증을 스토어에 검증하고 결제를 기록합니다
+// 스토어가 거절한 영수증은 CommandResult.Error로, 스토어 통신 오류는 error로 반환하므로 클라이언트는 후자만 재시도하면 됩니다
+func (s *Service) Redeem(ctx context.Context, userID string, receipt Receipt) (*cqrs.CommandResult, error) {
+	if userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: user ID is required", ErrInvalidPurchase)), nil
+	}
+	validator, ok := s.config.Validators[receipt.Store]
+	if !ok {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: %q", ErrUnsupportedStore, receipt.Store)), nil
+	}
+	if _, known := s.config.Catalog[receipt.ProductID]; !known {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: %q", ErrUnknownProduct, receipt.ProductID)), nil
+	}
+
+	purchase, err := validator.Validate(ctx, receipt)
+	if errors.Is(err, ErrInvalidReceipt) || errors.Is(err, ErrPurchasePending) {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate %s receipt: %w", receipt.Store, err)
+	}
+	if purchase.Environment == EnvironmentSandbox && !s.config.AllowSandbox {
+		return cqrs.NewFailedCommandResult(ErrSandboxPurchase), nil
+	}
+	return s.complete(ctx, userID, purchase)
+}
+
+// complete 스토어가 검증한 결제를 기록하고 지급합니다 (실패는 CommandResult.Error로 반환)
+// 지급에 실패하면 결제는 기록된 채로 error를 반환하고, 같은 영수증을 다시 보내면 지급만 다시 시도합니다
+func (s *Service) complete(ctx context.Context, userID string, purchase VerifiedPurchase) (*cqrs.CommandResult, error) {
+	purchaseID := PurchaseID(purchase.Store, purchase.TransactionID)
+	grant, known := s.config.Catalog[purchase.ProductID]
+	if !known {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: %q", ErrUnknownProduct, purchase.ProductID)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	duplicate, err := aggregate.Complete(userID, purchase, grant, now)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+
+	if !aggregate.Granted() {
+		if err := s.grant(ctx, aggregate); err != nil {
+			return nil, err
+		}
+		if err := aggregate.MarkGranted(now); err != nil {
+			return nil, err
+		}
+		events = append(events, aggregate.Changes()...)
+		if err := s.save(ctx, aggregate); err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := aggregate.Purchase()
+	if !duplicate {
+		log.Printf("[Purchase] %s %s redeemed by %s (%s)", recorded.Store, recorded.ProductID, userID, recorded.Environment)
+	}
+	return cqrs.NewCommandResult(purchaseID, aggregate.Version(), events...).WithData(PurchaseResult{
+		PurchaseID:    purchaseID,
+		ProductID:     recorded.ProductID,
+		TransactionID: recorded.TransactionID,
+		Environment:   recorded.Environment,
+		Grant:         aggregate.Grant(),
+		Duplicate:     duplicate,
+	}), nil
+}
+
+// grant 결제 ID를 지급 ID로 아이템, 광물, 프리미엄 재화를 지급합니다 (권한은 EntitlementProjection이 이벤트로 반영)
+func (s *Service) grant(ctx context.Context, aggregate *PurchaseAggregate) error {
+	grant := aggregate.Grant()
+	grants := []struct {
+		name    string
+		granter Granter
+		amounts map[string]int64
+	}{
+		{"items", s.config.Inventory, grant.Items},
+		{"minerals", s.config.Treasury, grant.Minerals},
+		{"currency", s.config.Wallet, currencyAmounts(grant.Currency)},
+	}
+	for _, g := range grants {
+		if len(g.amounts) == 0 {
+			continue
+		}
+		if g.granter == nil {
+			return fmt.Errorf("no granter configured for %s of %s", g.name, aggregate.ID())
+		}
+		if err := g.granter.Grant(ctx, aggregate.UserID(), aggregate.ID(), g.amounts); err != nil {
+			return fmt.Errorf("failed to grant %s of %s: %w", g.name, aggregate.ID(), err)
+		}
+	}
+	return nil
+}
+
+// currencyAmounts 프리미엄 재화를 Granter에 넘길 양으로 바꿉니다 (없으면 nil)
+func currencyAmounts(amount int64) map[string]int64 {
+	if amount == 0 {
+		return nil
+	}
+	return map[string]int64{"currency": amount}
+}
+
+func (s *Service) load(ctx context.Context, purchaseID string) (*PurchaseAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, purchaseID, PurchaseAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load purchase %s: %w", purchaseID, err)
+	}
+	aggregate := NewPurchaseAggregate(purchaseID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *PurchaseAggregate) error {
+	events := aggregate.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save purchase %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Purchase] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
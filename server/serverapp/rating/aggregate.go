@@ -0,0 +1,208 @@
+package rating
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 레이팅 애그리게이트 타입
+const (
+	PlayerRatingAggregateType = "PlayerRating" // 플레이어 개인 레이팅
+	PairRatingAggregateType   = "PairRating"   // 두 플레이어의 협동 레이팅
+)
+
+// RatingUpdatedEventType 매치 결과로 레이팅이 바뀜 (레이팅 히스토리)
+const RatingUpdatedEventType = "RatingUpdated"
+
+// RatingUpdatedData 레이팅 변경 이벤트 데이터
+type RatingUpdatedData struct {
+	MatchID string    `json:"match_id"`
+	Outcome Outcome   `json:"outcome"`
+	Before  Rating    `json:"before"`
+	After   Rating    `json:"after"`
+	At      time.Time `json:"at"`
+}
+
+// RatingEvent 레이팅 애그리게이트 이벤트
+type RatingEvent struct {
+	*cqrs.BaseEventMessage
+	data RatingUpdatedData
+}
+
+func (e *RatingEvent) EventData() interface{} {
+	return e.data
+}
+
+// PairID 두 플레이어의 협동 레이팅 ID (순서와 관계없이 같음)
+func PairID(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "+" + b
+}
+
+// RatingAggregate 플레이어 또는 협동 쌍 하나의 레이팅
+// 레이팅 히스토리는 RatingUpdated 이벤트 스트림 자체입니다
+type RatingAggregate struct {
+	*cqrs.BaseAggregate
+	rating  Rating
+	matches int
+	applied map[string]bool // 반영한 매치 (중복 처리 방지)
+}
+
+// NewRatingAggregate 새로운 RatingAggregate를 생성합니다
+func NewRatingAggregate(id, aggregateType string) *RatingAggregate {
+	return &RatingAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(id, aggregateType),
+		rating:        NewRating(),
+		applied:       make(map[string]bool),
+	}
+}
+
+// Rating 현재 레이팅
+func (a *RatingAggregate) Rating() Rating {
+	return a.rating
+}
+
+// Matches 반영한 매치 수
+func (a *RatingAggregate) Matches() int {
+	return a.matches
+}
+
+// Applied 매치를 이미 반영했는지 확인합니다
+func (a *RatingAggregate) Applied(matchID string) bool {
+	return a.applied[matchID]
+}
+
+// Record 매치 결과를 반영합니다 (이미 반영한 매치는 무시)
+func (a *RatingAggregate) Record(matchID string, outcome Outcome, tau float64, at time.Time) error {
+	if matchID == "" {
+		return fmt.Errorf("match ID is required")
+	}
+	if a.applied[matchID] {
+		return nil
+	}
+	event := &RatingEvent{
+		BaseEventMessage: cqrs.NewBaseEventMessage(RatingUpdatedEventType),
+		data: RatingUpdatedData{
+			MatchID: matchID,
+			Outcome: outcome,
+			Before:  a.rating,
+			After:   a.rating.Update([]Outcome{outcome}, tau),
+			At:      at,
+		},
+	}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(event.data)
+	return nil
+}
+
+// LoadFromHistory 이벤트 스트림에서 레이팅을 복원합니다
+func (a *RatingAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *RatingAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	data, err := decodeRatingUpdated(event)
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *RatingAggregate) when(data RatingUpdatedData) {
+	a.rating = data.After
+	a.matches++
+	a.applied[data.MatchID] = true
+}
+
+// decodeRatingUpdated 저장소에서 읽은 레이팅 이벤트 데이터를 되돌립니다
+func decodeRatingUpdated(event cqrs.EventMessage) (RatingUpdatedData, error) {
+	if event.EventType() != RatingUpdatedEventType {
+		return RatingUpdatedData{}, fmt.Errorf("unexpected rating event type %q", event.EventType())
+	}
+	return decodeData[RatingUpdatedData](event.EventData())
+}
+
+// decodeData 이벤트 버스나 저장소 구현에 따라 값, 포인터, map으로 오는 데이터를 T로 되돌립니다
+// 직렬화하는 구현은 데이터를 map 등으로 돌려주므로 JSON을 거쳐 변환합니다
+func decodeData[T any](data interface{}) (T, error) {
+	switch value := data.(type) {
+	case T:
+		return value, nil
+	case *T:
+		return *value, nil
+	}
+	var decoded T
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return decoded, fmt.Errorf("failed to decode %T: %w", decoded, err)
+	}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return decoded, fmt.Errorf("failed to decode %T: %w", decoded, err)
+	}
+	return decoded, nil
+}
+
+// HistoryStore 레이팅 이벤트 저장소
+// cqrsx.RedisEventStore, cqrsx.MongoEventStore가 이 메서드들을 구현합니다
+type HistoryStore interface {
+	SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error
+	GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error)
+}
+
+// InMemoryHistoryStore 메모리 레이팅 이벤트 저장소 (개발, 테스트용)
+type InMemoryHistoryStore struct {
+	mu     sync.RWMutex
+	events map[string][]cqrs.EventMessage
+}
+
+// NewInMemoryHistoryStore 새로운 InMemoryHistoryStore를 생성합니다
+func NewInMemoryHistoryStore() *InMemoryHistoryStore {
+	return &InMemoryHistoryStore{events: make(map[string][]cqrs.EventMessage)}
+}
+
+func (s *InMemoryHistoryStore) SaveEvents(ctx context.Context, aggregateID string, events []cqrs.EventMessage, expectedVersion int) error {
+	if len(events) == 0 {
+		return nil
+	}
+	key := events[0].AggregateType() + ":" + aggregateID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current := len(s.events[key]); current != expectedVersion {
+		return cqrs.NewConcurrencyError(fmt.Sprintf("expected version %d, current version %d", expectedVersion, current), nil)
+	}
+	s.events[key] = append(s.events[key], events...)
+	return nil
+}
+
+func (s *InMemoryHistoryStore) GetEventHistory(ctx context.Context, aggregateID, aggregateType string, fromVersion int) ([]cqrs.EventMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.events[aggregateType+":"+aggregateID]
+	history := make([]cqrs.EventMessage, 0, len(stored))
+	for _, event := range stored {
+		if event.Version() > fromVersion {
+			history = append(history, event)
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,221 @@
+package rating
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"defense-allies-server/serverapp"
+	"defense-allies-server/serverapp/match"
+)
+
+// DefaultBasePath 레이팅 서버 기본 경로
+const DefaultBasePath = "/ratings"
+
+// Config 레이팅 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /ratings)
+	EventBus cqrs.EventBus                   // 선택: MatchCompleted 구독 (없으면 Service().Record를 직접 호출)
+	Service  ServiceConfig                   // 레이팅 서비스 설정
+	Auth     func(http.Handler) http.Handler // 선택: 인증 미들웨어
+}
+
+// RatingApp 매치 결과로 플레이어 레이팅을 갱신하고 매치메이킹용 조회를 제공하는 서버앱
+type RatingApp struct {
+	*serverapp.BaseApp
+	config       Config
+	service      *RatingService
+	subscription cqrs.SubscriptionID
+}
+
+// NewRatingApp 새로운 RatingApp을 생성합니다
+func NewRatingApp(config Config) *RatingApp {
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	return &RatingApp{
+		BaseApp: serverapp.NewBaseApp("rating"),
+		config:  config,
+		service: NewRatingService(config.Service),
+	}
+}
+
+// Service 레이팅 서비스
+func (a *RatingApp) Service() *RatingService {
+	return a.service
+}
+
+// Start 이벤트 버스에서 MatchCompleted 구독을 시작합니다
+func (a *RatingApp) Start(ctx context.Context) error {
+	if a.config.EventBus != nil {
+		subscription, err := a.config.EventBus.Subscribe(match.MatchCompletedEventType, a.service)
+		if err != nil {
+			return err
+		}
+		a.subscription = subscription
+	}
+	return a.BaseApp.Start(ctx)
+}
+
+// Stop 구독을 해제합니다
+func (a *RatingApp) Stop(ctx context.Context) error {
+	if a.config.EventBus != nil && a.subscription != "" {
+		if err := a.config.EventBus.Unsubscribe(a.subscription); err != nil {
+			log.Printf("[Rating] Failed to unsubscribe: %v", err)
+		}
+		a.subscription = ""
+	}
+	return a.BaseApp.Stop(ctx)
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *RatingApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base, protect(http.HandlerFunc(a.rating)))
+	mux.Handle(base+"/pair", protect(http.HandlerFunc(a.pairRating)))
+	mux.Handle(base+"/history", protect(http.HandlerFunc(a.history)))
+	mux.Handle(base+"/distribution", protect(http.HandlerFunc(a.distribution)))
+
+	log.Printf("[Rating] Routes registered under %s", base)
+}
+
+// DescribeAPI 레이팅 엔드포인트 설명 (/openapi.json)
+func (a *RatingApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	playerID := serverapp.APIParameter{Name: "player_id", In: "query", Required: true}
+	return []serverapp.APIOperation{
+		{Method: http.MethodGet, Path: base, Summary: "플레이어 레이팅 조회", Description: "매치 기록이 없으면 초기 레이팅을 반환합니다.", Parameters: []serverapp.APIParameter{playerID}, Response: RatingView{}, Secured: secured},
+		{
+			Method:     http.MethodGet,
+			Path:       base + "/pair",
+			Summary:    "두 플레이어의 협동 레이팅 조회",
+			Parameters: []serverapp.APIParameter{{Name: "player_a", In: "query", Required: true}, {Name: "player_b", In: "query", Required: true}},
+			Response:   RatingView{},
+			Secured:    secured,
+		},
+		{Method: http.MethodGet, Path: base + "/history", Summary: "플레이어 레이팅 변경 기록", Parameters: []serverapp.APIParameter{playerID}, Response: []RatingUpdatedData{}, Secured: secured},
+		{
+			Method:  http.MethodGet,
+			Path:    base + "/distribution",
+			Summary: "레이팅 분포 (매치메이킹용)",
+			Parameters: []serverapp.APIParameter{
+				{Name: "bucket_size", In: "query", Description: "기본값 100"},
+				{Name: "min_matches", In: "query", Description: "이보다 적게 플레이한 플레이어 제외"},
+			},
+			Response: Distribution{},
+			Secured:  secured,
+		},
+	}
+}
+
+func (a *RatingApp) rating(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	view, err := a.service.GetRating(r.Context(), r.URL.Query().Get("player_id"))
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, view)
+}
+
+func (a *RatingApp) pairRating(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	view, err := a.service.GetPairRating(r.Context(), query.Get("player_a"), query.Get("player_b"))
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, view)
+}
+
+func (a *RatingApp) history(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	history, err := a.service.History(r.Context(), r.URL.Query().Get("player_id"))
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, history)
+}
+
+func (a *RatingApp) distribution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	params := r.URL.Query()
+	var query DistributionQuery
+	if value := params.Get("bucket_size"); value != "" {
+		size, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, "invalid bucket_size")
+			return
+		}
+		query.BucketSize = size
+	}
+	if value := params.Get("min_matches"); value != "" {
+		minMatches, err := strconv.Atoi(value)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, "invalid min_matches")
+			return
+		}
+		query.MinMatches = minMatches
+	}
+
+	distribution, err := a.service.Distribution(r.Context(), query)
+	if err != nil {
+		sendError(w, statusForError(err), err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, distribution)
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
@@ -0,0 +1,111 @@
+package rating
+
+import "math"
+
+// Glicko-2 기본값 (Glickman, "Example of the Glicko-2 system")
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+	DefaultTau        = 0.5 // 변동성 변화 제한 (0.3 ~ 1.2)
+
+	glickoScale      = 173.7178
+	glickoTolerance  = 0.000001
+	minimumDeviation = 30.0 // 오래 활동해도 이보다 확신하지 않음
+)
+
+// Rating Glicko-2 레이팅
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`  // RD, 낮을수록 확신
+	Volatility float64 `json:"volatility"` // 실력 변화 정도
+}
+
+// NewRating 신규 플레이어의 초기 레이팅
+func NewRating() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+// Conservative 매치메이킹용 보수적 추정치 (rating - 2RD)
+func (r Rating) Conservative() float64 {
+	return r.Rating - 2*r.Deviation
+}
+
+// Outcome 한 번의 대전 결과
+type Outcome struct {
+	Opponent Rating  `json:"opponent"`
+	Score    float64 `json:"score"` // 1 승리, 0.5 무승부, 0 패배 (사이 값 허용)
+}
+
+// Update 한 레이팅 기간의 결과를 반영한 새 레이팅을 계산합니다
+// 결과가 없으면 RD만 늘어납니다
+func (r Rating) Update(outcomes []Outcome, tau float64) Rating {
+	mu := (r.Rating - DefaultRating) / glickoScale
+	phi := r.Deviation / glickoScale
+	sigma := r.Volatility
+
+	if len(outcomes) == 0 {
+		return Rating{Rating: r.Rating, Deviation: math.Min(math.Sqrt(phi*phi+sigma*sigma)*glickoScale, DefaultDeviation), Volatility: sigma}
+	}
+
+	var vInverse, improvement float64
+	for _, outcome := range outcomes {
+		muJ := (outcome.Opponent.Rating - DefaultRating) / glickoScale
+		g := glickoG(outcome.Opponent.Deviation / glickoScale)
+		expected := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInverse += g * g * expected * (1 - expected)
+		improvement += g * (outcome.Score - expected)
+	}
+	v := 1 / vInverse
+	delta := v * improvement
+
+	sigma = glickoVolatility(phi, sigma, v, delta, tau)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * improvement
+
+	return Rating{
+		Rating:     mu*glickoScale + DefaultRating,
+		Deviation:  math.Max(phi*glickoScale, minimumDeviation),
+		Volatility: sigma,
+	}
+}
+
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// glickoVolatility 새 변동성을 Illinois 방식으로 구합니다 (Glicko-2 step 5)
+func glickoVolatility(phi, sigma, v, delta, tau float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	upper := a
+	var lower float64
+	if delta*delta > phi*phi+v {
+		lower = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		lower = a - k*tau
+	}
+
+	fUpper, fLower := f(upper), f(lower)
+	for math.Abs(lower-upper) > glickoTolerance {
+		c := upper + (upper-lower)*fUpper/(fLower-fUpper)
+		fC := f(c)
+		if fC*fLower <= 0 {
+			upper, fUpper = lower, fLower
+		} else {
+			fUpper /= 2
+		}
+		lower, fLower = c, fC
+	}
+	return math.Exp(upper / 2)
+}
@@ -0,0 +1,177 @@
+package rating
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/match"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopBroadcaster struct{}
+
+func (nopBroadcaster) Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error) {
+	return "", nil
+}
+
+func victory(matchID string, players ...string) match.MatchCompletedData {
+	return match.MatchCompletedData{MatchID: matchID, Players: players, Result: match.ResultVictory, WavesCleared: 10, FinalWave: 10}
+}
+
+func TestRating_Glicko2Example(t *testing.T) {
+	// Arrange: Glickman의 Glicko-2 예제
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	outcomes := []Outcome{
+		{Opponent: Rating{Rating: 1400, Deviation: 30}, Score: 1},
+		{Opponent: Rating{Rating: 1550, Deviation: 100}, Score: 0},
+		{Opponent: Rating{Rating: 1700, Deviation: 300}, Score: 0},
+	}
+
+	// Act
+	updated := player.Update(outcomes, 0.5)
+
+	// Assert
+	assert.InDelta(t, 1464.06, updated.Rating, 0.05)
+	assert.InDelta(t, 151.52, updated.Deviation, 0.05)
+	assert.InDelta(t, 0.05999, updated.Volatility, 0.00001)
+}
+
+func TestMatchScore(t *testing.T) {
+	assert.Equal(t, 1.0, MatchScore(victory("m-1", "alice")))
+	assert.Equal(t, 0.25, MatchScore(match.MatchCompletedData{Result: match.ResultDefeat, WavesCleared: 5, FinalWave: 10}))
+	assert.Equal(t, 0.0, MatchScore(match.MatchCompletedData{Result: match.ResultDefeat, FinalWave: 10}))
+}
+
+func TestRatingService_RecordsPlayerAndPairRatings(t *testing.T) {
+	// Arrange
+	service := NewRatingService(ServiceConfig{})
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, service.Record(ctx, victory("m-1", "carol", "alice", "bob")))
+	require.NoError(t, service.Record(ctx, victory("m-1", "carol", "alice", "bob"))) // 중복 전달
+	require.NoError(t, service.Record(ctx, match.MatchCompletedData{MatchID: "m-2", Players: []string{"alice", "dave"}, Result: match.ResultDefeat, FinalWave: 10}))
+
+	// Assert
+	alice, err := service.GetRating(ctx, "alice")
+	require.NoError(t, err)
+	bob, err := service.GetRating(ctx, "bob")
+	require.NoError(t, err)
+	dave, err := service.GetRating(ctx, "dave")
+	require.NoError(t, err)
+	assert.Equal(t, 2, alice.Matches)
+	assert.Equal(t, 1, bob.Matches)
+	assert.Greater(t, bob.Rating.Rating, DefaultRating)
+	assert.Less(t, dave.Rating.Rating, DefaultRating)
+	assert.Less(t, bob.Rating.Deviation, DefaultDeviation)
+
+	pair, err := service.GetPairRating(ctx, "bob", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice+bob", pair.ID)
+	assert.Equal(t, []string{"alice", "bob"}, pair.Players)
+	assert.Equal(t, 1, pair.Matches)
+	assert.Equal(t, bob.Rating, pair.Rating) // 같은 초기값에서 같은 결과 하나
+
+	unknown, err := service.GetRating(ctx, "eve")
+	require.NoError(t, err)
+	assert.Equal(t, NewRating(), unknown.Rating)
+	assert.Zero(t, unknown.Matches)
+
+	history, err := service.History(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "m-1", history[0].MatchID)
+	assert.Equal(t, history[0].After, history[1].Before)
+	assert.Equal(t, alice.Rating, history[1].After)
+
+	_, err = service.GetPairRating(ctx, "alice", "alice")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestRatingService_Distribution(t *testing.T) {
+	// Arrange
+	service := NewRatingService(ServiceConfig{})
+	ctx := context.Background()
+	require.NoError(t, service.Record(ctx, victory("m-1", "alice", "bob")))
+	require.NoError(t, service.Record(ctx, victory("m-2", "alice")))
+	require.NoError(t, service.Record(ctx, match.MatchCompletedData{MatchID: "m-3", Players: []string{"carol"}, Result: match.ResultDefeat, FinalWave: 10}))
+
+	// Act
+	all, err := service.Distribution(ctx, DistributionQuery{})
+	require.NoError(t, err)
+	experienced, err := service.Distribution(ctx, DistributionQuery{BucketSize: 50, MinMatches: 2})
+	require.NoError(t, err)
+
+	// Assert: 협동 레이팅은 분포에 포함하지 않음
+	assert.Equal(t, 3, all.Players)
+	total := 0
+	for _, bucket := range all.Buckets {
+		assert.Equal(t, 100.0, bucket.To-bucket.From)
+		total += bucket.Count
+	}
+	assert.Equal(t, 3, total)
+	assert.LessOrEqual(t, all.Percentiles["p10"], all.Percentiles["p90"])
+
+	alice, err := service.GetRating(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, experienced.Players)
+	assert.Equal(t, alice.Rating.Rating, experienced.Mean)
+	assert.Zero(t, experienced.StdDev)
+}
+
+func TestRatingApp_ConsumesMatchCompletedFromEventBus(t *testing.T) {
+	// Arrange
+	bus := cqrs.NewInMemoryEventBus()
+	ctx := context.Background()
+	require.NoError(t, bus.Start(ctx))
+	t.Cleanup(func() { bus.Stop(context.Background()) })
+
+	app := NewRatingApp(Config{EventBus: bus})
+	require.NoError(t, app.Start(ctx))
+	t.Cleanup(func() { app.Stop(context.Background()) })
+
+	manager, err := match.NewRoomManager(match.ManagerConfig{TickRate: 200, EventBus: bus}, nopBroadcaster{})
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Shutdown(context.Background()) })
+
+	// Act
+	room, err := manager.Create("m-1", []string{"alice", "bob"})
+	require.NoError(t, err)
+	require.NoError(t, room.Dispatch(ctx, match.Command{Type: match.CommandSurrender, PlayerID: "alice"}))
+	select {
+	case <-room.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("match did not end")
+	}
+
+	// Assert
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ratings?player_id=bob", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var bob RatingView
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &bob))
+	assert.Equal(t, 1, bob.Matches)
+	assert.Less(t, bob.Rating.Rating, DefaultRating)
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ratings/pair?player_a=alice&player_b=bob", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ratings/distribution?bucket_size=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ratings/history", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,309 @@
+package rating
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/match"
+)
+
+var ErrInvalidQuery = errors.New("invalid rating query")
+
+// DifficultyFunc 협동 매치의 상대(맵, 웨이브 구성) 레이팅을 정합니다
+type DifficultyFunc func(result match.MatchCompletedData) Rating
+
+// ScoreFunc 매치 결과를 Glicko 점수(0~1)로 바꿉니다
+type ScoreFunc func(result match.MatchCompletedData) float64
+
+// DefaultDifficulty 모든 매치를 같은 난이도로 봅니다
+func DefaultDifficulty(result match.MatchCompletedData) Rating {
+	return Rating{Rating: DefaultRating, Deviation: 100, Volatility: DefaultVolatility}
+}
+
+// MatchScore 승리는 1, 패배는 클리어한 웨이브 비율만큼 최대 0.5까지 부분 점수를 줍니다
+func MatchScore(result match.MatchCompletedData) float64 {
+	if result.Result == match.ResultVictory {
+		return 1
+	}
+	if result.FinalWave <= 0 || result.WavesCleared <= 0 {
+		return 0
+	}
+	return 0.5 * math.Min(float64(result.WavesCleared)/float64(result.FinalWave), 1)
+}
+
+// ServiceConfig 레이팅 서비스 설정
+type ServiceConfig struct {
+	History    HistoryStore   // 레이팅 히스토리 이벤트 저장소 (기본값: 메모리)
+	ReadStore  cqrs.ReadStore // 현재 레이팅 조회 모델 (기본값: 메모리)
+	Tau        float64        // Glicko-2 변동성 제한 (기본값: 0.5)
+	Difficulty DifficultyFunc // 기본값: DefaultDifficulty
+	Score      ScoreFunc      // 기본값: MatchScore
+}
+
+// RatingView 현재 레이팅 조회 모델
+type RatingView struct {
+	ID           string    `json:"id"`
+	Players      []string  `json:"players"` // 개인 레이팅은 1명, 협동 레이팅은 2명
+	Rating       Rating    `json:"rating"`
+	Conservative float64   `json:"conservative"` // rating - 2RD
+	Matches      int       `json:"matches"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
+
+// Bucket 레이팅 분포 구간 [From, To)
+type Bucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// Distribution 플레이어 레이팅 분포 (매치메이킹 구간 설정용)
+type Distribution struct {
+	Players     int                `json:"players"`
+	Mean        float64            `json:"mean"`
+	StdDev      float64            `json:"std_dev"`
+	Percentiles map[string]float64 `json:"percentiles"` // p10, p25, p50, p75, p90
+	Buckets     []Bucket           `json:"buckets"`
+}
+
+// DistributionQuery 분포 조회 조건
+type DistributionQuery struct {
+	BucketSize float64 // 구간 크기 (기본값: 100)
+	MinMatches int     // 이보다 적게 플레이한 플레이어 제외 (배치 중인 플레이어)
+}
+
+// RatingService MatchCompleted 이벤트를 받아 플레이어와 협동 쌍의 레이팅을 갱신합니다
+type RatingService struct {
+	*cqrs.BaseEventHandler
+	config ServiceConfig
+	mu     sync.Mutex // 레이팅 갱신 직렬화
+}
+
+// NewRatingService 새로운 RatingService를 생성합니다
+func NewRatingService(config ServiceConfig) *RatingService {
+	if config.History == nil {
+		config.History = NewInMemoryHistoryStore()
+	}
+	if config.ReadStore == nil {
+		config.ReadStore = cqrs.NewInMemoryReadStore()
+	}
+	if config.Tau <= 0 {
+		config.Tau = DefaultTau
+	}
+	if config.Difficulty == nil {
+		config.Difficulty = DefaultDifficulty
+	}
+	if config.Score == nil {
+		config.Score = MatchScore
+	}
+	return &RatingService{
+		BaseEventHandler: cqrs.NewBaseEventHandler("rating", cqrs.ProcessManagerHandler, []string{match.MatchCompletedEventType}),
+		config:           config,
+	}
+}
+
+// Handle 끝난 매치의 결과를 반영합니다 (같은 매치를 다시 받아도 한 번만 반영)
+func (s *RatingService) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	if event.EventType() != match.MatchCompletedEventType {
+		return nil
+	}
+	result, err := decodeData[match.MatchCompletedData](event.EventData())
+	if err != nil {
+		return err
+	}
+	return s.Record(ctx, result)
+}
+
+// Record 매치 결과로 참가자 개인 레이팅과 모든 참가자 쌍의 협동 레이팅을 갱신합니다
+func (s *RatingService) Record(ctx context.Context, result match.MatchCompletedData) error {
+	if result.MatchID == "" || len(result.Players) == 0 {
+		return fmt.Errorf("%w: match ID and players are required", ErrInvalidQuery)
+	}
+	outcome := Outcome{Opponent: s.config.Difficulty(result), Score: s.config.Score(result)}
+	at := result.EndedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	players := append([]string(nil), result.Players...)
+	sort.Strings(players)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, player := range players {
+		if err := s.update(ctx, player, PlayerRatingAggregateType, []string{player}, result.MatchID, outcome, at); err != nil {
+			return err
+		}
+		for _, ally := range players[i+1:] {
+			if ally == player {
+				continue
+			}
+			if err := s.update(ctx, PairID(player, ally), PairRatingAggregateType, []string{player, ally}, result.MatchID, outcome, at); err != nil {
+				return err
+			}
+		}
+	}
+	log.Printf("[Rating] Match %s recorded for %d players (score %.2f)", result.MatchID, len(players), outcome.Score)
+	return nil
+}
+
+func (s *RatingService) update(ctx context.Context, id, aggregateType string, players []string, matchID string, outcome Outcome, at time.Time) error {
+	aggregate, err := s.load(ctx, id, aggregateType)
+	if err != nil {
+		return err
+	}
+	if aggregate.Applied(matchID) {
+		return nil
+	}
+	if err := aggregate.Record(matchID, outcome, s.config.Tau, at); err != nil {
+		return err
+	}
+	if err := s.config.History.SaveEvents(ctx, id, aggregate.Changes(), aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save rating history for %s: %w", id, err)
+	}
+	aggregate.ClearChanges()
+
+	view := RatingView{
+		ID:           id,
+		Players:      players,
+		Rating:       aggregate.Rating(),
+		Conservative: aggregate.Rating().Conservative(),
+		Matches:      aggregate.Matches(),
+		UpdatedAt:    at,
+	}
+	return s.config.ReadStore.Save(ctx, cqrs.NewBaseReadModel(id, aggregateType, view))
+}
+
+func (s *RatingService) load(ctx context.Context, id, aggregateType string) (*RatingAggregate, error) {
+	events, err := s.config.History.GetEventHistory(ctx, id, aggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rating history for %s: %w", id, err)
+	}
+	aggregate := NewRatingAggregate(id, aggregateType)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// GetRating 플레이어의 현재 레이팅 (매치 기록이 없으면 초기 레이팅)
+func (s *RatingService) GetRating(ctx context.Context, playerID string) (RatingView, error) {
+	if playerID == "" {
+		return RatingView{}, fmt.Errorf("%w: player ID is required", ErrInvalidQuery)
+	}
+	return s.view(ctx, playerID, PlayerRatingAggregateType, []string{playerID})
+}
+
+// GetPairRating 두 플레이어의 협동 레이팅 (함께 플레이한 적이 없으면 초기 레이팅)
+func (s *RatingService) GetPairRating(ctx context.Context, playerA, playerB string) (RatingView, error) {
+	if playerA == "" || playerB == "" || playerA == playerB {
+		return RatingView{}, fmt.Errorf("%w: two different player IDs are required", ErrInvalidQuery)
+	}
+	players := []string{playerA, playerB}
+	sort.Strings(players)
+	return s.view(ctx, PairID(playerA, playerB), PairRatingAggregateType, players)
+}
+
+func (s *RatingService) view(ctx context.Context, id, aggregateType string, players []string) (RatingView, error) {
+	model, err := s.config.ReadStore.GetByID(ctx, id, aggregateType)
+	if err != nil {
+		if cqrs.IsNotFoundError(err) {
+			initial := NewRating()
+			return RatingView{ID: id, Players: players, Rating: initial, Conservative: initial.Conservative()}, nil
+		}
+		return RatingView{}, err
+	}
+	return decodeData[RatingView](model.GetData())
+}
+
+// History 플레이어 레이팅 변경 기록 (오래된 순)
+func (s *RatingService) History(ctx context.Context, playerID string) ([]RatingUpdatedData, error) {
+	if playerID == "" {
+		return nil, fmt.Errorf("%w: player ID is required", ErrInvalidQuery)
+	}
+	events, err := s.config.History.GetEventHistory(ctx, playerID, PlayerRatingAggregateType, 0)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]RatingUpdatedData, 0, len(events))
+	for _, event := range events {
+		data, err := decodeRatingUpdated(event)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, data)
+	}
+	return history, nil
+}
+
+// Distribution 플레이어 개인 레이팅 분포
+func (s *RatingService) Distribution(ctx context.Context, query DistributionQuery) (Distribution, error) {
+	if query.BucketSize < 0 || query.MinMatches < 0 {
+		return Distribution{}, fmt.Errorf("%w: bucket size and min matches cannot be negative", ErrInvalidQuery)
+	}
+	if query.BucketSize == 0 {
+		query.BucketSize = 100
+	}
+	models, err := s.config.ReadStore.Query(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": PlayerRatingAggregateType}})
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	ratings := make([]float64, 0, len(models))
+	for _, model := range models {
+		if model.GetType() != PlayerRatingAggregateType {
+			continue
+		}
+		view, err := decodeData[RatingView](model.GetData())
+		if err != nil {
+			return Distribution{}, err
+		}
+		if view.Matches < query.MinMatches {
+			continue
+		}
+		ratings = append(ratings, view.Rating.Rating)
+	}
+	return distribute(ratings, query.BucketSize), nil
+}
+
+func distribute(ratings []float64, bucketSize float64) Distribution {
+	distribution := Distribution{Players: len(ratings), Percentiles: map[string]float64{}, Buckets: []Bucket{}}
+	if len(ratings) == 0 {
+		return distribution
+	}
+	sort.Float64s(ratings)
+
+	var sum float64
+	for _, rating := range ratings {
+		sum += rating
+	}
+	distribution.Mean = sum / float64(len(ratings))
+	var variance float64
+	for _, rating := range ratings {
+		variance += (rating - distribution.Mean) * (rating - distribution.Mean)
+	}
+	distribution.StdDev = math.Sqrt(variance / float64(len(ratings)))
+
+	for _, p := range []int{10, 25, 50, 75, 90} {
+		index := int(math.Ceil(float64(p)/100*float64(len(ratings)))) - 1
+		distribution.Percentiles[fmt.Sprintf("p%d", p)] = ratings[max(index, 0)]
+	}
+
+	for _, rating := range ratings {
+		from := math.Floor(rating/bucketSize) * bucketSize
+		last := len(distribution.Buckets) - 1
+		if last >= 0 && distribution.Buckets[last].From == from {
+			distribution.Buckets[last].Count++
+			continue
+		}
+		distribution.Buckets = append(distribution.Buckets, Bucket{From: from, To: from + bucketSize, Count: 1})
+	}
+	return distribution
+}
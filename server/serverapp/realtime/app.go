@@ -0,0 +1,222 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/websocket"
+)
+
+// DefaultBasePath 실시간 채널 기본 경로
+const DefaultBasePath = "/realtime"
+
+// Config 실시간 서버앱 설정
+type Config struct {
+	BasePath          string                                                  // 라우트 기본 경로 (기본값: /realtime)
+	Redis             redis.UniversalClient                                   // 필수: 채널 스트림과 세션 저장소
+	KeyPrefix         string                                                  // Redis 키 접두사 (기본값: realtime)
+	ReplayWindow      time.Duration                                           // 끊긴 세션을 재개할 수 있는 시간 (기본값: 60s)
+	MaxReplayEvents   int                                                     // 재개 시 채널별 최대 재생 이벤트 수, 넘으면 resync (기본값: 1000)
+	StreamMaxLen      int64                                                   // 채널 스트림 최대 길이 (기본값: 10000)
+	HeartbeatInterval time.Duration                                           // ping 주기, 두 주기 동안 응답이 없으면 연결 종료 (기본값: 20s)
+	SendBuffer        int                                                     // 세션별 송신 버퍼 크기 (기본값: 256)
+	Auth              func(http.Handler) http.Handler                         // 선택: 연결 인증 미들웨어
+	Identify          func(r *http.Request) string                            // 선택: 세션 소유자 식별 (같은 사용자만 세션 재개 가능)
+	Authorize         func(ctx context.Context, userID, channel string) error // 선택: 채널 구독 권한 확인
+	CheckOrigin       func(r *http.Request) bool                              // 선택: Origin 검사 (기본값: 모두 허용)
+	Registry          *MessageTypeRegistry                                    // 선택: 설정하면 protobuf 이진 프로토콜 협상 허용
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Redis == nil {
+		return fmt.Errorf("redis client is required")
+	}
+	return nil
+}
+
+// RealtimeApp 채널 이벤트를 WebSocket으로 전달하는 서버앱
+// 이벤트는 채널별 Redis Stream에 기록되므로, 잠시 끊겼던 모바일 클라이언트는
+// 세션 ID와 마지막 이벤트 ID로 재접속해 전체 상태 대신 놓친 이벤트만 받습니다
+type RealtimeApp struct {
+	*serverapp.BaseApp
+	config   Config
+	log      *channelLog
+	sessions *sessionStore
+	hub      *hub
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	active map[*session]struct{}
+}
+
+// NewRealtimeApp 새로운 RealtimeApp을 생성합니다
+func NewRealtimeApp(config Config) (*RealtimeApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "realtime"
+	}
+	if config.ReplayWindow <= 0 {
+		config.ReplayWindow = 60 * time.Second
+	}
+	if config.MaxReplayEvents <= 0 {
+		config.MaxReplayEvents = 1000
+	}
+	if config.StreamMaxLen <= 0 {
+		config.StreamMaxLen = 10000
+	}
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = 20 * time.Second
+	}
+	if config.SendBuffer <= 0 {
+		config.SendBuffer = 256
+	}
+
+	channelLog := &channelLog{
+		client:       config.Redis,
+		keyPrefix:    config.KeyPrefix,
+		maxLen:       config.StreamMaxLen,
+		replayWindow: config.ReplayWindow,
+	}
+	return &RealtimeApp{
+		BaseApp:  serverapp.NewBaseApp("realtime"),
+		config:   config,
+		log:      channelLog,
+		sessions: &sessionStore{client: config.Redis, keyPrefix: config.KeyPrefix},
+		hub:      newHub(channelLog, 500*time.Millisecond),
+		active:   make(map[*session]struct{}),
+	}, nil
+}
+
+// Start 채널 스트림 읽기를 시작합니다
+func (a *RealtimeApp) Start(ctx context.Context) error {
+	if err := a.BaseApp.Start(ctx); err != nil {
+		return err
+	}
+
+	hubCtx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	a.cancel = cancel
+	a.mu.Unlock()
+	go a.hub.run(hubCtx)
+	return nil
+}
+
+// Stop 스트림 읽기를 멈추고 연결을 모두 닫습니다 (클라이언트는 다른 인스턴스로 세션을 재개)
+func (a *RealtimeApp) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	if a.cancel != nil {
+		a.cancel()
+	}
+	for s := range a.active {
+		s.close()
+	}
+	a.mu.Unlock()
+	return a.BaseApp.Stop(ctx)
+}
+
+// Publish 채널에 이벤트를 발행하고 이벤트 ID를 반환합니다
+func (a *RealtimeApp) Publish(ctx context.Context, channel, eventType string, data interface{}) (string, error) {
+	if channel == "" || eventType == "" {
+		return "", fmt.Errorf("channel and event type are required")
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event data: %w", err)
+	}
+	return a.log.append(ctx, channel, eventType, payload)
+}
+
+// Online 사용자의 WebSocket 연결이 이 인스턴스에 열려 있는지 확인합니다 (party.Presence)
+// 다른 인스턴스에 연결된 사용자는 확인하지 않으므로, 여러 인스턴스에서는 사용자를 같은 인스턴스로 라우팅해야 합니다
+func (a *RealtimeApp) Online(ctx context.Context, userID string) bool {
+	if userID == "" {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for s := range a.active {
+		if s.userID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *RealtimeApp) RegisterRoutes(mux *http.ServeMux) {
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	server := websocket.Server{Handshake: a.handshake, Handler: a.serveConn}
+	mux.Handle(a.config.BasePath+"/ws", protect(server))
+
+	log.Printf("[Realtime] Routes registered under %s", a.config.BasePath)
+}
+
+// DescribeAPI 실시간 엔드포인트 설명 (/openapi.json)
+func (a *RealtimeApp) DescribeAPI() []serverapp.APIOperation {
+	return []serverapp.APIOperation{
+		{
+			Method:  http.MethodGet,
+			Path:    a.config.BasePath + "/ws",
+			Summary: "실시간 이벤트 WebSocket",
+			Description: "연결 후 hello 메시지를 보냅니다. 재접속 시 session_id와 채널별 last_event_ids를 보내면 놓친 이벤트를 재생합니다. " +
+				"서브프로토콜 " + ProtocolProtobuf + "을 요청하면 protobuf 이진 프레임(proto/realtime.proto)을, 그 외에는 JSON 텍스트 프레임을 사용합니다.",
+			Secured: a.config.Auth != nil,
+		},
+	}
+}
+
+// handshake Origin을 검사하고 서브프로토콜을 고릅니다
+func (a *RealtimeApp) handshake(config *websocket.Config, r *http.Request) error {
+	if a.config.CheckOrigin != nil && !a.config.CheckOrigin(r) {
+		return fmt.Errorf("origin not allowed")
+	}
+
+	config.Protocol = nil
+	if protocol := negotiateProtocol(websocketProtocols(r), a.config.Registry != nil); protocol != "" {
+		config.Protocol = []string{protocol}
+	}
+	return nil
+}
+
+// websocketProtocols Sec-WebSocket-Protocol 헤더의 서브프로토콜 목록
+func websocketProtocols(r *http.Request) []string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+func (a *RealtimeApp) track(s *session, active bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if active {
+		a.active[s] = struct{}{}
+	} else {
+		delete(a.active, s)
+	}
+}
@@ -0,0 +1,259 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+type testServer struct {
+	app    *RealtimeApp
+	server *httptest.Server
+	redis  *miniredis.Miniredis
+}
+
+func newTestServer(t *testing.T, config Config) *testServer {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	config.Redis = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	if config.Identify == nil {
+		config.Identify = func(r *http.Request) string { return r.URL.Query().Get("user") }
+	}
+
+	app, err := NewRealtimeApp(config)
+	require.NoError(t, err)
+	require.NoError(t, app.Start(context.Background()))
+
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		server.Close()
+		app.Stop(context.Background())
+	})
+	return &testServer{app: app, server: server, redis: mr}
+}
+
+func (s *testServer) dial(t *testing.T, user string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http") + DefaultBasePath + "/ws?user=" + user
+	ws, err := websocket.Dial(url, "", s.server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+func send(t *testing.T, ws *websocket.Conn, message ClientMessage) {
+	t.Helper()
+	require.NoError(t, websocket.JSON.Send(ws, message))
+}
+
+// expect 다음 메시지를 읽습니다 (ping은 건너뜀)
+func expect(t *testing.T, ws *websocket.Conn, messageType string) ServerMessage {
+	t.Helper()
+	for {
+		ws.SetReadDeadline(time.Now().Add(3 * time.Second))
+		var message ServerMessage
+		require.NoError(t, websocket.JSON.Receive(ws, &message))
+		if message.Type == MessagePing {
+			continue
+		}
+		require.Equal(t, messageType, message.Type, "unexpected message: %+v", message)
+		return message
+	}
+}
+
+func (s *testServer) publish(t *testing.T, channel string, n int) []string {
+	t.Helper()
+	ids := make([]string, n)
+	for i := range ids {
+		id, err := s.app.Publish(context.Background(), channel, "WaveStarted", map[string]int{"wave": i + 1})
+		require.NoError(t, err)
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestRealtimeApp_SubscribeReceivesEvents(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{})
+	ts.publish(t, "match:1", 1) // 구독 전 이벤트는 전달되지 않음
+	ws := ts.dial(t, "alice")
+
+	send(t, ws, ClientMessage{Type: MessageHello})
+	welcome := expect(t, ws, MessageWelcome)
+	send(t, ws, ClientMessage{Type: MessageSubscribe, Channel: "match:1"})
+	subscribed := expect(t, ws, MessageSubscribed)
+
+	// Act
+	ids := ts.publish(t, "match:1", 2)
+
+	// Assert
+	assert.NotEmpty(t, welcome.SessionID)
+	assert.False(t, welcome.Resumed)
+	assert.Equal(t, -1, compareEventIDs(subscribed.EventID, ids[0]))
+	for i, id := range ids {
+		event := expect(t, ws, MessageEvent)
+		assert.Equal(t, id, event.EventID)
+		assert.Equal(t, "WaveStarted", event.EventType)
+		assert.JSONEq(t, fmt.Sprintf(`{"wave":%d}`, i+1), string(event.Data))
+		assert.False(t, event.Replayed)
+	}
+}
+
+func TestRealtimeApp_ResumeReplaysMissedEvents(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{})
+	ws := ts.dial(t, "alice")
+	send(t, ws, ClientMessage{Type: MessageHello})
+	sessionID := expect(t, ws, MessageWelcome).SessionID
+	send(t, ws, ClientMessage{Type: MessageSubscribe, Channel: "match:1"})
+	expect(t, ws, MessageSubscribed)
+	received := ts.publish(t, "match:1", 1)
+	expect(t, ws, MessageEvent)
+	ws.Close()
+
+	missed := ts.publish(t, "match:1", 3)
+
+	// Act
+	resumed := ts.dial(t, "alice")
+	send(t, resumed, ClientMessage{Type: MessageHello, SessionID: sessionID, LastEventIDs: map[string]string{"match:1": received[0]}})
+	welcome := expect(t, resumed, MessageWelcome)
+
+	// Assert
+	assert.True(t, welcome.Resumed)
+	assert.Equal(t, sessionID, welcome.SessionID)
+	assert.Equal(t, []string{"match:1"}, welcome.Channels)
+	for _, id := range missed {
+		event := expect(t, resumed, MessageEvent)
+		assert.Equal(t, id, event.EventID)
+		assert.True(t, event.Replayed)
+	}
+
+	live := ts.publish(t, "match:1", 1)
+	event := expect(t, resumed, MessageEvent)
+	assert.Equal(t, live[0], event.EventID)
+	assert.False(t, event.Replayed)
+}
+
+func TestRealtimeApp_ResumeFallsBackToResync(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{MaxReplayEvents: 2})
+	ws := ts.dial(t, "alice")
+	send(t, ws, ClientMessage{Type: MessageHello})
+	sessionID := expect(t, ws, MessageWelcome).SessionID
+	send(t, ws, ClientMessage{Type: MessageSubscribe, Channel: "match:1"})
+	send(t, ws, ClientMessage{Type: MessageSubscribe, Channel: "match:2"})
+	first := expect(t, ws, MessageSubscribed).EventID
+	expect(t, ws, MessageSubscribed)
+	ws.Close()
+
+	ids := ts.publish(t, "match:1", 3) // 재생 한도 초과
+	time.Sleep(50 * time.Millisecond)
+
+	// Act
+	resumed := ts.dial(t, "alice")
+	send(t, resumed, ClientMessage{Type: MessageHello, SessionID: sessionID, LastEventIDs: map[string]string{"match:1": first}})
+	welcome := expect(t, resumed, MessageWelcome)
+	resyncs := []ServerMessage{expect(t, resumed, MessageResync), expect(t, resumed, MessageResync)}
+
+	// Assert
+	assert.True(t, welcome.Resumed)
+	assert.ElementsMatch(t, []string{"match:1", "match:2"}, []string{resyncs[0].Channel, resyncs[1].Channel})
+	for _, resync := range resyncs {
+		if resync.Channel == "match:1" {
+			assert.Equal(t, ids[2], resync.EventID)
+		}
+	}
+}
+
+func TestRealtimeApp_SessionBelongsToUser(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{})
+	ws := ts.dial(t, "alice")
+	send(t, ws, ClientMessage{Type: MessageHello})
+	sessionID := expect(t, ws, MessageWelcome).SessionID
+	ws.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	// Act
+	other := ts.dial(t, "mallory")
+	send(t, other, ClientMessage{Type: MessageHello, SessionID: sessionID})
+	welcome := expect(t, other, MessageWelcome)
+
+	// Assert
+	assert.False(t, welcome.Resumed)
+	assert.NotEqual(t, sessionID, welcome.SessionID)
+}
+
+func TestRealtimeApp_SessionExpiresAfterReplayWindow(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{ReplayWindow: 10 * time.Second})
+	ws := ts.dial(t, "alice")
+	send(t, ws, ClientMessage{Type: MessageHello})
+	sessionID := expect(t, ws, MessageWelcome).SessionID
+	ws.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	// Act
+	ts.redis.FastForward(11 * time.Second)
+	resumed := ts.dial(t, "alice")
+	send(t, resumed, ClientMessage{Type: MessageHello, SessionID: sessionID})
+
+	// Assert
+	assert.False(t, expect(t, resumed, MessageWelcome).Resumed)
+}
+
+func TestRealtimeApp_RejectsUnauthorizedChannel(t *testing.T) {
+	ts := newTestServer(t, Config{
+		Authorize: func(ctx context.Context, userID, channel string) error {
+			if strings.HasPrefix(channel, "admin:") {
+				return fmt.Errorf("forbidden")
+			}
+			return nil
+		},
+	})
+	ws := ts.dial(t, "alice")
+	send(t, ws, ClientMessage{Type: MessageHello})
+	expect(t, ws, MessageWelcome)
+
+	send(t, ws, ClientMessage{Type: MessageSubscribe, Channel: "admin:ops"})
+	denied := expect(t, ws, MessageError)
+
+	assert.Equal(t, "admin:ops", denied.Channel)
+	assert.Contains(t, denied.Message, "forbidden")
+}
+
+func TestCompareEventIDs(t *testing.T) {
+	assert.Equal(t, -1, compareEventIDs("1-0", "1-1"))
+	assert.Equal(t, 1, compareEventIDs("10-0", "9-5"))
+	assert.Equal(t, 0, compareEventIDs("5-2", "5-2"))
+	assert.Equal(t, -1, compareEventIDs(streamStartID, "1-0"))
+}
+
+func TestRealtimeApp_OnlineTracksConnectedUsers(t *testing.T) {
+	// Arrange
+	ts := newTestServer(t, Config{})
+	ws := ts.dial(t, "alice")
+
+	// Act
+	send(t, ws, ClientMessage{Type: MessageHello})
+	expect(t, ws, MessageWelcome)
+
+	// Assert
+	assert.True(t, ts.app.Online(context.Background(), "alice"))
+	assert.False(t, ts.app.Online(context.Background(), "bob"))
+
+	ws.Close()
+	assert.Eventually(t, func() bool { return !ts.app.Online(context.Background(), "alice") }, 3*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,360 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// WebSocket 서브프로토콜
+// 서브프로토콜을 요청하지 않은 기존 클라이언트는 JSON 텍스트 프레임을 사용합니다
+const (
+	ProtocolJSON     = "defense-allies.json.v1"
+	ProtocolProtobuf = "defense-allies.proto.v1"
+)
+
+// frameCodec 연결 하나의 메시지 인코딩
+type frameCodec interface {
+	// encode 보낼 프레임을 만듭니다 (string은 텍스트 프레임, []byte는 이진 프레임)
+	encode(message ServerMessage) (interface{}, error)
+	decode(data []byte) (ClientMessage, error)
+}
+
+// negotiateProtocol 클라이언트가 제시한 서브프로토콜 중 하나를 고릅니다
+// 이진 프로토콜은 레지스트리가 설정된 경우에만 허용하며, 아무것도 고르지 못하면 ""(JSON)입니다
+func negotiateProtocol(offered []string, protobufEnabled bool) string {
+	selected := ""
+	for _, protocol := range offered {
+		switch {
+		case protocol == ProtocolProtobuf && protobufEnabled:
+			return ProtocolProtobuf
+		case protocol == ProtocolJSON:
+			selected = ProtocolJSON
+		}
+	}
+	return selected
+}
+
+func newFrameCodec(protocol string, registry *MessageTypeRegistry) frameCodec {
+	if protocol == ProtocolProtobuf {
+		return &protobufCodec{registry: registry}
+	}
+	return jsonCodec{}
+}
+
+// jsonCodec JSON 텍스트 프레임
+type jsonCodec struct{}
+
+func (jsonCodec) encode(message ServerMessage) (interface{}, error) {
+	data, err := json.Marshal(message)
+	return string(data), err
+}
+
+func (jsonCodec) decode(data []byte) (ClientMessage, error) {
+	var message ClientMessage
+	err := json.Unmarshal(data, &message)
+	return message, err
+}
+
+// protobuf 프레임 필드 번호 (proto/realtime.proto와 일치해야 함)
+const (
+	clientFieldType         protowire.Number = 1
+	clientFieldSessionID    protowire.Number = 2
+	clientFieldLastEventIDs protowire.Number = 3
+	clientFieldChannel      protowire.Number = 4
+
+	serverFieldType        protowire.Number = 1
+	serverFieldSessionID   protowire.Number = 2
+	serverFieldResumed     protowire.Number = 3
+	serverFieldChannels    protowire.Number = 4
+	serverFieldChannel     protowire.Number = 5
+	serverFieldEventID     protowire.Number = 6
+	serverFieldEventType   protowire.Number = 7
+	serverFieldEventTypeID protowire.Number = 8
+	serverFieldData        protowire.Number = 9
+	serverFieldEncoding    protowire.Number = 10
+	serverFieldTimestamp   protowire.Number = 11
+	serverFieldReplayed    protowire.Number = 12
+	serverFieldMessage     protowire.Number = 13
+)
+
+// 페이로드 인코딩 (PayloadEncoding)
+const (
+	payloadJSON     = 0
+	payloadProtobuf = 1
+)
+
+// messageTypeNumbers 메시지 타입 이름과 MessageType 열거값
+var messageTypeNumbers = map[string]uint64{
+	MessageHello:        1,
+	MessageSubscribe:    2,
+	MessageUnsubscribe:  3,
+	MessagePong:         4,
+	MessageWelcome:      5,
+	MessageSubscribed:   6,
+	MessageUnsubscribed: 7,
+	MessageEvent:        8,
+	MessageResync:       9,
+	MessagePing:         10,
+	MessageError:        11,
+}
+
+var messageTypeNames = func() map[uint64]string {
+	names := make(map[uint64]string, len(messageTypeNumbers))
+	for name, number := range messageTypeNumbers {
+		names[number] = name
+	}
+	return names
+}()
+
+// protobufCodec ServerFrame/ClientFrame 이진 프레임
+type protobufCodec struct {
+	registry *MessageTypeRegistry
+}
+
+func (c *protobufCodec) encode(message ServerMessage) (interface{}, error) {
+	return EncodeServerFrame(message, c.registry)
+}
+
+func (c *protobufCodec) decode(data []byte) (ClientMessage, error) {
+	return DecodeClientFrame(data)
+}
+
+// EncodeServerFrame 서버 메시지를 ServerFrame으로 인코딩합니다
+// 레지스트리에 등록된 이벤트 타입은 번호로 보내고, protobuf 타입이 있으면 데이터도 변환합니다
+func EncodeServerFrame(message ServerMessage, registry *MessageTypeRegistry) ([]byte, error) {
+	number, known := messageTypeNumbers[message.Type]
+	if !known {
+		return nil, fmt.Errorf("unknown message type %q", message.Type)
+	}
+
+	b := appendVarintField(nil, serverFieldType, number)
+	b = appendStringField(b, serverFieldSessionID, message.SessionID)
+	if message.Resumed {
+		b = appendVarintField(b, serverFieldResumed, 1)
+	}
+	for _, channel := range message.Channels {
+		b = protowire.AppendTag(b, serverFieldChannels, protowire.BytesType)
+		b = protowire.AppendString(b, channel)
+	}
+	b = appendStringField(b, serverFieldChannel, message.Channel)
+	b = appendStringField(b, serverFieldEventID, message.EventID)
+
+	data, encoding := []byte(message.Data), uint64(payloadJSON)
+	if entry, registered := lookupEventType(registry, message.EventType); registered {
+		b = appendVarintField(b, serverFieldEventTypeID, uint64(entry.id))
+		if entry.prototype != nil {
+			converted, err := entry.toProtobuf(message.Data)
+			if err != nil {
+				// 변환할 수 없는 데이터는 JSON으로 보냅니다 (클라이언트는 encoding으로 구분)
+				log.Printf("[Realtime] %v", err)
+			} else {
+				data, encoding = converted, payloadProtobuf
+			}
+		}
+	} else {
+		b = appendStringField(b, serverFieldEventType, message.EventType)
+	}
+	if len(data) > 0 {
+		b = protowire.AppendTag(b, serverFieldData, protowire.BytesType)
+		b = protowire.AppendBytes(b, data)
+	}
+	if encoding != payloadJSON {
+		b = appendVarintField(b, serverFieldEncoding, encoding)
+	}
+
+	if message.Timestamp != nil {
+		b = appendVarintField(b, serverFieldTimestamp, uint64(message.Timestamp.UnixMilli()))
+	}
+	if message.Replayed {
+		b = appendVarintField(b, serverFieldReplayed, 1)
+	}
+	b = appendStringField(b, serverFieldMessage, message.Message)
+	return b, nil
+}
+
+// DecodeServerFrame ServerFrame을 서버 메시지로 디코딩합니다 (Go 클라이언트, 부하 테스트용)
+// protobuf 페이로드는 레지스트리의 메시지 타입으로 JSON으로 되돌립니다
+func DecodeServerFrame(b []byte, registry *MessageTypeRegistry) (ServerMessage, error) {
+	var message ServerMessage
+	var eventTypeID uint64
+	var encoding uint64
+
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch num {
+		case serverFieldType:
+			message.Type = messageTypeNames[varint]
+		case serverFieldSessionID:
+			message.SessionID = string(value)
+		case serverFieldResumed:
+			message.Resumed = varint != 0
+		case serverFieldChannels:
+			message.Channels = append(message.Channels, string(value))
+		case serverFieldChannel:
+			message.Channel = string(value)
+		case serverFieldEventID:
+			message.EventID = string(value)
+		case serverFieldEventType:
+			message.EventType = string(value)
+		case serverFieldEventTypeID:
+			eventTypeID = varint
+		case serverFieldData:
+			message.Data = append(json.RawMessage(nil), value...)
+		case serverFieldEncoding:
+			encoding = varint
+		case serverFieldTimestamp:
+			timestamp := time.UnixMilli(int64(varint))
+			message.Timestamp = &timestamp
+		case serverFieldReplayed:
+			message.Replayed = varint != 0
+		case serverFieldMessage:
+			message.Message = string(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return ServerMessage{}, err
+	}
+
+	if eventTypeID != 0 {
+		entry, registered := lookupEventTypeID(registry, uint32(eventTypeID))
+		if !registered {
+			return ServerMessage{}, fmt.Errorf("unknown event type id %d", eventTypeID)
+		}
+		message.EventType = entry.name
+		if encoding == payloadProtobuf {
+			if entry.prototype == nil {
+				return ServerMessage{}, fmt.Errorf("event type %s has no protobuf message", entry.name)
+			}
+			if message.Data, err = entry.toJSON(message.Data); err != nil {
+				return ServerMessage{}, err
+			}
+		}
+	}
+	return message, nil
+}
+
+// EncodeClientFrame 클라이언트 메시지를 ClientFrame으로 인코딩합니다 (Go 클라이언트, 부하 테스트용)
+func EncodeClientFrame(message ClientMessage) ([]byte, error) {
+	number, known := messageTypeNumbers[message.Type]
+	if !known {
+		return nil, fmt.Errorf("unknown message type %q", message.Type)
+	}
+
+	b := appendVarintField(nil, clientFieldType, number)
+	b = appendStringField(b, clientFieldSessionID, message.SessionID)
+	for channel, eventID := range message.LastEventIDs {
+		var entry []byte
+		entry = appendStringField(entry, 1, channel)
+		entry = appendStringField(entry, 2, eventID)
+		b = protowire.AppendTag(b, clientFieldLastEventIDs, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	b = appendStringField(b, clientFieldChannel, message.Channel)
+	return b, nil
+}
+
+// DecodeClientFrame ClientFrame을 클라이언트 메시지로 디코딩합니다
+func DecodeClientFrame(b []byte) (ClientMessage, error) {
+	var message ClientMessage
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch num {
+		case clientFieldType:
+			name, known := messageTypeNames[varint]
+			if !known {
+				return fmt.Errorf("unknown message type %d", varint)
+			}
+			message.Type = name
+		case clientFieldSessionID:
+			message.SessionID = string(value)
+		case clientFieldLastEventIDs:
+			var channel, eventID string
+			if err := consumeFields(value, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+				switch num {
+				case 1:
+					channel = string(value)
+				case 2:
+					eventID = string(value)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if message.LastEventIDs == nil {
+				message.LastEventIDs = make(map[string]string)
+			}
+			message.LastEventIDs[channel] = eventID
+		case clientFieldChannel:
+			message.Channel = string(value)
+		}
+		return nil
+	})
+	return message, err
+}
+
+func lookupEventType(registry *MessageTypeRegistry, eventType string) (*registeredEventType, bool) {
+	if registry == nil || eventType == "" {
+		return nil, false
+	}
+	return registry.lookupName(eventType)
+}
+
+func lookupEventTypeID(registry *MessageTypeRegistry, id uint32) (*registeredEventType, bool) {
+	if registry == nil {
+		return nil, false
+	}
+	return registry.lookupID(id)
+}
+
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+// appendStringField 빈 문자열은 proto3 기본값이므로 생략합니다
+func appendStringField(b []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+// consumeFields 메시지의 필드를 차례로 읽습니다 (모르는 필드와 타입은 건너뜀)
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var value []byte
+		var varint uint64
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newTestRegistry(t *testing.T) *MessageTypeRegistry {
+	registry := NewMessageTypeRegistry()
+	require.NoError(t, registry.Register(1, "WaveStarted", &structpb.Struct{}))
+	require.NoError(t, registry.Register(2, "MatchEnded", nil))
+	return registry
+}
+
+func TestMessageTypeRegistry_RejectsConflicts(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	assert.NoError(t, registry.Register(1, "WaveStarted", &structpb.Struct{}))
+	assert.Error(t, registry.Register(1, "TowerPlaced", nil))
+	assert.Error(t, registry.Register(3, "WaveStarted", nil))
+	assert.Error(t, registry.Register(0, "TowerPlaced", nil))
+}
+
+func TestServerFrame_RoundTrip(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry(t)
+	timestamp := time.UnixMilli(1700000000123)
+	messages := []ServerMessage{
+		{Type: MessageWelcome, SessionID: "s-1", Resumed: true, Channels: []string{"match:1", "match:2"}},
+		{Type: MessageEvent, Channel: "match:1", EventID: "1-0", EventType: "WaveStarted", Data: json.RawMessage(`{"wave":3}`), Timestamp: &timestamp, Replayed: true},
+		{Type: MessageEvent, Channel: "match:1", EventID: "1-1", EventType: "MatchEnded", Data: json.RawMessage(`{"winner":"allies"}`), Timestamp: &timestamp},
+		{Type: MessageEvent, Channel: "match:1", EventID: "1-2", EventType: "Unregistered", Data: json.RawMessage(`{"x":1}`), Timestamp: &timestamp},
+		{Type: MessageResync, Channel: "match:1", EventID: "2-0", Message: "missed events are no longer available"},
+	}
+
+	for _, message := range messages {
+		// Act
+		frame, err := EncodeServerFrame(message, registry)
+		require.NoError(t, err)
+		decoded, err := DecodeServerFrame(frame, registry)
+		require.NoError(t, err)
+
+		// Assert
+		if message.Data != nil {
+			assert.JSONEq(t, string(message.Data), string(decoded.Data))
+			decoded.Data, message.Data = nil, nil
+		}
+		assert.Equal(t, message, decoded)
+	}
+}
+
+func TestServerFrame_RegisteredEventIsCompact(t *testing.T) {
+	registry := newTestRegistry(t)
+	message := ServerMessage{Type: MessageEvent, Channel: "match:1", EventID: "1-0", EventType: "MatchEnded", Data: json.RawMessage(`{}`)}
+
+	withRegistry, err := EncodeServerFrame(message, registry)
+	require.NoError(t, err)
+	withoutRegistry, err := EncodeServerFrame(message, nil)
+	require.NoError(t, err)
+	asJSON, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	assert.Less(t, len(withRegistry), len(withoutRegistry))
+	assert.Less(t, len(withoutRegistry), len(asJSON))
+	assert.NotContains(t, string(withRegistry), "MatchEnded")
+}
+
+func TestClientFrame_RoundTrip(t *testing.T) {
+	message := ClientMessage{Type: MessageHello, SessionID: "s-1", LastEventIDs: map[string]string{"match:1": "1-0", "match:2": "2-5"}}
+
+	frame, err := EncodeClientFrame(message)
+	require.NoError(t, err)
+	decoded, err := DecodeClientFrame(frame)
+	require.NoError(t, err)
+
+	assert.Equal(t, message, decoded)
+
+	_, err = DecodeClientFrame([]byte{0x08, 0x63}) // type = 99
+	assert.Error(t, err)
+	_, err = DecodeClientFrame([]byte{0x12, 0x05, 'a'}) // 잘린 문자열
+	assert.Error(t, err)
+}
+
+func TestNegotiateProtocol(t *testing.T) {
+	assert.Equal(t, ProtocolProtobuf, negotiateProtocol([]string{ProtocolJSON, ProtocolProtobuf}, true))
+	assert.Equal(t, ProtocolJSON, negotiateProtocol([]string{ProtocolJSON, ProtocolProtobuf}, false))
+	assert.Equal(t, "", negotiateProtocol([]string{"chat"}, true))
+	assert.Equal(t, "", negotiateProtocol(nil, true))
+}
+
+func (s *testServer) dialProtocol(t *testing.T, user string, protocols ...string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http") + DefaultBasePath + "/ws?user=" + user
+	config, err := websocket.NewConfig(url, s.server.URL)
+	require.NoError(t, err)
+	config.Protocol = protocols
+	ws, err := websocket.DialConfig(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+func TestRealtimeApp_ProtobufProtocol(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry(t)
+	require.NoError(t, registry.Register(3, "GoldChanged", &wrapperspb.Int64Value{}))
+	ts := newTestServer(t, Config{Registry: registry})
+	ws := ts.dialProtocol(t, "alice", ProtocolProtobuf, ProtocolJSON)
+
+	receive := func(messageType string) ServerMessage {
+		t.Helper()
+		for {
+			ws.SetReadDeadline(time.Now().Add(3 * time.Second))
+			var frame []byte
+			require.NoError(t, websocket.Message.Receive(ws, &frame))
+			message, err := DecodeServerFrame(frame, registry)
+			require.NoError(t, err)
+			if message.Type == MessagePing {
+				continue
+			}
+			require.Equal(t, messageType, message.Type, "unexpected message: %+v", message)
+			return message
+		}
+	}
+	sendFrame := func(message ClientMessage) {
+		t.Helper()
+		frame, err := EncodeClientFrame(message)
+		require.NoError(t, err)
+		require.NoError(t, websocket.Message.Send(ws, frame))
+	}
+
+	// Act
+	sendFrame(ClientMessage{Type: MessageHello})
+	welcome := receive(MessageWelcome)
+	sendFrame(ClientMessage{Type: MessageSubscribe, Channel: "match:1"})
+	receive(MessageSubscribed)
+	_, err := ts.app.Publish(context.Background(), "match:1", "WaveStarted", map[string]int{"wave": 7})
+	require.NoError(t, err)
+	_, err = ts.app.Publish(context.Background(), "match:1", "GoldChanged", 250)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{ProtocolProtobuf}, ws.Config().Protocol)
+	assert.NotEmpty(t, welcome.SessionID)
+	wave := receive(MessageEvent)
+	assert.Equal(t, "WaveStarted", wave.EventType)
+	assert.JSONEq(t, `{"wave":7}`, string(wave.Data))
+	gold := receive(MessageEvent)
+	assert.Equal(t, "GoldChanged", gold.EventType)
+	assert.JSONEq(t, `"250"`, string(gold.Data)) // protojson은 int64를 문자열로 표현
+}
+
+func TestRealtimeApp_JSONClientsUnaffectedByRegistry(t *testing.T) {
+	// 서브프로토콜 없이 접속한 기존 클라이언트는 계속 JSON 텍스트 프레임을 받습니다
+	ts := newTestServer(t, Config{Registry: newTestRegistry(t)})
+	ws := ts.dial(t, "alice")
+
+	send(t, ws, ClientMessage{Type: MessageHello})
+	welcome := expect(t, ws, MessageWelcome)
+
+	assert.Empty(t, ws.Config().Protocol)
+	assert.NotEmpty(t, welcome.SessionID)
+}
@@ -0,0 +1,190 @@
+package realtime
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+// maxMessageSize 클라이언트 메시지 최대 크기
+const maxMessageSize = 64 << 10
+
+// serveConn WebSocket 연결 하나를 처리합니다
+// 첫 메시지는 hello여야 하며, 이후 subscribe/unsubscribe/pong을 받습니다
+func (a *RealtimeApp) serveConn(ws *websocket.Conn) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = maxMessageSize
+
+	protocol := ""
+	if len(ws.Config().Protocol) == 1 {
+		protocol = ws.Config().Protocol[0]
+	}
+	codec := newFrameCodec(protocol, a.config.Registry)
+
+	r := ws.Request()
+	ctx := r.Context()
+	userID := ""
+	if a.config.Identify != nil {
+		userID = a.config.Identify(r)
+	}
+
+	ws.SetReadDeadline(time.Now().Add(a.config.HeartbeatInterval))
+	var data []byte
+	if err := websocket.Message.Receive(ws, &data); err != nil {
+		return
+	}
+	hello, err := codec.decode(data)
+	if err != nil || hello.Type != MessageHello {
+		sendFrame(ws, codec, ServerMessage{Type: MessageError, Message: "first message must be hello"})
+		return
+	}
+
+	s, record := a.openSession(ctx, hello.SessionID, userID)
+	a.track(s, true)
+	defer func() {
+		a.track(s, false)
+		s.close()
+		for _, channel := range s.subscribedChannels() {
+			a.hub.remove(channel, s)
+		}
+		// 재생 윈도우 동안 세션을 남겨 재접속 시 복원합니다
+		if err := a.sessions.save(context.WithoutCancel(ctx), s.record(), a.config.ReplayWindow); err != nil {
+			log.Printf("[Realtime] %v", err)
+		}
+	}()
+
+	go a.writeLoop(ctx, ws, codec, s)
+
+	resumed := record != nil
+	welcome := ServerMessage{Type: MessageWelcome, SessionID: s.id, Resumed: resumed}
+	if resumed {
+		welcome.Channels = record.Channels
+	}
+	s.send(welcome)
+
+	if resumed {
+		for _, channel := range record.Channels {
+			if !a.authorized(ctx, s, channel) {
+				continue
+			}
+			lastEventID := hello.LastEventIDs[channel]
+			if err := s.subscribe(ctx, channel, lastEventID, true); err != nil {
+				log.Printf("[Realtime] Failed to resume channel %s: %v", channel, err)
+				s.send(ServerMessage{Type: MessageError, Channel: channel, Message: "failed to resume channel"})
+			}
+		}
+	}
+	a.saveSession(ctx, s)
+
+	for {
+		ws.SetReadDeadline(time.Now().Add(2 * a.config.HeartbeatInterval))
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			return
+		}
+		message, err := codec.decode(data)
+		if err != nil {
+			s.send(ServerMessage{Type: MessageError, Message: "malformed message"})
+			continue
+		}
+
+		switch message.Type {
+		case MessageSubscribe:
+			if message.Channel == "" {
+				s.send(ServerMessage{Type: MessageError, Message: "channel is required"})
+				continue
+			}
+			if !a.authorized(ctx, s, message.Channel) {
+				continue
+			}
+			if err := s.subscribe(ctx, message.Channel, "", false); err != nil {
+				log.Printf("[Realtime] Failed to subscribe to channel %s: %v", message.Channel, err)
+				s.send(ServerMessage{Type: MessageError, Channel: message.Channel, Message: "failed to subscribe"})
+				continue
+			}
+			a.saveSession(ctx, s)
+		case MessageUnsubscribe:
+			s.unsubscribe(message.Channel)
+			a.saveSession(ctx, s)
+		case MessagePong:
+		default:
+			s.send(ServerMessage{Type: MessageError, Message: "unknown message type: " + message.Type})
+		}
+
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+	}
+}
+
+// openSession hello의 세션을 복원하거나 새 세션을 만듭니다
+// 없거나 만료되었거나 다른 사용자의 세션이면 새 세션을 만들고 record는 nil입니다
+func (a *RealtimeApp) openSession(ctx context.Context, sessionID, userID string) (*session, *sessionRecord) {
+	if sessionID != "" {
+		record, err := a.sessions.load(ctx, sessionID)
+		if err != nil {
+			log.Printf("[Realtime] %v", err)
+		}
+		if record != nil && record.UserID == userID {
+			return newSession(a, record.ID, userID), record
+		}
+	}
+	return newSession(a, uuid.NewString(), userID), nil
+}
+
+// authorized 채널 구독 권한을 확인하고, 거부되면 클라이언트에 알립니다
+func (a *RealtimeApp) authorized(ctx context.Context, s *session, channel string) bool {
+	if a.config.Authorize == nil {
+		return true
+	}
+	if err := a.config.Authorize(ctx, s.userID, channel); err != nil {
+		s.send(ServerMessage{Type: MessageError, Channel: channel, Message: "subscription denied: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// saveSession 연결 중인 세션을 저장합니다 (heartbeat마다 만료 시간 연장)
+func (a *RealtimeApp) saveSession(ctx context.Context, s *session) {
+	if err := a.sessions.save(ctx, s.record(), a.config.ReplayWindow+2*a.config.HeartbeatInterval); err != nil {
+		log.Printf("[Realtime] %v", err)
+	}
+}
+
+// writeLoop 송신 버퍼의 메시지와 주기적인 ping을 보냅니다
+func (a *RealtimeApp) writeLoop(ctx context.Context, ws *websocket.Conn, codec frameCodec, s *session) {
+	defer ws.Close()
+	ticker := time.NewTicker(a.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case message := <-s.outbound:
+			if err := sendFrame(ws, codec, message); err != nil {
+				s.close()
+				return
+			}
+		case <-ticker.C:
+			if err := sendFrame(ws, codec, ServerMessage{Type: MessagePing}); err != nil {
+				s.close()
+				return
+			}
+			a.saveSession(ctx, s)
+		}
+	}
+}
+
+// sendFrame 연결의 프로토콜로 인코딩해 보냅니다 (JSON은 텍스트, protobuf는 이진 프레임)
+func sendFrame(ws *websocket.Conn, codec frameCodec, message ServerMessage) error {
+	frame, err := codec.encode(message)
+	if err != nil {
+		return err
+	}
+	return websocket.Message.Send(ws, frame)
+}
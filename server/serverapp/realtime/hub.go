@@ -0,0 +1,162 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// hub 인스턴스당 하나의 XREAD 루프로 구독 중인 모든 채널 스트림을 읽어 세션에 전달합니다
+// 다른 인스턴스에서 발행한 이벤트도 스트림을 통해 전달됩니다
+type hub struct {
+	log          *channelLog
+	blockTimeout time.Duration
+
+	mu       sync.Mutex
+	channels map[string]*hubChannel
+	wake     chan struct{}
+}
+
+type hubChannel struct {
+	position    string // 이 인스턴스가 마지막으로 읽은 엔트리 ID
+	subscribers map[*session]struct{}
+}
+
+func newHub(channelLog *channelLog, blockTimeout time.Duration) *hub {
+	return &hub{
+		log:          channelLog,
+		blockTimeout: blockTimeout,
+		channels:     make(map[string]*hubChannel),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// add 세션을 채널 구독자로 등록합니다 (처음 구독되는 채널은 현재 끝부터 읽음)
+func (h *hub) add(ctx context.Context, channel string, s *session) error {
+	h.mu.Lock()
+	if entry, exists := h.channels[channel]; exists {
+		entry.subscribers[s] = struct{}{}
+		h.mu.Unlock()
+		return nil
+	}
+	h.mu.Unlock()
+
+	tail, err := h.log.tail(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	entry, exists := h.channels[channel]
+	if !exists {
+		entry = &hubChannel{position: tail, subscribers: make(map[*session]struct{})}
+		h.channels[channel] = entry
+	}
+	entry.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// remove 세션의 채널 구독을 해제합니다 (구독자가 없으면 채널 읽기 중단)
+func (h *hub) remove(channel string, s *session) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry, exists := h.channels[channel]; exists {
+		delete(entry.subscribers, s)
+		if len(entry.subscribers) == 0 {
+			delete(h.channels, channel)
+		}
+	}
+}
+
+// run ctx가 끝날 때까지 채널 스트림을 읽습니다
+func (h *hub) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		channels, streams := h.snapshot()
+		if len(channels) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-h.wake:
+				continue
+			}
+		}
+
+		results, err := h.log.client.XRead(ctx, &redis.XReadArgs{
+			Streams: streams,
+			Count:   100,
+			Block:   h.blockTimeout,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("[Realtime] Failed to read channel streams: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		for _, result := range results {
+			channel, known := channels[result.Stream]
+			if !known {
+				continue
+			}
+			for _, message := range result.Messages {
+				h.dispatch(toEvent(channel, message))
+			}
+		}
+	}
+}
+
+// snapshot XREAD 인자(키들 다음에 위치들)와 스트림 키별 채널 이름을 만듭니다
+func (h *hub) snapshot() (map[string]string, []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	channels := make(map[string]string, len(h.channels))
+	keys := make([]string, 0, len(h.channels))
+	positions := make([]string, 0, len(h.channels))
+	for channel, entry := range h.channels {
+		key := h.log.key(channel)
+		channels[key] = channel
+		keys = append(keys, key)
+		positions = append(positions, entry.position)
+	}
+	return channels, append(keys, positions...)
+}
+
+// dispatch 읽은 이벤트를 채널 구독자에게 전달하고 읽기 위치를 갱신합니다
+func (h *hub) dispatch(event Event) {
+	h.mu.Lock()
+	entry, exists := h.channels[event.Channel]
+	if !exists || compareEventIDs(event.ID, entry.position) <= 0 {
+		h.mu.Unlock()
+		return
+	}
+	entry.position = event.ID
+	subscribers := make([]*session, 0, len(entry.subscribers))
+	for s := range entry.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	h.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.deliver(event, false)
+	}
+}
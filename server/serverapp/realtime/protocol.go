@@ -0,0 +1,60 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 메시지 타입
+const (
+	// 클라이언트 → 서버
+	MessageHello       = "hello"       // 연결 직후 첫 메시지 (세션 재개 시 session_id, last_event_ids 포함)
+	MessageSubscribe   = "subscribe"   // 채널 구독
+	MessageUnsubscribe = "unsubscribe" // 채널 구독 해제
+	MessagePong        = "pong"        // ping 응답
+
+	// 서버 → 클라이언트
+	MessageWelcome      = "welcome"      // hello 응답 (resumed 여부와 복원된 채널)
+	MessageSubscribed   = "subscribed"   // 구독 완료 (event_id는 구독 시점의 채널 위치)
+	MessageUnsubscribed = "unsubscribed" // 구독 해제 완료
+	MessageEvent        = "event"        // 채널 이벤트
+	MessageResync       = "resync"       // 놓친 이벤트를 재생할 수 없음: 채널 상태를 다시 불러와야 함
+	MessagePing         = "ping"         // 연결 유지 확인
+	MessageError        = "error"
+)
+
+// ClientMessage 클라이언트가 보내는 메시지
+type ClientMessage struct {
+	Type         string            `json:"type"`
+	SessionID    string            `json:"session_id,omitempty"`     // hello: 재개할 세션 ID
+	LastEventIDs map[string]string `json:"last_event_ids,omitempty"` // hello: 채널별 마지막으로 받은 이벤트 ID
+	Channel      string            `json:"channel,omitempty"`        // subscribe, unsubscribe
+}
+
+// ServerMessage 서버가 보내는 메시지
+type ServerMessage struct {
+	Type      string          `json:"type"`
+	SessionID string          `json:"session_id,omitempty"`
+	Resumed   bool            `json:"resumed,omitempty"`
+	Channels  []string        `json:"channels,omitempty"`
+	Channel   string          `json:"channel,omitempty"`
+	EventID   string          `json:"event_id,omitempty"`
+	EventType string          `json:"event_type,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Timestamp *time.Time      `json:"timestamp,omitempty"`
+	Replayed  bool            `json:"replayed,omitempty"` // 재접속 후 재생된 이벤트
+	Message   string          `json:"message,omitempty"`
+}
+
+func eventMessage(event Event, replayed bool) ServerMessage {
+	timestamp := event.Timestamp
+	return ServerMessage{
+		Type:      MessageEvent,
+		Channel:   event.Channel,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Data:      event.Data,
+		Timestamp: &timestamp,
+		Replayed:  replayed,
+	}
+}
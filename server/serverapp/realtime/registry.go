@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MessageTypeRegistry 이진 프로토콜에서 쓰는 이벤트 타입 번호와 protobuf 페이로드 타입을 관리합니다
+// 등록된 이벤트는 타입 이름 대신 번호로 전송되고, 메시지 타입이 있으면 JSON 데이터를
+// protobuf로 변환해 보냅니다 (고빈도 매치 상태 업데이트의 대역폭 절감)
+type MessageTypeRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]*registeredEventType
+	byID   map[uint32]*registeredEventType
+}
+
+type registeredEventType struct {
+	id        uint32
+	name      string
+	prototype proto.Message // nil이면 데이터는 JSON 그대로 전송
+}
+
+// NewMessageTypeRegistry 새로운 레지스트리를 생성합니다
+func NewMessageTypeRegistry() *MessageTypeRegistry {
+	return &MessageTypeRegistry{
+		byName: make(map[string]*registeredEventType),
+		byID:   make(map[uint32]*registeredEventType),
+	}
+}
+
+// Register 이벤트 타입에 번호를 부여합니다
+// prototype을 주면 이벤트 JSON 데이터를 해당 protobuf 메시지로 변환해 전송합니다 (JSON 필드명은 protojson 규칙)
+// 번호는 클라이언트와 공유되므로 한 번 배포한 번호는 다른 타입에 재사용하지 않아야 합니다
+func (r *MessageTypeRegistry) Register(id uint32, eventType string, prototype proto.Message) error {
+	if id == 0 || eventType == "" {
+		return fmt.Errorf("event type id and name are required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, exists := r.byID[id]; exists && existing.name != eventType {
+		return fmt.Errorf("event type id %d is already registered for %s", id, existing.name)
+	}
+	if existing, exists := r.byName[eventType]; exists && existing.id != id {
+		return fmt.Errorf("event type %s is already registered with id %d", eventType, existing.id)
+	}
+
+	entry := &registeredEventType{id: id, name: eventType, prototype: prototype}
+	r.byID[id] = entry
+	r.byName[eventType] = entry
+	return nil
+}
+
+func (r *MessageTypeRegistry) lookupName(eventType string) (*registeredEventType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, exists := r.byName[eventType]
+	return entry, exists
+}
+
+func (r *MessageTypeRegistry) lookupID(id uint32) (*registeredEventType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, exists := r.byID[id]
+	return entry, exists
+}
+
+// toProtobuf JSON 이벤트 데이터를 등록된 protobuf 메시지로 변환합니다
+func (t *registeredEventType) toProtobuf(data json.RawMessage) ([]byte, error) {
+	message := t.prototype.ProtoReflect().New().Interface()
+	if len(data) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+			return nil, fmt.Errorf("failed to convert %s data to protobuf: %w", t.name, err)
+		}
+	}
+	return proto.Marshal(message)
+}
+
+// toJSON protobuf 이벤트 데이터를 JSON으로 되돌립니다 (Go 클라이언트, 테스트용)
+func (t *registeredEventType) toJSON(data []byte) (json.RawMessage, error) {
+	message := t.prototype.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("failed to decode %s protobuf data: %w", t.name, err)
+	}
+	return protojson.Marshal(message)
+}
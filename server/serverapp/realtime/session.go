@@ -0,0 +1,223 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sessionRecord 재접속 시 복원할 세션 정보 (Redis에 저장)
+type sessionRecord struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id,omitempty"`
+	Channels  []string  `json:"channels,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// sessionStore 세션 정보를 만료 시간과 함께 Redis에 보관합니다
+type sessionStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+func (s *sessionStore) key(sessionID string) string {
+	return s.keyPrefix + ":session:" + sessionID
+}
+
+func (s *sessionStore) save(ctx context.Context, record sessionRecord, ttl time.Duration) error {
+	record.UpdatedAt = time.Now()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.key(record.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save realtime session: %w", err)
+	}
+	return nil
+}
+
+// load 세션을 조회합니다 (없거나 만료되었으면 nil)
+func (s *sessionStore) load(ctx context.Context, sessionID string) (*sessionRecord, error) {
+	data, err := s.client.Get(ctx, s.key(sessionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load realtime session: %w", err)
+	}
+
+	var record sessionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode realtime session: %w", err)
+	}
+	return &record, nil
+}
+
+// session WebSocket 연결 하나의 구독 상태
+type session struct {
+	id       string
+	userID   string
+	app      *RealtimeApp
+	outbound chan ServerMessage
+	done     chan struct{}
+	once     sync.Once
+
+	mu       sync.Mutex
+	channels map[string]*channelCursor
+}
+
+// channelCursor 채널별 전달 위치
+// 구독 직후 재생하는 동안 도착한 실시간 이벤트는 pending에 모았다가 재생 뒤에 전달합니다
+type channelCursor struct {
+	position  string
+	replaying bool
+	pending   []Event
+}
+
+func newSession(app *RealtimeApp, id, userID string) *session {
+	return &session{
+		id:       id,
+		userID:   userID,
+		app:      app,
+		outbound: make(chan ServerMessage, app.config.SendBuffer),
+		done:     make(chan struct{}),
+		channels: make(map[string]*channelCursor),
+	}
+}
+
+// subscribe 채널을 구독합니다
+// resume이면 resumeFrom 이후 이벤트를 재생하고, 재생할 수 없으면 resync를 보낸 뒤 현재 위치부터 전달합니다
+func (s *session) subscribe(ctx context.Context, channel, resumeFrom string, resume bool) error {
+	s.mu.Lock()
+	if _, exists := s.channels[channel]; exists {
+		s.mu.Unlock()
+		return nil
+	}
+	cursor := &channelCursor{replaying: true}
+	s.channels[channel] = cursor
+	s.mu.Unlock()
+
+	// 허브에 먼저 등록해야 아래 조회와 실시간 전달 사이에 빠지는 이벤트가 없습니다
+	if err := s.app.hub.add(ctx, channel, s); err != nil {
+		s.drop(channel)
+		return err
+	}
+
+	var events []Event
+	position, resync := resumeFrom, false
+	if resume {
+		if _, _, ok := parseEventID(resumeFrom); !ok {
+			resync = true // 클라이언트가 마지막 이벤트 ID를 모름
+		} else {
+			replayed, complete, err := s.app.log.since(ctx, channel, resumeFrom, s.app.config.MaxReplayEvents)
+			if err != nil {
+				s.drop(channel)
+				return err
+			}
+			events, resync = replayed, !complete
+		}
+	}
+	if !resume || resync {
+		tail, err := s.app.log.tail(ctx, channel)
+		if err != nil {
+			s.drop(channel)
+			return err
+		}
+		position = tail
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor.position = position
+	switch {
+	case resync:
+		s.send(ServerMessage{Type: MessageResync, Channel: channel, EventID: position, Message: "missed events are no longer available"})
+	case !resume:
+		s.send(ServerMessage{Type: MessageSubscribed, Channel: channel, EventID: position})
+	}
+	pending := cursor.pending
+	cursor.pending, cursor.replaying = nil, false
+	for _, event := range events {
+		s.deliverLocked(cursor, event, true)
+	}
+	for _, event := range pending {
+		s.deliverLocked(cursor, event, false)
+	}
+	return nil
+}
+
+// unsubscribe 채널 구독을 해제합니다
+func (s *session) unsubscribe(channel string) {
+	s.drop(channel)
+	s.send(ServerMessage{Type: MessageUnsubscribed, Channel: channel})
+}
+
+func (s *session) drop(channel string) {
+	s.app.hub.remove(channel, s)
+	s.mu.Lock()
+	delete(s.channels, channel)
+	s.mu.Unlock()
+}
+
+// subscribedChannels 구독 중인 채널 목록 (정렬됨)
+func (s *session) subscribedChannels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channels := make([]string, 0, len(s.channels))
+	for channel := range s.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// deliver 허브가 읽은 이벤트를 전달합니다
+func (s *session) deliver(event Event, replayed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cursor, exists := s.channels[event.Channel]; exists {
+		s.deliverLocked(cursor, event, replayed)
+	}
+}
+
+// deliverLocked 이미 전달한 위치 이후의 이벤트만 보냅니다 (s.mu 보유 상태)
+func (s *session) deliverLocked(cursor *channelCursor, event Event, replayed bool) {
+	if cursor.replaying {
+		cursor.pending = append(cursor.pending, event)
+		return
+	}
+	if compareEventIDs(event.ID, cursor.position) <= 0 {
+		return
+	}
+	cursor.position = event.ID
+	s.send(eventMessage(event, replayed))
+}
+
+// send 송신 버퍼에 메시지를 넣습니다
+// 버퍼가 가득 찬 느린 클라이언트는 연결을 끊고, 재접속 시 놓친 이벤트를 재생받게 합니다
+func (s *session) send(message ServerMessage) {
+	select {
+	case <-s.done:
+	case s.outbound <- message:
+	default:
+		log.Printf("[Realtime] Session %s send buffer full, closing connection", s.id)
+		s.close()
+	}
+}
+
+func (s *session) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// record 저장할 세션 정보
+func (s *session) record() sessionRecord {
+	return sessionRecord{ID: s.id, UserID: s.userID, Channels: s.subscribedChannels()}
+}
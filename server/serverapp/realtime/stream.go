@@ -0,0 +1,144 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// streamStartID 비어 있는 스트림의 위치 (모든 이벤트보다 앞)
+const streamStartID = "0-0"
+
+// Event 채널 스트림에 기록된 이벤트
+type Event struct {
+	ID        string          `json:"id"` // Redis Streams 엔트리 ID ("<ms>-<seq>")
+	Channel   string          `json:"channel"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// channelLog 채널별 이벤트를 Redis Streams에 보관합니다
+// 스트림 길이는 maxLen 근처로 잘리며, 재생 윈도우의 두 배 동안 발행이 없으면 만료됩니다
+type channelLog struct {
+	client       redis.UniversalClient
+	keyPrefix    string
+	maxLen       int64
+	replayWindow time.Duration
+}
+
+func (l *channelLog) key(channel string) string {
+	return l.keyPrefix + ":stream:" + channel
+}
+
+// append 이벤트를 기록하고 엔트리 ID를 반환합니다
+func (l *channelLog) append(ctx context.Context, channel, eventType string, data []byte) (string, error) {
+	pipe := l.client.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: l.key(channel),
+		MaxLen: l.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": data},
+	})
+	pipe.PExpire(ctx, l.key(channel), 2*l.replayWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to append event to channel %s: %w", channel, err)
+	}
+	return add.Val(), nil
+}
+
+// tail 채널의 마지막 이벤트 ID를 반환합니다 (비어 있으면 streamStartID)
+func (l *channelLog) tail(ctx context.Context, channel string) (string, error) {
+	messages, err := l.client.XRevRangeN(ctx, l.key(channel), "+", "-", 1).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("failed to read tail of channel %s: %w", channel, err)
+	}
+	if len(messages) == 0 {
+		return streamStartID, nil
+	}
+	return messages[0].ID, nil
+}
+
+// since afterID 이후의 이벤트를 최대 limit개 반환합니다
+// afterID 이후 이벤트가 이미 잘려나갔으면 complete=false를 반환합니다
+func (l *channelLog) since(ctx context.Context, channel, afterID string, limit int) (events []Event, complete bool, err error) {
+	oldest, err := l.client.XRangeN(ctx, l.key(channel), "-", "+", 1).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, fmt.Errorf("failed to read head of channel %s: %w", channel, err)
+	}
+	if len(oldest) > 0 && compareEventIDs(oldest[0].ID, afterID) > 0 && afterID != streamStartID {
+		return nil, false, nil // 길이 제한으로 잘려나감
+	}
+
+	messages, err := l.client.XRangeN(ctx, l.key(channel), "("+afterID, "+", int64(limit)+1).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, fmt.Errorf("failed to read channel %s: %w", channel, err)
+	}
+	if len(messages) > limit {
+		return nil, false, nil // 재생할 이벤트가 너무 많음
+	}
+
+	events = make([]Event, 0, len(messages))
+	for _, message := range messages {
+		events = append(events, toEvent(channel, message))
+	}
+	return events, true, nil
+}
+
+func toEvent(channel string, message redis.XMessage) Event {
+	event := Event{ID: message.ID, Channel: channel, Timestamp: eventIDTime(message.ID)}
+	if eventType, ok := message.Values["type"].(string); ok {
+		event.Type = eventType
+	}
+	if data, ok := message.Values["data"].(string); ok && data != "" {
+		event.Data = json.RawMessage(data)
+	}
+	return event
+}
+
+// parseEventID "<ms>-<seq>" 형식의 스트림 ID를 해석합니다
+func parseEventID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+// compareEventIDs 두 스트림 ID를 비교합니다 (-1, 0, 1)
+func compareEventIDs(a, b string) int {
+	aMs, aSeq, _ := parseEventID(a)
+	bMs, bSeq, _ := parseEventID(b)
+	switch {
+	case aMs != bMs:
+		if aMs < bMs {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func eventIDTime(id string) time.Time {
+	ms, _, _ := parseEventID(id)
+	return time.UnixMilli(int64(ms))
+}
@@ -0,0 +1,232 @@
+package sanction
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// SanctionAggregateType 사용자 제재 애그리게이트 타입 (애그리게이트 ID는 사용자 ID)
+const SanctionAggregateType = "Sanction"
+
+// 제재 종류
+const (
+	KindBan        = "ban"        // 영구 정지 (해제 전까지)
+	KindSuspension = "suspension" // 기간 정지
+)
+
+// 제재 이벤트 타입
+const (
+	UserBannedEventType      = "UserBanned"
+	UserSuspendedEventType   = "UserSuspended"
+	SanctionLiftedEventType  = "SanctionLifted"
+	SanctionExpiredEventType = "SanctionExpired"
+)
+
+var (
+	ErrInvalidSanction = errors.New("invalid sanction")
+	ErrNotSanctioned   = errors.New("user is not sanctioned")
+	ErrStoreRequired   = eventstore.ErrStoreRequired
+)
+
+// Sanction 적용 중인 제재
+type Sanction struct {
+	Kind      string    `json:"kind"`
+	Reason    string    `json:"reason"`
+	IssuedBy  string    `json:"issued_by"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // 영구 정지는 비어 있음
+}
+
+// Permanent 만료 시각이 없는 제재인지 확인합니다
+func (s Sanction) Permanent() bool {
+	return s.ExpiresAt.IsZero()
+}
+
+// Expired now 기준으로 만료되었는지 확인합니다
+func (s Sanction) Expired(now time.Time) bool {
+	return !s.Permanent() && !now.Before(s.ExpiresAt)
+}
+
+// Remaining now 기준 남은 기간 (영구 정지는 0)
+func (s Sanction) Remaining(now time.Time) time.Duration {
+	if s.Permanent() || s.Expired(now) {
+		return 0
+	}
+	return s.ExpiresAt.Sub(now)
+}
+
+// 제재 이벤트 데이터
+type (
+	UserBannedData struct {
+		Sanction Sanction `json:"sanction"`
+	}
+	UserSuspendedData struct {
+		Sanction Sanction `json:"sanction"`
+	}
+	SanctionLiftedData struct {
+		Kind     string    `json:"kind"`
+		LiftedBy string    `json:"lifted_by"`
+		Reason   string    `json:"reason"`
+		LiftedAt time.Time `json:"lifted_at"`
+	}
+	SanctionExpiredData struct {
+		Kind      string    `json:"kind"`
+		ExpiredAt time.Time `json:"expired_at"`
+	}
+)
+
+// SanctionEvent 제재 애그리게이트 이벤트
+type SanctionEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *SanctionEvent) EventData() interface{} {
+	return e.data
+}
+
+// SanctionAggregate 사용자 한 명의 제재 상태
+// 새 제재는 기존 제재를 대체합니다 (정지 중 영구 정지, 기간 연장 등)
+type SanctionAggregate struct {
+	*cqrs.BaseAggregate
+	active  *Sanction
+	history int // 지금까지 받은 제재 수
+}
+
+// NewSanctionAggregate 새로운 SanctionAggregate를 생성합니다
+func NewSanctionAggregate(userID string) *SanctionAggregate {
+	return &SanctionAggregate{BaseAggregate: cqrs.NewBaseAggregate(userID, SanctionAggregateType)}
+}
+
+// Active now 기준으로 적용 중인 제재 (만료 이벤트가 아직 없어도 만료된 제재는 제외)
+func (a *SanctionAggregate) Active(now time.Time) (Sanction, bool) {
+	if a.active == nil || a.active.Expired(now) {
+		return Sanction{}, false
+	}
+	return *a.active, true
+}
+
+// Pending 만료 이벤트가 아직 기록되지 않은 제재 (만료 스케줄링용)
+func (a *SanctionAggregate) Pending() (Sanction, bool) {
+	if a.active == nil {
+		return Sanction{}, false
+	}
+	return *a.active, true
+}
+
+// SanctionCount 지금까지 받은 제재 수
+func (a *SanctionAggregate) SanctionCount() int {
+	return a.history
+}
+
+// Ban 사용자를 영구 정지합니다
+func (a *SanctionAggregate) Ban(reason, issuedBy string, now time.Time) error {
+	if reason == "" || issuedBy == "" {
+		return fmt.Errorf("%w: reason and issuer are required", ErrInvalidSanction)
+	}
+	return a.raise(UserBannedEventType, UserBannedData{Sanction: Sanction{Kind: KindBan, Reason: reason, IssuedBy: issuedBy, IssuedAt: now}})
+}
+
+// Suspend 사용자를 duration 동안 정지합니다
+func (a *SanctionAggregate) Suspend(reason, issuedBy string, duration time.Duration, now time.Time) error {
+	if reason == "" || issuedBy == "" {
+		return fmt.Errorf("%w: reason and issuer are required", ErrInvalidSanction)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("%w: suspension duration must be positive", ErrInvalidSanction)
+	}
+	if active, exists := a.Active(now); exists && active.Permanent() {
+		return fmt.Errorf("%w: user is banned; lift the ban before suspending", ErrInvalidSanction)
+	}
+	return a.raise(UserSuspendedEventType, UserSuspendedData{Sanction: Sanction{
+		Kind:      KindSuspension,
+		Reason:    reason,
+		IssuedBy:  issuedBy,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(duration),
+	}})
+}
+
+// Lift 적용 중인 제재를 해제합니다
+func (a *SanctionAggregate) Lift(reason, liftedBy string, now time.Time) error {
+	if liftedBy == "" {
+		return fmt.Errorf("%w: issuer is required", ErrInvalidSanction)
+	}
+	active, exists := a.Active(now)
+	if !exists {
+		return ErrNotSanctioned
+	}
+	return a.raise(SanctionLiftedEventType, SanctionLiftedData{Kind: active.Kind, LiftedBy: liftedBy, Reason: reason, LiftedAt: now})
+}
+
+// Expire 만료된 제재의 만료를 기록합니다 (만료할 제재가 없으면 아무것도 하지 않음)
+func (a *SanctionAggregate) Expire(now time.Time) (bool, error) {
+	if a.active == nil || !a.active.Expired(now) {
+		return false, nil
+	}
+	return true, a.raise(SanctionExpiredEventType, SanctionExpiredData{Kind: a.active.Kind, ExpiredAt: a.active.ExpiresAt})
+}
+
+// LoadFromHistory 이벤트 스트림에서 제재 상태를 복원합니다
+func (a *SanctionAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *SanctionAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := sanctionEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown sanction event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *SanctionAggregate) raise(eventType string, data interface{}) error {
+	event := &SanctionEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *SanctionAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case UserBannedData:
+		sanction := data.Sanction
+		a.active = &sanction
+		a.history++
+	case UserSuspendedData:
+		sanction := data.Sanction
+		a.active = &sanction
+		a.history++
+	case SanctionLiftedData, SanctionExpiredData:
+		a.active = nil
+	}
+}
+
+// sanctionEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var sanctionEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	UserBannedEventType:      eventstore.DecodeEventData[UserBannedData],
+	UserSuspendedEventType:   eventstore.DecodeEventData[UserSuspendedData],
+	SanctionLiftedEventType:  eventstore.DecodeEventData[SanctionLiftedData],
+	SanctionExpiredEventType: eventstore.DecodeEventData[SanctionExpiredData],
+}
@@ -0,0 +1,266 @@
+package sanction
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 제재 관리 기본 경로
+const DefaultBasePath = "/sanctions"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 16 << 10
+
+// Config 제재 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /sanctions)
+	Service  ServiceConfig                   // 제재 서비스 설정
+	Auth     func(http.Handler) http.Handler // 선택: 관리자 인증 미들웨어
+	Identify func(r *http.Request) string    // 필수: 제재를 내리는 관리자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to record who issued a sanction")
+	}
+	return nil
+}
+
+// SanctionApp 사용자 정지, 기간 정지, 해제를 관리하는 서버앱
+// 다른 서버앱은 Service().Middleware 또는 NewEnforcingDispatcher로 제재를 적용합니다
+type SanctionApp struct {
+	*serverapp.BaseApp
+	config        Config
+	service       *Service
+	subscriptions []cqrs.SubscriptionID
+}
+
+// NewSanctionApp 새로운 SanctionApp을 생성합니다
+func NewSanctionApp(config Config) (*SanctionApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	service, err := NewService(config.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &SanctionApp{
+		BaseApp: serverapp.NewBaseApp("sanction"),
+		config:  config,
+		service: service,
+	}, nil
+}
+
+// Service 제재 서비스
+func (a *SanctionApp) Service() *Service {
+	return a.service
+}
+
+// Start 다른 인스턴스의 제재 이벤트 구독과 만료 스케줄러를 시작합니다
+func (a *SanctionApp) Start(ctx context.Context) error {
+	if bus := a.config.Service.EventBus; bus != nil {
+		subscriptions, err := a.service.Subscribe(bus)
+		a.subscriptions = subscriptions
+		if err != nil {
+			a.unsubscribe()
+			return err
+		}
+	}
+	a.service.Start(context.Background())
+	return a.BaseApp.Start(ctx)
+}
+
+// Stop 만료 스케줄러를 멈추고 구독을 해제합니다
+func (a *SanctionApp) Stop(ctx context.Context) error {
+	if err := a.service.Stop(ctx); err != nil {
+		log.Printf("[Sanction] Failed to stop expiry scheduler: %v", err)
+	}
+	a.unsubscribe()
+	return a.BaseApp.Stop(ctx)
+}
+
+func (a *SanctionApp) unsubscribe() {
+	for _, subscription := range a.subscriptions {
+		if err := a.config.Service.EventBus.Unsubscribe(subscription); err != nil {
+			log.Printf("[Sanction] Failed to unsubscribe: %v", err)
+		}
+	}
+	a.subscriptions = nil
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *SanctionApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base, protect(http.HandlerFunc(a.status)))
+	mux.Handle(base+"/ban", protect(http.HandlerFunc(a.ban)))
+	mux.Handle(base+"/suspend", protect(http.HandlerFunc(a.suspend)))
+	mux.Handle(base+"/lift", protect(http.HandlerFunc(a.lift)))
+
+	log.Printf("[Sanction] Routes registered under %s", base)
+}
+
+// DescribeAPI 제재 엔드포인트 설명 (/openapi.json)
+func (a *SanctionApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	userID := serverapp.APIParameter{Name: "user_id", In: "query", Required: true}
+	return []serverapp.APIOperation{
+		{Method: http.MethodGet, Path: base, Summary: "사용자 제재 상태 조회", Parameters: []serverapp.APIParameter{userID}, Response: SanctionStatus{}, Secured: secured},
+		{Method: http.MethodPost, Path: base + "/ban", Summary: "사용자 영구 정지", Parameters: []serverapp.APIParameter{userID}, Request: BanUserData{}, Response: SanctionStatus{}, Secured: secured},
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/suspend",
+			Summary:     "사용자 기간 정지",
+			Description: "기존 기간 정지는 새 기간으로 대체됩니다. 영구 정지 중인 사용자는 먼저 해제해야 합니다.",
+			Parameters:  []serverapp.APIParameter{userID},
+			Request:     SuspendUserData{},
+			Response:    SanctionStatus{},
+			Secured:     secured,
+		},
+		{Method: http.MethodPost, Path: base + "/lift", Summary: "제재 해제", Parameters: []serverapp.APIParameter{userID}, Request: LiftSanctionData{}, Response: SanctionStatus{}, Secured: secured},
+	}
+}
+
+// SanctionStatus 사용자 제재 상태
+type SanctionStatus struct {
+	UserID           string    `json:"user_id"`
+	Sanctioned       bool      `json:"sanctioned"`
+	Sanction         *Sanction `json:"sanction,omitempty"`
+	RemainingSeconds int64     `json:"remaining_seconds,omitempty"`
+}
+
+func (a *SanctionApp) sanctionStatus(ctx context.Context, userID string) (SanctionStatus, error) {
+	sanction, sanctioned, err := a.service.Check(ctx, userID)
+	if err != nil || !sanctioned {
+		return SanctionStatus{UserID: userID}, err
+	}
+	return SanctionStatus{
+		UserID:           userID,
+		Sanctioned:       true,
+		Sanction:         &sanction,
+		RemainingSeconds: remainingSeconds(sanction, a.service.config.Now()),
+	}, nil
+}
+
+func (a *SanctionApp) status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		sendError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+	status, err := a.sanctionStatus(r.Context(), userID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, status)
+}
+
+func (a *SanctionApp) ban(w http.ResponseWriter, r *http.Request) {
+	var request BanUserData
+	if !a.decode(w, r, &request) {
+		return
+	}
+	a.execute(w, r, NewBanUserCommand(r.URL.Query().Get("user_id"), a.config.Identify(r), request.Reason))
+}
+
+func (a *SanctionApp) suspend(w http.ResponseWriter, r *http.Request) {
+	var request SuspendUserData
+	if !a.decode(w, r, &request) {
+		return
+	}
+	duration := time.Duration(request.DurationSeconds) * time.Second
+	a.execute(w, r, NewSuspendUserCommand(r.URL.Query().Get("user_id"), a.config.Identify(r), request.Reason, duration))
+}
+
+func (a *SanctionApp) lift(w http.ResponseWriter, r *http.Request) {
+	var request LiftSanctionData
+	if !a.decode(w, r, &request) {
+		return
+	}
+	a.execute(w, r, NewLiftSanctionCommand(r.URL.Query().Get("user_id"), a.config.Identify(r), request.Reason))
+}
+
+func (a *SanctionApp) decode(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	if r.URL.Query().Get("user_id") == "" {
+		sendError(w, http.StatusBadRequest, "user_id is required")
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(request); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid JSON body")
+		return false
+	}
+	return true
+}
+
+func (a *SanctionApp) execute(w http.ResponseWriter, r *http.Request, command cqrs.Command) {
+	result, err := a.service.Handle(r.Context(), command)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !result.Success {
+		sendError(w, statusForError(result.Error), result.Error.Error())
+		return
+	}
+	status, err := a.sanctionStatus(r.Context(), command.ID())
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, status)
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidSanction):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrNotSanctioned):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
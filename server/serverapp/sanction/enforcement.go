@@ -0,0 +1,142 @@
+package sanction
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrSanctioned 제재 중인 사용자의 요청 (errors.Is로 확인)
+var ErrSanctioned = errors.New("user is sanctioned")
+
+// SanctionedError 제재 중인 사용자의 요청을 거부한 이유와 남은 기간
+type SanctionedError struct {
+	UserID           string    `json:"user_id"`
+	Kind             string    `json:"kind"`
+	Reason           string    `json:"reason"`
+	ExpiresAt        time.Time `json:"expires_at,omitempty"`        // 영구 정지는 비어 있음
+	RemainingSeconds int64     `json:"remaining_seconds,omitempty"` // 영구 정지는 0
+}
+
+func newSanctionedError(userID string, sanction Sanction, now time.Time) *SanctionedError {
+	return &SanctionedError{
+		UserID:           userID,
+		Kind:             sanction.Kind,
+		Reason:           sanction.Reason,
+		ExpiresAt:        sanction.ExpiresAt,
+		RemainingSeconds: remainingSeconds(sanction, now),
+	}
+}
+
+// remainingSeconds 남은 기간을 초 단위로 올림합니다 (1초 미만이 남아도 0(영구)으로 보이지 않도록)
+func remainingSeconds(sanction Sanction, now time.Time) int64 {
+	remaining := sanction.Remaining(now)
+	seconds := int64(remaining / time.Second)
+	if remaining%time.Second > 0 {
+		seconds++
+	}
+	return seconds
+}
+
+func (e *SanctionedError) Error() string {
+	if e.Kind == KindBan {
+		return fmt.Sprintf("user %s is banned: %s", e.UserID, e.Reason)
+	}
+	return fmt.Sprintf("user %s is suspended for %ds: %s", e.UserID, e.RemainingSeconds, e.Reason)
+}
+
+func (e *SanctionedError) Is(target error) bool {
+	return target == ErrSanctioned
+}
+
+// Enforce 사용자가 제재 중이면 *SanctionedError를 반환합니다
+func (s *Service) Enforce(ctx context.Context, userID string) error {
+	if userID == "" {
+		return nil
+	}
+	sanction, sanctioned, err := s.Check(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !sanctioned {
+		return nil
+	}
+	return newSanctionedError(userID, sanction, s.config.Now())
+}
+
+// EnforcingDispatcher 제재 중인 사용자가 보낸 명령을 거부하는 디스패처
+// 명령의 UserID를 명령을 보낸 사용자로 보며, 거부는 CommandResult.Error에 *SanctionedError로 담깁니다
+type EnforcingDispatcher struct {
+	cqrs.CommandDispatcher
+	service *Service
+	exempt  map[string]bool
+}
+
+// NewEnforcingDispatcher dispatcher를 감쌉니다 (exempt 명령 타입은 확인하지 않음)
+// 제재 명령은 관리자가 보내므로 항상 제외됩니다
+func NewEnforcingDispatcher(dispatcher cqrs.CommandDispatcher, service *Service, exempt ...string) *EnforcingDispatcher {
+	exempted := make(map[string]bool)
+	for _, commandType := range exempt {
+		exempted[commandType] = true
+	}
+	for _, commandType := range service.GetSupportedCommandTypes() {
+		exempted[commandType] = true
+	}
+	return &EnforcingDispatcher{CommandDispatcher: dispatcher, service: service, exempt: exempted}
+}
+
+func (d *EnforcingDispatcher) Dispatch(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	if command != nil && !d.exempt[command.CommandType()] {
+		if err := d.service.Enforce(ctx, command.UserID()); err != nil {
+			if errors.Is(err, ErrSanctioned) {
+				return cqrs.NewFailedCommandResult(err), nil
+			}
+			return nil, err
+		}
+	}
+	return d.CommandDispatcher.Dispatch(ctx, command)
+}
+
+// sanctionMessage 요청 로케일로 제재 안내 문구를 만듭니다 (카탈로그에 없으면 영어 에러 문구)
+func (s *Service) sanctionMessage(ctx context.Context, sanctioned *SanctionedError) string {
+	key := "sanction.suspended"
+	if sanctioned.Kind == KindBan {
+		key = "sanction.banned"
+	}
+	return s.config.Messages.LocalizeOr(ctx, key, sanctioned.Error(), map[string]interface{}{
+		"reason":    sanctioned.Reason,
+		"remaining": (time.Duration(sanctioned.RemainingSeconds) * time.Second).String(),
+	})
+}
+
+// Middleware 제재 중인 사용자의 요청을 403으로 거부하는 HTTP 미들웨어
+// identify가 빈 문자열을 반환하면 (미인증 요청) 그대로 통과시킵니다
+// 기간 정지는 Retry-After 헤더로 남은 시간을 알려 줍니다
+func (s *Service) Middleware(identify func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := s.Enforce(r.Context(), identify(r))
+			var sanctioned *SanctionedError
+			switch {
+			case err == nil:
+				next.ServeHTTP(w, r)
+			case errors.As(err, &sanctioned):
+				if sanctioned.RemainingSeconds > 0 {
+					w.Header().Set("Retry-After", strconv.FormatInt(sanctioned.RemainingSeconds, 10))
+				}
+				sendJSON(w, http.StatusForbidden, map[string]interface{}{
+					"error":    s.sanctionMessage(r.Context(), sanctioned),
+					"status":   http.StatusForbidden,
+					"success":  false,
+					"sanction": sanctioned,
+				})
+			default:
+				sendError(w, http.StatusInternalServerError, err.Error())
+			}
+		})
+	}
+}
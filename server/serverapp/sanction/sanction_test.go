@@ -0,0 +1,302 @@
+package sanction
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/i18n"
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(t *testing.T, config ServiceConfig) *Service {
+	t.Helper()
+	if config.Store == nil {
+		config.Store = eventstore.NewInMemoryEventStore()
+	}
+	service, err := NewService(config)
+	require.NoError(t, err)
+	return service
+}
+
+func TestSanctionAggregate_Rules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sanction := NewSanctionAggregate("alice")
+
+	assert.ErrorIs(t, sanction.Suspend("spam", "mod", 0, now), ErrInvalidSanction)
+	assert.ErrorIs(t, sanction.Ban("", "mod", now), ErrInvalidSanction)
+	assert.ErrorIs(t, sanction.Lift("", "mod", now), ErrNotSanctioned)
+
+	require.NoError(t, sanction.Suspend("spam", "mod", time.Hour, now))
+	active, exists := sanction.Active(now.Add(30 * time.Minute))
+	require.True(t, exists)
+	assert.Equal(t, 30*time.Minute, active.Remaining(now.Add(30*time.Minute)))
+	_, exists = sanction.Active(now.Add(time.Hour))
+	assert.False(t, exists)
+
+	require.NoError(t, sanction.Ban("cheating", "mod", now))
+	assert.ErrorIs(t, sanction.Suspend("spam", "mod", time.Hour, now), ErrInvalidSanction)
+	expired, err := sanction.Expire(now.Add(24 * time.Hour))
+	require.NoError(t, err)
+	assert.False(t, expired) // 영구 정지는 만료되지 않음
+	assert.Equal(t, 2, sanction.SanctionCount())
+
+	restored := NewSanctionAggregate("alice")
+	require.NoError(t, restored.LoadFromHistory(sanction.Changes()))
+	restoredActive, _ := restored.Active(now)
+	banned, _ := sanction.Active(now)
+	assert.Equal(t, banned, restoredActive)
+	assert.Equal(t, sanction.Version(), restored.OriginalVersion())
+}
+
+func TestService_SuspensionExpiresOnSchedule(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(testkit.DefaultStart)
+	bus := testkit.StartedEventBus(t)
+	service := newService(t, ServiceConfig{Now: clock.Now, EventBus: bus})
+	ctx := context.Background()
+
+	// Act
+	result := testkit.Handle(t, service, NewSuspendUserCommand("alice", "mod", "spam", 2*time.Hour))
+	require.True(t, result.Success, result.Error)
+	clock.Advance(90 * time.Minute)
+	remaining := service.Enforce(ctx, "alice")
+	clock.Advance(time.Hour)
+	afterExpiry := service.Enforce(ctx, "alice")
+	expired, err := service.ExpireDue(ctx)
+	require.NoError(t, err)
+	again, err := service.ExpireDue(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	var sanctioned *SanctionedError
+	require.ErrorAs(t, remaining, &sanctioned)
+	assert.Equal(t, KindSuspension, sanctioned.Kind)
+	assert.Equal(t, int64(30*60), sanctioned.RemainingSeconds)
+	assert.NoError(t, afterExpiry) // 만료 이벤트 기록 전에도 만료된 정지는 적용하지 않음
+	assert.Equal(t, 1, expired)
+	assert.Zero(t, again)
+
+	history, err := service.config.Store.GetEventHistory(ctx, "alice", SanctionAggregateType, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, SanctionExpiredEventType, history[1].EventType())
+	assert.Equal(t, int64(2), bus.GetMetrics().PublishedEvents)
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 제재가 사라짐
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestService_EnforcesSanctionsFromOtherInstances(t *testing.T) {
+	// Arrange - 같은 저장소와 버스를 쓰는 두 인스턴스, 두 인스턴스 모두 "제재 없음"을 캐시한 상태
+	clock := testkit.NewClock(testkit.DefaultStart)
+	store := eventstore.NewInMemoryEventStore()
+	bus := testkit.StartedEventBus(t)
+	ctx := context.Background()
+	moderator := newService(t, ServiceConfig{Store: store, EventBus: bus, Now: clock.Now})
+	subscribed := newService(t, ServiceConfig{Store: store, Now: clock.Now})
+	_, err := subscribed.Subscribe(bus)
+	require.NoError(t, err)
+	unsubscribed := newService(t, ServiceConfig{Store: store, CacheTTL: time.Minute, Now: clock.Now})
+	for _, service := range []*Service{subscribed, unsubscribed} {
+		require.NoError(t, service.Enforce(ctx, "mallory"))
+	}
+
+	// Act
+	banned := testkit.Handle(t, moderator, NewBanUserCommand("mallory", "mod", "cheating"))
+	require.True(t, banned.Success)
+	require.Eventually(t, func() bool { return subscribed.Enforce(ctx, "mallory") != nil }, time.Second, 5*time.Millisecond)
+	beforeTTL := unsubscribed.Enforce(ctx, "mallory")
+	clock.Advance(time.Minute)
+	afterTTL := unsubscribed.Enforce(ctx, "mallory")
+
+	// Assert
+	assert.NoError(t, beforeTTL) // 이벤트를 받지 못한 인스턴스는 CacheTTL까지 캐시 사용
+	var sanctioned *SanctionedError
+	require.ErrorAs(t, afterTTL, &sanctioned)
+	assert.Equal(t, KindBan, sanctioned.Kind)
+}
+
+func TestService_ExpireDuePrunesStaleCacheEntries(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(testkit.DefaultStart)
+	service := newService(t, ServiceConfig{CacheTTL: time.Minute, Now: clock.Now})
+	ctx := context.Background()
+	for _, userID := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, service.Enforce(ctx, userID))
+	}
+
+	// Act
+	clock.Advance(time.Minute)
+	_, err := service.ExpireDue(ctx)
+
+	// Assert - 확인한 사용자마다 쌓인 "제재 없음" 항목이 정리됨
+	require.NoError(t, err)
+	assert.Empty(t, service.cache)
+}
+
+func TestService_BanAndLift(t *testing.T) {
+	// Arrange
+	service := newService(t, ServiceConfig{})
+	ctx := context.Background()
+
+	// Act
+	banned := testkit.Handle(t, service, NewBanUserCommand("bob", "mod", "cheating"))
+	enforced := service.Enforce(ctx, "bob")
+	lifted := testkit.Handle(t, service, NewLiftSanctionCommand("bob", "mod", "appeal accepted"))
+	liftAgain := testkit.Handle(t, service, NewLiftSanctionCommand("bob", "mod", "again"))
+
+	// Assert
+	require.True(t, banned.Success)
+	assert.Equal(t, []string{UserBannedEventType}, banned.EventTypes())
+	var sanctioned *SanctionedError
+	require.ErrorAs(t, enforced, &sanctioned)
+	assert.Equal(t, KindBan, sanctioned.Kind)
+	assert.Zero(t, sanctioned.RemainingSeconds)
+	require.True(t, lifted.Success)
+	assert.NoError(t, service.Enforce(ctx, "bob"))
+	assert.False(t, liftAgain.Success)
+	assert.ErrorIs(t, liftAgain.Error, ErrNotSanctioned)
+}
+
+func TestEnforcingDispatcher_RejectsSanctionedIssuer(t *testing.T) {
+	// Arrange
+	service := newService(t, ServiceConfig{})
+	inner := cqrs.NewInMemoryCommandDispatcher()
+	require.NoError(t, service.RegisterWith(inner))
+	handled := 0
+	handler := cqrs.NewBaseCommandHandler("chat", []string{"SendMessage"})
+	require.NoError(t, inner.RegisterHandler("SendMessage", &countingHandler{BaseCommandHandler: handler, count: &handled}))
+	dispatcher := NewEnforcingDispatcher(inner, service)
+	ctx := context.Background()
+
+	sendMessage := func(userID string) *cqrs.CommandResult {
+		command := cqrs.NewBaseCommand("SendMessage", "room-1", "Chat", nil)
+		command.SetUserID(userID)
+		result, err := dispatcher.Dispatch(ctx, command)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Act
+	ban, err := dispatcher.Dispatch(ctx, NewBanUserCommand("eve", "mod", "abuse"))
+	require.NoError(t, err)
+	rejected := sendMessage("eve")
+	accepted := sendMessage("alice")
+
+	// Assert
+	assert.True(t, ban.Success)
+	assert.False(t, rejected.Success)
+	assert.ErrorIs(t, rejected.Error, ErrSanctioned)
+	assert.True(t, accepted.Success)
+	assert.Equal(t, 1, handled)
+}
+
+type countingHandler struct {
+	*cqrs.BaseCommandHandler
+	count *int
+}
+
+func (h *countingHandler) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	*h.count++
+	return cqrs.NewCommandResult(command.ID(), 1), nil
+}
+
+func TestSanctionApp_RoutesAndMiddleware(t *testing.T) {
+	// Arrange
+	clock := testkit.NewClock(testkit.DefaultStart)
+	app, err := NewSanctionApp(Config{
+		Service:  ServiceConfig{Store: eventstore.NewInMemoryEventStore(), Now: clock.Now},
+		Identify: func(r *http.Request) string { return r.Header.Get("X-User") },
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	mux.Handle("/game", app.Service().Middleware(func(r *http.Request) string { return r.Header.Get("X-User") })(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	))
+
+	request := func(method, target, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-User", user)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act & Assert
+	suspended := request(http.MethodPost, "/sanctions/suspend?user_id=alice", "mod", `{"reason": "spam", "duration_seconds": 600}`)
+	require.Equal(t, http.StatusOK, suspended.Code, suspended.Body.String())
+	var status SanctionStatus
+	require.NoError(t, json.Unmarshal(suspended.Body.Bytes(), &status))
+	assert.True(t, status.Sanctioned)
+	assert.Equal(t, "mod", status.Sanction.IssuedBy)
+	assert.Equal(t, int64(600), status.RemainingSeconds)
+
+	clock.Advance(4 * time.Minute)
+	blocked := request(http.MethodGet, "/game", "alice", "")
+	assert.Equal(t, http.StatusForbidden, blocked.Code)
+	assert.Equal(t, "360", blocked.Header().Get("Retry-After"))
+	var body struct {
+		Success  bool            `json:"success"`
+		Sanction SanctionedError `json:"sanction"`
+	}
+	require.NoError(t, json.Unmarshal(blocked.Body.Bytes(), &body))
+	assert.False(t, body.Success)
+	assert.Equal(t, int64(360), body.Sanction.RemainingSeconds)
+	assert.Equal(t, "spam", body.Sanction.Reason)
+
+	assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/game", "bob", "").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, request(http.MethodPost, "/sanctions/suspend?user_id=bob", "mod", `{"reason": "spam"}`).Code)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/sanctions/lift?user_id=bob", "mod", `{}`).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodPost, "/sanctions/lift?user_id=alice", "mod", `{"reason": "appeal"}`).Code)
+	assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/game", "alice", "").Code)
+}
+
+func TestService_MiddlewareLocalizesMessage(t *testing.T) {
+	// Arrange
+	catalog, err := i18n.NewCatalog(i18n.CatalogConfig{})
+	require.NoError(t, err)
+	catalog.Set("ko", map[string]string{"sanction.banned": "계정이 영구 정지되었습니다: {reason}"})
+	service := newService(t, ServiceConfig{Messages: catalog})
+	ctx := context.Background()
+	_, err = service.Handle(ctx, NewBanUserCommand("alice", "mod", "cheating"))
+	require.NoError(t, err)
+	handler := i18n.Middleware(catalog, nil, nil)(service.Middleware(func(r *http.Request) string { return "alice" })(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	))
+	request := func(acceptLanguage string) string {
+		req := httptest.NewRequest(http.MethodGet, "/game", nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		var body struct {
+			Error string `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		return body.Error
+	}
+
+	// Act
+	korean := request("ko-KR")
+	english := request("en")
+
+	// Assert
+	assert.Equal(t, "계정이 영구 정지되었습니다: cheating", korean)
+	assert.Equal(t, "user alice is banned: cheating", english) // 카탈로그에 없으면 기존 문구
+}
@@ -0,0 +1,370 @@
+package sanction
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"defense-allies-server/serverapp/i18n"
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 제재 명령 타입
+const (
+	BanUserCommandType      = "BanUser"
+	SuspendUserCommandType  = "SuspendUser"
+	LiftSanctionCommandType = "LiftSanction"
+)
+
+// 제재 명령 데이터 (명령의 UserID는 제재를 내리는 관리자)
+type (
+	BanUserData struct {
+		Reason string `json:"reason"`
+	}
+	SuspendUserData struct {
+		Reason          string `json:"reason"`
+		DurationSeconds int64  `json:"duration_seconds"`
+	}
+	LiftSanctionData struct {
+		Reason string `json:"reason"`
+	}
+)
+
+// NewBanUserCommand 사용자 영구 정지 명령
+func NewBanUserCommand(userID, issuedBy, reason string) cqrs.Command {
+	return newSanctionCommand(BanUserCommandType, userID, issuedBy, BanUserData{Reason: reason})
+}
+
+// NewSuspendUserCommand 사용자 기간 정지 명령
+func NewSuspendUserCommand(userID, issuedBy, reason string, duration time.Duration) cqrs.Command {
+	return newSanctionCommand(SuspendUserCommandType, userID, issuedBy, SuspendUserData{Reason: reason, DurationSeconds: int64(duration / time.Second)})
+}
+
+// NewLiftSanctionCommand 제재 해제 명령
+func NewLiftSanctionCommand(userID, issuedBy, reason string) cqrs.Command {
+	return newSanctionCommand(LiftSanctionCommandType, userID, issuedBy, LiftSanctionData{Reason: reason})
+}
+
+func newSanctionCommand(commandType, userID, issuedBy string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, userID, SanctionAggregateType, data)
+	command.SetUserID(issuedBy)
+	return command
+}
+
+// DefaultCacheTTL 캐시한 제재 상태를 저장소에서 다시 읽기 전까지의 기본 시간
+const DefaultCacheTTL = 30 * time.Second
+
+// ServiceConfig 제재 서비스 설정
+type ServiceConfig struct {
+	Store          eventstore.EventStore // 필수: 제재 이벤트 저장소
+	EventBus       cqrs.EventBus         // 선택: 제재 이벤트 발행, 다른 인스턴스의 제재 변경 구독
+	ExpiryInterval time.Duration         // 만료된 정지를 기록하고 오래된 캐시를 정리하는 주기 (기본값: 1m)
+	CacheTTL       time.Duration         // 캐시한 제재 상태(제재 없음 포함)를 저장소에서 다시 읽는 주기 (기본값: 30초)
+	Now            func() time.Time      // 테스트용 시계 (기본값: time.Now)
+	Messages       *i18n.Catalog         // 선택: 제재 안내 메시지 카탈로그 (sanction.banned, sanction.suspended)
+}
+
+// Service 제재 명령을 처리하고 사용자 제재 여부를 확인합니다
+// 사용자별 제재 상태는 CacheTTL 동안 메모리에 캐시하므로 확인은 저장소를 매번 읽지 않습니다
+// 다른 인스턴스에서 내린 제재는 Subscribe로 받은 이벤트로 즉시, 이벤트를 받지 못해도 CacheTTL 안에 적용됩니다
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu     sync.Mutex // 제재 변경 직렬화
+	cacheM sync.RWMutex
+	cache  map[string]cachedSanction // 사용자 ID -> 제재 상태
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// cachedSanction 캐시한 사용자 제재 상태
+type cachedSanction struct {
+	sanction *Sanction // 만료가 기록되지 않은 제재 (nil이면 제재 없음)
+	version  int       // 읽은 애그리게이트 버전
+	loadedAt time.Time
+}
+
+// NewService 새로운 Service를 생성합니다
+// 제재가 재시작 후에도 유지되어야 하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("sanction: %w", ErrStoreRequired)
+	}
+	if config.ExpiryInterval <= 0 {
+		config.ExpiryInterval = time.Minute
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultCacheTTL
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("sanction", []string{BanUserCommandType, SuspendUserCommandType, LiftSanctionCommandType}),
+		config:             config,
+		cache:              make(map[string]cachedSanction),
+		stop:               make(chan struct{}),
+		done:               make(chan struct{}),
+	}, nil
+}
+
+// RegisterWith 제재 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle 제재 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	userID, issuedBy := command.ID(), command.UserID()
+	if userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: user ID is required", ErrInvalidSanction)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	switch command.CommandType() {
+	case BanUserCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[BanUserData](command.GetData(), ErrInvalidSanction)
+		if err = decodeErr; err == nil {
+			err = aggregate.Ban(data.Reason, issuedBy, now)
+		}
+	case SuspendUserCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[SuspendUserData](command.GetData(), ErrInvalidSanction)
+		if err = decodeErr; err == nil {
+			err = aggregate.Suspend(data.Reason, issuedBy, time.Duration(data.DurationSeconds)*time.Second, now)
+		}
+	case LiftSanctionCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[LiftSanctionData](command.GetData(), ErrInvalidSanction)
+		if err = decodeErr; err == nil {
+			err = aggregate.Lift(data.Reason, issuedBy, now)
+		}
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidSanction, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	log.Printf("[Sanction] %s applied to %s by %s", command.CommandType(), userID, issuedBy)
+	result := cqrs.NewCommandResult(userID, aggregate.Version(), events...)
+	if active, exists := aggregate.Active(now); exists {
+		result.WithData(active)
+	}
+	return result, nil
+}
+
+// Check 사용자에게 적용 중인 제재를 확인합니다
+// 캐시가 없거나 CacheTTL이 지났으면 저장소에서 다시 읽습니다 (제재 변경 잠금 밖에서 읽음)
+func (s *Service) Check(ctx context.Context, userID string) (Sanction, bool, error) {
+	now := s.config.Now()
+	s.cacheM.RLock()
+	cached, known := s.cache[userID]
+	s.cacheM.RUnlock()
+
+	if !known || now.Sub(cached.loadedAt) >= s.config.CacheTTL {
+		aggregate, err := s.load(ctx, userID)
+		if err != nil {
+			return Sanction{}, false, err
+		}
+		cached = s.remember(aggregate)
+	}
+	if cached.sanction == nil || cached.sanction.Expired(now) {
+		return Sanction{}, false, nil
+	}
+	return *cached.sanction, true, nil
+}
+
+// ExpireDue 만료된 정지의 만료 이벤트를 기록하고 기록한 수를 반환합니다
+// 캐시에 있는 사용자만 확인하며, 그 밖의 사용자는 Check가 만료된 제재를 무시합니다
+// CacheTTL이 지난 캐시 항목은 함께 정리해 확인한 사용자 수만큼 캐시가 계속 커지지 않습니다
+func (s *Service) ExpireDue(ctx context.Context) (int, error) {
+	now := s.config.Now()
+	s.cacheM.Lock()
+	var due []string
+	for userID, cached := range s.cache {
+		switch {
+		case cached.sanction != nil && cached.sanction.Expired(now):
+			due = append(due, userID)
+		case now.Sub(cached.loadedAt) >= s.config.CacheTTL:
+			delete(s.cache, userID)
+		}
+	}
+	s.cacheM.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired := 0
+	for _, userID := range due {
+		aggregate, err := s.load(ctx, userID)
+		if err != nil {
+			return expired, err
+		}
+		changed, err := aggregate.Expire(now)
+		if err != nil {
+			return expired, err
+		}
+		if !changed {
+			s.remember(aggregate)
+			continue
+		}
+		if err := s.save(ctx, aggregate); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// Subscribe 다른 인스턴스에서 바뀐 제재를 캐시에서 지우도록 이벤트 버스를 구독합니다
+// 인스턴스 사이에 이벤트를 전달하는 버스여야 다른 인스턴스의 제재가 즉시 적용됩니다
+func (s *Service) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	invalidator := &cacheInvalidator{
+		BaseEventHandler: cqrs.NewBaseEventHandler("sanction-cache", cqrs.NotificationHandler, []string{
+			UserBannedEventType,
+			UserSuspendedEventType,
+			SanctionLiftedEventType,
+			SanctionExpiredEventType,
+		}),
+		service: s,
+	}
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(invalidator.GetSupportedEventTypes()))
+	for _, eventType := range invalidator.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, invalidator)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// cacheInvalidator 제재 이벤트로 캐시를 지우는 이벤트 핸들러
+type cacheInvalidator struct {
+	*cqrs.BaseEventHandler
+	service *Service
+}
+
+func (h *cacheInvalidator) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	h.service.forget(event.AggregateID(), event.Version())
+	return nil
+}
+
+// Start 만료 스케줄러를 시작합니다
+func (s *Service) Start(ctx context.Context) {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.config.ExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if count, err := s.ExpireDue(ctx); err != nil {
+					log.Printf("[Sanction] Failed to expire sanctions: %v", err)
+				} else if count > 0 {
+					log.Printf("[Sanction] %d sanctions expired", count)
+				}
+			}
+		}
+	}()
+}
+
+// Stop 만료 스케줄러를 멈추고 끝날 때까지 기다립니다
+func (s *Service) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	if !s.started.Load() {
+		return nil
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Service) load(ctx context.Context, userID string) (*SanctionAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, userID, SanctionAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sanctions for %s: %w", userID, err)
+	}
+	aggregate := NewSanctionAggregate(userID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 캐시를 갱신한 뒤 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *SanctionAggregate) error {
+	events := aggregate.Changes()
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save sanctions for %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+	s.remember(aggregate)
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Sanction] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// remember 제재 상태를 캐시하고 캐시된 상태를 반환합니다
+// 동시에 읽은 오래된 상태가 이미 캐시된 새 상태를 덮지 않도록 버전이 낮으면 캐시된 상태를 유지합니다
+func (s *Service) remember(aggregate *SanctionAggregate) cachedSanction {
+	cached := cachedSanction{version: aggregate.Version(), loadedAt: s.config.Now()}
+	if pending, exists := aggregate.Pending(); exists {
+		cached.sanction = &pending
+	}
+	s.cacheM.Lock()
+	defer s.cacheM.Unlock()
+	if existing, known := s.cache[aggregate.ID()]; known && existing.version > cached.version {
+		return existing
+	}
+	s.cache[aggregate.ID()] = cached
+	return cached
+}
+
+// forget 캐시된 제재 상태가 version보다 오래되었으면 캐시에서 지웁니다
+func (s *Service) forget(userID string, version int) {
+	s.cacheM.Lock()
+	defer s.cacheM.Unlock()
+	if cached, known := s.cache[userID]; known && cached.version < version {
+		delete(s.cache, userID)
+	}
+}
@@ -0,0 +1,285 @@
+package season
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/i18n"
+)
+
+// RewardMailSubjectKey 시즌 보상 우편 제목의 메시지 키 ({season}, {leaderboard}, {rank})
+const RewardMailSubjectKey = "season.reward_mail_subject"
+
+// ErrSeasonClosing 다른 호출이 시즌을 닫는 중일 때 반환하는 에러
+var ErrSeasonClosing = errors.New("season is already closing")
+
+// Config 시즌 관리자 설정
+type Config struct {
+	Duration            time.Duration           // 다음 시즌부터의 시즌 길이
+	Leaderboards        []string                // 시즌 종료 시 보관할 리더보드
+	Rewards             map[string][]RewardTier // 리더보드 ID -> 보상 구간
+	SeasonalProjections []string                // 새 시즌을 위해 다시 빌드할 프로젝션
+	Messages            *i18n.Catalog           // 선택: 보상 우편 제목 카탈로그 (RewardMailSubjectKey, 없으면 영어 제목)
+	Locales             i18n.LocaleResolver     // 선택: 받는 사람의 선호 로케일 (없으면 카탈로그 기본 로케일)
+}
+
+// closure 닫는 중인 시즌에서 이미 마친 단계
+// 실패한 종료를 재시도하면 부수 효과를 되풀이하지 않고 멈춘 곳부터 이어갑니다
+type closure struct {
+	season    Season
+	running   bool // 어떤 호출이 이 종료를 진행 중인지 (진행 중에는 그 호출만 아래 필드를 바꿈)
+	published bool
+	standings map[string][]Standing
+	rewarded  map[string]bool
+	rebuilt   map[string]bool
+}
+
+// Manager 일정에 따라 시즌을 닫습니다
+// SeasonEnded를 발행하고, 리더보드를 보관하고, 시즌 보상을 우편으로 보내고, 시즌 프로젝션을 다시 빌드한 뒤 다음 시즌을 시작합니다
+// 뮤텍스는 시즌 상태만 보호하며, 이벤트 발행, 보관, 우편, 프로젝션 빌드는 잠금 밖에서 실행합니다
+type Manager struct {
+	mutex       sync.Mutex
+	config      Config
+	current     Season
+	closing     *closure
+	eventBus    cqrs.EventBus
+	projections cqrs.ProjectionManager
+	archive     LeaderboardArchive
+	mailbox     Mailbox
+	now         func() time.Time
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewManager current 시즌부터 시작하는 Manager를 생성합니다
+func NewManager(config Config, current Season, eventBus cqrs.EventBus, projections cqrs.ProjectionManager, archive LeaderboardArchive, mailbox Mailbox) (*Manager, error) {
+	if config.Duration <= 0 {
+		return nil, errors.New("season duration must be positive")
+	}
+	if current.ID == "" || !current.EndsAt.After(current.StartsAt) {
+		return nil, errors.New("current season needs an ID and must end after it starts")
+	}
+	if eventBus == nil || projections == nil || archive == nil || mailbox == nil {
+		return nil, errors.New("event bus, projection manager, leaderboard archive and mailbox are required")
+	}
+
+	return &Manager{
+		config:      config,
+		current:     current,
+		eventBus:    eventBus,
+		projections: projections,
+		archive:     archive,
+		mailbox:     mailbox,
+		now:         time.Now,
+	}, nil
+}
+
+// CurrentSeason 진행 중인 시즌 (닫는 중이면 닫히는 시즌)
+func (m *Manager) CurrentSeason() Season {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.current
+}
+
+// CheckSchedule 현재 시즌이 끝났으면 닫고, 닫았는지 반환합니다
+// 다른 호출이 닫는 중이면 기다리지 않고 false를 반환합니다
+func (m *Manager) CheckSchedule(ctx context.Context) (bool, error) {
+	m.mutex.Lock()
+	if m.now().Before(m.current.EndsAt) || (m.closing != nil && m.closing.running) {
+		m.mutex.Unlock()
+		return false, nil
+	}
+	closing := m.beginLocked()
+	m.mutex.Unlock()
+
+	if err := m.close(ctx, closing); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EndSeason 일정과 관계없이 현재 시즌을 즉시 닫고 닫은 시즌을 반환합니다
+// 다른 호출이 닫는 중이면 ErrSeasonClosing을 반환합니다
+func (m *Manager) EndSeason(ctx context.Context) (Season, error) {
+	m.mutex.Lock()
+	if m.closing != nil && m.closing.running {
+		m.mutex.Unlock()
+		return Season{}, ErrSeasonClosing
+	}
+	closing := m.beginLocked()
+	m.mutex.Unlock()
+
+	if err := m.close(ctx, closing); err != nil {
+		return Season{}, err
+	}
+	return closing.season, nil
+}
+
+// Start interval마다 일정을 확인합니다 (Stop을 호출할 때까지)
+func (m *Manager) Start(ctx context.Context, interval time.Duration) {
+	m.mutex.Lock()
+	if m.stopCh != nil {
+		m.mutex.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	m.stopCh = stopCh
+	m.mutex.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				// 실패한 종료는 진행 상황을 유지하고 다음 틱에 재시도
+				if _, err := m.CheckSchedule(ctx); err != nil {
+					log.Printf("[Season] Failed to close season: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop Start로 시작한 확인 루프를 멈춥니다
+func (m *Manager) Stop() {
+	m.mutex.Lock()
+	stopCh := m.stopCh
+	m.stopCh = nil
+	m.mutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		m.wg.Wait()
+	}
+}
+
+// beginLocked 현재 시즌의 종료를 진행 중으로 표시합니다 (이전에 실패한 종료가 있으면 이어감)
+func (m *Manager) beginLocked() *closure {
+	if m.closing == nil || m.closing.season.ID != m.current.ID {
+		m.closing = &closure{
+			season:    m.current,
+			standings: make(map[string][]Standing),
+			rewarded:  make(map[string]bool),
+			rebuilt:   make(map[string]bool),
+		}
+	}
+	m.closing.running = true
+	return m.closing
+}
+
+// close 잠금 밖에서 남은 종료 단계를 실행하고, 모두 마치면 다음 시즌을 시작합니다
+func (m *Manager) close(ctx context.Context, closing *closure) error {
+	err := m.runSteps(ctx, closing)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	closing.running = false
+	if err != nil {
+		return err
+	}
+	season := closing.season
+	m.current = Season{
+		ID:       fmt.Sprintf("season-%d", season.Number+1),
+		Number:   season.Number + 1,
+		StartsAt: season.EndsAt,
+		EndsAt:   season.EndsAt.Add(m.config.Duration),
+	}
+	m.closing = nil
+	return nil
+}
+
+func (m *Manager) runSteps(ctx context.Context, closing *closure) error {
+	season := closing.season
+	if !closing.published {
+		if err := m.eventBus.Publish(ctx, NewSeasonEndedEvent(season)); err != nil {
+			return fmt.Errorf("failed to publish end of season %s: %w", season.ID, err)
+		}
+		closing.published = true
+	}
+
+	for _, leaderboardID := range m.config.Leaderboards {
+		if _, archived := closing.standings[leaderboardID]; archived {
+			continue
+		}
+		standings, err := m.archive.ArchiveLeaderboard(ctx, season, leaderboardID)
+		if err != nil {
+			return fmt.Errorf("failed to archive leaderboard %s: %w", leaderboardID, err)
+		}
+		closing.standings[leaderboardID] = standings
+	}
+
+	for _, leaderboardID := range m.config.Leaderboards {
+		if closing.rewarded[leaderboardID] {
+			continue
+		}
+		if err := m.sendRewards(ctx, season, leaderboardID, closing.standings[leaderboardID]); err != nil {
+			return err
+		}
+		closing.rewarded[leaderboardID] = true
+	}
+
+	for _, projectionName := range m.config.SeasonalProjections {
+		if closing.rebuilt[projectionName] {
+			continue
+		}
+		if err := m.projections.RebuildProjection(ctx, projectionName); err != nil {
+			return fmt.Errorf("failed to rebuild projection %s: %w", projectionName, err)
+		}
+		closing.rebuilt[projectionName] = true
+	}
+	return nil
+}
+
+func (m *Manager) sendRewards(ctx context.Context, season Season, leaderboardID string, standings []Standing) error {
+	tiers := m.config.Rewards[leaderboardID]
+	for _, standing := range standings {
+		tier, ok := rewardTierFor(tiers, standing.Rank)
+		if !ok {
+			continue
+		}
+		mail := Mail{
+			ID:          fmt.Sprintf("%s:%s:%s", season.ID, leaderboardID, standing.PlayerID),
+			RecipientID: standing.PlayerID,
+			Subject:     m.rewardSubject(ctx, season, leaderboardID, standing),
+			Items:       tier.Items,
+			SentAt:      m.now(),
+		}
+		if err := m.mailbox.Send(ctx, mail); err != nil {
+			return fmt.Errorf("failed to mail season reward to %s: %w", standing.PlayerID, err)
+		}
+	}
+	return nil
+}
+
+// rewardSubject 받는 사람의 로케일로 보상 우편 제목을 만듭니다 (카탈로그에 없으면 영어 제목)
+func (m *Manager) rewardSubject(ctx context.Context, season Season, leaderboardID string, standing Standing) string {
+	fallback := fmt.Sprintf("Season %d reward: %s rank %d", season.Number, leaderboardID, standing.Rank)
+	if m.config.Messages == nil {
+		return fallback
+	}
+	if m.config.Locales != nil {
+		locale, err := m.config.Locales.ResolveLocale(ctx, standing.PlayerID)
+		if err != nil {
+			// 로케일을 찾지 못해도 보상은 보내고 기본 로케일을 사용
+			log.Printf("[Season] Failed to resolve locale of %s: %v", standing.PlayerID, err)
+		}
+		ctx = i18n.WithLocale(ctx, locale)
+	}
+	return m.config.Messages.LocalizeOr(ctx, RewardMailSubjectKey, fallback, map[string]interface{}{
+		"season":      season.Number,
+		"leaderboard": leaderboardID,
+		"rank":        standing.Rank,
+	})
+}
@@ -0,0 +1,84 @@
+package season
+
+import (
+	"context"
+	"cqrs"
+	"time"
+)
+
+// SeasonEndedEventType 시즌을 닫을 때 발행하는 이벤트 타입
+const SeasonEndedEventType = "SeasonEnded"
+
+// SeasonAggregateType 시즌 이벤트의 애그리게이트 타입 (애그리게이트 ID는 시즌 ID)
+const SeasonAggregateType = "Season"
+
+// Season 경쟁 기간 하나 (리더보드와 시즌 읽기 모델은 시즌마다 초기화)
+type Season struct {
+	ID       string    `json:"id"`
+	Number   int       `json:"number"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// SeasonEndedEvent 시즌 종료 이벤트
+type SeasonEndedEvent struct {
+	*cqrs.BaseEventMessage
+	Season Season `json:"season"`
+}
+
+// NewSeasonEndedEvent 새로운 SeasonEndedEvent를 생성합니다
+func NewSeasonEndedEvent(season Season) *SeasonEndedEvent {
+	event := &SeasonEndedEvent{BaseEventMessage: cqrs.NewBaseEventMessage(SeasonEndedEventType), Season: season}
+	event.AggregateID_ = season.ID
+	event.AggregateType_ = SeasonAggregateType
+	event.Version_ = season.Number
+	return event
+}
+
+func (e *SeasonEndedEvent) EventData() interface{} {
+	return e.Season
+}
+
+// Standing 리더보드의 최종 순위 한 줄
+type Standing struct {
+	PlayerID string `json:"player_id"`
+	Rank     int    `json:"rank"`
+	Score    int64  `json:"score"`
+}
+
+// LeaderboardArchive 시즌 리더보드의 최종 순위를 보관합니다
+// 시즌 종료를 재시도하면 다시 호출되므로 ArchiveLeaderboard는 멱등이어야 합니다
+type LeaderboardArchive interface {
+	ArchiveLeaderboard(ctx context.Context, season Season, leaderboardID string) ([]Standing, error)
+}
+
+// Mail 아이템이 첨부된 우편
+type Mail struct {
+	ID          string           `json:"id"` // 재시도해도 같은 값이므로 우편함이 중복을 버릴 수 있음
+	RecipientID string           `json:"recipient_id"`
+	Subject     string           `json:"subject"`
+	Items       map[string]int64 `json:"items"`
+	SentAt      time.Time        `json:"sent_at"`
+}
+
+// Mailbox 플레이어에게 우편을 보냅니다
+type Mailbox interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+// RewardTier MinRank..MaxRank(포함) 순위에 Items를 지급하는 보상 구간
+type RewardTier struct {
+	MinRank int              `json:"min_rank"`
+	MaxRank int              `json:"max_rank"`
+	Items   map[string]int64 `json:"items"`
+}
+
+// rewardTierFor rank가 속한 보상 구간
+func rewardTierFor(tiers []RewardTier, rank int) (RewardTier, bool) {
+	for _, tier := range tiers {
+		if rank >= tier.MinRank && rank <= tier.MaxRank {
+			return tier, true
+		}
+	}
+	return RewardTier{}, false
+}
@@ -0,0 +1,190 @@
+package season
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaderboardArchive 고정 순위를 돌려주고 아카이브 호출을 기록
+type fakeLeaderboardArchive struct {
+	standings map[string][]Standing
+	archived  []string
+}
+
+func (a *fakeLeaderboardArchive) ArchiveLeaderboard(ctx context.Context, season Season, leaderboardID string) ([]Standing, error) {
+	a.archived = append(a.archived, season.ID+"/"+leaderboardID)
+	return a.standings[leaderboardID], nil
+}
+
+// fakeMailbox 보낸 우편을 기록하고 failures 횟수만큼 실패 (onSend는 보낼 때마다 호출)
+type fakeMailbox struct {
+	sent     []Mail
+	failures int
+	onSend   func()
+}
+
+func (m *fakeMailbox) Send(ctx context.Context, mail Mail) error {
+	if m.onSend != nil {
+		m.onSend()
+	}
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("mail server unavailable")
+	}
+	m.sent = append(m.sent, mail)
+	return nil
+}
+
+// rebuildCountingProjection Rebuild 호출 횟수를 세는 프로젝션
+type rebuildCountingProjection struct {
+	*cqrs.BaseProjection
+	rebuilds int
+}
+
+func (p *rebuildCountingProjection) Rebuild(ctx context.Context) error {
+	p.rebuilds++
+	return p.BaseProjection.Rebuild(ctx)
+}
+
+func newFixture(t *testing.T, mailbox *fakeMailbox, configure func(config *Config)) (*Manager, *cqrs.InMemoryEventBus, *fakeLeaderboardArchive, *rebuildCountingProjection) {
+	t.Helper()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bus := cqrs.NewInMemoryEventBus()
+	projections := cqrs.NewInMemoryProjectionManager()
+	ranking := &rebuildCountingProjection{BaseProjection: cqrs.NewBaseProjection("SeasonRanking", "1.0.0", []string{"MatchFinished"})}
+	require.NoError(t, projections.RegisterProjection(ranking))
+	archive := &fakeLeaderboardArchive{standings: map[string][]Standing{
+		"pvp": {{PlayerID: "p1", Rank: 1, Score: 900}, {PlayerID: "p2", Rank: 2, Score: 800}, {PlayerID: "p3", Rank: 50, Score: 10}},
+	}}
+	config := Config{
+		Duration:            28 * 24 * time.Hour,
+		Leaderboards:        []string{"pvp"},
+		Rewards:             map[string][]RewardTier{"pvp": {{MinRank: 1, MaxRank: 1, Items: map[string]int64{"crystal": 500}}, {MinRank: 2, MaxRank: 10, Items: map[string]int64{"crystal": 100}}}},
+		SeasonalProjections: []string{"SeasonRanking"},
+	}
+	if configure != nil {
+		configure(&config)
+	}
+	season := Season{ID: "season-1", Number: 1, StartsAt: start, EndsAt: start.Add(config.Duration)}
+	manager, err := NewManager(config, season, bus, projections, archive, mailbox)
+	require.NoError(t, err)
+	return manager, bus, archive, ranking
+}
+
+func TestManager_CheckSchedule_ClosesEndedSeason(t *testing.T) {
+	// Arrange
+	mailbox := &fakeMailbox{}
+	manager, bus, archive, ranking := newFixture(t, mailbox, nil)
+	ended := manager.CurrentSeason()
+	manager.now = func() time.Time { return ended.EndsAt.Add(time.Minute) }
+
+	// Act
+	closed, err := manager.CheckSchedule(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, closed)
+	assert.Equal(t, int64(1), bus.GetMetrics().PublishedEvents)
+	assert.Equal(t, []string{"season-1/pvp"}, archive.archived)
+	require.Len(t, mailbox.sent, 2)
+	assert.Equal(t, "season-1:pvp:p1", mailbox.sent[0].ID)
+	assert.Equal(t, "Season 1 reward: pvp rank 1", mailbox.sent[0].Subject)
+	assert.Equal(t, int64(500), mailbox.sent[0].Items["crystal"])
+	assert.Equal(t, int64(100), mailbox.sent[1].Items["crystal"])
+	assert.Equal(t, 1, ranking.rebuilds)
+	next := manager.CurrentSeason()
+	assert.Equal(t, "season-2", next.ID)
+	assert.Equal(t, ended.EndsAt, next.StartsAt)
+}
+
+func TestManager_CheckSchedule_WaitsForSeasonEnd(t *testing.T) {
+	// Arrange
+	manager, bus, _, _ := newFixture(t, &fakeMailbox{}, nil)
+	current := manager.CurrentSeason()
+	manager.now = func() time.Time { return current.EndsAt.Add(-time.Hour) }
+
+	// Act
+	closed, err := manager.CheckSchedule(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, closed)
+	assert.Equal(t, int64(0), bus.GetMetrics().PublishedEvents)
+	assert.Equal(t, "season-1", manager.CurrentSeason().ID)
+}
+
+func TestManager_EndSeason_ResumesAfterFailure(t *testing.T) {
+	// Arrange
+	mailbox := &fakeMailbox{failures: 1}
+	manager, bus, archive, ranking := newFixture(t, mailbox, nil)
+
+	// Act
+	_, firstErr := manager.EndSeason(context.Background())
+	ended, secondErr := manager.EndSeason(context.Background())
+
+	// Assert
+	require.Error(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "season-1", ended.ID)
+	assert.Equal(t, int64(1), bus.GetMetrics().PublishedEvents, "SeasonEnded는 두 번 발행하지 않음")
+	assert.Len(t, archive.archived, 1, "리더보드는 한 번만 보관")
+	assert.Len(t, mailbox.sent, 2)
+	assert.Equal(t, 1, ranking.rebuilds)
+	assert.Equal(t, "season-2", manager.CurrentSeason().ID)
+}
+
+func TestManager_EndSeason_RunsStepsOutsideTheLock(t *testing.T) {
+	// Arrange
+	mailbox := &fakeMailbox{}
+	manager, _, _, _ := newFixture(t, mailbox, nil)
+	var observed []string
+	var concurrentErr error
+	mailbox.onSend = func() {
+		// 우편을 보내는 동안에도 시즌 조회와 중복 종료 요청이 막히지 않아야 함
+		observed = append(observed, manager.CurrentSeason().ID)
+		_, concurrentErr = manager.EndSeason(context.Background())
+	}
+
+	// Act
+	ended, err := manager.EndSeason(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "season-1", ended.ID)
+	assert.Equal(t, []string{"season-1", "season-1"}, observed)
+	assert.ErrorIs(t, concurrentErr, ErrSeasonClosing)
+	assert.Equal(t, "season-2", manager.CurrentSeason().ID)
+}
+
+func TestManager_LocalizesRewardMailPerRecipient(t *testing.T) {
+	// Arrange
+	catalog, err := i18n.NewCatalog(i18n.CatalogConfig{})
+	require.NoError(t, err)
+	catalog.Set("en", map[string]string{RewardMailSubjectKey: "Season {season} reward: {leaderboard} #{rank}"})
+	catalog.Set("ko", map[string]string{RewardMailSubjectKey: "시즌 {season} 보상: {leaderboard} {rank}위"})
+	locales := map[string]string{"p1": "ko-KR"}
+	mailbox := &fakeMailbox{}
+	manager, _, _, _ := newFixture(t, mailbox, func(config *Config) {
+		config.Messages = catalog
+		config.Locales = i18n.LocaleResolverFunc(func(ctx context.Context, userID string) (string, error) {
+			return locales[userID], nil
+		})
+	})
+
+	// Act
+	_, err = manager.EndSeason(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, mailbox.sent, 2)
+	assert.Equal(t, "시즌 1 보상: pvp 1위", mailbox.sent[0].Subject)
+	assert.Equal(t, "Season 1 reward: pvp #2", mailbox.sent[1].Subject)
+}
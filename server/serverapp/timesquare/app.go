@@ -2,6 +2,7 @@ package timesquare
 
 import (
 	"context"
+	"cqrs/cqrsx"
 	"log"
 	"net/http"
 	"time"
@@ -31,6 +32,7 @@ type TimeSquareApp struct {
 	authService    *auth.Service
 	authMiddleware *middleware.AuthMiddleware
 	gameHandler    *handlers.GameHandler
+	idempotency    func(http.Handler) http.Handler
 }
 
 // NewTimeSquareApp 새로운 TimeSquareApp을 생성합니다 (설정 파일 경로로 생성)
@@ -115,9 +117,32 @@ func NewTimeSquareApp(configPath string) (*TimeSquareApp, error) {
 	// 게임 핸들러 초기화
 	app.gameHandler = handlers.NewGameHandler(app.userService)
 
+	// 명령 라우트용 Idempotency-Key 미들웨어 (재시도 시 첫 응답을 24시간 동안 재생)
+	dedupStore, err := cqrsx.NewRedisCommandDeduplicationStore(redisClient, "timesquare:idempotency")
+	if err != nil {
+		return nil, err
+	}
+	app.idempotency, err = cqrsx.NewIdempotencyMiddleware(cqrsx.IdempotencyConfig{
+		Store: dedupStore,
+		TTL:   24 * time.Hour,
+		Scope: idempotencyScope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return app, nil
 }
 
+// idempotencyScope 인증된 사용자별로 Idempotency-Key 네임스페이스를 분리합니다
+func idempotencyScope(r *http.Request) string {
+	if userID, ok := middleware.GetUserIDFromContext(r.Context()); ok {
+		return userID
+	}
+	gameAccountID, _ := middleware.GetGameAccountIDFromContext(r.Context())
+	return gameAccountID
+}
+
 // RegisterRoutes HTTP Mux에 라우트를 등록합니다
 func (t *TimeSquareApp) RegisterRoutes(mux *http.ServeMux) {
 	// 헬스체크 엔드포인트 (인증 불필요)
@@ -137,7 +162,7 @@ func (t *TimeSquareApp) RegisterRoutes(mux *http.ServeMux) {
 
 	// 게임 데이터 업데이트
 	mux.Handle("/api/v1/game/update", t.authMiddleware.Authenticate(
-		http.HandlerFunc(t.gameHandler.UpdateGameData),
+		t.idempotency(http.HandlerFunc(t.gameHandler.UpdateGameData)),
 	))
 
 	// 리더보드
@@ -147,17 +172,94 @@ func (t *TimeSquareApp) RegisterRoutes(mux *http.ServeMux) {
 
 	// 게임 세션 시작
 	mux.Handle("/api/v1/game/session/start", t.authMiddleware.Authenticate(
-		http.HandlerFunc(t.gameHandler.StartSession),
+		t.idempotency(http.HandlerFunc(t.gameHandler.StartSession)),
 	))
 
 	// 게임 세션 종료
 	mux.Handle("/api/v1/game/session/end", t.authMiddleware.Authenticate(
-		http.HandlerFunc(t.gameHandler.EndSession),
+		t.idempotency(http.HandlerFunc(t.gameHandler.EndSession)),
 	))
 
 	log.Printf("[TimeSquare] Routes registered - Game APIs and Auth APIs ready")
 }
 
+// GameResponse 게임 API 성공 응답 형식 (OpenAPI 문서용)
+type GameResponse[T any] struct {
+	Success bool `json:"success"`
+	Data    T    `json:"data"`
+}
+
+// GameMessage 데이터 없이 메시지만 반환하는 게임 API 응답 형식 (OpenAPI 문서용)
+type GameMessage struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// EndSessionParams 게임 세션 종료 요청 본문 (OpenAPI 문서용)
+type EndSessionParams struct {
+	SessionID string `json:"session_id"`
+	Score     *int64 `json:"score,omitempty"`
+	Level     *int   `json:"level,omitempty"`
+}
+
+// idempotencyKeyParameter 명령 라우트가 받는 재시도용 헤더
+var idempotencyKeyParameter = serverapp.APIParameter{
+	Name:        cqrsx.IdempotencyKeyHeader,
+	In:          "header",
+	Description: "재시도 시 같은 값을 보내면 첫 응답을 재생합니다 (24시간)",
+}
+
+// DescribeAPI 게임 API 라우트 설명 (/openapi.json)
+func (t *TimeSquareApp) DescribeAPI() []serverapp.APIOperation {
+	return []serverapp.APIOperation{
+		{Method: http.MethodGet, Path: "/health", Summary: "TimeSquare 헬스체크"},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/game/profile",
+			Summary:  "프로필 조회 (없으면 기본 게임 데이터로 생성)",
+			Response: GameResponse[middleware.User]{},
+			Secured:  true,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/api/v1/game/update",
+			Summary:    "게임 데이터 업데이트",
+			Request:    handlers.UpdateGameDataParams{},
+			Response:   GameMessage{},
+			Parameters: []serverapp.APIParameter{idempotencyKeyParameter},
+			Secured:    true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/game/leaderboard",
+			Summary: "최근 30일 활성 유저 리더보드",
+			Parameters: []serverapp.APIParameter{
+				{Name: "limit", In: "query", Type: "integer", Description: "최대 항목 수 (1-1000, 기본값 100)"},
+			},
+			Response: GameResponse[[]handlers.LeaderboardEntry]{},
+			Secured:  true,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/api/v1/game/session/start",
+			Summary:    "게임 세션 시작",
+			Request:    handlers.JoinGameParams{},
+			Response:   GameResponse[handlers.GameSession]{},
+			Parameters: []serverapp.APIParameter{idempotencyKeyParameter},
+			Secured:    true,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/api/v1/game/session/end",
+			Summary:    "게임 세션 종료 및 결과 반영",
+			Request:    EndSessionParams{},
+			Response:   GameMessage{},
+			Parameters: []serverapp.APIParameter{idempotencyKeyParameter},
+			Secured:    true,
+		},
+	}
+}
+
 // onStart 시작 시 호출되는 훅
 func (t *TimeSquareApp) onStart(ctx context.Context) error {
 	// Redis 연결 테스트
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"cqrs/validation"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -88,6 +89,10 @@ func (gh *GameHandler) GetGameData(ctx context.Context) (*middleware.GameData, e
 
 // JoinGame 게임 참가
 func (gh *GameHandler) JoinGame(ctx context.Context, params JoinGameParams) (*GameSession, error) {
+	if err := validation.Struct(params); err != nil {
+		return nil, err
+	}
+
 	// 유저 존재 보장 (없으면 생성)
 	user, err := gh.ensureUser(ctx)
 	if err != nil {
@@ -114,9 +119,9 @@ func (gh *GameHandler) JoinGame(ctx context.Context, params JoinGameParams) (*Ga
 
 // UpdateGameDataParams 게임 데이터 업데이트 파라미터
 type UpdateGameDataParams struct {
-	Level     *int              `json:"level,omitempty"`
-	Score     *int64            `json:"score,omitempty"`
-	Resources map[string]int64  `json:"resources,omitempty"`
+	Level     *int              `json:"level,omitempty" validate:"omitempty,min=1"`
+	Score     *int64            `json:"score,omitempty" validate:"omitempty,min=0"`
+	Resources map[string]int64  `json:"resources,omitempty" validate:"dive,min=0"`
 	Settings  map[string]string `json:"settings,omitempty"`
 }
 
@@ -127,7 +132,7 @@ type LeaderboardParams struct {
 
 // JoinGameParams 게임 참가 파라미터
 type JoinGameParams struct {
-	GameType string `json:"game_type"`
+	GameType string `json:"game_type" validate:"required,max=32"`
 }
 
 // 응답 구조체들
@@ -186,8 +191,8 @@ func (gh *GameHandler) UpdateGameData(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var params UpdateGameDataParams
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	if err := validation.DecodeJSON(r, &params); err != nil {
+		validation.WriteError(w, err)
 		return
 	}
 
@@ -282,8 +287,8 @@ func (gh *GameHandler) StartSession(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var params JoinGameParams
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	if err := validation.DecodeJSON(r, &params); err != nil {
+		validation.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AdminTokenHeader 관리자 토큰 헤더 이름
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminAuthMiddleware 관리자 전용 엔드포인트 보호 미들웨어
+// 고정 관리자 토큰을 X-Admin-Token 헤더 또는 Bearer 토큰으로 검증합니다
+type AdminAuthMiddleware struct {
+	tokens [][]byte
+}
+
+// NewAdminAuthMiddleware 새로운 관리자 인증 미들웨어 생성
+// 토큰이 하나도 없으면 모든 요청을 거부합니다
+func NewAdminAuthMiddleware(tokens ...string) *AdminAuthMiddleware {
+	am := &AdminAuthMiddleware{}
+	for _, token := range tokens {
+		if token != "" {
+			am.tokens = append(am.tokens, []byte(token))
+		}
+	}
+	return am
+}
+
+// Protect 관리자 토큰이 없는 요청을 차단합니다
+func (am *AdminAuthMiddleware) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(AdminTokenHeader)
+		if token == "" {
+			token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+
+		if token == "" {
+			am.sendError(w, http.StatusUnauthorized, "Missing admin token")
+			return
+		}
+		if !am.valid(token) {
+			am.sendError(w, http.StatusForbidden, "Invalid admin token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// valid 상수 시간 비교로 토큰 검증
+func (am *AdminAuthMiddleware) valid(token string) bool {
+	matched := 0
+	for _, expected := range am.tokens {
+		matched |= subtle.ConstantTimeCompare([]byte(token), expected)
+	}
+	return matched == 1
+}
+
+// sendError 에러 응답 전송
+func (am *AdminAuthMiddleware) sendError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
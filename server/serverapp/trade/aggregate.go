@@ -0,0 +1,382 @@
+package trade
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// TradeAggregateType 거래 애그리게이트 타입 (애그리게이트 ID는 거래 ID)
+const TradeAggregateType = "Trade"
+
+// 거래 상태
+const (
+	StatusOpen      = "open"      // 양쪽이 제안을 고치는 중
+	StatusAccepted  = "accepted"  // 양쪽이 제안을 수락, 최종 확인 대기
+	StatusHeld      = "held"      // 사기 방지 보류 중, 보류가 끝나면 정산
+	StatusSettled   = "settled"   // 에스크로가 상대에게 넘어감
+	StatusCancelled = "cancelled" // 에스크로가 원래 주인에게 돌아감
+)
+
+// 거래 이벤트 타입
+// 에스크로와 잔액은 거래 서비스가 사용자별 보유량 스트림에 반영하고, 인벤토리, 재화 프로젝션은 보유량 이벤트를 구독합니다
+const (
+	TradeOpenedEventType       = "TradeOpened"
+	TradeOfferUpdatedEventType = "TradeOfferUpdated"
+	TradeAcceptedEventType     = "TradeAccepted"
+	TradeConfirmedEventType    = "TradeConfirmed"
+	TradeHeldEventType         = "TradeHeld"
+	TradeSettledEventType      = "TradeSettled"
+	TradeCancelledEventType    = "TradeCancelled"
+)
+
+var (
+	ErrInvalidTrade  = errors.New("invalid trade")
+	ErrNotParty      = errors.New("user is not a party to this trade")
+	ErrTradeClosed   = errors.New("trade is already closed")
+	ErrTradeOnHold   = errors.New("trade is on hold for fraud review")
+	ErrWrongStatus   = errors.New("trade is not in the required status")
+	ErrInsufficient  = errors.New("insufficient items or minerals for escrow")
+	ErrTradeNotFound = errors.New("trade not found")
+	ErrStoreRequired = eventstore.ErrStoreRequired
+)
+
+// Offer 한쪽이 내놓는 아이템과 광물 (에스크로 대상)
+type Offer struct {
+	Items    map[string]int64 `json:"items,omitempty"`    // 아이템 ID -> 수량
+	Minerals map[string]int64 `json:"minerals,omitempty"` // 광물 종류 -> 양
+}
+
+// Empty 아무것도 내놓지 않는지 확인합니다
+func (o Offer) Empty() bool {
+	return len(o.Items) == 0 && len(o.Minerals) == 0
+}
+
+// Validate 수량이 모두 양수인지 확인합니다
+func (o Offer) Validate() error {
+	for itemID, quantity := range o.Items {
+		if itemID == "" || quantity <= 0 {
+			return fmt.Errorf("%w: item quantities must be positive", ErrInvalidTrade)
+		}
+	}
+	for mineral, amount := range o.Minerals {
+		if mineral == "" || amount <= 0 {
+			return fmt.Errorf("%w: mineral amounts must be positive", ErrInvalidTrade)
+		}
+	}
+	return nil
+}
+
+// 거래 이벤트 데이터
+type (
+	TradeOpenedData struct {
+		InitiatorID    string    `json:"initiator_id"`
+		CounterpartyID string    `json:"counterparty_id"`
+		OpenedAt       time.Time `json:"opened_at"`
+	}
+	TradeOfferUpdatedData struct {
+		UserID    string    `json:"user_id"`
+		Offer     Offer     `json:"offer"` // 이 사용자의 에스크로 전체 (이전 제안을 대체)
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	TradeAcceptedData struct {
+		UserID     string    `json:"user_id"`
+		AcceptedAt time.Time `json:"accepted_at"`
+	}
+	TradeConfirmedData struct {
+		UserID      string    `json:"user_id"`
+		ConfirmedAt time.Time `json:"confirmed_at"`
+	}
+	TradeHeldData struct {
+		HeldAt    time.Time `json:"held_at"`
+		ReleaseAt time.Time `json:"release_at"`
+	}
+	TradeSettledData struct {
+		InitiatorID       string    `json:"initiator_id"`
+		CounterpartyID    string    `json:"counterparty_id"`
+		InitiatorOffer    Offer     `json:"initiator_offer"`
+		CounterpartyOffer Offer     `json:"counterparty_offer"`
+		SettledAt         time.Time `json:"settled_at"`
+	}
+	TradeCancelledData struct {
+		InitiatorID    string    `json:"initiator_id"`
+		CounterpartyID string    `json:"counterparty_id"`
+		CancelledBy    string    `json:"cancelled_by"`
+		Reason         string    `json:"reason,omitempty"`
+		CancelledAt    time.Time `json:"cancelled_at"`
+	}
+)
+
+// TradeEvent 거래 애그리게이트 이벤트
+type TradeEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *TradeEvent) EventData() interface{} {
+	return e.data
+}
+
+// TradeAggregate 두 사용자 사이의 거래
+// 제안 -> 양쪽 수락 -> 양쪽 최종 확인 -> (보류) -> 정산 순서로 진행하며,
+// 제안이 바뀌면 수락이 초기화되어 상대가 바뀐 제안을 다시 확인해야 합니다
+type TradeAggregate struct {
+	*cqrs.BaseAggregate
+	initiatorID    string
+	counterpartyID string
+	status         string
+	offers         map[string]Offer
+	accepted       map[string]bool
+	confirmed      map[string]bool
+	releaseAt      time.Time
+}
+
+// NewTradeAggregate 새로운 TradeAggregate를 생성합니다
+func NewTradeAggregate(tradeID string) *TradeAggregate {
+	return &TradeAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(tradeID, TradeAggregateType),
+		offers:        make(map[string]Offer),
+		accepted:      make(map[string]bool),
+		confirmed:     make(map[string]bool),
+	}
+}
+
+// Exists 거래가 열렸는지 확인합니다
+func (a *TradeAggregate) Exists() bool {
+	return a.status != ""
+}
+
+// Status 거래 상태
+func (a *TradeAggregate) Status() string {
+	return a.status
+}
+
+// Parties 거래 당사자 (시작한 사용자, 상대)
+func (a *TradeAggregate) Parties() (string, string) {
+	return a.initiatorID, a.counterpartyID
+}
+
+// Offer 사용자가 내놓은 제안
+func (a *TradeAggregate) Offer(userID string) Offer {
+	return a.offers[userID]
+}
+
+// ReleaseAt 보류가 끝나는 시각 (보류 중이 아니면 zero)
+func (a *TradeAggregate) ReleaseAt() time.Time {
+	return a.releaseAt
+}
+
+// OneSided 한쪽만 무언가를 내놓는 거래인지 확인합니다 (선물, 작업장 이전에 자주 쓰이는 형태)
+func (a *TradeAggregate) OneSided() bool {
+	return a.offers[a.initiatorID].Empty() != a.offers[a.counterpartyID].Empty()
+}
+
+// Open 거래를 엽니다
+func (a *TradeAggregate) Open(initiatorID, counterpartyID string, now time.Time) error {
+	if a.Exists() {
+		return fmt.Errorf("%w: trade already exists", ErrInvalidTrade)
+	}
+	if initiatorID == "" || counterpartyID == "" || initiatorID == counterpartyID {
+		return fmt.Errorf("%w: two different parties are required", ErrInvalidTrade)
+	}
+	return a.raise(TradeOpenedEventType, TradeOpenedData{InitiatorID: initiatorID, CounterpartyID: counterpartyID, OpenedAt: now})
+}
+
+// UpdateOffer 사용자의 제안을 바꿉니다 (양쪽 수락 초기화)
+func (a *TradeAggregate) UpdateOffer(userID string, offer Offer, now time.Time) error {
+	if err := a.requireParty(userID, StatusOpen); err != nil {
+		return err
+	}
+	if err := offer.Validate(); err != nil {
+		return err
+	}
+	return a.raise(TradeOfferUpdatedEventType, TradeOfferUpdatedData{UserID: userID, Offer: offer, UpdatedAt: now})
+}
+
+// Accept 사용자가 현재 제안들을 수락합니다 (양쪽이 수락하면 제안이 잠김)
+func (a *TradeAggregate) Accept(userID string, now time.Time) error {
+	if err := a.requireParty(userID, StatusOpen); err != nil {
+		return err
+	}
+	if a.offers[a.initiatorID].Empty() && a.offers[a.counterpartyID].Empty() {
+		return fmt.Errorf("%w: nothing has been offered", ErrInvalidTrade)
+	}
+	if a.accepted[userID] {
+		return nil
+	}
+	return a.raise(TradeAcceptedEventType, TradeAcceptedData{UserID: userID, AcceptedAt: now})
+}
+
+// Confirm 사용자가 잠긴 제안을 최종 확인합니다
+// 양쪽이 확인하면 hold가 0보다 클 때 보류하고, 아니면 바로 정산합니다
+func (a *TradeAggregate) Confirm(userID string, hold time.Duration, now time.Time) error {
+	if err := a.requireParty(userID, StatusAccepted); err != nil {
+		return err
+	}
+	if a.confirmed[userID] {
+		return nil
+	}
+	if err := a.raise(TradeConfirmedEventType, TradeConfirmedData{UserID: userID, ConfirmedAt: now}); err != nil {
+		return err
+	}
+	if !a.confirmed[a.initiatorID] || !a.confirmed[a.counterpartyID] {
+		return nil
+	}
+	if hold > 0 {
+		return a.raise(TradeHeldEventType, TradeHeldData{HeldAt: now, ReleaseAt: now.Add(hold)})
+	}
+	return a.settle(now)
+}
+
+// Release 보류가 끝난 거래를 정산합니다 (정산할 것이 없으면 false)
+func (a *TradeAggregate) Release(now time.Time) (bool, error) {
+	if a.status != StatusHeld || now.Before(a.releaseAt) {
+		return false, nil
+	}
+	return true, a.settle(now)
+}
+
+// Cancel 당사자가 거래를 취소합니다 (보류 중인 거래는 취소할 수 없음)
+func (a *TradeAggregate) Cancel(userID, reason string, now time.Time) error {
+	if !a.Exists() {
+		return ErrTradeNotFound
+	}
+	if userID != a.initiatorID && userID != a.counterpartyID {
+		return ErrNotParty
+	}
+	if a.status == StatusHeld {
+		return ErrTradeOnHold
+	}
+	return a.cancel(userID, reason, now)
+}
+
+// Void 운영자가 정산 전 거래를 무효로 돌립니다 (보류 중인 거래 포함)
+func (a *TradeAggregate) Void(moderatorID, reason string, now time.Time) error {
+	if !a.Exists() {
+		return ErrTradeNotFound
+	}
+	if moderatorID == "" || reason == "" {
+		return fmt.Errorf("%w: moderator and reason are required", ErrInvalidTrade)
+	}
+	return a.cancel(moderatorID, reason, now)
+}
+
+func (a *TradeAggregate) cancel(by, reason string, now time.Time) error {
+	if a.status == StatusSettled || a.status == StatusCancelled {
+		return ErrTradeClosed
+	}
+	return a.raise(TradeCancelledEventType, TradeCancelledData{
+		InitiatorID:    a.initiatorID,
+		CounterpartyID: a.counterpartyID,
+		CancelledBy:    by,
+		Reason:         reason,
+		CancelledAt:    now,
+	})
+}
+
+func (a *TradeAggregate) settle(now time.Time) error {
+	return a.raise(TradeSettledEventType, TradeSettledData{
+		InitiatorID:       a.initiatorID,
+		CounterpartyID:    a.counterpartyID,
+		InitiatorOffer:    a.offers[a.initiatorID],
+		CounterpartyOffer: a.offers[a.counterpartyID],
+		SettledAt:         now,
+	})
+}
+
+func (a *TradeAggregate) requireParty(userID, status string) error {
+	if !a.Exists() {
+		return ErrTradeNotFound
+	}
+	if userID != a.initiatorID && userID != a.counterpartyID {
+		return ErrNotParty
+	}
+	switch {
+	case a.status == StatusSettled || a.status == StatusCancelled:
+		return ErrTradeClosed
+	case a.status == StatusHeld:
+		return ErrTradeOnHold
+	case a.status != status:
+		return fmt.Errorf("%w: trade is %s", ErrWrongStatus, a.status)
+	}
+	return nil
+}
+
+// LoadFromHistory 이벤트 스트림에서 거래 상태를 복원합니다
+func (a *TradeAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *TradeAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := tradeEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown trade event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *TradeAggregate) raise(eventType string, data interface{}) error {
+	event := &TradeEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *TradeAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case TradeOpenedData:
+		a.initiatorID = data.InitiatorID
+		a.counterpartyID = data.CounterpartyID
+		a.status = StatusOpen
+	case TradeOfferUpdatedData:
+		a.offers[data.UserID] = data.Offer
+		a.accepted = make(map[string]bool)
+	case TradeAcceptedData:
+		a.accepted[data.UserID] = true
+		if a.accepted[a.initiatorID] && a.accepted[a.counterpartyID] {
+			a.status = StatusAccepted
+		}
+	case TradeConfirmedData:
+		a.confirmed[data.UserID] = true
+	case TradeHeldData:
+		a.status = StatusHeld
+		a.releaseAt = data.ReleaseAt
+	case TradeSettledData:
+		a.status = StatusSettled
+		a.releaseAt = time.Time{}
+	case TradeCancelledData:
+		a.status = StatusCancelled
+		a.releaseAt = time.Time{}
+	}
+}
+
+// tradeEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var tradeEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	TradeOpenedEventType:       eventstore.DecodeEventData[TradeOpenedData],
+	TradeOfferUpdatedEventType: eventstore.DecodeEventData[TradeOfferUpdatedData],
+	TradeAcceptedEventType:     eventstore.DecodeEventData[TradeAcceptedData],
+	TradeConfirmedEventType:    eventstore.DecodeEventData[TradeConfirmedData],
+	TradeHeldEventType:         eventstore.DecodeEventData[TradeHeldData],
+	TradeSettledEventType:      eventstore.DecodeEventData[TradeSettledData],
+	TradeCancelledEventType:    eventstore.DecodeEventData[TradeCancelledData],
+}
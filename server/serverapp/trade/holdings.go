@@ -0,0 +1,226 @@
+package trade
+
+import (
+	"cqrs"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// HoldingsAggregateType 사용자 보유량 애그리게이트 타입 (애그리게이트 ID는 HoldingsID(사용자 ID))
+// 잔액과 거래별 에스크로를 이벤트 스트림에 남기므로 에스크로 확인이 읽기 모델 갱신을 기다리지 않고,
+// 같은 사용자의 두 제안이 동시에 들어와도 저장소의 버전 검사로 하나만 통과합니다
+const HoldingsAggregateType = "TradeHoldings"
+
+// 보유량 이벤트 타입 (인벤토리, 재화 프로젝션이 구독)
+const (
+	HoldingsGrantedEventType     = "HoldingsGranted"     // 거래 밖에서 얻은 아이템, 광물 (보상, 구매 등)
+	HoldingsReservedEventType    = "HoldingsReserved"    // 거래 제안만큼 에스크로 (이전 제안을 대체)
+	HoldingsReleasedEventType    = "HoldingsReleased"    // 취소된 거래의 에스크로 해제
+	HoldingsTransferredEventType = "HoldingsTransferred" // 정산된 거래의 에스크로를 내주고 상대 제안을 받음
+)
+
+// 보유량 이벤트 데이터
+type (
+	HoldingsGrantedData struct {
+		UserID    string    `json:"user_id"`
+		GrantID   string    `json:"grant_id"`
+		Amounts   Offer     `json:"amounts"`
+		GrantedAt time.Time `json:"granted_at"`
+	}
+	HoldingsReservedData struct {
+		UserID     string    `json:"user_id"`
+		TradeID    string    `json:"trade_id"`
+		Offer      Offer     `json:"offer"`
+		ReservedAt time.Time `json:"reserved_at"`
+	}
+	HoldingsReleasedData struct {
+		UserID     string    `json:"user_id"`
+		TradeID    string    `json:"trade_id"`
+		ReleasedAt time.Time `json:"released_at"`
+	}
+	HoldingsTransferredData struct {
+		UserID        string    `json:"user_id"`
+		TradeID       string    `json:"trade_id"`
+		Sent          Offer     `json:"sent"`
+		Received      Offer     `json:"received"`
+		TransferredAt time.Time `json:"transferred_at"`
+	}
+)
+
+// HoldingsID 사용자 보유량 스트림의 애그리게이트 ID (거래 ID와 겹치지 않도록 접두사를 붙입니다)
+func HoldingsID(userID string) string {
+	return "holdings:" + userID
+}
+
+// HoldingsAggregate 사용자 한 명의 아이템, 광물 잔액과 거래별 에스크로
+type HoldingsAggregate struct {
+	*cqrs.BaseAggregate
+	userID   string
+	balances Offer            // 에스크로를 포함한 보유량
+	escrow   map[string]Offer // 거래 ID -> 묶인 양
+	grants   map[string]bool  // 이미 적용한 지급 ID
+	closed   map[string]bool  // 정산이나 취소를 반영한 거래 ID
+}
+
+// NewHoldingsAggregate 새로운 HoldingsAggregate를 생성합니다
+func NewHoldingsAggregate(userID string) *HoldingsAggregate {
+	return &HoldingsAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(HoldingsID(userID), HoldingsAggregateType),
+		userID:        userID,
+		balances:      Offer{Items: make(map[string]int64), Minerals: make(map[string]int64)},
+		escrow:        make(map[string]Offer),
+		grants:        make(map[string]bool),
+		closed:        make(map[string]bool),
+	}
+}
+
+// Balances 아이템과 광물 보유량 (에스크로 포함)
+func (a *HoldingsAggregate) Balances() Offer {
+	return Offer{Items: copyAmounts(a.balances.Items), Minerals: copyAmounts(a.balances.Minerals)}
+}
+
+// Escrow 거래에 묶인 양
+func (a *HoldingsAggregate) Escrow(tradeID string) Offer {
+	return a.escrow[tradeID]
+}
+
+// Grant 거래 밖에서 얻은 아이템과 광물을 더합니다 (같은 grantID는 한 번만 적용)
+func (a *HoldingsAggregate) Grant(grantID string, amounts Offer, now time.Time) error {
+	if grantID == "" {
+		return fmt.Errorf("%w: grant ID is required", ErrInvalidTrade)
+	}
+	if err := amounts.Validate(); err != nil {
+		return err
+	}
+	if a.grants[grantID] || amounts.Empty() {
+		return nil
+	}
+	return a.raise(HoldingsGrantedEventType, HoldingsGrantedData{UserID: a.userID, GrantID: grantID, Amounts: amounts, GrantedAt: now})
+}
+
+// Reserve 거래 제안만큼 에스크로합니다
+// 다른 거래에 묶이지 않은 양이 모자라면 ErrInsufficient를 반환합니다 (같은 거래의 이전 에스크로는 새 제안으로 대체)
+func (a *HoldingsAggregate) Reserve(tradeID string, offer Offer, now time.Time) error {
+	if a.closed[tradeID] {
+		return ErrTradeClosed
+	}
+	checks := []struct {
+		balances map[string]int64
+		amounts  map[string]int64
+		part     func(Offer) map[string]int64
+	}{
+		{a.balances.Items, offer.Items, itemsOf},
+		{a.balances.Minerals, offer.Minerals, mineralsOf},
+	}
+	for _, check := range checks {
+		for key, amount := range check.amounts {
+			available := check.balances[key]
+			for escrowedTradeID, escrow := range a.escrow {
+				if escrowedTradeID != tradeID {
+					available -= check.part(escrow)[key]
+				}
+			}
+			if available < amount {
+				return fmt.Errorf("%w: %s has %d of %s available, offered %d", ErrInsufficient, a.userID, available, key, amount)
+			}
+		}
+	}
+	return a.raise(HoldingsReservedEventType, HoldingsReservedData{UserID: a.userID, TradeID: tradeID, Offer: offer, ReservedAt: now})
+}
+
+// Release 취소된 거래의 에스크로를 풉니다 (이미 반영한 거래는 건너뜀)
+func (a *HoldingsAggregate) Release(tradeID string, now time.Time) error {
+	if a.closed[tradeID] {
+		return nil
+	}
+	return a.raise(HoldingsReleasedEventType, HoldingsReleasedData{UserID: a.userID, TradeID: tradeID, ReleasedAt: now})
+}
+
+// Transfer 정산된 거래에서 sent를 내주고 received를 받습니다 (이미 반영한 거래는 건너뜀)
+// 양쪽이 같은 거래 제안으로 정산하므로 에스크로와 어긋나더라도 아이템과 광물의 총량은 보존됩니다
+func (a *HoldingsAggregate) Transfer(tradeID string, sent, received Offer, now time.Time) error {
+	if a.closed[tradeID] {
+		return nil
+	}
+	return a.raise(HoldingsTransferredEventType, HoldingsTransferredData{UserID: a.userID, TradeID: tradeID, Sent: sent, Received: received, TransferredAt: now})
+}
+
+// LoadFromHistory 이벤트 스트림에서 보유량을 복원합니다
+func (a *HoldingsAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *HoldingsAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := holdingsEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown holdings event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *HoldingsAggregate) raise(eventType string, data interface{}) error {
+	event := &TradeEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *HoldingsAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case HoldingsGrantedData:
+		addAmounts(a.balances, data.Amounts, 1)
+		a.grants[data.GrantID] = true
+	case HoldingsReservedData:
+		a.escrow[data.TradeID] = data.Offer
+	case HoldingsReleasedData:
+		delete(a.escrow, data.TradeID)
+		a.closed[data.TradeID] = true
+	case HoldingsTransferredData:
+		addAmounts(a.balances, data.Sent, -1)
+		addAmounts(a.balances, data.Received, 1)
+		delete(a.escrow, data.TradeID)
+		a.closed[data.TradeID] = true
+	}
+}
+
+// addAmounts balances에 amounts를 sign 방향으로 더하고 0이 된 항목을 지웁니다
+func addAmounts(balances, amounts Offer, sign int64) {
+	for _, pair := range [][2]map[string]int64{{balances.Items, amounts.Items}, {balances.Minerals, amounts.Minerals}} {
+		for key, amount := range pair[1] {
+			pair[0][key] += sign * amount
+			if pair[0][key] == 0 {
+				delete(pair[0], key)
+			}
+		}
+	}
+}
+
+func itemsOf(offer Offer) map[string]int64    { return offer.Items }
+func mineralsOf(offer Offer) map[string]int64 { return offer.Minerals }
+
+// holdingsEventDecoders 저장소에서 읽은 보유량 이벤트 데이터를 값 타입으로 되돌립니다
+var holdingsEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	HoldingsGrantedEventType:     eventstore.DecodeEventData[HoldingsGrantedData],
+	HoldingsReservedEventType:    eventstore.DecodeEventData[HoldingsReservedData],
+	HoldingsReleasedEventType:    eventstore.DecodeEventData[HoldingsReleasedData],
+	HoldingsTransferredEventType: eventstore.DecodeEventData[HoldingsTransferredData],
+}
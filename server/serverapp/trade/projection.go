@@ -0,0 +1,202 @@
+package trade
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sync"
+)
+
+// 거래 읽기 모델 타입 (읽기 모델 ID는 사용자 ID)
+const (
+	InventoryViewType = "TradeInventory" // 아이템 잔액과 에스크로
+	TreasuryViewType  = "TradeTreasury"  // 광물 잔액과 에스크로
+)
+
+// HoldingsView 사용자 한 명의 잔액과 거래별 에스크로
+// 인벤토리(아이템)와 재화(광물)가 같은 모양을 쓰며 GetType으로 구분합니다
+type HoldingsView struct {
+	*cqrs.BaseReadModel
+	UserID   string                      `json:"user_id"`
+	Balances map[string]int64            `json:"balances"` // 에스크로를 포함한 보유량
+	Escrow   map[string]map[string]int64 `json:"escrow"`   // 거래 ID -> 묶인 양
+	Applied  int                         `json:"applied"`  // 마지막으로 적용한 보유량 스트림 버전
+}
+
+// NewHoldingsView 새로운 HoldingsView를 생성합니다
+func NewHoldingsView(userID, viewType string) *HoldingsView {
+	return &HoldingsView{
+		BaseReadModel: cqrs.NewBaseReadModel(userID, viewType, map[string]interface{}{}),
+		UserID:        userID,
+		Balances:      make(map[string]int64),
+		Escrow:        make(map[string]map[string]int64),
+	}
+}
+
+// Available 다른 거래에 묶이지 않은 양 (excludeTradeID 거래의 에스크로는 빼지 않음)
+func (v *HoldingsView) Available(key, excludeTradeID string) int64 {
+	available := v.Balances[key]
+	for tradeID, escrow := range v.Escrow {
+		if tradeID != excludeTradeID {
+			available -= escrow[key]
+		}
+	}
+	return available
+}
+
+// Escrowed 모든 거래에 묶인 양
+func (v *HoldingsView) Escrowed(key string) int64 {
+	var escrowed int64
+	for _, escrow := range v.Escrow {
+		escrowed += escrow[key]
+	}
+	return escrowed
+}
+
+// add 잔액에 amounts를 sign 방향으로 더하고 0이 된 항목을 지웁니다
+func (v *HoldingsView) add(amounts map[string]int64, sign int64) {
+	for key, amount := range amounts {
+		v.Balances[key] += sign * amount
+		if v.Balances[key] == 0 {
+			delete(v.Balances, key)
+		}
+	}
+}
+
+// GetData 읽기 모델 데이터
+func (v *HoldingsView) GetData() interface{} {
+	return map[string]interface{}{
+		"user_id":  v.UserID,
+		"balances": v.Balances,
+		"escrow":   v.Escrow,
+	}
+}
+
+// HoldingsProjection 보유량 이벤트로 인벤토리 또는 재화 읽기 모델을 갱신합니다
+// 잔액과 에스크로의 원본은 사용자별 보유량 스트림이므로 읽기 모델을 지우고 다시 재생해도 같은 결과가 나오며,
+// 스트림 버전보다 오래된 이벤트는 건너뛰므로 같은 이벤트를 다시 받아도 잔액이 바뀌지 않습니다
+type HoldingsProjection struct {
+	*cqrs.BaseEventHandler
+	store    cqrs.ReadStore
+	viewType string
+	part     func(Offer) map[string]int64
+
+	mu sync.Mutex // 같은 사용자 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewInventoryProjection 아이템 인벤토리 프로젝션
+func NewInventoryProjection(store cqrs.ReadStore) *HoldingsProjection {
+	return newHoldingsProjection("trade-inventory", store, InventoryViewType, func(offer Offer) map[string]int64 { return offer.Items })
+}
+
+// NewTreasuryProjection 광물 재화 프로젝션
+func NewTreasuryProjection(store cqrs.ReadStore) *HoldingsProjection {
+	return newHoldingsProjection("trade-treasury", store, TreasuryViewType, func(offer Offer) map[string]int64 { return offer.Minerals })
+}
+
+func newHoldingsProjection(name string, store cqrs.ReadStore, viewType string, part func(Offer) map[string]int64) *HoldingsProjection {
+	return &HoldingsProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler(name, cqrs.ProjectionHandler, []string{
+			HoldingsGrantedEventType,
+			HoldingsReservedEventType,
+			HoldingsReleasedEventType,
+			HoldingsTransferredEventType,
+		}),
+		store:    store,
+		viewType: viewType,
+		part:     part,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *HoldingsProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// View 사용자의 읽기 모델 (없으면 빈 읽기 모델)
+func (p *HoldingsProjection) View(ctx context.Context, userID string) (*HoldingsView, error) {
+	return loadHoldings(ctx, p.store, userID, p.viewType)
+}
+
+// Handle 보유량 이벤트를 적용합니다
+func (p *HoldingsProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	decode, known := holdingsEventDecoders[event.EventType()]
+	if !known {
+		return nil
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	var apply func(view *HoldingsView)
+	switch data := data.(type) {
+	case HoldingsGrantedData:
+		userID = data.UserID
+		apply = func(view *HoldingsView) { view.add(p.part(data.Amounts), 1) }
+	case HoldingsReservedData:
+		userID = data.UserID
+		apply = func(view *HoldingsView) { view.Escrow[data.TradeID] = copyAmounts(p.part(data.Offer)) }
+	case HoldingsReleasedData:
+		userID = data.UserID
+		apply = func(view *HoldingsView) { delete(view.Escrow, data.TradeID) }
+	case HoldingsTransferredData:
+		userID = data.UserID
+		apply = func(view *HoldingsView) {
+			view.add(p.part(data.Sent), -1)
+			view.add(p.part(data.Received), 1)
+			delete(view.Escrow, data.TradeID)
+		}
+	default:
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.update(ctx, userID, func(view *HoldingsView) {
+		if event.Version() <= view.Applied {
+			return
+		}
+		apply(view)
+		view.Applied = event.Version()
+	})
+}
+
+func (p *HoldingsProjection) update(ctx context.Context, userID string, fn func(*HoldingsView)) error {
+	return cqrs.UpsertReadModel(ctx, p.store, userID, p.viewType,
+		func() *HoldingsView { return NewHoldingsView(userID, p.viewType) },
+		func(view *HoldingsView) error {
+			fn(view)
+			view.IncrementVersion()
+			return nil
+		})
+}
+
+// loadHoldings 사용자의 읽기 모델을 불러옵니다 (없으면 빈 읽기 모델)
+func loadHoldings(ctx context.Context, store cqrs.ReadStore, userID, viewType string) (*HoldingsView, error) {
+	view, err := cqrs.LoadReadModel[*HoldingsView](ctx, store, userID, viewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewHoldingsView(userID, viewType), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s for %s: %w", viewType, userID, err)
+	}
+	return view, nil
+}
+
+func copyAmounts(amounts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(amounts))
+	for key, amount := range amounts {
+		copied[key] = amount
+	}
+	return copied
+}
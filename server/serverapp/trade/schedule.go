@@ -0,0 +1,125 @@
+package trade
+
+import (
+	"cqrs"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 거래 정산 일정 애그리게이트 (애그리게이트 ID는 ScheduleID)
+// 보류 중이거나 보유량 반영이 남은 거래를 이벤트 스트림에 기록해 두므로
+// 재시작한 서버나 다른 인스턴스도 같은 스트림을 읽어 ReleaseDue에서 이어서 처리합니다
+const (
+	ScheduleAggregateType = "TradeSchedule"
+	ScheduleID            = "trade-schedule"
+)
+
+// 정산 일정 이벤트 타입
+const (
+	TradeScheduledEventType   = "TradeScheduled"
+	TradeUnscheduledEventType = "TradeUnscheduled"
+)
+
+// 정산 일정 이벤트 데이터
+type (
+	TradeScheduledData struct {
+		TradeID string    `json:"trade_id"`
+		DueAt   time.Time `json:"due_at"` // 보류가 끝나는 시각 (보유량 반영만 남았으면 기록한 시각)
+	}
+	TradeUnscheduledData struct {
+		TradeID string `json:"trade_id"`
+	}
+)
+
+// ScheduleAggregate 처리할 거래와 처리할 시각
+type ScheduleAggregate struct {
+	*cqrs.BaseAggregate
+	due map[string]time.Time // 거래 ID -> 처리 시각
+}
+
+// NewScheduleAggregate 새로운 ScheduleAggregate를 생성합니다
+func NewScheduleAggregate() *ScheduleAggregate {
+	return &ScheduleAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(ScheduleID, ScheduleAggregateType),
+		due:           make(map[string]time.Time),
+	}
+}
+
+// Due 처리할 거래 ID와 처리 시각
+func (a *ScheduleAggregate) Due() map[string]time.Time {
+	due := make(map[string]time.Time, len(a.due))
+	for tradeID, dueAt := range a.due {
+		due[tradeID] = dueAt
+	}
+	return due
+}
+
+// Schedule 거래를 dueAt에 처리하도록 기록합니다 (같은 시각으로 이미 기록했으면 건너뜀)
+func (a *ScheduleAggregate) Schedule(tradeID string, dueAt time.Time) error {
+	if current, exists := a.due[tradeID]; exists && current.Equal(dueAt) {
+		return nil
+	}
+	return a.raise(TradeScheduledEventType, TradeScheduledData{TradeID: tradeID, DueAt: dueAt})
+}
+
+// Unschedule 처리한 거래를 일정에서 지웁니다
+func (a *ScheduleAggregate) Unschedule(tradeID string) error {
+	if _, exists := a.due[tradeID]; !exists {
+		return nil
+	}
+	return a.raise(TradeUnscheduledEventType, TradeUnscheduledData{TradeID: tradeID})
+}
+
+// LoadFromHistory 이벤트 스트림에서 일정을 복원합니다 (이미 불러온 뒤의 이벤트만 넘겨 이어 붙일 수 있음)
+func (a *ScheduleAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *ScheduleAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := scheduleEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown trade schedule event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *ScheduleAggregate) raise(eventType string, data interface{}) error {
+	event := &TradeEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *ScheduleAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case TradeScheduledData:
+		a.due[data.TradeID] = data.DueAt
+	case TradeUnscheduledData:
+		delete(a.due, data.TradeID)
+	}
+}
+
+// scheduleEventDecoders 저장소에서 읽은 일정 이벤트 데이터를 값 타입으로 되돌립니다
+var scheduleEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	TradeScheduledEventType:   eventstore.DecodeEventData[TradeScheduledData],
+	TradeUnscheduledEventType: eventstore.DecodeEventData[TradeUnscheduledData],
+}
@@ -0,0 +1,535 @@
+package trade
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 거래 명령 타입 (명령의 ID는 거래 ID, UserID는 명령을 보낸 사용자)
+const (
+	OpenTradeCommandType    = "OpenTrade"
+	UpdateOfferCommandType  = "UpdateTradeOffer"
+	AcceptTradeCommandType  = "AcceptTrade"
+	ConfirmTradeCommandType = "ConfirmTrade"
+	CancelTradeCommandType  = "CancelTrade"
+	VoidTradeCommandType    = "VoidTrade" // 운영자 전용, 사용자 요청 경로에 노출하지 않습니다
+)
+
+// 거래 명령 데이터
+type (
+	OpenTradeData struct {
+		CounterpartyID string `json:"counterparty_id"`
+	}
+	UpdateOfferData struct {
+		Offer Offer `json:"offer"`
+	}
+	CancelTradeData struct {
+		Reason string `json:"reason,omitempty"`
+	}
+)
+
+// NewOpenTradeCommand 거래 시작 명령
+func NewOpenTradeCommand(tradeID, initiatorID, counterpartyID string) cqrs.Command {
+	return newTradeCommand(OpenTradeCommandType, tradeID, initiatorID, OpenTradeData{CounterpartyID: counterpartyID})
+}
+
+// NewUpdateOfferCommand 제안 변경 명령
+func NewUpdateOfferCommand(tradeID, userID string, offer Offer) cqrs.Command {
+	return newTradeCommand(UpdateOfferCommandType, tradeID, userID, UpdateOfferData{Offer: offer})
+}
+
+// NewAcceptTradeCommand 제안 수락 명령
+func NewAcceptTradeCommand(tradeID, userID string) cqrs.Command {
+	return newTradeCommand(AcceptTradeCommandType, tradeID, userID, nil)
+}
+
+// NewConfirmTradeCommand 최종 확인 명령
+func NewConfirmTradeCommand(tradeID, userID string) cqrs.Command {
+	return newTradeCommand(ConfirmTradeCommandType, tradeID, userID, nil)
+}
+
+// NewCancelTradeCommand 당사자 거래 취소 명령
+func NewCancelTradeCommand(tradeID, userID, reason string) cqrs.Command {
+	return newTradeCommand(CancelTradeCommandType, tradeID, userID, CancelTradeData{Reason: reason})
+}
+
+// NewVoidTradeCommand 운영자 거래 무효 명령
+func NewVoidTradeCommand(tradeID, moderatorID, reason string) cqrs.Command {
+	return newTradeCommand(VoidTradeCommandType, tradeID, moderatorID, CancelTradeData{Reason: reason})
+}
+
+func newTradeCommand(commandType, tradeID, userID string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, tradeID, TradeAggregateType, data)
+	command.SetUserID(userID)
+	return command
+}
+
+// HoldPolicy 양쪽이 확인한 거래의 사기 방지 보류 기간을 정합니다 (0이면 바로 정산)
+type HoldPolicy func(trade *TradeAggregate) time.Duration
+
+// OneSidedHold 한쪽만 내놓는 거래만 period 동안 보류합니다
+// 대가 없는 이전은 계정 탈취, 작업장 거래에 주로 쓰이므로 운영자가 확인할 시간을 둡니다
+func OneSidedHold(period time.Duration) HoldPolicy {
+	return func(trade *TradeAggregate) time.Duration {
+		if trade.OneSided() {
+			return period
+		}
+		return 0
+	}
+}
+
+// ServiceConfig 거래 서비스 설정
+type ServiceConfig struct {
+	Store           eventstore.EventStore // 필수: 거래, 보유량, 정산 일정 이벤트 저장소
+	EventBus        cqrs.EventBus         // 선택: 거래, 보유량 이벤트 발행 (인벤토리, 재화 프로젝션이 구독)
+	HoldPolicy      HoldPolicy            // 보류 기간 정책 (기본값: OneSidedHold(24h))
+	ReleaseInterval time.Duration         // 보류가 끝난 거래를 정산하는 주기 (기본값: 1m)
+	Now             func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 거래 명령을 처리하고 보류가 끝난 거래를 정산합니다
+// 에스크로는 사용자별 보유량 스트림(HoldingsAggregate)에, 보류 중인 거래는 정산 일정 스트림(ScheduleAggregate)에 기록합니다
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu       sync.Mutex         // 거래 변경 직렬화 (인스턴스 간 경합은 저장소의 버전 검사가 막음)
+	schedule *ScheduleAggregate // 저장소에서 이어 읽는 정산 일정
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewService 새로운 Service를 생성합니다
+// 에스크로 기록이 재시작 후에도 남아야 하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("trade: %w", ErrStoreRequired)
+	}
+	if config.HoldPolicy == nil {
+		config.HoldPolicy = OneSidedHold(24 * time.Hour)
+	}
+	if config.ReleaseInterval <= 0 {
+		config.ReleaseInterval = time.Minute
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("trade", []string{
+			OpenTradeCommandType,
+			UpdateOfferCommandType,
+			AcceptTradeCommandType,
+			ConfirmTradeCommandType,
+			CancelTradeCommandType,
+			VoidTradeCommandType,
+		}),
+		config:   config,
+		schedule: NewScheduleAggregate(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// RegisterWith 거래 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load 거래 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, tradeID string) (*TradeAggregate, error) {
+	return s.load(ctx, tradeID)
+}
+
+// LoadHoldings 사용자의 아이템, 광물 잔액과 에스크로를 불러옵니다
+func (s *Service) LoadHoldings(ctx context.Context, userID string) (*HoldingsAggregate, error) {
+	return s.loadHoldings(ctx, userID)
+}
+
+// Grant 거래 밖에서 얻은 아이템과 광물을 사용자 보유량에 더합니다 (보상, 채굴 등)
+// 같은 grantID는 한 번만 적용하므로 지급을 재시도해도 잔액이 두 번 늘지 않습니다
+func (s *Service) Grant(ctx context.Context, userID, grantID string, amounts Offer) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidTrade)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	holdings, err := s.loadHoldings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := holdings.Grant(grantID, amounts, s.config.Now()); err != nil {
+		return err
+	}
+	return s.saveHoldings(ctx, holdings)
+}
+
+// Inventory 아이템 지급 (purchase.Granter, loginreward.Granter를 구현)
+func (s *Service) Inventory() *HoldingsGranter {
+	return &HoldingsGranter{service: s, kind: "items", offer: func(amounts map[string]int64) Offer { return Offer{Items: amounts} }}
+}
+
+// Treasury 광물 지급 (purchase.Granter를 구현)
+func (s *Service) Treasury() *HoldingsGranter {
+	return &HoldingsGranter{service: s, kind: "minerals", offer: func(amounts map[string]int64) Offer { return Offer{Minerals: amounts} }}
+}
+
+// HoldingsGranter 아이템이나 광물 한 종류만 보유량 스트림에 지급합니다
+// 한 결제에서 아이템과 광물을 같은 지급 ID로 함께 지급하므로 종류별로 지급 ID를 나눕니다
+type HoldingsGranter struct {
+	service *Service
+	kind    string
+	offer   func(amounts map[string]int64) Offer
+}
+
+// Grant 같은 grantID는 한 번만 적용합니다
+func (g *HoldingsGranter) Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error {
+	if grantID == "" {
+		return fmt.Errorf("%w: grant ID is required", ErrInvalidTrade)
+	}
+	return g.service.Grant(ctx, userID, g.kind+":"+grantID, g.offer(amounts))
+}
+
+// Handle 거래 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	tradeID, userID := command.ID(), command.UserID()
+	if tradeID == "" || userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: trade ID and user ID are required", ErrInvalidTrade)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	previous := aggregate.Offer(userID) // 거래 저장에 실패하면 되돌릴 에스크로
+	var offered *Offer                  // 에스크로할 새 제안
+	switch command.CommandType() {
+	case OpenTradeCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[OpenTradeData](command.GetData(), ErrInvalidTrade)
+		if err = decodeErr; err == nil {
+			err = aggregate.Open(userID, data.CounterpartyID, now)
+		}
+	case UpdateOfferCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[UpdateOfferData](command.GetData(), ErrInvalidTrade)
+		if err = decodeErr; err == nil {
+			if err = aggregate.UpdateOffer(userID, data.Offer, now); err == nil {
+				offered = &data.Offer
+			}
+		}
+	case AcceptTradeCommandType:
+		err = aggregate.Accept(userID, now)
+	case ConfirmTradeCommandType:
+		err = aggregate.Confirm(userID, s.config.HoldPolicy(aggregate), now)
+	case CancelTradeCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[CancelTradeData](command.GetData(), ErrInvalidTrade)
+		if err = decodeErr; err == nil {
+			err = aggregate.Cancel(userID, data.Reason, now)
+		}
+	case VoidTradeCommandType:
+		data, decodeErr := eventstore.DecodeCommandData[CancelTradeData](command.GetData(), ErrInvalidTrade)
+		if err = decodeErr; err == nil {
+			err = aggregate.Void(userID, data.Reason, now)
+		}
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidTrade, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	if offered != nil {
+		if err := s.reserve(ctx, userID, tradeID, *offered, now); err != nil {
+			if errors.Is(err, ErrInsufficient) || errors.Is(err, ErrTradeClosed) {
+				return cqrs.NewFailedCommandResult(err), nil
+			}
+			return nil, err
+		}
+	}
+
+	// 보류나 종료로 바뀌는 거래는 저장 전에 일정에 올려, 이후 단계가 실패해도 ReleaseDue가 이어서 처리합니다
+	if dueAt, due := s.dueAt(aggregate, now); due {
+		if err := s.updateSchedule(ctx, func(schedule *ScheduleAggregate) error { return schedule.Schedule(tradeID, dueAt) }); err != nil {
+			return nil, err
+		}
+	}
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		if offered != nil {
+			if restoreErr := s.reserve(ctx, userID, tradeID, previous, now); restoreErr != nil {
+				log.Printf("[Trade] Failed to restore escrow of %s in %s: %v", userID, tradeID, restoreErr)
+			}
+		}
+		return nil, err
+	}
+	if err := s.close(ctx, aggregate, now); err != nil {
+		log.Printf("[Trade] Failed to apply %s to holdings, retrying on the next release: %v", tradeID, err)
+	}
+	log.Printf("[Trade] %s applied to %s by %s", command.CommandType(), tradeID, userID)
+	return cqrs.NewCommandResult(tradeID, aggregate.Version(), events...).WithData(aggregate.Status()), nil
+}
+
+// reserve 사용자 보유량 스트림에 거래 제안만큼 에스크로합니다
+// 다른 거래에 묶이지 않은 양이 모자라면 ErrInsufficient, 다른 인스턴스가 먼저 바꿨으면 동시성 에러를 반환합니다
+func (s *Service) reserve(ctx context.Context, userID, tradeID string, offer Offer, now time.Time) error {
+	holdings, err := s.loadHoldings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := holdings.Reserve(tradeID, offer, now); err != nil {
+		return err
+	}
+	return s.saveHoldings(ctx, holdings)
+}
+
+// dueAt 거래가 일정에 올라야 하는지와 처리 시각 (보류는 보류가 끝나는 시각, 종료는 지금)
+func (s *Service) dueAt(aggregate *TradeAggregate, now time.Time) (time.Time, bool) {
+	switch aggregate.Status() {
+	case StatusHeld:
+		return aggregate.ReleaseAt(), true
+	case StatusSettled, StatusCancelled:
+		return now, true
+	}
+	return time.Time{}, false
+}
+
+// close 정산이나 취소된 거래를 양쪽 보유량에 반영하고 일정에서 지웁니다 (끝나지 않은 거래는 건너뜀)
+// 보유량 스트림이 거래별 반영 여부를 기억하므로 같은 거래를 다시 반영해도 잔액이 바뀌지 않습니다
+func (s *Service) close(ctx context.Context, aggregate *TradeAggregate, now time.Time) error {
+	status := aggregate.Status()
+	if status != StatusSettled && status != StatusCancelled {
+		return nil
+	}
+	tradeID := aggregate.ID()
+	initiatorID, counterpartyID := aggregate.Parties()
+	for _, parties := range [][2]string{{initiatorID, counterpartyID}, {counterpartyID, initiatorID}} {
+		holdings, err := s.loadHoldings(ctx, parties[0])
+		if err != nil {
+			return err
+		}
+		if status == StatusSettled {
+			err = holdings.Transfer(tradeID, aggregate.Offer(parties[0]), aggregate.Offer(parties[1]), now)
+		} else {
+			err = holdings.Release(tradeID, now)
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.saveHoldings(ctx, holdings); err != nil {
+			return err
+		}
+	}
+	return s.updateSchedule(ctx, func(schedule *ScheduleAggregate) error { return schedule.Unschedule(tradeID) })
+}
+
+// ReleaseDue 처리 시각이 지난 거래를 정산하고 정산한 수를 반환합니다
+// 일정은 저장소에서 이어 읽으므로 재시작 전이나 다른 인스턴스에서 보류된 거래도 정산하고,
+// 거래는 끝났지만 보유량 반영이 실패했던 거래는 반영을 다시 시도합니다
+func (s *Service) ReleaseDue(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refreshSchedule(ctx); err != nil {
+		return 0, err
+	}
+
+	now := s.config.Now()
+	released := 0
+	for tradeID, dueAt := range s.schedule.Due() {
+		if now.Before(dueAt) {
+			continue
+		}
+		aggregate, err := s.load(ctx, tradeID)
+		if err != nil {
+			return released, err
+		}
+		changed, err := aggregate.Release(now)
+		if err != nil {
+			return released, err
+		}
+		if changed {
+			if err := s.save(ctx, aggregate); err != nil {
+				if cqrs.IsConcurrencyError(err) {
+					continue // 다른 인스턴스가 먼저 정산함, 다음 주기에 보유량 반영만 확인
+				}
+				return released, err
+			}
+			released++
+		}
+		if _, due := s.dueAt(aggregate, now); !due {
+			// 보류가 풀린 채 다시 열린 거래 등 더 처리할 것이 없는 일정
+			if err := s.updateSchedule(ctx, func(schedule *ScheduleAggregate) error { return schedule.Unschedule(tradeID) }); err != nil {
+				return released, err
+			}
+			continue
+		}
+		if err := s.close(ctx, aggregate, now); err != nil {
+			return released, err
+		}
+	}
+	return released, nil
+}
+
+// Start 보류 정산 스케줄러를 시작합니다
+func (s *Service) Start(ctx context.Context) {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.config.ReleaseInterval)
+		defer ticker.Stop()
+		// 재시작 직후 저장소의 일정을 읽어 밀린 정산을 바로 처리
+		s.releaseDue(ctx)
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.releaseDue(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Service) releaseDue(ctx context.Context) {
+	if count, err := s.ReleaseDue(ctx); err != nil {
+		log.Printf("[Trade] Failed to release held trades: %v", err)
+	} else if count > 0 {
+		log.Printf("[Trade] %d held trades settled", count)
+	}
+}
+
+// Stop 보류 정산 스케줄러를 멈추고 끝날 때까지 기다립니다
+func (s *Service) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	if !s.started.Load() {
+		return nil
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Service) load(ctx context.Context, tradeID string) (*TradeAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, tradeID, TradeAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trade %s: %w", tradeID, err)
+	}
+	aggregate := NewTradeAggregate(tradeID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *TradeAggregate) error {
+	events := aggregate.Changes()
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save trade %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+	s.publish(ctx, aggregate.ID(), events)
+	return nil
+}
+
+func (s *Service) loadHoldings(ctx context.Context, userID string) (*HoldingsAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, HoldingsID(userID), HoldingsAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load holdings of %s: %w", userID, err)
+	}
+	holdings := NewHoldingsAggregate(userID)
+	if err := holdings.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return holdings, nil
+}
+
+// saveHoldings 보유량 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) saveHoldings(ctx context.Context, holdings *HoldingsAggregate) error {
+	events := holdings.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, holdings.ID(), events, holdings.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save holdings of %s: %w", holdings.userID, err)
+	}
+	holdings.ClearChanges()
+	holdings.SetOriginalVersion(holdings.Version())
+	s.publish(ctx, holdings.ID(), events)
+	return nil
+}
+
+// refreshSchedule 마지막으로 읽은 뒤 저장소에 쌓인 일정 이벤트를 이어 읽습니다
+func (s *Service) refreshSchedule(ctx context.Context) error {
+	events, err := s.config.Store.GetEventHistory(ctx, ScheduleID, ScheduleAggregateType, s.schedule.Version())
+	if err != nil {
+		return fmt.Errorf("failed to load trade schedule: %w", err)
+	}
+	return s.schedule.LoadFromHistory(events)
+}
+
+// updateSchedule 일정을 바꿔 저장합니다 (다른 인스턴스가 먼저 바꿨으면 이어 읽고 다시 시도)
+func (s *Service) updateSchedule(ctx context.Context, change func(*ScheduleAggregate) error) error {
+	const attempts = 3
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = s.refreshSchedule(ctx); err != nil {
+			return err
+		}
+		if err = change(s.schedule); err != nil {
+			return err
+		}
+		events := s.schedule.Changes()
+		if len(events) == 0 {
+			return nil
+		}
+		err = s.config.Store.SaveEvents(ctx, ScheduleID, events, s.schedule.OriginalVersion())
+		if err == nil {
+			s.schedule.ClearChanges()
+			s.schedule.SetOriginalVersion(s.schedule.Version())
+			return nil
+		}
+		// 저장하지 못한 변경을 버리고 저장소 기준으로 다시 읽음
+		s.schedule = NewScheduleAggregate()
+		if !cqrs.IsConcurrencyError(err) {
+			break
+		}
+	}
+	return fmt.Errorf("failed to save trade schedule: %w", err)
+}
+
+func (s *Service) publish(ctx context.Context, aggregateID string, events []cqrs.EventMessage) {
+	if s.config.EventBus == nil {
+		return
+	}
+	for _, event := range events {
+		if err := s.config.EventBus.Publish(ctx, event); err != nil {
+			log.Printf("[Trade] Failed to publish %s for %s: %v", event.EventType(), aggregateID, err)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package trade
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tradeFixture 거래 서비스와 프로젝션을 이벤트 버스 없이 직접 연결합니다
+type tradeFixture struct {
+	clock     *testkit.Clock
+	events    eventstore.EventStore
+	store     cqrs.ReadStore
+	service   *Service
+	inventory *HoldingsProjection
+	treasury  *HoldingsProjection
+}
+
+func newTradeFixture(t *testing.T) *tradeFixture {
+	t.Helper()
+	clock := testkit.NewClock(testkit.DefaultStart)
+	events := eventstore.NewInMemoryEventStore()
+	store := cqrs.NewInMemoryReadStore()
+	return &tradeFixture{
+		clock:     clock,
+		events:    events,
+		store:     store,
+		service:   newService(t, events, clock),
+		inventory: NewInventoryProjection(store),
+		treasury:  NewTreasuryProjection(store),
+	}
+}
+
+// newService 같은 저장소를 쓰는 거래 서비스 (재시작이나 다른 인스턴스)
+func newService(t *testing.T, events eventstore.EventStore, clock *testkit.Clock) *Service {
+	t.Helper()
+	service, err := NewService(ServiceConfig{Store: events, HoldPolicy: OneSidedHold(time.Hour), Now: clock.Now})
+	require.NoError(t, err)
+	return service
+}
+
+func (f *tradeFixture) grant(t *testing.T, userID string, amounts Offer) {
+	t.Helper()
+	require.NoError(t, f.service.Grant(context.Background(), userID, "seed:"+userID, amounts))
+}
+
+func (f *tradeFixture) dispatch(t *testing.T, command cqrs.Command) *cqrs.CommandResult {
+	t.Helper()
+	return testkit.Handle(t, f.service, command)
+}
+
+// view 사용자의 보유량 스트림 전체를 프로젝션에 다시 적용한 뒤 읽기 모델을 읽습니다
+func (f *tradeFixture) view(t *testing.T, projection *HoldingsProjection, userID string) *HoldingsView {
+	t.Helper()
+	history, err := f.events.GetEventHistory(context.Background(), HoldingsID(userID), HoldingsAggregateType, 0)
+	require.NoError(t, err)
+	for _, event := range history {
+		require.NoError(t, projection.Handle(context.Background(), event))
+	}
+	view, err := projection.View(context.Background(), userID)
+	require.NoError(t, err)
+	return view
+}
+
+func TestTradeAggregate_StateMachine(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	trade := NewTradeAggregate("t1")
+
+	assert.ErrorIs(t, trade.Open("alice", "alice", now), ErrInvalidTrade)
+	require.NoError(t, trade.Open("alice", "bob", now))
+	assert.ErrorIs(t, trade.UpdateOffer("carol", Offer{Items: map[string]int64{"sword": 1}}, now), ErrNotParty)
+	assert.ErrorIs(t, trade.UpdateOffer("alice", Offer{Items: map[string]int64{"sword": 0}}, now), ErrInvalidTrade)
+	assert.ErrorIs(t, trade.Accept("alice", now), ErrInvalidTrade) // 아무것도 제안하지 않음
+
+	require.NoError(t, trade.UpdateOffer("alice", Offer{Items: map[string]int64{"sword": 1}}, now))
+	require.NoError(t, trade.Accept("alice", now))
+	// 제안이 바뀌면 수락 초기화
+	require.NoError(t, trade.UpdateOffer("bob", Offer{Minerals: map[string]int64{"gold": 50}}, now))
+	require.NoError(t, trade.Accept("bob", now))
+	assert.Equal(t, StatusOpen, trade.Status())
+	assert.ErrorIs(t, trade.Confirm("alice", 0, now), ErrWrongStatus)
+
+	require.NoError(t, trade.Accept("alice", now))
+	assert.Equal(t, StatusAccepted, trade.Status())
+	assert.ErrorIs(t, trade.UpdateOffer("alice", Offer{}, now), ErrWrongStatus)
+
+	require.NoError(t, trade.Confirm("alice", time.Hour, now))
+	require.NoError(t, trade.Confirm("bob", time.Hour, now))
+	assert.Equal(t, StatusHeld, trade.Status())
+	assert.ErrorIs(t, trade.Cancel("bob", "changed my mind", now), ErrTradeOnHold)
+
+	released, err := trade.Release(now.Add(30 * time.Minute))
+	require.NoError(t, err)
+	assert.False(t, released)
+	require.NoError(t, trade.Void("mod", "stolen account", now))
+	assert.Equal(t, StatusCancelled, trade.Status())
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewTradeAggregate("t1")
+	require.NoError(t, restored.LoadFromHistory(trade.Changes()))
+	assert.Equal(t, StatusCancelled, restored.Status())
+	assert.Equal(t, int64(50), restored.Offer("bob").Minerals["gold"])
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 에스크로 기록이 사라짐
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestService_BalancedTradeSettlesAndMovesHoldings(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	f.grant(t, "alice", Offer{Items: map[string]int64{"sword": 2}})
+	f.grant(t, "bob", Offer{Minerals: map[string]int64{"gold": 100}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Items: map[string]int64{"sword": 1}}))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "bob", Offer{Minerals: map[string]int64{"gold": 60}}))
+
+	// Act
+	escrowed := f.view(t, f.inventory, "alice").Available("sword", "")
+	f.dispatch(t, NewAcceptTradeCommand("t1", "alice"))
+	f.dispatch(t, NewAcceptTradeCommand("t1", "bob"))
+	f.dispatch(t, NewConfirmTradeCommand("t1", "alice"))
+	result := f.dispatch(t, NewConfirmTradeCommand("t1", "bob"))
+
+	// Assert
+	require.True(t, result.Success, result.Error)
+	assert.Equal(t, StatusSettled, result.Data)
+	assert.Equal(t, int64(1), escrowed) // 제안 중에는 한 개가 묶임
+	assert.Equal(t, int64(1), f.view(t, f.inventory, "alice").Balances["sword"])
+	assert.Equal(t, int64(1), f.view(t, f.inventory, "bob").Balances["sword"])
+	assert.Equal(t, int64(60), f.view(t, f.treasury, "alice").Balances["gold"])
+	assert.Equal(t, int64(40), f.view(t, f.treasury, "bob").Balances["gold"])
+	assert.Empty(t, f.view(t, f.treasury, "bob").Escrow)
+
+	// 보유량 이벤트를 다시 받아도 잔액은 그대로
+	assert.Equal(t, int64(40), f.view(t, f.treasury, "bob").Balances["gold"])
+}
+
+func TestService_RejectsOfferBeyondAvailableHoldings(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	f.grant(t, "alice", Offer{Minerals: map[string]int64{"gold": 100}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	f.dispatch(t, NewOpenTradeCommand("t2", "alice", "carol"))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Minerals: map[string]int64{"gold": 80}}))
+
+	// Act
+	overdrawn := f.dispatch(t, NewUpdateOfferCommand("t2", "alice", Offer{Minerals: map[string]int64{"gold": 30}}))
+	raised := f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Minerals: map[string]int64{"gold": 100}}))
+	f.dispatch(t, NewCancelTradeCommand("t1", "bob", "too expensive"))
+	afterCancel := f.dispatch(t, NewUpdateOfferCommand("t2", "alice", Offer{Minerals: map[string]int64{"gold": 30}}))
+
+	// Assert
+	assert.ErrorIs(t, overdrawn.Error, ErrInsufficient) // 다른 거래에 80이 묶여 있음
+	assert.True(t, raised.Success, raised.Error)        // 같은 거래의 에스크로는 새 제안으로 대체
+	assert.True(t, afterCancel.Success, afterCancel.Error)
+	assert.Equal(t, int64(30), f.view(t, f.treasury, "alice").Escrowed("gold"))
+}
+
+func TestService_OneSidedTradeIsHeldUntilReleased(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	f.grant(t, "alice", Offer{Minerals: map[string]int64{"gold": 100}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Minerals: map[string]int64{"gold": 100}}))
+	f.dispatch(t, NewAcceptTradeCommand("t1", "alice"))
+	f.dispatch(t, NewAcceptTradeCommand("t1", "bob"))
+	f.dispatch(t, NewConfirmTradeCommand("t1", "alice"))
+
+	// Act
+	held := f.dispatch(t, NewConfirmTradeCommand("t1", "bob"))
+	early, err := f.service.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	f.clock.Advance(time.Hour)
+	released, err := f.service.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	history, err := f.events.GetEventHistory(context.Background(), "t1", TradeAggregateType, 0)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, StatusHeld, held.Data)
+	assert.Zero(t, early)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, TradeSettledEventType, history[len(history)-1].EventType())
+	assert.Equal(t, int64(100), f.view(t, f.treasury, "bob").Balances["gold"])
+	assert.Zero(t, f.view(t, f.treasury, "alice").Balances["gold"])
+}
+
+func TestService_EscrowIsEnforcedAcrossInstances(t *testing.T) {
+	// Arrange: 읽기 모델 없이 같은 저장소를 쓰는 두 인스턴스
+	f := newTradeFixture(t)
+	other := newService(t, f.events, f.clock)
+	f.grant(t, "alice", Offer{Minerals: map[string]int64{"gold": 100}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	testkit.Handle(t, other, NewOpenTradeCommand("t2", "alice", "carol"))
+	require.True(t, f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Minerals: map[string]int64{"gold": 80}})).Success)
+
+	// Act
+	doubleSpent := testkit.Handle(t, other, NewUpdateOfferCommand("t2", "alice", Offer{Minerals: map[string]int64{"gold": 30}}))
+	holdings, err := other.LoadHoldings(context.Background(), "alice")
+	require.NoError(t, err)
+
+	// Assert
+	assert.ErrorIs(t, doubleSpent.Error, ErrInsufficient)
+	assert.Equal(t, int64(80), holdings.Escrow("t1").Minerals["gold"])
+	assert.Empty(t, holdings.Escrow("t2").Minerals)
+}
+
+func TestService_ReleasesTradesHeldBeforeRestart(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	f.grant(t, "alice", Offer{Items: map[string]int64{"sword": 1}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Items: map[string]int64{"sword": 1}}))
+	f.dispatch(t, NewAcceptTradeCommand("t1", "alice"))
+	f.dispatch(t, NewAcceptTradeCommand("t1", "bob"))
+	f.dispatch(t, NewConfirmTradeCommand("t1", "alice"))
+	require.Equal(t, StatusHeld, f.dispatch(t, NewConfirmTradeCommand("t1", "bob")).Data)
+
+	// Act: 보류한 서비스가 사라지고 새 서비스가 정산
+	restarted := newService(t, f.events, f.clock)
+	f.clock.Advance(time.Hour)
+	released, err := restarted.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	again, err := restarted.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	trade, err := restarted.Load(context.Background(), "t1")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, released)
+	assert.Zero(t, again) // 정산한 거래는 일정에서 지워짐
+	assert.Equal(t, StatusSettled, trade.Status())
+	assert.Equal(t, int64(1), f.view(t, f.inventory, "bob").Balances["sword"])
+	assert.Zero(t, f.view(t, f.inventory, "alice").Balances["sword"])
+}
+
+func TestHoldingsProjection_RebuildsFromHoldingsStreams(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	f.grant(t, "alice", Offer{Items: map[string]int64{"sword": 2}, Minerals: map[string]int64{"gold": 10}})
+	f.dispatch(t, NewOpenTradeCommand("t1", "alice", "bob"))
+	f.dispatch(t, NewUpdateOfferCommand("t1", "alice", Offer{Items: map[string]int64{"sword": 1}}))
+
+	// Act: 비어 있는 읽기 모델에 보유량 스트림을 다시 재생
+	rebuilt := NewInventoryProjection(cqrs.NewInMemoryReadStore())
+	view := f.view(t, rebuilt, "alice")
+
+	// Assert
+	assert.Equal(t, int64(2), view.Balances["sword"])
+	assert.Equal(t, int64(1), view.Available("sword", ""))
+	assert.Equal(t, int64(10), f.view(t, f.treasury, "alice").Balances["gold"])
+}
+
+func TestHoldingsGranter_GrantsItemsAndMineralsOnce(t *testing.T) {
+	// Arrange
+	f := newTradeFixture(t)
+	ctx := context.Background()
+
+	// Act: 한 결제에서 같은 지급 ID로 아이템과 광물을 지급하고 재시도
+	for attempt := 0; attempt < 2; attempt++ {
+		require.NoError(t, f.service.Inventory().Grant(ctx, "alice", "purchase-1", map[string]int64{"sword": 1}))
+		require.NoError(t, f.service.Treasury().Grant(ctx, "alice", "purchase-1", map[string]int64{"gold": 50}))
+	}
+	holdings, err := f.service.LoadHoldings(ctx, "alice")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(1), holdings.Balances().Items["sword"])
+	assert.Equal(t, int64(50), holdings.Balances().Minerals["gold"])
+	assert.Equal(t, int64(50), f.view(t, f.treasury, "alice").Balances["gold"])
+}
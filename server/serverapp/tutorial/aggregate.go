@@ -0,0 +1,244 @@
+package tutorial
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// TutorialProgressAggregateType 튜토리얼 진행 애그리게이트 타입 (애그리게이트 ID는 사용자 ID)
+const TutorialProgressAggregateType = "TutorialProgress"
+
+// 튜토리얼 이벤트 타입
+const (
+	TutorialStepCompletedEventType = "TutorialStepCompleted"
+	TutorialSkippedEventType       = "TutorialSkipped"
+)
+
+var (
+	ErrInvalidStep             = errors.New("invalid tutorial step")
+	ErrUnknownStep             = errors.New("unknown tutorial step")
+	ErrPrerequisitesIncomplete = errors.New("tutorial prerequisites are not completed")
+	ErrSkipNotAllowed          = errors.New("skipping the tutorial is not allowed")
+)
+
+// Step 튜토리얼 단계
+type Step struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Requires []string `json:"requires,omitempty"` // 먼저 끝내야 하는 단계 ID (커리큘럼에서 앞에 있어야 함)
+}
+
+// Curriculum 튜토리얼 단계 목록 (클라이언트에 보여 주는 순서)
+// JSON 예: [{"id":"place-tower","title":"타워 설치"},{"id":"start-wave","title":"웨이브 시작","requires":["place-tower"]}]
+type Curriculum []Step
+
+// Validate 커리큘럼 유효성 검사
+// 선행 단계는 앞에 있어야 하므로 순환이 생기지 않습니다
+func (c Curriculum) Validate() error {
+	seen := make(map[string]bool, len(c))
+	for _, step := range c {
+		if strings.TrimSpace(step.ID) == "" {
+			return fmt.Errorf("%w: step id is required", ErrInvalidStep)
+		}
+		if seen[step.ID] {
+			return fmt.Errorf("%w: duplicate step %s", ErrInvalidStep, step.ID)
+		}
+		for _, required := range step.Requires {
+			if !seen[required] {
+				return fmt.Errorf("%w: %s requires %s, which must come earlier", ErrInvalidStep, step.ID, required)
+			}
+		}
+		seen[step.ID] = true
+	}
+	return nil
+}
+
+// Step ID로 단계를 찾습니다
+func (c Curriculum) Step(stepID string) (Step, bool) {
+	for _, step := range c {
+		if step.ID == stepID {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// IDs 단계 ID (커리큘럼 순서)
+func (c Curriculum) IDs() []string {
+	ids := make([]string, 0, len(c))
+	for _, step := range c {
+		ids = append(ids, step.ID)
+	}
+	return ids
+}
+
+// 튜토리얼 이벤트 데이터
+type (
+	TutorialStepCompletedData struct {
+		UserID      string    `json:"user_id"`
+		StepID      string    `json:"step_id"`
+		CompletedAt time.Time `json:"completed_at"`
+	}
+	TutorialSkippedData struct {
+		UserID    string    `json:"user_id"`
+		Steps     []string  `json:"steps"` // 건너뛰어 완료로 처리한 단계
+		SkippedAt time.Time `json:"skipped_at"`
+	}
+)
+
+// TutorialProgressEvent 튜토리얼 진행 애그리게이트 이벤트
+type TutorialProgressEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *TutorialProgressEvent) EventData() interface{} {
+	return e.data
+}
+
+// TutorialProgressAggregate 사용자 한 명의 튜토리얼 진행
+type TutorialProgressAggregate struct {
+	*cqrs.BaseAggregate
+	completed map[string]time.Time
+	skipped   bool
+}
+
+// NewTutorialProgressAggregate 새로운 TutorialProgressAggregate를 생성합니다
+func NewTutorialProgressAggregate(userID string) *TutorialProgressAggregate {
+	return &TutorialProgressAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(userID, TutorialProgressAggregateType),
+		completed:     make(map[string]time.Time),
+	}
+}
+
+// Completed 단계를 끝냈는지 확인합니다
+func (a *TutorialProgressAggregate) Completed(stepID string) bool {
+	_, ok := a.completed[stepID]
+	return ok
+}
+
+// CompletedSteps 끝낸 단계 ID (정렬)
+func (a *TutorialProgressAggregate) CompletedSteps() []string {
+	steps := make([]string, 0, len(a.completed))
+	for stepID := range a.completed {
+		steps = append(steps, stepID)
+	}
+	sort.Strings(steps)
+	return steps
+}
+
+// Skipped 튜토리얼을 건너뛰었는지 확인합니다
+func (a *TutorialProgressAggregate) Skipped() bool {
+	return a.skipped
+}
+
+// Finished 커리큘럼의 모든 단계를 끝냈는지 확인합니다
+func (a *TutorialProgressAggregate) Finished(curriculum Curriculum) bool {
+	return len(a.Missing(curriculum.IDs())) == 0
+}
+
+// Missing stepIDs 중 아직 끝내지 않은 단계 (입력 순서 유지)
+func (a *TutorialProgressAggregate) Missing(stepIDs []string) []string {
+	var missing []string
+	for _, stepID := range stepIDs {
+		if !a.Completed(stepID) {
+			missing = append(missing, stepID)
+		}
+	}
+	return missing
+}
+
+// CompleteStep 단계를 끝냅니다
+// 이미 끝낸 단계면 아무것도 하지 않고 true를 반환합니다 (클라이언트 재전송)
+func (a *TutorialProgressAggregate) CompleteStep(curriculum Curriculum, stepID string, now time.Time) (bool, error) {
+	step, ok := curriculum.Step(stepID)
+	if !ok {
+		return false, fmt.Errorf("%w: %q", ErrUnknownStep, stepID)
+	}
+	if a.Completed(stepID) {
+		return true, nil
+	}
+	if missing := a.Missing(step.Requires); len(missing) > 0 {
+		return false, fmt.Errorf("%w: %s needs %s", ErrPrerequisitesIncomplete, stepID, strings.Join(missing, ", "))
+	}
+	return false, a.raise(TutorialStepCompletedEventType, TutorialStepCompletedData{
+		UserID:      a.ID(),
+		StepID:      stepID,
+		CompletedAt: now,
+	})
+}
+
+// Skip 남은 단계를 모두 끝낸 것으로 처리합니다 (숙련 사용자, 재설치)
+// 남은 단계가 없으면 아무것도 하지 않고 true를 반환합니다
+func (a *TutorialProgressAggregate) Skip(curriculum Curriculum, now time.Time) (bool, error) {
+	remaining := a.Missing(curriculum.IDs())
+	if len(remaining) == 0 {
+		return true, nil
+	}
+	return false, a.raise(TutorialSkippedEventType, TutorialSkippedData{
+		UserID:    a.ID(),
+		Steps:     remaining,
+		SkippedAt: now,
+	})
+}
+
+// LoadFromHistory 이벤트 스트림에서 진행 상태를 복원합니다
+func (a *TutorialProgressAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *TutorialProgressAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	decode, known := tutorialEventDecoders[event.EventType()]
+	if !known {
+		return fmt.Errorf("unknown tutorial event type %q", event.EventType())
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *TutorialProgressAggregate) raise(eventType string, data interface{}) error {
+	event := &TutorialProgressEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: data}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(data)
+	return nil
+}
+
+func (a *TutorialProgressAggregate) when(data interface{}) {
+	switch data := data.(type) {
+	case TutorialStepCompletedData:
+		a.completed[data.StepID] = data.CompletedAt
+	case TutorialSkippedData:
+		for _, stepID := range data.Steps {
+			a.completed[stepID] = data.SkippedAt
+		}
+		a.skipped = true
+	}
+}
+
+// tutorialEventDecoders 저장소에서 읽은 이벤트 데이터를 이벤트 타입별 값 타입으로 되돌립니다
+var tutorialEventDecoders = map[string]func(data interface{}) (interface{}, error){
+	TutorialStepCompletedEventType: eventstore.DecodeEventData[TutorialStepCompletedData],
+	TutorialSkippedEventType:       eventstore.DecodeEventData[TutorialSkippedData],
+}
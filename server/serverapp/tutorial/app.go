@@ -0,0 +1,212 @@
+package tutorial
+
+import (
+	"cqrs"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"defense-allies-server/serverapp"
+)
+
+// DefaultBasePath 튜토리얼 기본 경로
+const DefaultBasePath = "/tutorial"
+
+// maxRequestBodySize 요청 본문 최대 크기
+const maxRequestBodySize = 4 << 10
+
+// Config 튜토리얼 서버앱 설정
+type Config struct {
+	BasePath string                          // 라우트 기본 경로 (기본값: /tutorial)
+	Service  ServiceConfig                   // 튜토리얼 서비스 설정
+	Progress *ProgressProjection             // 선택: 설정하면 진행 조회 라우트 제공 (Service.EventBus를 구독시켜야 함)
+	Auth     func(http.Handler) http.Handler // 선택: 사용자 인증 미들웨어
+	Identify func(r *http.Request) string    // 필수: 진행하는 사용자 식별
+}
+
+// Validate 설정 유효성 검사
+func (c *Config) Validate() error {
+	if c.Identify == nil {
+		return errors.New("identify is required to bind tutorial progress to users")
+	}
+	if len(c.Service.Curriculum) == 0 {
+		return errors.New("tutorial curriculum is empty")
+	}
+	return c.Service.Curriculum.Validate()
+}
+
+// TutorialApp 튜토리얼 단계 완료, 건너뛰기, 진행 조회를 제공하는 서버앱
+// 다른 명령을 튜토리얼 뒤로 잠그려면 Service()로 NewGatingDispatcher나 Middleware를 씁니다
+type TutorialApp struct {
+	*serverapp.BaseApp
+	config  Config
+	service *Service
+}
+
+// NewTutorialApp 새로운 TutorialApp을 생성합니다
+func NewTutorialApp(config Config) (*TutorialApp, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BasePath == "" {
+		config.BasePath = DefaultBasePath
+	}
+	config.BasePath = strings.TrimSuffix(config.BasePath, "/")
+	return &TutorialApp{
+		BaseApp: serverapp.NewBaseApp("tutorial"),
+		config:  config,
+		service: NewService(config.Service),
+	}, nil
+}
+
+// Service 튜토리얼 서비스
+func (a *TutorialApp) Service() *Service {
+	return a.service
+}
+
+// RegisterRoutes HTTP Mux에 라우트를 등록합니다
+func (a *TutorialApp) RegisterRoutes(mux *http.ServeMux) {
+	base := a.config.BasePath
+	protect := a.config.Auth
+	if protect == nil {
+		protect = func(next http.Handler) http.Handler { return next }
+	}
+
+	mux.Handle(base+"/steps/complete", protect(http.HandlerFunc(a.complete)))
+	if a.config.Service.AllowSkip {
+		mux.Handle(base+"/skip", protect(http.HandlerFunc(a.skip)))
+	}
+	if a.config.Progress != nil {
+		mux.Handle(base+"/progress", protect(http.HandlerFunc(a.progress)))
+	}
+
+	log.Printf("[Tutorial] Routes registered under %s", base)
+}
+
+// DescribeAPI 튜토리얼 엔드포인트 설명 (/openapi.json)
+func (a *TutorialApp) DescribeAPI() []serverapp.APIOperation {
+	base := a.config.BasePath
+	secured := a.config.Auth != nil
+	operations := []serverapp.APIOperation{
+		{
+			Method:      http.MethodPost,
+			Path:        base + "/steps/complete",
+			Summary:     "튜토리얼 단계 완료",
+			Description: "선행 단계가 남아 있으면 412를 반환합니다. 이미 끝낸 단계는 duplicate가 true로 돌아옵니다.",
+			Request:     CompleteStepData{},
+			Response:    StepResult{},
+			Secured:     secured,
+		},
+	}
+	if a.config.Service.AllowSkip {
+		operations = append(operations, serverapp.APIOperation{
+			Method:   http.MethodPost,
+			Path:     base + "/skip",
+			Summary:  "튜토리얼 건너뛰기",
+			Response: StepResult{},
+			Secured:  secured,
+		})
+	}
+	if a.config.Progress != nil {
+		operations = append(operations, serverapp.APIOperation{
+			Method:      http.MethodGet,
+			Path:        base + "/progress",
+			Summary:     "튜토리얼 진행",
+			Description: "클라이언트는 next 단계부터 온보딩을 이어 갑니다.",
+			Response:    Progress{},
+			Secured:     secured,
+		})
+	}
+	return operations
+}
+
+func (a *TutorialApp) complete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var data CompleteStepData
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&data); err != nil || data.Step == "" {
+		sendError(w, http.StatusBadRequest, "step is required")
+		return
+	}
+	a.handle(w, r, NewCompleteStepCommand(a.config.Identify(r), data.Step))
+}
+
+func (a *TutorialApp) skip(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	a.handle(w, r, NewSkipTutorialCommand(a.config.Identify(r)))
+}
+
+func (a *TutorialApp) handle(w http.ResponseWriter, r *http.Request, command cqrs.Command) {
+	if command.ID() == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	result, err := a.service.Handle(r.Context(), command)
+	if err != nil {
+		log.Printf("[Tutorial] Failed to handle %s for %s: %v", command.CommandType(), command.ID(), err)
+		sendError(w, http.StatusInternalServerError, "tutorial progress could not be saved, retry later")
+		return
+	}
+	if !result.Success {
+		sendError(w, statusForError(result.Error), result.Error.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, result.Data)
+}
+
+func (a *TutorialApp) progress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID := a.config.Identify(r)
+	if userID == "" {
+		sendError(w, http.StatusUnauthorized, "user is required")
+		return
+	}
+	view, err := a.config.Progress.View(r.Context(), userID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sendJSON(w, http.StatusOK, BuildProgress(view, a.service.Curriculum()))
+}
+
+// statusForError 에러를 HTTP 상태 코드로 변환합니다
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrPrerequisitesIncomplete), errors.Is(err, ErrTutorialIncomplete):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrUnknownStep):
+		return http.StatusNotFound
+	case errors.Is(err, ErrSkipNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidStep):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sendJSON JSON 응답 전송
+func sendJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// sendError 에러 응답 전송
+func sendError(w http.ResponseWriter, statusCode int, message string) {
+	sendJSON(w, statusCode, map[string]interface{}{
+		"error":   message,
+		"status":  statusCode,
+		"success": false,
+	})
+}
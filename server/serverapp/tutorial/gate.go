@@ -0,0 +1,120 @@
+package tutorial
+
+import (
+	"context"
+	"cqrs"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrTutorialIncomplete 튜토리얼 선행 단계를 끝내지 않은 사용자의 요청 (errors.Is로 확인)
+var ErrTutorialIncomplete = errors.New("tutorial is not completed")
+
+// LockedError 튜토리얼 선행 단계가 남아 요청을 거부한 이유
+type LockedError struct {
+	UserID      string   `json:"user_id"`
+	CommandType string   `json:"command_type,omitempty"` // HTTP 미들웨어에서 거부하면 비어 있음
+	Missing     []string `json:"missing"`                // 아직 끝내지 않은 선행 단계
+}
+
+func (e *LockedError) Error() string {
+	if e.CommandType == "" {
+		return fmt.Sprintf("user %s must complete tutorial steps %s first", e.UserID, strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("user %s must complete tutorial steps %s before %s", e.UserID, strings.Join(e.Missing, ", "), e.CommandType)
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == ErrTutorialIncomplete
+}
+
+// Gates 명령 타입별로 먼저 끝내야 하는 튜토리얼 단계
+// JSON 예: {"JoinMatch":["place-tower","start-wave"],"CreateGuild":["finish"]}
+type Gates map[string][]string
+
+// Validate 모든 단계가 커리큘럼에 있는지 확인합니다
+func (g Gates) Validate(curriculum Curriculum) error {
+	for commandType, steps := range g {
+		for _, stepID := range steps {
+			if _, ok := curriculum.Step(stepID); !ok {
+				return fmt.Errorf("%w: %s is gated on %q", ErrUnknownStep, commandType, stepID)
+			}
+		}
+	}
+	return nil
+}
+
+// Require 사용자가 steps를 모두 끝내지 않았으면 *LockedError를 반환합니다
+// 사용자를 모르는 요청(시스템 명령)은 확인하지 않습니다
+func (s *Service) Require(ctx context.Context, userID string, steps ...string) error {
+	if userID == "" || len(steps) == 0 {
+		return nil
+	}
+	aggregate, err := s.load(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if missing := aggregate.Missing(steps); len(missing) > 0 {
+		return &LockedError{UserID: userID, Missing: missing}
+	}
+	return nil
+}
+
+// GatingDispatcher 튜토리얼 선행 단계를 끝내지 않은 사용자가 보낸 명령을 거부하는 디스패처
+// 명령의 UserID를 명령을 보낸 사용자로 보며, 거부는 CommandResult.Error에 *LockedError로 담깁니다
+type GatingDispatcher struct {
+	cqrs.CommandDispatcher
+	service *Service
+	gates   Gates
+}
+
+// NewGatingDispatcher dispatcher를 감쌉니다 (gates에 없는 명령 타입은 확인하지 않음)
+func NewGatingDispatcher(dispatcher cqrs.CommandDispatcher, service *Service, gates Gates) (*GatingDispatcher, error) {
+	if err := gates.Validate(service.Curriculum()); err != nil {
+		return nil, err
+	}
+	return &GatingDispatcher{CommandDispatcher: dispatcher, service: service, gates: gates}, nil
+}
+
+func (d *GatingDispatcher) Dispatch(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	if command != nil {
+		if steps := d.gates[command.CommandType()]; len(steps) > 0 {
+			err := d.service.Require(ctx, command.UserID(), steps...)
+			var locked *LockedError
+			if errors.As(err, &locked) {
+				locked.CommandType = command.CommandType()
+				return cqrs.NewFailedCommandResult(locked), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return d.CommandDispatcher.Dispatch(ctx, command)
+}
+
+// Middleware 튜토리얼 steps를 끝내지 않은 사용자의 요청을 412로 거부하는 HTTP 미들웨어
+// identify가 빈 문자열을 반환하면 (미인증 요청) 그대로 통과시킵니다
+func (s *Service) Middleware(identify func(r *http.Request) string, steps ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := s.Require(r.Context(), identify(r), steps...)
+			var locked *LockedError
+			switch {
+			case err == nil:
+				next.ServeHTTP(w, r)
+			case errors.As(err, &locked):
+				sendJSON(w, http.StatusPreconditionFailed, map[string]interface{}{
+					"error":   locked.Error(),
+					"status":  http.StatusPreconditionFailed,
+					"success": false,
+					"missing": locked.Missing,
+				})
+			default:
+				sendError(w, http.StatusInternalServerError, err.Error())
+			}
+		})
+	}
+}
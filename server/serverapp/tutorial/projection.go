@@ -0,0 +1,179 @@
+package tutorial
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ProgressViewType 튜토리얼 진행 읽기 모델 타입 (읽기 모델 ID는 사용자 ID)
+const ProgressViewType = "TutorialProgress"
+
+// 단계 상태
+const (
+	StepCompleted = "completed" // 끝냄
+	StepAvailable = "available" // 선행 단계를 모두 끝내 지금 진행 가능
+	StepLocked    = "locked"    // 선행 단계가 남음
+)
+
+// ProgressView 사용자 한 명의 튜토리얼 진행
+type ProgressView struct {
+	*cqrs.BaseReadModel
+	UserID    string               `json:"user_id"`
+	Completed map[string]time.Time `json:"completed"` // 단계 ID -> 끝낸 시각
+	Skipped   bool                 `json:"skipped"`
+}
+
+// NewProgressView 새로운 ProgressView를 생성합니다
+func NewProgressView(userID string) *ProgressView {
+	return &ProgressView{
+		BaseReadModel: cqrs.NewBaseReadModel(userID, ProgressViewType, map[string]interface{}{}),
+		UserID:        userID,
+		Completed:     make(map[string]time.Time),
+	}
+}
+
+// GetData 읽기 모델 데이터
+func (v *ProgressView) GetData() interface{} {
+	return map[string]interface{}{
+		"user_id":   v.UserID,
+		"completed": len(v.Completed),
+		"skipped":   v.Skipped,
+	}
+}
+
+// ProgressProjection 튜토리얼 이벤트로 진행 읽기 모델을 갱신합니다
+// 단계별 완료 시각을 덮어쓰므로 같은 이벤트를 다시 받아도 바뀌지 않습니다
+type ProgressProjection struct {
+	*cqrs.BaseEventHandler
+	store cqrs.ReadStore
+
+	mu sync.Mutex // 같은 사용자 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewProgressProjection 새로운 ProgressProjection을 생성합니다
+func NewProgressProjection(store cqrs.ReadStore) *ProgressProjection {
+	return &ProgressProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler("tutorial-progress", cqrs.ProjectionHandler, []string{
+			TutorialStepCompletedEventType,
+			TutorialSkippedEventType,
+		}),
+		store: store,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *ProgressProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// View 사용자의 튜토리얼 진행 (없으면 빈 읽기 모델)
+func (p *ProgressProjection) View(ctx context.Context, userID string) (*ProgressView, error) {
+	view, err := cqrs.LoadReadModel[*ProgressView](ctx, p.store, userID, ProgressViewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewProgressView(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tutorial progress of %s: %w", userID, err)
+	}
+	return view, nil
+}
+
+// Handle 튜토리얼 이벤트를 적용합니다
+func (p *ProgressProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	decode, known := tutorialEventDecoders[event.EventType()]
+	if !known {
+		return nil
+	}
+	data, err := decode(event.EventData())
+	if err != nil {
+		return err
+	}
+	userID := event.AggregateID()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return cqrs.UpsertReadModel(ctx, p.store, userID, ProgressViewType,
+		func() *ProgressView { return NewProgressView(userID) },
+		func(view *ProgressView) error {
+			if view.Completed == nil {
+				view.Completed = make(map[string]time.Time)
+			}
+			switch data := data.(type) {
+			case TutorialStepCompletedData:
+				view.Completed[data.StepID] = data.CompletedAt
+			case TutorialSkippedData:
+				for _, stepID := range data.Steps {
+					view.Completed[stepID] = data.SkippedAt
+				}
+				view.Skipped = true
+			}
+			view.IncrementVersion()
+			return nil
+		})
+}
+
+// StepStatus 클라이언트에 보여 줄 단계 하나
+type StepStatus struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	Missing     []string  `json:"missing,omitempty"` // 잠긴 단계의 남은 선행 단계
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+}
+
+// Progress 클라이언트가 온보딩을 이어 가기 위한 응답
+type Progress struct {
+	UserID    string       `json:"user_id"`
+	Steps     []StepStatus `json:"steps"`          // 커리큘럼 순서
+	Next      string       `json:"next,omitempty"` // 이어서 진행할 단계 (진행 가능한 첫 단계)
+	Completed int          `json:"completed"`
+	Total     int          `json:"total"`
+	Finished  bool         `json:"finished"`
+	Skipped   bool         `json:"skipped"`
+}
+
+// BuildProgress 진행 읽기 모델과 커리큘럼으로 단계별 상태를 만듭니다
+// 커리큘럼에서 빠진 단계의 기록은 무시하고, 새로 추가된 단계는 아직 끝내지 않은 것으로 봅니다
+func BuildProgress(view *ProgressView, curriculum Curriculum) *Progress {
+	progress := &Progress{
+		UserID:  view.UserID,
+		Steps:   make([]StepStatus, 0, len(curriculum)),
+		Total:   len(curriculum),
+		Skipped: view.Skipped,
+	}
+	for _, step := range curriculum {
+		status := StepStatus{ID: step.ID, Title: step.Title}
+		if completedAt, ok := view.Completed[step.ID]; ok {
+			status.Status, status.CompletedAt = StepCompleted, completedAt
+			progress.Completed++
+			progress.Steps = append(progress.Steps, status)
+			continue
+		}
+		for _, required := range step.Requires {
+			if _, ok := view.Completed[required]; !ok {
+				status.Missing = append(status.Missing, required)
+			}
+		}
+		status.Status = StepLocked
+		if len(status.Missing) == 0 {
+			status.Status = StepAvailable
+			if progress.Next == "" {
+				progress.Next = step.ID
+			}
+		}
+		progress.Steps = append(progress.Steps, status)
+	}
+	progress.Finished = progress.Completed == progress.Total
+	return progress
+}
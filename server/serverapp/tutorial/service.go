@@ -0,0 +1,179 @@
+package tutorial
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 튜토리얼 명령 타입 (명령의 ID는 사용자 ID)
+const (
+	CompleteTutorialStepCommandType = "CompleteTutorialStep"
+	SkipTutorialCommandType         = "SkipTutorial"
+)
+
+// CompleteStepData 단계 완료 명령 데이터
+type CompleteStepData struct {
+	Step string `json:"step"`
+}
+
+// NewCompleteStepCommand 단계 완료 명령
+func NewCompleteStepCommand(userID, stepID string) cqrs.Command {
+	return newTutorialCommand(CompleteTutorialStepCommandType, userID, CompleteStepData{Step: stepID})
+}
+
+// NewSkipTutorialCommand 튜토리얼 건너뛰기 명령
+func NewSkipTutorialCommand(userID string) cqrs.Command {
+	return newTutorialCommand(SkipTutorialCommandType, userID, nil)
+}
+
+func newTutorialCommand(commandType, userID string, data interface{}) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, userID, TutorialProgressAggregateType, data)
+	command.SetUserID(userID)
+	return command
+}
+
+// StepResult 단계 완료, 건너뛰기 결과 (CommandResult.Data)
+type StepResult struct {
+	Completed []string `json:"completed"`           // 끝낸 단계 전체
+	Finished  bool     `json:"finished"`            // 모든 단계를 끝냄
+	Duplicate bool     `json:"duplicate,omitempty"` // 이미 끝낸 단계
+}
+
+// ServiceConfig 튜토리얼 서비스 설정
+type ServiceConfig struct {
+	Store      eventstore.EventStore // 튜토리얼 이벤트 저장소 (기본값: 메모리)
+	EventBus   cqrs.EventBus         // 선택: 튜토리얼 이벤트 발행 (진행 프로젝션이 구독)
+	Curriculum Curriculum            // 튜토리얼 단계
+	AllowSkip  bool                  // 건너뛰기 허용 (건너뛰면 선행 조건이 모두 풀림)
+	Now        func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 튜토리얼 단계 완료, 건너뛰기 명령을 처리하는 명령 핸들러
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu sync.Mutex // 진행 변경 직렬화
+}
+
+// NewService 새로운 Service를 생성합니다
+func NewService(config ServiceConfig) *Service {
+	if config.Store == nil {
+		config.Store = eventstore.NewInMemoryEventStore()
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("tutorial", []string{CompleteTutorialStepCommandType, SkipTutorialCommandType}),
+		config:             config,
+	}
+}
+
+// RegisterWith 튜토리얼 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Curriculum 튜토리얼 단계
+func (s *Service) Curriculum() Curriculum {
+	return s.config.Curriculum
+}
+
+// Load 튜토리얼 진행을 불러옵니다
+func (s *Service) Load(ctx context.Context, userID string) (*TutorialProgressAggregate, error) {
+	return s.load(ctx, userID)
+}
+
+// Handle 튜토리얼 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	userID := command.ID()
+	if userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: user ID is required", ErrInvalidStep)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicate bool
+	switch command.CommandType() {
+	case CompleteTutorialStepCommandType:
+		data, err := eventstore.DecodeCommandData[CompleteStepData](command.GetData(), ErrInvalidStep)
+		if err != nil {
+			return cqrs.NewFailedCommandResult(err), nil
+		}
+		duplicate, err = aggregate.CompleteStep(s.config.Curriculum, data.Step, s.config.Now())
+		if err != nil {
+			return cqrs.NewFailedCommandResult(err), nil
+		}
+	case SkipTutorialCommandType:
+		if !s.config.AllowSkip {
+			return cqrs.NewFailedCommandResult(ErrSkipNotAllowed), nil
+		}
+		duplicate, err = aggregate.Skip(s.config.Curriculum, s.config.Now())
+		if err != nil {
+			return cqrs.NewFailedCommandResult(err), nil
+		}
+	default:
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: unsupported command type %q", ErrInvalidStep, command.CommandType())), nil
+	}
+
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	return cqrs.NewCommandResult(aggregate.ID(), aggregate.Version(), events...).WithData(StepResult{
+		Completed: aggregate.CompletedSteps(),
+		Finished:  aggregate.Finished(s.config.Curriculum),
+		Duplicate: duplicate,
+	}), nil
+}
+
+func (s *Service) load(ctx context.Context, userID string) (*TutorialProgressAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, userID, TutorialProgressAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tutorial progress of %s: %w", userID, err)
+	}
+	aggregate := NewTutorialProgressAggregate(userID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *TutorialProgressAggregate) error {
+	events := aggregate.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save tutorial progress of %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Tutorial] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
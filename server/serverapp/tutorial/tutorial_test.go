@@ -0,0 +1,161 @@
+package tutorial
+
+import (
+	"context"
+	"cqrs"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+// testCurriculum 타워 설치 → 웨이브 시작 → (업그레이드, 상점) → 졸업
+var testCurriculum = Curriculum{
+	{ID: "place-tower", Title: "타워 설치"},
+	{ID: "start-wave", Title: "웨이브 시작", Requires: []string{"place-tower"}},
+	{ID: "upgrade-tower", Title: "타워 업그레이드", Requires: []string{"start-wave"}},
+	{ID: "visit-shop", Title: "상점 방문", Requires: []string{"start-wave"}},
+	{ID: "graduate", Title: "졸업", Requires: []string{"upgrade-tower", "visit-shop"}},
+}
+
+// countingHandler 처리한 명령 수를 세는 핸들러
+type countingHandler struct {
+	*cqrs.BaseCommandHandler
+	count *int
+}
+
+func (h *countingHandler) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	*h.count++
+	return cqrs.NewCommandResult(command.ID(), 1), nil
+}
+
+func TestTutorialProgressAggregate_Prerequisites(t *testing.T) {
+	aggregate := NewTutorialProgressAggregate("alice")
+
+	assert.ErrorIs(t, Curriculum{{ID: "b", Requires: []string{"a"}}, {ID: "a"}}.Validate(), ErrInvalidStep)
+	assert.ErrorIs(t, Curriculum{{ID: "a"}, {ID: "a"}}.Validate(), ErrInvalidStep)
+	require.NoError(t, testCurriculum.Validate())
+
+	_, err := aggregate.CompleteStep(testCurriculum, "start-wave", testNow)
+	assert.ErrorIs(t, err, ErrPrerequisitesIncomplete)
+	_, err = aggregate.CompleteStep(testCurriculum, "unknown", testNow)
+	assert.ErrorIs(t, err, ErrUnknownStep)
+
+	duplicate, err := aggregate.CompleteStep(testCurriculum, "place-tower", testNow)
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+	duplicate, err = aggregate.CompleteStep(testCurriculum, "place-tower", testNow)
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+	assert.Len(t, aggregate.Changes(), 1)
+
+	// 건너뛰면 남은 단계가 모두 끝난 것으로 처리되고 이벤트 스트림에서 복원됨
+	_, err = aggregate.Skip(testCurriculum, testNow)
+	require.NoError(t, err)
+	assert.True(t, aggregate.Finished(testCurriculum))
+	restored := NewTutorialProgressAggregate("alice")
+	require.NoError(t, restored.LoadFromHistory(aggregate.Changes()))
+	assert.True(t, restored.Skipped())
+	assert.Equal(t, aggregate.CompletedSteps(), restored.CompletedSteps())
+}
+
+func TestGatingDispatcher_RejectsUntilPrerequisitesComplete(t *testing.T) {
+	// Arrange
+	service := NewService(ServiceConfig{Curriculum: testCurriculum, Now: func() time.Time { return testNow }})
+	inner := cqrs.NewInMemoryCommandDispatcher()
+	require.NoError(t, service.RegisterWith(inner))
+	handled := 0
+	require.NoError(t, inner.RegisterHandler("JoinMatch", &countingHandler{BaseCommandHandler: cqrs.NewBaseCommandHandler("match", []string{"JoinMatch"}), count: &handled}))
+	_, err := NewGatingDispatcher(inner, service, Gates{"JoinMatch": {"missing-step"}})
+	assert.ErrorIs(t, err, ErrUnknownStep)
+	dispatcher, err := NewGatingDispatcher(inner, service, Gates{"JoinMatch": {"place-tower", "start-wave"}})
+	require.NoError(t, err)
+	ctx := context.Background()
+	joinMatch := func(userID string) *cqrs.CommandResult {
+		command := cqrs.NewBaseCommand("JoinMatch", "match-1", "Match", nil)
+		command.SetUserID(userID)
+		result, err := dispatcher.Dispatch(ctx, command)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Act
+	locked := joinMatch("bob")
+	for _, stepID := range []string{"place-tower", "start-wave"} {
+		result, err := dispatcher.Dispatch(ctx, NewCompleteStepCommand("bob", stepID))
+		require.NoError(t, err)
+		require.True(t, result.Success, "%v", result.Error)
+	}
+	unlocked := joinMatch("bob")
+
+	// Assert
+	assert.False(t, locked.Success)
+	assert.ErrorIs(t, locked.Error, ErrTutorialIncomplete)
+	var lockedErr *LockedError
+	require.ErrorAs(t, locked.Error, &lockedErr)
+	assert.Equal(t, "JoinMatch", lockedErr.CommandType)
+	assert.Equal(t, []string{"place-tower", "start-wave"}, lockedErr.Missing)
+	assert.True(t, unlocked.Success)
+	assert.Equal(t, 1, handled)
+}
+
+func TestTutorialApp_ResumeProgress(t *testing.T) {
+	// Arrange
+	bus := testkit.StartedEventBus(t)
+	projection := NewProgressProjection(cqrs.NewInMemoryReadStore())
+	_, err := projection.Subscribe(bus)
+	require.NoError(t, err)
+	identify := func(r *http.Request) string { return r.Header.Get("X-User") }
+	app, err := NewTutorialApp(Config{
+		Service:  ServiceConfig{EventBus: bus, Curriculum: testCurriculum, Now: func() time.Time { return testNow }},
+		Progress: projection,
+		Identify: identify,
+	})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	app.RegisterRoutes(mux)
+	mux.Handle("/shop", app.Service().Middleware(identify, "visit-shop")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	))
+	request := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-User", "carol")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Act
+	blocked := request(http.MethodPost, "/tutorial/steps/complete", `{"step": "start-wave"}`)
+	request(http.MethodPost, "/tutorial/steps/complete", `{"step": "place-tower"}`)
+	request(http.MethodPost, "/tutorial/steps/complete", `{"step": "start-wave"}`)
+	shopLocked := request(http.MethodGet, "/shop", "")
+	request(http.MethodPost, "/tutorial/steps/complete", `{"step": "visit-shop"}`)
+	shopOpen := request(http.MethodGet, "/shop", "")
+	skip := request(http.MethodPost, "/tutorial/skip", "")
+	progressResponse := request(http.MethodGet, "/tutorial/progress", "")
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, blocked.Code)
+	assert.Equal(t, http.StatusPreconditionFailed, shopLocked.Code)
+	assert.Equal(t, http.StatusNoContent, shopOpen.Code)
+	assert.Equal(t, http.StatusNotFound, skip.Code)
+	require.Equal(t, http.StatusOK, progressResponse.Code, progressResponse.Body.String())
+	var progress Progress
+	require.NoError(t, json.Unmarshal(progressResponse.Body.Bytes(), &progress))
+	assert.Equal(t, 3, progress.Completed)
+	assert.Equal(t, 5, progress.Total)
+	assert.Equal(t, "upgrade-tower", progress.Next)
+	assert.Equal(t, StepLocked, progress.Steps[4].Status)
+	assert.Equal(t, []string{"upgrade-tower"}, progress.Steps[4].Missing)
+	assert.False(t, progress.Finished)
+}
@@ -0,0 +1,222 @@
+package wallet
+
+import (
+	"cqrs"
+	"errors"
+	"fmt"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// WalletAggregateType 지갑 애그리게이트 타입 (애그리게이트 ID는 사용자 ID)
+const WalletAggregateType = "Wallet"
+
+// 지갑 이벤트 타입 (둘 다 LedgerEntry 데이터)
+const (
+	WalletCreditedEventType = "WalletCredited"
+	WalletDebitedEventType  = "WalletDebited"
+)
+
+// 시스템 원장 계정 (지갑과 반대편에 기록되는 계정)
+const (
+	AccountPurchases   = "system:purchases"   // 인앱 결제로 산 재화
+	AccountRewards     = "system:rewards"     // 이벤트, 보상으로 준 재화
+	AccountSpending    = "system:spending"    // 상점 등에서 쓴 재화
+	AccountAdjustments = "system:adjustments" // 운영자 보정, 환불 회수
+)
+
+var (
+	ErrInvalidEntry      = errors.New("invalid ledger entry")
+	ErrUnknownAccount    = errors.New("unknown ledger account")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrEntryConflict     = errors.New("ledger entry ID was already used for a different entry")
+	ErrStoreRequired     = eventstore.ErrStoreRequired
+)
+
+// systemAccounts 지갑과 거래할 수 있는 시스템 계정
+var systemAccounts = map[string]bool{
+	AccountPurchases:   true,
+	AccountRewards:     true,
+	AccountSpending:    true,
+	AccountAdjustments: true,
+}
+
+// UserAccount 사용자 지갑의 원장 계정
+func UserAccount(userID string) string {
+	return "wallet:" + userID
+}
+
+// Posting 원장 분개 한 줄 (Debit과 Credit 중 하나만 0이 아님)
+// 지갑 잔액은 사용자에게 진 부채이므로 Credit이 잔액을 늘리고 Debit이 줄입니다
+type Posting struct {
+	Account string `json:"account"`
+	Debit   int64  `json:"debit,omitempty"`
+	Credit  int64  `json:"credit,omitempty"`
+}
+
+// LedgerEntry 복식부기 원장 항목 (WalletCredited, WalletDebited 이벤트 데이터)
+// Postings의 Debit 합과 Credit 합은 항상 같습니다
+type LedgerEntry struct {
+	EntryID      string    `json:"entry_id"` // 멱등 키 (같은 ID는 한 번만 기록)
+	UserID       string    `json:"user_id"`
+	Amount       int64     `json:"amount"`
+	Counterparty string    `json:"counterparty"` // 반대편 시스템 계정
+	Reason       string    `json:"reason,omitempty"`
+	Reference    string    `json:"reference,omitempty"` // 결제 ID, 상품 ID 등 원인
+	Postings     []Posting `json:"postings"`
+	BalanceAfter int64     `json:"balance_after"`
+	RecordedAt   time.Time `json:"recorded_at"`
+}
+
+// Balanced 분개의 Debit 합과 Credit 합이 같은지 확인합니다
+func (e LedgerEntry) Balanced() bool {
+	var debits, credits int64
+	for _, posting := range e.Postings {
+		debits += posting.Debit
+		credits += posting.Credit
+	}
+	return debits == credits && debits == e.Amount
+}
+
+// LedgerRequest 적립, 차감 요청
+type LedgerRequest struct {
+	EntryID   string `json:"entry_id"`
+	Amount    int64  `json:"amount"`
+	Account   string `json:"account"` // 반대편 시스템 계정
+	Reason    string `json:"reason,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
+
+// WalletEvent 지갑 애그리게이트 이벤트
+type WalletEvent struct {
+	*cqrs.BaseEventMessage
+	data interface{}
+}
+
+func (e *WalletEvent) EventData() interface{} {
+	return e.data
+}
+
+// recordedEntry 이미 기록한 항목 (같은 ID 재요청 비교용)
+type recordedEntry struct {
+	eventType string
+	amount    int64
+	account   string
+}
+
+// WalletAggregate 사용자 한 명의 프리미엄 재화 지갑
+// 모든 변경은 지갑 계정과 시스템 계정 양쪽에 분개하는 원장 항목으로 기록됩니다
+type WalletAggregate struct {
+	*cqrs.BaseAggregate
+	balance int64
+	entries map[string]recordedEntry
+}
+
+// NewWalletAggregate 새로운 WalletAggregate를 생성합니다
+func NewWalletAggregate(userID string) *WalletAggregate {
+	return &WalletAggregate{
+		BaseAggregate: cqrs.NewBaseAggregate(userID, WalletAggregateType),
+		entries:       make(map[string]recordedEntry),
+	}
+}
+
+// Balance 현재 잔액
+func (a *WalletAggregate) Balance() int64 {
+	return a.balance
+}
+
+// Credit 시스템 계정에서 지갑으로 재화를 적립합니다
+// 같은 항목 ID로 같은 요청을 다시 보내면 아무것도 하지 않고 true를 반환합니다
+func (a *WalletAggregate) Credit(request LedgerRequest, now time.Time) (bool, error) {
+	return a.record(WalletCreditedEventType, request, now)
+}
+
+// Debit 지갑에서 시스템 계정으로 재화를 차감합니다 (잔액이 모자라면 ErrInsufficientFunds)
+func (a *WalletAggregate) Debit(request LedgerRequest, now time.Time) (bool, error) {
+	return a.record(WalletDebitedEventType, request, now)
+}
+
+func (a *WalletAggregate) record(eventType string, request LedgerRequest, now time.Time) (bool, error) {
+	if request.EntryID == "" || request.Amount <= 0 {
+		return false, fmt.Errorf("%w: entry ID and a positive amount are required", ErrInvalidEntry)
+	}
+	if !systemAccounts[request.Account] {
+		return false, fmt.Errorf("%w: %q", ErrUnknownAccount, request.Account)
+	}
+	if recorded, exists := a.entries[request.EntryID]; exists {
+		if recorded != (recordedEntry{eventType: eventType, amount: request.Amount, account: request.Account}) {
+			return false, fmt.Errorf("%w: %s", ErrEntryConflict, request.EntryID)
+		}
+		return true, nil
+	}
+
+	wallet := UserAccount(a.ID())
+	entry := LedgerEntry{
+		EntryID:      request.EntryID,
+		UserID:       a.ID(),
+		Amount:       request.Amount,
+		Counterparty: request.Account,
+		Reason:       request.Reason,
+		Reference:    request.Reference,
+		RecordedAt:   now,
+	}
+	if eventType == WalletCreditedEventType {
+		entry.BalanceAfter = a.balance + request.Amount
+		entry.Postings = []Posting{
+			{Account: request.Account, Debit: request.Amount},
+			{Account: wallet, Credit: request.Amount},
+		}
+	} else {
+		if a.balance < request.Amount {
+			return false, fmt.Errorf("%w: balance %d, requested %d", ErrInsufficientFunds, a.balance, request.Amount)
+		}
+		entry.BalanceAfter = a.balance - request.Amount
+		entry.Postings = []Posting{
+			{Account: wallet, Debit: request.Amount},
+			{Account: request.Account, Credit: request.Amount},
+		}
+	}
+	return false, a.raise(eventType, entry)
+}
+
+// LoadFromHistory 이벤트 스트림에서 지갑 상태를 복원합니다
+func (a *WalletAggregate) LoadFromHistory(events []cqrs.EventMessage) error {
+	for _, event := range events {
+		if err := a.ReplayEvent(event); err != nil {
+			return err
+		}
+	}
+	a.SetOriginalVersion(a.Version())
+	return nil
+}
+
+// ReplayEvent 버전을 맞추고 상태를 적용합니다
+func (a *WalletAggregate) ReplayEvent(event cqrs.EventMessage) error {
+	if event.EventType() != WalletCreditedEventType && event.EventType() != WalletDebitedEventType {
+		return fmt.Errorf("unknown wallet event type %q", event.EventType())
+	}
+	data, err := eventstore.DecodeEventData[LedgerEntry](event.EventData())
+	if err != nil {
+		return err
+	}
+	if err := a.BaseAggregate.ReplayEvent(event); err != nil {
+		return err
+	}
+	a.when(event.EventType(), data.(LedgerEntry))
+	return nil
+}
+
+func (a *WalletAggregate) raise(eventType string, entry LedgerEntry) error {
+	event := &WalletEvent{BaseEventMessage: cqrs.NewBaseEventMessage(eventType), data: entry}
+	if err := a.ApplyEvent(event); err != nil {
+		return err
+	}
+	a.when(eventType, entry)
+	return nil
+}
+
+func (a *WalletAggregate) when(eventType string, entry LedgerEntry) {
+	a.balance = entry.BalanceAfter
+	a.entries[entry.EntryID] = recordedEntry{eventType: eventType, amount: entry.Amount, account: entry.Counterparty}
+}
@@ -0,0 +1,199 @@
+package wallet
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 지갑 읽기 모델 타입
+const (
+	BalanceViewType = "WalletBalance"       // 읽기 모델 ID는 사용자 ID
+	AccountViewType = "WalletLedgerAccount" // 읽기 모델 ID는 시스템 계정 (AccountPurchases 등)
+)
+
+// BalanceView 사용자 한 명의 지갑 잔액
+type BalanceView struct {
+	*cqrs.BaseReadModel
+	UserID      string    `json:"user_id"`
+	Balance     int64     `json:"balance"`
+	Credited    int64     `json:"credited"` // 누적 적립
+	Debited     int64     `json:"debited"`  // 누적 차감
+	LastEntryAt time.Time `json:"last_entry_at,omitempty"`
+	LastVersion int       `json:"last_version"` // 마지막으로 적용한 지갑 이벤트 버전 (중복 적용 방지)
+}
+
+// NewBalanceView 새로운 BalanceView를 생성합니다
+func NewBalanceView(userID string) *BalanceView {
+	return &BalanceView{
+		BaseReadModel: cqrs.NewBaseReadModel(userID, BalanceViewType, map[string]interface{}{}),
+		UserID:        userID,
+	}
+}
+
+// GetData 읽기 모델 데이터
+func (v *BalanceView) GetData() interface{} {
+	return map[string]interface{}{
+		"user_id":  v.UserID,
+		"balance":  v.Balance,
+		"credited": v.Credited,
+		"debited":  v.Debited,
+	}
+}
+
+// AccountView 시스템 계정 하나의 누적 분개
+// 모든 시스템 계정의 Balance 합과 모든 지갑 잔액 합을 더하면 0이어야 합니다
+type AccountView struct {
+	*cqrs.BaseReadModel
+	Account string         `json:"account"`
+	Debits  int64          `json:"debits"`
+	Credits int64          `json:"credits"`
+	Applied map[string]int `json:"applied"` // 지갑(사용자 ID) -> 마지막으로 적용한 이벤트 버전
+}
+
+// NewAccountView 새로운 AccountView를 생성합니다
+func NewAccountView(account string) *AccountView {
+	return &AccountView{
+		BaseReadModel: cqrs.NewBaseReadModel(account, AccountViewType, map[string]interface{}{}),
+		Account:       account,
+		Applied:       make(map[string]int),
+	}
+}
+
+// Balance Credit - Debit (지갑 잔액과 같은 방향)
+func (v *AccountView) Balance() int64 {
+	return v.Credits - v.Debits
+}
+
+// GetData 읽기 모델 데이터
+func (v *AccountView) GetData() interface{} {
+	return map[string]interface{}{
+		"account": v.Account,
+		"debits":  v.Debits,
+		"credits": v.Credits,
+		"balance": v.Balance(),
+	}
+}
+
+// BalanceProjection 원장 이벤트로 지갑 잔액과 시스템 계정 합계를 갱신합니다
+// 지갑 이벤트 버전으로 적용 여부를 기록하므로 같은 이벤트를 다시 받아도 합계가 바뀌지 않습니다
+type BalanceProjection struct {
+	*cqrs.BaseEventHandler
+	store cqrs.ReadStore
+
+	mu sync.Mutex // 같은 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewBalanceProjection 새로운 BalanceProjection을 생성합니다
+func NewBalanceProjection(store cqrs.ReadStore) *BalanceProjection {
+	return &BalanceProjection{
+		BaseEventHandler: cqrs.NewBaseEventHandler("wallet-balances", cqrs.ProjectionHandler, []string{
+			WalletCreditedEventType,
+			WalletDebitedEventType,
+		}),
+		store: store,
+	}
+}
+
+// Subscribe 프로젝션이 처리하는 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (p *BalanceProjection) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(p.GetSupportedEventTypes()))
+	for _, eventType := range p.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, p)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// View 사용자의 잔액 (없으면 빈 읽기 모델)
+func (p *BalanceProjection) View(ctx context.Context, userID string) (*BalanceView, error) {
+	view, err := cqrs.LoadReadModel[*BalanceView](ctx, p.store, userID, BalanceViewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewBalanceView(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet balance of %s: %w", userID, err)
+	}
+	return view, nil
+}
+
+// Account 시스템 계정의 누적 분개 (없으면 빈 읽기 모델)
+func (p *BalanceProjection) Account(ctx context.Context, account string) (*AccountView, error) {
+	view, err := cqrs.LoadReadModel[*AccountView](ctx, p.store, account, AccountViewType)
+	if cqrs.IsNotFoundError(err) {
+		return NewAccountView(account), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ledger account %s: %w", account, err)
+	}
+	return view, nil
+}
+
+// Handle 원장 이벤트를 적용합니다
+func (p *BalanceProjection) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	if event.EventType() != WalletCreditedEventType && event.EventType() != WalletDebitedEventType {
+		return nil
+	}
+	decoded, err := eventstore.DecodeEventData[LedgerEntry](event.EventData())
+	if err != nil {
+		return err
+	}
+	entry := decoded.(LedgerEntry)
+	userID, version := event.AggregateID(), event.Version()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err = cqrs.UpsertReadModel(ctx, p.store, userID, BalanceViewType,
+		func() *BalanceView { return NewBalanceView(userID) },
+		func(view *BalanceView) error {
+			if version <= view.LastVersion {
+				return nil
+			}
+			if event.EventType() == WalletCreditedEventType {
+				view.Credited += entry.Amount
+			} else {
+				view.Debited += entry.Amount
+			}
+			view.Balance = entry.BalanceAfter
+			view.LastEntryAt = entry.RecordedAt
+			view.LastVersion = version
+			view.IncrementVersion()
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for _, posting := range entry.Postings {
+		if !systemAccounts[posting.Account] {
+			continue
+		}
+		posting := posting
+		err := cqrs.UpsertReadModel(ctx, p.store, posting.Account, AccountViewType,
+			func() *AccountView { return NewAccountView(posting.Account) },
+			func(view *AccountView) error {
+				if view.Applied == nil {
+					view.Applied = make(map[string]int)
+				}
+				if version <= view.Applied[userID] {
+					return nil
+				}
+				view.Debits += posting.Debit
+				view.Credits += posting.Credit
+				view.Applied[userID] = version
+				view.IncrementVersion()
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
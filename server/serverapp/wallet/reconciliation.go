@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/purchase"
+)
+
+// ReconciliationViewType 결제-지갑 대사 읽기 모델 타입 (읽기 모델 ID는 결제 ID)
+const ReconciliationViewType = "WalletPurchaseReconciliation"
+
+// 대사 불일치 종류
+const (
+	DiscrepancyMissingCredit  = "missing_credit"  // 결제는 있는데 지갑 적립이 없음
+	DiscrepancyAmountMismatch = "amount_mismatch" // 적립한 양이 상품 구성과 다름
+	DiscrepancyOrphanCredit   = "orphan_credit"   // 결제 기록 없이 결제 계정에서 적립됨
+)
+
+// ReconciliationView 결제 하나에 대해 기대한 재화와 실제 적립한 재화
+type ReconciliationView struct {
+	*cqrs.BaseReadModel
+	PurchaseID  string    `json:"purchase_id"`
+	UserID      string    `json:"user_id"`
+	ProductID   string    `json:"product_id,omitempty"`
+	Expected    int64     `json:"expected"`
+	Credited    int64     `json:"credited"`
+	Purchased   bool      `json:"purchased"` // PurchaseCompleted를 받았는지
+	PurchasedAt time.Time `json:"purchased_at,omitempty"`
+	CreditedAt  time.Time `json:"credited_at,omitempty"`
+}
+
+// NewReconciliationView 새로운 ReconciliationView를 생성합니다
+func NewReconciliationView(purchaseID string) *ReconciliationView {
+	return &ReconciliationView{
+		BaseReadModel: cqrs.NewBaseReadModel(purchaseID, ReconciliationViewType, map[string]interface{}{}),
+		PurchaseID:    purchaseID,
+	}
+}
+
+// GetData 읽기 모델 데이터
+func (v *ReconciliationView) GetData() interface{} {
+	return map[string]interface{}{
+		"purchase_id": v.PurchaseID,
+		"user_id":     v.UserID,
+		"expected":    v.Expected,
+		"credited":    v.Credited,
+	}
+}
+
+// Discrepancy 대사 불일치 하나
+type Discrepancy struct {
+	Kind       string `json:"kind"`
+	PurchaseID string `json:"purchase_id"`
+	UserID     string `json:"user_id"`
+	ProductID  string `json:"product_id,omitempty"`
+	Expected   int64  `json:"expected"`
+	Credited   int64  `json:"credited"`
+}
+
+// ReconciliationReport 결제 이벤트와 지갑 원장의 대사 결과
+type ReconciliationReport struct {
+	GeneratedAt   time.Time     `json:"generated_at"`
+	Purchases     int           `json:"purchases"` // 재화가 포함된 결제 수
+	Matched       int           `json:"matched"`
+	Pending       int           `json:"pending"` // 유예 시간 안이라 아직 판단하지 않은 결제
+	ExpectedTotal int64         `json:"expected_total"`
+	CreditedTotal int64         `json:"credited_total"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Balanced 불일치가 없는지 확인합니다
+func (r *ReconciliationReport) Balanced() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// ReconcilerConfig 대사 설정
+type ReconcilerConfig struct {
+	Store cqrs.ReadStore   // 대사 읽기 모델 저장소
+	Grace time.Duration    // 결제 후 적립을 기다리는 시간 (기본값: 5분)
+	Now   func() time.Time // 테스트용 시계 (기본값: time.Now)
+}
+
+// Reconciler 결제 이벤트(PurchaseCompleted)와 결제 계정 적립(WalletCredited)을 결제 ID로 맞춰 봅니다
+type Reconciler struct {
+	*cqrs.BaseEventHandler
+	config ReconcilerConfig
+
+	mu sync.Mutex // 같은 결제 읽기 모델의 읽고 쓰기 직렬화
+}
+
+// NewReconciler 새로운 Reconciler를 생성합니다
+func NewReconciler(config ReconcilerConfig) *Reconciler {
+	if config.Grace <= 0 {
+		config.Grace = 5 * time.Minute
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Reconciler{
+		BaseEventHandler: cqrs.NewBaseEventHandler("wallet-purchase-reconciliation", cqrs.ProjectionHandler, []string{
+			purchase.PurchaseCompletedEventType,
+			WalletCreditedEventType,
+		}),
+		config: config,
+	}
+}
+
+// Subscribe 대사에 필요한 이벤트를 이벤트 버스에서 구독하고 구독 ID를 반환합니다
+func (r *Reconciler) Subscribe(bus cqrs.EventBus) ([]cqrs.SubscriptionID, error) {
+	subscriptions := make([]cqrs.SubscriptionID, 0, len(r.GetSupportedEventTypes()))
+	for _, eventType := range r.GetSupportedEventTypes() {
+		subscription, err := bus.Subscribe(eventType, r)
+		if err != nil {
+			return subscriptions, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// Handle 결제 이벤트와 결제 계정 적립 이벤트를 기록합니다
+func (r *Reconciler) Handle(ctx context.Context, event cqrs.EventMessage) error {
+	switch event.EventType() {
+	case purchase.PurchaseCompletedEventType:
+		decoded, err := eventstore.DecodeEventData[purchase.PurchaseCompletedData](event.EventData())
+		if err != nil {
+			return err
+		}
+		completed := decoded.(purchase.PurchaseCompletedData)
+		if completed.Grant.Currency == 0 {
+			return nil
+		}
+		return r.update(ctx, event.AggregateID(), func(view *ReconciliationView) {
+			view.UserID = completed.UserID
+			view.ProductID = completed.Purchase.ProductID
+			view.Expected = completed.Grant.Currency
+			view.Purchased = true
+			view.PurchasedAt = completed.CompletedAt
+		})
+	case WalletCreditedEventType:
+		decoded, err := eventstore.DecodeEventData[LedgerEntry](event.EventData())
+		if err != nil {
+			return err
+		}
+		entry := decoded.(LedgerEntry)
+		if entry.Counterparty != AccountPurchases || entry.Reference == "" {
+			return nil
+		}
+		// 같은 결제는 항목 ID가 같아 지갑에서 한 번만 적립되므로 덮어써도 됩니다
+		return r.update(ctx, entry.Reference, func(view *ReconciliationView) {
+			if view.UserID == "" {
+				view.UserID = entry.UserID
+			}
+			view.Credited = entry.Amount
+			view.CreditedAt = entry.RecordedAt
+		})
+	}
+	return nil
+}
+
+// Report 지금까지 받은 결제와 적립을 대사합니다
+// 유예 시간 안의 결제는 적립이 오는 중일 수 있으므로 불일치 대신 Pending으로 셉니다
+func (r *Reconciler) Report(ctx context.Context) (*ReconciliationReport, error) {
+	models, err := r.config.Store.Query(ctx, cqrs.QueryCriteria{Filters: map[string]interface{}{"type": ReconciliationViewType}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reconciliation views: %w", err)
+	}
+	now := r.config.Now()
+	report := &ReconciliationReport{GeneratedAt: now, Discrepancies: []Discrepancy{}}
+	for _, model := range models {
+		view, ok := model.(*ReconciliationView)
+		if !ok {
+			continue
+		}
+		report.ExpectedTotal += view.Expected
+		report.CreditedTotal += view.Credited
+		if view.Purchased {
+			report.Purchases++
+		}
+
+		kind := ""
+		switch {
+		case !view.Purchased:
+			kind = DiscrepancyOrphanCredit
+		case view.Credited == view.Expected:
+			report.Matched++
+			continue
+		case view.Credited == 0 && now.Sub(view.PurchasedAt) < r.config.Grace:
+			report.Pending++
+			continue
+		case view.Credited == 0:
+			kind = DiscrepancyMissingCredit
+		default:
+			kind = DiscrepancyAmountMismatch
+		}
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:       kind,
+			PurchaseID: view.PurchaseID,
+			UserID:     view.UserID,
+			ProductID:  view.ProductID,
+			Expected:   view.Expected,
+			Credited:   view.Credited,
+		})
+	}
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		return report.Discrepancies[i].PurchaseID < report.Discrepancies[j].PurchaseID
+	})
+	return report, nil
+}
+
+func (r *Reconciler) update(ctx context.Context, purchaseID string, fn func(*ReconciliationView)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return cqrs.UpsertReadModel(ctx, r.config.Store, purchaseID, ReconciliationViewType,
+		func() *ReconciliationView { return NewReconciliationView(purchaseID) },
+		func(view *ReconciliationView) error {
+			fn(view)
+			view.IncrementVersion()
+			return nil
+		})
+}
@@ -0,0 +1,188 @@
+package wallet
+
+import (
+	"context"
+	"cqrs"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+)
+
+// 지갑 명령 타입 (명령의 ID는 지갑 주인 사용자 ID, 데이터는 LedgerRequest)
+const (
+	CreditWalletCommandType = "CreditWallet"
+	DebitWalletCommandType  = "DebitWallet"
+)
+
+// NewCreditWalletCommand 지갑 적립 명령
+func NewCreditWalletCommand(userID string, request LedgerRequest) cqrs.Command {
+	return newWalletCommand(CreditWalletCommandType, userID, request)
+}
+
+// NewDebitWalletCommand 지갑 차감 명령
+func NewDebitWalletCommand(userID string, request LedgerRequest) cqrs.Command {
+	return newWalletCommand(DebitWalletCommandType, userID, request)
+}
+
+func newWalletCommand(commandType, userID string, request LedgerRequest) cqrs.Command {
+	command := cqrs.NewBaseCommand(commandType, userID, WalletAggregateType, request)
+	command.SetUserID(userID)
+	return command
+}
+
+// LedgerResult 적립, 차감 결과 (CommandResult.Data)
+type LedgerResult struct {
+	UserID    string `json:"user_id"`
+	EntryID   string `json:"entry_id"`
+	Balance   int64  `json:"balance"`
+	Duplicate bool   `json:"duplicate,omitempty"` // 이미 기록한 항목 (잔액은 한 번만 바뀜)
+}
+
+// ServiceConfig 지갑 서비스 설정
+type ServiceConfig struct {
+	Store    eventstore.EventStore // 필수: 지갑 이벤트 저장소 (cqrsx.RedisEventStore, cqrsx.MongoEventStore)
+	EventBus cqrs.EventBus         // 선택: 원장 이벤트 발행 (잔액 프로젝션, 정산 대사가 구독)
+	Now      func() time.Time      // 테스트용 시계 (기본값: time.Now)
+}
+
+// Service 지갑 명령을 처리하는 명령 핸들러
+type Service struct {
+	*cqrs.BaseCommandHandler
+	config ServiceConfig
+
+	mu sync.Mutex // 지갑 변경 직렬화
+}
+
+// NewService 새로운 Service를 생성합니다
+// 잔액이 재시작 후에도 남아야 하므로 저장소가 없으면 ErrStoreRequired를 반환합니다
+func NewService(config ServiceConfig) (*Service, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("wallet: %w", ErrStoreRequired)
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Service{
+		BaseCommandHandler: cqrs.NewBaseCommandHandler("wallet", []string{CreditWalletCommandType, DebitWalletCommandType}),
+		config:             config,
+	}, nil
+}
+
+// RegisterWith 지갑 명령 핸들러를 디스패처에 등록합니다
+func (s *Service) RegisterWith(dispatcher cqrs.CommandDispatcher) error {
+	for _, commandType := range s.GetSupportedCommandTypes() {
+		if err := dispatcher.RegisterHandler(commandType, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load 지갑 상태를 불러옵니다
+func (s *Service) Load(ctx context.Context, userID string) (*WalletAggregate, error) {
+	return s.load(ctx, userID)
+}
+
+// Grant 결제한 프리미엄 재화를 적립합니다 (purchase.Granter 구현)
+// amounts의 합을 grantID(결제 ID)를 항목 ID와 원인으로 AccountPurchases에서 적립합니다
+func (s *Service) Grant(ctx context.Context, userID, grantID string, amounts map[string]int64) error {
+	var amount int64
+	for _, value := range amounts {
+		amount += value
+	}
+	result, err := s.Handle(ctx, NewCreditWalletCommand(userID, LedgerRequest{
+		EntryID:   grantID,
+		Amount:    amount,
+		Account:   AccountPurchases,
+		Reason:    "purchase",
+		Reference: grantID,
+	}))
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return result.Error
+	}
+	return nil
+}
+
+// Handle 지갑 명령을 처리합니다 (실패는 CommandResult.Error로 반환)
+func (s *Service) Handle(ctx context.Context, command cqrs.Command) (*cqrs.CommandResult, error) {
+	userID := command.ID()
+	if userID == "" {
+		return cqrs.NewFailedCommandResult(fmt.Errorf("%w: user ID is required", ErrInvalidEntry)), nil
+	}
+	request, err := eventstore.DecodeCommandData[LedgerRequest](command.GetData(), ErrInvalidEntry)
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggregate, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.config.Now()
+	var duplicate bool
+	switch command.CommandType() {
+	case CreditWalletCommandType:
+		duplicate, err = aggregate.Credit(request, now)
+	case DebitWalletCommandType:
+		duplicate, err = aggregate.Debit(request, now)
+	default:
+		err = fmt.Errorf("%w: unsupported command type %q", ErrInvalidEntry, command.CommandType())
+	}
+	if err != nil {
+		return cqrs.NewFailedCommandResult(err), nil
+	}
+
+	events := aggregate.Changes()
+	if err := s.save(ctx, aggregate); err != nil {
+		return nil, err
+	}
+	return cqrs.NewCommandResult(userID, aggregate.Version(), events...).WithData(LedgerResult{
+		UserID:    userID,
+		EntryID:   request.EntryID,
+		Balance:   aggregate.Balance(),
+		Duplicate: duplicate,
+	}), nil
+}
+
+func (s *Service) load(ctx context.Context, userID string) (*WalletAggregate, error) {
+	events, err := s.config.Store.GetEventHistory(ctx, userID, WalletAggregateType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet of %s: %w", userID, err)
+	}
+	aggregate := NewWalletAggregate(userID)
+	if err := aggregate.LoadFromHistory(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// save 새 이벤트를 저장하고 이벤트 버스로 발행합니다
+func (s *Service) save(ctx context.Context, aggregate *WalletAggregate) error {
+	events := aggregate.Changes()
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.config.Store.SaveEvents(ctx, aggregate.ID(), events, aggregate.OriginalVersion()); err != nil {
+		return fmt.Errorf("failed to save wallet of %s: %w", aggregate.ID(), err)
+	}
+	aggregate.ClearChanges()
+	aggregate.SetOriginalVersion(aggregate.Version())
+
+	if s.config.EventBus != nil {
+		for _, event := range events {
+			if err := s.config.EventBus.Publish(ctx, event); err != nil {
+				log.Printf("[Wallet] Failed to publish %s for %s: %v", event.EventType(), aggregate.ID(), err)
+			}
+		}
+	}
+	return nil
+}
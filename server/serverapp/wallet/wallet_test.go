@@ -0,0 +1,218 @@
+package wallet
+
+import (
+	"context"
+	"cqrs"
+	"testing"
+	"time"
+
+	"defense-allies-server/serverapp/internal/eventstore"
+	"defense-allies-server/serverapp/internal/testkit"
+	"defense-allies-server/serverapp/purchase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeValidator 영수증 내용을 거래 ID로 그대로 돌려주는 검증기
+type fakeValidator struct{}
+
+func (fakeValidator) Validate(ctx context.Context, receipt purchase.Receipt) (purchase.VerifiedPurchase, error) {
+	return purchase.VerifiedPurchase{
+		Store:         receipt.Store,
+		ProductID:     receipt.ProductID,
+		TransactionID: receipt.Payload,
+		Environment:   purchase.EnvironmentProduction,
+	}, nil
+}
+
+// walletFixture 지갑 서비스와 잔액 프로젝션, 대사를 같은 이벤트 버스에 연결합니다
+type walletFixture struct {
+	clock      *testkit.Clock
+	bus        cqrs.EventBus
+	service    *Service
+	balances   *BalanceProjection
+	reconciler *Reconciler
+	dispatcher *cqrs.InMemoryCommandDispatcher
+}
+
+func newWalletFixture(t *testing.T) *walletFixture {
+	t.Helper()
+	clock := testkit.NewClock(testkit.DefaultStart.Add(12 * time.Hour))
+	readStore := cqrs.NewInMemoryReadStore()
+	bus := testkit.StartedEventBus(t)
+	balances := NewBalanceProjection(readStore)
+	_, err := balances.Subscribe(bus)
+	require.NoError(t, err)
+	reconciler := NewReconciler(ReconcilerConfig{Store: readStore, Grace: 10 * time.Minute, Now: clock.Now})
+	_, err = reconciler.Subscribe(bus)
+	require.NoError(t, err)
+
+	service, err := NewService(ServiceConfig{Store: eventstore.NewInMemoryEventStore(), EventBus: bus, Now: clock.Now})
+	require.NoError(t, err)
+	return &walletFixture{
+		clock:      clock,
+		bus:        bus,
+		service:    service,
+		balances:   balances,
+		reconciler: reconciler,
+		dispatcher: testkit.NewDispatcher(t, service),
+	}
+}
+
+func (f *walletFixture) dispatch(t *testing.T, command cqrs.Command) *cqrs.CommandResult {
+	t.Helper()
+	return testkit.Dispatch(t, f.dispatcher, command)
+}
+
+func TestWalletAggregate_Rules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	wallet := NewWalletAggregate("alice")
+
+	_, err := wallet.Credit(LedgerRequest{EntryID: "e1", Amount: 0, Account: AccountRewards}, now)
+	assert.ErrorIs(t, err, ErrInvalidEntry)
+	_, err = wallet.Credit(LedgerRequest{EntryID: "e1", Amount: 100, Account: "wallet:bob"}, now)
+	assert.ErrorIs(t, err, ErrUnknownAccount)
+
+	duplicate, err := wallet.Credit(LedgerRequest{EntryID: "e1", Amount: 100, Account: AccountRewards}, now)
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+	duplicate, err = wallet.Credit(LedgerRequest{EntryID: "e1", Amount: 100, Account: AccountRewards}, now)
+	require.NoError(t, err)
+	assert.True(t, duplicate) // 같은 항목 재요청
+	_, err = wallet.Debit(LedgerRequest{EntryID: "e1", Amount: 100, Account: AccountSpending}, now)
+	assert.ErrorIs(t, err, ErrEntryConflict)
+	_, err = wallet.Debit(LedgerRequest{EntryID: "e2", Amount: 101, Account: AccountSpending}, now)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	_, err = wallet.Debit(LedgerRequest{EntryID: "e2", Amount: 40, Account: AccountSpending}, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), wallet.Balance())
+
+	// 모든 항목은 차변과 대변이 같음
+	require.Len(t, wallet.Changes(), 2)
+	for _, event := range wallet.Changes() {
+		entry := event.EventData().(LedgerEntry)
+		assert.True(t, entry.Balanced(), entry.EntryID)
+	}
+	debited := wallet.Changes()[1].EventData().(LedgerEntry)
+	assert.Equal(t, []Posting{{Account: "wallet:alice", Debit: 40}, {Account: AccountSpending, Credit: 40}}, debited.Postings)
+
+	// 저장된 이벤트로 같은 상태 복원
+	restored := NewWalletAggregate("alice")
+	require.NoError(t, restored.LoadFromHistory(wallet.Changes()))
+	assert.Equal(t, int64(60), restored.Balance())
+	duplicate, err = restored.Debit(LedgerRequest{EntryID: "e2", Amount: 40, Account: AccountSpending}, now)
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+}
+
+func TestNewService_RequiresStore(t *testing.T) {
+	// Act
+	service, err := NewService(ServiceConfig{EventBus: cqrs.NewInMemoryEventBus()})
+
+	// Assert - 메모리 저장소로 대체하면 재시작할 때 잔액이 사라짐
+	assert.ErrorIs(t, err, ErrStoreRequired)
+	assert.Nil(t, service)
+}
+
+func TestService_LedgerBalancesAcrossAccounts(t *testing.T) {
+	// Arrange
+	fixture := newWalletFixture(t)
+	ctx := context.Background()
+
+	// Act
+	fixture.dispatch(t, NewCreditWalletCommand("alice", LedgerRequest{EntryID: "reward-1", Amount: 300, Account: AccountRewards}))
+	fixture.dispatch(t, NewCreditWalletCommand("bob", LedgerRequest{EntryID: "reward-1", Amount: 50, Account: AccountRewards}))
+	spent := fixture.dispatch(t, NewDebitWalletCommand("alice", LedgerRequest{EntryID: "shop-1", Amount: 120, Account: AccountSpending, Reference: "skin_blue"}))
+	again := fixture.dispatch(t, NewDebitWalletCommand("alice", LedgerRequest{EntryID: "shop-1", Amount: 120, Account: AccountSpending, Reference: "skin_blue"}))
+	overdraft := fixture.dispatch(t, NewDebitWalletCommand("bob", LedgerRequest{EntryID: "shop-2", Amount: 51, Account: AccountSpending}))
+	// 같은 이벤트를 다시 받아도 잔액이 바뀌지 않음
+	for _, event := range spent.Events {
+		require.NoError(t, fixture.balances.Handle(ctx, event))
+	}
+
+	// Assert
+	require.True(t, spent.Success, spent.Error)
+	assert.Equal(t, int64(180), spent.Data.(LedgerResult).Balance)
+	assert.True(t, again.Data.(LedgerResult).Duplicate)
+	assert.ErrorIs(t, overdraft.Error, ErrInsufficientFunds)
+
+	alice, err := fixture.balances.View(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(180), alice.Balance)
+	assert.Equal(t, int64(300), alice.Credited)
+	assert.Equal(t, int64(120), alice.Debited)
+	bob, err := fixture.balances.View(ctx, "bob")
+	require.NoError(t, err)
+
+	// 시스템 계정 잔액과 지갑 잔액을 모두 더하면 0
+	total := alice.Balance + bob.Balance
+	for _, account := range []string{AccountRewards, AccountSpending} {
+		view, err := fixture.balances.Account(ctx, account)
+		require.NoError(t, err)
+		total += view.Balance()
+	}
+	assert.Equal(t, int64(0), total)
+	rewards, err := fixture.balances.Account(ctx, AccountRewards)
+	require.NoError(t, err)
+	assert.Equal(t, int64(350), rewards.Debits)
+}
+
+func TestReconciler_ReportsPurchaseDiscrepancies(t *testing.T) {
+	// Arrange
+	fixture := newWalletFixture(t)
+	ctx := context.Background()
+	purchases, err := purchase.NewService(purchase.ServiceConfig{
+		Store:      eventstore.NewInMemoryEventStore(),
+		EventBus:   fixture.bus,
+		Validators: map[string]purchase.ReceiptValidator{purchase.StoreGoogle: fakeValidator{}},
+		Catalog:    map[string]purchase.Grant{"gems_500": {Currency: 500}},
+		Wallet:     fixture.service,
+		Now:        fixture.clock.Now,
+	})
+	require.NoError(t, err)
+	// 지갑 적립 없이 결제 이벤트만 도착한 결제
+	completeOnly := func(transactionID string) {
+		aggregate := purchase.NewPurchaseAggregate(purchase.PurchaseID(purchase.StoreGoogle, transactionID))
+		_, err := aggregate.Complete("carol", purchase.VerifiedPurchase{Store: purchase.StoreGoogle, ProductID: "gems_500", TransactionID: transactionID}, purchase.Grant{Currency: 500}, fixture.clock.Now())
+		require.NoError(t, err)
+		for _, event := range aggregate.Changes() {
+			require.NoError(t, fixture.reconciler.Handle(ctx, event))
+		}
+	}
+
+	// Act
+	redeemed, err := purchases.Redeem(ctx, "alice", purchase.Receipt{Store: purchase.StoreGoogle, ProductID: "gems_500", Payload: "GPA.1"})
+	require.NoError(t, err)
+	completeOnly("GPA.2")
+	completeOnly("GPA.3")
+	fixture.dispatch(t, NewCreditWalletCommand("carol", LedgerRequest{EntryID: "google:GPA.3", Amount: 50, Account: AccountPurchases, Reference: "google:GPA.3"}))
+	fixture.dispatch(t, NewCreditWalletCommand("mallory", LedgerRequest{EntryID: "google:GPA.9", Amount: 9999, Account: AccountPurchases, Reference: "google:GPA.9"}))
+	fixture.dispatch(t, NewCreditWalletCommand("alice", LedgerRequest{EntryID: "reward-1", Amount: 10, Account: AccountRewards, Reference: "season-1"}))
+	early, err := fixture.reconciler.Report(ctx)
+	require.NoError(t, err)
+	fixture.clock.Advance(time.Hour)
+	late, err := fixture.reconciler.Report(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.True(t, redeemed.Success, redeemed.Error)
+	balance, err := fixture.balances.View(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(510), balance.Balance)
+
+	assert.Equal(t, 1, early.Pending) // 유예 시간 안이라 아직 불일치가 아님
+	assert.Len(t, early.Discrepancies, 2)
+
+	assert.False(t, late.Balanced())
+	assert.Equal(t, 3, late.Purchases)
+	assert.Equal(t, 1, late.Matched)
+	assert.Equal(t, 0, late.Pending)
+	assert.Equal(t, int64(1500), late.ExpectedTotal)
+	assert.Equal(t, int64(500+50+9999), late.CreditedTotal)
+	require.Len(t, late.Discrepancies, 3)
+	assert.Equal(t, Discrepancy{Kind: DiscrepancyMissingCredit, PurchaseID: "google:GPA.2", UserID: "carol", ProductID: "gems_500", Expected: 500}, late.Discrepancies[0])
+	assert.Equal(t, DiscrepancyAmountMismatch, late.Discrepancies[1].Kind)
+	assert.Equal(t, DiscrepancyOrphanCredit, late.Discrepancies[2].Kind)
+	assert.Equal(t, "mallory", late.Discrepancies[2].UserID)
+}